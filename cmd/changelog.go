@@ -2,19 +2,40 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/toba/jig/internal/changelog"
+	"github.com/toba/jig/internal/todo/integration"
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
 )
 
 var changelogCmd = &cobra.Command{
 	Use:   "changelog",
 	Short: "Gather recent issues and commits for changelog generation",
-	Long:  `Collects issues created, updated, or completed within a time range, optionally with git commits. By default, uses the last commit that touched CHANGELOG.md as the start date, falling back to 7 days.`,
+	Long: `Collects issues created, updated, or completed within a time range, optionally with git commits. By default, uses the last commit that touched CHANGELOG.md as the start date, falling back to 7 days.
+
+--auto covers the latest semver tag to now, and --from-tag/--to-tag cover the
+commit dates of two tags (--to-tag defaults to now). With a tag range, an
+issue counts as completed when the git history of its file shows it moving
+to completed or review inside the window.
+
+Internal issues are left out unless --include-internal is given.
+
+Issues read by their release_title and release_note when they have them,
+falling back to title and body; --internal uses title and body throughout.
+--require-release-notes fails, listing the offenders, when any issue in the
+range lacks a release title, which suits a CI check before tagging.`,
+	Example: `  jig changelog --auto --json
+  jig changelog --from-tag v1.2.0 --to-tag v1.3.0
+  jig changelog --auto --require-release-notes`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		return initTodoCore(cmd)
 	},
@@ -26,6 +47,15 @@ func init() {
 	changelogCmd.Flags().Int("commits", 0, "include issues within the last N git commits' time range")
 	changelogCmd.Flags().String("since", "", "explicit start date (YYYY-MM-DD, overrides --days/--commits)")
 	changelogCmd.Flags().Bool("git", false, "include git commits in output")
+	changelogCmd.Flags().Bool("auto", false, "use the latest semver tag to now as the range")
+	changelogCmd.Flags().String("from-tag", "", "start the range at this tag's commit date")
+	changelogCmd.Flags().String("to-tag", "", "end the range at this tag's commit date (with --from-tag)")
+	changelogCmd.Flags().Bool("include-internal", false, "include internal issues, which are left out by default")
+	changelogCmd.Flags().Bool("internal", false, "use issue titles and bodies instead of release titles and notes")
+	changelogCmd.Flags().Bool("require-release-notes", false, "fail when an issue in the range has no release title")
+	changelogCmd.MarkFlagsMutuallyExclusive("auto", "from-tag")
+	changelogCmd.MarkFlagsMutuallyExclusive("auto", "since")
+	changelogCmd.MarkFlagsMutuallyExclusive("from-tag", "since")
 	rootCmd.AddCommand(changelogCmd)
 }
 
@@ -34,12 +64,26 @@ func runChangelog(cmd *cobra.Command, _ []string) error {
 	commits, _ := cmd.Flags().GetInt("commits")
 	sinceStr, _ := cmd.Flags().GetString("since")
 	includeGit, _ := cmd.Flags().GetBool("git")
+	auto, _ := cmd.Flags().GetBool("auto")
+	fromTag, _ := cmd.Flags().GetString("from-tag")
+	toTag, _ := cmd.Flags().GetString("to-tag")
+	if toTag != "" && fromTag == "" {
+		return errors.New("--to-tag requires --from-tag")
+	}
 
-	now := time.Now()
+	now := todoStore.Now()
 	var since, until time.Time
 	until = now
+	var tagRange *changelog.TimeRange
 
 	switch {
+	case auto || fromTag != "":
+		r, err := tagTimeRange(fromTag, toTag, now)
+		if err != nil {
+			return err
+		}
+		tagRange = r
+		since, until = r.Since, r.Until
 	case sinceStr != "":
 		t, err := time.Parse("2006-01-02", sinceStr)
 		if err != nil {
@@ -67,13 +111,35 @@ func runChangelog(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	all := todoStore.All()
+	includeInternal, _ := cmd.Flags().GetBool("include-internal")
+	internal, _ := cmd.Flags().GetBool("internal")
+	requireNotes, _ := cmd.Flags().GetBool("require-release-notes")
+	all := publicIssues(todoStore.All(), includeInternal)
 	opts := changelog.Options{
 		Since:      since,
 		Until:      until,
 		IncludeGit: includeGit,
+		Internal:   internal,
+	}
+	if tagRange != nil {
+		opts.ResolvedAt = func(b *issue.Issue) (time.Time, bool) {
+			return changelog.ResolvedAt(todoStore.Root(), b)
+		}
 	}
 	result := changelog.Gather(all, opts)
+	if tagRange != nil {
+		result.Range = *tagRange
+	}
+	if requireNotes {
+		if missing := result.MissingReleaseTitles(); len(missing) > 0 {
+			lines := make([]string, len(missing))
+			for i, b := range missing {
+				lines[i] = fmt.Sprintf("  %s %s", b.ID, b.Title)
+			}
+			return cmdError(jsonOut, output.ErrValidation, "%d issue(s) in the changelog have no release title (set one with 'jig todo update <id> --release-title'):\n%s",
+				len(missing), strings.Join(lines, "\n"))
+		}
+	}
 
 	// Add GitHub repo URL from sync config if available.
 	if ghCfg := todoCfg.SyncConfig("github"); ghCfg != nil {
@@ -82,6 +148,8 @@ func runChangelog(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	result.Links = changelogLinks(result.Issues)
+
 	if includeGit || commits > 0 {
 		gitCommits, err := changelog.GitCommits(since, until)
 		if err != nil {
@@ -99,14 +167,43 @@ func runChangelog(cmd *cobra.Command, _ []string) error {
 	return printChangelogText(result)
 }
 
+// tagTimeRange resolves the window between two tags. An empty from means
+// the latest semver tag; an empty to means now.
+func tagTimeRange(from, to string, now time.Time) (*changelog.TimeRange, error) {
+	var start changelog.Tag
+	var err error
+	if from == "" {
+		start, err = changelog.LatestTag()
+	} else {
+		start, err = changelog.ResolveTag(from)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The tagged commits belong to the release they are tagged as, so the
+	// window starts just after from and ends just after to.
+	r := &changelog.TimeRange{Since: start.Date.Add(time.Second), Until: now, FromTag: start.Name}
+	if to != "" {
+		end, err := changelog.ResolveTag(to)
+		if err != nil {
+			return nil, err
+		}
+		if !end.Date.After(start.Date) {
+			return nil, fmt.Errorf("tag %s is not newer than %s", end.Name, start.Name)
+		}
+		r.Until, r.ToTag = end.Date.Add(time.Second), end.Name
+	}
+	return r, nil
+}
+
 func printChangelogText(r *changelog.Result) error {
 	fmt.Printf("Changelog: %s to %s\n\n",
-		r.Range.Since.Format("2006-01-02"),
-		r.Range.Until.Format("2006-01-02"))
+		rangeEnd(r.Range.FromTag, r.Range.Since),
+		rangeEnd(r.Range.ToTag, r.Range.Until))
 
-	printIssueSection("Completed", r.Issues.Completed)
-	printIssueSection("Created", r.Issues.Created)
-	printIssueSection("Updated", r.Issues.Updated)
+	printIssueSection("Completed", r.Issues.Completed, r)
+	printIssueSection("Created", r.Issues.Created, r)
+	printIssueSection("Updated", r.Issues.Updated, r)
 
 	if len(r.Commits) > 0 {
 		fmt.Println("## Commits")
@@ -119,7 +216,37 @@ func printChangelogText(r *changelog.Result) error {
 	return nil
 }
 
-func printIssueSection(heading string, issues []*issue.Issue) {
+// rangeEnd formats one end of the range, with its tag if it has one.
+func rangeEnd(tag string, t time.Time) string {
+	if tag == "" {
+		return t.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%s (%s)", tag, t.Format("2006-01-02"))
+}
+
+// changelogLinks collects the sync entry URLs of every issue in the
+// changelog; see integration.SyncLinks.
+func changelogLinks(groups changelog.Issues) map[string]map[string]string {
+	var links map[string]map[string]string
+	for _, group := range [][]*issue.Issue{groups.Completed, groups.Created, groups.Updated} {
+		for _, b := range group {
+			for _, l := range integration.SyncLinks(b, todoCfg) {
+				if links == nil {
+					links = map[string]map[string]string{}
+				}
+				if links[b.ID] == nil {
+					links[b.ID] = map[string]string{}
+				}
+				links[b.ID][l.Name] = l.URL
+			}
+		}
+	}
+	return links
+}
+
+// printIssueSection lists issues under heading by their release titles,
+// with any release notes, or by their titles when r has no release wording.
+func printIssueSection(heading string, issues []*issue.Issue, r *changelog.Result) {
 	if len(issues) == 0 {
 		return
 	}
@@ -129,7 +256,20 @@ func printIssueSection(heading string, issues []*issue.Issue) {
 		if iss.Type != "" {
 			prefix = fmt.Sprintf("[%s] ", iss.Type)
 		}
-		fmt.Printf("  %s%s (%s)\n", prefix, iss.Title, iss.ID)
+		title := iss.Title
+		if rel, ok := r.Release[iss.ID]; ok {
+			title = rel.Title
+		}
+		fmt.Printf("  %s%s (%s)\n", prefix, title, iss.ID)
+		if _, ok := r.Release[iss.ID]; ok && iss.ReleaseNote != "" {
+			for line := range strings.SplitSeq(iss.ReleaseNote, "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+		names := slices.Sorted(maps.Keys(r.Links[iss.ID]))
+		for _, name := range names {
+			fmt.Printf("    %s: %s\n", name, r.Links[iss.ID][name])
+		}
 	}
 	fmt.Println()
 }
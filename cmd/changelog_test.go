@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestChangelogReleaseWording(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg, oldJSON := todoCfg, jsonOut
+	todoCfg, jsonOut = todoconfig.Default(), true
+	t.Cleanup(func() { todoCfg, jsonOut = oldCfg, oldJSON })
+
+	for _, b := range []*issue.Issue{
+		{ID: "rel-worded", Slug: "worded", Title: "Fix the horrible auth hack", Status: "completed", Type: "bug",
+			ReleaseTitle: "Sign-in is more reliable", ReleaseNote: "Sessions no longer expire early."},
+		{ID: "rel-plain", Slug: "plain", Title: "Add dark mode", Status: "completed", Type: "feature"},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runJSONCommand(t, changelogCmd, map[string]string{"days": "7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Issues struct {
+			Completed []map[string]any `json:"completed"`
+		} `json:"issues"`
+		Release map[string]struct {
+			Title string `json:"title"`
+			Note  string `json:"note"`
+		} `json:"release"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got := result.Release["rel-worded"]; got.Title != "Sign-in is more reliable" || got.Note != "Sessions no longer expire early." {
+		t.Errorf("release[rel-worded] = %+v, want the release title and note", got)
+	}
+	if got := result.Release["rel-plain"].Title; got != "Add dark mode" {
+		t.Errorf("release[rel-plain].title = %q, want the issue title", got)
+	}
+	for _, b := range result.Issues.Completed {
+		if b["id"] == "rel-worded" && (b["title"] != "Fix the horrible auth hack" || b["release_title"] != "Sign-in is more reliable") {
+			t.Errorf("issue JSON = %v, want both the internal and release titles", b)
+		}
+	}
+
+	out, err = runJSONCommand(t, changelogCmd, map[string]string{"days": "7", "internal": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, `"release":`) {
+		t.Errorf("--internal still emitted release wording:\n%s", out)
+	}
+
+	out, err = runJSONCommand(t, changelogCmd, map[string]string{"days": "7", "require-release-notes": "true"})
+	if got := errorCode(err, ""); got != output.ErrValidation {
+		t.Fatalf("--require-release-notes: code %q, want %s (error: %v)", got, output.ErrValidation, err)
+	}
+	if !strings.Contains(out, "rel-plain") || strings.Contains(out, "rel-worded") {
+		t.Errorf("--require-release-notes should list only rel-plain:\n%s", out)
+	}
+
+	plain, err := testCore.Get("rel-plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain.ReleaseTitle = "Dark mode"
+	if err := testCore.Update(plain, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runJSONCommand(t, changelogCmd, map[string]string{"days": "7", "require-release-notes": "true"}); err != nil {
+		t.Errorf("--require-release-notes with every release title set: %v", err)
+	}
+}
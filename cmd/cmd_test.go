@@ -612,7 +612,7 @@ func TestTodoSubcommands(t *testing.T) {
 		cmdNames[c.Name()] = true
 	}
 
-	for _, name := range []string{"init", "create", "list", "show", "delete", "archive", "roadmap"} {
+	for _, name := range []string{"init", "create", "list", "show", "delete", "archive", "roadmap", "fmt", "graph"} {
 		if !cmdNames[name] {
 			t.Errorf("todoCmd missing %q subcommand", name)
 		}
@@ -656,7 +656,7 @@ func TestZedInitFlags(t *testing.T) {
 }
 
 func TestCreateCmdFlags(t *testing.T) {
-	flags := []string{"status", "type", "priority", "body", "body-file", "tag", "due", "parent", "blocking", "blocked-by", "json"}
+	flags := []string{"status", "type", "priority", "estimate", "body", "body-file", "tag", "due", "parent", "blocking", "blocked-by", "json"}
 	for _, name := range flags {
 		f := createCmd.Flags().Lookup(name)
 		if f == nil {
@@ -1072,6 +1072,16 @@ func TestMutationError(t *testing.T) {
 			t.Errorf("mutationError() = %q, expected 'generic'", err.Error())
 		}
 	})
+
+	t.Run("required etag names the field class", func(t *testing.T) {
+		err := mutationError(false, &core.ETagRequiredError{Field: "status"})
+		if !strings.Contains(err.Error(), "to change status") || !strings.Contains(err.Error(), "--if-match") {
+			t.Errorf("mutationError() = %q, want the field class and an --if-match hint", err.Error())
+		}
+		if code := errorCode(err, ""); code != output.ErrConflict {
+			t.Errorf("errorCode() = %q, want %q", code, output.ErrConflict)
+		}
+	})
 }
 
 // --- typeBadge tests ---
@@ -1190,7 +1200,7 @@ func TestContainsStatus(t *testing.T) {
 
 func TestUpdateCmdFlags(t *testing.T) {
 	flags := []string{
-		"status", "type", "priority", "title", "due",
+		"status", "type", "priority", "title", "due", "estimate",
 		"replace-body", "replace-body-file", "append-body",
 		"body", "body-file", "body-replace-old", "body-replace-new", "body-append",
 		"body-check", "body-uncheck",
@@ -1198,7 +1208,7 @@ func TestUpdateCmdFlags(t *testing.T) {
 		"blocking", "remove-blocking",
 		"blocked-by", "remove-blocked-by",
 		"tag", "remove-tag",
-		"if-match", "json",
+		"if-match", "unarchive", "force", "json",
 	}
 	for _, name := range flags {
 		f := todoUpdateCmd.Flags().Lookup(name)
@@ -1270,6 +1280,63 @@ func TestBuildUpdateInputBody(t *testing.T) {
 		})
 	}
 
+	t.Run("section sets BodyMod setSection", func(t *testing.T) {
+		c := newCmd()
+		path := filepath.Join(t.TempDir(), "plan.md")
+		if err := os.WriteFile(path, []byte("New plan\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_ = c.Flags().Set("section", "Plan")
+		_ = c.Flags().Set("section-content-file", path)
+		_ = c.Flags().Set("section-create", "true")
+		input, _, err := buildUpdateInput(c, nil, "old body")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		edit := input.BodyMod.SetSection
+		if edit == nil || edit.Title != "Plan" || edit.Content != "New plan" || !*edit.CreateIfMissing {
+			t.Errorf("BodyMod.SetSection = %+v", edit)
+		}
+	})
+
+	t.Run("section requires content", func(t *testing.T) {
+		c := newCmd()
+		_ = c.Flags().Set("section", "Plan")
+		if _, _, err := buildUpdateInput(c, nil, "old body"); err == nil {
+			t.Error("expected error for --section without content")
+		}
+	})
+
+	t.Run("summary is trimmed and validated", func(t *testing.T) {
+		c := newCmd()
+		_ = c.Flags().Set("summary", "  Short description  ")
+		input, changes, err := buildUpdateInput(c, nil, "old body")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if input.Summary == nil || *input.Summary != "Short description" {
+			t.Errorf("Summary = %v, want %q", input.Summary, "Short description")
+		}
+		if len(changes) != 1 || changes[0] != "summary" {
+			t.Errorf("changes = %v, want [summary]", changes)
+		}
+
+		c = newCmd()
+		_ = c.Flags().Set("summary", "line one\nline two")
+		if _, _, err := buildUpdateInput(c, nil, "old body"); err == nil {
+			t.Error("expected error for a multi-line summary")
+		}
+	})
+
+	t.Run("priority typo suggests the nearest value", func(t *testing.T) {
+		c := newCmd()
+		_ = c.Flags().Set("priority", "hgih")
+		_, _, err := buildUpdateInput(c, nil, "old body")
+		if err == nil || !strings.Contains(err.Error(), `did you mean "high"?`) {
+			t.Errorf("error = %v, want a suggestion of high", err)
+		}
+	})
+
 	t.Run("body-append still works as hidden alias", func(t *testing.T) {
 		c := newCmd()
 		if err := c.Flags().Set("body-append", "legacy add"); err != nil {
@@ -1553,7 +1620,7 @@ func TestRenderRoadmapMarkdown(t *testing.T) {
 		},
 	}
 
-	result := renderRoadmapMarkdown(data, false, "")
+	result := renderRoadmapMarkdown(data, false, "", nil)
 	if !strings.Contains(result, "v1.0") {
 		t.Error("renderRoadmapMarkdown() missing milestone title")
 	}
@@ -1579,7 +1646,7 @@ func TestRenderRoadmapMarkdownWithLinks(t *testing.T) {
 		},
 	}
 
-	result := renderRoadmapMarkdown(data, true, ".issues")
+	result := renderRoadmapMarkdown(data, true, ".issues", nil)
 	if !strings.Contains(result, ".issues/") {
 		t.Error("renderRoadmapMarkdown() with links missing link prefix")
 	}
@@ -1587,7 +1654,7 @@ func TestRenderRoadmapMarkdownWithLinks(t *testing.T) {
 
 func TestRenderRoadmapMarkdownEmpty(t *testing.T) {
 	data := &roadmapData{}
-	result := renderRoadmapMarkdown(data, false, "")
+	result := renderRoadmapMarkdown(data, false, "", nil)
 	// Should not panic and should produce some output (at least template headers).
 	if result == "" {
 		t.Error("renderRoadmapMarkdown() returned empty for empty data")
@@ -1603,6 +1670,9 @@ func TestOutputSyncText(t *testing.T) {
 		{IssueID: "i3", IssueTitle: "Unchanged Issue", Action: integration.ActionUnchanged},
 		{IssueID: "i4", IssueTitle: "Skipped Issue", Action: integration.ActionSkipped},
 		{IssueID: "i5", IssueTitle: "Error Issue", Action: integration.ActionError, Error: errors.New("test error")},
+		{IssueID: "i6", IssueTitle: "Filtered Issue", Action: integration.ActionSkipped, Reason: integration.SkipReasonType},
+		{IssueID: "i7", IssueTitle: "Relabelled Issue", Action: integration.ActionWouldUpdate, LabelsAdded: []string{"ui"}, LabelsRemoved: []string{"old"},
+			Changes: []integration.FieldChange{{Field: "labels", Local: "ui", Remote: "old"}}},
 	}
 
 	old := os.Stdout
@@ -1637,6 +1707,12 @@ func TestOutputSyncText(t *testing.T) {
 	if !strings.Contains(out, "1 created") {
 		t.Errorf("outputSyncText() summary = %q, expected '1 created'", out)
 	}
+	if !strings.Contains(out, "Skipped: i6 - excluded by type filter") || strings.Contains(out, "Skipped: i4") {
+		t.Errorf("outputSyncText() should list only skips with a reason, got %q", out)
+	}
+	if !strings.Contains(out, "labels: +ui -old") || strings.Contains(out, `labels: "old"`) {
+		t.Errorf("outputSyncText() should show label operations in place of the labels diff, got %q", out)
+	}
 }
 
 // --- outputSyncJSON test ---
@@ -1926,7 +2002,7 @@ func TestGraphqlCmdAliases(t *testing.T) {
 // --- graphql cmd flags ---
 
 func TestGraphqlCmdFlags(t *testing.T) {
-	flags := []string{"json", "variables", "operation", "schema"}
+	flags := []string{"json", "variables", "variables-file", "operation", "strict", "schema", "query-file", "file"}
 	for _, name := range flags {
 		f := graphqlCmd.Flags().Lookup(name)
 		if f == nil {
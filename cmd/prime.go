@@ -2,8 +2,14 @@ package cmd
 
 import (
 	_ "embed"
+	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
@@ -12,6 +18,19 @@ import (
 //go:embed todo_prompt.tmpl
 var agentPromptTemplate string
 
+var (
+	primeSectionsFlag []string
+	primeMaxTokens    int
+)
+
+// primeSections lists the prime sections in priority order, highest first.
+// When trimming to --max-tokens, lower-priority sections lose content first.
+var primeSections = []string{"todo", "sync", "graphql", "commit", "tools"}
+
+// primeBlockKinds are the optional parts of a section, in the order they are
+// dropped to fit a token budget.
+var primeBlockKinds = []string{"examples", "verbose"}
+
 // promptData holds all data needed to render the prompt template.
 type promptData struct {
 	Types           []todoconfig.TypeConfig
@@ -24,76 +43,172 @@ type promptData struct {
 	ReviewEnabled   bool
 	DraftEnabled    bool
 	DeferredEnabled bool
+
+	// blocks holds the sections ("sync") and section parts ("sync.examples")
+	// that are rendered.
+	blocks map[string]bool
+}
+
+// Show reports whether a section or section part should be rendered.
+func (d promptData) Show(block string) bool {
+	return d.blocks[block]
 }
 
 var primeCmd = &cobra.Command{
 	Use:   "prime",
 	Short: "Output instructions for AI coding agents",
-	Long:  `Outputs a prompt that primes AI coding agents on how to use the issues CLI to manage project issues.`,
-	Args:  cobra.NoArgs,
+	Long: `Outputs a prompt that primes AI coding agents on how to use the issues CLI to manage project issues.
+
+The prompt is built from the project config: statuses, types, and priorities
+are listed as configured, and the sync section only appears when todo.sync is
+set. Use --sections to pick sections (` + strings.Join(primeSections, ", ") + `)
+and --max-tokens to trim examples, then explanations, then whole sections
+until the prompt fits (estimated at 4 characters per token).`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var primeCfg *todoconfig.Config
-		if todoDataPath == "" {
-			cwd, err := os.Getwd()
-			if err != nil {
-				return nil
-			}
-			configFile, err := todoconfig.FindConfig(cwd)
-			if err != nil || configFile == "" {
-				return nil
-			}
-			primeCfg, _ = todoconfig.Load(configFile)
-		} else {
-			cp := configPath()
-			primeCfg, _ = todoconfig.Load(cp)
+		// Without a project there is nothing to prime; print nothing.
+		primeCfg, src, err := resolveTodoConfig()
+		if err != nil || (src.Path == "" && todoDataPath == "") {
+			return nil
 		}
 
-		tmpl, err := template.New("prompt").Parse(agentPromptTemplate)
+		out, err := renderPrime(primeCfg, primeSectionsFlag, primeMaxTokens)
 		if err != nil {
 			return err
 		}
+		_, err = io.WriteString(os.Stdout, out)
+		return err
+	},
+}
+
+// renderPrime renders the agent prompt for cfg (nil means defaults). sections
+// restricts output to the named sections (empty means all); maxTokens > 0
+// drops optional content until the estimated size fits.
+func renderPrime(cfg *todoconfig.Config, sections []string, maxTokens int) (string, error) {
+	for _, s := range sections {
+		if !slices.Contains(primeSections, s) {
+			return "", fmt.Errorf("unknown prime section %q (valid: %s)", s, strings.Join(primeSections, ", "))
+		}
+	}
+	if len(sections) == 0 {
+		sections = primeSections
+	}
+
+	tmpl, err := template.New("prompt").Parse(agentPromptTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	data := newPromptData(cfg)
+	data.blocks = make(map[string]bool)
+	for _, s := range primeSections {
+		if !slices.Contains(sections, s) || (s == "sync" && !data.HasSync) {
+			continue
+		}
+		data.blocks[s] = true
+		for _, kind := range primeBlockKinds {
+			data.blocks[s+"."+kind] = true
+		}
+	}
 
-		data := promptData{
-			Types:           todoconfig.DefaultTypes,
-			Priorities:      todoconfig.DefaultPriorities,
-			ReviewEnabled:   true,
-			DraftEnabled:    true,
-			DeferredEnabled: true,
+	render := func() (string, error) {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return "", err
 		}
+		return collapseBlankLines(b.String()), nil
+	}
 
-		// Filter the listed statuses to those enabled for this project,
-		// and surface a few flags the template uses for branching.
-		if primeCfg != nil {
-			for _, s := range todoconfig.DefaultStatuses {
-				if primeCfg.IsStatusEnabled(s.Name) {
-					data.Statuses = append(data.Statuses, s)
-				}
-			}
-			data.ReviewEnabled = primeCfg.IsStatusEnabled(todoconfig.StatusReview)
-			data.DraftEnabled = primeCfg.IsStatusEnabled(todoconfig.StatusDraft)
-			data.DeferredEnabled = primeCfg.IsStatusEnabled(todoconfig.StatusDeferred)
-		} else {
-			data.Statuses = todoconfig.DefaultStatuses
+	out, err := render()
+	if err != nil || maxTokens <= 0 {
+		return out, err
+	}
+	for _, block := range primeDropOrder() {
+		if estimateTokens(out) <= maxTokens {
+			break
+		}
+		if !data.blocks[block] {
+			continue
+		}
+		delete(data.blocks, block)
+		if out, err = render(); err != nil {
+			return "", err
 		}
+	}
+	return out, nil
+}
 
-		if primeCfg != nil && len(primeCfg.Tags) > 0 {
-			data.Tags = primeCfg.Tags
+// primeDropOrder returns the blocks removed, one at a time, to meet a token
+// budget: every section's examples (lowest priority first), then every
+// section's verbose explanations, then whole sections. The todo section
+// itself is never dropped.
+func primeDropOrder() []string {
+	var order []string
+	for _, kind := range primeBlockKinds {
+		for _, s := range slices.Backward(primeSections) {
+			order = append(order, s+"."+kind)
 		}
+	}
+	for _, s := range slices.Backward(primeSections[1:]) {
+		order = append(order, s)
+	}
+	return order
+}
+
+// newPromptData builds template data from the project config, so the
+// statuses, types, and priorities shown match what the CLI accepts.
+func newPromptData(cfg *todoconfig.Config) promptData {
+	if cfg == nil {
+		cfg = todoconfig.Default()
+	}
 
-		if primeCfg != nil && primeCfg.Sync != nil {
-			data.HasSync = true
-			for name := range primeCfg.Sync {
-				data.SyncNames = append(data.SyncNames, name)
-				if name == "github" {
-					data.HasGitHubSync = true
-				}
-			}
+	data := promptData{
+		ReviewEnabled:   cfg.IsStatusEnabled(todoconfig.StatusReview),
+		DraftEnabled:    cfg.IsStatusEnabled(todoconfig.StatusDraft),
+		DeferredEnabled: cfg.IsStatusEnabled(todoconfig.StatusDeferred),
+		Tags:            cfg.Tags,
+	}
+	for _, name := range cfg.TypeNames() {
+		data.Types = append(data.Types, *cfg.GetType(name))
+	}
+	for _, name := range cfg.EnabledStatusNames() {
+		data.Statuses = append(data.Statuses, *cfg.GetStatus(name))
+	}
+	for _, name := range cfg.PriorityNames() {
+		data.Priorities = append(data.Priorities, *cfg.GetPriority(name))
+	}
+
+	for name := range cfg.Sync {
+		data.SyncNames = append(data.SyncNames, name)
+		if name == "github" {
+			data.HasGitHubSync = true
 		}
+	}
+	slices.Sort(data.SyncNames)
+	data.HasSync = len(data.SyncNames) > 0
 
-		return tmpl.Execute(os.Stdout, data)
-	},
+	return data
+}
+
+var blankLinesRe = regexp.MustCompile(`\n{3,}`)
+
+// collapseBlankLines squeezes the blank-line runs left by omitted template
+// blocks down to a single blank line and trims blank lines at either end.
+func collapseBlankLines(s string) string {
+	s = strings.Trim(blankLinesRe.ReplaceAllString(s, "\n\n"), "\n")
+	if s == "" {
+		return ""
+	}
+	return s + "\n"
+}
+
+// estimateTokens approximates the token count of s at 4 characters per token.
+func estimateTokens(s string) int {
+	return (utf8.RuneCountInString(s) + 3) / 4
 }
 
 func init() {
+	primeCmd.Flags().StringSliceVar(&primeSectionsFlag, "sections", nil, "Only output these sections (comma-separated: "+strings.Join(primeSections, ",")+")")
+	primeCmd.Flags().IntVar(&primeMaxTokens, "max-tokens", 0, "Trim optional content to fit this many tokens (0 for no limit)")
 	rootCmd.AddCommand(primeCmd)
 }
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+)
+
+func TestRenderPrimeSync(t *testing.T) {
+	plain := todoconfig.Default()
+	withSync := todoconfig.Default()
+	withSync.Sync = map[string]map[string]any{
+		"github":  {"repo": "toba/jig"},
+		"clickup": {"list_id": "123"},
+	}
+
+	without, err := renderPrime(plain, nil, 0)
+	if err != nil {
+		t.Fatalf("renderPrime() error: %v", err)
+	}
+	with, err := renderPrime(withSync, nil, 0)
+	if err != nil {
+		t.Fatalf("renderPrime() error: %v", err)
+	}
+
+	for _, s := range []string{"## Sync", "jig todo sync", "## Tag Management"} {
+		if strings.Contains(without, s) {
+			t.Errorf("output without sync contains %q", s)
+		}
+		if !strings.Contains(with, s) {
+			t.Errorf("output with sync missing %q", s)
+		}
+	}
+	// Sync names are sorted so the prompt is stable across runs.
+	if !strings.Contains(with, "This project syncs with: **clickup**, **github**.") {
+		t.Error("sync names not listed in sorted order")
+	}
+	if again, _ := renderPrime(withSync, nil, 0); again != with {
+		t.Error("output differs between renders of the same config")
+	}
+}
+
+func TestRenderPrimeStatuses(t *testing.T) {
+	cfg := todoconfig.Default()
+	cfg.ExtraStatuses = map[string]bool{todoconfig.StatusReview: true}
+
+	out, err := renderPrime(cfg, nil, 0)
+	if err != nil {
+		t.Fatalf("renderPrime() error: %v", err)
+	}
+
+	want := "## Statuses\n\n" +
+		"- **review**: Code complete, awaiting evaluation\n" +
+		"- **ready**: Ready to be worked on\n" +
+		"- **completed**: Finished successfully\n\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("status list not rendered verbatim; want:\n%s\ngot:\n%s", want, out)
+	}
+	if strings.Contains(out, "-s deferred") {
+		t.Error("disabled deferred status should not be suggested")
+	}
+}
+
+func TestRenderPrimeSections(t *testing.T) {
+	out, err := renderPrime(todoconfig.Default(), []string{"commit"}, 0)
+	if err != nil {
+		t.Fatalf("renderPrime() error: %v", err)
+	}
+	if !strings.HasPrefix(out, "## Commits\n") {
+		t.Errorf("expected output to start with the commit section, got:\n%s", out)
+	}
+	for _, s := range []string{"## Workflow", "## GraphQL", "## Other jig Tools"} {
+		if strings.Contains(out, s) {
+			t.Errorf("unselected section %q rendered", s)
+		}
+	}
+
+	if _, err := renderPrime(todoconfig.Default(), []string{"bogus"}, 0); err == nil {
+		t.Error("expected error for unknown section")
+	}
+}
+
+func TestRenderPrimeMaxTokens(t *testing.T) {
+	cfg := todoconfig.Default()
+	full, err := renderPrime(cfg, nil, 0)
+	if err != nil {
+		t.Fatalf("renderPrime() error: %v", err)
+	}
+
+	// A budget just under the full size only needs examples removed.
+	budget := estimateTokens(full) - 10
+	trimmed, err := renderPrime(cfg, nil, budget)
+	if err != nil {
+		t.Fatalf("renderPrime() error: %v", err)
+	}
+	if got := estimateTokens(trimmed); got > budget {
+		t.Errorf("trimmed output is %d tokens, want <= %d", got, budget)
+	}
+	if !strings.Contains(trimmed, "## Other jig Tools") {
+		t.Error("whole sections should not be dropped while examples remain")
+	}
+	if !strings.Contains(trimmed, "**Statuses are project-configurable**") {
+		t.Error("verbose explanations should outlast examples")
+	}
+
+	// An impossible budget keeps only the core todo content.
+	minimal, _ := renderPrime(cfg, nil, 1)
+	for _, s := range []string{"```", "## GraphQL", "## Commits", "**Statuses are project-configurable**"} {
+		if strings.Contains(minimal, s) {
+			t.Errorf("minimal output still contains %q", s)
+		}
+	}
+	if !strings.Contains(minimal, "## Statuses") || !strings.Contains(minimal, "## Workflow") {
+		t.Error("minimal output dropped core todo content")
+	}
+}
@@ -9,13 +9,15 @@ import (
 	"github.com/toba/jig/internal/config"
 	"github.com/toba/jig/internal/constants"
 	"github.com/toba/jig/internal/nope"
+	"github.com/toba/jig/internal/todo/ui"
 )
 
 var (
-	cfgPath string
-	jsonOut bool
-	cfg     *config.Config
-	cfgDoc  *config.Document
+	cfgPath    string
+	jsonOut    bool
+	accessible bool
+	cfg        *config.Config
+	cfgDoc     *config.Document
 )
 
 var rootCmd = &cobra.Command{
@@ -30,14 +32,22 @@ var rootCmd = &cobra.Command{
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgPath, "config", "", "path to config file (default .jig.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON")
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "text labels instead of icons, for screen readers and logs (or "+ui.AccessibleEnv+"=1)")
+	cobra.OnInitialize(func() {
+		if accessible || ui.AccessibleRequested() {
+			ui.SetAccessible(true)
+		}
+	})
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
 		if exitErr, ok := errors.AsType[nope.ExitError](err); ok {
 			os.Exit(exitErr.Code)
 		}
-		os.Exit(1)
+		reportJSONError(cmd, err)
+		os.Exit(exitCode(err))
 	}
 }
 
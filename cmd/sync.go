@@ -12,6 +12,9 @@ var syncAliasCmd = &cobra.Command{
 
 This is an alias for 'jig todo sync'. See 'jig todo sync --help' for full details.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputMode(cmd); err != nil {
+			return err
+		}
 		return initTodoCore(cmd)
 	},
 	RunE: runSync,
@@ -24,6 +27,15 @@ var syncAliasCheckCmd = &cobra.Command{
 	RunE:  syncCheckCmd.RunE,
 }
 
+// syncAliasSmokeTestCmd is a top-level alias for "jig todo sync smoke-test".
+var syncAliasSmokeTestCmd = &cobra.Command{
+	Use:   "smoke-test [provider]",
+	Short: "Check credentials and permissions end to end against a sandbox",
+	Long:  syncSmokeTestCmd.Long,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  syncSmokeTestCmd.RunE,
+}
+
 // syncAliasLinkCmd is a top-level alias for "jig todo sync link".
 var syncAliasLinkCmd = &cobra.Command{
 	Use:   "link <issue-id> <external-id>",
@@ -32,6 +44,14 @@ var syncAliasLinkCmd = &cobra.Command{
 	RunE:  syncLinkCmd.RunE,
 }
 
+// syncAliasLinkPRCmd is a top-level alias for "jig todo sync link-pr".
+var syncAliasLinkPRCmd = &cobra.Command{
+	Use:   "link-pr <issue-id> <pr-number>",
+	Short: "Link an issue to a GitHub pull request",
+	Args:  cobra.ExactArgs(2),
+	RunE:  syncLinkPRCmd.RunE,
+}
+
 // syncAliasUnlinkCmd is a top-level alias for "jig todo sync unlink".
 var syncAliasUnlinkCmd = &cobra.Command{
 	Use:   "unlink <issue-id>",
@@ -49,11 +69,19 @@ func init() {
 	syncAliasCheckCmd.Flags().BoolVar(&syncCheckSkipAPI, "skip-api", false, "Skip API checks (offline validation only)")
 	syncAliasCheckCmd.Flags().BoolVar(&syncCheckJSON, "json", false, "Output as JSON")
 
+	syncAliasSmokeTestCmd.Flags().BoolVar(&syncSmokeTestJSON, "json", false, "Output as JSON")
+
 	syncAliasLinkCmd.Flags().BoolVar(&syncLinkJSON, "json", false, "Output as JSON")
+	syncAliasLinkPRCmd.Flags().BoolVar(&syncLinkPRJSON, "json", false, "Output as JSON")
 	syncAliasUnlinkCmd.Flags().BoolVar(&syncUnlinkJSON, "json", false, "Output as JSON")
+	for _, c := range []*cobra.Command{syncAliasLinkCmd, syncAliasLinkPRCmd, syncAliasUnlinkCmd} {
+		addQuietFlag(c)
+	}
 
 	syncAliasCmd.AddCommand(syncAliasCheckCmd)
+	syncAliasCmd.AddCommand(syncAliasSmokeTestCmd)
 	syncAliasCmd.AddCommand(syncAliasLinkCmd)
+	syncAliasCmd.AddCommand(syncAliasLinkPRCmd)
 	syncAliasCmd.AddCommand(syncAliasUnlinkCmd)
 	rootCmd.AddCommand(syncAliasCmd)
 }
@@ -1,82 +1,206 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
 )
 
 var (
-	todoStore    *core.Core
-	todoCfg      *todoconfig.Config
-	todoDataPath string
+	todoStore       *core.Core
+	todoCfg         *todoconfig.Config
+	todoDataPath    string
+	todoProjectRoot string
 )
 
-// loadConfigWithFallback loads todo config from the given path, falling back
-// to searching upward from the current directory.
-func loadConfigWithFallback(cfgPath string) (*todoconfig.Config, error) {
-	if _, statErr := os.Stat(cfgPath); statErr == nil {
-		cfg, err := todoconfig.Load(cfgPath)
+// cliContext is the context commands create issues under, recording them
+// as created via the CLI by the local actor.
+func cliContext() context.Context {
+	return graph.WithCreator(context.Background(), graph.Creator{By: graph.DefaultActor(), Via: issue.CreatedViaCLI})
+}
+
+// How resolveTodoConfig found the config, as todo which reports it.
+const (
+	viaConfigFlag  = "--config"
+	viaProjectRoot = "nearest config above --project-root"
+	viaSearch      = "nearest config above the current directory"
+	viaDefaults    = "no config found; using defaults"
+)
+
+// todoConfigSource is where the todo config came from.
+type todoConfigSource struct {
+	// Path is the absolute config file path, or "" when running on defaults.
+	Path string
+	// Via says how Path was chosen.
+	Via string
+	// StartDir is the directory the search started from.
+	StartDir string
+	// Outer lists configs further up the repository that Path shadows.
+	Outer []string
+}
+
+// todoStartDir is the directory config discovery starts from: --project-root
+// if given, otherwise the current directory.
+func todoStartDir() (string, error) {
+	if todoProjectRoot != "" {
+		dir, err := filepath.Abs(todoProjectRoot)
 		if err != nil {
-			return nil, fmt.Errorf("loading config from %s: %w", cfgPath, err)
+			return "", err
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return "", fmt.Errorf("project root does not exist or is not a directory: %s", todoProjectRoot)
 		}
-		return cfg, nil
+		return dir, nil
 	}
 	cwd, err := os.Getwd()
 	if err != nil {
-		return nil, fmt.Errorf("getting current directory: %w", err)
+		return "", fmt.Errorf("getting current directory: %w", err)
+	}
+	return cwd, nil
+}
+
+// resolveTodoConfig loads the todo config every todo command and the TUI
+// use: the file named by --config, or else the nearest config found
+// searching upward from todoStartDir to the git root. Data paths in the
+// config resolve against the config's own directory. With no config found,
+// defaults apply if --data-path is given or the start directory has the
+// default data directory; otherwise it is an error.
+func resolveTodoConfig() (*todoconfig.Config, *todoConfigSource, error) {
+	if cfgPath != "" {
+		path, err := filepath.Abs(cfgPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil, fmt.Errorf("config file not found: %s", cfgPath)
+		}
+		cfg, err := todoconfig.Load(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading config from %s: %w", cfgPath, err)
+		}
+		return cfg, &todoConfigSource{Path: path, Via: viaConfigFlag}, nil
+	}
+
+	start, err := todoStartDir()
+	if err != nil {
+		return nil, nil, err
 	}
-	cfg, err := todoconfig.LoadFromDirectory(cwd)
+	found, err := todoconfig.Discover(start)
 	if err != nil {
-		return nil, fmt.Errorf("loading config: %w", err)
+		return nil, nil, fmt.Errorf("loading config: %w", err)
 	}
-	return cfg, nil
+	src := &todoConfigSource{Path: found.Path, Via: viaSearch, StartDir: start, Outer: found.Outer}
+	if todoProjectRoot != "" {
+		src.Via = viaProjectRoot
+	}
+
+	if found.Path == "" {
+		cfg := todoconfig.Default()
+		cfg.SetConfigDir(start)
+		if info, err := os.Stat(cfg.ResolveDataPath()); todoDataPath == "" && (err != nil || !info.IsDir()) {
+			return nil, nil, cmdError(false, output.ErrNoDataDir,
+				"no %s found in %s or any parent up to %s (run 'jig todo init' to create one, or pass --project-root)",
+				todoconfig.ConfigFileName, start, found.StopDir)
+		}
+		src.Via = viaDefaults
+		return cfg, src, nil
+	}
+
+	cfg, err := todoconfig.Load(found.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading config from %s: %w", found.Path, err)
+	}
+	return cfg, src, nil
 }
 
 // initTodoCore loads config, resolves data dir, and creates the core.
 // Extracted from todo's rootCmd.PersistentPreRunE.
-func initTodoCore(_ *cobra.Command) error {
-	var err error
-
-	todoCfg, err = loadConfigWithFallback(configPath())
+func initTodoCore(cmd *cobra.Command) error {
+	var (
+		src *todoConfigSource
+		err error
+	)
+	todoCfg, src, err = resolveTodoConfig()
 	if err != nil {
 		return err
 	}
+	if len(src.Outer) > 0 && !quietRequested(cmd) {
+		fmt.Fprintf(os.Stderr, "warning: using %s, which shadows %s\n", src.Path, strings.Join(src.Outer, ", "))
+	}
 
 	// Determine data directory
 	var root string
 	if todoDataPath != "" {
 		root = todoDataPath
 		if info, statErr := os.Stat(root); statErr != nil || !info.IsDir() {
-			return fmt.Errorf("data path does not exist or is not a directory: %s", root)
+			return cmdError(false, output.ErrNoDataDir, "data path does not exist or is not a directory: %s", root)
 		}
 	} else {
 		root = todoCfg.ResolveDataPath()
 		if info, statErr := os.Stat(root); statErr != nil || !info.IsDir() {
-			return fmt.Errorf("no data directory found at %s (run 'jig todo init' to create one)", root)
+			return cmdError(false, output.ErrNoDataDir, "no data directory found at %s (run 'jig todo init' to create one)", root)
 		}
 	}
 
 	todoStore = core.New(root, todoCfg)
+	if w := todoCfg.IDFormatWarning(); w != "" && !quietRequested(cmd) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	// Load warnings are reported below so --quiet can hold them back; later
+	// warnings (watcher, search index) still go straight to stderr.
+	todoStore.SetWarnWriter(nil)
+	// The TUI shows the snapshot from its last exit and loads behind it;
+	// its load warnings appear in the TUI instead. Archiving on start
+	// needs the real state first.
+	if cmd != nil && cmd.Name() == "tui" && !todoCfg.AutoArchive.OnStart && todoStore.LoadSnapshot() {
+		todoStore.SetWarnWriter(os.Stderr)
+		return nil
+	}
 	if err := todoStore.Load(); err != nil {
 		return fmt.Errorf("loading issues: %w", err)
 	}
+	todoStore.SetWarnWriter(os.Stderr)
+	if !quietRequested(cmd) {
+		for _, w := range todoStore.Warnings() {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", w.Notice())
+		}
+	}
 
 	return nil
 }
 
+// quietRequested reports whether the command was run with --quiet.
+func quietRequested(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	f := cmd.Flags().Lookup("quiet")
+	return f != nil && f.Value.String() == "true"
+}
+
 var todoCmd = &cobra.Command{
 	Use:   "todo",
 	Short: "File-based issue tracker for AI-first workflows",
 	Long: `Todo is a lightweight issue tracker that stores issues as markdown files.
 Track your work alongside your code and supercharge your coding agent with
-a full view of your project.`,
+a full view of your project.
+
+` + porcelainHelp + "\n\n" + quietHelp + "\n\n" + exitCodeHelp,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip core initialization for init, prime, and refry commands
-		if cmd.Name() == "init" || cmd.Name() == "prime" || cmd.Name() == "refry" || cmd.Name() == "import" {
+		if err := validateOutputMode(cmd); err != nil {
+			return err
+		}
+		// Skip core initialization for commands that resolve config themselves
+		if cmd.Name() == "init" || cmd.Name() == "prime" || cmd.Name() == "refry" || cmd.Name() == "import" || cmd.Name() == "which" {
 			return nil
 		}
 		return initTodoCore(cmd)
@@ -85,5 +209,7 @@ a full view of your project.`,
 
 func init() {
 	todoCmd.PersistentFlags().StringVar(&todoDataPath, "data-path", "", "Path to data directory (overrides config)")
+	todoCmd.PersistentFlags().StringVar(&todoProjectRoot, "project-root", "", "Directory to find the config from instead of the current directory")
+	todoCmd.PersistentFlags().BoolVar(&todoPorcelain, "porcelain", false, "Stable tab-separated output for scripts (see 'jig todo --help')")
 	rootCmd.AddCommand(todoCmd)
 }
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// seedAccessibleIssues seeds issues that carry every list glyph: status,
+// type, and priority icons, a pin, a blocker, and a due date.
+func seedAccessibleIssues(t *testing.T) {
+	t.Helper()
+	testCore := seedTestIssues(t)
+	testCore.SetClock(func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) })
+	due, _ := issue.ParseDueDate("2026-03-05")
+	addTestIssues(t, testCore,
+		&issue.Issue{ID: "prc-001", Slug: "tabs", Title: "Title\twith tab", Status: "ready", Type: "bug", Priority: "high", Tags: []string{"a", "b"}},
+		&issue.Issue{ID: "prc-002", Slug: "plain", Title: "Plain", Status: "in-progress", Type: "task", Parent: "prc-001"},
+		&issue.Issue{ID: "prc-003", Slug: "blocker", Title: "Blocker", Status: "ready", Type: "task"},
+		&issue.Issue{ID: "prc-004", Slug: "pinned", Title: "Pinned and due", Status: "ready", Type: "feature", Priority: "critical", Pinned: true, Due: due,
+			Tags: []string{"a"}, BlockedBy: []string{"prc-003"}, Body: "- [ ] one\n- [x] two\n"},
+	)
+	ui.SetAccessible(true)
+	t.Cleanup(func() { ui.SetAccessible(false) })
+}
+
+// assertAccessibleFixture compares output byte for byte with
+// testdata/accessible/name.
+func assertAccessibleFixture(t *testing.T, name, got string) {
+	t.Helper()
+	want, err := os.ReadFile(filepath.Join("testdata", "accessible", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s output drifted from fixture:\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestAccessibleList(t *testing.T) {
+	seedAccessibleIssues(t)
+	oldSort := listSort
+	t.Cleanup(func() { listSort = oldSort })
+	listSort = "id"
+
+	var buf bytes.Buffer
+	if err := renderList(&colorprofile.Writer{Forward: &buf, Profile: colorprofile.NoTTY}, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	assertAccessibleFixture(t, "list.txt", buf.String())
+}
+
+func TestAccessibleShow(t *testing.T) {
+	seedAccessibleIssues(t)
+	b, err := todoStore.Get("prc-004")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAccessibleFixture(t, "show.txt", renderIssue(b, false))
+}
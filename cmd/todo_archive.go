@@ -4,11 +4,16 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/output"
 )
 
-var archiveJSON bool
+var (
+	archiveJSON   bool
+	archiveAuto   bool
+	archiveDryRun bool
+)
 
 var archiveCmd = &cobra.Command{
 	Use:   "archive",
@@ -17,51 +22,89 @@ var archiveCmd = &cobra.Command{
 archived issues are preserved for project memory and remain visible in all queries.
 The archive keeps the main data directory tidy while preserving project history.
 
+With --auto, only issues matching the auto_archive policy in .jig.yaml are moved:
+those in one of auto_archive.statuses that have gone longer than auto_archive.after
+without an update. This mode is meant for cron jobs and CI.
+
 Relationships (parent, blocking) are preserved in archived issues.`,
+	Example: `  jig todo archive
+  jig todo archive --auto --dry-run
+  jig todo archive --auto --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		allIssues := todoStore.All()
-
-		// Find issues with any archive status
 		var archiveIssues []*issue.Issue
-		for _, b := range allIssues {
-			if todoCfg.IsArchiveStatus(b.Status) {
-				archiveIssues = append(archiveIssues, b)
+		if archiveAuto {
+			if todoCfg.GetAutoArchiveAfter() <= 0 {
+				return cmdError(archiveJSON, output.ErrValidation, "auto_archive.after is not set in %s", config.ConfigFileName)
+			}
+			archiveIssues = todoStore.AutoArchiveCandidates()
+		} else {
+			// Find issues with any archive status
+			for _, b := range todoStore.AllUnordered() {
+				if todoCfg.IsArchiveStatus(b.Status) && !todoStore.IsArchived(b.ID) {
+					archiveIssues = append(archiveIssues, b)
+				}
 			}
 		}
 
+		mode := resolveOutputMode(archiveJSON)
 		if len(archiveIssues) == 0 {
-			if archiveJSON {
-				return output.SuccessMessage("No issues to archive")
-			}
-			fmt.Println("No issues with archive status to archive.")
-			return nil
+			return mode.write(commandResult{
+				JSON: func() error { return output.SuccessMessage("No issues to archive") },
+				Text: func() error {
+					fmt.Println("No issues with archive status to archive.")
+					return nil
+				},
+			})
 		}
 
 		// Sort issues for consistent display
 		issue.SortByStatusPriorityAndType(archiveIssues, todoCfg.StatusNames(), todoCfg.PriorityNames(), todoCfg.TypeNames())
 
-		// Archive all issues with archive status
-		var archived []string
+		if archiveDryRun {
+			msg := fmt.Sprintf("Would archive %d issue(s) to .issues/archive/", len(archiveIssues))
+			return mode.write(archiveResult(archiveIssues, msg))
+		}
+
+		// Archive all selected issues
 		for _, b := range archiveIssues {
 			if err := todoStore.Archive(b.ID); err != nil {
-				if archiveJSON {
+				if mode == outputJSON {
 					return output.Error(output.ErrFileError, fmt.Sprintf("failed to archive issue %s: %s", b.ID, err.Error()))
 				}
 				return fmt.Errorf("failed to archive issue %s: %w", b.ID, err)
 			}
-			archived = append(archived, b.ID)
-		}
-
-		if archiveJSON {
-			return output.SuccessMessage(fmt.Sprintf("Archived %d issue(s) to .issues/archive/", len(archived)))
 		}
 
-		fmt.Printf("Archived %d issue(s) to .issues/archive/\n", len(archived))
-		return nil
+		msg := fmt.Sprintf("Archived %d issue(s) to .issues/archive/", len(archiveIssues))
+		return mode.write(archiveResult(archiveIssues, msg))
 	},
 }
 
+// archiveResult reports the issues archived, or that would be, with msg.
+func archiveResult(issues []*issue.Issue, msg string) commandResult {
+	return commandResult{
+		JSON: func() error {
+			return output.JSON(output.Response{Success: true, Issues: issues, Count: len(issues), Message: msg})
+		},
+		Quiet: issueIDs(issues),
+		Text: func() error {
+			printArchiveList(issues)
+			fmt.Println(msg)
+			return nil
+		},
+	}
+}
+
+func printArchiveList(issues []*issue.Issue) {
+	for _, b := range issues {
+		fmt.Printf("  - %s (%s) [%s]\n", b.ID, b.Title, b.Status)
+	}
+}
+
 func init() {
 	archiveCmd.Flags().BoolVar(&archiveJSON, "json", false, "Output as JSON")
+	addQuietFlag(archiveCmd)
+	archiveCmd.Flags().BoolVar(&archiveAuto, "auto", false, "Apply the auto_archive policy from config instead of archiving every closed issue")
+	archiveCmd.Flags().BoolVar(&archiveDryRun, "dry-run", false, "List what would be archived without moving anything")
 	todoCmd.AddCommand(archiveCmd)
 }
@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// defaultBulkLimit caps how many issues a filter may select without --all.
+const defaultBulkLimit = 50
+
+var (
+	bulkIDs            []string
+	bulkWhereStatus    []string
+	bulkWhereTag       []string
+	bulkWhereParent    string
+	bulkSetStatus      string
+	bulkSetType        string
+	bulkSetPriority    string
+	bulkSetMilestone   string
+	bulkSetIteration   string
+	bulkSetParent      string
+	bulkRemoveParent   bool
+	bulkSetDue         string
+	bulkAddTag         []string
+	bulkRemoveTag      []string
+	bulkAppendBody     string
+	bulkDryRun         bool
+	bulkLimit          int
+	bulkAll            bool
+	todoBulkUpdateJSON bool
+)
+
+// bulkResult is the per-issue outcome reported by bulk-update.
+type bulkResult struct {
+	ID    string `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	ETag  string `json:"etag,omitempty"`
+}
+
+var todoBulkUpdateCmd = &cobra.Command{
+	Use:   "bulk-update",
+	Short: "Apply the same update to many issues",
+	Long: `Applies one set of changes to every selected issue in a single process.
+
+Select issues with repeated --id flags, or with a filter (--status, --tag,
+--parent). At least one selector is required. Filter selections are capped
+by --limit unless --all is given.
+
+Changes use --set-* / --add-tag / --remove-tag / --append-body flags. Each
+issue is updated independently; if another process edits an issue mid-run
+the update is retried once against the fresh copy before being reported as
+failed.
+
+  jig todo bulk-update --tag sprint-12 --set-status completed
+  jig todo bulk-update --id abc-def --id ghi-jkl --add-tag needs-review --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input, err := buildBulkInput(cmd)
+		if err != nil {
+			return cmdError(todoBulkUpdateJSON, output.ErrValidation, "%w", err)
+		}
+
+		targets, err := selectBulkTargets(cmd)
+		if err != nil {
+			return cmdError(todoBulkUpdateJSON, resolveErrorCode(err), "%w", err)
+		}
+
+		mode := resolveOutputMode(todoBulkUpdateJSON)
+		if bulkDryRun {
+			return mode.write(commandResult{
+				JSON: func() error {
+					return output.JSON(output.Response{
+						Success: true,
+						Issues:  targets,
+						Count:   len(targets),
+						Message: fmt.Sprintf("Would update %d issue(s)", len(targets)),
+					})
+				},
+				Quiet: issueIDs(targets),
+				Text: func() error {
+					fmt.Printf("Would update %d issue(s):\n", len(targets))
+					for _, b := range targets {
+						fmt.Printf("  %s %s\n", ui.ID.Render(b.ID), b.Title)
+					}
+					return nil
+				},
+			})
+		}
+
+		results := applyBulkUpdate(targets, captureETags(targets), input)
+
+		failed := 0
+		for _, r := range results {
+			if !r.OK {
+				failed++
+			}
+		}
+
+		updated, failures := quietResults(results)
+		if err := mode.write(commandResult{
+			JSON:        func() error { return writeIndentedJSON(results) },
+			Quiet:       updated,
+			QuietErrors: failures,
+			Text: func() error {
+				for _, r := range results {
+					if r.OK {
+						fmt.Println(ui.Success.Render("Updated ") + ui.ID.Render(r.ID))
+					} else {
+						fmt.Println(ui.Danger.Render("Failed  ") + ui.ID.Render(r.ID) + " " + ui.Muted.Render(r.Error))
+					}
+				}
+				return nil
+			},
+		}); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d update(s) failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// quietResults splits results into the --quiet IDs of the issues updated
+// and the failure lines --quiet writes to stderr.
+func quietResults(results []bulkResult) (updated, failures []string) {
+	for _, r := range results {
+		switch {
+		case r.OK:
+			updated = append(updated, r.ID)
+		case r.Error != "":
+			failures = append(failures, ui.Danger.Render("Failed ")+ui.ID.Render(r.ID)+" "+ui.Muted.Render(r.Error))
+		}
+	}
+	return updated, failures
+}
+
+// buildBulkInput validates the mutation flags and converts them into a
+// GraphQL update input shared by every selected issue.
+func buildBulkInput(cmd *cobra.Command) (model.UpdateIssueInput, error) {
+	var input model.UpdateIssueInput
+
+	if cmd.Flags().Changed("set-status") {
+		if err := todoCfg.ValidateStatus(bulkSetStatus); err != nil {
+			return input, err
+		}
+		if !todoCfg.IsStatusEnabled(bulkSetStatus) {
+			return input, fmt.Errorf("status %q is disabled in this project (enabled: %s)", bulkSetStatus, todoCfg.EnabledStatusList())
+		}
+		input.Status = &bulkSetStatus
+	}
+	if cmd.Flags().Changed("set-type") {
+		if err := todoCfg.ValidateType(bulkSetType); err != nil {
+			return input, err
+		}
+		input.Type = &bulkSetType
+	}
+	if cmd.Flags().Changed("set-priority") {
+		if err := todoCfg.ValidatePriority(bulkSetPriority); err != nil {
+			return input, err
+		}
+		input.Priority = &bulkSetPriority
+	}
+	if cmd.Flags().Changed("set-milestone") {
+		input.Milestone = &bulkSetMilestone
+	}
+	if cmd.Flags().Changed("set-iteration") {
+		iteration, err := todoCfg.ResolveIteration(bulkSetIteration, todoStore.Now())
+		if err != nil {
+			return input, err
+		}
+		input.Iteration = &iteration
+	}
+	if cmd.Flags().Changed("set-due") {
+		input.Due = &bulkSetDue
+	}
+	if cmd.Flags().Changed("set-parent") {
+		input.Parent = &bulkSetParent
+	} else if bulkRemoveParent {
+		empty := ""
+		input.Parent = &empty
+	}
+	if len(bulkAddTag) > 0 {
+		input.AddTags = bulkAddTag
+	}
+	if len(bulkRemoveTag) > 0 {
+		input.RemoveTags = bulkRemoveTag
+	}
+	if cmd.Flags().Changed("append-body") {
+		text, err := resolveAppendContent(bulkAppendBody)
+		if err != nil {
+			return input, err
+		}
+		input.BodyMod = &model.BodyModification{Append: &text}
+	}
+
+	if !hasFieldUpdates(input) {
+		return input, errors.New("no changes specified (use --set-status, --set-type, --set-priority, --set-milestone, --set-iteration, --set-due, --set-parent, --remove-parent, --add-tag, --remove-tag, or --append-body)")
+	}
+	return input, nil
+}
+
+// selectBulkTargets resolves --id references or evaluates the filter flags.
+// Running with no selector at all is refused so a typo cannot touch every issue.
+func selectBulkTargets(cmd *cobra.Command) ([]*issue.Issue, error) {
+	hasFilter := len(bulkWhereStatus) > 0 || len(bulkWhereTag) > 0 || cmd.Flags().Changed("parent")
+	if len(bulkIDs) == 0 && !hasFilter {
+		return nil, errors.New("no issues selected (use --id, --status, --tag, or --parent)")
+	}
+	if cmd.Flags().Changed("parent") && bulkWhereParent == "" {
+		return nil, errors.New("--parent requires an issue ID")
+	}
+
+	var targets []*issue.Issue
+	if len(bulkIDs) > 0 {
+		seen := make(map[string]bool)
+		for _, ref := range bulkIDs {
+			b, err := resolveIssueArg(ref)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[b.ID] {
+				seen[b.ID] = true
+				targets = append(targets, b)
+			}
+		}
+	}
+
+	if hasFilter {
+		filter := &model.IssueFilter{Status: bulkWhereStatus, Tags: bulkWhereTag}
+		if cmd.Flags().Changed("parent") {
+			filter.ParentID = &bulkWhereParent
+		}
+		pool := targets
+		if len(bulkIDs) == 0 {
+			pool = todoStore.All()
+		}
+		targets = graph.ApplyFilter(pool, filter, todoStore)
+
+		if !bulkAll && len(targets) > bulkLimit {
+			return nil, fmt.Errorf("filter selects %d issues, more than --limit %d (raise --limit or pass --all)", len(targets), bulkLimit)
+		}
+	}
+
+	sortIssues(targets, "id", todoCfg)
+	return targets, nil
+}
+
+// captureETags records the on-disk etag of each target at selection time so
+// applyBulkUpdate can detect edits other processes make during the run.
+func captureETags(targets []*issue.Issue) map[string]string {
+	etags := make(map[string]string, len(targets))
+	for _, b := range targets {
+		if etag, err := todoStore.DiskETag(b.ID); err == nil {
+			etags[b.ID] = etag
+		}
+	}
+	return etags
+}
+
+// applyBulkUpdate applies input to each target, guarding every write with the
+// etag captured at selection. A conflicting issue is reloaded from disk and
+// retried once against the fresh copy before being reported as failed.
+func applyBulkUpdate(targets []*issue.Issue, etags map[string]string, input model.UpdateIssueInput) []bulkResult {
+	ctx := context.Background()
+	resolver := &graph.Resolver{Core: todoStore}
+
+	results := make([]bulkResult, 0, len(targets))
+	for _, target := range targets {
+		etag := etags[target.ID]
+		var updated *issue.Issue
+		var err error
+		for attempt := range 2 {
+			if attempt > 0 {
+				// UpdateIssue mutates the stored issue before the etag check
+				// rejects the write, so the retry must start from a fresh copy.
+				if _, err = todoStore.Reload(target.ID); err != nil {
+					break
+				}
+				if etag, err = todoStore.DiskETag(target.ID); err != nil {
+					break
+				}
+			}
+			perIssue := input
+			perIssue.IfMatch = &etag
+			updated, err = resolver.Mutation().UpdateIssue(ctx, target.ID, perIssue)
+			if _, conflict := errors.AsType[*core.ETagMismatchError](err); !conflict {
+				break
+			}
+		}
+
+		if err != nil {
+			// Discard any partial in-memory mutation left by the failed write.
+			_, _ = todoStore.Reload(target.ID)
+			results = append(results, bulkResult{ID: target.ID, Error: err.Error()})
+			continue
+		}
+		results = append(results, bulkResult{ID: updated.ID, OK: true, ETag: updated.ETag()})
+	}
+	return results
+}
+
+// registerBulkUpdateFlags binds the bulk-update flags to cmd. Split out from
+// init() so tests can parse flags on an isolated command.
+func registerBulkUpdateFlags(cmd *cobra.Command) {
+	statusNames := todoconfig.DefaultStatusNames()
+	priorityNames := todoconfig.DefaultPriorityNames()
+
+	f := cmd.Flags()
+	f.StringArrayVar(&bulkIDs, "id", nil, "Issue ID, slug, or title substring to update (can be repeated)")
+	f.StringArrayVarP(&bulkWhereStatus, "status", "s", nil, "Select issues with this status (can be repeated)")
+	f.StringArrayVar(&bulkWhereTag, "tag", nil, "Select issues with this tag (can be repeated, OR logic)")
+	f.StringVar(&bulkWhereParent, "parent", "", "Select children of this parent ID")
+	f.StringVar(&bulkSetStatus, "set-status", "", "New status ("+strings.Join(statusNames, ", ")+")")
+	f.StringVar(&bulkSetType, "set-type", "", "New type")
+	f.StringVar(&bulkSetPriority, "set-priority", "", "New priority ("+strings.Join(priorityNames, ", ")+")")
+	f.StringVar(&bulkSetMilestone, "set-milestone", "", "Milestone ID to assign (empty to clear)")
+	f.StringVar(&bulkSetIteration, "set-iteration", "", "Iteration to assign (ISO week, a configured name, or 'current'; empty to clear)")
+	f.StringVar(&bulkSetDue, "set-due", "", "Due date (YYYY-MM-DD, empty to clear)")
+	f.StringVar(&bulkSetParent, "set-parent", "", "New parent issue ID")
+	f.BoolVar(&bulkRemoveParent, "remove-parent", false, "Remove parent")
+	f.StringArrayVar(&bulkAddTag, "add-tag", nil, "Add tag (can be repeated)")
+	f.StringArrayVar(&bulkRemoveTag, "remove-tag", nil, "Remove tag (can be repeated)")
+	f.StringVar(&bulkAppendBody, "append-body", "", "Append text to each body (use '-' for stdin)")
+	f.BoolVar(&bulkDryRun, "dry-run", false, "List the issues that would be updated without writing")
+	f.IntVar(&bulkLimit, "limit", defaultBulkLimit, "Maximum issues a filter may select")
+	f.BoolVar(&bulkAll, "all", false, "Allow a filter to select any number of issues")
+	f.BoolVar(&todoBulkUpdateJSON, "json", false, "Output per-issue results as JSON")
+	addQuietFlag(cmd)
+
+	cmd.MarkFlagsMutuallyExclusive("set-parent", "remove-parent")
+	cmd.MarkFlagsMutuallyExclusive("limit", "all")
+}
+
+func init() {
+	registerBulkUpdateFlags(todoBulkUpdateCmd)
+	todoCmd.AddCommand(todoBulkUpdateCmd)
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// newBulkTestCmd parses args against a fresh command carrying the bulk-update
+// flags, resetting the package-level flag variables in the process.
+func newBulkTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	c := &cobra.Command{Use: "bulk-update"}
+	registerBulkUpdateFlags(c)
+	if err := c.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags(%v) error = %v", args, err)
+	}
+	return c
+}
+
+func seedBulkIssues(t *testing.T) {
+	t.Helper()
+	seedTestIssues(t,
+		&issue.Issue{ID: "blk-001", Slug: "one", Title: "One", Status: "ready", Tags: []string{"sprint"}},
+		&issue.Issue{ID: "blk-002", Slug: "two", Title: "Two", Status: "ready", Tags: []string{"sprint"}},
+		&issue.Issue{ID: "blk-003", Slug: "three", Title: "Three", Status: "in-progress"},
+	)
+}
+
+func TestBulkUpdateRequiresSelector(t *testing.T) {
+	seedBulkIssues(t)
+
+	c := newBulkTestCmd(t, "--set-status", "completed")
+	if _, err := selectBulkTargets(c); err == nil || !strings.Contains(err.Error(), "no issues selected") {
+		t.Errorf("selectBulkTargets() error = %v, want no-selector refusal", err)
+	}
+}
+
+func TestBulkUpdateRequiresChange(t *testing.T) {
+	seedBulkIssues(t)
+
+	c := newBulkTestCmd(t, "--tag", "sprint")
+	if _, err := buildBulkInput(c); err == nil || !strings.Contains(err.Error(), "no changes specified") {
+		t.Errorf("buildBulkInput() error = %v, want no-changes error", err)
+	}
+}
+
+func TestBulkUpdateSelectByFilter(t *testing.T) {
+	seedBulkIssues(t)
+
+	c := newBulkTestCmd(t, "--tag", "sprint", "--add-tag", "done")
+	targets, err := selectBulkTargets(c)
+	if err != nil {
+		t.Fatalf("selectBulkTargets() error = %v", err)
+	}
+	if len(targets) != 2 || targets[0].ID != "blk-001" || targets[1].ID != "blk-002" {
+		t.Fatalf("selected %v, want blk-001, blk-002", issueIDs(targets))
+	}
+
+	c = newBulkTestCmd(t, "--tag", "sprint", "--limit", "1", "--add-tag", "done")
+	if _, err := selectBulkTargets(c); err == nil || !strings.Contains(err.Error(), "--limit") {
+		t.Errorf("selectBulkTargets() over limit error = %v", err)
+	}
+
+	c = newBulkTestCmd(t, "--status", "ready", "--status", "in-progress", "--all", "--add-tag", "done")
+	targets, err = selectBulkTargets(c)
+	if err != nil {
+		t.Fatalf("selectBulkTargets() with --all error = %v", err)
+	}
+	if len(targets) != 3 {
+		t.Errorf("selected %d issues with --all, want 3", len(targets))
+	}
+}
+
+func TestBulkUpdateSelectByIDNarrowedByFilter(t *testing.T) {
+	seedBulkIssues(t)
+
+	c := newBulkTestCmd(t, "--id", "blk-001", "--id", "blk-003", "--id", "blk-001", "--status", "ready", "--add-tag", "x")
+	targets, err := selectBulkTargets(c)
+	if err != nil {
+		t.Fatalf("selectBulkTargets() error = %v", err)
+	}
+	if len(targets) != 1 || targets[0].ID != "blk-001" {
+		t.Errorf("selected %v, want [blk-001]", issueIDs(targets))
+	}
+}
+
+func TestApplyBulkUpdate(t *testing.T) {
+	seedBulkIssues(t)
+
+	c := newBulkTestCmd(t, "--tag", "sprint", "--set-status", "completed")
+	input, err := buildBulkInput(c)
+	if err != nil {
+		t.Fatalf("buildBulkInput() error = %v", err)
+	}
+	targets, err := selectBulkTargets(c)
+	if err != nil {
+		t.Fatalf("selectBulkTargets() error = %v", err)
+	}
+
+	results := applyBulkUpdate(targets, captureETags(targets), input)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for _, r := range results {
+		if !r.OK || r.ETag == "" {
+			t.Errorf("result %+v, want ok with etag", r)
+		}
+		b, _ := todoStore.Get(r.ID)
+		if b.Status != "completed" {
+			t.Errorf("%s status = %s, want completed", r.ID, b.Status)
+		}
+	}
+}
+
+func TestApplyBulkUpdateRetriesAfterConcurrentEdit(t *testing.T) {
+	seedBulkIssues(t)
+
+	c := newBulkTestCmd(t, "--id", "blk-001", "--add-tag", "reviewed")
+	input, err := buildBulkInput(c)
+	if err != nil {
+		t.Fatalf("buildBulkInput() error = %v", err)
+	}
+	targets, err := selectBulkTargets(c)
+	if err != nil {
+		t.Fatalf("selectBulkTargets() error = %v", err)
+	}
+	etags := captureETags(targets)
+
+	// Simulate another process editing the file after selection.
+	b, _ := todoStore.Get("blk-001")
+	external := *b
+	external.Title = "Edited elsewhere"
+	content, err := external.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(todoStore.FullPath(b), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := applyBulkUpdate(targets, etags, input)
+	if len(results) != 1 || !results[0].OK {
+		t.Fatalf("results = %+v, want single success after retry", results)
+	}
+	got, _ := todoStore.Get("blk-001")
+	if got.Title != "Edited elsewhere" {
+		t.Errorf("concurrent edit lost: title = %q", got.Title)
+	}
+	if !got.HasTag("reviewed") {
+		t.Errorf("bulk change not applied after retry: tags = %v", got.Tags)
+	}
+}
+
+func TestApplyBulkUpdateValidationRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  []todoconfig.ValidationRule
+		args   []string
+		wantOK map[string]bool
+		errHas string
+	}{
+		{
+			name:   "status needs body",
+			rules:  []todoconfig.ValidationRule{{WhenStatus: "completed", Require: []string{"body"}}},
+			args:   []string{"--tag", "sprint", "--set-status", "completed"},
+			wantOK: map[string]bool{"blk-001": true, "blk-002": false},
+			errHas: "blk-002 is missing body",
+		},
+		{
+			name:   "change supplies the field",
+			rules:  []todoconfig.ValidationRule{{WhenStatus: "completed", Require: []string{"due"}}},
+			args:   []string{"--tag", "sprint", "--set-status", "completed", "--set-due", "2026-06-01"},
+			wantOK: map[string]bool{"blk-001": true, "blk-002": true},
+		},
+		{
+			name:   "rule for another status",
+			rules:  []todoconfig.ValidationRule{{WhenStatus: "review", Require: []string{"tags"}}},
+			args:   []string{"--tag", "sprint", "--set-status", "completed"},
+			wantOK: map[string]bool{"blk-001": true, "blk-002": true},
+		},
+		{
+			name:   "clearing a required field",
+			rules:  []todoconfig.ValidationRule{{WhenStatus: "ready", Require: []string{"tags"}}},
+			args:   []string{"--tag", "sprint", "--remove-tag", "sprint"},
+			wantOK: map[string]bool{"blk-001": false, "blk-002": false},
+			errHas: "required for status ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedBulkIssues(t)
+			b, _ := todoStore.Get("blk-001")
+			b.Body = "Done."
+			if err := todoStore.Update(b, nil); err != nil {
+				t.Fatal(err)
+			}
+			todoStore.Config().ValidationRules = tt.rules
+
+			c := newBulkTestCmd(t, tt.args...)
+			input, err := buildBulkInput(c)
+			if err != nil {
+				t.Fatalf("buildBulkInput() error = %v", err)
+			}
+			targets, err := selectBulkTargets(c)
+			if err != nil {
+				t.Fatalf("selectBulkTargets() error = %v", err)
+			}
+
+			results := applyBulkUpdate(targets, captureETags(targets), input)
+			if len(results) != len(tt.wantOK) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.wantOK))
+			}
+			for _, r := range results {
+				if r.OK != tt.wantOK[r.ID] {
+					t.Errorf("%s ok = %v, want %v (error %q)", r.ID, r.OK, tt.wantOK[r.ID], r.Error)
+				}
+				if !r.OK && !strings.Contains(r.Error, tt.errHas) {
+					t.Errorf("%s error = %q, want it to contain %q", r.ID, r.Error, tt.errHas)
+				}
+			}
+		})
+	}
+}
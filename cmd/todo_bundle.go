@@ -0,0 +1,449 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+// Bundle formats.
+const (
+	bundleMarkdown = "markdown"
+	bundleGHIssue  = "gh-issue"
+)
+
+var (
+	bundleFormat   string
+	bundleInternal bool
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle <id>",
+	Short: "Render an issue as a self-contained markdown document",
+	Long: `Prints one issue as markdown for sharing outside the repository, such as
+pasting into chat or filing upstream.
+
+The markdown format has the title as a heading, a metadata table, and the
+body. The gh-issue format follows GitHub's issue form layout, one "###"
+section per field with the body last under Description, and leaves the
+title out so the output works with gh issue create --body-file. Linked
+issues and milestones are given by title with their IDs.
+
+jig todo create --from-bundle reads either format back into a new issue.`,
+	Example: `  jig todo bundle abc-123 | pbcopy
+  jig todo bundle abc-123 --format gh-issue > body.md
+  gh issue create --title "Fix login" --body-file body.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if bundleFormat != bundleMarkdown && bundleFormat != bundleGHIssue {
+			return cmdError(false, output.ErrValidation, "unknown format %q (must be %s or %s)", bundleFormat, bundleMarkdown, bundleGHIssue)
+		}
+		b, err := resolveIssueArg(args[0])
+		if err != nil {
+			return err
+		}
+		if b.Visibility == todoconfig.VisibilityInternal && !bundleInternal {
+			return cmdError(false, output.ErrValidation, "%s is internal; use --include-internal to bundle it", b.ID)
+		}
+		fmt.Fprint(os.Stdout, renderBundle(b, bundleFormat))
+		return nil
+	},
+}
+
+// bundleField is one metadata entry of a bundle.
+type bundleField struct {
+	label string
+	// values holds one entry per tag or linked issue, otherwise just one.
+	values []string
+}
+
+// bundleFields lists b's metadata in bundle order, leaving out unset fields.
+// Linked issues and milestones read "Title (`id`)" when they can be found.
+func bundleFields(b *issue.Issue) []bundleField {
+	var fields []bundleField
+	add := func(label string, values ...string) {
+		if len(values) > 0 && values[0] != "" {
+			fields = append(fields, bundleField{label: label, values: values})
+		}
+	}
+	linked := func(ids []string) []string {
+		refs := make([]string, len(ids))
+		for i, id := range ids {
+			refs[i] = "`" + id + "`"
+			if other, err := todoStore.Get(id); err == nil {
+				refs[i] = other.Title + " (" + refs[i] + ")"
+			}
+		}
+		return refs
+	}
+
+	add("ID", "`"+b.ID+"`")
+	add("Summary", b.Summary)
+	add("Status", b.Status)
+	add("Type", b.Type)
+	add("Priority", b.Priority)
+	add("Tags", b.Tags...)
+	if b.Milestone != "" {
+		ref := "`" + b.Milestone + "`"
+		if m, err := todoStore.GetMilestone(b.Milestone); err == nil {
+			ref = m.Name + " (" + ref + ")"
+		}
+		add("Milestone", ref)
+	}
+	add("Iteration", b.Iteration)
+	if b.Due != nil {
+		add("Due", b.Due.String())
+	}
+	if b.Parent != "" {
+		add("Parent", linked([]string{b.Parent})...)
+	}
+	add("Blocking", linked(b.Blocking)...)
+	add("Blocked by", linked(b.BlockedBy)...)
+	return fields
+}
+
+// renderBundle renders b in the given bundle format.
+func renderBundle(b *issue.Issue, format string) string {
+	var sb strings.Builder
+	body := strings.TrimSpace(b.Body)
+
+	if format == bundleGHIssue {
+		for _, f := range bundleFields(b) {
+			fmt.Fprintf(&sb, "### %s\n\n", f.label)
+			if len(f.values) == 1 {
+				sb.WriteString(f.values[0] + "\n\n")
+				continue
+			}
+			for _, v := range f.values {
+				sb.WriteString("- " + v + "\n")
+			}
+			sb.WriteString("\n")
+		}
+		if body == "" {
+			body = "_No response_"
+		}
+		sb.WriteString("### Description\n\n" + body + "\n")
+		return sb.String()
+	}
+
+	sb.WriteString("# " + b.Title + "\n\n")
+	sb.WriteString("| Field | Value |\n| --- | --- |\n")
+	for _, f := range bundleFields(b) {
+		sep := "<br>"
+		if f.label == "Tags" {
+			sep = ", "
+		}
+		value := strings.ReplaceAll(strings.Join(f.values, sep), "|", `\|`)
+		fmt.Fprintf(&sb, "| %s | %s |\n", f.label, value)
+	}
+	if body != "" {
+		sb.WriteString("\n" + body + "\n")
+	}
+	return sb.String()
+}
+
+// issueBundle is what parseBundle reads from a bundle document.
+type issueBundle struct {
+	title string
+	// fields maps lower-case field labels to their raw values; see
+	// splitValues for fields with several.
+	fields map[string]string
+	body   string
+}
+
+// bundleLabels are the field labels parseBundle recognises, lower-cased.
+var bundleLabels = map[string]bool{
+	"id": true, "summary": true, "status": true, "type": true, "priority": true,
+	"tags": true, "milestone": true, "iteration": true, "due": true,
+	"parent": true, "blocking": true, "blocked by": true,
+}
+
+var (
+	tableSeparatorRe = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	// definitionRe matches "Status: ready", "**Status:** ready",
+	// "- **Status**: ready", and similar one-line definitions.
+	definitionRe = regexp.MustCompile(`^(?:[-*]\s+)?(?:\*\*|__)?([A-Za-z][A-Za-z ]*?)(?::(?:\*\*|__)|(?:\*\*|__):|:)\s*(.*)$`)
+	refIDRe      = regexp.MustCompile("`([^`]+)`")
+	trailingIDRe = regexp.MustCompile(`\(([^()\s]+)\)\s*$`)
+)
+
+// parseBundle reads a bundle in either format, or a hand-written document
+// close to one: a first "# " heading is the title; a metadata table, a
+// definition list, or "### Field" sections right after it are the fields;
+// everything else is the body. Unknown fields are left in the body.
+func parseBundle(text string) issueBundle {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	doc := issueBundle{fields: map[string]string{}}
+
+	i := skipBlank(lines, 0)
+	if i < len(lines) && strings.HasPrefix(lines[i], "# ") {
+		doc.title = strings.TrimSpace(strings.TrimPrefix(lines[i], "# "))
+		i++
+	}
+
+	for {
+		i = skipBlank(lines, i)
+		if i >= len(lines) {
+			break
+		}
+		line := strings.TrimSpace(lines[i])
+		if label, ok := sectionLabel(line); ok {
+			end := i + 1
+			for end < len(lines) {
+				if _, next := sectionLabel(strings.TrimSpace(lines[end])); next {
+					break
+				}
+				end++
+			}
+			if label == "description" {
+				doc.body = strings.Join(lines[i+1:], "\n")
+				i = len(lines)
+				break
+			}
+			doc.fields[label] = sectionValue(lines[i+1 : end])
+			i = end
+			continue
+		}
+		if strings.HasPrefix(line, "|") {
+			next, ok := parseBundleTable(lines, i, doc.fields)
+			if !ok {
+				break
+			}
+			i = next
+			continue
+		}
+		if i+1 < len(lines) && bundleLabels[strings.ToLower(line)] && strings.HasPrefix(lines[i+1], ":") {
+			doc.fields[strings.ToLower(line)] = strings.TrimSpace(strings.TrimPrefix(lines[i+1], ":"))
+			i += 2
+			continue
+		}
+		if m := definitionRe.FindStringSubmatch(line); m != nil && bundleLabels[strings.ToLower(m[1])] {
+			doc.fields[strings.ToLower(m[1])] = strings.TrimSpace(m[2])
+			i++
+			continue
+		}
+		break
+	}
+
+	if i < len(lines) {
+		doc.body = strings.Join(lines[i:], "\n")
+	}
+	doc.body = strings.TrimSpace(doc.body)
+	if doc.body == "_No response_" {
+		doc.body = ""
+	}
+	return doc
+}
+
+// skipBlank returns the index of the first non-blank line at or after i.
+func skipBlank(lines []string, i int) int {
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	return i
+}
+
+// sectionLabel reports the field label of a "### Label" issue form heading,
+// including the form's Description section.
+func sectionLabel(line string) (string, bool) {
+	rest, ok := strings.CutPrefix(line, "### ")
+	if !ok {
+		return "", false
+	}
+	label := strings.ToLower(strings.TrimSpace(rest))
+	return label, label == "description" || bundleLabels[label]
+}
+
+// parseBundleTable reads the markdown table starting at lines[i] into fields
+// and returns the index after it. It reports false, consuming nothing, when
+// the table holds no known field labels.
+func parseBundleTable(lines []string, i int, fields map[string]string) (int, bool) {
+	found := map[string]string{}
+	end := i
+	for ; end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "|"); end++ {
+		row := strings.TrimSpace(lines[end])
+		if tableSeparatorRe.MatchString(row) {
+			continue
+		}
+		cells := tableCells(row)
+		if len(cells) < 2 {
+			continue
+		}
+		if label := strings.ToLower(strings.Trim(cells[0], "*_ ")); bundleLabels[label] {
+			found[label] = cells[1]
+		}
+	}
+	if len(found) == 0 {
+		return i, false
+	}
+	maps.Copy(fields, found)
+	return end, true
+}
+
+// tableCells splits a markdown table row into trimmed cells, honouring
+// escaped pipes.
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// sectionValue reads an issue form section: its "- " items joined with
+// "<br>" as in a table cell, or else its text.
+func sectionValue(lines []string) string {
+	var items []string
+	for _, line := range lines {
+		if item, ok := strings.CutPrefix(strings.TrimSpace(line), "- "); ok {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+	if len(items) > 0 {
+		return strings.Join(items, "<br>")
+	}
+	value := strings.TrimSpace(strings.Join(lines, " "))
+	if value == "_No response_" {
+		return ""
+	}
+	return value
+}
+
+// splitValues splits a table cell or definition into values at "<br>" or,
+// failing that, commas outside of title text.
+func splitValues(s string) []string {
+	if s == "" || s == "_No response_" {
+		return nil
+	}
+	parts := strings.Split(s, "<br>")
+	if len(parts) == 1 && !strings.Contains(s, "(`") {
+		parts = strings.Split(s, ",")
+	}
+	var values []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			values = append(values, p)
+		}
+	}
+	return values
+}
+
+// bundleRef returns the ID in a "Title (`id`)" reference, or the reference
+// itself when it is a bare ID.
+func bundleRef(ref string) string {
+	if m := refIDRe.FindAllStringSubmatch(ref, -1); m != nil {
+		return m[len(m)-1][1]
+	}
+	if m := trailingIDRe.FindStringSubmatch(ref); m != nil {
+		return m[1]
+	}
+	return strings.TrimSpace(ref)
+}
+
+// bundleFlags maps bundle field labels to the create flags they fill.
+var bundleFlags = []struct{ label, flag string }{
+	{"summary", "summary"},
+	{"status", "status"},
+	{"type", "type"},
+	{"priority", "priority"},
+	{"tags", "tag"},
+	{"milestone", "milestone"},
+	{"iteration", "iteration"},
+	{"due", "due"},
+	{"parent", "parent"},
+	{"blocking", "blocking"},
+	{"blocked by", "blocked-by"},
+}
+
+// applyBundle sets each create flag not given on the command line from the
+// matching bundle field. Values this project can't take, such as a status
+// it doesn't define or a link to an issue that isn't here, are dropped with
+// a warning so a bundle from elsewhere still imports.
+func applyBundle(cmd *cobra.Command, doc issueBundle) error {
+	for _, bf := range bundleFlags {
+		raw, ok := doc.fields[bf.label]
+		if !ok || cmd.Flags().Changed(bf.flag) {
+			continue
+		}
+		values := []string{raw}
+		switch bf.label {
+		case "tags", "milestone", "parent", "blocking", "blocked by":
+			values = splitValues(raw)
+		}
+		for _, v := range values {
+			v = strings.Trim(v, "`")
+			switch bf.label {
+			case "milestone", "parent", "blocking", "blocked by":
+				v = bundleRef(v)
+			}
+			if v == "" {
+				continue
+			}
+			if err := checkBundleValue(bf.label, v); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring bundle %s %q: %v\n", bf.label, v, err)
+				continue
+			}
+			if err := cmd.Flags().Set(bf.flag, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkBundleValue reports why a bundle field value doesn't fit this project.
+func checkBundleValue(label, value string) error {
+	switch label {
+	case "summary":
+		return issue.ValidateSummary(value)
+	case "status":
+		if err := todoCfg.ValidateStatus(value); err != nil {
+			return err
+		}
+		if !todoCfg.IsStatusEnabled(value) {
+			return fmt.Errorf("status %q is disabled in this project", value)
+		}
+	case "type":
+		return todoCfg.ValidateType(value)
+	case "priority":
+		return todoCfg.ValidatePriority(value)
+	case "milestone":
+		if !todoStore.MilestoneExists(value) {
+			return fmt.Errorf("milestone not found: %s", value)
+		}
+	case "iteration":
+		_, err := todoCfg.ResolveIteration(value, todoStore.Now())
+		return err
+	case "due":
+		_, err := issue.ParseDueDate(value)
+		return err
+	case "parent", "blocking", "blocked by":
+		_, err := todoStore.Get(value)
+		return err
+	}
+	return nil
+}
+
+func init() {
+	bundleCmd.Flags().StringVar(&bundleFormat, "format", bundleMarkdown, "Output format: markdown or gh-issue")
+	bundleCmd.Flags().BoolVar(&bundleInternal, "include-internal", false, "Allow bundling an internal issue")
+	todoCmd.AddCommand(bundleCmd)
+}
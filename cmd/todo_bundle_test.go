@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// resetCreateFlags restores every create flag when the test ends, since
+// --from-bundle sets the flags a bundle fills.
+func resetCreateFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		createCmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				_ = sv.Replace(nil)
+			} else {
+				_ = f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		})
+	})
+}
+
+// createIssueFromBundle runs create --from-bundle on text and returns the new issue.
+func createIssueFromBundle(t *testing.T, c *core.Core, text string, args ...string) *issue.Issue {
+	t.Helper()
+	resetCreateFlags(t)
+	path := filepath.Join(t.TempDir(), "bundle.md")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runJSONCommand(t, createCmd, map[string]string{"from-bundle": path, "json": "true"}, args...)
+	if err != nil {
+		t.Fatalf("create --from-bundle error = %v\n%s", err, out)
+	}
+	var resp struct {
+		Issue issue.Issue `json:"issue"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parsing create output: %v\n%s", err, out)
+	}
+	b, err := c.Get(resp.Issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	testCore := setupConflictTest(t)
+	todoCfg.ExtraStatuses = map[string]bool{"in-progress": true}
+	m := &issue.Milestone{Name: "v1.0"}
+	if err := testCore.CreateMilestone(m); err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*issue.Issue{
+		{ID: "epc-1", Title: "Auth, epic", Status: "ready", Type: "epic"},
+		{ID: "blk-1", Title: "Upgrade the | session store", Status: "ready", Type: "task"},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	due, _ := issue.ParseDueDate("2026-03-01")
+	src := &issue.Issue{
+		ID:        "src-1",
+		Title:     "Login fails after timeout",
+		Summary:   "Session expiry drops the redirect",
+		Status:    "in-progress",
+		Type:      "bug",
+		Priority:  "high",
+		Tags:      []string{"auth", "regression"},
+		Milestone: m.ID,
+		Iteration: "2026-W09",
+		Due:       due,
+		Parent:    "epc-1",
+		BlockedBy: []string{"blk-1"},
+		Body:      "## Steps\n\n1. Sign in\n2. Wait an hour\n\n### Notes\n\n| a | b |\n| - | - |\n| 1 | 2 |",
+	}
+	if err := testCore.Create(src); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{bundleMarkdown, bundleGHIssue} {
+		t.Run(format, func(t *testing.T) {
+			first := renderBundle(src, format)
+			var args []string
+			if format == bundleGHIssue {
+				args = []string{src.Title} // the form leaves the title out
+			}
+			got := createIssueFromBundle(t, testCore, first, args...)
+
+			if got.ID == src.ID {
+				t.Fatal("created issue reused the bundled ID")
+			}
+			if got.Title != src.Title || got.Body != src.Body {
+				t.Errorf("title/body = %q / %q, want %q / %q", got.Title, got.Body, src.Title, src.Body)
+			}
+			second := strings.ReplaceAll(renderBundle(got, format), got.ID, src.ID)
+			if second != first {
+				t.Errorf("bundle after round trip differs:\n--- first\n%s\n--- second\n%s", first, second)
+			}
+		})
+	}
+}
+
+func TestCreateFromBundleTolerant(t *testing.T) {
+	testCore := setupConflictTest(t)
+
+	t.Run("no metadata table", func(t *testing.T) {
+		got := createIssueFromBundle(t, testCore, "# Plain report\n\nSomething broke.\n\n- [ ] fix it\n")
+		if got.Title != "Plain report" || got.Body != "Something broke.\n\n- [ ] fix it" {
+			t.Errorf("issue = %q %q", got.Title, got.Body)
+		}
+		if got.Status != todoCfg.GetDefaultStatus() || got.Type != todoCfg.GetDefaultType() {
+			t.Errorf("status/type = %s/%s, want the defaults", got.Status, got.Type)
+		}
+	})
+
+	t.Run("unknown values dropped, flags win", func(t *testing.T) {
+		text := "# Upstream bug\n\n| Field | Value |\n|---|---|\n| Status | triaged |\n| Priority | high |\n| Type | bug |\n| Parent | Elsewhere (`zzz-999`) |\n\nBody.\n"
+		resetCreateFlags(t)
+		if err := createCmd.Flags().Set("type", "feature"); err != nil {
+			t.Fatal(err)
+		}
+		got := createIssueFromBundle(t, testCore, text)
+		if got.Status != todoCfg.GetDefaultStatus() || got.Priority != "high" || got.Type != "feature" || got.Parent != "" {
+			t.Errorf("status/priority/type/parent = %s/%s/%s/%q", got.Status, got.Priority, got.Type, got.Parent)
+		}
+		if got.Body != "Body." {
+			t.Errorf("body = %q", got.Body)
+		}
+	})
+}
+
+func TestParseBundle(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		title  string
+		fields map[string]string
+		body   string
+	}{
+		{
+			name:   "definition list",
+			text:   "# Title\n\n**Status:** ready\n- **Tags**: a, b\nPriority: low\nDue\n: 2026-01-02\n\nBody text: with a colon.",
+			title:  "Title",
+			fields: map[string]string{"status": "ready", "tags": "a, b", "priority": "low", "due": "2026-01-02"},
+			body:   "Body text: with a colon.",
+		},
+		{
+			name:   "issue form without title",
+			text:   "### Status\n\nready\n\n### Tags\n\n- a\n- b\n\n### Milestone\n\n_No response_\n\n### Description\n\n### Heading kept\n\ntext",
+			fields: map[string]string{"status": "ready", "tags": "a<br>b", "milestone": ""},
+			body:   "### Heading kept\n\ntext",
+		},
+		{
+			name:   "unrelated table stays in the body",
+			text:   "# T\n\n| Col | Val |\n|---|---|\n| x | y |\n",
+			title:  "T",
+			fields: map[string]string{},
+			body:   "| Col | Val |\n|---|---|\n| x | y |",
+		},
+		{
+			name:   "no heading",
+			text:   "\r\nJust a note.\r\n",
+			fields: map[string]string{},
+			body:   "Just a note.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := parseBundle(tt.text)
+			if doc.title != tt.title || doc.body != tt.body {
+				t.Errorf("title/body = %q / %q, want %q / %q", doc.title, doc.body, tt.title, tt.body)
+			}
+			if len(doc.fields) != len(tt.fields) {
+				t.Errorf("fields = %q, want %q", doc.fields, tt.fields)
+			}
+			for k, v := range tt.fields {
+				if doc.fields[k] != v {
+					t.Errorf("fields[%s] = %q, want %q", k, doc.fields[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/changes"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	burndownInterval string
+	burndownSince    string
+	burndownJSON     bool
+)
+
+// sparkBlocks are the bar heights of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown <milestone-id>",
+	Short: "Show how many of a milestone's issues are open over time",
+	Long: `Counts the open issues in a milestone at each interval from the milestone's
+creation (or --since) until now. An issue is in the milestone when it is
+assigned to it or its parent chain reaches one that is.
+
+Each point is read from git: the issue files as committed at that time, and
+the files on disk for the final point. Where the data directory is not
+tracked, points are estimated from created_at, with a closed issue's
+updated_at standing in for when it was closed.
+
+The terminal output is a sparkline and a table with the issues added to
+(+) and removed from (-) the milestone and completed (✓) in each interval,
+so scope changes stand apart from progress. --json prints the series with
+the IDs behind each count.
+
+--since takes a duration ("4w") or a date ("2026-03-04" or RFC 3339).`,
+	Example: `  jig todo burndown ms-v1
+  jig todo burndown ms-v1 --interval 1w --since 2026-03-01
+  jig todo burndown ms-v1 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := todoStore.GetMilestone(args[0])
+		if err != nil {
+			return cmdError(burndownJSON, output.ErrNotFound, "milestone not found: %s", args[0])
+		}
+		interval, err := todoconfig.ParseDuration(burndownInterval)
+		if err != nil || interval <= 0 {
+			return cmdError(burndownJSON, output.ErrValidation, "invalid --interval %q: expected a positive duration (12h, 1d, 1w)", burndownInterval)
+		}
+
+		now := todoStore.Now()
+		since := now.Add(-todoconfig.Week)
+		switch {
+		case burndownSince != "":
+			if since, err = parseSince(burndownSince, now); err != nil {
+				return cmdError(burndownJSON, output.ErrValidation, "%w", err)
+			}
+		case m.CreatedAt != nil:
+			since = *m.CreatedAt
+		}
+
+		bd, err := changes.BuildBurndown(changes.BurndownOptions{
+			Milestone: m.ID,
+			Since:     since,
+			Until:     now,
+			Interval:  interval,
+			DataDir:   todoStore.Root(),
+			Parse:     todoStore.ParseIssue,
+			Current:   todoStore.All(),
+		})
+		if err != nil {
+			return cmdError(burndownJSON, output.ErrValidation, "%w", err)
+		}
+
+		if burndownJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(bd)
+		}
+		writeBurndown(os.Stdout, m, bd, interval)
+		return nil
+	},
+}
+
+// writeBurndown prints a heading, a sparkline of open counts, and a row per
+// point.
+func writeBurndown(w io.Writer, m *issue.Milestone, bd *changes.Burndown, interval time.Duration) {
+	source := "git"
+	if bd.Source == changes.SourceUpdatedAt {
+		source = "estimated from timestamps"
+	}
+	fmt.Fprintf(w, "%s %s %s\n", ui.ID.Render(m.ID), ui.Bold.Render(m.Name), ui.Muted.Render("("+source+")"))
+
+	open := make([]int, len(bd.Points))
+	for i, p := range bd.Points {
+		open[i] = p.Open
+	}
+	last := bd.Points[len(bd.Points)-1]
+	fmt.Fprintf(w, "%s  %d of %d open\n\n", sparkline(open), last.Open, last.Total)
+
+	layout := time.DateOnly
+	if interval < todoconfig.Day {
+		layout = "2006-01-02 15:04"
+	}
+	fmt.Fprintln(w, ui.Muted.Render(fmt.Sprintf("%-*s  %5s  %5s  %s", len(layout), "AT", "OPEN", "TOTAL", "CHANGES")))
+	for _, p := range bd.Points {
+		var changed []string
+		for _, part := range []struct {
+			mark string
+			ids  []string
+		}{{"+", p.Added}, {"-", p.Removed}, {"✓", p.Completed}, {"↺", p.Reopened}} {
+			if len(part.ids) > 0 {
+				changed = append(changed, fmt.Sprintf("%s%d", part.mark, len(part.ids)))
+			}
+		}
+		fmt.Fprintf(w, "%-*s  %5d  %5d  %s\n", len(layout), p.At.Local().Format(layout), p.Open, p.Total, strings.Join(changed, " "))
+	}
+}
+
+// sparkline draws values as bars scaled to the largest.
+func sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		if peak == 0 {
+			sb.WriteRune(sparkBlocks[0])
+			continue
+		}
+		sb.WriteRune(sparkBlocks[v*(len(sparkBlocks)-1)/peak])
+	}
+	return sb.String()
+}
+
+func init() {
+	burndownCmd.Flags().StringVar(&burndownInterval, "interval", "1d", "Time between points (e.g. 12h, 1d, 1w)")
+	burndownCmd.Flags().StringVar(&burndownSince, "since", "", "Start of the series (default: when the milestone was created)")
+	burndownCmd.Flags().BoolVar(&burndownJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(burndownCmd)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/changes"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestBurndownJSON(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+
+	now := time.Now()
+	at := func(ago time.Duration) {
+		testCore.SetClock(func() time.Time { return now.Add(-ago) })
+	}
+	at(5 * todoconfig.Day)
+	if err := testCore.CreateMilestone(&issue.Milestone{ID: "ms-bd", Name: "Release"}); err != nil {
+		t.Fatal(err)
+	}
+	at(84 * time.Hour)
+	createQueryTestIssue(t, testCore, "bd-a", "First", "ready")
+	at(60 * time.Hour)
+	createQueryTestIssue(t, testCore, "bd-b", "Second", "ready")
+	for _, id := range []string{"bd-a", "bd-b"} {
+		b, _ := testCore.Get(id)
+		b.Milestone = "ms-bd"
+		if err := testCore.Update(b, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	at(36 * time.Hour)
+	b, _ := testCore.Get("bd-a")
+	b.Status = todoconfig.StatusCompleted
+	if err := testCore.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	at(0)
+
+	out, err := runJSONCommand(t, burndownCmd, map[string]string{"json": "true", "since": "4d"}, "ms-bd")
+	if err != nil {
+		t.Fatalf("burndown: %v", err)
+	}
+	var bd changes.Burndown
+	if err := json.Unmarshal([]byte(out), &bd); err != nil {
+		t.Fatalf("output is not a burndown: %v\n%s", err, out)
+	}
+	if bd.Source != changes.SourceUpdatedAt {
+		t.Errorf("source = %q, want %q", bd.Source, changes.SourceUpdatedAt)
+	}
+	var open []int
+	for _, p := range bd.Points {
+		open = append(open, p.Open)
+	}
+	if want := []int{0, 1, 2, 1, 1}; !slices.Equal(open, want) {
+		t.Errorf("open = %v, want %v", open, want)
+	}
+	if got := bd.Points[2].Added; !slices.Equal(got, []string{"bd-b"}) {
+		t.Errorf("added at point 2 = %v, want [bd-b]", got)
+	}
+	if got := bd.Points[3].Completed; !slices.Equal(got, []string{"bd-a"}) {
+		t.Errorf("completed at point 3 = %v, want [bd-a]", got)
+	}
+
+	_, err = runJSONCommand(t, burndownCmd, map[string]string{"json": "true"}, "nope")
+	if got := errorCode(err, ""); got != output.ErrNotFound {
+		t.Errorf("unknown milestone: code %q, want %s (error: %v)", got, output.ErrNotFound, err)
+	}
+	_, err = runJSONCommand(t, burndownCmd, map[string]string{"json": "true", "interval": "0d"}, "ms-bd")
+	if got := errorCode(err, ""); got != output.ErrValidation {
+		t.Errorf("zero --interval: code %q, want %s (error: %v)", got, output.ErrValidation, err)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{nil, ""},
+		{[]int{0, 0}, "▁▁"},
+		{[]int{7, 4, 0}, "█▅▁"},
+		{[]int{3, 3}, "██"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
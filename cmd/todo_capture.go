@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	captureClipboard bool
+	captureDryRun    bool
+	captureJSON      bool
+)
+
+// Trailing capture tokens. Tags must start with a letter so that issue
+// numbers ("fix crash #123") stay in the title.
+var (
+	captureTagPattern    = regexp.MustCompile(`^#\pL[\pL\pN_./-]*$`)
+	captureParentPattern = regexp.MustCompile(`^\^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+)
+
+// capturedIssue is what capture read from its input.
+type capturedIssue struct {
+	Title    string         `json:"title"`
+	Body     string         `json:"body,omitempty"`
+	Tags     []string       `json:"tags,omitempty"`
+	Priority string         `json:"priority,omitempty"`
+	Due      *issue.DueDate `json:"due,omitempty"`
+	Parent   string         `json:"parent,omitempty"`
+}
+
+var captureCmd = &cobra.Command{
+	Use:   "capture",
+	Short: "Create an issue from stdin or the clipboard",
+	Long: `Reads text from stdin (or the clipboard with --clipboard) and creates an
+issue from it: the first line is the title and the rest the body.
+
+Words at the end of the title set fields and are removed from it:
+
+  #tag          add a tag (must start with a letter)
+  !high         set the priority
+  @friday       set the due date (today, tomorrow, a weekday, 3d, 2w,
+                or YYYY-MM-DD)
+  ^abc-123      set the parent
+
+Only the trailing run of such words is read, so a "#" or "@" earlier in the
+title, or inside a code span, is left alone. The created issue's ID is
+printed on its own line.`,
+	Example: `  echo "Fix login redirect #auth !high @friday" | jig todo capture
+  jig todo capture --clipboard --dry-run
+  pbpaste | jig todo capture --json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{porcelainAnnotation: "id\tetag\tpath"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text, err := readCaptureInput(captureClipboard)
+		if err != nil {
+			return cmdError(captureJSON, output.ErrFileError, "%w", err)
+		}
+		captured, err := parseCapture(text, todoCfg.PriorityNames(), todoStore.Now())
+		if err != nil {
+			return cmdError(captureJSON, output.ErrValidation, "%w", err)
+		}
+
+		mode := resolveOutputMode(captureJSON)
+		if captureDryRun {
+			return mode.write(commandResult{
+				JSON: func() error {
+					enc := json.NewEncoder(os.Stdout)
+					enc.SetIndent("", "  ")
+					return enc.Encode(captured)
+				},
+				Text: func() error {
+					writeCaptured(os.Stdout, captured)
+					return nil
+				},
+			})
+		}
+
+		status := todoCfg.GetDefaultStatus()
+		typ := todoCfg.GetDefaultType()
+		input := model.CreateIssueInput{Title: captured.Title, Status: &status, Type: &typ, Tags: captured.Tags}
+		if captured.Body != "" {
+			input.Body = &captured.Body
+		}
+		if captured.Priority != "" {
+			input.Priority = &captured.Priority
+		}
+		if captured.Due != nil {
+			due := captured.Due.String()
+			input.Due = &due
+		}
+		if captured.Parent != "" {
+			input.Parent = &captured.Parent
+		}
+
+		resolver := &graph.Resolver{Core: todoStore}
+		b, err := resolver.Mutation().CreateIssue(cliContext(), input)
+		if _, ok := errors.AsType[*core.SizeError](err); ok {
+			return mutationError(captureJSON, err)
+		}
+		if err != nil {
+			return cmdError(captureJSON, output.ErrFileError, "failed to create issue: %w", err)
+		}
+
+		return mode.write(commandResult{
+			JSON:      func() error { return output.Success(b, "Issue created") },
+			Porcelain: func() error { return writePorcelain(os.Stdout, b.ID, b.ETag(), b.Path) },
+			Quiet:     []string{b.ID},
+			Text:      func() error { return printQuiet(b.ID) },
+		})
+	},
+}
+
+// readCaptureInput returns the clipboard text, or all of stdin.
+func readCaptureInput(fromClipboard bool) (string, error) {
+	if fromClipboard {
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			return "", fmt.Errorf("failed to read clipboard: %w", err)
+		}
+		return text, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return string(data), nil
+}
+
+// parseCapture splits text into a title line and body, then reads the
+// trailing field tokens off the title. Scanning stops at the first word that
+// is not a token, a token of a kind already seen, or one that sits inside an
+// open code span, and the first word of the title is never taken.
+func parseCapture(text string, priorities []string, now time.Time) (*capturedIssue, error) {
+	text = strings.TrimLeft(strings.ReplaceAll(text, "\r\n", "\n"), "\n \t")
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("nothing to capture: input is empty")
+	}
+	line, body, _ := strings.Cut(text, "\n")
+	c := &capturedIssue{Body: strings.Trim(body, "\n")}
+
+	words := strings.Fields(line)
+	end := len(words)
+	for end > 1 {
+		word := words[end-1]
+		if strings.Count(strings.Join(words[:end-1], " "), "`")%2 == 1 {
+			break
+		}
+		if !c.takeToken(word, priorities, now) {
+			break
+		}
+		end--
+	}
+	c.Title = strings.Join(words[:end], " ")
+	// Tags were read right to left.
+	for i, j := 0, len(c.Tags)-1; i < j; i, j = i+1, j-1 {
+		c.Tags[i], c.Tags[j] = c.Tags[j], c.Tags[i]
+	}
+	return c, nil
+}
+
+// takeToken applies word to c if it is a field token, reporting whether it
+// was one.
+func (c *capturedIssue) takeToken(word string, priorities []string, now time.Time) bool {
+	switch {
+	case captureTagPattern.MatchString(word):
+		c.Tags = append(c.Tags, word[1:])
+		return true
+	case strings.HasPrefix(word, "!") && c.Priority == "":
+		for _, p := range priorities {
+			if strings.EqualFold(word[1:], p) {
+				c.Priority = p
+				return true
+			}
+		}
+	case strings.HasPrefix(word, "@") && c.Due == nil:
+		if due, err := issue.ParseRelativeDue(word[1:], now); err == nil {
+			c.Due = due
+			return true
+		}
+	case captureParentPattern.MatchString(word) && c.Parent == "":
+		c.Parent = word[1:]
+		return true
+	}
+	return false
+}
+
+// writeCaptured prints the fields a capture would create.
+func writeCaptured(w io.Writer, c *capturedIssue) {
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "%s %s\n", ui.Muted.Render(fmt.Sprintf("%-9s", label)), value)
+		}
+	}
+	row("title:", c.Title)
+	row("tags:", strings.Join(c.Tags, ", "))
+	row("priority:", c.Priority)
+	if c.Due != nil {
+		row("due:", c.Due.String())
+	}
+	row("parent:", c.Parent)
+	if c.Body != "" {
+		fmt.Fprintf(w, "\n%s\n", c.Body)
+	}
+}
+
+func init() {
+	captureCmd.Flags().BoolVar(&captureClipboard, "clipboard", false, "Read from the clipboard instead of stdin")
+	captureCmd.Flags().BoolVar(&captureDryRun, "dry-run", false, "Show the parsed fields without creating the issue")
+	captureCmd.Flags().BoolVar(&captureJSON, "json", false, "Output as JSON")
+	addQuietFlag(captureCmd)
+	todoCmd.AddCommand(captureCmd)
+}
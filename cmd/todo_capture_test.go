@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestParseCapture(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		input    string
+		title    string
+		body     string
+		tags     []string
+		priority string
+		due      string
+		parent   string
+	}{
+		{name: "plain", input: "Fix login redirect\n", title: "Fix login redirect"},
+		{
+			name:     "all tokens",
+			input:    "Fix login redirect #auth #web !high @friday ^epc-123",
+			title:    "Fix login redirect",
+			tags:     []string{"auth", "web"},
+			priority: "high",
+			due:      "2025-06-13",
+			parent:   "epc-123",
+		},
+		{name: "priority case", input: "Outage !Critical", title: "Outage", priority: "critical"},
+		{name: "due date", input: "Ship it @2025-07-01", title: "Ship it", due: "2025-07-01"},
+		{
+			name:  "body after first line",
+			input: "\n  Write docs #docs\n\nCover the sync flags.\nAnd webhooks.\n\n",
+			title: "Write docs",
+			body:  "Cover the sync flags.\nAnd webhooks.",
+			tags:  []string{"docs"},
+		},
+		// Only the trailing run of tokens is read.
+		{name: "inner hash kept", input: "Use #region markers in parser #code", title: "Use #region markers in parser", tags: []string{"code"}},
+		{name: "issue number kept", input: "Fix crash from #123", title: "Fix crash from #123"},
+		{name: "unknown priority stops", input: "Deploy !now #ops", title: "Deploy !now", tags: []string{"ops"}},
+		{name: "unparsed due stops", input: "Email @bob", title: "Email @bob"},
+		{name: "second priority stops", input: "Urgent !low !high", title: "Urgent !low", priority: "high"},
+		{name: "code span kept", input: "Document `grep #todo` usage", title: "Document `grep #todo` usage"},
+		{name: "open code span", input: "Run `make #all", title: "Run `make #all"},
+		{name: "tokens after code span", input: "Escape `#` in `a #b` #md", title: "Escape `#` in `a #b`", tags: []string{"md"}},
+		{name: "first word kept", input: "#urgent", title: "#urgent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseCapture(tt.input, todoconfig.DefaultPriorityNames(), now)
+			if err != nil {
+				t.Fatalf("parseCapture() error = %v", err)
+			}
+			if c.Title != tt.title {
+				t.Errorf("title = %q, want %q", c.Title, tt.title)
+			}
+			if c.Body != tt.body {
+				t.Errorf("body = %q, want %q", c.Body, tt.body)
+			}
+			if !slices.Equal(c.Tags, tt.tags) {
+				t.Errorf("tags = %v, want %v", c.Tags, tt.tags)
+			}
+			if c.Priority != tt.priority {
+				t.Errorf("priority = %q, want %q", c.Priority, tt.priority)
+			}
+			due := ""
+			if c.Due != nil {
+				due = c.Due.String()
+			}
+			if due != tt.due {
+				t.Errorf("due = %q, want %q", due, tt.due)
+			}
+			if c.Parent != tt.parent {
+				t.Errorf("parent = %q, want %q", c.Parent, tt.parent)
+			}
+		})
+	}
+
+	if _, err := parseCapture(" \n\n", nil, now); err == nil {
+		t.Error("parseCapture() of blank input: expected an error")
+	}
+}
+
+// runCapture runs the capture command with input on stdin. Flags are reset
+// before it returns.
+func runCapture(t *testing.T, input string, flags map[string]string) (out string, err error) {
+	t.Helper()
+	t.Run("capture", func(t *testing.T) {
+		out, err = runCaptureIn(t, input, flags)
+	})
+	return out, err
+}
+
+func runCaptureIn(t *testing.T, input string, flags map[string]string) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = orig })
+	go func() {
+		_, _ = w.WriteString(input)
+		_ = w.Close()
+	}()
+	return runJSONCommand(t, captureCmd, flags)
+}
+
+func TestCaptureCreatesIssue(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg := todoCfg
+	todoCfg = todoconfig.Default()
+	t.Cleanup(func() { todoCfg = oldCfg })
+	createQueryTestIssue(t, testCore, "epc", "Epic", "ready")
+
+	// --dry-run reports the fields and creates nothing.
+	out, err := runCapture(t, "Fix login #auth !high @tomorrow ^epc\nSteps to reproduce.\n", map[string]string{"dry-run": "true", "json": "true"})
+	if err != nil {
+		t.Fatalf("capture --dry-run: %v", err)
+	}
+	var dry capturedIssue
+	if err := json.Unmarshal([]byte(out), &dry); err != nil {
+		t.Fatalf("dry run output is not JSON: %v\n%s", err, out)
+	}
+	if dry.Title != "Fix login" || dry.Parent != "epc" || dry.Due == nil {
+		t.Errorf("dry run = %+v", dry)
+	}
+	if n := len(testCore.All()); n != 1 {
+		t.Fatalf("--dry-run created an issue: %d issues", n)
+	}
+
+	out, err = runCapture(t, "Fix login #auth !high @tomorrow ^epc\nSteps to reproduce.\n", nil)
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	id := strings.TrimSpace(out)
+	b, err := testCore.Get(id)
+	if err != nil {
+		t.Fatalf("printed ID %q is not an issue: %v", out, err)
+	}
+	tomorrow := testCore.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	if b.Title != "Fix login" || b.Priority != "high" || b.Parent != "epc" || !slices.Equal(b.Tags, []string{"auth"}) {
+		t.Errorf("created %+v", b)
+	}
+	if b.Due == nil || b.Due.String() != tomorrow {
+		t.Errorf("due = %v, want %s", b.Due, tomorrow)
+	}
+	if strings.TrimSpace(b.Body) != "Steps to reproduce." {
+		t.Errorf("body = %q", b.Body)
+	}
+	if b.Status != todoCfg.GetDefaultStatus() {
+		t.Errorf("status = %q, want the default %q", b.Status, todoCfg.GetDefaultStatus())
+	}
+
+	_, err = runCapture(t, "\n", map[string]string{"json": "true"})
+	if got := errorCode(err, ""); got != output.ErrValidation {
+		t.Errorf("empty input: code %q, want %s (error: %v)", got, output.ErrValidation, err)
+	}
+}
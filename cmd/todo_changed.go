@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/changes"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	changedSince        string
+	changedSnapshot     string
+	changedSaveSnapshot string
+	changedJSON         bool
+)
+
+var changedCmd = &cobra.Command{
+	Use:   "changed",
+	Short: "Summarize what changed in issues over a period",
+	Long: `Lists the issues created, deleted, or modified since a point in time, for
+reviewing what agents did during a session. Modified issues name the fields
+that changed, with body edits counted as +added/-removed lines, and the
+output is grouped by the status each issue moved to.
+
+The earlier state is read from git: the issue files as of the last commit
+before --since, compared with the files on disk now. Where the data
+directory is not tracked, record a snapshot with --save-snapshot at the
+start of a session and compare against it later with --snapshot. Without
+either, issues are listed by created_at and updated_at alone, with no
+field details or deletions.
+
+--since takes a duration ("4h", "2d") or a date ("2026-03-04" or RFC 3339).`,
+	Example: `  jig todo changed --since 4h
+  jig todo changed --since 2026-03-04 --json
+  jig todo changed --save-snapshot /tmp/start.json
+  jig todo changed --snapshot /tmp/start.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := todoStore.Now()
+		since, err := parseSince(changedSince, now)
+		if err != nil {
+			return cmdError(changedJSON, output.ErrValidation, "%w", err)
+		}
+
+		current := todoStore.All()
+		report := changes.Report{Since: since}
+		switch {
+		case changedSnapshot != "":
+			before, err := changes.ReadSnapshot(changedSnapshot)
+			if err != nil {
+				return cmdError(changedJSON, output.ErrFileError, "%w", err)
+			}
+			report.Source = changes.SourceSnapshot
+			report.Changes = changes.Diff(before, issueMap(current))
+		default:
+			before, commit, err := changes.GitState(todoStore.Root(), since, todoStore.ParseIssue)
+			switch {
+			case errors.Is(err, changes.ErrNoGit):
+				report.Source = changes.SourceUpdatedAt
+				report.Changes = changes.SinceUpdated(current, since)
+			case err != nil:
+				return cmdError(changedJSON, output.ErrFileError, "%w", err)
+			default:
+				report.Source = changes.SourceGit
+				report.Commit = commit
+				report.Changes = changes.Diff(before, issueMap(current))
+			}
+		}
+		if report.Changes == nil {
+			report.Changes = []changes.Change{}
+		}
+
+		if changedSaveSnapshot != "" {
+			if err := changes.WriteSnapshot(changedSaveSnapshot, current); err != nil {
+				return cmdError(changedJSON, output.ErrFileError, "failed to write %s: %w", changedSaveSnapshot, err)
+			}
+		}
+
+		if changedJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		fmt.Print(formatChangeReport(report, todoconfig.DefaultStatusNames()))
+		return nil
+	},
+}
+
+// parseSince parses --since as a duration before now, a date, or an RFC 3339
+// time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := todoconfig.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: expected a duration (4h, 2d), YYYY-MM-DD, or RFC 3339 time", s)
+}
+
+func issueMap(issues []*issue.Issue) map[string]*issue.Issue {
+	m := make(map[string]*issue.Issue, len(issues))
+	for _, b := range issues {
+		m[b.ID] = b
+	}
+	return m
+}
+
+// formatChangeReport renders a report as a heading per group, each change on
+// one line with its status move and changed fields.
+func formatChangeReport(r changes.Report, statuses []string) string {
+	var sb strings.Builder
+	source := "updated_at only"
+	switch r.Source {
+	case changes.SourceGit:
+		source = "git"
+		if r.Commit != "" {
+			source += " " + shortHash(r.Commit)
+		}
+	case changes.SourceSnapshot:
+		source = "snapshot"
+	}
+	fmt.Fprintf(&sb, "Changes since %s %s\n", r.Since.Local().Format("2006-01-02 15:04"), ui.Muted.Render("("+source+")"))
+	if len(r.Changes) == 0 {
+		sb.WriteString("\nNo changes.\n")
+		return sb.String()
+	}
+
+	for _, g := range changes.Groups(r.Changes, statuses) {
+		noun := "issues"
+		if len(g.Changes) == 1 {
+			noun = "issue"
+		}
+		label := strings.ToUpper(g.Label[:1]) + g.Label[1:]
+		fmt.Fprintf(&sb, "\n%s\n", ui.Bold.Render(fmt.Sprintf("%s: %d %s", label, len(g.Changes), noun)))
+		for _, c := range g.Changes {
+			line := fmt.Sprintf("  %s  %s", ui.ID.Render(c.ID), c.Title)
+			if detail := changeDetail(c); detail != "" {
+				line += "  " + ui.Muted.Render(detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// changeDetail describes a modified issue's status move and other changed
+// fields, e.g. "draft → review; priority, body +4/-1".
+func changeDetail(c changes.Change) string {
+	var parts []string
+	if c.Kind == changes.KindModified && c.StatusChanged() {
+		parts = append(parts, c.StatusFrom+" → "+c.StatusTo)
+	}
+	var fields []string
+	for _, f := range c.Fields {
+		switch f {
+		case "status":
+		case "body":
+			if c.BodyAdded > 0 || c.BodyRemoved > 0 {
+				f = fmt.Sprintf("body +%d/-%d", c.BodyAdded, c.BodyRemoved)
+			}
+			fields = append(fields, f)
+		default:
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) > 0 {
+		parts = append(parts, strings.Join(fields, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
+
+func init() {
+	changedCmd.Flags().StringVar(&changedSince, "since", "24h", "Start of the period: a duration (4h, 2d), YYYY-MM-DD, or RFC 3339 time")
+	changedCmd.Flags().StringVar(&changedSnapshot, "snapshot", "", "Compare against a snapshot file instead of git")
+	changedCmd.Flags().StringVar(&changedSaveSnapshot, "save-snapshot", "", "Write the current state to a snapshot file for a later --snapshot")
+	changedCmd.Flags().BoolVar(&changedJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(changedCmd)
+}
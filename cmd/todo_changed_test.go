@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/changes"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"4h", now.Add(-4 * time.Hour)},
+		{"2d", now.Add(-48 * time.Hour)},
+		{"2026-03-01T09:30:00Z", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.in, now)
+		if err != nil {
+			t.Errorf("parseSince(%q) error = %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := parseSince("yesterday", now); err == nil {
+		t.Error("parseSince(yesterday) should fail")
+	}
+}
+
+func TestFormatChangeReport(t *testing.T) {
+	r := changes.Report{
+		Since:  time.Now().Add(-4 * time.Hour),
+		Source: changes.SourceGit,
+		Commit: "0123456789abcdef",
+		Changes: []changes.Change{
+			{ID: "aaa-111", Title: "Login", Kind: changes.KindModified, Fields: []string{"status", "priority", "body"}, StatusFrom: "in-progress", StatusTo: "review", BodyAdded: 4, BodyRemoved: 1},
+			{ID: "bbb-222", Title: "Signup", Kind: changes.KindModified, Fields: []string{"status"}, StatusFrom: "ready", StatusTo: "review"},
+			{ID: "ccc-333", Title: "Logout", Kind: changes.KindCreated, StatusFrom: "draft", StatusTo: "draft"},
+		},
+	}
+	got := formatChangeReport(r, []string{"in-progress", "review", "ready"})
+	for _, want := range []string{
+		"(git 0123456)",
+		"Created: 1 issue",
+		"Moved to review: 2 issues",
+		"in-progress → review; priority, body +4/-1",
+		"ready → review",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "Created") > strings.Index(got, "Moved to review") {
+		t.Errorf("created group should come first:\n%s", got)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,8 @@ import (
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
@@ -19,24 +22,57 @@ var (
 )
 
 type todoCheckResult struct {
-	Success      bool                  `json:"success"`
-	ConfigErrors []string              `json:"config_errors"`
-	LinkIssues   *core.LinkCheckResult `json:"link_issues,omitempty"`
-	Fixed        int                   `json:"fixed,omitempty"`
+	Success       bool                          `json:"success"`
+	ConfigErrors  []string                      `json:"config_errors"`
+	LinkIssues    *core.LinkCheckResult         `json:"link_issues,omitempty"`
+	UnknownValues []core.UnknownValue           `json:"unknown_values,omitempty"`
+	SyncData      []integration.SyncDataProblem `json:"sync_data,omitempty"`
+	StaleSlugs    []core.StaleSlug              `json:"stale_slugs,omitempty"`
+	LoadWarnings  []core.LoadWarning            `json:"load_warnings,omitempty"`
+	Watcher       todoWatcherStatus             `json:"watcher"`
+	Fixed         int                           `json:"fixed,omitempty"`
+}
+
+// todoWatcherStatus is how the file watcher would run: its mode (empty
+// when it cannot start), debounce, and poll interval, with fsnotify's error
+// when fsnotify cannot watch the data directory.
+type todoWatcherStatus struct {
+	Mode         string `json:"mode"`
+	Debounce     string `json:"debounce"`
+	PollInterval string `json:"poll_interval"`
+	Error        string `json:"error,omitempty"`
 }
 
 var todoCheckCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "Validate configuration and issue integrity",
 	Long: `Checks configuration and issue integrity, including:
-- Configuration settings (colors, default type)
+- Configuration settings (colors, default type, iterations)
 - Sync integration configuration (unknown or multiple integrations)
 - Broken links (links to non-existent issues)
 - Self-references (issues linking to themselves)
+- Blocking links stored on both issues (blocking on one, blocked_by on the other)
 - Circular dependencies (cycles in blocks/parent relationships)
+- Parent chains deeper than max_hierarchy_depth (default 3 parents)
+- Statuses, types, priorities, and iterations the config does not define
+- ClickUp and GitHub sync data with unknown keys, missing required keys, or
+  values of the wrong kind
+- Issue files skipped while loading (unparseable, duplicate IDs, non-issue files),
+  or loaded with a timestamp that had no zone or lay in the future
+- With rename_files_on_title_change, files whose slug no longer matches the
+  issue's title
+- Whether the file watcher can use fsnotify, or polls (watcher.poll_fallback)
 
-Use --fix to automatically remove broken links and self-references.
-Note: Cycles cannot be auto-fixed and require manual intervention.`,
+Use --fix to automatically remove broken links and self-references, to keep
+each blocking link only on the blocker, to remap unknown field values to
+the nearest valid one (or the default when nothing is close), to rename
+sync data keys that differ from a known key only in case or separators
+(task_Id or taskId to task_id), and to break parent cycles by clearing the
+parent of the most recently updated issue in each, to rename stale-slug
+files (with git mv inside a git repository, so history follows), and to
+rewrite files whose timestamps were read as UTC or clamped to the load time.
+Note: Blocking cycles, deep chains, and other sync data problems cannot be
+auto-fixed and require manual intervention.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var configErrors []string
 		var fixed int
@@ -48,12 +84,12 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 
 		// 1. Check statuses are defined (always true since hardcoded)
 		if !todoCheckJSON {
-			fmt.Printf("  %s Statuses defined (%d hardcoded)\n", ui.Success.Render("✓"), len(todoconfig.DefaultStatuses))
+			fmt.Printf("  %s Statuses defined (%d hardcoded)\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), len(todoconfig.DefaultStatuses))
 		}
 
 		// 2. Check default_status exists in statuses (always true since hardcoded)
 		if !todoCheckJSON {
-			fmt.Printf("  %s Default status '%s' exists\n", ui.Success.Render("✓"), todoCfg.GetDefaultStatus())
+			fmt.Printf("  %s Default status '%s' exists\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), todoCfg.GetDefaultStatus())
 		}
 
 		// 2b. Check default_type is a valid hardcoded type
@@ -61,7 +97,7 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 			configErrors = append(configErrors, fmt.Sprintf("default_type '%s' is not a valid type", todoCfg.GetDefaultType()))
 		} else if todoCfg.GetDefaultType() != "" {
 			if !todoCheckJSON {
-				fmt.Printf("  %s Default type '%s' is valid\n", ui.Success.Render("✓"), todoCfg.GetDefaultType())
+				fmt.Printf("  %s Default type '%s' is valid\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), todoCfg.GetDefaultType())
 			}
 		}
 
@@ -79,13 +115,13 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 				}
 			}
 			if colorErrors == 0 {
-				fmt.Printf("  %s All status colors valid\n", ui.Success.Render("✓"))
+				fmt.Printf("  %s All status colors valid\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
 			}
 		}
 
-		// 4. Check all type colors are valid (hardcoded types)
-		for _, t := range todoconfig.DefaultTypes {
-			if !ui.IsValidColor(t.Color) {
+		// 4. Check all type colors are valid (built-in and project-defined types)
+		for _, t := range todoCfg.TypeConfigs() {
+			if t.Color != "" && !ui.IsValidColor(t.Color) {
 				configErrors = append(configErrors, fmt.Sprintf("invalid color '%s' for type '%s'", t.Color, t.Name))
 			}
 		}
@@ -97,10 +133,26 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 				}
 			}
 			if typeColorErrors == 0 {
-				fmt.Printf("  %s All type colors valid\n", ui.Success.Render("✓"))
+				fmt.Printf("  %s All type colors valid\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
 			}
 		}
 
+		// 4b. Check allowed_parents only names known types
+		for _, t := range todoCfg.TypeConfigs() {
+			if t.Name == "" {
+				configErrors = append(configErrors, "type entry with an empty name")
+				continue
+			}
+			for _, p := range t.AllowedParents {
+				if p != todoconfig.TypeMilestone && !todoCfg.IsValidType(p) {
+					configErrors = append(configErrors, fmt.Sprintf("type '%s' allows unknown parent type '%s'", t.Name, p))
+				}
+			}
+		}
+
+		// 4c. Check declared iterations have names and valid dates
+		configErrors = append(configErrors, todoCfg.IterationErrors()...)
+
 		// 5. Check `extra_statuses` is populated for sync-enabled projects.
 		// `extra_statuses` is purely additive — missing or empty means only
 		// `ready` and `completed` work. Projects that sync to ClickUp or
@@ -111,7 +163,7 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 		if (hasGithub || hasClickup) && len(todoCfg.ExtraStatuses) == 0 {
 			configErrors = append(configErrors, "sync integration configured but `todo.extra_statuses` is missing — only `ready` and `completed` are enabled. Run `jig update` to populate the map (adds the historical default statuses; excludes `review` for github-synced projects).")
 		} else if (hasGithub || hasClickup) && !todoCheckJSON {
-			fmt.Printf("  %s `todo.extra_statuses` populated (%d entries)\n", ui.Success.Render("✓"), len(todoCfg.ExtraStatuses))
+			fmt.Printf("  %s `todo.extra_statuses` populated (%d entries)\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), len(todoCfg.ExtraStatuses))
 		}
 
 		// 6. Check sync configuration
@@ -129,14 +181,14 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 				slices.Sort(configuredIntegrations)
 				configErrors = append(configErrors, fmt.Sprintf("multiple sync integrations configured (%s); only one is supported at a time", strings.Join(configuredIntegrations, ", ")))
 			} else if len(configuredIntegrations) == 1 && !todoCheckJSON {
-				fmt.Printf("  %s Sync integration '%s' configured\n", ui.Success.Render("✓"), configuredIntegrations[0])
+				fmt.Printf("  %s Sync integration '%s' configured\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), configuredIntegrations[0])
 			}
 		}
 
 		// Print config errors in human-readable mode
 		if !todoCheckJSON {
 			for _, e := range configErrors {
-				fmt.Printf("  %s %s\n", ui.Danger.Render("✗"), e)
+				fmt.Printf("  %s %s\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), e)
 			}
 		}
 
@@ -158,10 +210,10 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 
 			if !todoCheckJSON {
 				for _, bl := range linkResult.BrokenLinks {
-					fmt.Printf("  %s %s: removed broken link %s:%s\n", ui.Success.Render("✓"), bl.IssueID, bl.LinkType, bl.Target)
+					fmt.Printf("  %s %s: removed broken link %s:%s\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), bl.IssueID, bl.LinkType, bl.Target)
 				}
 				for _, sl := range linkResult.SelfLinks {
-					fmt.Printf("  %s %s: removed self-reference in %s link\n", ui.Success.Render("✓"), sl.IssueID, sl.LinkType)
+					fmt.Printf("  %s %s: removed self-reference in %s link\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), sl.IssueID, sl.LinkType)
 				}
 			}
 
@@ -171,38 +223,220 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 		} else if !todoCheckJSON {
 			// Report issues without fixing
 			for _, bl := range linkResult.BrokenLinks {
-				fmt.Printf("  %s %s: broken link %s:%s\n", ui.Danger.Render("✗"), bl.IssueID, bl.LinkType, bl.Target)
+				fmt.Printf("  %s %s: broken link %s:%s\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), bl.IssueID, bl.LinkType, bl.Target)
 			}
 			for _, sl := range linkResult.SelfLinks {
-				fmt.Printf("  %s %s: self-reference in %s link\n", ui.Danger.Render("✗"), sl.IssueID, sl.LinkType)
+				fmt.Printf("  %s %s: self-reference in %s link\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), sl.IssueID, sl.LinkType)
+			}
+		}
+
+		// Links stored on both issues are rewritten to live on the blocker only
+		if todoCheckFix && len(linkResult.DuplicateLinks) > 0 {
+			fixedCount, err := todoStore.FixDuplicateLinks()
+			if err != nil {
+				return fmt.Errorf("deduplicating blocking links: %w", err)
+			}
+			fixed += fixedCount
+
+			if !todoCheckJSON {
+				for _, dl := range linkResult.DuplicateLinks {
+					fmt.Printf("  %s %s: removed blocked_by:%s (kept on %s)\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), dl.Blocked, dl.Blocker, dl.Blocker)
+				}
+			}
+			linkResult.DuplicateLinks = []core.DuplicateLink{}
+		} else if !todoCheckJSON {
+			for _, dl := range linkResult.DuplicateLinks {
+				fmt.Printf("  %s %s: blocked_by:%s repeats the blocking link on %s\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), dl.Blocked, dl.Blocker, dl.Blocker)
 			}
 		}
 
-		// Cycles cannot be auto-fixed
+		// Parent cycles are broken at the most recently updated issue; other
+		// cycles cannot be auto-fixed
+		if todoCheckFix && slices.ContainsFunc(linkResult.Cycles, isParentCycle) {
+			breaks, err := todoStore.FixParentCycles()
+			if err != nil {
+				return fmt.Errorf("breaking parent cycles: %w", err)
+			}
+			fixed += len(breaks)
+			if !todoCheckJSON {
+				for _, cb := range breaks {
+					fmt.Printf("  %s %s: removed parent:%s to break a parent cycle\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), cb.IssueID, cb.Parent)
+				}
+			}
+			linkResult.Cycles = slices.DeleteFunc(linkResult.Cycles, isParentCycle)
+		}
 		if !todoCheckJSON {
 			for _, c := range linkResult.Cycles {
 				if todoCheckFix {
 					fmt.Printf("  %s Cannot auto-fix cycle: %s (via %s)\n", ui.Warning.Render("!"), formatCycle(c.Path), c.LinkType)
 				} else {
-					fmt.Printf("  %s Circular dependency: %s (via %s)\n", ui.Danger.Render("✗"), formatCycle(c.Path), c.LinkType)
+					fmt.Printf("  %s Circular dependency: %s (via %s)\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), formatCycle(c.Path), c.LinkType)
 				}
 			}
 		}
 
+		// Chains deeper than max_hierarchy_depth need the hierarchy reworked
+		if !todoCheckJSON {
+			for _, dc := range linkResult.DeepChains {
+				fmt.Printf("  %s %s: %d parents above it, max_hierarchy_depth is %d (%s)\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)),
+					dc.IssueID, len(dc.Chain)-1, todoCfg.GetMaxHierarchyDepth(), formatCycle(dc.Chain))
+			}
+		}
+
 		// Show success if no issues
 		if !todoCheckJSON && !linkResult.HasIssues() && fixed == 0 {
-			fmt.Printf("  %s No link issues found\n", ui.Success.Render("✓"))
+			fmt.Printf("  %s No link issues found\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
+		}
+
+		// === Unknown field values ===
+		if !todoCheckJSON {
+			fmt.Println()
+			fmt.Println(ui.Bold.Render("Field Values"))
+		}
+		unknownValues := todoStore.CheckUnknownValues()
+		if !todoCheckJSON && len(unknownValues) == 0 {
+			fmt.Printf("  %s All statuses, types, priorities, and iterations known\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
+		}
+		if todoCheckFix && len(unknownValues) > 0 {
+			fixedCount, err := todoStore.FixUnknownValues()
+			if err != nil {
+				return fmt.Errorf("remapping unknown values: %w", err)
+			}
+			fixed += fixedCount
+			if !todoCheckJSON {
+				for _, u := range unknownValues {
+					fmt.Printf("  %s %s: %s '%s' → '%s'\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), u.IssueID, u.Field, u.Value, u.RemapTo)
+				}
+			}
+			unknownValues = nil
+		} else if !todoCheckJSON {
+			for _, u := range unknownValues {
+				hint := fmt.Sprintf("--fix sets '%s'", u.RemapTo)
+				if u.Suggestion != "" {
+					hint = fmt.Sprintf("did you mean '%s'?", u.Suggestion)
+				}
+				fmt.Printf("  %s %s: unknown %s '%s' (%s)\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), u.IssueID, u.Field, u.Value, hint)
+			}
+		}
+
+		// === Sync data ===
+		if !todoCheckJSON {
+			fmt.Println()
+			fmt.Println(ui.Bold.Render("Sync Data"))
+		}
+		syncProblems := integration.CheckSyncData(todoStore.All())
+		if todoCheckFix && slices.ContainsFunc(syncProblems, isSyncRename) {
+			fixedCount, err := integration.FixSyncData(todoStore)
+			if err != nil {
+				return fmt.Errorf("normalizing sync data: %w", err)
+			}
+			fixed += fixedCount
+			if !todoCheckJSON {
+				for _, p := range syncProblems {
+					if isSyncRename(p) {
+						fmt.Printf("  %s %s: %s sync key '%s' → '%s'\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), p.IssueID, p.Provider, p.Key, p.Rename)
+					}
+				}
+			}
+			syncProblems = integration.CheckSyncData(todoStore.All())
+		}
+		if !todoCheckJSON {
+			for _, p := range syncProblems {
+				fmt.Printf("  %s %s: %s sync data: %s\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), p.IssueID, p.Provider, p.Message)
+			}
+			if len(syncProblems) == 0 {
+				fmt.Printf("  %s All sync data matches its provider\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
+			}
+		}
+
+		// === Stale slugs ===
+		var staleSlugs []core.StaleSlug
+		if todoCfg.RenameFilesOnTitleChange {
+			if !todoCheckJSON {
+				fmt.Println()
+				fmt.Println(ui.Bold.Render("File Names"))
+			}
+			staleSlugs = todoStore.StaleSlugs()
+			if todoCheckFix && len(staleSlugs) > 0 {
+				renamed, err := todoStore.FixStaleSlugs()
+				fixed += len(renamed)
+				if !todoCheckJSON {
+					for _, r := range renamed {
+						fmt.Printf("  %s %s: %s → %s\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), r.IssueID, r.Path, r.NewPath)
+					}
+				}
+				if err != nil {
+					return fmt.Errorf("renaming stale-slug files: %w", err)
+				}
+				staleSlugs = nil
+			}
+			if !todoCheckJSON {
+				for _, r := range staleSlugs {
+					fmt.Printf("  %s %s: %s should be %s\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), r.IssueID, r.Path, r.NewPath)
+				}
+				if len(staleSlugs) == 0 {
+					fmt.Printf("  %s All file names match their titles\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
+				}
+			}
+		}
+
+		// === Skipped files ===
+		var rewritten int
+		if todoCheckFix {
+			var err error
+			if rewritten, err = todoStore.FixTimestamps(); err != nil {
+				return fmt.Errorf("rewriting timestamps: %w", err)
+			}
+			fixed += rewritten
+		}
+		loadWarnings := todoStore.Warnings()
+		if !todoCheckJSON {
+			fmt.Println()
+			fmt.Println(ui.Bold.Render("Issue Files"))
+			if rewritten > 0 {
+				fmt.Printf("  %s Rewrote %d file(s) with corrected timestamps\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), rewritten)
+			}
+			for _, w := range loadWarnings {
+				fmt.Printf("  %s %s: %s (%s)\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), w.Path, w.Message(), w.Kind)
+			}
+			if len(loadWarnings) == 0 {
+				fmt.Printf("  %s All issue files loaded\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)))
+			}
+		}
+
+		// === File watcher ===
+		watcherStatus := probeWatcher()
+		watcherFailed := 0
+		if watcherStatus.Mode == "" {
+			watcherFailed = 1
+		}
+		if !todoCheckJSON {
+			fmt.Println()
+			fmt.Println(ui.Bold.Render("File Watcher"))
+			switch watcherStatus.Mode {
+			case core.WatchModeFSNotify:
+				fmt.Printf("  %s fsnotify (debounce %s, safety-net poll every %s)\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), watcherStatus.Debounce, watcherStatus.PollInterval)
+			case core.WatchModePoll:
+				fmt.Printf("  %s Polling every %s: fsnotify unavailable (%s)\n", ui.Warning.Render("!"), watcherStatus.PollInterval, watcherStatus.Error)
+			default:
+				fmt.Printf("  %s fsnotify unavailable (%s); set watcher.poll_fallback to poll instead\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), watcherStatus.Error)
+			}
 		}
 
 		// === Summary ===
-		totalIssues := len(configErrors) + linkResult.TotalIssues()
+		totalIssues := len(configErrors) + linkResult.TotalIssues() + len(unknownValues) + len(syncProblems) + len(staleSlugs) + len(loadWarnings) + watcherFailed
 
 		if todoCheckJSON {
 			result := todoCheckResult{
-				Success:      totalIssues == 0,
-				ConfigErrors: configErrors,
-				LinkIssues:   linkResult,
-				Fixed:        fixed,
+				Success:       totalIssues == 0,
+				ConfigErrors:  configErrors,
+				LinkIssues:    linkResult,
+				UnknownValues: unknownValues,
+				SyncData:      syncProblems,
+				StaleSlugs:    staleSlugs,
+				LoadWarnings:  loadWarnings,
+				Watcher:       watcherStatus,
+				Fixed:         fixed,
 			}
 			data, _ := json.MarshalIndent(result, "", "  ")
 			fmt.Println(string(data))
@@ -230,8 +464,33 @@ Note: Cycles cannot be auto-fixed and require manual intervention.`,
 	},
 }
 
+// probeWatcher reports how a file watcher started now would run.
+func probeWatcher() todoWatcherStatus {
+	tuning := todoCfg.GetWatcher()
+	status := todoWatcherStatus{
+		Debounce:     cmp.Or(tuning.Debounce(), core.DefaultDebounce).String(),
+		PollInterval: cmp.Or(tuning.PollInterval(), core.DefaultPollInterval).String(),
+	}
+	mode, err := todoStore.ProbeWatchMode()
+	status.Mode = mode
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// isSyncRename reports whether --fix can correct p by renaming its key.
+func isSyncRename(p integration.SyncDataProblem) bool {
+	return p.Rename != ""
+}
+
+// isParentCycle reports whether c runs through parent links.
+func isParentCycle(c core.Cycle) bool {
+	return c.LinkType == issue.LinkTypeParent
+}
+
 func init() {
 	todoCheckCmd.Flags().BoolVar(&todoCheckJSON, "json", false, "Output as JSON")
-	todoCheckCmd.Flags().BoolVar(&todoCheckFix, "fix", false, "Automatically fix broken links and self-references")
+	todoCheckCmd.Flags().BoolVar(&todoCheckFix, "fix", false, "Automatically fix broken links, self-references, duplicate blocking links, parent cycles, unknown field values, misspelled sync data keys, stale-slug file names, and timestamps without a zone or in the future")
 	todoCmd.AddCommand(todoCheckCmd)
 }
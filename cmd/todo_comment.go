@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -16,8 +17,10 @@ import (
 
 var todoCommentJSON bool
 
-// commentIssue appends text to an issue's body via the same body-modification
-// path as `update --append-body`, so etag checks, timestamps, and sync all run.
+// commentIssue adds text to an issue's Comments section as a local comment
+// signed by the current actor, so sync can push it to the linked item. The
+// body goes through the same update path as `update --body`, guarded by the
+// etag it was read with, so etag checks, timestamps, and sync all run.
 // It is the implementation behind the discoverable `comment` verb that agents
 // reach for by analogy with gh/git, instead of editing `.issues/*.md` directly.
 func commentIssue(id, text string) (*issue.Issue, error) {
@@ -29,26 +32,34 @@ func commentIssue(id, text string) (*issue.Issue, error) {
 	ctx := context.Background()
 	resolver := &graph.Resolver{Core: todoStore}
 
-	b, err := resolver.Query().Issue(ctx, id)
+	b, err := resolveIssueArg(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find issue: %w", err)
-	}
-	if b == nil {
-		return nil, fmt.Errorf("issue not found: %s", id)
+		return nil, err
 	}
 
-	input := model.UpdateIssueInput{BodyMod: &model.BodyModification{Append: &text}}
+	body, err := issue.AppendComment(b.Body, issue.Comment{
+		Author: cmp.Or(graph.DefaultActor(), "unknown"),
+		Source: issue.CommentSourceLocal,
+		At:     todoStore.Now(),
+		Text:   text,
+	})
+	if err != nil {
+		return nil, err
+	}
+	etag := b.ETag()
+	input := model.UpdateIssueInput{Body: &body, IfMatch: &etag}
 	return resolver.Mutation().UpdateIssue(ctx, b.ID, input)
 }
 
 var todoCommentCmd = &cobra.Command{
 	Use:   "comment <id> <text>",
-	Short: "Append a note to an issue's body",
-	Long: `Appends text to an issue's body, separated from existing content by a blank line.
+	Short: "Add a comment to an issue",
+	Long: `Adds a comment to the issue's Comments section, signed with the current
+actor ($JIG_ACTOR, or your user name) and time. Sync posts comments written
+this way to the linked GitHub issue or ClickUp task.
 
-This is a discoverable alias for 'update --append-body'. Use it instead of
-editing the issue's markdown file directly, which bypasses concurrency (etag)
-checks, the updated timestamp, and external sync.
+Use it instead of editing the issue's markdown file directly, which bypasses
+concurrency (etag) checks, the updated timestamp, and external sync.
 
 Pass '-' as the text to read the comment from stdin (best for multi-line
 content with backticks):
@@ -67,24 +78,27 @@ content with backticks):
 		}
 		resolved, err := resolveAppendContent(text)
 		if err != nil {
-			return cmdError(todoCommentJSON, output.ErrFileError, "%s", err)
+			return cmdError(todoCommentJSON, output.ErrFileError, "%w", err)
 		}
 
 		b, err := commentIssue(id, resolved)
 		if err != nil {
-			return cmdError(todoCommentJSON, output.ErrValidation, "%s", err)
-		}
-
-		if todoCommentJSON {
-			return output.Success(b, "Comment added")
+			return cmdError(todoCommentJSON, output.ErrValidation, "%w", err)
 		}
 
-		fmt.Println(ui.Success.Render("Commented on ") + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
-		return nil
+		return resolveOutputMode(todoCommentJSON).write(commandResult{
+			JSON:  func() error { return output.Success(b, "Comment added") },
+			Quiet: []string{b.ID},
+			Text: func() error {
+				fmt.Println(ui.Success.Render("Commented on ") + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
+				return nil
+			},
+		})
 	},
 }
 
 func init() {
 	todoCommentCmd.Flags().BoolVar(&todoCommentJSON, "json", false, "Output as JSON")
+	addQuietFlag(todoCommentCmd)
 	todoCmd.AddCommand(todoCommentCmd)
 }
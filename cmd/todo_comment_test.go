@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
@@ -21,20 +26,67 @@ func TestCommentIssueAppends(t *testing.T) {
 		t.Fatalf("seeding issue: %v", err)
 	}
 
-	b, err := commentIssue("cmt-1", "## Summary\n\nDid the thing.")
+	t.Setenv(graph.ActorEnv, "sam")
+	b, err := commentIssue("cmt-1", "Did the thing.\n\nSecond paragraph.")
 	if err != nil {
 		t.Fatalf("commentIssue() error = %v", err)
 	}
 
-	if !strings.Contains(b.Body, "Original body.") {
+	if !strings.HasPrefix(b.Body, "Original body.\n\n") {
 		t.Errorf("comment clobbered existing body: %q", b.Body)
 	}
-	if !strings.Contains(b.Body, "## Summary") || !strings.Contains(b.Body, "Did the thing.") {
-		t.Errorf("comment did not append text: %q", b.Body)
+	comments, err := issue.ParseComments(b.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("got %d comments, want 1: %q", len(comments), b.Body)
+	}
+	c := comments[0]
+	if c.Author != "sam" || c.Source != issue.CommentSourceLocal || c.Text != "Did the thing.\n\nSecond paragraph." || !c.At.Equal(testCore.Now().Truncate(time.Second)) {
+		t.Errorf("comment = %+v, want a local comment by sam", c)
+	}
+}
+
+// pushedComments records the comments SyncComments posts.
+type pushedComments struct{ texts []string }
+
+func (p *pushedComments) ListComments(context.Context) ([]syncutil.RemoteComment, error) {
+	return nil, nil
+}
+
+func (p *pushedComments) CreateComment(_ context.Context, text string) (string, error) {
+	p.texts = append(p.texts, text)
+	return fmt.Sprintf("remote-%d", len(p.texts)), nil
+}
+
+func TestCommentCmdIsPushedBySync(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	t.Setenv(graph.ActorEnv, "sam")
+	createQueryTestIssue(t, testCore, "cmt-3", "Synced", "ready")
+
+	if err := todoCommentCmd.RunE(todoCommentCmd, []string{"cmt-3", "Looks", "good"}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := testCore.Get("cmt-3")
+	if err != nil {
+		t.Fatal(err)
 	}
-	// Appended content should be separated from the original by a blank line.
-	if !strings.Contains(b.Body, "Original body.\n\n## Summary") {
-		t.Errorf("comment not separated from existing body by blank line: %q", b.Body)
+
+	api := &pushedComments{}
+	result, err := syncutil.SyncComments(context.Background(), api, b.Body, syncutil.CommentState{}, syncutil.CommentOptions{Provider: "github"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pushed != 1 || len(api.texts) != 1 || !strings.HasPrefix(api.texts[0], "**sam** (jig, ") || !strings.HasSuffix(api.texts[0], "Looks good") {
+		t.Errorf("pushed %d: %q, want the comment by sam", result.Pushed, api.texts)
+	}
+
+	// A second sync with the recorded state posts nothing again.
+	again, err := syncutil.SyncComments(context.Background(), api, b.Body, result.State, syncutil.CommentOptions{Provider: "github"})
+	if err != nil || again.Pushed != 0 {
+		t.Errorf("re-sync pushed %d (error %v), want 0", again.Pushed, err)
 	}
 }
 
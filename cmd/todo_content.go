@@ -57,12 +57,15 @@ func formatCycle(path []string) string {
 	return strings.Join(path, " → ")
 }
 
-// cmdError returns an appropriate error for JSON or text mode.
+// cmdError returns an appropriate error for JSON or text mode. Format it
+// with %w so the typed errors it wraps can refine code (see errorCode).
 func cmdError(jsonMode bool, code, format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	code = errorCode(err, code)
 	if jsonMode {
-		return output.Error(code, fmt.Sprintf(format, args...))
+		return output.ErrorFrom(code, err)
 	}
-	return fmt.Errorf(format, args...)
+	return &output.CodedError{Code: code, Err: err}
 }
 
 // mergeTags combines existing tags with additions and removals.
@@ -1,63 +1,118 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/output"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
 var (
-	createStatus    string
-	createType      string
-	createPriority  string
-	createMilestone string
-	createBody      string
-	createBodyFile  string
-	createTag       []string
-	createDue       string
-	createParent    string
-	createBlocking  []string
-	createBlockedBy []string
-	createJSON      bool
+	createStatus       string
+	createSummary      string
+	createType         string
+	createPriority     string
+	createMilestone    string
+	createIteration    string
+	createEstimate     string
+	createBody         string
+	createBodyFile     string
+	createTag          []string
+	createDue          string
+	createDueTime      string
+	createParent       string
+	createBlocking     []string
+	createBlockedBy    []string
+	createEncrypted    bool
+	createVisibility   string
+	createReleaseTitle string
+	createReleaseNote  string
+	createFromBundle   string
+	createJSON         bool
 )
 
 var createCmd = &cobra.Command{
 	Use:     "create [title]",
 	Aliases: []string{"c", "new"},
 	Short:   "Create a new issue",
-	Long:    `Creates a new issue (issue) with a generated ID and optional title.`,
+	Long: `Creates a new issue (issue) with a generated ID and optional title.
+
+--from-bundle reads the title, fields, and body from a document written by
+jig todo bundle, or one laid out like it. Flags and a title argument take
+precedence over the bundle.`,
+	Annotations: map[string]string{porcelainAnnotation: "id\tetag\tpath"},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var bundle issueBundle
+		if createFromBundle != "" {
+			text, err := resolveContent("", createFromBundle)
+			if err != nil {
+				return cmdError(createJSON, output.ErrFileError, "%w", err)
+			}
+			bundle = parseBundle(text)
+			if err := applyBundle(cmd, bundle); err != nil {
+				return cmdError(createJSON, output.ErrValidation, "%w", err)
+			}
+		}
+
 		title := strings.Join(args, " ")
+		if title == "" {
+			title = bundle.title
+		}
 		if title == "" {
 			title = "Untitled"
 		}
 
 		// Validate inputs
 		if createStatus != "" {
-			if !todoCfg.IsValidStatus(createStatus) {
-				return cmdError(createJSON, output.ErrInvalidStatus, "invalid status: %s (must be %s)", createStatus, todoCfg.StatusList())
+			if err := todoCfg.ValidateStatus(createStatus); err != nil {
+				return cmdError(createJSON, output.ErrInvalidStatus, "%w", err)
 			}
 			if !todoCfg.IsStatusEnabled(createStatus) {
 				return cmdError(createJSON, output.ErrInvalidStatus, "status %q is disabled in this project (enabled: %s)", createStatus, todoCfg.EnabledStatusList())
 			}
 		}
-		if createType != "" && !todoCfg.IsValidType(createType) {
-			return cmdError(createJSON, output.ErrValidation, "invalid type: %s (must be %s)", createType, todoCfg.TypeList())
+		if createType != "" {
+			if err := todoCfg.ValidateType(createType); err != nil {
+				return cmdError(createJSON, output.ErrValidation, "%w", err)
+			}
+		}
+		if err := todoCfg.ValidatePriority(createPriority); err != nil {
+			return cmdError(createJSON, output.ErrValidation, "%w", err)
+		}
+		iteration, err := todoCfg.ResolveIteration(createIteration, todoStore.Now())
+		if err != nil {
+			return cmdError(createJSON, output.ErrValidation, "%w", err)
+		}
+
+		if err := todoCfg.ValidateEstimate(createEstimate); err != nil {
+			return cmdError(createJSON, output.ErrValidation, "%w", err)
 		}
-		if createPriority != "" && !todoCfg.IsValidPriority(createPriority) {
-			return cmdError(createJSON, output.ErrValidation, "invalid priority: %s (must be %s)", createPriority, todoCfg.PriorityList())
+
+		if err := todoconfig.ValidateVisibility(createVisibility); err != nil {
+			return cmdError(createJSON, output.ErrValidation, "%w", err)
+		}
+
+		summary := strings.TrimSpace(createSummary)
+		if err := issue.ValidateSummary(summary); err != nil {
+			return cmdError(createJSON, output.ErrValidation, "%w", err)
 		}
 
 		body, err := resolveContent(createBody, createBodyFile)
 		if err != nil {
-			return cmdError(createJSON, output.ErrFileError, "%s", err)
+			return cmdError(createJSON, output.ErrFileError, "%w", err)
+		}
+		if body == "" {
+			body = bundle.body
 		}
 
 		// Build GraphQL input
@@ -80,14 +135,27 @@ var createCmd = &cobra.Command{
 		if createMilestone != "" {
 			input.Milestone = &createMilestone
 		}
+		if iteration != "" {
+			input.Iteration = &iteration
+		}
+		if createEstimate != "" {
+			input.Estimate = &createEstimate
+		}
+		if summary != "" {
+			input.Summary = &summary
+		}
 		if body != "" {
 			input.Body = &body
 		}
 		if len(createTag) > 0 {
 			input.Tags = createTag
 		}
-		if createDue != "" {
-			input.Due = &createDue
+		if createDue != "" || createDueTime != "" {
+			due, err := combineDueTime(createDue, createDueTime)
+			if err != nil {
+				return cmdError(createJSON, output.ErrValidation, "%w", err)
+			}
+			input.Due = &due
 		}
 		if createParent != "" {
 			input.Parent = &createParent
@@ -98,23 +166,59 @@ var createCmd = &cobra.Command{
 		if len(createBlockedBy) > 0 {
 			input.BlockedBy = createBlockedBy
 		}
+		if createEncrypted {
+			input.Encrypted = &createEncrypted
+		}
+		if createVisibility != "" {
+			input.Visibility = &createVisibility
+		}
+		if releaseTitle := strings.TrimSpace(createReleaseTitle); releaseTitle != "" {
+			input.ReleaseTitle = &releaseTitle
+		}
+		if releaseNote := strings.TrimSpace(createReleaseNote); releaseNote != "" {
+			input.ReleaseNote = &releaseNote
+		}
 
 		// Create via GraphQL mutation
 		resolver := &graph.Resolver{Core: todoStore}
-		b, err := resolver.Mutation().CreateIssue(context.Background(), input)
-		if err != nil {
-			return cmdError(createJSON, output.ErrFileError, "failed to create issue: %v", err)
+		b, err := resolver.Mutation().CreateIssue(cliContext(), input)
+		_, tooLarge := errors.AsType[*core.SizeError](err)
+		_, brokeRule := errors.AsType[*core.RuleError](err)
+		if tooLarge || brokeRule {
+			return mutationError(createJSON, err)
 		}
-
-		if createJSON {
-			return output.Success(b, "Issue created")
+		if err != nil {
+			return cmdError(createJSON, output.ErrFileError, "failed to create issue: %w", err)
 		}
 
-		fmt.Println(ui.Success.Render("Created ") + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
-		return nil
+		return resolveOutputMode(createJSON).write(commandResult{
+			JSON:      func() error { return output.Success(b, "Issue created") },
+			Porcelain: func() error { return writePorcelain(os.Stdout, b.ID, b.ETag(), b.Path) },
+			Quiet:     []string{b.ID},
+			Text: func() error {
+				fmt.Println(ui.Success.Render("Created ") + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
+				return nil
+			},
+		})
 	},
 }
 
+// combineDueTime appends a local "15:04" --due-time to a --due date,
+// returning the due string to send to the GraphQL API.
+func combineDueTime(date, clock string) (string, error) {
+	if clock == "" {
+		return date, nil
+	}
+	if date == "" {
+		return "", errors.New("--due-time requires --due")
+	}
+	due, err := issue.ParseDueDateTime(date, clock, time.Local)
+	if err != nil {
+		return "", err
+	}
+	return due.String(), nil
+}
+
 func init() {
 	statusNames := todoconfig.DefaultStatusNames()
 	typeNames := todoconfig.DefaultTypeNames()
@@ -124,14 +228,24 @@ func init() {
 	createCmd.Flags().StringVarP(&createType, "type", "t", "", "issue type ("+strings.Join(typeNames, ", ")+")")
 	createCmd.Flags().StringVarP(&createPriority, "priority", "p", "", "Priority level ("+strings.Join(priorityNames, ", ")+")")
 	createCmd.Flags().StringVar(&createMilestone, "milestone", "", "Milestone ID to assign this issue to")
+	createCmd.Flags().StringVar(&createIteration, "iteration", "", "Iteration to assign (ISO week such as 2025-W34, a configured name, or 'current')")
+	createCmd.Flags().StringVar(&createEstimate, "estimate", "", "Expected effort (a duration such as 4h, 2d, or 1w)")
+	createCmd.Flags().StringVar(&createSummary, "summary", "", "One-line description shown in lists and roadmaps")
 	createCmd.Flags().StringVarP(&createBody, "body", "d", "", "Body content (use '-' to read from stdin)")
 	createCmd.Flags().StringVar(&createBodyFile, "body-file", "", "Read body from file (use '-' to read from stdin)")
 	createCmd.Flags().StringArrayVar(&createTag, "tag", nil, "Add tag (can be repeated)")
-	createCmd.Flags().StringVar(&createDue, "due", "", "Due date (YYYY-MM-DD)")
+	createCmd.Flags().StringVar(&createDue, "due", "", "Due date (YYYY-MM-DD, or RFC 3339 with a time)")
+	createCmd.Flags().StringVar(&createDueTime, "due-time", "", "Due time of day in local time (HH:MM, requires --due)")
 	createCmd.Flags().StringVar(&createParent, "parent", "", "Parent issue ID")
 	createCmd.Flags().StringArrayVar(&createBlocking, "blocking", nil, "ID of issue this blocks (can be repeated)")
 	createCmd.Flags().StringArrayVar(&createBlockedBy, "blocked-by", nil, "ID of issue that blocks this one (can be repeated)")
+	createCmd.Flags().BoolVar(&createEncrypted, "encrypted", false, "Encrypt the body at rest (key from $JIG_ISSUE_KEY or issue_key_file)")
+	createCmd.Flags().StringVar(&createVisibility, "visibility", "", "public (default) or internal (kept out of exports, changelogs, roadmaps, and sync)")
+	createCmd.Flags().StringVar(&createReleaseTitle, "release-title", "", "Customer-facing title for the changelog (defaults to the title)")
+	createCmd.Flags().StringVar(&createReleaseNote, "release-note", "", "Customer-facing note for the changelog (defaults to the body)")
+	createCmd.Flags().StringVar(&createFromBundle, "from-bundle", "", "Read title, fields, and body from a bundle file (use '-' to read from stdin)")
 	createCmd.Flags().BoolVar(&createJSON, "json", false, "Output as JSON")
+	addQuietFlag(createCmd)
 	createCmd.MarkFlagsMutuallyExclusive("body", "body-file")
 	todoCmd.AddCommand(createCmd)
 }
@@ -32,21 +32,26 @@ var deleteCmd = &cobra.Command{
 	Long: `Deletes one or more issues after confirmation (use -f to skip confirmation).
 
 If other issues reference the target issue(s) (as parent or via blocking), you will be
-warned and those references will be removed after confirmation. Use -f to skip all warnings.`,
-	Args: cobra.MinimumNArgs(1),
+warned and those references will be removed after confirmation. Use -f to skip all warnings.
+
+Archived issues are read-only and cannot be deleted until they are unarchived.
+
+--json, --porcelain, and --quiet are for scripts and imply --force.`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{porcelainAnnotation: "id\tdeleted"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		resolver := &graph.Resolver{Core: todoStore}
 
 		// Collect all issues and their incoming links upfront
 		var targets []issueWithLinks
-		for _, id := range args {
-			b, err := resolver.Query().Issue(ctx, id)
-			if err != nil {
-				return cmdError(deleteJSON, output.ErrNotFound, "failed to find issue: %v", err)
-			}
-			if b == nil {
-				return cmdError(deleteJSON, output.ErrNotFound, "issue not found: %s", id)
+		resolved, err := resolveIssueArgs(deleteJSON, args)
+		if err != nil {
+			return err
+		}
+		for _, b := range resolved {
+			if todoStore.IsArchived(b.ID) {
+				return mutationError(deleteJSON, &core.ArchivedError{ID: b.ID})
 			}
 			targets = append(targets, issueWithLinks{
 				issue: b,
@@ -55,7 +60,8 @@ warned and those references will be removed after confirmation. Use -f to skip a
 		}
 
 		// Prompt for confirmation
-		if !forceDelete && !deleteJSON {
+		mode := resolveOutputMode(deleteJSON)
+		if !forceDelete && mode == outputText {
 			if !confirmDeleteMultiple(targets) {
 				fmt.Println("Cancelled")
 				return nil
@@ -68,31 +74,43 @@ warned and those references will be removed after confirmation. Use -f to skip a
 		for _, target := range targets {
 			_, err := resolver.Mutation().DeleteIssue(ctx, target.issue.ID)
 			if err != nil {
-				return cmdError(deleteJSON, output.ErrFileError, "failed to delete issue %s: %v", target.issue.ID, err)
+				return cmdError(deleteJSON, output.ErrFileError, "failed to delete issue %s: %w", target.issue.ID, err)
 			}
 			deleted = append(deleted, target.issue)
 			totalLinksRemoved += len(target.links)
 		}
 
-		if deleteJSON {
-			if len(deleted) == 1 {
-				return output.Success(deleted[0], "Issue deleted")
-			}
-			return output.JSON(output.Response{
-				Success: true,
-				Issues:  deleted,
-				Count:   len(deleted),
-				Message: fmt.Sprintf("%d issues deleted", len(deleted)),
-			})
-		}
-
-		if totalLinksRemoved > 0 {
-			fmt.Printf("Removed %d reference(s)\n", totalLinksRemoved)
-		}
-		for _, b := range deleted {
-			fmt.Printf("Deleted %s\n", b.Path)
-		}
-		return nil
+		return mode.write(commandResult{
+			JSON: func() error {
+				if len(deleted) == 1 {
+					return output.Success(deleted[0], "Issue deleted")
+				}
+				return output.JSON(output.Response{
+					Success: true,
+					Issues:  deleted,
+					Count:   len(deleted),
+					Message: fmt.Sprintf("%d issues deleted", len(deleted)),
+				})
+			},
+			Porcelain: func() error {
+				for _, b := range deleted {
+					if err := writePorcelain(os.Stdout, b.ID, "deleted"); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Quiet: issueIDs(deleted),
+			Text: func() error {
+				if totalLinksRemoved > 0 {
+					fmt.Printf("Removed %d reference(s)\n", totalLinksRemoved)
+				}
+				for _, b := range deleted {
+					fmt.Printf("Deleted %s\n", b.Path)
+				}
+				return nil
+			},
+		})
 	},
 }
 
@@ -142,5 +160,6 @@ func confirmDeleteMultiple(targets []issueWithLinks) bool {
 func init() {
 	deleteCmd.Flags().BoolVarP(&forceDelete, "force", "f", false, "Skip confirmation and warnings")
 	deleteCmd.Flags().BoolVar(&deleteJSON, "json", false, "Output as JSON (implies --force)")
+	addQuietFlag(deleteCmd)
 	todoCmd.AddCommand(deleteCmd)
 }
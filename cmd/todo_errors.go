@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/github"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+// errFailed is the JSON error code of a failure no other code describes.
+const errFailed = "ERROR"
+
+// exitCodeHelp documents the exit codes in `jig todo --help`.
+const exitCodeHelp = `Exit codes:
+  0  success
+  1  any other failure
+  2  validation error (bad flag value, invalid status, ambiguous reference)
+  3  issue or milestone not found
+  4  conflict (etag mismatch, unmerged concurrent update, ID already exists,
+     change to an archived issue, unforced removal of unchecked tasks)
+  5  sync provider error (bad sync config, missing token, failed API call)
+
+  With --json, a failure also prints {"success": false, "error", "code",
+  "exit_code"} to stdout.`
+
+// typedErrorCode returns the JSON error code for the typed errors err wraps,
+// or "" when it wraps none of them.
+func typedErrorCode(err error) string {
+	if _, ok := errors.AsType[*integration.ProviderError](err); ok || errors.Is(err, integration.ErrNotConfigured) {
+		return output.ErrIntegration
+	}
+	if _, ok := errors.AsType[*github.RateLimitError](err); ok {
+		return output.ErrIntegration
+	}
+	if _, ok := errors.AsType[*github.TransientError](err); ok {
+		return output.ErrIntegration
+	}
+	if _, ok := errors.AsType[*clickup.RateLimitError](err); ok {
+		return output.ErrIntegration
+	}
+	if _, ok := errors.AsType[*clickup.TransientError](err); ok {
+		return output.ErrIntegration
+	}
+	if isConflictError(err) || errors.Is(err, core.ErrIDExists) || errors.Is(err, core.ErrArchived) {
+		return output.ErrConflict
+	}
+	if _, ok := errors.AsType[*updateConflictError](err); ok {
+		return output.ErrConflict
+	}
+	if _, ok := errors.AsType[*core.RemovedTasksError](err); ok {
+		return output.ErrConflict
+	}
+	if errors.Is(err, core.ErrNotFound) || errors.Is(err, core.ErrMilestoneNotFound) {
+		return output.ErrNotFound
+	}
+	if _, ok := errors.AsType[*core.AmbiguousError](err); ok {
+		return output.ErrAmbiguous
+	}
+	_, badValue := errors.AsType[*todoconfig.ValueError](err)
+	_, tooLarge := errors.AsType[*core.SizeError](err)
+	_, tooDeep := errors.AsType[*core.HierarchyDepthError](err)
+	_, badSync := errors.AsType[*syncutil.SchemaError](err)
+	if badValue || tooLarge || tooDeep || badSync || isQueryValidationError(err) {
+		return output.ErrValidation
+	}
+	return ""
+}
+
+// isQueryValidationError reports whether err is a GraphQL query that failed
+// to parse or validate against the schema.
+func isQueryValidationError(err error) bool {
+	e, ok := errors.AsType[*graphQLError](err)
+	if !ok {
+		return false
+	}
+	for _, gqlErr := range e.errs {
+		switch gqlErr.Extensions["code"] {
+		case errcode.ParseFailed, errcode.ValidationFailed:
+			return true
+		}
+	}
+	return false
+}
+
+// errorCode returns the JSON error code for err. The typed errors it wraps
+// decide the failure class; fallback, the code the command chose (or the
+// one err already carries), is kept when it names the same class, since it
+// is often more specific (e.g. INVALID_STATUS rather than VALIDATION_ERROR).
+func errorCode(err error, fallback string) string {
+	if e, ok := errors.AsType[*output.CodedError](err); ok && fallback == "" {
+		fallback = e.Code
+	}
+	if code := typedErrorCode(err); code != "" && output.ExitCode(code) != output.ExitCode(fallback) {
+		return code
+	}
+	return cmp.Or(fallback, errFailed)
+}
+
+// exitCode returns the process exit code for a command error. It is the one
+// place command errors are mapped to exit codes; see exitCodeHelp.
+func exitCode(err error) int {
+	return output.ExitCode(errorCode(err, ""))
+}
+
+// reportJSONError prints err as a JSON error response when cmd is a todo or
+// sync command run with --json and the command has not printed it already.
+func reportJSONError(cmd *cobra.Command, err error) {
+	if cmd == nil || !isTodoCommand(cmd) {
+		return
+	}
+	if f := cmd.Flags().Lookup("json"); f == nil || f.Value.String() != "true" {
+		return
+	}
+	if e, ok := errors.AsType[*output.CodedError](err); ok && e.Reported() {
+		return
+	}
+	_ = output.ErrorFrom(errorCode(err, ""), err)
+}
+
+// isTodoCommand reports whether cmd is `jig todo` or `jig sync`, or one of
+// their subcommands.
+func isTodoCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == todoCmd || c == syncAliasCmd {
+			return true
+		}
+	}
+	return false
+}
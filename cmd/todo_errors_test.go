@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+// runJSONCommand runs cmd with flags set as if given on the command line,
+// then reports its error the way Execute does. It returns stdout and the
+// error; the flags are reset afterwards.
+func runJSONCommand(t *testing.T, cmd *cobra.Command, flags map[string]string, args ...string) (string, error) {
+	t.Helper()
+	for name, value := range flags {
+		f := cmd.Flags().Lookup(name)
+		if f == nil {
+			t.Fatalf("%s has no --%s flag", cmd.Name(), name)
+		}
+		// Set appends to a slice flag, so slices are put back whole.
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			saved := sv.GetSlice()
+			t.Cleanup(func() { _ = sv.Replace(saved) })
+		} else {
+			t.Cleanup(func() { _ = f.Value.Set(f.DefValue) })
+		}
+		t.Cleanup(func() { f.Changed = false })
+		if err := f.Value.Set(value); err != nil {
+			t.Fatal(err)
+		}
+		f.Changed = true
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Drain the pipe while the command runs so large output cannot fill
+	// its buffer and block the write.
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = buf.ReadFrom(r)
+		close(done)
+	}()
+	orig := os.Stdout
+	os.Stdout = w
+	runErr := cmd.RunE(cmd, args)
+	if runErr != nil {
+		reportJSONError(cmd, runErr)
+	}
+	w.Close()
+	os.Stdout = orig
+	<-done
+	return buf.String(), runErr
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	setupConflictTest(t)
+
+	tests := []struct {
+		name     string
+		cmd      *cobra.Command
+		flags    map[string]string
+		args     []string
+		sync     map[string]map[string]any
+		wantExit int
+		wantCode string
+	}{
+		{
+			name:     "not found",
+			cmd:      showCmd,
+			flags:    map[string]string{"json": "true"},
+			args:     []string{"nope-1"},
+			wantExit: output.ExitNotFound,
+			wantCode: output.ErrNotFound,
+		},
+		{
+			name:     "invalid status",
+			cmd:      createCmd,
+			flags:    map[string]string{"json": "true", "status": "bogus"},
+			args:     []string{"New issue"},
+			wantExit: output.ExitValidation,
+			wantCode: output.ErrInvalidStatus,
+		},
+		{
+			name:     "invalid query",
+			cmd:      graphqlCmd,
+			flags:    map[string]string{"json": "true"},
+			args:     []string{"{ nope }"},
+			wantExit: output.ExitValidation,
+			wantCode: output.ErrValidation,
+		},
+		{
+			name:     "etag mismatch",
+			cmd:      todoUpdateCmd,
+			flags:    map[string]string{"json": "true", "if-match": "stale", "title": "Changed"},
+			args:     []string{"cfl-1"},
+			wantExit: output.ExitConflict,
+			wantCode: output.ErrConflict,
+		},
+		{
+			name:     "bad sync config",
+			cmd:      syncLinkCmd,
+			flags:    map[string]string{"json": "true"},
+			args:     []string{"cfl-1", "42"},
+			sync:     map[string]map[string]any{"github": {"repo": "not-a-repo"}},
+			wantExit: output.ExitIntegration,
+			wantCode: output.ErrIntegration,
+		},
+		{
+			name:     "write failure",
+			cmd:      exportCSVCmd,
+			flags:    map[string]string{"json": "true", "output": filepath.Join(t.TempDir(), "missing", "out.csv")},
+			wantExit: output.ExitError,
+			wantCode: output.ErrFileError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todoCfg.Sync = tt.sync
+			t.Cleanup(func() { todoCfg.Sync = nil })
+
+			out, err := runJSONCommand(t, tt.cmd, tt.flags, tt.args...)
+			if err == nil {
+				t.Fatalf("expected an error, got output %s", out)
+			}
+			if got := exitCode(err); got != tt.wantExit {
+				t.Errorf("exitCode() = %d, want %d (error: %v)", got, tt.wantExit, err)
+			}
+
+			var resp map[string]any
+			if jsonErr := json.Unmarshal([]byte(out), &resp); jsonErr != nil {
+				t.Fatalf("stdout is not one JSON document: %v\n%s", jsonErr, out)
+			}
+			if resp["success"] != false {
+				t.Errorf("success = %v, want false", resp["success"])
+			}
+			if resp["code"] != tt.wantCode {
+				t.Errorf("code = %v, want %s", resp["code"], tt.wantCode)
+			}
+			if resp["exit_code"] != float64(tt.wantExit) {
+				t.Errorf("exit_code = %v, want %d", resp["exit_code"], tt.wantExit)
+			}
+			if resp["error"] != err.Error() {
+				t.Errorf("error = %v, want %q", resp["error"], err.Error())
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		fallback string
+		want     string
+	}{
+		{"untyped", errors.New("boom"), "", errFailed},
+		{"untyped keeps fallback", errors.New("boom"), output.ErrFileError, output.ErrFileError},
+		{"not found", fmt.Errorf("%w: abc", core.ErrNotFound), "", output.ErrNotFound},
+		{"ID exists refines file error", fmt.Errorf("failed to create issue: %w", core.ErrIDExists), output.ErrFileError, output.ErrConflict},
+		{"same class keeps fallback", &todoconfig.ValueError{Field: "status", Value: "x"}, output.ErrInvalidStatus, output.ErrInvalidStatus},
+		{"archived refines file error", fmt.Errorf("failed to delete issue: %w", &core.ArchivedError{ID: "abc"}), output.ErrFileError, output.ErrConflict},
+		{"size", &core.SizeError{}, "", output.ErrValidation},
+		{"coded", &output.CodedError{Code: output.ErrNoDataDir, Err: errors.New("no data")}, "", output.ErrNoDataDir},
+		{"provider", fmt.Errorf("detecting integration: %w", &integration.ProviderError{Provider: "github", Err: errors.New("bad repo")}), "", output.ErrIntegration},
+		{"not configured", integration.ErrNotConfigured, "", output.ErrIntegration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(tt.err, tt.fallback); got != tt.want {
+				t.Errorf("errorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportJSONErrorSkipsReported(t *testing.T) {
+	showJSON = true
+	t.Cleanup(func() { showJSON = false })
+	out := capturePorcelain(t, func() error {
+		err := output.Error(output.ErrNotFound, "issue not found: x")
+		reportJSONError(showCmd, fmt.Errorf("show: %w", err))
+		return nil
+	})
+	if n := bytes.Count([]byte(out), []byte(`"success"`)); n != 1 {
+		t.Errorf("error reported %d times, want once:\n%s", n, out)
+	}
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	expandSection string
+	expandType    string
+	expandDryRun  bool
+	expandJSON    bool
+)
+
+// Actions reported for each task item by todo expand.
+const (
+	expandCreate = "create"
+	expandMatch  = "match"
+)
+
+// expandMapping pairs a task item with the child issue it became.
+type expandMapping struct {
+	Item string `json:"item"`
+	// ID is empty for items --dry-run would create.
+	ID     string `json:"id,omitempty"`
+	Action string `json:"action"`
+	line   int
+	// same is the index of an earlier item with the same title, which this
+	// one links to.
+	same int
+}
+
+// expandResult is the --json output of todo expand.
+type expandResult struct {
+	Success  bool            `json:"success"`
+	DryRun   bool            `json:"dry_run"`
+	Issue    string          `json:"issue"`
+	Mappings []expandMapping `json:"mappings"`
+}
+
+var todoExpandCmd = &cobra.Command{
+	Use:   "expand <id>",
+	Short: "Create child issues from an issue's task checklist",
+	Long: `Creates a child issue for each unchecked item in the checklist under the
+"Tasks" heading of an issue's body (--section names another), and links the
+item to it by appending the child's ID.
+
+Checked items and items that already name one of the issue's children are
+left alone, so expand can be run again as the list grows. An item whose
+title matches an existing child (ignoring case and spacing) is linked to
+that child instead of creating another.
+
+If any child cannot be created, or the body changed on disk meanwhile, the
+children created so far are deleted and the body is left as it was.
+--quiet prints the IDs of the children it created.`,
+	Example: `  jig todo expand epic-1 --dry-run
+  jig todo expand epic-1 --section "Plan" --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		parent, err := resolveIssueArg(args[0])
+		if err != nil {
+			return cmdError(expandJSON, resolveErrorCode(err), "%w", err)
+		}
+		etag, err := todoStore.DiskETag(parent.ID)
+		if err != nil {
+			return cmdError(expandJSON, output.ErrFileError, "%w", err)
+		}
+		items, err := issue.TaskItems(parent.Body, expandSection)
+		if err != nil {
+			return cmdError(expandJSON, output.ErrValidation, "%w", err)
+		}
+
+		mappings := planExpansion(items, todoStore.ChildrenOf(parent.ID), todoStore.IDMatcher())
+		if !expandDryRun && len(mappings) > 0 {
+			if err := runExpansion(parent, etag, mappings); err != nil {
+				return mutationError(expandJSON, err)
+			}
+		}
+
+		var created []string
+		for _, m := range mappings {
+			if m.Action == expandCreate && m.same < 0 && m.ID != "" {
+				created = append(created, m.ID)
+			}
+		}
+		return resolveOutputMode(expandJSON).write(commandResult{
+			JSON: func() error {
+				data, _ := json.MarshalIndent(expandResult{Success: true, DryRun: expandDryRun, Issue: parent.ID, Mappings: mappings}, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			},
+			Quiet: created,
+			Text:  func() error { return printExpansion(mappings) },
+		})
+	},
+}
+
+// printExpansion lists each mapping with what expand did, or would do, to it.
+func printExpansion(mappings []expandMapping) error {
+	if len(mappings) == 0 {
+		fmt.Println("No task items to expand.")
+		return nil
+	}
+	for _, m := range mappings {
+		id := ui.Muted.Render("(new)")
+		if m.ID != "" {
+			id = ui.ID.Render(m.ID)
+		}
+		verb := "created"
+		switch {
+		case m.Action == expandMatch:
+			verb = "linked"
+		case expandDryRun:
+			verb = "would create"
+		}
+		fmt.Printf("  %s %s %s\n", id, ui.Muted.Render(verb), m.Item)
+	}
+	return nil
+}
+
+// planExpansion decides, for each unchecked item that names none of
+// children, whether it links to an existing child of the same title or
+// needs a new one. Matched mappings carry the child's ID. ids finds the
+// children an item names, in any of the project's ID formats.
+func planExpansion(items []issue.TaskItem, children []*issue.Issue, ids *issue.IDMatcher) []expandMapping {
+	byTitle := make(map[string]string, len(children))
+	childIDs := make(map[string]bool, len(children))
+	for _, c := range children {
+		byTitle[issue.NormalizeTitle(c.Title)] = c.ID
+		childIDs[c.ID] = true
+	}
+
+	var mappings []expandMapping
+	planned := make(map[string]int)
+	for _, item := range items {
+		if item.Checked || slices.ContainsFunc(ids.ExtractMentions(item.Title), func(id string) bool { return childIDs[id] }) {
+			continue
+		}
+		m := expandMapping{Item: item.Title, Action: expandCreate, line: item.Line, same: -1}
+		title := issue.NormalizeTitle(item.Title)
+		if id, ok := byTitle[title]; ok {
+			m.ID, m.Action = id, expandMatch
+		} else if i, ok := planned[title]; ok {
+			m.Action, m.same = expandMatch, i
+		} else {
+			planned[title] = len(mappings)
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings
+}
+
+// runExpansion creates the planned children and links the items in
+// parent's body, filling in the mappings' IDs. On any failure the children
+// it created are deleted again and parent is reread from disk.
+func runExpansion(parent *issue.Issue, etag string, mappings []expandMapping) (err error) {
+	var created []string
+	defer func() {
+		if err != nil {
+			for _, id := range created {
+				_ = todoStore.Delete(id)
+			}
+			// A refused update can leave the new body on the in-memory copy.
+			_, _ = todoStore.Reload(parent.ID)
+		}
+	}()
+
+	ctx := cliContext()
+	resolver := &graph.Resolver{Core: todoStore}
+	status, typ := todoCfg.GetDefaultStatus(), expandType
+	links := make(map[int]string, len(mappings))
+	for i := range mappings {
+		m := &mappings[i]
+		switch {
+		case m.same >= 0:
+			m.ID = mappings[m.same].ID
+		case m.Action == expandCreate:
+			child, err := resolver.Mutation().CreateIssue(ctx, model.CreateIssueInput{
+				Title: m.Item, Status: &status, Type: &typ, Parent: &parent.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("creating %q: %w", m.Item, err)
+			}
+			created = append(created, child.ID)
+			m.ID = child.ID
+		}
+		links[m.line] = m.ID
+	}
+
+	body := issue.LinkTaskItems(parent.Body, links)
+	_, err = resolver.Mutation().UpdateIssue(ctx, parent.ID, model.UpdateIssueInput{Body: &body, IfMatch: &etag})
+	return err
+}
+
+func init() {
+	todoExpandCmd.Flags().StringVar(&expandSection, "section", issue.TaskSection, "Heading of the checklist to expand")
+	todoExpandCmd.Flags().StringVarP(&expandType, "type", "t", "task", "Type of the created children")
+	todoExpandCmd.Flags().BoolVar(&expandDryRun, "dry-run", false, "Show what would be created without changing anything")
+	todoExpandCmd.Flags().BoolVar(&expandJSON, "json", false, "Output as JSON")
+	addQuietFlag(todoExpandCmd)
+	todoCmd.AddCommand(todoExpandCmd)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+const expandBody = `Plan for the importer.
+
+## Tasks
+
+- [ ] Write the parser
+- [x] Pick a format
+- [ ] Existing  CHILD
+- [ ] write the parser
+
+## Notes
+
+- [ ] Not a task`
+
+func seedExpandIssues(t *testing.T) *core.Core {
+	t.Helper()
+	testCore := seedTestIssues(t,
+		&issue.Issue{ID: "epc-001", Slug: "importer", Title: "Importer", Status: "ready", Type: "epic", Body: expandBody},
+		&issue.Issue{ID: "chd-001", Slug: "existing-child", Title: "Existing child", Status: "ready", Type: "task", Parent: "epc-001"},
+	)
+	oldSection, oldType, oldDryRun := expandSection, expandType, expandDryRun
+	t.Cleanup(func() { expandSection, expandType, expandDryRun = oldSection, oldType, oldDryRun })
+	expandSection, expandType, expandDryRun = issue.TaskSection, "task", false
+	return testCore
+}
+
+func TestExpand(t *testing.T) {
+	testCore := seedExpandIssues(t)
+	run := func() {
+		capturePorcelain(t, func() error { return todoExpandCmd.RunE(todoExpandCmd, []string{"epc-001"}) })
+	}
+
+	expandDryRun = true
+	run()
+	if n := len(testCore.ChildrenOf("epc-001")); n != 1 {
+		t.Fatalf("--dry-run created children: %d", n)
+	}
+
+	expandDryRun = false
+	run()
+	children := testCore.ChildrenOf("epc-001")
+	if len(children) != 2 {
+		t.Fatalf("children after expand = %d, want the existing one and one new", len(children))
+	}
+	var created *issue.Issue
+	for _, c := range children {
+		if c.ID != "chd-001" {
+			created = c
+		}
+	}
+	if created.Title != "Write the parser" || created.Type != "task" {
+		t.Errorf("created child = %+v", created)
+	}
+	epic, _ := testCore.Get("epc-001")
+	for _, want := range []string{
+		"- [ ] Write the parser (" + created.ID + ")",
+		"- [x] Pick a format\n",
+		"- [ ] Existing  CHILD (chd-001)",
+		"- [ ] write the parser (" + created.ID + ")",
+		"- [ ] Not a task",
+	} {
+		if !strings.Contains(epic.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, epic.Body)
+		}
+	}
+
+	// A second run finds every item linked and changes nothing.
+	body := epic.Body
+	run()
+	if n := len(testCore.ChildrenOf("epc-001")); n != 2 {
+		t.Errorf("children after re-run = %d, want 2", n)
+	}
+	if epic, _ = testCore.Get("epc-001"); epic.Body != body {
+		t.Errorf("re-run changed the body:\n%s", epic.Body)
+	}
+}
+
+func TestExpandRollsBack(t *testing.T) {
+	testCore := seedExpandIssues(t)
+	epic, _ := testCore.Get("epc-001")
+	items, err := issue.TaskItems(epic.Body, issue.TaskSection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The body changed on disk since the etag was taken: the update fails and
+	// the child created for it goes again.
+	err = runExpansion(epic, "0000000000000000", planExpansion(items, testCore.ChildrenOf("epc-001"), testCore.IDMatcher()))
+	if _, ok := errors.AsType[*core.ETagMismatchError](err); !ok {
+		t.Fatalf("runExpansion() error = %v, want an etag mismatch", err)
+	}
+	if n := len(testCore.ChildrenOf("epc-001")); n != 1 {
+		t.Errorf("children after a failed expand = %d, want only the existing one", n)
+	}
+	if epic, _ = testCore.Get("epc-001"); strings.TrimPrefix(epic.Body, "\n") != expandBody {
+		t.Errorf("failed expand changed the body:\n%s", epic.Body)
+	}
+}
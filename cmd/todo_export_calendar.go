@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/ical"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+var (
+	exportCalOutput   string
+	exportCalStatuses []string
+	exportCalAs       string
+	exportCalSince    string
+	exportCalUntil    string
+	exportCalJSON     bool
+	exportCalInternal bool
+)
+
+var exportCalendarCmd = &cobra.Command{
+	Use:   "export-calendar",
+	Short: "Export issues with due dates as an iCalendar (.ics) file",
+	Long: `Writes an RFC 5545 iCalendar file with one entry per issue that has a due
+date, for import into calendar and reminder apps.
+
+Each entry's UID is derived from the issue ID, so importing a fresh export
+updates existing entries instead of duplicating them. The description holds
+the issue ID and the start of the body; tags become categories.
+
+By default issues in any non-archive status are exported; use --status to
+choose statuses explicitly. --since and --until (YYYY-MM-DD, inclusive)
+restrict the due-date range. Internal issues are left out unless
+--include-internal is given.`,
+	Example: `  jig todo export-calendar --output issues.ics
+  jig todo export-calendar --as event --status ready --status in-progress
+  jig todo export-calendar --since 2026-01-01 --until 2026-03-31 --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		toStdout := exportCalOutput == "" || exportCalOutput == "-"
+		if toStdout && exportCalJSON {
+			return cmdError(exportCalJSON, output.ErrValidation, "--json requires --output <file>")
+		}
+
+		var component string
+		switch exportCalAs {
+		case "todo":
+			component = ical.ComponentTodo
+		case "event":
+			component = ical.ComponentEvent
+		default:
+			return cmdError(exportCalJSON, output.ErrValidation, "invalid --as %q: must be todo or event", exportCalAs)
+		}
+
+		for _, s := range exportCalStatuses {
+			if !todoCfg.IsValidStatus(s) {
+				return cmdError(exportCalJSON, output.ErrInvalidStatus, "invalid status: %s (must be %s)", s, todoCfg.StatusList())
+			}
+		}
+
+		since, err := parseCalendarDate("since", exportCalSince)
+		if err != nil {
+			return cmdError(exportCalJSON, output.ErrValidation, "%w", err)
+		}
+		until, err := parseCalendarDate("until", exportCalUntil)
+		if err != nil {
+			return cmdError(exportCalJSON, output.ErrValidation, "%w", err)
+		}
+
+		var issues []*issue.Issue
+		for _, b := range publicIssues(todoStore.All(), exportCalInternal) {
+			if b.Due == nil {
+				continue
+			}
+			if len(exportCalStatuses) > 0 {
+				if !slices.Contains(exportCalStatuses, b.Status) {
+					continue
+				}
+			} else if todoCfg.IsArchiveStatus(b.Status) {
+				continue
+			}
+			day := b.Due.DateKey()
+			if exportCalSince != "" && day < since {
+				continue
+			}
+			if exportCalUntil != "" && day > until {
+				continue
+			}
+			issues = append(issues, b)
+		}
+		issue.SortByDueDate(issues)
+
+		var buf bytes.Buffer
+		if err := ical.Write(&buf, issues, ical.Options{Component: component, Now: time.Now()}); err != nil {
+			return cmdError(exportCalJSON, output.ErrValidation, "%w", err)
+		}
+
+		if toStdout {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(exportCalOutput, buf.Bytes(), 0644); err != nil {
+			return cmdError(exportCalJSON, output.ErrFileError, "failed to write %s: %w", exportCalOutput, err)
+		}
+
+		msg := fmt.Sprintf("Exported %d issue(s) to %s", len(issues), exportCalOutput)
+		if exportCalJSON {
+			return output.JSON(output.Response{Success: true, Issues: issues, Count: len(issues), Message: msg, Path: exportCalOutput})
+		}
+		fmt.Println(msg)
+		return nil
+	},
+}
+
+// parseCalendarDate validates a --since/--until value, returning it as a
+// "YYYY-MM-DD" string comparable with DueDate.Day.
+func parseCalendarDate(flag, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if _, err := time.Parse(issue.DueDateFormat, value); err != nil {
+		return "", fmt.Errorf("invalid --%s date %q: expected YYYY-MM-DD", flag, value)
+	}
+	return value, nil
+}
+
+func init() {
+	exportCalendarCmd.Flags().StringVarP(&exportCalOutput, "output", "o", "", "File to write (default: stdout)")
+	exportCalendarCmd.Flags().StringArrayVarP(&exportCalStatuses, "status", "s", nil, "Include only issues with this status (repeatable; default: all non-archive statuses)")
+	exportCalendarCmd.Flags().StringVar(&exportCalAs, "as", "todo", "Calendar entry kind: todo (VTODO) or event (VEVENT)")
+	exportCalendarCmd.Flags().StringVar(&exportCalSince, "since", "", "Only issues due on or after this date (YYYY-MM-DD)")
+	exportCalendarCmd.Flags().StringVar(&exportCalUntil, "until", "", "Only issues due on or before this date (YYYY-MM-DD)")
+	exportCalendarCmd.Flags().BoolVar(&exportCalInternal, "include-internal", false, includeInternalUsage)
+	exportCalendarCmd.Flags().BoolVar(&exportCalJSON, "json", false, "Print a JSON summary of exported issues (requires --output)")
+	todoCmd.AddCommand(exportCalendarCmd)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+var (
+	exportCSVOutput   string
+	exportCSVColumns  []string
+	exportCSVFilter   issueFilterFlags
+	exportCSVSort     string
+	exportCSVBOM      bool
+	exportCSVJSON     bool
+	exportCSVInternal bool
+)
+
+// defaultCSVColumns is the export-csv layout when --columns is unset.
+var defaultCSVColumns = []string{"id", "title", "status", "type", "priority", "tags", "parent", "due", "created", "updated", "blocked"}
+
+var exportCSVCmd = &cobra.Command{
+	Use:   "export-csv",
+	Short: "Export issues as CSV for spreadsheets",
+	Long: `Writes issues as RFC 4180 CSV, one row per issue after a header row, for
+triage in a spreadsheet.
+
+Columns come from the same set as list --columns, plus created and updated
+(RFC 3339 timestamps) and blocked (the number of active blockers). Tags are
+joined with ";". The filter flags match list. Internal issues are left out
+unless --include-internal is given.
+
+Use --excel-bom when the file is opened in Excel, which otherwise misreads
+non-ASCII text.`,
+	Example: `  jig todo export-csv --output issues.csv
+  jig todo export-csv --columns id,title,status,tags --status ready --excel-bom
+  jig todo export-csv --tag backend --sort priority > backend.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		toStdout := exportCSVOutput == "" || exportCSVOutput == "-"
+		if toStdout && exportCSVJSON {
+			return cmdError(exportCSVJSON, output.ErrValidation, "--json requires --output <file>")
+		}
+
+		names := exportCSVColumns
+		if len(names) == 0 {
+			names = defaultCSVColumns
+		}
+		columns, err := csvColumns(names, todoStore.AllBlockCounts())
+		if err != nil {
+			return cmdError(exportCSVJSON, output.ErrValidation, "%w", err)
+		}
+
+		filter, err := exportCSVFilter.filter()
+		if err != nil {
+			return cmdError(exportCSVJSON, output.ErrValidation, "%w", err)
+		}
+		if exportCSVFilter.visibility == todoconfig.VisibilityInternal && !exportCSVInternal {
+			return cmdError(exportCSVJSON, output.ErrValidation, "--visibility internal requires --include-internal")
+		}
+		resolver := &graph.Resolver{Core: todoStore}
+		issues, err := resolver.Query().Issues(context.Background(), filter)
+		if err != nil {
+			return cmdError(exportCSVJSON, output.ErrValidation, "querying issues: %w", err)
+		}
+		issues = publicIssues(issues, exportCSVInternal)
+		sortIssues(issues, exportCSVSort, todoCfg)
+
+		var buf bytes.Buffer
+		if err := output.WriteCSV(&buf, issues, columns, exportCSVBOM); err != nil {
+			return cmdError(exportCSVJSON, output.ErrValidation, "%w", err)
+		}
+
+		if toStdout {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(exportCSVOutput, buf.Bytes(), 0644); err != nil {
+			return cmdError(exportCSVJSON, output.ErrFileError, "failed to write %s: %w", exportCSVOutput, err)
+		}
+
+		msg := fmt.Sprintf("Exported %d issue(s) to %s", len(issues), exportCSVOutput)
+		if exportCSVJSON {
+			for _, b := range issues {
+				b.Body = ""
+			}
+			return output.JSON(output.Response{Success: true, Issues: issues, Count: len(issues), Message: msg, Path: exportCSVOutput})
+		}
+		fmt.Println(msg)
+		return nil
+	},
+}
+
+// csvColumns resolves column names to CSV columns. They are the porcelain
+// columns, with tags joined by ";" (commas would need quoting in every
+// cell), plus created, updated, and blocked.
+func csvColumns(names []string, blocks map[string]core.BlockCounts) ([]output.CSVColumn, error) {
+	columns := make([]output.CSVColumn, len(names))
+	for i, name := range names {
+		var get func(*issue.Issue) string
+		switch name {
+		case "tags":
+			get = func(b *issue.Issue) string { return strings.Join(b.Tags, ";") }
+		case "created":
+			get = func(b *issue.Issue) string { return csvTime(b.CreatedAt) }
+		case "updated":
+			get = func(b *issue.Issue) string { return csvTime(b.UpdatedAt) }
+		case "blocked":
+			get = func(b *issue.Issue) string { return strconv.Itoa(blocks[b.ID].BlockedBy) }
+		default:
+			var ok bool
+			if get, ok = porcelainColumns[name]; !ok {
+				return nil, fmt.Errorf("unknown column %q (must be id, title, summary, status, type, priority, parent, milestone, tags, due, created, updated, blocked, etag, or path)", name)
+			}
+		}
+		columns[i] = output.CSVColumn{Name: name, Value: get}
+	}
+	return columns, nil
+}
+
+// csvTime formats a timestamp for export, or "" when unset.
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func init() {
+	exportCSVCmd.Flags().StringVarP(&exportCSVOutput, "output", "o", "", "File to write (default: stdout)")
+	exportCSVCmd.Flags().StringSliceVar(&exportCSVColumns, "columns", nil, "Columns to export (default: "+strings.Join(defaultCSVColumns, ",")+")")
+	exportCSVCmd.Flags().StringVar(&exportCSVSort, "sort", "", "Sort by: status, priority, milestone, created, updated, due, id")
+	exportCSVCmd.Flags().BoolVar(&exportCSVBOM, "excel-bom", false, "Start the file with a UTF-8 byte order mark for Excel")
+	exportCSVCmd.Flags().BoolVar(&exportCSVInternal, "include-internal", false, includeInternalUsage)
+	exportCSVCmd.Flags().BoolVar(&exportCSVJSON, "json", false, "Print a JSON summary of exported issues (requires --output)")
+	exportCSVFilter.register(exportCSVCmd)
+	todoCmd.AddCommand(exportCSVCmd)
+}
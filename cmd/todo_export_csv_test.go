@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestCSVColumns(t *testing.T) {
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	issues := []*issue.Issue{
+		{ID: "aaa-111", Title: "Quote \"this\",\nplease", Status: "ready", Tags: []string{"ui", "urgent"}, CreatedAt: &created},
+		{ID: "bbb-222", Title: "Plain", Status: "draft"},
+	}
+	blocks := map[string]core.BlockCounts{"aaa-111": {BlockedBy: 2}}
+
+	columns, err := csvColumns([]string{"id", "title", "tags", "created", "blocked"}, blocks)
+	if err != nil {
+		t.Fatalf("csvColumns() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := output.WriteCSV(&buf, issues, columns, false); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing output: %v", err)
+	}
+	want := [][]string{
+		{"id", "title", "tags", "created", "blocked"},
+		{"aaa-111", "Quote \"this\",\nplease", "ui;urgent", "2026-03-04T05:06:07Z", "2"},
+		{"bbb-222", "Plain", "", "", "0"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(records[i], want[i]) {
+			t.Errorf("record %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+
+	if _, err := csvColumns([]string{"id", "bogus"}, nil); err == nil {
+		t.Error("csvColumns() with an unknown column should fail")
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// includeInternalUsage is the --include-internal help on commands that
+// publish issues outside the tracker.
+const includeInternalUsage = "Include internal issues, which are left out by default"
+
+// issueFilterFlags holds the issue filter flags shared by list and the
+// export commands, so they select issues the same way.
+type issueFilterFlags struct {
+	search      string
+	status      []string
+	noStatus    []string
+	typ         []string
+	noType      []string
+	priority    []string
+	noPriority  []string
+	milestone   []string
+	noMilestone []string
+	iteration   []string
+	noIteration []string
+	createdBy   []string
+	createdVia  []string
+	tag         []string
+	noTag       []string
+	hasParent   bool
+	noParent    bool
+	parentID    string
+	hasBlocking bool
+	noBlocking  bool
+	isBlocked   bool
+	ready       bool
+	stale       bool
+	pinned      bool
+	visibility  string
+}
+
+// register binds the filter flags to cmd.
+func (f *issueFilterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.search, "search", "S", "", "Full-text search in title, summary, and body")
+	cmd.Flags().StringArrayVarP(&f.status, "status", "s", nil, "Filter by status (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.noStatus, "no-status", nil, "Exclude by status (can be repeated)")
+	cmd.Flags().StringArrayVarP(&f.typ, "type", "t", nil, "Filter by type (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.noType, "no-type", nil, "Exclude by type (can be repeated)")
+	cmd.Flags().StringArrayVarP(&f.priority, "priority", "p", nil, "Filter by priority (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.noPriority, "no-priority", nil, "Exclude by priority (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.milestone, "milestone", nil, "Filter by milestone ID (can be repeated, OR logic)")
+	cmd.Flags().StringArrayVar(&f.noMilestone, "no-milestone", nil, "Exclude by milestone ID (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.iteration, "iteration", nil, "Filter by iteration, or 'current' (can be repeated, OR logic)")
+	cmd.Flags().StringArrayVar(&f.noIteration, "no-iteration", nil, "Exclude by iteration (can be repeated)")
+	cmd.Flags().StringArrayVar(&f.createdBy, "created-by", nil, "Filter by who created the issue (can be repeated, OR logic)")
+	cmd.Flags().StringArrayVar(&f.createdVia, "created-via", nil, "Filter by entry point the issue was created through: cli, tui, graphql, import, or sync (can be repeated, OR logic)")
+	cmd.Flags().StringArrayVar(&f.tag, "tag", nil, "Filter by tag (can be repeated, OR logic)")
+	cmd.Flags().StringArrayVar(&f.noTag, "no-tag", nil, "Exclude issues with tag (can be repeated)")
+	cmd.Flags().BoolVar(&f.hasParent, "has-parent", false, "Filter issues with a parent")
+	cmd.Flags().BoolVar(&f.noParent, "no-parent", false, "Filter issues without a parent")
+	cmd.Flags().StringVar(&f.parentID, "parent", "", "Filter by parent ID")
+	cmd.Flags().BoolVar(&f.hasBlocking, "has-blocking", false, "Filter issues that are blocking others")
+	cmd.Flags().BoolVar(&f.noBlocking, "no-blocking", false, "Filter issues that aren't blocking others")
+	cmd.Flags().BoolVar(&f.isBlocked, "is-blocked", false, "Filter issues that are blocked by others")
+	cmd.Flags().BoolVar(&f.ready, "ready", false, "Filter issues available to start")
+	cmd.Flags().BoolVar(&f.stale, "stale", false, "Filter issues not updated within stale_after (see config)")
+	cmd.Flags().BoolVar(&f.pinned, "pinned", false, "Filter pinned issues")
+	cmd.Flags().StringVar(&f.visibility, "visibility", "", "Filter by visibility (public or internal)")
+}
+
+// filter builds the GraphQL filter the flags describe.
+func (f *issueFilterFlags) filter() (*model.IssueFilter, error) {
+	filter := &model.IssueFilter{
+		Status:           f.status,
+		ExcludeStatus:    f.noStatus,
+		Type:             f.typ,
+		ExcludeType:      f.noType,
+		Priority:         f.priority,
+		ExcludePriority:  f.noPriority,
+		Milestone:        f.milestone,
+		ExcludeMilestone: f.noMilestone,
+		CreatedBy:        f.createdBy,
+		CreatedVia:       f.createdVia,
+		Tags:             f.tag,
+		ExcludeTags:      f.noTag,
+	}
+	var err error
+	if filter.Iteration, err = resolveIterationFlags(f.iteration); err != nil {
+		return nil, err
+	}
+	if filter.ExcludeIteration, err = resolveIterationFlags(f.noIteration); err != nil {
+		return nil, err
+	}
+
+	if f.search != "" {
+		filter.Search = &f.search
+	}
+	if f.hasParent {
+		filter.HasParent = &f.hasParent
+	}
+	if f.noParent {
+		filter.NoParent = &f.noParent
+	}
+	if f.parentID != "" {
+		filter.ParentID = &f.parentID
+	}
+	if f.hasBlocking {
+		filter.HasBlocking = &f.hasBlocking
+	}
+	if f.noBlocking {
+		filter.NoBlocking = &f.noBlocking
+	}
+	if f.ready && f.isBlocked {
+		return nil, errors.New("--ready and --is-blocked are mutually exclusive")
+	}
+	if f.isBlocked {
+		filter.IsBlocked = &f.isBlocked
+	}
+	if f.stale {
+		filter.IsStale = &f.stale
+	}
+	if f.pinned {
+		filter.Pinned = &f.pinned
+	}
+	if f.visibility != "" {
+		if err := todoconfig.ValidateVisibility(f.visibility); err != nil {
+			return nil, err
+		}
+		filter.Visibility = &f.visibility
+	}
+	if f.ready {
+		isBlocked := false
+		filter.IsBlocked = &isBlocked
+		filter.ExcludeStatus = append(filter.ExcludeStatus, todoconfig.StatusInProgress, todoconfig.StatusReview, todoconfig.StatusCompleted, todoconfig.StatusScrapped, todoconfig.StatusDraft)
+	}
+	return filter, nil
+}
+
+// resolveIterationFlags validates iteration filter values and resolves
+// "current", so a typo or a project with no current iteration is an error
+// rather than an empty list.
+func resolveIterationFlags(names []string) ([]string, error) {
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		it, err := todoCfg.ResolveIteration(name, todoStore.Now())
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, it)
+	}
+	return resolved, nil
+}
+
+// publicIssues drops internal issues unless include is set. Commands whose
+// output leaves the tracker (exports, changelog, roadmap) apply it, so an
+// internal issue is only published when asked for.
+func publicIssues(issues []*issue.Issue, include bool) []*issue.Issue {
+	if include {
+		return issues
+	}
+	kept := make([]*issue.Issue, 0, len(issues))
+	for _, b := range issues {
+		if b.Visibility != todoconfig.VisibilityInternal {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
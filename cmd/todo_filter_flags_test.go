@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestEgressExcludesInternal(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg, oldJSON := todoCfg, jsonOut
+	todoCfg = todoconfig.Default()
+	t.Cleanup(func() { todoCfg, jsonOut = oldCfg, oldJSON })
+
+	due := issue.NewDueDate(time.Now().AddDate(0, 0, 3))
+	for _, b := range []*issue.Issue{
+		{ID: "vis-pub", Slug: "public", Title: "Public roadmap item", Status: "ready", Type: "task", Due: due},
+		{ID: "vis-int", Slug: "internal", Title: "Secret roadmap item", Status: "ready", Type: "task", Due: due, Visibility: todoconfig.VisibilityInternal},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		cmd   *cobra.Command
+		flags map[string]string
+	}{
+		{"export-csv", exportCSVCmd, nil},
+		{"export-calendar", exportCalendarCmd, nil},
+		{"roadmap", roadmapCmd, map[string]string{"json": "true"}},
+		{"changelog", changelogCmd, map[string]string{"days": "7"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonOut = tt.name == "changelog"
+			out, err := runJSONCommand(t, tt.cmd, tt.flags)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if !strings.Contains(out, "Public roadmap item") {
+				t.Errorf("%s left out the public issue:\n%s", tt.name, out)
+			}
+			if strings.Contains(out, "Secret roadmap item") {
+				t.Errorf("%s published the internal issue by default:\n%s", tt.name, out)
+			}
+
+			out, err = runJSONCommand(t, tt.cmd, map[string]string{"include-internal": "true"})
+			if err != nil {
+				t.Fatalf("%s --include-internal: %v", tt.name, err)
+			}
+			if !strings.Contains(out, "Secret roadmap item") {
+				t.Errorf("%s --include-internal left out the internal issue:\n%s", tt.name, out)
+			}
+		})
+	}
+
+	// Asking export-csv for internal issues alone needs the opt-in too.
+	_, err := runJSONCommand(t, exportCSVCmd, map[string]string{"visibility": "internal", "include-internal": "false"})
+	if got := errorCode(err, ""); got != output.ErrValidation {
+		t.Errorf("--visibility internal without --include-internal: code %q, want %s (error: %v)", got, output.ErrValidation, err)
+	}
+}
+
+func TestVisibilityFilterFlag(t *testing.T) {
+	_, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+
+	f := issueFilterFlags{visibility: "internal"}
+	filter, err := f.filter()
+	if err != nil || filter.Visibility == nil || *filter.Visibility != "internal" {
+		t.Errorf("filter() = %+v, %v; want visibility internal", filter, err)
+	}
+	f.visibility = "secret"
+	if _, err := f.filter(); err == nil || !strings.Contains(err.Error(), "invalid visibility") {
+		t.Errorf("filter() with --visibility secret: error = %v, want invalid visibility", err)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/nope"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	fmtCheck bool
+	fmtJSON  bool
+)
+
+// fmtResult is the --json output of todo fmt.
+type fmtResult struct {
+	Success bool     `json:"success"`
+	Check   bool     `json:"check"`
+	Files   []string `json:"files"`
+}
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Rewrite issue files in canonical form",
+	Long: `Rewrites every issue and milestone file in canonical form, so unrelated
+edits don't reorder keys and clutter diffs: front matter keys in a fixed
+order, sync data keys sorted, lists in block style, and one blank line
+before the body. Keys jig does not know are dropped.
+
+With --check, nothing is written; the files that would change are listed
+and the command exits 1 if there are any, for use in CI.`,
+	Example: `  jig todo fmt
+  jig todo fmt --check`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := todoStore.FormatFiles(!fmtCheck)
+		if err != nil {
+			return cmdError(fmtJSON, output.ErrFileError, "formatting issue files: %v", err)
+		}
+
+		if fmtJSON {
+			data, _ := json.MarshalIndent(fmtResult{Success: !fmtCheck || len(files) == 0, Check: fmtCheck, Files: files}, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			for _, f := range files {
+				if fmtCheck {
+					fmt.Printf("  %s %s\n", ui.Danger.Render(ui.Glyph(ui.FailSymbol)), f)
+				} else {
+					fmt.Printf("  %s %s\n", ui.Success.Render(ui.Glyph(ui.PassSymbol)), f)
+				}
+			}
+			switch {
+			case len(files) == 0:
+				fmt.Println(ui.Success.Render("All issue files are formatted"))
+			case fmtCheck:
+				fmt.Println(ui.Danger.Render(fmt.Sprintf("%d file(s) not formatted; run 'jig todo fmt'", len(files))))
+			default:
+				fmt.Println(ui.Success.Render(fmt.Sprintf("Formatted %d file(s)", len(files))))
+			}
+		}
+
+		if fmtCheck && len(files) > 0 {
+			return nope.ExitError{Code: 1}
+		}
+		return nil
+	},
+}
+
+func init() {
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "List unformatted files without rewriting them; exit 1 if there are any")
+	fmtCmd.Flags().BoolVar(&fmtJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(fmtCmd)
+}
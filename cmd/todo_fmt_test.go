@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/nope"
+)
+
+func TestFmtCheck(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	createQueryTestIssue(t, testCore, "fmt-001", "Canonical", "ready")
+	messyPath := filepath.Join(testCore.Root(), "fmt-002--messy.md")
+	if err := os.WriteFile(messyPath, []byte("---\nstatus: ready\ntitle: Messy\n---\nBody.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := testCore.Load(); err != nil {
+		t.Fatal(err)
+	}
+	oldCheck := fmtCheck
+	t.Cleanup(func() { fmtCheck = oldCheck })
+
+	fmtCheck = true
+	var runErr error
+	out := capturePorcelain(t, func() error { runErr = fmtCmd.RunE(fmtCmd, nil); return nil })
+	if exitErr, ok := errors.AsType[nope.ExitError](runErr); !ok || exitErr.Code != 1 {
+		t.Errorf("fmt --check error = %v, want exit 1", runErr)
+	}
+	if !strings.Contains(out, "fmt-002--messy.md") || strings.Contains(out, "fmt-001") {
+		t.Errorf("fmt --check output = %q, want only the messy file", out)
+	}
+
+	fmtCheck = false
+	capturePorcelain(t, func() error { return fmtCmd.RunE(fmtCmd, nil) })
+	fmtCheck = true
+	if out := capturePorcelain(t, func() error { return fmtCmd.RunE(fmtCmd, nil) }); strings.Contains(out, ".md") {
+		t.Errorf("fmt --check after fmt listed files: %q", out)
+	}
+	data, _ := os.ReadFile(messyPath)
+	if want := "---\n# fmt-002\ntitle: Messy\nstatus: ready\n---\n\nBody.\n"; string(data) != want {
+		t.Errorf("formatted file = %q, want %q", data, want)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/dot"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+var (
+	graphFormat          string
+	graphRoot            string
+	graphDepth           int
+	graphIncludeResolved bool
+	graphInternal        bool
+)
+
+var todoGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the issue graph in Graphviz DOT format",
+	Long: `Writes the issue graph as a Graphviz DOT digraph on stdout. Each issue is
+a node labelled with its title and ID, shaped by type and colored by status
+and type as in the terminal. Parent-to-child edges are solid; blocking
+edges are dashed and point from the blocker to the issue it blocks.
+
+With --root only the issues reachable from that issue through child and
+blocking edges are drawn, at most --depth edges away (0 for no limit).
+Without it every issue is drawn, grouped into a cluster per milestone.
+Edges that close a dependency cycle are drawn red with a comment naming
+the cycle.
+
+Issues in archive statuses are left out unless --include-resolved is
+given, and internal issues unless --include-internal is.`,
+	Example: `  jig todo graph | dot -Tsvg -o issues.svg
+  jig todo graph --root epic-1 --depth 2
+  jig todo graph --include-resolved > issues.dot`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if graphFormat != "dot" {
+			return cmdError(false, output.ErrValidation, "invalid --format %q: must be dot", graphFormat)
+		}
+		if graphDepth < 0 {
+			return cmdError(false, output.ErrValidation, "invalid --depth %d: must be 0 or more", graphDepth)
+		}
+
+		opts := dot.Options{
+			Config:          todoCfg,
+			Depth:           graphDepth,
+			IncludeResolved: graphIncludeResolved,
+			Milestones:      todoStore.AllMilestones(),
+		}
+		if graphRoot != "" {
+			root, err := resolveIssueArg(graphRoot)
+			if err != nil {
+				return cmdError(false, resolveErrorCode(err), "%w", err)
+			}
+			opts.Root = root.ID
+		}
+
+		var buf bytes.Buffer
+		if err := dot.Write(&buf, publicIssues(todoStore.All(), graphInternal), opts); err != nil {
+			return cmdError(false, output.ErrValidation, "%w", err)
+		}
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	},
+}
+
+func init() {
+	todoGraphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format (dot)")
+	todoGraphCmd.Flags().StringVar(&graphRoot, "root", "", "Draw only the issues reachable from this issue")
+	todoGraphCmd.Flags().IntVar(&graphDepth, "depth", 0, "With --root, how many edges away issues may be (0 = no limit)")
+	todoGraphCmd.Flags().BoolVar(&graphIncludeResolved, "include-resolved", false, "Include issues in archive statuses")
+	todoGraphCmd.Flags().BoolVar(&graphInternal, "include-internal", false, includeInternalUsage)
+	todoCmd.AddCommand(todoGraphCmd)
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,22 +10,29 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
-	"github.com/99designs/gqlgen/graphql/executor"
 	"github.com/spf13/cobra"
 	"github.com/tidwall/pretty"
 	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/ui"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/formatter"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
 )
 
 var (
-	queryJSON       bool
-	queryVariables  string
-	queryOperation  string
-	querySchemaOnly bool
-	queryFile       string
+	queryJSON          bool
+	queryVariables     string
+	queryVariablesFile string
+	queryOperation     string
+	queryStrict        bool
+	querySchemaOnly    bool
+	queryFile          string
+	queryTimeout       time.Duration
 )
 
 var graphqlCmd = &cobra.Command{
@@ -33,7 +41,13 @@ var graphqlCmd = &cobra.Command{
 	Short:   "Execute a GraphQL query or mutation",
 	Long: `Execute a GraphQL query or mutation against the issues data.
 
-The argument should be a valid GraphQL query or mutation string.
+The argument should be a valid GraphQL query or mutation string, or - to
+read it from stdin. --query-file (or -f) reads it from a file instead, and
+--variables-file reads the variables from a JSON file instead of -v.
+
+A document with several operations runs the one --operation names, or the
+first when none is named. --strict makes that an error instead, so a
+forgotten --operation cannot run the wrong one.
 
 Examples:
   # List all issues
@@ -45,11 +59,11 @@ Examples:
   # Use variables
   jig todo graphql -v '{"id": "abc"}' 'query GetIssue($id: ID!) { issue(id: $id) { title } }'
 
-  # Read from a file (avoids shell escaping issues with backticks)
-  jig todo graphql -f query.graphql
+  # Read the query and variables from files (avoids shell escaping issues)
+  jig todo graphql --query-file ops.graphql --variables-file vars.json --operation GetIssue --strict
 
   # Read from stdin
-  echo '{ issues { id title } }' | jig todo graphql
+  echo '{ issues { id title } }' | jig todo graphql -
 
   # Print the schema
   jig todo graphql --schema`,
@@ -67,35 +81,34 @@ Examples:
 			return printSchema()
 		}
 
-		var query string
-		switch {
-		case queryFile != "":
-			q, err := readQueryFile(queryFile)
-			if err != nil {
-				return err
-			}
-			query = q
-		case len(args) == 1:
-			query = args[0]
-		default:
-			stdinQuery, err := readFromStdin()
-			if err != nil {
-				return err
-			}
-			if stdinQuery == "" {
-				return errors.New("no query provided (pass as argument, -f file, or pipe to stdin)")
-			}
-			query = stdinQuery
+		query, err := graphqlQuery(args)
+		if err != nil {
+			return err
+		}
+		variables, err := graphqlVariables()
+		if err != nil {
+			return err
+		}
+		op, err := selectOperation(query, queryOperation, queryStrict)
+		if err != nil {
+			return err
+		}
+		operationName := queryOperation
+		if op != nil {
+			operationName = op.Name
 		}
 
-		var variables map[string]any
-		if queryVariables != "" {
-			if err := json.Unmarshal([]byte(queryVariables), &variables); err != nil {
-				return fmt.Errorf("invalid variables JSON: %w", err)
-			}
+		ctx := context.Background()
+		if queryTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, queryTimeout)
+			defer cancel()
 		}
 
-		result, err := executeQuery(query, variables, queryOperation)
+		result, err := executeQueryContext(ctx, query, variables, operationName)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("graphql: query exceeded --timeout of %s", queryTimeout)
+		}
 		if err != nil {
 			return err
 		}
@@ -103,6 +116,9 @@ Examples:
 		if queryJSON {
 			fmt.Println(string(pretty.Pretty(result)))
 		} else {
+			if op != nil {
+				fmt.Println(ui.Muted.Render("# " + operationLabel(op)))
+			}
 			fmt.Println(string(pretty.Color(pretty.Pretty(result), nil)))
 		}
 
@@ -110,6 +126,100 @@ Examples:
 	},
 }
 
+// graphqlQuery returns the query from --query-file, the argument (stdin
+// when it is -), or piped stdin, in that order.
+func graphqlQuery(args []string) (string, error) {
+	switch {
+	case queryFile != "" && len(args) == 1:
+		return "", errors.New("pass the query as an argument or with --query-file, not both")
+	case queryFile != "":
+		return readQueryFile(queryFile)
+	case len(args) == 1 && args[0] == "-":
+		q, err := readFromStdin()
+		if err != nil {
+			return "", err
+		}
+		if q == "" {
+			return "", errors.New("no query on stdin")
+		}
+		return q, nil
+	case len(args) == 1:
+		return args[0], nil
+	}
+	q, err := readFromStdin()
+	if err != nil {
+		return "", err
+	}
+	if q == "" {
+		return "", errors.New("no query provided (pass as argument, --query-file, or pipe to stdin)")
+	}
+	return q, nil
+}
+
+// graphqlVariables decodes --variables or --variables-file.
+func graphqlVariables() (map[string]any, error) {
+	data, source := []byte(queryVariables), "variables"
+	if queryVariablesFile != "" {
+		var err error
+		if data, err = os.ReadFile(queryVariablesFile); err != nil {
+			return nil, fmt.Errorf("reading variables file: %w", err)
+		}
+		source = queryVariablesFile
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var variables map[string]any
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return nil, fmt.Errorf("invalid %s JSON: %w", source, jsonErrorPosition(data, err))
+	}
+	return variables, nil
+}
+
+// jsonErrorPosition adds the line and column a JSON decoding error points
+// at, counted from 1.
+func jsonErrorPosition(data []byte, err error) error {
+	var offset int64
+	if syntaxErr, ok := errors.AsType[*json.SyntaxError](err); ok {
+		offset = syntaxErr.Offset - 1 // Offset counts the offending byte
+	} else if typeErr, ok := errors.AsType[*json.UnmarshalTypeError](err); ok {
+		offset = typeErr.Offset
+	} else {
+		return err
+	}
+	before := data[:min(max(int(offset), 0), len(data))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
+
+// selectOperation picks the operation named name from query, or the first
+// one when name is empty. With strict, a document of several operations
+// needs a name. A query that does not parse returns nil, leaving execution
+// to report the syntax error.
+func selectOperation(query, name string, strict bool) (*ast.OperationDefinition, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil || len(doc.Operations) == 0 {
+		return nil, nil //nolint:nilerr // execution reports it with the same message
+	}
+	if name != "" {
+		return doc.Operations.ForName(name), nil
+	}
+	if strict && len(doc.Operations) > 1 {
+		names := make([]string, len(doc.Operations))
+		for i, op := range doc.Operations {
+			names[i] = cmp.Or(op.Name, "(anonymous)")
+		}
+		return nil, fmt.Errorf("document has %d operations (%s); --strict needs --operation to pick one", len(names), strings.Join(names, ", "))
+	}
+	return doc.Operations[0], nil
+}
+
+// operationLabel names op for the text output header, e.g. "query GetIssue".
+func operationLabel(op *ast.OperationDefinition) string {
+	return string(op.Operation) + " " + cmp.Or(op.Name, "(anonymous)")
+}
+
 func readQueryFile(path string) (string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -134,13 +244,19 @@ func readFromStdin() (string, error) {
 }
 
 func executeQuery(query string, variables map[string]any, operationName string) ([]byte, error) {
-	es := graph.NewExecutableSchema(graph.Config{
-		Resolvers: &graph.Resolver{Core: todoStore},
-	})
+	return executeQueryContext(context.Background(), query, variables, operationName)
+}
 
-	exec := executor.New(es)
+// executeQueryContext runs a query under ctx, so a deadline cancels resolver
+// work mid-traversal. Depth and complexity limits come from the todo config.
+// Resolver warnings go to stderr. Issues it creates are recorded as created
+// via graphql by the local actor, unless the input names another.
+func executeQueryContext(ctx context.Context, query string, variables map[string]any, operationName string) ([]byte, error) {
+	exec := graph.NewExecutor(&graph.Resolver{Core: todoStore})
 
-	ctx := graphql.StartOperationTrace(context.Background())
+	ctx = graph.WithCreator(ctx, graph.Creator{By: graph.DefaultActor(), Via: issue.CreatedViaGraphQL})
+
+	ctx = graphql.StartOperationTrace(ctx)
 	params := &graphql.RawParams{
 		Query:         query,
 		Variables:     variables,
@@ -156,25 +272,53 @@ func executeQuery(query string, variables map[string]any, operationName string)
 	handler, ctx := exec.DispatchOperation(ctx, opCtx)
 	resp := handler(ctx)
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("graphql: query cancelled: %w", err)
+	}
 	if len(resp.Errors) > 0 {
 		return nil, formatGraphQLErrors(resp.Errors)
 	}
+	if warnings, ok := resp.Extensions["warnings"].([]string); ok {
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, ui.Warning.Render("warning: ")+w)
+		}
+	}
 
 	return resp.Data, nil
 }
 
+// graphQLError carries the underlying gqlerror list so callers can inspect
+// extension codes (e.g. GRAPHQL_VALIDATION_FAILED for exceeded limits).
+type graphQLError struct {
+	errs gqlerror.List
+}
+
+func (e *graphQLError) Error() string {
+	if len(e.errs) == 1 {
+		return "graphql: " + e.errs[0].Message
+	}
+	var msgs []string
+	for _, err := range e.errs {
+		msgs = append(msgs, err.Message)
+	}
+	return "graphql errors:\n  " + strings.Join(msgs, "\n  ")
+}
+
+// Unwrap returns the resolver errors behind each GraphQL error, so typed
+// errors such as core.ErrNotFound decide the command's exit code.
+func (e *graphQLError) Unwrap() []error {
+	errs := make([]error, len(e.errs))
+	for i, err := range e.errs {
+		errs[i] = err
+	}
+	return errs
+}
+
 func formatGraphQLErrors(errs gqlerror.List) error {
 	if len(errs) == 0 {
 		return nil
 	}
-	if len(errs) == 1 {
-		return fmt.Errorf("graphql: %s", errs[0].Message)
-	}
-	var msgs []string
-	for _, e := range errs {
-		msgs = append(msgs, e.Message)
-	}
-	return fmt.Errorf("graphql errors:\n  %s", strings.Join(msgs, "\n  "))
+	return &graphQLError{errs: errs}
 }
 
 func printSchema() error {
@@ -198,8 +342,15 @@ func GetGraphQLSchema() string {
 func init() {
 	graphqlCmd.Flags().BoolVar(&queryJSON, "json", false, "Output JSON without colors (for piping)")
 	graphqlCmd.Flags().StringVarP(&queryVariables, "variables", "v", "", "Query variables as JSON string")
+	graphqlCmd.Flags().StringVar(&queryVariablesFile, "variables-file", "", "Read query variables from a JSON file")
 	graphqlCmd.Flags().StringVarP(&queryOperation, "operation", "o", "", "Operation name (for multi-operation documents)")
+	graphqlCmd.Flags().BoolVar(&queryStrict, "strict", false, "Fail when the document has several operations and --operation is not given")
 	graphqlCmd.Flags().BoolVar(&querySchemaOnly, "schema", false, "Print the GraphQL schema and exit")
-	graphqlCmd.Flags().StringVarP(&queryFile, "file", "f", "", "Read query from a file (avoids shell escaping issues)")
+	graphqlCmd.Flags().StringVarP(&queryFile, "query-file", "f", "", "Read query from a file (avoids shell escaping issues)")
+	graphqlCmd.Flags().StringVar(&queryFile, "file", "", "Alias for --query-file")
+	_ = graphqlCmd.Flags().MarkHidden("file")
+	graphqlCmd.MarkFlagsMutuallyExclusive("query-file", "file")
+	graphqlCmd.MarkFlagsMutuallyExclusive("variables", "variables-file")
+	graphqlCmd.Flags().DurationVar(&queryTimeout, "timeout", 30*time.Second, "Cancel the query after this long (0 disables)")
 	todoCmd.AddCommand(graphqlCmd)
 }
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var todoImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create issues from files exported by other tools",
+}
+
+func init() {
+	todoCmd.AddCommand(todoImportCmd)
+}
@@ -0,0 +1,549 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	importCSVMap          []string
+	importCSVStatusMap    []string
+	importCSVPriorityMap  []string
+	importCSVTypeMap      []string
+	importCSVTagDelimiter string
+	importCSVChunkSize    int
+	importCSVDryRun       bool
+	importCSVPreview      int
+	importCSVSkipExisting bool
+	importCSVJSON         bool
+)
+
+// csvImportFields are the issue fields a CSV column can be mapped to.
+var csvImportFields = []string{"title", "summary", "status", "type", "priority", "tags", "due", "created", "milestone", "iteration", "estimate", "created_by", "body"}
+
+var importCSVCmd = &cobra.Command{
+	Use:   "csv <file>",
+	Short: "Create issues from a CSV file",
+	Long: `Creates one issue per row of a CSV file. The first row must be a header.
+
+--map names the issue field each column fills, as Header=field pairs:
+` + strings.Join(csvImportFields, ", ") + `.
+Columns left out of the map are ignored. Without --map, columns whose header
+is a field name are used. A title column is required. Issues have no
+assignee; map such a column to created_by or tags if you want to keep it.
+
+--status-map, --priority-map, and --type-map translate values, as
+Source=value pairs matched without regard to case; values they do not
+mention are used as they are. An empty status or type gets the project
+default. Tags are split on --tag-delimiter (";" by default, as export-csv
+writes them). Due dates take the forms --due does, plus relative ones like
+3d or fri; created takes RFC 3339 or a timestamp without a zone, down to a
+bare date, read as UTC.
+
+Every row is checked before anything is written, and every problem is
+reported with its row number (the header is row 1, as in a spreadsheet).
+Rows with problems are not imported. The rest are created in chunks of
+--chunk-size: if one issue in a chunk cannot be saved, the chunk's issues
+are deleted again and the whole chunk counts as failed.
+
+--skip-existing-titles skips a row whose title exactly matches an existing
+issue or an earlier row. --dry-run writes nothing and shows the first
+--preview issues as they would be created.`,
+	Example: `  jig todo import csv issues.csv --map "Title=title,State=status,Labels=tags" --status-map "Open=ready,Done=completed"
+  jig todo import csv export.csv --tag-delimiter , --skip-existing-titles
+  jig todo import csv issues.csv --map "Summary=title,Due Date=due" --dry-run --preview 5`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if importCSVChunkSize < 1 {
+			return cmdError(importCSVJSON, output.ErrValidation, "--chunk-size must be at least 1")
+		}
+		opts, err := newCSVImportOptions()
+		if err != nil {
+			return cmdError(importCSVJSON, output.ErrValidation, "%w", err)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return cmdError(importCSVJSON, output.ErrFileError, "%w", err)
+		}
+		defer f.Close()
+		rows, rowErrors, err := readCSVImport(f, opts)
+		if err != nil {
+			return cmdError(importCSVJSON, output.ErrValidation, "%s: %w", args[0], err)
+		}
+
+		// Failed rows are in the report; usage would only bury it.
+		cmd.SilenceUsage = true
+		report := importCSVRows(rows, importCSVChunkSize, importCSVSkipExisting, importCSVDryRun)
+		report.addRowErrors(rowErrors)
+		if importCSVDryRun && len(report.Issues) > importCSVPreview {
+			report.Issues = report.Issues[:max(importCSVPreview, 0)]
+		}
+
+		var created, rowErrs []string
+		if !report.DryRun {
+			created = issueIDs(report.Issues)
+		}
+		for _, n := range report.Errors {
+			rowErrs = append(rowErrs, fmt.Sprintf("row %d: %s", n.Row, n.Message))
+		}
+		if err := resolveOutputMode(importCSVJSON).write(commandResult{
+			JSON:        func() error { return writeIndentedJSON(report) },
+			Quiet:       created,
+			QuietErrors: rowErrs,
+			Text: func() error {
+				printCSVImportReport(report)
+				return nil
+			},
+		}); err != nil {
+			return err
+		}
+		if report.Failed > 0 {
+			err := fmt.Errorf("%d row(s) of %s failed", report.Failed, args[0])
+			if resolveOutputMode(importCSVJSON) == outputJSON {
+				return output.AlreadyReported(output.ErrValidation, err)
+			}
+			return &output.CodedError{Code: output.ErrValidation, Err: err}
+		}
+		return nil
+	},
+}
+
+// csvImportOptions say how CSV rows become issues.
+type csvImportOptions struct {
+	// columns maps header names to issue fields; empty means headers that
+	// are field names.
+	columns map[string]string
+	// values maps a field to its translations, keyed by lower-case source
+	// value.
+	values       map[string]map[string]string
+	tagDelimiter string
+	actor        string
+	now          time.Time
+}
+
+// newCSVImportOptions builds the import options from the flags.
+func newCSVImportOptions() (csvImportOptions, error) {
+	opts := csvImportOptions{
+		values:       map[string]map[string]string{},
+		tagDelimiter: importCSVTagDelimiter,
+		actor:        graph.DefaultActor(),
+		now:          todoStore.Now(),
+	}
+	if opts.tagDelimiter == "" {
+		return opts, errors.New("--tag-delimiter must not be empty")
+	}
+	var err error
+	if opts.columns, err = parseCSVColumnMap(importCSVMap); err != nil {
+		return opts, err
+	}
+	for field, pairs := range map[string][]string{"status": importCSVStatusMap, "priority": importCSVPriorityMap, "type": importCSVTypeMap} {
+		if opts.values[field], err = parseCSVValueMap(field, pairs); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// parseCSVColumnMap parses Header=field pairs.
+func parseCSVColumnMap(pairs []string) (map[string]string, error) {
+	columns := make(map[string]string, len(pairs))
+	mapped := map[string]string{}
+	for _, pair := range pairs {
+		header, field, ok := strings.Cut(pair, "=")
+		header, field = strings.TrimSpace(header), strings.ToLower(strings.TrimSpace(field))
+		if !ok || header == "" || field == "" {
+			return nil, fmt.Errorf("invalid --map entry %q (want Header=field)", pair)
+		}
+		if field == "assignee" {
+			return nil, fmt.Errorf("--map %s: issues have no assignee field (map the column to created_by or tags instead)", pair)
+		}
+		if !slices.Contains(csvImportFields, field) {
+			return nil, fmt.Errorf("--map %s: unknown field %q (must be %s)", pair, field, strings.Join(csvImportFields, ", "))
+		}
+		if other, ok := mapped[field]; ok {
+			return nil, fmt.Errorf("--map: columns %q and %q both fill %s", other, header, field)
+		}
+		if _, ok := columns[header]; ok {
+			return nil, fmt.Errorf("--map: column %q is mapped twice", header)
+		}
+		columns[header] = field
+		mapped[field] = header
+	}
+	return columns, nil
+}
+
+// parseCSVValueMap parses Source=value pairs for field, keyed by the
+// lower-case source value.
+func parseCSVValueMap(field string, pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" {
+			return nil, fmt.Errorf("invalid --%s-map entry %q (want Source=%s)", field, pair, field)
+		}
+		values[strings.ToLower(from)] = to
+	}
+	return values, nil
+}
+
+// csvImportRow is a row that parsed into an issue.
+type csvImportRow struct {
+	row   int
+	issue *issue.Issue
+}
+
+// csvRowNote is why a row was skipped or failed.
+type csvRowNote struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// readCSVImport reads the header and then every row of r, returning the
+// rows that parsed and a note for each problem found in the others. The
+// error is for a file that cannot be read as a whole.
+func readCSVImport(r io.Reader, opts csvImportOptions) ([]csvImportRow, []csvRowNote, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("empty file: a header row is required")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	fields, err := csvHeaderFields(header, opts.columns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []csvImportRow
+	var notes []csvRowNote
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, csv.ErrFieldCount) {
+			notes = append(notes, csvRowNote{Row: row, Message: fmt.Sprintf("has %d fields, want %d", len(record), len(header))})
+			continue
+		}
+		// The reader resumes on the next line after a malformed one.
+		if pe, ok := errors.AsType[*csv.ParseError](err); ok {
+			notes = append(notes, csvRowNote{Row: row, Message: pe.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		b, problems := parseCSVRow(record, fields, opts)
+		for _, p := range problems {
+			notes = append(notes, csvRowNote{Row: row, Message: p})
+		}
+		if len(problems) == 0 {
+			rows = append(rows, csvImportRow{row: row, issue: b})
+		}
+	}
+	return rows, notes, nil
+}
+
+// csvHeaderFields returns the issue field each column fills, "" for
+// columns that are not imported.
+func csvHeaderFields(header []string, columns map[string]string) ([]string, error) {
+	fields := make([]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if len(columns) == 0 {
+			if field := strings.ToLower(name); slices.Contains(csvImportFields, field) {
+				fields[i] = field
+			}
+			continue
+		}
+		fields[i] = columns[name]
+	}
+	for name := range columns {
+		if !slices.ContainsFunc(header, func(h string) bool { return strings.TrimSpace(h) == name }) {
+			return nil, fmt.Errorf("mapped column %q is not in the header (%s)", name, strings.Join(header, ", "))
+		}
+	}
+	if !slices.Contains(fields, "title") {
+		return nil, errors.New("no column is mapped to title")
+	}
+	return fields, nil
+}
+
+// parseCSVRow builds an issue from one record, returning every problem
+// with its values rather than stopping at the first.
+func parseCSVRow(record, fields []string, opts csvImportOptions) (*issue.Issue, []string) {
+	b := &issue.Issue{CreatedBy: opts.actor, CreatedVia: issue.CreatedViaImport}
+	var problems []string
+	fail := func(field string, err error) {
+		problems = append(problems, field+": "+err.Error())
+	}
+	for i, field := range fields {
+		if field == "" {
+			continue
+		}
+		value := record[i]
+		if field != "body" {
+			value = strings.TrimSpace(value)
+		}
+		if to, ok := opts.values[field][strings.ToLower(value)]; ok {
+			value = to
+		}
+		switch field {
+		case "title":
+			b.Title = value
+		case "summary":
+			if err := issue.ValidateSummary(value); err != nil {
+				fail(field, err)
+			}
+			b.Summary = value
+		case "status":
+			b.Status = value
+		case "type":
+			b.Type = value
+		case "priority":
+			if err := todoCfg.ValidatePriority(value); err != nil {
+				fail(field, err)
+			}
+			b.Priority = value
+		case "tags":
+			for tag := range strings.SplitSeq(value, opts.tagDelimiter) {
+				if tag = issue.NormalizeTag(tag); tag == "" {
+					continue
+				}
+				if err := b.AddTag(tag); err != nil {
+					fail(field, err)
+				}
+			}
+		case "due":
+			if value == "" {
+				continue
+			}
+			due, err := issue.ParseRelativeDue(value, opts.now)
+			if err != nil {
+				fail(field, err)
+			}
+			b.Due = due
+		case "created":
+			if value == "" {
+				continue
+			}
+			created, err := issue.ParseTimestamp(value)
+			if err != nil {
+				fail(field, err)
+				continue
+			}
+			created = created.Truncate(time.Second)
+			b.CreatedAt = &created
+		case "milestone":
+			if value != "" && !todoStore.MilestoneExists(value) {
+				fail(field, fmt.Errorf("milestone not found: %s", value))
+			}
+			b.Milestone = value
+		case "iteration":
+			iteration, err := todoCfg.ResolveIteration(value, opts.now)
+			if err != nil {
+				fail(field, err)
+			}
+			b.Iteration = iteration
+		case "estimate":
+			if err := todoCfg.ValidateEstimate(value); err != nil {
+				fail(field, err)
+			}
+			b.Estimate = value
+		case "created_by":
+			if value != "" {
+				b.CreatedBy = value
+			}
+		case "body":
+			b.Body = value
+		}
+	}
+
+	if b.Title == "" {
+		problems = append(problems, "title: empty")
+	}
+	if b.Status == "" {
+		b.Status = todoCfg.GetDefaultStatus()
+	} else if err := todoCfg.ValidateStatus(b.Status); err != nil {
+		fail("status", err)
+	} else if !todoCfg.IsStatusEnabled(b.Status) {
+		fail("status", fmt.Errorf("%q is disabled in this project (enabled: %s)", b.Status, todoCfg.EnabledStatusList()))
+	}
+	if b.Type == "" {
+		b.Type = todoCfg.GetDefaultType()
+	} else if err := todoCfg.ValidateType(b.Type); err != nil {
+		fail("type", err)
+	}
+	b.Slug = issue.Slugify(b.Title)
+	return b, problems
+}
+
+// csvImportReport is the outcome of an import, or of a dry run.
+type csvImportReport struct {
+	Success bool `json:"success"`
+	DryRun  bool `json:"dry_run,omitempty"`
+	Created int  `json:"created"`
+	Skipped int  `json:"skipped"`
+	Failed  int  `json:"failed"`
+	// Issues are the created issues, or on a dry run the ones that would be.
+	Issues      []*issue.Issue `json:"issues,omitempty"`
+	SkippedRows []csvRowNote   `json:"skipped_rows,omitempty"`
+	Errors      []csvRowNote   `json:"errors,omitempty"`
+}
+
+// addRowErrors counts the rows that did not parse as failed and adds their
+// notes, keeping all notes in row order.
+func (r *csvImportReport) addRowErrors(notes []csvRowNote) {
+	var last int
+	for _, n := range notes {
+		if n.Row != last {
+			r.Failed++
+			last = n.Row
+		}
+	}
+	r.Errors = append(r.Errors, notes...)
+	slices.SortStableFunc(r.Errors, func(a, b csvRowNote) int { return a.Row - b.Row })
+	r.Success = r.Failed == 0
+}
+
+// importCSVRows creates the parsed rows chunkSize at a time. A chunk is
+// all or nothing: when an issue cannot be created, the ones created before
+// it in the chunk are deleted. With dryRun nothing is written.
+func importCSVRows(rows []csvImportRow, chunkSize int, skipExisting, dryRun bool) *csvImportReport {
+	report := &csvImportReport{Success: true, DryRun: dryRun}
+	titles := map[string]string{}
+	if skipExisting {
+		for _, b := range todoStore.All() {
+			titles[b.Title] = b.ID
+		}
+	}
+	for chunk := range slices.Chunk(rows, chunkSize) {
+		var pending []csvImportRow
+		for _, r := range chunk {
+			if skipExisting {
+				if by, ok := titles[r.issue.Title]; ok {
+					report.Skipped++
+					report.SkippedRows = append(report.SkippedRows, csvRowNote{Row: r.row, Message: "title already used by " + by})
+					continue
+				}
+				titles[r.issue.Title] = fmt.Sprintf("row %d", r.row)
+			}
+			pending = append(pending, r)
+		}
+		if dryRun {
+			for _, r := range pending {
+				report.Issues = append(report.Issues, r.issue)
+			}
+			report.Created += len(pending)
+			continue
+		}
+		if failed, err := createCSVChunk(pending); err != nil {
+			for _, r := range pending {
+				msg := fmt.Sprintf("not imported: row %d failed", failed.row)
+				if r.row == failed.row {
+					msg = err.Error()
+				}
+				report.Errors = append(report.Errors, csvRowNote{Row: r.row, Message: msg})
+				delete(titles, r.issue.Title)
+			}
+			report.Failed += len(pending)
+			continue
+		}
+		for _, r := range pending {
+			titles[r.issue.Title] = r.issue.ID
+			report.Issues = append(report.Issues, r.issue)
+		}
+		report.Created += len(pending)
+	}
+	return report
+}
+
+// createCSVChunk creates the issues of one chunk, deleting them all again
+// if one fails. It returns the row that failed.
+func createCSVChunk(chunk []csvImportRow) (csvImportRow, error) {
+	for i, r := range chunk {
+		err := todoStore.Create(r.issue)
+		if err == nil {
+			continue
+		}
+		var undo []error
+		for _, done := range chunk[:i] {
+			if delErr := todoStore.Delete(done.issue.ID); delErr != nil {
+				undo = append(undo, fmt.Errorf("rolling back %s: %w", done.issue.ID, delErr))
+			}
+		}
+		return r, errors.Join(append([]error{err}, undo...)...)
+	}
+	return csvImportRow{}, nil
+}
+
+// printCSVImportReport writes a report for people.
+func printCSVImportReport(report *csvImportReport) {
+	for _, b := range report.Issues {
+		if report.DryRun {
+			fmt.Println(csvPreviewLine(b))
+			continue
+		}
+		fmt.Println(ui.Success.Render("Created ") + ui.ID.Render(b.ID) + " " + b.Title)
+	}
+	for _, n := range report.SkippedRows {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("Skipped row %d: %s", n.Row, n.Message)))
+	}
+	for _, n := range report.Errors {
+		fmt.Println(ui.Danger.Render(fmt.Sprintf("Row %d: ", n.Row)) + n.Message)
+	}
+	verb := "Created"
+	if report.DryRun {
+		verb = "Would create"
+	}
+	fmt.Printf("%s %d issue(s) · %d skipped · %d failed\n", verb, report.Created, report.Skipped, report.Failed)
+}
+
+// csvPreviewLine shows an issue as a dry run would create it.
+func csvPreviewLine(b *issue.Issue) string {
+	parts := []string{b.Title, "status=" + b.Status, "type=" + b.Type}
+	if b.Priority != "" {
+		parts = append(parts, "priority="+b.Priority)
+	}
+	if len(b.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(b.Tags, ","))
+	}
+	if b.Due != nil {
+		parts = append(parts, "due="+b.Due.String())
+	}
+	if b.CreatedAt != nil {
+		parts = append(parts, "created="+csvTime(b.CreatedAt))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func init() {
+	importCSVCmd.Flags().StringSliceVar(&importCSVMap, "map", nil, "Columns to import as Header=field pairs (comma-separated or repeated)")
+	importCSVCmd.Flags().StringSliceVar(&importCSVStatusMap, "status-map", nil, "Translate statuses as Source=status pairs")
+	importCSVCmd.Flags().StringSliceVar(&importCSVPriorityMap, "priority-map", nil, "Translate priorities as Source=priority pairs")
+	importCSVCmd.Flags().StringSliceVar(&importCSVTypeMap, "type-map", nil, "Translate types as Source=type pairs")
+	importCSVCmd.Flags().StringVar(&importCSVTagDelimiter, "tag-delimiter", ";", "Separator between tags in the tags column")
+	importCSVCmd.Flags().IntVar(&importCSVChunkSize, "chunk-size", 50, "Issues created together, all or nothing")
+	importCSVCmd.Flags().BoolVar(&importCSVDryRun, "dry-run", false, "Check every row and show what would be created without writing")
+	importCSVCmd.Flags().IntVar(&importCSVPreview, "preview", 10, "Issues shown by --dry-run")
+	importCSVCmd.Flags().BoolVar(&importCSVSkipExisting, "skip-existing-titles", false, "Skip rows whose title exactly matches an existing issue or an earlier row")
+	importCSVCmd.Flags().BoolVar(&importCSVJSON, "json", false, "Output the report as JSON")
+	addQuietFlag(importCSVCmd)
+	todoImportCmd.AddCommand(importCSVCmd)
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// importNow is a Wednesday.
+var importNow = time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+// setupImportTest points the command at an empty store with the default
+// config.
+func setupImportTest(t *testing.T) {
+	t.Helper()
+	_, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg := todoCfg
+	todoCfg = todoconfig.Default()
+	t.Cleanup(func() { todoCfg = oldCfg })
+}
+
+// readImportFixture reads testdata/import/name with the given --map and
+// --status-map entries.
+func readImportFixture(t *testing.T, name string, columns, statuses []string) ([]csvImportRow, []csvRowNote) {
+	t.Helper()
+	setupImportTest(t)
+
+	opts := csvImportOptions{tagDelimiter: ";", actor: "tester", now: importNow, values: map[string]map[string]string{}}
+	var err error
+	if opts.columns, err = parseCSVColumnMap(columns); err != nil {
+		t.Fatalf("parseCSVColumnMap() error = %v", err)
+	}
+	if opts.values["status"], err = parseCSVValueMap("status", statuses); err != nil {
+		t.Fatalf("parseCSVValueMap() error = %v", err)
+	}
+	f, err := os.Open(filepath.Join("testdata", "import", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, notes, err := readCSVImport(f, opts)
+	if err != nil {
+		t.Fatalf("readCSVImport() error = %v", err)
+	}
+	return rows, notes
+}
+
+func TestImportCSVQuoting(t *testing.T) {
+	rows, notes := readImportFixture(t, "quoting.csv",
+		[]string{"Title=title", "State=status", "Labels=tags", "Due Date=due", "Opened=created", "Notes=body", "Owner=created_by"},
+		[]string{"open=ready", "DONE=completed"})
+	if len(notes) > 0 {
+		t.Fatalf("notes = %v, want none", notes)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	opened := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	first := rows[0].issue
+	if first.Title != "Fix login, then logout" || first.Status != "ready" || first.Body != "First line\nsecond line" {
+		t.Errorf("row 2 = %q %q %q", first.Title, first.Status, first.Body)
+	}
+	if !slices.Equal(first.Tags, []string{"auth", "ui"}) || first.Due.String() != "2026-04-01" || !first.CreatedAt.Equal(opened) || first.CreatedBy != "sam" {
+		t.Errorf("row 2 tags %v, due %v, created %v, by %q", first.Tags, first.Due, first.CreatedAt, first.CreatedBy)
+	}
+	second := rows[1].issue
+	if second.Title != `Say "hello"` || second.Status != "completed" || second.Tags != nil || second.Due != nil || second.CreatedBy != "tester" {
+		t.Errorf("row 3 = %+v", second)
+	}
+	third := rows[2].issue
+	if third.Status != todoconfig.StatusReady || !slices.Equal(third.Tags, []string{"backend"}) || third.Due.String() != "2026-03-06" || third.Body != `Body with "quotes", commas` {
+		t.Errorf("row 4 status %q, tags %v, due %v, body %q", third.Status, third.Tags, third.Due, third.Body)
+	}
+	if rows[2].row != 4 {
+		t.Errorf("third row number = %d, want 4 (quoted line breaks do not count)", rows[2].row)
+	}
+
+	report := importCSVRows(rows, 2, false, false)
+	if report.Created != 3 || report.Failed != 0 || len(report.Issues) != 3 {
+		t.Fatalf("report = %+v, want 3 created", report)
+	}
+	b, err := todoStore.Get(report.Issues[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.CreatedAt.Equal(opened) || b.CreatedVia != issue.CreatedViaImport {
+		t.Errorf("saved created_at %v via %q, want %v via import", b.CreatedAt, b.CreatedVia, opened)
+	}
+}
+
+func TestImportCSVBadRows(t *testing.T) {
+	rows, notes := readImportFixture(t, "bad.csv",
+		[]string{"Title=title", "State=status", "Priority=priority", "Due Date=due"},
+		[]string{"Open=ready", "Done=completed"})
+
+	var got []string
+	for _, n := range notes {
+		got = append(got, n.Message[:strings.Index(n.Message+":", ":")])
+		if n.Row < 3 || n.Row > 6 {
+			t.Errorf("note for row %d: %s", n.Row, n.Message)
+		}
+	}
+	if want := []string{"title", "priority", "due", "status", "has 5 fields, want 4", "bare \" in non-quoted-field"}; !slices.Equal(got, want) {
+		t.Errorf("problems = %q, want %q", got, want)
+	}
+	for _, n := range notes[1:4] {
+		if n.Row != 4 {
+			t.Errorf("%q reported for row %d, want 4", n.Message, n.Row)
+		}
+	}
+	if notes[5].Row != 6 {
+		t.Errorf("bare quote reported for row %d, want 6", notes[5].Row)
+	}
+	if len(rows) != 2 || rows[0].row != 2 || rows[1].row != 7 {
+		t.Fatalf("rows = %+v, want rows 2 and 7", rows)
+	}
+
+	report := importCSVRows(rows, 50, false, true)
+	report.addRowErrors(notes)
+	if report.Created != 2 || report.Failed != 4 || report.Success {
+		t.Errorf("report = %+v, want 2 to create and 4 failed", report)
+	}
+	if len(todoStore.All()) != 0 {
+		t.Error("dry run created issues")
+	}
+}
+
+func TestImportCSVSkipAndRollback(t *testing.T) {
+	setupImportTest(t)
+	existing := &issue.Issue{Title: "Good one", Status: "ready"}
+	if err := todoStore.Create(existing); err != nil {
+		t.Fatal(err)
+	}
+	row := func(n int, title, id string) csvImportRow {
+		return csvImportRow{row: n, issue: &issue.Issue{ID: id, Title: title, Status: "ready", Type: "task"}}
+	}
+	rows := []csvImportRow{
+		row(2, "Good one", ""),
+		row(3, "Alpha", ""),
+		row(4, "Beta", existing.ID), // cannot be created, so Alpha is rolled back
+		row(5, "Gamma", ""),
+		row(6, "Gamma", ""),
+	}
+
+	report := importCSVRows(rows, 3, true, false)
+	if report.Created != 1 || report.Skipped != 2 || report.Failed != 2 {
+		t.Fatalf("report = %+v, want 1 created, 2 skipped, 2 failed", report)
+	}
+	wantSkips := []csvRowNote{{2, "title already used by " + existing.ID}, {6, "title already used by row 5"}}
+	if !slices.Equal(report.SkippedRows, wantSkips) {
+		t.Errorf("skipped rows = %+v, want %+v", report.SkippedRows, wantSkips)
+	}
+	if len(report.Errors) != 2 || report.Errors[0] != (csvRowNote{3, "not imported: row 4 failed"}) || report.Errors[1].Row != 4 {
+		t.Errorf("errors = %+v", report.Errors)
+	}
+	if got := len(todoStore.All()); got != 2 {
+		t.Errorf("got %d issues, want the existing one and Gamma; Alpha should be rolled back", got)
+	}
+}
+
+func TestParseCSVColumnMap(t *testing.T) {
+	for _, tt := range []struct {
+		pairs []string
+		want  string
+	}{
+		{[]string{"Assignee=assignee"}, "no assignee field"},
+		{[]string{"Title=name"}, `unknown field "name"`},
+		{[]string{"Title=title", "Name=title"}, "both fill title"},
+		{[]string{"Title"}, "want Header=field"},
+	} {
+		if _, err := parseCSVColumnMap(tt.pairs); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseCSVColumnMap(%q) error = %v, want %q", tt.pairs, err, tt.want)
+		}
+	}
+}
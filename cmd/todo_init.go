@@ -1,82 +1,281 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
-	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/output"
+	"golang.org/x/term"
 )
 
-var todoInitJSON bool
+var (
+	todoInitJSON           bool
+	todoInitDryRun         bool
+	todoInitStatuses       []string
+	todoInitSync           []string
+	todoInitRequireIfMatch bool
+)
 
 var todoInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a todo project",
-	Long:  `Creates a data directory and todo config section in .jig.yaml.`,
+	Long: `Creates a data directory and todo config section in .jig.yaml.
+
+Flags choose what the todo section starts with: --data-path for the data
+directory, --statuses for the statuses to enable beyond the mandatory ready
+and completed, --with-sync to add a sync provider section to fill in, and
+--require-if-match to require etags on updates. Run in a terminal without
+any of them and init asks instead.
+
+If .jig.yaml already has a todo section, init keeps every setting in it and
+adds only the keys it is missing. --dry-run prints the todo section and the
+directories init would create without touching disk.`,
+	Example: `  jig todo init
+  jig todo init --statuses in-progress,review,draft --with-sync github
+  jig todo init --data-path tasks --require-if-match --dry-run`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var projectDir string
-		var dataDir string
-
-		if todoDataPath != "" {
-			dataDir = todoDataPath
-			projectDir = filepath.Dir(dataDir)
-			c := core.New(dataDir, nil)
-			if err := c.Init(); err != nil {
-				if todoInitJSON {
-					return output.Error(output.ErrFileError, err.Error())
-				}
-				return fmt.Errorf("failed to create directory: %w", err)
-			}
-		} else {
-			dir, err := os.Getwd()
-			if err != nil {
-				if todoInitJSON {
-					return output.Error(output.ErrFileError, err.Error())
-				}
-				return err
+		fail := func(err error) error {
+			if todoInitJSON {
+				return output.Error(output.ErrFileError, err.Error())
 			}
+			return err
+		}
 
-			if err := core.Init(dir); err != nil {
-				if todoInitJSON {
-					return output.Error(output.ErrFileError, err.Error())
-				}
-				return fmt.Errorf("failed to initialize: %w", err)
-			}
+		cwd, err := todoStartDir()
+		if err != nil {
+			return fail(err)
+		}
 
-			projectDir = dir
-			dataDir = filepath.Join(dir, todoconfig.DefaultDataPath)
+		opts := todoconfig.InitOptions{
+			Statuses:       todoInitStatuses,
+			Sync:           todoInitSync,
+			RequireIfMatch: todoInitRequireIfMatch,
+		}
+		chosen := false
+		for _, name := range []string{"data-path", "statuses", "with-sync", "require-if-match"} {
+			chosen = chosen || cmd.Flags().Changed(name)
+		}
+		interactive := !todoInitJSON && !chosen && isTerminal(os.Stdin)
+		in := bufio.NewReader(os.Stdin)
+		dataPath := todoDataPath
+		if interactive {
+			dataPath = promptInitOptions(in, os.Stdout, &opts)
 		}
 
-		// Load existing config (returns defaults if no file exists)
-		cfg, err := todoconfig.LoadFromDirectory(projectDir)
+		plan, err := planTodoInit(cwd, dataPath, opts)
 		if err != nil {
 			if todoInitJSON {
-				return output.Error(output.ErrFileError, err.Error())
+				return output.Error(output.ErrValidation, err.Error())
 			}
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
 		}
-		cfg.SetConfigDir(projectDir)
-		if err := cfg.Save(projectDir); err != nil {
-			if todoInitJSON {
-				return output.Error(output.ErrFileError, err.Error())
+
+		if todoInitDryRun {
+			return writeTodoInitPlan(os.Stdout, plan, todoInitJSON)
+		}
+
+		if plan.Merge.Existed && len(plan.Merge.Added) > 0 && interactive {
+			question := fmt.Sprintf("%s already has a todo section; add %s?", plan.Merge.Path, strings.Join(plan.Merge.Added, ", "))
+			if !promptYes(in, os.Stdout, question, true) {
+				plan.Merge.Content = nil
 			}
-			return fmt.Errorf("failed to create config: %w", err)
 		}
 
-		if todoInitJSON {
-			return output.SuccessInit(dataDir)
+		if err := applyTodoInit(plan); err != nil {
+			return fail(err)
 		}
 
-		fmt.Println("Initialized todo project")
+		if todoInitJSON {
+			return output.SuccessInit(plan.DataDir)
+		}
+		switch {
+		case !plan.Merge.Existed:
+			fmt.Println("Initialized todo project")
+		case len(plan.Merge.Added) > 0 && plan.Merge.Content != nil:
+			fmt.Printf("Added to existing todo section: %s\n", strings.Join(plan.Merge.Added, ", "))
+		default:
+			fmt.Println("Todo project already initialized")
+		}
+		for _, name := range opts.Sync {
+			for key := range todoconfig.SyncScaffolds[name] {
+				fmt.Printf("Fill in todo.sync.%s.%s in %s\n", name, key, todoconfig.ConfigFileName)
+			}
+		}
 		return nil
 	},
 }
 
+// todoInitPlan is what `todo init` will create and write.
+type todoInitPlan struct {
+	DataDir string
+	// Directories are the directories that do not exist yet.
+	Directories []string
+	Merge       *todoconfig.MergeResult
+}
+
+// planTodoInit works out where the data directory goes and the .jig.yaml
+// that results from scaffolding opts into the config found from cwd.
+// dataPath, if set, names the data directory; the config is written next to
+// it, as before, and records it relative to the config.
+func planTodoInit(cwd, dataPath string, opts todoconfig.InitOptions) (*todoInitPlan, error) {
+	configDir := cwd
+	if dataPath != "" {
+		abs, err := filepath.Abs(dataPath)
+		if err != nil {
+			return nil, err
+		}
+		configDir = filepath.Dir(abs)
+		opts.Path = filepath.Base(abs)
+	} else if found, err := todoconfig.FindConfig(cwd); err != nil {
+		return nil, err
+	} else if found != "" {
+		configDir = filepath.Dir(found)
+	}
+
+	cfg, err := todoconfig.Scaffold(opts)
+	if err != nil {
+		return nil, err
+	}
+	merge, err := cfg.MergeMissing(configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &todoInitPlan{DataDir: merge.DataPath, Merge: merge}
+	if _, err := os.Stat(plan.DataDir); os.IsNotExist(err) {
+		plan.Directories = append(plan.Directories, plan.DataDir)
+	}
+	return plan, nil
+}
+
+// applyTodoInit creates the data directory and writes the config. A nil
+// Merge.Content leaves the config as it is.
+func applyTodoInit(plan *todoInitPlan) error {
+	if err := os.MkdirAll(plan.DataDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if plan.Merge.Content == nil || (plan.Merge.Existed && len(plan.Merge.Added) == 0) {
+		return nil
+	}
+	if err := os.WriteFile(plan.Merge.Path, plan.Merge.Content, 0o644); err != nil {
+		return fmt.Errorf("failed to create config: %w", err)
+	}
+	return nil
+}
+
+// writeTodoInitPlan prints what init would do.
+func writeTodoInitPlan(w io.Writer, plan *todoInitPlan, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]any{
+			"dry_run":     true,
+			"config_path": plan.Merge.Path,
+			"config":      string(plan.Merge.Section),
+			"existing":    plan.Merge.Existed,
+			"added":       plan.Merge.Added,
+			"directories": plan.Directories,
+		})
+	}
+
+	for _, dir := range plan.Directories {
+		fmt.Fprintf(w, "Would create directory: %s\n", dir)
+	}
+	switch {
+	case !plan.Merge.Existed:
+		fmt.Fprintf(w, "Would write to %s:\n\n%s", plan.Merge.Path, plan.Merge.Section)
+	case len(plan.Merge.Added) > 0:
+		fmt.Fprintf(w, "Would add %s to the todo section in %s:\n\n%s", strings.Join(plan.Merge.Added, ", "), plan.Merge.Path, plan.Merge.Section)
+	default:
+		fmt.Fprintf(w, "%s already has every todo setting; nothing to write\n", plan.Merge.Path)
+	}
+	return nil
+}
+
+// promptInitOptions asks for the init choices, filling in opts, and returns
+// the data path entered ("" for the default).
+func promptInitOptions(in *bufio.Reader, w io.Writer, opts *todoconfig.InitOptions) string {
+	dataPath := promptLine(in, w, "Data directory", todoconfig.DefaultDataPath)
+	if dataPath == todoconfig.DefaultDataPath {
+		dataPath = ""
+	}
+
+	var optional []string
+	for _, s := range todoconfig.DefaultStatusNames() {
+		if !todoconfig.IsMandatoryStatus(s) {
+			optional = append(optional, s)
+		}
+	}
+	fmt.Fprintf(w, "Statuses %s are always enabled; also enable (from %s)\n",
+		strings.Join(todoconfig.MandatoryStatuses, " and "), strings.Join(optional, ", "))
+	opts.Statuses = splitList(promptLine(in, w, "Statuses", strings.Join(optional, ",")))
+
+	opts.RequireIfMatch = promptYes(in, w, "Require etags (--if-match) on updates?", false)
+
+	sync := promptLine(in, w, fmt.Sprintf("Sync provider (%s, or none)", strings.Join(todoconfig.SyncProviderNames(), ", ")), "none")
+	if sync != "none" {
+		opts.Sync = splitList(sync)
+	}
+	return dataPath
+}
+
+// promptLine asks question and returns the trimmed answer, or def when the
+// answer is empty.
+func promptLine(in *bufio.Reader, w io.Writer, question, def string) string {
+	fmt.Fprintf(w, "%s [%s]: ", question, def)
+	answer, _ := in.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def
+	}
+	return answer
+}
+
+// promptYes asks a yes/no question, returning def for an empty answer.
+func promptYes(in *bufio.Reader, w io.Writer, question string, def bool) bool {
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	fmt.Fprintf(w, "%s %s ", question, hint)
+	answer, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return def
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// splitList splits a comma-separated answer, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// isTerminal reports whether f is an interactive terminal. A character
+// device alone is not enough: /dev/null is one too.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
 func init() {
 	todoInitCmd.Flags().BoolVar(&todoInitJSON, "json", false, "Output as JSON")
+	todoInitCmd.Flags().BoolVar(&todoInitDryRun, "dry-run", false, "Print the config and directories init would create without writing")
+	todoInitCmd.Flags().StringSliceVar(&todoInitStatuses, "statuses", nil, "Statuses to enable besides ready and completed (comma-separated)")
+	todoInitCmd.Flags().StringSliceVar(&todoInitSync, "with-sync", nil, "Add a sync section for a provider (github, clickup)")
+	todoInitCmd.Flags().BoolVar(&todoInitRequireIfMatch, "require-if-match", false, "Require --if-match etags on updates")
 	todoCmd.AddCommand(todoInitCmd)
 }
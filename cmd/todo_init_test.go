@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+)
+
+func TestTodoInitDryRunWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	opts := todoconfig.InitOptions{Statuses: []string{"review"}, Sync: []string{"github"}}
+	plan, err := planTodoInit(dir, "", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeTodoInitPlan(&buf, plan, false); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	dataDir := filepath.Join(dir, todoconfig.DefaultDataPath)
+	for _, want := range []string{
+		"Would create directory: " + dataDir,
+		"Would write to " + filepath.Join(dir, todoconfig.ConfigFileName),
+		"review: true",
+		"repo: \"\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry-run output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := writeTodoInitPlan(&buf, plan, true); err != nil {
+		t.Fatal(err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("dry-run JSON: %v\n%s", err, buf.String())
+	}
+	if payload["dry_run"] != true {
+		t.Errorf("dry_run = %v, want true", payload["dry_run"])
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("dry run touched disk: %v", entries)
+	}
+}
+
+func TestTodoInitMergesIntoExistingConfig(t *testing.T) {
+	path := writeTempConfig(t, "todo:\n  path: work\n")
+	dir := filepath.Dir(path)
+	plan, err := planTodoInit(dir, "", todoconfig.InitOptions{RequireIfMatch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.DataDir != filepath.Join(dir, "work") {
+		t.Errorf("DataDir = %q, want the existing path", plan.DataDir)
+	}
+	if err := applyTodoInit(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := todoconfig.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Path != "work" || !cfg.RequireIfMatch.All() {
+		t.Errorf("Path = %q, RequireIfMatch = %v, want work and true", cfg.Path, cfg.RequireIfMatch)
+	}
+	if _, err := os.Stat(plan.DataDir); err != nil {
+		t.Errorf("data directory not created: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	linkBlockedBy string
+	linkBlocking  string
+	linkParent    string
+)
+
+var todoLinkCmd = &cobra.Command{
+	Use:   "link <id>... | -",
+	Short: "Add the same relationship to many issues at once",
+	Long: `Links every listed issue to one other issue in a single run.
+
+  --blocked-by <id>  each issue is blocked by <id>
+  --blocking <id>    each issue blocks <id>
+  --parent <id>      each issue gets <id> as its parent
+
+The flags may be combined. Every link is checked before anything is
+written: both issues must exist, no link may close a cycle together with
+the rest of the batch, and parent links must fit the type hierarchy. If any
+link is rejected, no issue is changed.
+
+Pass - to read issue IDs from stdin, one per line:
+
+  jig todo link --blocked-by release-gate abc-def ghi-jkl
+  jig todo list --tag v2 --quiet | jig todo link --parent v2-epic -`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if linkBlockedBy == "" && linkBlocking == "" && linkParent == "" {
+			return cmdError(jsonOut, output.ErrValidation, "no relationship specified (use --blocked-by, --blocking, or --parent)")
+		}
+
+		refs, err := expandStdinArgs(args)
+		if err != nil {
+			return cmdError(jsonOut, output.ErrValidation, "%w", err)
+		}
+		targets, err := resolveIssueArgs(jsonOut, refs)
+		if err != nil {
+			return err
+		}
+		targets = uniqueIssues(targets)
+		links, err := buildLinkInputs(targets)
+		if err != nil {
+			return cmdError(jsonOut, resolveErrorCode(err), "%w", err)
+		}
+
+		resolver := &graph.Resolver{Core: todoStore}
+		updated, err := resolver.Mutation().LinkIssues(context.Background(), links)
+		results := linkResults(targets, updated, err)
+
+		linked, failures := quietResults(results)
+		if werr := resolveOutputMode(jsonOut).write(commandResult{
+			JSON:        func() error { return writeIndentedJSON(results) },
+			Quiet:       linked,
+			QuietErrors: failures,
+			Text: func() error {
+				for _, r := range results {
+					switch {
+					case r.OK:
+						fmt.Println(ui.Success.Render("Linked ") + ui.ID.Render(r.ID))
+					case r.Error != "":
+						fmt.Println(ui.Danger.Render("Failed ") + ui.ID.Render(r.ID) + " " + ui.Muted.Render(r.Error))
+					}
+				}
+				return nil
+			},
+		}); werr != nil {
+			return werr
+		}
+
+		if err != nil {
+			return fmt.Errorf("no links added: %w", err)
+		}
+		return nil
+	},
+}
+
+// expandStdinArgs replaces a "-" argument with the non-blank lines of stdin.
+func expandStdinArgs(args []string) ([]string, error) {
+	var refs []string
+	for _, arg := range args {
+		if arg != "-" {
+			refs = append(refs, arg)
+			continue
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				refs = append(refs, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("reading issue IDs from stdin: %w", err)
+		}
+	}
+	if len(refs) == 0 {
+		return nil, errors.New("no issue IDs given")
+	}
+	return refs, nil
+}
+
+// uniqueIssues drops repeats of an issue, keeping the first.
+func uniqueIssues(issues []*issue.Issue) []*issue.Issue {
+	seen := make(map[string]bool, len(issues))
+	return slices.DeleteFunc(issues, func(b *issue.Issue) bool {
+		dup := seen[b.ID]
+		seen[b.ID] = true
+		return dup
+	})
+}
+
+// buildLinkInputs turns the relationship flags into one link per target
+// and flag.
+func buildLinkInputs(targets []*issue.Issue) ([]*model.LinkInput, error) {
+	var links []*model.LinkInput
+	for _, f := range []struct{ linkType, ref string }{
+		{issue.LinkTypeBlockedBy, linkBlockedBy},
+		{issue.LinkTypeBlocking, linkBlocking},
+		{issue.LinkTypeParent, linkParent},
+	} {
+		if f.ref == "" {
+			continue
+		}
+		other, err := resolveIssueArg(f.ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range targets {
+			links = append(links, &model.LinkInput{ID: b.ID, Type: f.linkType, Target: other.ID})
+		}
+	}
+	return links, nil
+}
+
+// linkResults reports each target's outcome. A rejected batch changes no
+// issue, so only the issue whose link failed carries the error.
+func linkResults(targets []*issue.Issue, updated []*issue.Issue, err error) []bulkResult {
+	etags := make(map[string]string, len(updated))
+	for _, b := range updated {
+		etags[b.ID] = b.ETag()
+	}
+	linkErr, _ := errors.AsType[*graph.LinkError](err)
+
+	results := make([]bulkResult, 0, len(targets))
+	for _, b := range targets {
+		switch {
+		case err == nil:
+			results = append(results, bulkResult{ID: b.ID, OK: true, ETag: etags[b.ID]})
+		case linkErr == nil:
+			results = append(results, bulkResult{ID: b.ID, Error: err.Error()})
+		case linkErr.ID == b.ID:
+			results = append(results, bulkResult{ID: b.ID, Error: linkErr.Err.Error()})
+		default:
+			results = append(results, bulkResult{ID: b.ID})
+		}
+	}
+	return results
+}
+
+func init() {
+	todoLinkCmd.Flags().StringVar(&linkBlockedBy, "blocked-by", "", "Issue every listed issue is blocked by")
+	todoLinkCmd.Flags().StringVar(&linkBlocking, "blocking", "", "Issue every listed issue blocks")
+	todoLinkCmd.Flags().StringVar(&linkParent, "parent", "", "Parent for every listed issue")
+	addQuietFlag(todoLinkCmd)
+	todoCmd.AddCommand(todoLinkCmd)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func seedLinkIssues(t *testing.T) *core.Core {
+	t.Helper()
+	testCore := seedTestIssues(t,
+		&issue.Issue{ID: "lnk-gate", Slug: "gate", Title: "Release checklist", Status: "ready"},
+		&issue.Issue{ID: "lnk-001", Slug: "one", Title: "One", Status: "ready"},
+		&issue.Issue{ID: "lnk-002", Slug: "two", Title: "Two", Status: "ready"},
+	)
+	oldJSON := jsonOut
+	jsonOut = true
+	t.Cleanup(func() { jsonOut = oldJSON })
+	return testCore
+}
+
+func TestLinkFromStdin(t *testing.T) {
+	testCore := seedLinkIssues(t)
+
+	out := withStdin(t, "lnk-001\n\nlnk-002\n", func() (string, error) {
+		return runJSONCommand(t, todoLinkCmd, map[string]string{"blocked-by": "lnk-gate"}, "-")
+	})
+	var results []bulkResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %s", len(results), out)
+	}
+	for _, r := range results {
+		b, _ := testCore.Get(r.ID)
+		if !r.OK || r.ETag != b.ETag() {
+			t.Errorf("result %+v, want ok with etag %s", r, b.ETag())
+		}
+		if !b.IsBlockedBy("lnk-gate") {
+			t.Errorf("%s.BlockedBy = %v, want lnk-gate", r.ID, b.BlockedBy)
+		}
+	}
+}
+
+func TestLinkRejectsBatchCycle(t *testing.T) {
+	testCore := seedLinkIssues(t)
+	before, _ := testCore.DiskETag("lnk-001")
+
+	// Blocked by and blocking the same gate loops only with both links.
+	out, err := runJSONCommand(t, todoLinkCmd,
+		map[string]string{"blocked-by": "lnk-gate", "blocking": "lnk-gate"}, "lnk-001", "lnk-002")
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("link error = %v, want a cycle", err)
+	}
+	var results []bulkResult
+	if err := json.NewDecoder(strings.NewReader(out)).Decode(&results); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(results) != 2 || results[0].OK || !strings.Contains(results[0].Error, "cycle") || results[1].OK {
+		t.Errorf("results = %+v, want lnk-001 failed on the cycle and lnk-002 not linked", results)
+	}
+	if after, _ := testCore.DiskETag("lnk-001"); after != before {
+		t.Error("lnk-001 changed on disk after a rejected batch")
+	}
+}
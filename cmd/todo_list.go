@@ -3,11 +3,14 @@ package cmd
 import (
 	"cmp"
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"slices"
+	"time"
 
+	"github.com/charmbracelet/colorprofile"
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/graph"
@@ -19,30 +22,24 @@ import (
 )
 
 var (
-	listJSON        bool
-	listSearch      string
-	listStatus      []string
-	listNoStatus    []string
-	listType        []string
-	listNoType      []string
-	listPriority    []string
-	listNoPriority  []string
-	listMilestone   []string
-	listNoMilestone []string
-	listTag         []string
-	listNoTag       []string
-	listHasParent   bool
-	listNoParent    bool
-	listParentID    string
-	listHasBlocking bool
-	listNoBlocking  bool
-	listIsBlocked   bool
-	listReady       bool
-	listQuiet       bool
-	listSort        string
-	listFull        bool
+	listJSON    bool
+	listFilter  issueFilterFlags
+	listQuiet   bool
+	listSort    string
+	listFull    bool
+	listColumns []string
+	listWatch   bool
+	listEvery   time.Duration
+	listExplain string
 )
 
+// listExplainResult is the --json output of list --explain.
+type listExplainResult struct {
+	ID         string              `json:"id"`
+	Match      bool                `json:"match"`
+	Predicates []graph.Explanation `json:"predicates"`
+}
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
@@ -57,116 +54,192 @@ Search Syntax (--search/-S):
   log*           Wildcard prefix match
   "user login"   Exact phrase match
   user AND login Both terms required
-  user OR login  Either term matches`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		filter := &model.IssueFilter{
-			Status:           listStatus,
-			ExcludeStatus:    listNoStatus,
-			Type:             listType,
-			ExcludeType:      listNoType,
-			Priority:         listPriority,
-			ExcludePriority:  listNoPriority,
-			Milestone:        listMilestone,
-			ExcludeMilestone: listNoMilestone,
-			Tags:             listTag,
-			ExcludeTags:      listNoTag,
-		}
+  user OR login  Either term matches
 
-		if listSearch != "" {
-			filter.Search = &listSearch
-		}
-		if listHasParent {
-			filter.HasParent = &listHasParent
-		}
-		if listNoParent {
-			filter.NoParent = &listNoParent
-		}
-		if listParentID != "" {
-			filter.ParentID = &listParentID
-		}
-		if listHasBlocking {
-			filter.HasBlocking = &listHasBlocking
-		}
-		if listNoBlocking {
-			filter.NoBlocking = &listNoBlocking
-		}
-		if listReady && listIsBlocked {
-			return errors.New("--ready and --is-blocked are mutually exclusive")
-		}
-		if listIsBlocked {
-			filter.IsBlocked = &listIsBlocked
-		}
-		if listReady {
-			isBlocked := false
-			filter.IsBlocked = &isBlocked
-			filter.ExcludeStatus = append(filter.ExcludeStatus, todoconfig.StatusInProgress, todoconfig.StatusReview, todoconfig.StatusCompleted, todoconfig.StatusScrapped, todoconfig.StatusDraft)
-		}
+With --porcelain, --columns picks the fields of each record.
+
+--watch clears the screen and re-renders the list, with the same filters,
+sort, and columns, each time issues change. --interval polls at that period
+instead of watching files, for filesystems where change notification does not
+work; watch mode falls back to polling on its own when notification cannot
+start. With --json, each refresh is written as one JSON document per line.
 
-		resolver := &graph.Resolver{Core: todoStore}
-		issues, err := resolver.Query().Issues(context.Background(), filter)
+--explain <id> lists nothing; it evaluates the filter flags against that one
+issue and prints each condition with its outcome and the data it looked at,
+to show why an issue is or is not in the list.`,
+	Annotations: map[string]string{porcelainAnnotation: "columns"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := listFilter.filter()
 		if err != nil {
-			return fmt.Errorf("querying issues: %w", err)
+			return err
+		}
+		if listExplain != "" {
+			return explainList(os.Stdout, listExplain, filter)
 		}
+		if listWatch {
+			return runListWatch(cmd.Context(), os.Stdout, filter)
+		}
+		return renderList(os.Stdout, filter, true)
+	},
+}
 
-		sortIssues(issues, listSort, todoCfg)
+// renderList writes the issues matching filter to w in the format the list
+// flags select. indentJSON pretty-prints --json output; watch mode writes one
+// compact document per refresh instead.
+func renderList(w io.Writer, filter *model.IssueFilter, indentJSON bool) error {
+	resolver := &graph.Resolver{Core: todoStore}
+	issues, err := resolver.Query().Issues(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("querying issues: %w", err)
+	}
 
-		if listJSON {
-			if !listFull {
-				for _, b := range issues {
-					b.Body = ""
-				}
-			}
-			return output.SuccessMultiple(issues)
-		}
+	sortListIssues(issues, listSort, todoCfg)
 
-		if listQuiet {
+	if listJSON {
+		if !listFull {
 			for _, b := range issues {
-				fmt.Println(b.ID)
+				b.Body = ""
 			}
-			return nil
 		}
+		items := listJSONItems(issues, todoStore.Now())
+		enc := json.NewEncoder(w)
+		if indentJSON {
+			enc.SetIndent("", "  ")
+		}
+		return enc.Encode(items)
+	}
 
-		// Tree view
-		allIssues, err := resolver.Query().Issues(context.Background(), nil)
-		if err != nil {
-			return fmt.Errorf("querying all issues for tree: %w", err)
+	if todoPorcelain {
+		columns := listColumns
+		if len(columns) == 0 {
+			columns = defaultPorcelainColumns
 		}
+		return writeListPorcelain(w, issues, columns)
+	}
 
-		sortFn := func(b []*issue.Issue) {
-			sortIssues(b, listSort, todoCfg)
+	if listQuiet {
+		for _, b := range issues {
+			fmt.Fprintln(w, b.ID)
 		}
+		return nil
+	}
+
+	// Tree view
+	allIssues, err := resolver.Query().Issues(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("querying all issues for tree: %w", err)
+	}
 
-		tree := ui.BuildTree(issues, allIssues, sortFn)
+	sortFn := func(b []*issue.Issue) {
+		sortListIssues(b, listSort, todoCfg)
+	}
 
-		if len(tree) == 0 {
-			fmt.Println(ui.Muted.Render("No issues found. Create one with: jig todo create <title>"))
-			return nil
+	tree := ui.BuildTree(issues, allIssues, sortFn)
+
+	if len(tree) == 0 {
+		fmt.Fprintln(w, ui.Muted.Render("No issues found. Create one with: jig todo create <title>"))
+		return nil
+	}
+
+	maxIDWidth := 2
+	for _, b := range allIssues {
+		if len(b.ID) > maxIDWidth {
+			maxIDWidth = len(b.ID)
 		}
+	}
+	maxIDWidth += 2
 
-		maxIDWidth := 2
-		for _, b := range allIssues {
-			if len(b.ID) > maxIDWidth {
-				maxIDWidth = len(b.ID)
-			}
+	hasTags := false
+	for _, b := range issues {
+		if len(b.Tags) > 0 {
+			hasTags = true
+			break
 		}
-		maxIDWidth += 2
+	}
 
-		hasTags := false
-		for _, b := range issues {
-			if len(b.Tags) > 0 {
-				hasTags = true
-				break
-			}
+	termWidth := 80
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		termWidth = w
+	}
+
+	fmt.Fprint(w, ui.RenderTree(tree, todoCfg, maxIDWidth, hasTags, termWidth))
+	return nil
+}
+
+// explainList writes, for the issue query names, whether it passes each
+// condition of filter.
+func explainList(w io.Writer, query string, filter *model.IssueFilter) error {
+	b, err := resolveIssueArg(query)
+	if err != nil {
+		return cmdError(listJSON, resolveErrorCode(err), "%w", err)
+	}
+	explanations, err := graph.ExplainIssue(b, filter, todoStore)
+	if err != nil {
+		return cmdError(listJSON, output.ErrValidation, "%w", err)
+	}
+	match := !slices.ContainsFunc(explanations, func(e graph.Explanation) bool { return !e.Pass })
+
+	if listJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(listExplainResult{ID: b.ID, Match: match, Predicates: explanations})
+	}
+
+	w = colorprofile.NewWriter(w, os.Environ())
+	verdict := ui.Success.Render("matches")
+	if !match {
+		verdict = ui.Danger.Render("does not match")
+	}
+	fmt.Fprintf(w, "%s %s the filter\n", ui.ID.Render(b.ID), verdict)
+	if len(explanations) == 0 {
+		fmt.Fprintln(w, ui.Muted.Render("  no filter flags given"))
+	}
+	for _, e := range explanations {
+		outcome := ui.Success.Render("pass")
+		if !e.Pass {
+			outcome = ui.Danger.Render("fail")
 		}
+		fmt.Fprintf(w, "  %s(%s): %s %s\n", e.Filter, e.Arg, outcome, ui.Muted.Render("— "+e.Detail))
+	}
+	return nil
+}
+
+// listAgeFields are the computed fields list --json appends to each issue so
+// scripts don't have to recompute them from the timestamps.
+type listAgeFields struct {
+	Age             string `json:"age,omitempty"`
+	TimeSinceUpdate string `json:"time_since_update,omitempty"`
+	Stale           bool   `json:"stale"`
+}
 
-		termWidth := 80
-		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
-			termWidth = w
+// listJSONItem is an issue as list --json prints it: the regular issue
+// JSON followed by its age fields.
+type listJSONItem struct {
+	issue.JSONView
+	listAgeFields
+}
+
+// listJSONItems adds the age fields to each issue for list --json.
+func listJSONItems(issues []*issue.Issue, now time.Time) []listJSONItem {
+	items := make([]listJSONItem, 0, len(issues))
+	for _, b := range issues {
+		fields := listAgeFields{Stale: todoStore.IsStale(b)}
+		if b.CreatedAt != nil {
+			fields.Age = todoconfig.FormatAge(now.Sub(*b.CreatedAt))
 		}
+		if b.UpdatedAt != nil {
+			fields.TimeSinceUpdate = todoconfig.FormatAge(now.Sub(*b.UpdatedAt))
+		}
+		items = append(items, listJSONItem{JSONView: b.JSONView(), listAgeFields: fields})
+	}
+	return items
+}
 
-		fmt.Print(ui.RenderTree(tree, todoCfg, maxIDWidth, hasTags, termWidth))
-		return nil
-	},
+// sortListIssues orders issues for list output: pinned issues first, each
+// group sorted by sortBy.
+func sortListIssues(issues []*issue.Issue, sortBy string, cfg *todoconfig.Config) {
+	sortIssues(issues, sortBy, cfg)
+	issue.PinnedFirst(issues)
 }
 
 func sortIssues(issues []*issue.Issue, sortBy string, cfg *todoconfig.Config) {
@@ -220,26 +293,14 @@ func sortIssues(issues []*issue.Issue, sortBy string, cfg *todoconfig.Config) {
 
 func init() {
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "Output as JSON")
-	listCmd.Flags().StringVarP(&listSearch, "search", "S", "", "Full-text search in title and body")
-	listCmd.Flags().StringArrayVarP(&listStatus, "status", "s", nil, "Filter by status (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listNoStatus, "no-status", nil, "Exclude by status (can be repeated)")
-	listCmd.Flags().StringArrayVarP(&listType, "type", "t", nil, "Filter by type (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listNoType, "no-type", nil, "Exclude by type (can be repeated)")
-	listCmd.Flags().StringArrayVarP(&listPriority, "priority", "p", nil, "Filter by priority (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listNoPriority, "no-priority", nil, "Exclude by priority (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listMilestone, "milestone", nil, "Filter by milestone ID (can be repeated, OR logic)")
-	listCmd.Flags().StringArrayVar(&listNoMilestone, "no-milestone", nil, "Exclude by milestone ID (can be repeated)")
-	listCmd.Flags().StringArrayVar(&listTag, "tag", nil, "Filter by tag (can be repeated, OR logic)")
-	listCmd.Flags().StringArrayVar(&listNoTag, "no-tag", nil, "Exclude issues with tag (can be repeated)")
-	listCmd.Flags().BoolVar(&listHasParent, "has-parent", false, "Filter issues with a parent")
-	listCmd.Flags().BoolVar(&listNoParent, "no-parent", false, "Filter issues without a parent")
-	listCmd.Flags().StringVar(&listParentID, "parent", "", "Filter by parent ID")
-	listCmd.Flags().BoolVar(&listHasBlocking, "has-blocking", false, "Filter issues that are blocking others")
-	listCmd.Flags().BoolVar(&listNoBlocking, "no-blocking", false, "Filter issues that aren't blocking others")
-	listCmd.Flags().BoolVar(&listIsBlocked, "is-blocked", false, "Filter issues that are blocked by others")
-	listCmd.Flags().BoolVar(&listReady, "ready", false, "Filter issues available to start")
+	listFilter.register(listCmd)
 	listCmd.Flags().BoolVarP(&listQuiet, "quiet", "q", false, "Only output IDs (one per line)")
 	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by: status, priority, milestone, created, updated, due, id")
 	listCmd.Flags().BoolVar(&listFull, "full", false, "Include issue body in JSON output")
+	listCmd.Flags().StringSliceVar(&listColumns, "columns", nil, "Fields for --porcelain records (id, title, summary, status, type, priority, parent, milestone, iteration, estimate, tags, due, etag, path)")
+	listCmd.Flags().BoolVarP(&listWatch, "watch", "w", false, "Re-render the list whenever issues change (ctrl-C to stop)")
+	listCmd.Flags().DurationVar(&listEvery, "interval", 0, "With --watch, poll for changes this often instead of watching files (e.g. 2s)")
+	listCmd.Flags().StringVar(&listExplain, "explain", "", "Show why an issue does or does not match the filter flags, instead of listing")
+	listCmd.MarkFlagsMutuallyExclusive("explain", "watch")
 	todoCmd.AddCommand(listCmd)
 }
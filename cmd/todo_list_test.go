@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -125,6 +129,36 @@ func TestSortIssues(t *testing.T) {
 	})
 }
 
+func TestSortListIssuesPinnedFirst(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-1 * time.Hour)
+	due := issue.NewDueDate(now.AddDate(0, 0, 1))
+	testCfg := todoconfig.Default()
+
+	// Within each group "a" sorts ahead of "b" under every sort order.
+	newIssues := func() []*issue.Issue {
+		return []*issue.Issue{
+			{ID: "unpinned-b", Status: "completed", Priority: "low", CreatedAt: &earlier, UpdatedAt: &earlier},
+			{ID: "pinned-b", Status: "completed", Priority: "low", CreatedAt: &earlier, UpdatedAt: &earlier, Pinned: true},
+			{ID: "unpinned-a", Status: "in-progress", Priority: "critical", CreatedAt: &now, UpdatedAt: &now, Due: due},
+			{ID: "pinned-a", Status: "in-progress", Priority: "critical", CreatedAt: &now, UpdatedAt: &now, Due: due, Pinned: true},
+		}
+	}
+	want := []string{"pinned-a", "pinned-b", "unpinned-a", "unpinned-b"}
+
+	for _, sortBy := range []string{"", "status", "priority", "created", "updated", "due", "id"} {
+		t.Run(cmp.Or(sortBy, "default"), func(t *testing.T) {
+			issues := newIssues()
+			sortListIssues(issues, sortBy, testCfg)
+			for i, id := range want {
+				if issues[i].ID != id {
+					t.Errorf("sort %q: issues[%d] = %q, want %q", sortBy, i, issues[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
 func TestListReadyFlagMutualExclusion(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -150,3 +184,80 @@ func TestListReadyFlagMutualExclusion(t *testing.T) {
 }
 
 // TestTruncate was removed because the truncate function was extracted out of this package.
+
+func TestListJSONItemsAgeFields(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(5 * 24 * time.Hour)
+	b := &issue.Issue{ID: "age-1", Slug: "aging", Title: "Aging", Status: "in-progress"}
+	if err := testCore.Create(b); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	b.CreatedAt, b.UpdatedAt = &created, &updated
+
+	now := created.Add(20 * 24 * time.Hour)
+	testCore.SetClock(func() time.Time { return now })
+	testCore.Config().StaleAfter = "14d"
+
+	data, err := json.Marshal(listJSONItems([]*issue.Issue{b}, now)[0])
+	if err != nil {
+		t.Fatalf("encoding item: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("item is not valid JSON: %v\n%s", err, data)
+	}
+	if got["id"] != "age-1" || got["etag"] != b.ETag() {
+		t.Errorf("id = %v, etag = %v, want the regular issue fields", got["id"], got["etag"])
+	}
+	if got["age"] != "20d" || got["time_since_update"] != "15d" {
+		t.Errorf("age = %v, time_since_update = %v, want 20d and 15d", got["age"], got["time_since_update"])
+	}
+	if got["stale"] != true {
+		t.Errorf("stale = %v, want true", got["stale"])
+	}
+}
+
+func TestExplainList(t *testing.T) {
+	seedPorcelainIssues(t)
+	oldJSON := listJSON
+	t.Cleanup(func() { listJSON = oldJSON })
+
+	f := issueFilterFlags{noTag: []string{"a"}, ready: true}
+	filter, err := f.filter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listJSON = true
+	var buf bytes.Buffer
+	if err := explainList(&buf, "prc-001", filter); err != nil {
+		t.Fatalf("explainList() error = %v", err)
+	}
+	var got listExplainResult
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("explainList() output %q: %v", buf.String(), err)
+	}
+	if got.ID != "prc-001" || got.Match || len(got.Predicates) != 3 {
+		t.Fatalf("explainList() = %+v, want prc-001 failing 3 predicates", got)
+	}
+	if e := got.Predicates[1]; e.Filter != "excludeTags" || e.Pass || e.Detail != "tags: [a b]" {
+		t.Errorf("excludeTags explanation = %+v", e)
+	}
+
+	listJSON = false
+	buf.Reset()
+	if err := explainList(&buf, "prc-001", filter); err != nil {
+		t.Fatalf("explainList() error = %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "does not match") || !strings.Contains(out, "isBlocked(false): pass — active blockers: []") {
+		t.Errorf("explainList() text = %q", out)
+	}
+
+	if err := explainList(&buf, "nope-404", filter); err == nil {
+		t.Error("explainList() with unknown issue: want error")
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// defaultListPollInterval is how often list --watch polls when file watching
+// cannot start and no --interval was given.
+const defaultListPollInterval = 2 * time.Second
+
+// runListWatch renders the list, then renders it again after every batch of
+// issue changes until ctx is cancelled or the user presses ctrl-C. With
+// listEvery set, or when the file watcher cannot start, it polls the data
+// directory at that interval instead.
+func runListWatch(ctx context.Context, w io.Writer, filter *model.IssueFilter) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	tty := isTerminal(os.Stdout)
+	if tty {
+		fmt.Fprint(w, "\033[?25l")       // hide the cursor while redrawing
+		defer fmt.Fprint(w, "\033[?25h") // and bring it back on exit
+	}
+	draw := func() error {
+		// Headers and clearing would corrupt machine-readable output.
+		if !listJSON && !listQuiet && !todoPorcelain {
+			if tty {
+				fmt.Fprint(w, "\033[H\033[2J")
+			}
+			fmt.Fprintln(w, ui.Muted.Render("Updated "+todoStore.Now().Format(time.TimeOnly)+" · ctrl-C to stop"))
+			fmt.Fprintln(w)
+		}
+		return renderList(w, filter, false)
+	}
+
+	interval := listEvery
+	var events <-chan []core.IssueEvent
+	if interval <= 0 {
+		if err := todoStore.StartWatching(); err != nil {
+			interval = defaultListPollInterval
+			fmt.Fprintf(os.Stderr, "Warning: file watching unavailable (%v); polling every %s\n", err, interval)
+		} else {
+			defer todoStore.Unwatch() //nolint:errcheck // best-effort cleanup
+			var unsubscribe func()
+			events, unsubscribe = todoStore.Subscribe()
+			defer unsubscribe()
+		}
+	}
+
+	if err := draw(); err != nil {
+		return err
+	}
+
+	if interval > 0 {
+		return pollList(ctx, interval, draw)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := draw(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pollList reloads the data directory every interval and calls draw when
+// any issue was added, removed, or changed.
+func pollList(ctx context.Context, interval time.Duration, draw func() error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Sign what a reload sees, which can differ from issues created in this
+	// process in fields filled in on load.
+	if err := todoStore.Load(); err != nil {
+		return err
+	}
+	last := listSignature()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := todoStore.Load(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: reloading issues: %v\n", err)
+				continue
+			}
+			if sig := listSignature(); sig != last {
+				last = sig
+				if err := draw(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// listSignature identifies the current state of every issue, so polling can
+// tell whether anything changed since the last render.
+func listSignature() string {
+	var parts []string
+	for _, b := range todoStore.All() {
+		parts = append(parts, b.ID+":"+b.ETag())
+	}
+	return strings.Join(parts, ",")
+}
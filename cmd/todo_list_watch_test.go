@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// watchBuffer collects list --watch output written from another goroutine.
+type watchBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *watchBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *watchBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// startListWatch runs runListWatch until the test ends and returns its output.
+func startListWatch(t *testing.T) *watchBuffer {
+	t.Helper()
+	out := &watchBuffer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runListWatch(ctx, out, nil) }()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("runListWatch() error = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("runListWatch() did not return after cancel")
+		}
+	})
+	return out
+}
+
+// waitForOutput waits until out satisfies ok, failing the test after a while.
+func waitForOutput(t *testing.T, out *watchBuffer, what string, ok func(string) bool) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if s := out.String(); ok(s) {
+			return s
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s; output:\n%s", what, out.String())
+	return ""
+}
+
+// writeWatchedIssue writes an issue file the way another process would.
+func writeWatchedIssue(t *testing.T, c *core.Core, id, title string) {
+	t.Helper()
+	path := filepath.Join(c.Root(), issue.BuildPath(id, issue.Slugify(title)))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\ntitle: " + title + "\nstatus: ready\ntype: task\n---\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListWatch(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup) // after the watch stops
+	createQueryTestIssue(t, testCore, "wat-1", "First watched", "ready")
+
+	out := startListWatch(t)
+	waitForOutput(t, out, "first render", func(s string) bool {
+		return strings.Contains(s, "First watched")
+	})
+
+	writeWatchedIssue(t, testCore, "wat-2", "Second watched")
+	s := waitForOutput(t, out, "refresh", func(s string) bool {
+		return strings.Contains(s, "Second watched")
+	})
+	frames := strings.Split(s, "Updated ")
+	if len(frames) < 3 {
+		t.Fatalf("got %d frames, want a header per render:\n%s", len(frames)-1, s)
+	}
+	last := frames[len(frames)-1]
+	if !strings.Contains(last, "First watched") || !strings.Contains(last, "Second watched") {
+		t.Errorf("last frame lacks the full list:\n%s", last)
+	}
+}
+
+func TestListWatchJSON(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup) // after the watch stops
+	createQueryTestIssue(t, testCore, "wat-1", "First watched", "ready")
+	listJSON = true
+	t.Cleanup(func() { listJSON = false })
+
+	out := startListWatch(t)
+	waitForOutput(t, out, "first document", func(s string) bool { return strings.Count(s, "\n") >= 1 })
+
+	writeWatchedIssue(t, testCore, "wat-2", "Second watched")
+	s := waitForOutput(t, out, "second document", func(s string) bool {
+		return strings.Contains(s, "Second watched")
+	})
+
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		var items []map[string]any
+		if err := json.Unmarshal([]byte(line), &items); err != nil {
+			t.Fatalf("line %d is not a JSON document: %v\n%s", i, err, line)
+		}
+		if i == 0 && len(items) != 1 {
+			t.Errorf("first document has %d issues, want 1", len(items))
+		}
+		if i == len(lines)-1 && len(items) != 2 {
+			t.Errorf("last document has %d issues, want 2", len(items))
+		}
+	}
+}
+
+func TestListWatchInterval(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup) // after the watch stops
+	createQueryTestIssue(t, testCore, "wat-1", "First watched", "ready")
+	listQuiet = true
+	listEvery = 20 * time.Millisecond
+	t.Cleanup(func() { listQuiet, listEvery = false, 0 })
+
+	out := startListWatch(t)
+	waitForOutput(t, out, "first render", func(s string) bool { return s == "wat-1\n" })
+
+	// Polls with nothing changed render nothing.
+	time.Sleep(5 * listEvery)
+	if s := out.String(); s != "wat-1\n" {
+		t.Fatalf("output without changes = %q, want the first render only", s)
+	}
+
+	writeWatchedIssue(t, testCore, "wat-2", "Second watched")
+	waitForOutput(t, out, "polled refresh", func(s string) bool { return s == "wat-1\nwat-1\nwat-2\n" })
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	mergeInto         string
+	mergeDeleteSource bool
+	mergeMigrateSync  bool
+	mergeDryRun       bool
+)
+
+var todoMergeCmd = &cobra.Command{
+	Use:   "merge <source-id> --into <target-id>",
+	Short: "Fold a duplicate issue into another",
+	Long: `Merges the source issue into the target:
+
+  - the source body is appended to the target under "## Merged from <id>"
+  - tags are unioned; the earlier due date and higher priority are kept
+  - the source's children, and the issues it blocks or is blocked by, move
+    to the target
+  - other issues' bodies that mention the source mention the target instead
+  - the source gets merged_into: <target> and is scrapped, or is deleted
+    with --delete-source
+
+Sync data on the source is reported but stays put unless --migrate-sync is
+given; an entry the target already has for the same integration is never
+overwritten. Every change is checked before any is written (a move that
+breaks the type hierarchy or a link that closes a cycle rejects the whole
+merge), and either all are saved or none are. --dry-run shows the merge
+without writing it. --quiet prints the target's ID.`,
+	Example: `  jig todo merge abc-def --into ghi-jkl --dry-run
+  jig todo merge abc-def --into ghi-jkl --delete-source --migrate-sync`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if mergeInto == "" {
+			return cmdError(jsonOut, output.ErrValidation, "no target specified (use --into)")
+		}
+		src, err := resolveIssueArg(args[0])
+		if err != nil {
+			return cmdError(jsonOut, resolveErrorCode(err), "%w", err)
+		}
+		tgt, err := resolveIssueArg(mergeInto)
+		if err != nil {
+			return cmdError(jsonOut, resolveErrorCode(err), "%w", err)
+		}
+
+		resolver := &graph.Resolver{Core: todoStore}
+		result, err := resolver.Mutation().MergeIssues(context.Background(), src.ID, tgt.ID,
+			&mergeDeleteSource, &mergeMigrateSync, &mergeDryRun)
+		if err != nil {
+			return mutationError(jsonOut, err)
+		}
+
+		report := newMergeReport(src.ID, result)
+		return resolveOutputMode(jsonOut).write(commandResult{
+			JSON: func() error {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			},
+			Quiet: []string{report.Target.ID},
+			Text: func() error {
+				printMergeReport(report)
+				return nil
+			},
+		})
+	},
+}
+
+// mergeReport is the --json output of todo merge.
+type mergeReport struct {
+	Source        string           `json:"source"`
+	Target        *issue.Issue     `json:"target"`
+	Merged        *issue.Issue     `json:"merged,omitempty"`
+	DryRun        bool             `json:"dry_run"`
+	DeletedSource bool             `json:"deleted_source"`
+	Children      []string         `json:"children"`
+	Blocking      []string         `json:"blocking"`
+	BlockedBy     []string         `json:"blocked_by"`
+	Mentions      []string         `json:"mentions"`
+	TagsAdded     []string         `json:"tags_added"`
+	Sync          []mergeSyncEntry `json:"sync"`
+}
+
+// mergeSyncEntry is one sync entry of a mergeReport.
+type mergeSyncEntry struct {
+	Name     string `json:"name"`
+	Migrated bool   `json:"migrated"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func newMergeReport(sourceID string, r *model.MergeResult) mergeReport {
+	report := mergeReport{
+		Source:        sourceID,
+		Target:        r.Target,
+		Merged:        r.Source,
+		DryRun:        r.DryRun,
+		DeletedSource: r.DeletedSource,
+		Children:      r.Children,
+		Blocking:      r.Blocking,
+		BlockedBy:     r.BlockedBy,
+		Mentions:      r.Mentions,
+		TagsAdded:     r.TagsAdded,
+		Sync:          make([]mergeSyncEntry, 0, len(r.Sync)),
+	}
+	for _, s := range r.Sync {
+		entry := mergeSyncEntry{Name: s.Name, Migrated: s.Migrated}
+		if s.Reason != nil {
+			entry.Reason = *s.Reason
+		}
+		report.Sync = append(report.Sync, entry)
+	}
+	return report
+}
+
+func printMergeReport(r mergeReport) {
+	verb := "Merged "
+	if r.DryRun {
+		verb = "Would merge "
+	}
+	fmt.Println(ui.Success.Render(verb) + ui.ID.Render(r.Source) + " into " + ui.ID.Render(r.Target.ID))
+	for _, line := range []struct {
+		label string
+		ids   []string
+	}{
+		{"children moved", r.Children},
+		{"now blocks", r.Blocking},
+		{"now blocked by", r.BlockedBy},
+		{"mentions rewritten in", r.Mentions},
+		{"tags added", r.TagsAdded},
+	} {
+		if len(line.ids) > 0 {
+			fmt.Printf("  %s: %s\n", line.label, strings.Join(line.ids, ", "))
+		}
+	}
+	for _, s := range r.Sync {
+		if s.Migrated {
+			fmt.Printf("  sync %s: moved to %s\n", s.Name, r.Target.ID)
+		} else {
+			fmt.Println("  " + ui.Warning.Render("sync "+s.Name+": ") + s.Reason)
+		}
+	}
+	switch {
+	case r.DeletedSource && r.DryRun:
+		fmt.Println("  " + r.Source + " would be deleted")
+	case r.DeletedSource:
+		fmt.Println("  " + r.Source + " deleted")
+	case r.DryRun:
+		fmt.Println("  " + r.Source + " would be scrapped")
+	default:
+		fmt.Println("  " + r.Source + " scrapped")
+	}
+}
+
+func init() {
+	todoMergeCmd.Flags().StringVar(&mergeInto, "into", "", "Issue to merge the source into")
+	todoMergeCmd.Flags().BoolVar(&mergeDeleteSource, "delete-source", false, "Delete the source instead of scrapping it")
+	todoMergeCmd.Flags().BoolVar(&mergeMigrateSync, "migrate-sync", false, "Move the source's sync data to the target")
+	todoMergeCmd.Flags().BoolVar(&mergeDryRun, "dry-run", false, "Show the merge without writing anything")
+	addQuietFlag(todoMergeCmd)
+	todoCmd.AddCommand(todoMergeCmd)
+}
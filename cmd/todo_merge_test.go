@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestMergeDryRunThenMerge(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg, oldJSON := todoCfg, jsonOut
+	todoCfg, jsonOut = todoconfig.Default(), true
+	t.Cleanup(func() { todoCfg, jsonOut = oldCfg, oldJSON })
+
+	for _, b := range []*issue.Issue{
+		{ID: "mrg-001", Title: "Crash on save", Status: "ready", Type: "bug", Tags: []string{"editor"}, Body: "Stack trace attached."},
+		{ID: "mrg-002", Title: "Saving crashes", Status: "ready", Type: "bug"},
+		{ID: "mrg-003", Title: "Release", Status: "ready", Type: "task", BlockedBy: []string{"mrg-001"}},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before, _ := testCore.DiskETag("mrg-003")
+
+	t.Run("dry run", func(t *testing.T) {
+		out, err := runJSONCommand(t, todoMergeCmd, map[string]string{"into": "mrg-002", "dry-run": "true"}, "mrg-001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var report mergeReport
+		if err := json.Unmarshal([]byte(out), &report); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		if !report.DryRun || report.Target.ID != "mrg-002" || len(report.Blocking) != 1 || report.Blocking[0] != "mrg-003" {
+			t.Errorf("dry run report = %+v", report)
+		}
+		if after, _ := testCore.DiskETag("mrg-003"); after != before {
+			t.Error("dry run rewrote mrg-003")
+		}
+		if src, _ := testCore.Get("mrg-001"); src.Status != "ready" {
+			t.Error("dry run scrapped the source")
+		}
+	})
+
+	if _, err := runJSONCommand(t, todoMergeCmd, map[string]string{"into": "mrg-002"}, "mrg-001"); err != nil {
+		t.Fatal(err)
+	}
+	src, _ := testCore.Get("mrg-001")
+	if src.Status != "scrapped" || src.MergedInto != "mrg-002" {
+		t.Errorf("source status %q, merged_into %q", src.Status, src.MergedInto)
+	}
+	if tgt, _ := testCore.Get("mrg-002"); !tgt.HasTag("editor") {
+		t.Errorf("target tags = %v, want editor", tgt.Tags)
+	}
+	if rel, _ := testCore.Get("mrg-003"); !rel.IsBlockedBy("mrg-002") || rel.IsBlockedBy("mrg-001") {
+		t.Errorf("mrg-003 blocked_by = %v, want mrg-002", rel.BlockedBy)
+	}
+}
@@ -42,7 +42,7 @@ var milestoneCreateCmd = &cobra.Command{
 			return cmdError(milestoneJSON, output.ErrValidation, "milestone name is required (pass as argument or --name)")
 		}
 		if err := issue.ValidateShort(milestoneShort); err != nil {
-			return cmdError(milestoneJSON, output.ErrValidation, "%s", err)
+			return cmdError(milestoneJSON, output.ErrValidation, "%w", err)
 		}
 
 		m := &issue.Milestone{
@@ -53,21 +53,24 @@ var milestoneCreateCmd = &cobra.Command{
 		if milestoneDue != "" {
 			due, err := issue.ParseDueDate(milestoneDue)
 			if err != nil {
-				return cmdError(milestoneJSON, output.ErrValidation, "%s", err)
+				return cmdError(milestoneJSON, output.ErrValidation, "%w", err)
 			}
 			m.Due = due
 		}
 
 		if err := todoStore.CreateMilestone(m); err != nil {
-			return cmdError(milestoneJSON, output.ErrFileError, "failed to create milestone: %v", err)
+			return cmdError(milestoneJSON, output.ErrFileError, "failed to create milestone: %w", err)
 		}
 
-		if milestoneJSON {
-			return printMilestoneJSON(m)
-		}
-		fmt.Println(ui.Success.Render("Created milestone ") + ui.ID.Render(m.ID) +
-			" " + ui.Muted.Render("["+m.Short+"] "+m.Name))
-		return nil
+		return resolveOutputMode(milestoneJSON).write(commandResult{
+			JSON:  func() error { return printMilestoneJSON(m) },
+			Quiet: []string{m.ID},
+			Text: func() error {
+				fmt.Println(ui.Success.Render("Created milestone ") + ui.ID.Render(m.ID) +
+					" " + ui.Muted.Render("["+m.Short+"] "+m.Name))
+				return nil
+			},
+		})
 	},
 }
 
@@ -130,7 +133,7 @@ var milestoneUpdateCmd = &cobra.Command{
 		}
 		if cmd.Flags().Changed("short") {
 			if err := issue.ValidateShort(milestoneShort); err != nil {
-				return cmdError(milestoneJSON, output.ErrValidation, "%s", err)
+				return cmdError(milestoneJSON, output.ErrValidation, "%w", err)
 			}
 			m.Short = milestoneShort
 		}
@@ -146,19 +149,22 @@ var milestoneUpdateCmd = &cobra.Command{
 			} else {
 				due, err := issue.ParseDueDate(milestoneDue)
 				if err != nil {
-					return cmdError(milestoneJSON, output.ErrValidation, "%s", err)
+					return cmdError(milestoneJSON, output.ErrValidation, "%w", err)
 				}
 				m.Due = due
 			}
 		}
 		if err := todoStore.UpdateMilestone(m); err != nil {
-			return cmdError(milestoneJSON, output.ErrFileError, "failed to update milestone: %v", err)
+			return cmdError(milestoneJSON, output.ErrFileError, "failed to update milestone: %w", err)
 		}
-		if milestoneJSON {
-			return printMilestoneJSON(m)
-		}
-		fmt.Println(ui.Success.Render("Updated milestone ") + ui.ID.Render(m.ID))
-		return nil
+		return resolveOutputMode(milestoneJSON).write(commandResult{
+			JSON:  func() error { return printMilestoneJSON(m) },
+			Quiet: []string{m.ID},
+			Text: func() error {
+				fmt.Println(ui.Success.Render("Updated milestone ") + ui.ID.Render(m.ID))
+				return nil
+			},
+		})
 	},
 }
 
@@ -169,13 +175,16 @@ var milestoneDeleteCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := todoStore.DeleteMilestone(args[0]); err != nil {
-			return cmdError(milestoneJSON, output.ErrNotFound, "failed to delete milestone: %v", err)
+			return cmdError(milestoneJSON, output.ErrNotFound, "failed to delete milestone: %w", err)
 		}
-		if milestoneJSON {
-			return output.SuccessMessage("Milestone deleted")
-		}
-		fmt.Println(ui.Success.Render("Deleted milestone ") + ui.ID.Render(args[0]))
-		return nil
+		return resolveOutputMode(milestoneJSON).write(commandResult{
+			JSON:  func() error { return output.SuccessMessage("Milestone deleted") },
+			Quiet: []string{args[0]},
+			Text: func() error {
+				fmt.Println(ui.Success.Render("Deleted milestone ") + ui.ID.Render(args[0]))
+				return nil
+			},
+		})
 	},
 }
 
@@ -194,7 +203,7 @@ Use --dry-run to preview the changes without writing anything.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		migs, err := todoStore.MigrateMilestoneTypeIssues(milestoneMigrateDryRun)
 		if err != nil {
-			return cmdError(milestoneJSON, output.ErrFileError, "migration failed: %v", err)
+			return cmdError(milestoneJSON, output.ErrFileError, "migration failed: %w", err)
 		}
 		if milestoneJSON {
 			enc := json.NewEncoder(os.Stdout)
@@ -238,6 +247,7 @@ func init() {
 	milestoneCreateCmd.Flags().StringVar(&milestoneDue, "due", "", "Due date (YYYY-MM-DD)")
 	milestoneCreateCmd.Flags().StringVarP(&milestoneBody, "body", "d", "", "Description")
 	milestoneCreateCmd.Flags().BoolVar(&milestoneJSON, "json", false, "Output as JSON")
+	addQuietFlag(milestoneCreateCmd)
 	_ = milestoneCreateCmd.MarkFlagRequired("short")
 
 	milestoneListCmd.Flags().BoolVar(&milestoneJSON, "json", false, "Output as JSON")
@@ -248,8 +258,10 @@ func init() {
 	milestoneUpdateCmd.Flags().StringVar(&milestoneDue, "due", "", "Due date (YYYY-MM-DD, empty to clear)")
 	milestoneUpdateCmd.Flags().StringVarP(&milestoneBody, "body", "d", "", "Description")
 	milestoneUpdateCmd.Flags().BoolVar(&milestoneJSON, "json", false, "Output as JSON")
+	addQuietFlag(milestoneUpdateCmd)
 
 	milestoneDeleteCmd.Flags().BoolVar(&milestoneJSON, "json", false, "Output as JSON")
+	addQuietFlag(milestoneDeleteCmd)
 
 	milestoneMigrateCmd.Flags().BoolVar(&milestoneMigrateDryRun, "dry-run", false, "Preview changes without writing")
 	milestoneMigrateCmd.Flags().BoolVar(&milestoneJSON, "json", false, "Output as JSON")
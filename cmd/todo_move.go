@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	moveParent   string
+	moveRoot     bool
+	movePosition int
+	moveJSON     bool
+)
+
+var todoMoveCmd = &cobra.Command{
+	Use:   "move <id> (--parent <id> | --root)",
+	Short: "Move an issue under a new parent",
+	Long: `Moves an issue under a new parent, or to the top level with --root.
+
+The type hierarchy is validated the same way as 'update --parent'. Each
+change of parent is recorded as a line in the body's "History" section
+unless skip_move_notes is set in .jig.yaml.`,
+	Example: `  jig todo move abc --parent epic-1
+  jig todo move abc --root`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if moveRoot == cmd.Flags().Changed("parent") {
+			return cmdError(moveJSON, output.ErrValidation, "specify exactly one of --parent or --root")
+		}
+
+		b, err := resolveIssueArg(args[0])
+		if err != nil {
+			return cmdError(moveJSON, resolveErrorCode(err), "%w", err)
+		}
+
+		var newParent *string
+		if !moveRoot {
+			parent, err := resolveIssueArg(moveParent)
+			if err != nil {
+				return cmdError(moveJSON, resolveErrorCode(err), "%w", err)
+			}
+			newParent = &parent.ID
+		}
+		resolver := &graph.Resolver{Core: todoStore}
+		b, err = resolver.Mutation().MoveIssue(context.Background(), b.ID, newParent, nil)
+		if err != nil {
+			return mutationError(moveJSON, err)
+		}
+
+		where := "to the top level"
+		if b.Parent != "" {
+			where = "under " + b.Parent
+		}
+		return resolveOutputMode(moveJSON).write(commandResult{
+			JSON:  func() error { return output.Success(b, "Moved "+where) },
+			Quiet: []string{b.ID},
+			Text: func() error {
+				fmt.Println(ui.Success.Render("Moved ") + ui.ID.Render(b.ID) + " " + where)
+				return nil
+			},
+		})
+	},
+}
+
+func init() {
+	todoMoveCmd.Flags().StringVar(&moveParent, "parent", "", "New parent issue ID")
+	todoMoveCmd.Flags().BoolVar(&moveRoot, "root", false, "Move to the top level (no parent)")
+	todoMoveCmd.Flags().IntVar(&movePosition, "position", 0, "Ignored: issues have no sibling order")
+	_ = todoMoveCmd.Flags().MarkDeprecated("position", "issues have no sibling order; a moved issue goes after its new siblings")
+	todoMoveCmd.Flags().BoolVar(&moveJSON, "json", false, "Output as JSON")
+	addQuietFlag(todoMoveCmd)
+	todoCmd.AddCommand(todoMoveCmd)
+}
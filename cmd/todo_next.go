@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	nextCount int
+	nextTypes []string
+	nextTags  []string
+	nextJSON  bool
+)
+
+// nextPreviewLines caps how much of the first body section a next card shows.
+const nextPreviewLines = 4
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the issues to work on next",
+	Long: `Picks the issues to work on next and explains why.
+
+An issue qualifies when its status is one of next_statuses in the config
+(default: ready), no open issue blocks it, and no open issue blocks its
+parent or any issue above that. Qualifying issues are ranked by effective
+priority, then due date (issues without one last), then age (oldest first).
+An issue's effective priority is its own, or that of an open issue it
+blocks, directly or through others, if that is more urgent.
+
+Each issue is shown as a card with the first section of its body. With
+--json, each issue's full JSON also has a "reason" explaining its rank
+(e.g. "critical priority, due in 2 days, unblocks 3 issues"), plus
+"effective_priority", "priority_from", and "unblocks". The GraphQL
+nextIssues query makes the same selection.`,
+	Example: `  jig todo next
+  jig todo next --count 3 --type task,bug
+  jig todo next --tag backend --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if nextCount < 1 {
+			return cmdError(nextJSON, output.ErrValidation, "--count must be at least 1, got %d", nextCount)
+		}
+		for _, t := range nextTypes {
+			if err := todoCfg.ValidateType(t); err != nil {
+				return cmdError(nextJSON, output.ErrValidation, "%w", err)
+			}
+		}
+
+		picked := todoStore.Next(core.NextOptions{Types: nextTypes, Tags: nextTags, Count: nextCount})
+
+		if nextJSON {
+			items := nextJSONItems(picked)
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(items)
+		}
+
+		out := colorprofile.NewWriter(os.Stdout, os.Environ())
+		writeNextCards(out, picked)
+		return nil
+	},
+}
+
+// nextRankFields are the fields next --json appends to each issue.
+type nextRankFields struct {
+	Reason            string `json:"reason"`
+	EffectivePriority string `json:"effective_priority"`
+	PriorityFrom      string `json:"priority_from,omitempty"`
+	Unblocks          int    `json:"unblocks"`
+}
+
+// nextJSONItem is a picked issue as next --json prints it: the regular
+// issue JSON followed by its rank fields.
+type nextJSONItem struct {
+	issue.JSONView
+	nextRankFields
+}
+
+// nextJSONItems adds the rank fields to each picked issue.
+func nextJSONItems(picked []core.NextIssue) []nextJSONItem {
+	items := make([]nextJSONItem, 0, len(picked))
+	for _, n := range picked {
+		items = append(items, nextJSONItem{JSONView: n.Issue.JSONView(), nextRankFields: nextRankFields{
+			Reason:            n.Reason,
+			EffectivePriority: n.Priority,
+			PriorityFrom:      n.PriorityFrom,
+			Unblocks:          n.Unblocks,
+		}})
+	}
+	return items
+}
+
+// writeNextCards prints a compact card per picked issue: its ID, status,
+// priority, and type, the title, why it ranks where it does, and the start
+// of the first body section.
+func writeNextCards(w io.Writer, picked []core.NextIssue) {
+	if len(picked) == 0 {
+		fmt.Fprintf(w, "%s\n", ui.Muted.Render("Nothing to work on: no unblocked issues in "+strings.Join(todoCfg.GetNextStatuses(), ", ")))
+		return
+	}
+	for i, n := range picked {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		b := n.Issue
+		colors := todoCfg.GetIssueColors(b.Status, b.Type, b.Priority)
+
+		var line strings.Builder
+		line.WriteString(ui.ID.Render(b.ID))
+		line.WriteString(" ")
+		line.WriteString(ui.RenderStatusWithColor(b.Status, colors.StatusColor, colors.IsArchive))
+		if b.Priority != "" {
+			line.WriteString(" ")
+			line.WriteString(ui.RenderPriorityWithColor(b.Priority, colors.PriorityColor))
+		}
+		if b.Type != "" {
+			line.WriteString(" ")
+			line.WriteString(ui.Muted.Render(b.Type))
+		}
+		fmt.Fprintln(w, line.String())
+		fmt.Fprintln(w, ui.Title.Render(b.Title))
+		fmt.Fprintln(w, ui.Muted.Render(n.Reason))
+
+		if title, content := firstBodySection(b.Body); content != "" || title != "" {
+			fmt.Fprintln(w, ui.Muted.Render(strings.Repeat("─", 50)))
+			if title != "" {
+				fmt.Fprintln(w, ui.Bold.Render(title))
+			}
+			if content != "" {
+				fmt.Fprintln(w, content)
+			}
+		}
+	}
+}
+
+// firstBodySection returns the first section of body, trimmed to
+// nextPreviewLines lines: the text before the first heading if there is
+// any, otherwise the first heading and what follows it.
+func firstBodySection(body string) (title, content string) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ""
+	}
+	content = body
+	if sections := issue.ParseSections(body); len(sections) > 0 {
+		first := sections[0]
+		lines := strings.Split(body, "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(line, "#") && strings.TrimSpace(strings.TrimLeft(line, "#")) == first.Title {
+				content = strings.TrimSpace(strings.Join(lines[:i], "\n"))
+				break
+			}
+		}
+		if content == "" {
+			title, content = first.Title, strings.TrimSpace(first.Content)
+		}
+	}
+	lines := strings.Split(content, "\n")
+	if len(lines) > nextPreviewLines {
+		lines = append(lines[:nextPreviewLines], "…")
+	}
+	return title, strings.Join(lines, "\n")
+}
+
+func init() {
+	nextCmd.Flags().IntVarP(&nextCount, "count", "n", 1, "Number of issues to show")
+	nextCmd.Flags().StringSliceVarP(&nextTypes, "type", "t", nil, "Only issues of these types (comma-separated or repeated)")
+	nextCmd.Flags().StringSliceVar(&nextTags, "tag", nil, "Only issues with one of these tags (comma-separated or repeated)")
+	nextCmd.Flags().BoolVar(&nextJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(nextCmd)
+}
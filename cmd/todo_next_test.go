@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestNextJSON(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg := todoCfg
+	todoCfg = todoconfig.Default()
+	oldTypes, oldTags, oldCount := nextTypes, nextTags, nextCount
+	t.Cleanup(func() { todoCfg, nextTypes, nextTags, nextCount = oldCfg, oldTypes, oldTags, oldCount })
+
+	for _, b := range []*issue.Issue{
+		{ID: "nx-a", Title: "Unblock the release", Status: "ready", Type: "task", Priority: "low", Blocking: []string{"nx-b"}, Body: "## Plan\n\nShip it."},
+		{ID: "nx-b", Title: "Release", Status: "ready", Type: "task", Priority: "critical"},
+		{ID: "nx-c", Title: "Polish", Status: "ready", Type: "task"},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runJSONCommand(t, nextCmd, map[string]string{"json": "true", "count": "2"})
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	var items []map[string]any
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out)
+	}
+	if len(items) != 2 || items[0]["id"] != "nx-a" || items[1]["id"] != "nx-c" {
+		t.Fatalf("picked %v, want nx-a then nx-c", out)
+	}
+	first := items[0]
+	if first["reason"] != "critical priority (blocks nx-b), unblocks 1 issue" {
+		t.Errorf("reason = %v", first["reason"])
+	}
+	if first["effective_priority"] != "critical" || first["priority_from"] != "nx-b" || first["unblocks"] != float64(1) {
+		t.Errorf("rank fields = %v, %v, %v", first["effective_priority"], first["priority_from"], first["unblocks"])
+	}
+	if first["body"] != "## Plan\n\nShip it." || first["priority"] != "low" {
+		t.Errorf("issue fields missing from %v", first)
+	}
+
+	_, err = runJSONCommand(t, nextCmd, map[string]string{"json": "true", "type": "nope"})
+	if got := errorCode(err, ""); got != output.ErrValidation {
+		t.Errorf("unknown --type: code %q, want %s (error: %v)", got, output.ErrValidation, err)
+	}
+}
+
+func TestFirstBodySection(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantTitle string
+		want      string
+	}{
+		{"empty", "", "", ""},
+		{"no headings", "Just text.\n", "", "Just text."},
+		{"lead text", "Intro line.\n\n## Plan\n\nSteps", "", "Intro line."},
+		{"first heading", "## Plan\n\nStep one\n\n## Notes\n\nLater", "Plan", "Step one"},
+		{"truncated", "a\nb\nc\nd\ne\nf", "", "a\nb\nc\nd\n…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, content := firstBodySection(tt.body)
+			if title != tt.wantTitle || content != tt.want {
+				t.Errorf("firstBodySection() = %q, %q; want %q, %q", title, content, tt.wantTitle, tt.want)
+			}
+		})
+	}
+	if _, content := firstBodySection("Fixes #42 first.\n\n## Plan\n\nx"); !strings.Contains(content, "#42") {
+		t.Errorf("lead text with a # was cut short: %q", content)
+	}
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+var (
+	onChangeRun         string
+	onChangeEvents      []string
+	onChangeStatuses    []string
+	onChangePerEvent    bool
+	onChangeMaxParallel int
+)
+
+// eventStatusChanged is the --events value for updates that change an
+// issue's status.
+const eventStatusChanged = "status_changed"
+
+// onChangeEventTypes are the --events values, in the order they are listed.
+var onChangeEventTypes = []string{core.EventCreated.String(), core.EventUpdated.String(), core.EventDeleted.String(), eventStatusChanged}
+
+var onChangeCmd = &cobra.Command{
+	Use:   "on-change",
+	Short: "Run a command whenever issues change",
+	Long: `Watches the data directory and runs a shell command for each batch of
+issue changes, so a script can regenerate a page or notify a chat relay
+without any Go.
+
+The command receives the batch as a JSON array of events on stdin, each
+{"type", "id", "status", "path", "changed_fields", "issue"}, with "issue"
+omitted for deletions. "changed_fields" lists the front matter keys an
+update changed, then "body" and "path", plus "status:<old>→<new>" when the
+status changed. JIG_EVENT_TYPE, JIG_ISSUE_ID, JIG_ISSUE_STATUS,
+JIG_ISSUE_PATH, and JIG_CHANGED_FIELDS (comma-separated per event) hold the
+same fields, space-separated when the batch has more than one event, and
+JIG_EVENT_COUNT the number of events. With --per-event the command runs
+once per event instead, with a single JSON object on stdin.
+
+--events and --filter-status limit which events count; a deleted issue
+matches the status it had when it was last seen. status_changed picks the
+updates that change the status, so with --filter-status it matches issues
+moving into those statuses. A failing command is
+reported and watching goes on. At most --max-parallel commands run at once;
+later batches wait for a slot.`,
+	Example: `  jig todo on-change --run './scripts/notify.sh'
+  jig todo on-change --run 'make docs' --events created,deleted
+  jig todo on-change --run './scripts/announce.sh' --events status_changed --filter-status completed
+  jig todo on-change --run 'jq -r .id >> reviewed.txt' --filter-status review --per-event`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(onChangeRun) == "" {
+			return cmdError(false, output.ErrValidation, "--run is required")
+		}
+		for _, e := range onChangeEvents {
+			if !slices.Contains(onChangeEventTypes, e) {
+				return cmdError(false, output.ErrValidation, "invalid event %q (must be %s)", e, strings.Join(onChangeEventTypes, ", "))
+			}
+		}
+		for _, s := range onChangeStatuses {
+			if !todoStore.Config().IsValidStatus(s) {
+				return cmdError(false, output.ErrInvalidStatus, "invalid status %q", s)
+			}
+		}
+		if onChangeMaxParallel < 1 {
+			return cmdError(false, output.ErrValidation, "--max-parallel must be at least 1")
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		if err := todoStore.StartWatching(); err != nil {
+			return cmdError(false, output.ErrFileError, "starting file watcher: %w", err)
+		}
+		defer todoStore.Unwatch() //nolint:errcheck // best-effort cleanup
+		events, unsubscribe := todoStore.Subscribe()
+		defer unsubscribe()
+
+		fmt.Fprintf(os.Stderr, "Watching %s · ctrl-C to stop\n", todoStore.Root())
+		h := newChangeHook(onChangeRun, todoStore)
+		h.events, h.statuses = onChangeEvents, onChangeStatuses
+		h.perEvent, h.maxParallel = onChangePerEvent, onChangeMaxParallel
+		h.run(ctx, events)
+		return nil
+	},
+}
+
+// hookEvent is one event as on-change passes it to the command.
+type hookEvent struct {
+	Type          string       `json:"type"`
+	ID            string       `json:"id"`
+	Status        string       `json:"status,omitempty"`
+	Path          string       `json:"path,omitempty"`
+	ChangedFields []string     `json:"changed_fields,omitempty"`
+	Issue         *issue.Issue `json:"issue,omitempty"`
+}
+
+// changeHook runs a shell command for the issue events that match its
+// filters.
+type changeHook struct {
+	command     string
+	events      []string // event types to run for; all when empty
+	statuses    []string // statuses to run for; all when empty
+	perEvent    bool
+	maxParallel int
+	root        string
+	stdout      io.Writer
+	stderr      io.Writer
+
+	// seen holds the last status and path of each issue, which deletion
+	// events no longer carry.
+	seen map[string]hookEvent
+}
+
+func newChangeHook(command string, c *core.Core) *changeHook {
+	h := &changeHook{
+		command:     command,
+		maxParallel: 1,
+		root:        c.Root(),
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+		seen:        make(map[string]hookEvent),
+	}
+	for _, b := range c.AllUnordered() {
+		h.seen[b.ID] = h.describe(core.IssueEvent{Type: core.EventUpdated, Issue: b, IssueID: b.ID})
+	}
+	return h
+}
+
+// run invokes the command for each batch received from events until ctx is
+// cancelled or events is closed, then waits for running commands to finish.
+func (h *changeHook) run(ctx context.Context, events <-chan []core.IssueEvent) {
+	slots := make(chan struct{}, h.maxParallel)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		var batch []core.IssueEvent
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case batch, ok = <-events:
+			if !ok {
+				return
+			}
+		}
+
+		var matched []hookEvent
+		for _, e := range batch {
+			ev := h.describe(e)
+			if h.matches(ev) {
+				matched = append(matched, ev)
+			}
+		}
+		groups := [][]hookEvent{matched}
+		if h.perEvent {
+			groups = nil
+			for _, ev := range matched {
+				groups = append(groups, []hookEvent{ev})
+			}
+		}
+		for _, group := range groups {
+			if len(group) == 0 {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- struct{}{}:
+			}
+			wg.Go(func() {
+				defer func() { <-slots }()
+				h.invoke(group)
+			})
+		}
+	}
+}
+
+// describe turns e into a hookEvent, filling in a deleted issue's status and
+// path from when it was last seen.
+func (h *changeHook) describe(e core.IssueEvent) hookEvent {
+	ev := hookEvent{Type: e.Type.String(), ID: e.IssueID}
+	if e.Issue == nil {
+		last := h.seen[e.IssueID]
+		ev.Status, ev.Path = last.Status, last.Path
+		delete(h.seen, e.IssueID)
+		return ev
+	}
+	ev.Issue = e.Issue
+	ev.Status = e.Issue.Status
+	ev.ChangedFields = e.ChangedFields
+	ev.Path = filepath.Join(h.root, e.Issue.Path)
+	h.seen[ev.ID] = hookEvent{Status: ev.Status, Path: ev.Path}
+	return ev
+}
+
+func (h *changeHook) matches(ev hookEvent) bool {
+	statusChanged := ev.Type == core.EventUpdated.String() && slices.Contains(ev.ChangedFields, issue.FieldStatus)
+	return (len(h.events) == 0 || slices.Contains(h.events, ev.Type) ||
+		(statusChanged && slices.Contains(h.events, eventStatusChanged))) &&
+		(len(h.statuses) == 0 || slices.Contains(h.statuses, ev.Status))
+}
+
+// invoke runs the command once for events, reporting rather than returning
+// a failure.
+func (h *changeHook) invoke(events []hookEvent) {
+	var input []byte
+	var err error
+	if h.perEvent {
+		input, err = json.Marshal(events[0])
+	} else {
+		input, err = json.Marshal(events)
+	}
+	if err != nil {
+		fmt.Fprintf(h.stderr, "Warning: encoding events: %v\n", err)
+		return
+	}
+
+	field := func(get func(hookEvent) string) string {
+		values := make([]string, len(events))
+		for i, ev := range events {
+			values[i] = get(ev)
+		}
+		return strings.Join(values, " ")
+	}
+	cmd := shellCommand(h.command)
+	cmd.Env = append(os.Environ(),
+		"JIG_EVENT_TYPE="+field(func(ev hookEvent) string { return ev.Type }),
+		"JIG_ISSUE_ID="+field(func(ev hookEvent) string { return ev.ID }),
+		"JIG_ISSUE_STATUS="+field(func(ev hookEvent) string { return ev.Status }),
+		"JIG_ISSUE_PATH="+field(func(ev hookEvent) string { return ev.Path }),
+		"JIG_CHANGED_FIELDS="+field(func(ev hookEvent) string { return strings.Join(ev.ChangedFields, ",") }),
+		"JIG_EVENT_COUNT="+strconv.Itoa(len(events)),
+	)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout, cmd.Stderr = h.stdout, h.stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(h.stderr, "Warning: %s failed for %s: %v\n", h.command, field(func(ev hookEvent) string { return ev.ID }), err)
+	}
+}
+
+// shellCommand runs command through the platform shell.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+func init() {
+	onChangeCmd.Flags().StringVar(&onChangeRun, "run", "", "Shell command to run for each batch of changes")
+	onChangeCmd.Flags().StringSliceVar(&onChangeEvents, "events", nil, "Only run for these events (comma-separated: "+strings.Join(onChangeEventTypes, ",")+")")
+	onChangeCmd.Flags().StringSliceVar(&onChangeStatuses, "filter-status", nil, "Only run for issues with these statuses (comma-separated)")
+	onChangeCmd.Flags().BoolVar(&onChangePerEvent, "per-event", false, "Run once per event instead of once per batch")
+	onChangeCmd.Flags().IntVar(&onChangeMaxParallel, "max-parallel", 4, "Most commands to run at once")
+	todoCmd.AddCommand(onChangeCmd)
+}
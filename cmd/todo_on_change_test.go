@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// hookScript writes a script that appends its environment and stdin to a
+// log file, one invocation per line, and returns the script and log paths.
+func hookScript(t *testing.T, exit int) (script, log string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts need sh")
+	}
+	dir := t.TempDir()
+	log = filepath.Join(dir, "calls.log")
+	script = filepath.Join(dir, "hook.sh")
+	content := "#!/bin/sh\n" +
+		`printf '%s|%s|%s|%s|' "$JIG_EVENT_TYPE" "$JIG_ISSUE_ID" "$JIG_ISSUE_STATUS" "$JIG_EVENT_COUNT" >> "` + log + "\"\n" +
+		`tr -d '\n' >> "` + log + "\"\n" +
+		`echo >> "` + log + "\"\n" +
+		"exit " + strconv.Itoa(exit) + "\n"
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return script, log
+}
+
+func readCalls(t *testing.T, log string) []string {
+	t.Helper()
+	data, err := os.ReadFile(log)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// runHook feeds batches to h and waits for its commands to finish.
+func runHook(t *testing.T, h *changeHook, batches ...[]core.IssueEvent) {
+	t.Helper()
+	h.stdout, h.stderr = io.Discard, io.Discard
+	ch := make(chan []core.IssueEvent, len(batches))
+	for _, b := range batches {
+		ch <- b
+	}
+	close(ch)
+	h.run(context.Background(), ch)
+}
+
+func hookEvents(c *core.Core) []core.IssueEvent {
+	a, _ := c.Get("hok-1")
+	b, _ := c.Get("hok-2")
+	return []core.IssueEvent{
+		{Type: core.EventCreated, Issue: a, IssueID: a.ID},
+		{Type: core.EventUpdated, Issue: b, IssueID: b.ID},
+		{Type: core.EventDeleted, IssueID: "hok-3"},
+	}
+}
+
+func setupHookCore(t *testing.T) *core.Core {
+	t.Helper()
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	createQueryTestIssue(t, testCore, "hok-1", "Hook one", "ready")
+	createQueryTestIssue(t, testCore, "hok-2", "Hook two", "review")
+	createQueryTestIssue(t, testCore, "hok-3", "Hook three", "review")
+	return testCore
+}
+
+func TestChangeHookBatched(t *testing.T) {
+	c := setupHookCore(t)
+	script, log := hookScript(t, 0)
+	h := newChangeHook(script, c)
+	runHook(t, h, hookEvents(c))
+
+	calls := readCalls(t, log)
+	if len(calls) != 1 {
+		t.Fatalf("got %d invocations, want one per batch: %q", len(calls), calls)
+	}
+	env, input, _ := strings.Cut(calls[0], "|3|")
+	if env != "created updated deleted|hok-1 hok-2 hok-3|ready review review" {
+		t.Errorf("env = %q", env)
+	}
+	var events []hookEvent
+	if err := json.Unmarshal([]byte(input), &events); err != nil {
+		t.Fatalf("stdin is not a JSON array: %v\n%s", err, input)
+	}
+	if len(events) != 3 || events[0].Issue == nil || events[0].Issue.Title != "Hook one" || events[2].Issue != nil {
+		t.Errorf("events = %+v", events)
+	}
+	if want := filepath.Join(c.Root(), issue.BuildPath("hok-3", issue.Slugify("Hook three"))); events[2].Path != want {
+		t.Errorf("deleted path = %q, want the last seen %q", events[2].Path, want)
+	}
+}
+
+func TestChangeHookPerEvent(t *testing.T) {
+	c := setupHookCore(t)
+	script, log := hookScript(t, 0)
+	h := newChangeHook(script, c)
+	h.perEvent = true
+	runHook(t, h, hookEvents(c), hookEvents(c)[:1])
+
+	calls := readCalls(t, log)
+	if len(calls) != 4 {
+		t.Fatalf("got %d invocations, want one per event: %q", len(calls), calls)
+	}
+	if !strings.HasPrefix(calls[0], "created|hok-1|ready|1|{") {
+		t.Errorf("first call = %q, want a single event object", calls[0])
+	}
+}
+
+func TestChangeHookFilters(t *testing.T) {
+	c := setupHookCore(t)
+	script, log := hookScript(t, 0)
+	h := newChangeHook(script, c)
+	h.statuses = []string{"review"}
+	h.events = []string{"deleted"}
+	runHook(t, h, hookEvents(c), hookEvents(c)[:1])
+
+	calls := readCalls(t, log)
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "deleted|hok-3|review|1|") {
+		t.Errorf("calls = %q, want only the review issue's deletion", calls)
+	}
+}
+
+func TestChangeHookStatusChanged(t *testing.T) {
+	c := setupHookCore(t)
+	script, log := hookScript(t, 0)
+	h := newChangeHook(script, c)
+	h.events = []string{eventStatusChanged}
+	b, _ := c.Get("hok-2")
+	runHook(t, h, []core.IssueEvent{
+		{Type: core.EventUpdated, Issue: b, IssueID: b.ID, ChangedFields: []string{"body"}},
+		{Type: core.EventUpdated, Issue: b, IssueID: b.ID, ChangedFields: []string{"status", "status:ready→review", "tags"}},
+		{Type: core.EventCreated, Issue: b, IssueID: b.ID},
+	})
+
+	calls := readCalls(t, log)
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "updated|hok-2|review|1|") {
+		t.Fatalf("calls = %q, want only the status change", calls)
+	}
+	_, input, _ := strings.Cut(calls[0], "|1|")
+	var events []hookEvent
+	if err := json.Unmarshal([]byte(input), &events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || strings.Join(events[0].ChangedFields, ",") != "status,status:ready→review,tags" {
+		t.Errorf("events = %+v, want the changed fields passed on", events)
+	}
+}
+
+func TestChangeHookFailureKeepsWatching(t *testing.T) {
+	c := setupHookCore(t)
+	script, log := hookScript(t, 1)
+	h := newChangeHook(script, c)
+	runHook(t, h, hookEvents(c)[:1], hookEvents(c)[1:2], hookEvents(c)[2:])
+
+	if calls := readCalls(t, log); len(calls) != 3 {
+		t.Errorf("got %d invocations after failures, want 3: %q", len(calls), calls)
+	}
+}
+
+func TestChangeHookWatching(t *testing.T) {
+	c := setupHookCore(t)
+	script, log := hookScript(t, 0)
+	if err := c.StartWatching(); err != nil {
+		t.Skipf("file watching unavailable: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Unwatch() })
+	events, unsubscribe := c.Subscribe()
+	t.Cleanup(unsubscribe)
+
+	h := newChangeHook(script, c)
+	h.stdout, h.stderr = io.Discard, io.Discard
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() { h.run(ctx, events); close(done) }()
+
+	writeWatchedIssue(t, c, "hok-4", "Hook four")
+	deadline := time.Now().Add(5 * time.Second)
+	for len(readCalls(t, log)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	calls := readCalls(t, log)
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "created|hok-4|ready|1|[") {
+		t.Errorf("calls = %q, want one created batch", calls)
+	}
+}
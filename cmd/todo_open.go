@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/github"
+	"github.com/toba/jig/internal/todo/launch"
+)
+
+var (
+	openReveal  bool
+	openGitHub  bool
+	openClickUp bool
+	openPrint   bool
+	openSync    string
+)
+
+// opener opens URLs and folders; tests replace it to record the commands.
+var opener = launch.New()
+
+var openCmd = &cobra.Command{
+	Use:   "open <id>",
+	Short: "Open an issue's file, folder, or linked URL",
+	Long: `Opens an issue's markdown file in your editor: the editor setting in
+.jig.yaml, then $VISUAL, then $EDITOR.
+
+--reveal shows the file in the system file manager instead, --github or
+--clickup opens the linked GitHub issue or ClickUp task in the browser (an
+error when the issue is not linked), --sync <name> opens the URL of any sync
+entry, built from sync_url_templates when no provider integration knows it,
+and --print prints the file's absolute path for use in other commands.
+
+The argument may be an issue ID, a slug, or a unique title substring.`,
+	Example: `  jig todo open abc-123
+  jig todo open abc-123 --github
+  jig todo open abc-123 --sync jira
+  cat "$(jig todo open abc-123 --print)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := resolveIssueArg(args[0])
+		if err != nil {
+			return err
+		}
+		path, err := filepath.Abs(filepath.Join(todoStore.Root(), b.Path))
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case openPrint:
+			fmt.Println(path)
+			return nil
+		case openReveal:
+			return opener.Reveal(path)
+		case openGitHub, openClickUp:
+			provider := github.SyncName
+			if openClickUp {
+				provider = clickup.SyncName
+			}
+			url, err := integration.ExternalURL(b, provider, todoCfg.Sync)
+			if err != nil {
+				return err
+			}
+			return opener.URL(url)
+		case openSync != "":
+			url, err := integration.SyncEntryURL(b, openSync, todoCfg)
+			if err != nil {
+				return err
+			}
+			return opener.URL(url)
+		}
+
+		edit := launch.EditorCommand(todoCfg, path)
+		edit.Stdin, edit.Stdout, edit.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return edit.Run()
+	},
+}
+
+func init() {
+	openCmd.Flags().BoolVar(&openReveal, "reveal", false, "Show the file in the system file manager")
+	openCmd.Flags().BoolVar(&openGitHub, "github", false, "Open the linked GitHub issue in the browser")
+	openCmd.Flags().BoolVar(&openClickUp, "clickup", false, "Open the linked ClickUp task in the browser")
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the file's absolute path instead of opening it")
+	openCmd.Flags().StringVar(&openSync, "sync", "", "Open the URL of the named sync entry in the browser")
+	openCmd.MarkFlagsMutuallyExclusive("reveal", "github", "clickup", "sync", "print")
+	todoCmd.AddCommand(openCmd)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/launch"
+)
+
+func TestOpenCmdLinks(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	createQueryTestIssue(t, testCore, "opn-1", "Linked", "ready")
+	b, _ := testCore.Get("opn-1")
+	b.SetSync("clickup", map[string]any{"task_id": "86abc"})
+
+	oldCfg, oldOpener := todoCfg, opener
+	t.Cleanup(func() {
+		todoCfg, opener = oldCfg, oldOpener
+		openReveal, openGitHub, openClickUp, openPrint, openSync = false, false, false, false, ""
+	})
+	todoCfg = todoconfig.Default()
+	var ran []string
+	opener = &launch.Opener{GOOS: "darwin", Run: func(name string, args ...string) error {
+		ran = append(ran, name+" "+strings.Join(args, " "))
+		return nil
+	}}
+
+	openClickUp = true
+	if err := openCmd.RunE(openCmd, []string{"opn-1"}); err != nil {
+		t.Fatalf("open --clickup: %v", err)
+	}
+	openClickUp = false
+
+	openReveal = true
+	if err := openCmd.RunE(openCmd, []string{"opn-1"}); err != nil {
+		t.Fatalf("open --reveal: %v", err)
+	}
+	openReveal = false
+
+	if len(ran) != 2 || ran[0] != "open https://app.clickup.com/t/86abc" || !strings.HasPrefix(ran[1], "open -R ") || !strings.HasSuffix(ran[1], b.Path) {
+		t.Errorf("ran = %q, want the ClickUp URL then the revealed file", ran)
+	}
+
+	openGitHub = true
+	if err := openCmd.RunE(openCmd, []string{"opn-1"}); !errors.Is(err, integration.ErrNotLinked) {
+		t.Errorf("open --github on an unlinked issue: error = %v, want ErrNotLinked", err)
+	}
+	openGitHub = false
+
+	b.SetSync("jira", map[string]any{"issue_key": "ENG-12"})
+	todoCfg.SyncURLTemplates = map[string]string{"jira": "https://acme.atlassian.net/browse/{issue_key}"}
+	openSync = "jira"
+	if err := openCmd.RunE(openCmd, []string{"opn-1"}); err != nil {
+		t.Fatalf("open --sync jira: %v", err)
+	}
+	if last := ran[len(ran)-1]; last != "open https://acme.atlassian.net/browse/ENG-12" {
+		t.Errorf("open --sync jira ran %q, want the templated URL", last)
+	}
+	openSync = "linear"
+	if err := openCmd.RunE(openCmd, []string{"opn-1"}); err == nil || !strings.Contains(err.Error(), `no "linear" sync entry`) {
+		t.Errorf("open --sync linear: error = %v, want no sync entry", err)
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// outputMode is how a todo subcommand reports its result. A command
+// resolves it once with resolveOutputMode and hands its result to write,
+// instead of checking --json, --porcelain, and --quiet itself.
+type outputMode int
+
+const (
+	outputText outputMode = iota
+	outputJSON
+	outputPorcelain
+	outputQuiet
+)
+
+// resolveOutputMode returns the mode asked for, given the command's own
+// --json flag. validateOutputMode has already rejected combinations, so at
+// most one of the flags is set.
+func resolveOutputMode(jsonFlag bool) outputMode {
+	switch {
+	case jsonFlag:
+		return outputJSON
+	case todoPorcelain:
+		return outputPorcelain
+	case todoQuiet:
+		return outputQuiet
+	default:
+		return outputText
+	}
+}
+
+// commandResult is a command's result in every mode it supports. Quiet is
+// the IDs of the issues the command changed, and QuietErrors the failures
+// --quiet reports on stderr, since its one line has no room for them.
+// Porcelain may be nil on commands without a porcelain format, which
+// validatePorcelain keeps from being asked for one.
+type commandResult struct {
+	JSON        func() error
+	Porcelain   func() error
+	Quiet       []string
+	QuietErrors []string
+	Text        func() error
+}
+
+// write prints r in mode m.
+func (m outputMode) write(r commandResult) error {
+	switch {
+	case m == outputJSON:
+		return r.JSON()
+	case m == outputPorcelain && r.Porcelain != nil:
+		return r.Porcelain()
+	case m == outputQuiet:
+		for _, e := range r.QuietErrors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		return printQuiet(r.Quiet...)
+	default:
+		return r.Text()
+	}
+}
+
+// writeIndentedJSON writes v to stdout as indented JSON.
+func writeIndentedJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/plan"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	planIteration   string
+	planCapacity    string
+	planMustInclude []string
+	planApply       bool
+	planJSON        bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Propose which ready issues fit in an iteration",
+	Long: `Proposes which issues to pull into an iteration given its capacity.
+
+Issues already assigned to the iteration (except scrapped ones) count
+against the capacity first. Then every --must-include issue is added, even
+past capacity, with a warning. Then the unassigned issues "jig todo next"
+would pick are taken in its rank order, each one that still fits being
+proposed and the rest skipped, so one large issue does not keep smaller ones
+out.
+
+An issue counts for its estimate (set with --estimate on create and
+update); issues without one count for default_estimate in the config
+(default: 1d). Estimates and --capacity are durations such as 4h, 2d, or 1w,
+where a day is 24h of effort.
+
+Nothing changes unless --apply is given, which assigns the proposed issues
+to the iteration in one batch, as bulk-update --set-iteration would.
+--quiet prints the IDs of the proposed issues, or with --apply of those
+assigned.`,
+	Example: `  jig todo plan --iteration 2025-W34 --capacity 10d
+  jig todo plan --iteration current --capacity 5d --must-include abc-123 --json
+  jig todo plan --iteration 2025-W34 --capacity 10d --apply`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		iteration, err := todoCfg.ResolveIteration(planIteration, todoStore.Now())
+		if err != nil {
+			return cmdError(planJSON, output.ErrValidation, "%w", err)
+		}
+		if iteration == "" {
+			return cmdError(planJSON, output.ErrValidation, "--iteration is required")
+		}
+		capacity, err := todoconfig.ParseDuration(planCapacity)
+		if err != nil || capacity < 0 {
+			return cmdError(planJSON, output.ErrValidation, "invalid --capacity %q (must be a duration such as 10d or 40h)", planCapacity)
+		}
+
+		var must []plan.Item
+		for _, id := range planMustInclude {
+			b, err := todoStore.Get(id)
+			if err != nil {
+				return cmdError(planJSON, output.ErrNotFound, "%w", err)
+			}
+			must = append(must, planItem(b))
+		}
+		p := plan.Pack(capacity, committedItems(iteration), must, rankedItems())
+
+		var results []bulkResult
+		if planApply {
+			var targets []*issue.Issue
+			for _, b := range p.ProposedIssues() {
+				if b.Iteration != iteration {
+					targets = append(targets, b)
+				}
+			}
+			results = applyBulkUpdate(targets, captureETags(targets), model.UpdateIssueInput{Iteration: &iteration})
+		}
+
+		quiet, failures := issueIDs(p.ProposedIssues()), []string(nil)
+		if planApply {
+			quiet, failures = quietResults(results)
+		}
+		if err := resolveOutputMode(planJSON).write(commandResult{
+			JSON:        func() error { return writeIndentedJSON(planJSONReport(iteration, p, results)) },
+			Quiet:       quiet,
+			QuietErrors: failures,
+			Text: func() error {
+				writePlan(cmd.OutOrStdout(), iteration, p, results)
+				return nil
+			},
+		}); err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, r := range results {
+			if !r.OK {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d update(s) failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// planItem is b with the effort it counts for: its estimate, or the
+// configured default when it has none (or an unparseable one).
+func planItem(b *issue.Issue) plan.Item {
+	if b.Estimate != "" {
+		if d, err := todoconfig.ParseEstimate(b.Estimate); err == nil {
+			return plan.Item{Issue: b, Estimate: d}
+		}
+	}
+	return plan.Item{Issue: b, Estimate: todoCfg.GetDefaultEstimate(), Defaulted: true}
+}
+
+// committedItems returns the issues assigned to iteration, except scrapped
+// ones, by ID.
+func committedItems(iteration string) []plan.Item {
+	var items []plan.Item
+	for _, b := range todoStore.AllUnordered() {
+		if b.Iteration == iteration && b.Status != todoconfig.StatusScrapped {
+			items = append(items, planItem(b))
+		}
+	}
+	slices.SortFunc(items, func(a, b plan.Item) int { return cmp.Compare(a.Issue.ID, b.Issue.ID) })
+	return items
+}
+
+// rankedItems returns the unassigned issues Next picks, in its order.
+func rankedItems() []plan.Item {
+	var items []plan.Item
+	for _, n := range todoStore.Next(core.NextOptions{}) {
+		if n.Issue.Iteration == "" {
+			items = append(items, planItem(n.Issue))
+		}
+	}
+	return items
+}
+
+// planJSONIssue is one issue in plan --json.
+type planJSONIssue struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Estimate  string `json:"estimate"`
+	Defaulted bool   `json:"estimate_defaulted,omitempty"`
+}
+
+func planJSONIssues(items []plan.Item) []planJSONIssue {
+	out := make([]planJSONIssue, len(items))
+	for i, it := range items {
+		out[i] = planJSONIssue{ID: it.Issue.ID, Title: it.Issue.Title, Estimate: plan.FormatEffort(it.Estimate), Defaulted: it.Defaulted}
+	}
+	return out
+}
+
+// planReport is plan as plan --json prints it. Results is set with --apply.
+type planReport struct {
+	Iteration string          `json:"iteration"`
+	Capacity  string          `json:"capacity"`
+	Load      string          `json:"load"`
+	Remaining string          `json:"remaining"`
+	Committed []planJSONIssue `json:"committed"`
+	Proposed  []planJSONIssue `json:"proposed"`
+	Skipped   []planJSONIssue `json:"skipped"`
+	Warnings  []string        `json:"warnings"`
+	Results   []bulkResult    `json:"results,omitempty"`
+}
+
+func planJSONReport(iteration string, p plan.Plan, results []bulkResult) planReport {
+	return planReport{
+		Iteration: iteration,
+		Capacity:  plan.FormatEffort(p.Capacity),
+		Load:      plan.FormatEffort(p.Load),
+		Remaining: plan.FormatEffort(p.Remaining()),
+		Committed: planJSONIssues(p.Committed),
+		Proposed:  planJSONIssues(p.Proposed),
+		Skipped:   planJSONIssues(p.Skipped),
+		Warnings:  append([]string{}, p.Warnings...),
+		Results:   results,
+	}
+}
+
+// writePlan prints the committed, proposed, and skipped issues as a table,
+// then the load against capacity and any warnings.
+func writePlan(w io.Writer, iteration string, p plan.Plan, results []bulkResult) {
+	width := len("Estimate")
+	fmt.Fprintln(w, ui.Bold.Render(fmt.Sprintf("%-9s  %-*s  %s", "", width, "Estimate", "Issue")))
+	section := func(label string, items []plan.Item) {
+		for _, it := range items {
+			estimate := plan.FormatEffort(it.Estimate)
+			if it.Defaulted {
+				estimate += "*"
+			}
+			fmt.Fprintf(w, "%-9s  %-*s  %s %s\n", label, width, estimate, ui.ID.Render(it.Issue.ID), it.Issue.Title)
+		}
+	}
+	section("committed", p.Committed)
+	section("proposed", p.Proposed)
+	section("skipped", p.Skipped)
+	if len(p.Committed)+len(p.Proposed)+len(p.Skipped) == 0 {
+		fmt.Fprintln(w, ui.Muted.Render("No issues to plan: none are assigned to "+iteration+" or ready"))
+	}
+	if slices.ContainsFunc(slices.Concat(p.Committed, p.Proposed, p.Skipped), func(it plan.Item) bool { return it.Defaulted }) {
+		fmt.Fprintln(w, ui.Muted.Render(fmt.Sprintf("* no estimate; counted as default_estimate (%s)", plan.FormatEffort(todoCfg.GetDefaultEstimate()))))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s: %s of %s committed (%s left)\n", ui.Bold.Render(iteration), plan.FormatEffort(p.Load), plan.FormatEffort(p.Capacity), plan.FormatEffort(p.Remaining()))
+	for _, warning := range p.Warnings {
+		fmt.Fprintln(w, ui.Warning.Render("warning: ")+warning)
+	}
+
+	switch {
+	case results == nil && len(p.Proposed) > 0:
+		fmt.Fprintln(w, ui.Muted.Render("Run with --apply to assign the proposed issues to "+iteration))
+	case results != nil:
+		for _, r := range results {
+			if r.OK {
+				fmt.Fprintln(w, ui.Success.Render("Assigned ")+ui.ID.Render(r.ID))
+			} else {
+				fmt.Fprintln(w, ui.Danger.Render("Failed ")+ui.ID.Render(r.ID)+" "+ui.Muted.Render(r.Error))
+			}
+		}
+	}
+}
+
+func init() {
+	planCmd.Flags().StringVar(&planIteration, "iteration", "", "Iteration to plan (ISO week such as 2025-W34, a configured name, or 'current')")
+	planCmd.Flags().StringVar(&planCapacity, "capacity", "", "Effort the iteration can hold (a duration such as 10d or 40h)")
+	planCmd.Flags().StringSliceVar(&planMustInclude, "must-include", nil, "Issue IDs to include even past capacity (comma-separated or repeated)")
+	planCmd.Flags().BoolVar(&planApply, "apply", false, "Assign the proposed issues to the iteration")
+	planCmd.Flags().BoolVar(&planJSON, "json", false, "Output as JSON")
+	addQuietFlag(planCmd)
+	_ = planCmd.MarkFlagRequired("iteration")
+	_ = planCmd.MarkFlagRequired("capacity")
+	todoCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestPlanCmd(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+
+	oldCfg := todoCfg
+	t.Cleanup(func() {
+		todoCfg = oldCfg
+		planIteration, planCapacity, planMustInclude, planApply, planJSON = "", "", nil, false, false
+	})
+	todoCfg = todoconfig.Default()
+
+	for _, b := range []*issue.Issue{
+		{ID: "pln-001", Slug: "a", Title: "Committed", Status: "in-progress", Iteration: "2025-W34", Estimate: "2d"},
+		{ID: "pln-002", Slug: "b", Title: "Urgent", Status: "ready", Priority: "critical", Estimate: "3d"},
+		{ID: "pln-003", Slug: "c", Title: "Too big", Status: "ready", Priority: "high", Estimate: "1w"},
+		{ID: "pln-004", Slug: "d", Title: "Unestimated", Status: "ready"},
+		{ID: "pln-005", Slug: "e", Title: "Draft", Status: "draft", Estimate: "4h"},
+		{ID: "pln-006", Slug: "f", Title: "Last", Status: "ready", Priority: "low", Estimate: "1d"},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatalf("seeding %s: %v", b.ID, err)
+		}
+	}
+
+	run := func() planReport {
+		t.Helper()
+		planJSON = true
+		out := capturePorcelain(t, func() error { return planCmd.RunE(planCmd, nil) })
+		var report planReport
+		if err := json.Unmarshal([]byte(out), &report); err != nil {
+			t.Fatalf("decoding %q: %v", out, err)
+		}
+		return report
+	}
+	idsOf := func(issues []planJSONIssue) []string {
+		var ids []string
+		for _, b := range issues {
+			ids = append(ids, b.ID)
+		}
+		return ids
+	}
+
+	planIteration, planCapacity = "2025-W34", "6d"
+	report := run()
+	if got := idsOf(report.Committed); len(got) != 1 || got[0] != "pln-001" {
+		t.Errorf("committed = %v, want [pln-001]", got)
+	}
+	if got, want := idsOf(report.Proposed), []string{"pln-002", "pln-004"}; !slices.Equal(got, want) {
+		t.Errorf("proposed = %v, want %v", got, want)
+	}
+	if got, want := idsOf(report.Skipped), []string{"pln-003", "pln-006"}; !slices.Equal(got, want) {
+		t.Errorf("skipped = %v, want %v", got, want)
+	}
+	if report.Load != "6d" || report.Remaining != "0h" || len(report.Warnings) != 0 {
+		t.Errorf("load %s, remaining %s, warnings %q; want 6d, 0h, none", report.Load, report.Remaining, report.Warnings)
+	}
+	if !report.Proposed[1].Defaulted || report.Proposed[1].Estimate != "1d" {
+		t.Errorf("unestimated issue = %+v, want the 1d default", report.Proposed[1])
+	}
+	if b, _ := testCore.Get("pln-002"); b.Iteration != "" {
+		t.Errorf("dry run assigned pln-002 to %q", b.Iteration)
+	}
+
+	planMustInclude, planApply = []string{"pln-003"}, true
+	report = run()
+	if got, want := idsOf(report.Proposed), []string{"pln-003"}; !slices.Equal(got, want) {
+		t.Errorf("proposed with --must-include = %v, want %v", got, want)
+	}
+	if len(report.Warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the over-capacity must-include", report.Warnings)
+	}
+	if len(report.Results) != 1 || !report.Results[0].OK {
+		t.Errorf("results = %+v, want one successful update", report.Results)
+	}
+	for id, want := range map[string]string{"pln-003": "2025-W34", "pln-002": "", "pln-004": ""} {
+		if b, _ := testCore.Get(id); b.Iteration != want {
+			t.Errorf("%s iteration = %q, want %q", id, b.Iteration, want)
+		}
+	}
+}
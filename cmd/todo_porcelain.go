@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// todoPorcelain switches supported todo subcommands to stable, tab-separated
+// output for scripts.
+var todoPorcelain bool
+
+// porcelainAnnotation marks a command as supporting --porcelain; its value is
+// the record layout shown in help.
+const porcelainAnnotation = "porcelain"
+
+// porcelainHelp documents the --porcelain record formats. These layouts are a
+// compatibility promise: change them only in a major release.
+const porcelainHelp = `Porcelain output (--porcelain):
+  Supported commands print one tab-separated record per line. The layouts
+  below do not change between releases without a major version bump.
+  Tabs, newlines, and backslashes inside values are escaped as \t, \n, \\.
+
+    create   id	etag	path
+    update   id	etag
+    delete   id	deleted
+    list     one record per issue with the --columns fields
+             (default: id,status,type,priority,title)
+
+  --porcelain cannot be combined with --json. Errors go to stderr.`
+
+// porcelainEscaper keeps each value on one line and inside one field.
+var porcelainEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// writePorcelain writes one porcelain record.
+func writePorcelain(w io.Writer, fields ...string) error {
+	escaped := make([]string, len(fields))
+	for i, f := range fields {
+		escaped[i] = porcelainEscaper.Replace(f)
+	}
+	_, err := fmt.Fprintln(w, strings.Join(escaped, "\t"))
+	return err
+}
+
+// validatePorcelain rejects --porcelain on commands without a stable format
+// and in combination with --json.
+func validatePorcelain(cmd *cobra.Command) error {
+	if !todoPorcelain {
+		return nil
+	}
+	if _, ok := cmd.Annotations[porcelainAnnotation]; !ok {
+		return fmt.Errorf("--porcelain is not supported by %q", cmd.CommandPath())
+	}
+	if f := cmd.Flags().Lookup("json"); jsonOut || (f != nil && f.Changed) {
+		return errors.New("--porcelain and --json are mutually exclusive")
+	}
+	return nil
+}
+
+// porcelainColumns are the fields list --porcelain can print, by name.
+var porcelainColumns = map[string]func(*issue.Issue) string{
+	"id":        func(b *issue.Issue) string { return b.ID },
+	"title":     func(b *issue.Issue) string { return b.Title },
+	"summary":   func(b *issue.Issue) string { return b.Summary },
+	"status":    func(b *issue.Issue) string { return b.Status },
+	"type":      func(b *issue.Issue) string { return b.Type },
+	"priority":  func(b *issue.Issue) string { return b.Priority },
+	"parent":    func(b *issue.Issue) string { return b.Parent },
+	"milestone": func(b *issue.Issue) string { return b.Milestone },
+	"iteration": func(b *issue.Issue) string { return b.Iteration },
+	"estimate":  func(b *issue.Issue) string { return b.Estimate },
+	"tags":      func(b *issue.Issue) string { return strings.Join(b.Tags, ",") },
+	"due": func(b *issue.Issue) string {
+		if b.Due == nil {
+			return ""
+		}
+		return b.Due.String()
+	},
+	"etag": (*issue.Issue).ETag,
+	"path": func(b *issue.Issue) string { return b.Path },
+}
+
+// defaultPorcelainColumns is the list --porcelain layout when --columns is unset.
+var defaultPorcelainColumns = []string{"id", "status", "type", "priority", "title"}
+
+// writeListPorcelain writes one record per issue with the named columns.
+func writeListPorcelain(w io.Writer, issues []*issue.Issue, columns []string) error {
+	getters := make([]func(*issue.Issue) string, len(columns))
+	for i, name := range columns {
+		get, ok := porcelainColumns[name]
+		if !ok {
+			return fmt.Errorf("unknown column %q (must be id, title, summary, status, type, priority, parent, milestone, iteration, estimate, tags, due, etag, or path)", name)
+		}
+		getters[i] = get
+	}
+	for _, b := range issues {
+		fields := make([]string, len(getters))
+		for i, get := range getters {
+			fields[i] = get(b)
+		}
+		if err := writePorcelain(w, fields...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// seedPorcelainIssues sets up a store with a fixed clock so rendered etags
+// match the fixtures in testdata/porcelain, and turns on --porcelain.
+func seedPorcelainIssues(t *testing.T) *core.Core {
+	t.Helper()
+	testCore := seedTestIssues(t)
+	testCore.SetClock(func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) })
+	addTestIssues(t, testCore,
+		&issue.Issue{ID: "prc-001", Slug: "tabs", Title: "Title\twith tab", Status: "ready", Type: "bug", Priority: "high", Tags: []string{"a", "b"}},
+		&issue.Issue{ID: "prc-002", Slug: "plain", Title: "Plain", Status: "in-progress", Type: "task", Parent: "prc-001"},
+	)
+
+	oldPorcelain := todoPorcelain
+	todoPorcelain = true
+	t.Cleanup(func() { todoPorcelain = oldPorcelain })
+	return testCore
+}
+
+// capturePorcelain runs fn with stdout redirected and returns what it wrote.
+func capturePorcelain(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	runErr := fn()
+	w.Close()
+	os.Stdout = orig
+	if runErr != nil {
+		t.Fatalf("command error: %v", runErr)
+	}
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String()
+}
+
+// assertFixture compares output byte for byte with testdata/porcelain/name.
+func assertFixture(t *testing.T, name, got string) {
+	t.Helper()
+	want, err := os.ReadFile(filepath.Join("testdata", "porcelain", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s output drifted from fixture:\ngot:  %q\nwant: %q", name, got, want)
+	}
+}
+
+func TestPorcelainList(t *testing.T) {
+	seedPorcelainIssues(t)
+	oldColumns, oldSort := listColumns, listSort
+	t.Cleanup(func() { listColumns, listSort = oldColumns, oldSort })
+	listSort = "id"
+
+	listColumns = nil
+	assertFixture(t, "list.txt", capturePorcelain(t, func() error { return listCmd.RunE(listCmd, nil) }))
+
+	listColumns = []string{"id", "parent", "tags", "etag", "path"}
+	assertFixture(t, "list-columns.txt", capturePorcelain(t, func() error { return listCmd.RunE(listCmd, nil) }))
+
+	listColumns = []string{"id", "bogus"}
+	if err := listCmd.RunE(listCmd, nil); err == nil || !strings.Contains(err.Error(), `unknown column "bogus"`) {
+		t.Errorf("unknown column error = %v", err)
+	}
+}
+
+func TestPorcelainUpdate(t *testing.T) {
+	seedPorcelainIssues(t)
+
+	c := &cobra.Command{Use: "update", RunE: todoUpdateCmd.RunE}
+	registerUpdateFlags(c)
+	if err := c.ParseFlags([]string{"--status", "completed"}); err != nil {
+		t.Fatal(err)
+	}
+	assertFixture(t, "update.txt", capturePorcelain(t, func() error { return c.RunE(c, []string{"prc-002"}) }))
+}
+
+func TestPorcelainDelete(t *testing.T) {
+	seedPorcelainIssues(t)
+
+	// --porcelain never prompts, so scripts don't hang on confirmation.
+	got := capturePorcelain(t, func() error { return deleteCmd.RunE(deleteCmd, []string{"prc-001", "prc-002"}) })
+	assertFixture(t, "delete.txt", got)
+}
+
+func TestPorcelainCreate(t *testing.T) {
+	testCore := seedPorcelainIssues(t)
+
+	got := capturePorcelain(t, func() error { return createCmd.RunE(createCmd, []string{"Write", "docs"}) })
+
+	// The ID is random, so check the layout against the stored issue.
+	id, _, _ := strings.Cut(got, "\t")
+	b, err := testCore.Get(id)
+	if err != nil {
+		t.Fatalf("created issue %q not found: %v", id, err)
+	}
+	if want := b.ID + "\t" + b.ETag() + "\t" + b.Path + "\n"; got != want {
+		t.Errorf("create output = %q, want %q", got, want)
+	}
+}
+
+func TestValidatePorcelain(t *testing.T) {
+	oldPorcelain := todoPorcelain
+	t.Cleanup(func() { todoPorcelain = oldPorcelain })
+	todoPorcelain = true
+
+	supported := &cobra.Command{Use: "list", Annotations: map[string]string{porcelainAnnotation: "columns"}}
+	supported.Flags().Bool("json", false, "")
+	if err := validatePorcelain(supported); err != nil {
+		t.Errorf("validatePorcelain() = %v, want nil", err)
+	}
+	if err := supported.Flags().Set("json", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := validatePorcelain(supported); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("--porcelain with --json error = %v", err)
+	}
+
+	if err := validatePorcelain(&cobra.Command{Use: "roadmap"}); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("unsupported command error = %v", err)
+	}
+}
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql/errcode"
 	todoconfig "github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
@@ -49,11 +55,34 @@ func createQueryTestIssue(t *testing.T, c *core.Core, id, title, status string)
 	}
 }
 
+// seedTestIssues points todoStore at a fresh core, and todoCfg at the
+// default config, until t ends, and creates issues in it.
+func seedTestIssues(t *testing.T, issues ...*issue.Issue) *core.Core {
+	t.Helper()
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg := todoCfg
+	todoCfg = todoconfig.Default()
+	t.Cleanup(func() { todoCfg = oldCfg })
+	addTestIssues(t, testCore, issues...)
+	return testCore
+}
+
+// addTestIssues creates issues in c, failing t on the first error.
+func addTestIssues(t *testing.T, c *core.Core, issues ...*issue.Issue) {
+	t.Helper()
+	for _, b := range issues {
+		if err := c.Create(b); err != nil {
+			t.Fatalf("seeding %s: %v", b.ID, err)
+		}
+	}
+}
+
 func TestExecuteQuery(t *testing.T) {
 	testCore, cleanup := setupQueryTestCore(t)
 	defer cleanup()
 
-	createQueryTestIssue(t, testCore, "test-1", "First Issue", "todo")
+	createQueryTestIssue(t, testCore, "test-1", "First Issue", "ready")
 	createQueryTestIssue(t, testCore, "test-2", "Second Issue", "in-progress")
 	createQueryTestIssue(t, testCore, "test-3", "Third Issue", "completed")
 
@@ -105,7 +134,7 @@ func TestExecuteQuery(t *testing.T) {
 	})
 
 	t.Run("query with filter", func(t *testing.T) {
-		query := `{ issues(filter: { status: ["todo"] }) { id } }`
+		query := `{ issues(filter: { status: ["ready"] }) { id } }`
 		result, err := executeQuery(query, nil, "")
 		if err != nil {
 			t.Fatalf("executeQuery() error = %v", err)
@@ -122,7 +151,7 @@ func TestExecuteQuery(t *testing.T) {
 		}
 
 		if len(data.Issues) != 1 {
-			t.Errorf("expected 1 issue with status 'todo', got %d", len(data.Issues))
+			t.Errorf("expected 1 issue with status 'ready', got %d", len(data.Issues))
 		}
 	})
 
@@ -194,7 +223,7 @@ func TestExecuteQueryWithRelationships(t *testing.T) {
 		ID:     "parent-1",
 		Slug:   "parent-issue",
 		Title:  "Parent Issue",
-		Status: "todo",
+		Status: "ready",
 	}
 	testCore.Create(parent)
 
@@ -202,7 +231,7 @@ func TestExecuteQueryWithRelationships(t *testing.T) {
 		ID:     "child-1",
 		Slug:   "child-issue",
 		Title:  "Child Issue",
-		Status: "todo",
+		Status: "ready",
 		Parent: "parent-1",
 	}
 	testCore.Create(child)
@@ -211,7 +240,7 @@ func TestExecuteQueryWithRelationships(t *testing.T) {
 		ID:       "blocker-1",
 		Slug:     "blocker-issue",
 		Title:    "Blocker Issue",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"child-1"},
 	}
 	testCore.Create(blocker)
@@ -328,7 +357,7 @@ func TestExecuteQueryWithFilters(t *testing.T) {
 	testCore, cleanup := setupQueryTestCore(t)
 	defer cleanup()
 
-	testCore.Create(&issue.Issue{ID: "bug-1", Slug: "bug-one", Title: "Bug One", Status: "todo", Type: "bug", Priority: "critical", Tags: []string{"frontend"}})
+	testCore.Create(&issue.Issue{ID: "bug-1", Slug: "bug-one", Title: "Bug One", Status: "ready", Type: "bug", Priority: "critical", Tags: []string{"frontend"}})
 	testCore.Create(&issue.Issue{ID: "feat-1", Slug: "feature-one", Title: "Feature One", Status: "in-progress", Type: "feature", Priority: "high", Tags: []string{"backend"}})
 	testCore.Create(&issue.Issue{ID: "task-1", Slug: "task-one", Title: "Task One", Status: "completed", Type: "task", Priority: "normal", Tags: []string{"frontend", "backend"}})
 
@@ -425,7 +454,7 @@ func TestExecuteQueryWithFilters(t *testing.T) {
 	})
 
 	t.Run("combined filters", func(t *testing.T) {
-		query := `{ issues(filter: { status: ["todo", "in-progress"], type: ["bug", "feature"] }) { id } }`
+		query := `{ issues(filter: { status: ["ready", "in-progress"], type: ["bug", "feature"] }) { id } }`
 		result, err := executeQuery(query, nil, "")
 		if err != nil {
 			t.Fatalf("executeQuery() error = %v", err)
@@ -485,7 +514,7 @@ func TestReadQueryFromFile(t *testing.T) {
 	testCore, cleanup := setupQueryTestCore(t)
 	defer cleanup()
 
-	createQueryTestIssue(t, testCore, "file-1", "File Test Issue", "todo")
+	createQueryTestIssue(t, testCore, "file-1", "File Test Issue", "ready")
 
 	t.Run("reads query from file", func(t *testing.T) {
 		tmpFile := filepath.Join(t.TempDir(), "query.graphql")
@@ -557,3 +586,272 @@ func TestReadFromStdin(t *testing.T) {
 		}
 	})
 }
+
+func TestExecuteQueryLimits(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+
+	// Chain of parented issues: chain-0 <- chain-1 <- ... <- chain-14.
+	for i := range 15 {
+		b := &issue.Issue{
+			ID:     fmt.Sprintf("chain-%d", i),
+			Slug:   "link",
+			Title:  fmt.Sprintf("Chain %d", i),
+			Status: "ready",
+		}
+		if i > 0 {
+			b.Parent = fmt.Sprintf("chain-%d", i-1)
+		}
+		if err := testCore.Create(b); err != nil {
+			t.Fatalf("failed to create chain issue: %v", err)
+		}
+	}
+
+	// nested builds a query whose total field depth is depth.
+	nested := func(depth int) string {
+		q := "id"
+		for range depth - 2 {
+			q = "children { " + q + " }"
+		}
+		return `{ issue(id: "chain-0") { ` + q + ` } }`
+	}
+
+	t.Run("within depth limit", func(t *testing.T) {
+		if _, err := executeQuery(nested(todoconfig.DefaultGraphQLMaxDepth), nil, ""); err != nil {
+			t.Fatalf("executeQuery() error = %v", err)
+		}
+	})
+
+	t.Run("depth limit exceeded", func(t *testing.T) {
+		_, err := executeQuery(nested(todoconfig.DefaultGraphQLMaxDepth+1), nil, "")
+		gqlErr, ok := errors.AsType[*graphQLError](err)
+		if !ok {
+			t.Fatalf("executeQuery() error = %v, want *graphQLError", err)
+		}
+		if code := gqlErr.errs[0].Extensions["code"]; code != errcode.ValidationFailed {
+			t.Errorf("error code = %v, want %s", code, errcode.ValidationFailed)
+		}
+		if !strings.Contains(err.Error(), "exceeds the limit") {
+			t.Errorf("error = %v, want depth limit message", err)
+		}
+	})
+
+	t.Run("configured limits", func(t *testing.T) {
+		testCore.Config().GraphQL.MaxDepth = 3
+		defer func() { testCore.Config().GraphQL.MaxDepth = 0 }()
+
+		if _, err := executeQuery(nested(4), nil, ""); err == nil {
+			t.Error("expected depth error with max_depth 3")
+		}
+	})
+
+	t.Run("timeout returns promptly", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		start := time.Now()
+		_, err := executeQueryContext(ctx, nested(todoconfig.DefaultGraphQLMaxDepth), nil, "")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("executeQueryContext() error = %v, want deadline exceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("timed-out query took %s", elapsed)
+		}
+	})
+}
+
+func TestExecuteQueryValidationCode(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	createQueryTestIssue(t, testCore, "val-1", "Existing", "ready")
+
+	for name, query := range map[string]string{
+		"create": `mutation { createIssue(input: { title: "Typo", priority: "hgih" }) { id } }`,
+		"update": `mutation { updateIssue(id: "val-1", input: { priority: "hgih" }) { id } }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := executeQuery(query, nil, "")
+			gqlErr, ok := errors.AsType[*graphQLError](err)
+			if !ok {
+				t.Fatalf("executeQuery() error = %v, want *graphQLError", err)
+			}
+			if code := gqlErr.errs[0].Extensions["code"]; code != graph.ErrCodeValidation {
+				t.Errorf("error code = %v, want %s", code, graph.ErrCodeValidation)
+			}
+			if !strings.Contains(err.Error(), `did you mean "high"?`) {
+				t.Errorf("error = %v, want a suggestion", err)
+			}
+		})
+	}
+
+	if b, _ := testCore.Get("val-1"); b.Priority == "hgih" {
+		t.Error("rejected update changed the stored issue")
+	}
+}
+
+func TestExecuteQuerySizeLimitCode(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	testCore.Config().MaxBodyBytes = 16
+
+	_, err := executeQuery(`mutation { createIssue(input: { title: "Log dump", body: "far more than sixteen bytes" }) { id } }`, nil, "")
+	gqlErr, ok := errors.AsType[*graphQLError](err)
+	if !ok {
+		t.Fatalf("executeQuery() error = %v, want *graphQLError", err)
+	}
+	if code := gqlErr.errs[0].Extensions["code"]; code != graph.ErrCodeValidation {
+		t.Errorf("error code = %v, want %s", code, graph.ErrCodeValidation)
+	}
+	if !strings.Contains(err.Error(), "max_body_bytes") {
+		t.Errorf("error = %v, want the limit's config key", err)
+	}
+}
+
+func TestGraphQLCommandInput(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	createQueryTestIssue(t, testCore, "gqi-1", "Input Test", "ready")
+
+	resetFlags := func() {
+		queryFile, queryVariables, queryVariablesFile, queryOperation, queryStrict = "", "", "", "", false
+	}
+	t.Cleanup(resetFlags)
+
+	t.Run("dash reads the query from stdin", func(t *testing.T) {
+		resetFlags()
+		got := withStdin(t, "{ issues { id } }\n", func() (string, error) {
+			return graphqlQuery([]string{"-"})
+		})
+		if got != "{ issues { id } }" {
+			t.Errorf("graphqlQuery(-) = %q", got)
+		}
+	})
+
+	t.Run("query file and argument conflict", func(t *testing.T) {
+		resetFlags()
+		queryFile = "ops.graphql"
+		if _, err := graphqlQuery([]string{"{ issues { id } }"}); err == nil {
+			t.Error("graphqlQuery() with --query-file and an argument: want error")
+		}
+	})
+
+	t.Run("variables file", func(t *testing.T) {
+		resetFlags()
+		path := filepath.Join(t.TempDir(), "vars.json")
+		if err := os.WriteFile(path, []byte(`{"id": "gqi-1"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		queryVariablesFile = path
+		got, err := graphqlVariables()
+		if err != nil || got["id"] != "gqi-1" {
+			t.Fatalf("graphqlVariables() = %v, %v, want id gqi-1", got, err)
+		}
+
+		// --variables and --variables-file cannot be combined.
+		graphqlCmd.Flags().Set("variables", `{"id": "other"}`)
+		graphqlCmd.Flags().Set("variables-file", path)
+		defer func() {
+			graphqlCmd.Flags().Lookup("variables").Changed = false
+			graphqlCmd.Flags().Lookup("variables-file").Changed = false
+		}()
+		if err := graphqlCmd.ValidateFlagGroups(); err == nil || !strings.Contains(err.Error(), "variables-file") {
+			t.Errorf("ValidateFlagGroups() = %v, want the variables flags to conflict", err)
+		}
+	})
+
+	t.Run("invalid variables JSON names line and column", func(t *testing.T) {
+		resetFlags()
+		queryVariables = "{\n  \"id\": \"gqi-1\",\n  \"limit\": ,\n}"
+		_, err := graphqlVariables()
+		if err == nil || !strings.Contains(err.Error(), "line 3, column 12") {
+			t.Errorf("graphqlVariables() error = %v, want line 3, column 12", err)
+		}
+		queryVariables = `["not", "an", "object"]`
+		if _, err := graphqlVariables(); err == nil || !strings.Contains(err.Error(), "line 1, column") {
+			t.Errorf("graphqlVariables() error = %v, want a position", err)
+		}
+	})
+
+	t.Run("strict needs an operation for multi-operation documents", func(t *testing.T) {
+		resetFlags()
+		doc := `query Ids { issues { id } } query Titles { issues { title } }`
+		if _, err := selectOperation(doc, "", true); err == nil || !strings.Contains(err.Error(), "Ids, Titles") {
+			t.Errorf("selectOperation(strict) error = %v, want the operation names", err)
+		}
+		op, err := selectOperation(doc, "", false)
+		if err != nil || op.Name != "Ids" {
+			t.Errorf("selectOperation() = %v, %v, want the first operation", op, err)
+		}
+		op, err = selectOperation(doc, "Titles", true)
+		if err != nil || operationLabel(op) != "query Titles" {
+			t.Errorf("selectOperation(Titles) = %v, %v", op, err)
+		}
+		if op, err := selectOperation(`{ issues { id } }`, "", true); err != nil || operationLabel(op) != "query (anonymous)" {
+			t.Errorf("selectOperation(single) = %v, %v, want the anonymous query", op, err)
+		}
+
+		queryStrict = true
+		if err := graphqlCmd.RunE(graphqlCmd, []string{doc}); err == nil || !strings.Contains(err.Error(), "--strict") {
+			t.Errorf("graphql --strict on two operations: error = %v", err)
+		}
+	})
+}
+
+func TestCreatedByEntryPoints(t *testing.T) {
+	t.Setenv(graph.ActorEnv, "dana")
+	testCore := seedPorcelainIssues(t)
+
+	out := capturePorcelain(t, func() error { return createCmd.RunE(createCmd, []string{"From", "the", "CLI"}) })
+	cliID, _, _ := strings.Cut(out, "\t")
+	if _, err := executeQuery(`mutation { createIssue(input: {title: "From GraphQL"}) { id } }`, nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeQuery(`mutation { createIssue(input: {title: "From an agent", actor: "agent-7"}) { id } }`, nil, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	byTitle := map[string]*issue.Issue{}
+	for _, b := range testCore.All() {
+		byTitle[b.Title] = b
+	}
+	for title, want := range map[string][2]string{
+		"From the CLI":  {"dana", issue.CreatedViaCLI},
+		"From GraphQL":  {"dana", issue.CreatedViaGraphQL},
+		"From an agent": {"agent-7", issue.CreatedViaGraphQL},
+	} {
+		b := byTitle[title]
+		if b == nil {
+			t.Fatalf("no issue %q", title)
+		}
+		if b.CreatedBy != want[0] || b.CreatedVia != want[1] {
+			t.Errorf("%q created by %q via %q, want %q via %q", title, b.CreatedBy, b.CreatedVia, want[0], want[1])
+		}
+	}
+	if b := byTitle["From the CLI"]; b.ID != cliID {
+		t.Errorf("create printed %q, want %q", cliID, b.ID)
+	}
+
+	resolver := &graph.Resolver{Core: testCore}
+	for _, tt := range []struct {
+		flags issueFilterFlags
+		want  int
+	}{
+		{issueFilterFlags{createdVia: []string{issue.CreatedViaCLI}}, 1},
+		{issueFilterFlags{createdVia: []string{issue.CreatedViaGraphQL}}, 2},
+		{issueFilterFlags{createdBy: []string{"dana"}}, 2},
+		{issueFilterFlags{createdBy: []string{"dana"}, createdVia: []string{issue.CreatedViaGraphQL}}, 1},
+	} {
+		filter, err := tt.flags.filter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := resolver.Query().Issues(context.Background(), filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("--created-by %v --created-via %v matched %d issues, want %d", tt.flags.createdBy, tt.flags.createdVia, len(got), tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// todoQuiet switches a mutating todo subcommand to printing only the IDs of
+// the issues it changed. Each such command registers --quiet with
+// addQuietFlag; list keeps its own one-ID-per-line --quiet.
+var todoQuiet bool
+
+// quietHelp documents --quiet for scripts, next to porcelainHelp.
+const quietHelp = `Quiet output (--quiet, -q):
+  Commands that change issues print exactly one line: the IDs of the
+  issues they created, changed, or deleted, separated by spaces, and
+  nothing else. Errors and warnings still go to stderr.
+
+    id=$(jig todo create "Fix login" -q)
+    jig todo update "$id" --blocked-by "$(jig todo create "Repro" -q)" -q
+
+  --quiet cannot be combined with --json or --porcelain.`
+
+// addQuietFlag gives a mutating command --quiet.
+func addQuietFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&todoQuiet, "quiet", "q", false, "Print only the affected issue ID(s), on one line")
+}
+
+// validateOutputMode rejects asking for more than one of --json,
+// --porcelain, and --quiet, and --porcelain on commands without a stable
+// format. todo's PersistentPreRunE runs it for every subcommand, so
+// resolveOutputMode only has to pick the one mode asked for.
+func validateOutputMode(cmd *cobra.Command) error {
+	if err := validatePorcelain(cmd); err != nil {
+		return err
+	}
+	if !quietRequested(cmd) {
+		return nil
+	}
+	if f := cmd.Flags().Lookup("json"); jsonOut || (f != nil && f.Changed) {
+		return errors.New("--quiet and --json are mutually exclusive")
+	}
+	if todoPorcelain {
+		return errors.New("--quiet and --porcelain are mutually exclusive")
+	}
+	return nil
+}
+
+// writeQuiet writes the --quiet line: ids separated by spaces. With no ids
+// the line is empty, so a script reading one line never blocks.
+func writeQuiet(w io.Writer, ids ...string) error {
+	_, err := fmt.Fprintln(w, strings.Join(ids, " "))
+	return err
+}
+
+// printQuiet writes the --quiet line to stdout.
+func printQuiet(ids ...string) error {
+	return writeQuiet(os.Stdout, ids...)
+}
+
+// issueIDs returns the IDs of issues, for a commandResult's Quiet line.
+func issueIDs(issues []*issue.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, b := range issues {
+		ids[i] = b.ID
+	}
+	return ids
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// seedQuietIssues sets up a store with an epic, a child, and a completed
+// issue, and turns on --quiet.
+func seedQuietIssues(t *testing.T) {
+	t.Helper()
+	seedTestIssues(t,
+		&issue.Issue{ID: "qui-001", Slug: "epic", Title: "Epic", Status: "ready", Type: "epic", Body: "## Tasks\n\n- [ ] One\n- [ ] Two\n"},
+		&issue.Issue{ID: "qui-002", Slug: "child", Title: "Child", Status: "ready", Type: "task", Parent: "qui-001"},
+		&issue.Issue{ID: "qui-003", Slug: "done", Title: "Done", Status: "completed", Type: "task"},
+	)
+	todoQuiet = true
+	t.Cleanup(func() { todoQuiet = false })
+}
+
+// quietLine is a --quiet line: IDs separated by single spaces, then a
+// newline.
+var quietLine = regexp.MustCompile(`^([a-z0-9]+-[a-z0-9]+( [a-z0-9]+-[a-z0-9]+)*)?\n$`)
+
+func TestQuietOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T) error
+		// want is the exact output, or "" to check the IDs with check.
+		want  string
+		check func(t *testing.T, ids []string)
+	}{
+		{name: "create", run: func(t *testing.T) error {
+			return createCmd.RunE(createCmd, []string{"Write", "docs"})
+		}, check: func(t *testing.T, ids []string) {
+			if b, err := todoStore.Get(ids[0]); len(ids) != 1 || err != nil || b.Title != "Write docs" {
+				t.Errorf("created %v, want the one new issue", ids)
+			}
+		}},
+		{name: "update", want: "qui-002\n", run: func(t *testing.T) error {
+			c := &cobra.Command{Use: "update", RunE: todoUpdateCmd.RunE}
+			registerUpdateFlags(c)
+			if err := c.ParseFlags([]string{"--status", "completed"}); err != nil {
+				t.Fatal(err)
+			}
+			todoQuiet = true // registering the flag reset it
+			return c.RunE(c, []string{"qui-002"})
+		}},
+		{name: "delete", want: "qui-002 qui-003\n", run: func(t *testing.T) error {
+			return deleteCmd.RunE(deleteCmd, []string{"qui-002", "qui-003"})
+		}},
+		{name: "archive", want: "qui-003\n", run: func(t *testing.T) error {
+			return archiveCmd.RunE(archiveCmd, nil)
+		}},
+		{name: "comment", want: "qui-001\n", run: func(t *testing.T) error {
+			return todoCommentCmd.RunE(todoCommentCmd, []string{"qui-001", "looks", "good"})
+		}},
+		{name: "move", want: "qui-002\n", run: func(t *testing.T) error {
+			moveRoot = true
+			t.Cleanup(func() { moveRoot = false })
+			return todoMoveCmd.RunE(todoMoveCmd, []string{"qui-002"})
+		}},
+		{name: "link", want: "qui-002 qui-003\n", run: func(t *testing.T) error {
+			linkBlocking = "qui-001"
+			t.Cleanup(func() { linkBlocking = "" })
+			return todoLinkCmd.RunE(todoLinkCmd, []string{"qui-002", "qui-003"})
+		}},
+		{name: "bulk-update", want: "qui-002 qui-003\n", run: func(t *testing.T) error {
+			c := newBulkTestCmd(t, "--id", "qui-002", "--id", "qui-003", "--add-tag", "sprint")
+			todoQuiet = true
+			return todoBulkUpdateCmd.RunE(c, nil)
+		}},
+		{name: "merge", want: "qui-001\n", run: func(t *testing.T) error {
+			mergeInto = "qui-001"
+			t.Cleanup(func() { mergeInto = "" })
+			return todoMergeCmd.RunE(todoMergeCmd, []string{"qui-003"})
+		}},
+		{name: "expand", run: func(t *testing.T) error {
+			return todoExpandCmd.RunE(todoExpandCmd, []string{"qui-001"})
+		}, check: func(t *testing.T, ids []string) {
+			if len(ids) != 2 {
+				t.Fatalf("expand printed %v, want the two new children", ids)
+			}
+			for _, id := range ids {
+				if b, err := todoStore.Get(id); err != nil || b.Parent != "qui-001" {
+					t.Errorf("%s is not a new child of qui-001", id)
+				}
+			}
+		}},
+		{name: "milestone create", run: func(t *testing.T) error {
+			milestoneShort = "M1"
+			t.Cleanup(func() { milestoneShort = "" })
+			return milestoneCreateCmd.RunE(milestoneCreateCmd, []string{"Beta"})
+		}, check: func(t *testing.T, ids []string) {
+			if len(ids) != 1 || !todoStore.MilestoneExists(ids[0]) {
+				t.Errorf("milestone create printed %v, want the new milestone's ID", ids)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedQuietIssues(t)
+			got := capturePorcelain(t, func() error { return tt.run(t) })
+			if !quietLine.MatchString(got) {
+				t.Fatalf("output = %q, want one line of IDs", got)
+			}
+			if tt.check != nil {
+				tt.check(t, strings.Fields(got))
+			} else if got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOutputMode(t *testing.T) {
+	oldPorcelain := todoPorcelain
+	t.Cleanup(func() { todoPorcelain, todoQuiet = oldPorcelain, false })
+
+	newCmd := func(args ...string) *cobra.Command {
+		c := &cobra.Command{Use: "create", Annotations: map[string]string{porcelainAnnotation: "id"}}
+		c.Flags().Bool("json", false, "")
+		addQuietFlag(c)
+		if err := c.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	if err := validateOutputMode(newCmd("--quiet")); err != nil {
+		t.Errorf("--quiet alone: %v", err)
+	}
+	if err := validateOutputMode(newCmd("-q", "--json")); err == nil || !strings.Contains(err.Error(), "--quiet and --json") {
+		t.Errorf("--quiet --json error = %v", err)
+	}
+	todoPorcelain = true
+	if err := validateOutputMode(newCmd("--quiet")); err == nil || !strings.Contains(err.Error(), "--quiet and --porcelain") {
+		t.Errorf("--quiet --porcelain error = %v", err)
+	}
+}
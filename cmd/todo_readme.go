@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Markers around the part of the output file that todo readme manages.
+// Everything outside them is left as written.
+const (
+	readmeBeginMarker = "<!-- BEGIN jig todo readme: generated, edits inside are overwritten -->"
+	readmeEndMarker   = "<!-- END jig todo readme -->"
+)
+
+// readmeRecentWindow is how far back the recently completed section looks.
+const readmeRecentWindow = 14 * todoconfig.Day
+
+// readmeSections are the section names --sections accepts, in default order.
+var readmeSections = []string{"active", "blocked", "upcoming", "recent"}
+
+var (
+	readmeOutput     string
+	readmeSectionSet []string
+	readmeCheck      bool
+	readmeLinkPrefix string
+	readmeInternal   bool
+)
+
+var readmeCmd = &cobra.Command{
+	Use:   "readme",
+	Short: "Write a markdown summary of the tracker for people who don't run jig",
+	Long: `Writes a markdown summary of the issue tracker into --output (TODO.md by
+default): active work grouped by status, blocked issues with their
+blockers, open issues with due dates, and issues completed in the last 14
+days. Each issue links to its file.
+
+The summary sits between two marker comments. Running again replaces only
+what is between them, so hand-written text around the markers is kept; a
+file without markers gets the summary appended.
+
+--check writes nothing and fails if the file is not what this run would
+write, for CI.`,
+	Example: `  jig todo readme
+  jig todo readme --sections active,blocked
+  jig todo readme --check`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, s := range readmeSectionSet {
+			if !slices.Contains(readmeSections, s) {
+				return fmt.Errorf("unknown section %q (use %s)", s, strings.Join(readmeSections, ", "))
+			}
+		}
+
+		linkPrefix := readmeLinkPrefix
+		if linkPrefix == "" && readmeOutput != "-" {
+			linkPrefix = linkPrefixFrom(filepath.Dir(readmeOutput))
+		} else if linkPrefix == "" {
+			linkPrefix = defaultLinkPrefix()
+		}
+		block := renderReadme(publicIssues(todoStore.All(), readmeInternal), readmeSectionSet, linkPrefix, todoStore.Now(), todoStore.FindActiveBlockers)
+
+		if readmeOutput == "-" {
+			fmt.Print(block)
+			return nil
+		}
+
+		existing, err := os.ReadFile(readmeOutput)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		content := mergeManagedRegion(string(existing), block)
+
+		if readmeCheck {
+			if content != string(existing) {
+				return fmt.Errorf("%s is out of date; run 'jig todo readme' to regenerate it", readmeOutput)
+			}
+			fmt.Printf("%s is up to date\n", readmeOutput)
+			return nil
+		}
+		if content == string(existing) {
+			return nil
+		}
+		if err := os.WriteFile(readmeOutput, []byte(content), 0o644); err != nil { //nolint:gosec // a committed document, not a secret
+			return err
+		}
+		fmt.Printf("Wrote %s\n", readmeOutput)
+		return nil
+	},
+}
+
+// mergeManagedRegion puts block in place of the marked region of existing,
+// or after its content when it has no complete region.
+func mergeManagedRegion(existing, block string) string {
+	region := readmeBeginMarker + "\n" + block + readmeEndMarker
+	if before, rest, ok := strings.Cut(existing, readmeBeginMarker); ok {
+		if _, after, ok := strings.Cut(rest, readmeEndMarker); ok {
+			return before + region + after
+		}
+	}
+	if strings.TrimSpace(existing) == "" {
+		return region + "\n"
+	}
+	return strings.TrimRight(existing, "\n") + "\n\n" + region + "\n"
+}
+
+// renderReadme renders the named sections as markdown. It depends only on
+// the issues and now, so an unchanged tracker renders the same text.
+func renderReadme(issues []*issue.Issue, sections []string, linkPrefix string, now time.Time, activeBlockers func(string) []*issue.Issue) string {
+	var open, recent []*issue.Issue
+	for _, b := range issues {
+		switch {
+		case !todoCfg.IsArchiveStatus(b.Status):
+			open = append(open, b)
+		case b.Status == todoconfig.StatusCompleted && b.UpdatedAt != nil && now.Sub(*b.UpdatedAt) <= readmeRecentWindow:
+			recent = append(recent, b)
+		}
+	}
+	slices.SortFunc(open, func(a, b *issue.Issue) int { return cmp.Compare(a.ID, b.ID) })
+
+	ref := func(b *issue.Issue) string {
+		return b.Title + " " + renderIssueRef(b, true, linkPrefix)
+	}
+	var sb strings.Builder
+	none := func(n int) {
+		if n == 0 {
+			sb.WriteString("_None._\n")
+		}
+	}
+
+	for i, section := range sections {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		switch section {
+		case "active":
+			sb.WriteString("## Active work\n")
+			count := 0
+			for _, status := range todoCfg.StatusNames() {
+				if status == todoconfig.StatusDraft || status == todoconfig.StatusDeferred || todoCfg.IsArchiveStatus(status) {
+					continue
+				}
+				var group []*issue.Issue
+				for _, b := range open {
+					if b.Status == status {
+						group = append(group, b)
+					}
+				}
+				if len(group) == 0 {
+					continue
+				}
+				fmt.Fprintf(&sb, "\n### %s\n\n", status)
+				for _, b := range group {
+					fmt.Fprintf(&sb, "- %s\n", ref(b))
+				}
+				count += len(group)
+			}
+			if count == 0 {
+				sb.WriteString("\n")
+			}
+			none(count)
+
+		case "blocked":
+			sb.WriteString("## Blocked\n\n")
+			count := 0
+			for _, b := range open {
+				blockers := activeBlockers(b.ID)
+				if len(blockers) == 0 {
+					continue
+				}
+				slices.SortFunc(blockers, func(a, b *issue.Issue) int { return cmp.Compare(a.ID, b.ID) })
+				refs := make([]string, len(blockers))
+				for i, blocker := range blockers {
+					refs[i] = ref(blocker)
+				}
+				fmt.Fprintf(&sb, "- %s, blocked by %s\n", ref(b), strings.Join(refs, "; "))
+				count++
+			}
+			none(count)
+
+		case "upcoming":
+			sb.WriteString("## Upcoming\n\n")
+			var due []*issue.Issue
+			for _, b := range open {
+				if b.Due != nil {
+					due = append(due, b)
+				}
+			}
+			issue.SortByDueDate(due)
+			for _, b := range due {
+				overdue := ""
+				if b.Due.Deadline().Before(now) {
+					overdue = " ⚠ overdue"
+				}
+				fmt.Fprintf(&sb, "- %s: %s%s\n", b.Due.String(), ref(b), overdue)
+			}
+			none(len(due))
+
+		case "recent":
+			sb.WriteString("## Recently completed\n\n")
+			slices.SortFunc(recent, func(a, b *issue.Issue) int {
+				return cmp.Or(b.UpdatedAt.Compare(*a.UpdatedAt), cmp.Compare(a.ID, b.ID))
+			})
+			for _, b := range recent {
+				fmt.Fprintf(&sb, "- %s: %s\n", b.UpdatedAt.UTC().Format(issue.DueDateFormat), ref(b))
+			}
+			none(len(recent))
+		}
+	}
+	return sb.String()
+}
+
+func init() {
+	readmeCmd.Flags().StringVarP(&readmeOutput, "output", "o", "TODO.md", "File to write the summary into ('-' for stdout)")
+	readmeCmd.Flags().StringSliceVar(&readmeSectionSet, "sections", readmeSections, "Sections to include, in order ("+strings.Join(readmeSections, ", ")+")")
+	readmeCmd.Flags().BoolVar(&readmeCheck, "check", false, "Fail if the file is out of date instead of writing it")
+	readmeCmd.Flags().StringVar(&readmeLinkPrefix, "link-prefix", "", "URL prefix for issue links (default: the data directory relative to the output file)")
+	readmeCmd.Flags().BoolVar(&readmeInternal, "include-internal", false, includeInternalUsage)
+	todoCmd.AddCommand(readmeCmd)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// seedReadmeIssues sets up a store covering every readme section, with each
+// issue created at its own day so the recently completed window is fixed,
+// and leaves the clock at 2026-03-20.
+func seedReadmeIssues(t *testing.T) *core.Core {
+	t.Helper()
+	testCore := seedTestIssues(t)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	for _, seed := range []struct {
+		day int
+		b   *issue.Issue
+	}{
+		{1, &issue.Issue{ID: "rdm-001", Slug: "parser", Title: "Rewrite parser", Status: "in-progress", Type: "feature"}},
+		{1, &issue.Issue{ID: "rdm-002", Slug: "docs", Title: "Document parser", Status: "ready", Type: "task", BlockedBy: []string{"rdm-001"}}},
+		{1, &issue.Issue{ID: "rdm-003", Slug: "release", Title: "Cut release", Status: "review", Type: "task", Due: issue.NewDueDate(day(25))}},
+		{1, &issue.Issue{ID: "rdm-004", Slug: "audit", Title: "License audit", Status: "ready", Type: "task", Due: issue.NewDueDate(day(18))}},
+		{1, &issue.Issue{ID: "rdm-005", Slug: "idea", Title: "Someday idea", Status: "draft", Type: "task"}},
+		{2, &issue.Issue{ID: "rdm-006", Slug: "old", Title: "Old fix", Status: "completed", Type: "bug"}},
+		{10, &issue.Issue{ID: "rdm-007", Slug: "lexer", Title: "Fix lexer", Status: "completed", Type: "bug"}},
+		{15, &issue.Issue{ID: "rdm-008", Slug: "ci", Title: "Speed up CI", Status: "completed", Type: "task"}},
+		{15, &issue.Issue{ID: "rdm-009", Slug: "dropped", Title: "Dropped plan", Status: "scrapped", Type: "task"}},
+	} {
+		testCore.SetClock(func() time.Time { return day(seed.day) })
+		addTestIssues(t, testCore, seed.b)
+	}
+	testCore.SetClock(func() time.Time { return day(20) })
+	return testCore
+}
+
+// runReadme runs todo readme with the given flags, restoring the defaults
+// afterwards.
+func runReadme(t *testing.T, output string, check bool) (string, error) {
+	t.Helper()
+	oldOutput, oldSections, oldCheck, oldPrefix := readmeOutput, readmeSectionSet, readmeCheck, readmeLinkPrefix
+	t.Cleanup(func() {
+		readmeOutput, readmeSectionSet, readmeCheck, readmeLinkPrefix = oldOutput, oldSections, oldCheck, oldPrefix
+	})
+	readmeOutput, readmeSectionSet, readmeCheck, readmeLinkPrefix = output, readmeSections, check, ".issues"
+
+	var runErr error
+	out := capturePorcelain(t, func() error {
+		runErr = readmeCmd.RunE(readmeCmd, nil)
+		return nil
+	})
+	return out, runErr
+}
+
+func TestReadmeGolden(t *testing.T) {
+	seedReadmeIssues(t)
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if _, err := runReadme(t, path, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "readme", "TODO.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("TODO.md drifted from fixture:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReadmeIsIdempotent(t *testing.T) {
+	seedReadmeIssues(t)
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if _, err := runReadme(t, path, false); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(path)
+
+	out, err := runReadme(t, path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(path)
+	if string(first) != string(second) {
+		t.Errorf("second run changed the file:\nfirst:\n%s\nsecond:\n%s", first, second)
+	}
+	if out != "" {
+		t.Errorf("second run output = %q, want nothing for an unchanged file", out)
+	}
+}
+
+func TestReadmePreservesHandWrittenContent(t *testing.T) {
+	seedReadmeIssues(t)
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	intro := "# Roadmap\n\nWritten by hand.\n"
+	outro := "\n## Notes\n\nAlso by hand.\n"
+	stale := intro + "\n" + readmeBeginMarker + "\nstale\n" + readmeEndMarker + "\n" + outro
+	if err := os.WriteFile(path, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runReadme(t, path, false); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(got), intro+"\n"+readmeBeginMarker+"\n## Active work\n") {
+		t.Errorf("content before the markers not kept:\n%s", got)
+	}
+	if !strings.HasSuffix(string(got), readmeEndMarker+"\n"+outro) {
+		t.Errorf("content after the markers not kept:\n%s", got)
+	}
+	if strings.Contains(string(got), "stale") {
+		t.Errorf("old generated block not replaced:\n%s", got)
+	}
+
+	t.Run("no markers", func(t *testing.T) {
+		if err := os.WriteFile(path, []byte(intro), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := runReadme(t, path, false); err != nil {
+			t.Fatal(err)
+		}
+		got, _ := os.ReadFile(path)
+		if !strings.HasPrefix(string(got), intro+"\n"+readmeBeginMarker+"\n") {
+			t.Errorf("block not appended after existing content:\n%s", got)
+		}
+	})
+}
+
+func TestReadmeCheck(t *testing.T) {
+	testCore := seedReadmeIssues(t)
+	path := filepath.Join(t.TempDir(), "TODO.md")
+
+	if _, err := runReadme(t, path, true); err == nil {
+		t.Error("--check passed with no file")
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("--check wrote the file")
+	}
+
+	if _, err := runReadme(t, path, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runReadme(t, path, true); err != nil {
+		t.Errorf("--check after generating: %v", err)
+	}
+
+	b, err := testCore.Get("rdm-002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Status = "in-progress"
+	if err := testCore.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runReadme(t, path, true); err == nil || !strings.Contains(err.Error(), "out of date") {
+		t.Errorf("--check after an issue changed: error = %v, want out of date", err)
+	}
+}
+
+func TestReadmeRejectsUnknownSection(t *testing.T) {
+	seedReadmeIssues(t)
+	oldSections := readmeSectionSet
+	t.Cleanup(func() { readmeSectionSet = oldSections })
+	readmeSectionSet = []string{"active", "bogus"}
+	if err := readmeCmd.RunE(readmeCmd, nil); err == nil || !strings.Contains(err.Error(), `unknown section "bogus"`) {
+		t.Errorf("error = %v, want unknown section", err)
+	}
+}
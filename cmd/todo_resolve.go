@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+// resolveIssueArg looks up an issue from a command-line reference, accepting
+// an exact ID, an exact slug, or a unique case-insensitive title substring.
+// GraphQL keeps exact-ID semantics; only CLI arguments get this fallback.
+// A reference that matches nothing fails with a *core.NotFoundError that
+// suggests the nearest ID.
+func resolveIssueArg(query string) (*issue.Issue, error) {
+	b, err := todoStore.Resolve(query)
+	if errors.Is(err, core.ErrNotFound) {
+		return nil, todoStore.NotFound(query)
+	}
+	return b, err
+}
+
+// resolveErrorCode maps a resolveIssueArg error to the JSON error code.
+func resolveErrorCode(err error) string {
+	if _, ok := errors.AsType[*core.AmbiguousError](err); ok {
+		return output.ErrAmbiguous
+	}
+	return output.ErrNotFound
+}
+
+// resolveIssueArgs resolves each reference in order, stopping at the first
+// failure. The error is already formatted for JSON or text mode.
+func resolveIssueArgs(jsonMode bool, queries []string) ([]*issue.Issue, error) {
+	issues := make([]*issue.Issue, 0, len(queries))
+	for _, q := range queries {
+		b, err := resolveIssueArg(q)
+		if err != nil {
+			return nil, cmdError(jsonMode, resolveErrorCode(err), "%w", err)
+		}
+		issues = append(issues, b)
+	}
+	return issues, nil
+}
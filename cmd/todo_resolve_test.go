@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestResolveIssueArg(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+
+	createQueryTestIssue(t, testCore, "k3f-9da", "Login flow", "ready")
+	createQueryTestIssue(t, testCore, "m2n-4op", "Session timeout", "ready")
+
+	for _, q := range []string{"k3f-9da", "login-flow", "LOGIN"} {
+		b, err := resolveIssueArg(q)
+		if err != nil {
+			t.Fatalf("resolveIssueArg(%q) error = %v", q, err)
+		}
+		if b.ID != "k3f-9da" {
+			t.Errorf("resolveIssueArg(%q) = %s, want k3f-9da", q, b.ID)
+		}
+	}
+
+	_, err := resolveIssueArg("missing")
+	if err == nil || !strings.Contains(err.Error(), "issue not found: missing") {
+		t.Errorf("resolveIssueArg(missing) error = %v, want not-found message", err)
+	}
+	if code := resolveErrorCode(err); code != output.ErrNotFound {
+		t.Errorf("resolveErrorCode(not found) = %s, want %s", code, output.ErrNotFound)
+	}
+}
+
+func TestResolveIssueArgAmbiguous(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+
+	createQueryTestIssue(t, testCore, "aaa-111", "Login flow", "ready")
+	createQueryTestIssue(t, testCore, "bbb-222", "Login page styling", "ready")
+
+	_, err := resolveIssueArg("login")
+	if _, ok := errors.AsType[*core.AmbiguousError](err); !ok {
+		t.Fatalf("resolveIssueArg() error = %v, want *core.AmbiguousError", err)
+	}
+	if code := resolveErrorCode(err); code != output.ErrAmbiguous {
+		t.Errorf("resolveErrorCode(ambiguous) = %s, want %s", code, output.ErrAmbiguous)
+	}
+	for _, id := range []string{"aaa-111", "bbb-222"} {
+		if !strings.Contains(err.Error(), id) {
+			t.Errorf("ambiguity error should list candidate %s: %v", id, err)
+		}
+	}
+
+	// Multi-arg resolution stops at the ambiguous reference.
+	if _, err := resolveIssueArgs(false, []string{"aaa-111", "login"}); err == nil {
+		t.Error("resolveIssueArgs() expected error for ambiguous argument")
+	}
+}
+
+func TestCommentIssueBySlug(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+
+	if err := testCore.Create(&issue.Issue{
+		ID:     "cmt-9",
+		Slug:   "add-retries",
+		Title:  "Add retries",
+		Status: "ready",
+	}); err != nil {
+		t.Fatalf("seeding issue: %v", err)
+	}
+
+	b, err := commentIssue("add-retries", "note")
+	if err != nil {
+		t.Fatalf("commentIssue() error = %v", err)
+	}
+	if b.ID != "cmt-9" || !strings.Contains(b.Body, "note") {
+		t.Errorf("commentIssue() by slug = %s %q", b.ID, b.Body)
+	}
+}
+
+func TestIssueArgNotFoundSuggestsID(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	createQueryTestIssue(t, testCore, "k3f-9da", "Login flow", "ready")
+
+	for name, cmd := range map[string]*cobra.Command{
+		"show":   showCmd,
+		"update": todoUpdateCmd,
+		"delete": deleteCmd,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := runJSONCommand(t, cmd, nil, "k3f-9db")
+			if got := errorCode(err, ""); got != output.ErrNotFound {
+				t.Errorf("code %q, want %s (error: %v)", got, output.ErrNotFound, err)
+			}
+			if err == nil || !strings.Contains(err.Error(), "did you mean k3f-9da?") {
+				t.Errorf("error = %v, want a suggestion of k3f-9da", err)
+			}
+		})
+	}
+
+	_, err := runJSONCommand(t, showCmd, nil, "zzz-000")
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("error = %v, want not found without a suggestion", err)
+	}
+}
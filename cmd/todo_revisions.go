@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	revisionsShow    string
+	revisionsRestore string
+	revisionsJSON    bool
+)
+
+var revisionsCmd = &cobra.Command{
+	Use:   "revisions <id>",
+	Short: "List, show, or restore an issue's previous bodies",
+	Long: `Lists the previous bodies kept for an issue, newest first. With
+keep_body_revisions: N in ` + config.ConfigFileName + `, every update that changes a body
+stores the old one under .issues/.revisions/<id>/, keeping the newest N.
+
+--show prints the body stored at a timestamp, and --restore makes it the
+issue's body again. A restore is an ordinary update: it fails if the issue
+changed since it was read, and the body it replaces is kept as a revision.
+
+The argument may be an issue ID, a slug, or a unique title substring.`,
+	Example: `  jig todo revisions abc-123
+  jig todo revisions abc-123 --show 20250611T100300.000Z
+  jig todo revisions abc-123 --restore 20250611T100300.000Z`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if resolveOutputMode(revisionsJSON) == outputQuiet && revisionsRestore == "" {
+			return cmdError(false, output.ErrValidation, "--quiet requires --restore")
+		}
+		b, err := resolveIssueArg(args[0])
+		if err != nil {
+			return cmdError(revisionsJSON, resolveErrorCode(err), "%w", err)
+		}
+
+		if timestamp := cmp.Or(revisionsShow, revisionsRestore); timestamp != "" {
+			body, err := todoStore.RevisionBody(b.ID, timestamp)
+			if errors.Is(err, core.ErrRevisionNotFound) {
+				return cmdError(revisionsJSON, output.ErrNotFound, "%w", err)
+			}
+			if err != nil {
+				return cmdError(revisionsJSON, output.ErrFileError, "%w", err)
+			}
+			if revisionsShow != "" {
+				if revisionsJSON {
+					return printRevisionJSON(map[string]string{"id": b.ID, "timestamp": timestamp, "body": body})
+				}
+				fmt.Print(body)
+				return nil
+			}
+
+			// Restoring a revision is a deliberate whole-body rewrite, so tasks
+			// added since it was saved may go.
+			etag := b.ETag()
+			resolver := &graph.Resolver{Core: todoStore}
+			restored, err := resolver.Mutation().UpdateIssue(context.Background(), b.ID, model.UpdateIssueInput{Body: &body, IfMatch: &etag, Force: new(true)})
+			if err != nil {
+				return mutationError(revisionsJSON, err)
+			}
+			return resolveOutputMode(revisionsJSON).write(commandResult{
+				JSON:  func() error { return output.Success(restored, "Restored revision "+timestamp) },
+				Quiet: []string{restored.ID},
+				Text: func() error {
+					fmt.Println(ui.Success.Render("Restored ") + ui.ID.Render(restored.ID) + ui.Muted.Render(" body from "+timestamp))
+					return nil
+				},
+			})
+		}
+
+		revisions, err := todoStore.Revisions(b.ID)
+		if err != nil {
+			return cmdError(revisionsJSON, output.ErrFileError, "%w", err)
+		}
+		if revisionsJSON {
+			return printRevisionJSON(revisions)
+		}
+		if len(revisions) == 0 {
+			hint := ""
+			if todoCfg.KeepBodyRevisions <= 0 {
+				hint = " (set keep_body_revisions in " + config.ConfigFileName + " to keep them)"
+			}
+			fmt.Println(ui.Muted.Render("No revisions of " + b.ID + hint))
+			return nil
+		}
+		for _, r := range revisions {
+			fmt.Printf("%s  %s  %s\n", ui.ID.Render(r.Timestamp),
+				r.CreatedAt.Local().Format(time.DateTime), ui.Muted.Render(fmt.Sprintf("%d bytes", r.Size)))
+		}
+		return nil
+	},
+}
+
+// printRevisionJSON prints v as indented JSON.
+func printRevisionJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func init() {
+	revisionsCmd.Flags().StringVar(&revisionsShow, "show", "", "Print the body stored at this timestamp")
+	revisionsCmd.Flags().StringVar(&revisionsRestore, "restore", "", "Make the body stored at this timestamp the issue's body again")
+	revisionsCmd.Flags().BoolVar(&revisionsJSON, "json", false, "Output as JSON")
+	addQuietFlag(revisionsCmd)
+	revisionsCmd.MarkFlagsMutuallyExclusive("show", "restore")
+	todoCmd.AddCommand(revisionsCmd)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+func TestRevisionsRestore(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	testCore.Config().KeepBodyRevisions = 5
+	createQueryTestIssue(t, testCore, "rev-1", "Revised", "ready")
+
+	b, _ := testCore.Get("rev-1")
+	b.Body = "original plan"
+	if err := testCore.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	b.Body = "rewritten by someone else"
+	if err := testCore.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var revisions []core.Revision
+	t.Run("list", func(t *testing.T) {
+		out, err := runJSONCommand(t, revisionsCmd, map[string]string{"json": "true"}, "rev-1")
+		if err != nil {
+			t.Fatalf("revisions: %v", err)
+		}
+		if err := json.Unmarshal([]byte(out), &revisions); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out)
+		}
+	})
+	if len(revisions) != 2 {
+		t.Fatalf("got %d revisions, want 2", len(revisions))
+	}
+
+	t.Run("show", func(t *testing.T) {
+		out, err := runJSONCommand(t, revisionsCmd, map[string]string{"show": revisions[0].Timestamp}, "rev-1")
+		if err != nil || strings.TrimSpace(out) != "original plan" {
+			t.Errorf("--show = %q, %v; want the original plan", out, err)
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		if _, err := runJSONCommand(t, revisionsCmd, map[string]string{"restore": revisions[0].Timestamp}, "rev-1"); err != nil {
+			t.Fatalf("--restore: %v", err)
+		}
+	})
+	b, _ = testCore.Get("rev-1")
+	if strings.TrimSpace(b.Body) != "original plan" {
+		t.Errorf("body after restore = %q", b.Body)
+	}
+	// The replaced body is itself kept.
+	if got, _ := testCore.Revisions("rev-1"); len(got) != 3 {
+		t.Errorf("revisions after restore = %d, want 3", len(got))
+	}
+
+	t.Run("unknown timestamp", func(t *testing.T) {
+		_, err := runJSONCommand(t, revisionsCmd, map[string]string{"show": "20000101T000000.000Z"}, "rev-1")
+		if got := errorCode(err, ""); got != output.ErrNotFound {
+			t.Errorf("code %q, want %s (error: %v)", got, output.ErrNotFound, err)
+		}
+	})
+}
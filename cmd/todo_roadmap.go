@@ -11,11 +11,14 @@ import (
 	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
 	todoconfig "github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/stats"
+	"github.com/toba/jig/internal/todo/ui"
 )
 
 //go:embed todo_roadmap.tmpl
@@ -28,6 +31,9 @@ var (
 	roadmapNoStatus    []string
 	roadmapNoLinks     bool
 	roadmapLinkPrefix  string
+	roadmapGroupBy     string
+	roadmapInternal    bool
+	roadmapShowBlocked bool
 )
 
 type roadmapData struct {
@@ -44,24 +50,56 @@ type milestoneGroup struct {
 	Milestone *issue.Issue   `json:"milestone"`
 	Epics     []epicGroup    `json:"epics,omitempty"`
 	Other     []*issue.Issue `json:"other,omitempty"`
+	*stats.Rollup
 }
 
 type epicGroup struct {
 	Epic  *issue.Issue   `json:"epic"`
 	Items []*issue.Issue `json:"items,omitempty"`
+	*stats.Rollup
+}
+
+// iterationRoadmap is the roadmap grouped by iteration rather than
+// milestone (--group-by iteration).
+type iterationRoadmap struct {
+	Iterations []iterationGroup `json:"iterations"`
+	Unassigned []*issue.Issue   `json:"unassigned,omitempty"`
+}
+
+type iterationGroup struct {
+	stats.IterationCount
+	Items []*issue.Issue `json:"items,omitempty"`
 }
 
 var roadmapCmd = &cobra.Command{
 	Use:   "roadmap",
 	Short: "Generate a Markdown roadmap from milestones and epics",
+	Long: `Generates a Markdown roadmap from milestones and epics.
+
+With --json, each milestone and epic also carries a rollup of everything
+under it: totals by status and type, how many open issues are blocked or
+overdue, and the blocked issues with their active blockers. --show-blocked
+marks blocked issues in the Markdown with their blocker IDs.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		resolver := &graph.Resolver{Core: todoStore}
 		allIssues, err := resolver.Query().Issues(context.Background(), nil)
 		if err != nil {
 			return fmt.Errorf("querying issues: %w", err)
 		}
-
-		data := buildRoadmap(allIssues, roadmapIncludeDone, roadmapStatus, roadmapNoStatus)
+		allIssues = publicIssues(allIssues, roadmapInternal)
+
+		var data any
+		switch roadmapGroupBy {
+		case "milestone":
+			roadmap := buildRoadmap(allIssues, roadmapIncludeDone, roadmapStatus, roadmapNoStatus)
+			rollUpRoadmap(roadmap, allIssues, todoStore.Now(), todoStore.FindActiveBlockers)
+			data = roadmap
+		case "iteration":
+			current, _ := todoCfg.CurrentIteration(todoStore.Now())
+			data = buildIterationRoadmap(allIssues, roadmapIncludeDone, current)
+		default:
+			return fmt.Errorf("invalid --group-by %q (must be milestone or iteration)", roadmapGroupBy)
+		}
 
 		if roadmapJSON {
 			enc := json.NewEncoder(cmd.OutOrStdout())
@@ -74,7 +112,11 @@ var roadmapCmd = &cobra.Command{
 		if links && linkPrefix == "" {
 			linkPrefix = defaultLinkPrefix()
 		}
-		md := renderRoadmapMarkdown(data, links, linkPrefix)
+		var activeBlockers func(string) []*issue.Issue
+		if roadmapShowBlocked {
+			activeBlockers = todoStore.FindActiveBlockers
+		}
+		md := renderRoadmapMarkdown(data, links, linkPrefix, activeBlockers)
 		fmt.Print(md)
 		return nil
 	},
@@ -182,6 +224,91 @@ func buildRoadmap(allIssues []*issue.Issue, includeDone bool, statusFilter, noSt
 	}
 }
 
+// rollUpRoadmap sets the rollup of every milestone and epic group in data
+// from all their descendants in allIssues, done or not, whatever the item
+// lists show.
+func rollUpRoadmap(data *roadmapData, allIssues []*issue.Issue, now time.Time, activeBlockers func(string) []*issue.Issue) {
+	children := make(map[string][]*issue.Issue)
+	for _, b := range allIssues {
+		if b.Parent != "" {
+			children[b.Parent] = append(children[b.Parent], b)
+		}
+	}
+	rollUp := func(root *issue.Issue) *stats.Rollup {
+		r := stats.RollUp(descendants(root.ID, children), todoCfg, now, activeBlockers)
+		return &r
+	}
+	epics := func(groups []epicGroup) {
+		for i := range groups {
+			groups[i].Rollup = rollUp(groups[i].Epic)
+		}
+	}
+	for i := range data.Milestones {
+		data.Milestones[i].Rollup = rollUp(data.Milestones[i].Milestone)
+		epics(data.Milestones[i].Epics)
+	}
+	if data.Unscheduled != nil {
+		epics(data.Unscheduled.Epics)
+	}
+}
+
+// descendants returns every issue below id in children, each once.
+func descendants(id string, children map[string][]*issue.Issue) []*issue.Issue {
+	var result []*issue.Issue
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, child := range children[parent] {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			result = append(result, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	return result
+}
+
+// buildIterationRoadmap groups issues by iteration, in the order of
+// stats.ByIteration, with their committed and completed counts. Counts
+// always include completed issues; the item lists only with includeDone.
+// Milestones, epics, and empty groups are left out; unassigned issues are
+// listed after the iterations.
+func buildIterationRoadmap(allIssues []*issue.Issue, includeDone bool, current string) *iterationRoadmap {
+	var items []*issue.Issue
+	for _, b := range allIssues {
+		if b.Type == todoconfig.TypeMilestone || b.Type == todoconfig.TypeEpic {
+			continue
+		}
+		if includeDone || !todoCfg.IsArchiveStatus(b.Status) {
+			items = append(items, b)
+		}
+	}
+
+	byIteration := make(map[string][]*issue.Issue)
+	for _, b := range items {
+		byIteration[b.Iteration] = append(byIteration[b.Iteration], b)
+	}
+
+	data := &iterationRoadmap{}
+	for _, count := range stats.ByIteration(allIssues, todoCfg, current) {
+		group := iterationGroup{IterationCount: count, Items: byIteration[count.Iteration]}
+		if group.Committed == 0 && len(group.Items) == 0 {
+			continue
+		}
+		sortByTypeThenStatus(group.Items, todoCfg)
+		data.Iterations = append(data.Iterations, group)
+	}
+	if unassigned := byIteration[""]; len(unassigned) > 0 {
+		sortByTypeThenStatus(unassigned, todoCfg)
+		data.Unassigned = unassigned
+	}
+	return data
+}
+
 func buildMilestoneGroup(m *issue.Issue, children map[string][]*issue.Issue, includeDone bool) milestoneGroup {
 	group := milestoneGroup{Milestone: m}
 
@@ -283,24 +410,63 @@ func sortByTypeThenStatus(issues []*issue.Issue, cfg interface {
 	})
 }
 
-func renderRoadmapMarkdown(data *roadmapData, links bool, linkPrefix string) string {
+// renderRoadmapMarkdown renders a *roadmapData, or an *iterationRoadmap with
+// the template's "iterations" layout. With activeBlockers, open issues that
+// have some are marked "⚠ blocked" with their blocker IDs.
+func renderRoadmapMarkdown(data any, links bool, linkPrefix string, activeBlockers func(string) []*issue.Issue) string {
 	tmpl := template.Must(
 		template.New("roadmap").Funcs(template.FuncMap{
-			"firstParagraph": firstParagraph,
-			"typeBadge":      typeBadge,
+			"synopsis":         roadmapSynopsis,
+			"typeBadge":        typeBadge,
+			"iterationSummary": iterationSummary,
 			"beanRef": func(b *issue.Issue) string {
 				return renderIssueRef(b, links, linkPrefix)
 			},
+			"blocked": func(b *issue.Issue) string {
+				return blockedAnnotation(b, activeBlockers)
+			},
 		}).Parse(roadmapTemplateContent),
 	)
 
+	name := "roadmap"
+	if _, ok := data.(*iterationRoadmap); ok {
+		name = "iterations"
+	}
 	var sb strings.Builder
-	if err := tmpl.Execute(&sb, data); err != nil {
+	if err := tmpl.ExecuteTemplate(&sb, name, data); err != nil {
 		panic(err)
 	}
 	return sb.String()
 }
 
+// iterationSummary is the line under an iteration heading: progress, then
+// the iteration's dates when declared.
+func iterationSummary(g iterationGroup) string {
+	s := fmt.Sprintf("%d of %d completed", g.Completed, g.Committed)
+	if g.Start != "" && g.End != "" {
+		s += fmt.Sprintf(" · %s – %s", g.Start, g.End)
+	}
+	return s
+}
+
+// blockedAnnotation is the " ⚠ blocked by a, b" suffix of an open issue
+// with active blockers, or "" when it has none or activeBlockers is nil.
+func blockedAnnotation(b *issue.Issue, activeBlockers func(string) []*issue.Issue) string {
+	if activeBlockers == nil || todoCfg.IsArchiveStatus(b.Status) {
+		return ""
+	}
+	blockers := activeBlockers(b.ID)
+	if len(blockers) == 0 {
+		return ""
+	}
+	ids := make([]string, len(blockers))
+	for i, blocker := range blockers {
+		ids[i] = blocker.ID
+	}
+	slices.Sort(ids)
+	return " ⚠ blocked by " + strings.Join(ids, ", ")
+}
+
 func renderIssueRef(b *issue.Issue, asLink bool, linkPrefix string) string {
 	if !asLink {
 		return "(" + b.ID + ")"
@@ -318,6 +484,9 @@ func typeBadge(b *issue.Issue) string {
 	if b.Type == "" {
 		return ""
 	}
+	if ui.Accessible {
+		return ui.Label(b.Type)
+	}
 	colors := map[string]string{
 		todoconfig.TypeBug:       "d73a4a",
 		todoconfig.TypeFeature:   "0e8a16",
@@ -326,6 +495,13 @@ func typeBadge(b *issue.Issue) string {
 		todoconfig.TypeMilestone: "fbca04",
 	}
 	color := colors[b.Type]
+	if color == "" && todoCfg != nil {
+		// Project-defined types use their configured color; shields.io takes
+		// both CSS color names and bare hex values.
+		if t := todoCfg.GetType(b.Type); t != nil {
+			color = strings.TrimPrefix(t.Color, "#")
+		}
+	}
 	if color == "" {
 		color = "gray"
 	}
@@ -337,38 +513,29 @@ func defaultLinkPrefix() string {
 	if err != nil {
 		return ""
 	}
-	rel, err := filepath.Rel(cwd, todoStore.Root())
-	if err != nil {
-		return ""
-	}
-	return filepath.ToSlash(rel)
+	return linkPrefixFrom(cwd)
 }
 
-func firstParagraph(body string) string {
-	body = strings.TrimSpace(body)
-	if body == "" {
+// linkPrefixFrom returns the data directory relative to dir, for links in
+// a document written to dir.
+func linkPrefixFrom(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
 		return ""
 	}
-
-	lines := strings.Split(body, "\n")
-	var para []string
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			break
-		}
-		if strings.HasPrefix(line, "#") {
-			continue
-		}
-		para = append(para, strings.TrimSpace(line))
+	rel, err := filepath.Rel(abs, todoStore.Root())
+	if err != nil {
+		return ""
 	}
+	return filepath.ToSlash(rel)
+}
 
-	const maxDescriptionLen = 200
+// maxRoadmapDescriptionLen caps the quoted description under an epic or
+// milestone heading.
+const maxRoadmapDescriptionLen = 200
 
-	result := strings.Join(para, " ")
-	if len(result) > maxDescriptionLen {
-		result = result[:maxDescriptionLen-3] + "..."
-	}
-	return result
+func roadmapSynopsis(b *issue.Issue) string {
+	return b.Synopsis(maxRoadmapDescriptionLen)
 }
 
 func init() {
@@ -378,5 +545,8 @@ func init() {
 	roadmapCmd.Flags().StringArrayVar(&roadmapNoStatus, "no-status", nil, "Exclude milestones by status (can be repeated)")
 	roadmapCmd.Flags().BoolVar(&roadmapNoLinks, "no-links", false, "Don't render issue IDs as markdown links")
 	roadmapCmd.Flags().StringVar(&roadmapLinkPrefix, "link-prefix", "", "URL prefix for links")
+	roadmapCmd.Flags().BoolVar(&roadmapInternal, "include-internal", false, includeInternalUsage)
+	roadmapCmd.Flags().BoolVar(&roadmapShowBlocked, "show-blocked", false, "Mark blocked issues with their active blockers")
+	roadmapCmd.Flags().StringVar(&roadmapGroupBy, "group-by", "milestone", "Group by milestone or iteration (with committed and completed counts)")
 	todoCmd.AddCommand(roadmapCmd)
 }
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	todoconfig "github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/stats"
 )
 
 func TestBuildRoadmap(t *testing.T) {
@@ -108,7 +112,7 @@ func TestBuildRoadmap(t *testing.T) {
 	}
 }
 
-func TestFirstParagraph(t *testing.T) {
+func TestRoadmapSynopsis(t *testing.T) {
 	tests := []struct {
 		name string
 		body string
@@ -119,6 +123,7 @@ func TestFirstParagraph(t *testing.T) {
 		{"multiple paragraphs", "First paragraph.\n\nSecond paragraph.", "First paragraph."},
 		{"multiline first paragraph", "Line one\nLine two\n\nSecond para.", "Line one Line two"},
 		{"skips headers at start", "## Checklist\n- item one", "- item one"},
+		{"skips blank line after header", "## Context\n\nWhy it matters.", "Why it matters."},
 		{
 			"truncates long text",
 			"This is a very long paragraph that exceeds two hundred characters and needs to be truncated so it does not take up too much space in the roadmap output. Lorem ipsum dolor sit amet consectetur adipiscing elit.",
@@ -128,9 +133,9 @@ func TestFirstParagraph(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := firstParagraph(tt.body)
+			got := roadmapSynopsis(&issue.Issue{Body: tt.body})
 			if got != tt.want {
-				t.Errorf("firstParagraph() = %q, want %q", got, tt.want)
+				t.Errorf("roadmapSynopsis() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -217,3 +222,149 @@ func TestStatusFiltering(t *testing.T) {
 		}
 	})
 }
+
+func TestIterationRoadmap(t *testing.T) {
+	oldCfg := todoCfg
+	defer func() { todoCfg = oldCfg }()
+
+	todoCfg = todoconfig.Default()
+	todoCfg.Iterations = []todoconfig.IterationConfig{
+		{Name: "sprint-1", Start: "2025-08-04", End: "2025-08-17"},
+		{Name: "sprint-2"},
+		{Name: "sprint-3"},
+	}
+
+	issues := []*issue.Issue{
+		{ID: "t1", Type: "task", Title: "Shipped", Status: "completed", Iteration: "sprint-1"},
+		{ID: "t2", Type: "task", Title: "Carried", Status: "in-progress", Iteration: "sprint-1"},
+		{ID: "t3", Type: "bug", Title: "Next", Status: "ready", Iteration: "sprint-2"},
+		{ID: "t4", Type: "task", Title: "Someday", Status: "ready"},
+		{ID: "e1", Type: "epic", Title: "Epic", Status: "ready", Iteration: "sprint-2"},
+	}
+
+	data := buildIterationRoadmap(issues, false, "sprint-2")
+	if len(data.Iterations) != 2 {
+		t.Fatalf("got %d iterations, want 2 (empty sprint-3 left out)", len(data.Iterations))
+	}
+	first := data.Iterations[0]
+	if first.Iteration != "sprint-1" || first.Committed != 2 || first.Completed != 1 {
+		t.Errorf("sprint-1 = %+v, want 1 of 2 completed", first.IterationCount)
+	}
+	if len(first.Items) != 1 || first.Items[0].ID != "t2" {
+		t.Errorf("sprint-1 items = %v, want only the open issue", first.Items)
+	}
+	if len(data.Unassigned) != 1 || data.Unassigned[0].ID != "t4" {
+		t.Errorf("unassigned = %v, want t4", data.Unassigned)
+	}
+
+	md := renderRoadmapMarkdown(data, false, "", nil)
+	for _, want := range []string{
+		"## Iteration: sprint-1\n",
+		"> 1 of 2 completed · 2025-08-04 – 2025-08-17",
+		"## Iteration: sprint-2 (current)",
+		"Carried (t2)",
+		"## No Iteration",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Epic (e1)") {
+		t.Errorf("epics should not be listed:\n%s", md)
+	}
+}
+
+func TestRoadmapRollups(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	oldCfg := todoCfg
+	defer func() { todoCfg = oldCfg }()
+	todoCfg = todoconfig.Default()
+
+	now := time.Now()
+	yesterday := issue.NewDueDate(now.AddDate(0, 0, -1))
+	for _, b := range []*issue.Issue{
+		{ID: "m1", Type: "milestone", Title: "v1", Status: "ready"},
+		{ID: "e1", Type: "epic", Title: "Auth", Status: "ready", Parent: "m1"},
+		{ID: "e2", Type: "epic", Title: "Later", Status: "ready"},
+		{ID: "t4", Type: "task", Title: "Schema", Status: "ready", Parent: "e2"},
+		{ID: "t5", Type: "task", Title: "Spike", Status: "completed", Parent: "e2"},
+		{ID: "t3", Type: "task", Title: "API", Status: "in-progress", Parent: "m1", BlockedBy: []string{"t4"}},
+		{ID: "t1", Type: "feature", Title: "Login", Status: "ready", Parent: "e1", BlockedBy: []string{"t3"}},
+		{ID: "s1", Type: "task", Title: "Form", Status: "ready", Parent: "t1", Due: yesterday, BlockedBy: []string{"t5"}},
+		{ID: "t2", Type: "bug", Title: "Crash", Status: "completed", Parent: "e1"},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatalf("Create(%s): %v", b.ID, err)
+		}
+	}
+
+	data := buildRoadmap(testCore.All(), false, nil, nil)
+	rollUpRoadmap(data, testCore.All(), now, testCore.FindActiveBlockers)
+
+	if len(data.Milestones) != 1 || len(data.Milestones[0].Epics) != 1 {
+		t.Fatalf("roadmap = %+v, want one milestone with one epic", data)
+	}
+	milestone := data.Milestones[0]
+	assertRollup(t, "m1", milestone.Rollup, stats.Rollup{
+		Totals: stats.Totals{
+			ByStatus: map[string]int{"ready": 3, "in-progress": 1, "completed": 1},
+			ByType:   map[string]int{"epic": 1, "feature": 1, "task": 2, "bug": 1},
+		},
+		BlockedCount: 2,
+		OverdueCount: 1,
+		Blockers: []stats.BlockedIssue{
+			{IssueID: "t1", BlockedBy: []string{"t3"}},
+			{IssueID: "t3", BlockedBy: []string{"t4"}},
+		},
+	})
+	assertRollup(t, "e1", milestone.Epics[0].Rollup, stats.Rollup{
+		Totals: stats.Totals{
+			ByStatus: map[string]int{"ready": 2, "completed": 1},
+			ByType:   map[string]int{"feature": 1, "task": 1, "bug": 1},
+		},
+		BlockedCount: 1,
+		OverdueCount: 1,
+		Blockers:     []stats.BlockedIssue{{IssueID: "t1", BlockedBy: []string{"t3"}}},
+	})
+	if data.Unscheduled == nil || len(data.Unscheduled.Epics) != 1 {
+		t.Fatalf("unscheduled = %+v, want epic e2", data.Unscheduled)
+	}
+	assertRollup(t, "e2", data.Unscheduled.Epics[0].Rollup, stats.Rollup{
+		Totals: stats.Totals{
+			ByStatus: map[string]int{"ready": 1, "completed": 1},
+			ByType:   map[string]int{"task": 2},
+		},
+		Blockers: []stats.BlockedIssue{},
+	})
+
+	out, err := json.Marshal(milestone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"totals":{"byStatus":`, `"blockedCount":2`, `"overdueCount":1`, `"blockers":[{"issueId":"t1","blockedBy":["t3"]}`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("milestone JSON missing %s:\n%s", want, out)
+		}
+	}
+
+	md := renderRoadmapMarkdown(data, false, "", testCore.FindActiveBlockers)
+	for _, want := range []string{"Login (t1) ⚠ blocked by t3\n", "API (t3) ⚠ blocked by t4\n", "Schema (t4)\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if plain := renderRoadmapMarkdown(data, false, "", nil); strings.Contains(plain, "⚠") {
+		t.Errorf("markdown without --show-blocked marks blocked issues:\n%s", plain)
+	}
+}
+
+func assertRollup(t *testing.T, id string, got *stats.Rollup, want stats.Rollup) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("%s has no rollup", id)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("%s rollup = %+v, want %+v", id, *got, want)
+	}
+}
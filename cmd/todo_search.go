@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/search"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	searchRegex         bool
+	searchCaseSensitive bool
+	searchIn            []string
+	searchJSON          bool
+)
+
+// searchHitJSON is one issue in the --json output of todo search.
+type searchHitJSON struct {
+	ID      string         `json:"id"`
+	Status  string         `json:"status"`
+	Title   string         `json:"title"`
+	Matches []search.Match `json:"matches"`
+}
+
+var todoSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search issue titles, tags, summaries, bodies, and comments",
+	Long: `Searches every issue for the query and shows where it matched: the field,
+and the matching line with a line of context on either side. Issues with a
+title match rank first, then tag, summary, body, and comment matches, and
+more recently updated issues before older ones. Matches in the Comments
+section are reported as comment.
+
+The query is literal text matched without regard to case, unless --regex
+or --case-sensitive is given. --in restricts the fields searched.
+
+Matches are underlined on a terminal and wrapped in ** when piped. With
+--json, each match carries its snippet and the byte offsets of the match
+within it.`,
+	Example: `  jig todo search "login timeout"
+  jig todo search --regex 'retr(y|ies)' --in title,body
+  jig todo search parser --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := strings.Join(args, " ")
+		hits, err := todoStore.Find(query, search.Options{Regex: searchRegex, CaseSensitive: searchCaseSensitive, Fields: searchIn})
+		if err != nil {
+			return cmdError(searchJSON, output.ErrValidation, "%w", err)
+		}
+
+		if searchJSON {
+			items := make([]searchHitJSON, 0, len(hits))
+			for _, h := range hits {
+				items = append(items, searchHitJSON{ID: h.Issue.ID, Status: h.Issue.Status, Title: h.Issue.Title, Matches: h.Matches})
+			}
+			data, _ := json.MarshalIndent(items, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		out := colorprofile.NewWriter(os.Stdout, os.Environ())
+		writeSearchHits(out, hits, out.Profile > colorprofile.ASCII)
+		return nil
+	},
+}
+
+// writeSearchHits prints each hit's issue line followed by its matches.
+func writeSearchHits(w io.Writer, hits []search.Hit, color bool) {
+	if len(hits) == 0 {
+		fmt.Fprintln(w, ui.Muted.Render("No matches."))
+		return
+	}
+	for i, h := range hits {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s %s %s\n", ui.ID.Render(h.Issue.ID), ui.RenderStatusText(h.Issue.Status), ui.Bold.Render(h.Issue.Title))
+		for _, m := range h.Matches {
+			label := fmt.Sprintf("%s:%d", m.Field, m.Line)
+			indent := "\n  " + strings.Repeat(" ", len(label)+1)
+			snippet := strings.ReplaceAll(highlightMatch(m, color), "\n", indent)
+			fmt.Fprintf(w, "  %s %s\n", ui.Muted.Render(label), snippet)
+		}
+	}
+}
+
+// highlightMatch returns the match's snippet with the matched text
+// underlined, or wrapped in ** when color is off.
+func highlightMatch(m search.Match, color bool) string {
+	matched := m.Snippet[m.Start:m.End]
+	if color {
+		matched = lipgloss.NewStyle().Underline(true).Render(matched)
+	} else {
+		matched = "**" + matched + "**"
+	}
+	return m.Snippet[:m.Start] + matched + m.Snippet[m.End:]
+}
+
+func init() {
+	todoSearchCmd.Flags().BoolVar(&searchRegex, "regex", false, "Treat the query as a regular expression")
+	todoSearchCmd.Flags().BoolVar(&searchCaseSensitive, "case-sensitive", false, "Match letter case exactly")
+	todoSearchCmd.Flags().StringSliceVar(&searchIn, "in", nil, "Fields to search ("+strings.Join(search.Fields, ", ")+"; default all)")
+	todoSearchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(todoSearchCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/search"
+)
+
+func TestWriteSearchHitsPlain(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	defer cleanup()
+	if err := testCore.Create(&issue.Issue{ID: "srh-001", Slug: "cache", Title: "Cache eviction", Status: "ready", Body: "Intro\nThe cache grows.\nOutro"}); err != nil {
+		t.Fatal(err)
+	}
+	hits, err := testCore.Find("cache", search.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	writeSearchHits(&colorprofile.Writer{Forward: &buf, Profile: colorprofile.NoTTY}, hits, false)
+	want := "srh-001 ready Cache eviction\n" +
+		"  title:1 **Cache** eviction\n" +
+		"  body:2 Intro\n" +
+		"         The **cache** grows.\n" +
+		"         Outro\n"
+	if buf.String() != want {
+		t.Errorf("plain output =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
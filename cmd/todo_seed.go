@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/testfixtures"
+)
+
+var (
+	seedCount int
+	seedSeed  uint64
+	seedForce bool
+	seedJSON  bool
+)
+
+var seedCmd = &cobra.Command{
+	Use:    "seed",
+	Short:  "Fill the data directory with generated demo issues",
+	Hidden: true,
+	Long: `Generates a deterministic, realistic issue graph for demos and tests:
+milestones, epics, features, tasks, and bugs with parents, blocking chains,
+tags, due dates, and placeholder bodies. The same --seed always produces the
+same issues.
+
+Refuses to run when the data directory already has issues or milestones
+unless --force is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Generate reads a zero Count as "use the default", so --count 0
+		// would create the default 50.
+		if seedCount < 1 {
+			return cmdError(seedJSON, output.ErrValidation, "--count must be at least 1")
+		}
+		if !seedForce && (len(todoStore.AllUnordered()) > 0 || len(todoStore.AllMilestones()) > 0) {
+			return cmdError(seedJSON, output.ErrValidation,
+				"data directory is not empty (use --force to add generated issues anyway)")
+		}
+
+		opts := testfixtures.DefaultOptions()
+		opts.Count = seedCount
+		opts.Seed = seedSeed
+		res, err := testfixtures.Generate(todoStore, opts)
+		if err != nil {
+			return cmdError(seedJSON, output.ErrFileError, "seeding failed: %w", err)
+		}
+
+		msg := fmt.Sprintf("Created %d issue(s) and %d milestone(s)", len(res.Issues), len(res.Milestones))
+		if seedJSON {
+			return output.JSON(output.Response{Success: true, Issues: res.Issues, Count: len(res.Issues), Message: msg})
+		}
+		fmt.Println(msg)
+		return nil
+	},
+}
+
+func init() {
+	seedCmd.Flags().IntVar(&seedCount, "count", 50, "Number of issues to generate")
+	seedCmd.Flags().Uint64Var(&seedSeed, "seed", 1, "Random seed (the same seed gives the same issues)")
+	seedCmd.Flags().BoolVar(&seedForce, "force", false, "Seed even if the data directory is not empty")
+	seedCmd.Flags().BoolVar(&seedJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(seedCmd)
+}
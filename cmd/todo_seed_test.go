@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSeedRejectsZeroCount(t *testing.T) {
+	_, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	t.Cleanup(func() { seedCount = 50 })
+
+	for _, n := range []int{0, -1} {
+		seedCount = n
+		if err := seedCmd.RunE(seedCmd, nil); err == nil || !strings.Contains(err.Error(), "--count") {
+			t.Errorf("seed --count %d error = %v, want a --count error", n, err)
+		}
+	}
+	if n := len(todoStore.All()); n != 0 {
+		t.Errorf("seed created %d issues, want none", n)
+	}
+}
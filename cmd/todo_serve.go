@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/integration"
+)
+
+var (
+	serveListen         string
+	servePlayground     bool
+	serveAllowMutations bool
+	serveCORSOrigins    []string
+	serveTimeout        time.Duration
+	serveWebhooks       bool
+)
+
+var todoServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the GraphQL API over HTTP",
+	Long: `Runs the GraphQL schema behind an HTTP server so editor extensions and
+dashboards can query issues without shelling out. Queries are POSTed (or
+sent with GET) to /graphql; --playground also serves GraphiQL at /.
+
+The server is read-only unless --allow-mutations is given. When a token is
+set in $JIG_SERVE_TOKEN, or as serve_token in the uncommitted .jig.local.yaml,
+every request must send "Authorization: Bearer <token>".
+
+--inbound-webhooks also serves /webhooks/github and /webhooks/clickup for
+providers with a webhook secret, set in $JIG_WEBHOOK_SECRET_GITHUB (or
+_CLICKUP) or under webhook_secrets in .jig.local.yaml. Deliveries are
+checked against the secret instead of the bearer token, and status changes,
+title edits, and comments on linked items are applied to their issues and
+noted in the History section. Set sync.<provider>.webhook_auto_import to
+import items created on the provider that are not linked yet.
+
+The issues directory is watched while serving, so edits made elsewhere show
+up in the next response. Ctrl-C stops the server after in-flight requests
+finish.`,
+	Example: `  jig todo serve
+  jig todo serve --listen 127.0.0.1:8080 --playground
+  jig todo serve --allow-mutations --cors-origin http://localhost:5173
+  jig todo serve --listen :7777 --inbound-webhooks`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token := todoCfg.ServeToken()
+		if !isLoopback(serveListen) && token == "" {
+			fmt.Fprintf(os.Stderr, "warning: serving on %s without a token; set $%s\n", serveListen, todoconfig.ServeTokenEnv)
+		}
+
+		if err := todoStore.StartWatching(); err != nil {
+			return fmt.Errorf("watching issues: %w", err)
+		}
+		defer func() { _ = todoStore.Unwatch() }()
+
+		var handler http.Handler = graph.NewHandler(&graph.Resolver{Core: todoStore}, graph.ServerOptions{
+			AllowMutations: serveAllowMutations,
+			Playground:     servePlayground,
+			Token:          token,
+			CORSOrigins:    serveCORSOrigins,
+			Timeout:        serveTimeout,
+		})
+		if serveWebhooks {
+			webhooks, err := integration.NewWebhookHandler(todoStore, integration.WebhookOptions{Log: os.Stderr})
+			if err != nil {
+				return err
+			}
+			// Webhooks are authenticated by their signatures, not the token.
+			mux := http.NewServeMux()
+			mux.Handle(webhookPrefix, webhooks)
+			mux.Handle("/", handler)
+			handler = mux
+		}
+		ln, err := net.Listen("tcp", serveListen)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		serveErr := make(chan error, 1)
+		go func() { serveErr <- srv.Serve(ln) }()
+
+		mode := "read-only"
+		if serveAllowMutations {
+			mode = "mutations allowed"
+		}
+		fmt.Fprintf(os.Stderr, "Serving GraphQL at http://%s%s (%s)\n", ln.Addr(), graph.QueryPath, mode)
+		if serveWebhooks {
+			for _, path := range integration.WebhookPaths(todoCfg) {
+				fmt.Fprintf(os.Stderr, "Receiving webhooks at http://%s%s\n", ln.Addr(), path)
+			}
+		}
+
+		select {
+		case err := <-serveErr:
+			return err
+		case <-ctx.Done():
+		}
+
+		fmt.Fprintln(os.Stderr, "Shutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutting down: %w", err)
+		}
+		if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// webhookPrefix is the path prefix routed to the inbound webhook handler.
+const webhookPrefix = "/webhooks/"
+
+// isLoopback reports whether addr listens only on a loopback interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func init() {
+	todoServeCmd.Flags().StringVar(&serveListen, "listen", "127.0.0.1:7777", "Address to listen on")
+	todoServeCmd.Flags().BoolVar(&servePlayground, "playground", false, "Serve the GraphiQL playground at /")
+	todoServeCmd.Flags().BoolVar(&serveAllowMutations, "allow-mutations", false, "Allow mutations (read-only otherwise)")
+	todoServeCmd.Flags().StringSliceVar(&serveCORSOrigins, "cors-origin", nil, "Browser origin allowed to call the server (repeatable, * for any)")
+	todoServeCmd.Flags().BoolVar(&serveWebhooks, "inbound-webhooks", false, "Receive GitHub and ClickUp webhooks at /webhooks/<provider>")
+	todoServeCmd.Flags().DurationVar(&serveTimeout, "timeout", 30*time.Second, "Cancel a request after this long (0 disables)")
+	todoCmd.AddCommand(todoServeCmd)
+}
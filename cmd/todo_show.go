@@ -2,17 +2,18 @@ package cmd
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"charm.land/glamour/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/colorprofile"
 	"github.com/spf13/cobra"
-	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/integration"
 	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/output"
 	"github.com/toba/jig/internal/todo/ui"
@@ -28,27 +29,18 @@ var (
 var showCmd = &cobra.Command{
 	Use:   "show <id> [id...]",
 	Short: "Show an issue's contents",
-	Long:  `Displays the full contents of one or more issues, including front matter and body.`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Displays the full contents of one or more issues, including front matter and body.
+
+Each argument may be an issue ID, a slug, or a unique title substring
+(case-insensitive). Ambiguous references list the matching IDs instead.
+
+Archived issues are shown too, under an "archived" banner: they are
+read-only until unarchived.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resolver := &graph.Resolver{Core: todoStore}
-
-		var issues []*issue.Issue
-		for _, id := range args {
-			b, err := resolver.Query().Issue(context.Background(), id)
-			if err != nil {
-				if showJSON {
-					return output.Error(output.ErrNotFound, err.Error())
-				}
-				return fmt.Errorf("failed to find issue: %w", err)
-			}
-			if b == nil {
-				if showJSON {
-					return output.Error(output.ErrNotFound, "issue not found: "+id)
-				}
-				return fmt.Errorf("issue not found: %s", id)
-			}
-			issues = append(issues, b)
+		issues, err := resolveIssueArgs(showJSON, args)
+		if err != nil {
+			return err
 		}
 
 		if showJSON {
@@ -126,6 +118,22 @@ func renderIssue(b *issue.Issue, color bool) string {
 	return buf.String()
 }
 
+// createdByText describes who created b and through what, e.g. "created
+// by alice via tui", or "" when neither was recorded.
+func createdByText(b *issue.Issue) string {
+	var parts []string
+	if b.CreatedBy != "" {
+		parts = append(parts, "by "+b.CreatedBy)
+	}
+	if b.CreatedVia != "" {
+		parts = append(parts, "via "+b.CreatedVia)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "created " + strings.Join(parts, " ")
+}
+
 func writeStyledIssue(w io.Writer, b *issue.Issue, color bool) {
 	statusCfg := todoCfg.GetStatus(b.Status)
 	statusColor := "gray"
@@ -135,6 +143,10 @@ func writeStyledIssue(w io.Writer, b *issue.Issue, color bool) {
 	isArchive := todoCfg.IsArchiveStatus(b.Status)
 
 	var header strings.Builder
+	if todoStore != nil && todoStore.IsArchived(b.ID) {
+		header.WriteString(ui.Warning.Render("archived · read-only (restore with 'jig todo update " + b.ID + " --unarchive')"))
+		header.WriteString("\n")
+	}
 	header.WriteString(ui.ID.Render(b.ID))
 	header.WriteString(" ")
 	header.WriteString(ui.RenderStatusWithColor(b.Status, statusColor, isArchive))
@@ -151,18 +163,53 @@ func writeStyledIssue(w io.Writer, b *issue.Issue, color bool) {
 		header.WriteString(" ")
 		header.WriteString(ui.Muted.Render("due:" + b.Due.String()))
 	}
+	if b.Iteration != "" {
+		header.WriteString(" ")
+		header.WriteString(ui.Muted.Render("iteration:" + b.Iteration))
+	}
+	if b.Estimate != "" {
+		header.WriteString(" ")
+		header.WriteString(ui.Muted.Render("estimate:" + b.Estimate))
+	}
+	if created := createdByText(b); created != "" {
+		header.WriteString(" ")
+		header.WriteString(ui.Muted.Render(created))
+	}
 	if len(b.Tags) > 0 {
 		header.WriteString("  ")
 		header.WriteString(ui.Muted.Render(strings.Join(b.Tags, ", ")))
 	}
 	header.WriteString("\n")
 	header.WriteString(ui.Title.Render(b.Title))
+	if b.Summary != "" {
+		header.WriteString("\n")
+		header.WriteString(ui.Muted.Render(b.Summary))
+	}
 
-	if b.Parent != "" || len(b.Blocking) > 0 || len(b.BlockedBy) > 0 {
+	relationships := formatRelationships(b)
+	if mentions := formatMentions(b); mentions != "" {
+		if relationships != "" {
+			relationships += "\n"
+		}
+		relationships += mentions
+	}
+	if prs := formatPullRequests(b); prs != "" {
+		if relationships != "" {
+			relationships += "\n"
+		}
+		relationships += prs
+	}
+	if links := formatSyncLinks(b); links != "" {
+		if relationships != "" {
+			relationships += "\n"
+		}
+		relationships += links
+	}
+	if relationships != "" {
 		header.WriteString("\n")
 		header.WriteString(ui.Muted.Render(strings.Repeat("─", 50)))
 		header.WriteString("\n")
-		header.WriteString(formatRelationships(b))
+		header.WriteString(relationships)
 	}
 
 	header.WriteString("\n")
@@ -178,7 +225,7 @@ func writeStyledIssue(w io.Writer, b *issue.Issue, color bool) {
 		// Use the clean "notty" style (no background padding) when color is
 		// disabled, otherwise the terminal's configured style.
 		styleOpt := glamour.WithEnvironmentConfig()
-		if !color {
+		if !color || ui.Accessible {
 			styleOpt = glamour.WithStandardStyle("notty")
 		}
 		renderer, err := glamour.NewTermRenderer(
@@ -190,7 +237,7 @@ func writeStyledIssue(w io.Writer, b *issue.Issue, color bool) {
 			return
 		}
 
-		rendered, err := renderer.Render(b.Body)
+		rendered, err := renderer.Render(linkMentions(b))
 		if err != nil {
 			fmt.Fprintf(w, "failed to render markdown: %v\n", err)
 			return
@@ -221,6 +268,71 @@ func formatRelationships(b *issue.Issue) string {
 	return strings.Join(parts, "\n")
 }
 
+// formatMentions lists the issues b's body references and the issues whose
+// bodies reference b, with titles so the references read without a lookup.
+func formatMentions(b *issue.Issue) string {
+	if todoStore == nil {
+		return ""
+	}
+	var parts []string
+	for _, m := range todoStore.Mentions(b.ID) {
+		parts = append(parts, fmt.Sprintf("%s %s %s",
+			ui.Muted.Render("mentions:"),
+			ui.ID.Render(m.ID),
+			ui.Muted.Render(m.Title)))
+	}
+	for _, m := range todoStore.MentionedBy(b.ID) {
+		parts = append(parts, fmt.Sprintf("%s %s %s",
+			ui.Muted.Render("mentioned by:"),
+			ui.ID.Render(m.ID),
+			ui.Muted.Render(m.Title)))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// linkMentions returns b's body with bare mentions of other issues turned
+// into file links, which glamour renders as terminal hyperlinks.
+func linkMentions(b *issue.Issue) string {
+	if todoStore == nil {
+		return b.Body
+	}
+	return todoStore.IDMatcher().LinkMentions(b.Body, func(id string) string {
+		target, err := todoStore.Get(id)
+		if err != nil || target.ID == b.ID {
+			return ""
+		}
+		return (&url.URL{Scheme: "file", Path: todoStore.FullPath(target)}).String()
+	})
+}
+
+// formatPullRequests lists the GitHub pull requests linked to b with the
+// state last fetched by sync.
+func formatPullRequests(b *issue.Issue) string {
+	var syncCfg map[string]map[string]any
+	if todoCfg != nil {
+		syncCfg = todoCfg.Sync
+	}
+	var parts []string
+	for _, pr := range integration.FormatPullRequests(b, syncCfg, time.Now()) {
+		parts = append(parts, fmt.Sprintf("%s %s",
+			ui.Muted.Render("pr:"),
+			pr))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// formatSyncLinks lists the web URLs of b's sync entries that have one,
+// from a provider integration or sync_url_templates.
+func formatSyncLinks(b *issue.Issue) string {
+	var parts []string
+	for _, l := range integration.SyncLinks(b, todoCfg) {
+		parts = append(parts, fmt.Sprintf("%s %s",
+			ui.Muted.Render(l.Name+":"),
+			l.URL))
+	}
+	return strings.Join(parts, "\n")
+}
+
 func init() {
 	showCmd.Flags().BoolVar(&showJSON, "json", false, "Output as JSON")
 	showCmd.Flags().BoolVar(&showRaw, "raw", false, "Output raw markdown without styling")
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
@@ -125,3 +130,39 @@ func TestFormatRelationships(t *testing.T) {
 		}
 	})
 }
+
+func TestRenderIssueMentions(t *testing.T) {
+	oldCfg, oldStore := todoCfg, todoStore
+	defer func() { todoCfg, todoStore = oldCfg, oldStore }()
+	dataDir := filepath.Join(t.TempDir(), ".issues")
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	todoCfg = config.Default()
+	todoStore = core.New(dataDir, todoCfg)
+	if err := todoStore.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range []*issue.Issue{
+		{ID: "aaa-111", Slug: "target", Title: "Target issue", Status: "ready"},
+		{ID: "bbb-222", Slug: "source", Title: "Source issue", Status: "ready", Body: "Builds on aaa-111."},
+	} {
+		if err := todoStore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	target, _ := todoStore.Get("aaa-111")
+	out := renderIssue(target, false)
+	if !strings.Contains(out, "mentioned by: bbb-222 Source issue") {
+		t.Errorf("render missing reverse mention:\n%s", out)
+	}
+	source, _ := todoStore.Get("bbb-222")
+	if out := renderIssue(source, false); !strings.Contains(out, "mentions: aaa-111 Target issue") {
+		t.Errorf("render missing mention:\n%s", out)
+	}
+	if body := linkMentions(source); body != "Builds on [aaa-111]("+(&url.URL{Scheme: "file", Path: todoStore.FullPath(target)}).String()+")." {
+		t.Errorf("linkMentions() = %q, want aaa-111 linked to its file", body)
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/stats"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+var (
+	statsGroupBy string
+	statsSummary bool
+	statsJSON    bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Count issues by status, type, priority, or iteration",
+	Long: `Counts issues grouped by status (the default), type, or priority.
+
+--group-by iteration shows, for each iteration, how many issues were
+committed to it (everything assigned except scrapped issues) and how many of
+those are completed. Declared iterations are listed in config order, then
+any other assigned iterations.
+
+--summary shows the same overview as the TUI footer: counts by status, then
+how many open issues are blocked, stale (see stale_after), due within a week,
+or overdue.`,
+	Example: `  jig todo stats
+  jig todo stats --group-by iteration --json
+  jig todo stats --summary`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolver := &graph.Resolver{Core: todoStore}
+		issues, err := resolver.Query().Issues(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("querying issues: %w", err)
+		}
+
+		if statsSummary {
+			summary := stats.Summarize(issues, todoCfg, todoStore.Now(), todoStore.IsBlocked, todoStore.IsStale)
+			if statsJSON {
+				return writeStatsJSON(cmd.OutOrStdout(), summary)
+			}
+			writeSummaryStats(cmd.OutOrStdout(), summary)
+			return nil
+		}
+
+		var key func(*issue.Issue) string
+		var order []string
+		switch statsGroupBy {
+		case "status":
+			key, order = func(b *issue.Issue) string { return b.Status }, todoCfg.StatusNames()
+		case "type":
+			key, order = func(b *issue.Issue) string { return b.Type }, todoCfg.TypeNames()
+		case "priority":
+			key = func(b *issue.Issue) string { return cmp.Or(b.Priority, todoconfig.PriorityNormal) }
+			order = todoCfg.PriorityNames()
+		case "iteration":
+			current, _ := todoCfg.CurrentIteration(todoStore.Now())
+			rollup := stats.ByIteration(issues, todoCfg, current)
+			if statsJSON {
+				return writeStatsJSON(cmd.OutOrStdout(), rollup)
+			}
+			writeIterationStats(cmd.OutOrStdout(), rollup)
+			return nil
+		default:
+			return fmt.Errorf("invalid --group-by %q (must be status, type, priority, or iteration)", statsGroupBy)
+		}
+
+		counts := stats.Tally(issues, order, key)
+		if statsJSON {
+			return writeStatsJSON(cmd.OutOrStdout(), counts)
+		}
+		writeCountStats(cmd.OutOrStdout(), statsGroupBy, counts, len(issues))
+		return nil
+	},
+}
+
+func writeStatsJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeCountStats prints one line per value, then the total.
+func writeCountStats(w io.Writer, field string, counts []stats.Count, total int) {
+	width := len("total")
+	for _, c := range counts {
+		width = max(width, len(c.Value))
+	}
+	fmt.Fprintln(w, ui.Bold.Render(strings.ToUpper(field[:1])+field[1:]))
+	for _, c := range counts {
+		fmt.Fprintf(w, "  %-*s %5d\n", width, c.Value, c.Count)
+	}
+	fmt.Fprintf(w, "  %-*s %5d\n", width, "total", total)
+}
+
+// writeSummaryStats prints the status counts, then the open issues that need
+// attention.
+func writeSummaryStats(w io.Writer, s stats.Summary) {
+	writeCountStats(w, "status", s.Statuses, s.Total)
+	fmt.Fprintln(w, ui.Bold.Render("Open"))
+	for _, c := range []stats.Count{{Value: "blocked", Count: s.Blocked}, {Value: "stale", Count: s.Stale}, {Value: "due soon", Count: s.DueSoon}, {Value: "overdue", Count: s.Overdue}} {
+		fmt.Fprintf(w, "  %-8s %5d\n", c.Value, c.Count)
+	}
+}
+
+// writeIterationStats prints committed and completed counts per iteration,
+// marking the current one.
+func writeIterationStats(w io.Writer, rollup []stats.IterationCount) {
+	if len(rollup) == 0 {
+		fmt.Fprintln(w, ui.Muted.Render("No issues are assigned to an iteration"))
+		return
+	}
+	labels := make([]string, len(rollup))
+	width := len("Iteration")
+	for i, r := range rollup {
+		labels[i] = r.Iteration
+		if r.Current {
+			labels[i] += " (current)"
+		}
+		width = max(width, len(labels[i]))
+	}
+	fmt.Fprintln(w, ui.Bold.Render(fmt.Sprintf("%-*s  %9s  %9s", width, "Iteration", "Committed", "Completed")))
+	for i, r := range rollup {
+		fmt.Fprintf(w, "%-*s  %9d  %9d\n", width, labels[i], r.Committed, r.Completed)
+	}
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsGroupBy, "group-by", "status", "Group counts by status, type, priority, or iteration")
+	statsCmd.Flags().BoolVar(&statsSummary, "summary", false, "Show counts by status plus blocked, stale, due soon, and overdue open issues")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Output as JSON")
+	todoCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/stats"
+)
+
+func TestStatsSummaryCountsStale(t *testing.T) {
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	cfg := todoconfig.Default()
+	cfg.StaleAfter = "14d"
+	cfg.StaleStatuses = []string{"ready"}
+	testCore.SetConfig(cfg)
+	oldCfg := todoCfg
+	todoCfg = cfg
+	t.Cleanup(func() { todoCfg, statsSummary, statsJSON = oldCfg, false, false })
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	testCore.SetClock(func() time.Time { return now.AddDate(0, 0, -30) })
+	for _, b := range []*issue.Issue{
+		{ID: "sta-1", Title: "Old ready", Status: "ready", Type: "task"},
+		{ID: "sta-2", Title: "Old pinned", Status: "ready", Type: "task", Pinned: true},
+		{ID: "sta-3", Title: "Old done", Status: "completed", Type: "task"},
+	} {
+		if err := testCore.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testCore.SetClock(func() time.Time { return now })
+	if err := testCore.Create(&issue.Issue{ID: "sta-4", Title: "New ready", Status: "ready", Type: "task"}); err != nil {
+		t.Fatal(err)
+	}
+
+	statsSummary, statsJSON = true, true
+	var out bytes.Buffer
+	statsCmd.SetOut(&out)
+	t.Cleanup(func() { statsCmd.SetOut(nil) })
+	if err := statsCmd.RunE(statsCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	var got stats.Summary
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", out.String(), err)
+	}
+	if got.Stale != 1 {
+		t.Errorf("stale = %d, want 1 (sta-1; pinned and closed issues are not stale)", got.Stale)
+	}
+
+	statsJSON = false
+	out.Reset()
+	if err := statsCmd.RunE(statsCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "stale        1") {
+		t.Errorf("text output has no stale count:\n%s", out.String())
+	}
+}
@@ -5,11 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/toba/jig/internal/display"
 	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/ui"
 )
 
 var (
@@ -17,6 +24,9 @@ var (
 	syncForce           bool
 	syncNoRelationships bool
 	syncJSON            bool
+	syncResume          bool
+	syncInternal        bool
+	syncFailFast        bool
 )
 
 // syncConfigHint is the help text shown when no integration is configured.
@@ -42,7 +52,17 @@ var todoSyncCmd = &cobra.Command{
 	Long: `Syncs issues to an external integration configured in .jig.yaml.
 
 If issue IDs are provided, only those issues are synced. Otherwise, all issues
-matching the sync filter are synced.
+matching the sync filter are synced. Each provider section can set a filter;
+issues it leaves out are reported as skipped with the reason:
+
+  todo:
+    sync:
+      github:
+        repo: owner/repo
+        filter:
+          include_types: [bug, feature]
+          include_statuses: [ready, in-progress]
+          exclude_tags: [internal]
 
 Configuration goes in .jig.yaml under the todo key:
 
@@ -58,6 +78,22 @@ Or for ClickUp:
       clickup:
         list_id: "abc123"
 
+Internal issues (visibility: internal) are skipped unless --include-internal
+is given. GitHub refuses them even then unless its section sets
+allow_internal: true.
+
+Progress is checkpointed to .issues/.sync-state/<provider>.json as each issue
+finishes. If a run is interrupted (ctrl-C, network drop), run it again with
+--resume to skip the issues it already finished; without --resume any old
+checkpoint is discarded. The checkpoint is deleted once a run completes
+without errors.
+
+Issues are pushed several at a time (concurrency: N in the provider section,
+default 4), parents before their children; results are listed by issue ID.
+An error in one issue does not stop the others unless --fail-fast is given,
+which starts no new issues after the first error and leaves the rest for
+--resume.
+
 GitHub sync requires the gh CLI to be installed and authenticated.
 ClickUp sync requires a CLICKUP_TOKEN environment variable.`,
 	RunE: runSync,
@@ -68,11 +104,17 @@ func init() {
 	todoSyncCmd.Flags().BoolVar(&syncForce, "force", false, "Force update even if unchanged")
 	todoSyncCmd.Flags().BoolVar(&syncNoRelationships, "no-relationships", false, "Skip syncing blocking relationships as dependencies")
 	todoSyncCmd.Flags().BoolVar(&syncJSON, "json", false, "Output results as JSON")
+	todoSyncCmd.Flags().BoolVar(&syncInternal, "include-internal", false, includeInternalUsage)
+	todoSyncCmd.Flags().BoolVar(&syncResume, "resume", false, "Continue an interrupted run, skipping issues it already finished")
+	todoSyncCmd.Flags().BoolVar(&syncFailFast, "fail-fast", false, "Stop starting issues after the first error")
+	todoSyncCmd.MarkFlagsMutuallyExclusive("resume", "dry-run")
 	todoCmd.AddCommand(todoSyncCmd)
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	// Ctrl-C cancels the run; finished issues stay in the checkpoint.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
 	integ, err := integration.Detect(todoCfg.Sync, todoStore)
 	if err != nil {
@@ -96,13 +138,11 @@ func runSync(cmd *cobra.Command, args []string) error {
 		issueList = todoStore.All()
 	}
 	if len(args) > 0 {
-		for _, id := range args {
-			b, err := todoStore.Get(id)
-			if err != nil {
-				return fmt.Errorf("issue not found: %s", id)
-			}
-			issueList = append(issueList, b)
+		resolved, err := resolveIssueArgs(false, args)
+		if err != nil {
+			return err
 		}
+		issueList = resolved
 	}
 
 	if len(issueList) == 0 {
@@ -117,23 +157,32 @@ func runSync(cmd *cobra.Command, args []string) error {
 		DryRun:          syncDryRun,
 		Force:           syncForce,
 		NoRelationships: syncNoRelationships,
+		IncludeInternal: syncInternal,
+		FailFast:        syncFailFast,
 	}
 
-	if !syncJSON {
-		fmt.Printf("Syncing %d issues to %s", len(issueList), integ.Name())
-		if len(issueList) >= 5 {
-			fmt.Print(" ")
-			opts.OnProgress = func(result integration.SyncResult, completed, total int) {
-				if result.Error != nil {
-					fmt.Print("x")
-				} else {
-					fmt.Print(".")
-				}
-			}
+	// Dry runs change nothing, so they are never checkpointed.
+	var cp *integration.Checkpoint
+	pending := len(issueList)
+	if !syncDryRun {
+		cp, err = syncCheckpoint(integ.Name())
+		if err != nil {
+			return err
 		}
+		pending = len(cp.Pending(issueList))
 	}
 
-	results, err := integ.Sync(ctx, issueList, opts)
+	if !syncJSON {
+		fmt.Printf("Syncing %d issues to %s", pending, integ.Name())
+		opts.OnProgress = syncProgress(integ.Name(), pending)
+	}
+
+	var results []integration.SyncResult
+	if cp == nil {
+		results, err = integ.Sync(ctx, issueList, opts)
+	} else {
+		results, err = integration.SyncWithCheckpoint(ctx, integ, issueList, opts, cp)
+	}
 
 	if !syncJSON {
 		fmt.Println()
@@ -143,6 +192,21 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Interrupted: report what finished, then point at --resume.
+	if ctx.Err() != nil {
+		if cp == nil {
+			return ctx.Err()
+		}
+		if syncJSON {
+			if err := outputSyncJSON(results); err != nil {
+				return err
+			}
+		} else if err := outputSyncText(results); err != nil {
+			return err
+		}
+		return fmt.Errorf("sync interrupted after %d of %d issues; run 'jig todo sync --resume' to continue", len(cp.Completed), len(issueList))
+	}
+
 	if results == nil {
 		if syncJSON {
 			return outputSyncJSON(nil)
@@ -154,17 +218,80 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if syncJSON {
 		return outputSyncJSON(results)
 	}
-	return outputSyncText(results)
+	if err := outputSyncText(results); err != nil {
+		return err
+	}
+	if syncFailFast && cp != nil && slices.ContainsFunc(results, func(r integration.SyncResult) bool { return r.Error != nil }) {
+		fmt.Println("Stopped after the first error (--fail-fast); run 'jig todo sync --resume' to sync the rest")
+	}
+	return nil
+}
+
+// syncProgress returns the progress callback for text output: on a terminal,
+// a counter rewritten in place; otherwise a dot per issue (x for an error)
+// once there are enough issues for it to help. Issues finish concurrently,
+// so the counter only moves forward.
+func syncProgress(provider string, pending int) integration.ProgressFunc {
+	if isTerminal(os.Stdout) {
+		var mu sync.Mutex
+		var done, failed int
+		return func(result integration.SyncResult, completed, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			done = max(done, completed)
+			if result.Error != nil {
+				failed++
+			}
+			fmt.Printf("\rSyncing %d/%d issues to %s", done, total, provider)
+			if failed > 0 {
+				fmt.Printf(" (%d failed)", failed)
+			}
+		}
+	}
+	if pending < 5 {
+		return nil
+	}
+	fmt.Print(" ")
+	return func(result integration.SyncResult, completed, total int) {
+		if result.Error != nil {
+			fmt.Print("x")
+		} else {
+			fmt.Print(".")
+		}
+	}
+}
+
+// syncCheckpoint returns the checkpoint for this run: the saved one when
+// resuming, or a fresh one otherwise.
+func syncCheckpoint(provider string) (*integration.Checkpoint, error) {
+	if syncResume {
+		cp, err := integration.LoadCheckpoint(todoStore.Root(), provider)
+		if err != nil {
+			return nil, err
+		}
+		if cp != nil {
+			if !syncJSON {
+				fmt.Printf("Resuming: %d issues already synced\n", len(cp.Completed))
+			}
+			return cp, nil
+		}
+	}
+	return integration.NewCheckpoint(todoStore.Root(), provider, todoStore.Now()), nil
 }
 
 func outputSyncJSON(results []integration.SyncResult) error {
 	type jsonResult struct {
-		IssueID     string `json:"issue_id"`
-		IssueTitle  string `json:"issue_title"`
-		ExternalID  string `json:"external_id,omitempty"`
-		ExternalURL string `json:"external_url,omitempty"`
-		Action      string `json:"action"`
-		Error       string `json:"error,omitempty"`
+		IssueID       string                    `json:"issue_id"`
+		IssueTitle    string                    `json:"issue_title"`
+		ExternalID    string                    `json:"external_id,omitempty"`
+		ExternalURL   string                    `json:"external_url,omitempty"`
+		Action        string                    `json:"action"`
+		Error         string                    `json:"error,omitempty"`
+		Warnings      []string                  `json:"warnings,omitempty"`
+		Reason        string                    `json:"reason,omitempty"`
+		Changes       []integration.FieldChange `json:"changes,omitempty"`
+		LabelsAdded   []string                  `json:"labels_added,omitempty"`
+		LabelsRemoved []string                  `json:"labels_removed,omitempty"`
 	}
 
 	if results == nil {
@@ -175,11 +302,16 @@ func outputSyncJSON(results []integration.SyncResult) error {
 	jsonResults := make([]jsonResult, len(results))
 	for i, r := range results {
 		jsonResults[i] = jsonResult{
-			IssueID:     r.IssueID,
-			IssueTitle:  r.IssueTitle,
-			ExternalID:  r.ExternalID,
-			ExternalURL: r.ExternalURL,
-			Action:      r.Action,
+			IssueID:       r.IssueID,
+			IssueTitle:    r.IssueTitle,
+			ExternalID:    r.ExternalID,
+			ExternalURL:   r.ExternalURL,
+			Action:        r.Action,
+			Warnings:      r.Warnings,
+			Reason:        r.Reason,
+			Changes:       r.Changes,
+			LabelsAdded:   r.LabelsAdded,
+			LabelsRemoved: r.LabelsRemoved,
 		}
 		if r.Error != nil {
 			jsonResults[i].Error = r.Error.Error()
@@ -198,25 +330,62 @@ func outputSyncText(results []integration.SyncResult) error {
 		switch r.Action {
 		case integration.ActionCreated:
 			created++
-			fmt.Printf("  Created: %s \u2192 %s \"%s\"\n", r.IssueID, r.ExternalURL, display.Truncate(r.IssueTitle, 20))
+			fmt.Printf("  Created: %s %s %s \"%s\"\n", r.IssueID, ui.Glyph(ui.ArrowSymbol), r.ExternalURL, display.Truncate(r.IssueTitle, 20))
 		case integration.ActionUpdated:
 			updated++
-			fmt.Printf("  Updated: %s \u2192 %s \"%s\"\n", r.IssueID, r.ExternalURL, display.Truncate(r.IssueTitle, 20))
+			fmt.Printf("  Updated: %s %s %s \"%s\"\n", r.IssueID, ui.Glyph(ui.ArrowSymbol), r.ExternalURL, display.Truncate(r.IssueTitle, 20))
 		case integration.ActionUnchanged:
 			unchanged++
 		case integration.ActionSkipped:
 			skipped++
+			if r.Reason != "" {
+				fmt.Printf("  Skipped: %s - %s\n", r.IssueID, r.Reason)
+			}
 		case integration.ActionWouldCreate:
 			fmt.Printf("  Would create: %s - %s\n", r.IssueID, r.IssueTitle)
 		case integration.ActionWouldUpdate:
 			fmt.Printf("  Would update: %s - %s\n", r.IssueID, r.IssueTitle)
+			for _, c := range r.Changes {
+				if c.Field == syncutil.FieldLabels && labelOps(r) != "" {
+					continue // shown as label operations below
+				}
+				fmt.Printf("      %s: %s %s %s\n", c.Field, displayChangeValue(c.Remote), ui.Glyph(ui.ArrowSymbol), displayChangeValue(c.Local))
+			}
 		case integration.ActionError:
 			errors++
 			fmt.Printf("  Error: %s - %v\n", r.IssueID, r.Error)
 		}
+		if ops := labelOps(r); ops != "" && r.Action != integration.ActionError {
+			fmt.Printf("      labels: %s\n", ops)
+		}
+		for _, w := range r.Warnings {
+			fmt.Printf("  Warning: %s - %s\n", r.IssueID, w)
+		}
 	}
 
 	fmt.Printf("\nSummary: %d created, %d updated, %d unchanged, %d skipped, %d errors\n",
 		created, updated, unchanged, skipped, errors)
 	return nil
 }
+
+// labelOps renders the labels a sync added and removed as "+a +b -c", or ""
+// when there were none.
+func labelOps(r integration.SyncResult) string {
+	ops := make([]string, 0, len(r.LabelsAdded)+len(r.LabelsRemoved))
+	for _, l := range r.LabelsAdded {
+		ops = append(ops, "+"+l)
+	}
+	for _, l := range r.LabelsRemoved {
+		ops = append(ops, "-"+l)
+	}
+	return strings.Join(ops, " ")
+}
+
+// displayChangeValue renders one side of a dry-run field change, quoting it so
+// empty values and whitespace stay visible.
+func displayChangeValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return strconv.Quote(display.Truncate(v, 60))
+}
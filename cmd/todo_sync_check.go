@@ -66,7 +66,7 @@ Use --skip-api to perform offline validation only.`,
 		printCheckReport(report)
 
 		if report.Summary.Failed > 0 {
-			return fmt.Errorf("%d check(s) failed", report.Summary.Failed)
+			return &integration.ProviderError{Provider: integ.Name(), Err: fmt.Errorf("%d check(s) failed", report.Summary.Failed)}
 		}
 
 		return nil
@@ -85,17 +85,20 @@ func printCheckReport(report *integration.CheckReport) {
 		for _, check := range section.Checks {
 			switch check.Status {
 			case integration.CheckPass:
-				fmt.Print(ui.Success.Render("  \u2713 "))
+				fmt.Print(ui.Success.Render("  " + ui.Glyph(ui.PassSymbol) + " "))
 			case integration.CheckWarn:
-				fmt.Print(ui.Warning.Render("  \u26a0 "))
+				fmt.Print(ui.Warning.Render("  " + ui.Glyph(ui.WarnSymbol) + " "))
 			case integration.CheckFail:
-				fmt.Print(ui.Danger.Render("  \u2717 "))
+				fmt.Print(ui.Danger.Render("  " + ui.Glyph(ui.FailSymbol) + " "))
 			}
 
 			fmt.Print(check.Name)
 			if check.Message != "" {
 				fmt.Print(ui.Muted.Render(fmt.Sprintf(" (%s)", check.Message)))
 			}
+			if check.DurationMS > 0 {
+				fmt.Print(ui.Muted.Render(fmt.Sprintf(" %dms", check.DurationMS)))
+			}
 			fmt.Println()
 		}
 		fmt.Println()
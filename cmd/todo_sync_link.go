@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 
@@ -11,14 +10,28 @@ import (
 	"github.com/toba/jig/internal/todo/integration"
 )
 
-var syncLinkJSON bool
+var (
+	syncLinkJSON       bool
+	syncLinkAllowExtra bool
+)
 
 var syncLinkCmd = &cobra.Command{
 	Use:   "link <issue-id> <external-id>",
 	Short: "Link an issue to an existing external task",
-	Args:  cobra.ExactArgs(2),
+	Long: `Links an issue to an existing external task by recording its ID in the
+issue's sync data.
+
+The sync data the link would leave is first checked against the provider's
+schema: the external ID must have the right form (a GitHub issue number is
+numeric), and keys the provider does not use are rejected, since they are
+usually typos such as task_Id. Pass --allow-extra to keep unknown keys.`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		issueID := args[0]
+		resolved, err := resolveIssueArg(args[0])
+		if err != nil {
+			return err
+		}
+		issueID := resolved.ID
 		externalID := args[1]
 		ctx := context.Background()
 
@@ -27,7 +40,10 @@ var syncLinkCmd = &cobra.Command{
 			return fmt.Errorf("detecting integration: %w", err)
 		}
 		if integ == nil {
-			return errors.New("no integration configured")
+			return integration.ErrNotConfigured
+		}
+		if err := integration.ValidateLink(integ.Name(), resolved, externalID, syncLinkAllowExtra); err != nil {
+			return err
 		}
 
 		result, err := integ.Link(ctx, issueID, externalID)
@@ -41,25 +57,38 @@ var syncLinkCmd = &cobra.Command{
 			title = b.Title
 		}
 
-		if syncLinkJSON {
-			return outputLinkJSON(issueID, title, externalID, result.Action)
-		}
-
-		switch result.Action {
-		case integration.ActionAlreadyLinked:
-			fmt.Printf("Skipped: %s already linked to %s\n", issueID, externalID)
-		case integration.ActionLinked:
-			fmt.Printf("Linked: %s → %s\n", issueID, externalID)
-		}
-		return nil
+		return resolveOutputMode(syncLinkJSON).write(commandResult{
+			JSON:  func() error { return outputLinkJSON(issueID, title, externalID, result.Action) },
+			Quiet: linkedID(issueID, result.Action == integration.ActionLinked),
+			Text: func() error {
+				switch result.Action {
+				case integration.ActionAlreadyLinked:
+					fmt.Printf("Skipped: %s already linked to %s\n", issueID, externalID)
+				case integration.ActionLinked:
+					fmt.Printf("Linked: %s → %s\n", issueID, externalID)
+				}
+				return nil
+			},
+		})
 	},
 }
 
 func init() {
 	syncLinkCmd.Flags().BoolVar(&syncLinkJSON, "json", false, "Output as JSON")
+	addQuietFlag(syncLinkCmd)
+	syncLinkCmd.Flags().BoolVar(&syncLinkAllowExtra, "allow-extra", false, "Accept sync data keys the provider does not know")
 	todoSyncCmd.AddCommand(syncLinkCmd)
 }
 
+// linkedID returns the --quiet IDs of a link or unlink: issueID if it
+// changed anything, none if it was skipped.
+func linkedID(issueID string, changed bool) []string {
+	if !changed {
+		return nil
+	}
+	return []string{issueID}
+}
+
 func outputLinkJSON(issueID, issueTitle, externalID, action string) error {
 	result := map[string]string{
 		"issue_id":    issueID,
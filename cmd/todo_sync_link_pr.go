@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+var syncLinkPRJSON bool
+
+var syncLinkPRCmd = &cobra.Command{
+	Use:   "link-pr <issue-id> <pr-number>",
+	Short: "Link an issue to a GitHub pull request",
+	Long: `Records a GitHub pull request against an issue in its sync data
+(github.prs). When sync.github is configured and GITHUB_TOKEN is set, the
+pull request's state is fetched right away; otherwise the next sync fills
+it in.
+
+Sync also links pull requests automatically when their branch name or
+body references the issue ID or its GitHub issue number (disable with
+sync.github.detect_prs: false).`,
+	Example: `  jig todo sync link-pr abc-def 123
+  jig todo sync link-pr abc-def '#123'`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolved, err := resolveIssueArg(args[0])
+		if err != nil {
+			return err
+		}
+		issueID := resolved.ID
+		number, err := strconv.Atoi(strings.TrimPrefix(args[1], "#"))
+		if err != nil {
+			return cmdError(false, output.ErrValidation, "invalid pull request number: %s", args[1])
+		}
+
+		result, err := integration.LinkPullRequest(context.Background(), todoCfg.Sync, todoStore, issueID, number)
+		if err != nil {
+			return err
+		}
+
+		return resolveOutputMode(syncLinkPRJSON).write(commandResult{
+			JSON:  func() error { return outputLinkJSON(issueID, resolved.Title, result.ExternalID, result.Action) },
+			Quiet: linkedID(issueID, result.Action == integration.ActionLinked),
+			Text: func() error {
+				switch result.Action {
+				case integration.ActionAlreadyLinked:
+					fmt.Printf("Skipped: %s already linked to pull request %s\n", issueID, result.ExternalID)
+				case integration.ActionLinked:
+					fmt.Printf("Linked: %s → pull request %s\n", issueID, result.ExternalID)
+				}
+				return nil
+			},
+		})
+	},
+}
+
+func init() {
+	syncLinkPRCmd.Flags().BoolVar(&syncLinkPRJSON, "json", false, "Output as JSON")
+	addQuietFlag(syncLinkPRCmd)
+	todoSyncCmd.AddCommand(syncLinkPRCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/integration"
+)
+
+const syncSmokeTestTimeout = 2 * time.Minute
+
+var syncSmokeTestJSON bool
+
+var syncSmokeTestCmd = &cobra.Command{
+	Use:   "smoke-test [provider]",
+	Short: "Check credentials and permissions end to end against a sandbox",
+	Long: `Runs a non-destructive end-to-end check against the provider's live API:
+authenticates, reads the sandbox, then creates, updates, and deletes a
+temporary item there, reporting each step with its timing.
+
+The sandbox is a scratch location sync never touches, named in .jig.yaml:
+sync.clickup.sandbox (a list ID) or sync.github.sandbox (owner/repo). The
+command refuses to run without one. GitHub only lets repository admins
+delete issues; without that permission the temporary issue is closed
+instead and the step is reported as a warning.
+
+With several providers configured, name the one to test.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), syncSmokeTestTimeout)
+		defer cancel()
+
+		var integ integration.Integration
+		var err error
+		if len(args) == 1 {
+			integ, err = integration.DetectProvider(todoCfg.Sync, args[0], todoStore)
+		} else {
+			integ, err = integration.Detect(todoCfg.Sync, todoStore)
+		}
+		if err != nil {
+			return fmt.Errorf("detecting integration: %w", err)
+		}
+		if integ == nil {
+			return integration.ErrNotConfigured
+		}
+
+		sandbox, err := integ.Sandbox()
+		if err != nil {
+			return err
+		}
+		report := integration.SmokeTest(ctx, integ.Name(), sandbox)
+
+		if syncSmokeTestJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(report); err != nil {
+				return err
+			}
+		} else {
+			printCheckReport(report)
+		}
+
+		if report.Summary.Failed > 0 {
+			return &integration.ProviderError{Provider: integ.Name(), Err: fmt.Errorf("%d smoke test step(s) failed", report.Summary.Failed)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	syncSmokeTestCmd.Flags().BoolVar(&syncSmokeTestJSON, "json", false, "Output as JSON")
+	todoSyncCmd.AddCommand(syncSmokeTestCmd)
+}
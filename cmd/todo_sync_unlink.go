@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 
@@ -18,7 +17,11 @@ var syncUnlinkCmd = &cobra.Command{
 	Short: "Remove the link between an issue and its external task",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		issueID := args[0]
+		resolved, err := resolveIssueArg(args[0])
+		if err != nil {
+			return err
+		}
+		issueID := resolved.ID
 		ctx := context.Background()
 
 		integ, err := integration.Detect(todoCfg.Sync, todoStore)
@@ -26,7 +29,7 @@ var syncUnlinkCmd = &cobra.Command{
 			return fmt.Errorf("detecting integration: %w", err)
 		}
 		if integ == nil {
-			return errors.New("no integration configured")
+			return integration.ErrNotConfigured
 		}
 
 		result, err := integ.Unlink(ctx, issueID)
@@ -40,22 +43,25 @@ var syncUnlinkCmd = &cobra.Command{
 			title = b.Title
 		}
 
-		if syncUnlinkJSON {
-			return outputUnlinkJSON(issueID, title, result.ExternalID, result.Action)
-		}
-
-		switch result.Action {
-		case integration.ActionNotLinked:
-			fmt.Printf("Skipped: %s is not linked to an external task\n", issueID)
-		case integration.ActionUnlinked:
-			fmt.Printf("Unlinked: %s (was %s)\n", issueID, result.ExternalID)
-		}
-		return nil
+		return resolveOutputMode(syncUnlinkJSON).write(commandResult{
+			JSON:  func() error { return outputUnlinkJSON(issueID, title, result.ExternalID, result.Action) },
+			Quiet: linkedID(issueID, result.Action == integration.ActionUnlinked),
+			Text: func() error {
+				switch result.Action {
+				case integration.ActionNotLinked:
+					fmt.Printf("Skipped: %s is not linked to an external task\n", issueID)
+				case integration.ActionUnlinked:
+					fmt.Printf("Unlinked: %s (was %s)\n", issueID, result.ExternalID)
+				}
+				return nil
+			},
+		})
 	},
 }
 
 func init() {
 	syncUnlinkCmd.Flags().BoolVar(&syncUnlinkJSON, "json", false, "Output as JSON")
+	addQuietFlag(syncUnlinkCmd)
 	todoSyncCmd.AddCommand(syncUnlinkCmd)
 }
 
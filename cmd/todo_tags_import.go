@@ -21,7 +21,7 @@ var tagsImportCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load config (we skip initTodoCore for "import")
 		var err error
-		todoCfg, err = loadConfigWithFallback(configPath())
+		todoCfg, _, err = resolveTodoConfig()
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/tui"
+)
+
+var triageStatuses []string
+
+var todoTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Open the TUI in a triage session",
+	Long: `Opens the TUI on a triage session: the issues in triage_statuses (default
+draft), most urgent first, one at a time with the full body shown.
+
+Each key decides and moves on to the next issue:
+
+  1-4    set priority (critical, high, normal, low)
+  s      move to the next status
+  t      add a tag
+  d      set a due date (3d, 2w, fri, tomorrow, or YYYY-MM-DD)
+  x      scrap
+  space  skip
+  u      undo the last decision
+
+Every decision is saved as it is made. esc ends the session with a summary
+of what changed; g r starts one from the list too.`,
+	Example: `  jig todo triage
+  jig todo triage --status draft,ready`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, s := range triageStatuses {
+			if !todoCfg.IsValidStatus(s) {
+				return cmdError(false, output.ErrInvalidStatus, "invalid status %q", s)
+			}
+		}
+		return tui.RunTriage(todoStore, todoCfg, triageStatuses)
+	},
+}
+
+func init() {
+	todoTriageCmd.Flags().StringSliceVar(&triageStatuses, "status", nil, "Triage issues in these statuses instead of triage_statuses (comma-separated or repeated)")
+	todoCmd.AddCommand(todoTriageCmd)
+}
@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/toba/jig/internal/todo/tui"
 )
@@ -8,12 +14,48 @@ import (
 var todoTuiCmd = &cobra.Command{
 	Use:   "tui",
 	Short: "Open the interactive TUI",
-	Long:  `Opens an interactive terminal user interface for browsing and managing issues.`,
+	Long: `Opens an interactive terminal user interface for browsing and managing issues.
+
+When auto_archive.on_start is set in .jig.yaml, you are first asked whether to
+archive the issues the auto_archive policy matches.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.Run(todoStore, todoCfg)
+		return runTUI()
 	},
 }
 
+// runTUI offers the auto_archive policy if it is configured to run on start,
+// then opens the TUI.
+func runTUI() error {
+	if todoCfg.AutoArchive.OnStart {
+		offerAutoArchive(os.Stdin, os.Stdout)
+	}
+	return tui.Run(todoStore, todoCfg)
+}
+
+// offerAutoArchive asks before archiving the policy's candidates. Failures
+// are reported but never keep the TUI from opening.
+func offerAutoArchive(in io.Reader, out io.Writer) {
+	candidates := todoStore.AutoArchiveCandidates()
+	if len(candidates) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "Archive %d closed issue(s) unchanged for more than %s? [y/N] ", len(candidates), todoCfg.AutoArchive.After)
+	response, _ := bufio.NewReader(in).ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		return
+	}
+	archived := 0
+	for _, b := range candidates {
+		if err := todoStore.Archive(b.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to archive issue %s: %v\n", b.ID, err)
+			continue
+		}
+		archived++
+	}
+	fmt.Fprintf(out, "Archived %d issue(s) to .issues/archive/\n", archived)
+}
+
 func init() {
 	todoCmd.AddCommand(todoTuiCmd)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -11,6 +12,7 @@ import (
 	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/output"
 	"github.com/toba/jig/internal/todo/ui"
 )
@@ -20,7 +22,10 @@ var (
 	updateType            string
 	updatePriority        string
 	updateMilestone       string
+	updateIteration       string
+	updateEstimate        string
 	updateTitle           string
+	updateSummary         string
 	updateBody            string
 	updateBodyFile        string
 	updateReplaceBody     string
@@ -31,7 +36,19 @@ var (
 	updateBodyAppend      string
 	updateBodyCheck       []string
 	updateBodyUncheck     []string
+	updateSection         string
+	updateSectionContent  string
+	updateSectionFile     string
+	updateSectionAppend   bool
+	updateSectionCreate   bool
 	updateDue             string
+	updateDueTime         string
+	updateEncrypted       bool
+	updatePin             bool
+	updateVisibility      string
+	updateReleaseTitle    string
+	updateReleaseNote     string
+	updateUnpin           bool
 	updateParent          string
 	updateRemoveParent    bool
 	updateBlocking        []string
@@ -41,6 +58,9 @@ var (
 	updateTag             []string
 	updateRemoveTag       []string
 	updateIfMatch         string
+	updateRetry           bool
+	updateUnarchive       bool
+	updateForce           bool
 	todoUpdateJSON        bool
 )
 
@@ -48,28 +68,34 @@ var todoUpdateCmd = &cobra.Command{
 	Use:     "update <id>",
 	Aliases: []string{"u"},
 	Short:   "Update an issue's properties",
-	Long:    `Updates one or more properties of an existing issue.`,
-	Args:    cobra.ExactArgs(1),
+	Long: `Updates one or more properties of an existing issue.
+
+--if-match only updates the issue if its etag still matches. With
+--retry-on-conflict, an etag mismatch is merged field by field instead: if
+the concurrent change touched other fields than this update, the update is
+applied again on top of it (up to 3 times); if both changed the same field,
+the command fails and lists each conflicting field with both values. A body
+edit conflicts with any concurrent body change unless it only appends. The
+version --if-match names is read from the file or, once it has changed, from
+git history. Without --if-match, the etag of the issue as read is used.
+
+A --replace-body that drops unchecked tasks ("- [ ] ...") warns, listing
+them; tasks that are checked off, moved, or reworded do not count. With
+protect_unchecked_tasks: strict in the config the update is refused unless
+--force acknowledges the removal.
+
+An archived issue is read-only: updating it fails with a conflict unless
+--unarchive moves it back out of the archive first. --unarchive on its own
+just unarchives the issue.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{porcelainAnnotation: "id\tetag"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx, warnings := graph.WithWarnings(context.Background())
 		resolver := &graph.Resolver{Core: todoStore}
 
-		b, err := resolver.Query().Issue(ctx, args[0])
+		b, err := resolveIssueArg(args[0])
 		if err != nil {
-			return cmdError(todoUpdateJSON, output.ErrNotFound, "failed to find issue: %v", err)
-		}
-
-		wasArchived := false
-		if b == nil {
-			unarchived, unarchiveErr := todoStore.LoadAndUnarchive(args[0])
-			if unarchiveErr != nil {
-				return cmdError(todoUpdateJSON, output.ErrNotFound, "issue not found: %s", args[0])
-			}
-			b, err = resolver.Query().Issue(ctx, unarchived.ID)
-			if err != nil || b == nil {
-				return cmdError(todoUpdateJSON, output.ErrNotFound, "issue not found: %s", args[0])
-			}
-			wasArchived = true
+			return cmdError(todoUpdateJSON, resolveErrorCode(err), "%w", err)
 		}
 
 		var changes []string
@@ -81,16 +107,37 @@ var todoUpdateCmd = &cobra.Command{
 
 		input, fieldChanges, err := buildUpdateInput(cmd, b.Tags, b.Body)
 		if err != nil {
-			return cmdError(todoUpdateJSON, output.ErrValidation, "%s", err)
+			return cmdError(todoUpdateJSON, output.ErrValidation, "%w", err)
 		}
 		changes = append(changes, fieldChanges...)
 
+		// Archived issues are read-only; only an explicit --unarchive
+		// brings one back, before any other change is applied.
+		wasArchived := false
+		if todoStore.IsArchived(b.ID) {
+			if !updateUnarchive {
+				return mutationError(todoUpdateJSON, &core.ArchivedError{ID: b.ID})
+			}
+			if b, err = todoStore.LoadAndUnarchive(b.ID); err != nil {
+				return cmdError(todoUpdateJSON, output.ErrFileError, "failed to unarchive %s: %w", args[0], err)
+			}
+			wasArchived = true
+			changes = append(changes, "unarchived")
+		}
+
 		if ifMatch != nil {
 			input.IfMatch = ifMatch
 		}
 
 		if hasFieldUpdates(input) {
-			b, err = resolver.Mutation().UpdateIssue(ctx, b.ID, input)
+			if updateRetry {
+				b, err = updateWithRetry(ctx, resolver, b, input, patchedFields(fieldChanges))
+			} else {
+				b, err = resolver.Mutation().UpdateIssue(ctx, b.ID, input)
+			}
+			if conflict, ok := errors.AsType[*updateConflictError](err); ok {
+				return conflictReport(todoUpdateJSON, conflict)
+			}
 			if err != nil {
 				return mutationError(todoUpdateJSON, err)
 			}
@@ -98,23 +145,36 @@ var todoUpdateCmd = &cobra.Command{
 
 		if len(changes) == 0 {
 			return cmdError(todoUpdateJSON, output.ErrValidation,
-				"no changes specified (use --status, --type, --priority, --title, --due, --append-body, --body-replace-old/--body-replace-new, --replace-body, --parent, --blocking, --blocked-by, --tag, or their --remove-* variants)")
+				"no changes specified (use --status, --type, --priority, --title, --summary, --due, --append-body, --body-replace-old/--body-replace-new, --section, --replace-body, --parent, --blocking, --blocked-by, --tag, --unarchive, or their --remove-* variants)")
 		}
 
-		if todoUpdateJSON {
-			msg := "Issue updated"
-			if wasArchived {
-				msg = "Issue unarchived and updated"
-			}
-			return output.Success(b, msg)
+		msg, verb := "Issue updated", "Updated "
+		switch {
+		case wasArchived && hasFieldUpdates(input):
+			msg, verb = "Issue unarchived and updated", "Unarchived and updated "
+		case wasArchived:
+			msg, verb = "Issue unarchived", "Unarchived "
 		}
-
-		if wasArchived {
-			fmt.Println(ui.Success.Render("Unarchived and updated ") + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
-		} else {
-			fmt.Println(ui.Success.Render("Updated ") + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
+		mode := resolveOutputMode(todoUpdateJSON)
+		if mode != outputJSON {
+			for _, w := range warnings() {
+				fmt.Fprintln(os.Stderr, ui.Warning.Render("warning: ")+w)
+			}
 		}
-		return nil
+		return mode.write(commandResult{
+			JSON: func() error {
+				if w := warnings(); len(w) > 0 {
+					return output.SuccessWithWarnings(b, msg, w)
+				}
+				return output.Success(b, msg)
+			},
+			Porcelain: func() error { return writePorcelain(os.Stdout, b.ID, b.ETag()) },
+			Quiet:     []string{b.ID},
+			Text: func() error {
+				fmt.Println(ui.Success.Render(verb) + ui.ID.Render(b.ID) + " " + ui.Muted.Render(b.Path))
+				return nil
+			},
+		})
 	},
 }
 
@@ -123,8 +183,8 @@ func buildUpdateInput(cmd *cobra.Command, _ []string, _ string) (model.UpdateIss
 	var changes []string
 
 	if cmd.Flags().Changed("status") {
-		if !todoCfg.IsValidStatus(updateStatus) {
-			return input, nil, fmt.Errorf("invalid status: %s (must be %s)", updateStatus, todoCfg.StatusList())
+		if err := todoCfg.ValidateStatus(updateStatus); err != nil {
+			return input, nil, err
 		}
 		if !todoCfg.IsStatusEnabled(updateStatus) {
 			return input, nil, fmt.Errorf("status %q is disabled in this project (enabled: %s)", updateStatus, todoCfg.EnabledStatusList())
@@ -134,16 +194,16 @@ func buildUpdateInput(cmd *cobra.Command, _ []string, _ string) (model.UpdateIss
 	}
 
 	if cmd.Flags().Changed("type") {
-		if !todoCfg.IsValidType(updateType) {
-			return input, nil, fmt.Errorf("invalid type: %s (must be %s)", updateType, todoCfg.TypeList())
+		if err := todoCfg.ValidateType(updateType); err != nil {
+			return input, nil, err
 		}
 		input.Type = &updateType
 		changes = append(changes, "type")
 	}
 
 	if cmd.Flags().Changed("priority") {
-		if !todoCfg.IsValidPriority(updatePriority) {
-			return input, nil, fmt.Errorf("invalid priority: %s (must be %s)", updatePriority, todoCfg.PriorityList())
+		if err := todoCfg.ValidatePriority(updatePriority); err != nil {
+			return input, nil, err
 		}
 		input.Priority = &updatePriority
 		changes = append(changes, "priority")
@@ -154,16 +214,79 @@ func buildUpdateInput(cmd *cobra.Command, _ []string, _ string) (model.UpdateIss
 		changes = append(changes, "milestone")
 	}
 
+	if cmd.Flags().Changed("iteration") {
+		iteration, err := todoCfg.ResolveIteration(updateIteration, todoStore.Now())
+		if err != nil {
+			return input, nil, err
+		}
+		input.Iteration = &iteration
+		changes = append(changes, "iteration")
+	}
+
+	if cmd.Flags().Changed("estimate") {
+		if err := todoCfg.ValidateEstimate(updateEstimate); err != nil {
+			return input, nil, err
+		}
+		input.Estimate = &updateEstimate
+		changes = append(changes, "estimate")
+	}
+
 	if cmd.Flags().Changed("title") {
 		input.Title = &updateTitle
 		changes = append(changes, "title")
 	}
 
-	if cmd.Flags().Changed("due") {
-		input.Due = &updateDue
+	if cmd.Flags().Changed("summary") {
+		summary := strings.TrimSpace(updateSummary)
+		if err := issue.ValidateSummary(summary); err != nil {
+			return input, nil, err
+		}
+		input.Summary = &summary
+		changes = append(changes, "summary")
+	}
+
+	if cmd.Flags().Changed("due") || cmd.Flags().Changed("due-time") {
+		due, err := combineDueTime(updateDue, updateDueTime)
+		if err != nil {
+			return input, nil, err
+		}
+		input.Due = &due
 		changes = append(changes, "due")
 	}
 
+	if cmd.Flags().Changed("encrypted") {
+		input.Encrypted = &updateEncrypted
+		changes = append(changes, "encrypted")
+	}
+
+	if updatePin && updateUnpin {
+		return input, nil, errors.New("--pin and --unpin are mutually exclusive")
+	}
+	if updatePin || updateUnpin {
+		pinned := updatePin
+		input.Pinned = &pinned
+		changes = append(changes, "pinned")
+	}
+
+	if cmd.Flags().Changed("visibility") {
+		if err := todoconfig.ValidateVisibility(updateVisibility); err != nil {
+			return input, nil, err
+		}
+		input.Visibility = &updateVisibility
+		changes = append(changes, "visibility")
+	}
+
+	if cmd.Flags().Changed("release-title") {
+		releaseTitle := strings.TrimSpace(updateReleaseTitle)
+		input.ReleaseTitle = &releaseTitle
+		changes = append(changes, "release-title")
+	}
+	if cmd.Flags().Changed("release-note") {
+		releaseNote := strings.TrimSpace(updateReleaseNote)
+		input.ReleaseNote = &releaseNote
+		changes = append(changes, "release-note")
+	}
+
 	// The legacy --body/--body-file flags silently replaced the entire body, which
 	// repeatedly caused accidental loss of existing content. They are retired on
 	// update in favor of the explicit --replace-body/--append-body verbs.
@@ -174,6 +297,10 @@ func buildUpdateInput(cmd *cobra.Command, _ []string, _ string) (model.UpdateIss
 				"or --replace-body/--replace-body-file to intentionally overwrite the whole body")
 	}
 
+	if !cmd.Flags().Changed("section") && (cmd.Flags().Changed("section-content") || cmd.Flags().Changed("section-content-file")) {
+		return input, nil, errors.New("--section-content/--section-content-file require --section")
+	}
+
 	appendChanged := cmd.Flags().Changed("append-body") || cmd.Flags().Changed("body-append")
 
 	if cmd.Flags().Changed("replace-body") || cmd.Flags().Changed("replace-body-file") {
@@ -182,10 +309,35 @@ func buildUpdateInput(cmd *cobra.Command, _ []string, _ string) (model.UpdateIss
 			return input, nil, err
 		}
 		input.Body = &body
+		if updateForce {
+			input.Force = &updateForce
+		}
 		changes = append(changes, "body")
-	} else if cmd.Flags().Changed("body-replace-old") || appendChanged || len(updateBodyCheck) > 0 || len(updateBodyUncheck) > 0 {
+	} else if cmd.Flags().Changed("force") {
+		return input, nil, errors.New("--force only applies to --replace-body/--replace-body-file")
+	} else if cmd.Flags().Changed("body-replace-old") || appendChanged || len(updateBodyCheck) > 0 || len(updateBodyUncheck) > 0 || cmd.Flags().Changed("section") {
 		bodyMod := &model.BodyModification{}
 
+		if cmd.Flags().Changed("section") {
+			if !cmd.Flags().Changed("section-content") && !cmd.Flags().Changed("section-content-file") {
+				return input, nil, errors.New("--section requires --section-content or --section-content-file")
+			}
+			content, err := resolveContent(updateSectionContent, updateSectionFile)
+			if err != nil {
+				return input, nil, err
+			}
+			edit := &model.SectionEdit{
+				Title:           updateSection,
+				Content:         strings.TrimRight(content, "\n"),
+				CreateIfMissing: &updateSectionCreate,
+			}
+			if updateSectionAppend {
+				bodyMod.AppendToSection = edit
+			} else {
+				bodyMod.SetSection = edit
+			}
+		}
+
 		if cmd.Flags().Changed("body-replace-old") {
 			bodyMod.Replace = []*model.ReplaceOperation{
 				{
@@ -259,7 +411,7 @@ func buildUpdateInput(cmd *cobra.Command, _ []string, _ string) (model.UpdateIss
 
 func hasFieldUpdates(input model.UpdateIssueInput) bool {
 	return input.Status != nil || input.Type != nil || input.Priority != nil || input.Milestone != nil ||
-		input.Title != nil || input.Due != nil || input.Body != nil || input.BodyMod != nil || input.Tags != nil ||
+		input.Title != nil || input.Summary != nil || input.Due != nil || input.Encrypted != nil || input.Pinned != nil || input.Visibility != nil || input.ReleaseTitle != nil || input.ReleaseNote != nil || input.Body != nil || input.BodyMod != nil || input.Tags != nil ||
 		input.AddTags != nil || input.RemoveTags != nil ||
 		input.Parent != nil || input.AddBlocking != nil || input.RemoveBlocking != nil ||
 		input.AddBlockedBy != nil || input.RemoveBlockedBy != nil
@@ -272,10 +424,22 @@ func isConflictError(err error) bool {
 }
 
 func mutationError(jsonOutput bool, err error) error {
+	if _, ok := errors.AsType[*core.ETagRequiredError](err); ok {
+		return cmdError(jsonOutput, output.ErrConflict, "%w; get the etag with 'jig todo show <id> --etag-only' and pass it as --if-match", err)
+	}
+	if archived, ok := errors.AsType[*core.ArchivedError](err); ok {
+		return cmdError(jsonOutput, output.ErrConflict, "%w (restore it with 'jig todo update %s --unarchive')", err, archived.ID)
+	}
+	if _, ok := errors.AsType[*core.RemovedTasksError](err); ok {
+		return cmdError(jsonOutput, output.ErrConflict, "%w with --force", err)
+	}
 	if isConflictError(err) {
-		return cmdError(jsonOutput, output.ErrConflict, "%s", err)
+		return cmdError(jsonOutput, output.ErrConflict, "%w", err)
+	}
+	if _, ok := errors.AsType[*core.SizeError](err); ok {
+		return cmdError(jsonOutput, output.ErrValidation, "%w; save large logs or output to a file, link it from the body, and pass the rest with --body-file", err)
 	}
-	return cmdError(jsonOutput, output.ErrValidation, "%s", err)
+	return cmdError(jsonOutput, output.ErrValidation, "%w", err)
 }
 
 // registerUpdateFlags binds all `todo update` flags to the given command. Split
@@ -290,8 +454,18 @@ func registerUpdateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&updateType, "type", "t", "", "New type ("+strings.Join(typeNames, ", ")+")")
 	cmd.Flags().StringVarP(&updatePriority, "priority", "p", "", "New priority ("+strings.Join(priorityNames, ", ")+", or empty to clear)")
 	cmd.Flags().StringVar(&updateTitle, "title", "", "New title")
+	cmd.Flags().StringVar(&updateSummary, "summary", "", "New one-line description (empty to clear)")
 	cmd.Flags().StringVar(&updateMilestone, "milestone", "", "Milestone ID to assign (empty to clear)")
-	cmd.Flags().StringVar(&updateDue, "due", "", "Due date (YYYY-MM-DD, empty to clear)")
+	cmd.Flags().StringVar(&updateIteration, "iteration", "", "Iteration to assign (ISO week, a configured name, or 'current'; empty to clear)")
+	cmd.Flags().StringVar(&updateEstimate, "estimate", "", "Expected effort (a duration such as 4h or 2d, empty to clear)")
+	cmd.Flags().StringVar(&updateDue, "due", "", "Due date (YYYY-MM-DD or RFC 3339, empty to clear)")
+	cmd.Flags().StringVar(&updateDueTime, "due-time", "", "Due time of day in local time (HH:MM, requires --due)")
+	cmd.Flags().BoolVar(&updateEncrypted, "encrypted", false, "Encrypt the body at rest (--encrypted=false to decrypt)")
+	cmd.Flags().BoolVar(&updatePin, "pin", false, "Pin the issue so it sorts ahead of the rest")
+	cmd.Flags().BoolVar(&updateUnpin, "unpin", false, "Unpin the issue")
+	cmd.Flags().StringVar(&updateVisibility, "visibility", "", "public or internal (internal issues stay out of exports, changelogs, roadmaps, and sync)")
+	cmd.Flags().StringVar(&updateReleaseTitle, "release-title", "", "Customer-facing title for the changelog (empty to clear)")
+	cmd.Flags().StringVar(&updateReleaseNote, "release-note", "", "Customer-facing note for the changelog (empty to clear)")
 
 	// Whole-body writes. --replace-body is destructive (overwrites everything);
 	// --append-body is the safe additive verb. The legacy --body/--body-file are
@@ -311,6 +485,11 @@ func registerUpdateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&updateBodyReplaceNew, "body-replace-new", "", "Replacement substring (requires --body-replace-old)")
 	cmd.Flags().StringArrayVar(&updateBodyCheck, "body-check", nil, "Check a checkbox item by substring match (can be repeated)")
 	cmd.Flags().StringArrayVar(&updateBodyUncheck, "body-uncheck", nil, "Uncheck a checkbox item by substring match (can be repeated)")
+	cmd.Flags().StringVar(&updateSection, "section", "", "Edit only the body section with this heading (requires --section-content or --section-content-file)")
+	cmd.Flags().StringVar(&updateSectionContent, "section-content", "", "New content for --section (use '-' for stdin)")
+	cmd.Flags().StringVar(&updateSectionFile, "section-content-file", "", "Read new content for --section from a file (use '-' for stdin)")
+	cmd.Flags().BoolVar(&updateSectionAppend, "section-append", false, "Append to --section instead of replacing its content")
+	cmd.Flags().BoolVar(&updateSectionCreate, "section-create", false, "Create --section at the end of the body if it does not exist")
 	cmd.Flags().StringVar(&updateParent, "parent", "", "Set parent issue ID")
 	cmd.Flags().BoolVar(&updateRemoveParent, "remove-parent", false, "Remove parent")
 	cmd.Flags().StringArrayVar(&updateBlocking, "blocking", nil, "ID of issue this blocks (can be repeated)")
@@ -320,11 +499,17 @@ func registerUpdateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringArrayVar(&updateTag, "tag", nil, "Add tag (can be repeated)")
 	cmd.Flags().StringArrayVar(&updateRemoveTag, "remove-tag", nil, "Remove tag (can be repeated)")
 	cmd.Flags().StringVar(&updateIfMatch, "if-match", "", "Only update if etag matches (optimistic locking)")
+	cmd.Flags().BoolVar(&updateRetry, "retry-on-conflict", false, "On an etag mismatch, merge with the concurrent change when they touch different fields")
+	cmd.Flags().BoolVar(&updateUnarchive, "unarchive", false, "Move an archived issue back out of the archive (alone, or before applying the update)")
+	cmd.Flags().BoolVar(&updateForce, "force", false, "With --replace-body, replace the body even if that removes unchecked tasks (see protect_unchecked_tasks)")
 	cmd.Flags().BoolVar(&todoUpdateJSON, "json", false, "Output as JSON")
+	addQuietFlag(cmd)
 
 	cmd.MarkFlagsMutuallyExclusive("parent", "remove-parent")
 	cmd.MarkFlagsMutuallyExclusive("replace-body", "replace-body-file", "body-replace-old")
 	cmd.MarkFlagsMutuallyExclusive("replace-body", "replace-body-file", "append-body")
+	cmd.MarkFlagsMutuallyExclusive("replace-body", "replace-body-file", "section")
+	cmd.MarkFlagsMutuallyExclusive("section-content", "section-content-file")
 	cmd.MarkFlagsRequiredTogether("body-replace-old", "body-replace-new")
 }
 
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/changes"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/output"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// maxConflictRetries caps how many times --retry-on-conflict re-applies an
+// update after an etag mismatch.
+const maxConflictRetries = 3
+
+// gitBaseVersions is how many earlier versions of an issue file are searched
+// for the one --if-match names.
+const gitBaseVersions = 20
+
+// updateConflictError reports an update that could not be merged with a
+// concurrent change to the same issue.
+type updateConflictError struct {
+	id        string
+	reason    string
+	conflicts []output.FieldConflict
+}
+
+func (e *updateConflictError) Error() string {
+	if len(e.conflicts) == 0 {
+		return fmt.Sprintf("%s changed concurrently: %s", e.id, e.reason)
+	}
+	names := make([]string, len(e.conflicts))
+	for i, c := range e.conflicts {
+		names[i] = c.Field
+	}
+	return fmt.Sprintf("%s changed concurrently in %s", e.id, strings.Join(names, ", "))
+}
+
+// updateWithRetry applies input to b like UpdateIssue. When the etag no
+// longer matches, it re-reads the issue and, if the concurrent change
+// touched none of the fields in patched, applies input again on top of it,
+// up to maxConflictRetries times. Without an ifMatch the etag of b as read is
+// used, so the update cannot silently overwrite a change made since.
+func updateWithRetry(ctx context.Context, resolver *graph.Resolver, b *issue.Issue, input model.UpdateIssueInput, patched []string) (*issue.Issue, error) {
+	id := b.ID
+	if input.IfMatch == nil {
+		etag, err := todoStore.DiskETag(id)
+		if err != nil {
+			return nil, err
+		}
+		input.IfMatch = &etag
+	}
+	base := conflictBase(b, *input.IfMatch)
+
+	for attempt := 0; ; attempt++ {
+		updated, err := resolver.Mutation().UpdateIssue(ctx, id, input)
+		if _, ok := errors.AsType[*core.ETagMismatchError](err); !ok || attempt == maxConflictRetries {
+			return updated, err
+		}
+
+		// The failed update left the patch applied to the stored issue;
+		// keep it to report conflicting values, then re-read the file.
+		yours, err := todoStore.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		theirs, err := todoStore.Reload(id)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil {
+			return nil, &updateConflictError{
+				id:        id,
+				reason:    "the version --if-match names is no longer available to merge against",
+				conflicts: fieldConflicts(nil, yours, theirs, patched),
+			}
+		}
+		if overlap := overlappingFields(base, theirs, patched, input); len(overlap) > 0 {
+			return nil, &updateConflictError{id: id, conflicts: fieldConflicts(base, yours, theirs, overlap)}
+		}
+
+		// The retry mutates the stored issue, so the next base is parsed
+		// from the file rather than shared with it.
+		content, err := os.ReadFile(filepath.Join(todoStore.Root(), theirs.Path))
+		if err != nil {
+			return nil, err
+		}
+		input.IfMatch = new(core.ContentETag(content))
+		base = parseBase(theirs.Path, content)
+	}
+}
+
+// conflictBase finds the version of b whose file content has the given
+// etag: the file as it is now, or else an earlier version from git. It
+// returns nil when neither matches.
+func conflictBase(b *issue.Issue, etag string) *issue.Issue {
+	if content, err := os.ReadFile(filepath.Join(todoStore.Root(), b.Path)); err == nil && core.ContentETag(content) == etag {
+		return parseBase(b.Path, content)
+	}
+	versions, err := changes.GitVersions(todoStore.Root(), b.Path, gitBaseVersions)
+	if err != nil {
+		return nil
+	}
+	for _, content := range versions {
+		if core.ContentETag(content) == etag {
+			return parseBase(b.Path, content)
+		}
+	}
+	return nil
+}
+
+func parseBase(relPath string, content []byte) *issue.Issue {
+	base, err := todoStore.ParseIssue(relPath, bytes.NewReader(content))
+	if err != nil {
+		return nil
+	}
+	return base
+}
+
+// patchedFields maps the change names buildUpdateInput reports to the field
+// names changes.ChangedFields uses, without duplicates.
+func patchedFields(fieldChanges []string) []string {
+	var fields []string
+	for _, name := range fieldChanges {
+		if name == "blocked-by" {
+			name = "blocked_by"
+		}
+		if !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// overlappingFields returns the fields in patched that also changed between
+// base and theirs. A body appended to merges with any concurrent body edit.
+func overlappingFields(base, theirs *issue.Issue, patched []string, input model.UpdateIssueInput) []string {
+	var overlap []string
+	for _, name := range changes.ChangedFields(base, theirs) {
+		if !slices.Contains(patched, name) {
+			continue
+		}
+		if name == "body" && isAppendOnly(input) {
+			continue
+		}
+		overlap = append(overlap, name)
+	}
+	return overlap
+}
+
+// isAppendOnly reports whether input's only body change is an append.
+func isAppendOnly(input model.UpdateIssueInput) bool {
+	m := input.BodyMod
+	return input.Body == nil && m != nil && m.Append != nil &&
+		len(m.Replace) == 0 && len(m.Check) == 0 && len(m.Uncheck) == 0 &&
+		m.SetSection == nil && m.AppendToSection == nil
+}
+
+// fieldConflicts describes each named field's value in yours and theirs,
+// and in base when it is known.
+func fieldConflicts(base, yours, theirs *issue.Issue, names []string) []output.FieldConflict {
+	conflicts := make([]output.FieldConflict, 0, len(names))
+	for _, name := range names {
+		c := output.FieldConflict{
+			Field:  name,
+			Yours:  changes.FieldValue(yours, name),
+			Theirs: changes.FieldValue(theirs, name),
+		}
+		if base != nil {
+			c.Base = new(changes.FieldValue(base, name))
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts
+}
+
+// conflictReport returns the command error for an unmerged update: the
+// structured report with --json, else one line per conflicting field.
+func conflictReport(jsonOutput bool, e *updateConflictError) error {
+	if jsonOutput {
+		return output.ConflictError(e.Error(), e.conflicts)
+	}
+	var sb strings.Builder
+	sb.WriteString(e.Error())
+	if e.reason != "" && len(e.conflicts) > 0 {
+		sb.WriteString(" (" + e.reason + ")")
+	}
+	for _, c := range e.conflicts {
+		if c.Field == "body" {
+			fmt.Fprintf(&sb, "\n  %s: edited on both sides", ui.Bold.Render(c.Field))
+			continue
+		}
+		fmt.Fprintf(&sb, "\n  %s: yours %q, theirs %q", ui.Bold.Render(c.Field), c.Yours, c.Theirs)
+		if c.Base != nil {
+			fmt.Fprintf(&sb, " (was %q)", *c.Base)
+		}
+	}
+	return errors.New(sb.String())
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	todoconfig "github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/output"
+)
+
+// setupConflictTest creates issue cfl-1 and returns its store.
+func setupConflictTest(t *testing.T) *core.Core {
+	t.Helper()
+	testCore, cleanup := setupQueryTestCore(t)
+	t.Cleanup(cleanup)
+	oldCfg := todoCfg
+	todoCfg = todoconfig.Default()
+	t.Cleanup(func() { todoCfg = oldCfg })
+
+	createQueryTestIssue(t, testCore, "cfl-1", "Original", "ready")
+	b, _ := testCore.Get("cfl-1")
+	b.Body = "First line."
+	if err := testCore.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	return testCore
+}
+
+// editOnce makes the next Update see the issue file edited by someone else:
+// each old string in the file is replaced by the new one that follows it.
+func editOnce(t *testing.T, c *core.Core, id string, oldNew ...string) {
+	t.Helper()
+	b, _ := c.Get(id)
+	path := filepath.Join(c.Root(), b.Path)
+	fired := false
+	c.SetUpdateHook(func(string) {
+		if fired {
+			return
+		}
+		fired = true
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		edited := strings.NewReplacer(oldNew...).Replace(string(content))
+		if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() { c.SetUpdateHook(nil) })
+}
+
+// runUpdate runs `todo update id args...` and returns its stdout.
+func runUpdate(t *testing.T, id string, args ...string) (string, error) {
+	t.Helper()
+	c := &cobra.Command{Use: "update", RunE: todoUpdateCmd.RunE}
+	registerUpdateFlags(c)
+	if err := c.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	runErr := c.RunE(c, []string{id})
+	w.Close()
+	os.Stdout = orig
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String(), runErr
+}
+
+func TestUpdateRetryOnConflictMerges(t *testing.T) {
+	c := setupConflictTest(t)
+	editOnce(t, c, "cfl-1", "title: Original", "title: Renamed elsewhere")
+
+	if _, err := runUpdate(t, "cfl-1", "--status", "completed", "--retry-on-conflict"); err != nil {
+		t.Fatalf("update error = %v", err)
+	}
+	b, _ := c.Get("cfl-1")
+	if b.Title != "Renamed elsewhere" || b.Status != "completed" {
+		t.Errorf("got title %q, status %q; want both changes kept", b.Title, b.Status)
+	}
+	disk, err := c.Reload("cfl-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disk.Title != "Renamed elsewhere" || disk.Status != "completed" {
+		t.Errorf("file has title %q, status %q; want both changes kept", disk.Title, disk.Status)
+	}
+}
+
+func TestUpdateRetryOnConflictAppendsBody(t *testing.T) {
+	c := setupConflictTest(t)
+	editOnce(t, c, "cfl-1", "First line.", "First line, edited.")
+
+	if _, err := runUpdate(t, "cfl-1", "--append-body", "Second line.", "--retry-on-conflict"); err != nil {
+		t.Fatalf("update error = %v", err)
+	}
+	b, _ := c.Reload("cfl-1")
+	if !strings.Contains(b.Body, "First line, edited.") || !strings.Contains(b.Body, "Second line.") {
+		t.Errorf("body = %q, want the concurrent edit and the append", b.Body)
+	}
+}
+
+func TestUpdateRetryOnConflictReportsOverlap(t *testing.T) {
+	c := setupConflictTest(t)
+	editOnce(t, c, "cfl-1", "status: ready", "status: in-progress")
+
+	out, err := runUpdate(t, "cfl-1", "--status", "completed", "--retry-on-conflict", "--json")
+	if err == nil {
+		t.Fatal("expected a conflict error")
+	}
+	var resp output.Response
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parsing %q: %v", out, err)
+	}
+	if resp.Code != output.ErrConflict || len(resp.Conflicts) != 1 {
+		t.Fatalf("response = %+v, want one CONFLICT field", resp)
+	}
+	got := resp.Conflicts[0]
+	if got.Field != "status" || got.Yours != "completed" || got.Theirs != "in-progress" || got.Base == nil || *got.Base != "ready" {
+		t.Errorf("conflict = %+v (base %v), want status ready → completed vs in-progress", got, got.Base)
+	}
+	if b, _ := c.Get("cfl-1"); b.Status != "in-progress" {
+		t.Errorf("status = %q, want the concurrent change kept", b.Status)
+	}
+}
+
+func TestUpdateRetryOnConflictBodyOverlap(t *testing.T) {
+	c := setupConflictTest(t)
+	editOnce(t, c, "cfl-1", "First line.", "First line, edited.")
+
+	_, err := runUpdate(t, "cfl-1", "--body-replace-old", "First", "--body-replace-new", "Only", "--retry-on-conflict")
+	if err == nil || !strings.Contains(err.Error(), "body") {
+		t.Fatalf("error = %v, want a body conflict", err)
+	}
+}
+
+func TestUpdateWithoutRetryKeepsMismatch(t *testing.T) {
+	c := setupConflictTest(t)
+	etag, _ := c.DiskETag("cfl-1")
+	editOnce(t, c, "cfl-1", "title: Original", "title: Renamed elsewhere")
+
+	_, err := runUpdate(t, "cfl-1", "--status", "completed", "--if-match", etag)
+	if err == nil || !strings.Contains(err.Error(), "etag mismatch") {
+		t.Fatalf("error = %v, want an etag mismatch", err)
+	}
+}
+
+func TestUpdateRetryOnConflictGivesUp(t *testing.T) {
+	c := setupConflictTest(t)
+	b, _ := c.Get("cfl-1")
+	path := filepath.Join(c.Root(), b.Path)
+	calls := 0
+	c.SetUpdateHook(func(string) {
+		calls++
+		content, _ := os.ReadFile(path)
+		_ = os.WriteFile(path, append(content, '\n'), 0644)
+	})
+	t.Cleanup(func() { c.SetUpdateHook(nil) })
+
+	_, err := runUpdate(t, "cfl-1", "--status", "completed", "--retry-on-conflict")
+	if err == nil || !strings.Contains(err.Error(), "etag mismatch") {
+		t.Fatalf("error = %v, want an etag mismatch once retries run out", err)
+	}
+	if calls != maxConflictRetries+1 {
+		t.Errorf("Update called %d times, want %d", calls, maxConflictRetries+1)
+	}
+}
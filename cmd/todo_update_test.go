@@ -1,4 +1,172 @@
 package cmd
 
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/output"
+)
+
 // Tests for parseLink and isKnownLinkType have been moved to content_test.go
 // since those functions now live in todo_content.go
+
+func TestArchivedIssueCommands(t *testing.T) {
+	archive := func(t *testing.T) *core.Core {
+		t.Helper()
+		testCore := setupConflictTest(t)
+		if err := testCore.Archive("cfl-1"); err != nil {
+			t.Fatal(err)
+		}
+		return testCore
+	}
+	assertConflict := func(t *testing.T, out string, err error) {
+		t.Helper()
+		if !errors.Is(err, core.ErrArchived) {
+			t.Fatalf("error = %v, want ErrArchived", err)
+		}
+		if got := exitCode(err); got != output.ExitConflict {
+			t.Errorf("exitCode() = %d, want %d", got, output.ExitConflict)
+		}
+		var resp map[string]any
+		if jsonErr := json.Unmarshal([]byte(out), &resp); jsonErr != nil || resp["code"] != output.ErrConflict {
+			t.Errorf("response = %s, want code %s", out, output.ErrConflict)
+		}
+	}
+
+	t.Run("update is refused", func(t *testing.T) {
+		testCore := archive(t)
+		out, err := runJSONCommand(t, todoUpdateCmd, map[string]string{"json": "true", "title": "Changed"}, "cfl-1")
+		assertConflict(t, out, err)
+		if !strings.Contains(err.Error(), "jig todo update cfl-1 --unarchive") {
+			t.Errorf("error %q does not say how to unarchive", err)
+		}
+		if b, _ := testCore.Get("cfl-1"); b.Title != "Original" || !testCore.IsArchived("cfl-1") {
+			t.Errorf("archived issue changed: title %q, archived %v", b.Title, testCore.IsArchived("cfl-1"))
+		}
+	})
+
+	t.Run("update --unarchive", func(t *testing.T) {
+		testCore := archive(t)
+		out, err := runJSONCommand(t, todoUpdateCmd, map[string]string{"json": "true", "unarchive": "true", "title": "Changed"}, "cfl-1")
+		if err != nil {
+			t.Fatalf("update --unarchive: %v\n%s", err, out)
+		}
+		if !strings.Contains(out, "Issue unarchived and updated") {
+			t.Errorf("output = %s", out)
+		}
+		if b, _ := testCore.Get("cfl-1"); b.Title != "Changed" || testCore.IsArchived("cfl-1") {
+			t.Errorf("title %q, archived %v; want Changed, false", b.Title, testCore.IsArchived("cfl-1"))
+		}
+	})
+
+	t.Run("--unarchive alone", func(t *testing.T) {
+		testCore := archive(t)
+		out, err := runJSONCommand(t, todoUpdateCmd, map[string]string{"json": "true", "unarchive": "true"}, "cfl-1")
+		if err != nil {
+			t.Fatalf("update --unarchive: %v\n%s", err, out)
+		}
+		if !strings.Contains(out, `"Issue unarchived"`) || testCore.IsArchived("cfl-1") {
+			t.Errorf("output = %s, archived %v", out, testCore.IsArchived("cfl-1"))
+		}
+	})
+
+	t.Run("delete is refused", func(t *testing.T) {
+		testCore := archive(t)
+		out, err := runJSONCommand(t, deleteCmd, map[string]string{"json": "true"}, "cfl-1")
+		assertConflict(t, out, err)
+		if _, getErr := testCore.Get("cfl-1"); getErr != nil {
+			t.Errorf("archived issue was deleted: %v", getErr)
+		}
+	})
+
+	t.Run("sync link is refused", func(t *testing.T) {
+		archive(t)
+		t.Setenv("GITHUB_TOKEN", "")
+		todoCfg.Sync = map[string]map[string]any{"github": {"repo": "o/r"}}
+		out, err := runJSONCommand(t, syncLinkCmd, map[string]string{"json": "true"}, "cfl-1", "42")
+		assertConflict(t, out, err)
+	})
+
+	t.Run("show has a banner", func(t *testing.T) {
+		testCore := archive(t)
+		b, _ := testCore.Get("cfl-1")
+		if got := renderIssue(b, false); !strings.Contains(got, "archived · read-only") {
+			t.Errorf("show output has no archived banner:\n%s", got)
+		}
+		if err := testCore.Unarchive("cfl-1"); err != nil {
+			t.Fatal(err)
+		}
+		if got := renderIssue(b, false); strings.Contains(got, "archived") {
+			t.Errorf("unarchived issue still has a banner:\n%s", got)
+		}
+	})
+}
+
+func TestUpdateReplaceBodyRemovedTasks(t *testing.T) {
+	setup := func(t *testing.T, mode string) *core.Core {
+		t.Helper()
+		testCore := setupConflictTest(t)
+		testCore.Config().ProtectUncheckedTasks = mode
+		b, _ := testCore.Get("cfl-1")
+		b.Body = "- [ ] Keep me\n- [ ] Drop me"
+		if err := testCore.Update(b, nil); err != nil {
+			t.Fatal(err)
+		}
+		return testCore
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		testCore := setup(t, "")
+		out, err := runUpdate(t, "cfl-1", "--replace-body", "- [ ] Keep me", "--json")
+		if err != nil {
+			t.Fatalf("update error = %v", err)
+		}
+		var resp output.Response
+		if err := json.Unmarshal([]byte(out), &resp); err != nil {
+			t.Fatalf("decoding %q: %v", out, err)
+		}
+		if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], `"Drop me"`) {
+			t.Errorf("warnings = %q, want the removed task", resp.Warnings)
+		}
+		if b, _ := testCore.Get("cfl-1"); b.Body != "- [ ] Keep me" {
+			t.Errorf("body = %q, want it replaced", b.Body)
+		}
+	})
+
+	t.Run("strict refuses", func(t *testing.T) {
+		testCore := setup(t, "strict")
+		out, err := runUpdate(t, "cfl-1", "--replace-body", "- [ ] Keep me", "--json")
+		if _, ok := errors.AsType[*core.RemovedTasksError](err); !ok {
+			t.Fatalf("error = %v, want RemovedTasksError", err)
+		}
+		if got := exitCode(err); got != output.ExitConflict {
+			t.Errorf("exitCode() = %d, want %d", got, output.ExitConflict)
+		}
+		if !strings.Contains(out, "--force") {
+			t.Errorf("response = %s, want a --force hint", out)
+		}
+		if b, _ := testCore.Get("cfl-1"); !strings.Contains(b.Body, "Drop me") {
+			t.Errorf("refused update changed the body to %q", b.Body)
+		}
+	})
+
+	t.Run("strict forced", func(t *testing.T) {
+		testCore := setup(t, "strict")
+		if _, err := runUpdate(t, "cfl-1", "--replace-body", "- [ ] Keep me", "--force"); err != nil {
+			t.Fatalf("forced update error = %v", err)
+		}
+		if b, _ := testCore.Get("cfl-1"); b.Body != "- [ ] Keep me" {
+			t.Errorf("body = %q, want it replaced", b.Body)
+		}
+	})
+
+	t.Run("force needs replace-body", func(t *testing.T) {
+		setup(t, "strict")
+		if _, err := runUpdate(t, "cfl-1", "--append-body", "More", "--force"); err == nil || !strings.Contains(err.Error(), "--force only applies") {
+			t.Errorf("error = %v, want --force misuse", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// todoWhichResult is what todo which reports.
+type todoWhichResult struct {
+	ConfigPath string   `json:"configPath"`
+	ConfigVia  string   `json:"configVia"`
+	StartDir   string   `json:"startDir,omitempty"`
+	Shadows    []string `json:"shadows,omitempty"`
+	DataDir    string   `json:"dataDir"`
+	DataVia    string   `json:"dataVia"`
+	DataExists bool     `json:"dataExists"`
+}
+
+var todoWhichCmd = &cobra.Command{
+	Use:   "which",
+	Short: "Show which config and data directory todo commands use, and why",
+	Long: `Prints the config file todo commands resolve from here, the data directory
+it names, and how each was chosen. The config is the one --config names, or
+else the nearest .jig.yaml in the current directory (or --project-root) and
+its parents up to the git root; a relative data path is taken from the
+config's directory. Configs further up that the nearest one shadows are
+listed too.
+
+Unlike other todo commands, which runs even when the data directory is
+missing, so it can show where it was looked for.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, src, err := resolveTodoConfig()
+		if err != nil {
+			return err
+		}
+
+		r := todoWhichResult{ConfigPath: src.Path, ConfigVia: src.Via, StartDir: src.StartDir, Shadows: src.Outer}
+		switch {
+		case todoDataPath != "":
+			r.DataDir, r.DataVia = todoDataPath, "--data-path"
+			if abs, err := filepath.Abs(todoDataPath); err == nil {
+				r.DataDir = abs
+			}
+		case filepath.IsAbs(cfg.Path):
+			r.DataDir, r.DataVia = cfg.Path, "absolute path in the config"
+		default:
+			r.DataDir = cfg.ResolveDataPath()
+			r.DataVia = fmt.Sprintf("%q relative to %s", cfg.Path, cfg.ConfigDir())
+		}
+		if info, err := os.Stat(r.DataDir); err == nil && info.IsDir() {
+			r.DataExists = true
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(r)
+		}
+		config := r.ConfigPath
+		if config == "" {
+			config = "(none)"
+		}
+		fmt.Printf("config:   %s\n          %s\n", config, r.ConfigVia)
+		if r.StartDir != "" {
+			fmt.Printf("          searched from %s\n", r.StartDir)
+		}
+		if len(r.Shadows) > 0 {
+			fmt.Printf("shadows:  %s\n", strings.Join(r.Shadows, "\n          "))
+		}
+		missing := ""
+		if !r.DataExists {
+			missing = " (missing)"
+		}
+		fmt.Printf("data dir: %s%s\n          %s\n", r.DataDir, missing, r.DataVia)
+		return nil
+	},
+}
+
+func init() {
+	todoCmd.AddCommand(todoWhichCmd)
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	todoconfig "github.com/toba/jig/internal/todo/config"
+)
+
+// setupMonorepo builds a repository with a root project and a nested
+// services/auth project, each with its own config and data directory, and
+// resets the flags resolveTodoConfig reads.
+func setupMonorepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{".git", ".issues", "services/auth/tasks", "services/auth/src", "docs"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for path, content := range map[string]string{
+		".jig.yaml":               "todo:\n    path: .issues\n",
+		"services/auth/.jig.yaml": "todo:\n    path: tasks\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldCfgPath, oldRoot, oldData, oldStore, oldCfg := cfgPath, todoProjectRoot, todoDataPath, todoStore, todoCfg
+	t.Cleanup(func() {
+		cfgPath, todoProjectRoot, todoDataPath, todoStore, todoCfg = oldCfgPath, oldRoot, oldData, oldStore, oldCfg
+	})
+	cfgPath, todoProjectRoot, todoDataPath = "", "", ""
+	return root
+}
+
+// captureStderr runs fn with stderr redirected and returns what it wrote.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = orig }()
+	fn()
+	w.Close()
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	return buf.String()
+}
+
+func TestResolveTodoConfigNearestWins(t *testing.T) {
+	root := setupMonorepo(t)
+	t.Chdir(filepath.Join(root, "services/auth/src"))
+
+	cfg, src, err := resolveTodoConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "services/auth/.jig.yaml"); src.Path != want {
+		t.Errorf("Path = %q, want %q", src.Path, want)
+	}
+	if len(src.Outer) != 1 || src.Outer[0] != filepath.Join(root, ".jig.yaml") {
+		t.Errorf("Outer = %q, want the root config", src.Outer)
+	}
+	if want := filepath.Join(root, "services/auth/tasks"); cfg.ResolveDataPath() != want {
+		t.Errorf("data path = %q, want %q relative to the config, not the cwd", cfg.ResolveDataPath(), want)
+	}
+
+	stderr := captureStderr(t, func() {
+		if err := initTodoCore(todoCmd); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if want := filepath.Join(root, "services/auth/tasks"); todoStore.Root() != want {
+		t.Errorf("store root = %q, want %q", todoStore.Root(), want)
+	}
+	if !strings.Contains(stderr, "shadows "+filepath.Join(root, ".jig.yaml")) {
+		t.Errorf("stderr = %q, want a warning naming the outer config", stderr)
+	}
+}
+
+func TestResolveTodoConfigFromRootDirectory(t *testing.T) {
+	root := setupMonorepo(t)
+	t.Chdir(filepath.Join(root, "docs"))
+
+	cfg, src, err := resolveTodoConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Path != filepath.Join(root, ".jig.yaml") || len(src.Outer) != 0 {
+		t.Errorf("Path = %q, Outer = %q, want the root config alone", src.Path, src.Outer)
+	}
+	if want := filepath.Join(root, ".issues"); cfg.ResolveDataPath() != want {
+		t.Errorf("data path = %q, want %q", cfg.ResolveDataPath(), want)
+	}
+}
+
+func TestResolveTodoConfigProjectRoot(t *testing.T) {
+	root := setupMonorepo(t)
+	t.Chdir(filepath.Join(root, "docs"))
+	todoProjectRoot = filepath.Join(root, "services/auth")
+
+	_, src, err := resolveTodoConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "services/auth/.jig.yaml"); src.Path != want {
+		t.Errorf("Path = %q, want %q", src.Path, want)
+	}
+	if src.Via != viaProjectRoot {
+		t.Errorf("Via = %q, want %q", src.Via, viaProjectRoot)
+	}
+
+	todoProjectRoot = filepath.Join(root, "missing")
+	if _, _, err := resolveTodoConfig(); err == nil || !strings.Contains(err.Error(), "project root does not exist") {
+		t.Errorf("missing project root: error = %v", err)
+	}
+}
+
+func TestResolveTodoConfigAboveAnyConfig(t *testing.T) {
+	setupMonorepo(t)
+	outside := t.TempDir()
+	if err := os.Mkdir(filepath.Join(outside, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(outside)
+
+	_, _, err := resolveTodoConfig()
+	if err == nil || !strings.Contains(err.Error(), "no .jig.yaml found in "+outside) {
+		t.Fatalf("error = %v, want a clear no-config error", err)
+	}
+	if code := errorCode(err, ""); code != "NO_DATA_DIR" {
+		t.Errorf("error code = %q, want NO_DATA_DIR", code)
+	}
+
+	// A default data directory, or --data-path, still works without config.
+	if err := os.Mkdir(filepath.Join(outside, todoconfig.DefaultDataPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, src, err := resolveTodoConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.Path != "" || src.Via != viaDefaults {
+		t.Errorf("source = %+v, want defaults", src)
+	}
+}
+
+func TestTodoWhichJSON(t *testing.T) {
+	root := setupMonorepo(t)
+	t.Chdir(filepath.Join(root, "services/auth/src"))
+
+	oldJSON := jsonOut
+	t.Cleanup(func() { jsonOut = oldJSON })
+	jsonOut = true
+
+	var got todoWhichResult
+	out, err := runJSONCommand(t, todoWhichCmd, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decoding %q: %v", out, err)
+	}
+	want := todoWhichResult{
+		ConfigPath: filepath.Join(root, "services/auth/.jig.yaml"),
+		ConfigVia:  viaSearch,
+		StartDir:   filepath.Join(root, "services/auth/src"),
+		Shadows:    []string{filepath.Join(root, ".jig.yaml")},
+		DataDir:    filepath.Join(root, "services/auth/tasks"),
+		DataVia:    `"tasks" relative to ` + filepath.Join(root, "services/auth"),
+		DataExists: true,
+	}
+	if got.ConfigPath != want.ConfigPath || got.ConfigVia != want.ConfigVia || got.StartDir != want.StartDir ||
+		strings.Join(got.Shadows, ",") != strings.Join(want.Shadows, ",") ||
+		got.DataDir != want.DataDir || got.DataVia != want.DataVia || got.DataExists != want.DataExists {
+		t.Errorf("which = %+v\nwant    %+v", got, want)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
-	"github.com/toba/jig/internal/todo/tui"
 )
 
 // tuiAliasCmd is a top-level alias for "jig todo tui".
@@ -14,7 +13,7 @@ var tuiAliasCmd = &cobra.Command{
 		return initTodoCore(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.Run(todoStore, todoCfg)
+		return runTUI()
 	},
 }
 
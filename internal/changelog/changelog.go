@@ -18,10 +18,13 @@ type Commit struct {
 	Date    string `json:"date"`
 }
 
-// TimeRange represents the time window for the changelog.
+// TimeRange represents the time window for the changelog. FromTag and
+// ToTag name the tags the window was derived from, if any.
 type TimeRange struct {
-	Since time.Time `json:"since"`
-	Until time.Time `json:"until"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+	FromTag string    `json:"from_tag,omitempty"`
+	ToTag   string    `json:"to_tag,omitempty"`
 }
 
 // Issues groups issues by how they relate to the time range.
@@ -37,6 +40,45 @@ type Result struct {
 	Range   TimeRange `json:"range"`
 	Issues  Issues    `json:"issues"`
 	Commits []Commit  `json:"commits,omitempty"`
+	// Links maps issue IDs to the web URLs of their sync entries, by entry
+	// name. Issues without any are left out.
+	Links map[string]map[string]string `json:"links,omitempty"`
+	// Release maps issue IDs to their customer-facing wording; see
+	// ReleaseOf. It is nil when Options.Internal is set.
+	Release map[string]Release `json:"release,omitempty"`
+}
+
+// Release is how an issue reads in a customer-facing changelog.
+type Release struct {
+	Title string `json:"title"`
+	Note  string `json:"note,omitempty"`
+}
+
+// ReleaseOf returns the release title and note of b, falling back to its
+// title and body when they are unset.
+func ReleaseOf(b *issue.Issue) Release {
+	title, note := b.ReleaseTitle, b.ReleaseNote
+	if title == "" {
+		title = b.Title
+	}
+	if note == "" {
+		note = b.Body
+	}
+	return Release{Title: title, Note: note}
+}
+
+// MissingReleaseTitles returns the gathered issues that have no release
+// title, in completed, created, updated order.
+func (r *Result) MissingReleaseTitles() []*issue.Issue {
+	var missing []*issue.Issue
+	for _, group := range [][]*issue.Issue{r.Issues.Completed, r.Issues.Created, r.Issues.Updated} {
+		for _, b := range group {
+			if b.ReleaseTitle == "" {
+				missing = append(missing, b)
+			}
+		}
+	}
+	return missing
 }
 
 // Options configures what to gather.
@@ -44,6 +86,12 @@ type Options struct {
 	Since      time.Time
 	Until      time.Time
 	IncludeGit bool
+	// ResolvedAt, if set, gives when an issue was resolved. Resolved issues
+	// it has no answer for fall back to their updated_at.
+	ResolvedAt func(*issue.Issue) (time.Time, bool)
+	// Internal leaves Result.Release unset, so issues read by their
+	// internal titles and bodies.
+	Internal bool
 }
 
 // Gather filters issues into created/updated/completed buckets based on the time range.
@@ -60,7 +108,12 @@ func Gather(all []*issue.Issue, opts Options) *Result {
 	for _, iss := range all {
 		inCreated := iss.CreatedAt != nil && !iss.CreatedAt.Before(opts.Since) && iss.CreatedAt.Before(opts.Until)
 		inUpdated := iss.UpdatedAt != nil && !iss.UpdatedAt.Before(opts.Since) && iss.UpdatedAt.Before(opts.Until)
-		isCompleted := (iss.Status == config.StatusCompleted || iss.Status == config.StatusReview) && inUpdated
+		isCompleted := IsResolved(iss.Status) && inUpdated
+		if IsResolved(iss.Status) && opts.ResolvedAt != nil {
+			if at, ok := opts.ResolvedAt(iss); ok {
+				isCompleted = !at.Before(opts.Since) && at.Before(opts.Until)
+			}
+		}
 
 		switch {
 		case isCompleted:
@@ -69,12 +122,25 @@ func Gather(all []*issue.Issue, opts Options) *Result {
 			r.Issues.Created = append(r.Issues.Created, iss)
 		case inUpdated:
 			r.Issues.Updated = append(r.Issues.Updated, iss)
+		default:
+			continue
+		}
+		if !opts.Internal {
+			if r.Release == nil {
+				r.Release = map[string]Release{}
+			}
+			r.Release[iss.ID] = ReleaseOf(iss)
 		}
 	}
 
 	return r
 }
 
+// IsResolved reports whether status counts as done for the changelog.
+func IsResolved(status string) bool {
+	return status == config.StatusCompleted || status == config.StatusReview
+}
+
 // GitCommits returns commits in the given time range.
 func GitCommits(since, until time.Time) ([]Commit, error) {
 	args := []string{
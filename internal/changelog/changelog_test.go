@@ -128,3 +128,46 @@ func TestGather_RangeIsSet(t *testing.T) {
 		t.Errorf("expected until %v, got %v", until, result.Range.Until)
 	}
 }
+
+func TestGather_ReleaseWording(t *testing.T) {
+	now := time.Date(2026, 2, 25, 12, 0, 0, 0, time.UTC)
+	issues := []*issue.Issue{
+		{
+			ID: "worded", Title: "Fix the horrible auth hack", Body: "Ripped out the token cache.", Status: "completed",
+			ReleaseTitle: "Sign-in is more reliable", ReleaseNote: "Sessions no longer expire early.",
+			UpdatedAt: new(now.AddDate(0, 0, -1)),
+		},
+		{
+			ID: "plain", Title: "Add dark mode", Body: "Follows the system theme.", Status: "completed",
+			UpdatedAt: new(now.AddDate(0, 0, -1)),
+		},
+		{
+			ID: "outside", Title: "Old", Status: "completed",
+			UpdatedAt: new(now.AddDate(0, 0, -30)),
+		},
+	}
+
+	result := Gather(issues, Options{Since: now.AddDate(0, 0, -7), Until: now})
+	want := map[string]Release{
+		"worded": {Title: "Sign-in is more reliable", Note: "Sessions no longer expire early."},
+		"plain":  {Title: "Add dark mode", Note: "Follows the system theme."},
+	}
+	if len(result.Release) != len(want) {
+		t.Errorf("Release = %v, want %v", result.Release, want)
+	}
+	for id, rel := range want {
+		if result.Release[id] != rel {
+			t.Errorf("Release[%s] = %+v, want %+v", id, result.Release[id], rel)
+		}
+	}
+
+	missing := result.MissingReleaseTitles()
+	if len(missing) != 1 || missing[0].ID != "plain" {
+		t.Errorf("MissingReleaseTitles() = %v, want [plain]", missing)
+	}
+
+	internal := Gather(issues, Options{Since: now.AddDate(0, 0, -7), Until: now, Internal: true})
+	if internal.Release != nil {
+		t.Errorf("Internal: Release = %v, want nil", internal.Release)
+	}
+}
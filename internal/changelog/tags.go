@@ -0,0 +1,111 @@
+package changelog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// ErrNoTags is returned by LatestTag when the repository has no semver tags.
+var ErrNoTags = errors.New("no semver tags found (use --since to pick a start date)")
+
+// maxResolvedCommits bounds how far back ResolvedAt walks an issue file's
+// history.
+const maxResolvedCommits = 50
+
+// Tag is a git tag resolved to the commit it points at. Lightweight and
+// annotated tags resolve the same way; Date is the commit date, not the
+// tagger date.
+type Tag struct {
+	Name string    `json:"name"`
+	Date time.Time `json:"date"`
+}
+
+// LatestTag returns the highest semver tag, or ErrNoTags. Tags with and
+// without a leading "v" both count.
+func LatestTag() (Tag, error) {
+	out, err := exec.Command("git", "tag", "--list").Output()
+	if err != nil {
+		return Tag{}, fmt.Errorf("git tag: %w", err)
+	}
+	var tags []string
+	for name := range strings.FieldsSeq(string(out)) {
+		if semver.IsValid(canonicalTag(name)) {
+			tags = append(tags, name)
+		}
+	}
+	if len(tags) == 0 {
+		return Tag{}, ErrNoTags
+	}
+	latest := slices.MaxFunc(tags, func(a, b string) int {
+		return semver.Compare(canonicalTag(a), canonicalTag(b))
+	})
+	return ResolveTag(latest)
+}
+
+// ResolveTag looks up the commit date of the named tag.
+func ResolveTag(name string) (Tag, error) {
+	out, err := exec.Command("git", "log", "-1", "--format=%cI", name+"^{commit}", "--").Output()
+	if err != nil {
+		return Tag{}, fmt.Errorf("tag %q not found", name)
+	}
+	date, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return Tag{}, fmt.Errorf("parsing date of tag %q: %w", name, err)
+	}
+	return Tag{Name: name, Date: date}, nil
+}
+
+// canonicalTag adds the "v" semver.IsValid requires.
+func canonicalTag(name string) string {
+	if strings.HasPrefix(name, "v") {
+		return name
+	}
+	return "v" + name
+}
+
+// ResolvedAt returns when b last moved into a resolved status according to
+// the git history of its file under dataDir: the commit date of the oldest
+// commit in the newest unbroken run of commits with a resolved status. It
+// reports false when b is not resolved in its latest commit or the file has
+// no history.
+func ResolvedAt(dataDir string, b *issue.Issue) (time.Time, bool) {
+	file := "./" + filepath.ToSlash(b.Path)
+	cmd := exec.Command("git", "log", fmt.Sprintf("-%d", maxResolvedCommits), "--format=%H %cI", "--", file)
+	cmd.Dir = dataDir
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var resolved time.Time
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		rev, date, ok := strings.Cut(line, " ")
+		if !ok {
+			break
+		}
+		show := exec.Command("git", "show", rev+":"+file)
+		show.Dir = dataDir
+		content, err := show.Output()
+		if err != nil {
+			break // renamed or deleted in that commit
+		}
+		version, err := issue.Parse(bytes.NewReader(content))
+		if err != nil || !IsResolved(version.Status) {
+			break
+		}
+		if t, err := time.Parse(time.RFC3339, date); err == nil {
+			resolved = t
+		}
+	}
+	return resolved, !resolved.IsZero()
+}
@@ -0,0 +1,178 @@
+package changelog
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// runGit runs git in repo with both author and committer dates set to at.
+func runGit(t *testing.T, repo string, at time.Time, args ...string) {
+	t.Helper()
+	date := at.Format(time.RFC3339)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repo
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func commitAll(t *testing.T, repo string, at time.Time, msg string) {
+	t.Helper()
+	runGit(t, repo, at, "add", "-A")
+	runGit(t, repo, at, "commit", "-q", "-m", msg)
+}
+
+// setupTagRepo makes a git repo in a temp dir, changes into it, and returns
+// it with a core over its data directory.
+func setupTagRepo(t *testing.T) (string, *core.Core) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	now := time.Now()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"config", "commit.gpgsign", "false"},
+		{"config", "tag.gpgsign", "false"},
+	} {
+		runGit(t, repo, now, args...)
+	}
+	dataDir := filepath.Join(repo, core.DataDir)
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	c := core.New(dataDir, config.Default())
+	c.SetWarnWriter(nil)
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+	return repo, c
+}
+
+func setStatus(t *testing.T, c *core.Core, id, status string) {
+	t.Helper()
+	b, err := c.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Status = status
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTagRangeUsesIssueHistory(t *testing.T) {
+	repo, c := setupTagRepo(t)
+	base := time.Now().Add(-10 * time.Hour).Truncate(time.Second)
+
+	for _, b := range []*issue.Issue{
+		{ID: "aaa-111", Slug: "in-release", Title: "In release", Status: "ready"},
+		{ID: "bbb-222", Slug: "after-release", Title: "After release", Status: "ready"},
+		{ID: "ccc-333", Slug: "earlier-release", Title: "Earlier release", Status: "ready"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commitAll(t, repo, base, "add issues")
+	setStatus(t, c, "ccc-333", "completed")
+	commitAll(t, repo, base.Add(time.Hour), "finish earlier")
+	// Lightweight tag.
+	runGit(t, repo, base.Add(time.Hour), "tag", "v1.0.0")
+
+	setStatus(t, c, "aaa-111", "completed")
+	commitAll(t, repo, base.Add(2*time.Hour), "finish in release")
+	// Annotated tag on that commit, tagged later than the commit; the window
+	// uses the commit date.
+	runGit(t, repo, base.Add(5*time.Hour), "tag", "-a", "v1.1.0", "-m", "release")
+	runGit(t, repo, base.Add(5*time.Hour), "tag", "nightly")
+
+	setStatus(t, c, "bbb-222", "completed")
+	commitAll(t, repo, base.Add(4*time.Hour), "finish after release")
+
+	latest, err := LatestTag()
+	if err != nil {
+		t.Fatalf("LatestTag: %v", err)
+	}
+	if latest.Name != "v1.1.0" || !latest.Date.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("LatestTag() = %+v, want v1.1.0 at %v", latest, base.Add(2*time.Hour))
+	}
+
+	from, err := ResolveTag("v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !from.Date.Equal(base.Add(time.Hour)) {
+		t.Errorf("v1.0.0 date = %v, want %v", from.Date, base.Add(time.Hour))
+	}
+
+	// Every issue's updated_at is now, outside the window, so only the git
+	// history can place aaa-111 in it.
+	result := Gather(c.All(), Options{
+		Since: from.Date.Add(time.Second),
+		Until: latest.Date.Add(time.Second),
+		ResolvedAt: func(b *issue.Issue) (time.Time, bool) {
+			return ResolvedAt(c.Root(), b)
+		},
+	})
+	if len(result.Issues.Completed) != 1 || result.Issues.Completed[0].ID != "aaa-111" {
+		var ids []string
+		for _, b := range result.Issues.Completed {
+			ids = append(ids, b.ID)
+		}
+		t.Errorf("completed = %v, want only aaa-111", ids)
+	}
+
+	if _, err := ResolveTag("v9.9.9"); err == nil {
+		t.Error("expected error for a missing tag")
+	}
+}
+
+func TestResolvedAtFindsStartOfRun(t *testing.T) {
+	repo, c := setupTagRepo(t)
+	base := time.Now().Add(-10 * time.Hour).Truncate(time.Second)
+
+	if err := c.Create(&issue.Issue{ID: "aaa-111", Slug: "flip", Title: "Flip", Status: "completed"}); err != nil {
+		t.Fatal(err)
+	}
+	commitAll(t, repo, base, "done early")
+	setStatus(t, c, "aaa-111", "in-progress")
+	commitAll(t, repo, base.Add(time.Hour), "reopened")
+	setStatus(t, c, "aaa-111", "review")
+	commitAll(t, repo, base.Add(2*time.Hour), "in review")
+	setStatus(t, c, "aaa-111", "completed")
+	commitAll(t, repo, base.Add(3*time.Hour), "done")
+
+	b, _ := c.Get("aaa-111")
+	at, ok := ResolvedAt(c.Root(), b)
+	if !ok || !at.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("ResolvedAt() = %v, %v; want %v", at, ok, base.Add(2*time.Hour))
+	}
+}
+
+func TestLatestTagWithoutTags(t *testing.T) {
+	repo, c := setupTagRepo(t)
+	if err := c.Create(&issue.Issue{ID: "aaa-111", Title: "One", Status: "ready"}); err != nil {
+		t.Fatal(err)
+	}
+	commitAll(t, repo, time.Now(), "first")
+	runGit(t, repo, time.Now(), "tag", "nightly")
+
+	if _, err := LatestTag(); !errors.Is(err, ErrNoTags) {
+		t.Errorf("LatestTag() error = %v, want ErrNoTags", err)
+	}
+}
@@ -0,0 +1,214 @@
+package changes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// MaxBurndownPoints bounds how many intervals a burndown spans, so a small
+// interval over a long milestone fails fast instead of running git hundreds
+// of thousands of times.
+const MaxBurndownPoints = 1000
+
+// BurndownOptions describe the burndown to reconstruct.
+type BurndownOptions struct {
+	// Milestone is the ID of the milestone whose issues are counted: those
+	// assigned to it and every issue below them.
+	Milestone string
+	// Since and Until bound the series; Until is usually now.
+	Since, Until time.Time
+	// Interval separates the points of the series.
+	Interval time.Duration
+	// DataDir is the issues directory, whose git history is read when it is
+	// tracked.
+	DataDir string
+	// Parse parses issue files read from git.
+	Parse ParseFunc
+	// Current is the store as it is now, counted at Until and used for every
+	// point when there is no git history.
+	Current []*issue.Issue
+}
+
+// BurndownPoint counts a milestone's issues at one point in time.
+type BurndownPoint struct {
+	At    time.Time `json:"at"`
+	Open  int       `json:"open"`
+	Total int       `json:"total"`
+	// Added and Removed list the issues that joined or left the milestone
+	// since the previous point; Completed and Reopened those that were
+	// closed or reopened while in it. All are sorted by ID.
+	Added     []string `json:"added,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+	Completed []string `json:"completed,omitempty"`
+	Reopened  []string `json:"reopened,omitempty"`
+}
+
+// Burndown is the number of open issues in a milestone over time.
+type Burndown struct {
+	Milestone string    `json:"milestone"`
+	Since     time.Time `json:"since"`
+	// Source is SourceGit when each point was read from the issue files as
+	// committed at that time, or SourceUpdatedAt when only created_at and
+	// updated_at were available: issues are counted from their creation,
+	// with a closed issue's updated_at standing in for when it was closed.
+	Source string          `json:"source"`
+	Points []BurndownPoint `json:"points"`
+}
+
+// membership maps the ID of each issue in a milestone to whether it is open.
+type membership map[string]bool
+
+// BuildBurndown reconstructs a milestone's burndown from opts.Since to
+// opts.Until, one point per interval plus a final point at Until. It falls
+// back from git history to timestamps when the data directory is not
+// tracked.
+func BuildBurndown(opts BurndownOptions) (*Burndown, error) {
+	if opts.Interval <= 0 {
+		return nil, fmt.Errorf("burndown interval must be positive, got %s", opts.Interval)
+	}
+	if !opts.Since.Before(opts.Until) {
+		return nil, fmt.Errorf("burndown start %s is not before its end %s", opts.Since.Format(time.RFC3339), opts.Until.Format(time.RFC3339))
+	}
+	times := burndownTimes(opts.Since, opts.Until, opts.Interval)
+	if len(times) > MaxBurndownPoints {
+		return nil, fmt.Errorf("%s intervals since %s make more than %d points; use a longer interval or a later start",
+			opts.Interval, opts.Since.Format(time.DateOnly), MaxBurndownPoints)
+	}
+
+	bd := &Burndown{Milestone: opts.Milestone, Since: opts.Since, Source: SourceGit}
+	current := issueMap(opts.Current)
+	states := make([]membership, len(times))
+	for i, at := range times {
+		if i == len(times)-1 {
+			states[i] = milestoneMembers(current, opts.Milestone)
+			continue
+		}
+		if bd.Source == SourceGit {
+			// Whether the directory is tracked does not depend on at, so
+			// ErrNoGit surfaces on the first point, before any are read.
+			state, _, err := GitState(opts.DataDir, at, opts.Parse)
+			switch {
+			case errors.Is(err, ErrNoGit):
+				bd.Source = SourceUpdatedAt
+			case err != nil:
+				return nil, err
+			default:
+				states[i] = milestoneMembers(state, opts.Milestone)
+				continue
+			}
+		}
+		states[i] = timestampMembers(opts.Current, opts.Milestone, at)
+	}
+
+	bd.Points = make([]BurndownPoint, len(times))
+	var prev membership
+	for i, at := range times {
+		bd.Points[i] = burndownPoint(at, prev, states[i])
+		prev = states[i]
+	}
+	return bd, nil
+}
+
+// burndownTimes returns since, each interval after it before until, and
+// until.
+func burndownTimes(since, until time.Time, interval time.Duration) []time.Time {
+	var times []time.Time
+	for at := since; at.Before(until); at = at.Add(interval) {
+		times = append(times, at)
+		if len(times) > MaxBurndownPoints {
+			break
+		}
+	}
+	return append(times, until)
+}
+
+// burndownPoint counts cur and lists what changed since prev. The first
+// point, with a nil prev, is the baseline and lists nothing.
+func burndownPoint(at time.Time, prev, cur membership) BurndownPoint {
+	p := BurndownPoint{At: at, Total: len(cur)}
+	for id, open := range cur {
+		if open {
+			p.Open++
+		}
+		if prev == nil {
+			continue
+		}
+		wasOpen, existed := prev[id]
+		switch {
+		case !existed:
+			p.Added = append(p.Added, id)
+		case wasOpen && !open:
+			p.Completed = append(p.Completed, id)
+		case !wasOpen && open:
+			p.Reopened = append(p.Reopened, id)
+		}
+	}
+	for id := range prev {
+		if _, ok := cur[id]; !ok {
+			p.Removed = append(p.Removed, id)
+		}
+	}
+	for _, ids := range []*[]string{&p.Added, &p.Removed, &p.Completed, &p.Reopened} {
+		slices.Sort(*ids)
+	}
+	return p
+}
+
+// milestoneMembers returns the issues in state that belong to the milestone:
+// those assigned to it, and those whose parent chain reaches one.
+func milestoneMembers(state map[string]*issue.Issue, milestone string) membership {
+	members := membership{}
+	for id, b := range state {
+		if inMilestone(state, b, milestone) {
+			members[id] = !isClosed(b.Status)
+		}
+	}
+	return members
+}
+
+// timestampMembers approximates the milestone's issues at t from their
+// current state: every current member created by t, open unless it is
+// closed now and was last updated by t.
+func timestampMembers(current []*issue.Issue, milestone string, t time.Time) membership {
+	state := issueMap(current)
+	members := membership{}
+	for _, b := range current {
+		if b.CreatedAt != nil && b.CreatedAt.After(t) {
+			continue
+		}
+		if !inMilestone(state, b, milestone) {
+			continue
+		}
+		closedBy := isClosed(b.Status) && (b.UpdatedAt == nil || !b.UpdatedAt.After(t))
+		members[b.ID] = !closedBy
+	}
+	return members
+}
+
+func inMilestone(state map[string]*issue.Issue, b *issue.Issue, milestone string) bool {
+	seen := map[string]bool{}
+	for cur := b; cur != nil && !seen[cur.ID]; cur = state[cur.Parent] {
+		if cur.Milestone == milestone {
+			return true
+		}
+		seen[cur.ID] = true
+	}
+	return false
+}
+
+func isClosed(status string) bool {
+	return status == config.StatusCompleted || status == config.StatusScrapped
+}
+
+func issueMap(issues []*issue.Issue) map[string]*issue.Issue {
+	m := make(map[string]*issue.Issue, len(issues))
+	for _, b := range issues {
+		m[b.ID] = b
+	}
+	return m
+}
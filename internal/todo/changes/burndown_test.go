@@ -0,0 +1,186 @@
+package changes
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// summarizePoints renders each point as "open/total" followed by the
+// issues added (+), removed (-), completed (✓), and reopened (↺).
+func summarizePoints(points []BurndownPoint) []string {
+	var s []string
+	for _, p := range points {
+		line := fmt.Sprintf("%d/%d", p.Open, p.Total)
+		for _, part := range []struct {
+			mark string
+			ids  []string
+		}{{"+", p.Added}, {"-", p.Removed}, {"✓", p.Completed}, {"↺", p.Reopened}} {
+			if len(part.ids) > 0 {
+				line += " " + part.mark + strings.Join(part.ids, ",")
+			}
+		}
+		s = append(s, line)
+	}
+	return s
+}
+
+func updateIssue(t *testing.T, c *core.Core, id string, change func(*issue.Issue)) {
+	t.Helper()
+	b, err := c.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change(b)
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildBurndownFromGit(t *testing.T) {
+	repo, c := setupRepo(t)
+	base := time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -10)
+	if err := c.CreateMilestone(&issue.Milestone{ID: "ms-1", Name: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Day 0: two issues in the milestone, a child of one, and an unrelated one.
+	for _, b := range []*issue.Issue{
+		{ID: "aaa-111", Slug: "epic", Title: "Epic", Status: "ready", Milestone: "ms-1"},
+		{ID: "bbb-222", Slug: "task", Title: "Task", Status: "ready", Milestone: "ms-1"},
+		{ID: "ccc-333", Slug: "child", Title: "Child", Status: "ready", Parent: "aaa-111"},
+		{ID: "ddd-444", Slug: "other", Title: "Other", Status: "ready"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitCommit(t, repo, base.Add(time.Hour), "day 0")
+
+	// Day 1: one is completed.
+	updateIssue(t, c, "bbb-222", func(b *issue.Issue) { b.Status = config.StatusCompleted })
+	gitCommit(t, repo, base.Add(25*time.Hour), "day 1")
+
+	// Day 2: scope grows by a new issue and an existing one moved in.
+	if err := c.Create(&issue.Issue{ID: "eee-555", Slug: "late", Title: "Late", Status: "ready", Milestone: "ms-1"}); err != nil {
+		t.Fatal(err)
+	}
+	updateIssue(t, c, "ddd-444", func(b *issue.Issue) { b.Milestone = "ms-1" })
+	gitCommit(t, repo, base.Add(49*time.Hour), "day 2")
+
+	// Day 3, uncommitted: the child is done (completing its parent too), the
+	// completed one reopened, and the late issue dropped from the milestone.
+	updateIssue(t, c, "ccc-333", func(b *issue.Issue) { b.Status = config.StatusCompleted })
+	updateIssue(t, c, "bbb-222", func(b *issue.Issue) { b.Status = "in-progress" })
+	updateIssue(t, c, "eee-555", func(b *issue.Issue) { b.Milestone = "" })
+
+	bd, err := BuildBurndown(BurndownOptions{
+		Milestone: "ms-1",
+		Since:     base.Add(2 * time.Hour),
+		Until:     base.Add(74 * time.Hour),
+		Interval:  config.Day,
+		DataDir:   c.Root(),
+		Parse:     c.ParseIssue,
+		Current:   c.All(),
+	})
+	if err != nil {
+		t.Fatalf("BuildBurndown() error = %v", err)
+	}
+	if bd.Source != SourceGit {
+		t.Errorf("Source = %q, want %q", bd.Source, SourceGit)
+	}
+	got := summarizePoints(bd.Points)
+	want := []string{
+		"3/3",
+		"2/3 ✓bbb-222",
+		"4/5 +ddd-444,eee-555",
+		"2/4 -eee-555 ✓aaa-111,ccc-333 ↺bbb-222",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("points =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !bd.Points[3].At.Equal(base.Add(74 * time.Hour)) {
+		t.Errorf("last point at %s, want until", bd.Points[3].At)
+	}
+}
+
+func TestBuildBurndownFromTimestamps(t *testing.T) {
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").CombinedOutput(); err == nil {
+		t.Skipf("temp directory is inside a git repository: %s", out)
+	}
+	c := core.New(dir, config.Default())
+	c.SetWarnWriter(nil)
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) {
+		c.SetClock(func() time.Time { return base.Add(time.Duration(hours) * time.Hour) })
+	}
+
+	at(0)
+	for _, b := range []*issue.Issue{
+		{ID: "aaa-111", Slug: "first", Title: "First", Status: "ready", Milestone: "ms-1"},
+		{ID: "bbb-222", Slug: "second", Title: "Second", Status: "ready", Milestone: "ms-1"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	at(30)
+	updateIssue(t, c, "aaa-111", func(b *issue.Issue) { b.Status = config.StatusCompleted })
+	at(40)
+	if err := c.Create(&issue.Issue{ID: "ccc-333", Slug: "added", Title: "Added", Status: "ready", Milestone: "ms-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	bd, err := BuildBurndown(BurndownOptions{
+		Milestone: "ms-1",
+		Since:     base.Add(time.Hour),
+		Until:     base.Add(50 * time.Hour),
+		Interval:  config.Day,
+		DataDir:   c.Root(),
+		Parse:     c.ParseIssue,
+		Current:   c.All(),
+	})
+	if err != nil {
+		t.Fatalf("BuildBurndown() error = %v", err)
+	}
+	if bd.Source != SourceUpdatedAt {
+		t.Errorf("Source = %q, want %q", bd.Source, SourceUpdatedAt)
+	}
+	got := summarizePoints(bd.Points)
+	want := []string{"2/2", "2/2", "2/3 +ccc-333 ✓aaa-111", "2/3"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("points = %q, want %q", got, want)
+	}
+}
+
+func TestBuildBurndownLimits(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		since    time.Time
+		interval time.Duration
+		want     string
+	}{
+		{"zero interval", now.Add(-time.Hour), 0, "interval must be positive"},
+		{"start after end", now.Add(time.Hour), time.Hour, "is not before its end"},
+		{"too many points", now.AddDate(0, 0, -30), time.Minute, "more than 1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildBurndown(BurndownOptions{Milestone: "ms-1", Since: tt.since, Until: now, Interval: tt.interval})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("BuildBurndown() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+// Package changes compares two states of the issue store, for digests of
+// what changed over a work session.
+package changes
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Kinds of change.
+const (
+	KindCreated  = "created"
+	KindDeleted  = "deleted"
+	KindModified = "modified"
+	// KindUpdated is a change known only from updated_at, with no record of
+	// the fields involved.
+	KindUpdated = "updated"
+)
+
+// Sources of the earlier state a report compares against.
+const (
+	SourceGit       = "git"
+	SourceSnapshot  = "snapshot"
+	SourceUpdatedAt = "updated_at"
+)
+
+// Change is one issue's difference between two states.
+type Change struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Kind  string `json:"kind"`
+	// Fields lists the front matter fields that changed, plus "body", in a
+	// fixed order. Only set for modified issues.
+	Fields     []string `json:"fields,omitempty"`
+	StatusFrom string   `json:"status_from,omitempty"`
+	StatusTo   string   `json:"status_to,omitempty"`
+	// BodyAdded and BodyRemoved count changed body lines. Both stay zero
+	// when only the ciphertext of an encrypted body is known.
+	BodyAdded   int `json:"body_added,omitempty"`
+	BodyRemoved int `json:"body_removed,omitempty"`
+}
+
+// StatusChanged reports whether the issue moved between statuses.
+func (c Change) StatusChanged() bool {
+	return c.StatusFrom != c.StatusTo
+}
+
+// Report is the set of changes since a point in time.
+type Report struct {
+	Since  time.Time `json:"since"`
+	Source string    `json:"source"`
+	// Commit is the git commit the earlier state was read from.
+	Commit  string   `json:"commit,omitempty"`
+	Changes []Change `json:"changes"`
+}
+
+// field is a compared issue field.
+type field struct {
+	name  string
+	value func(*issue.Issue) string
+}
+
+func joined(s []string) string { return strings.Join(s, "\x00") }
+
+var fields = []field{
+	{"title", func(b *issue.Issue) string { return b.Title }},
+	{"summary", func(b *issue.Issue) string { return b.Summary }},
+	{"status", func(b *issue.Issue) string { return b.Status }},
+	{"type", func(b *issue.Issue) string { return b.Type }},
+	{"priority", func(b *issue.Issue) string { return b.Priority }},
+	{"milestone", func(b *issue.Issue) string { return b.Milestone }},
+	{"iteration", func(b *issue.Issue) string { return b.Iteration }},
+	{"tags", func(b *issue.Issue) string { return joined(b.Tags) }},
+	{"due", func(b *issue.Issue) string {
+		if b.Due == nil {
+			return ""
+		}
+		return b.Due.String()
+	}},
+	{"pinned", func(b *issue.Issue) string { return fmt.Sprint(b.Pinned) }},
+	{"visibility", func(b *issue.Issue) string { return b.Visibility }},
+	{"parent", func(b *issue.Issue) string { return b.Parent }},
+	{"blocking", func(b *issue.Issue) string { return joined(b.Blocking) }},
+	{"blocked_by", func(b *issue.Issue) string { return joined(b.BlockedBy) }},
+	{"encrypted", func(b *issue.Issue) string { return fmt.Sprint(b.Encrypted) }},
+}
+
+// Diff compares two states of the store, each keyed by issue ID, and
+// returns a change for every issue created, deleted, or modified, sorted by
+// ID. Timestamps and sync metadata are not compared.
+func Diff(before, after map[string]*issue.Issue) []Change {
+	var result []Change
+	for id, b := range after {
+		old, ok := before[id]
+		if !ok {
+			result = append(result, Change{ID: id, Title: b.Title, Kind: KindCreated, StatusTo: b.Status, StatusFrom: b.Status})
+			continue
+		}
+		if c, changed := diffIssue(old, b); changed {
+			result = append(result, c)
+		}
+	}
+	for id, old := range before {
+		if _, ok := after[id]; !ok {
+			result = append(result, Change{ID: id, Title: old.Title, Kind: KindDeleted, StatusFrom: old.Status, StatusTo: old.Status})
+		}
+	}
+	slices.SortFunc(result, func(a, b Change) int { return cmp.Compare(a.ID, b.ID) })
+	return result
+}
+
+func diffIssue(old, b *issue.Issue) (Change, bool) {
+	c := Change{ID: b.ID, Title: b.Title, Kind: KindModified, StatusFrom: old.Status, StatusTo: b.Status}
+	for _, f := range fields {
+		if f.value(old) != f.value(b) {
+			c.Fields = append(c.Fields, f.name)
+		}
+	}
+	if oldBody, newBody, ok := comparableBodies(old, b); ok {
+		if oldBody != newBody {
+			c.Fields = append(c.Fields, "body")
+			c.BodyAdded, c.BodyRemoved = LineDelta(oldBody, newBody)
+		}
+	} else if old.Ciphertext != "" && b.Ciphertext != "" && old.Ciphertext != b.Ciphertext {
+		// Snapshots do not record ciphertext, so a locked body can only be
+		// compared between two versions read from files.
+		c.Fields = append(c.Fields, "body")
+	}
+	return c, len(c.Fields) > 0
+}
+
+// ChangedFields lists the fields that differ between two versions of an
+// issue, in the order Change.Fields uses.
+func ChangedFields(old, b *issue.Issue) []string {
+	c, _ := diffIssue(old, b)
+	return c.Fields
+}
+
+// FieldValue returns the named field of b as ChangedFields compares it, with
+// list fields joined by commas. "body" is the plaintext body.
+func FieldValue(b *issue.Issue, name string) string {
+	if name == "body" {
+		return b.Body
+	}
+	for _, f := range fields {
+		if f.name == name {
+			return strings.ReplaceAll(f.value(b), "\x00", ", ")
+		}
+	}
+	return ""
+}
+
+// comparableBodies returns the plaintext bodies of old and b, or false when
+// either one is encrypted without its key.
+func comparableBodies(old, b *issue.Issue) (string, string, bool) {
+	locked := func(b *issue.Issue) bool { return b.Encrypted && b.Body == issue.EncryptedPlaceholder }
+	if locked(old) || locked(b) {
+		return "", "", false
+	}
+	return old.Body, b.Body, true
+}
+
+// LineDelta counts the lines added and removed between two texts, as a
+// line diff would: lines outside their longest common subsequence.
+func LineDelta(before, after string) (added, removed int) {
+	a, b := splitLines(before), splitLines(after)
+	// Trim the common prefix and suffix so typical edits stay cheap.
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	common := prev[len(b)]
+	return len(b) - common, len(a) - common
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// SinceUpdated lists the issues created or updated at or after since, for
+// when no earlier state is available. Deletions and field names are
+// unknown.
+func SinceUpdated(issues []*issue.Issue, since time.Time) []Change {
+	var result []Change
+	for _, b := range issues {
+		c := Change{ID: b.ID, Title: b.Title, StatusFrom: b.Status, StatusTo: b.Status}
+		switch {
+		case b.CreatedAt != nil && !b.CreatedAt.Before(since):
+			c.Kind = KindCreated
+		case b.UpdatedAt != nil && !b.UpdatedAt.Before(since):
+			c.Kind = KindUpdated
+		default:
+			continue
+		}
+		result = append(result, c)
+	}
+	slices.SortFunc(result, func(a, b Change) int { return cmp.Compare(a.ID, b.ID) })
+	return result
+}
+
+// Group is a set of changes shown under one heading.
+type Group struct {
+	Label   string   `json:"label"`
+	Changes []Change `json:"changes"`
+}
+
+// Groups arranges changes for display: created issues first, then one group
+// per status moved to (in the order of statuses, with unknown ones after
+// in name order), then issues modified or updated without a status change,
+// then deleted issues. Empty groups are left out.
+func Groups(changes []Change, statuses []string) []Group {
+	var created, modified, deleted []Change
+	moved := make(map[string][]Change)
+	for _, c := range changes {
+		switch {
+		case c.Kind == KindCreated:
+			created = append(created, c)
+		case c.Kind == KindDeleted:
+			deleted = append(deleted, c)
+		case c.StatusChanged():
+			moved[c.StatusTo] = append(moved[c.StatusTo], c)
+		default:
+			modified = append(modified, c)
+		}
+	}
+
+	var targets []string
+	for status := range moved {
+		targets = append(targets, status)
+	}
+	rank := func(s string) int {
+		if i := slices.Index(statuses, s); i >= 0 {
+			return i
+		}
+		return len(statuses)
+	}
+	slices.SortFunc(targets, func(a, b string) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(a, b))
+	})
+
+	var groups []Group
+	add := func(label string, cs []Change) {
+		if len(cs) > 0 {
+			groups = append(groups, Group{Label: label, Changes: cs})
+		}
+	}
+	add("created", created)
+	for _, status := range targets {
+		add("moved to "+status, moved[status])
+	}
+	add("modified", modified)
+	add("deleted", deleted)
+	return groups
+}
+
+// ReadSnapshot loads a snapshot written by WriteSnapshot.
+func ReadSnapshot(path string) (map[string]*issue.Issue, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-supplied snapshot path
+	if err != nil {
+		return nil, err
+	}
+	var list []*issue.Issue
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	state := make(map[string]*issue.Issue, len(list))
+	for _, b := range list {
+		state[b.ID] = b
+	}
+	return state, nil
+}
+
+// WriteSnapshot records issues to path, for a later comparison with
+// ReadSnapshot where the data directory is not tracked in git.
+func WriteSnapshot(path string, issues []*issue.Issue) error {
+	sorted := slices.Clone(issues)
+	slices.SortFunc(sorted, func(a, b *issue.Issue) int { return cmp.Compare(a.ID, b.ID) })
+	data, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
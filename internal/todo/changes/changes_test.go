@@ -0,0 +1,140 @@
+package changes
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestDiff(t *testing.T) {
+	before := map[string]*issue.Issue{
+		"aaa": {ID: "aaa", Title: "Same", Status: "ready", Body: "x"},
+		"bbb": {ID: "bbb", Title: "Moved", Status: "ready", Priority: "normal", Body: "one\ntwo\nthree"},
+		"ccc": {ID: "ccc", Title: "Gone", Status: "draft"},
+		"ddd": {ID: "ddd", Title: "Tagged", Status: "ready", Tags: []string{}},
+	}
+	later := time.Now()
+	after := map[string]*issue.Issue{
+		"aaa": {ID: "aaa", Title: "Same", Status: "ready", Body: "x", UpdatedAt: &later},
+		"bbb": {ID: "bbb", Title: "Moved", Status: "review", Priority: "high", Body: "one\n2\nthree\nfour"},
+		"ddd": {ID: "ddd", Title: "Tagged", Status: "ready", Tags: []string{"ui"}},
+		"eee": {ID: "eee", Title: "New", Status: "draft"},
+	}
+
+	got := Diff(before, after)
+	want := []Change{
+		{ID: "bbb", Title: "Moved", Kind: KindModified, Fields: []string{"status", "priority", "body"}, StatusFrom: "ready", StatusTo: "review", BodyAdded: 2, BodyRemoved: 1},
+		{ID: "ccc", Title: "Gone", Kind: KindDeleted, StatusFrom: "draft", StatusTo: "draft"},
+		{ID: "ddd", Title: "Tagged", Kind: KindModified, Fields: []string{"tags"}, StatusFrom: "ready", StatusTo: "ready"},
+		{ID: "eee", Title: "New", Kind: KindCreated, StatusFrom: "draft", StatusTo: "draft"},
+	}
+	if !slices.EqualFunc(got, want, changeEqual) {
+		t.Errorf("Diff() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDiffLockedBody(t *testing.T) {
+	locked := func(ciphertext string) *issue.Issue {
+		return &issue.Issue{ID: "aaa", Title: "Secret", Status: "ready", Encrypted: true, Body: issue.EncryptedPlaceholder, Ciphertext: ciphertext}
+	}
+	got := Diff(map[string]*issue.Issue{"aaa": locked("c1")}, map[string]*issue.Issue{"aaa": locked("c2")})
+	if len(got) != 1 || !slices.Equal(got[0].Fields, []string{"body"}) || got[0].BodyAdded != 0 {
+		t.Errorf("changed ciphertext: got %+v, want body changed with no line counts", got)
+	}
+	if got := Diff(map[string]*issue.Issue{"aaa": locked("")}, map[string]*issue.Issue{"aaa": locked("c2")}); len(got) != 0 {
+		t.Errorf("snapshot without ciphertext: got %+v, want no change", got)
+	}
+}
+
+func TestLineDelta(t *testing.T) {
+	tests := []struct {
+		before, after  string
+		added, removed int
+	}{
+		{"", "", 0, 0},
+		{"", "a\nb", 2, 0},
+		{"a\nb", "", 0, 2},
+		{"a\nb\nc", "a\nc", 0, 1},
+		{"a\nb\nc", "a\nB\nc\nd", 2, 1},
+		{"a\nb\nc\nd", "d\na\nb\nc", 1, 1},
+	}
+	for _, tt := range tests {
+		added, removed := LineDelta(tt.before, tt.after)
+		if added != tt.added || removed != tt.removed {
+			t.Errorf("LineDelta(%q, %q) = +%d/-%d, want +%d/-%d", tt.before, tt.after, added, removed, tt.added, tt.removed)
+		}
+	}
+}
+
+func TestSinceUpdated(t *testing.T) {
+	since := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	at := func(h int) *time.Time {
+		t := since.Add(time.Duration(h) * time.Hour)
+		return &t
+	}
+	issues := []*issue.Issue{
+		{ID: "old", Title: "Old", Status: "ready", CreatedAt: at(-5), UpdatedAt: at(-4)},
+		{ID: "upd", Title: "Updated", Status: "review", CreatedAt: at(-5), UpdatedAt: at(1)},
+		{ID: "new", Title: "New", Status: "draft", CreatedAt: at(0), UpdatedAt: at(2)},
+	}
+	got := SinceUpdated(issues, since)
+	want := []Change{
+		{ID: "new", Title: "New", Kind: KindCreated, StatusFrom: "draft", StatusTo: "draft"},
+		{ID: "upd", Title: "Updated", Kind: KindUpdated, StatusFrom: "review", StatusTo: "review"},
+	}
+	if !slices.EqualFunc(got, want, changeEqual) {
+		t.Errorf("SinceUpdated() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	changes := []Change{
+		{ID: "a", Kind: KindDeleted},
+		{ID: "b", Kind: KindModified, StatusFrom: "ready", StatusTo: "review"},
+		{ID: "c", Kind: KindModified, StatusFrom: "ready", StatusTo: "ready", Fields: []string{"tags"}},
+		{ID: "d", Kind: KindModified, StatusFrom: "review", StatusTo: "completed"},
+		{ID: "e", Kind: KindCreated},
+		{ID: "f", Kind: KindModified, StatusFrom: "draft", StatusTo: "review"},
+		{ID: "g", Kind: KindModified, StatusFrom: "draft", StatusTo: "legacy"},
+	}
+	var got []string
+	for _, g := range Groups(changes, []string{"in-progress", "review", "ready", "completed"}) {
+		ids := ""
+		for _, c := range g.Changes {
+			ids += c.ID
+		}
+		got = append(got, g.Label+":"+ids)
+	}
+	want := []string{"created:e", "moved to review:bf", "moved to completed:d", "moved to legacy:g", "modified:c", "deleted:a"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Groups() = %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	issues := []*issue.Issue{
+		{ID: "bbb", Title: "B", Status: "ready", Body: "body", Tags: []string{"x"}},
+		{ID: "aaa", Title: "A", Status: "draft"},
+	}
+	if err := WriteSnapshot(path, issues); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	state, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+	after := map[string]*issue.Issue{"aaa": issues[1], "bbb": issues[0]}
+	if got := Diff(state, after); len(got) != 0 {
+		t.Errorf("Diff(snapshot, same issues) = %+v, want none", got)
+	}
+}
+
+func changeEqual(a, b Change) bool {
+	return a.ID == b.ID && a.Title == b.Title && a.Kind == b.Kind &&
+		slices.Equal(a.Fields, b.Fields) && a.StatusFrom == b.StatusFrom && a.StatusTo == b.StatusTo &&
+		a.BodyAdded == b.BodyAdded && a.BodyRemoved == b.BodyRemoved
+}
@@ -0,0 +1,195 @@
+package changes
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// ErrNoGit is returned by GitState when the data directory has no git
+// history to compare against: git is missing, the directory is outside a
+// repository, or none of its files are tracked.
+var ErrNoGit = errors.New("data directory is not tracked in git")
+
+// ParseFunc parses an issue file's content as though it were stored at
+// relPath under the data directory. core.Core.ParseIssue is one.
+type ParseFunc func(relPath string, r io.Reader) (*issue.Issue, error)
+
+// GitState returns the issues in dataDir as committed in the last commit
+// before at, keyed by ID, with that commit's hash. When the repository has
+// no commit that old, the state is empty and the hash is "". Files that do
+// not parse as issues are skipped, as Load skips them.
+func GitState(dataDir string, at time.Time, parse ParseFunc) (map[string]*issue.Issue, string, error) {
+	dir, err := filepath.Abs(dataDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	tracked, err := git(dir, "ls-files", "--", ".")
+	if err != nil || len(bytes.TrimSpace(tracked)) == 0 {
+		return nil, "", ErrNoGit
+	}
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, "", ErrNoGit
+	}
+	rel, err := filepath.Rel(strings.TrimSpace(string(top)), dir)
+	if err != nil {
+		return nil, "", err
+	}
+	rel = filepath.ToSlash(rel)
+
+	out, err := git(dir, "rev-list", "-1", "--before="+at.Format(time.RFC3339), "HEAD")
+	if err != nil {
+		return nil, "", fmt.Errorf("finding commit before %s: %w", at.Format(time.RFC3339), err)
+	}
+	rev := strings.TrimSpace(string(out))
+	state := make(map[string]*issue.Issue)
+	if rev == "" {
+		return state, "", nil
+	}
+
+	out, err = git(dir, "ls-tree", "-r", "-z", "--name-only", "--full-tree", rev, "--", rel)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing %s at %s: %w", rel, rev, err)
+	}
+	var files []string
+	for name := range strings.SplitSeq(strings.TrimSuffix(string(out), "\x00"), "\x00") {
+		if inner, ok := issueFile(rel, name); ok {
+			files = append(files, inner)
+		}
+	}
+	if len(files) == 0 {
+		return state, rev, nil
+	}
+
+	contents, err := catFiles(dir, rev, rel, files)
+	if err != nil {
+		return nil, "", err
+	}
+	for i, name := range files {
+		b, err := parse(filepath.FromSlash(name), bytes.NewReader(contents[i]))
+		if err != nil {
+			continue
+		}
+		state[b.ID] = b
+	}
+	return state, rev, nil
+}
+
+// GitVersions returns up to limit earlier contents of the issue file at
+// relPath under dataDir, newest first: the staged version, then the version
+// in each commit that changed it. It returns ErrNoGit when the file is not
+// tracked.
+func GitVersions(dataDir, relPath string, limit int) ([][]byte, error) {
+	dir, err := filepath.Abs(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	file := "./" + filepath.ToSlash(relPath)
+	if _, err := git(dir, "ls-files", "--error-unmatch", "--", file); err != nil {
+		return nil, ErrNoGit
+	}
+
+	var versions [][]byte
+	if staged, err := git(dir, "show", ":"+file); err == nil {
+		versions = append(versions, staged)
+	}
+	out, err := git(dir, "log", fmt.Sprintf("-%d", limit), "--format=%H", "--", file)
+	if err != nil {
+		return nil, fmt.Errorf("listing commits of %s: %w", relPath, err)
+	}
+	for rev := range strings.FieldsSeq(string(out)) {
+		if len(versions) == limit {
+			break
+		}
+		content, err := git(dir, "show", rev+":"+file)
+		if err != nil {
+			continue // renamed or deleted in that commit
+		}
+		versions = append(versions, content)
+	}
+	return versions, nil
+}
+
+// issueFile reports whether name, a path from the repository root, is an
+// issue file under rel, returning its path relative to rel. It skips
+// what Load skips: dot directories, the milestones directory, and files
+// other than markdown.
+func issueFile(rel, name string) (string, bool) {
+	inner := name
+	if rel != "." {
+		var ok bool
+		if inner, ok = strings.CutPrefix(name, rel+"/"); !ok {
+			return "", false
+		}
+	}
+	if path.Ext(inner) != ".md" {
+		return "", false
+	}
+	dirs := strings.Split(path.Dir(inner), "/")
+	if dirs[0] == issue.MilestonesDir {
+		return "", false
+	}
+	for _, d := range dirs {
+		if strings.HasPrefix(d, ".") && d != "." {
+			return "", false
+		}
+	}
+	return inner, true
+}
+
+// catFiles reads the named files at rev in one git process.
+func catFiles(dir, rev, rel string, files []string) ([][]byte, error) {
+	var in bytes.Buffer
+	for _, name := range files {
+		fmt.Fprintf(&in, "%s:%s\n", rev, path.Join(rel, name))
+	}
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = dir
+	cmd.Stdin = &in
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading files at %s: %w", rev, err)
+	}
+
+	r := bufio.NewReader(bytes.NewReader(out))
+	contents := make([][]byte, len(files))
+	for i := range files {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("reading files at %s: %w", rev, err)
+		}
+		var sha, kind string
+		var size int
+		if _, err := fmt.Sscanf(header, "%s %s %d", &sha, &kind, &size); err != nil {
+			// "<object> missing" leaves the file empty, so it is skipped.
+			continue
+		}
+		contents[i] = make([]byte, size)
+		if _, err := io.ReadFull(r, contents[i]); err != nil {
+			return nil, fmt.Errorf("reading files at %s: %w", rev, err)
+		}
+		if _, err := r.Discard(1); err != nil { // trailing newline
+			return nil, fmt.Errorf("reading files at %s: %w", rev, err)
+		}
+	}
+	return contents, nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...) //nolint:gosec // fixed git subcommands
+	cmd.Dir = dir
+	return cmd.Output()
+}
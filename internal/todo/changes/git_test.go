@@ -0,0 +1,204 @@
+package changes
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// gitCommit commits everything in repo with the given commit time.
+func gitCommit(t *testing.T, repo string, at time.Time, msg string) {
+	t.Helper()
+	date := at.Format(time.RFC3339)
+	for _, args := range [][]string{{"add", "-A"}, {"commit", "-q", "-m", msg}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+func setupRepo(t *testing.T) (string, *core.Core) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	dataDir := filepath.Join(repo, core.DataDir)
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	c := core.New(dataDir, config.Default())
+	c.SetWarnWriter(nil)
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return repo, c
+}
+
+func TestGitState(t *testing.T) {
+	repo, c := setupRepo(t)
+	first := time.Now().Add(-6 * time.Hour)
+	second := time.Now().Add(-2 * time.Hour)
+
+	for _, b := range []*issue.Issue{
+		{ID: "aaa-111", Slug: "stays", Title: "Stays", Status: "ready"},
+		{ID: "bbb-222", Slug: "reviewed", Title: "Reviewed", Status: "in-progress", Body: "one\ntwo"},
+		{ID: "ccc-333", Slug: "removed", Title: "Removed", Status: "draft"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A README in the data directory is not an issue and is skipped.
+	if err := os.WriteFile(filepath.Join(c.Root(), "README.md"), []byte("# Issues\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitCommit(t, repo, first, "first")
+
+	b, _ := c.Get("bbb-222")
+	b.Status = "review"
+	b.Body = "one\ntwo\nthree"
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete("ccc-333"); err != nil {
+		t.Fatal(err)
+	}
+	gitCommit(t, repo, second, "second")
+
+	// An uncommitted edit and a new issue are part of the current state.
+	a, _ := c.Get("aaa-111")
+	a.Tags = []string{"ui"}
+	if err := c.Update(a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Create(&issue.Issue{ID: "ddd-444", Slug: "fresh", Title: "Fresh", Status: "draft"}); err != nil {
+		t.Fatal(err)
+	}
+	// Compare against issues as loaded from disk, as the command does.
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	current := make(map[string]*issue.Issue)
+	for _, b := range c.All() {
+		current[b.ID] = b
+	}
+
+	summarize := func(changes []Change) []string {
+		var s []string
+		for _, ch := range changes {
+			s = append(s, ch.ID+" "+ch.Kind+" "+strings.Join(ch.Fields, ","))
+		}
+		return s
+	}
+
+	t.Run("since between commits", func(t *testing.T) {
+		before, commit, err := GitState(c.Root(), first.Add(time.Hour), c.ParseIssue)
+		if err != nil {
+			t.Fatalf("GitState: %v", err)
+		}
+		if commit == "" {
+			t.Fatal("GitState returned no commit")
+		}
+		got := summarize(Diff(before, current))
+		want := []string{"aaa-111 modified tags", "bbb-222 modified status,body", "ccc-333 deleted ", "ddd-444 created "}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("changes = %q, want %q", got, want)
+		}
+		for _, ch := range Diff(before, current) {
+			if ch.ID == "bbb-222" && (ch.StatusFrom != "in-progress" || ch.StatusTo != "review" || ch.BodyAdded != 1 || ch.BodyRemoved != 0) {
+				t.Errorf("bbb-222 = %+v, want in-progress → review, body +1/-0", ch)
+			}
+		}
+	})
+
+	t.Run("since after last commit", func(t *testing.T) {
+		before, _, err := GitState(c.Root(), second.Add(time.Hour), c.ParseIssue)
+		if err != nil {
+			t.Fatalf("GitState: %v", err)
+		}
+		got := summarize(Diff(before, current))
+		want := []string{"aaa-111 modified tags", "ddd-444 created "}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("changes = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("since before first commit", func(t *testing.T) {
+		before, commit, err := GitState(c.Root(), first.Add(-time.Hour), c.ParseIssue)
+		if err != nil {
+			t.Fatalf("GitState: %v", err)
+		}
+		if commit != "" || len(before) != 0 {
+			t.Errorf("GitState = %d issues at %q, want empty state and no commit", len(before), commit)
+		}
+	})
+}
+
+func TestGitStateUntracked(t *testing.T) {
+	_, c := setupRepo(t)
+	if err := c.Create(&issue.Issue{ID: "aaa-111", Title: "Untracked", Status: "ready"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := GitState(c.Root(), time.Now(), c.ParseIssue); !errors.Is(err, ErrNoGit) {
+		t.Errorf("GitState() error = %v, want ErrNoGit", err)
+	}
+}
+
+func TestGitVersions(t *testing.T) {
+	repo, c := setupRepo(t)
+	b := &issue.Issue{ID: "aaa-111", Slug: "edited", Title: "First", Status: "ready"}
+	if err := c.Create(b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GitVersions(c.Root(), b.Path, 10); !errors.Is(err, ErrNoGit) {
+		t.Fatalf("untracked file: error = %v, want ErrNoGit", err)
+	}
+	gitCommit(t, repo, time.Now().Add(-time.Hour), "first")
+
+	b.Title = "Second"
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	gitCommit(t, repo, time.Now(), "second")
+
+	versions, err := GitVersions(c.Root(), b.Path, 10)
+	if err != nil {
+		t.Fatalf("GitVersions() error = %v", err)
+	}
+	// Staged (same as the last commit), then each commit newest first.
+	if len(versions) != 3 {
+		t.Fatalf("got %d versions, want 3", len(versions))
+	}
+	for i, want := range []string{"title: Second", "title: Second", "title: First"} {
+		if !strings.Contains(string(versions[i]), want) {
+			t.Errorf("version %d = %q, want %q", i, versions[i], want)
+		}
+	}
+	if versions, _ := GitVersions(c.Root(), b.Path, 2); len(versions) != 2 {
+		t.Errorf("limit 2 gave %d versions", len(versions))
+	}
+}
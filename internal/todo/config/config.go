@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/toba/jig/internal/constants"
 	"gopkg.in/yaml.v3"
@@ -20,10 +22,33 @@ const (
 	LegacyTobaConfigFileName = ".toba.yaml"
 	// LegacyConfigFileName is the old config file name (pre-migration)
 	LegacyConfigFileName = ".todo.yml"
+	// LocalConfigFileName is the uncommitted, machine-local overlay read
+	// alongside ConfigFileName (add it to .gitignore)
+	LocalConfigFileName = ".jig.local.yaml"
+	// IssueKeyEnv names the environment variable holding the issue encryption key
+	IssueKeyEnv = "JIG_ISSUE_KEY"
+	// ServeTokenEnv names the environment variable holding the bearer token
+	// `todo serve` requires
+	ServeTokenEnv = "JIG_SERVE_TOKEN"
+	// WebhookSecretEnvPrefix, followed by the upper-cased provider name
+	// (JIG_WEBHOOK_SECRET_GITHUB), names the environment variable holding
+	// that provider's inbound webhook secret
+	WebhookSecretEnvPrefix = "JIG_WEBHOOK_SECRET_"
 	// DefaultDataPath is the default directory for storing issues
 	DefaultDataPath = ".issues"
 )
 
+// localConfig is the shape of LocalConfigFileName. It holds settings that
+// must not be committed, such as the path to the issue encryption keyfile.
+type localConfig struct {
+	Todo struct {
+		IssueKeyFile   string            `yaml:"issue_key_file,omitempty"`
+		ServeToken     string            `yaml:"serve_token,omitempty"`
+		WebhookSecrets map[string]string `yaml:"webhook_secrets,omitempty"`
+		SplitView      bool              `yaml:"split_view,omitempty"`
+	} `yaml:"todo"`
+}
+
 // JigConfig is the top-level wrapper for the .jig.yaml file format.
 // The todo configuration lives under the "todo" key to support
 // a shared config format where multiple jig tools each have their own section.
@@ -65,22 +90,44 @@ const (
 	PriorityDeferred = "deferred"
 )
 
+// Visibility constants. An issue without a visibility is public; internal
+// issues stay out of exports, changelogs, roadmaps, and sync unless a
+// command is asked to include them.
+const (
+	VisibilityPublic   = "public"
+	VisibilityInternal = "internal"
+)
+
+// VisibilityNames lists the valid visibility values.
+var VisibilityNames = []string{VisibilityPublic, VisibilityInternal}
+
 // Sort order constants.
 const (
 	SortDefault = "default"
 )
 
+// protect_unchecked_tasks modes: what a full body replacement that drops
+// unchecked checkbox items does.
+const (
+	ProtectTasksWarn   = "warn"   // update, with a warning listing the items
+	ProtectTasksStrict = "strict" // reject unless forced
+	ProtectTasksOff    = "off"    // no check
+)
+
+// ProtectTasksModes are the valid protect_unchecked_tasks values.
+var ProtectTasksModes = []string{ProtectTasksWarn, ProtectTasksStrict, ProtectTasksOff}
+
 // DefaultStatuses defines the hardcoded status configuration.
 // Statuses are not configurable - they are hardcoded like types.
 // Order determines sort priority: in-progress first (active work), then review, ready, draft, and done states last.
 var DefaultStatuses = []StatusConfig{
-	{Name: StatusInProgress, Color: "yellow", Description: "Currently being worked on"},
-	{Name: StatusReview, Color: "cyan", Description: "Code complete, awaiting evaluation"},
-	{Name: StatusReady, Color: "green", Description: "Ready to be worked on"},
-	{Name: StatusDraft, Color: "blue", Description: "Needs refinement before it can be worked on"},
-	{Name: StatusDeferred, Color: "pink", Description: "Parked pending further consideration; concerns must be resolved before work can resume"},
-	{Name: StatusCompleted, Color: "gray", Archive: true, Description: "Finished successfully"},
-	{Name: StatusScrapped, Color: "gray", Archive: true, Description: "Will not be done"},
+	{Name: StatusInProgress, Color: "yellow", Icon: "◔", Description: "Currently being worked on"},
+	{Name: StatusReview, Color: "cyan", Icon: "◈", Description: "Code complete, awaiting evaluation"},
+	{Name: StatusReady, Color: "green", Icon: "○", Description: "Ready to be worked on"},
+	{Name: StatusDraft, Color: "blue", Icon: "△", Description: "Needs refinement before it can be worked on"},
+	{Name: StatusDeferred, Color: "pink", Icon: "⏸", Description: "Parked pending further consideration; concerns must be resolved before work can resume"},
+	{Name: StatusCompleted, Color: "gray", Icon: "✔", Archive: true, Description: "Finished successfully"},
+	{Name: StatusScrapped, Color: "gray", Icon: "✖", Archive: true, Description: "Will not be done"},
 }
 
 // DefaultTypes defines the default type configuration.
@@ -89,12 +136,16 @@ var DefaultStatuses = []StatusConfig{
 // entities (see internal/todo/issue.Milestone); the TypeMilestone constant is retained
 // only for backward compatibility with legacy issues and the `milestone migrate` command.
 var DefaultTypes = []TypeConfig{
-	{Name: TypeEpic, Color: "purple", Description: "A thematic container for related work; should have child issues, not be worked on directly"},
-	{Name: TypeBug, Color: "red", Description: "Something that is broken and needs fixing"},
-	{Name: TypeFeature, Color: "green", Description: "A user-facing capability or enhancement"},
-	{Name: TypeTask, Color: "blue", Description: "A concrete piece of work to complete (eg. a chore, or a sub-task for a feature)"},
+	{Name: TypeEpic, Color: "purple", Description: "A thematic container for related work; should have child issues, not be worked on directly", AllowedParents: []string{TypeMilestone}},
+	{Name: TypeBug, Color: "red", Description: "Something that is broken and needs fixing", AllowedParents: DefaultParentTypes},
+	{Name: TypeFeature, Color: "green", Description: "A user-facing capability or enhancement", AllowedParents: []string{TypeMilestone, TypeEpic}},
+	{Name: TypeTask, Color: "blue", Description: "A concrete piece of work to complete (eg. a chore, or a sub-task for a feature)", AllowedParents: DefaultParentTypes},
 }
 
+// DefaultParentTypes are the parent types allowed for a type that does not
+// set allowed_parents.
+var DefaultParentTypes = []string{TypeMilestone, TypeEpic, TypeFeature}
+
 // DefaultPriorities defines the hardcoded priority configuration.
 // Priorities are ordered from highest to lowest urgency.
 var DefaultPriorities = []PriorityConfig{
@@ -111,13 +162,21 @@ type StatusConfig struct {
 	Color       string `yaml:"color"`
 	Archive     bool   `yaml:"archive,omitempty"`
 	Description string `yaml:"description,omitempty"`
+	// Icon is the glyph the TUI and pickers show for the status.
+	Icon string `yaml:"icon,omitempty"`
 }
 
-// TypeConfig defines a single issue type with its display color.
+// TypeConfig defines a single issue type with its display color and the
+// types it may be nested under.
 type TypeConfig struct {
 	Name        string `yaml:"name"`
 	Color       string `yaml:"color"`
 	Description string `yaml:"description,omitempty"`
+	// AllowedParents lists the types an issue of this type may have as its
+	// parent. Empty means DefaultParentTypes.
+	AllowedParents []string `yaml:"allowed_parents,omitempty"`
+	// Icon replaces the two-letter type abbreviation in the TUI.
+	Icon string `yaml:"icon,omitempty"`
 }
 
 // PriorityConfig defines a single priority level with its display color.
@@ -133,17 +192,58 @@ type TagConfig struct {
 	Description string `yaml:"description,omitempty"`
 }
 
+// Default GraphQL execution limits, applied when the config leaves them unset.
+const (
+	DefaultGraphQLMaxDepth      = 12
+	DefaultGraphQLMaxComplexity = 1000
+)
+
+// DefaultMaxHierarchyDepth is the most parents above an issue when the config
+// leaves max_hierarchy_depth unset: enough for milestone → epic → feature →
+// task.
+const DefaultMaxHierarchyDepth = 3
+
+// DefaultSplitViewMinWidth is the narrowest terminal, in columns, the TUI
+// shows its list and preview side by side in when the config leaves
+// split_view_min_width unset.
+const DefaultSplitViewMinWidth = 120
+
+// Default issue size limits, applied when the config leaves them unset.
+const (
+	DefaultMaxBodyBytes        = 1 << 20
+	DefaultMaxFrontmatterBytes = 64 << 10
+)
+
+// GraphQLConfig bounds the cost of a single GraphQL operation so recursive
+// queries over children/blockedBy cannot run unbounded on large repos.
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth,omitempty"`
+	MaxComplexity int `yaml:"max_complexity,omitempty"`
+}
+
+// AutoArchiveConfig is the policy behind `archive --auto`.
+type AutoArchiveConfig struct {
+	// After is how long an issue must go without an update before it is
+	// archived (e.g. "30d"). Empty disables the policy.
+	After string `yaml:"after,omitempty"`
+	// Statuses limits the policy to these archive statuses. Defaults to all
+	// archive statuses (completed and scrapped).
+	Statuses []string `yaml:"statuses,omitempty"`
+	// OnStart offers to run the policy when the TUI starts.
+	OnStart bool `yaml:"on_start,omitempty"`
+}
+
 // Config holds the todo configuration.
 // Note: Statuses are no longer stored in config - they are hardcoded like types.
 type Config struct {
 	// Path is the path to the issues directory (relative to config file location)
-	Path           string      `yaml:"path,omitempty"`
-	DefaultStatus  string      `yaml:"default_status,omitempty"`
-	DefaultType    string      `yaml:"default_type,omitempty"`
-	DefaultSort    string      `yaml:"default_sort,omitempty"`
-	Editor         string      `yaml:"editor,omitempty"`
-	RequireIfMatch bool        `yaml:"require_if_match,omitempty"`
-	Tags           []TagConfig `yaml:"tags,omitempty"`
+	Path           string        `yaml:"path,omitempty"`
+	DefaultStatus  string        `yaml:"default_status,omitempty"`
+	DefaultType    string        `yaml:"default_type,omitempty"`
+	DefaultSort    string        `yaml:"default_sort,omitempty"`
+	Editor         string        `yaml:"editor,omitempty"`
+	RequireIfMatch IfMatchPolicy `yaml:"require_if_match,omitempty"`
+	Tags           []TagConfig   `yaml:"tags,omitempty"`
 	// ExtraStatuses additively opts non-mandatory statuses into this project.
 	// The map is purely additive: an entry of `true` enables that status; an
 	// entry of `false` or a missing entry leaves the status disabled.
@@ -151,6 +251,86 @@ type Config struct {
 	// what this map says.
 	ExtraStatuses map[string]bool           `yaml:"extra_statuses,omitempty"`
 	Sync          map[string]map[string]any `yaml:"sync,omitempty"`
+	// SyncURLTemplates turns sync entries into web URLs, keyed by sync name.
+	// Each {key} in a template is replaced with that key of the entry's sync
+	// data. See SyncURLTemplate.
+	SyncURLTemplates map[string]string `yaml:"sync_url_templates,omitempty"`
+	GraphQL          GraphQLConfig     `yaml:"graphql,omitempty"`
+	// Types adds project-defined issue types or overrides fields of the
+	// built-in ones (matched by name). See TypeConfigs.
+	Types []TypeConfig `yaml:"types,omitempty"`
+	// StaleAfter marks issues in StaleStatuses as stale once they go this long
+	// without an update (e.g. "14d"). Empty means nothing is ever stale.
+	StaleAfter    string   `yaml:"stale_after,omitempty"`
+	StaleStatuses []string `yaml:"stale_statuses,omitempty"`
+	// MaxClockSkew is how far in the future a created_at or updated_at may
+	// be before loading clamps it to the current time (e.g. "36h").
+	// Defaults to DefaultMaxClockSkew.
+	MaxClockSkew string `yaml:"max_clock_skew,omitempty"`
+	// NextStatuses are the statuses `todo next` picks work from. Defaults
+	// to DefaultNextStatuses.
+	NextStatuses []string `yaml:"next_statuses,omitempty"`
+	// TriageStatuses are the statuses a TUI triage session walks. Defaults
+	// to DefaultTriageStatuses.
+	TriageStatuses []string `yaml:"triage_statuses,omitempty"`
+	// AutoArchive archives closed issues once they have gone unchanged for a
+	// while. See AutoArchiveConfig.
+	AutoArchive AutoArchiveConfig `yaml:"auto_archive,omitempty"`
+	// AllowEncryptedSync lets sync providers push decrypted bodies of
+	// encrypted issues. Off by default: encrypted issues are skipped.
+	AllowEncryptedSync bool `yaml:"allow_encrypted_sync,omitempty"`
+	// HideBlockIndicators turns off the blocked/blocking counts in the TUI list.
+	HideBlockIndicators bool `yaml:"hide_block_indicators,omitempty"`
+	// SplitViewMinWidth is the narrowest terminal the TUI split view is
+	// shown in. See GetSplitViewMinWidth.
+	SplitViewMinWidth int `yaml:"split_view_min_width,omitempty"`
+	// SkipMoveNotes stops moveIssue (and `todo move`) from recording moves in
+	// the body's History section.
+	SkipMoveNotes bool `yaml:"skip_move_notes,omitempty"`
+	// MaxBodyBytes and MaxFrontmatterBytes cap the size of an issue's body
+	// and front matter. Larger writes are rejected, and larger files are
+	// skipped on load rather than parsed.
+	MaxBodyBytes        int `yaml:"max_body_bytes,omitempty"`
+	MaxFrontmatterBytes int `yaml:"max_frontmatter_bytes,omitempty"`
+	// KeepBodyRevisions, when positive, keeps that many previous bodies per
+	// issue under .revisions/ in the data directory, one per update that
+	// changes the body.
+	KeepBodyRevisions int `yaml:"keep_body_revisions,omitempty"`
+	// RenameFilesOnTitleChange makes an update that changes an issue's title
+	// regenerate its slug and rename the file to match.
+	RenameFilesOnTitleChange bool `yaml:"rename_files_on_title_change,omitempty"`
+	// MaxHierarchyDepth caps how many parents may sit above an issue, so
+	// accidental deep chains are rejected. See GetMaxHierarchyDepth.
+	MaxHierarchyDepth int `yaml:"max_hierarchy_depth,omitempty"`
+	// Iterations declares the named iterations issues can be assigned to, in
+	// order. ISO week names (2025-W34) are valid without being declared.
+	Iterations []IterationConfig `yaml:"iterations,omitempty"`
+	// DefaultEstimateValue is what `todo plan` counts an issue without an
+	// estimate as (e.g. "4h"). See GetDefaultEstimate.
+	DefaultEstimateValue string `yaml:"default_estimate,omitempty"`
+	// ProtectUncheckedTasks guards against body rewrites that drop
+	// unchecked tasks: warn (the default), strict, or off.
+	ProtectUncheckedTasks string `yaml:"protect_unchecked_tasks,omitempty"`
+	// ValidationRules require fields on issues in a status; creates and
+	// updates that break one are rejected. See ValidationRule.
+	ValidationRules []ValidationRule `yaml:"validation_rules,omitempty"`
+	// IDFormat shapes the IDs of new issues. See GetIDFormat.
+	IDFormat IDFormat `yaml:"id_format,omitempty"`
+	// Watcher tunes the file watcher. See WatcherConfig.
+	Watcher WatcherConfig `yaml:"watcher,omitempty"`
+	// TUI tunes the TUI list. See TUIConfig.
+	TUI TUIConfig `yaml:"tui,omitempty"`
+
+	// issueKeyFile comes from the local overlay only, so it is never written
+	// back to the shared config by Save.
+	issueKeyFile string `yaml:"-"`
+	// serveToken likewise comes from the local overlay only.
+	serveToken string `yaml:"-"`
+	// webhookSecrets (provider name -> secret) likewise.
+	webhookSecrets map[string]string `yaml:"-"`
+	// splitView is the TUI layout preference, kept per machine in the local
+	// overlay. See SetSplitView.
+	splitView bool `yaml:"-"`
 
 	// configDir is the directory containing the config file (not serialized)
 	// Used to resolve relative paths
@@ -167,68 +347,28 @@ func Default() *Config {
 }
 
 // FindConfig searches upward from the given directory for a .jig.yaml config file,
-// falling back to .toba.yaml, then the legacy .todo.yml. If only a .todo.yml is found,
-// it is automatically migrated to .jig.yaml (written in the new format, old file removed).
+// falling back to .toba.yaml, then the legacy .todo.yml, and stopping at the git
+// root as Discover does. If only a .todo.yml is found, it is automatically
+// migrated to .jig.yaml (written in the new format, old file removed).
 // Returns the absolute path to the config file, or empty string if not found.
 func FindConfig(startDir string) (string, error) {
-	dir, err := filepath.Abs(startDir)
+	d, err := Discover(startDir)
 	if err != nil {
 		return "", err
 	}
-
-	for {
-		// Check .jig.yaml first
-		newPath := filepath.Join(dir, ConfigFileName)
-		if _, err := os.Stat(newPath); err == nil {
-			return newPath, nil
-		}
-
-		// Check .toba.yaml as legacy fallback
-		tobaPath := filepath.Join(dir, LegacyTobaConfigFileName)
-		if _, err := os.Stat(tobaPath); err == nil {
-			return tobaPath, nil
-		}
-
-		// Check .todo.yml as oldest legacy fallback
-		legacyPath := filepath.Join(dir, LegacyConfigFileName)
-		if _, err := os.Stat(legacyPath); err == nil {
-			// Auto-migrate legacy config to new format
-			migrated, migrateErr := migrateLegacyConfig(legacyPath, newPath)
-			if migrateErr != nil {
-				return "", fmt.Errorf("migrating %s to %s: %w", LegacyConfigFileName, ConfigFileName, migrateErr)
-			}
-			if migrated {
-				return newPath, nil
-			}
-			// If migration failed silently, fall back to legacy
-			return legacyPath, nil
-		}
-
-		// Check for common typo: .jig.yml instead of .jig.yaml
-		typoPath := filepath.Join(dir, ".jig.yml")
-		if _, err := os.Stat(typoPath); err == nil {
-			return "", errors.New("found .jig.yml but jig expects .jig.yaml — please rename it")
-		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// Reached filesystem root
-			return "", nil
-		}
-		dir = parent
-	}
+	return d.Path, nil
 }
 
 // legacyConfig is used to parse the old .todo.yml format which had
 // an "issues:" top-level key containing the issue settings.
 type legacyConfig struct {
 	Issues struct {
-		Path           string `yaml:"path,omitempty"`
-		DefaultStatus  string `yaml:"default_status,omitempty"`
-		DefaultType    string `yaml:"default_type,omitempty"`
-		DefaultSort    string `yaml:"default_sort,omitempty"`
-		Editor         string `yaml:"editor,omitempty"`
-		RequireIfMatch bool   `yaml:"require_if_match,omitempty"`
+		Path           string        `yaml:"path,omitempty"`
+		DefaultStatus  string        `yaml:"default_status,omitempty"`
+		DefaultType    string        `yaml:"default_type,omitempty"`
+		DefaultSort    string        `yaml:"default_sort,omitempty"`
+		Editor         string        `yaml:"editor,omitempty"`
+		RequireIfMatch IfMatchPolicy `yaml:"require_if_match,omitempty"`
 	} `yaml:"issues"`
 	Sync map[string]map[string]any `yaml:"sync,omitempty"`
 }
@@ -324,9 +464,195 @@ func Load(configPath string) (*Config, error) {
 	cfg.DefaultStatus = cmp.Or(cfg.DefaultStatus, StatusReady)
 	cfg.DefaultType = cmp.Or(cfg.DefaultType, TypeTask)
 
+	if cfg.StaleAfter != "" {
+		if _, err := ParseDuration(cfg.StaleAfter); err != nil {
+			return nil, fmt.Errorf("stale_after: %w", err)
+		}
+	}
+
+	if cfg.MaxClockSkew != "" {
+		if d, err := ParseDuration(cfg.MaxClockSkew); err != nil {
+			return nil, fmt.Errorf("max_clock_skew: %w", err)
+		} else if d <= 0 {
+			return nil, fmt.Errorf("max_clock_skew: must be positive, got %q", cfg.MaxClockSkew)
+		}
+	}
+
+	if cfg.AutoArchive.After != "" {
+		if _, err := ParseDuration(cfg.AutoArchive.After); err != nil {
+			return nil, fmt.Errorf("auto_archive.after: %w", err)
+		}
+	}
+	for _, s := range cfg.AutoArchive.Statuses {
+		if !cfg.IsArchiveStatus(s) {
+			return nil, fmt.Errorf("auto_archive.statuses: %q is not an archive status", s)
+		}
+	}
+
+	if cfg.ProtectUncheckedTasks != "" && !slices.Contains(ProtectTasksModes, cfg.ProtectUncheckedTasks) {
+		return nil, fmt.Errorf("protect_unchecked_tasks: %q is not %s", cfg.ProtectUncheckedTasks, strings.Join(ProtectTasksModes, ", "))
+	}
+
+	if cfg.DefaultEstimateValue != "" {
+		if _, err := ParseEstimate(cfg.DefaultEstimateValue); err != nil {
+			return nil, fmt.Errorf("default_estimate: %w", err)
+		}
+	}
+
+	for _, s := range cfg.NextStatuses {
+		if !cfg.IsValidStatus(s) {
+			return nil, fmt.Errorf("next_statuses: %q is not a status", s)
+		}
+	}
+	for _, s := range cfg.TriageStatuses {
+		if !cfg.IsValidStatus(s) {
+			return nil, fmt.Errorf("triage_statuses: %q is not a status", s)
+		}
+	}
+
+	if err := cfg.validateRules(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateSyncURLTemplates(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateIDFormat(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateWatcher(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validateTUI(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadLocal(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// loadLocal applies the machine-local overlay next to the config file, if any.
+func (c *Config) loadLocal() error {
+	data, err := os.ReadFile(filepath.Join(c.configDir, LocalConfigFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var local localConfig
+	if err := yaml.Unmarshal(data, &local); err != nil {
+		return fmt.Errorf("%s: %w", LocalConfigFileName, err)
+	}
+	c.issueKeyFile = local.Todo.IssueKeyFile
+	c.serveToken = local.Todo.ServeToken
+	c.webhookSecrets = local.Todo.WebhookSecrets
+	c.splitView = local.Todo.SplitView
+	return nil
+}
+
+// SplitView reports whether the TUI list shows a preview of the highlighted
+// issue beside it.
+func (c *Config) SplitView() bool {
+	return c.splitView
+}
+
+// SetSplitView records the TUI layout preference in the local overlay,
+// keeping everything else in the file.
+func (c *Config) SetSplitView(on bool) error {
+	if c.configDir == "" {
+		return fmt.Errorf("no config directory to write %s in", LocalConfigFileName)
+	}
+	path := filepath.Join(c.configDir, LocalConfigFileName)
+	var root yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	case len(data) > 0:
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return fmt.Errorf("%s: %w", LocalConfigFileName, err)
+		}
+	}
+	if root.Kind == 0 {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	todo := mappingValue(root.Content[0], "todo")
+	if todo == nil || todo.Kind != yaml.MappingNode {
+		todo = &yaml.Node{Kind: yaml.MappingNode}
+		if !replaceOrAppendKey(&root, "todo", todo) {
+			return fmt.Errorf("%s: top level is not a mapping", LocalConfigFileName)
+		}
+	}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(on)}
+	replaceOrAppendKey(todo, "split_view", value)
+
+	out, err := yaml.Marshal(&root)
+	if err != nil {
+		return err
+	}
+	// The overlay can hold secrets, so a new one is private.
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		return err
+	}
+	c.splitView = on
+	return nil
+}
+
+// ServeToken returns the bearer token `todo serve` requires of clients: the
+// JIG_SERVE_TOKEN environment variable if set, otherwise the serve_token in
+// the local overlay. Returns "" when no token is configured.
+func (c *Config) ServeToken() string {
+	return cmp.Or(os.Getenv(ServeTokenEnv), c.serveToken)
+}
+
+// WebhookSecret returns the secret inbound webhooks from provider are signed
+// with: the JIG_WEBHOOK_SECRET_<PROVIDER> environment variable if set,
+// otherwise webhook_secrets.<provider> in the local overlay. Returns "" when
+// none is configured.
+func (c *Config) WebhookSecret(provider string) string {
+	return cmp.Or(os.Getenv(WebhookSecretEnvPrefix+strings.ToUpper(provider)), c.webhookSecrets[provider])
+}
+
+// IssueKeySecret returns the secret used to encrypt issue bodies: the
+// JIG_ISSUE_KEY environment variable if set, otherwise the trimmed contents
+// of the issue_key_file named in the local overlay. Keyfile paths may start
+// with ~/; relative paths resolve against the config directory. Returns "" when no key is configured.
+func (c *Config) IssueKeySecret() (string, error) {
+	if v := os.Getenv(IssueKeyEnv); v != "" {
+		return v, nil
+	}
+	if c.issueKeyFile == "" {
+		return "", nil
+	}
+	path := c.issueKeyFile
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.configDir, path)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path from local config
+	if err != nil {
+		return "", fmt.Errorf("reading issue_key_file: %w", err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("issue_key_file %s is empty", path)
+	}
+	return secret, nil
+}
+
 // isLegacyConfig returns true if the given path is a legacy .todo.yml file.
 func isLegacyConfig(configPath string) bool {
 	return filepath.Base(configPath) == LegacyConfigFileName
@@ -562,6 +888,153 @@ func (c *Config) GetDefaultType() string {
 	return c.DefaultType
 }
 
+// GetSplitViewMinWidth returns the narrowest terminal the TUI split view is
+// shown in.
+func (c *Config) GetSplitViewMinWidth() int {
+	return cmp.Or(c.SplitViewMinWidth, DefaultSplitViewMinWidth)
+}
+
+// GetMaxHierarchyDepth returns the most parents allowed above an issue.
+func (c *Config) GetMaxHierarchyDepth() int {
+	return cmp.Or(c.MaxHierarchyDepth, DefaultMaxHierarchyDepth)
+}
+
+// GetGraphQLMaxDepth returns the maximum selection depth for a GraphQL operation.
+func (c *Config) GetGraphQLMaxDepth() int {
+	return cmp.Or(c.GraphQL.MaxDepth, DefaultGraphQLMaxDepth)
+}
+
+// GetGraphQLMaxComplexity returns the maximum complexity for a GraphQL operation.
+func (c *Config) GetGraphQLMaxComplexity() int {
+	return cmp.Or(c.GraphQL.MaxComplexity, DefaultGraphQLMaxComplexity)
+}
+
+// GetMaxBodyBytes returns the largest body, in bytes, an issue may have.
+func (c *Config) GetMaxBodyBytes() int {
+	return cmp.Or(c.MaxBodyBytes, DefaultMaxBodyBytes)
+}
+
+// GetMaxFrontmatterBytes returns the largest front matter, in bytes, an
+// issue may have.
+func (c *Config) GetMaxFrontmatterBytes() int {
+	return cmp.Or(c.MaxFrontmatterBytes, DefaultMaxFrontmatterBytes)
+}
+
+// DefaultStaleStatuses are the statuses checked for staleness when
+// stale_statuses is unset: work someone has started but not finished.
+var DefaultStaleStatuses = []string{StatusInProgress, StatusReview}
+
+// GetStaleAfter returns the staleness threshold, or 0 if staleness is disabled.
+func (c *Config) GetStaleAfter() time.Duration {
+	if c.StaleAfter == "" {
+		return 0
+	}
+	d, err := ParseDuration(c.StaleAfter)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// DefaultMaxClockSkew is how far in the future a timestamp may be when
+// max_clock_skew is unset.
+const DefaultMaxClockSkew = 24 * time.Hour
+
+// GetMaxClockSkew returns how far in the future a created_at or updated_at
+// may be before it is treated as the product of a skewed clock.
+func (c *Config) GetMaxClockSkew() time.Duration {
+	if c == nil || c.MaxClockSkew == "" {
+		return DefaultMaxClockSkew
+	}
+	d, err := ParseDuration(c.MaxClockSkew)
+	if err != nil || d <= 0 {
+		return DefaultMaxClockSkew
+	}
+	return d
+}
+
+// GetStaleStatuses returns the statuses whose issues can become stale.
+func (c *Config) GetStaleStatuses() []string {
+	if len(c.StaleStatuses) > 0 {
+		return c.StaleStatuses
+	}
+	return DefaultStaleStatuses
+}
+
+// IsStale reports whether an issue with the given status, last updated at
+// updatedAt, is stale at now. Always false when stale_after is unset.
+func (c *Config) IsStale(status string, updatedAt, now time.Time) bool {
+	threshold := c.GetStaleAfter()
+	if threshold <= 0 || updatedAt.IsZero() || !slices.Contains(c.GetStaleStatuses(), status) {
+		return false
+	}
+	return now.Sub(updatedAt) > threshold
+}
+
+// DefaultNextStatuses are the statuses `todo next` picks from when
+// next_statuses is unset: work that is ready to start.
+var DefaultNextStatuses = []string{StatusReady}
+
+// GetNextStatuses returns the statuses `todo next` picks work from.
+func (c *Config) GetNextStatuses() []string {
+	if len(c.NextStatuses) > 0 {
+		return c.NextStatuses
+	}
+	return DefaultNextStatuses
+}
+
+// DefaultTriageStatuses are the statuses a triage session walks when
+// triage_statuses is unset: issues that still need refining.
+var DefaultTriageStatuses = []string{StatusDraft}
+
+// GetTriageStatuses returns the statuses a triage session walks.
+func (c *Config) GetTriageStatuses() []string {
+	if len(c.TriageStatuses) > 0 {
+		return c.TriageStatuses
+	}
+	return DefaultTriageStatuses
+}
+
+// GetAutoArchiveAfter returns the auto-archive age threshold, or 0 if the
+// policy is disabled.
+func (c *Config) GetAutoArchiveAfter() time.Duration {
+	if c.AutoArchive.After == "" {
+		return 0
+	}
+	d, err := ParseDuration(c.AutoArchive.After)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// GetAutoArchiveStatuses returns the statuses the auto-archive policy
+// applies to. Only archive statuses are ever returned, so open work is never
+// swept up even if the config lists it.
+func (c *Config) GetAutoArchiveStatuses() []string {
+	if len(c.AutoArchive.Statuses) == 0 {
+		var statuses []string
+		for _, s := range c.StatusNames() {
+			if c.IsArchiveStatus(s) {
+				statuses = append(statuses, s)
+			}
+		}
+		return statuses
+	}
+	return slices.DeleteFunc(slices.Clone(c.AutoArchive.Statuses), func(s string) bool { return !c.IsArchiveStatus(s) })
+}
+
+// IsAutoArchiveDue reports whether an issue with the given status, last
+// updated at updatedAt, should be auto-archived at now. Always false when
+// auto_archive.after is unset.
+func (c *Config) IsAutoArchiveDue(status string, updatedAt, now time.Time) bool {
+	threshold := c.GetAutoArchiveAfter()
+	if threshold <= 0 || updatedAt.IsZero() || !slices.Contains(c.GetAutoArchiveStatuses(), status) {
+		return false
+	}
+	return now.Sub(updatedAt) > threshold
+}
+
 // GetEditor returns the configured editor command, or empty string if unset.
 func (c *Config) GetEditor() string {
 	return c.Editor
@@ -573,6 +1046,12 @@ func (c *Config) GetDefaultSort() string {
 	return cmp.Or(c.DefaultSort, SortDefault)
 }
 
+// GetProtectUncheckedTasks returns the protect_unchecked_tasks mode, or
+// ProtectTasksWarn if not set.
+func (c *Config) GetProtectUncheckedTasks() string {
+	return cmp.Or(c.ProtectUncheckedTasks, ProtectTasksWarn)
+}
+
 // IsArchiveStatus returns true if the given status is marked for archiving.
 // Statuses are hardcoded and not configurable.
 func (c *Config) IsArchiveStatus(name string) bool {
@@ -594,30 +1073,69 @@ func IsCompleteStatus(name string) bool {
 	return false
 }
 
+// TypeConfigs returns the project's issue types: DefaultTypes with any
+// same-named entries from Types overriding their non-empty fields, followed by
+// the project-defined types in config order.
+func (c *Config) TypeConfigs() []TypeConfig {
+	if c == nil || len(c.Types) == 0 {
+		return DefaultTypes
+	}
+	types := slices.Clone(DefaultTypes)
+	for _, t := range c.Types {
+		i := slices.IndexFunc(types, func(d TypeConfig) bool { return d.Name == t.Name })
+		if i < 0 {
+			types = append(types, t)
+			continue
+		}
+		merged := &types[i]
+		merged.Color = cmp.Or(t.Color, merged.Color)
+		merged.Description = cmp.Or(t.Description, merged.Description)
+		merged.Icon = cmp.Or(t.Icon, merged.Icon)
+		if len(t.AllowedParents) > 0 {
+			merged.AllowedParents = t.AllowedParents
+		}
+	}
+	return types
+}
+
 // GetType returns the TypeConfig for a given type name, or nil if not found.
 func (c *Config) GetType(name string) *TypeConfig {
-	return configFind(DefaultTypes, name, typeName)
+	return configFind(c.TypeConfigs(), name, typeName)
 }
 
 // TypeNames returns a slice of valid type names.
 func (c *Config) TypeNames() []string {
-	return configNames(DefaultTypes, typeName)
+	return configNames(c.TypeConfigs(), typeName)
 }
 
-// IsValidType returns true if the type is a valid hardcoded type.
+// IsValidType returns true if the type is a built-in or project-defined type.
 func (c *Config) IsValidType(name string) bool {
-	return configIsValid(DefaultTypes, name, typeName)
+	return configIsValid(c.TypeConfigs(), name, typeName)
 }
 
 // TypeList returns a comma-separated list of valid types.
 func (c *Config) TypeList() string {
-	return configList(DefaultTypes, typeName)
+	return configList(c.TypeConfigs(), typeName)
+}
+
+// ValidParentTypes returns the types an issue of the given type may have as
+// its parent, or nil if it cannot have a parent (legacy milestone issues).
+// Unknown types get DefaultParentTypes.
+func (c *Config) ValidParentTypes(name string) []string {
+	if name == TypeMilestone {
+		return nil
+	}
+	if t := c.GetType(name); t != nil && len(t.AllowedParents) > 0 {
+		return t.AllowedParents
+	}
+	return DefaultParentTypes
 }
 
 // IssueColors holds resolved color information for rendering an issue
 type IssueColors struct {
 	StatusColor   string
 	TypeColor     string
+	TypeIcon      string
 	PriorityColor string
 	IsArchive     bool
 }
@@ -638,6 +1156,7 @@ func (c *Config) GetIssueColors(status, typeName, priority string) IssueColors {
 
 	if typeCfg := c.GetType(typeName); typeCfg != nil {
 		colors.TypeColor = typeCfg.Color
+		colors.TypeIcon = typeCfg.Icon
 	}
 
 	if priorityCfg := c.GetPriority(priority); priorityCfg != nil {
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -390,6 +391,66 @@ func TestTypesAreHardcoded(t *testing.T) {
 	}
 }
 
+func TestValidParentTypesDefaults(t *testing.T) {
+	cfg := Default()
+	tests := map[string][]string{
+		TypeMilestone: nil,
+		TypeEpic:      {TypeMilestone},
+		TypeFeature:   {TypeMilestone, TypeEpic},
+		TypeTask:      {TypeMilestone, TypeEpic, TypeFeature},
+		TypeBug:       {TypeMilestone, TypeEpic, TypeFeature},
+		"unknown":     {TypeMilestone, TypeEpic, TypeFeature},
+	}
+	for typ, want := range tests {
+		if got := cfg.ValidParentTypes(typ); !slices.Equal(got, want) {
+			t.Errorf("ValidParentTypes(%q) = %v, want %v", typ, got, want)
+		}
+	}
+}
+
+func TestConfiguredTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ConfigFileName)
+	content := `todo:
+    types:
+        - name: spike
+          color: cyan
+          icon: Sk
+          allowed_parents: [epic]
+        - name: bug
+          color: orange
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if want := []string{"epic", "bug", "feature", "task", "spike"}; !slices.Equal(cfg.TypeNames(), want) {
+		t.Errorf("TypeNames() = %v, want %v", cfg.TypeNames(), want)
+	}
+	if !cfg.IsValidType("spike") {
+		t.Error("project-defined type should be valid")
+	}
+	if got := cfg.ValidParentTypes("spike"); !slices.Equal(got, []string{TypeEpic}) {
+		t.Errorf("ValidParentTypes(spike) = %v, want [epic]", got)
+	}
+	if colors := cfg.GetIssueColors(StatusReady, "spike", ""); colors.TypeColor != "cyan" || colors.TypeIcon != "Sk" {
+		t.Errorf("spike colors = %+v, want cyan/Sk", colors)
+	}
+
+	// Overrides keep the fields they don't set.
+	bug := cfg.GetType(TypeBug)
+	if bug.Color != "orange" || bug.Description == "" || !slices.Equal(bug.AllowedParents, DefaultParentTypes) {
+		t.Errorf("bug override = %+v, want orange with default description and parents", bug)
+	}
+	if DefaultTypes[1].Color != "red" {
+		t.Error("override mutated DefaultTypes")
+	}
+}
+
 func TestTypeDescriptions(t *testing.T) {
 	t.Run("hardcoded types have descriptions", func(t *testing.T) {
 		cfg := Default()
@@ -1339,3 +1400,205 @@ func TestSyncConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestGraphQLLimits(t *testing.T) {
+	t.Run("defaults when not set", func(t *testing.T) {
+		cfg := Default()
+		if got := cfg.GetGraphQLMaxDepth(); got != DefaultGraphQLMaxDepth {
+			t.Errorf("GetGraphQLMaxDepth() = %d, want %d", got, DefaultGraphQLMaxDepth)
+		}
+		if got := cfg.GetGraphQLMaxComplexity(); got != DefaultGraphQLMaxComplexity {
+			t.Errorf("GetGraphQLMaxComplexity() = %d, want %d", got, DefaultGraphQLMaxComplexity)
+		}
+	})
+
+	t.Run("loads from YAML", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		configPath := filepath.Join(tmpDir, ConfigFileName)
+		configYAML := `todo:
+    graphql:
+        max_depth: 4
+        max_complexity: 50
+`
+		if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+			t.Fatalf("WriteFile error = %v", err)
+		}
+
+		cfg, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := cfg.GetGraphQLMaxDepth(); got != 4 {
+			t.Errorf("GetGraphQLMaxDepth() = %d, want 4", got)
+		}
+		if got := cfg.GetGraphQLMaxComplexity(); got != 50 {
+			t.Errorf("GetGraphQLMaxComplexity() = %d, want 50", got)
+		}
+	})
+}
+
+func TestLoadRejectsInvalidStaleAfter(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ConfigFileName)
+	if err := os.WriteFile(configPath, []byte("todo:\n    stale_after: fortnight\n"), 0644); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+	if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), "stale_after") {
+		t.Errorf("Load() error = %v, want stale_after error", err)
+	}
+}
+
+func TestIssueKeySecret(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ConfigFileName)
+	writes := map[string]string{
+		configPath:                              "todo:\n    path: .issues\n",
+		filepath.Join(dir, LocalConfigFileName): "todo:\n    issue_key_file: issue.key\n",
+		filepath.Join(dir, "issue.key"):         "from-file\n",
+	}
+	for path, content := range writes {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile error = %v", err)
+		}
+	}
+
+	t.Setenv(IssueKeyEnv, "")
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if secret, err := cfg.IssueKeySecret(); err != nil || secret != "from-file" {
+		t.Errorf("IssueKeySecret() = %q, %v; want keyfile contents", secret, err)
+	}
+
+	// The environment variable wins over the keyfile.
+	t.Setenv(IssueKeyEnv, "from-env")
+	if secret, _ := cfg.IssueKeySecret(); secret != "from-env" {
+		t.Errorf("IssueKeySecret() = %q, want from-env", secret)
+	}
+
+	// The overlay path must never leak into the shared config.
+	if err := cfg.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, _ := os.ReadFile(configPath)
+	if strings.Contains(string(data), "issue_key_file") {
+		t.Errorf("Save() wrote local overlay settings:\n%s", data)
+	}
+}
+
+func TestServeToken(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ConfigFileName)
+	writes := map[string]string{
+		configPath:                              "todo:\n    path: .issues\n",
+		filepath.Join(dir, LocalConfigFileName): "todo:\n    serve_token: from-file\n",
+	}
+	for path, content := range writes {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile error = %v", err)
+		}
+	}
+
+	t.Setenv(ServeTokenEnv, "")
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.ServeToken(); got != "from-file" {
+		t.Errorf("ServeToken() = %q, want from-file", got)
+	}
+
+	t.Setenv(ServeTokenEnv, "from-env")
+	if got := cfg.ServeToken(); got != "from-env" {
+		t.Errorf("ServeToken() = %q, want from-env", got)
+	}
+}
+
+func TestLoadValidatesAutoArchive(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"bad duration", "todo:\n    auto_archive:\n        after: monthly\n", "auto_archive.after"},
+		{"open status", "todo:\n    auto_archive:\n        after: 30d\n        statuses: [draft]\n", "not an archive status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ConfigFileName)
+			if err := os.WriteFile(configPath, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	configPath := filepath.Join(t.TempDir(), ConfigFileName)
+	yaml := "todo:\n    auto_archive:\n        after: 30d\n        statuses: [completed]\n        on_start: true\n"
+	if err := os.WriteFile(configPath, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.GetAutoArchiveAfter() != 30*Day || !cfg.AutoArchive.OnStart || !slices.Equal(cfg.GetAutoArchiveStatuses(), []string{StatusCompleted}) {
+		t.Errorf("auto_archive = %+v", cfg.AutoArchive)
+	}
+}
+
+func TestSetSplitView(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ConfigFileName)
+	localPath := filepath.Join(dir, LocalConfigFileName)
+	if err := os.WriteFile(configPath, []byte("todo:\n    path: .issues\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.SplitView() {
+		t.Error("SplitView() = true without a local overlay")
+	}
+	if got := cfg.GetSplitViewMinWidth(); got != DefaultSplitViewMinWidth {
+		t.Errorf("GetSplitViewMinWidth() = %d, want %d", got, DefaultSplitViewMinWidth)
+	}
+
+	if err := cfg.SetSplitView(true); err != nil {
+		t.Fatalf("SetSplitView() error = %v", err)
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		t.Fatalf("local overlay not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("new overlay mode = %o, want 600", perm)
+	}
+
+	// Other overlay settings survive a toggle.
+	if err := os.WriteFile(localPath, []byte("# mine\ntodo:\n    serve_token: secret\n    split_view: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetSplitView(false); err != nil {
+		t.Fatalf("SetSplitView(false) error = %v", err)
+	}
+	reloaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if reloaded.SplitView() || reloaded.serveToken != "secret" {
+		t.Errorf("after toggling off: SplitView() = %v, serve_token = %q", reloaded.SplitView(), reloaded.serveToken)
+	}
+	data, _ := os.ReadFile(localPath)
+	if !strings.Contains(string(data), "# mine") {
+		t.Errorf("overlay lost its comment:\n%s", data)
+	}
+	if err := reloaded.SetSplitView(true); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := Load(configPath); !again.SplitView() {
+		t.Error("SplitView() = false after SetSplitView(true) and reload")
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Discovery is the result of searching a directory and its parents for the
+// config that governs it.
+type Discovery struct {
+	// Path is the absolute path of the nearest config file, or "" if none
+	// was found.
+	Path string
+	// Outer lists the config files further up the search that Path
+	// shadows, nearest first. In a monorepo these are the configs of
+	// enclosing projects.
+	Outer []string
+	// StopDir is the last directory searched: the git root above the start
+	// directory, or the filesystem root outside a repository.
+	StopDir string
+}
+
+// Discover searches startDir and its parents for a config file, stopping
+// at the git root (the nearest directory with a .git entry) so a checkout
+// never picks up a config from outside it. The nearest config wins; any
+// others up to the stop directory are listed in Outer. A legacy .todo.yml
+// found as the nearest config is migrated as FindConfig describes.
+func Discover(startDir string) (*Discovery, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Discovery{}
+	for {
+		path, err := configIn(dir, d.Path == "")
+		if err != nil && d.Path == "" {
+			return nil, err
+		}
+		switch {
+		case path == "":
+		case d.Path == "":
+			d.Path = path
+		default:
+			d.Outer = append(d.Outer, path)
+		}
+
+		parent := filepath.Dir(dir)
+		if isGitRoot(dir) || parent == dir {
+			d.StopDir = dir
+			return d, nil
+		}
+		dir = parent
+	}
+}
+
+// configIn returns the config file in dir, or "" if it has none. With
+// migrate set, a legacy .todo.yml is rewritten as .jig.yaml and that path
+// returned; without it, the legacy file is only reported.
+func configIn(dir string, migrate bool) (string, error) {
+	// Check .jig.yaml first
+	newPath := filepath.Join(dir, ConfigFileName)
+	if _, err := os.Stat(newPath); err == nil {
+		return newPath, nil
+	}
+
+	// Check .toba.yaml as legacy fallback
+	tobaPath := filepath.Join(dir, LegacyTobaConfigFileName)
+	if _, err := os.Stat(tobaPath); err == nil {
+		return tobaPath, nil
+	}
+
+	// Check .todo.yml as oldest legacy fallback
+	legacyPath := filepath.Join(dir, LegacyConfigFileName)
+	if _, err := os.Stat(legacyPath); err == nil {
+		if !migrate {
+			return legacyPath, nil
+		}
+		// Auto-migrate legacy config to new format
+		migrated, migrateErr := migrateLegacyConfig(legacyPath, newPath)
+		if migrateErr != nil {
+			return "", fmt.Errorf("migrating %s to %s: %w", LegacyConfigFileName, ConfigFileName, migrateErr)
+		}
+		if migrated {
+			return newPath, nil
+		}
+		// If migration failed silently, fall back to legacy
+		return legacyPath, nil
+	}
+
+	// Check for common typo: .jig.yml instead of .jig.yaml
+	typoPath := filepath.Join(dir, ".jig.yml")
+	if _, err := os.Stat(typoPath); err == nil {
+		return "", errors.New("found .jig.yml but jig expects .jig.yaml — please rename it")
+	}
+	return "", nil
+}
+
+// isGitRoot reports whether dir is the top of a git checkout. A worktree
+// or submodule has a .git file rather than a directory, so either counts.
+func isGitRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeTree creates each path under root; names ending in / are
+// directories, others are files with a minimal todo section.
+func writeTree(t *testing.T, root string, paths ...string) {
+	t.Helper()
+	for _, p := range paths {
+		full := filepath.Join(root, p)
+		if p[len(p)-1] == '/' {
+			if err := os.MkdirAll(full, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte("todo:\n    path: .issues\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	t.Run("nearest config wins and outer ones are listed", func(t *testing.T) {
+		root := t.TempDir()
+		writeTree(t, root, ".git/", ConfigFileName, "services/"+ConfigFileName, "services/auth/"+ConfigFileName, "services/auth/internal/db/")
+
+		d, err := Discover(filepath.Join(root, "services/auth/internal/db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(root, "services/auth", ConfigFileName); d.Path != want {
+			t.Errorf("Path = %q, want %q", d.Path, want)
+		}
+		wantOuter := []string{filepath.Join(root, "services", ConfigFileName), filepath.Join(root, ConfigFileName)}
+		if !slices.Equal(d.Outer, wantOuter) {
+			t.Errorf("Outer = %q, want %q", d.Outer, wantOuter)
+		}
+		if d.StopDir != root {
+			t.Errorf("StopDir = %q, want the git root %q", d.StopDir, root)
+		}
+	})
+
+	t.Run("stops at the git root", func(t *testing.T) {
+		outside := t.TempDir()
+		writeTree(t, outside, ConfigFileName, "repo/.git/", "repo/pkg/")
+
+		d, err := Discover(filepath.Join(outside, "repo/pkg"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Path != "" || len(d.Outer) != 0 {
+			t.Errorf("found %q (outer %q) above the git root", d.Path, d.Outer)
+		}
+		if want := filepath.Join(outside, "repo"); d.StopDir != want {
+			t.Errorf("StopDir = %q, want %q", d.StopDir, want)
+		}
+	})
+
+	t.Run("a .git file marks a worktree root", func(t *testing.T) {
+		outside := t.TempDir()
+		writeTree(t, outside, ConfigFileName, "wt/.git", "wt/src/")
+
+		d, err := Discover(filepath.Join(outside, "wt/src"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Path != "" {
+			t.Errorf("Path = %q, want none past the worktree root", d.Path)
+		}
+	})
+
+	t.Run("outer legacy config is listed, not migrated", func(t *testing.T) {
+		root := t.TempDir()
+		writeTree(t, root, ".git/", LegacyConfigFileName, "app/"+ConfigFileName)
+
+		d, err := Discover(filepath.Join(root, "app"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{filepath.Join(root, LegacyConfigFileName)}; !slices.Equal(d.Outer, want) {
+			t.Errorf("Outer = %q, want %q", d.Outer, want)
+		}
+		if _, err := os.Stat(filepath.Join(root, ConfigFileName)); err == nil {
+			t.Error("outer legacy config was migrated")
+		}
+	})
+}
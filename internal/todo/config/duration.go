@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day and Week extend time.Duration's units for human-scale config values.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// ParseDuration parses a duration string. In addition to everything
+// time.ParseDuration accepts, it understands whole days and weeks ("14d",
+// "2w"), which are the natural units for issue ages.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	for suffix, unit := range map[string]time.Duration{"d": Day, "w": Week} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// FormatAge renders a duration in the coarsest unit that keeps it readable:
+// minutes under an hour, hours under a day, and whole days beyond that.
+// The result round-trips through ParseDuration (to the displayed precision).
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < Day:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/Day))
+	}
+}
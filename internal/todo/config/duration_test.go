@@ -0,0 +1,123 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "14d", want: 14 * Day},
+		{in: "2w", want: 2 * Week},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "90m", want: 90 * time.Minute},
+		{in: " 3d ", want: 3 * Day},
+		{in: "", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "1.5d", wantErr: true},
+		{in: "-2d", wantErr: true},
+		{in: "-1h", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDuration(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{in: 0, want: "0m"},
+		{in: 59 * time.Minute, want: "59m"},
+		{in: 5 * time.Hour, want: "5h"},
+		{in: 23*time.Hour + 59*time.Minute, want: "23h"},
+		{in: 15*Day + 3*time.Hour, want: "15d"},
+	}
+	for _, tt := range tests {
+		if got := FormatAge(tt.in); got != tt.want {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsStale(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-15 * Day)
+	recent := now.Add(-13 * Day)
+
+	t.Run("disabled without stale_after", func(t *testing.T) {
+		cfg := Default()
+		if cfg.IsStale(StatusInProgress, old, now) {
+			t.Error("IsStale() = true with no stale_after configured")
+		}
+	})
+
+	cfg := Default()
+	cfg.StaleAfter = "14d"
+
+	if !cfg.IsStale(StatusInProgress, old, now) {
+		t.Error("in-progress issue idle 15d should be stale")
+	}
+	if cfg.IsStale(StatusInProgress, recent, now) {
+		t.Error("in-progress issue idle 13d should not be stale")
+	}
+	if cfg.IsStale(StatusReady, old, now) {
+		t.Error("ready is not a default stale status")
+	}
+
+	cfg.StaleStatuses = []string{StatusReady}
+	if !cfg.IsStale(StatusReady, old, now) || cfg.IsStale(StatusInProgress, old, now) {
+		t.Error("stale_statuses should replace the default set")
+	}
+}
+
+func TestIsAutoArchiveDue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("disabled without auto_archive.after", func(t *testing.T) {
+		cfg := Default()
+		if cfg.IsAutoArchiveDue(StatusCompleted, now.Add(-365*Day), now) {
+			t.Error("IsAutoArchiveDue() = true with no auto_archive.after configured")
+		}
+	})
+
+	cfg := Default()
+	cfg.AutoArchive.After = "30d"
+
+	if cfg.IsAutoArchiveDue(StatusCompleted, now.Add(-30*Day), now) {
+		t.Error("an issue exactly 30d old should not be archived yet")
+	}
+	if !cfg.IsAutoArchiveDue(StatusCompleted, now.Add(-30*Day-time.Second), now) {
+		t.Error("an issue just over 30d old should be archived")
+	}
+	if !cfg.IsAutoArchiveDue(StatusScrapped, now.Add(-31*Day), now) {
+		t.Error("scrapped is a default auto-archive status")
+	}
+	for _, status := range []string{StatusDraft, StatusReady, StatusInProgress, StatusDeferred} {
+		if cfg.IsAutoArchiveDue(status, now.Add(-365*Day), now) {
+			t.Errorf("%s issues must never be auto-archived", status)
+		}
+	}
+
+	cfg.AutoArchive.Statuses = []string{StatusScrapped, StatusDraft}
+	if cfg.IsAutoArchiveDue(StatusCompleted, now.Add(-31*Day), now) {
+		t.Error("auto_archive.statuses should replace the default set")
+	}
+	if cfg.IsAutoArchiveDue(StatusDraft, now.Add(-31*Day), now) {
+		t.Error("non-archive statuses listed in auto_archive.statuses must be ignored")
+	}
+}
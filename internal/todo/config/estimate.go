@@ -0,0 +1,42 @@
+package config
+
+import "time"
+
+// DefaultEstimate is what `todo plan` counts an issue without an estimate
+// as, unless default_estimate says otherwise.
+const DefaultEstimate = "1d"
+
+// estimateForms describes the accepted estimate syntax in errors.
+var estimateForms = []string{"a duration such as 4h, 2d, or 1w"}
+
+// ParseEstimate parses an issue estimate or a planning capacity: a positive
+// duration in ParseDuration's units. It returns a *ValueError otherwise.
+func ParseEstimate(s string) (time.Duration, error) {
+	d, err := ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, &ValueError{Field: "estimate", Value: s, Valid: estimateForms}
+	}
+	return d, nil
+}
+
+// ValidateEstimate returns a *ValueError if estimate is not a positive
+// duration. Empty means no estimate and is valid.
+func (c *Config) ValidateEstimate(estimate string) error {
+	if estimate == "" {
+		return nil
+	}
+	_, err := ParseEstimate(estimate)
+	return err
+}
+
+// GetDefaultEstimate returns default_estimate, or DefaultEstimate when it is
+// unset or invalid.
+func (c *Config) GetDefaultEstimate() time.Duration {
+	if c != nil && c.DefaultEstimateValue != "" {
+		if d, err := ParseEstimate(c.DefaultEstimateValue); err == nil {
+			return d
+		}
+	}
+	d, _ := ParseEstimate(DefaultEstimate)
+	return d
+}
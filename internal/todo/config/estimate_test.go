@@ -0,0 +1,34 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateEstimate(t *testing.T) {
+	cfg := Default()
+	for _, ok := range []string{"", "4h", "2d", "1w", "90m"} {
+		if err := cfg.ValidateEstimate(ok); err != nil {
+			t.Errorf("ValidateEstimate(%q) error = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"0h", "soon", "1.5d", "-2d"} {
+		err := cfg.ValidateEstimate(bad)
+		if valueErr, ok := errors.AsType[*ValueError](err); !ok || valueErr.Field != "estimate" {
+			t.Errorf("ValidateEstimate(%q) error = %v, want an estimate ValueError", bad, err)
+		}
+	}
+}
+
+func TestGetDefaultEstimate(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.GetDefaultEstimate(); got != Day {
+		t.Errorf("nil config default = %v, want 1d", got)
+	}
+	cfg := Default()
+	cfg.DefaultEstimateValue = "4h"
+	if got := cfg.GetDefaultEstimate(); got != 4*time.Hour {
+		t.Errorf("default_estimate 4h = %v", got)
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// ID format defaults, which give the original xxx-xxx IDs.
+const (
+	DefaultIDGroups         = 2
+	DefaultIDGroupLength    = 3
+	DefaultIDAlphabet       = "0123456789abcdefghijklmnopqrstuvwxyz"
+	DefaultIDExpectedIssues = 1000
+)
+
+// maxIDCollisionChance is the chance of two IDs colliding among the
+// expected issues above which IDFormatWarning warns.
+const maxIDCollisionChance = 0.01
+
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// IDFormat shapes generated issue IDs: Prefix, then Groups runs of
+// GroupLength characters drawn from Alphabet, joined by hyphens. Zero
+// fields take the defaults. It only affects new issues; existing IDs are
+// kept as they are.
+type IDFormat struct {
+	Prefix      string `yaml:"prefix,omitempty"`
+	Groups      int    `yaml:"groups,omitempty"`
+	GroupLength int    `yaml:"group_length,omitempty"`
+	Alphabet    string `yaml:"alphabet,omitempty"`
+	// ExpectedIssues is how many issues the project expects to hold, used
+	// to warn when the format has too few possible IDs. See
+	// IDFormatWarning.
+	ExpectedIssues int `yaml:"expected_issues,omitempty"`
+}
+
+// DefaultIDFormat returns the format of IDs when id_format is unset.
+func DefaultIDFormat() IDFormat {
+	return IDFormat{
+		Groups:         DefaultIDGroups,
+		GroupLength:    DefaultIDGroupLength,
+		Alphabet:       DefaultIDAlphabet,
+		ExpectedIssues: DefaultIDExpectedIssues,
+	}
+}
+
+// GetIDFormat returns id_format with unset fields defaulted.
+func (c *Config) GetIDFormat() IDFormat {
+	f := DefaultIDFormat()
+	if c == nil {
+		return f
+	}
+	set := c.IDFormat
+	f.Prefix = set.Prefix
+	if set.Groups > 0 {
+		f.Groups = set.Groups
+	}
+	if set.GroupLength > 0 {
+		f.GroupLength = set.GroupLength
+	}
+	if set.Alphabet != "" {
+		f.Alphabet = set.Alphabet
+	}
+	if set.ExpectedIssues > 0 {
+		f.ExpectedIssues = set.ExpectedIssues
+	}
+	return f
+}
+
+// Space returns how many distinct IDs the format can produce.
+func (f IDFormat) Space() float64 {
+	return math.Pow(float64(len(f.Alphabet)), float64(f.Groups*f.GroupLength))
+}
+
+// CollisionChance estimates the chance that ExpectedIssues random IDs
+// include at least one repeat (the birthday bound). Create retries on a
+// collision, so a high chance means slow creates, not lost issues.
+func (f IDFormat) CollisionChance() float64 {
+	n := float64(f.ExpectedIssues)
+	return -math.Expm1(-n * (n - 1) / (2 * f.Space()))
+}
+
+// IDFormatWarning says why id_format has too few possible IDs for
+// expected_issues issues, or returns "" when it has enough.
+func (c *Config) IDFormatWarning() string {
+	f := c.GetIDFormat()
+	chance := f.CollisionChance()
+	if chance <= maxIDCollisionChance {
+		return ""
+	}
+	return fmt.Sprintf("id_format: %.0f possible IDs give a %.0f%% chance of a collision among %d issues; use a longer or larger format",
+		f.Space(), chance*100, f.ExpectedIssues)
+}
+
+// validateIDFormat rejects an id_format that could not generate IDs, or
+// whose IDs could be mistaken for filenames or mentions of other shapes.
+func (c *Config) validateIDFormat() error {
+	set := c.IDFormat
+	switch {
+	case set.Groups < 0:
+		return errors.New("id_format.groups: must be at least 1")
+	case set.GroupLength < 0:
+		return errors.New("id_format.group_length: must be at least 1")
+	case set.ExpectedIssues < 0:
+		return errors.New("id_format.expected_issues: must be positive")
+	case set.Prefix != "" && !idPrefixPattern.MatchString(set.Prefix):
+		return fmt.Errorf("id_format.prefix: %q must start with a letter or digit and hold only letters, digits, and hyphens", set.Prefix)
+	case strings.Contains(set.Prefix, "--"):
+		return fmt.Errorf("id_format.prefix: %q must not contain \"--\", which separates an ID from its slug", set.Prefix)
+	}
+	if set.Alphabet == "" {
+		return nil
+	}
+	seen := make(map[rune]bool)
+	for _, r := range set.Alphabet {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z') {
+			return fmt.Errorf("id_format.alphabet: %q may hold only lowercase letters and digits", set.Alphabet)
+		}
+		if seen[r] {
+			return fmt.Errorf("id_format.alphabet: %q repeats %q", set.Alphabet, r)
+		}
+		seen[r] = true
+	}
+	if len(seen) < 2 {
+		return fmt.Errorf("id_format.alphabet: %q needs at least two characters", set.Alphabet)
+	}
+	return nil
+}
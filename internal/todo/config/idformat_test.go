@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetIDFormat(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.GetIDFormat(); got != DefaultIDFormat() {
+		t.Errorf("nil config format = %+v, want the default", got)
+	}
+	cfg := Default()
+	cfg.IDFormat = IDFormat{Prefix: "PLAT-", Groups: 1, GroupLength: 4}
+	got := cfg.GetIDFormat()
+	want := IDFormat{Prefix: "PLAT-", Groups: 1, GroupLength: 4, Alphabet: DefaultIDAlphabet, ExpectedIssues: DefaultIDExpectedIssues}
+	if got != want {
+		t.Errorf("GetIDFormat() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIDFormatWarning(t *testing.T) {
+	cfg := Default()
+	if w := cfg.IDFormatWarning(); w != "" {
+		t.Errorf("default format warning = %q, want none", w)
+	}
+
+	cfg.IDFormat = IDFormat{Groups: 1, GroupLength: 3, Alphabet: "abcdefghjkmnpqrstuvwxyz23456789"}
+	if w := cfg.IDFormatWarning(); !strings.Contains(w, "chance of a collision among 1000 issues") {
+		t.Errorf("short format warning = %q, want a collision warning", w)
+	}
+
+	cfg.IDFormat.ExpectedIssues = 10
+	if w := cfg.IDFormatWarning(); w != "" {
+		t.Errorf("short format for 10 issues warning = %q, want none", w)
+	}
+}
+
+func TestLoadIDFormat(t *testing.T) {
+	tests := []struct {
+		content string
+		wantErr string
+	}{
+		{"todo:\n  id_format:\n    prefix: PLAT-\n    groups: 1\n    group_length: 4\n    alphabet: abcdefghjkmnpqrstuvwxyz23456789\n", ""},
+		{"todo:\n  id_format:\n    groups: -1\n", "id_format.groups"},
+		{"todo:\n  id_format:\n    prefix: -x\n", "id_format.prefix"},
+		{"todo:\n  id_format:\n    prefix: a--b\n", "id_format.prefix"},
+		{"todo:\n  id_format:\n    alphabet: ABC\n", "lowercase letters and digits"},
+		{"todo:\n  id_format:\n    alphabet: abca\n", "repeats"},
+		{"todo:\n  id_format:\n    alphabet: a\n", "at least two"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), ConfigFileName)
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(path)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Fatalf("Load(%q) error = %v", tt.content, err)
+			}
+			if got := cfg.GetIDFormat(); got.Prefix != "PLAT-" || got.Groups != 1 || got.GroupLength != 4 {
+				t.Errorf("GetIDFormat() = %+v", got)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Load(%q) error = %v, want %q", tt.content, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Field classes require_if_match can protect separately. Body covers body
+// edits and encryption, status covers status changes, and metadata is
+// every other field: title, tags, type, priority, relationships, dates,
+// and sync data.
+const (
+	IfMatchBody     = "body"
+	IfMatchStatus   = "status"
+	IfMatchMetadata = "metadata"
+)
+
+// IfMatchFields lists the field classes in the order they are checked.
+var IfMatchFields = []string{IfMatchBody, IfMatchStatus, IfMatchMetadata}
+
+// IfMatchPolicy says which kinds of change need an --if-match etag. In
+// config it is either a boolean, which sets every class, or a mapping per
+// class:
+//
+//	require_if_match: true
+//	require_if_match: {body: true, status: true, metadata: false}
+type IfMatchPolicy struct {
+	Body     bool
+	Status   bool
+	Metadata bool
+}
+
+// RequireIfMatchAll returns the policy of require_if_match: true.
+func RequireIfMatchAll() IfMatchPolicy {
+	return IfMatchPolicy{Body: true, Status: true, Metadata: true}
+}
+
+// Any reports whether any field class needs an etag.
+func (p IfMatchPolicy) Any() bool {
+	return p.Body || p.Status || p.Metadata
+}
+
+// All reports whether every field class needs an etag.
+func (p IfMatchPolicy) All() bool {
+	return p.Body && p.Status && p.Metadata
+}
+
+// Requires reports whether changing a field of the given class needs an
+// etag. Unknown classes count as metadata.
+func (p IfMatchPolicy) Requires(class string) bool {
+	switch class {
+	case IfMatchBody:
+		return p.Body
+	case IfMatchStatus:
+		return p.Status
+	default:
+		return p.Metadata
+	}
+}
+
+// FirstRequired returns the first of classes, in IfMatchFields order, that
+// needs an etag, and false when none does.
+func (p IfMatchPolicy) FirstRequired(classes []string) (string, bool) {
+	for _, class := range IfMatchFields {
+		if slices.Contains(classes, class) && p.Requires(class) {
+			return class, true
+		}
+	}
+	return "", false
+}
+
+// IsZero lets omitempty drop a policy that requires nothing.
+func (p IfMatchPolicy) IsZero() bool {
+	return !p.Any()
+}
+
+// UnmarshalYAML accepts the boolean form and the per-class mapping.
+func (p *IfMatchPolicy) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var all bool
+		if err := node.Decode(&all); err != nil {
+			return fmt.Errorf("require_if_match: must be true, false, or a mapping of %v to booleans", IfMatchFields)
+		}
+		*p = IfMatchPolicy{Body: all, Status: all, Metadata: all}
+		return nil
+	}
+	var m map[string]bool
+	if err := node.Decode(&m); err != nil {
+		return fmt.Errorf("require_if_match: must be true, false, or a mapping of %v to booleans", IfMatchFields)
+	}
+	*p = IfMatchPolicy{}
+	for class, on := range m {
+		switch class {
+		case IfMatchBody:
+			p.Body = on
+		case IfMatchStatus:
+			p.Status = on
+		case IfMatchMetadata:
+			p.Metadata = on
+		default:
+			return fmt.Errorf("require_if_match: unknown field class %q (must be one of %v)", class, IfMatchFields)
+		}
+	}
+	return nil
+}
+
+// MarshalYAML writes the boolean form when every class agrees, so configs
+// that never used the mapping keep their shape.
+func (p IfMatchPolicy) MarshalYAML() (any, error) {
+	if p.All() || !p.Any() {
+		return p.All(), nil
+	}
+	return map[string]bool{IfMatchBody: p.Body, IfMatchStatus: p.Status, IfMatchMetadata: p.Metadata}, nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ifMatchDoc struct {
+	RequireIfMatch IfMatchPolicy `yaml:"require_if_match,omitempty"`
+}
+
+func TestIfMatchPolicyUnmarshal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    IfMatchPolicy
+		wantErr bool
+	}{
+		{in: "require_if_match: true", want: RequireIfMatchAll()},
+		{in: "require_if_match: false", want: IfMatchPolicy{}},
+		{in: "other: 1", want: IfMatchPolicy{}},
+		{in: "require_if_match: {body: true, status: true, metadata: false}", want: IfMatchPolicy{Body: true, Status: true}},
+		{in: "require_if_match: {status: true}", want: IfMatchPolicy{Status: true}},
+		{in: "require_if_match: {tags: true}", wantErr: true},
+		{in: "require_if_match: sometimes", wantErr: true},
+		{in: "require_if_match: [body]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var doc ifMatchDoc
+			err := yaml.Unmarshal([]byte(tt.in), &doc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && doc.RequireIfMatch != tt.want {
+				t.Errorf("Unmarshal(%q) = %+v, want %+v", tt.in, doc.RequireIfMatch, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchPolicyMarshal(t *testing.T) {
+	tests := []struct {
+		policy IfMatchPolicy
+		want   string
+	}{
+		{RequireIfMatchAll(), "require_if_match: true\n"},
+		{IfMatchPolicy{}, "{}\n"},
+		{IfMatchPolicy{Body: true, Status: true}, "require_if_match:\n    body: true\n    metadata: false\n    status: true\n"},
+	}
+	for _, tt := range tests {
+		out, err := yaml.Marshal(ifMatchDoc{RequireIfMatch: tt.policy})
+		if err != nil {
+			t.Fatalf("Marshal(%+v) error = %v", tt.policy, err)
+		}
+		if string(out) != tt.want {
+			t.Errorf("Marshal(%+v) = %q, want %q", tt.policy, out, tt.want)
+		}
+		var back ifMatchDoc
+		if err := yaml.Unmarshal(out, &back); err != nil || back.RequireIfMatch != tt.policy {
+			t.Errorf("round trip of %+v = %+v, %v", tt.policy, back.RequireIfMatch, err)
+		}
+	}
+}
+
+func TestIfMatchPolicyFirstRequired(t *testing.T) {
+	policy := IfMatchPolicy{Body: true, Status: true}
+	tests := []struct {
+		classes []string
+		want    string
+		ok      bool
+	}{
+		{nil, "", false},
+		{[]string{IfMatchMetadata}, "", false},
+		{[]string{IfMatchMetadata, IfMatchStatus}, IfMatchStatus, true},
+		{[]string{IfMatchStatus, IfMatchBody}, IfMatchBody, true},
+	}
+	for _, tt := range tests {
+		got, ok := policy.FirstRequired(tt.classes)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("FirstRequired(%v) = %q, %v, want %q, %v", tt.classes, got, ok, tt.want, tt.ok)
+		}
+	}
+	if !policy.Any() || policy.All() {
+		t.Errorf("Any/All of %+v = %v/%v, want true/false", policy, policy.Any(), policy.All())
+	}
+}
+
+func TestLoadRequireIfMatchForms(t *testing.T) {
+	tests := []struct {
+		file, content string
+		want          IfMatchPolicy
+	}{
+		{ConfigFileName, "todo:\n  require_if_match: true\n", RequireIfMatchAll()},
+		{ConfigFileName, "todo:\n  require_if_match:\n    body: true\n", IfMatchPolicy{Body: true}},
+		{LegacyConfigFileName, "issues:\n  require_if_match: true\n", RequireIfMatchAll()},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), tt.file)
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", tt.content, err)
+		}
+		if cfg.RequireIfMatch != tt.want {
+			t.Errorf("Load(%q).RequireIfMatch = %+v, want %+v", tt.content, cfg.RequireIfMatch, tt.want)
+		}
+	}
+}
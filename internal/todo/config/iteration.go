@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// IterationCurrent is the iteration value that resolves to the current
+// iteration (see CurrentIteration).
+const IterationCurrent = "current"
+
+// IterationConfig declares a named iteration (sprint) issues can be assigned
+// to. Start and End are inclusive YYYY-MM-DD dates.
+type IterationConfig struct {
+	Name    string `yaml:"name"`
+	Start   string `yaml:"start,omitempty"`
+	End     string `yaml:"end,omitempty"`
+	Current bool   `yaml:"current,omitempty"`
+}
+
+// isoWeekPattern matches ISO 8601 week names such as 2025-W34.
+var isoWeekPattern = regexp.MustCompile(`^\d{4}-W(0[1-9]|[1-4]\d|5[0-3])$`)
+
+// IsISOWeek reports whether name is an ISO 8601 week such as 2025-W34.
+// Week names are always valid iterations, declared or not.
+func IsISOWeek(name string) bool {
+	return isoWeekPattern.MatchString(name)
+}
+
+// ISOWeek returns the ISO 8601 week containing t, such as 2025-W34.
+func ISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// IterationNames returns the declared iteration names in config order.
+func (c *Config) IterationNames() []string {
+	names := make([]string, 0, len(c.Iterations))
+	for _, it := range c.Iterations {
+		names = append(names, it.Name)
+	}
+	return names
+}
+
+// GetIteration returns the declared iteration with the given name, or nil.
+func (c *Config) GetIteration(name string) *IterationConfig {
+	for i := range c.Iterations {
+		if c.Iterations[i].Name == name {
+			return &c.Iterations[i]
+		}
+	}
+	return nil
+}
+
+// ValidateIteration returns a *ValueError if name is neither an ISO week nor
+// a declared iteration. Empty means no iteration and is valid.
+func (c *Config) ValidateIteration(name string) error {
+	if name == "" || IsISOWeek(name) || c.GetIteration(name) != nil {
+		return nil
+	}
+	names := c.IterationNames()
+	err := newValueError("iteration", name, names)
+	err.Valid = append(slices.Clone(names), "an ISO week such as 2025-W34")
+	return err
+}
+
+// CurrentIteration returns the iteration marked current, else the declared
+// iteration whose dates contain now. A project that declares no iterations
+// uses ISO weeks, so its current iteration is now's week.
+func (c *Config) CurrentIteration(now time.Time) (string, error) {
+	if len(c.Iterations) == 0 {
+		return ISOWeek(now), nil
+	}
+	for _, it := range c.Iterations {
+		if it.Current {
+			return it.Name, nil
+		}
+	}
+	today := now.Format(time.DateOnly)
+	for _, it := range c.Iterations {
+		if it.Start != "" && it.End != "" && it.Start <= today && today <= it.End {
+			return it.Name, nil
+		}
+	}
+	return "", errors.New("no current iteration: mark one with current: true or give the iterations start and end dates")
+}
+
+// ResolveIteration maps IterationCurrent to the current iteration and
+// validates any other name.
+func (c *Config) ResolveIteration(name string, now time.Time) (string, error) {
+	if name == IterationCurrent && c.GetIteration(name) == nil {
+		return c.CurrentIteration(now)
+	}
+	if err := c.ValidateIteration(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// IterationErrors describes problems with the declared iterations: missing
+// or duplicate names, unparseable or reversed dates, and more than one
+// marked current.
+func (c *Config) IterationErrors() []string {
+	var errs []string
+	seen := make(map[string]bool)
+	current := 0
+	for _, it := range c.Iterations {
+		if it.Name == "" {
+			errs = append(errs, "iteration entry with an empty name")
+			continue
+		}
+		if seen[it.Name] {
+			errs = append(errs, fmt.Sprintf("iteration '%s' is declared more than once", it.Name))
+		}
+		seen[it.Name] = true
+		if it.Current {
+			current++
+		}
+		var start, end time.Time
+		var err error
+		if it.Start != "" {
+			if start, err = time.Parse(time.DateOnly, it.Start); err != nil {
+				errs = append(errs, fmt.Sprintf("iteration '%s' has invalid start '%s' (want YYYY-MM-DD)", it.Name, it.Start))
+			}
+		}
+		if it.End != "" {
+			if end, err = time.Parse(time.DateOnly, it.End); err != nil {
+				errs = append(errs, fmt.Sprintf("iteration '%s' has invalid end '%s' (want YYYY-MM-DD)", it.Name, it.End))
+			}
+		}
+		if !start.IsZero() && !end.IsZero() && end.Before(start) {
+			errs = append(errs, fmt.Sprintf("iteration '%s' ends before it starts", it.Name))
+		}
+	}
+	if current > 1 {
+		errs = append(errs, fmt.Sprintf("%d iterations are marked current; only one may be", current))
+	}
+	return errs
+}
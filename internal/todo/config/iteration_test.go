@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func iterationConfig() *Config {
+	cfg := Default()
+	cfg.Iterations = []IterationConfig{
+		{Name: "sprint-1", Start: "2025-08-04", End: "2025-08-17"},
+		{Name: "sprint-2", Start: "2025-08-18", End: "2025-08-31"},
+		{Name: "backlog-grooming"},
+	}
+	return cfg
+}
+
+func TestCurrentIteration(t *testing.T) {
+	now := time.Date(2025, 8, 20, 9, 0, 0, 0, time.UTC)
+
+	t.Run("by dates", func(t *testing.T) {
+		got, err := iterationConfig().CurrentIteration(now)
+		if err != nil || got != "sprint-2" {
+			t.Errorf("CurrentIteration() = %q, %v; want sprint-2", got, err)
+		}
+	})
+
+	t.Run("marked current wins over dates", func(t *testing.T) {
+		cfg := iterationConfig()
+		cfg.Iterations[0].Current = true
+		got, err := cfg.CurrentIteration(now)
+		if err != nil || got != "sprint-1" {
+			t.Errorf("CurrentIteration() = %q, %v; want sprint-1", got, err)
+		}
+	})
+
+	t.Run("none matches", func(t *testing.T) {
+		if got, err := iterationConfig().CurrentIteration(now.AddDate(0, 2, 0)); err == nil {
+			t.Errorf("CurrentIteration() = %q, want an error", got)
+		}
+	})
+
+	t.Run("ISO week without declared iterations", func(t *testing.T) {
+		got, err := Default().CurrentIteration(now)
+		if err != nil || got != "2025-W34" {
+			t.Errorf("CurrentIteration() = %q, %v; want 2025-W34", got, err)
+		}
+	})
+}
+
+func TestResolveIteration(t *testing.T) {
+	cfg := iterationConfig()
+	now := time.Date(2025, 8, 5, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct{ in, want string }{
+		{"current", "sprint-1"},
+		{"sprint-2", "sprint-2"},
+		{"2025-W40", "2025-W40"},
+		{"", ""},
+	} {
+		got, err := cfg.ResolveIteration(tt.in, now)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveIteration(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	_, err := cfg.ResolveIteration("sprnt-2", now)
+	valueErr, ok := errors.AsType[*ValueError](err)
+	if !ok {
+		t.Fatalf("ResolveIteration(sprnt-2) = %v, want *ValueError", err)
+	}
+	if valueErr.Field != "iteration" || valueErr.Suggestion != "sprint-2" {
+		t.Errorf("error = %+v, want iteration with suggestion sprint-2", valueErr)
+	}
+	if !strings.Contains(err.Error(), "an ISO week such as 2025-W34") {
+		t.Errorf("error %q should mention ISO weeks", err)
+	}
+}
+
+func TestValidateIterationWeeks(t *testing.T) {
+	cfg := Default()
+	for _, name := range []string{"2025-W01", "2025-W34", "2026-W53"} {
+		if err := cfg.ValidateIteration(name); err != nil {
+			t.Errorf("ValidateIteration(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"2025-W00", "2025-W54", "2025-w34", "sprint-1"} {
+		if err := cfg.ValidateIteration(name); err == nil {
+			t.Errorf("ValidateIteration(%q) = nil, want an error", name)
+		}
+	}
+}
+
+func TestIterationErrors(t *testing.T) {
+	cfg := iterationConfig()
+	if errs := cfg.IterationErrors(); len(errs) != 0 {
+		t.Errorf("IterationErrors() = %q, want none", errs)
+	}
+
+	cfg.Iterations = append(cfg.Iterations,
+		IterationConfig{Name: "sprint-1"},
+		IterationConfig{Name: "sprint-3", Start: "2025-09-14", End: "2025-09-01"},
+		IterationConfig{Name: "sprint-4", Start: "Sept 1"},
+		IterationConfig{},
+	)
+	cfg.Iterations[0].Current = true
+	cfg.Iterations[1].Current = true
+	got := strings.Join(cfg.IterationErrors(), "\n")
+	for _, want := range []string{
+		"'sprint-1' is declared more than once",
+		"'sprint-3' ends before it starts",
+		"'sprint-4' has invalid start 'Sept 1'",
+		"empty name",
+		"2 iterations are marked current",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("IterationErrors() missing %q:\n%s", want, got)
+		}
+	}
+}
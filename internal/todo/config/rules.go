@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidationRule requires fields to be set on issues in a status, such as
+// a body before an issue is completed.
+type ValidationRule struct {
+	WhenStatus string   `yaml:"when_status"`
+	Require    []string `yaml:"require"`
+}
+
+func (r ValidationRule) String() string {
+	return fmt.Sprintf("when_status: %s, require: [%s]", r.WhenStatus, strings.Join(r.Require, ", "))
+}
+
+// RuleFields are the issue fields a validation rule can require.
+var RuleFields = []string{
+	"summary", "type", "priority", "milestone", "iteration", "tags",
+	"due", "parent", "blocking", "blocked_by", "body",
+}
+
+// RulesFor returns the validation rules that apply to issues in status,
+// in config order.
+func (c *Config) RulesFor(status string) []ValidationRule {
+	var rules []ValidationRule
+	for _, r := range c.ValidationRules {
+		if r.WhenStatus == status {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// validateRules rejects rules for unknown statuses or fields.
+func (c *Config) validateRules() error {
+	for i, r := range c.ValidationRules {
+		if !c.IsValidStatus(r.WhenStatus) {
+			return fmt.Errorf("validation_rules[%d].when_status: %q is not a status", i, r.WhenStatus)
+		}
+		if len(r.Require) == 0 {
+			return fmt.Errorf("validation_rules[%d].require: no fields listed", i)
+		}
+		for _, f := range r.Require {
+			if !slices.Contains(RuleFields, f) {
+				return fmt.Errorf("validation_rules[%d].require: unknown field %q (must be %s)", i, f, strings.Join(RuleFields, ", "))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadValidationRules(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unknown field", "todo:\n    validation_rules:\n        - when_status: in-progress\n          require: [assignee]\n", `validation_rules[0].require: unknown field "assignee"`},
+		{"unknown status", "todo:\n    validation_rules:\n        - when_status: done\n          require: [body]\n", `validation_rules[0].when_status: "done" is not a status`},
+		{"no fields", "todo:\n    validation_rules:\n        - when_status: completed\n", "validation_rules[0].require: no fields listed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ConfigFileName)
+			if err := os.WriteFile(configPath, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(configPath); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	configPath := filepath.Join(t.TempDir(), ConfigFileName)
+	yaml := "todo:\n    validation_rules:\n        - when_status: completed\n          require: [body, due]\n        - when_status: review\n          require: [summary]\n        - when_status: completed\n          require: [tags]\n"
+	if err := os.WriteFile(configPath, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	rules := cfg.RulesFor(StatusCompleted)
+	if len(rules) != 2 || rules[0].String() != "when_status: completed, require: [body, due]" || rules[1].Require[0] != "tags" {
+		t.Errorf("RulesFor(completed) = %v", rules)
+	}
+	if rules := cfg.RulesFor(StatusReady); len(rules) != 0 {
+		t.Errorf("RulesFor(ready) = %v, want none", rules)
+	}
+}
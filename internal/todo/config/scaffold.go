@@ -0,0 +1,185 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SyncScaffolds are the sync providers `todo init --with-sync` can add, with
+// the settings each needs filled in.
+var SyncScaffolds = map[string]map[string]any{
+	"github":  {"repo": ""},
+	"clickup": {"list_id": ""},
+}
+
+// InitOptions are the choices `todo init` scaffolds into the todo section.
+type InitOptions struct {
+	// Path is the data directory, relative to the config file. Empty means
+	// DefaultDataPath.
+	Path string
+	// Statuses are the statuses to enable. Mandatory statuses are enabled
+	// whether listed or not; empty enables only those.
+	Statuses []string
+	// Sync names the providers to add a sync section for (see SyncScaffolds).
+	Sync           []string
+	RequireIfMatch bool
+}
+
+// Scaffold returns the todo config for opts, with the defaults a fresh
+// project gets. It rejects unknown statuses and sync providers.
+func Scaffold(opts InitOptions) (*Config, error) {
+	cfg := Default()
+	if opts.Path != "" {
+		cfg.Path = opts.Path
+	}
+	if opts.RequireIfMatch {
+		cfg.RequireIfMatch = RequireIfMatchAll()
+	}
+
+	for _, s := range opts.Statuses {
+		if err := cfg.ValidateStatus(s); err != nil {
+			return nil, err
+		}
+		if IsMandatoryStatus(s) {
+			continue
+		}
+		if cfg.ExtraStatuses == nil {
+			cfg.ExtraStatuses = make(map[string]bool)
+		}
+		cfg.ExtraStatuses[s] = true
+	}
+
+	for _, name := range opts.Sync {
+		settings, ok := SyncScaffolds[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sync provider %q (must be %s)", name, strings.Join(SyncProviderNames(), ", "))
+		}
+		if cfg.Sync == nil {
+			cfg.Sync = make(map[string]map[string]any)
+		}
+		cfg.Sync[name] = settings
+	}
+	return cfg, nil
+}
+
+// SyncProviderNames returns the providers in SyncScaffolds, sorted.
+func SyncProviderNames() []string {
+	names := make([]string, 0, len(SyncScaffolds))
+	for name := range SyncScaffolds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// MergeResult is the outcome of MergeMissing.
+type MergeResult struct {
+	// Path is the config file to write.
+	Path string
+	// Content is the full config file with the merged todo section.
+	Content []byte
+	// Section is the merged todo section alone, wrapped in its "todo:" key.
+	Section []byte
+	// Existed reports whether the file already had a todo section.
+	Existed bool
+	// Added lists the dotted keys filled in from c (e.g. "extra_statuses.review")
+	// when a todo section existed.
+	Added []string
+	// DataPath is the absolute data directory the merged section names.
+	DataPath string
+}
+
+// MergeMissing works out the .jig.yaml in dir with c scaffolded into it,
+// without writing anything. A file with no todo section gets all of c; an
+// existing todo section keeps every key it sets and gains only the ones it
+// is missing, so running init twice is harmless. Other sections and the
+// existing section's comments are preserved.
+func (c *Config) MergeMissing(dir string) (*MergeResult, error) {
+	result := &MergeResult{Path: filepath.Join(dir, ConfigFileName)}
+
+	var scaffold yaml.Node
+	if err := scaffold.Encode(c); err != nil {
+		return nil, fmt.Errorf("encoding todo config: %w", err)
+	}
+
+	var root yaml.Node
+	data, err := os.ReadFile(result.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	case len(data) > 0:
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", result.Path, err)
+		}
+	}
+	if root.Kind == 0 {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: top level is not a mapping", result.Path)
+	}
+
+	section := mappingValue(doc, "todo")
+	if section != nil && section.Kind == yaml.MappingNode {
+		result.Existed = true
+		result.Added = mergeMissingKeys(section, &scaffold, "")
+	} else {
+		section = &scaffold
+		replaceOrAppendKey(&root, "todo", section)
+	}
+
+	// An existing section may already name a different data directory.
+	var merged Config
+	if err := section.Decode(&merged); err != nil {
+		return nil, fmt.Errorf("decoding todo section: %w", err)
+	}
+	merged.Path = cmp.Or(merged.Path, DefaultDataPath)
+	merged.configDir = dir
+	result.DataPath = merged.ResolveDataPath()
+
+	if result.Content, err = yaml.Marshal(&root); err != nil {
+		return nil, fmt.Errorf("marshaling document: %w", err)
+	}
+	wrapped := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: "todo"}, section}}
+	if result.Section, err = yaml.Marshal(wrapped); err != nil {
+		return nil, fmt.Errorf("marshaling todo section: %w", err)
+	}
+	return result, nil
+}
+
+// mappingValue returns the value node for key in mapping m, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mergeMissingKeys adds the keys of src that dst lacks, recursing into
+// mappings both have, and returns the dotted names of the keys it added.
+func mergeMissingKeys(dst, src *yaml.Node, prefix string) []string {
+	var added []string
+	for i := 0; i < len(src.Content)-1; i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		existing := mappingValue(dst, key.Value)
+		switch {
+		case existing == nil:
+			dst.Content = append(dst.Content, key, value)
+			added = append(added, prefix+key.Value)
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			added = append(added, mergeMissingKeys(existing, value, prefix+key.Value+".")...)
+		}
+	}
+	return added
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScaffoldRejectsUnknownChoices(t *testing.T) {
+	if _, err := Scaffold(InitOptions{Statuses: []string{"bogus"}}); err == nil {
+		t.Error("expected error for unknown status")
+	}
+	if _, err := Scaffold(InitOptions{Sync: []string{"jira"}}); err == nil {
+		t.Error("expected error for unknown sync provider")
+	}
+}
+
+func TestMergeMissingFreshFile(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Scaffold(InitOptions{Path: "tasks", Sync: []string{"github"}, RequireIfMatch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := cfg.MergeMissing(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Existed || len(result.Added) != 0 {
+		t.Errorf("Existed = %v, Added = %v, want a fresh section", result.Existed, result.Added)
+	}
+	if result.DataPath != filepath.Join(dir, "tasks") {
+		t.Errorf("DataPath = %q, want %q", result.DataPath, filepath.Join(dir, "tasks"))
+	}
+	for _, want := range []string{"todo:", "path: tasks", "require_if_match: true", "github:", "repo:"} {
+		if !strings.Contains(string(result.Section), want) {
+			t.Errorf("Section missing %q:\n%s", want, result.Section)
+		}
+	}
+	if _, err := os.Stat(result.Path); !os.IsNotExist(err) {
+		t.Error("MergeMissing wrote the config file")
+	}
+}
+
+func TestMergeMissingKeepsExistingSection(t *testing.T) {
+	dir := t.TempDir()
+	existing := `# project config
+todo:
+  # where issues live
+  path: work
+  default_type: bug
+  extra_statuses:
+    draft: true
+citations:
+  sources: []
+`
+	path := filepath.Join(dir, ConfigFileName)
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Scaffold(InitOptions{Statuses: []string{"review"}, RequireIfMatch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := cfg.MergeMissing(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Existed {
+		t.Fatal("expected existing todo section")
+	}
+	for _, key := range []string{"default_status", "extra_statuses.review", "require_if_match"} {
+		if !slices.Contains(result.Added, key) {
+			t.Errorf("Added = %v, want %q", result.Added, key)
+		}
+	}
+	if slices.Contains(result.Added, "path") || slices.Contains(result.Added, "default_type") {
+		t.Errorf("Added = %v, should not replace existing keys", result.Added)
+	}
+	if result.DataPath != filepath.Join(dir, "work") {
+		t.Errorf("DataPath = %q, want existing path kept", result.DataPath)
+	}
+
+	if err := os.WriteFile(path, result.Content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# where issues live", "citations:", "path: work"} {
+		if !strings.Contains(string(result.Content), want) {
+			t.Errorf("merged file missing %q:\n%s", want, result.Content)
+		}
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.DefaultType != "bug" || !loaded.RequireIfMatch.All() {
+		t.Errorf("loaded DefaultType = %q, RequireIfMatch = %v", loaded.DefaultType, loaded.RequireIfMatch)
+	}
+	if !loaded.IsStatusEnabled("draft") || !loaded.IsStatusEnabled("review") {
+		t.Errorf("enabled statuses = %v, want draft and review", loaded.EnabledStatusNames())
+	}
+
+	// A second run has nothing left to add.
+	again, err := cfg.MergeMissing(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Added) != 0 {
+		t.Errorf("second merge Added = %v, want none", again.Added)
+	}
+}
+
+func TestScaffoldStatusesRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Scaffold(InitOptions{Statuses: []string{"in-progress", "ready"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := cfg.MergeMissing(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ConfigFileName)
+	if err := os.WriteFile(path, result.Content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"in-progress", "ready", "completed"}
+	got := loaded.EnabledStatusNames()
+	for _, s := range want {
+		if !slices.Contains(got, s) {
+			t.Errorf("EnabledStatusNames() = %v, missing %q", got, s)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("EnabledStatusNames() = %v, want only %v", got, want)
+	}
+	def := Default()
+	if loaded.Path != def.Path || loaded.DefaultStatus != def.DefaultStatus || loaded.DefaultType != def.DefaultType {
+		t.Errorf("loaded defaults = %q/%q/%q, want %q/%q/%q",
+			loaded.Path, loaded.DefaultStatus, loaded.DefaultType, def.Path, def.DefaultStatus, def.DefaultType)
+	}
+}
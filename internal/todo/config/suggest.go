@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValueError reports a status, type, or priority the config does not define.
+type ValueError struct {
+	Field      string   // "status", "type", "priority", "iteration", or "visibility"
+	Value      string   // the rejected value
+	Valid      []string // the configured values
+	Suggestion string   // nearest valid value, or "" when none is close
+}
+
+func (e *ValueError) Error() string {
+	msg := fmt.Sprintf("invalid %s: %s (must be %s)", e.Field, e.Value, strings.Join(e.Valid, ", "))
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+func newValueError(field, value string, valid []string) *ValueError {
+	return &ValueError{Field: field, Value: value, Valid: valid, Suggestion: Suggest(value, valid)}
+}
+
+// ValidateStatus returns a *ValueError if status is not a known status. It
+// does not check whether the status is enabled.
+func (c *Config) ValidateStatus(status string) error {
+	if c.IsValidStatus(status) {
+		return nil
+	}
+	return newValueError("status", status, DefaultStatusNames())
+}
+
+// ValidateType returns a *ValueError if name is not a built-in or
+// project-defined type.
+func (c *Config) ValidateType(name string) error {
+	if c.IsValidType(name) {
+		return nil
+	}
+	return newValueError("type", name, c.TypeNames())
+}
+
+// ValidatePriority returns a *ValueError if priority is set but not a known
+// priority. Empty means no priority and is valid.
+func (c *Config) ValidatePriority(priority string) error {
+	if c.IsValidPriority(priority) {
+		return nil
+	}
+	return newValueError("priority", priority, c.PriorityNames())
+}
+
+// ValidateVisibility returns a *ValueError if visibility is set but neither
+// public nor internal. Empty means public and is valid.
+func ValidateVisibility(visibility string) error {
+	if visibility == "" || slices.Contains(VisibilityNames, visibility) {
+		return nil
+	}
+	return newValueError("visibility", visibility, VisibilityNames)
+}
+
+// Suggest returns the candidate nearest to value, ignoring case, or "" when
+// none is within a typo's reach: one edit, or one per three characters of
+// the candidate for longer names. A swap of adjacent letters counts as one
+// edit.
+func Suggest(value string, candidates []string) string {
+	return nearest(value, candidates, func(cand string) int { return max(1, len([]rune(cand))/3) })
+}
+
+// SuggestWithin is Suggest with a fixed reach: the nearest candidate at most
+// maxEdits edits from value, or "".
+func SuggestWithin(value string, candidates []string, maxEdits int) string {
+	return nearest(value, candidates, func(string) int { return maxEdits })
+}
+
+// nearest returns the first candidate with the smallest distance to value
+// that is within reach(candidate), ignoring case.
+func nearest(value string, candidates []string, reach func(cand string) int) string {
+	value = strings.ToLower(value)
+	best, bestDist := "", -1
+	for _, cand := range candidates {
+		d := editDistance(value, strings.ToLower(cand))
+		if d > reach(cand) {
+			continue
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = cand, d
+		}
+	}
+	return best
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions, and adjacent transpositions.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// rows[i][j] is the distance between s[:i] and t[:j]; only the last
+	// three rows are needed.
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(t)]
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	priorities := Default().PriorityNames()
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"hgih", "high"},         // adjacent swap
+		{"crtical", "critical"},  // dropped letter
+		{"HIGH", "high"},         // case only
+		{"deffered", "deferred"}, // two edits in a long name
+		{"urgent", ""},           // nothing close
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Suggest(tt.value, priorities); got != tt.want {
+			t.Errorf("Suggest(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	cfg := Default()
+
+	err := cfg.ValidatePriority("hgih")
+	valueErr, ok := errors.AsType[*ValueError](err)
+	if !ok {
+		t.Fatalf("ValidatePriority(hgih) = %v, want *ValueError", err)
+	}
+	if valueErr.Suggestion != PriorityHigh {
+		t.Errorf("Suggestion = %q, want %q", valueErr.Suggestion, PriorityHigh)
+	}
+	for _, want := range []string{"invalid priority: hgih", "critical, high, normal", `did you mean "high"?`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	if err := cfg.ValidateStatus("redy"); err == nil || !strings.Contains(err.Error(), `"ready"`) {
+		t.Errorf("ValidateStatus(redy) = %v, want suggestion of ready", err)
+	}
+	if err := cfg.ValidateType("wibble"); err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("ValidateType(wibble) = %v, want an error without a suggestion", err)
+	}
+	if err := cfg.ValidatePriority(""); err != nil {
+		t.Errorf("ValidatePriority(\"\") = %v, want nil (no priority)", err)
+	}
+	if err := cfg.ValidateType(TypeBug); err != nil {
+		t.Errorf("ValidateType(bug) = %v", err)
+	}
+}
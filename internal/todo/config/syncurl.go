@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SyncURLTemplate returns the sync_url_templates entry for the sync entry
+// name, or "" when there is none.
+func (c *Config) SyncURLTemplate(name string) string {
+	if c == nil {
+		return ""
+	}
+	return c.SyncURLTemplates[name]
+}
+
+// ExpandSyncURL fills tmpl's {key} placeholders from an issue's sync data,
+// query-escaping each value so it is safe in a path or a query string.
+// It returns "" when a placeholder's key is missing or empty in data, or
+// tmpl is malformed, so an entry without the data has no URL rather than a
+// broken one.
+func ExpandSyncURL(tmpl string, data map[string]any) string {
+	var out strings.Builder
+	for rest := tmpl; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			out.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return ""
+		}
+		key := rest[open+1 : open+end]
+		value := syncValueString(data[key])
+		if key == "" || value == "" {
+			return ""
+		}
+		out.WriteString(rest[:open])
+		out.WriteString(strings.ReplaceAll(url.QueryEscape(value), "+", "%20"))
+		rest = rest[open+end+1:]
+	}
+	return out.String()
+}
+
+// syncValueString formats a scalar sync data value; anything else (a
+// nested map or list) has no URL form and formats as "".
+func syncValueString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int, int64, float64, bool:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+// validateSyncURLTemplates rejects templates with an empty or unclosed
+// placeholder, which could never expand.
+func (c *Config) validateSyncURLTemplates() error {
+	names := make([]string, 0, len(c.SyncURLTemplates))
+	for name := range c.SyncURLTemplates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err := checkURLTemplate(c.SyncURLTemplates[name]); err != nil {
+			return fmt.Errorf("sync_url_templates.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func checkURLTemplate(tmpl string) error {
+	if tmpl == "" {
+		return errors.New("template is empty")
+	}
+	for rest := tmpl; ; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			return nil
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return fmt.Errorf("unclosed placeholder in %q", tmpl)
+		}
+		if end == 1 {
+			return fmt.Errorf("empty placeholder in %q", tmpl)
+		}
+		rest = rest[open+end+1:]
+	}
+}
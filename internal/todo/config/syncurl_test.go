@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandSyncURL(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		data map[string]any
+		want string
+	}{
+		{"single", "https://acme.atlassian.net/browse/{issue_key}", map[string]any{"issue_key": "ENG-12"}, "https://acme.atlassian.net/browse/ENG-12"},
+		{"multiple", "https://gitlab.com/{group}/{project}/-/issues/{iid}", map[string]any{"group": "toba", "project": "jig", "iid": 7}, "https://gitlab.com/toba/jig/-/issues/7"},
+		{"escaped", "https://x.test/search?q={q}&page={page}", map[string]any{"q": "a b&c=d/e", "page": "1"}, "https://x.test/search?q=a%20b%26c%3Dd%2Fe&page=1"},
+		{"no placeholders", "https://x.test/", nil, "https://x.test/"},
+		{"missing key", "https://x.test/{id}/{other}", map[string]any{"id": "1"}, ""},
+		{"empty value", "https://x.test/{id}", map[string]any{"id": ""}, ""},
+		{"non-scalar value", "https://x.test/{id}", map[string]any{"id": []any{"1"}}, ""},
+		{"unclosed", "https://x.test/{id", map[string]any{"id": "1"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandSyncURL(tt.tmpl, tt.data); got != tt.want {
+				t.Errorf("ExpandSyncURL(%q) = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSyncURLTemplates(t *testing.T) {
+	tests := []struct {
+		content string
+		wantErr string
+	}{
+		{"todo:\n  sync_url_templates:\n    jira: https://acme.atlassian.net/browse/{issue_key}\n", ""},
+		{"todo:\n  sync_url_templates:\n    jira: https://acme.atlassian.net/browse/{issue_key\n", "sync_url_templates.jira: unclosed placeholder"},
+		{"todo:\n  sync_url_templates:\n    jira: https://acme.atlassian.net/browse/{}\n", "sync_url_templates.jira: empty placeholder"},
+		{"todo:\n  sync_url_templates:\n    jira: \"\"\n", "sync_url_templates.jira: template is empty"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), ConfigFileName)
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(path)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Fatalf("Load(%q) error = %v", tt.content, err)
+			}
+			if got := cfg.SyncURLTemplate("jira"); got != "https://acme.atlassian.net/browse/{issue_key}" {
+				t.Errorf("SyncURLTemplate(jira) = %q", got)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Load(%q) error = %v, want %q", tt.content, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// TUIConfig tunes the TUI list.
+type TUIConfig struct {
+	// CollapseResolved greys out closed issues and folds the top-level ones
+	// into one summary row per status at the bottom of the list: "true" for
+	// every closed issue, an age such as "30d" for those unchanged that long,
+	// and "false" (the default) for none.
+	CollapseResolved string `yaml:"collapse_resolved,omitempty"`
+}
+
+// GetCollapseResolved reports whether the TUI collapses closed issues, and
+// how long one must go unchanged first (0 for all of them).
+func (c *Config) GetCollapseResolved() (after time.Duration, on bool) {
+	if c == nil {
+		return 0, false
+	}
+	switch c.TUI.CollapseResolved {
+	case "", "false":
+		return 0, false
+	case "true":
+		return 0, true
+	}
+	d, err := ParseDuration(c.TUI.CollapseResolved)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// IsCollapsedResolved reports whether the TUI greys out and collapses an
+// issue with the given status, last updated at updatedAt, at now: it is in
+// an archive status and, when collapse_resolved is an age, has gone
+// unchanged longer than that.
+func (c *Config) IsCollapsedResolved(status string, updatedAt, now time.Time) bool {
+	after, on := c.GetCollapseResolved()
+	if !on || !c.IsArchiveStatus(status) {
+		return false
+	}
+	return after == 0 || (!updatedAt.IsZero() && now.Sub(updatedAt) > after)
+}
+
+// validateTUI rejects a collapse_resolved that is neither a boolean nor an
+// age.
+func (c *Config) validateTUI() error {
+	switch v := c.TUI.CollapseResolved; v {
+	case "", "true", "false":
+		return nil
+	default:
+		if _, err := ParseDuration(v); err != nil {
+			return fmt.Errorf("tui.collapse_resolved: must be true, false, or an age such as 30d: %w", err)
+		}
+	}
+	return nil
+}
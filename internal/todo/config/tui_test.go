@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadCollapseResolved(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	old, recent := now.Add(-40*24*time.Hour), now.Add(-2*24*time.Hour)
+	tests := []struct {
+		value   string
+		wantErr string
+		old     bool // whether an old completed issue collapses
+		recent  bool // whether a recent scrapped issue collapses
+	}{
+		{value: "true", old: true, recent: true},
+		{value: "false"},
+		{value: "30d", old: true},
+		{value: "soon", wantErr: "tui.collapse_resolved"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), ConfigFileName)
+		content := "todo:\n  tui:\n    collapse_resolved: " + tt.value + "\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(path)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load(%s) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", tt.value, err)
+		}
+		if got := cfg.IsCollapsedResolved(StatusCompleted, old, now); got != tt.old {
+			t.Errorf("%s: old completed collapsed = %v, want %v", tt.value, got, tt.old)
+		}
+		if got := cfg.IsCollapsedResolved(StatusScrapped, recent, now); got != tt.recent {
+			t.Errorf("%s: recent scrapped collapsed = %v, want %v", tt.value, got, tt.recent)
+		}
+		if cfg.IsCollapsedResolved(StatusInProgress, old, now) {
+			t.Errorf("%s: open issue collapsed", tt.value)
+		}
+	}
+
+	if _, on := Default().GetCollapseResolved(); on {
+		t.Error("collapse_resolved is on by default")
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// WatcherConfig tunes the file watcher behind the TUI, `list --watch`, and
+// `serve`. Zero fields keep the built-in behavior: a 100ms debounce, no
+// batch limit, a 2s safety-net poll, and an error when fsnotify cannot
+// watch the data directory.
+type WatcherConfig struct {
+	// DebounceMs is how long the watcher waits after a change for more
+	// before handling the batch.
+	DebounceMs int `yaml:"debounce_ms,omitempty"`
+	// MaxBatch handles a batch as soon as it holds this many changed files,
+	// without waiting out the debounce. Zero means no limit.
+	MaxBatch int `yaml:"max_batch,omitempty"`
+	// PollFallback watches by scanning file modification times when
+	// fsnotify cannot watch the data directory, instead of failing.
+	PollFallback bool `yaml:"poll_fallback,omitempty"`
+	// PollIntervalMs is how often the directory is scanned: as a safety net
+	// alongside fsnotify, or as the only source of changes in poll mode.
+	PollIntervalMs int `yaml:"poll_interval_ms,omitempty"`
+}
+
+// Debounce returns debounce_ms as a duration, or 0 when unset.
+func (w WatcherConfig) Debounce() time.Duration {
+	return time.Duration(w.DebounceMs) * time.Millisecond
+}
+
+// PollInterval returns poll_interval_ms as a duration, or 0 when unset.
+func (w WatcherConfig) PollInterval() time.Duration {
+	return time.Duration(w.PollIntervalMs) * time.Millisecond
+}
+
+// GetWatcher returns the watcher settings; a nil config has none.
+func (c *Config) GetWatcher() WatcherConfig {
+	if c == nil {
+		return WatcherConfig{}
+	}
+	return c.Watcher
+}
+
+// validateWatcher rejects negative watcher settings.
+func (c *Config) validateWatcher() error {
+	w := c.Watcher
+	switch {
+	case w.DebounceMs < 0:
+		return errors.New("watcher.debounce_ms: must not be negative")
+	case w.MaxBatch < 0:
+		return errors.New("watcher.max_batch: must not be negative")
+	case w.PollIntervalMs < 0:
+		return errors.New("watcher.poll_interval_ms: must not be negative")
+	}
+	return nil
+}
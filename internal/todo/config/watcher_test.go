@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadWatcher(t *testing.T) {
+	tests := []struct {
+		content string
+		wantErr string
+	}{
+		{"todo:\n  watcher:\n    debounce_ms: 750\n    max_batch: 50\n    poll_fallback: true\n    poll_interval_ms: 5000\n", ""},
+		{"todo:\n  watcher:\n    debounce_ms: -1\n", "watcher.debounce_ms"},
+		{"todo:\n  watcher:\n    max_batch: -1\n", "watcher.max_batch"},
+		{"todo:\n  watcher:\n    poll_interval_ms: -1\n", "watcher.poll_interval_ms"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), ConfigFileName)
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(path)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Fatalf("Load(%q) error = %v", tt.content, err)
+			}
+			w := cfg.GetWatcher()
+			if w.Debounce() != 750*time.Millisecond || w.MaxBatch != 50 || !w.PollFallback || w.PollInterval() != 5*time.Second {
+				t.Errorf("GetWatcher() = %+v", w)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Load(%q) error = %v, want %q", tt.content, err, tt.wantErr)
+		}
+	}
+
+	// Unset, the watcher keeps its built-in timings.
+	var nilCfg *Config
+	if w := nilCfg.GetWatcher(); w.Debounce() != 0 || w.PollInterval() != 0 || w.PollFallback {
+		t.Errorf("nil config GetWatcher() = %+v, want zero", w)
+	}
+}
@@ -3,14 +3,17 @@
 package core
 
 import (
+	"bytes"
 	"cmp"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"hash/fnv"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +28,31 @@ const ArchiveDir = "archive"
 
 var ErrNotFound = errors.New("issue not found")
 
+// ErrIDExists is returned by Create when an explicitly supplied ID is already
+// used by an issue in memory or on disk.
+var ErrIDExists = errors.New("issue ID already exists")
+
+// ErrArchived is matched by the error Update and Delete return for an
+// issue in the archive directory. Archived issues stay readable, but are
+// only changed by unarchiving them first.
+var ErrArchived = errors.New("issue is archived")
+
+// ArchivedError is returned by a mutation of an archived issue. It matches
+// ErrArchived with errors.Is, and is distinct from ErrNotFound: the issue
+// exists, it is just closed to edits.
+type ArchivedError struct {
+	ID string
+}
+
+func (e *ArchivedError) Error() string {
+	return "issue " + e.ID + " is archived; unarchive it before changing it"
+}
+
+func (e *ArchivedError) Is(target error) bool { return target == ErrArchived }
+
+// maxIDAttempts bounds how many generated IDs Create tries before giving up.
+const maxIDAttempts = 10
+
 // ETagMismatchError is returned when an ETag validation fails.
 // This allows callers to distinguish concurrency conflicts from other errors.
 type ETagMismatchError struct {
@@ -36,11 +64,19 @@ func (e *ETagMismatchError) Error() string {
 	return fmt.Sprintf("etag mismatch: provided %s, current is %s", e.Provided, e.Current)
 }
 
-// ETagRequiredError is returned when require_if_match is enabled and no ETag is provided.
-type ETagRequiredError struct{}
+// ETagRequiredError is returned when require_if_match protects a field the
+// update changes and no ETag is provided. Field is the protected field class
+// (config.IfMatchBody, IfMatchStatus, or IfMatchMetadata), or empty when the
+// update changes nothing.
+type ETagRequiredError struct {
+	Field string
+}
 
 func (e *ETagRequiredError) Error() string {
-	return "if-match etag is required (set require_if_match: false in config to disable)"
+	if e.Field == "" {
+		return "if-match etag is required (set require_if_match: false in config to disable)"
+	}
+	return fmt.Sprintf("if-match etag is required to change %s (set require_if_match.%s: false in config to disable)", e.Field, e.Field)
 }
 
 // Core provides thread-safe in-memory storage for issues with filesystem persistence.
@@ -53,13 +89,42 @@ type Core struct {
 	issues     map[string]*issue.Issue     // ID -> Issue
 	milestones map[string]*issue.Milestone // ID -> Milestone
 
+	// Mention index: IDs referenced in each body, and the reverse
+	mentions    map[string][]string            // source ID -> mentioned IDs
+	mentionedBy map[string]map[string]struct{} // mentioned ID -> source IDs
+
+	// Link index: each issue's outgoing links, and the reverse of each kind
+	links      map[string]indexedLinks        // source ID -> its links
+	children   map[string]map[string]struct{} // parent ID -> child IDs
+	blockers   map[string]map[string]struct{} // blocked ID -> IDs whose blocking lists it
+	dependents map[string]map[string]struct{} // blocker ID -> IDs whose blocked_by lists it
+
+	// Blocked ID -> blockers its file still lists in blocked_by although the
+	// blocker's blocking field already holds the link (see dedupe.go)
+	duplicates map[string][]string
+
 	// Search index (optional, lazy-initialized)
 	searchIndex *search.Index
 
 	// File watching (optional)
-	watching bool
-	done     chan struct{}
-	onChange func() // callback when issues change (legacy API)
+	watching  bool
+	watchMode string // one of the WatchMode constants while watching
+	done      chan struct{}
+	onChange  func() // callback when issues change (legacy API)
+
+	// Issues as their files held them before this process rewrote them, by
+	// ID, until the watcher reads the new files (see noteWriteLocked)
+	written map[string]*issue.Issue
+
+	// Watcher tuning (tests only): debounce overrides debounceDelay when
+	// positive, and syncDelivery handles each change without debouncing
+	debounce     time.Duration
+	syncDelivery bool
+
+	// Pending WaitIdle calls, by the name of their marker file
+	barriers    map[string]chan struct{}
+	barrierMu   sync.Mutex
+	nextBarrier uint64
 
 	// Event subscribers (for channel-based API)
 	subscribers map[uint64]*subscription
@@ -68,11 +133,48 @@ type Core struct {
 
 	// Warning logger for non-fatal errors (defaults to stderr)
 	warnWriter io.Writer
+
+	// Files skipped by Load and the watcher, sorted by path
+	warnings []LoadWarning
+
+	// Paths Load and the watcher skip: the defaults plus IgnoreFile, read on
+	// each load (nil means the defaults)
+	ignore ignoreRules
+
+	// clock returns the current time for age computations (defaults to time.Now)
+	clock func() time.Time
+
+	// newID generates issue IDs (tests only; defaults to idGen)
+	newID func() string
+
+	// idGen generates IDs in the config's id_format, and idMatcher finds
+	// IDs in it or the default format, so older IDs keep matching after a
+	// format change. Both are rebuilt by SetConfig.
+	idGen     *issue.IDGenerator
+	idMatcher *issue.IDMatcher
+
+	// beforeUpdate runs at the start of Update, before the etag check (tests only)
+	beforeUpdate func(id string)
+
+	// Warm start (see snapshot.go): warm is open while the issues come from
+	// LoadSnapshot, with each one's ETag when saved in snapshotETags. Issues
+	// read from the snapshot are kept in snapshotIssues so Update refuses them.
+	warm           chan struct{}
+	snapshotETags  map[string]string
+	snapshotIssues map[*issue.Issue]struct{}
+
+	// live is the core a read-only view was made from (see View); nil on
+	// a core that can be changed
+	live *Core
+
+	// Issue body encryption key, resolved lazily from config (nil if none)
+	keyOnce sync.Once
+	key     []byte
 }
 
 // New creates a new Core with the given root path and configuration.
 func New(root string, cfg *config.Config) *Core {
-	return &Core{
+	c := &Core{
 		root:        root,
 		config:      cfg,
 		issues:      make(map[string]*issue.Issue),
@@ -80,6 +182,23 @@ func New(root string, cfg *config.Config) *Core {
 		subscribers: make(map[uint64]*subscription),
 		warnWriter:  os.Stderr,
 	}
+	c.buildIDFormat()
+	return c
+}
+
+// buildIDFormat builds idGen and idMatcher from the config's id_format.
+func (c *Core) buildIDFormat() {
+	format := c.config.GetIDFormat()
+	c.idGen = issue.NewIDGenerator(format)
+	c.idMatcher = issue.NewIDMatcher(config.DefaultIDFormat(), format)
+}
+
+// IDMatcher returns the matcher of IDs in the config's id_format or the
+// default one.
+func (c *Core) IDMatcher() *issue.IDMatcher {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.idMatcher
 }
 
 // SetWarnWriter sets the writer for warning messages.
@@ -88,6 +207,106 @@ func (c *Core) SetWarnWriter(w io.Writer) {
 	c.warnWriter = w
 }
 
+// SetClock overrides the time source used for timestamps and age checks.
+// Pass nil to restore time.Now. Intended for deterministic tests.
+func (c *Core) SetClock(fn func() time.Time) {
+	c.clock = fn
+}
+
+// SetDebounce sets how long the watcher waits after a change for more before
+// handling the batch. Pass 0 to restore the default. Call it before starting
+// the watcher. Intended for tests.
+func (c *Core) SetDebounce(d time.Duration) {
+	c.debounce = d
+}
+
+// SetSyncDelivery makes the watcher handle each change, and notify
+// subscribers, as soon as it reads it instead of debouncing. Call it before
+// starting the watcher. Intended for tests.
+func (c *Core) SetSyncDelivery(on bool) {
+	c.syncDelivery = on
+}
+
+// SetIDGenerator overrides the function Create uses to generate issue IDs.
+// Pass nil to restore IDs in the config's id_format. Intended for tests.
+func (c *Core) SetIDGenerator(fn func() string) {
+	c.newID = fn
+}
+
+// SetUpdateHook sets a function Update calls with the issue's ID before it
+// checks ifMatch, with the store locked, so a test can land a concurrent edit
+// between a read and a write. fn must not call back into c. Pass nil to
+// remove it. Intended for tests.
+func (c *Core) SetUpdateHook(fn func(id string)) {
+	c.beforeUpdate = fn
+}
+
+// generateID returns a new ID from the configured generator.
+func (c *Core) generateID() string {
+	if c.newID != nil {
+		return c.newID()
+	}
+	return c.idGen.NewID()
+}
+
+// Now returns the current time according to the core's clock.
+func (c *Core) Now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
+
+// IsStale reports whether b has gone longer than the configured stale_after
+// threshold without an update while in one of the stale statuses. Issues
+// that were never updated fall back to their creation time. Pinned issues
+// are never stale.
+func (c *Core) IsStale(b *issue.Issue) bool {
+	cfg := c.Config()
+	if cfg == nil || b.Pinned {
+		return false
+	}
+	ts := b.UpdatedAt
+	if ts == nil {
+		ts = b.CreatedAt
+	}
+	if ts == nil {
+		return false
+	}
+	return cfg.IsStale(b.Status, *ts, c.Now())
+}
+
+// AutoArchiveCandidates returns the issues outside the archive that the
+// auto_archive policy would move: those in a policy status that have gone
+// longer than auto_archive.after without an update (falling back to their
+// creation time). Empty when the policy is disabled.
+func (c *Core) AutoArchiveCandidates() []*issue.Issue {
+	now := c.Now()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.config == nil || c.config.GetAutoArchiveAfter() <= 0 {
+		return nil
+	}
+
+	var candidates []*issue.Issue
+	for _, b := range c.issues {
+		if c.isArchivedPath(b.Path) {
+			continue
+		}
+		ts := b.UpdatedAt
+		if ts == nil {
+			ts = b.CreatedAt
+		}
+		if ts == nil || !c.config.IsAutoArchiveDue(b.Status, *ts, now) {
+			continue
+		}
+		candidates = append(candidates, b)
+	}
+	return candidates
+}
+
 // logWarn logs a warning message if a warn writer is configured.
 func (c *Core) logWarn(format string, args ...any) {
 	if c.warnWriter != nil {
@@ -100,11 +319,24 @@ func (c *Core) Root() string {
 	return c.root
 }
 
-// Config returns the configuration.
+// Config returns the current configuration.
 func (c *Core) Config() *config.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.config
 }
 
+// SetConfig replaces the configuration, e.g. after the config file is edited
+// during a long-running session. Callers that read fields while holding c.mu
+// see either the old or the new config, never a mix. The body encryption key
+// stays the one resolved from the first config.
+func (c *Core) SetConfig(cfg *config.Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = cfg
+	c.buildIDFormat()
+}
+
 // Load reads all issues from disk into memory.
 func (c *Core) Load() error {
 	c.mu.Lock()
@@ -119,6 +351,12 @@ func (c *Core) loadFromDisk() error {
 	// Clear existing issues
 	c.issues = make(map[string]*issue.Issue)
 	c.milestones = make(map[string]*issue.Milestone)
+	c.mentions = nil
+	c.mentionedBy = nil
+	c.links, c.children, c.blockers, c.dependents = nil, nil, nil, nil
+	c.duplicates = nil
+	c.warnings = nil
+	c.ignore = loadIgnoreRules(c.root)
 
 	// Load milestones from the milestones subdirectory (best-effort: a missing
 	// directory is not an error).
@@ -132,9 +370,13 @@ func (c *Core) loadFromDisk() error {
 			return err
 		}
 
-		// Skip dot-prefixed subdirectories (e.g. .git, .DS_Store dirs)
-		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != c.root {
-			return filepath.SkipDir
+		// Skip ignored paths (hidden and junk files by default, see
+		// IgnoreFile) without a warning
+		if path != c.root && c.isIgnoredLocked(path, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
 		}
 
 		// Skip the milestones directory: milestone files are not issues.
@@ -147,18 +389,36 @@ func (c *Core) loadFromDisk() error {
 			return nil
 		}
 
+		// A bad file is skipped with a warning rather than failing the
+		// whole load, so one stray edit cannot hide every other issue.
 		b, loadErr := c.loadIssue(path)
 		if loadErr != nil {
-			return fmt.Errorf("loading %s: %w", path, loadErr)
+			c.addWarningLocked(path, loadWarningKind(loadErr), loadErr)
+			return nil
+		}
+		if existing, dup := c.issues[b.ID]; dup {
+			c.addWarningLocked(path, WarnDuplicate, fmt.Errorf("ID %s is already used by %s", b.ID, existing.Path))
+			return nil
 		}
 
+		c.checkTimesLocked(b)
 		c.issues[b.ID] = b
+		c.indexMentionsLocked(b)
+		c.indexLinksLocked(b)
 		return nil
 	})
 	if err != nil {
 		return err
 	}
 
+	// Links stored on both issues are kept once, on the blocker.
+	for _, b := range c.issues {
+		c.dedupeLinksLocked(b)
+	}
+	if n := len(c.duplicateLinksLocked()); n > 0 {
+		c.logWarn("%d blocking link(s) are also listed in blocked_by; run 'jig todo doctor --fix' to store each once", n)
+	}
+
 	// Reinitialize search index if it was active: close and re-create (best-effort, don't fail load)
 	if c.searchIndex != nil {
 		c.searchIndex.Close() //nolint:errcheck // best-effort cleanup
@@ -180,25 +440,59 @@ func (c *Core) loadIssue(path string) (*issue.Issue, error) {
 	}
 	defer f.Close() //nolint:errcheck // read-only file
 
-	b, err := issue.Parse(f)
+	data, err := c.readIssueFile(f)
 	if err != nil {
 		return nil, err
 	}
-
-	// Set metadata from path
 	relPath, err := filepath.Rel(c.root, path)
 	if err != nil {
 		return nil, err
 	}
+	b, err := c.ParseIssue(relPath, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if maxBody, _ := c.sizeLimitsLocked(); b.Body != issue.EncryptedPlaceholder && int64(len(b.Body)) > maxBody {
+		return nil, &SizeError{Part: "body", Size: int64(len(b.Body)), Limit: maxBody, Key: "max_body_bytes"}
+	}
+	if b.CreatedAt == nil {
+		// Use file modification time as fallback
+		info, statErr := os.Stat(path)
+		if statErr == nil {
+			modTime := info.ModTime().UTC().Truncate(time.Second)
+			b.CreatedAt = &modTime
+		}
+	}
+	if b.UpdatedAt == nil {
+		b.UpdatedAt = b.CreatedAt
+	}
+	return b, nil
+}
+
+// ParseIssue parses issue file content as though it were stored at relPath
+// under the data directory, decrypting it and filling in defaults as Load
+// does. It reads other versions of a file, such as one from git history;
+// CreatedAt stays nil when the file has neither timestamp.
+func (c *Core) ParseIssue(relPath string, r io.Reader) (*issue.Issue, error) {
+	b, err := issue.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if b.Title == "" && b.Status == "" && b.Type == "" {
+		return nil, errNotAnIssue
+	}
+
+	// Set metadata from path
 	b.Path = relPath
 
 	// Extract ID and slug from filename
-	filename := filepath.Base(path)
-	b.ID, b.Slug = issue.ParseFilename(filename)
+	filename := filepath.Base(relPath)
+	b.ID, b.Slug = c.idMatcher.ParseFilename(filename)
+
+	c.decryptLoaded(b)
 
 	// Apply defaults for GraphQL non-nullable fields
-	b.Type = cmp.Or(b.Type, config.TypeTask)
-	b.Priority = cmp.Or(b.Priority, config.PriorityNormal)
+	applyFieldDefaults(b)
 	if b.Tags == nil {
 		b.Tags = []string{}
 	}
@@ -206,16 +500,7 @@ func (c *Core) loadIssue(path string) (*issue.Issue, error) {
 		b.Blocking = []string{}
 	}
 	if b.CreatedAt == nil {
-		if b.UpdatedAt != nil {
-			b.CreatedAt = b.UpdatedAt
-		} else {
-			// Use file modification time as fallback
-			info, statErr := os.Stat(path)
-			if statErr == nil {
-				modTime := info.ModTime().UTC().Truncate(time.Second)
-				b.CreatedAt = &modTime
-			}
-		}
+		b.CreatedAt = b.UpdatedAt
 	}
 	if b.UpdatedAt == nil {
 		b.UpdatedAt = b.CreatedAt
@@ -224,6 +509,27 @@ func (c *Core) loadIssue(path string) (*issue.Issue, error) {
 	return b, nil
 }
 
+// errNotAnIssue is returned by loadIssue for markdown files without issue
+// front matter, such as a README dropped into the data directory.
+var errNotAnIssue = errors.New("no issue front matter (title, status, or type)")
+
+// loadWarningKind classifies a loadIssue error.
+func loadWarningKind(err error) string {
+	if errors.Is(err, errNotAnIssue) {
+		return WarnOrphanFile
+	}
+	if _, ok := errors.AsType[*SizeError](err); ok {
+		return WarnTooLarge
+	}
+	return WarnParse
+}
+
+// applyFieldDefaults fills the type and priority that an issue file may omit.
+func applyFieldDefaults(b *issue.Issue) {
+	b.Type = cmp.Or(b.Type, config.TypeTask)
+	b.Priority = cmp.Or(b.Priority, config.PriorityNormal)
+}
+
 // ensureSearchIndexLocked initializes the in-memory search index if not already created.
 // Must be called with lock held or from a method that holds the lock.
 func (c *Core) ensureSearchIndexLocked() error {
@@ -253,6 +559,20 @@ func (c *Core) ensureSearchIndexLocked() error {
 // Search performs full-text search and returns matching issues.
 // The search index is lazily initialized on first use.
 func (c *Core) Search(query string) ([]*issue.Issue, error) {
+	// A view searches the index of the core it was made from
+	index := c
+	if c.live != nil {
+		index = c.live
+	}
+	ids, err := index.searchIDs(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.issuesByID(ids), nil
+}
+
+// searchIDs returns the IDs of the issues the search index matches.
+func (c *Core) searchIDs(query string) ([]string, error) {
 	// Ensure index is initialized (needs write lock for lazy init)
 	c.mu.Lock()
 	if err := c.ensureSearchIndexLocked(); err != nil {
@@ -264,12 +584,12 @@ func (c *Core) Search(query string) ([]*issue.Issue, error) {
 	c.mu.Unlock()
 
 	// Perform search outside the lock (Bleve is thread-safe)
-	ids, err := idx.Search(query, search.DefaultSearchLimit)
-	if err != nil {
-		return nil, err
-	}
+	return idx.Search(query, search.DefaultSearchLimit)
+}
 
-	// Read from issues map (needs read lock only)
+// issuesByID returns the issues with the given IDs, in order, skipping any
+// that are gone.
+func (c *Core) issuesByID(ids []string) []*issue.Issue {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -279,11 +599,59 @@ func (c *Core) Search(query string) ([]*issue.Issue, error) {
 			result = append(result, b)
 		}
 	}
-	return result, nil
+	return result
 }
 
-// All returns a slice of all issues.
+// Find returns the issues query matches, with each place it matched, ranked
+// as search.Matcher.Find does. When the search index has been built, a
+// literal one-word query scans only the issues the index finds it in.
+func (c *Core) Find(query string, opts search.Options) ([]search.Hit, error) {
+	m, err := search.NewMatcher(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var issues []*issue.Issue
+	if c.searchIndex != nil && opts.Literal() {
+		ids, ok, err := c.searchIndex.Candidates(query)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			issues = make([]*issue.Issue, 0, len(ids))
+			for _, id := range ids {
+				if b, found := c.issues[id]; found {
+					issues = append(issues, b)
+				}
+			}
+			return m.Find(issues), nil
+		}
+	}
+	issues = make([]*issue.Issue, 0, len(c.issues))
+	for _, b := range c.issues {
+		issues = append(issues, b)
+	}
+	return m.Find(issues), nil
+}
+
+// All returns a new slice of all issues, oldest created first, then those
+// without created_at, with ties in ID order. The order is the same on every
+// call for the same issues, so callers may rely on it rather than sorting
+// again.
 func (c *Core) All() []*issue.Issue {
+	result := c.AllUnordered()
+	slices.SortFunc(result, func(a, b *issue.Issue) int {
+		return cmp.Or(compareCreated(a.CreatedAt, b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return result
+}
+
+// AllUnordered returns a new slice of all issues in no particular order,
+// for callers that aggregate over the issues or sort them themselves.
+func (c *Core) AllUnordered() []*issue.Issue {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -294,16 +662,33 @@ func (c *Core) All() []*issue.Issue {
 	return result
 }
 
-// Get finds an issue by exact ID match.
+// GetOptions controls which issues GetWith and LookupWith find.
+type GetOptions struct {
+	// IncludeArchived finds issues in the archive directory too. Without
+	// it, an archived issue is reported as an *ArchivedError, which is what
+	// a caller about to change the issue wants.
+	IncludeArchived bool
+}
+
+// Get finds an issue by exact ID match, archived or not, since archived
+// issues stay visible to every query.
 func (c *Core) Get(id string) (*issue.Issue, error) {
+	return c.GetWith(id, GetOptions{IncludeArchived: true})
+}
+
+// GetWith finds an issue by exact ID match as opts allow.
+func (c *Core) GetWith(id string, opts GetOptions) (*issue.Issue, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	if b, ok := c.issues[id]; ok {
-		return b, nil
+	b, ok := c.issues[id]
+	if !ok {
+		return nil, ErrNotFound
 	}
-
-	return nil, ErrNotFound
+	if !opts.IncludeArchived && c.isArchivedPath(b.Path) {
+		return nil, &ArchivedError{ID: id}
+	}
+	return b, nil
 }
 
 // NormalizeID checks if the given ID exists and returns it.
@@ -320,27 +705,51 @@ func (c *Core) NormalizeID(id string) (string, bool) {
 }
 
 // Create adds a new issue, generating an ID if needed, and writes it to disk.
+// An issue that already has a created_at, as an imported one may, keeps it.
 func (c *Core) Create(b *issue.Issue) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
-	// Generate ID if not provided
-	if b.ID == "" {
-		b.ID = issue.NewID()
+	if err := c.validateValuesLocked(b); err != nil {
+		return err
+	}
+	if err := c.checkRulesLocked(b); err != nil {
+		return err
+	}
+	if err := c.checkSizeLocked(b); err != nil {
+		return err
 	}
 
 	// Set timestamps
-	now := time.Now().UTC().Truncate(time.Second)
-	b.CreatedAt = &now
+	now := c.Now().UTC().Truncate(time.Second)
+	if b.CreatedAt == nil {
+		b.CreatedAt = &now
+	}
 	b.UpdatedAt = &now
 
-	// Write to disk
-	if err := c.saveToDisk(b); err != nil {
+	if b.ID != "" {
+		// An explicit ID must never overwrite an existing issue.
+		if c.idTakenLocked(b.ID) {
+			return fmt.Errorf("%w: %s", ErrIDExists, b.ID)
+		}
+		if err := c.createOnDisk(b); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				return fmt.Errorf("%w: %s", ErrIDExists, b.ID)
+			}
+			return err
+		}
+	} else if err := c.createWithGeneratedIDLocked(b); err != nil {
 		return err
 	}
 
 	// Add to in-memory map
 	c.issues[b.ID] = b
+	c.indexMentionsLocked(b)
+	c.indexLinksLocked(b)
+	c.dedupeLinksLocked(b)
+	if err := c.flushDuplicatesLocked(b.Blocking); err != nil {
+		return err
+	}
 
 	// Update search index if active (best-effort, don't fail create)
 	if c.searchIndex != nil {
@@ -352,34 +761,85 @@ func (c *Core) Create(b *issue.Issue) error {
 	return nil
 }
 
-// Update modifies an existing issue and writes it to disk.
+// Update modifies an existing issue and writes it to disk. An archived
+// issue is refused with an *ArchivedError.
 // If ifMatch is provided, validates the current on-disk version's etag matches before updating.
 // This provides optimistic concurrency control to prevent lost updates.
 func (c *Core) Update(b *issue.Issue, ifMatch *string) error {
-	c.mu.Lock()
+	return c.update(b, ifMatch, GetOptions{})
+}
+
+// update is Update with opts deciding whether an archived issue may be
+// written; only migrations that rewrite every issue in place allow it.
+func (c *Core) update(b *issue.Issue, ifMatch *string, opts GetOptions) error {
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
+	if _, stale := c.snapshotIssues[b]; stale {
+		return ErrSnapshotIssue
+	}
+
 	// Verify issue exists in memory
 	storedIssue, ok := c.issues[b.ID]
 	if !ok {
 		return ErrNotFound
 	}
+	if !opts.IncludeArchived && c.isArchivedPath(storedIssue.Path) {
+		return &ArchivedError{ID: b.ID}
+	}
 
-	if err := c.validateETagLocked(storedIssue, ifMatch); err != nil {
+	if c.beforeUpdate != nil {
+		c.beforeUpdate(b.ID)
+	}
+	if err := c.validateETagLocked(storedIssue, ifMatch, func() []string { return c.changedClassesLocked(b) }); err != nil {
+		return err
+	}
+	if err := c.validateValuesLocked(b); err != nil {
+		return err
+	}
+	if err := c.checkRulesLocked(b); err != nil {
+		return err
+	}
+	if err := c.checkSizeLocked(b); err != nil {
 		return err
 	}
 
-	// Update timestamp
-	now := time.Now().UTC().Truncate(time.Second)
-	b.UpdatedAt = &now
+	// Update timestamp, unless nothing but sync metadata changed: bumping
+	// updated_at then would make the issue look sync-stale forever.
+	if c.contentChangedLocked(b) {
+		now := c.Now().UTC().Truncate(time.Second)
+		b.UpdatedAt = &now
+	}
+
+	// Targets this issue stopped blocking are rewritten too if their files
+	// still repeat the link, so it does not come back on the next load.
+	touched := slices.Concat(c.links[b.ID].blocking, b.Blocking)
+
+	// Keep the body being replaced (best-effort, don't fail update)
+	if err := c.saveRevisionLocked(b); err != nil {
+		c.logWarn("failed to save revision of issue %s: %v", b.ID, err)
+	}
+
+	// Follow a title change with the filename (rename_files_on_title_change)
+	undoRename, err := c.renameForTitleLocked(b)
+	if err != nil {
+		return err
+	}
 
 	// Write to disk
 	if err := c.saveToDisk(b); err != nil {
+		undoRename()
 		return err
 	}
 
 	// Update in-memory map
 	c.issues[b.ID] = b
+	c.indexMentionsLocked(b)
+	c.indexLinksLocked(b)
+	c.dedupeLinksLocked(b)
+	if err := c.flushDuplicatesLocked(touched); err != nil {
+		return err
+	}
 
 	// Update search index if active (best-effort, don't fail update)
 	if c.searchIndex != nil {
@@ -397,17 +857,22 @@ func (c *Core) Update(b *issue.Issue, ifMatch *string) error {
 // SaveSyncOnly persists an issue whose only changes are to sync metadata.
 // Unlike Update, it does NOT bump updated_at, so that consumers comparing
 // updated_at against a sync timestamp are not tricked into thinking
-// the issue's content has changed.
+// the issue's content has changed. Archived issues are allowed: sync keeps
+// recording provider state for closed issues, and the file stays in the
+// archive.
 func (c *Core) SaveSyncOnly(b *issue.Issue, ifMatch *string) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
+	if _, stale := c.snapshotIssues[b]; stale {
+		return ErrSnapshotIssue
+	}
 	storedIssue, ok := c.issues[b.ID]
 	if !ok {
 		return ErrNotFound
 	}
 
-	if err := c.validateETagLocked(storedIssue, ifMatch); err != nil {
+	if err := c.validateETagLocked(storedIssue, ifMatch, func() []string { return []string{config.IfMatchMetadata} }); err != nil {
 		return err
 	}
 
@@ -418,36 +883,112 @@ func (c *Core) SaveSyncOnly(b *issue.Issue, ifMatch *string) error {
 	}
 
 	c.issues[b.ID] = b
+	c.indexLinksLocked(b)
 
 	// No search index update needed — extension data is not indexed
 	return nil
 }
 
-// validateETagLocked validates the etag for a stored issue against the provided ifMatch value.
+// changedClassesLocked returns the require_if_match field classes in which b
+// differs from its on-disk version. An unreadable file counts as changing
+// every class.
 // Must be called with c.mu held.
-func (c *Core) validateETagLocked(storedIssue *issue.Issue, ifMatch *string) error {
-	requireIfMatch := c.config != nil && c.config.RequireIfMatch
+func (c *Core) changedClassesLocked(b *issue.Issue) []string {
+	if b.Path == "" {
+		return config.IfMatchFields
+	}
+	disk, err := c.loadIssue(filepath.Join(c.root, b.Path))
+	if err != nil {
+		return config.IfMatchFields
+	}
+
+	// Compare b with the disk version's value of one class swapped in, so
+	// the parser's normalization of the file does not count as a change.
+	withBody, withStatus, rest := *b, *b, *disk
+	withBody.Body = disk.Body
+	withStatus.Status = disk.Status
+	rest.Body, rest.Status = b.Body, b.Status
 
-	if requireIfMatch && (ifMatch == nil || *ifMatch == "") {
-		return &ETagRequiredError{}
+	var classes []string
+	if disk.Encrypted != b.Encrypted || !sameRendering(&withBody, b) {
+		classes = append(classes, config.IfMatchBody)
 	}
+	if !sameRendering(&withStatus, b) {
+		classes = append(classes, config.IfMatchStatus)
+	}
+	if !sameRendering(&rest, b) {
+		classes = append(classes, config.IfMatchMetadata)
+	}
+	return classes
+}
 
-	if ifMatch != nil && *ifMatch != "" {
-		var currentETag string
-		if storedIssue.Path != "" {
-			diskPath := filepath.Join(c.root, storedIssue.Path)
-			content, err := os.ReadFile(diskPath) //nolint:gosec // path from known directory
-			if err != nil {
-				currentETag = storedIssue.ETag()
-			} else {
-				h := fnv.New64a()
-				h.Write(content) //nolint:gosec // hash.Write never returns error
-				currentETag = hex.EncodeToString(h.Sum(nil))
-			}
-		} else {
-			currentETag = storedIssue.ETag()
+// sameRendering reports whether a and b render to the same file, ignoring
+// updated_at, which Update sets itself, and encryption at rest.
+func sameRendering(a, b *issue.Issue) bool {
+	x, y := *a, *b
+	for _, v := range []*issue.Issue{&x, &y} {
+		applyFieldDefaults(v)
+		v.ID = b.ID
+		v.UpdatedAt = nil
+		v.Encrypted = false
+		v.Ciphertext = ""
+	}
+	rx, errX := x.Render()
+	ry, errY := y.Render()
+	return errX == nil && errY == nil && bytes.Equal(rx, ry)
+}
+
+// contentChangedLocked reports whether b differs from its on-disk version in
+// anything other than updated_at and sync metadata. Callers usually mutate
+// the stored issue in place, so the comparison is against the file. An
+// unreadable file counts as changed.
+// Must be called with c.mu held.
+func (c *Core) contentChangedLocked(b *issue.Issue) bool {
+	if b.Path == "" {
+		return true
+	}
+	disk, err := c.loadIssue(filepath.Join(c.root, b.Path))
+	if err != nil {
+		return true
+	}
+	if disk.Encrypted != b.Encrypted {
+		return true
+	}
+
+	// Compare plaintext renderings with the ignored fields blanked out and
+	// load-time defaults applied to both sides.
+	x, y := *disk, *b
+	for _, v := range []*issue.Issue{&x, &y} {
+		applyFieldDefaults(v)
+		v.ID = b.ID
+		v.UpdatedAt = nil
+		v.Sync = nil
+		v.Encrypted = false
+		v.Ciphertext = ""
+	}
+	rx, errX := x.Render()
+	ry, errY := y.Render()
+	return errX != nil || errY != nil || !bytes.Equal(rx, ry)
+}
+
+// validateETagLocked validates the etag for a stored issue against the provided ifMatch value.
+// Without one, it asks classes for the field classes the write changes and
+// rejects it if require_if_match protects any of them. Under the plain
+// require_if_match: true, a write that changes nothing needs an etag too.
+// Must be called with c.mu held.
+func (c *Core) validateETagLocked(storedIssue *issue.Issue, ifMatch *string, classes func() []string) error {
+	if (ifMatch == nil || *ifMatch == "") && c.config != nil && c.config.RequireIfMatch.Any() {
+		policy := c.config.RequireIfMatch
+		if field, ok := policy.FirstRequired(classes()); ok {
+			return &ETagRequiredError{Field: field}
+		}
+		if policy.All() {
+			return &ETagRequiredError{}
 		}
+	}
 
+	if ifMatch != nil && *ifMatch != "" {
+		currentETag := c.diskETagLocked(storedIssue)
 		if currentETag != *ifMatch {
 			return &ETagMismatchError{
 				Provided: *ifMatch,
@@ -459,8 +1000,141 @@ func (c *Core) validateETagLocked(storedIssue *issue.Issue, ifMatch *string) err
 	return nil
 }
 
+// diskETagLocked hashes the issue's file as it exists on disk, falling back to
+// the in-memory rendering when the file is missing or unreadable.
+// Must be called with c.mu held.
+func (c *Core) diskETagLocked(b *issue.Issue) string {
+	if b.Path == "" {
+		return b.ETag()
+	}
+	content, err := os.ReadFile(filepath.Join(c.root, b.Path)) //nolint:gosec // path from known directory
+	if err != nil {
+		return b.ETag()
+	}
+	return ContentETag(content)
+}
+
+// ContentETag returns the etag of an issue file with the given content, as
+// Update compares ifMatch against.
+func ContentETag(content []byte) string {
+	h := fnv.New64a()
+	h.Write(content) //nolint:gosec // hash.Write never returns error
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DiskETag returns the etag of an issue as currently stored on disk. Passing it
+// as ifMatch to Update detects edits made by other processes since the value
+// was captured.
+func (c *Core) DiskETag(id string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b, ok := c.issues[id]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return c.diskETagLocked(b), nil
+}
+
+// Reload re-reads a single issue from disk, replacing the in-memory copy.
+// Used to recover from an ETag conflict caused by another process.
+func (c *Core) Reload(id string) (*issue.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored, ok := c.issues[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	b, err := c.loadIssue(filepath.Join(c.root, stored.Path))
+	if err != nil {
+		return nil, err
+	}
+	c.clearWarningLocked(b.Path)
+	c.checkTimesLocked(b)
+	c.issues[id] = b
+	delete(c.duplicates, id)
+	c.indexMentionsLocked(b)
+	c.indexLinksLocked(b)
+	c.dedupeLinksLocked(b)
+
+	if c.searchIndex != nil {
+		if err := c.searchIndex.IndexIssue(b); err != nil {
+			c.logWarn("failed to reindex issue %s: %v", id, err)
+		}
+	}
+	return b, nil
+}
+
+// createWithGeneratedIDLocked assigns b a fresh ID and writes it, retrying
+// when the ID is already used here or was claimed by another process between
+// the check and the write.
+func (c *Core) createWithGeneratedIDLocked(b *issue.Issue) error {
+	explicitPath := b.Path
+	for range maxIDAttempts {
+		b.ID = c.generateID()
+		b.Path = explicitPath
+		if c.idTakenLocked(b.ID) {
+			continue
+		}
+		err := c.createOnDisk(b)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return err
+	}
+	b.ID, b.Path = "", explicitPath
+	return fmt.Errorf("could not generate an unused issue ID after %d attempts", maxIDAttempts)
+}
+
+// idTakenLocked reports whether id belongs to a loaded issue or to an issue
+// file on disk that this process has not loaded (for example one just created
+// by another process, or sitting in the archive).
+func (c *Core) idTakenLocked(id string) bool {
+	if _, ok := c.issues[id]; ok {
+		return true
+	}
+	return len(c.idFiles(id)) > 0
+}
+
+// idFiles returns the issue files on disk named for id, whatever their slug.
+func (c *Core) idFiles(id string) []string {
+	var files []string
+	for _, dir := range []string{c.root, filepath.Join(c.root, string(id[0])), filepath.Join(c.root, ArchiveDir)} {
+		for _, pattern := range []string{id + ".md", id + "--*.md", id + ".*.md"} {
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+			files = append(files, matches...)
+		}
+	}
+	return files
+}
+
 // saveToDisk writes an issue to the filesystem.
 func (c *Core) saveToDisk(b *issue.Issue) error {
+	return c.writeToDisk(b, os.O_TRUNC)
+}
+
+// createOnDisk writes a new issue file, failing with fs.ErrExist if the file
+// already exists so a concurrent creator cannot be silently overwritten.
+// O_EXCL only guards the exact name, which includes the slug, so the ID is
+// checked again once the file is written: if another process wrote the same
+// ID under another slug meanwhile, this file is removed and fs.ErrExist
+// returned. Both creators may back off, but the ID never lands twice.
+func (c *Core) createOnDisk(b *issue.Issue) error {
+	if err := c.writeToDisk(b, os.O_EXCL); err != nil {
+		return err
+	}
+	if len(c.idFiles(b.ID)) > 1 {
+		if err := os.Remove(filepath.Join(c.root, b.Path)); err != nil {
+			return fmt.Errorf("removing duplicate of %s: %w", b.ID, err)
+		}
+		return fs.ErrExist
+	}
+	return nil
+}
+
+func (c *Core) writeToDisk(b *issue.Issue, mode int) error {
 	// Determine the file path
 	var path string
 	if b.Path != "" {
@@ -477,28 +1151,63 @@ func (c *Core) saveToDisk(b *issue.Issue) error {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
+	// Each blocking link is stored once, on the blocker.
+	if len(c.dropMirroredBlockedByLocked(b)) > 0 {
+		c.indexLinksLocked(b)
+	}
+
+	if err := c.sealBody(b); err != nil {
+		return err
+	}
+
+	if mode == os.O_TRUNC {
+		c.noteWriteLocked(path, b.ID)
+	}
+
 	// Render and write
 	content, err := b.Render()
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(path, content, 0644); err != nil {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|mode, 0644)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		return fmt.Errorf("writing file: %w", err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
 		return fmt.Errorf("writing file: %w", err)
 	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	delete(c.duplicates, b.ID)
 
 	return nil
 }
 
-// Delete removes an issue by exact ID match.
+// Delete removes an issue by exact ID match. An archived issue is refused
+// with an *ArchivedError.
 func (c *Core) Delete(id string) error {
-	c.mu.Lock()
+	return c.remove(id, GetOptions{})
+}
+
+// remove is Delete with opts deciding whether an archived issue may be
+// removed.
+func (c *Core) remove(id string, opts GetOptions) error {
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	targetIssue, ok := c.issues[id]
 	if !ok {
 		return ErrNotFound
 	}
+	if !opts.IncludeArchived && c.isArchivedPath(targetIssue.Path) {
+		return &ArchivedError{ID: id}
+	}
 
 	// Remove from disk
 	path := filepath.Join(c.root, targetIssue.Path)
@@ -508,6 +1217,9 @@ func (c *Core) Delete(id string) error {
 
 	// Remove from in-memory map
 	delete(c.issues, id)
+	c.unindexMentionsLocked(id)
+	c.unindexLinksLocked(id)
+	c.forgetDuplicatesLocked(id)
 
 	// Update search index if active (best-effort, don't fail delete)
 	if c.searchIndex != nil {
@@ -521,7 +1233,7 @@ func (c *Core) Delete(id string) error {
 
 // Archive moves an issue to the archive directory.
 func (c *Core) Archive(id string) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	// Find the issue
@@ -559,7 +1271,7 @@ func (c *Core) Archive(id string) error {
 
 // Unarchive moves an issue from the archive directory back to the correct hash subfolder.
 func (c *Core) Unarchive(id string) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	// Find the issue
@@ -642,7 +1354,7 @@ func (c *Core) GetFromArchive(id string) (*issue.Issue, error) {
 			continue
 		}
 
-		fileID, _ := issue.ParseFilename(entry.Name())
+		fileID, _ := c.idMatcher.ParseFilename(entry.Name())
 		if fileID == id {
 			path := filepath.Join(archiveDir, entry.Name())
 			return c.loadIssue(path)
@@ -655,7 +1367,7 @@ func (c *Core) GetFromArchive(id string) (*issue.Issue, error) {
 // LoadAndUnarchive finds an issue in the archive, loads it, unarchives it,
 // and adds it to the in-memory store. Returns the issue or ErrNotFound.
 func (c *Core) LoadAndUnarchive(id string) (*issue.Issue, error) {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	// Find the issue (always loaded since we now include archived issues)
@@ -2,10 +2,12 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -38,7 +40,7 @@ func setupTestCore(t *testing.T, opts ...func(*config.Config)) (*Core, string) {
 
 func setupTestCoreWithRequireIfMatch(t *testing.T) (*Core, string) {
 	return setupTestCore(t, func(cfg *config.Config) {
-		cfg.RequireIfMatch = true
+		cfg.RequireIfMatch = config.RequireIfMatchAll()
 	})
 }
 
@@ -118,7 +120,7 @@ func TestCreate(t *testing.T) {
 		ID:     "abc-def",
 		Slug:   "test-issue",
 		Title:  "Test Issue",
-		Status: "todo",
+		Status: "ready",
 		Body:   "Some content here.",
 	}
 
@@ -153,12 +155,165 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateExplicitIDExists(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	createTestIssue(t, core, "abc-def", "Original", "ready")
+
+	dup := &issue.Issue{ID: "abc-def", Title: "Duplicate", Status: "ready"}
+	if err := core.Create(dup); !errors.Is(err, ErrIDExists) {
+		t.Fatalf("Create(duplicate) error = %v, want ErrIDExists", err)
+	}
+
+	// A file written by another process (not loaded here) also blocks the ID,
+	// as does one sitting in the archive.
+	for _, rel := range []string{"x/xyz-123--other.md", "archive/arc-456--old.md"} {
+		path := filepath.Join(dataDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("---\ntitle: Other\nstatus: todo\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		id, _ := issue.ParseFilename(filepath.Base(rel))
+		err := core.Create(&issue.Issue{ID: id, Slug: "other", Title: "Clobber", Status: "ready"})
+		if !errors.Is(err, ErrIDExists) {
+			t.Errorf("Create(%s) error = %v, want ErrIDExists", id, err)
+		}
+		if data, _ := os.ReadFile(path); !strings.Contains(string(data), "title: Other") {
+			t.Errorf("%s was overwritten: %s", rel, data)
+		}
+	}
+
+	got, err := core.Get("abc-def")
+	if err != nil || got.Title != "Original" {
+		t.Errorf("original issue = %+v, %v", got, err)
+	}
+}
+
+func TestCreateRegeneratesCollidingID(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	createTestIssue(t, core, "aaa-aaa", "Existing", "ready")
+
+	// Another process claimed bbb-bbb on disk with the same slug, so even the
+	// O_EXCL write would collide.
+	if err := os.MkdirAll(filepath.Join(dataDir, "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "b", "bbb-bbb--new.md"), []byte("---\ntitle: Taken\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{"aaa-aaa", "bbb-bbb", "ccc-ccc"}
+	core.SetIDGenerator(func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	})
+
+	b := &issue.Issue{Slug: "new", Title: "New", Status: "ready"}
+	if err := core.Create(b); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if b.ID != "ccc-ccc" {
+		t.Errorf("ID = %q, want ccc-ccc after two collisions", b.ID)
+	}
+	if b.Path != filepath.Join("c", "ccc-ccc--new.md") {
+		t.Errorf("Path = %q", b.Path)
+	}
+
+	core.SetIDGenerator(func() string { return "aaa-aaa" })
+	err := core.Create(&issue.Issue{Title: "Unlucky", Status: "ready"})
+	if err == nil || !strings.Contains(err.Error(), "after 10 attempts") {
+		t.Errorf("Create() with exhausted generator error = %v", err)
+	}
+}
+
+func TestCreateExclusiveWrite(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+
+	// The in-memory check cannot see a file that appears between the check
+	// and the write; O_EXCL must still refuse to replace it.
+	b := &issue.Issue{ID: "rac-eee", Slug: "race", Title: "Race", Status: "ready", Path: filepath.Join("r", "rac-eee--race.md")}
+	path := filepath.Join(dataDir, b.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("winner"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.createOnDisk(b); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("createOnDisk() error = %v, want os.ErrExist", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "winner" {
+		t.Errorf("file was overwritten: %q", data)
+	}
+}
+
+func TestCreateOnDiskRefusesIDUnderOtherSlug(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+
+	// Another process wrote the same ID under a different slug, which O_EXCL
+	// on this file's name cannot see.
+	other := filepath.Join(dataDir, "r", "rac-eee--other.md")
+	if err := os.MkdirAll(filepath.Dir(other), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, []byte("winner"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b := &issue.Issue{ID: "rac-eee", Slug: "race", Title: "Race", Status: "ready"}
+	if err := core.createOnDisk(b); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("createOnDisk() error = %v, want os.ErrExist", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "r", "rac-eee--race.md")); !os.IsNotExist(err) {
+		t.Errorf("duplicate file left behind: %v", err)
+	}
+	if data, _ := os.ReadFile(other); string(data) != "winner" {
+		t.Errorf("other file = %q", data)
+	}
+}
+
+func TestConcurrentCreateSameIDDifferentSlugs(t *testing.T) {
+	first, dataDir := setupTestCore(t)
+	second := New(dataDir, config.Default())
+	second.SetWarnWriter(nil)
+	if err := second.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 20 {
+		id := fmt.Sprintf("dup-%03d", i)
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for n, c := range []*Core{first, second} {
+			wg.Go(func() {
+				errs[n] = c.Create(&issue.Issue{ID: id, Slug: fmt.Sprintf("slug-%d", n), Title: "Dup", Status: "ready"})
+			})
+		}
+		wg.Wait()
+
+		created := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				created++
+			case !errors.Is(err, ErrIDExists):
+				t.Fatalf("Create(%s) error = %v", id, err)
+			}
+		}
+		files, _ := filepath.Glob(filepath.Join(dataDir, "d", id+"--*.md"))
+		if len(files) > 1 || len(files) != created {
+			t.Fatalf("%s: %d creates succeeded, files on disk = %v", id, created, files)
+		}
+	}
+}
+
 func TestCreateGeneratesID(t *testing.T) {
 	core, _ := setupTestCore(t)
 
 	b := &issue.Issue{
 		Title:  "Auto ID Issue",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	err := core.Create(b)
@@ -181,7 +336,7 @@ func TestCreateGeneratesID(t *testing.T) {
 func TestAll(t *testing.T) {
 	core, _ := setupTestCore(t)
 
-	createTestIssue(t, core, "aaa1", "First Issue", "todo")
+	createTestIssue(t, core, "aaa1", "First Issue", "ready")
 	createTestIssue(t, core, "bbb2", "Second Issue", "in-progress")
 	createTestIssue(t, core, "ccc3", "Third Issue", "completed")
 
@@ -200,11 +355,31 @@ func TestAllEmpty(t *testing.T) {
 	}
 }
 
+func TestAllOrder(t *testing.T) {
+	writer, dataDir := setupTestCore(t)
+	for _, created := range []struct {
+		day int
+		id  string
+	}{{9, "zzz-111"}, {5, "mmm-222"}, {5, "bbb-333"}, {1, "yyy-444"}} {
+		writer.SetClock(func() time.Time { return time.Date(2026, 1, created.day, 0, 0, 0, 0, time.UTC) })
+		createTestIssue(t, writer, created.id, "Issue "+created.id, "ready")
+	}
+
+	for i := range 2 {
+		c := New(dataDir, config.Default())
+		c.SetWarnWriter(nil)
+		if err := c.Load(); err != nil {
+			t.Fatal(err)
+		}
+		assertIDs(t, fmt.Sprintf("load %d All()", i+1), c.All(), "yyy-444", "bbb-333", "mmm-222", "zzz-111")
+	}
+}
+
 func TestGet(t *testing.T) {
 	core, _ := setupTestCore(t)
 
-	createTestIssue(t, core, "abc1", "First", "todo")
-	createTestIssue(t, core, "def2", "Second", "todo")
+	createTestIssue(t, core, "abc1", "First", "ready")
+	createTestIssue(t, core, "def2", "Second", "ready")
 
 	t.Run("exact match", func(t *testing.T) {
 		b, err := core.Get("abc1")
@@ -227,7 +402,7 @@ func TestGet(t *testing.T) {
 func TestGetNotFound(t *testing.T) {
 	core, _ := setupTestCore(t)
 
-	createTestIssue(t, core, "abc1", "Test", "todo")
+	createTestIssue(t, core, "abc1", "Test", "ready")
 
 	_, err := core.Get("xyz")
 	if !errors.Is(err, ErrNotFound) {
@@ -238,7 +413,7 @@ func TestGetNotFound(t *testing.T) {
 func TestUpdate(t *testing.T) {
 	core, _ := setupTestCore(t)
 
-	b := createTestIssue(t, core, "upd1", "Original Title", "todo")
+	b := createTestIssue(t, core, "upd1", "Original Title", "ready")
 	originalCreatedAt := *b.CreatedAt
 
 	// Update the issue
@@ -279,7 +454,7 @@ func TestUpdateNotFound(t *testing.T) {
 	b := &issue.Issue{
 		ID:     "nonexistent",
 		Title:  "Ghost Issue",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	err := core.Update(b, nil)
@@ -291,7 +466,7 @@ func TestUpdateNotFound(t *testing.T) {
 func TestDelete(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
-	b := createTestIssue(t, core, "del1", "To Delete", "todo")
+	b := createTestIssue(t, core, "del1", "To Delete", "ready")
 	filePath := filepath.Join(dataDir, b.Path)
 
 	// Verify file exists
@@ -329,7 +504,7 @@ func TestDeleteNotFound(t *testing.T) {
 func TestDeletePartialIDNotFound(t *testing.T) {
 	core, _ := setupTestCore(t)
 
-	createTestIssue(t, core, "unique123", "Test", "todo")
+	createTestIssue(t, core, "unique123", "Test", "ready")
 
 	// Partial ID should not match
 	err := core.Delete("unique")
@@ -393,7 +568,7 @@ Manual content.
 func TestLoadIgnoresNonMdFiles(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
-	createTestIssue(t, core, "abc1", "Real Issue", "todo")
+	createTestIssue(t, core, "abc1", "Real Issue", "ready")
 
 	// Create non-.md files that should be ignored
 	os.WriteFile(filepath.Join(dataDir, "config.yaml"), []byte("config"), 0644)
@@ -419,7 +594,7 @@ func TestBlocksPreserved(t *testing.T) {
 		ID:       "aaa1",
 		Slug:     "blocker",
 		Title:    "Blocker Issue",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2"},
 	}
 	if err := core.Create(issueA); err != nil {
@@ -431,7 +606,7 @@ func TestBlocksPreserved(t *testing.T) {
 		ID:     "bbb2",
 		Slug:   "blocked",
 		Title:  "Blocked Issue",
-		Status: "todo",
+		Status: "ready",
 	}
 	if err := core.Create(issueB); err != nil {
 		t.Fatalf("Create issueB error = %v", err)
@@ -468,7 +643,7 @@ func TestConcurrentAccess(t *testing.T) {
 
 	// Create some initial issues
 	for range 10 {
-		createTestIssue(t, core, issue.NewID(), "Initial Issue", "todo")
+		createTestIssue(t, core, issue.NewID(), "Initial Issue", "ready")
 	}
 
 	// Run concurrent operations
@@ -490,7 +665,7 @@ func TestConcurrentAccess(t *testing.T) {
 			for range 10 {
 				b := &issue.Issue{
 					Title:  "Concurrent Issue",
-					Status: "todo",
+					Status: "ready",
 				}
 				if err := core.Create(b); err != nil {
 					errors <- err
@@ -507,10 +682,33 @@ func TestConcurrentAccess(t *testing.T) {
 	}
 }
 
+// waitIdle waits for the watcher to handle every change made so far.
+func waitIdle(t *testing.T, c *Core) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.WaitIdle(ctx); err != nil {
+		t.Fatalf("WaitIdle() error = %v", err)
+	}
+}
+
+// receiveEvents returns the events the watcher has already delivered to ch.
+func receiveEvents(ch <-chan []IssueEvent) []IssueEvent {
+	var events []IssueEvent
+	for {
+		select {
+		case batch := <-ch:
+			events = append(events, batch...)
+		default:
+			return events
+		}
+	}
+}
+
 func TestWatch(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
-	createTestIssue(t, core, "wat1", "Initial Issue", "todo")
+	createTestIssue(t, core, "wat1", "Initial Issue", "ready")
 
 	// Start watching
 	changeCount := 0
@@ -525,9 +723,6 @@ func TestWatch(t *testing.T) {
 		t.Fatalf("Watch() error = %v", err)
 	}
 
-	// Give watcher time to start
-	time.Sleep(50 * time.Millisecond)
-
 	// Create a new issue file manually (simulating external change)
 	content := `---
 title: External Issue
@@ -537,9 +732,7 @@ status: open
 	if err := os.WriteFile(filepath.Join(dataDir, "ext1--external.md"), []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write test file: %v", err)
 	}
-
-	// Wait for debounce + processing
-	time.Sleep(200 * time.Millisecond)
+	waitIdle(t, core)
 
 	mu.Lock()
 	count := changeCount
@@ -564,7 +757,7 @@ status: open
 func TestWatchDeletedIssue(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
-	b := createTestIssue(t, core, "del1", "To Delete", "todo")
+	b := createTestIssue(t, core, "del1", "To Delete", "ready")
 
 	// Start watching
 	changed := make(chan struct{}, 1)
@@ -755,7 +948,7 @@ func TestEventTypes(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
 	// Create an initial issue
-	createTestIssue(t, core, "evt1", "Event Test", "todo")
+	createTestIssue(t, core, "evt1", "Event Test", "ready")
 
 	if err := core.StartWatching(); err != nil {
 		t.Fatalf("StartWatching() error = %v", err)
@@ -765,9 +958,6 @@ func TestEventTypes(t *testing.T) {
 	ch, unsub := core.Subscribe()
 	defer unsub()
 
-	// Give watcher time to start
-	time.Sleep(50 * time.Millisecond)
-
 	t.Run("update event", func(t *testing.T) {
 		// Modify the existing issue file
 		content := `---
@@ -778,26 +968,23 @@ status: in-progress
 		if err := os.WriteFile(filepath.Join(dataDir, "evt1--event-test.md"), []byte(content), 0644); err != nil {
 			t.Fatalf("failed to write test file: %v", err)
 		}
+		waitIdle(t, core)
 
-		select {
-		case events := <-ch:
-			found := false
-			for _, e := range events {
-				if e.Type == EventUpdated && e.IssueID == "evt1" {
-					found = true
-					if e.Issue == nil {
-						t.Error("EventUpdated should include Issue")
-					}
-					if e.Issue.Title != "Updated Title" {
-						t.Errorf("expected updated title, got %q", e.Issue.Title)
-					}
+		found := false
+		events := receiveEvents(ch)
+		for _, e := range events {
+			if e.Type == EventUpdated && e.IssueID == "evt1" {
+				found = true
+				if e.Issue == nil {
+					t.Fatal("EventUpdated should include Issue")
+				}
+				if e.Issue.Title != "Updated Title" {
+					t.Errorf("expected updated title, got %q", e.Issue.Title)
 				}
 			}
-			if !found {
-				t.Errorf("expected EventUpdated for evt1, got: %+v", events)
-			}
-		case <-time.After(500 * time.Millisecond):
-			t.Error("timeout waiting for update event")
+		}
+		if !found {
+			t.Errorf("expected EventUpdated for evt1, got: %+v", events)
 		}
 	})
 
@@ -806,23 +993,20 @@ status: in-progress
 		if err := os.Remove(filepath.Join(dataDir, "evt1--event-test.md")); err != nil {
 			t.Fatalf("failed to delete file: %v", err)
 		}
+		waitIdle(t, core)
 
-		select {
-		case events := <-ch:
-			found := false
-			for _, e := range events {
-				if e.Type == EventDeleted && e.IssueID == "evt1" {
-					found = true
-					if e.Issue != nil {
-						t.Error("EventDeleted should have nil Issue")
-					}
+		found := false
+		events := receiveEvents(ch)
+		for _, e := range events {
+			if e.Type == EventDeleted && e.IssueID == "evt1" {
+				found = true
+				if e.Issue != nil {
+					t.Error("EventDeleted should have nil Issue")
 				}
 			}
-			if !found {
-				t.Errorf("expected EventDeleted for evt1, got: %+v", events)
-			}
-		case <-time.After(500 * time.Millisecond):
-			t.Error("timeout waiting for delete event")
+		}
+		if !found {
+			t.Errorf("expected EventDeleted for evt1, got: %+v", events)
 		}
 	})
 }
@@ -852,8 +1036,11 @@ func TestMultipleChangesInDebounceWindow(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
 	// Create an initial issue to update
-	createTestIssue(t, core, "upd1", "To Update", "todo")
+	createTestIssue(t, core, "upd1", "To Update", "ready")
 
+	// The timer never fires on its own, so everything below lands in the
+	// one batch WaitIdle flushes.
+	core.SetDebounce(time.Hour)
 	if err := core.StartWatching(); err != nil {
 		t.Fatalf("StartWatching() error = %v", err)
 	}
@@ -862,8 +1049,6 @@ func TestMultipleChangesInDebounceWindow(t *testing.T) {
 	ch, unsub := core.Subscribe()
 	defer unsub()
 
-	time.Sleep(50 * time.Millisecond)
-
 	// Make multiple changes rapidly (within debounce window)
 	// 1. Create a new issue
 	content1 := `---
@@ -885,11 +1070,11 @@ status: in-progress
 	os.WriteFile(filepath.Join(dataDir, "tmp1--temp.md"), []byte(content1), 0644)
 	os.Remove(filepath.Join(dataDir, "tmp1--temp.md"))
 
-	// Wait for debounced events
+	waitIdle(t, core)
+
 	select {
 	case events := <-ch:
 		// Should have events for new1 (created) and upd1 (updated)
-		// tmp1 might or might not appear depending on timing
 		foundNew := false
 		foundUpd := false
 		for _, e := range events {
@@ -899,6 +1084,9 @@ status: in-progress
 			if e.IssueID == "upd1" && e.Type == EventUpdated {
 				foundUpd = true
 			}
+			if e.IssueID == "tmp1" {
+				t.Errorf("unexpected event for tmp1 (created then deleted): %+v", e)
+			}
 		}
 		if !foundNew {
 			t.Error("expected EventCreated for new1")
@@ -906,8 +1094,11 @@ status: in-progress
 		if !foundUpd {
 			t.Error("expected EventUpdated for upd1")
 		}
-	case <-time.After(500 * time.Millisecond):
-		t.Error("timeout waiting for events")
+	default:
+		t.Fatal("no events delivered")
+	}
+	if extra := receiveEvents(ch); len(extra) > 0 {
+		t.Errorf("changes in one debounce window arrived in more than one batch: %+v", extra)
 	}
 
 	// Verify state is correct
@@ -935,7 +1126,7 @@ func TestInvalidFileIgnored(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
 	// Create a valid issue first
-	createTestIssue(t, core, "val1", "Valid Issue", "todo")
+	createTestIssue(t, core, "val1", "Valid Issue", "ready")
 
 	if err := core.StartWatching(); err != nil {
 		t.Fatalf("StartWatching() error = %v", err)
@@ -995,8 +1186,9 @@ status: todo
 func TestRapidUpdatesToSameFile(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
-	createTestIssue(t, core, "rap1", "Rapid Updates", "todo")
+	createTestIssue(t, core, "rap1", "Rapid Updates", "ready")
 
+	core.SetDebounce(time.Hour)
 	if err := core.StartWatching(); err != nil {
 		t.Fatalf("StartWatching() error = %v", err)
 	}
@@ -1005,8 +1197,6 @@ func TestRapidUpdatesToSameFile(t *testing.T) {
 	ch, unsub := core.Subscribe()
 	defer unsub()
 
-	time.Sleep(50 * time.Millisecond)
-
 	// Write to the same file multiple times rapidly
 	for i := 1; i <= 5; i++ {
 		content := fmt.Sprintf(`---
@@ -1015,33 +1205,27 @@ status: todo
 ---
 `, i)
 		os.WriteFile(filepath.Join(dataDir, "rap1--rapid-updates.md"), []byte(content), 0644)
-		time.Sleep(10 * time.Millisecond) // Small delay but within debounce
 	}
+	waitIdle(t, core)
 
-	// Should get a single batch of events (debounced)
-	select {
-	case events := <-ch:
-		// Count events for rap1 - should be exactly one
-		rap1Count := 0
-		var lastEvent IssueEvent
-		for _, e := range events {
-			if e.IssueID == "rap1" {
-				rap1Count++
-				lastEvent = e
-			}
-		}
-		if rap1Count != 1 {
-			t.Errorf("expected 1 event for rap1, got %d", rap1Count)
-		}
-		if lastEvent.Type != EventUpdated {
-			t.Errorf("expected EventUpdated, got %v", lastEvent.Type)
+	// Count events for rap1 - should be exactly one
+	rap1Count := 0
+	var lastEvent IssueEvent
+	for _, e := range receiveEvents(ch) {
+		if e.IssueID == "rap1" {
+			rap1Count++
+			lastEvent = e
 		}
-		// Should have the final value
-		if lastEvent.Issue != nil && lastEvent.Issue.Title != "Update 5" {
-			t.Errorf("expected title 'Update 5', got %q", lastEvent.Issue.Title)
-		}
-	case <-time.After(500 * time.Millisecond):
-		t.Error("timeout waiting for events")
+	}
+	if rap1Count != 1 {
+		t.Fatalf("expected 1 event for rap1, got %d", rap1Count)
+	}
+	if lastEvent.Type != EventUpdated {
+		t.Errorf("expected EventUpdated, got %v", lastEvent.Type)
+	}
+	// Should have the final value
+	if lastEvent.Issue != nil && lastEvent.Issue.Title != "Update 5" {
+		t.Errorf("expected title 'Update 5', got %q", lastEvent.Issue.Title)
 	}
 }
 
@@ -1186,11 +1370,161 @@ func TestIsArchived(t *testing.T) {
 	})
 }
 
+func TestArchivedIssueIsReadOnly(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+
+	createTestIssue(t, core, "ro-001", "Read Only", "completed")
+	if err := core.Archive("ro-001"); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	archivedPath := filepath.Join(ArchiveDir, "ro-001--read-only.md")
+	mainPath := filepath.Join(dataDir, issue.BuildPath("ro-001", "read-only"))
+
+	t.Run("get", func(t *testing.T) {
+		if b, err := core.Get("ro-001"); err != nil || b.Path != archivedPath {
+			t.Errorf("Get() = %v, %v, want the archived issue", b, err)
+		}
+		if _, err := core.GetWith("ro-001", GetOptions{IncludeArchived: true}); err != nil {
+			t.Errorf("GetWith(IncludeArchived) error = %v", err)
+		}
+		_, err := core.GetWith("ro-001", GetOptions{})
+		if archived, ok := errors.AsType[*ArchivedError](err); !ok || archived.ID != "ro-001" {
+			t.Errorf("GetWith() error = %v, want *ArchivedError", err)
+		}
+		if errors.Is(err, ErrNotFound) {
+			t.Error("an archived issue must not be reported as not found")
+		}
+		if _, err := core.LookupWith("ro-001", GetOptions{}); !errors.Is(err, ErrArchived) {
+			t.Errorf("LookupWith() error = %v, want ErrArchived", err)
+		}
+		if _, err := core.LookupWith("ro-002", GetOptions{}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("LookupWith(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		b, _ := core.Get("ro-001")
+		edited := *b
+		edited.Title = "Resurrected"
+		if err := core.Update(&edited, nil); !errors.Is(err, ErrArchived) {
+			t.Fatalf("Update() error = %v, want ErrArchived", err)
+		}
+		if _, err := os.Stat(mainPath); !os.IsNotExist(err) {
+			t.Error("a refused update must not write the issue into the main directory")
+		}
+		if b, _ := core.Get("ro-001"); b.Title != "Read Only" {
+			t.Errorf("title = %q, want it unchanged", b.Title)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := core.Delete("ro-001"); !errors.Is(err, ErrArchived) {
+			t.Fatalf("Delete() error = %v, want ErrArchived", err)
+		}
+		if _, err := os.Stat(filepath.Join(dataDir, archivedPath)); err != nil {
+			t.Errorf("archived file should survive a refused delete: %v", err)
+		}
+	})
+
+	t.Run("sync metadata", func(t *testing.T) {
+		b, _ := core.Get("ro-001")
+		synced := *b
+		synced.SetSync("github", map[string]any{"issue_number": "7"})
+		if err := core.SaveSyncOnly(&synced, nil); err != nil {
+			t.Fatalf("SaveSyncOnly() error = %v", err)
+		}
+		if !core.IsArchived("ro-001") {
+			t.Error("recording sync state must leave the issue in the archive")
+		}
+	})
+
+	t.Run("unarchive", func(t *testing.T) {
+		if err := core.Unarchive("ro-001"); err != nil {
+			t.Fatalf("Unarchive() error = %v", err)
+		}
+		b, _ := core.Get("ro-001")
+		edited := *b
+		edited.Title = "Restored"
+		if err := core.Update(&edited, nil); err != nil {
+			t.Errorf("Update() after Unarchive error = %v", err)
+		}
+	})
+}
+
+func TestAutoArchiveCandidates(t *testing.T) {
+	core, _ := setupTestCore(t, func(cfg *config.Config) {
+		cfg.ExtraStatuses = map[string]bool{config.StatusDraft: true, config.StatusScrapped: true}
+		cfg.AutoArchive.After = "30d"
+	})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	core.SetClock(func() time.Time { return now })
+
+	createTestIssues(t, core,
+		&issue.Issue{ID: "aa-epic", Title: "Done epic", Status: config.StatusCompleted, Type: config.TypeEpic},
+		&issue.Issue{ID: "aa-child", Title: "Open child", Status: config.StatusReady, Type: config.TypeTask, Parent: "aa-epic"},
+		&issue.Issue{ID: "aa-scrap", Title: "Dropped", Status: config.StatusScrapped},
+		&issue.Issue{ID: "aa-draft", Title: "Old draft", Status: config.StatusDraft},
+	)
+	now = start.Add(24 * time.Hour)
+	createTestIssue(t, core, "aa-new", "Recently done", config.StatusCompleted)
+
+	ids := func() []string {
+		var ids []string
+		for _, b := range core.AutoArchiveCandidates() {
+			ids = append(ids, b.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	now = start.Add(30 * config.Day)
+	if got := ids(); len(got) != 0 {
+		t.Errorf("at exactly 30d, candidates = %v, want none", got)
+	}
+
+	now = start.Add(30*config.Day + time.Second)
+	if got := ids(); !slices.Equal(got, []string{"aa-epic", "aa-scrap"}) {
+		t.Errorf("candidates = %v, want [aa-epic aa-scrap] (never drafts or open issues)", got)
+	}
+
+	for _, id := range ids() {
+		if err := core.Archive(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := ids(); len(got) != 0 {
+		t.Errorf("archived issues should not be candidates again, got %v", got)
+	}
+
+	// Children keep pointing at an archived parent, which still resolves.
+	child, err := core.Get("aa-child")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Parent != "aa-epic" {
+		t.Errorf("child parent = %q, want aa-epic", child.Parent)
+	}
+	if _, err := core.Get("aa-epic"); err != nil {
+		t.Errorf("archived parent should still resolve: %v", err)
+	}
+}
+
+func TestAutoArchiveCandidatesDisabled(t *testing.T) {
+	core, _ := setupTestCore(t)
+	core.SetClock(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) })
+	createTestIssue(t, core, "aa-old", "Old", config.StatusCompleted)
+	core.SetClock(nil)
+	if got := core.AutoArchiveCandidates(); len(got) != 0 {
+		t.Errorf("candidates = %v, want none without auto_archive.after", got)
+	}
+}
+
 func TestArchivedIssuesAlwaysLoaded(t *testing.T) {
 	core, dataDir := setupTestCore(t)
 
 	// Create issues and archive one
-	createTestIssue(t, core, "act-001", "Active Issue", "todo")
+	createTestIssue(t, core, "act-001", "Active Issue", "ready")
 	createTestIssue(t, core, "arc-001", "Archived Issue", "completed")
 	if err := core.Archive("arc-001"); err != nil {
 		t.Fatalf("Archive() error = %v", err)
@@ -1440,7 +1774,7 @@ func TestLoadAndUnarchiveNotFound(t *testing.T) {
 func TestNormalizeID(t *testing.T) {
 	core, _ := setupTestCore(t)
 
-	createTestIssue(t, core, "abc-def", "Test Issue", "todo")
+	createTestIssue(t, core, "abc-def", "Test Issue", "ready")
 
 	t.Run("exact match returns same ID", func(t *testing.T) {
 		normalized, found := core.NormalizeID("abc-def")
@@ -1470,7 +1804,7 @@ func TestUpdateWithETag(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-test-1",
 			Title:  "ETag Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "Original",
 		}
 		if err := core.Create(b); err != nil {
@@ -1489,7 +1823,7 @@ func TestUpdateWithETag(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-test-2",
 			Title:  "ETag Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		if err := core.Create(b); err != nil {
 			t.Fatalf("Create() error = %v", err)
@@ -1508,7 +1842,7 @@ func TestUpdateWithETag(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-test-3",
 			Title:  "ETag Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		if err := core.Create(b); err != nil {
 			t.Fatalf("Create() error = %v", err)
@@ -1529,7 +1863,7 @@ func TestUpdateWithETagRequired(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-req-test-1",
 			Title:  "ETag Required Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		if err := core.Create(b); err != nil {
 			t.Fatalf("Create() error = %v", err)
@@ -1547,7 +1881,7 @@ func TestUpdateWithETagRequired(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-req-test-2",
 			Title:  "ETag Required Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		if err := core.Create(b); err != nil {
 			t.Fatalf("Create() error = %v", err)
@@ -1566,7 +1900,7 @@ func TestUpdateWithETagRequired(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-req-test-3",
 			Title:  "ETag Required Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		if err := core.Create(b); err != nil {
 			t.Fatalf("Create() error = %v", err)
@@ -1580,13 +1914,44 @@ func TestUpdateWithETagRequired(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateWithPartialETagPolicy(t *testing.T) {
+	core, _ := setupTestCore(t, func(cfg *config.Config) {
+		cfg.RequireIfMatch = config.IfMatchPolicy{Body: true, Status: true}
+	})
+	b := &issue.Issue{ID: "etag-class-1", Title: "Partial", Status: "ready", Body: "Old"}
+	if err := core.Create(b); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	b.Tags = []string{"automation"}
+	if err := core.Update(b, nil); err != nil {
+		t.Fatalf("Update() of tags without etag error = %v, want allowed", err)
+	}
+	if err := core.SaveSyncOnly(b, nil); err != nil {
+		t.Fatalf("SaveSyncOnly() without etag error = %v, want allowed", err)
+	}
+
+	for field, change := range map[string]func(*issue.Issue){
+		config.IfMatchStatus: func(b *issue.Issue) { b.Status = "in-progress" },
+		config.IfMatchBody:   func(b *issue.Issue) { b.Body = "New" },
+	} {
+		x := *b
+		change(&x)
+		err := core.Update(&x, nil)
+		required, ok := errors.AsType[*ETagRequiredError](err)
+		if !ok || required.Field != field {
+			t.Errorf("Update() changing %s without etag = %v, want ETagRequiredError{%s}", field, err, field)
+		}
+	}
+}
 func TestUpdateWithETagDebug(t *testing.T) {
 	core, _ := setupTestCore(t)
 
 	b := &issue.Issue{
 		ID:     "etag-debug",
 		Title:  "ETag Test",
-		Status: "todo",
+		Status: "ready",
 		Body:   "Original",
 	}
 	if err := core.Create(b); err != nil {
@@ -1673,3 +2038,45 @@ func TestFanOutLogsDroppedEvents(t *testing.T) {
 		t.Errorf("expected warning about dropping events, got: %q", buf.String())
 	}
 }
+
+func TestDiskETagAndReload(t *testing.T) {
+	core, _ := setupTestCore(t)
+	b := createTestIssue(t, core, "rld-1", "Original", "ready")
+
+	etag, err := core.DiskETag(b.ID)
+	if err != nil {
+		t.Fatalf("DiskETag() error = %v", err)
+	}
+	if etag != b.ETag() {
+		t.Errorf("DiskETag() = %s, want rendered etag %s", etag, b.ETag())
+	}
+
+	edited := *b
+	edited.Title = "Changed on disk"
+	content, err := edited.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(core.FullPath(b), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if fresh, _ := core.DiskETag(b.ID); fresh == etag {
+		t.Error("DiskETag() did not change after external edit")
+	}
+
+	reloaded, err := core.Reload(b.ID)
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if reloaded.Title != "Changed on disk" {
+		t.Errorf("Reload() title = %q", reloaded.Title)
+	}
+	if got, _ := core.Get(b.ID); got.Title != "Changed on disk" {
+		t.Errorf("Get() after Reload() title = %q", got.Title)
+	}
+
+	if _, err := core.Reload("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Reload(missing) error = %v, want ErrNotFound", err)
+	}
+}
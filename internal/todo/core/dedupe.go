@@ -0,0 +1,178 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// A blocking link is stored once, in the blocker's blocking list. A
+// blocked_by entry is kept only when the blocker does not already list the
+// link; older files often carry both halves, and removing one half used to
+// leave the other behind, so the issue still looked blocked.
+
+// DuplicateLink is a blocking link stored on both issues: Blocker lists
+// Blocked in its blocking field, and Blocked's file also lists Blocker in its
+// blocked_by field.
+type DuplicateLink struct {
+	Blocker string `json:"blocker"`
+	Blocked string `json:"blocked"`
+}
+
+// dropMirroredBlockedByLocked removes the entries of b.BlockedBy whose
+// blocker already lists b, returning the blocker IDs removed.
+// Must be called with c.mu held for writing.
+func (c *Core) dropMirroredBlockedByLocked(b *issue.Issue) []string {
+	var dropped []string
+	b.BlockedBy = slices.DeleteFunc(b.BlockedBy, func(blockerID string) bool {
+		blocker, ok := c.issues[blockerID]
+		if ok && blocker.ID != b.ID && slices.Contains(blocker.Blocking, b.ID) {
+			dropped = append(dropped, blockerID)
+			return true
+		}
+		return false
+	})
+	if len(b.BlockedBy) == 0 {
+		b.BlockedBy = nil
+	}
+	return dropped
+}
+
+// dedupeLinksLocked keeps only the blocking half of each link b shares with
+// another loaded issue, in memory. Files still holding the dropped blocked_by
+// halves are remembered so that DuplicateLinks can report them and
+// FixDuplicateLinks can rewrite them.
+// Must be called with c.mu held for writing.
+func (c *Core) dedupeLinksLocked(b *issue.Issue) {
+	if dropped := c.dropMirroredBlockedByLocked(b); len(dropped) > 0 {
+		c.addDuplicatesLocked(b.ID, dropped)
+		c.indexLinksLocked(b)
+	}
+	for _, targetID := range b.Blocking {
+		target, ok := c.issues[targetID]
+		if !ok || target.ID == b.ID || !slices.Contains(target.BlockedBy, b.ID) {
+			continue
+		}
+		target.RemoveBlockedBy(b.ID)
+		c.addDuplicatesLocked(target.ID, []string{b.ID})
+		c.indexLinksLocked(target)
+	}
+}
+
+// addDuplicatesLocked records that the file of the issue with the given ID
+// still lists blockers in its blocked_by field.
+// Must be called with c.mu held for writing.
+func (c *Core) addDuplicatesLocked(id string, blockers []string) {
+	if c.duplicates == nil {
+		c.duplicates = make(map[string][]string)
+	}
+	for _, blocker := range blockers {
+		if !slices.Contains(c.duplicates[id], blocker) {
+			c.duplicates[id] = append(c.duplicates[id], blocker)
+		}
+	}
+}
+
+// flushDuplicatesLocked rewrites the files of the given issues if they still
+// hold duplicate blocked_by entries.
+// Must be called with c.mu held for writing.
+func (c *Core) flushDuplicatesLocked(ids []string) error {
+	for _, id := range ids {
+		b, ok := c.issues[id]
+		if _, dup := c.duplicates[id]; !ok || !dup {
+			continue
+		}
+		if err := c.saveToDisk(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DuplicateLinks returns the blocking links whose blocked_by half is still
+// written in the blocked issue's file, sorted by blocked then blocker ID.
+// Load already ignores those halves; FixDuplicateLinks removes them.
+func (c *Core) DuplicateLinks() []DuplicateLink {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.duplicateLinksLocked()
+}
+
+// duplicateLinksLocked implements DuplicateLinks.
+// Must be called with c.mu held.
+func (c *Core) duplicateLinksLocked() []DuplicateLink {
+	result := []DuplicateLink{}
+	for blocked, blockers := range c.duplicates {
+		for _, blocker := range blockers {
+			result = append(result, DuplicateLink{Blocker: blocker, Blocked: blocked})
+		}
+	}
+	slices.SortFunc(result, func(a, b DuplicateLink) int {
+		return cmp.Or(cmp.Compare(a.Blocked, b.Blocked), cmp.Compare(a.Blocker, b.Blocker))
+	})
+	return result
+}
+
+// FixDuplicateLinks rewrites every file that repeats a blocking link in its
+// blocked_by field, leaving the link stored only on the blocker. Returns the
+// number of files rewritten.
+func (c *Core) FixDuplicateLinks() (int, error) {
+	c.lockForWrite()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.duplicates))
+	for id := range c.duplicates {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	fixed := 0
+	for _, id := range ids {
+		if err := c.flushDuplicatesLocked([]string{id}); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+	return fixed, nil
+}
+
+// forgetDuplicatesLocked drops what is recorded about duplicate links
+// involving the issue with the given ID, once it is deleted.
+// Must be called with c.mu held for writing.
+func (c *Core) forgetDuplicatesLocked(id string) {
+	delete(c.duplicates, id)
+	for blocked, blockers := range c.duplicates {
+		if blockers = slices.DeleteFunc(blockers, func(b string) bool { return b == id }); len(blockers) == 0 {
+			delete(c.duplicates, blocked)
+		} else {
+			c.duplicates[blocked] = blockers
+		}
+	}
+}
+
+// UnlinkBlocking removes the blocking link from blockerID to blockedID,
+// whichever issue stores it, and rewrites the files that mention it. Removing
+// a link through either issue therefore clears it for both.
+func (c *Core) UnlinkBlocking(blockerID, blockedID string) error {
+	c.lockForWrite()
+	defer c.mu.Unlock()
+
+	var changed []*issue.Issue
+	if blocker, ok := c.issues[blockerID]; ok && slices.Contains(blocker.Blocking, blockedID) {
+		blocker.RemoveBlocking(blockedID)
+		changed = append(changed, blocker)
+	}
+	if blocked, ok := c.issues[blockedID]; ok {
+		if slices.Contains(blocked.BlockedBy, blockerID) || slices.Contains(c.duplicates[blockedID], blockerID) {
+			blocked.RemoveBlockedBy(blockerID)
+			changed = append(changed, blocked)
+		}
+	}
+	for _, b := range changed {
+		if err := c.saveToDisk(b); err != nil {
+			return err
+		}
+		c.indexLinksLocked(b)
+	}
+	return nil
+}
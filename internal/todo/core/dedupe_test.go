@@ -0,0 +1,163 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeMirroredLink writes aaa1 blocking bbb2 with the link repeated in
+// bbb2's blocked_by field, as older versions saved it, and reloads.
+func writeMirroredLink(t *testing.T, c *Core, dataDir string) {
+	t.Helper()
+	files := map[string]string{
+		"aaa1--blocker.md": "---\ntitle: Blocker\nstatus: ready\nblocking:\n    - bbb2\n---\n",
+		"bbb2--blocked.md": "---\ntitle: Blocked\nstatus: ready\nblocked_by:\n    - aaa1\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+}
+
+func readIssueFile(t *testing.T, dataDir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dataDir, name))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestLoadDedupesMirroredLinks(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	writeMirroredLink(t, c, dataDir)
+
+	blocked, _ := c.Get("bbb2")
+	if len(blocked.BlockedBy) != 0 {
+		t.Errorf("BlockedBy = %v, want the mirror dropped in memory", blocked.BlockedBy)
+	}
+	if !c.IsBlocked("bbb2") {
+		t.Error("bbb2 should still be blocked through aaa1's blocking link")
+	}
+	want := []DuplicateLink{{Blocker: "aaa1", Blocked: "bbb2"}}
+	if got := c.DuplicateLinks(); !slices.Equal(got, want) {
+		t.Errorf("DuplicateLinks() = %v, want %v", got, want)
+	}
+	if got := c.CheckAllLinks().DuplicateLinks; !slices.Equal(got, want) {
+		t.Errorf("CheckAllLinks().DuplicateLinks = %v, want %v", got, want)
+	}
+	if counts := c.AllBlockCounts()["bbb2"]; counts.BlockedBy != 1 {
+		t.Errorf("BlockedBy count = %d, want 1", counts.BlockedBy)
+	}
+}
+
+func TestFixDuplicateLinks(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	writeMirroredLink(t, c, dataDir)
+
+	fixed, err := c.FixDuplicateLinks()
+	if err != nil {
+		t.Fatalf("FixDuplicateLinks() error = %v", err)
+	}
+	if fixed != 1 {
+		t.Errorf("fixed = %d, want 1", fixed)
+	}
+	if content := readIssueFile(t, dataDir, "bbb2--blocked.md"); strings.Contains(content, "blocked_by") {
+		t.Errorf("bbb2 file still lists blocked_by:\n%s", content)
+	}
+	if content := readIssueFile(t, dataDir, "aaa1--blocker.md"); !strings.Contains(content, "bbb2") {
+		t.Errorf("aaa1 file lost its blocking link:\n%s", content)
+	}
+	if got := c.DuplicateLinks(); len(got) != 0 {
+		t.Errorf("DuplicateLinks() after fix = %v, want none", got)
+	}
+
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !c.IsBlocked("bbb2") {
+		t.Error("bbb2 should still be blocked after the fix")
+	}
+}
+
+func TestRemovingMirroredLinkLeavesNoGhost(t *testing.T) {
+	t.Run("removed from the blocker", func(t *testing.T) {
+		c, dataDir := setupTestCore(t)
+		writeMirroredLink(t, c, dataDir)
+
+		blocker, _ := c.Get("aaa1")
+		blocker.RemoveBlocking("bbb2")
+		if err := c.Update(blocker, nil); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if c.IsBlocked("bbb2") {
+			t.Error("bbb2 still blocked after aaa1 stopped blocking it")
+		}
+
+		if err := c.Load(); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if c.IsBlocked("bbb2") {
+			t.Error("bbb2 blocked again after reload: its file kept the blocked_by half")
+		}
+	})
+
+	t.Run("removed from the blocked issue", func(t *testing.T) {
+		c, dataDir := setupTestCore(t)
+		writeMirroredLink(t, c, dataDir)
+
+		if err := c.UnlinkBlocking("aaa1", "bbb2"); err != nil {
+			t.Fatalf("UnlinkBlocking() error = %v", err)
+		}
+		if c.IsBlocked("bbb2") {
+			t.Error("bbb2 still blocked after unlinking")
+		}
+		if blocker, _ := c.Get("aaa1"); len(blocker.Blocking) != 0 {
+			t.Errorf("aaa1 Blocking = %v, want empty", blocker.Blocking)
+		}
+
+		if err := c.Load(); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if c.IsBlocked("bbb2") {
+			t.Error("bbb2 blocked again after reload")
+		}
+	})
+}
+
+func TestWriteStoresLinkOnce(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	blocked := createTestIssue(t, c, "bbb2", "Blocked", "ready")
+	blocked.BlockedBy = []string{"aaa1"}
+	createTestIssue(t, c, "aaa1", "Blocker", "ready")
+	if err := c.Update(blocked, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// The blocker now lists the link too: the blocked_by half is dropped.
+	blocker, _ := c.Get("aaa1")
+	blocker.AddBlocking("bbb2")
+	if err := c.Update(blocker, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if got, _ := c.Get("bbb2"); len(got.BlockedBy) != 0 {
+		t.Errorf("BlockedBy = %v, want empty", got.BlockedBy)
+	}
+	if content := readIssueFile(t, dataDir, blocked.Path); strings.Contains(content, "blocked_by") {
+		t.Errorf("bbb2 file still lists blocked_by:\n%s", content)
+	}
+	if !c.IsBlocked("bbb2") {
+		t.Error("bbb2 should be blocked by aaa1")
+	}
+	if got := c.DuplicateLinks(); len(got) != 0 {
+		t.Errorf("DuplicateLinks() = %v, want none", got)
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// ErrIssueKeyUnavailable is returned when writing an encrypted issue whose body
+// changed (or was newly marked encrypted) without a usable key.
+var ErrIssueKeyUnavailable = errors.New("encrypted issue: no key available (set JIG_ISSUE_KEY or issue_key_file in .jig.local.yaml)")
+
+// issueKey returns the AES key for encrypted bodies, or nil when none is
+// configured. The key is resolved once per Core.
+func (c *Core) issueKey() []byte {
+	c.keyOnce.Do(func() {
+		if c.config == nil {
+			return
+		}
+		secret, err := c.config.IssueKeySecret()
+		if err != nil {
+			c.logWarn("issue encryption key unavailable: %v", err)
+			return
+		}
+		if secret != "" {
+			c.key = issue.DeriveKey(secret)
+		}
+	})
+	return c.key
+}
+
+// decryptLoaded replaces the ciphertext body of a freshly parsed encrypted
+// issue with its plaintext, or with issue.EncryptedPlaceholder when the key
+// is missing or wrong. The ciphertext is kept so metadata-only updates can
+// write it back unchanged.
+func (c *Core) decryptLoaded(b *issue.Issue) {
+	if !b.Encrypted {
+		return
+	}
+	b.Ciphertext = strings.TrimSpace(b.Body)
+	b.Body = issue.EncryptedPlaceholder
+
+	key := c.issueKey()
+	if key == nil {
+		return
+	}
+	plain, err := issue.DecryptBody(key, b.Ciphertext)
+	if err != nil {
+		c.logWarn("issue %s: %v", b.Path, err)
+		return
+	}
+	b.Body = plain
+}
+
+// sealBody prepares b's Ciphertext before it is rendered to disk. An issue
+// still showing the placeholder keeps its existing ciphertext, so issues
+// can be re-statused or re-tagged without the key.
+func (c *Core) sealBody(b *issue.Issue) error {
+	locked := b.Ciphertext != "" && b.Body == issue.EncryptedPlaceholder
+	if !b.Encrypted {
+		if locked {
+			// Dropping the flag would write the placeholder over the real body.
+			return ErrIssueKeyUnavailable
+		}
+		b.Ciphertext = ""
+		return nil
+	}
+	if locked {
+		return nil
+	}
+
+	key := c.issueKey()
+	if key == nil {
+		return ErrIssueKeyUnavailable
+	}
+	sealed, err := issue.EncryptBody(key, b.Body)
+	if err != nil {
+		return err
+	}
+	b.Ciphertext = sealed
+	return nil
+}
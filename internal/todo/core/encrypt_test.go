@@ -0,0 +1,139 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+const secretBody = "password: hunter2"
+
+// reopenCore loads dataDir into a fresh Core, picking up the current key.
+func reopenCore(t *testing.T, dataDir string) *Core {
+	t.Helper()
+	c := New(dataDir, config.Default())
+	c.SetWarnWriter(nil)
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	return c
+}
+
+// createEncryptedIssue creates an encrypted issue under key "right".
+func createEncryptedIssue(t *testing.T) (string, string) {
+	t.Helper()
+	t.Setenv(config.IssueKeyEnv, "right")
+	c, dataDir := setupTestCore(t)
+	b := &issue.Issue{ID: "sec-1", Slug: "leak", Title: "Token leak", Status: "ready", Body: secretBody, Encrypted: true}
+	createTestIssues(t, c, b)
+	return dataDir, b.Path
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	dataDir, path := createEncryptedIssue(t)
+
+	raw, err := os.ReadFile(filepath.Join(dataDir, path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "hunter2") {
+		t.Fatalf("plaintext body written to disk:\n%s", raw)
+	}
+	if !strings.Contains(string(raw), "title: Token leak") || !strings.Contains(string(raw), "encrypted: true") {
+		t.Errorf("metadata should stay plaintext:\n%s", raw)
+	}
+
+	c := reopenCore(t, dataDir)
+	b, err := c.Get("sec-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Body != secretBody {
+		t.Errorf("Body = %q, want decrypted %q", b.Body, secretBody)
+	}
+
+	// Rendering (show --raw, etags) uses the ciphertext.
+	rendered, _ := b.Render()
+	if strings.Contains(string(rendered), "hunter2") {
+		t.Error("Render leaked plaintext")
+	}
+
+	// Turning encryption off writes the body back in plaintext.
+	b.Encrypted = false
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	raw, _ = os.ReadFile(filepath.Join(dataDir, path))
+	if !strings.Contains(string(raw), secretBody) {
+		t.Errorf("decrypted issue should store plaintext:\n%s", raw)
+	}
+}
+
+func TestEncryptedWrongKey(t *testing.T) {
+	dataDir, _ := createEncryptedIssue(t)
+
+	t.Setenv(config.IssueKeyEnv, "wrong")
+	c := reopenCore(t, dataDir)
+	b, _ := c.Get("sec-1")
+	if b.Body != issue.EncryptedPlaceholder {
+		t.Fatalf("Body = %q, want placeholder", b.Body)
+	}
+
+	// Metadata edits keep the original ciphertext intact.
+	b.Status = "in-progress"
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("metadata update without a usable key: %v", err)
+	}
+
+	t.Setenv(config.IssueKeyEnv, "right")
+	b, _ = reopenCore(t, dataDir).Get("sec-1")
+	if b.Body != secretBody || b.Status != "in-progress" {
+		t.Errorf("after wrong-key update: body=%q status=%q", b.Body, b.Status)
+	}
+}
+
+func TestEncryptedMissingKey(t *testing.T) {
+	dataDir, _ := createEncryptedIssue(t)
+
+	t.Setenv(config.IssueKeyEnv, "")
+	c := reopenCore(t, dataDir)
+	b, _ := c.Get("sec-1")
+	if b.Body != issue.EncryptedPlaceholder {
+		t.Fatalf("Body = %q, want placeholder", b.Body)
+	}
+
+	b.Encrypted = false
+	if err := c.Update(b, nil); !errors.Is(err, ErrIssueKeyUnavailable) {
+		t.Errorf("decrypting without key: err = %v, want ErrIssueKeyUnavailable", err)
+	}
+
+	fresh := &issue.Issue{ID: "sec-2", Slug: "new", Title: "New", Status: "ready", Body: "x", Encrypted: true}
+	if err := c.Create(fresh); !errors.Is(err, ErrIssueKeyUnavailable) {
+		t.Errorf("creating encrypted issue without key: err = %v, want ErrIssueKeyUnavailable", err)
+	}
+}
+
+func TestSearchSkipsEncryptedBody(t *testing.T) {
+	t.Setenv(config.IssueKeyEnv, "right")
+	c, _ := setupTestCore(t)
+	createTestIssues(t, c,
+		&issue.Issue{ID: "sec-1", Slug: "leak", Title: "Token leak", Status: "ready", Body: "hunter2", Encrypted: true},
+		&issue.Issue{ID: "pub-1", Slug: "note", Title: "Note", Status: "ready", Body: "hunter2"},
+	)
+
+	results, err := c.Search("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "pub-1" {
+		t.Errorf("Search returned %v, want only pub-1", results)
+	}
+	if results, _ := c.Search("leak"); len(results) != 1 {
+		t.Errorf("encrypted issue title should stay searchable, got %d results", len(results))
+	}
+}
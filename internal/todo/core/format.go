@@ -0,0 +1,61 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// FormatFiles finds the issue and milestone files that are not in canonical
+// form (see issue.Format) and returns their paths relative to the data
+// directory, sorted. With write, it rewrites them in canonical form. Files
+// Load skipped are left alone.
+func (c *Core) FormatFiles(write bool) ([]string, error) {
+	if write {
+		c.lockForWrite()
+		defer c.mu.Unlock()
+	} else {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+
+	type file struct {
+		id, path string
+		format   func(id string, content []byte) ([]byte, error)
+	}
+	files := make([]file, 0, len(c.issues)+len(c.milestones))
+	for _, b := range c.issues {
+		files = append(files, file{b.ID, b.Path, issue.Format})
+	}
+	for _, m := range c.milestones {
+		files = append(files, file{m.ID, m.Path, issue.FormatMilestone})
+	}
+	slices.SortFunc(files, func(a, b file) int { return cmp.Compare(a.path, b.path) })
+
+	var changed []string
+	for _, f := range files {
+		path := filepath.Join(c.root, f.path)
+		content, err := os.ReadFile(path) //nolint:gosec // path from known directory
+		if err != nil {
+			return changed, err
+		}
+		canonical, err := f.format(f.id, content)
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", f.path, err)
+		}
+		if string(canonical) == string(content) {
+			continue
+		}
+		if write {
+			if err := os.WriteFile(path, canonical, 0644); err != nil {
+				return changed, fmt.Errorf("writing file: %w", err)
+			}
+		}
+		changed = append(changed, filepath.ToSlash(f.path))
+	}
+	return changed, nil
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFormatFiles(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	createTestIssue(t, c, "fmt-1", "Canonical", "ready")
+	messy := "---\ntags: [b, a]\nstatus: ready\ntitle: Messy\n---\nBody.\n"
+	messyPath := filepath.Join(dataDir, "fmt-2--messy.md")
+	if err := os.WriteFile(messyPath, []byte(messy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := c.FormatFiles(false)
+	if err != nil {
+		t.Fatalf("FormatFiles(false) error = %v", err)
+	}
+	if want := []string{"fmt-2--messy.md"}; !slices.Equal(changed, want) {
+		t.Errorf("FormatFiles(false) = %v, want %v", changed, want)
+	}
+	if data, _ := os.ReadFile(messyPath); string(data) != messy {
+		t.Error("FormatFiles(false) rewrote a file")
+	}
+
+	if changed, err = c.FormatFiles(true); err != nil || len(changed) != 1 {
+		t.Fatalf("FormatFiles(true) = %v, %v", changed, err)
+	}
+	if changed, _ = c.FormatFiles(false); len(changed) != 0 {
+		t.Errorf("FormatFiles(false) after rewriting = %v, want none", changed)
+	}
+	b, err := c.Reload("fmt-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Title != "Messy" || !slices.Equal(b.Tags, []string{"b", "a"}) {
+		t.Errorf("rewritten issue = %+v", b)
+	}
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// HierarchyDepthError is returned when setting a parent would put more
+// parents above an issue than max_hierarchy_depth allows.
+type HierarchyDepthError struct {
+	// Chain runs from the deepest affected issue up to the top-level issue.
+	Chain []string
+	Max   int
+}
+
+func (e *HierarchyDepthError) Error() string {
+	return fmt.Sprintf("parent chain too deep: %s has %d parents above it (max_hierarchy_depth is %d)",
+		strings.Join(e.Chain, " → "), len(e.Chain)-1, e.Max)
+}
+
+// DeepChain is an issue with more parents above it than
+// max_hierarchy_depth allows. Only the first issue down each chain to
+// exceed the limit is reported.
+type DeepChain struct {
+	IssueID string   `json:"issue_id"`
+	Chain   []string `json:"chain"`
+}
+
+// CycleBreak is a parent link removed to break a parent cycle.
+type CycleBreak struct {
+	IssueID string `json:"issue_id"`
+	Parent  string `json:"parent"`
+}
+
+// ValidateHierarchyDepth checks that giving b the parent parentID keeps b
+// and everything below it within max_hierarchy_depth.
+func (c *Core) ValidateHierarchyDepth(b *issue.Issue, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	limit := c.maxHierarchyDepthLocked()
+	above := append([]string{parentID}, c.ancestorsLocked(parentID)...)
+	below := c.deepestDescendantsLocked(b.ID, map[string]bool{b.ID: true})
+	chain := slices.Concat(below, []string{b.ID}, above)
+	if len(chain)-1 > limit {
+		return &HierarchyDepthError{Chain: chain, Max: limit}
+	}
+	return nil
+}
+
+// maxHierarchyDepthLocked returns the configured depth limit.
+// Must be called with c.mu held.
+func (c *Core) maxHierarchyDepthLocked() int {
+	if c.config == nil {
+		return config.DefaultMaxHierarchyDepth
+	}
+	return c.config.GetMaxHierarchyDepth()
+}
+
+// Ancestors returns the IDs of the parents above the issue with the given
+// ID, nearest first.
+func (c *Core) Ancestors(id string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ancestorsLocked(id)
+}
+
+// ancestorsLocked returns the parents above the issue with the given ID,
+// nearest first. It stops at a broken link or where the chain loops back.
+// Must be called with c.mu held.
+func (c *Core) ancestorsLocked(id string) []string {
+	var chain []string
+	seen := map[string]bool{id: true}
+	for {
+		b, ok := c.issues[id]
+		if !ok || b.Parent == "" || seen[b.Parent] {
+			return chain
+		}
+		if _, ok := c.issues[b.Parent]; !ok {
+			return chain
+		}
+		id = b.Parent
+		seen[id] = true
+		chain = append(chain, id)
+	}
+}
+
+// deepestDescendantsLocked returns the longest chain of descendants below
+// the issue with the given ID, deepest first. visited guards against cycles.
+// Must be called with c.mu held.
+func (c *Core) deepestDescendantsLocked(id string, visited map[string]bool) []string {
+	var deepest []string
+	for childID := range c.children[id] {
+		if visited[childID] {
+			continue
+		}
+		visited[childID] = true
+		chain := append(c.deepestDescendantsLocked(childID, visited), childID)
+		delete(visited, childID)
+		if len(chain) > len(deepest) || (len(chain) == len(deepest) && chain[0] < deepest[0]) {
+			deepest = chain
+		}
+	}
+	return deepest
+}
+
+// CheckHierarchyDepth reports issues with more parents above them than
+// max_hierarchy_depth allows, sorted by issue ID.
+func (c *Core) CheckHierarchyDepth() []DeepChain {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.deepChainsLocked()
+}
+
+// deepChainsLocked is CheckHierarchyDepth without the lock.
+// Must be called with c.mu held.
+func (c *Core) deepChainsLocked() []DeepChain {
+	limit := c.maxHierarchyDepthLocked()
+	deep := []DeepChain{}
+	for id := range c.issues {
+		if above := c.ancestorsLocked(id); len(above) == limit+1 {
+			deep = append(deep, DeepChain{IssueID: id, Chain: append([]string{id}, above...)})
+		}
+	}
+	slices.SortFunc(deep, func(a, b DeepChain) int { return strings.Compare(a.IssueID, b.IssueID) })
+	return deep
+}
+
+// FixParentCycles breaks every parent cycle by clearing the parent of its
+// most recently updated issue, taken as the one whose parent link closed
+// the cycle. Returns the links removed.
+func (c *Core) FixParentCycles() ([]CycleBreak, error) {
+	c.lockForWrite()
+	defer c.mu.Unlock()
+
+	var breaks []CycleBreak
+	for _, cycle := range c.findCycles(issue.LinkTypeParent) {
+		members := cycle.Path[:len(cycle.Path)-1]
+		newest := c.issues[members[0]]
+		for _, id := range members[1:] {
+			if b := c.issues[id]; newerThan(b, newest) {
+				newest = b
+			}
+		}
+		breaks = append(breaks, CycleBreak{IssueID: newest.ID, Parent: newest.Parent})
+		newest.Parent = ""
+		c.indexLinksLocked(newest)
+		if err := c.saveToDisk(newest); err != nil {
+			return breaks, err
+		}
+	}
+	return breaks, nil
+}
+
+// newerThan reports whether a was updated after b, breaking ties by the
+// larger ID so the choice is stable.
+func newerThan(a, b *issue.Issue) bool {
+	switch {
+	case a.UpdatedAt == nil && b.UpdatedAt == nil:
+		return a.ID > b.ID
+	case a.UpdatedAt == nil:
+		return false
+	case b.UpdatedAt == nil:
+		return true
+	case a.UpdatedAt.Equal(*b.UpdatedAt):
+		return a.ID > b.ID
+	}
+	return a.UpdatedAt.After(*b.UpdatedAt)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// writeIssueFile writes an issue file directly, bypassing all validation.
+func writeIssueFile(t *testing.T, dataDir, id, parent string, updated time.Time) {
+	t.Helper()
+	content := "---\ntitle: " + id + "\nstatus: ready\ntype: epic\nupdated_at: " + updated.Format(time.RFC3339) + "\n"
+	if parent != "" {
+		content += "parent: " + parent + "\n"
+	}
+	content += "---\n"
+	if err := os.WriteFile(filepath.Join(dataDir, id+".md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateHierarchyDepth(t *testing.T) {
+	core, _ := setupTestCore(t, func(cfg *config.Config) { cfg.MaxHierarchyDepth = 2 })
+	createTestIssues(t, core,
+		&issue.Issue{ID: "top", Title: "Top", Status: "ready"},
+		&issue.Issue{ID: "mid", Title: "Mid", Status: "ready", Parent: "top"},
+		&issue.Issue{ID: "low", Title: "Low", Status: "ready", Parent: "mid"},
+		&issue.Issue{ID: "sub", Title: "Sub", Status: "ready"},
+		&issue.Issue{ID: "leaf", Title: "Leaf", Status: "ready", Parent: "sub"},
+	)
+
+	if err := core.ValidateHierarchyDepth(&issue.Issue{ID: "new"}, "mid"); err != nil {
+		t.Errorf("two parents above: %v", err)
+	}
+
+	err := core.ValidateHierarchyDepth(&issue.Issue{ID: "new"}, "low")
+	depthErr, ok := errors.AsType[*HierarchyDepthError](err)
+	if !ok {
+		t.Fatalf("three parents above: err = %v, want HierarchyDepthError", err)
+	}
+	if want := []string{"new", "low", "mid", "top"}; !slices.Equal(depthErr.Chain, want) {
+		t.Errorf("Chain = %v, want %v", depthErr.Chain, want)
+	}
+
+	// Moving sub under mid would put its child leaf three parents down.
+	sub, _ := core.Get("sub")
+	err = core.ValidateHierarchyDepth(sub, "mid")
+	if depthErr, ok := errors.AsType[*HierarchyDepthError](err); !ok || depthErr.Chain[0] != "leaf" {
+		t.Errorf("moving a subtree: err = %v, want chain from leaf", err)
+	}
+}
+
+func TestParentCycleCheckAndFix(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	writeIssueFile(t, dataDir, "aaa", "bbb", base)
+	writeIssueFile(t, dataDir, "bbb", "ccc", base.Add(time.Hour))
+	writeIssueFile(t, dataDir, "ccc", "aaa", base.Add(2*time.Hour)) // newest edge
+	writeIssueFile(t, dataDir, "ddd", "aaa", base)
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	result := core.CheckAllLinks()
+	if !slices.ContainsFunc(result.Cycles, func(c Cycle) bool { return c.LinkType == issue.LinkTypeParent }) {
+		t.Fatalf("Cycles = %+v, want a parent cycle", result.Cycles)
+	}
+
+	// Walking up from inside the cycle stops when it comes back around, and
+	// the three cycle members plus ddd count as four parents.
+	err := core.ValidateHierarchyDepth(&issue.Issue{ID: "new"}, "ddd")
+	if _, ok := errors.AsType[*HierarchyDepthError](err); !ok {
+		t.Errorf("ValidateHierarchyDepth in a cycle: err = %v, want HierarchyDepthError", err)
+	}
+
+	breaks, err := core.FixParentCycles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breaks) != 1 || breaks[0] != (CycleBreak{IssueID: "ccc", Parent: "aaa"}) {
+		t.Errorf("FixParentCycles() = %+v, want ccc's parent aaa removed", breaks)
+	}
+
+	// The fix is written to disk.
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := core.Get("ccc"); c.Parent != "" {
+		t.Errorf("ccc parent = %q after reload, want none", c.Parent)
+	}
+	if cycles := core.CheckAllLinks().Cycles; len(cycles) != 0 {
+		t.Errorf("Cycles after fix = %+v", cycles)
+	}
+}
+
+func TestCheckHierarchyDepth(t *testing.T) {
+	core, dataDir := setupTestCore(t, func(cfg *config.Config) { cfg.MaxHierarchyDepth = 1 })
+	now := time.Now()
+	writeIssueFile(t, dataDir, "aaa", "", now)
+	writeIssueFile(t, dataDir, "bbb", "aaa", now)
+	writeIssueFile(t, dataDir, "ccc", "bbb", now)
+	writeIssueFile(t, dataDir, "ddd", "ccc", now)
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	deep := core.CheckAllLinks().DeepChains
+	if len(deep) != 1 || deep[0].IssueID != "ccc" || !slices.Equal(deep[0].Chain, []string{"ccc", "bbb", "aaa"}) {
+		t.Errorf("DeepChains = %+v, want only ccc → bbb → aaa", deep)
+	}
+}
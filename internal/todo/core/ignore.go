@@ -0,0 +1,161 @@
+package core
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFile is the file in the data directory listing, in gitignore syntax,
+// paths that Load and the watcher skip without warnings or events. Its
+// patterns apply after defaultIgnorePatterns, so "!" can re-include what a
+// default excludes.
+const IgnoreFile = ".jigignore"
+
+// defaultIgnorePatterns are ignored in every data directory: hidden files
+// and directories (.git, .DS_Store, .cache, .templates, .attachments, and
+// editor lock files), editor swap and backup files, scratch files, and
+// stray package directories.
+var defaultIgnorePatterns = []string{
+	".*",
+	"*.swp",
+	"*.swo",
+	"*.swx",
+	"*~",
+	`\#*#`,
+	"*.tmp",
+	"node_modules/",
+}
+
+var defaultIgnoreRules = parseIgnore(defaultIgnorePatterns)
+
+// ignoreRule is one parsed pattern line.
+type ignoreRule struct {
+	// segments are the pattern's path components; "**" matches any number of
+	// them. A pattern without a slash in it is matched at any depth.
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// ignoreRules decide which paths in the data directory are skipped, as
+// gitignore does: the last matching rule wins, and a "!" rule re-includes a
+// path an earlier one excluded.
+type ignoreRules []ignoreRule
+
+// parseIgnore parses gitignore-style lines. Blank lines and "#" comments are
+// skipped; a leading backslash escapes a literal "#" or "!". Patterns that
+// path.Match rejects never match.
+func parseIgnore(lines []string) ignoreRules {
+	var rules ignoreRules
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var r ignoreRule
+		switch {
+		case strings.HasPrefix(line, "!"):
+			r.negate = true
+			line = line[1:]
+		case strings.HasPrefix(line, `\#`), strings.HasPrefix(line, `\!`):
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		anchored := strings.Contains(line, "/")
+		r.segments = strings.Split(strings.TrimPrefix(line, "/"), "/")
+		if !anchored {
+			r.segments = append([]string{"**"}, r.segments...)
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// loadIgnoreRules returns the default rules followed by those in root's
+// IgnoreFile, if it has one.
+func loadIgnoreRules(root string) ignoreRules {
+	data, err := os.ReadFile(filepath.Join(root, IgnoreFile)) //nolint:gosec // fixed name in the data directory
+	if err != nil {
+		return defaultIgnoreRules
+	}
+	return append(append(ignoreRules{}, defaultIgnoreRules...), parseIgnore(strings.Split(string(data), "\n"))...)
+}
+
+// match reports whether rel, a slash-separated path relative to the data
+// directory, is ignored. As in git, a path inside an ignored directory stays
+// ignored whatever later rules say about the path itself.
+func (rs ignoreRules) match(rel string, isDir bool) bool {
+	if rel == "." || rel == "" {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if rs.matchParts(parts[:i], true) {
+			return true
+		}
+	}
+	return rs.matchParts(parts, isDir)
+}
+
+func (rs ignoreRules) matchParts(parts []string, isDir bool) bool {
+	ignored := false
+	for _, r := range rs {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if matchSegments(r.segments, parts) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+func matchSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], parts[0])
+	return err == nil && ok && matchSegments(pattern[1:], parts[1:])
+}
+
+// isIgnoredLocked reports whether the file or directory at p, a path under
+// the data directory as a walk or the watcher reports it, is ignored. Paths
+// outside the data directory are not.
+// Must be called with c.mu held.
+func (c *Core) isIgnoredLocked(p string, isDir bool) bool {
+	rel, err := filepath.Rel(c.root, p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rules := c.ignore
+	if rules == nil {
+		rules = defaultIgnoreRules
+	}
+	return rules.match(filepath.ToSlash(rel), isDir)
+}
+
+// isIgnored is isIgnoredLocked for callers that do not hold c.mu.
+func (c *Core) isIgnored(p string, isDir bool) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isIgnoredLocked(p, isDir)
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	rules := append(append(ignoreRules{}, defaultIgnoreRules...), parseIgnore([]string{
+		"# scratch work",
+		"scratch-*.md",
+		"!scratch-keep.md",
+		"drafts/",
+		"!drafts/kept.md",
+		"/top.md",
+		"notes/**/old.md",
+		`\!bang.md`,
+		"",
+	})...)
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"aaa-111--fine.md", false, false},
+		{"b/aaa-111--fine.md", false, false},
+		// Defaults
+		{".DS_Store", false, true},
+		{"b/.DS_Store", false, true},
+		{".cache", true, true},
+		{".templates/bug.md", false, true},
+		{".aaa-111--fine.md.swp", false, true},
+		{"aaa-111--fine.md~", false, true},
+		{"#aaa-111--fine.md#", false, true},
+		{"upload.tmp", false, true},
+		{"node_modules", true, true},
+		{"node_modules/pkg/README.md", false, true},
+		{"node_modules", false, false}, // a file, not the directory
+		// Unanchored patterns match at any depth; negation re-includes.
+		{"scratch-1.md", false, true},
+		{"b/scratch-2.md", false, true},
+		{"scratch-keep.md", false, false},
+		// A file in an ignored directory cannot be re-included.
+		{"drafts", true, true},
+		{"drafts/kept.md", false, true},
+		{"b/drafts/x.md", false, true},
+		// A leading slash anchors to the data directory.
+		{"top.md", false, true},
+		{"b/top.md", false, false},
+		// ** matches any number of directories, including none.
+		{"notes/old.md", false, true},
+		{"notes/a/b/old.md", false, true},
+		{"other/old.md", false, false},
+		// Escaped "!" is a literal.
+		{"!bang.md", false, true},
+		{".", true, false},
+	}
+	for _, tt := range tests {
+		if got := rules.match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("match(%q, dir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadSkipsIgnored(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	createTestIssue(t, core, "aaa-111", "Good", "ready")
+
+	writeFixture(t, dataDir, ".DS_Store", "\x00\x01")
+	writeFixture(t, dataDir, ".cache/bbb-222--cached.md", "---\ntitle: [broken\n---\n")
+	writeFixture(t, dataDir, "node_modules/pkg/README.md", "# pkg\n")
+	writeFixture(t, dataDir, "README.md", "# Notes\n")
+	writeFixture(t, dataDir, "scratch/ccc-333--draft.md", "---\ntitle: Draft\nstatus: draft\ntype: task\n---\n")
+	writeFixture(t, dataDir, IgnoreFile, "README.md\nscratch/\n")
+
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if w := core.Warnings(); len(w) != 0 {
+		t.Errorf("Load() warned about ignored files: %v", w)
+	}
+	if n := len(core.All()); n != 1 {
+		t.Errorf("loaded %d issues, want 1", n)
+	}
+
+	// Un-ignoring the directory picks its issue up on the next load.
+	writeFixture(t, dataDir, IgnoreFile, "README.md\nscratch/\n!scratch/\n")
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := core.Get("ccc-333"); err != nil {
+		t.Errorf("un-ignored issue not loaded: %v", err)
+	}
+	if w := core.Warnings(); len(w) != 0 {
+		t.Errorf("Load() warnings after un-ignoring = %v", w)
+	}
+}
+
+func TestWatcherSkipsIgnored(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	writeFixture(t, dataDir, IgnoreFile, "*.bak.md\n")
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	writeFixture(t, dataDir, "aaa-111--kept.md", "---\ntitle: Kept\nstatus: ready\ntype: task\n---\n")
+	writeFixture(t, dataDir, "aaa-111--kept.bak.md", "---\ntitle: Backup\nstatus: ready\ntype: task\n---\n")
+	writeFixture(t, dataDir, ".git/COMMIT_EDITMSG.md", "x\n")
+
+	mtimes := core.snapshotMtimes()
+	if len(mtimes) != 1 {
+		t.Errorf("snapshotMtimes() = %v, want only the kept issue", mtimes)
+	}
+
+	// Ignored paths are dropped even when a change for them is queued.
+	core.mu.Lock()
+	core.watching = true
+	core.mu.Unlock()
+	core.handleChanges(map[string]fsnotify.Op{
+		filepath.Join(dataDir, "aaa-111--kept.bak.md"):      fsnotify.Create,
+		filepath.Join(dataDir, ".git", "COMMIT_EDITMSG.md"): fsnotify.Write,
+	})
+	core.mu.Lock()
+	core.watching = false
+	core.mu.Unlock()
+	if n := len(core.All()); n != 0 {
+		t.Errorf("handleChanges loaded %d ignored files", n)
+	}
+	if w := core.Warnings(); len(w) != 0 {
+		t.Errorf("handleChanges warned about ignored files: %v", w)
+	}
+}
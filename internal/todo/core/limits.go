@@ -0,0 +1,115 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// SizeError reports an issue body or front matter over its configured
+// limit.
+type SizeError struct {
+	Part  string // "body", "front matter", or "file"
+	Size  int64
+	Limit int64
+	// Key is the config setting that raises the limit.
+	Key string
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("%s is %s, over the %s limit (%s)", e.Part, formatBytes(e.Size), formatBytes(e.Limit), e.Key)
+}
+
+// formatBytes renders n as B, KB, or MB with one decimal place.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// sizeLimitsLocked returns the body and front matter limits.
+// Must be called with c.mu held.
+func (c *Core) sizeLimitsLocked() (maxBody, maxFrontmatter int64) {
+	if c.config == nil {
+		return config.DefaultMaxBodyBytes, config.DefaultMaxFrontmatterBytes
+	}
+	return int64(c.config.GetMaxBodyBytes()), int64(c.config.GetMaxFrontmatterBytes())
+}
+
+// checkSizeLocked returns a *SizeError if b's body or rendered front matter
+// is over its limit. The body is measured as plaintext, before encryption.
+// Must be called with c.mu held.
+func (c *Core) checkSizeLocked(b *issue.Issue) error {
+	maxBody, maxFrontmatter := c.sizeLimitsLocked()
+	if n := int64(len(b.Body)); n > maxBody {
+		return &SizeError{Part: "body", Size: n, Limit: maxBody, Key: "max_body_bytes"}
+	}
+	meta := *b
+	meta.Body, meta.Ciphertext, meta.Encrypted = "", "", false
+	content, err := meta.Render()
+	if err != nil {
+		return err
+	}
+	if n := int64(len(content)); n > maxFrontmatter {
+		return &SizeError{Part: "front matter", Size: n, Limit: maxFrontmatter, Key: "max_frontmatter_bytes"}
+	}
+	return nil
+}
+
+// readIssueFile reads an issue file, refusing one too large to be within
+// the limits before reading it, and one whose front matter is over its limit
+// before parsing it. The body limit is checked after decryption by
+// loadIssue; here an encrypted body is allowed its base64 overhead.
+// Must be called with c.mu held.
+func (c *Core) readIssueFile(f *os.File) ([]byte, error) {
+	maxBody, maxFrontmatter := c.sizeLimitsLocked()
+	maxFile := maxFrontmatter + maxBody*4/3 + 4
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxFile {
+		return nil, &SizeError{Part: "file", Size: info.Size(), Limit: maxFile, Key: "max_body_bytes"}
+	}
+	// The file may grow between the stat and the read.
+	data, err := io.ReadAll(io.LimitReader(f, maxFile+1))
+	if err != nil {
+		return nil, err
+	}
+	if n := int64(len(data)); n > maxFile {
+		return nil, &SizeError{Part: "file", Size: n, Limit: maxFile, Key: "max_body_bytes"}
+	}
+	if n := int64(frontMatterLen(data)); n > maxFrontmatter {
+		return nil, &SizeError{Part: "front matter", Size: n, Limit: maxFrontmatter, Key: "max_frontmatter_bytes"}
+	}
+	return data, nil
+}
+
+// frontMatterLen returns the length of the front matter block at the start
+// of data, delimiters included: everything up to the end of the closing
+// "---" line, or all of data when the block is never closed. It is 0 when
+// data does not open with a "---" line.
+func frontMatterLen(data []byte) int {
+	line, rest, ok := bytes.Cut(data, []byte("\n"))
+	if !ok || string(bytes.TrimSpace(line)) != "---" {
+		return 0
+	}
+	offset := len(line) + 1
+	for len(rest) > 0 {
+		line, rest, _ = bytes.Cut(rest, []byte("\n"))
+		offset += len(line) + 1
+		if string(bytes.TrimSpace(line)) == "---" {
+			return min(offset, len(data))
+		}
+	}
+	return len(data)
+}
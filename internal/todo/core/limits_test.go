@@ -0,0 +1,124 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestSizeLimitsOnWrite(t *testing.T) {
+	c, _ := setupTestCore(t)
+	createTestIssue(t, c, "aaa-111", "Small", "ready")
+
+	oversized := strings.Repeat("log line\n", config.DefaultMaxBodyBytes/9+1)
+	err := c.Create(&issue.Issue{ID: "bbb-222", Title: "Log dump", Status: "ready", Body: oversized})
+	if sizeErr, ok := errors.AsType[*SizeError](err); !ok || sizeErr.Part != "body" {
+		t.Fatalf("Create() with oversized body error = %v, want body *SizeError", err)
+	}
+	if _, err := c.Get("bbb-222"); err == nil {
+		t.Error("rejected issue was stored")
+	}
+	if _, err := os.Stat(filepath.Join(c.Root(), issue.BuildPath("bbb-222", ""))); !os.IsNotExist(err) {
+		t.Errorf("rejected issue was written to disk (stat error = %v)", err)
+	}
+
+	b, _ := c.Get("aaa-111")
+	edited := *b
+	edited.Body = oversized
+	if err := c.Update(&edited, nil); !isSizeError(err, "body") {
+		t.Errorf("Update() with oversized body error = %v, want body *SizeError", err)
+	}
+	edited.Body = ""
+	edited.Tags = []string{strings.Repeat("t", config.DefaultMaxFrontmatterBytes)}
+	if err := c.Update(&edited, nil); !isSizeError(err, "front matter") {
+		t.Errorf("Update() with oversized front matter error = %v, want front matter *SizeError", err)
+	}
+
+	// A body right at the limit is fine.
+	edited.Tags = nil
+	edited.Body = strings.Repeat("x", config.DefaultMaxBodyBytes)
+	if err := c.Update(&edited, nil); err != nil {
+		t.Errorf("Update() with body at the limit error = %v", err)
+	}
+}
+
+func TestSizeLimitsOnLoad(t *testing.T) {
+	c, dataDir := setupTestCore(t, func(cfg *config.Config) {
+		cfg.MaxBodyBytes = 1 << 10
+		cfg.MaxFrontmatterBytes = 512
+	})
+	createTestIssue(t, c, "aaa-111", "Small", "ready")
+
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	frontMatter := "---\ntitle: Big\nstatus: ready\n---\n"
+	// Far over every limit: skipped on stat alone.
+	write("bbb-222--huge.md", frontMatter+strings.Repeat("x", 1<<20))
+	// Over the body limit, within the file allowance for encryption overhead.
+	write("ccc-333--body.md", frontMatter+strings.Repeat("y", 1200))
+	// A front matter block padded past its limit.
+	write("ddd-444--bomb.md", "---\ntitle: Bomb\nstatus: ready\ntags: ["+strings.Repeat("a, ", 200)+"a]\n---\n")
+
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, err := c.Get("aaa-111"); err != nil {
+		t.Errorf("small issue not loaded: %v", err)
+	}
+	want := map[string]string{
+		"bbb-222--huge.md": "file",
+		"ccc-333--body.md": "body",
+		"ddd-444--bomb.md": "front matter",
+	}
+	for _, w := range c.Warnings() {
+		part, ok := want[w.Path]
+		if !ok {
+			t.Errorf("unexpected warning %v", w)
+			continue
+		}
+		if w.Kind != WarnTooLarge || !isSizeError(w.Err, part) {
+			t.Errorf("warning for %s = %v, want %s %s limit", w.Path, w, WarnTooLarge, part)
+		}
+		delete(want, w.Path)
+	}
+	for path := range want {
+		t.Errorf("no warning for %s", path)
+	}
+	for _, id := range []string{"bbb-222", "ccc-333", "ddd-444"} {
+		if _, err := c.Get(id); err == nil {
+			t.Errorf("oversized issue %s was loaded", id)
+		}
+	}
+}
+
+func TestFrontMatterLen(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"no front matter\n", 0},
+		{"---\ntitle: A\n---\nbody\n", 17},
+		{"---\ntitle: A\n---", 16},
+		{"---\ntitle: unclosed\n", 20},
+	}
+	for _, tt := range tests {
+		if got := frontMatterLen([]byte(tt.in)); got != tt.want {
+			t.Errorf("frontMatterLen(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func isSizeError(err error, part string) bool {
+	sizeErr, ok := errors.AsType[*SizeError](err)
+	return ok && sizeErr.Part == part
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// indexedLinks is an issue's outgoing links as last recorded in the link
+// index. They are copied rather than read back from the issue, because
+// callers edit stored issues in place before saving them.
+type indexedLinks struct {
+	parent    string
+	blocking  []string
+	blockedBy []string
+}
+
+// indexLinksLocked records b's parent, blocking, and blocked_by links in the
+// reverse indexes, replacing whatever was indexed for b before.
+// Must be called with c.mu held for writing.
+func (c *Core) indexLinksLocked(b *issue.Issue) {
+	c.unindexLinksLocked(b.ID)
+	if b.Parent == "" && len(b.Blocking) == 0 && len(b.BlockedBy) == 0 {
+		return
+	}
+	if c.links == nil {
+		c.links = make(map[string]indexedLinks)
+		c.children = make(map[string]map[string]struct{})
+		c.blockers = make(map[string]map[string]struct{})
+		c.dependents = make(map[string]map[string]struct{})
+	}
+	c.links[b.ID] = indexedLinks{
+		parent:    b.Parent,
+		blocking:  slices.Clone(b.Blocking),
+		blockedBy: slices.Clone(b.BlockedBy),
+	}
+	if b.Parent != "" {
+		addRef(c.children, b.Parent, b.ID)
+	}
+	for _, target := range b.Blocking {
+		addRef(c.blockers, target, b.ID)
+	}
+	for _, blocker := range b.BlockedBy {
+		addRef(c.dependents, blocker, b.ID)
+	}
+}
+
+// unindexLinksLocked drops the links made by the issue with the given ID.
+// Links to it from other issues are kept, so they resolve again if an issue
+// with that ID comes back.
+// Must be called with c.mu held for writing.
+func (c *Core) unindexLinksLocked(id string) {
+	links, ok := c.links[id]
+	if !ok {
+		return
+	}
+	if links.parent != "" {
+		dropRef(c.children, links.parent, id)
+	}
+	for _, target := range links.blocking {
+		dropRef(c.blockers, target, id)
+	}
+	for _, blocker := range links.blockedBy {
+		dropRef(c.dependents, blocker, id)
+	}
+	delete(c.links, id)
+}
+
+// rebuildLinkIndexLocked re-indexes every issue in c.issues.
+// Must be called with c.mu held for writing.
+func (c *Core) rebuildLinkIndexLocked() {
+	c.links, c.children, c.blockers, c.dependents = nil, nil, nil, nil
+	for _, b := range c.issues {
+		c.indexLinksLocked(b)
+	}
+}
+
+func addRef(index map[string]map[string]struct{}, target, source string) {
+	if index[target] == nil {
+		index[target] = make(map[string]struct{})
+	}
+	index[target][source] = struct{}{}
+}
+
+func dropRef(index map[string]map[string]struct{}, target, source string) {
+	delete(index[target], source)
+	if len(index[target]) == 0 {
+		delete(index, target)
+	}
+}
+
+// refsLocked returns the loaded issues among the sources indexed for target,
+// sorted by ID.
+// Must be called with c.mu held.
+func (c *Core) refsLocked(index map[string]map[string]struct{}, target string) []*issue.Issue {
+	sources := make([]string, 0, len(index[target]))
+	for source := range index[target] {
+		sources = append(sources, source)
+	}
+	slices.Sort(sources)
+
+	var result []*issue.Issue
+	for _, source := range sources {
+		if b, ok := c.issues[source]; ok {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// ChildrenOf returns the issues whose parent is the issue with the given ID,
+// sorted by ID.
+func (c *Core) ChildrenOf(parentID string) []*issue.Issue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.findChildrenLocked(parentID)
+}
+
+// BlockersOf returns the issues that list the issue with the given ID in
+// their blocking field, sorted by ID. Blockers named in the issue's own
+// blocked_by field are not included; read those from the issue.
+func (c *Core) BlockersOf(id string) []*issue.Issue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refsLocked(c.blockers, id)
+}
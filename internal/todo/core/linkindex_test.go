@@ -0,0 +1,233 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// scanChildren and scanBlockers are the full scans the link index replaced,
+// kept as the reference for correctness checks and benchmarks.
+func scanChildren(c *Core, parentID string) []string {
+	var ids []string
+	for _, b := range c.issues {
+		if b.Parent == parentID {
+			ids = append(ids, b.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func scanBlockers(c *Core, id string) []string {
+	var ids []string
+	for _, b := range c.issues {
+		if slices.Contains(b.Blocking, id) {
+			ids = append(ids, b.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func scanIncoming(c *Core, id string) []string {
+	var links []string
+	for _, b := range c.issues {
+		if b.Parent == id {
+			links = append(links, b.ID+" "+issue.LinkTypeParent)
+		}
+		if slices.Contains(b.Blocking, id) {
+			links = append(links, b.ID+" "+issue.LinkTypeBlocking)
+		}
+		if slices.Contains(b.BlockedBy, id) {
+			links = append(links, b.ID+" "+issue.LinkTypeBlockedBy)
+		}
+	}
+	slices.Sort(links)
+	return links
+}
+
+// assertLinkIndex checks the indexed lookups against full scans for every
+// loaded issue and for extra IDs that may not exist.
+func assertLinkIndex(t *testing.T, c *Core, step string, extra ...string) {
+	t.Helper()
+	ids := extra
+	for _, b := range c.All() {
+		ids = append(ids, b.ID)
+	}
+	for _, id := range ids {
+		if got, want := issueIDs(c.ChildrenOf(id)), scanChildren(c, id); !slices.Equal(got, want) {
+			t.Fatalf("%s: ChildrenOf(%s) = %v, want %v", step, id, got, want)
+		}
+		if got, want := issueIDs(c.BlockersOf(id)), scanBlockers(c, id); !slices.Equal(got, want) {
+			t.Fatalf("%s: BlockersOf(%s) = %v, want %v", step, id, got, want)
+		}
+		var got []string
+		for _, link := range c.FindIncomingLinks(id) {
+			got = append(got, link.FromIssue.ID+" "+link.LinkType)
+		}
+		if want := scanIncoming(c, id); !slices.Equal(got, want) {
+			t.Fatalf("%s: FindIncomingLinks(%s) = %v, want %v", step, id, got, want)
+		}
+	}
+}
+
+func TestLinkIndexTracksMutations(t *testing.T) {
+	c, _ := setupTestCore(t)
+	createTestIssues(t, c,
+		&issue.Issue{ID: "epic-1", Title: "Epic", Status: "ready", Type: "epic"},
+		&issue.Issue{ID: "epic-2", Title: "Other epic", Status: "ready", Type: "epic"},
+		&issue.Issue{ID: "task-c", Title: "C", Status: "ready", Type: "task", Parent: "epic-1"},
+		&issue.Issue{ID: "task-a", Title: "A", Status: "ready", Type: "task", Parent: "epic-1", Blocking: []string{"task-c"}},
+		&issue.Issue{ID: "task-b", Title: "B", Status: "ready", Type: "task", Parent: "epic-2", BlockedBy: []string{"task-a"}},
+	)
+	assertLinkIndex(t, c, "after create", "missing")
+	assertIDs(t, "ChildrenOf(epic-1)", c.ChildrenOf("epic-1"), "task-a", "task-c")
+	assertIDs(t, "BlockersOf(task-c)", c.BlockersOf("task-c"), "task-a")
+
+	// Callers edit the stored issue in place before saving, so the index
+	// must drop the links it recorded, not the ones on the edited issue.
+	b, _ := c.Get("task-a")
+	b.Parent = "epic-2"
+	b.Blocking = []string{"task-b"}
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	assertLinkIndex(t, c, "after re-parent")
+	assertIDs(t, "ChildrenOf(epic-1)", c.ChildrenOf("epic-1"), "task-c")
+	assertIDs(t, "BlockersOf(task-c)", c.BlockersOf("task-c"))
+
+	if _, err := c.RemoveLinksTo("task-b"); err != nil {
+		t.Fatalf("RemoveLinksTo: %v", err)
+	}
+	assertLinkIndex(t, c, "after RemoveLinksTo")
+
+	// Links to a deleted issue stay indexed, so FixBrokenLinks can find them.
+	b, _ = c.Get("task-c")
+	b.Blocking = []string{"epic-2"}
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := c.Delete("epic-2"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	assertLinkIndex(t, c, "after delete", "epic-2")
+	assertIDs(t, "ChildrenOf(epic-2)", c.ChildrenOf("epic-2"), "task-a", "task-b")
+	if _, err := c.FixBrokenLinks(); err != nil {
+		t.Fatalf("FixBrokenLinks: %v", err)
+	}
+	assertLinkIndex(t, c, "after FixBrokenLinks", "epic-2")
+	assertIDs(t, "ChildrenOf(epic-2)", c.ChildrenOf("epic-2"))
+
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	assertLinkIndex(t, c, "after reload", "epic-2")
+}
+
+func TestLinkIndexUpdatedByWatcher(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	createTestIssue(t, c, "epic-1", "Epic", "ready")
+	child := &issue.Issue{ID: "task-1", Slug: "child", Title: "Child", Status: "ready", Parent: "epic-1"}
+	createTestIssues(t, c, child)
+	c.watching = true
+
+	// An external edit moves the child to the top level and adds a blocker.
+	edited := *child
+	edited.Parent = ""
+	edited.Blocking = []string{"epic-1"}
+	content, err := edited.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dataDir, child.Path)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c.handleChanges(map[string]fsnotify.Op{path: fsnotify.Write})
+	assertLinkIndex(t, c, "after external edit")
+	assertIDs(t, "BlockersOf(epic-1)", c.BlockersOf("epic-1"), "task-1")
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	c.handleChanges(map[string]fsnotify.Op{path: fsnotify.Remove})
+	assertLinkIndex(t, c, "after external delete")
+	assertIDs(t, "BlockersOf(epic-1)", c.BlockersOf("epic-1"))
+}
+
+// linkFixture builds an in-memory core of n issues: epics of 50 tasks each,
+// with every task blocking the next one.
+func linkFixture(n int) *Core {
+	c := New(os.TempDir(), nil)
+	c.issues = make(map[string]*issue.Issue, n)
+	var epic string
+	for i := range n {
+		id := fmt.Sprintf("i%05d", i)
+		b := &issue.Issue{ID: id, Title: strings.ToUpper(id), Status: "ready", Type: "task"}
+		if i%50 == 0 {
+			b.Type = "epic"
+			epic = id
+		} else {
+			b.Parent = epic
+			b.Blocking = []string{fmt.Sprintf("i%05d", i+1)}
+		}
+		c.issues[id] = b
+	}
+	c.rebuildLinkIndexLocked()
+	return c
+}
+
+// BenchmarkChildrenAndBlockers looks up the children and blockers of every
+// issue, as roadmap and GraphQL tree queries do.
+func BenchmarkChildrenAndBlockers(b *testing.B) {
+	c := linkFixture(5000)
+	ids := issueIDs(c.All())
+
+	b.Run("scan", func(b *testing.B) {
+		for b.Loop() {
+			for _, id := range ids {
+				scanChildren(c, id)
+				scanBlockers(c, id)
+			}
+		}
+	})
+	b.Run("index", func(b *testing.B) {
+		for b.Loop() {
+			for _, id := range ids {
+				c.ChildrenOf(id)
+				c.BlockersOf(id)
+			}
+		}
+	})
+}
+
+// BenchmarkAll lists every issue of 5000, sorted by All and unsorted by
+// AllUnordered.
+func BenchmarkAll(b *testing.B) {
+	c := linkFixture(5000)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5000 {
+		// Shuffle created_at against ID so the sort does real work.
+		created := start.Add(time.Duration(i*7919%5000) * time.Minute)
+		c.issues[fmt.Sprintf("i%05d", i)].CreatedAt = &created
+	}
+
+	b.Run("sorted", func(b *testing.B) {
+		for b.Loop() {
+			c.All()
+		}
+	})
+	b.Run("unordered", func(b *testing.B) {
+		for b.Loop() {
+			c.AllUnordered()
+		}
+	})
+}
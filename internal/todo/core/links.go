@@ -36,52 +36,55 @@ type Cycle struct {
 
 // LinkCheckResult contains all link validation issues found.
 type LinkCheckResult struct {
-	BrokenLinks []BrokenLink `json:"broken_links"`
-	SelfLinks   []SelfLink   `json:"self_links"`
-	Cycles      []Cycle      `json:"cycles"`
+	BrokenLinks    []BrokenLink    `json:"broken_links"`
+	SelfLinks      []SelfLink      `json:"self_links"`
+	Cycles         []Cycle         `json:"cycles"`
+	DuplicateLinks []DuplicateLink `json:"duplicate_links"`
+	DeepChains     []DeepChain     `json:"deep_chains"`
 }
 
 // HasIssues returns true if any link issues were found.
 func (r *LinkCheckResult) HasIssues() bool {
-	return len(r.BrokenLinks) > 0 || len(r.SelfLinks) > 0 || len(r.Cycles) > 0
+	return r.TotalIssues() > 0
 }
 
 // TotalIssues returns the total count of all issues.
 func (r *LinkCheckResult) TotalIssues() int {
-	return len(r.BrokenLinks) + len(r.SelfLinks) + len(r.Cycles)
+	return len(r.BrokenLinks) + len(r.SelfLinks) + len(r.Cycles) + len(r.DuplicateLinks) + len(r.DeepChains)
 }
 
-// FindIncomingLinks returns all issues that link TO the given issue ID.
+// FindIncomingLinks returns all issues that link TO the given issue ID,
+// ordered by source ID. A source linking in more than one way yields one
+// entry per link type: parent, then blocking, then blocked_by.
 func (c *Core) FindIncomingLinks(targetID string) []IncomingLink {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	var sources []string
+	for _, index := range []map[string]map[string]struct{}{c.children, c.blockers, c.dependents} {
+		for source := range index[targetID] {
+			sources = append(sources, source)
+		}
+	}
+	slices.Sort(sources)
+	sources = slices.Compact(sources)
+
 	var result []IncomingLink
-	for _, b := range c.issues {
-		// Check parent link
-		if b.Parent == targetID {
-			result = append(result, IncomingLink{
-				FromIssue: b,
-				LinkType:  issue.LinkTypeParent,
-			})
+	for _, id := range sources {
+		b, ok := c.issues[id]
+		if !ok {
+			continue
+		}
+		if _, ok := c.children[targetID][id]; ok {
+			result = append(result, IncomingLink{FromIssue: b, LinkType: issue.LinkTypeParent})
 		}
 		// Check blocking links
-		for _, blocked := range b.Blocking {
-			if blocked == targetID {
-				result = append(result, IncomingLink{
-					FromIssue: b,
-					LinkType:  issue.LinkTypeBlocking,
-				})
-			}
+		if _, ok := c.blockers[targetID][id]; ok {
+			result = append(result, IncomingLink{FromIssue: b, LinkType: issue.LinkTypeBlocking})
 		}
 		// Check blocked_by links (inverse: if A has blocked_by B, then B links to A)
-		for _, blocker := range b.BlockedBy {
-			if blocker == targetID {
-				result = append(result, IncomingLink{
-					FromIssue: b,
-					LinkType:  issue.LinkTypeBlockedBy,
-				})
-			}
+		if _, ok := c.dependents[targetID][id]; ok {
+			result = append(result, IncomingLink{FromIssue: b, LinkType: issue.LinkTypeBlockedBy})
 		}
 	}
 	return result
@@ -148,15 +151,112 @@ func (c *Core) findPathToTarget(current, target, linkType string, visited map[st
 	return nil
 }
 
+// LinkEdge is one link of a batch: issue From gets a link of Type to To.
+type LinkEdge struct {
+	From string
+	Type string
+	To   string
+}
+
+// DetectBatchCycle checks if adding every edge at once would create a cycle,
+// so a batch whose links are each harmless alone but loop together is caught.
+// Blocking and blocked_by links are one relation here, whichever end stores
+// them. Returns the first edge that closes a cycle and the cycle path, or a
+// nil path if the batch is acyclic.
+func (c *Core) DetectBatchCycle(edges []LinkEdge) (LinkEdge, []string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// blocks maps a blocker to the issues it blocks; parents a child to its parent.
+	blocks := make(map[string][]string)
+	parents := make(map[string]string)
+	for id, b := range c.issues {
+		blocks[id] = append(blocks[id], b.Blocking...)
+		for _, blocker := range b.BlockedBy {
+			blocks[blocker] = append(blocks[blocker], id)
+		}
+		if b.Parent != "" {
+			parents[id] = b.Parent
+		}
+	}
+	for _, e := range edges {
+		switch e.Type {
+		case issue.LinkTypeBlocking:
+			blocks[e.From] = append(blocks[e.From], e.To)
+		case issue.LinkTypeBlockedBy:
+			blocks[e.To] = append(blocks[e.To], e.From)
+		case issue.LinkTypeParent:
+			parents[e.From] = e.To
+		}
+	}
+	for id := range blocks {
+		slices.Sort(blocks[id])
+		blocks[id] = slices.Compact(blocks[id])
+	}
+
+	for _, e := range edges {
+		var path []string
+		switch e.Type {
+		case issue.LinkTypeBlocking:
+			path = findBlockingPath(blocks, e.To, e.From, make(map[string]bool), []string{e.From, e.To})
+		case issue.LinkTypeBlockedBy:
+			path = findBlockingPath(blocks, e.From, e.To, make(map[string]bool), []string{e.To, e.From})
+		case issue.LinkTypeParent:
+			path = findParentPath(parents, e.From, e.To)
+		}
+		if path != nil {
+			return e, path
+		}
+	}
+	return LinkEdge{}, nil
+}
+
+// findBlockingPath is findPathToTarget over a prebuilt blocker-to-blocked map.
+func findBlockingPath(blocks map[string][]string, current, target string, visited map[string]bool, path []string) []string {
+	if current == target {
+		return path
+	}
+	if visited[current] {
+		return nil
+	}
+	visited[current] = true
+
+	for _, t := range blocks[current] {
+		if result := findBlockingPath(blocks, t, target, visited, append(slices.Clone(path), t)); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// findParentPath follows parents up from parentID and returns the chain
+// from childID if it leads back to childID.
+func findParentPath(parents map[string]string, childID, parentID string) []string {
+	path := []string{childID, parentID}
+	seen := map[string]bool{parentID: true}
+	for current := parentID; current != childID; {
+		next, ok := parents[current]
+		if !ok || seen[next] && next != childID {
+			return nil
+		}
+		seen[next] = true
+		path = append(path, next)
+		current = next
+	}
+	return path
+}
+
 // CheckAllLinks validates all links across all issues.
 func (c *Core) CheckAllLinks() *LinkCheckResult {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	result := &LinkCheckResult{
-		BrokenLinks: []BrokenLink{},
-		SelfLinks:   []SelfLink{},
-		Cycles:      []Cycle{},
+		BrokenLinks:    []BrokenLink{},
+		SelfLinks:      []SelfLink{},
+		Cycles:         []Cycle{},
+		DuplicateLinks: c.duplicateLinksLocked(),
+		DeepChains:     c.deepChainsLocked(),
 	}
 
 	// Check for broken links and self-references
@@ -329,7 +429,7 @@ func canonicalCycleKey(path []string) string {
 // RemoveLinksTo removes all links pointing to the given target ID from all issues.
 // Returns the number of links removed.
 func (c *Core) RemoveLinksTo(targetID string) (int, error) {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	removed := 0
@@ -360,6 +460,7 @@ func (c *Core) RemoveLinksTo(targetID string) (int, error) {
 		}
 
 		if changed {
+			c.indexLinksLocked(b)
 			if err := c.saveToDisk(b); err != nil {
 				return removed, err
 			}
@@ -372,7 +473,7 @@ func (c *Core) RemoveLinksTo(targetID string) (int, error) {
 // FixBrokenLinks removes all broken links (links to non-existent issues) and self-references.
 // Returns the number of issues fixed.
 func (c *Core) FixBrokenLinks() (int, error) {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	fixed := 0
@@ -434,6 +535,7 @@ func (c *Core) FixBrokenLinks() (int, error) {
 		}
 
 		if changed {
+			c.indexLinksLocked(b)
 			if err := c.saveToDisk(b); err != nil {
 				return fixed, err
 			}
@@ -443,55 +545,56 @@ func (c *Core) FixBrokenLinks() (int, error) {
 	return fixed, nil
 }
 
-// ValidParentTypes returns the valid parent types for a given issue type.
-// Returns nil if the issue type cannot have a parent.
-func ValidParentTypes(issueType string) []string {
-	switch issueType {
-	case config.TypeMilestone:
-		return nil // milestones cannot have parents
-	case config.TypeEpic:
-		return []string{config.TypeMilestone}
-	case config.TypeFeature:
-		return []string{config.TypeMilestone, config.TypeEpic}
-	case config.TypeTask, config.TypeBug:
-		return []string{config.TypeMilestone, config.TypeEpic, config.TypeFeature}
-	default:
-		return []string{config.TypeMilestone, config.TypeEpic, config.TypeFeature} // default for unknown types
-	}
+// ValidParentTypes returns the valid parent types for a given issue type, as
+// configured by the project's type definitions. Returns nil if the issue type
+// cannot have a parent.
+func (c *Core) ValidParentTypes(issueType string) []string {
+	return c.Config().ValidParentTypes(issueType)
 }
 
 // ValidateParent checks if a parent is valid for the given issue.
-// Returns nil if valid, error otherwise.
+// Returns nil if valid, error otherwise. A parent that is valid only as an
+// epic is promoted to one and saved.
 func (c *Core) ValidateParent(b *issue.Issue, parentID string) error {
 	if parentID == "" {
 		return nil
 	}
 
-	validTypes := ValidParentTypes(b.Type)
-	if validTypes == nil {
-		return fmt.Errorf("%s issues cannot have a parent", b.Type)
-	}
-
 	parent, err := c.Get(parentID)
 	if err != nil {
-		return fmt.Errorf("parent issue not found: %s", parentID)
+		if c.ValidParentTypes(b.Type) == nil {
+			return fmt.Errorf("%s issues cannot have a parent", b.Type)
+		}
+		return fmt.Errorf("parent %w", c.NotFound(parentID))
 	}
 
-	if slices.Contains(validTypes, parent.Type) {
-		return nil
+	promote, err := c.CheckParent(b, parent)
+	if err != nil || !promote {
+		return err
+	}
+	parent.Type = config.TypeEpic
+	if err := c.Update(parent, nil); err != nil {
+		return fmt.Errorf("failed to promote parent to epic: %w", err)
 	}
+	return nil
+}
 
-	// Auto-promote parent to epic if that would make it a valid parent.
-	// Only promote types below epic in the hierarchy (feature, task, bug).
+// CheckParent checks if parent is valid for the given issue without
+// changing anything. promote reports that it is valid only once parent is
+// promoted to an epic, which is allowed for types below epic in the
+// hierarchy (feature, task, bug); the caller makes that change.
+func (c *Core) CheckParent(b, parent *issue.Issue) (promote bool, err error) {
+	validTypes := c.ValidParentTypes(b.Type)
+	if validTypes == nil {
+		return false, fmt.Errorf("%s issues cannot have a parent", b.Type)
+	}
+	if slices.Contains(validTypes, parent.Type) {
+		return false, nil
+	}
 	if slices.Contains(validTypes, config.TypeEpic) && parent.Type != config.TypeMilestone {
-		parent.Type = config.TypeEpic
-		if err := c.Update(parent, nil); err != nil {
-			return fmt.Errorf("failed to promote parent to epic: %w", err)
-		}
-		return nil
+		return true, nil
 	}
-
-	return fmt.Errorf("%s issues can only have %s as parent, not %s",
+	return false, fmt.Errorf("%s issues can only have %s as parent, not %s",
 		b.Type, joinWithOr(validTypes), parent.Type)
 }
 
@@ -515,6 +618,61 @@ func isResolvedStatus(status string) bool {
 	return status == config.StatusCompleted || status == config.StatusScrapped
 }
 
+// BlockCounts holds the number of active blocking links touching an issue.
+type BlockCounts struct {
+	// BlockedBy counts unresolved issues blocking this one.
+	BlockedBy int
+	// Blocking counts unresolved issues this one blocks (zero once it is resolved).
+	Blocking int
+}
+
+// AllBlockCounts returns BlockCounts for every issue with at least one active
+// blocking link, computed in a single pass over all links. Links declared on
+// both ends (blocking and blocked_by) count once, and links to missing issues
+// are ignored.
+func (c *Core) AllBlockCounts() map[string]BlockCounts {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type edge struct{ blocker, target string }
+	seen := make(map[edge]bool)
+	counts := make(map[string]BlockCounts)
+
+	add := func(blockerID, targetID string) {
+		e := edge{blockerID, targetID}
+		if seen[e] {
+			return
+		}
+		seen[e] = true
+		blocker, ok := c.issues[blockerID]
+		if !ok || isResolvedStatus(blocker.Status) {
+			return
+		}
+		target, ok := c.issues[targetID]
+		if !ok {
+			return
+		}
+		tc := counts[targetID]
+		tc.BlockedBy++
+		counts[targetID] = tc
+		if !isResolvedStatus(target.Status) {
+			bc := counts[blockerID]
+			bc.Blocking++
+			counts[blockerID] = bc
+		}
+	}
+
+	for _, b := range c.issues {
+		for _, targetID := range b.Blocking {
+			add(b.ID, targetID)
+		}
+		for _, blockerID := range b.BlockedBy {
+			add(blockerID, b.ID)
+		}
+	}
+	return counts
+}
+
 // IsBlocked returns true if the issue with the given ID is blocked by any
 // active (non-completed, non-scrapped) issues.
 func (c *Core) IsBlocked(issueID string) bool {
@@ -527,7 +685,11 @@ func (c *Core) IsBlocked(issueID string) bool {
 func (c *Core) FindActiveBlockers(issueID string) []*issue.Issue {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	return c.findActiveBlockersLocked(issueID)
+}
 
+// findActiveBlockersLocked is FindActiveBlockers for callers holding c.mu.
+func (c *Core) findActiveBlockersLocked(issueID string) []*issue.Issue {
 	b, ok := c.issues[issueID]
 	if !ok {
 		return nil
@@ -547,12 +709,10 @@ func (c *Core) FindActiveBlockers(issueID string) []*issue.Issue {
 	}
 
 	// Check incoming blocking links (other issues that have this issue in their Blocking list)
-	for _, other := range c.issues {
-		for _, blocked := range other.Blocking {
-			if blocked == issueID && !isResolvedStatus(other.Status) && !seen[other.ID] {
-				seen[other.ID] = true
-				blockers = append(blockers, other)
-			}
+	for _, other := range c.refsLocked(c.blockers, issueID) {
+		if !isResolvedStatus(other.Status) && !seen[other.ID] {
+			seen[other.ID] = true
+			blockers = append(blockers, other)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/toba/jig/internal/todo/issue"
@@ -16,16 +17,16 @@ func TestFindIncomingLinks(t *testing.T) {
 	issueA := &issue.Issue{
 		ID:       "aaa1",
 		Title:    "Issue A",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2"},
 		Parent:   "ccc3",
 	}
-	issueB := &issue.Issue{ID: "bbb2", Title: "Issue B", Status: "todo"}
-	issueC := &issue.Issue{ID: "ccc3", Title: "Issue C", Status: "todo"}
+	issueB := &issue.Issue{ID: "bbb2", Title: "Issue B", Status: "ready"}
+	issueC := &issue.Issue{ID: "ccc3", Title: "Issue C", Status: "ready"}
 	issueD := &issue.Issue{
 		ID:       "ddd4",
 		Title:    "Issue D",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2"},
 	}
 
@@ -82,19 +83,19 @@ func TestDetectCycle(t *testing.T) {
 	issueA := &issue.Issue{
 		ID:       "aaa1",
 		Title:    "Issue A",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2"},
 	}
 	issueB := &issue.Issue{
 		ID:       "bbb2",
 		Title:    "Issue B",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"ccc3"},
 	}
 	issueC := &issue.Issue{
 		ID:     "ccc3",
 		Title:  "Issue C",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	createTestIssues(t, core, issueA, issueB, issueC)
@@ -112,7 +113,7 @@ func TestDetectCycle(t *testing.T) {
 
 	t.Run("no cycle", func(t *testing.T) {
 		// Adding D blocks A would not create a cycle (D doesn't exist in chain)
-		issueD := &issue.Issue{ID: "ddd4", Title: "Issue D", Status: "todo"}
+		issueD := &issue.Issue{ID: "ddd4", Title: "Issue D", Status: "ready"}
 		if err := core.Create(issueD); err != nil {
 			t.Fatalf("Create error: %v", err)
 		}
@@ -128,19 +129,19 @@ func TestDetectCycle(t *testing.T) {
 		issueX := &issue.Issue{
 			ID:     "xxx1",
 			Title:  "Issue X",
-			Status: "todo",
+			Status: "ready",
 			Parent: "yyy2",
 		}
 		issueY := &issue.Issue{
 			ID:     "yyy2",
 			Title:  "Issue Y",
-			Status: "todo",
+			Status: "ready",
 			Parent: "zzz3",
 		}
 		issueZ := &issue.Issue{
 			ID:     "zzz3",
 			Title:  "Issue Z",
-			Status: "todo",
+			Status: "ready",
 		}
 
 		createTestIssues(t, core, issueX, issueY, issueZ)
@@ -153,6 +154,71 @@ func TestDetectCycle(t *testing.T) {
 	})
 }
 
+func TestDetectBatchCycle(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssues(t, core,
+		&issue.Issue{ID: "gate", Title: "Gate", Status: "ready"},
+		&issue.Issue{ID: "aaa1", Title: "Issue A", Status: "ready"},
+		&issue.Issue{ID: "bbb2", Title: "Issue B", Status: "ready", BlockedBy: []string{"aaa1"}},
+	)
+
+	t.Run("links that loop only together", func(t *testing.T) {
+		// Each alone is fine; together gate -> aaa1 -> gate.
+		edges := []LinkEdge{
+			{From: "aaa1", Type: issue.LinkTypeBlockedBy, To: "gate"},
+			{From: "aaa1", Type: issue.LinkTypeBlocking, To: "gate"},
+		}
+		for _, e := range edges {
+			if _, cycle := core.DetectBatchCycle([]LinkEdge{e}); cycle != nil {
+				t.Fatalf("DetectBatchCycle(%v) alone = %v, want nil", e, cycle)
+			}
+		}
+		edge, cycle := core.DetectBatchCycle(edges)
+		if cycle == nil {
+			t.Fatal("DetectBatchCycle() = nil, want the cycle the pair closes")
+		}
+		if edge != edges[0] {
+			t.Errorf("closing edge = %v, want %v", edge, edges[0])
+		}
+		if want := []string{"gate", "aaa1", "gate"}; !slices.Equal(cycle, want) {
+			t.Errorf("cycle = %v, want %v", cycle, want)
+		}
+	})
+
+	t.Run("blocked_by stored on the other end", func(t *testing.T) {
+		// bbb2 is blocked by aaa1, so bbb2 blocking aaa1 loops.
+		_, cycle := core.DetectBatchCycle([]LinkEdge{{From: "bbb2", Type: issue.LinkTypeBlocking, To: "aaa1"}})
+		if want := []string{"bbb2", "aaa1", "bbb2"}; !slices.Equal(cycle, want) {
+			t.Errorf("cycle = %v, want %v", cycle, want)
+		}
+	})
+
+	t.Run("parents that loop together", func(t *testing.T) {
+		edge, cycle := core.DetectBatchCycle([]LinkEdge{
+			{From: "aaa1", Type: issue.LinkTypeParent, To: "bbb2"},
+			{From: "bbb2", Type: issue.LinkTypeParent, To: "gate"},
+			{From: "gate", Type: issue.LinkTypeParent, To: "aaa1"},
+		})
+		if edge.From != "aaa1" {
+			t.Errorf("closing edge = %v, want the first", edge)
+		}
+		if want := []string{"aaa1", "bbb2", "gate", "aaa1"}; !slices.Equal(cycle, want) {
+			t.Errorf("cycle = %v, want %v", cycle, want)
+		}
+	})
+
+	t.Run("acyclic batch", func(t *testing.T) {
+		_, cycle := core.DetectBatchCycle([]LinkEdge{
+			{From: "aaa1", Type: issue.LinkTypeBlockedBy, To: "gate"},
+			{From: "bbb2", Type: issue.LinkTypeBlockedBy, To: "gate"},
+			{From: "aaa1", Type: issue.LinkTypeParent, To: "gate"},
+		})
+		if cycle != nil {
+			t.Errorf("DetectBatchCycle() = %v, want nil", cycle)
+		}
+	})
+}
+
 func TestCheckAllLinks(t *testing.T) {
 	core, _ := setupTestCore(t)
 
@@ -163,14 +229,14 @@ func TestCheckAllLinks(t *testing.T) {
 	issueA := &issue.Issue{
 		ID:       "aaa1",
 		Title:    "Issue A",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2", "aaa1"}, // aaa1 is self-reference
 		Parent:   "nonexistent",
 	}
 	issueB := &issue.Issue{
 		ID:       "bbb2",
 		Title:    "Issue B",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"aaa1"}, // creates cycle
 	}
 
@@ -237,13 +303,13 @@ func TestCheckAllLinksClean(t *testing.T) {
 	issueA := &issue.Issue{
 		ID:       "aaa1",
 		Title:    "Issue A",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2"},
 	}
 	issueB := &issue.Issue{
 		ID:     "bbb2",
 		Title:  "Issue B",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	createTestIssues(t, core, issueA, issueB)
@@ -263,20 +329,20 @@ func TestRemoveLinksTo(t *testing.T) {
 	issueA := &issue.Issue{
 		ID:       "aaa1",
 		Title:    "Issue A",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"target"},
 		Parent:   "target",
 	}
 	issueB := &issue.Issue{
 		ID:       "bbb2",
 		Title:    "Issue B",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"target"},
 	}
 	target := &issue.Issue{
 		ID:     "target",
 		Title:  "Target Issue",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	createTestIssues(t, core, issueA, issueB, target)
@@ -310,14 +376,14 @@ func TestFixBrokenLinks(t *testing.T) {
 	issueA := &issue.Issue{
 		ID:       "aaa1",
 		Title:    "Issue A",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"bbb2", "aaa1"}, // bbb2 is valid, aaa1 is self-reference
 		Parent:   "nonexistent",            // broken
 	}
 	issueB := &issue.Issue{
 		ID:     "bbb2",
 		Title:  "Issue B",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	createTestIssues(t, core, issueA, issueB)
@@ -403,7 +469,7 @@ func TestIsBlocked(t *testing.T) {
 	activeBlocker := &issue.Issue{
 		ID:       "active-blocker",
 		Title:    "Active Blocker",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"blocked-by-active"},
 	}
 	completedBlocker := &issue.Issue{
@@ -421,55 +487,55 @@ func TestIsBlocked(t *testing.T) {
 	blockedByActive := &issue.Issue{
 		ID:     "blocked-by-active",
 		Title:  "Blocked by Active",
-		Status: "todo",
+		Status: "ready",
 	}
 	blockedByCompleted := &issue.Issue{
 		ID:     "blocked-by-completed",
 		Title:  "Blocked by Completed",
-		Status: "todo",
+		Status: "ready",
 	}
 	blockedByScrapped := &issue.Issue{
 		ID:     "blocked-by-scrapped",
 		Title:  "Blocked by Scrapped",
-		Status: "todo",
+		Status: "ready",
 	}
 	notBlocked := &issue.Issue{
 		ID:     "not-blocked",
 		Title:  "Not Blocked",
-		Status: "todo",
+		Status: "ready",
 	}
 	// Issue with direct blocked_by field
 	blockedByFieldActive := &issue.Issue{
 		ID:        "blocked-by-field-active",
 		Title:     "Blocked by Field (Active)",
-		Status:    "todo",
+		Status:    "ready",
 		BlockedBy: []string{"active-blocker"},
 	}
 	blockedByFieldCompleted := &issue.Issue{
 		ID:        "blocked-by-field-completed",
 		Title:     "Blocked by Field (Completed)",
-		Status:    "todo",
+		Status:    "ready",
 		BlockedBy: []string{"completed-blocker"},
 	}
 	// Issue with broken blocker link
 	blockedByBroken := &issue.Issue{
 		ID:        "blocked-by-broken",
 		Title:     "Blocked by Broken Link",
-		Status:    "todo",
+		Status:    "ready",
 		BlockedBy: []string{"nonexistent"},
 	}
 	// Issue with multiple blockers (one active, one completed)
 	mixedBlockers := &issue.Issue{
 		ID:        "mixed-blockers",
 		Title:     "Mixed Blockers",
-		Status:    "todo",
+		Status:    "ready",
 		BlockedBy: []string{"active-blocker", "completed-blocker"},
 	}
 	// Issue with multiple blockers (all completed)
 	allResolvedBlockers := &issue.Issue{
 		ID:        "all-resolved-blockers",
 		Title:     "All Resolved Blockers",
-		Status:    "todo",
+		Status:    "ready",
 		BlockedBy: []string{"completed-blocker", "scrapped-blocker"},
 	}
 
@@ -507,6 +573,50 @@ func TestIsBlocked(t *testing.T) {
 	}
 }
 
+func TestAllBlockCounts(t *testing.T) {
+	core, _ := setupTestCore(t)
+
+	// Diamond: top blocks left and right, which both block bottom. The
+	// top→left link is declared on both ends and must count once.
+	createTestIssues(t, core,
+		&issue.Issue{ID: "top", Title: "Top", Status: "in-progress", Blocking: []string{"left", "right"}},
+		&issue.Issue{ID: "left", Title: "Left", Status: "ready", Blocking: []string{"bottom"}, BlockedBy: []string{"top"}},
+		&issue.Issue{ID: "right", Title: "Right", Status: "ready"},
+		&issue.Issue{ID: "bottom", Title: "Bottom", Status: "ready", BlockedBy: []string{"right", "done", "missing"}},
+		&issue.Issue{ID: "done", Title: "Done", Status: "completed", Blocking: []string{"right"}},
+	)
+
+	want := map[string]BlockCounts{
+		"top":    {Blocking: 2},
+		"left":   {BlockedBy: 1, Blocking: 1},
+		"right":  {BlockedBy: 1, Blocking: 1},
+		"bottom": {BlockedBy: 2},
+	}
+	got := core.AllBlockCounts()
+	if len(got) != len(want) {
+		t.Errorf("AllBlockCounts() = %v, want %v", got, want)
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("AllBlockCounts()[%q] = %+v, want %+v", id, got[id], w)
+		}
+	}
+
+	// Resolving an issue drops its outgoing links on the next computation.
+	left, _ := core.Get("left")
+	left.Status = "completed"
+	if err := core.Update(left, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got = core.AllBlockCounts()
+	if got["bottom"].BlockedBy != 1 {
+		t.Errorf("bottom BlockedBy = %d, want 1 after left resolved", got["bottom"].BlockedBy)
+	}
+	if got["top"].Blocking != 1 {
+		t.Errorf("top Blocking = %d, want 1 (resolved targets don't count)", got["top"].Blocking)
+	}
+}
+
 func TestFindActiveBlockers(t *testing.T) {
 	core, _ := setupTestCore(t)
 
@@ -520,7 +630,7 @@ func TestFindActiveBlockers(t *testing.T) {
 	activeBlocker2 := &issue.Issue{
 		ID:     "active-blocker-2",
 		Title:  "Active Blocker 2",
-		Status: "todo",
+		Status: "ready",
 	}
 	completedBlocker := &issue.Issue{
 		ID:       "completed-blocker",
@@ -531,13 +641,13 @@ func TestFindActiveBlockers(t *testing.T) {
 	target := &issue.Issue{
 		ID:        "target",
 		Title:     "Target Issue",
-		Status:    "todo",
+		Status:    "ready",
 		BlockedBy: []string{"active-blocker-2", "completed-blocker"},
 	}
 	noBlockers := &issue.Issue{
 		ID:     "no-blockers",
 		Title:  "No Blockers",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	createTestIssues(t, core, activeBlocker1, activeBlocker2, completedBlocker, target, noBlockers)
@@ -581,8 +691,8 @@ func TestFindActiveBlockers(t *testing.T) {
 func TestValidateParentAutoPromote(t *testing.T) {
 	t.Run("promotes task parent to epic", func(t *testing.T) {
 		core, _ := setupTestCore(t)
-		parent := &issue.Issue{ID: "parent-task", Title: "Parent Task", Type: "task", Status: "todo"}
-		child := &issue.Issue{ID: "child-task", Title: "Child Task", Type: "task", Status: "todo"}
+		parent := &issue.Issue{ID: "parent-task", Title: "Parent Task", Type: "task", Status: "ready"}
+		child := &issue.Issue{ID: "child-task", Title: "Child Task", Type: "task", Status: "ready"}
 		createTestIssues(t, core, parent, child)
 
 		err := core.ValidateParent(child, "parent-task")
@@ -598,8 +708,8 @@ func TestValidateParentAutoPromote(t *testing.T) {
 
 	t.Run("promotes bug parent to epic", func(t *testing.T) {
 		core, _ := setupTestCore(t)
-		parent := &issue.Issue{ID: "parent-bug", Title: "Parent Bug", Type: "bug", Status: "todo"}
-		child := &issue.Issue{ID: "child-task2", Title: "Child Task", Type: "task", Status: "todo"}
+		parent := &issue.Issue{ID: "parent-bug", Title: "Parent Bug", Type: "bug", Status: "ready"}
+		child := &issue.Issue{ID: "child-task2", Title: "Child Task", Type: "task", Status: "ready"}
 		createTestIssues(t, core, parent, child)
 
 		err := core.ValidateParent(child, "parent-bug")
@@ -615,8 +725,8 @@ func TestValidateParentAutoPromote(t *testing.T) {
 
 	t.Run("promotes feature parent to epic for feature child", func(t *testing.T) {
 		core, _ := setupTestCore(t)
-		parent := &issue.Issue{ID: "parent-feat", Title: "Parent Feature", Type: "feature", Status: "todo"}
-		child := &issue.Issue{ID: "child-feat", Title: "Child Feature", Type: "feature", Status: "todo"}
+		parent := &issue.Issue{ID: "parent-feat", Title: "Parent Feature", Type: "feature", Status: "ready"}
+		child := &issue.Issue{ID: "child-feat", Title: "Child Feature", Type: "feature", Status: "ready"}
 		createTestIssues(t, core, parent, child)
 
 		err := core.ValidateParent(child, "parent-feat")
@@ -632,8 +742,8 @@ func TestValidateParentAutoPromote(t *testing.T) {
 
 	t.Run("does not promote feature when it can already parent task", func(t *testing.T) {
 		core, _ := setupTestCore(t)
-		parent := &issue.Issue{ID: "parent-feat2", Title: "Parent Feature", Type: "feature", Status: "todo"}
-		child := &issue.Issue{ID: "child-task3", Title: "Child Task", Type: "task", Status: "todo"}
+		parent := &issue.Issue{ID: "parent-feat2", Title: "Parent Feature", Type: "feature", Status: "ready"}
+		child := &issue.Issue{ID: "child-task3", Title: "Child Task", Type: "task", Status: "ready"}
 		createTestIssues(t, core, parent, child)
 
 		err := core.ValidateParent(child, "parent-feat2")
@@ -649,8 +759,8 @@ func TestValidateParentAutoPromote(t *testing.T) {
 
 	t.Run("does not promote epic", func(t *testing.T) {
 		core, _ := setupTestCore(t)
-		parent := &issue.Issue{ID: "parent-epic", Title: "Parent Epic", Type: "epic", Status: "todo"}
-		child := &issue.Issue{ID: "child-task4", Title: "Child Task", Type: "task", Status: "todo"}
+		parent := &issue.Issue{ID: "parent-epic", Title: "Parent Epic", Type: "epic", Status: "ready"}
+		child := &issue.Issue{ID: "child-task4", Title: "Child Task", Type: "task", Status: "ready"}
 		createTestIssues(t, core, parent, child)
 
 		err := core.ValidateParent(child, "parent-epic")
@@ -666,8 +776,8 @@ func TestValidateParentAutoPromote(t *testing.T) {
 
 	t.Run("does not promote milestone", func(t *testing.T) {
 		core, _ := setupTestCore(t)
-		parent := &issue.Issue{ID: "parent-ms", Title: "Parent Milestone", Type: "milestone", Status: "todo"}
-		child := &issue.Issue{ID: "child-epic", Title: "Child Epic", Type: "epic", Status: "todo"}
+		parent := &issue.Issue{ID: "parent-ms", Title: "Parent Milestone", Type: "milestone", Status: "ready"}
+		child := &issue.Issue{ID: "child-epic", Title: "Child Epic", Type: "epic", Status: "ready"}
 		createTestIssues(t, core, parent, child)
 
 		err := core.ValidateParent(child, "parent-ms")
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// indexMentionsLocked records the issues referenced in b's body, replacing
+// whatever was indexed for b before. Bodies that could not be decrypted are
+// indexed as having no mentions.
+// Must be called with c.mu held for writing.
+func (c *Core) indexMentionsLocked(b *issue.Issue) {
+	c.unindexMentionsLocked(b.ID)
+	if b.Body == issue.EncryptedPlaceholder {
+		return
+	}
+	ids := c.idMatcher.ExtractMentions(b.Body)
+	if len(ids) == 0 {
+		return
+	}
+	if c.mentions == nil {
+		c.mentions = make(map[string][]string)
+		c.mentionedBy = make(map[string]map[string]struct{})
+	}
+	c.mentions[b.ID] = ids
+	for _, target := range ids {
+		if c.mentionedBy[target] == nil {
+			c.mentionedBy[target] = make(map[string]struct{})
+		}
+		c.mentionedBy[target][b.ID] = struct{}{}
+	}
+}
+
+// unindexMentionsLocked drops the mentions made by the issue with the given
+// ID. Mentions of it by other issues are kept, so they resolve again if an
+// issue with that ID comes back.
+// Must be called with c.mu held for writing.
+func (c *Core) unindexMentionsLocked(id string) {
+	for _, target := range c.mentions[id] {
+		delete(c.mentionedBy[target], id)
+		if len(c.mentionedBy[target]) == 0 {
+			delete(c.mentionedBy, target)
+		}
+	}
+	delete(c.mentions, id)
+}
+
+// Mentions returns the issues referenced in the body of the issue with the
+// given ID, in order of first appearance. References to unknown IDs and to
+// the issue itself are left out.
+func (c *Core) Mentions(id string) []*issue.Issue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []*issue.Issue
+	for _, target := range c.mentions[id] {
+		if b, ok := c.issues[target]; ok && target != id {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// MentionedBy returns the issues whose bodies reference the issue with the
+// given ID, sorted by ID.
+func (c *Core) MentionedBy(id string) []*issue.Issue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	sources := make([]string, 0, len(c.mentionedBy[id]))
+	for source := range c.mentionedBy[id] {
+		if source != id {
+			sources = append(sources, source)
+		}
+	}
+	slices.Sort(sources)
+
+	var result []*issue.Issue
+	for _, source := range sources {
+		if b, ok := c.issues[source]; ok {
+			result = append(result, b)
+		}
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func issueIDs(issues []*issue.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, b := range issues {
+		ids[i] = b.ID
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, what string, got []*issue.Issue, want ...string) {
+	t.Helper()
+	if ids := issueIDs(got); !slices.Equal(ids, want) {
+		t.Fatalf("%s = %v, want %v", what, ids, want)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	c, _ := setupTestCore(t)
+	createTestIssue(t, c, "aaa-111", "Target", "ready")
+	createTestIssue(t, c, "bbb-222", "Other", "ready")
+	createTestIssues(t, c,
+		&issue.Issue{ID: "ccc-333", Slug: "source", Title: "Source", Status: "ready",
+			Body: "Needs bbb-222 and [aaa-111](../a/aaa-111--target.md).\n\n```\nccc-333 zzz-999 aaa-111\n```\nNot an issue: one-off, zzz-999, ccc-333."},
+		&issue.Issue{ID: "ddd-444", Slug: "second", Title: "Second", Status: "ready", Body: "Also see aaa-111."},
+	)
+
+	assertIDs(t, "Mentions(ccc-333)", c.Mentions("ccc-333"), "bbb-222", "aaa-111")
+	assertIDs(t, "MentionedBy(aaa-111)", c.MentionedBy("aaa-111"), "ccc-333", "ddd-444")
+	assertIDs(t, "MentionedBy(ccc-333)", c.MentionedBy("ccc-333"))
+
+	// Reloading from disk rebuilds the same index.
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	assertIDs(t, "MentionedBy(aaa-111) after load", c.MentionedBy("aaa-111"), "ccc-333", "ddd-444")
+}
+
+func TestIDFormatChangeKeepsExistingIssues(t *testing.T) {
+	c, _ := setupTestCore(t)
+	createTestIssue(t, c, "abc-123", "Old format", "ready")
+
+	cfg := *c.Config()
+	cfg.IDFormat = config.IDFormat{Prefix: "PLAT-", Groups: 1, GroupLength: 4, Alphabet: "abcdefghjkmnpqrstuvwxyz23456789"}
+	c.SetConfig(&cfg)
+	created := &issue.Issue{Title: "New format", Status: "ready", Body: "Follows abc-123."}
+	createTestIssues(t, c, created)
+	if !strings.HasPrefix(created.ID, "PLAT-") || len(created.ID) != 9 {
+		t.Fatalf("created ID = %q, want PLAT- and 4 characters", created.ID)
+	}
+	createTestIssues(t, c, &issue.Issue{ID: "def-456", Title: "Links both", Status: "ready", Body: "See abc-123 and " + created.ID + "."})
+
+	// Reload from disk, as a new process under the new format would.
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, id := range []string{"abc-123", created.ID} {
+		if _, err := c.Get(id); err != nil {
+			t.Errorf("Get(%q) error = %v", id, err)
+		}
+		if !c.IDMatcher().Match(id) {
+			t.Errorf("IDMatcher().Match(%q) = false", id)
+		}
+	}
+	assertIDs(t, "Mentions(def-456)", c.Mentions("def-456"), "abc-123", created.ID)
+	assertIDs(t, "MentionedBy(abc-123)", c.MentionedBy("abc-123"), created.ID, "def-456")
+}
+
+func TestMentionsUpdatedOnEdit(t *testing.T) {
+	c, _ := setupTestCore(t)
+	createTestIssue(t, c, "aaa-111", "Target", "ready")
+	createTestIssues(t, c, &issue.Issue{ID: "ccc-333", Title: "Source", Status: "ready", Body: "See aaa-111."})
+
+	b, _ := c.Get("ccc-333")
+	b.Body = "No longer related."
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	assertIDs(t, "MentionedBy(aaa-111) after edit", c.MentionedBy("aaa-111"))
+	assertIDs(t, "Mentions(ccc-333) after edit", c.Mentions("ccc-333"))
+
+	b.Body = "Back to aaa-111."
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := c.Delete("ccc-333"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	assertIDs(t, "MentionedBy(aaa-111) after delete", c.MentionedBy("aaa-111"))
+}
+
+func TestMentionsUpdatedByWatcher(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	createTestIssue(t, c, "aaa-111", "Target", "ready")
+	src := &issue.Issue{ID: "ccc-333", Slug: "source", Title: "Source", Status: "ready", Body: "See aaa-111."}
+	createTestIssues(t, c, src)
+	c.watching = true
+
+	// Simulate an external edit that drops the mention.
+	edited := *src
+	edited.Body = "Unrelated now."
+	content, err := edited.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dataDir, src.Path)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c.handleChanges(map[string]fsnotify.Op{path: fsnotify.Write})
+	assertIDs(t, "MentionedBy(aaa-111) after external edit", c.MentionedBy("aaa-111"))
+
+	// An external delete of the target hides it from Mentions.
+	edited.Body = "See aaa-111 again."
+	content, _ = edited.Render()
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c.handleChanges(map[string]fsnotify.Op{path: fsnotify.Write})
+	assertIDs(t, "Mentions(ccc-333)", c.Mentions("ccc-333"), "aaa-111")
+
+	b, _ := c.Get("aaa-111")
+	target := filepath.Join(dataDir, b.Path)
+	if err := os.Remove(target); err != nil {
+		t.Fatal(err)
+	}
+	c.handleChanges(map[string]fsnotify.Op{target: fsnotify.Remove})
+	assertIDs(t, "Mentions(ccc-333) after target removed", c.Mentions("ccc-333"))
+}
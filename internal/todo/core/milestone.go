@@ -28,13 +28,14 @@ func (c *Core) loadMilestonesLocked() error {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") || c.isIgnoredLocked(path, false) {
 			continue
 		}
-		path := filepath.Join(dir, entry.Name())
 		m, loadErr := loadMilestoneFile(path, c.root)
 		if loadErr != nil {
-			return fmt.Errorf("loading milestone %s: %w", path, loadErr)
+			c.addWarningLocked(path, WarnParse, loadErr)
+			continue
 		}
 		c.milestones[m.ID] = m
 	}
@@ -106,11 +107,11 @@ func (c *Core) MilestoneExists(id string) bool {
 
 // CreateMilestone adds a new milestone, generating an ID if needed, and writes it to disk.
 func (c *Core) CreateMilestone(m *issue.Milestone) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	if m.ID == "" {
-		m.ID = issue.NewID()
+		m.ID = c.generateID()
 	}
 	// Ensure a slug so the filename uses the "--" separator; without it,
 	// a hyphenated milestone ID (e.g. "cs3-pmi.md") would be mis-parsed.
@@ -120,7 +121,7 @@ func (c *Core) CreateMilestone(m *issue.Milestone) error {
 			m.Slug = issue.Slugify(m.Name)
 		}
 	}
-	now := time.Now().UTC().Truncate(time.Second)
+	now := c.Now().UTC().Truncate(time.Second)
 	m.CreatedAt = &now
 	m.UpdatedAt = &now
 
@@ -133,13 +134,13 @@ func (c *Core) CreateMilestone(m *issue.Milestone) error {
 
 // UpdateMilestone modifies an existing milestone and writes it to disk.
 func (c *Core) UpdateMilestone(m *issue.Milestone) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	if _, ok := c.milestones[m.ID]; !ok {
 		return ErrMilestoneNotFound
 	}
-	now := time.Now().UTC().Truncate(time.Second)
+	now := c.Now().UTC().Truncate(time.Second)
 	m.UpdatedAt = &now
 
 	if err := c.saveMilestoneToDisk(m); err != nil {
@@ -152,7 +153,7 @@ func (c *Core) UpdateMilestone(m *issue.Milestone) error {
 // SaveMilestoneSyncOnly persists a milestone whose only changes are sync metadata,
 // without bumping updated_at.
 func (c *Core) SaveMilestoneSyncOnly(m *issue.Milestone) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	if _, ok := c.milestones[m.ID]; !ok {
@@ -168,7 +169,7 @@ func (c *Core) SaveMilestoneSyncOnly(m *issue.Milestone) error {
 // DeleteMilestone removes a milestone by ID. It does NOT unassign issues that
 // reference it; callers should handle reference cleanup if desired.
 func (c *Core) DeleteMilestone(id string) error {
-	c.mu.Lock()
+	c.lockForWrite()
 	defer c.mu.Unlock()
 
 	m, ok := c.milestones[id]
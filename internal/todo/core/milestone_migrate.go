@@ -89,13 +89,13 @@ func (c *Core) MigrateMilestoneTypeIssues(dryRun bool) ([]MilestoneMigration, er
 			}
 			child.Milestone = m.ID
 			child.Parent = ""
-			if err := c.Update(child, nil); err != nil {
+			if err := c.update(child, nil, GetOptions{IncludeArchived: true}); err != nil {
 				return migrations, err
 			}
 		}
 
 		// Remove the old milestone-type issue.
-		if err := c.Delete(old.ID); err != nil {
+		if err := c.remove(old.ID, GetOptions{IncludeArchived: true}); err != nil {
 			return migrations, err
 		}
 
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// NextOptions narrow the issues Next picks from.
+type NextOptions struct {
+	// Types keeps issues of these types. Empty means any type.
+	Types []string
+	// Tags keeps issues with at least one of these tags. Empty means any.
+	Tags []string
+	// Count caps how many issues are returned. Zero or less means all.
+	Count int
+}
+
+// NextIssue is an issue Next picked, with what decided its rank.
+type NextIssue struct {
+	Issue *issue.Issue
+	// Priority is the effective priority: the issue's own, or the highest
+	// of the open issues it blocks, directly or through others, if that is
+	// more urgent.
+	Priority string
+	// PriorityFrom is the blocked issue Priority was raised to match, or
+	// "" when it is the issue's own.
+	PriorityFrom string
+	// Unblocks counts the open issues this one directly blocks.
+	Unblocks int
+	// Reason explains the rank, e.g. "critical priority, due in 2 days,
+	// unblocks 3 issues".
+	Reason string
+}
+
+// Next returns the issues to work on next, best first. An issue qualifies
+// when its status is one of the config's next statuses (see
+// config.GetNextStatuses), no open issue blocks it, and no open issue
+// blocks any of its ancestors. They are ranked by effective priority, then
+// due date (issues without one last), then age (oldest first), then ID, so
+// the same tree always gives the same order.
+func (c *Core) Next(opts NextOptions) []NextIssue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cfg := c.config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	priorities := cfg.PriorityNames()
+	rank := func(priority string) int {
+		if i := slices.Index(priorities, cmp.Or(priority, config.PriorityNormal)); i >= 0 {
+			return i
+		}
+		return len(priorities)
+	}
+	statuses := cfg.GetNextStatuses()
+
+	var picked []NextIssue
+	for _, b := range c.issues {
+		if !slices.Contains(statuses, b.Status) {
+			continue
+		}
+		if len(opts.Types) > 0 && !slices.Contains(opts.Types, b.Type) {
+			continue
+		}
+		if len(opts.Tags) > 0 && !slices.ContainsFunc(opts.Tags, b.HasTag) {
+			continue
+		}
+		if c.blockedOrUnderBlockedLocked(b) {
+			continue
+		}
+		n := NextIssue{
+			Issue:    b,
+			Priority: cmp.Or(b.Priority, config.PriorityNormal),
+			Unblocks: len(c.activeDependentsLocked(b.ID)),
+		}
+		for _, blocked := range c.transitiveDependentsLocked(b.ID) {
+			if blocked.Priority != "" && rank(blocked.Priority) < rank(n.Priority) {
+				n.Priority, n.PriorityFrom = blocked.Priority, blocked.ID
+			}
+		}
+		picked = append(picked, n)
+	}
+
+	slices.SortFunc(picked, func(x, y NextIssue) int {
+		if d := cmp.Compare(rank(x.Priority), rank(y.Priority)); d != 0 {
+			return d
+		}
+		if d := compareDue(x.Issue.Due, y.Issue.Due); d != 0 {
+			return d
+		}
+		if d := compareCreated(x.Issue.CreatedAt, y.Issue.CreatedAt); d != 0 {
+			return d
+		}
+		return cmp.Compare(x.Issue.ID, y.Issue.ID)
+	})
+	if opts.Count > 0 && len(picked) > opts.Count {
+		picked = picked[:opts.Count]
+	}
+
+	now := c.Now()
+	for i := range picked {
+		picked[i].Reason = nextReason(picked[i], now)
+	}
+	return picked
+}
+
+// blockedOrUnderBlockedLocked reports whether an open issue blocks b or any
+// of its ancestors.
+// Must be called with c.mu held.
+func (c *Core) blockedOrUnderBlockedLocked(b *issue.Issue) bool {
+	seen := map[string]bool{}
+	for cur := b; cur != nil && !seen[cur.ID]; cur = c.issues[cur.Parent] {
+		seen[cur.ID] = true
+		if len(c.findActiveBlockersLocked(cur.ID)) > 0 {
+			return true
+		}
+		if cur.Parent == "" {
+			break
+		}
+	}
+	return false
+}
+
+// activeDependentsLocked returns the open issues the issue with the given ID
+// directly blocks, through its blocking list or their blocked_by lists,
+// sorted by ID.
+// Must be called with c.mu held.
+func (c *Core) activeDependentsLocked(id string) []*issue.Issue {
+	b, ok := c.issues[id]
+	if !ok || isResolvedStatus(b.Status) {
+		return nil
+	}
+	seen := map[string]bool{}
+	var deps []*issue.Issue
+	add := func(dep *issue.Issue) {
+		if dep != nil && !seen[dep.ID] && !isResolvedStatus(dep.Status) {
+			seen[dep.ID] = true
+			deps = append(deps, dep)
+		}
+	}
+	for _, target := range b.Blocking {
+		add(c.issues[target])
+	}
+	for _, dep := range c.refsLocked(c.dependents, id) {
+		add(dep)
+	}
+	slices.SortFunc(deps, func(x, y *issue.Issue) int { return cmp.Compare(x.ID, y.ID) })
+	return deps
+}
+
+// transitiveDependentsLocked returns every open issue waiting on the issue
+// with the given ID, directly or through a chain of blocking links, in
+// breadth-first order.
+// Must be called with c.mu held.
+func (c *Core) transitiveDependentsLocked(id string) []*issue.Issue {
+	seen := map[string]bool{id: true}
+	var all []*issue.Issue
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, dep := range c.activeDependentsLocked(cur) {
+			if seen[dep.ID] {
+				continue
+			}
+			seen[dep.ID] = true
+			all = append(all, dep)
+			queue = append(queue, dep.ID)
+		}
+	}
+	return all
+}
+
+// compareDue orders due dates earliest first, with issues that have none
+// after those that do.
+func compareDue(a, b *issue.DueDate) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Deadline().Compare(b.Deadline())
+}
+
+// compareCreated orders creation times oldest first, with issues that have
+// none last.
+func compareCreated(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
+
+// nextReason describes what ranked n where it is.
+func nextReason(n NextIssue, now time.Time) string {
+	parts := []string{n.Priority + " priority"}
+	if n.PriorityFrom != "" {
+		parts[0] += " (blocks " + n.PriorityFrom + ")"
+	}
+	if n.Issue.Due != nil {
+		parts = append(parts, dueReason(*n.Issue.Due, now))
+	}
+	if n.Unblocks > 0 {
+		parts = append(parts, fmt.Sprintf("unblocks %d %s", n.Unblocks, plural(n.Unblocks, "issue", "issues")))
+	}
+	if n.Issue.CreatedAt != nil {
+		if age := now.Sub(*n.Issue.CreatedAt); age >= config.Day {
+			parts = append(parts, "waiting "+config.FormatAge(age))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dueReason describes a due date relative to now in calendar days.
+func dueReason(due issue.DueDate, now time.Time) string {
+	if due.HasTime && due.Before(now) {
+		return "overdue"
+	}
+	y, m, d := due.Date()
+	dueDay := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = now.In(due.Location()).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	switch days := int(dueDay.Sub(today) / config.Day); {
+	case days < 0:
+		return fmt.Sprintf("overdue by %d %s", -days, plural(-days, "day", "days"))
+	case days == 0:
+		return "due today"
+	case days == 1:
+		return "due tomorrow"
+	default:
+		return fmt.Sprintf("due in %d days", days)
+	}
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
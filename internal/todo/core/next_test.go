@@ -0,0 +1,152 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// setupNextFixture builds an issue graph covering every rule Next ranks or
+// excludes by. Issues are created a day apart in the order listed, and the
+// clock is left ten days after the first.
+func setupNextFixture(t *testing.T, opts ...func(*config.Config)) *Core {
+	t.Helper()
+	c, _ := setupTestCore(t, opts...)
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 10)
+
+	fixture := []*issue.Issue{
+		{ID: "c-high-old", Title: "High, oldest", Status: "ready", Type: "task", Priority: "high"},
+		{ID: "a-crit", Title: "Critical", Status: "ready", Type: "bug", Priority: "critical", Tags: []string{"backend"}},
+		{ID: "b-high-due", Title: "High, due soon", Status: "ready", Type: "task", Priority: "high", Due: issue.NewDueDate(now.AddDate(0, 0, 2))},
+		{ID: "d-high-new", Title: "High, newer", Status: "ready", Type: "feature", Priority: "high"},
+		{ID: "g-normal", Title: "No priority", Status: "ready", Type: "task"},
+		{ID: "e-low-unblocks", Title: "Low, blocks critical", Status: "ready", Type: "task", Priority: "low", Blocking: []string{"f-crit-blocked", "o-blocked"}},
+		{ID: "f-crit-blocked", Title: "Critical but blocked", Status: "ready", Type: "bug", Priority: "critical"},
+		{ID: "o-blocked", Title: "Blocked by e", Status: "draft", Type: "task"},
+		{ID: "h-working", Title: "Already in progress", Status: "in-progress", Type: "task", Priority: "critical"},
+		{ID: "j-blocker", Title: "Blocks the epic", Status: "in-progress", Type: "task", Blocking: []string{"i-epic"}},
+		{ID: "i-epic", Title: "Blocked epic", Status: "ready", Type: "epic"},
+		{ID: "k-child", Title: "Child of blocked epic", Status: "ready", Type: "task", Priority: "critical", Parent: "i-epic"},
+		{ID: "m-done", Title: "Finished blocker", Status: "completed", Type: "task"},
+		{ID: "n-unblocked", Title: "Blocker finished", Status: "ready", Type: "task", BlockedBy: []string{"m-done"}, Tags: []string{"backend"}},
+		{ID: "l-deferred", Title: "Deferred", Status: "ready", Type: "task", Priority: "deferred"},
+	}
+	for i, b := range fixture {
+		created := start.AddDate(0, 0, i)
+		c.SetClock(func() time.Time { return created })
+		createTestIssues(t, c, b)
+	}
+	c.SetClock(func() time.Time { return now })
+	return c
+}
+
+func nextIDs(picked []NextIssue) []string {
+	ids := make([]string, len(picked))
+	for i, n := range picked {
+		ids[i] = n.Issue.ID
+	}
+	return ids
+}
+
+func TestNextOrder(t *testing.T) {
+	c := setupNextFixture(t)
+
+	want := []string{"a-crit", "e-low-unblocks", "b-high-due", "c-high-old", "d-high-new", "g-normal", "n-unblocked", "l-deferred"}
+	for run := range 5 {
+		if got := nextIDs(c.Next(NextOptions{})); !slices.Equal(got, want) {
+			t.Fatalf("run %d: Next() = %v, want %v", run, got, want)
+		}
+	}
+}
+
+func TestNextReason(t *testing.T) {
+	c := setupNextFixture(t)
+	byID := map[string]NextIssue{}
+	for _, n := range c.Next(NextOptions{}) {
+		byID[n.Issue.ID] = n
+	}
+
+	tests := []struct {
+		id           string
+		priority     string
+		priorityFrom string
+		unblocks     int
+		reason       string
+	}{
+		{"a-crit", "critical", "", 0, "critical priority, waiting 9d"},
+		{"e-low-unblocks", "critical", "f-crit-blocked", 2, "critical priority (blocks f-crit-blocked), unblocks 2 issues, waiting 5d"},
+		{"b-high-due", "high", "", 0, "high priority, due in 2 days, waiting 8d"},
+		{"g-normal", "normal", "", 0, "normal priority, waiting 6d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			n, ok := byID[tt.id]
+			if !ok {
+				t.Fatalf("%s not picked", tt.id)
+			}
+			if n.Priority != tt.priority || n.PriorityFrom != tt.priorityFrom || n.Unblocks != tt.unblocks {
+				t.Errorf("priority %q from %q, unblocks %d; want %q from %q, unblocks %d",
+					n.Priority, n.PriorityFrom, n.Unblocks, tt.priority, tt.priorityFrom, tt.unblocks)
+			}
+			if n.Reason != tt.reason {
+				t.Errorf("Reason = %q, want %q", n.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestNextOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  func(*config.Config)
+		opts NextOptions
+		want []string
+	}{
+		{"count", nil, NextOptions{Count: 3}, []string{"a-crit", "e-low-unblocks", "b-high-due"}},
+		{"types", nil, NextOptions{Types: []string{"bug", "feature"}}, []string{"a-crit", "d-high-new"}},
+		{"tags", nil, NextOptions{Tags: []string{"backend"}}, []string{"a-crit", "n-unblocked"}},
+		{
+			"next statuses",
+			func(cfg *config.Config) { cfg.NextStatuses = []string{"in-progress"} },
+			NextOptions{},
+			[]string{"h-working", "j-blocker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []func(*config.Config)
+			if tt.cfg != nil {
+				opts = append(opts, tt.cfg)
+			}
+			c := setupNextFixture(t, opts...)
+			if got := nextIDs(c.Next(tt.opts)); !slices.Equal(got, tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDueReason(t *testing.T) {
+	now := time.Date(2026, 5, 11, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		due  *issue.DueDate
+		want string
+	}{
+		{issue.NewDueDate(now), "due today"},
+		{issue.NewDueDate(now.AddDate(0, 0, 1)), "due tomorrow"},
+		{issue.NewDueDate(now.AddDate(0, 0, 9)), "due in 9 days"},
+		{issue.NewDueDate(now.AddDate(0, 0, -1)), "overdue by 1 day"},
+		{issue.NewDueDate(now.AddDate(0, 0, -3)), "overdue by 3 days"},
+		{issue.NewDueDateTime(now.Add(-time.Hour)), "overdue"},
+		{issue.NewDueDateTime(now.Add(time.Hour)), "due today"},
+	}
+	for _, tt := range tests {
+		if got := dueReason(*tt.due, now); got != tt.want {
+			t.Errorf("dueReason(%s) = %q, want %q", tt.due, got, tt.want)
+		}
+	}
+}
@@ -7,24 +7,11 @@ import (
 	"github.com/toba/jig/internal/todo/issue"
 )
 
-// findChildrenLocked returns all issues whose Parent matches parentID.
+// findChildrenLocked returns all issues whose Parent matches parentID, sorted
+// by ID.
 // Must be called with c.mu held.
 func (c *Core) findChildrenLocked(parentID string) []*issue.Issue {
-	var children []*issue.Issue
-	for _, b := range c.issues {
-		if b.Parent == parentID {
-			children = append(children, b)
-		}
-	}
-	return children
-}
-
-// Children returns all issues whose Parent matches parentID. It is safe to call
-// without holding c.mu.
-func (c *Core) Children(parentID string) []*issue.Issue {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return c.findChildrenLocked(parentID)
+	return c.refsLocked(c.children, parentID)
 }
 
 // computeParentStatus determines what the parent's status should be based on
@@ -139,7 +126,7 @@ func (c *Core) propagateStatusLocked(issueID string, visited map[string]bool) {
 	}
 
 	parent.Status = newStatus
-	now := time.Now().UTC().Truncate(time.Second)
+	now := c.Now().UTC().Truncate(time.Second)
 	parent.UpdatedAt = &now
 
 	// Persist to disk (best-effort — don't fail the original update)
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// NotFoundError is returned by Lookup for an ID that no issue has. It
+// matches ErrNotFound with errors.Is. Suggestion is the nearest known ID when
+// one is a typo away.
+type NotFoundError struct {
+	ID         string
+	Suggestion string
+}
+
+func (e *NotFoundError) Error() string {
+	msg := "issue not found: " + e.ID
+	if e.Suggestion != "" {
+		msg += "; did you mean " + e.Suggestion + "?"
+	}
+	return msg
+}
+
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// Lookup finds an issue by exact ID like Get, but reports a missing one as a
+// *NotFoundError naming the nearest known ID.
+func (c *Core) Lookup(id string) (*issue.Issue, error) {
+	return c.LookupWith(id, GetOptions{IncludeArchived: true})
+}
+
+// LookupWith is Lookup as opts allow: an archived issue opts leave out is
+// reported as an *ArchivedError rather than as not found.
+func (c *Core) LookupWith(id string, opts GetOptions) (*issue.Issue, error) {
+	b, err := c.GetWith(id, opts)
+	if errors.Is(err, ErrNotFound) {
+		return nil, c.NotFound(id)
+	}
+	return b, err
+}
+
+// NotFound returns the error for an issue reference that matched nothing.
+func (c *Core) NotFound(id string) *NotFoundError {
+	return &NotFoundError{ID: id, Suggestion: c.SuggestID(id)}
+}
+
+// SuggestID returns the known ID one edit away from id (a wrong, missing,
+// extra, or swapped character), or "" when there is none. IDs are short and
+// random, so anything further off would be noise. Only IDs within one
+// character of id's length are compared, which keeps this cheap for
+// thousands of issues.
+func (c *Core) SuggestID(id string) string {
+	c.mu.RLock()
+	var near []string
+	for known := range c.issues {
+		if diff := len(known) - len(id); diff >= -1 && diff <= 1 {
+			near = append(near, known)
+		}
+	}
+	c.mu.RUnlock()
+
+	// Ties go to the first candidate, so order them.
+	slices.Sort(near)
+	return config.SuggestWithin(id, near, 1)
+}
+
+// AmbiguousError is returned by Resolve when a query matches more than one
+// issue. Candidates are sorted by ID so the listing is stable.
+type AmbiguousError struct {
+	Query      string
+	Candidates []*issue.Issue
+}
+
+func (e *AmbiguousError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%q matches %d issues; use one of these IDs:", e.Query, len(e.Candidates))
+	for _, b := range e.Candidates {
+		fmt.Fprintf(&sb, "\n  %s  %s", b.ID, b.Title)
+	}
+	return sb.String()
+}
+
+// Resolve finds an issue by a human-friendly reference. Resolution order:
+//  1. exact ID
+//  2. exact slug
+//  3. case-insensitive title substring
+//
+// Each stage only applies when the previous one found nothing. A stage that
+// matches more than one issue returns an *AmbiguousError rather than picking
+// one; ErrNotFound is returned when nothing matches at all.
+func (c *Core) Resolve(query string) (*issue.Issue, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if b, ok := c.issues[query]; ok {
+		return b, nil
+	}
+
+	q := strings.TrimSpace(query)
+	if q == "" {
+		return nil, ErrNotFound
+	}
+
+	var slugMatches []*issue.Issue
+	for _, b := range c.issues {
+		if b.Slug != "" && b.Slug == q {
+			slugMatches = append(slugMatches, b)
+		}
+	}
+	if b, err := pickUnique(query, slugMatches); b != nil || err != nil {
+		return b, err
+	}
+
+	lower := strings.ToLower(q)
+	var titleMatches []*issue.Issue
+	for _, b := range c.issues {
+		if strings.Contains(strings.ToLower(b.Title), lower) {
+			titleMatches = append(titleMatches, b)
+		}
+	}
+	if b, err := pickUnique(query, titleMatches); b != nil || err != nil {
+		return b, err
+	}
+
+	return nil, ErrNotFound
+}
+
+// pickUnique returns the single match, an *AmbiguousError for several, or
+// (nil, nil) when there are none so the caller can try the next stage.
+func pickUnique(query string, matches []*issue.Issue) (*issue.Issue, error) {
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	default:
+		slices.SortFunc(matches, func(a, b *issue.Issue) int { return strings.Compare(a.ID, b.ID) })
+		return nil, &AmbiguousError{Query: query, Candidates: matches}
+	}
+}
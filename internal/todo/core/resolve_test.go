@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestResolve(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssue(t, core, "k3f-9da", "Login flow", "ready")
+	createTestIssue(t, core, "a1b-2c3", "Logout button", "ready")
+	createTestIssue(t, core, "x9y-8z7", "Fix login redirect", "ready")
+	// Slug that looks like a truncated ID of another issue.
+	createTestIssues(t, core, &issue.Issue{ID: "p0q-r1s", Slug: "k3f", Title: "Keyboard shortcuts", Status: "ready"})
+
+	tests := []struct {
+		name   string
+		query  string
+		wantID string
+	}{
+		{"exact id", "k3f-9da", "k3f-9da"},
+		{"exact slug", "login-flow", "k3f-9da"},
+		{"slug resembling partial id", "k3f", "p0q-r1s"},
+		{"unique title substring", "logout", "a1b-2c3"},
+		{"title substring is case-insensitive", "REDIRECT", "x9y-8z7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := core.Resolve(tt.query)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error = %v", tt.query, err)
+			}
+			if b.ID != tt.wantID {
+				t.Errorf("Resolve(%q) = %s, want %s", tt.query, b.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestResolveAmbiguous(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssue(t, core, "bbb-111", "Login flow", "ready")
+	createTestIssue(t, core, "aaa-222", "Fix login redirect", "ready")
+	createTestIssue(t, core, "ccc-333", "Unrelated", "ready")
+
+	_, err := core.Resolve("login")
+	amb, ok := errors.AsType[*AmbiguousError](err)
+	if !ok {
+		t.Fatalf("Resolve() error = %v, want *AmbiguousError", err)
+	}
+	if len(amb.Candidates) != 2 {
+		t.Fatalf("got %d candidates, want 2", len(amb.Candidates))
+	}
+	if amb.Candidates[0].ID != "aaa-222" || amb.Candidates[1].ID != "bbb-111" {
+		t.Errorf("candidates not sorted by ID: %s, %s", amb.Candidates[0].ID, amb.Candidates[1].ID)
+	}
+	msg := err.Error()
+	for _, want := range []string{"aaa-222", "bbb-111", "Login flow", "Fix login redirect"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error message missing %q: %s", want, msg)
+		}
+	}
+}
+
+func TestResolveAmbiguousSlug(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssues(t, core,
+		&issue.Issue{ID: "aaa-111", Slug: "dup", Title: "One", Status: "ready"},
+		&issue.Issue{ID: "bbb-222", Slug: "dup", Title: "Two", Status: "ready"},
+	)
+
+	_, err := core.Resolve("dup")
+	if _, ok := errors.AsType[*AmbiguousError](err); !ok {
+		t.Fatalf("Resolve() error = %v, want *AmbiguousError", err)
+	}
+}
+
+func TestResolveSlugBeatsTitleSubstring(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssue(t, core, "aaa-111", "Search", "ready")
+	createTestIssue(t, core, "bbb-222", "Search index rebuild", "ready")
+
+	b, err := core.Resolve("search")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if b.ID != "aaa-111" {
+		t.Errorf("Resolve() = %s, want exact slug match aaa-111", b.ID)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssue(t, core, "aaa-111", "Something", "ready")
+
+	for _, q := range []string{"nothing-here", "", "   "} {
+		if _, err := core.Resolve(q); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Resolve(%q) error = %v, want ErrNotFound", q, err)
+		}
+	}
+}
+
+func TestLookupSuggestsNearestID(t *testing.T) {
+	core, _ := setupTestCore(t)
+	createTestIssue(t, core, "k3f-9da", "Login flow", "ready")
+	createTestIssue(t, core, "a1b-2c3", "Logout button", "ready")
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"k3f-9db", "k3f-9da"},  // wrong character
+		{"k3f9da", "k3f-9da"},   // missing character
+		{"k3f-9dda", "k3f-9da"}, // extra character
+		{"k3f-d9a", "k3f-9da"},  // swapped characters
+		{"K3F-9DB", "k3f-9da"},  // case is ignored
+		{"k3f-000", ""},         // nothing close
+		{"zzz", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := core.Lookup(tt.query)
+			nf, ok := errors.AsType[*NotFoundError](err)
+			if !ok || !errors.Is(err, ErrNotFound) {
+				t.Fatalf("Lookup(%q) error = %v, want a NotFoundError matching ErrNotFound", tt.query, err)
+			}
+			if nf.Suggestion != tt.want {
+				t.Errorf("Suggestion = %q, want %q", nf.Suggestion, tt.want)
+			}
+			hint := strings.Contains(err.Error(), "did you mean")
+			if hint != (tt.want != "") {
+				t.Errorf("Error() = %q", err.Error())
+			}
+		})
+	}
+
+	if b, err := core.Lookup("a1b-2c3"); err != nil || b.ID != "a1b-2c3" {
+		t.Errorf("Lookup(a1b-2c3) = %v, %v", b, err)
+	}
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// RevisionsDir is the directory under the data directory holding previous
+// issue bodies, one subdirectory per issue ID. Like every hidden path it is
+// skipped by Load and the watcher.
+const RevisionsDir = ".revisions"
+
+// RevisionTimeFormat is the layout of a revision's timestamp, which names
+// its file and sorts chronologically.
+const RevisionTimeFormat = "20060102T150405.000Z"
+
+const revisionExt = ".md.gz"
+
+// ErrRevisionNotFound is returned for a timestamp with no stored revision.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Revision describes a stored previous body.
+type Revision struct {
+	// Timestamp is when the body was replaced, in RevisionTimeFormat. It
+	// names the revision for RevisionBody.
+	Timestamp string    `json:"timestamp"`
+	CreatedAt time.Time `json:"created_at"`
+	// Size is the body's length in bytes, uncompressed.
+	Size int `json:"size"`
+}
+
+// saveRevisionLocked stores the body an update of b is about to replace,
+// when keep_body_revisions is set and the body changed, then prunes the
+// oldest revisions beyond the limit. Encrypted issues are skipped so their
+// bodies are never written out in plaintext.
+// Must be called with c.mu held.
+func (c *Core) saveRevisionLocked(b *issue.Issue) error {
+	keep := 0
+	if c.config != nil {
+		keep = c.config.KeepBodyRevisions
+	}
+	if keep <= 0 || b.Path == "" || b.Encrypted {
+		return nil
+	}
+	disk, err := c.loadIssue(filepath.Join(c.root, b.Path))
+	if err != nil || disk.Encrypted || strings.TrimSpace(disk.Body) == strings.TrimSpace(b.Body) {
+		return nil
+	}
+
+	dir := c.revisionDir(b.ID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	// Two edits in the same millisecond get consecutive timestamps.
+	at := c.Now().UTC()
+	path := filepath.Join(dir, at.Format(RevisionTimeFormat)+revisionExt)
+	for fileExists(path) {
+		at = at.Add(time.Millisecond)
+		path = filepath.Join(dir, at.Format(RevisionTimeFormat)+revisionExt)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(disk.Body)); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil { //nolint:gosec // issue data is not secret
+		return err
+	}
+	return c.pruneRevisions(b.ID, keep)
+}
+
+// pruneRevisions removes all but the newest keep revisions of id.
+func (c *Core) pruneRevisions(id string, keep int) error {
+	names, err := c.revisionNames(id)
+	if err != nil {
+		return err
+	}
+	for _, name := range names[:max(0, len(names)-keep)] {
+		if err := os.Remove(filepath.Join(c.revisionDir(id), name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Revisions lists the stored previous bodies of issue id, newest first.
+func (c *Core) Revisions(id string) ([]Revision, error) {
+	names, err := c.revisionNames(id)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]Revision, 0, len(names))
+	for _, name := range slices.Backward(names) {
+		stamp := strings.TrimSuffix(name, revisionExt)
+		at, _ := time.Parse(RevisionTimeFormat, stamp)
+		body, err := readRevision(filepath.Join(c.revisionDir(id), name))
+		if err != nil {
+			return nil, fmt.Errorf("revision %s of %s: %w", stamp, id, err)
+		}
+		revisions = append(revisions, Revision{Timestamp: stamp, CreatedAt: at, Size: len(body)})
+	}
+	return revisions, nil
+}
+
+// RevisionBody returns the body of issue id stored at timestamp (as listed by
+// Revisions).
+func (c *Core) RevisionBody(id, timestamp string) (string, error) {
+	if _, err := time.Parse(RevisionTimeFormat, timestamp); err != nil {
+		return "", fmt.Errorf("%w: %s (timestamps look like %s)", ErrRevisionNotFound, timestamp, RevisionTimeFormat)
+	}
+	body, err := readRevision(filepath.Join(c.revisionDir(id), timestamp+revisionExt))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s has no revision %s", ErrRevisionNotFound, id, timestamp)
+	}
+	return body, err
+}
+
+// revisionNames returns the revision file names of id, oldest first.
+func (c *Core) revisionNames(id string) ([]string, error) {
+	entries, err := os.ReadDir(c.revisionDir(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		stamp, ok := strings.CutSuffix(e.Name(), revisionExt)
+		if !ok || e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(RevisionTimeFormat, stamp); err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (c *Core) revisionDir(id string) string {
+	return filepath.Join(c.root, RevisionsDir, id)
+}
+
+func readRevision(path string) (string, error) {
+	f, err := os.Open(path) //nolint:gosec // path from known directory
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+func TestBodyRevisions(t *testing.T) {
+	core, dataDir := setupTestCore(t, func(cfg *config.Config) { cfg.KeepBodyRevisions = 2 })
+	now := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+	core.SetClock(func() time.Time { return now })
+
+	b := createTestIssue(t, core, "rev-1", "Revised", "ready")
+	for _, body := range []string{"first", "second", "third", "fourth"} {
+		b.Body = body
+		if err := core.Update(b, nil); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		now = now.Add(time.Minute)
+	}
+
+	// Metadata-only updates keep no revision.
+	b.Status = "in-progress"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	revisions, err := core.Revisions("rev-1")
+	if err != nil {
+		t.Fatalf("Revisions() error = %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("Revisions() = %d, want the 2 newest kept", len(revisions))
+	}
+	if want := "20250611T100300.000Z"; revisions[0].Timestamp != want {
+		t.Errorf("newest timestamp = %s, want %s", revisions[0].Timestamp, want)
+	}
+	for i, want := range []string{"third", "second"} {
+		body, err := core.RevisionBody("rev-1", revisions[i].Timestamp)
+		if err != nil {
+			t.Fatalf("RevisionBody(%s) error = %v", revisions[i].Timestamp, err)
+		}
+		if strings.TrimSpace(body) != want {
+			t.Errorf("revision %d body = %q, want %q", i, body, want)
+		}
+		if revisions[i].Size != len(body) {
+			t.Errorf("revision %d size = %d, want %d", i, revisions[i].Size, len(body))
+		}
+	}
+
+	if _, err := core.RevisionBody("rev-1", "20250611T100000.000Z"); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("RevisionBody(pruned) error = %v, want ErrRevisionNotFound", err)
+	}
+	if _, err := core.RevisionBody("rev-1", "../../rev-1"); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("RevisionBody(bad timestamp) error = %v, want ErrRevisionNotFound", err)
+	}
+
+	// Revision files are not issues.
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(core.All()); n != 1 {
+		t.Errorf("Load() found %d issues, want 1", n)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, RevisionsDir, "rev-1")); err != nil {
+		t.Errorf("revision directory: %v", err)
+	}
+}
+
+func TestBodyRevisionsOff(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	b := createTestIssue(t, core, "rev-2", "Unrevised", "ready")
+	b.Body = "changed"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, RevisionsDir)); !os.IsNotExist(err) {
+		t.Errorf("revisions written without keep_body_revisions: %v", err)
+	}
+	if revisions, err := core.Revisions("rev-2"); err != nil || len(revisions) != 0 {
+		t.Errorf("Revisions() = %v, %v; want none", revisions, err)
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// RuleError is returned when an issue's status requires fields, by a
+// validation_rules entry, that the issue does not have set.
+type RuleError struct {
+	IssueID string // "" for an issue being created
+	Missing []string
+	Rule    config.ValidationRule
+}
+
+func (e *RuleError) Error() string {
+	subject := "issue"
+	if e.IssueID != "" {
+		subject = e.IssueID
+	}
+	return fmt.Sprintf("%s is missing %s, required for status %s by validation rule {%s}",
+		subject, strings.Join(e.Missing, ", "), e.Rule.WhenStatus, e.Rule)
+}
+
+// Reason is the short form of the error, for inline display.
+func (e *RuleError) Reason() string {
+	return "missing " + strings.Join(e.Missing, ", ")
+}
+
+// CheckRules returns the validation rule b would break in status, or nil.
+// It lets callers check a status change before making it.
+func (c *Core) CheckRules(b *issue.Issue, status string) *RuleError {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ruleErrorLocked(b, status, nil)
+}
+
+// checkRulesLocked returns a *RuleError for the first validation rule b's
+// status breaks. On update, fields the saved file is already missing in the
+// same status are let through, so issues from before a rule was added stay
+// editable; entering the status, or clearing a required field, is checked.
+// Must be called with c.mu held.
+func (c *Core) checkRulesLocked(b *issue.Issue) error {
+	var exempt func(string) bool
+	if b.Path != "" {
+		if saved, err := c.loadIssue(filepath.Join(c.root, b.Path)); err == nil && saved.Status == b.Status {
+			exempt = func(field string) bool { return !ruleFieldSet(saved, field) }
+		}
+	}
+	if err := c.ruleErrorLocked(b, b.Status, exempt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ruleErrorLocked checks b against the rules for status, skipping fields
+// exempt reports true for.
+// Must be called with c.mu held.
+func (c *Core) ruleErrorLocked(b *issue.Issue, status string, exempt func(string) bool) *RuleError {
+	if c.config == nil {
+		return nil
+	}
+	for _, rule := range c.config.RulesFor(status) {
+		var missing []string
+		for _, field := range rule.Require {
+			if !ruleFieldSet(b, field) && (exempt == nil || !exempt(field)) {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			return &RuleError{IssueID: b.ID, Missing: missing, Rule: rule}
+		}
+	}
+	return nil
+}
+
+// ruleFieldSet reports whether b has field (one of config.RuleFields) set.
+func ruleFieldSet(b *issue.Issue, field string) bool {
+	switch field {
+	case "summary":
+		return b.Summary != ""
+	case "type":
+		return b.Type != ""
+	case "priority":
+		return b.Priority != ""
+	case "milestone":
+		return b.Milestone != ""
+	case "iteration":
+		return b.Iteration != ""
+	case "tags":
+		return len(b.Tags) > 0
+	case "due":
+		return b.Due != nil
+	case "parent":
+		return b.Parent != ""
+	case "blocking":
+		return len(b.Blocking) > 0
+	case "blocked_by":
+		return len(b.BlockedBy) > 0
+	case "body":
+		return strings.TrimSpace(b.Body) != ""
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func withRules(rules ...config.ValidationRule) func(*config.Config) {
+	return func(cfg *config.Config) {
+		cfg.ExtraStatuses = map[string]bool{"in-progress": true, "review": true}
+		cfg.ValidationRules = rules
+	}
+}
+
+var testRules = []config.ValidationRule{
+	{WhenStatus: "in-progress", Require: []string{"priority"}},
+	{WhenStatus: "completed", Require: []string{"body", "due"}},
+	{WhenStatus: "completed", Require: []string{"tags"}},
+}
+
+func TestCreateValidationRules(t *testing.T) {
+	due, _ := issue.ParseDueDate("2026-05-01")
+	tests := []struct {
+		name     string
+		b        issue.Issue
+		wantRule int // index into testRules, or -1 for no error
+		missing  []string
+	}{
+		{"status without rules", issue.Issue{Status: "ready"}, -1, nil},
+		{"in-progress without priority", issue.Issue{Status: "in-progress"}, 0, []string{"priority"}},
+		{"in-progress with priority", issue.Issue{Status: "in-progress", Priority: "high"}, -1, nil},
+		{"completed missing both", issue.Issue{Status: "completed"}, 1, []string{"body", "due"}},
+		{"completed whitespace body", issue.Issue{Status: "completed", Body: " \n", Due: due}, 1, []string{"body"}},
+		{"completed breaks second rule", issue.Issue{Status: "completed", Body: "Done.", Due: due}, 2, []string{"tags"}},
+		{"completed with all", issue.Issue{Status: "completed", Body: "Done.", Due: due, Tags: []string{"x"}}, -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, _ := setupTestCore(t, withRules(testRules...))
+			b := tt.b
+			b.Title = "Rule test"
+			err := core.Create(&b)
+			if tt.wantRule < 0 {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return
+			}
+			ruleErr, ok := errors.AsType[*RuleError](err)
+			if !ok {
+				t.Fatalf("Create() error = %v, want a *RuleError", err)
+			}
+			if ruleErr.Rule.String() != testRules[tt.wantRule].String() || !slices.Equal(ruleErr.Missing, tt.missing) {
+				t.Errorf("RuleError = %+v, want rule %d missing %v", ruleErr, tt.wantRule, tt.missing)
+			}
+			for _, want := range append([]string{ruleErr.Rule.String()}, tt.missing...) {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not name %q", err, want)
+				}
+			}
+			if got := len(core.All()); got != 0 {
+				t.Errorf("%d issues created, want none", got)
+			}
+		})
+	}
+}
+
+func TestUpdateValidationRules(t *testing.T) {
+	core, dataDir := setupTestCore(t, withRules(testRules...))
+	b := createTestIssue(t, core, "rul-1", "Rule test", "ready")
+
+	// Entering the status is checked.
+	b.Status = "in-progress"
+	if err := core.Update(b, nil); err == nil {
+		t.Fatal("Update() into in-progress without priority succeeded")
+	}
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ = core.Get("rul-1")
+	if b.Status != "ready" {
+		t.Errorf("status on disk = %s after rejected update", b.Status)
+	}
+	b.Status, b.Priority = "in-progress", "high"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatalf("Update() with priority: %v", err)
+	}
+
+	// Clearing a required field while in the status is checked too.
+	b.Priority = ""
+	if err := core.Update(b, nil); err == nil {
+		t.Error("Update() clearing a required field succeeded")
+	}
+
+	// An issue already breaking a rule when it was added stays editable.
+	legacy := "---\ntitle: Old\nstatus: completed\ntype: task\n---\n"
+	if err := os.WriteFile(filepath.Join(dataDir, "old-1--old.md"), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	old, _ := core.Get("old-1")
+	old.Title = "Old, renamed"
+	if err := core.Update(old, nil); err != nil {
+		t.Errorf("Update() of a legacy issue: %v", err)
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	core, _ := setupTestCore(t, withRules(testRules...))
+	b := createTestIssue(t, core, "chk-1", "Check", "ready")
+	if err := core.CheckRules(b, "completed"); err == nil || err.Reason() != "missing body, due" {
+		t.Errorf("CheckRules(completed) = %v, want missing body, due", err)
+	}
+	if err := core.CheckRules(b, "review"); err != nil {
+		t.Errorf("CheckRules(review) = %v, want nil", err)
+	}
+}
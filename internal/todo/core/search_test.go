@@ -2,9 +2,11 @@ package core
 
 import (
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/search"
 )
 
 func TestSearch(t *testing.T) {
@@ -273,6 +275,42 @@ func TestClose_ClosesSearchIndex(t *testing.T) {
 	}
 }
 
+func TestFindWithAndWithoutIndex(t *testing.T) {
+	core, _ := setupTestCore(t)
+	defer core.Close()
+	createTestIssues(t, core,
+		&issue.Issue{ID: "aaa1", Slug: "parser", Title: "Parser rewrite", Status: "ready"},
+		&issue.Issue{ID: "bbb2", Slug: "tags", Title: "Other", Status: "ready", Tags: []string{"reparse"}},
+		&issue.Issue{ID: "ccc3", Slug: "body", Title: "Third", Status: "ready", Body: "The parsing step"},
+		&issue.Issue{ID: "ddd4", Slug: "none", Title: "Unrelated", Status: "ready"},
+	)
+
+	ids := func() []string {
+		t.Helper()
+		hits, err := core.Find("pars", search.Options{})
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		var ids []string
+		for _, h := range hits {
+			ids = append(ids, h.Issue.ID)
+		}
+		return ids
+	}
+	scanned := ids()
+	if want := []string{"aaa1", "bbb2", "ccc3"}; !slices.Equal(scanned, want) {
+		t.Errorf("Find() by scan = %v, want %v", scanned, want)
+	}
+
+	// Once the index is built it narrows the scan to the same result.
+	if _, err := core.Search("parser"); err != nil {
+		t.Fatal(err)
+	}
+	if indexed := ids(); !slices.Equal(indexed, scanned) {
+		t.Errorf("Find() with the index = %v, want %v", indexed, scanned)
+	}
+}
+
 // Helper to write test files
 func writeTestFile(dir, name, content string) error {
 	return os.WriteFile(dir+"/"+name, []byte(content), 0644)
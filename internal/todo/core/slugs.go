@@ -0,0 +1,145 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// StaleSlug is an issue whose filename slug no longer matches its title.
+type StaleSlug struct {
+	IssueID string `json:"issue_id"`
+	Path    string `json:"path"`
+	NewPath string `json:"new_path"`
+}
+
+// StaleSlugs returns the issues whose files would be renamed to follow their
+// titles, ordered by ID.
+func (c *Core) StaleSlugs() []StaleSlug {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var stale []StaleSlug
+	for _, b := range c.issues {
+		if _, path := c.slugTargetLocked(b); path != b.Path {
+			stale = append(stale, StaleSlug{IssueID: b.ID, Path: b.Path, NewPath: path})
+		}
+	}
+	slices.SortFunc(stale, func(a, b StaleSlug) int { return cmp.Compare(a.IssueID, b.IssueID) })
+	return stale
+}
+
+// FixStaleSlugs renames every stale-slug file to follow its title, with
+// git mv when the data directory is inside a git work tree so history
+// follows the file. It returns the renames it made.
+func (c *Core) FixStaleSlugs() ([]StaleSlug, error) {
+	c.lockForWrite()
+	defer c.mu.Unlock()
+
+	useGit := c.inGitWorkTree()
+	ids := make([]string, 0, len(c.issues))
+	for id := range c.issues {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var fixed []StaleSlug
+	for _, id := range ids {
+		b := c.issues[id]
+		slug, path := c.slugTargetLocked(b)
+		if path == b.Path {
+			continue
+		}
+		if err := c.moveFile(b.Path, path, useGit); err != nil {
+			return fixed, err
+		}
+		fixed = append(fixed, StaleSlug{IssueID: b.ID, Path: b.Path, NewPath: path})
+		b.Slug, b.Path = slug, path
+	}
+	return fixed, nil
+}
+
+// renameForTitleLocked moves b's file to a slug of its new title when
+// rename_files_on_title_change is set and the title differs from the one on
+// disk. b.Slug and b.Path are updated to match, so the following write lands
+// in the new file. The returned function undoes the move if that write fails.
+// Must be called with c.mu held.
+func (c *Core) renameForTitleLocked(b *issue.Issue) (undo func(), err error) {
+	undo = func() {}
+	if c.config == nil || !c.config.RenameFilesOnTitleChange || b.Path == "" {
+		return undo, nil
+	}
+	disk, err := c.loadIssue(filepath.Join(c.root, b.Path))
+	if err != nil || disk.Title == b.Title {
+		return undo, nil //nolint:nilerr // an unreadable file keeps its name
+	}
+	slug, path := c.slugTargetLocked(b)
+	if path == b.Path {
+		return undo, nil
+	}
+	oldSlug, oldPath := b.Slug, b.Path
+	if err := c.moveFile(oldPath, path, false); err != nil {
+		return undo, err
+	}
+	b.Slug, b.Path = slug, path
+	return func() {
+		if os.Rename(filepath.Join(c.root, path), filepath.Join(c.root, oldPath)) == nil {
+			b.Slug, b.Path = oldSlug, oldPath
+		}
+	}, nil
+}
+
+// slugTargetLocked returns the slug and relative path b's file should have
+// for its title: in its hash subfolder, or still in the archive when
+// archived. A name taken by another file gets a numeric suffix (-2, -3, ...).
+// An issue whose title has no slug characters keeps its current name.
+// Must be called with c.mu held.
+func (c *Core) slugTargetLocked(b *issue.Issue) (slug, path string) {
+	base := issue.Slugify(b.Title)
+	if base == "" {
+		return b.Slug, b.Path
+	}
+	for n := 1; ; n++ {
+		slug = base
+		if n > 1 {
+			slug = base + "-" + strconv.Itoa(n)
+		}
+		path = issue.BuildPath(b.ID, slug)
+		if c.isArchivedPath(b.Path) {
+			path = filepath.Join(ArchiveDir, issue.BuildFilename(b.ID, slug))
+		}
+		if path == b.Path || !c.fileExists(filepath.Join(c.root, path)) {
+			return slug, path
+		}
+	}
+}
+
+// moveFile renames a file under the data directory, creating the target's
+// directory. With useGit it tries git mv first so the rename is staged, and
+// falls back to a plain rename when git refuses (e.g. an untracked file).
+func (c *Core) moveFile(from, to string, useGit bool) error {
+	oldPath, newPath := filepath.Join(c.root, from), filepath.Join(c.root, to)
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if useGit && exec.Command("git", "-C", c.root, "mv", oldPath, newPath).Run() == nil { //nolint:gosec // paths within the data directory
+		return nil
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("renaming %s: %w", from, err)
+	}
+	return nil
+}
+
+// inGitWorkTree reports whether git is installed and the data directory is
+// inside a git work tree.
+func (c *Core) inGitWorkTree() bool {
+	out, err := exec.Command("git", "-C", c.root, "rev-parse", "--is-inside-work-tree").Output()
+	return err == nil && string(out) == "true\n"
+}
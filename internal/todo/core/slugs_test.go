@@ -0,0 +1,231 @@
+package core
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/toba/jig/internal/todo/config"
+)
+
+func renameOnTitleChange(cfg *config.Config) { cfg.RenameFilesOnTitleChange = true }
+
+func TestUpdateRenamesFileOnTitleChange(t *testing.T) {
+	core, dataDir := setupTestCore(t, renameOnTitleChange)
+	b := createTestIssue(t, core, "ab1-2cd", "Fix login", "ready")
+	oldPath := b.Path
+
+	b.Title = "Rework auth flow"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if want := filepath.Join("a", "ab1-2cd--rework-auth-flow.md"); b.Path != want {
+		t.Errorf("Path = %q, want %q", b.Path, want)
+	}
+	if b.Slug != "rework-auth-flow" {
+		t.Errorf("Slug = %q, want rework-auth-flow", b.Slug)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, oldPath)); !os.IsNotExist(err) {
+		t.Errorf("old file %s still exists (stat err %v)", oldPath, err)
+	}
+
+	// Other updates leave the name alone.
+	b.Status = "in-progress"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := core.Get("ab1-2cd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Path != b.Path || got.Title != "Rework auth flow" || got.Status != "in-progress" {
+		t.Errorf("reloaded issue = %s %q %s, want %s", got.Path, got.Title, got.Status, b.Path)
+	}
+}
+
+func TestUpdateRenameCollisionAndSubfolder(t *testing.T) {
+	core, dataDir := setupTestCore(t, renameOnTitleChange)
+
+	// A legacy file outside its hash subfolder moves into it.
+	legacy := "---\ntitle: Old name\nstatus: ready\ntype: task\n---\n"
+	if err := os.WriteFile(filepath.Join(dataDir, "xy1-2ab--old-name.md"), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	// Something already holds the natural new name.
+	if err := os.MkdirAll(filepath.Join(dataDir, "x"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "x", "xy1-2ab--new-name.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := core.Get("xy1-2ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Title = "New name"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if want := filepath.Join("x", "xy1-2ab--new-name-2.md"); b.Path != want {
+		t.Errorf("Path = %q, want %q", b.Path, want)
+	}
+	if stale := core.StaleSlugs(); len(stale) != 0 {
+		t.Errorf("StaleSlugs() after suffixed rename = %+v, want none", stale)
+	}
+}
+
+func TestUpdateKeepsFileNameByDefault(t *testing.T) {
+	core, _ := setupTestCore(t)
+	b := createTestIssue(t, core, "keep-1", "Fix login", "ready")
+	oldPath := b.Path
+	b.Title = "Rework auth flow"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if b.Path != oldPath {
+		t.Errorf("Path = %q, want %q without rename_files_on_title_change", b.Path, oldPath)
+	}
+}
+
+func TestHandleChangesRenameIsOneUpdate(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	b := createTestIssue(t, core, "ren-1", "Before", "ready")
+	ch, unsub := core.Subscribe()
+	defer unsub()
+	core.watching = true
+
+	// Renamed by another process: the old name goes, the new one appears.
+	oldPath := filepath.Join(dataDir, b.Path)
+	newPath := filepath.Join(dataDir, "r", "ren-1--after.md")
+	if err := os.Rename(oldPath, newPath); err != nil {
+		t.Fatal(err)
+	}
+	core.handleChanges(map[string]fsnotify.Op{oldPath: fsnotify.Rename, newPath: fsnotify.Create})
+
+	events := <-ch
+	if len(events) != 1 || events[0].Type != EventUpdated || events[0].IssueID != "ren-1" {
+		t.Fatalf("events = %+v, want one update of ren-1", events)
+	}
+	if got, err := core.Get("ren-1"); err != nil || got.Path != filepath.Join("r", "ren-1--after.md") {
+		t.Errorf("Get() = %v, %v; want the renamed file", got, err)
+	}
+}
+
+func TestWatchTitleRenameEvent(t *testing.T) {
+	core, _ := setupTestCore(t, renameOnTitleChange)
+	b := createTestIssue(t, core, "wat-1", "Before", "ready")
+	if err := core.StartWatching(); err != nil {
+		t.Fatal(err)
+	}
+	defer core.Unwatch()
+	ch, unsub := core.Subscribe()
+	defer unsub()
+	time.Sleep(50 * time.Millisecond)
+
+	b.Title = "After"
+	if err := core.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []IssueEvent
+	timeout := time.After(4 * debounceDelay)
+	for done := false; !done; {
+		select {
+		case batch := <-ch:
+			events = append(events, batch...)
+		case <-timeout:
+			done = true
+		}
+	}
+	if len(events) != 1 || events[0].Type != EventUpdated || events[0].IssueID != "wat-1" {
+		t.Errorf("events = %+v, want one update of wat-1", events)
+	}
+}
+
+func TestFixStaleSlugs(t *testing.T) {
+	write := func(t *testing.T, dataDir string) {
+		t.Helper()
+		content := "---\ntitle: Rework auth flow\nstatus: ready\ntype: task\n---\n"
+		if err := os.MkdirAll(filepath.Join(dataDir, "a"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, "a", "ab1-2cd--fix-login.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func(t *testing.T, core *Core, dataDir string) {
+		t.Helper()
+		want := filepath.Join("a", "ab1-2cd--rework-auth-flow.md")
+		stale := core.StaleSlugs()
+		if len(stale) != 1 || stale[0].NewPath != want {
+			t.Fatalf("StaleSlugs() = %+v, want ab1-2cd -> %s", stale, want)
+		}
+		fixed, err := core.FixStaleSlugs()
+		if err != nil {
+			t.Fatalf("FixStaleSlugs() error = %v", err)
+		}
+		if len(fixed) != 1 {
+			t.Fatalf("FixStaleSlugs() = %+v, want one rename", fixed)
+		}
+		if _, err := os.Stat(filepath.Join(dataDir, want)); err != nil {
+			t.Errorf("renamed file: %v", err)
+		}
+		if b, _ := core.Get("ab1-2cd"); b.Path != want || b.Slug != "rework-auth-flow" {
+			t.Errorf("issue path/slug = %s/%s after fix", b.Path, b.Slug)
+		}
+		if stale := core.StaleSlugs(); len(stale) != 0 {
+			t.Errorf("StaleSlugs() after fix = %+v", stale)
+		}
+	}
+
+	t.Run("without git", func(t *testing.T) {
+		t.Setenv("PATH", "")
+		core, dataDir := setupTestCore(t)
+		write(t, dataDir)
+		if err := core.Load(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, core, dataDir)
+	})
+
+	t.Run("git mv", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not installed")
+		}
+		core, dataDir := setupTestCore(t)
+		write(t, dataDir)
+		repo := filepath.Dir(dataDir)
+		for _, args := range [][]string{
+			{"init", "-q"},
+			{"add", "."},
+			{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-qm", "init"},
+		} {
+			if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+		if err := core.Load(); err != nil {
+			t.Fatal(err)
+		}
+		check(t, core, dataDir)
+
+		out, err := exec.Command("git", "-C", repo, "status", "--porcelain").Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(out), "R ") {
+			t.Errorf("git status = %q, want a staged rename", out)
+		}
+	})
+}
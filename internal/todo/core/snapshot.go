@@ -0,0 +1,311 @@
+package core
+
+import (
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/issue"
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotFile is where SaveSnapshot writes the issue index, relative to
+// the data directory. Like every hidden path it is skipped by Load and the
+// watcher.
+const SnapshotFile = ".cache/snapshot.bin"
+
+// snapshotVersion is bumped whenever the snapshot layout changes; a
+// snapshot written by another version is ignored.
+const snapshotVersion = 1
+
+// ErrSnapshotIssue is returned for a change to an issue read from the
+// startup snapshot, which has no body; get the issue again and retry.
+var ErrSnapshotIssue = errors.New("issue was read before loading finished; reload it and retry")
+
+// snapshotHeader is decoded first, so a stale or foreign snapshot is
+// rejected before the issues are read.
+type snapshotHeader struct {
+	Version int
+	// DirHash identifies the data directory state the snapshot was taken
+	// from (see dirStateHash).
+	DirHash string
+}
+
+// snapshotIssue is an issue without its body. Sync goes as YAML, decoded
+// the way Load decodes front matter, since gob cannot carry its any values.
+type snapshotIssue struct {
+	Issue issue.Issue
+	Sync  []byte
+	// ETag of the file, to tell what changed on disk since.
+	ETag string
+}
+
+type snapshotMilestone struct {
+	Milestone issue.Milestone
+	Sync      []byte
+}
+
+type snapshotData struct {
+	Issues     []snapshotIssue
+	Milestones []snapshotMilestone
+}
+
+// SaveSnapshot writes the issue index, without bodies, to SnapshotFile so
+// the next TUI start can show it before Load finishes. It does nothing
+// while a warm start is still being reconciled, or when the snapshot on
+// disk was taken from the same directory state.
+func (c *Core) SaveSnapshot() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.warm != nil {
+		return nil
+	}
+
+	hash, err := c.dirStateHash()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(c.root, filepath.FromSlash(SnapshotFile))
+	if h, err := readSnapshotHeader(path); err == nil && h.Version == snapshotVersion && h.DirHash == hash {
+		return nil
+	}
+
+	var data snapshotData
+	for _, b := range c.issues {
+		entry := snapshotIssue{Issue: *b, ETag: c.diskETagLocked(b)}
+		entry.Issue.Body, entry.Issue.Ciphertext, entry.Issue.Sync = "", "", nil
+		if entry.Sync, err = marshalSync(b.Sync); err != nil {
+			return err
+		}
+		data.Issues = append(data.Issues, entry)
+	}
+	for _, m := range c.milestones {
+		entry := snapshotMilestone{Milestone: *m}
+		entry.Milestone.Sync = nil
+		if entry.Sync, err = marshalSync(m.Sync); err != nil {
+			return err
+		}
+		data.Milestones = append(data.Milestones, entry)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	// The cache is local to this checkout.
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*\n"), 0644); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	f, err := os.CreateTemp(dir, "snapshot-*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck // gone after the rename
+	enc := gob.NewEncoder(f)
+	if err := enc.Encode(snapshotHeader{Version: snapshotVersion, DirHash: hash}); err != nil {
+		f.Close()
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := enc.Encode(data); err != nil {
+		f.Close()
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadSnapshot fills the core from SnapshotFile in place of Load, and
+// reports whether it did. A missing, corrupt, or version-mismatched
+// snapshot is ignored. The core is then warm: issues have no bodies and
+// changes wait until Reconcile has loaded the real state.
+func (c *Core) LoadSnapshot() bool {
+	path := filepath.Join(c.root, filepath.FromSlash(SnapshotFile))
+	f, err := os.Open(path) //nolint:gosec // path from known directory
+	if err != nil {
+		return false
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+
+	dec := gob.NewDecoder(f)
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil || header.Version != snapshotVersion {
+		return false
+	}
+	var data snapshotData
+	if err := dec.Decode(&data); err != nil {
+		return false
+	}
+
+	issues := make(map[string]*issue.Issue, len(data.Issues))
+	etags := make(map[string]string, len(data.Issues))
+	for _, entry := range data.Issues {
+		b := entry.Issue
+		if err := yaml.Unmarshal(entry.Sync, &b.Sync); err != nil {
+			return false
+		}
+		issues[b.ID] = &b
+		etags[b.ID] = entry.ETag
+	}
+	milestones := make(map[string]*issue.Milestone, len(data.Milestones))
+	for _, entry := range data.Milestones {
+		m := entry.Milestone
+		if err := yaml.Unmarshal(entry.Sync, &m.Sync); err != nil {
+			return false
+		}
+		milestones[m.ID] = &m
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.issues, c.milestones = issues, milestones
+	c.mentions, c.mentionedBy = nil, nil
+	c.links, c.children, c.blockers, c.dependents = nil, nil, nil, nil
+	c.duplicates, c.warnings = nil, nil
+	c.ignore = loadIgnoreRules(c.root)
+	c.snapshotIssues = make(map[*issue.Issue]struct{}, len(issues))
+	for _, b := range issues {
+		c.indexLinksLocked(b)
+		c.snapshotIssues[b] = struct{}{}
+	}
+	c.snapshotETags = etags
+	c.warm = make(chan struct{})
+	return true
+}
+
+// Warm reports whether the core holds a snapshot that Reconcile has not
+// replaced yet.
+func (c *Core) Warm() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.warm != nil
+}
+
+// Reconcile loads the issues from disk in place of a snapshot filled by
+// LoadSnapshot, and returns the IDs of issues added, removed, or changed
+// since the snapshot was saved, sorted. Reads go on from the snapshot
+// while the load runs; changes wait for it. Outside a warm start it is Load.
+func (c *Core) Reconcile() ([]string, error) {
+	c.mu.RLock()
+	warm, etags := c.warm, c.snapshotETags
+	c.mu.RUnlock()
+	if warm == nil {
+		return nil, c.Load()
+	}
+
+	fresh := New(c.root, c.Config())
+	fresh.SetWarnWriter(nil)
+	err := fresh.Load()
+	var changed []string
+	if err == nil {
+		for id, b := range fresh.issues {
+			if etag, ok := etags[id]; !ok || etag != fresh.diskETagLocked(b) {
+				changed = append(changed, id)
+			}
+		}
+		for id := range etags {
+			if _, ok := fresh.issues[id]; !ok {
+				changed = append(changed, id)
+			}
+		}
+		slices.Sort(changed)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer func() {
+		close(warm)
+		c.warm, c.snapshotETags = nil, nil
+	}()
+	// Even on error the snapshot goes, as a failed Load drops the old state,
+	// so nothing is written back from it.
+	c.issues, c.milestones = fresh.issues, fresh.milestones
+	c.mentions, c.mentionedBy = fresh.mentions, fresh.mentionedBy
+	c.links, c.children, c.blockers, c.dependents = fresh.links, fresh.children, fresh.blockers, fresh.dependents
+	c.duplicates, c.warnings, c.ignore = fresh.duplicates, fresh.warnings, fresh.ignore
+	if c.searchIndex != nil {
+		c.searchIndex.Close() //nolint:errcheck // best-effort cleanup
+		c.searchIndex = nil
+		if err := c.ensureSearchIndexLocked(); err != nil {
+			c.logWarn("failed to reinitialize search index after reload: %v", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// lockForWrite takes c.mu for a change, first waiting out a warm start so
+// nothing is written back from body-less snapshot issues. It panics on a
+// view, which is read-only.
+func (c *Core) lockForWrite() {
+	if c.live != nil {
+		panic("core: change through a read-only view")
+	}
+	c.mu.RLock()
+	warm := c.warm
+	c.mu.RUnlock()
+	if warm != nil {
+		<-warm
+	}
+	c.mu.Lock()
+}
+
+// dirStateHash hashes the path, size, and modification time of every file
+// Load reads, which changes whenever an issue or milestone file does.
+// Must be called with c.mu held.
+func (c *Core) dirStateHash() (string, error) {
+	h := fnv.New64a()
+	err := filepath.WalkDir(c.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != c.root && c.isIgnoredLocked(path, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(c.root, path)
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", filepath.ToSlash(rel), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func readSnapshotHeader(path string) (snapshotHeader, error) {
+	var header snapshotHeader
+	f, err := os.Open(path) //nolint:gosec // path from known directory
+	if err != nil {
+		return header, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	err = gob.NewDecoder(f).Decode(&header)
+	return header, err
+}
+
+func marshalSync(sync map[string]map[string]any) ([]byte, error) {
+	if len(sync) == 0 {
+		return nil, nil
+	}
+	return yaml.Marshal(sync)
+}
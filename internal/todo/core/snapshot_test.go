@@ -0,0 +1,147 @@
+package core
+
+import (
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestSnapshotReconcile(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	due, _ := issue.ParseDueDate("2026-04-01T09:30:00+02:00")
+	m := &issue.Milestone{Name: "v1.0", Description: "First cut."}
+	if err := c.CreateMilestone(m); err != nil {
+		t.Fatal(err)
+	}
+	createTestIssues(t, c,
+		&issue.Issue{ID: "kep-1", Slug: "kept", Title: "Kept", Status: "ready", Body: "See edt-1.", Tags: []string{"a"}, Due: due, Milestone: m.ID,
+			Sync: map[string]map[string]any{"github": {"issue_number": "12", "synced_at": "2026-01-01T00:00:00Z"}}},
+		&issue.Issue{ID: "edt-1", Slug: "edited", Title: "Edited", Status: "ready", Parent: "kep-1", Blocking: []string{"kep-1"}},
+		&issue.Issue{ID: "del-1", Slug: "deleted", Title: "Deleted", Status: "ready", Body: "Going."},
+	)
+	if err := c.SaveSnapshot(); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+
+	// Change the files behind the snapshot's back.
+	edited, _ := c.Get("edt-1")
+	if err := os.WriteFile(c.FullPath(edited), []byte("---\ntitle: Edited elsewhere\nstatus: completed\nblocked_by: [kep-1]\n---\n\nNew body.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deleted, _ := c.Get("del-1")
+	if err := os.Remove(c.FullPath(deleted)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "new-1--added.md"), []byte("---\ntitle: Added\nstatus: ready\nparent: kep-1\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	warm := New(dataDir, c.Config())
+	warm.SetWarnWriter(nil)
+	if !warm.LoadSnapshot() {
+		t.Fatal("LoadSnapshot() = false")
+	}
+	if !warm.Warm() {
+		t.Error("Warm() = false after LoadSnapshot")
+	}
+	kept, err := warm.Get("kep-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.Body != "" || !kept.Due.HasTime || !kept.Due.Equal(due.Time) || kept.GithubIssueNumber() != 12 {
+		t.Errorf("snapshot issue = %+v", kept)
+	}
+	if got := warm.ChildrenOf("kep-1"); len(got) != 1 || got[0].ID != "edt-1" {
+		t.Errorf("snapshot children = %v, want edt-1", got)
+	}
+
+	changed, err := warm.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if want := []string{"del-1", "edt-1", "new-1"}; !slices.Equal(changed, want) {
+		t.Errorf("Reconcile() changed = %v, want %v", changed, want)
+	}
+	if warm.Warm() {
+		t.Error("Warm() = true after Reconcile")
+	}
+
+	fresh := New(dataDir, c.Config())
+	fresh.SetWarnWriter(nil)
+	if err := fresh.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for name, pair := range map[string][2]any{
+		"issues":      {warm.issues, fresh.issues},
+		"milestones":  {warm.milestones, fresh.milestones},
+		"mentions":    {warm.mentions, fresh.mentions},
+		"mentionedBy": {warm.mentionedBy, fresh.mentionedBy},
+		"links":       {warm.links, fresh.links},
+		"children":    {warm.children, fresh.children},
+		"blockers":    {warm.blockers, fresh.blockers},
+		"dependents":  {warm.dependents, fresh.dependents},
+		"duplicates":  {warm.duplicates, fresh.duplicates},
+		"warnings":    {warm.warnings, fresh.warnings},
+	} {
+		if !reflect.DeepEqual(pair[0], pair[1]) {
+			t.Errorf("reconciled %s = %v, want %v", name, pair[0], pair[1])
+		}
+	}
+
+	// An issue read from the snapshot is not written back without its body.
+	if err := warm.Update(kept, nil); !errors.Is(err, ErrSnapshotIssue) {
+		t.Errorf("Update() of a snapshot issue = %v, want ErrSnapshotIssue", err)
+	}
+}
+
+func TestLoadSnapshotIgnored(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	createTestIssue(t, c, "snp-1", "Snapshot", "ready")
+	path := filepath.Join(dataDir, filepath.FromSlash(SnapshotFile))
+
+	if New(dataDir, c.Config()).LoadSnapshot() {
+		t.Error("LoadSnapshot() with no snapshot = true")
+	}
+	if err := c.SaveSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string][]byte{
+		"truncated": data[:len(data)/2],
+		"garbage":   []byte("not a snapshot"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := os.WriteFile(path, content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			warm := New(dataDir, c.Config())
+			if warm.LoadSnapshot() || warm.Warm() {
+				t.Error("LoadSnapshot() of a corrupt snapshot = true")
+			}
+		})
+	}
+
+	t.Run("other version", func(t *testing.T) {
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enc := gob.NewEncoder(f)
+		_ = enc.Encode(snapshotHeader{Version: snapshotVersion + 1})
+		_ = enc.Encode(snapshotData{})
+		f.Close()
+		if New(dataDir, c.Config()).LoadSnapshot() {
+			t.Error("LoadSnapshot() of another version = true")
+		}
+	})
+}
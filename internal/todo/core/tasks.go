@@ -0,0 +1,54 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// RemovedTasksError is returned when replacing an issue's body would drop
+// unchecked tasks and protect_unchecked_tasks is strict.
+type RemovedTasksError struct {
+	IssueID string
+	Tasks   []string
+}
+
+func (e *RemovedTasksError) Error() string {
+	return fmt.Sprintf("replacing the body of %s removes unchecked tasks: %s; keep or check them off, or force the update",
+		e.IssueID, quoteTasks(e.Tasks))
+}
+
+// RemovedTasksWarning is the warning for a body replacement that drops
+// tasks but is let through.
+func RemovedTasksWarning(id string, tasks []string) string {
+	return fmt.Sprintf("replacing the body of %s removed unchecked tasks: %s", id, quoteTasks(tasks))
+}
+
+func quoteTasks(tasks []string) string {
+	quoted := make([]string, len(tasks))
+	for i, t := range tasks {
+		quoted[i] = fmt.Sprintf("%q", t)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// CheckRemovedTasks returns the unchecked tasks that replacing b's body with
+// body would drop (see issue.RemovedTasks). In protect_unchecked_tasks
+// strict mode it also returns a *RemovedTasksError for them unless force is
+// set; with the mode off it returns nothing.
+func (c *Core) CheckRemovedTasks(b *issue.Issue, body string, force bool) ([]string, error) {
+	mode := config.ProtectTasksWarn
+	if cfg := c.Config(); cfg != nil {
+		mode = cfg.GetProtectUncheckedTasks()
+	}
+	if mode == config.ProtectTasksOff {
+		return nil, nil
+	}
+	removed := issue.RemovedTasks(b.Body, body)
+	if len(removed) > 0 && mode == config.ProtectTasksStrict && !force {
+		return removed, &RemovedTasksError{IssueID: b.ID, Tasks: removed}
+	}
+	return removed, nil
+}
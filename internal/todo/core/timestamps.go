@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// checkTimesLocked corrects the timestamps of an issue just read from disk
+// and records a WarnTimestamp warning for its file when it had to: a
+// timestamp without a zone was already read as UTC, and one further in the
+// future than max_clock_skew allows (a skewed clock wrote it) is clamped to
+// now, so filters, ages, and sorting are not thrown off.
+// Must be called with c.mu held.
+func (c *Core) checkTimesLocked(b *issue.Issue) {
+	var notes []string
+	for _, key := range b.NaiveTimes {
+		notes = append(notes, key+" has no time zone; read as UTC")
+	}
+
+	now := c.Now().UTC().Truncate(time.Second)
+	limit := now.Add(c.config.GetMaxClockSkew())
+	for _, ts := range []struct {
+		key string
+		t   **time.Time
+	}{
+		{issue.KeyCreatedAt, &b.CreatedAt},
+		{issue.KeyUpdatedAt, &b.UpdatedAt},
+	} {
+		if *ts.t == nil || !(*ts.t).After(limit) {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("%s %s is in the future; clamped to %s",
+			ts.key, (*ts.t).UTC().Format(time.RFC3339), now.Format(time.RFC3339)))
+		clamped := now
+		*ts.t = &clamped
+	}
+
+	if len(notes) > 0 {
+		w := LoadWarning{Path: b.Path, Kind: WarnTimestamp, Err: errors.New(strings.Join(notes, "; "))}
+		c.insertWarningLocked(w)
+		c.logWarn("%s", w.Notice())
+	}
+}
+
+// FixTimestamps rewrites the files of issues loaded with a WarnTimestamp
+// warning, so their timestamps are stored in UTC and no longer lie in the
+// future. Returns how many files were rewritten.
+func (c *Core) FixTimestamps() (int, error) {
+	c.lockForWrite()
+	defer c.mu.Unlock()
+
+	byPath := make(map[string]*issue.Issue, len(c.issues))
+	for _, b := range c.issues {
+		byPath[b.Path] = b
+	}
+
+	fixed := 0
+	for _, w := range slices.Clone(c.warnings) {
+		b, ok := byPath[w.Path]
+		if w.Kind != WarnTimestamp || !ok {
+			continue
+		}
+		b.NaiveTimes = nil
+		if err := c.saveToDisk(b); err != nil {
+			return fixed, err
+		}
+		c.clearWarningLocked(w.Path)
+		fixed++
+	}
+	return fixed, nil
+}
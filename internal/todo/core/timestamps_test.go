@@ -0,0 +1,135 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// timestampsNow is the clock of the testdata/timestamps fixtures: the naive
+// file was updated half an hour before it, the skewed one years after.
+var timestampsNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// loadTimestampFixtures loads testdata/timestamps with the clock at
+// timestampsNow.
+func loadTimestampFixtures(t *testing.T, opts ...func(*config.Config)) (*Core, string) {
+	t.Helper()
+	c, dataDir := setupTestCore(t, opts...)
+	if err := os.CopyFS(dataDir, os.DirFS(filepath.Join("testdata", "timestamps"))); err != nil {
+		t.Fatal(err)
+	}
+	c.SetClock(func() time.Time { return timestampsNow })
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return c, dataDir
+}
+
+func TestLoadNormalizesTimestamps(t *testing.T) {
+	c, _ := loadTimestampFixtures(t)
+
+	get := func(id string) *issue.Issue {
+		t.Helper()
+		b, err := c.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	for _, tt := range []struct {
+		id               string
+		created, updated time.Time
+	}{
+		{"nav-001", time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 11, 30, 0, 0, time.UTC)},
+		{"fut-001", timestampsNow, timestampsNow},
+		{"skw-001", time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)},
+		{"utc-001", time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 8, 23, 0, 0, 0, time.UTC)},
+	} {
+		b := get(tt.id)
+		if !b.CreatedAt.Equal(tt.created) || !b.UpdatedAt.Equal(tt.updated) {
+			t.Errorf("%s created/updated = %v/%v, want %v/%v", tt.id, b.CreatedAt, b.UpdatedAt, tt.created, tt.updated)
+		}
+	}
+
+	warnings := c.Warnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Warnings() = %v, want the naive and future files", warnings)
+	}
+	for i, want := range []struct{ path, msg string }{
+		{"fut-001--future.md", "created_at 2031-06-01T00:00:00Z is in the future; clamped to 2025-03-10T12:00:00Z"},
+		{"nav-001--naive.md", "created_at has no time zone; read as UTC; updated_at has no time zone"},
+	} {
+		w := warnings[i]
+		if w.Path != want.path || w.Kind != WarnTimestamp || !strings.Contains(w.Message(), want.msg) {
+			t.Errorf("warning %d = %+v, want %s %s containing %q", i, w, want.path, WarnTimestamp, want.msg)
+		}
+	}
+
+	// The clamped issue sorts as created now, not five years from now.
+	all := c.All()
+	slices.SortFunc(all, issue.CompareByCreatedDesc)
+	var ids []string
+	for _, b := range all {
+		ids = append(ids, b.ID)
+	}
+	if want := []string{"skw-001", "fut-001", "utc-001", "nav-001"}; !slices.Equal(ids, want) {
+		t.Errorf("newest first = %v, want %v", ids, want)
+	}
+}
+
+func TestLoadClampsBeyondConfiguredSkew(t *testing.T) {
+	c, _ := loadTimestampFixtures(t, func(cfg *config.Config) { cfg.MaxClockSkew = "1h" })
+
+	b, err := c.Get("skw-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.CreatedAt.Equal(timestampsNow) {
+		t.Errorf("CreatedAt = %v, want it clamped to %v", b.CreatedAt, timestampsNow)
+	}
+	if n := len(c.Warnings()); n != 3 {
+		t.Errorf("got %d warnings, want 3", n)
+	}
+}
+
+func TestFixTimestamps(t *testing.T) {
+	c, dataDir := loadTimestampFixtures(t)
+
+	fixed, err := c.FixTimestamps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed != 2 {
+		t.Errorf("FixTimestamps() = %d, want 2", fixed)
+	}
+	if w := c.Warnings(); len(w) != 0 {
+		t.Errorf("Warnings() after fix = %v, want none", w)
+	}
+	for file, want := range map[string]string{
+		"nav-001--naive.md":  "created_at: 2025-03-01T08:00:00Z\nupdated_at: 2025-03-10T11:30:00Z\n",
+		"fut-001--future.md": "created_at: 2025-03-10T12:00:00Z\nupdated_at: 2025-03-10T12:00:00Z\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(dataDir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s = %s, want %q", file, data, want)
+		}
+	}
+
+	// A later clock finds nothing left to correct.
+	c.SetClock(func() time.Time { return timestampsNow.Add(time.Hour) })
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if w := c.Warnings(); len(w) != 0 {
+		t.Errorf("Warnings() after reload = %v, want none", w)
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// validateValuesLocked checks b's status, type, priority, iteration,
+// estimate, and visibility against the config, returning a *config.ValueError for the
+// first unknown one. On update (b.Path set), a value the saved file already
+// has is accepted, so issues with legacy values stay editable until
+// `jig todo doctor --fix` remaps them.
+// Must be called with c.mu held.
+func (c *Core) validateValuesLocked(b *issue.Issue) error {
+	if c.config == nil {
+		return nil
+	}
+	var saved *issue.Issue
+	for _, f := range valueFields {
+		err := f.check(c.config, f.get(b))
+		if err == nil {
+			continue
+		}
+		if saved == nil && b.Path != "" {
+			saved, _ = c.loadIssue(filepath.Join(c.root, b.Path))
+		}
+		if saved != nil && f.get(saved) == f.get(b) {
+			continue
+		}
+		return err
+	}
+	return nil
+}
+
+// valueField is an issue field whose values the config defines.
+type valueField struct {
+	name     string
+	get      func(*issue.Issue) string
+	set      func(*issue.Issue, string)
+	validate func(*config.Config, string) error
+	fallback func(*config.Config) string // value used when no suggestion is close
+}
+
+// check validates value, accepting an omitted one: loading fills in the
+// defaults for type and priority, and status is optional.
+func (f valueField) check(cfg *config.Config, value string) error {
+	if value == "" {
+		return nil
+	}
+	return f.validate(cfg, value)
+}
+
+var valueFields = []valueField{
+	{
+		name:     "status",
+		get:      func(b *issue.Issue) string { return b.Status },
+		set:      func(b *issue.Issue, v string) { b.Status = v },
+		validate: (*config.Config).ValidateStatus,
+		fallback: (*config.Config).GetDefaultStatus,
+	},
+	{
+		name: "type",
+		get:  func(b *issue.Issue) string { return b.Type },
+		set:  func(b *issue.Issue, v string) { b.Type = v },
+		validate: func(cfg *config.Config, v string) error {
+			// Legacy milestone issues load and migrate; they are not typos.
+			if v == config.TypeMilestone {
+				return nil
+			}
+			return cfg.ValidateType(v)
+		},
+		fallback: func(cfg *config.Config) string { return cmp.Or(cfg.GetDefaultType(), config.TypeTask) },
+	},
+	{
+		name:     "priority",
+		get:      func(b *issue.Issue) string { return b.Priority },
+		set:      func(b *issue.Issue, v string) { b.Priority = v },
+		validate: (*config.Config).ValidatePriority,
+		fallback: func(*config.Config) string { return config.PriorityNormal },
+	},
+	{
+		name:     "iteration",
+		get:      func(b *issue.Issue) string { return b.Iteration },
+		set:      func(b *issue.Issue, v string) { b.Iteration = v },
+		validate: (*config.Config).ValidateIteration,
+		fallback: func(*config.Config) string { return "" }, // unassign
+	},
+	{
+		name:     "estimate",
+		get:      func(b *issue.Issue) string { return b.Estimate },
+		set:      func(b *issue.Issue, v string) { b.Estimate = v },
+		validate: (*config.Config).ValidateEstimate,
+		fallback: func(*config.Config) string { return "" }, // unestimated
+	},
+	{
+		name:     "visibility",
+		get:      func(b *issue.Issue) string { return b.Visibility },
+		set:      func(b *issue.Issue, v string) { b.Visibility = v },
+		validate: func(_ *config.Config, v string) error { return config.ValidateVisibility(v) },
+		fallback: func(*config.Config) string { return config.VisibilityInternal }, // fail closed
+	},
+}
+
+// UnknownValue is an issue field holding a value the config does not define.
+type UnknownValue struct {
+	IssueID    string `json:"issue_id"`
+	Field      string `json:"field"`
+	Value      string `json:"value"`
+	Suggestion string `json:"suggestion,omitempty"`
+	// RemapTo is what FixUnknownValues sets: the suggestion, or the field's
+	// default when nothing is close.
+	RemapTo string `json:"remap_to"`
+}
+
+// CheckUnknownValues returns every status, type, priority, iteration,
+// estimate, or visibility that the config does not define, sorted by issue ID then field.
+func (c *Core) CheckUnknownValues() []UnknownValue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.unknownValuesLocked()
+}
+
+func (c *Core) unknownValuesLocked() []UnknownValue {
+	if c.config == nil {
+		return nil
+	}
+	var result []UnknownValue
+	for _, b := range c.issues {
+		for _, f := range valueFields {
+			err := f.check(c.config, f.get(b))
+			if err == nil {
+				continue
+			}
+			suggestion := err.(*config.ValueError).Suggestion
+			result = append(result, UnknownValue{
+				IssueID:    b.ID,
+				Field:      f.name,
+				Value:      f.get(b),
+				Suggestion: suggestion,
+				RemapTo:    cmp.Or(suggestion, f.fallback(c.config)),
+			})
+		}
+	}
+	slices.SortFunc(result, func(a, b UnknownValue) int {
+		return cmp.Or(cmp.Compare(a.IssueID, b.IssueID), cmp.Compare(a.Field, b.Field))
+	})
+	return result
+}
+
+// FixUnknownValues rewrites each unknown value to its RemapTo and saves the
+// issue. Returns the number of values changed.
+func (c *Core) FixUnknownValues() (int, error) {
+	c.lockForWrite()
+	defer c.mu.Unlock()
+
+	fixed := 0
+	changed := make(map[string]bool)
+	for _, u := range c.unknownValuesLocked() {
+		b := c.issues[u.IssueID]
+		for _, f := range valueFields {
+			if f.name == u.Field {
+				f.set(b, u.RemapTo)
+			}
+		}
+		changed[b.ID] = true
+		fixed++
+	}
+	for id := range changed {
+		if err := c.saveToDisk(c.issues[id]); err != nil {
+			return fixed, err
+		}
+	}
+	return fixed, nil
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestCreateRejectsUnknownValues(t *testing.T) {
+	c, _ := setupTestCore(t)
+
+	err := c.Create(&issue.Issue{ID: "typo-1", Title: "Typo", Status: "ready", Priority: "hgih"})
+	valueErr, ok := errors.AsType[*config.ValueError](err)
+	if !ok {
+		t.Fatalf("Create() error = %v, want *config.ValueError", err)
+	}
+	if valueErr.Field != "priority" || valueErr.Suggestion != config.PriorityHigh {
+		t.Errorf("error = %+v, want priority with suggestion high", valueErr)
+	}
+	if _, err := c.Get("typo-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("rejected issue was stored: %v", err)
+	}
+
+	b := createTestIssue(t, c, "ok-1", "Fine", "ready")
+	b.Status = "in-progres"
+	if err := c.Update(b, nil); err == nil {
+		t.Error("Update() accepted an unknown status")
+	}
+
+	b.Status = "ready"
+	b.Iteration = "sprint-9"
+	if _, ok := errors.AsType[*config.ValueError](c.Update(b, nil)); !ok {
+		t.Error("Update() accepted an undeclared iteration")
+	}
+	b.Iteration = "2025-W34"
+	if err := c.Update(b, nil); err != nil {
+		t.Errorf("Update() rejected an ISO week iteration: %v", err)
+	}
+}
+
+func TestUnknownValuesLoadAndRemap(t *testing.T) {
+	c, dataDir := setupTestCore(t)
+	content := "---\ntitle: Legacy\nstatus: todo\ntype: task\npriority: hgih\n---\n"
+	path := filepath.Join(dataDir, "leg-001--legacy.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(); err != nil {
+		t.Fatalf("Load() error = %v, want legacy values tolerated", err)
+	}
+
+	// Unrelated edits keep working while the legacy values remain.
+	b, err := c.Get("leg-001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Title = "Legacy, renamed"
+	if err := c.Update(b, nil); err != nil {
+		t.Fatalf("Update() of an unrelated field error = %v", err)
+	}
+
+	unknown := c.CheckUnknownValues()
+	want := []UnknownValue{
+		{IssueID: "leg-001", Field: "priority", Value: "hgih", Suggestion: "high", RemapTo: "high"},
+		{IssueID: "leg-001", Field: "status", Value: "todo", RemapTo: config.StatusReady},
+	}
+	if len(unknown) != len(want) || unknown[0] != want[0] || unknown[1] != want[1] {
+		t.Fatalf("CheckUnknownValues() = %+v, want %+v", unknown, want)
+	}
+
+	fixed, err := c.FixUnknownValues()
+	if err != nil || fixed != 2 {
+		t.Fatalf("FixUnknownValues() = %d, %v; want 2, nil", fixed, err)
+	}
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	b, _ = c.Get("leg-001")
+	if b.Priority != "high" || b.Status != config.StatusReady {
+		t.Errorf("after fix priority = %q, status = %q; want high, ready", b.Priority, b.Status)
+	}
+	if got := c.CheckUnknownValues(); len(got) != 0 {
+		t.Errorf("CheckUnknownValues() after fix = %+v", got)
+	}
+}
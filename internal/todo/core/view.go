@@ -0,0 +1,75 @@
+package core
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// View returns a read-only copy of the in-memory state as it is now, for a
+// caller whose reads must agree with each other, such as the fields of one
+// GraphQL query. Changes made through c or picked up by the watcher after
+// View returns do not show in it.
+//
+// Issues and milestones are copied one level deep: a field reassigned on a
+// stored issue, as Update and the watcher do, leaves the view's copy alone,
+// while slices and maps inside it are shared and must not be edited. A view
+// rejects every change with a panic; search uses c's index, so its hits are
+// limited to the issues the view holds.
+func (c *Core) View() *Core {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	v := &Core{
+		root:        c.root,
+		config:      c.config,
+		live:        c,
+		issues:      make(map[string]*issue.Issue, len(c.issues)),
+		milestones:  make(map[string]*issue.Milestone, len(c.milestones)),
+		mentions:    maps.Clone(c.mentions),
+		mentionedBy: cloneRefs(c.mentionedBy),
+		links:       maps.Clone(c.links),
+		children:    cloneRefs(c.children),
+		blockers:    cloneRefs(c.blockers),
+		dependents:  cloneRefs(c.dependents),
+		duplicates:  make(map[string][]string, len(c.duplicates)),
+		warnings:    slices.Clone(c.warnings),
+		ignore:      c.ignore,
+		clock:       c.clock,
+		idGen:       c.idGen,
+		idMatcher:   c.idMatcher,
+		subscribers: make(map[uint64]*subscription),
+	}
+	for id, b := range c.issues {
+		cp := *b
+		v.issues[id] = &cp
+	}
+	// Duplicates are edited in place when an issue is deleted
+	for id, blockers := range c.duplicates {
+		v.duplicates[id] = slices.Clone(blockers)
+	}
+	for id, m := range c.milestones {
+		cp := *m
+		v.milestones[id] = &cp
+	}
+	return v
+}
+
+// IsView reports whether c is a read-only view made by View.
+func (c *Core) IsView() bool {
+	return c.live != nil
+}
+
+// cloneRefs copies a reverse index and each set in it, since the sets are
+// edited in place as links change.
+func cloneRefs(index map[string]map[string]struct{}) map[string]map[string]struct{} {
+	if index == nil {
+		return nil
+	}
+	out := make(map[string]map[string]struct{}, len(index))
+	for target, sources := range index {
+		out[target] = maps.Clone(sources)
+	}
+	return out
+}
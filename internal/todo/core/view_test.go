@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestViewIsUnaffectedByLaterChanges(t *testing.T) {
+	c, _ := setupTestCore(t)
+	for _, b := range []*issue.Issue{
+		{ID: "epc-001", Title: "Epic", Status: "ready", Type: "epic"},
+		{ID: "tsk-001", Title: "Login form", Status: "ready", Type: "task", Parent: "epc-001", Body: "See tsk-002."},
+		{ID: "tsk-002", Title: "Gate", Status: "ready", Type: "task", Blocking: []string{"tsk-001"}},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := c.View()
+	if !v.IsView() || c.IsView() {
+		t.Fatal("IsView() wrong way round")
+	}
+
+	b, _ := c.Get("tsk-001")
+	b.Status = "completed"
+	b.Parent = ""
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UnlinkBlocking("tsk-002", "tsk-001"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete("tsk-002"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Create(&issue.Issue{ID: "tsk-003", Title: "Later", Status: "ready"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(v.All()); n != 3 {
+		t.Errorf("view has %d issues, want the 3 it was taken with", n)
+	}
+	if got, err := v.Get("tsk-001"); err != nil || got.Status != "ready" || got.Parent != "epc-001" {
+		t.Errorf("view tsk-001 = %+v, %v; want it as it was", got, err)
+	}
+	if kids := v.ChildrenOf("epc-001"); len(kids) != 1 || kids[0].ID != "tsk-001" {
+		t.Errorf("view children of epc-001 = %v", kids)
+	}
+	if blockers := v.BlockersOf("tsk-001"); len(blockers) != 1 || blockers[0].ID != "tsk-002" {
+		t.Errorf("view blockers of tsk-001 = %v", blockers)
+	}
+	if refs := v.MentionedBy("tsk-002"); len(refs) != 1 || refs[0].ID != "tsk-001" {
+		t.Errorf("view mentions of tsk-002 = %v", refs)
+	}
+	if len(c.ChildrenOf("epc-001")) != 0 || len(c.All()) != 3 {
+		t.Error("live core did not change")
+	}
+
+	hits, err := v.Search("Later")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("view search found %v, an issue created after the view", hits)
+	}
+}
+
+func TestViewRejectsChanges(t *testing.T) {
+	c, _ := setupTestCore(t)
+	v := c.View()
+	defer func() {
+		if recover() == nil {
+			t.Error("Create through a view did not panic")
+		}
+		if len(c.All()) != 0 {
+			t.Error("Create through a view changed the live core")
+		}
+	}()
+	_ = v.Create(&issue.Issue{Title: "Nope", Status: "ready"})
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Kinds of LoadWarning.
+const (
+	// WarnParse marks a file whose front matter could not be parsed.
+	WarnParse = "parse"
+	// WarnDuplicate marks a file whose ID is already used by another file.
+	WarnDuplicate = "duplicate"
+	// WarnOrphanFile marks a markdown file in the data directory that is not
+	// an issue (it has no front matter).
+	WarnOrphanFile = "orphan-file"
+	// WarnTooLarge marks a file whose body or front matter is over the
+	// configured size limit.
+	WarnTooLarge = "too-large"
+	// WarnPollFallback marks the data directory itself (path ".") when
+	// fsnotify could not watch it and the watcher polls instead.
+	WarnPollFallback = "poll-fallback"
+	// WarnTimestamp marks a file that loaded, but with a timestamp that had
+	// no zone or lay in the future; see Core.FixTimestamps.
+	WarnTimestamp = "timestamp"
+)
+
+// LoadWarning describes a file that Load or the watcher skipped, or loaded
+// only after correcting it (WarnTimestamp).
+type LoadWarning struct {
+	// Path is relative to the data directory.
+	Path string
+	// Kind is one of WarnParse, WarnDuplicate, WarnOrphanFile, WarnTooLarge,
+	// WarnPollFallback, or WarnTimestamp.
+	Kind string
+	Err  error
+}
+
+func (w LoadWarning) Error() string {
+	return fmt.Sprintf("%s: %s: %v", w.Path, w.Kind, w.Err)
+}
+
+// Message returns the underlying error text, for JSON and UI display.
+func (w LoadWarning) Message() string {
+	if w.Err == nil {
+		return ""
+	}
+	return w.Err.Error()
+}
+
+// Notice returns the warning as one line of CLI output: a skipped file is
+// "skipping <path>: ...", a corrected one says how to rewrite it.
+func (w LoadWarning) Notice() string {
+	switch w.Kind {
+	case WarnTimestamp:
+		return w.Error() + "; run 'jig todo doctor --fix' to rewrite it"
+	case WarnPollFallback:
+		return fmt.Sprintf("fsnotify cannot watch the data directory (%v); polling for changes instead", w.Err)
+	}
+	return "skipping " + w.Error()
+}
+
+// MarshalJSON renders Err as its message so warnings survive --json output.
+func (w LoadWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Path  string `json:"path"`
+		Kind  string `json:"kind"`
+		Error string `json:"error"`
+	}{w.Path, w.Kind, w.Message()})
+}
+
+// Warnings returns the files the last Load and the watcher since skipped or
+// loaded only after correcting them, sorted by path; a poll fallback is
+// listed under path ".".
+func (c *Core) Warnings() []LoadWarning {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.warnings)
+}
+
+// addWarningLocked records a skipped file, replacing any earlier warning for
+// the same path, and mirrors it to the warn writer.
+func (c *Core) addWarningLocked(absPath, kind string, err error) {
+	rel, relErr := filepath.Rel(c.root, absPath)
+	if relErr != nil {
+		rel = absPath
+	}
+	w := LoadWarning{Path: rel, Kind: kind, Err: err}
+	c.insertWarningLocked(w)
+	c.logWarn("%s", w.Notice())
+}
+
+// addWatcherWarningLocked records that fsnotify could not watch the data
+// directory, so the watcher polls instead.
+func (c *Core) addWatcherWarningLocked(err error) {
+	c.insertWarningLocked(LoadWarning{Path: ".", Kind: WarnPollFallback, Err: err})
+	c.logWarn("fsnotify cannot watch %s (%v); polling for changes instead", c.root, err)
+}
+
+// insertWarningLocked adds w in path order, replacing any earlier warning
+// for the same path.
+func (c *Core) insertWarningLocked(w LoadWarning) {
+	c.clearWarningLocked(w.Path)
+	i, _ := slices.BinarySearchFunc(c.warnings, w.Path, func(w LoadWarning, p string) int {
+		return strings.Compare(w.Path, p)
+	})
+	c.warnings = slices.Insert(c.warnings, i, w)
+}
+
+// clearWarningLocked drops the warning for a path (relative to the data
+// directory) once the file loads cleanly or is removed.
+func (c *Core) clearWarningLocked(rel string) {
+	c.warnings = slices.DeleteFunc(c.warnings, func(w LoadWarning) bool { return w.Path == rel })
+}
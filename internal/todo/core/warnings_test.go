@@ -0,0 +1,114 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func writeFixture(t *testing.T, dataDir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dataDir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadWarnings(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	createTestIssue(t, core, "aaa-111", "Good", "ready")
+
+	writeFixture(t, dataDir, "b/bad-222--broken.md", "---\ntitle: [unclosed\nstatus: ready\n---\n")
+	writeFixture(t, dataDir, "archive/aaa-111--good.md", "---\ntitle: Archived copy\nstatus: completed\n---\n")
+	writeFixture(t, dataDir, "README.md", "# Notes\n\nNot an issue.\n")
+	writeFixture(t, dataDir, issue.MilestonesDir+"/mmm-333--v1.md", "---\nname: [oops\n---\n")
+
+	var buf bytes.Buffer
+	core.SetWarnWriter(&buf)
+	if err := core.Load(); err != nil {
+		t.Fatalf("Load() should skip bad files, got error %v", err)
+	}
+
+	if _, err := core.Get("aaa-111"); err != nil {
+		t.Errorf("good issue should still load: %v", err)
+	}
+	if len(core.All()) != 1 {
+		t.Errorf("loaded %d issues, want 1", len(core.All()))
+	}
+
+	got := map[string]string{}
+	for _, w := range core.Warnings() {
+		got[w.Path] = w.Kind
+		if w.Err == nil {
+			t.Errorf("%s: warning has no error", w.Path)
+		}
+	}
+	want := map[string]string{
+		"README.md":                                          WarnOrphanFile,
+		filepath.Join("b", "bad-222--broken.md"):             WarnParse,
+		filepath.Join(issue.MilestonesDir, "mmm-333--v1.md"): WarnParse,
+		// The bucketed file loads first, so the archive copy is the duplicate.
+		filepath.Join("archive", "aaa-111--good.md"): WarnDuplicate,
+	}
+	if len(got) != len(want) {
+		t.Errorf("warnings = %v, want %v", got, want)
+	}
+	for path, kind := range want {
+		if got[path] != kind {
+			t.Errorf("warning for %s = %q, want %q", path, got[path], kind)
+		}
+	}
+
+	// Still mirrored as text for anything reading the warn writer.
+	if !strings.Contains(buf.String(), "warning: skipping README.md: orphan-file") {
+		t.Errorf("warn writer output missing orphan line:\n%s", buf.String())
+	}
+
+	// A fixed file clears its warning on the next load.
+	writeFixture(t, dataDir, "b/bad-222--broken.md", "---\ntitle: Fixed\nstatus: ready\n---\n")
+	if err := core.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range core.Warnings() {
+		if w.Path == filepath.Join("b", "bad-222--broken.md") {
+			t.Errorf("fixed file still warned: %v", w)
+		}
+	}
+}
+
+func TestLoadWarningJSON(t *testing.T) {
+	core := New(t.TempDir(), config.Default())
+	core.SetWarnWriter(nil)
+	core.addWarningLocked(filepath.Join(core.root, "x.md"), WarnParse, os.ErrInvalid)
+	data, err := core.Warnings()[0].MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"path":"x.md","kind":"parse","error":"invalid argument"}`; string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+}
+
+func TestLoadWarningNotice(t *testing.T) {
+	tests := []struct {
+		w    LoadWarning
+		want string
+	}{
+		{LoadWarning{Path: "x.md", Kind: WarnParse, Err: os.ErrInvalid}, "skipping x.md: parse: invalid argument"},
+		{LoadWarning{Path: "y.md", Kind: WarnTimestamp, Err: os.ErrInvalid},
+			"y.md: timestamp: invalid argument; run 'jig todo doctor --fix' to rewrite it"},
+	}
+	for _, tt := range tests {
+		if got := tt.w.Notice(); got != tt.want {
+			t.Errorf("Notice() = %q, want %q", got, tt.want)
+		}
+	}
+}
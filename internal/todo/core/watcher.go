@@ -1,8 +1,13 @@
 package core
 
 import (
+	"cmp"
+	"context"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,6 +20,22 @@ import (
 const debounceDelay = 100 * time.Millisecond
 const pollInterval = 2 * time.Second
 
+// DefaultDebounce and DefaultPollInterval are the watcher's timings when
+// the config's watcher section leaves them unset.
+const (
+	DefaultDebounce     = debounceDelay
+	DefaultPollInterval = pollInterval
+)
+
+// resyncRetries bounds how many times (at debounceDelay intervals) a resync
+// waits for a replaced root directory to reappear or become loadable before
+// leaving it to the poll ticker.
+const resyncRetries = 20
+
+// massRemovalMin is the fewest removals in one batch that can count as a
+// mass removal; below it, ordinary deletes are handled incrementally.
+const massRemovalMin = 3
+
 // EventType represents the type of change that occurred to an issue.
 type EventType int
 
@@ -46,6 +67,57 @@ type IssueEvent struct {
 	Type    EventType    // The type of change
 	Issue   *issue.Issue // the issue (nil for Deleted events)
 	IssueID string       // Always set, useful for Deleted when Issue is nil
+
+	// ChangedFields names what an Updated event changed, as returned by
+	// issue.ChangedFields (nil for Created and Deleted events)
+	ChangedFields []string
+}
+
+// HasChanged reports whether the event changed the named field. Created and
+// Deleted events change every field.
+func (e IssueEvent) HasChanged(field string) bool {
+	return e.Type != EventUpdated || slices.Contains(e.ChangedFields, field)
+}
+
+// noteWriteLocked remembers the issue as its file holds it before this
+// process rewrites it, the first time it does so since the watcher last
+// read the file. By the time the watcher sees the write, the cached issue is
+// already the new version, so changedFieldsLocked diffs against this one.
+func (c *Core) noteWriteLocked(path, id string) {
+	if !c.watching {
+		return
+	}
+	if _, ok := c.written[id]; ok {
+		return
+	}
+	prev, err := c.loadIssue(path)
+	if err != nil {
+		return
+	}
+	if c.written == nil {
+		c.written = make(map[string]*issue.Issue)
+	}
+	c.written[id] = prev
+}
+
+// changedFieldsLocked returns the fields that differ between the issue as it
+// was before b was read from disk and b: the version noted by
+// noteWriteLocked if this process wrote b, else prev.
+func (c *Core) changedFieldsLocked(prev, b *issue.Issue) []string {
+	if noted, ok := c.written[b.ID]; ok {
+		prev = noted
+		delete(c.written, b.ID)
+	}
+	return changedFields(prev, b)
+}
+
+// changedFields is issue.ChangedFields with the defaults a loaded issue gets
+// applied to both sides, since an issue created in this process has none.
+func changedFields(prev, b *issue.Issue) []string {
+	before, after := *prev, *b
+	applyFieldDefaults(&before)
+	applyFieldDefaults(&after)
+	return issue.ChangedFields(&before, &after)
 }
 
 // subscription represents a subscriber to issue events.
@@ -119,28 +191,40 @@ func (c *Core) Watch(onChange func()) error {
 		return nil // Already watching
 	}
 
-	watcher, err := fsnotify.NewWatcher()
+	watcher, err := openWatcher(c.root)
 	if err != nil {
+		if !c.config.GetWatcher().PollFallback {
+			c.mu.Unlock()
+			return err
+		}
+		// fsnotify does not work here (some network filesystems); scan
+		// modification times instead.
+		c.addWatcherWarningLocked(err)
+		c.watching = true
+		c.watchMode = WatchModePoll
+		c.done = make(chan struct{})
+		c.onChange = onChange
 		c.mu.Unlock()
-		return err
-	}
-
-	if err := watcher.Add(c.root); err != nil {
-		watcher.Close() //nolint:errcheck // cleanup on error path
-		c.mu.Unlock()
-		return err
+		// Seed before returning, so changes made after Watch are seen.
+		go c.pollLoop(c.snapshotMtimes())
+		return nil
 	}
 
-	// Watch all subdirectories (best effort - don't fail if any can't be watched)
+	// Watch all subdirectories that are not ignored (best effort - don't fail
+	// if any can't be watched)
 	_ = filepath.WalkDir(c.root, func(path string, d os.DirEntry, err error) error {
 		if err != nil || !d.IsDir() || path == c.root {
 			return nil //nolint:nilerr // best-effort: skip unwatchable dirs
 		}
+		if c.isIgnoredLocked(path, true) {
+			return filepath.SkipDir
+		}
 		_ = watcher.Add(path)
 		return nil
 	})
 
 	c.watching = true
+	c.watchMode = WatchModeFSNotify
 	c.done = make(chan struct{})
 	c.onChange = onChange
 	c.mu.Unlock()
@@ -167,7 +251,9 @@ func (c *Core) unwatchLocked() error { //nolint:unparam // returns error for Unw
 
 	close(c.done)
 	c.watching = false
+	c.watchMode = ""
 	c.onChange = nil
+	c.written = nil
 
 	// Close all subscriber channels
 	c.subMu.Lock()
@@ -184,38 +270,97 @@ func (c *Core) unwatchLocked() error { //nolint:unparam // returns error for Unw
 func (c *Core) watchLoop(watcher *fsnotify.Watcher) {
 	defer watcher.Close() //nolint:errcheck // cleanup
 
-	var debounceTimer *time.Timer
-	var pendingMu sync.Mutex
-	pendingChanges := make(map[string]fsnotify.Op)
-
 	// Seed mtime map for polling fallback
 	mtimes := c.snapshotMtimes()
 
-	pollTicker := time.NewTicker(pollInterval)
+	// A git checkout can swap the whole directory out from under fsnotify,
+	// leaving it watching stale inodes. Remember the root's identity so the
+	// swap can be detected, and funnel every trigger into one resync channel.
+	rootInfo, _ := os.Stat(c.root)
+	resync := make(chan struct{}, 1)
+	requestResync := func() {
+		select {
+		case resync <- struct{}{}:
+		default:
+		}
+	}
+	retries := 0
+
+	tuning := c.config.GetWatcher()
+	deb := newDebouncer(cmp.Or(c.debounce, tuning.Debounce(), debounceDelay), c.syncDelivery, func(changes map[string]fsnotify.Op) {
+		if c.isMassRemoval(changes) {
+			requestResync()
+			return
+		}
+		c.handleChanges(changes)
+	})
+	deb.maxBatch = tuning.MaxBatch
+
+	pollTicker := time.NewTicker(cmp.Or(tuning.PollInterval(), pollInterval))
 	defer pollTicker.Stop()
 
 	for {
 		select {
 		case <-c.done:
-			if debounceTimer != nil {
-				debounceTimer.Stop()
-			}
+			deb.reset()
 			return
 
+		case <-resync:
+			// Anything pending refers to the old tree; the full diff covers it.
+			deb.reset()
+
+			info, err := os.Stat(c.root)
+			if err == nil {
+				c.rewatch(watcher)
+				mtimes = c.snapshotMtimes()
+			}
+			if err != nil || !c.resync() {
+				if retries < resyncRetries {
+					retries++
+					time.AfterFunc(debounceDelay, requestResync)
+				}
+				continue
+			}
+			rootInfo = info
+			retries = 0
+
 		case event, ok := <-watcher.Events:
 			if !ok {
 				return
 			}
 
+			// The root itself being moved or removed means it was replaced.
+			if event.Name == c.root && event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				requestResync()
+				continue
+			}
+
+			// A WaitIdle marker: every change made before it has been read,
+			// so handle the pending batch now.
+			if done := c.takeBarrier(event.Name); done != nil {
+				_ = os.Remove(event.Name)
+				deb.drain()
+				close(done)
+				continue
+			}
+
+			// A changed ignore file can hide or reveal any file, so reload
+			// everything.
+			if event.Name == filepath.Join(c.root, IgnoreFile) {
+				requestResync()
+				continue
+			}
+
 			// Watch newly created subdirectories so fsnotify picks up files in them
 			if event.Op&fsnotify.Create != 0 && !strings.HasSuffix(event.Name, ".md") {
-				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() && !c.isIgnored(event.Name, true) {
 					_ = watcher.Add(event.Name)
 				}
 			}
 
 			// Only care about .md files within the issues directory tree
-			if !strings.HasSuffix(event.Name, ".md") {
+			// that are not ignored
+			if !strings.HasSuffix(event.Name, ".md") || c.isIgnored(event.Name, false) {
 				continue
 			}
 
@@ -236,25 +381,13 @@ func (c *Core) watchLoop(watcher *fsnotify.Watcher) {
 			}
 
 			// Accumulate changes during debounce window
-			pendingMu.Lock()
-			pendingChanges[event.Name] |= event.Op
-			pendingMu.Unlock()
-
-			// Start/reset debounce timer
-			if debounceTimer != nil {
-				debounceTimer.Stop()
-			}
-			debounceTimer = time.AfterFunc(debounceDelay, func() {
-				// Swap out pending changes atomically
-				pendingMu.Lock()
-				changes := pendingChanges
-				pendingChanges = make(map[string]fsnotify.Op)
-				pendingMu.Unlock()
-
-				c.handleChanges(changes)
-			})
+			deb.add(event.Name, event.Op)
 
 		case <-pollTicker.C:
+			if info, err := os.Stat(c.root); err != nil || !os.SameFile(info, rootInfo) {
+				requestResync()
+				continue
+			}
 			changes := c.pollForChanges(mtimes, watcher)
 			c.handleChanges(changes)
 
@@ -262,20 +395,352 @@ func (c *Core) watchLoop(watcher *fsnotify.Watcher) {
 			if !ok {
 				return
 			}
+			// Events may have been lost (e.g. queue overflow); start over.
 			c.logWarn("filesystem watcher error: %v", err)
+			requestResync()
 		}
 	}
 }
 
+// pollLoop is the watch loop of poll mode: it scans modification times
+// every poll interval, and a scan also serves the WaitIdle calls made
+// before it.
+func (c *Core) pollLoop(mtimes map[string]time.Time) {
+	ticker := time.NewTicker(cmp.Or(c.config.GetWatcher().PollInterval(), pollInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			waiting := c.pendingBarriers()
+			c.handleChanges(c.pollForChanges(mtimes, nil))
+			for name, done := range waiting {
+				_ = os.Remove(filepath.Join(c.root, name))
+				close(done)
+			}
+		}
+	}
+}
+
+// Watch modes reported by WatchMode and ProbeWatchMode.
+const (
+	// WatchModeFSNotify watches with fsnotify, polling as a safety net.
+	WatchModeFSNotify = "fsnotify"
+	// WatchModePoll only scans modification times (watcher.poll_fallback).
+	WatchModePoll = "poll"
+)
+
+// WatchMode returns how the running watcher sees changes, one of the
+// WatchMode constants, or "" when the core is not watching.
+func (c *Core) WatchMode() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.watchMode
+}
+
+// ProbeWatchMode reports how a watcher started now would see changes:
+// WatchModeFSNotify, or when fsnotify cannot watch the data directory,
+// WatchModePoll with watcher.poll_fallback set and "" (watching fails)
+// without it, along with fsnotify's error.
+func (c *Core) ProbeWatchMode() (string, error) {
+	watcher, err := openWatcher(c.root)
+	if err == nil {
+		watcher.Close() //nolint:errcheck // probe only
+		return WatchModeFSNotify, nil
+	}
+	if c.config.GetWatcher().PollFallback {
+		return WatchModePoll, err
+	}
+	return "", err
+}
+
+// addWatch adds a path to an fsnotify watcher; tests replace it to make
+// fsnotify fail.
+var addWatch = func(w *fsnotify.Watcher, path string) error { return w.Add(path) }
+
+// openWatcher returns an fsnotify watcher watching root.
+func openWatcher(root string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := addWatch(watcher, root); err != nil {
+		watcher.Close() //nolint:errcheck // cleanup on error path
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// stopper is a scheduled call that can be cancelled, like *time.Timer.
+type stopper interface{ Stop() bool }
+
+// afterFunc schedules the debounce timer; tests replace it to fire the timer
+// by hand.
+var afterFunc = func(d time.Duration, f func()) stopper { return time.AfterFunc(d, f) }
+
+// debouncer collects file changes and hands them to flush as one batch once
+// delay passes without another change, or at once when maxBatch files are
+// pending. With sync set, every change is flushed as soon as it is added.
+type debouncer struct {
+	delay    time.Duration
+	sync     bool
+	maxBatch int // 0 means no limit
+	flush    func(map[string]fsnotify.Op)
+
+	mu      sync.Mutex // guards pending and timer
+	pending map[string]fsnotify.Op
+	timer   stopper
+
+	flushMu sync.Mutex // held while a batch is handled
+}
+
+func newDebouncer(delay time.Duration, sync bool, flush func(map[string]fsnotify.Op)) *debouncer {
+	return &debouncer{delay: delay, sync: sync, flush: flush, pending: make(map[string]fsnotify.Op)}
+}
+
+// add records a change and restarts the timer.
+func (d *debouncer) add(path string, op fsnotify.Op) {
+	d.mu.Lock()
+	d.pending[path] |= op
+	now := d.sync || (d.maxBatch > 0 && len(d.pending) >= d.maxBatch)
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if !now {
+		d.timer = afterFunc(d.delay, d.fire)
+	}
+	d.mu.Unlock()
+
+	if now {
+		d.fire()
+	}
+}
+
+// fire flushes everything pending as one batch.
+func (d *debouncer) fire() {
+	d.flushMu.Lock()
+	defer d.flushMu.Unlock()
+
+	d.mu.Lock()
+	changes := d.pending
+	d.pending = make(map[string]fsnotify.Op)
+	d.mu.Unlock()
+
+	if len(changes) > 0 {
+		d.flush(changes)
+	}
+}
+
+// drain flushes what is pending without waiting for the timer, after any
+// batch already being flushed has finished.
+func (d *debouncer) drain() {
+	d.mu.Lock()
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+	d.fire()
+}
+
+// reset stops the timer and drops pending changes.
+func (d *debouncer) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.pending = make(map[string]fsnotify.Op)
+}
+
+// barrierPrefix starts the name of the hidden marker file WaitIdle writes.
+const barrierPrefix = ".jig-idle-"
+
+// WaitIdle blocks until the watcher has handled every change made in the
+// data directory before the call, and notified subscribers of it, or until
+// ctx is done. It returns at once when the core is not watching. It works by
+// writing a hidden marker file and waiting for the watcher to read it, so
+// changes are flushed without waiting out the debounce; in poll mode it
+// waits for the next scan. Intended for tests.
+func (c *Core) WaitIdle(ctx context.Context) error {
+	c.mu.RLock()
+	watching := c.watching
+	c.mu.RUnlock()
+	if !watching {
+		return nil
+	}
+
+	name := barrierPrefix + strconv.FormatUint(atomic.AddUint64(&c.nextBarrier, 1), 10)
+	done := make(chan struct{})
+	c.barrierMu.Lock()
+	if c.barriers == nil {
+		c.barriers = make(map[string]chan struct{})
+	}
+	c.barriers[name] = done
+	c.barrierMu.Unlock()
+	defer func() {
+		c.barrierMu.Lock()
+		delete(c.barriers, name)
+		c.barrierMu.Unlock()
+	}()
+
+	path := filepath.Join(c.root, name)
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		return err
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		_ = os.Remove(path)
+		return ctx.Err()
+	}
+}
+
+// pendingBarriers takes every pending WaitIdle call, by marker file name.
+func (c *Core) pendingBarriers() map[string]chan struct{} {
+	c.barrierMu.Lock()
+	defer c.barrierMu.Unlock()
+	waiting := c.barriers
+	c.barriers = nil
+	return waiting
+}
+
+// takeBarrier returns the channel of the WaitIdle call whose marker file is
+// path, removing it from the pending calls, or nil if path is not a marker.
+func (c *Core) takeBarrier(path string) chan struct{} {
+	if filepath.Dir(path) != filepath.Clean(c.root) || !strings.HasPrefix(filepath.Base(path), barrierPrefix) {
+		return nil
+	}
+	c.barrierMu.Lock()
+	defer c.barrierMu.Unlock()
+	done := c.barriers[filepath.Base(path)]
+	delete(c.barriers, filepath.Base(path))
+	return done
+}
+
+// rewatch drops every existing watch and re-adds the root and all of its
+// subdirectories that are not ignored, so watches follow a directory tree
+// that was replaced.
+func (c *Core) rewatch(watcher *fsnotify.Watcher) {
+	for _, path := range watcher.WatchList() {
+		_ = watcher.Remove(path)
+	}
+	_ = filepath.WalkDir(c.root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil //nolint:nilerr // best-effort: skip unwatchable dirs
+		}
+		if path != c.root && c.isIgnored(path, true) {
+			return filepath.SkipDir
+		}
+		_ = watcher.Add(path)
+		return nil
+	})
+}
+
+// isMassRemoval reports whether a batch removes more than half of the known
+// issues (and at least massRemovalMin), which is how a branch switch looks
+// from inside the directory.
+func (c *Core) isMassRemoval(changes map[string]fsnotify.Op) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	removed := 0
+	for path, op := range changes {
+		if op&(fsnotify.Remove|fsnotify.Rename) == 0 {
+			continue
+		}
+		id, _ := c.idMatcher.ParseFilename(filepath.Base(path))
+		if _, known := c.issues[id]; known {
+			removed++
+		}
+	}
+	return removed >= massRemovalMin && removed*2 > len(c.issues)
+}
+
+// resync reloads all issues from disk and fans out the created, updated, and
+// deleted events that turn the old in-memory state into the new one. It
+// returns false, leaving the old state in place, if loading fails.
+func (c *Core) resync() bool {
+	c.mu.Lock()
+	if !c.watching {
+		c.mu.Unlock()
+		return true
+	}
+
+	oldIssues, oldMilestones := c.issues, c.milestones
+	if err := c.loadFromDisk(); err != nil {
+		c.issues, c.milestones = oldIssues, oldMilestones
+		c.rebuildLinkIndexLocked()
+		c.mu.Unlock()
+		c.logWarn("failed to reload issues after directory change: %v", err)
+		return false
+	}
+
+	// Issues this process rewrote diff against what their files held before.
+	before := maps.Clone(oldIssues)
+	for id, prev := range c.written {
+		if _, ok := before[id]; ok {
+			before[id] = prev
+		}
+	}
+	clear(c.written)
+	events := diffIssues(before, c.issues)
+	callback := c.onChange
+	c.mu.Unlock()
+
+	c.fanOut(events)
+	if callback != nil {
+		callback()
+	}
+	return true
+}
+
+// diffIssues returns the events that turn before into after, ordered by ID.
+func diffIssues(before, after map[string]*issue.Issue) []IssueEvent {
+	var events []IssueEvent
+	for id, b := range after {
+		prev, existed := before[id]
+		switch {
+		case !existed:
+			events = append(events, IssueEvent{Type: EventCreated, Issue: b, IssueID: id})
+		case prev.ETag() != b.ETag():
+			events = append(events, IssueEvent{
+				Type:          EventUpdated,
+				Issue:         b,
+				IssueID:       id,
+				ChangedFields: changedFields(prev, b),
+			})
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			events = append(events, IssueEvent{Type: EventDeleted, IssueID: id})
+		}
+	}
+	slices.SortFunc(events, func(a, b IssueEvent) int { return cmp.Compare(a.IssueID, b.IssueID) })
+	return events
+}
+
 // snapshotMtimes walks the issues directory and returns a map of file path to modification time
-// for all .md files.
+// for all .md files that are not ignored.
 func (c *Core) snapshotMtimes() map[string]time.Time {
 	mtimes := make(map[string]time.Time)
 	_ = filepath.WalkDir(c.root, func(path string, d os.DirEntry, err error) error {
-		if err != nil || d.IsDir() {
+		if err != nil {
 			return nil //nolint:nilerr // best-effort walk: skip errors
 		}
-		if strings.HasSuffix(path, ".md") {
+		if path != c.root && c.isIgnored(path, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".md") {
 			if info, err := d.Info(); err == nil {
 				mtimes[path] = info.ModTime()
 			}
@@ -287,7 +752,8 @@ func (c *Core) snapshotMtimes() map[string]time.Time {
 
 // pollForChanges walks the issues directory, compares mtimes against a cached map,
 // and returns a map of changed file paths to fsnotify ops. It also watches any new
-// subdirectories discovered during the walk. The mtimes map is updated in place.
+// subdirectories discovered during the walk, unless watcher is nil (poll mode).
+// The mtimes map is updated in place.
 func (c *Core) pollForChanges(mtimes map[string]time.Time, watcher *fsnotify.Watcher) map[string]fsnotify.Op {
 	changes := make(map[string]fsnotify.Op)
 	seen := make(map[string]bool)
@@ -296,9 +762,15 @@ func (c *Core) pollForChanges(mtimes map[string]time.Time, watcher *fsnotify.Wat
 		if err != nil {
 			return nil //nolint:nilerr // best-effort walk: skip errors
 		}
+		if path != c.root && c.isIgnored(path, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if d.IsDir() {
 			// Watch new subdirectories
-			if path != c.root {
+			if path != c.root && watcher != nil {
 				_ = watcher.Add(path)
 			}
 			return nil
@@ -354,9 +826,21 @@ func (c *Core) handleChanges(changes map[string]fsnotify.Op) {
 
 	var events []IssueEvent
 
-	for path, op := range changes {
+	// Load created and written files before handling removals, so a rename
+	// (old name removed, new name created) reads as one update.
+	paths := slices.Collect(maps.Keys(changes))
+	slices.SortFunc(paths, func(a, b string) int {
+		return cmp.Compare(changes[a]&(fsnotify.Remove|fsnotify.Rename), changes[b]&(fsnotify.Remove|fsnotify.Rename))
+	})
+
+	for _, path := range paths {
+		op := changes[path]
+		// The ignore file may have changed since the event was queued.
+		if c.isIgnoredLocked(path, false) {
+			continue
+		}
 		filename := filepath.Base(path)
-		id, _ := issue.ParseFilename(filename)
+		id, _ := c.idMatcher.ParseFilename(filename)
 
 		// Milestone files live in the milestones subdirectory and are not issues.
 		// Keep the in-memory milestone map fresh, but never insert them into
@@ -369,8 +853,9 @@ func (c *Core) handleChanges(changes map[string]fsnotify.Op) {
 			} else if op&fsnotify.Create != 0 || op&fsnotify.Write != 0 {
 				if m, err := loadMilestoneFile(path, c.root); err == nil {
 					c.milestones[m.ID] = m
+					c.clearWarningLocked(m.Path)
 				} else {
-					c.logWarn("failed to load milestone from %s: %v", path, err)
+					c.addWarningLocked(path, WarnParse, err)
 				}
 			}
 			continue
@@ -378,11 +863,21 @@ func (c *Core) handleChanges(changes map[string]fsnotify.Op) {
 
 		// Handle removes/renames (file is gone)
 		if op&fsnotify.Remove != 0 || op&fsnotify.Rename != 0 {
+			if !c.fileExists(path) {
+				if rel, err := filepath.Rel(c.root, path); err == nil {
+					c.clearWarningLocked(rel)
+				}
+			}
 			// Check if the file actually exists (rename might be followed by create)
-			if _, exists := c.issues[id]; exists {
-				// Only delete if it was in our map and file is actually gone
-				if !c.fileExists(path) {
+			if b, exists := c.issues[id]; exists {
+				// Only delete if it was in our map, this was its file, and the
+				// file is actually gone
+				if filepath.Join(c.root, b.Path) == path && !c.fileExists(path) {
 					delete(c.issues, id)
+					delete(c.written, id)
+					c.unindexMentionsLocked(id)
+					c.unindexLinksLocked(id)
+					c.forgetDuplicatesLocked(id)
 
 					// Update search index
 					if c.searchIndex != nil {
@@ -405,12 +900,18 @@ func (c *Core) handleChanges(changes map[string]fsnotify.Op) {
 		if op&fsnotify.Create != 0 || op&fsnotify.Write != 0 {
 			newIssue, err := c.loadIssue(path)
 			if err != nil {
-				c.logWarn("failed to load issue from %s: %v", path, err)
+				c.addWarningLocked(path, loadWarningKind(err), err)
 				continue
 			}
 
-			_, existed := c.issues[newIssue.ID]
+			prev, existed := c.issues[newIssue.ID]
+			c.clearWarningLocked(newIssue.Path)
+			c.checkTimesLocked(newIssue)
 			c.issues[newIssue.ID] = newIssue
+			delete(c.duplicates, newIssue.ID)
+			c.indexMentionsLocked(newIssue)
+			c.indexLinksLocked(newIssue)
+			c.dedupeLinksLocked(newIssue)
 
 			// Update search index
 			if c.searchIndex != nil {
@@ -421,9 +922,10 @@ func (c *Core) handleChanges(changes map[string]fsnotify.Op) {
 
 			if existed {
 				events = append(events, IssueEvent{
-					Type:    EventUpdated,
-					Issue:   newIssue,
-					IssueID: newIssue.ID,
+					Type:          EventUpdated,
+					Issue:         newIssue,
+					IssueID:       newIssue.ID,
+					ChangedFields: c.changedFieldsLocked(prev, newIssue),
 				})
 			} else {
 				events = append(events, IssueEvent{
@@ -1,12 +1,21 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
 )
 
 func TestPollForChanges(t *testing.T) {
@@ -189,3 +198,474 @@ func TestHandleChangesIgnoresMilestoneFiles(t *testing.T) {
 		t.Fatalf("removed milestone still present in c.milestones")
 	}
 }
+
+func TestWatchDirectoryReplaced(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	kept := createTestIssue(t, core, "keep", "Keep", "ready")
+	edited := createTestIssue(t, core, "edit", "Edit", "ready")
+	createTestIssue(t, core, "gone", "Gone", "ready")
+
+	if err := core.StartWatching(); err != nil {
+		t.Fatalf("StartWatching() error = %v", err)
+	}
+	defer core.Unwatch()
+	ch, unsub := core.Subscribe()
+	defer unsub()
+	time.Sleep(50 * time.Millisecond)
+
+	// Build the "other branch" beside the data dir, then swap it in the way
+	// git does: move the old tree away and the new one into place.
+	next := dataDir + ".next"
+	if err := os.Mkdir(next, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	keepRaw, err := os.ReadFile(filepath.Join(dataDir, kept.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		kept.Path:          string(keepRaw),
+		edited.Path:        "---\ntitle: Edited on branch\nstatus: todo\n---\n",
+		"ab/new--fresh.md": "---\ntitle: Fresh\nstatus: todo\n---\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(next, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Rename(dataDir, dataDir+".old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(next, dataDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay events onto the pre-swap state, as a subscriber would.
+	state := map[string]string{"keep": "Keep", "edit": "Edit", "gone": "Gone"}
+	want := map[string]string{"keep": "Keep", "edit": "Edited on branch", "new": "Fresh"}
+	deadline := time.After(5 * time.Second)
+	for !maps.Equal(state, want) {
+		select {
+		case events := <-ch:
+			for _, e := range events {
+				if e.Type == EventDeleted {
+					delete(state, e.IssueID)
+				} else {
+					state[e.IssueID] = e.Issue.Title
+				}
+			}
+		case <-deadline:
+			t.Fatalf("subscriber state = %v, want %v", state, want)
+		}
+	}
+
+	for id, title := range want {
+		if b, err := core.Get(id); err != nil || b.Title != title {
+			t.Errorf("Get(%q) = %v, %v; want title %q", id, b, err, title)
+		}
+	}
+	if _, err := core.Get("gone"); err == nil {
+		t.Error("issue from the old tree is still loaded")
+	}
+
+	// Watches follow the new tree: a later edit in a new subfolder is seen.
+	if err := os.WriteFile(filepath.Join(dataDir, "ab", "late--late.md"), []byte("---\ntitle: Late\nstatus: todo\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case events := <-ch:
+		if len(events) != 1 || events[0].IssueID != "late" {
+			t.Errorf("events after swap = %+v, want created late", events)
+		}
+	case <-time.After(time.Second):
+		t.Error("timeout waiting for event in replaced tree")
+	}
+}
+
+func TestDiffIssues(t *testing.T) {
+	before := map[string]*issue.Issue{
+		"a": {ID: "a", Title: "A"},
+		"b": {ID: "b", Title: "B"},
+		"c": {ID: "c", Title: "C"},
+	}
+	after := map[string]*issue.Issue{
+		"a": {ID: "a", Title: "A"},
+		"b": {ID: "b", Title: "B2"},
+		"d": {ID: "d", Title: "D"},
+	}
+	got := diffIssues(before, after)
+	want := []struct {
+		id  string
+		typ EventType
+	}{{"b", EventUpdated}, {"c", EventDeleted}, {"d", EventCreated}}
+	if len(got) != len(want) {
+		t.Fatalf("diffIssues() = %+v, want %v", got, want)
+	}
+	for i, w := range want {
+		if got[i].IssueID != w.id || got[i].Type != w.typ {
+			t.Errorf("event %d = %s %s, want %s %s", i, got[i].Type, got[i].IssueID, w.typ, w.id)
+		}
+	}
+	if !slices.Equal(got[0].ChangedFields, []string{"title"}) || got[2].ChangedFields != nil {
+		t.Errorf("changed fields = %v and %v, want [title] and none", got[0].ChangedFields, got[2].ChangedFields)
+	}
+}
+
+func TestEventChangedFields(t *testing.T) {
+	edits := []struct {
+		name string
+		edit func(*issue.Issue)
+		want []string
+	}{
+		{"metadata", func(b *issue.Issue) { b.Priority = "high"; b.Tags = []string{"auth"} }, []string{"priority", "tags"}},
+		{"body", func(b *issue.Issue) { b.Body = "Fixed a typo." }, []string{"body"}},
+		{"status", func(b *issue.Issue) { b.Status = "completed" }, []string{"status", "status:ready→completed"}},
+	}
+	writes := map[string]func(t *testing.T, c *Core, dataDir string, b *issue.Issue){
+		"watcher": func(t *testing.T, _ *Core, dataDir string, b *issue.Issue) {
+			content, err := b.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dataDir, b.Path), content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"update": func(t *testing.T, c *Core, _ string, b *issue.Issue) {
+			if err := c.Update(b, nil); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+	for path, write := range writes {
+		for _, tt := range edits {
+			t.Run(path+"/"+tt.name, func(t *testing.T) {
+				c, dataDir := setupTestCore(t)
+				if err := c.Create(&issue.Issue{ID: "evt-001", Title: "Login", Status: "ready", Body: "Fix teh login."}); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.StartWatching(); err != nil {
+					t.Fatal(err)
+				}
+				defer c.Unwatch()
+				ch, unsub := c.Subscribe()
+				defer unsub()
+
+				b, _ := c.Get("evt-001")
+				cp := *b
+				tt.edit(&cp)
+				write(t, c, dataDir, &cp)
+				waitIdle(t, c)
+
+				var got *IssueEvent
+				for _, e := range receiveEvents(ch) {
+					if e.IssueID == "evt-001" {
+						got = &e
+					}
+				}
+				if got == nil || got.Type != EventUpdated {
+					t.Fatalf("event = %+v, want evt-001 updated", got)
+				}
+				if !slices.Equal(got.ChangedFields, tt.want) {
+					t.Errorf("ChangedFields = %v, want %v", got.ChangedFields, tt.want)
+				}
+				if got.HasChanged(issue.FieldStatus) != (tt.name == "status") {
+					t.Errorf("HasChanged(status) = %v", got.HasChanged(issue.FieldStatus))
+				}
+			})
+		}
+	}
+}
+
+func TestIsMassRemoval(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	var paths []string
+	for _, id := range []string{"aa", "bb", "cc", "dd", "ee"} {
+		b := createTestIssue(t, core, id, "Issue "+id, "ready")
+		paths = append(paths, filepath.Join(dataDir, b.Path))
+	}
+
+	removals := func(n int) map[string]fsnotify.Op {
+		changes := map[string]fsnotify.Op{
+			filepath.Join(dataDir, "zz--unknown.md"): fsnotify.Remove,
+			paths[4]:                                 fsnotify.Write,
+		}
+		for _, p := range paths[:n] {
+			changes[p] = fsnotify.Remove
+		}
+		return changes
+	}
+	if core.isMassRemoval(removals(2)) {
+		t.Error("removing 2 of 5 issues should not count as a mass removal")
+	}
+	if !core.isMassRemoval(removals(3)) {
+		t.Error("removing 3 of 5 issues should count as a mass removal")
+	}
+}
+
+// fakeTimers replaces afterFunc for the rest of the test with timers that
+// only fire when the test calls them.
+type fakeTimers struct {
+	mu     sync.Mutex
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	delay   time.Duration
+	f       func()
+	stopped bool
+}
+
+func (ft *fakeTimer) Stop() bool {
+	ft.stopped = true
+	return true
+}
+
+func useFakeTimers(t *testing.T) *fakeTimers {
+	t.Helper()
+	ft := &fakeTimers{}
+	orig := afterFunc
+	afterFunc = func(d time.Duration, f func()) stopper {
+		ft.mu.Lock()
+		defer ft.mu.Unlock()
+		timer := &fakeTimer{delay: d, f: f}
+		ft.timers = append(ft.timers, timer)
+		return timer
+	}
+	t.Cleanup(func() { afterFunc = orig })
+	return ft
+}
+
+func (ft *fakeTimers) all() []*fakeTimer {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return slices.Clone(ft.timers)
+}
+
+func TestDebouncerCoalesces(t *testing.T) {
+	timers := useFakeTimers(t)
+	var batches []map[string]fsnotify.Op
+	d := newDebouncer(debounceDelay, false, func(changes map[string]fsnotify.Op) {
+		batches = append(batches, changes)
+	})
+
+	for range 5 {
+		d.add("/issues/a/abc--x.md", fsnotify.Write)
+	}
+	d.add("/issues/b/bcd--y.md", fsnotify.Create)
+
+	all := timers.all()
+	if len(all) != 6 {
+		t.Fatalf("scheduled %d timers, want one per change", len(all))
+	}
+	for i, timer := range all {
+		if timer.delay != debounceDelay {
+			t.Errorf("timer %d delay = %v, want %v", i, timer.delay, debounceDelay)
+		}
+		if last := i == len(all)-1; timer.stopped == last {
+			t.Errorf("timer %d stopped = %v, want only the latest running", i, timer.stopped)
+		}
+	}
+	if len(batches) != 0 {
+		t.Fatalf("flushed %d batches before the timer fired", len(batches))
+	}
+
+	all[len(all)-1].f()
+	want := map[string]fsnotify.Op{"/issues/a/abc--x.md": fsnotify.Write, "/issues/b/bcd--y.md": fsnotify.Create}
+	if len(batches) != 1 || !maps.Equal(batches[0], want) {
+		t.Fatalf("batches = %v, want one batch %v", batches, want)
+	}
+
+	// A stopped timer that fires anyway finds nothing left to flush.
+	all[0].f()
+	if len(batches) != 1 {
+		t.Errorf("stale timer flushed another batch: %v", batches)
+	}
+}
+
+func TestWatchDebounceDefault(t *testing.T) {
+	if debounceDelay != 100*time.Millisecond {
+		t.Fatalf("debounceDelay = %v; changing it changes how editors' saves are batched", debounceDelay)
+	}
+
+	timers := useFakeTimers(t)
+	core, dataDir := setupTestCore(t)
+	b := createTestIssue(t, core, "deb-1", "Debounced", "ready")
+	if err := core.StartWatching(); err != nil {
+		t.Fatal(err)
+	}
+	defer core.Unwatch()
+	ch, unsub := core.Subscribe()
+	defer unsub()
+
+	path := filepath.Join(dataDir, b.Path)
+	for i := range 3 {
+		content := fmt.Sprintf("---\ntitle: Rewrite %d\nstatus: ready\n---\n", i)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Wait for the watcher to read a write; the fake timer keeps it from
+	// flushing.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(timers.all()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("watcher scheduled no debounce timer")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if delay := timers.all()[0].delay; delay != debounceDelay {
+		t.Errorf("watcher debounce = %v, want %v", delay, debounceDelay)
+	}
+	select {
+	case events := <-ch:
+		t.Fatalf("events delivered before the debounce timer fired: %+v", events)
+	default:
+	}
+
+	// The writes coalesce into one update carrying the last of them.
+	waitIdle(t, core)
+	events := receiveEvents(ch)
+	if len(events) != 1 || events[0].Type != EventUpdated || events[0].Issue.Title != "Rewrite 2" {
+		t.Errorf("events = %+v, want one update with the last write", events)
+	}
+}
+
+func TestWatchDebounceConfig(t *testing.T) {
+	timers := useFakeTimers(t)
+	core, dataDir := setupTestCore(t, func(cfg *config.Config) {
+		cfg.Watcher = config.WatcherConfig{DebounceMs: 400, MaxBatch: 2}
+	})
+	a := createTestIssue(t, core, "deb-a", "First", "ready")
+	b := createTestIssue(t, core, "deb-b", "Second", "ready")
+	if err := core.StartWatching(); err != nil {
+		t.Fatal(err)
+	}
+	defer core.Unwatch()
+	ch, unsub := core.Subscribe()
+	defer unsub()
+
+	if err := os.WriteFile(filepath.Join(dataDir, a.Path), []byte("---\ntitle: First again\nstatus: ready\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(timers.all()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("watcher scheduled no debounce timer")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if delay := timers.all()[0].delay; delay != 400*time.Millisecond {
+		t.Errorf("watcher debounce = %v, want debounce_ms 400", delay)
+	}
+
+	// A second changed file fills the batch, which is handled without
+	// waiting for the timer.
+	if err := os.WriteFile(filepath.Join(dataDir, b.Path), []byte("---\ntitle: Second again\nstatus: ready\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case events := <-ch:
+		if len(events) != 2 {
+			t.Errorf("events = %+v, want both updates in one batch", events)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("max_batch did not flush the full batch")
+	}
+}
+
+func TestWatchPollFallback(t *testing.T) {
+	orig := addWatch
+	addWatch = func(*fsnotify.Watcher, string) error { return errors.New("no inotify here") }
+	t.Cleanup(func() { addWatch = orig })
+
+	strict, _ := setupTestCore(t)
+	if err := strict.StartWatching(); err == nil {
+		strict.Unwatch()
+		t.Fatal("StartWatching() without poll_fallback succeeded although fsnotify failed")
+	}
+	if mode, err := strict.ProbeWatchMode(); mode != "" || err == nil {
+		t.Errorf("ProbeWatchMode() = %q, %v; want no mode and fsnotify's error", mode, err)
+	}
+
+	core, dataDir := setupTestCore(t, func(cfg *config.Config) {
+		cfg.Watcher = config.WatcherConfig{PollFallback: true, PollIntervalMs: 10}
+	})
+	if mode, err := core.ProbeWatchMode(); mode != WatchModePoll || err == nil {
+		t.Errorf("ProbeWatchMode() = %q, %v; want poll with fsnotify's error", mode, err)
+	}
+	if err := core.StartWatching(); err != nil {
+		t.Fatalf("StartWatching() with poll_fallback = %v", err)
+	}
+	defer core.Unwatch()
+	if mode := core.WatchMode(); mode != WatchModePoll {
+		t.Errorf("WatchMode() = %q, want %q", mode, WatchModePoll)
+	}
+	if w := core.Warnings(); len(w) != 1 || w[0].Kind != WarnPollFallback || w[0].Path != "." {
+		t.Errorf("Warnings() = %v, want one poll-fallback warning", w)
+	}
+	ch, unsub := core.Subscribe()
+	defer unsub()
+
+	path := filepath.Join(dataDir, "pol-1--polled.md")
+	if err := os.WriteFile(path, []byte("---\ntitle: Polled\nstatus: ready\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, core)
+	if events := receiveEvents(ch); len(events) != 1 || events[0].Type != EventCreated || events[0].IssueID != "pol-1" {
+		t.Errorf("events after create = %+v, want pol-1 created", events)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, core)
+	if events := receiveEvents(ch); len(events) != 1 || events[0].Type != EventDeleted || events[0].IssueID != "pol-1" {
+		t.Errorf("events after delete = %+v, want pol-1 deleted", events)
+	}
+	if _, err := core.Get("pol-1"); err == nil {
+		t.Error("pol-1 still loaded after its file was deleted")
+	}
+}
+
+func TestSyncDelivery(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	core.SetSyncDelivery(true)
+	if err := core.StartWatching(); err != nil {
+		t.Fatal(err)
+	}
+	defer core.Unwatch()
+	ch, unsub := core.Subscribe()
+	defer unsub()
+
+	content := "---\ntitle: Synced\nstatus: ready\n---\n"
+	if err := os.WriteFile(filepath.Join(dataDir, "syn-1--synced.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case events := <-ch:
+		if events[0].IssueID != "syn-1" {
+			t.Errorf("events = %+v, want syn-1", events)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event delivered")
+	}
+}
+
+func TestWaitIdleNotWatching(t *testing.T) {
+	core, dataDir := setupTestCore(t)
+	if err := core.WaitIdle(context.Background()); err != nil {
+		t.Errorf("WaitIdle() without a watcher = %v, want nil", err)
+	}
+	entries, _ := os.ReadDir(dataDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), barrierPrefix) {
+			t.Errorf("marker %s left behind", e.Name())
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Package dot renders the issue graph as a Graphviz DOT digraph: issues as
+// nodes, with solid edges from parent to child and dashed edges from
+// blocker to blocked.
+package dot
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// DefaultTitleWidth is how many characters of the title a node label shows.
+const DefaultTitleWidth = 32
+
+// Edge kinds.
+const (
+	EdgeParent   = "parent"
+	EdgeBlocking = "blocking"
+)
+
+// typeShapes gives each built-in type its node shape; other types are boxes.
+var typeShapes = map[string]string{
+	config.TypeMilestone: "tab",
+	config.TypeEpic:      "folder",
+	config.TypeBug:       "octagon",
+	config.TypeFeature:   "component",
+	config.TypeTask:      "box",
+}
+
+// Options controls which issues are drawn and how.
+type Options struct {
+	// Config supplies type and status colors and which statuses are
+	// resolved. Nil means config.Default().
+	Config *config.Config
+	// Root restricts the graph to the issues reachable from this issue ID
+	// through child and blocking edges.
+	Root string
+	// Depth limits how many edges from Root an issue may be; 0 is no limit.
+	Depth int
+	// IncludeResolved keeps issues in archive statuses, which are left out
+	// by default.
+	IncludeResolved bool
+	// Milestones names the clusters issues are grouped into when there is
+	// no Root. Issues whose milestone is not listed are drawn ungrouped.
+	Milestones []*issue.Milestone
+	// TitleWidth truncates titles in labels (default DefaultTitleWidth).
+	TitleWidth int
+}
+
+// edge is a directed edge between two drawn issues.
+type edge struct {
+	from, to, kind string
+	// cycle is the path the edge closes, from its target back to it, or
+	// nil when it closes none.
+	cycle []string
+}
+
+// Write renders issues as a DOT digraph. It is an error for Root to name
+// an issue that is not among them.
+func Write(w io.Writer, issues []*issue.Issue, opts Options) error {
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	width := cmp.Or(opts.TitleWidth, DefaultTitleWidth)
+
+	byID := make(map[string]*issue.Issue, len(issues))
+	for _, b := range issues {
+		byID[b.ID] = b
+	}
+	keep := func(b *issue.Issue) bool {
+		return opts.IncludeResolved || !cfg.IsArchiveStatus(b.Status)
+	}
+
+	// Outgoing edges between kept issues, deduplicated.
+	out := make(map[string][]edge)
+	seen := make(map[[3]string]bool)
+	addEdge := func(from, to, kind string) {
+		f, t := byID[from], byID[to]
+		if f == nil || t == nil || from == to || !keep(f) || !keep(t) {
+			return
+		}
+		if key := [3]string{from, to, kind}; !seen[key] {
+			seen[key] = true
+			out[from] = append(out[from], edge{from: from, to: to, kind: kind})
+		}
+	}
+	for _, b := range issues {
+		if b.Parent != "" {
+			addEdge(b.Parent, b.ID, EdgeParent)
+		}
+		for _, id := range b.Blocking {
+			addEdge(b.ID, id, EdgeBlocking)
+		}
+		for _, id := range b.BlockedBy {
+			addEdge(id, b.ID, EdgeBlocking)
+		}
+	}
+	for id := range out {
+		slices.SortFunc(out[id], compareEdges)
+	}
+
+	nodes := make(map[string]bool)
+	if opts.Root != "" {
+		root, ok := byID[opts.Root]
+		if !ok {
+			return fmt.Errorf("issue not found: %s", opts.Root)
+		}
+		if !keep(root) {
+			return fmt.Errorf("issue %s is resolved (use --include-resolved to draw it)", root.ID)
+		}
+		reach(opts.Root, opts.Depth, out, nodes)
+	} else {
+		for _, b := range issues {
+			if keep(b) {
+				nodes[b.ID] = true
+			}
+		}
+	}
+
+	var edges []edge
+	for from := range nodes {
+		for _, e := range out[from] {
+			if nodes[e.to] {
+				edges = append(edges, e)
+			}
+		}
+	}
+	slices.SortFunc(edges, compareEdges)
+	markCycles(sortedKeys(nodes), edges)
+
+	fmt.Fprintln(w, "digraph issues {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [fontname="Helvetica", fontsize=10, style=filled];`)
+	fmt.Fprintln(w, `  edge [fontname="Helvetica", fontsize=9];`)
+
+	writeNode := func(indent string, b *issue.Issue) {
+		colors := cfg.GetIssueColors(b.Status, b.Type, b.Priority)
+		shape := cmp.Or(typeShapes[b.Type], "box")
+		typeColor := ui.HexColor(cmp.Or(colors.TypeColor, "gray"))
+		attrs := []string{
+			"label=" + Quote(truncate(b.Title, width)+"\n"+b.ID),
+			"shape=" + shape,
+			"color=" + Quote(ui.HexColor(colors.StatusColor)),
+			"fillcolor=" + Quote(typeColor+"33"),
+			"tooltip=" + Quote(b.Type+", "+b.Status),
+		}
+		if colors.IsArchive {
+			attrs = append(attrs, `style="filled,dashed"`)
+		}
+		fmt.Fprintf(w, "%s%s [%s];\n", indent, Quote(b.ID), strings.Join(attrs, ", "))
+	}
+
+	ids := sortedKeys(nodes)
+	grouped := make(map[string]bool)
+	if opts.Root == "" {
+		milestones := slices.Clone(opts.Milestones)
+		slices.SortFunc(milestones, func(a, b *issue.Milestone) int { return cmp.Compare(a.ID, b.ID) })
+		for _, m := range milestones {
+			var members []*issue.Issue
+			for _, id := range ids {
+				if byID[id].Milestone == m.ID {
+					members = append(members, byID[id])
+				}
+			}
+			if len(members) == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "  subgraph %s {\n", Quote("cluster_"+m.ID))
+			fmt.Fprintf(w, "    label=%s;\n", Quote(cmp.Or(m.Name, m.ID)))
+			fmt.Fprintln(w, "    style=rounded;")
+			for _, b := range members {
+				writeNode("    ", b)
+				grouped[b.ID] = true
+			}
+			fmt.Fprintln(w, "  }")
+		}
+	}
+	for _, id := range ids {
+		if !grouped[id] {
+			writeNode("  ", byID[id])
+		}
+	}
+
+	for _, e := range edges {
+		var attrs []string
+		if e.kind == EdgeBlocking {
+			attrs = append(attrs, "style=dashed")
+		}
+		if e.cycle != nil {
+			attrs = append(attrs, `color="red"`, "comment="+Quote("cycle: "+strings.Join(e.cycle, " -> ")))
+		}
+		suffix := ""
+		if len(attrs) > 0 {
+			suffix = " [" + strings.Join(attrs, ", ") + "]"
+		}
+		fmt.Fprintf(w, "  %s -> %s%s;\n", Quote(e.from), Quote(e.to), suffix)
+	}
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+// reach adds to nodes every issue within depth edges of root (any
+// distance when depth is 0). Each issue is visited once, so cycles end.
+func reach(root string, depth int, out map[string][]edge, nodes map[string]bool) {
+	nodes[root] = true
+	frontier := []string{root}
+	for d := 1; len(frontier) > 0 && (depth == 0 || d <= depth); d++ {
+		var next []string
+		for _, id := range frontier {
+			for _, e := range out[id] {
+				if !nodes[e.to] {
+					nodes[e.to] = true
+					next = append(next, e.to)
+				}
+			}
+		}
+		frontier = next
+	}
+}
+
+// markCycles finds the edges that close a cycle in a depth-first walk from
+// each node in order, and records on each the cycle it closes.
+func markCycles(nodes []string, edges []edge) {
+	out := make(map[string][]int)
+	for i, e := range edges {
+		out[e.from] = append(out[e.from], i)
+	}
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int, len(nodes))
+	var path []string
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = onPath
+		path = append(path, id)
+		for _, i := range out[id] {
+			to := edges[i].to
+			switch state[to] {
+			case unvisited:
+				visit(to)
+			case onPath:
+				start := slices.Index(path, to)
+				edges[i].cycle = append(slices.Clone(path[start:]), to)
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+	}
+	for _, id := range nodes {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+}
+
+// Quote returns s as a DOT quoted string, so any issue ID or title is a
+// valid identifier.
+func Quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// truncate shortens s to at most n characters, ending in an ellipsis when
+// it was cut.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimRight(string(r[:n-1]), " ") + "…"
+}
+
+func compareEdges(a, b edge) int {
+	return cmp.Or(cmp.Compare(a.from, b.from), cmp.Compare(a.to, b.to), cmp.Compare(a.kind, b.kind))
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
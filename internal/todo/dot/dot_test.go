@@ -0,0 +1,115 @@
+package dot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// fixture is a small project: an epic with two children, one blocking the
+// other, a bug blocking the epic, a done task, and an issue with an ID and
+// title that need quoting.
+func fixture() []*issue.Issue {
+	return []*issue.Issue{
+		{ID: "epic-1", Title: "Checkout rewrite", Status: "in-progress", Type: "epic", Milestone: "m-1"},
+		{ID: "feat-2", Title: "Card payments through the new provider API", Status: "ready", Type: "feature", Parent: "epic-1", Milestone: "m-1", Blocking: []string{"task-3"}},
+		{ID: "task-3", Title: "Receipt emails", Status: "draft", Type: "task", Parent: "epic-1", Milestone: "m-1", BlockedBy: []string{"feat-2"}},
+		{ID: "bug-4", Title: "Cart total off by a cent", Status: "ready", Type: "bug", Blocking: []string{"epic-1"}},
+		{ID: "task-5", Title: "Old checkout cleanup", Status: "completed", Type: "task", Parent: "epic-1", Milestone: "m-1"},
+		{ID: `odd"id`, Title: "Say \"hi\"\nand \\ bye", Status: "ready", Type: "task", Blocking: []string{"bug-4"}},
+	}
+}
+
+var milestones = []*issue.Milestone{{ID: "m-1", Name: "Launch"}}
+
+func render(t *testing.T, issues []*issue.Issue, opts Options) string {
+	t.Helper()
+	var b strings.Builder
+	if err := Write(&b, issues, opts); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	return b.String()
+}
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	want, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("%s output drifted from golden file:\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestWriteGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"all.dot", Options{Milestones: milestones}},
+		{"resolved.dot", Options{Milestones: milestones, IncludeResolved: true}},
+		{"root.dot", Options{Milestones: milestones, Root: "epic-1"}},
+		{"root_depth.dot", Options{Root: "bug-4", Depth: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name, render(t, fixture(), tt.opts))
+		})
+	}
+}
+
+func TestWriteCycle(t *testing.T) {
+	issues := []*issue.Issue{
+		{ID: "a", Title: "A", Status: "ready", Type: "task", Blocking: []string{"b"}},
+		{ID: "b", Title: "B", Status: "ready", Type: "task", Blocking: []string{"c"}},
+		{ID: "c", Title: "C", Status: "ready", Type: "task", Blocking: []string{"a"}},
+	}
+	for _, opts := range []Options{{}, {Root: "b"}} {
+		got := render(t, issues, opts)
+		if n := strings.Count(got, "comment=\"cycle:"); n != 1 {
+			t.Errorf("Root %q: %d cycle comments, want 1:\n%s", opts.Root, n, got)
+		}
+	}
+	assertGolden(t, "cycle.dot", render(t, issues, Options{}))
+}
+
+func TestWriteUnknownRoot(t *testing.T) {
+	var b strings.Builder
+	if err := Write(&b, fixture(), Options{Root: "nope"}); err == nil {
+		t.Error("Write() with unknown root: want error")
+	}
+	if err := Write(&b, fixture(), Options{Root: "task-5"}); err == nil {
+		t.Error("Write() with resolved root: want error")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", `"plain"`},
+		{`a"b`, `"a\"b"`},
+		{`a\b`, `"a\\b"`},
+		{"a\nb", `"a\nb"`},
+		{"a\r\nb", `"a\nb"`},
+		{"a -> b; c", `"a -> b; c"`},
+	}
+	for _, tt := range tests {
+		if got := Quote(tt.in); got != tt.want {
+			t.Errorf("Quote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("truncate(short) = %q", got)
+	}
+	if got := truncate("héllo wörld again", 8); got != "héllo w…" {
+		t.Errorf("truncate = %q, want %q", got, "héllo w…")
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// issueBatch holds the issues a linkIssues or mergeIssues batch changes:
+// the stored originals, the etags of their files when read, and the copies
+// the changes are made to, in first-seen order.
+type issueBatch struct {
+	order     []string
+	originals map[string]*issue.Issue
+	etags     map[string]string
+	copies    map[string]*issue.Issue
+}
+
+func newIssueBatch() *issueBatch {
+	return &issueBatch{
+		originals: make(map[string]*issue.Issue),
+		etags:     make(map[string]string),
+		copies:    make(map[string]*issue.Issue),
+	}
+}
+
+// copyOf returns the batch's copy of b, making it on first use. The lists
+// and sync data are cloned so editing them never touches the stored issue.
+func (ib *issueBatch) copyOf(c *core.Core, b *issue.Issue) (*issue.Issue, error) {
+	if cp, ok := ib.copies[b.ID]; ok {
+		return cp, nil
+	}
+	etag, err := c.DiskETag(b.ID)
+	if err != nil {
+		return nil, err
+	}
+	cp := *b
+	cp.Blocking = append([]string(nil), b.Blocking...)
+	cp.BlockedBy = append([]string(nil), b.BlockedBy...)
+	cp.Tags = slices.Clone(b.Tags)
+	cp.Sync = maps.Clone(b.Sync)
+	ib.order = append(ib.order, b.ID)
+	ib.originals[b.ID] = b
+	ib.etags[b.ID] = etag
+	ib.copies[b.ID] = &cp
+	return &cp, nil
+}
+
+// save writes every copy, each guarded by the etag its original was read
+// at. If a write fails, the copies already written are replaced by their
+// originals again so the batch is saved whole or not at all; failed names
+// the issue whose write was refused.
+func (ib *issueBatch) save(c *core.Core) (saved []*issue.Issue, failed string, err error) {
+	saved = make([]*issue.Issue, 0, len(ib.order))
+	for _, id := range ib.order {
+		etag := ib.etags[id]
+		if err := c.Update(ib.copies[id], &etag); err != nil {
+			return nil, id, errors.Join(err, ib.restore(c, saved))
+		}
+		saved = append(saved, ib.copies[id])
+	}
+	return saved, "", nil
+}
+
+// restore puts back the originals of the saved copies.
+func (ib *issueBatch) restore(c *core.Core, saved []*issue.Issue) error {
+	var errs []error
+	for _, b := range saved {
+		etag, err := c.DiskETag(b.ID)
+		if err == nil {
+			err = c.Update(ib.originals[b.ID], &etag)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("restoring %s: %w", b.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"os/user"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// ActorEnv names the environment variable that sets who the CLI and TUI
+// record as an issue's creator, in place of the OS user name.
+const ActorEnv = "JIG_ACTOR"
+
+// creatorKey is the context key of the Creator WithCreator installs.
+type creatorKey struct{}
+
+// Creator is who is creating issues and through which entry point, as
+// recorded in an issue's created_by and created_via.
+type Creator struct {
+	By  string
+	Via string // one of the issue.CreatedVia constants
+}
+
+// WithCreator returns ctx carrying c, which createIssue records on the
+// issues it creates. Without one, issues are recorded as created via
+// graphql, by the input's actor if it names one.
+func WithCreator(ctx context.Context, c Creator) context.Context {
+	return context.WithValue(ctx, creatorKey{}, c)
+}
+
+// creatorFrom returns the Creator ctx carries, defaulting Via to graphql.
+func creatorFrom(ctx context.Context) Creator {
+	c, _ := ctx.Value(creatorKey{}).(Creator)
+	c.Via = cmp.Or(c.Via, issue.CreatedViaGraphQL)
+	return c
+}
+
+// DefaultActor is who the local user acts as: $JIG_ACTOR, else the OS
+// user name, else "".
+func DefaultActor() string {
+	if actor := os.Getenv(ActorEnv); actor != "" {
+		return actor
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrCodeValidation is the extensions.code of errors caused by an input value
+// the config does not allow, such as an unknown priority, an oversized body,
+// a parent chain deeper than max_hierarchy_depth, a missing field a
+// validation rule requires, or malformed sync data.
+const ErrCodeValidation = "VALIDATION"
+
+// ErrCodeETagRequired is the extensions.code of an update rejected because
+// require_if_match protects a field it changes and no ifMatch was given.
+// extensions.field names the protected field class, so a client can fetch
+// the etag and retry.
+const ErrCodeETagRequired = "ETAG_REQUIRED"
+
+// ErrCodeConflict is the extensions.code of a mutation of an archived
+// issue, which must be unarchived before it can change, or of a body
+// replacement that would drop unchecked tasks under protect_unchecked_tasks
+// strict, which must be forced.
+const ErrCodeConflict = "CONFLICT"
+
+// presentError adds an extensions.code to resolver errors that clients can
+// act on; everything else is presented as gqlgen does by default.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	_, badValue := errors.AsType[*config.ValueError](err)
+	_, tooLarge := errors.AsType[*core.SizeError](err)
+	_, tooDeep := errors.AsType[*core.HierarchyDepthError](err)
+	_, badSync := errors.AsType[*syncutil.SchemaError](err)
+	_, brokeRule := errors.AsType[*core.RuleError](err)
+	if badValue || tooLarge || tooDeep || badSync || brokeRule {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]any{}
+		}
+		gqlErr.Extensions["code"] = ErrCodeValidation
+	}
+	if required, ok := errors.AsType[*core.ETagRequiredError](err); ok {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]any{}
+		}
+		gqlErr.Extensions["code"] = ErrCodeETagRequired
+		if required.Field != "" {
+			gqlErr.Extensions["field"] = required.Field
+		}
+	}
+	_, removesTasks := errors.AsType[*core.RemovedTasksError](err)
+	if errors.Is(err, core.ErrArchived) || removesTasks {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]any{}
+		}
+		gqlErr.Extensions["code"] = ErrCodeConflict
+	}
+	return gqlErr
+}
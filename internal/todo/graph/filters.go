@@ -2,7 +2,10 @@ package graph
 
 import (
 	"cmp"
+	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/toba/jig/internal/todo/config"
@@ -12,110 +15,254 @@ import (
 	"github.com/toba/jig/internal/todo/issue"
 )
 
-// ApplyFilter applies IssueFilter to a slice of issues and returns filtered results.
-// This is used by both the top-level issues query and relationship field resolvers.
-func ApplyFilter(issues []*issue.Issue, filter *model.IssueFilter, core *core.Core) []*issue.Issue {
+// Predicate is one condition of an IssueFilter. Test reports whether an
+// issue passes it and describes the data it looked at, so listing and
+// --explain share one implementation.
+type Predicate struct {
+	// Filter is the IssueFilter field, e.g. "excludeTags".
+	Filter string
+	// Arg is the value the filter was given, e.g. "[wip]" or "clickup".
+	Arg  string
+	Test func(*issue.Issue) (bool, string)
+}
+
+// Explanation is the outcome of one predicate for one issue.
+type Explanation struct {
+	Filter string `json:"filter"`
+	Arg    string `json:"arg"`
+	Pass   bool   `json:"pass"`
+	Detail string `json:"detail"`
+}
+
+// Predicates returns the conditions filter sets, in the order ApplyFilter
+// applies them. Search is not among them: the issues query narrows to
+// search results before filtering.
+func Predicates(filter *model.IssueFilter, core *core.Core) []Predicate {
 	if filter == nil {
-		return issues
+		return nil
 	}
+	var preds []Predicate
+	add := func(name, arg string, test func(*issue.Issue) (bool, string)) {
+		preds = append(preds, Predicate{Filter: name, Arg: arg, Test: test})
+	}
+	status := func(b *issue.Issue) string { return b.Status }
+	typ := func(b *issue.Issue) string { return b.Type }
+	milestone := func(b *issue.Issue) string { return b.Milestone }
+	iteration := func(b *issue.Issue) string { return b.Iteration }
+	priority := func(b *issue.Issue) string { return cmp.Or(b.Priority, config.PriorityNormal) }
+	flag := func(v *bool) bool { return v != nil && *v }
+	nonEmpty := func(v *string) bool { return v != nil && *v != "" }
 
-	result := issues
-
-	// Status filters
+	// Status, type, and priority filters (empty priority treated as "normal")
 	if len(filter.Status) > 0 {
-		result = filterByField(result, filter.Status, func(b *issue.Issue) string { return b.Status })
+		add("status", listArg(filter.Status), fieldIn("status", filter.Status, status, true))
 	}
 	if len(filter.ExcludeStatus) > 0 {
-		result = excludeByField(result, filter.ExcludeStatus, func(b *issue.Issue) string { return b.Status })
+		add("excludeStatus", listArg(filter.ExcludeStatus), fieldIn("status", filter.ExcludeStatus, status, false))
 	}
-
-	// Type filters
 	if len(filter.Type) > 0 {
-		result = filterByField(result, filter.Type, func(b *issue.Issue) string { return b.Type })
+		add("type", listArg(filter.Type), fieldIn("type", filter.Type, typ, true))
 	}
 	if len(filter.ExcludeType) > 0 {
-		result = excludeByField(result, filter.ExcludeType, func(b *issue.Issue) string { return b.Type })
+		add("excludeType", listArg(filter.ExcludeType), fieldIn("type", filter.ExcludeType, typ, false))
 	}
-
-	// Priority filters (empty priority treated as "normal")
 	if len(filter.Priority) > 0 {
-		result = filterByPriority(result, filter.Priority)
+		add("priority", listArg(filter.Priority), fieldIn("priority", filter.Priority, priority, true))
 	}
 	if len(filter.ExcludePriority) > 0 {
-		result = excludeByPriority(result, filter.ExcludePriority)
+		add("excludePriority", listArg(filter.ExcludePriority), fieldIn("priority", filter.ExcludePriority, priority, false))
 	}
 
 	// Tag filters
 	if len(filter.Tags) > 0 {
-		result = filterByTags(result, filter.Tags)
+		add("tags", listArg(filter.Tags), tagsIn(filter.Tags, true))
 	}
 	if len(filter.ExcludeTags) > 0 {
-		result = excludeByTags(result, filter.ExcludeTags)
+		add("excludeTags", listArg(filter.ExcludeTags), tagsIn(filter.ExcludeTags, false))
 	}
 
-	// Milestone filters
+	// Milestone and iteration filters
 	if len(filter.Milestone) > 0 {
-		result = filterByField(result, filter.Milestone, func(b *issue.Issue) string { return b.Milestone })
+		add("milestone", listArg(filter.Milestone), fieldIn("milestone", filter.Milestone, milestone, true))
 	}
 	if len(filter.ExcludeMilestone) > 0 {
-		result = excludeByField(result, filter.ExcludeMilestone, func(b *issue.Issue) string { return b.Milestone })
+		add("excludeMilestone", listArg(filter.ExcludeMilestone), fieldIn("milestone", filter.ExcludeMilestone, milestone, false))
+	}
+	if len(filter.Iteration) > 0 {
+		add("iteration", listArg(filter.Iteration), fieldIn("iteration", resolveIterations(filter.Iteration, core), iteration, true))
+	}
+	if len(filter.ExcludeIteration) > 0 {
+		add("excludeIteration", listArg(filter.ExcludeIteration), fieldIn("iteration", resolveIterations(filter.ExcludeIteration, core), iteration, false))
+	}
+
+	// Creator filters
+	if len(filter.CreatedBy) > 0 {
+		add("createdBy", listArg(filter.CreatedBy), fieldIn("created_by", filter.CreatedBy, func(b *issue.Issue) string { return b.CreatedBy }, true))
+	}
+	if len(filter.CreatedVia) > 0 {
+		add("createdVia", listArg(filter.CreatedVia), fieldIn("created_via", filter.CreatedVia, func(b *issue.Issue) string { return b.CreatedVia }, true))
 	}
 
 	// Parent filters
-	if filter.HasParent != nil && *filter.HasParent {
-		result = filterByHasParent(result)
+	if flag(filter.HasParent) {
+		add("hasParent", "true", func(b *issue.Issue) (bool, string) { return b.Parent != "", "parent: " + orNone(b.Parent) })
 	}
-	if filter.NoParent != nil && *filter.NoParent {
-		result = filterByNoParent(result)
+	if flag(filter.NoParent) {
+		add("noParent", "true", func(b *issue.Issue) (bool, string) { return b.Parent == "", "parent: " + orNone(b.Parent) })
 	}
-	if filter.ParentID != nil && *filter.ParentID != "" {
-		result = filterByParentID(result, *filter.ParentID)
+	if nonEmpty(filter.ParentID) {
+		id := *filter.ParentID
+		add("parentId", id, func(b *issue.Issue) (bool, string) { return b.Parent == id, "parent: " + orNone(b.Parent) })
 	}
 
 	// Blocking filters
-	if filter.HasBlocking != nil && *filter.HasBlocking {
-		result = filterByHasBlocking(result)
+	if flag(filter.HasBlocking) {
+		add("hasBlocking", "true", func(b *issue.Issue) (bool, string) { return len(b.Blocking) > 0, "blocking: " + listArg(b.Blocking) })
 	}
-	if filter.BlockingID != nil && *filter.BlockingID != "" {
-		result = filterByBlockingID(result, *filter.BlockingID)
+	if nonEmpty(filter.BlockingID) {
+		id := *filter.BlockingID
+		add("blockingId", id, func(b *issue.Issue) (bool, string) {
+			return slices.Contains(b.Blocking, id), "blocking: " + listArg(b.Blocking)
+		})
 	}
-	if filter.NoBlocking != nil && *filter.NoBlocking {
-		result = filterByNoBlocking(result)
+	if flag(filter.NoBlocking) {
+		add("noBlocking", "true", func(b *issue.Issue) (bool, string) { return len(b.Blocking) == 0, "blocking: " + listArg(b.Blocking) })
 	}
 	if filter.IsBlocked != nil {
-		if *filter.IsBlocked {
-			result = filterByIsBlocked(result, core)
-		} else {
-			result = filterByNotBlocked(result, core)
-		}
+		want := *filter.IsBlocked
+		add("isBlocked", strconv.FormatBool(want), func(b *issue.Issue) (bool, string) {
+			var ids []string
+			for _, blocker := range core.FindActiveBlockers(b.ID) {
+				ids = append(ids, blocker.ID)
+			}
+			return (len(ids) > 0) == want, "active blockers: " + listArg(ids)
+		})
 	}
 
 	// Blocked-by filters (for direct blocked_by field)
-	if filter.HasBlockedBy != nil && *filter.HasBlockedBy {
-		result = filterByHasBlockedBy(result)
+	if flag(filter.HasBlockedBy) {
+		add("hasBlockedBy", "true", func(b *issue.Issue) (bool, string) {
+			return len(b.BlockedBy) > 0, "blocked_by: " + listArg(b.BlockedBy)
+		})
 	}
-	if filter.BlockedByID != nil && *filter.BlockedByID != "" {
-		result = filterByBlockedByID(result, *filter.BlockedByID)
+	if nonEmpty(filter.BlockedByID) {
+		id := *filter.BlockedByID
+		add("blockedById", id, func(b *issue.Issue) (bool, string) {
+			return slices.Contains(b.BlockedBy, id), "blocked_by: " + listArg(b.BlockedBy)
+		})
 	}
-	if filter.NoBlockedBy != nil && *filter.NoBlockedBy {
-		result = filterByNoBlockedBy(result)
+	if flag(filter.NoBlockedBy) {
+		add("noBlockedBy", "true", func(b *issue.Issue) (bool, string) {
+			return len(b.BlockedBy) == 0, "blocked_by: " + listArg(b.BlockedBy)
+		})
 	}
 
 	// Sync filters
-	if filter.HasSync != nil && *filter.HasSync != "" {
-		result = filterByHasSync(result, *filter.HasSync)
+	if nonEmpty(filter.HasSync) {
+		name := *filter.HasSync
+		add("hasSync", name, func(b *issue.Issue) (bool, string) { return b.HasSync(name), syncDetail(b, name) })
 	}
-	if filter.NoSync != nil && *filter.NoSync != "" {
-		result = filterByNoSync(result, *filter.NoSync)
+	if nonEmpty(filter.NoSync) {
+		name := *filter.NoSync
+		add("noSync", name, func(b *issue.Issue) (bool, string) { return !b.HasSync(name), syncDetail(b, name) })
 	}
-	if filter.SyncStale != nil && *filter.SyncStale != "" {
-		result = filterBySyncStale(result, *filter.SyncStale)
+	if nonEmpty(filter.SyncStale) {
+		name := *filter.SyncStale
+		add("syncStale", name, func(b *issue.Issue) (bool, string) { return syncStale(b, name) })
 	}
 	if filter.ChangedSince != nil {
-		result = filterByChangedSince(result, *filter.ChangedSince)
+		since := *filter.ChangedSince
+		add("changedSince", since.Format(time.RFC3339), func(b *issue.Issue) (bool, string) {
+			return b.UpdatedAt != nil && !b.UpdatedAt.Before(since), "updated_at " + formatTime(b.UpdatedAt)
+		})
+	}
+	if filter.DueBefore != nil {
+		add("dueBefore", *filter.DueBefore, dueTest(*filter.DueBefore, true))
+	}
+	if filter.DueAfter != nil {
+		add("dueAfter", *filter.DueAfter, dueTest(*filter.DueAfter, false))
 	}
 
-	return result
+	// Staleness filter
+	if filter.IsStale != nil {
+		want := *filter.IsStale
+		add("isStale", strconv.FormatBool(want), func(b *issue.Issue) (bool, string) {
+			stale := core.IsStale(b)
+			return stale == want, staleDetail(core, b, stale)
+		})
+	}
+
+	// Pin filter
+	if filter.Pinned != nil {
+		want := *filter.Pinned
+		add("pinned", strconv.FormatBool(want), func(b *issue.Issue) (bool, string) {
+			return b.Pinned == want, "pinned: " + strconv.FormatBool(b.Pinned)
+		})
+	}
+
+	// Visibility filter (an unset visibility is public)
+	if filter.Visibility != nil {
+		want := *filter.Visibility
+		add("visibility", want, func(b *issue.Issue) (bool, string) {
+			v := cmp.Or(b.Visibility, config.VisibilityPublic)
+			return v == want, "visibility: " + v
+		})
+	}
+
+	return preds
+}
+
+// ApplyFilter applies IssueFilter to a slice of issues and returns filtered results.
+// This is used by both the top-level issues query and relationship field resolvers.
+func ApplyFilter(issues []*issue.Issue, filter *model.IssueFilter, core *core.Core) []*issue.Issue {
+	preds := Predicates(filter, core)
+	if len(preds) == 0 {
+		return issues
+	}
+	return filterIssues(issues, func(b *issue.Issue) bool {
+		for _, p := range preds {
+			if ok, _ := p.Test(b); !ok {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ExplainFilter evaluates every predicate of filter against b, in the order
+// ApplyFilter applies them, without stopping at the first failure. b passes
+// the filter exactly when every explanation passes.
+func ExplainFilter(b *issue.Issue, filter *model.IssueFilter, core *core.Core) []Explanation {
+	preds := Predicates(filter, core)
+	explanations := make([]Explanation, 0, len(preds))
+	for _, p := range preds {
+		ok, detail := p.Test(b)
+		explanations = append(explanations, Explanation{Filter: p.Filter, Arg: p.Arg, Pass: ok, Detail: detail})
+	}
+	return explanations
+}
+
+// ExplainIssue is ExplainFilter preceded, when filter has a search query,
+// by whether b is among the query's results, as the issues query checks
+// before applying the rest of the filter.
+func ExplainIssue(b *issue.Issue, filter *model.IssueFilter, core *core.Core) ([]Explanation, error) {
+	if err := ValidateFilter(filter); err != nil {
+		return nil, err
+	}
+	var explanations []Explanation
+	if filter != nil && filter.Search != nil && *filter.Search != "" {
+		results, err := core.Search(*filter.Search)
+		if err != nil {
+			return nil, err
+		}
+		found := slices.ContainsFunc(results, func(r *issue.Issue) bool { return r.ID == b.ID })
+		detail := fmt.Sprintf("not among %d search results", len(results))
+		if found {
+			detail = fmt.Sprintf("among %d search results", len(results))
+		}
+		explanations = append(explanations, Explanation{Filter: "search", Arg: *filter.Search, Pass: found, Detail: detail})
+	}
+	return append(explanations, ExplainFilter(b, filter, core)...), nil
 }
 
 // stringSet builds a lookup set from a string slice.
@@ -138,146 +285,212 @@ func filterIssues(issues []*issue.Issue, pred func(*issue.Issue) bool) []*issue.
 	return result
 }
 
-// filterByField filters issues to include only those where getter returns a value in values (OR logic).
-func filterByField(issues []*issue.Issue, values []string, getter func(*issue.Issue) string) []*issue.Issue {
-	set := stringSet(values)
-	return filterIssues(issues, func(b *issue.Issue) bool { return set[getter(b)] })
-}
-
-// excludeByField filters issues to exclude those where getter returns a value in values.
-func excludeByField(issues []*issue.Issue, values []string, getter func(*issue.Issue) string) []*issue.Issue {
+// fieldIn tests whether getter returns one of values (include) or none of
+// them (exclude). field names the value in the explanation.
+func fieldIn(field string, values []string, getter func(*issue.Issue) string, include bool) func(*issue.Issue) (bool, string) {
 	set := stringSet(values)
-	return filterIssues(issues, func(b *issue.Issue) bool { return !set[getter(b)] })
-}
-
-// filterByPriority filters issues to include only those with matching priorities (OR logic).
-// Empty priority in the issue is treated as "normal" for matching purposes.
-func filterByPriority(issues []*issue.Issue, priorities []string) []*issue.Issue {
-	set := stringSet(priorities)
-	return filterIssues(issues, func(b *issue.Issue) bool { return set[cmp.Or(b.Priority, config.PriorityNormal)] })
-}
-
-// excludeByPriority filters issues to exclude those with matching priorities.
-// Empty priority in the issue is treated as "normal" for matching purposes.
-func excludeByPriority(issues []*issue.Issue, priorities []string) []*issue.Issue {
-	set := stringSet(priorities)
-	return filterIssues(issues, func(b *issue.Issue) bool { return !set[cmp.Or(b.Priority, config.PriorityNormal)] })
-}
-
-// filterByTags filters issues to include only those with any of the given tags (OR logic).
-func filterByTags(issues []*issue.Issue, tags []string) []*issue.Issue {
-	set := stringSet(tags)
-	return filterIssues(issues, func(b *issue.Issue) bool {
-		for _, t := range b.Tags {
-			if set[t] {
-				return true
-			}
-		}
-		return false
-	})
+	return func(b *issue.Issue) (bool, string) {
+		v := getter(b)
+		return set[v] == include, field + ": " + orNone(v)
+	}
 }
 
-// excludeByTags filters issues to exclude those with any of the given tags.
-func excludeByTags(issues []*issue.Issue, tags []string) []*issue.Issue {
+// tagsIn tests whether an issue has any of tags (include) or none of them
+// (exclude).
+func tagsIn(tags []string, include bool) func(*issue.Issue) (bool, string) {
 	set := stringSet(tags)
-	return filterIssues(issues, func(b *issue.Issue) bool {
-		for _, t := range b.Tags {
-			if set[t] {
-				return false
-			}
-		}
-		return true
-	})
+	return func(b *issue.Issue) (bool, string) {
+		has := slices.ContainsFunc(b.Tags, func(t string) bool { return set[t] })
+		return has == include, "tags: " + listArg(b.Tags)
+	}
 }
 
-func filterByHasParent(issues []*issue.Issue) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return b.Parent != "" })
+// listArg formats values for an explanation, e.g. "[a b]".
+func listArg(values []string) string {
+	return "[" + strings.Join(values, " ") + "]"
 }
 
-func filterByNoParent(issues []*issue.Issue) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return b.Parent == "" })
+// orNone returns s, or "(none)" when it is empty.
+func orNone(s string) string {
+	return cmp.Or(s, "(none)")
 }
 
-func filterByParentID(issues []*issue.Issue, parentID string) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return b.Parent == parentID })
+// formatTime formats t for an explanation, or "(none)" when it is unset.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "(none)"
+	}
+	return t.UTC().Format(time.RFC3339)
 }
 
-func filterByHasBlocking(issues []*issue.Issue) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return len(b.Blocking) > 0 })
+// syncDetail describes whether b has sync data for name.
+func syncDetail(b *issue.Issue, name string) string {
+	if !b.HasSync(name) {
+		return "no " + name + " sync data"
+	}
+	if at, ok := b.Sync[name][integration.SyncKeySyncedAt].(string); ok {
+		return name + " synced_at " + at
+	}
+	return "has " + name + " sync data"
 }
 
-func filterByBlockingID(issues []*issue.Issue, targetID string) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return slices.Contains(b.Blocking, targetID) })
+// staleDetail describes the data core.IsStale looked at.
+func staleDetail(c *core.Core, b *issue.Issue, stale bool) string {
+	cfg := c.Config()
+	switch {
+	case cfg == nil || cfg.GetStaleAfter() <= 0:
+		return "stale_after is not set"
+	case b.Pinned:
+		return "pinned issues are never stale"
+	case !slices.Contains(cfg.GetStaleStatuses(), b.Status):
+		return "status " + b.Status + " is not in stale_statuses " + listArg(cfg.GetStaleStatuses())
+	}
+	ts := cmp.Or(b.UpdatedAt, b.CreatedAt)
+	if ts == nil {
+		return "no updated_at or created_at"
+	}
+	verb := "within"
+	if stale {
+		verb = "more than"
+	}
+	return fmt.Sprintf("updated %s ago, %s stale_after %s", config.FormatAge(c.Now().Sub(*ts)), verb, cfg.StaleAfter)
 }
 
-func filterByNoBlocking(issues []*issue.Issue) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return len(b.Blocking) == 0 })
+// resolveIterations replaces "current" in names with the current iteration.
+// When there is none, "current" is kept and matches nothing.
+func resolveIterations(names []string, c *core.Core) []string {
+	if c == nil || !slices.Contains(names, config.IterationCurrent) {
+		return names
+	}
+	cfg := c.Config()
+	if cfg == nil {
+		return names
+	}
+	resolved := slices.Clone(names)
+	for i, name := range resolved {
+		if name != config.IterationCurrent {
+			continue
+		}
+		if current, err := cfg.CurrentIteration(c.Now()); err == nil {
+			resolved[i] = current
+		}
+	}
+	return resolved
 }
 
-// filterByIsBlocked filters issues that are blocked by active (non-completed, non-scrapped) blockers.
-func filterByIsBlocked(issues []*issue.Issue, core *core.Core) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return core.IsBlocked(b.ID) })
-}
+// filterByHasSync, filterByNoSync, filterBySyncStale, filterByChangedSince,
+// and filterByDue apply a single filter field through Predicates.
 
-// filterByNotBlocked filters issues that are NOT blocked by active blockers.
-func filterByNotBlocked(issues []*issue.Issue, core *core.Core) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return !core.IsBlocked(b.ID) })
+func filterByHasSync(issues []*issue.Issue, name string) []*issue.Issue {
+	return ApplyFilter(issues, &model.IssueFilter{HasSync: &name}, nil)
 }
 
-func filterByHasBlockedBy(issues []*issue.Issue) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return len(b.BlockedBy) > 0 })
+func filterByNoSync(issues []*issue.Issue, name string) []*issue.Issue {
+	return ApplyFilter(issues, &model.IssueFilter{NoSync: &name}, nil)
 }
 
-func filterByBlockedByID(issues []*issue.Issue, blockerID string) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return slices.Contains(b.BlockedBy, blockerID) })
+// filterBySyncStale filters issues where updatedAt > sync[name]["synced_at"].
+// If no synced_at or unparseable, the issue is treated as stale (conservative).
+func filterBySyncStale(issues []*issue.Issue, name string) []*issue.Issue {
+	return ApplyFilter(issues, &model.IssueFilter{SyncStale: &name}, nil)
 }
 
-func filterByNoBlockedBy(issues []*issue.Issue) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return len(b.BlockedBy) == 0 })
+func filterByChangedSince(issues []*issue.Issue, since time.Time) []*issue.Issue {
+	return ApplyFilter(issues, &model.IssueFilter{ChangedSince: &since}, nil)
 }
 
-func filterByHasSync(issues []*issue.Issue, name string) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return b.HasSync(name) })
+// filterByDue keeps issues due on or before (or on or after) boundary.
+func filterByDue(issues []*issue.Issue, boundary string, before bool) []*issue.Issue {
+	if before {
+		return ApplyFilter(issues, &model.IssueFilter{DueBefore: &boundary}, nil)
+	}
+	return ApplyFilter(issues, &model.IssueFilter{DueAfter: &boundary}, nil)
 }
 
-func filterByNoSync(issues []*issue.Issue, name string) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return !b.HasSync(name) })
-}
+// syncStaleTolerance absorbs clock skew and second truncation between the
+// updated_at written by core and the synced_at written by a provider.
+const syncStaleTolerance = time.Second
 
-// filterBySyncStale filters issues where updatedAt > sync[name]["synced_at"].
-// If no synced_at or unparseable, the issue is treated as stale (conservative).
-func filterBySyncStale(issues []*issue.Issue, name string) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return isSyncStale(b, name) })
+// isSyncStale returns true if the issue's updatedAt is more than
+// syncStaleTolerance after the sync integration's synced_at.
+func isSyncStale(b *issue.Issue, name string) bool {
+	stale, _ := syncStale(b, name)
+	return stale
 }
 
-// isSyncStale returns true if the issue's updatedAt is after the sync integration's synced_at.
-func isSyncStale(b *issue.Issue, name string) bool {
+// syncStale is isSyncStale with a description of the timestamps compared.
+func syncStale(b *issue.Issue, name string) (bool, string) {
 	if b.UpdatedAt == nil {
-		return false
+		return false, "no updated_at"
 	}
 
-	if b.Sync == nil {
-		return true
-	}
 	data, ok := b.Sync[name]
 	if !ok {
-		return true
+		return true, "no " + name + " sync data"
 	}
 	syncedAtRaw, ok := data[integration.SyncKeySyncedAt]
 	if !ok {
-		return true
+		return true, "no " + name + " synced_at"
 	}
 	syncedAtStr, ok := syncedAtRaw.(string)
 	if !ok {
-		return true
+		return true, fmt.Sprintf("%s synced_at %v is not a timestamp", name, syncedAtRaw)
 	}
 	syncedAt, err := time.Parse(time.RFC3339, syncedAtStr)
 	if err != nil {
-		return true
+		return true, fmt.Sprintf("%s synced_at %q is not a timestamp", name, syncedAtStr)
 	}
-	return b.UpdatedAt.After(syncedAt)
+	updated := formatTime(b.UpdatedAt)
+	if b.UpdatedAt.Sub(syncedAt) > syncStaleTolerance {
+		return true, fmt.Sprintf("updated_at %s > synced_at %s", updated, syncedAtStr)
+	}
+	return false, fmt.Sprintf("synced_at %s >= updated_at %s", syncedAtStr, updated)
 }
 
-func filterByChangedSince(issues []*issue.Issue, since time.Time) []*issue.Issue {
-	return filterIssues(issues, func(b *issue.Issue) bool { return b.UpdatedAt != nil && !b.UpdatedAt.Before(since) })
+// ValidateFilter reports filter values that ApplyFilter cannot interpret.
+func ValidateFilter(filter *model.IssueFilter) error {
+	if filter == nil {
+		return nil
+	}
+	for _, boundary := range []*string{filter.DueBefore, filter.DueAfter} {
+		if boundary == nil {
+			continue
+		}
+		if _, err := issue.ParseDueDate(*boundary); err != nil {
+			return err
+		}
+	}
+	for _, via := range filter.CreatedVia {
+		if !slices.Contains(issue.CreatedVias, via) {
+			return fmt.Errorf("unknown createdVia %q (want one of %s)", via, strings.Join(issue.CreatedVias, ", "))
+		}
+	}
+	return nil
+}
+
+// dueTest tests whether an issue is due on or before (or on or after)
+// boundary. A date-only boundary compares calendar days, so the whole day is
+// included; a boundary with a time compares against each issue's deadline,
+// where a date-only due date runs to the end of its day. Issues without a due
+// date never match, and neither does anything when the boundary is invalid.
+func dueTest(boundary string, before bool) func(*issue.Issue) (bool, string) {
+	bound, err := issue.ParseDueDate(boundary)
+	return func(b *issue.Issue) (bool, string) {
+		if err != nil {
+			return false, fmt.Sprintf("invalid boundary: %v", err)
+		}
+		if b.Due == nil {
+			return false, "no due date"
+		}
+		var c int
+		if bound.HasTime {
+			c = b.Due.Deadline().Compare(bound.Time)
+		} else {
+			c = cmp.Compare(b.Due.DateKey(), bound.DateKey())
+		}
+		if before {
+			return c <= 0, "due " + b.Due.String()
+		}
+		return c >= 0, "due " + b.Due.String()
+	}
 }
@@ -24,9 +24,9 @@ func TestFilterByTypeAndExcludeType(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	c.Create(&issue.Issue{ID: "t1", Title: "Bug", Status: "todo", Type: "bug"})
-	c.Create(&issue.Issue{ID: "t2", Title: "Task", Status: "todo", Type: "task"})
-	c.Create(&issue.Issue{ID: "t3", Title: "Feature", Status: "todo", Type: "feature"})
+	c.Create(&issue.Issue{ID: "t1", Title: "Bug", Status: "ready", Type: "bug"})
+	c.Create(&issue.Issue{ID: "t2", Title: "Task", Status: "ready", Type: "task"})
+	c.Create(&issue.Issue{ID: "t3", Title: "Feature", Status: "ready", Type: "feature"})
 
 	qr := resolver.Query()
 
@@ -73,9 +73,9 @@ func TestFilterByBlockingID(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	c.Create(&issue.Issue{ID: "target-1", Title: "Target", Status: "todo"})
-	c.Create(&issue.Issue{ID: "blocker-a", Title: "Blocker A", Status: "todo", Blocking: []string{"target-1"}})
-	c.Create(&issue.Issue{ID: "other-1", Title: "Other", Status: "todo"})
+	c.Create(&issue.Issue{ID: "target-1", Title: "Target", Status: "ready"})
+	c.Create(&issue.Issue{ID: "blocker-a", Title: "Blocker A", Status: "ready", Blocking: []string{"target-1"}})
+	c.Create(&issue.Issue{ID: "other-1", Title: "Other", Status: "ready"})
 
 	qr := resolver.Query()
 
@@ -96,8 +96,8 @@ func TestFilterByNoBlocking(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	c.Create(&issue.Issue{ID: "has-blocking", Title: "Has", Status: "todo", Blocking: []string{"x"}})
-	c.Create(&issue.Issue{ID: "no-blocking", Title: "None", Status: "todo"})
+	c.Create(&issue.Issue{ID: "has-blocking", Title: "Has", Status: "ready", Blocking: []string{"x"}})
+	c.Create(&issue.Issue{ID: "no-blocking", Title: "None", Status: "ready"})
 
 	qr := resolver.Query()
 
@@ -116,8 +116,8 @@ func TestFilterByHasBlockedBy(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	c.Create(&issue.Issue{ID: "has-bb", Title: "Has", Status: "todo", BlockedBy: []string{"x"}})
-	c.Create(&issue.Issue{ID: "no-bb", Title: "None", Status: "todo"})
+	c.Create(&issue.Issue{ID: "has-bb", Title: "Has", Status: "ready", BlockedBy: []string{"x"}})
+	c.Create(&issue.Issue{ID: "no-bb", Title: "None", Status: "ready"})
 
 	qr := resolver.Query()
 
@@ -162,15 +162,15 @@ func TestFilterCombination(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	c.Create(&issue.Issue{ID: "match", Title: "Match", Status: "todo", Type: "bug", Priority: "high", Tags: []string{"urgent"}})
+	c.Create(&issue.Issue{ID: "match", Title: "Match", Status: "ready", Type: "bug", Priority: "high", Tags: []string{"urgent"}})
 	c.Create(&issue.Issue{ID: "wrong-status", Title: "Wrong", Status: "completed", Type: "bug", Priority: "high"})
-	c.Create(&issue.Issue{ID: "wrong-type", Title: "Wrong", Status: "todo", Type: "task", Priority: "high"})
-	c.Create(&issue.Issue{ID: "wrong-priority", Title: "Wrong", Status: "todo", Type: "bug", Priority: "low"})
+	c.Create(&issue.Issue{ID: "wrong-type", Title: "Wrong", Status: "ready", Type: "task", Priority: "high"})
+	c.Create(&issue.Issue{ID: "wrong-priority", Title: "Wrong", Status: "ready", Type: "bug", Priority: "low"})
 
 	qr := resolver.Query()
 
 	filter := &model.IssueFilter{
-		Status:   []string{"todo"},
+		Status:   []string{"ready"},
 		Type:     []string{"bug"},
 		Priority: []string{"high"},
 		Tags:     []string{"urgent"},
@@ -191,7 +191,7 @@ func TestResolverIssueFieldResolvers(t *testing.T) {
 	b := &issue.Issue{
 		ID:        "field-test",
 		Title:     "Test",
-		Status:    "todo",
+		Status:    "ready",
 		Parent:    "some-parent",
 		Blocking:  []string{"target-1"},
 		BlockedBy: []string{"blocker-1"},
@@ -211,7 +211,7 @@ func TestResolverIssueFieldResolvers(t *testing.T) {
 	})
 
 	t.Run("parentId returns nil for empty", func(t *testing.T) {
-		noParent := &issue.Issue{ID: "no-parent", Title: "Test", Status: "todo"}
+		noParent := &issue.Issue{ID: "no-parent", Title: "Test", Status: "ready"}
 		c.Create(noParent)
 		got, err := br.ParentID(ctx, noParent)
 		if err != nil {
@@ -266,7 +266,7 @@ func TestResolverUpdateIssueDueInvalid(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	b := &issue.Issue{ID: "due-invalid", Title: "Test", Status: "todo"}
+	b := &issue.Issue{ID: "due-invalid", Title: "Test", Status: "ready"}
 	c.Create(b)
 
 	mr := resolver.Mutation()
@@ -281,7 +281,7 @@ func TestResolverUpdateIssueDueInvalid(t *testing.T) {
 func TestResolverValidateETag(t *testing.T) {
 	resolver, _ := setupTestResolver(t)
 
-	b := &issue.Issue{ID: "etag-val", Title: "Test", Status: "todo"}
+	b := &issue.Issue{ID: "etag-val", Title: "Test", Status: "ready"}
 
 	t.Run("nil ifMatch passes", func(t *testing.T) {
 		err := resolver.validateETag(b, nil)
@@ -318,8 +318,8 @@ func TestResolverValidateETag(t *testing.T) {
 func TestResolverValidateAndSetParent(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 
-	epic := &issue.Issue{ID: "epic-vp", Title: "Epic", Type: "epic", Status: "todo"}
-	task := &issue.Issue{ID: "task-vp", Title: "Task", Type: "task", Status: "todo"}
+	epic := &issue.Issue{ID: "epic-vp", Title: "Epic", Type: "epic", Status: "ready"}
+	task := &issue.Issue{ID: "task-vp", Title: "Task", Type: "task", Status: "ready"}
 	c.Create(epic)
 	c.Create(task)
 
@@ -350,8 +350,8 @@ func TestResolverValidateAndSetParent(t *testing.T) {
 func TestResolverValidateAndAddBlocking(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 
-	task1 := &issue.Issue{ID: "vab-1", Title: "Task 1", Type: "task", Status: "todo"}
-	task2 := &issue.Issue{ID: "vab-2", Title: "Task 2", Type: "task", Status: "todo"}
+	task1 := &issue.Issue{ID: "vab-1", Title: "Task 1", Type: "task", Status: "ready"}
+	task2 := &issue.Issue{ID: "vab-2", Title: "Task 2", Type: "task", Status: "ready"}
 	c.Create(task1)
 	c.Create(task2)
 
@@ -366,7 +366,7 @@ func TestResolverValidateAndAddBlocking(t *testing.T) {
 	})
 
 	t.Run("self-blocking fails", func(t *testing.T) {
-		b := &issue.Issue{ID: "vab-self", Title: "Self", Type: "task", Status: "todo"}
+		b := &issue.Issue{ID: "vab-self", Title: "Self", Type: "task", Status: "ready"}
 		c.Create(b)
 		err := resolver.validateAndAddBlocking(b, []string{"vab-self"})
 		if err == nil {
@@ -375,7 +375,7 @@ func TestResolverValidateAndAddBlocking(t *testing.T) {
 	})
 
 	t.Run("nonexistent target fails", func(t *testing.T) {
-		b := &issue.Issue{ID: "vab-ne", Title: "NE", Type: "task", Status: "todo"}
+		b := &issue.Issue{ID: "vab-ne", Title: "NE", Type: "task", Status: "ready"}
 		c.Create(b)
 		err := resolver.validateAndAddBlocking(b, []string{"nonexistent"})
 		if err == nil {
@@ -387,10 +387,10 @@ func TestResolverValidateAndAddBlocking(t *testing.T) {
 func TestResolverRemoveBlockingRelationships(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 
-	task := &issue.Issue{ID: "rem-b", Title: "Task", Type: "task", Status: "todo", Blocking: []string{"t1", "t2"}}
+	task := &issue.Issue{ID: "rem-b", Title: "Task", Type: "task", Status: "ready", Blocking: []string{"t1", "t2"}}
 	c.Create(task)
-	c.Create(&issue.Issue{ID: "t1", Title: "T1", Status: "todo"})
-	c.Create(&issue.Issue{ID: "t2", Title: "T2", Status: "todo"})
+	c.Create(&issue.Issue{ID: "t1", Title: "T1", Status: "ready"})
+	c.Create(&issue.Issue{ID: "t2", Title: "T2", Status: "ready"})
 
 	resolver.removeBlockingRelationships(task, []string{"t1"})
 	if len(task.Blocking) != 1 || task.Blocking[0] != "t2" {
@@ -401,8 +401,8 @@ func TestResolverRemoveBlockingRelationships(t *testing.T) {
 func TestResolverValidateAndAddBlockedBy(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 
-	task1 := &issue.Issue{ID: "vabb-1", Title: "Task 1", Type: "task", Status: "todo"}
-	task2 := &issue.Issue{ID: "vabb-2", Title: "Task 2", Type: "task", Status: "todo"}
+	task1 := &issue.Issue{ID: "vabb-1", Title: "Task 1", Type: "task", Status: "ready"}
+	task2 := &issue.Issue{ID: "vabb-2", Title: "Task 2", Type: "task", Status: "ready"}
 	c.Create(task1)
 	c.Create(task2)
 
@@ -414,7 +414,7 @@ func TestResolverValidateAndAddBlockedBy(t *testing.T) {
 	})
 
 	t.Run("self-blockedBy fails", func(t *testing.T) {
-		b := &issue.Issue{ID: "vabb-self", Title: "Self", Type: "task", Status: "todo"}
+		b := &issue.Issue{ID: "vabb-self", Title: "Self", Type: "task", Status: "ready"}
 		c.Create(b)
 		err := resolver.validateAndAddBlockedBy(b, []string{"vabb-self"})
 		if err == nil {
@@ -423,7 +423,7 @@ func TestResolverValidateAndAddBlockedBy(t *testing.T) {
 	})
 
 	t.Run("nonexistent blocker fails", func(t *testing.T) {
-		b := &issue.Issue{ID: "vabb-ne", Title: "NE", Type: "task", Status: "todo"}
+		b := &issue.Issue{ID: "vabb-ne", Title: "NE", Type: "task", Status: "ready"}
 		c.Create(b)
 		err := resolver.validateAndAddBlockedBy(b, []string{"nonexistent"})
 		if err == nil {
@@ -435,10 +435,10 @@ func TestResolverValidateAndAddBlockedBy(t *testing.T) {
 func TestResolverRemoveBlockedByRelationships(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 
-	task := &issue.Issue{ID: "rem-bb", Title: "Task", Type: "task", Status: "todo", BlockedBy: []string{"b1", "b2"}}
+	task := &issue.Issue{ID: "rem-bb", Title: "Task", Type: "task", Status: "ready", BlockedBy: []string{"b1", "b2"}}
 	c.Create(task)
-	c.Create(&issue.Issue{ID: "b1", Title: "B1", Status: "todo"})
-	c.Create(&issue.Issue{ID: "b2", Title: "B2", Status: "todo"})
+	c.Create(&issue.Issue{ID: "b1", Title: "B1", Status: "ready"})
+	c.Create(&issue.Issue{ID: "b2", Title: "B2", Status: "ready"})
 
 	resolver.removeBlockedByRelationships(task, []string{"b1"})
 	if len(task.BlockedBy) != 1 || task.BlockedBy[0] != "b2" {
@@ -450,7 +450,7 @@ func TestCreateIssueWithParentValidation(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	epic := &issue.Issue{ID: "epic-cp", Title: "Epic", Type: "epic", Status: "todo"}
+	epic := &issue.Issue{ID: "epic-cp", Title: "Epic", Type: "epic", Status: "ready"}
 	c.Create(epic)
 
 	mr := resolver.Mutation()
@@ -475,13 +475,13 @@ func TestSetSyncDataWithETag(t *testing.T) {
 	resolver, c := setupTestResolverWithRequireIfMatch(t)
 	ctx := context.Background()
 
-	b := &issue.Issue{ID: "sync-etag", Title: "Test", Status: "todo"}
+	b := &issue.Issue{ID: "sync-etag", Title: "Test", Status: "ready"}
 	c.Create(b)
 
 	mr := resolver.Mutation()
 	etag := b.ETag()
 	data := map[string]any{"key": "value"}
-	got, err := mr.SetSyncData(ctx, "sync-etag", "test", data, &etag)
+	got, err := mr.SetSyncData(ctx, "sync-etag", "test", data, &etag, nil)
 	if err != nil {
 		t.Fatalf("SetSyncData() error = %v", err)
 	}
@@ -497,7 +497,7 @@ func TestRemoveSyncDataWithETag(t *testing.T) {
 	b := &issue.Issue{
 		ID:     "rmsync-etag",
 		Title:  "Test",
-		Status: "todo",
+		Status: "ready",
 		Sync:   map[string]map[string]any{"test": {"key": "value"}},
 	}
 	c.Create(b)
@@ -518,7 +518,7 @@ func TestQueryIssueViaPartialID(t *testing.T) {
 	ctx := context.Background()
 
 	// NormalizeID in Core supports prefix matching
-	c.Create(&issue.Issue{ID: "abc-123", Title: "Test", Status: "todo"})
+	c.Create(&issue.Issue{ID: "abc-123", Title: "Test", Status: "ready"})
 
 	qr := resolver.Query()
 
@@ -536,7 +536,7 @@ func TestUpdateIssueAddTagsDuplicate(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	task := &issue.Issue{ID: "dup-tags", Title: "Task", Type: "task", Status: "todo", Tags: []string{"existing"}}
+	task := &issue.Issue{ID: "dup-tags", Title: "Task", Type: "task", Status: "ready", Tags: []string{"existing"}}
 	c.Create(task)
 
 	input := model.UpdateIssueInput{
@@ -564,8 +564,8 @@ func TestDeleteIssueWithParentLink(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	parent := &issue.Issue{ID: "parent-del", Title: "Parent", Type: "epic", Status: "todo"}
-	child := &issue.Issue{ID: "child-del", Title: "Child", Type: "task", Status: "todo", Parent: "parent-del"}
+	parent := &issue.Issue{ID: "parent-del", Title: "Parent", Type: "epic", Status: "ready"}
+	child := &issue.Issue{ID: "child-del", Title: "Child", Type: "task", Status: "ready", Parent: "parent-del"}
 	c.Create(parent)
 	c.Create(child)
 
@@ -628,13 +628,33 @@ func TestIsSyncStaleEdgeCases(t *testing.T) {
 			t.Error("unparseable synced_at should be treated as stale")
 		}
 	})
+
+	t.Run("update within tolerance is not stale", func(t *testing.T) {
+		synced := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		updated := synced.Add(syncStaleTolerance)
+		b := &issue.Issue{
+			ID:        "tolerance",
+			UpdatedAt: &updated,
+			Sync: map[string]map[string]any{
+				"test": {"synced_at": synced.Format(time.RFC3339)},
+			},
+		}
+		if isSyncStale(b, "test") {
+			t.Error("update at the tolerance boundary should not be stale")
+		}
+		later := updated.Add(time.Second)
+		b.UpdatedAt = &later
+		if !isSyncStale(b, "test") {
+			t.Error("update past the tolerance should be stale")
+		}
+	})
 }
 
 func TestCreateIssueBodyMutualExclusive(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
-	b := &issue.Issue{ID: "excl-test", Title: "Test", Status: "todo", Body: "Original", Tags: []string{"a"}}
+	b := &issue.Issue{ID: "excl-test", Title: "Test", Status: "ready", Body: "Original", Tags: []string{"a"}}
 	c.Create(b)
 
 	mr := resolver.Mutation()
@@ -1,9 +1,15 @@
 package graph
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
@@ -149,3 +155,194 @@ func TestFilterByChangedSince(t *testing.T) {
 		t.Error("expected 'exact' in results (updatedAt == since)")
 	}
 }
+
+// TestFiltersOnNormalizedTimestamps loads the core package's timestamp
+// fixtures, whose naive timestamps are read as UTC and whose skewed-clock
+// timestamps are clamped to the load time, and checks the time filters
+// against the core's clock.
+func TestFiltersOnNormalizedTimestamps(t *testing.T) {
+	dataDir := t.TempDir()
+	if err := os.CopyFS(dataDir, os.DirFS(filepath.Join("..", "core", "testdata", "timestamps"))); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	cfg.StaleAfter = "7d"
+	cfg.StaleStatuses = []string{"ready"}
+	c := core.New(dataDir, cfg)
+	c.SetWarnWriter(nil)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	c.SetClock(func() time.Time { return now })
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(filter *model.IssueFilter) []string {
+		var ids []string
+		for _, b := range ApplyFilter(c.All(), filter, c) {
+			ids = append(ids, b.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	since := now.Add(-time.Hour)
+	if got, want := ids(&model.IssueFilter{ChangedSince: &since}), []string{"fut-001", "nav-001", "skw-001"}; !slices.Equal(got, want) {
+		t.Errorf("changedSince %v = %v, want %v", since, got, want)
+	}
+
+	// A week on, the clamped issue goes stale like any other updated at
+	// load time; left in 2031 it never would.
+	c.SetClock(func() time.Time { return now.Add(7*config.Day + 3*time.Hour) })
+	if got, want := ids(&model.IssueFilter{IsStale: new(true)}), []string{"fut-001", "nav-001", "utc-001"}; !slices.Equal(got, want) {
+		t.Errorf("isStale = %v, want %v", got, want)
+	}
+}
+
+func TestFilterByDue(t *testing.T) {
+	mustDue := func(s string) *issue.DueDate {
+		d, err := issue.ParseDueDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	issues := []*issue.Issue{
+		{ID: "day", Due: mustDue("2025-06-15")},
+		{ID: "morning", Due: mustDue("2025-06-15T09:00:00Z")},
+		{ID: "evening", Due: mustDue("2025-06-15T17:00:00Z")},
+		{ID: "next", Due: mustDue("2025-06-16")},
+		{ID: "none"},
+	}
+	ids := func(got []*issue.Issue) []string {
+		var out []string
+		for _, b := range got {
+			out = append(out, b.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		boundary string
+		before   bool
+		want     []string
+	}{
+		{"date-only before includes whole day", "2025-06-15", true, []string{"day", "morning", "evening"}},
+		{"date-only after includes whole day", "2025-06-15", false, []string{"day", "morning", "evening", "next"}},
+		{"timed before", "2025-06-15T12:00:00Z", true, []string{"morning"}},
+		{"timed after counts date-only as end of day", "2025-06-15T12:00:00Z", false, []string{"day", "evening", "next"}},
+		{"invalid boundary matches nothing", "soon", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(filterByDue(issues, tt.boundary, tt.before))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := ValidateFilter(&model.IssueFilter{DueBefore: new("soon")}); err == nil {
+		t.Error("ValidateFilter should reject an invalid dueBefore")
+	}
+}
+
+func TestExplainFilter(t *testing.T) {
+	_, c := setupTestResolver(t)
+	updated := time.Date(2025, 4, 28, 0, 0, 0, 0, time.UTC)
+	blocker := &issue.Issue{ID: "k2j-88a", Title: "Blocker", Status: "ready", Blocking: []string{"b-1"}}
+	b := &issue.Issue{
+		ID: "b-1", Title: "Subject", Status: "ready", Type: "bug", Priority: "high",
+		Tags: []string{"backend"}, Parent: "p-1", Milestone: "m-1",
+		Due:  &issue.DueDate{Time: time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC)},
+		Sync: map[string]map[string]any{"clickup": {"synced_at": "2025-05-01T00:00:00Z"}},
+	}
+	for _, x := range []*issue.Issue{{ID: "p-1", Title: "Parent", Status: "ready", Type: "epic"}, blocker, b} {
+		if err := c.Create(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b.UpdatedAt = &updated
+
+	yes, no := true, false
+	str := func(s string) *string { return &s }
+	since := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter model.IssueFilter
+		pass   bool
+		detail string
+	}{
+		{"status", model.IssueFilter{Status: []string{"draft"}}, false, "status: ready"},
+		{"excludeStatus", model.IssueFilter{ExcludeStatus: []string{"draft"}}, true, "status: ready"},
+		{"type", model.IssueFilter{Type: []string{"bug"}}, true, "type: bug"},
+		{"excludeType", model.IssueFilter{ExcludeType: []string{"bug"}}, false, "type: bug"},
+		{"priority", model.IssueFilter{Priority: []string{"high"}}, true, "priority: high"},
+		{"excludePriority", model.IssueFilter{ExcludePriority: []string{"low"}}, true, "priority: high"},
+		{"tags", model.IssueFilter{Tags: []string{"frontend"}}, false, "tags: [backend]"},
+		{"excludeTags", model.IssueFilter{ExcludeTags: []string{"wip"}}, true, "tags: [backend]"},
+		{"milestone", model.IssueFilter{Milestone: []string{"m-1"}}, true, "milestone: m-1"},
+		{"excludeIteration", model.IssueFilter{ExcludeIteration: []string{"s1"}}, true, "iteration: (none)"},
+		{"hasParent", model.IssueFilter{HasParent: &yes}, true, "parent: p-1"},
+		{"noParent", model.IssueFilter{NoParent: &yes}, false, "parent: p-1"},
+		{"parentId", model.IssueFilter{ParentID: str("p-2")}, false, "parent: p-1"},
+		{"hasBlocking", model.IssueFilter{HasBlocking: &yes}, false, "blocking: []"},
+		{"isBlocked", model.IssueFilter{IsBlocked: &yes}, true, "active blockers: [k2j-88a]"},
+		{"isBlocked", model.IssueFilter{IsBlocked: &no}, false, "active blockers: [k2j-88a]"},
+		{"noBlockedBy", model.IssueFilter{NoBlockedBy: &yes}, true, "blocked_by: []"},
+		{"hasSync", model.IssueFilter{HasSync: str("clickup")}, true, "clickup synced_at 2025-05-01T00:00:00Z"},
+		{"noSync", model.IssueFilter{NoSync: str("github")}, true, "no github sync data"},
+		{"syncStale", model.IssueFilter{SyncStale: str("clickup")}, false, "synced_at 2025-05-01T00:00:00Z >= updated_at 2025-04-28T00:00:00Z"},
+		{"syncStale", model.IssueFilter{SyncStale: str("github")}, true, "no github sync data"},
+		{"changedSince", model.IssueFilter{ChangedSince: &since}, true, "updated_at 2025-04-28T00:00:00Z"},
+		{"dueBefore", model.IssueFilter{DueBefore: str("2025-05-01")}, false, "due 2025-05-10"},
+		{"dueAfter", model.IssueFilter{DueAfter: str("2025-05-01")}, true, "due 2025-05-10"},
+		{"isStale", model.IssueFilter{IsStale: &yes}, false, "stale_after is not set"},
+		{"pinned", model.IssueFilter{Pinned: &yes}, false, "pinned: false"},
+		{"visibility", model.IssueFilter{Visibility: str("public")}, true, "visibility: public"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExplainFilter(b, &tt.filter, c)
+			if len(got) != 1 {
+				t.Fatalf("ExplainFilter() = %+v, want one explanation", got)
+			}
+			e := got[0]
+			if e.Filter != tt.name || e.Pass != tt.pass || e.Detail != tt.detail {
+				t.Errorf("ExplainFilter() = %+v, want %s pass=%v detail %q", e, tt.name, tt.pass, tt.detail)
+			}
+			// The list and explain paths agree by construction.
+			if listed := len(ApplyFilter([]*issue.Issue{b}, &tt.filter, c)) == 1; listed != e.Pass {
+				t.Errorf("ApplyFilter() listed = %v, explanation pass = %v", listed, e.Pass)
+			}
+		})
+	}
+}
+
+func TestExplainIssueSearchAndOrder(t *testing.T) {
+	_, c := setupTestResolver(t)
+	b := &issue.Issue{ID: "s-1", Title: "Login timeout", Status: "ready", Tags: []string{"backend"}}
+	if err := c.Create(b); err != nil {
+		t.Fatal(err)
+	}
+	query := "nothing-matches-this"
+	got, err := ExplainIssue(b, &model.IssueFilter{Search: &query, ExcludeTags: []string{"backend"}, Status: []string{"ready"}}, c)
+	if err != nil {
+		t.Fatalf("ExplainIssue() error = %v", err)
+	}
+	var names []string
+	for _, e := range got {
+		names = append(names, e.Filter)
+	}
+	if want := []string{"search", "status", "excludeTags"}; !slices.Equal(names, want) {
+		t.Fatalf("ExplainIssue() filters = %v, want %v", names, want)
+	}
+	if got[0].Pass || !got[1].Pass || got[2].Pass {
+		t.Errorf("ExplainIssue() outcomes = %+v, want fail, pass, fail", got)
+	}
+
+	bad := "someday"
+	if _, err := ExplainIssue(b, &model.IssueFilter{DueBefore: &bad}, c); err == nil {
+		t.Error("ExplainIssue() with invalid dueBefore: want error")
+	}
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// NewExecutor builds a gqlgen executor for the resolver with the depth and
+// complexity limits from the project config applied, running each query
+// against its own view (see Views). Errors for disallowed input values
+// carry extensions.code VALIDATION.
+func NewExecutor(r *Resolver) *executor.Executor {
+	cfg := r.Core.Config()
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	exec := executor.New(NewExecutableSchema(Config{Resolvers: r}))
+	exec.Use(Views{Core: r.Core})
+	applyLimits(exec, cfg)
+	return exec
+}
+
+// applyLimits adds the config's depth and complexity limits, the error
+// presenter, and resolver warnings to an executor or HTTP handler.
+func applyLimits(x interface {
+	Use(graphql.HandlerExtension)
+	SetErrorPresenter(graphql.ErrorPresenterFunc)
+}, cfg *config.Config) {
+	x.Use(DepthLimit{Max: cfg.GetGraphQLMaxDepth()})
+	x.Use(extension.FixedComplexityLimit(cfg.GetGraphQLMaxComplexity()))
+	x.SetErrorPresenter(presentError)
+	x.Use(Warnings{})
+}
+
+// DepthLimit rejects operations whose field nesting exceeds Max. Fragment
+// spreads and inline fragments do not add depth, and introspection fields
+// (names beginning with "__") are not counted so tooling keeps working.
+type DepthLimit struct {
+	Max int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (d DepthLimit) ExtensionName() string { return "DepthLimit" }
+
+// Validate implements graphql.HandlerExtension.
+func (d DepthLimit) Validate(graphql.ExecutableSchema) error { return nil }
+
+// MutateOperationContext implements graphql.OperationContextMutator.
+func (d DepthLimit) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if d.Max <= 0 || opCtx.Operation == nil {
+		return nil
+	}
+	depth := selectionDepth(opCtx.Operation.SelectionSet, map[string]bool{})
+	if depth > d.Max {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Max)
+		errcode.Set(err, errcode.ValidationFailed)
+		return err
+	}
+	return nil
+}
+
+// selectionDepth returns the deepest field nesting within set. visiting guards
+// against fragment cycles when walking spreads.
+func selectionDepth(set ast.SelectionSet, visiting map[string]bool) int {
+	maxDepth := 0
+	for _, sel := range set {
+		var d int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(s.SelectionSet, visiting)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			if s.Definition == nil || visiting[s.Name] {
+				continue
+			}
+			visiting[s.Name] = true
+			d = selectionDepth(s.Definition.SelectionSet, visiting)
+			delete(visiting, s.Name)
+		}
+		maxDepth = max(maxDepth, d)
+	}
+	return maxDepth
+}
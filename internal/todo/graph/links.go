@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// LinkError is why linkIssues rejected a batch, naming the issue whose
+// link failed. No link of the batch is saved when one is returned.
+type LinkError struct {
+	ID  string
+	Err error
+}
+
+func (e *LinkError) Error() string { return fmt.Sprintf("%s: %v", e.ID, e.Err) }
+
+func (e *LinkError) Unwrap() error { return e.Err }
+
+// linkEdge validates one link of a batch on its own: a known type, an
+// existing target other than b itself, and for a parent the depth limit.
+// Cycles are checked later against the whole batch.
+func (r *Resolver) linkEdge(b *issue.Issue, link *model.LinkInput) (core.LinkEdge, error) {
+	targetID, _ := r.Core.NormalizeID(link.Target)
+	edge := core.LinkEdge{From: b.ID, Type: link.Type, To: targetID}
+
+	switch link.Type {
+	case issue.LinkTypeBlocking, issue.LinkTypeBlockedBy, issue.LinkTypeParent:
+	default:
+		return edge, fmt.Errorf("unknown link type %q (use %s, %s, or %s)",
+			link.Type, issue.LinkTypeBlocking, issue.LinkTypeBlockedBy, issue.LinkTypeParent)
+	}
+	if targetID == b.ID {
+		return edge, fmt.Errorf("issue cannot have a %s link to itself", link.Type)
+	}
+	if _, err := r.Core.Get(targetID); err != nil {
+		return edge, fmt.Errorf("%s target %w", link.Type, r.Core.NotFound(link.Target))
+	}
+	if link.Type == issue.LinkTypeParent {
+		if err := r.Core.ValidateHierarchyDepth(b, targetID); err != nil {
+			return edge, err
+		}
+	}
+	return edge, nil
+}
+
+// batchParent checks that parentID may be child's parent. A parent valid
+// only as an epic is promoted on its batch copy, so the promotion is saved
+// with the links or not at all.
+func (r *Resolver) batchParent(batch *issueBatch, child *issue.Issue, parentID string) error {
+	parent, err := r.Core.Get(parentID)
+	if err != nil {
+		return fmt.Errorf("parent %w", r.Core.NotFound(parentID))
+	}
+	if cp, ok := batch.copies[parentID]; ok {
+		parent = cp
+	}
+	promote, err := r.Core.CheckParent(child, parent)
+	if err != nil || !promote {
+		return err
+	}
+	cp, err := batch.copyOf(r.Core, parent)
+	if err != nil {
+		return err
+	}
+	cp.Type = config.TypeEpic
+	return nil
+}
@@ -0,0 +1,270 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// MergedFromHeading is the heading the source body is appended under in the
+// target of a merge, followed by the source ID.
+const MergedFromHeading = "## Merged from "
+
+// mergeOptions are the choices mergeIssues leaves to the caller.
+type mergeOptions struct {
+	deleteSource bool
+	migrateSync  bool
+	dryRun       bool
+}
+
+// merge folds the source issue into the target. All changes are made to
+// copies in one batch and checked before any is saved, so a rejected merge
+// leaves every issue as it was; with dryRun the copies are only reported.
+func (r *Resolver) merge(sourceRef, targetRef string, opts mergeOptions) (*model.MergeResult, error) {
+	src, err := r.Core.LookupWith(sourceRef, core.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	tgt, err := r.Core.LookupWith(targetRef, core.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if src.ID == tgt.ID {
+		return nil, errors.New("cannot merge an issue into itself")
+	}
+	if slices.Contains(r.Core.Ancestors(tgt.ID), src.ID) {
+		return nil, fmt.Errorf("cannot merge %s into %s, which is below it", src.ID, tgt.ID)
+	}
+	if src.MergedInto != "" {
+		return nil, fmt.Errorf("%s was already merged into %s", src.ID, src.MergedInto)
+	}
+	for _, b := range []*issue.Issue{src, tgt} {
+		if b.Encrypted && b.Body == issue.EncryptedPlaceholder {
+			return nil, fmt.Errorf("%s: %w", b.ID, core.ErrIssueKeyUnavailable)
+		}
+	}
+
+	result := &model.MergeResult{
+		Children:      []string{},
+		Blocking:      []string{},
+		BlockedBy:     []string{},
+		Mentions:      []string{},
+		TagsAdded:     []string{},
+		Sync:          []*model.MergeSync{},
+		DeletedSource: opts.deleteSource,
+		DryRun:        opts.dryRun,
+	}
+	batch := newIssueBatch()
+	t, err := batch.copyOf(r.Core, tgt)
+	if err != nil {
+		return nil, err
+	}
+	var edges []core.LinkEdge
+
+	t.Body = appendMergedBody(t.Body, src)
+	for _, tag := range src.Tags {
+		if !t.HasTag(tag) {
+			t.Tags = append(t.Tags, tag)
+			result.TagsAdded = append(result.TagsAdded, tag)
+		}
+	}
+	if src.Due != nil && (t.Due == nil || src.Due.Deadline().Before(t.Due.Deadline())) {
+		t.Due = src.Due
+	}
+	if r.priorityRank(src.Priority) < r.priorityRank(t.Priority) {
+		t.Priority = src.Priority
+	}
+
+	// Links between the two would become self-links.
+	t.RemoveBlocking(src.ID)
+	t.RemoveBlockedBy(src.ID)
+	for _, id := range src.Blocking {
+		if id != t.ID && !slices.Contains(t.Blocking, id) {
+			t.Blocking = append(t.Blocking, id)
+			edges = append(edges, core.LinkEdge{From: t.ID, Type: issue.LinkTypeBlocking, To: id})
+			result.Blocking = appendUnique(result.Blocking, id)
+		}
+	}
+	for _, id := range src.BlockedBy {
+		if id != t.ID && !slices.Contains(t.BlockedBy, id) {
+			t.BlockedBy = append(t.BlockedBy, id)
+			edges = append(edges, core.LinkEdge{From: t.ID, Type: issue.LinkTypeBlockedBy, To: id})
+			result.BlockedBy = appendUnique(result.BlockedBy, id)
+		}
+	}
+
+	for _, in := range r.Core.FindIncomingLinks(src.ID) {
+		if in.FromIssue.ID == t.ID {
+			continue
+		}
+		b, err := batch.copyOf(r.Core, in.FromIssue)
+		if err != nil {
+			return nil, err
+		}
+		switch in.LinkType {
+		case issue.LinkTypeParent:
+			if err := r.checkMergedParent(b, t); err != nil {
+				return nil, err
+			}
+			b.Parent = t.ID
+			edges = append(edges, core.LinkEdge{From: b.ID, Type: issue.LinkTypeParent, To: t.ID})
+			result.Children = append(result.Children, b.ID)
+		case issue.LinkTypeBlocking:
+			// b blocks the source, so it now blocks the target.
+			b.RemoveBlocking(src.ID)
+			b.AddBlocking(t.ID)
+			edges = append(edges, core.LinkEdge{From: b.ID, Type: issue.LinkTypeBlocking, To: t.ID})
+			result.BlockedBy = appendUnique(result.BlockedBy, b.ID)
+		case issue.LinkTypeBlockedBy:
+			b.RemoveBlockedBy(src.ID)
+			b.AddBlockedBy(t.ID)
+			edges = append(edges, core.LinkEdge{From: b.ID, Type: issue.LinkTypeBlockedBy, To: t.ID})
+			result.Blocking = appendUnique(result.Blocking, b.ID)
+		}
+	}
+	if edge, cycle := r.Core.DetectBatchCycle(edges); cycle != nil {
+		return nil, fmt.Errorf("merging would create a %s cycle through %s: %v", edge.Type, edge.From, cycle)
+	}
+
+	matcher := r.Core.IDMatcher()
+	for _, ref := range r.Core.MentionedBy(src.ID) {
+		if ref.ID == t.ID || ref.ID == src.ID || (ref.Encrypted && ref.Body == issue.EncryptedPlaceholder) {
+			continue
+		}
+		body := matcher.ReplaceMention(ref.Body, src.ID, t.ID)
+		if body == ref.Body {
+			continue
+		}
+		b, err := batch.copyOf(r.Core, ref)
+		if err != nil {
+			return nil, err
+		}
+		b.Body = matcher.ReplaceMention(b.Body, src.ID, t.ID)
+		result.Mentions = append(result.Mentions, b.ID)
+	}
+
+	// The source goes last so its children have moved before it is scrapped.
+	var s *issue.Issue
+	if opts.deleteSource {
+		cp := *src
+		s = &cp
+	} else if s, err = batch.copyOf(r.Core, src); err != nil {
+		return nil, err
+	}
+	s.MergedInto = t.ID
+	s.Status = config.StatusScrapped
+	s.Blocking, s.BlockedBy = nil, nil
+	for _, name := range slices.Sorted(maps.Keys(src.Sync)) {
+		entry := &model.MergeSync{Name: name}
+		switch {
+		case !opts.migrateSync:
+			entry.Reason = new("left on the source without migrateSync")
+		case t.HasSync(name):
+			entry.Reason = new(fmt.Sprintf("%s already has %s sync data", t.ID, name))
+		default:
+			t.SetSync(name, src.Sync[name])
+			s.RemoveSync(name)
+			entry.Migrated = true
+		}
+		result.Sync = append(result.Sync, entry)
+	}
+
+	result.Target = t
+	if !opts.deleteSource {
+		result.Source = s
+	}
+	if opts.dryRun {
+		return result, nil
+	}
+	if err := r.saveMerge(batch, src, opts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// saveMerge writes a merge batch, then deletes the source if asked. A
+// failed delete puts the saved issues back.
+func (r *Resolver) saveMerge(batch *issueBatch, src *issue.Issue, opts mergeOptions) error {
+	var srcETag string
+	if opts.deleteSource {
+		var err error
+		if srcETag, err = r.Core.DiskETag(src.ID); err != nil {
+			return err
+		}
+	}
+	saved, failed, err := batch.save(r.Core)
+	if err != nil {
+		return fmt.Errorf("merge not saved: %s: %w", failed, err)
+	}
+	if !opts.deleteSource {
+		return nil
+	}
+	etag, err := r.Core.DiskETag(src.ID)
+	if err == nil && etag != srcETag {
+		err = &core.ETagMismatchError{Provided: srcETag, Current: etag}
+	}
+	if err == nil {
+		err = r.Core.Delete(src.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("merge not saved: deleting %s: %w", src.ID, errors.Join(err, batch.restore(r.Core, saved)))
+	}
+	return nil
+}
+
+// appendMergedBody returns body with the source's body, or its title when
+// it has none, appended under a "Merged from" heading.
+func appendMergedBody(body string, src *issue.Issue) string {
+	content := strings.TrimSpace(src.Body)
+	if content == "" {
+		content = src.Title
+	}
+	section := MergedFromHeading + src.ID + "\n\n" + content
+	if strings.TrimSpace(body) == "" {
+		return section
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + section
+}
+
+// checkMergedParent checks that child may move under the merge target
+// without changing anything: unlike ValidateParent it never promotes the
+// target to an epic, since that would be saved outside the batch.
+func (r *Resolver) checkMergedParent(child, target *issue.Issue) error {
+	valid := r.Core.ValidParentTypes(child.Type)
+	if !slices.Contains(valid, target.Type) {
+		return fmt.Errorf("cannot move child %s: %s issues can only have %s as parent, not %s",
+			child.ID, child.Type, strings.Join(valid, " or "), target.Type)
+	}
+	return r.Core.ValidateHierarchyDepth(child, target.ID)
+}
+
+// priorityRank orders priorities most urgent first; no priority counts as
+// normal and an unknown one as least urgent.
+func (r *Resolver) priorityRank(priority string) int {
+	names := config.DefaultPriorityNames()
+	if cfg := r.Core.Config(); cfg != nil {
+		names = cfg.PriorityNames()
+	}
+	if priority == "" {
+		priority = config.PriorityNormal
+	}
+	if i := slices.Index(names, priority); i >= 0 {
+		return i
+	}
+	return len(names)
+}
+
+// appendUnique appends id to ids unless it is already there.
+func appendUnique(ids []string, id string) []string {
+	if slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
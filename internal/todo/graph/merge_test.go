@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// seedMerge creates a source epic and a target epic, with the source
+// carrying a child, blocking links stored on both ends, a mention from
+// another issue, and sync data.
+func seedMerge(t *testing.T) (*Resolver, *core.Core) {
+	t.Helper()
+	resolver, c := setupTestResolver(t)
+	early := &issue.DueDate{Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+	late := &issue.DueDate{Time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}
+	for _, b := range []*issue.Issue{
+		{ID: "src-001", Title: "Login is slow", Status: "ready", Type: "epic", Priority: "high",
+			Tags: []string{"auth", "perf"}, Due: early, Body: "Takes ten seconds.",
+			Blocking: []string{"dep-001"}, Sync: map[string]map[string]any{"github": {"issue_number": 12}}},
+		{ID: "tgt-001", Title: "Slow login", Status: "ready", Type: "epic", Priority: "normal",
+			Tags: []string{"auth"}, Due: late, Body: "Users wait on login."},
+		{ID: "kid-001", Title: "Profile the query", Status: "ready", Type: "task", Parent: "src-001"},
+		{ID: "dep-001", Title: "Ship login", Status: "ready", Type: "task"},
+		{ID: "pre-001", Title: "Add index", Status: "ready", Type: "task", Blocking: []string{"src-001"}},
+		{ID: "aft-001", Title: "Load test", Status: "ready", Type: "task", BlockedBy: []string{"src-001"}},
+		{ID: "ref-001", Title: "Notes", Status: "ready", Type: "task", Body: "Same as src-001, see `src-001`."},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resolver, c
+}
+
+// diskETags returns the on-disk etag of each issue.
+func diskETags(t *testing.T, c *core.Core) map[string]string {
+	t.Helper()
+	etags := map[string]string{}
+	for _, b := range c.All() {
+		etag, err := c.DiskETag(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		etags[b.ID] = etag
+	}
+	return etags
+}
+
+func TestMergeIssues(t *testing.T) {
+	resolver, c := seedMerge(t)
+
+	got, err := resolver.Mutation().MergeIssues(context.Background(), "src-001", "tgt-001", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tgt, _ := c.Get("tgt-001")
+	if want := "Users wait on login.\n\n## Merged from src-001\n\nTakes ten seconds."; tgt.Body != want {
+		t.Errorf("target body = %q, want %q", tgt.Body, want)
+	}
+	if !slices.Equal(tgt.Tags, []string{"auth", "perf"}) || !slices.Equal(got.TagsAdded, []string{"perf"}) {
+		t.Errorf("target tags = %v, added %v", tgt.Tags, got.TagsAdded)
+	}
+	if tgt.Priority != "high" || tgt.Due == nil || tgt.Due.DateKey() != "2026-03-01" {
+		t.Errorf("target priority %q, due %v; want the source's higher priority and earlier due date", tgt.Priority, tgt.Due)
+	}
+	if !slices.Equal(tgt.Blocking, []string{"dep-001"}) || len(tgt.BlockedBy) != 0 {
+		t.Errorf("target blocking %v, blocked_by %v", tgt.Blocking, tgt.BlockedBy)
+	}
+	if tgt.HasSync("github") {
+		t.Error("sync data moved without migrateSync")
+	}
+
+	src, _ := c.Get("src-001")
+	if src.MergedInto != "tgt-001" || src.Status != "scrapped" || len(src.Blocking) != 0 || !src.HasSync("github") {
+		t.Errorf("source = merged_into %q, status %q, blocking %v, sync %v", src.MergedInto, src.Status, src.Blocking, src.Sync)
+	}
+
+	if kid, _ := c.Get("kid-001"); kid.Parent != "tgt-001" {
+		t.Errorf("child parent = %q, want tgt-001", kid.Parent)
+	}
+	if pre, _ := c.Get("pre-001"); !slices.Equal(pre.Blocking, []string{"tgt-001"}) {
+		t.Errorf("pre-001 blocking = %v, want tgt-001 only", pre.Blocking)
+	}
+	if aft, _ := c.Get("aft-001"); !slices.Equal(aft.BlockedBy, []string{"tgt-001"}) {
+		t.Errorf("aft-001 blocked_by = %v, want tgt-001 only", aft.BlockedBy)
+	}
+	if ref, _ := c.Get("ref-001"); ref.Body != "Same as tgt-001, see `src-001`." {
+		t.Errorf("mentioning body = %q, want the bare mention rewritten and code left alone", ref.Body)
+	}
+
+	for name, pair := range map[string][2][]string{
+		"children":  {got.Children, {"kid-001"}},
+		"blocking":  {got.Blocking, {"dep-001", "aft-001"}},
+		"blockedBy": {got.BlockedBy, {"pre-001"}},
+		"mentions":  {got.Mentions, {"ref-001"}},
+	} {
+		if !slices.Equal(pair[0], pair[1]) {
+			t.Errorf("result %s = %v, want %v", name, pair[0], pair[1])
+		}
+	}
+	if len(got.Sync) != 1 || got.Sync[0].Migrated || got.Sync[0].Reason == nil {
+		t.Errorf("result sync = %+v, want github reported as not migrated", got.Sync)
+	}
+}
+
+func TestMergeIssuesDeleteSourceMigratesSync(t *testing.T) {
+	resolver, c := seedMerge(t)
+
+	got, err := resolver.Mutation().MergeIssues(context.Background(), "src-001", "tgt-001", new(true), new(true), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get("src-001"); !errors.Is(err, core.ErrNotFound) {
+		t.Errorf("source still exists after deleteSource: %v", err)
+	}
+	if got.Source != nil || !got.DeletedSource {
+		t.Errorf("result source = %v, deletedSource %v", got.Source, got.DeletedSource)
+	}
+	tgt, _ := c.Get("tgt-001")
+	if !tgt.HasSync("github") || len(got.Sync) != 1 || !got.Sync[0].Migrated {
+		t.Errorf("target sync = %v, result %+v; want github migrated", tgt.Sync, got.Sync)
+	}
+	if kid, _ := c.Get("kid-001"); kid.Parent != "tgt-001" {
+		t.Errorf("child parent = %q, want tgt-001", kid.Parent)
+	}
+}
+
+func TestMergeIssuesRejectsCycle(t *testing.T) {
+	resolver, c := seedMerge(t)
+	// pre-001 blocks the source; if the target blocks pre-001 too, the
+	// merged target would block itself through it.
+	tgt, _ := c.Get("tgt-001")
+	tgt.Blocking = []string{"pre-001"}
+	if err := c.Update(tgt, nil); err != nil {
+		t.Fatal(err)
+	}
+	before := diskETags(t, c)
+
+	_, err := resolver.Mutation().MergeIssues(context.Background(), "src-001", "tgt-001", nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("MergeIssues() error = %v, want a cycle", err)
+	}
+	if after := diskETags(t, c); !maps.Equal(before, after) {
+		t.Error("a rejected merge changed issues on disk")
+	}
+	if src, _ := c.Get("src-001"); src.MergedInto != "" || src.Status != "ready" {
+		t.Error("a rejected merge changed the source in memory")
+	}
+}
+
+func TestMergeIssuesRejectsDescendantTarget(t *testing.T) {
+	resolver, _ := seedMerge(t)
+	if _, err := resolver.Mutation().MergeIssues(context.Background(), "src-001", "kid-001", nil, nil, nil); err == nil {
+		t.Error("merging into the source's own child succeeded")
+	}
+	if _, err := resolver.Mutation().MergeIssues(context.Background(), "src-001", "src-001", nil, nil, nil); err == nil {
+		t.Error("merging an issue into itself succeeded")
+	}
+}
+
+func TestMergeIssuesDryRun(t *testing.T) {
+	resolver, c := seedMerge(t)
+	before := diskETags(t, c)
+
+	got, err := resolver.Mutation().MergeIssues(context.Background(), "src-001", "tgt-001", new(true), new(true), new(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.DryRun || !strings.Contains(got.Target.Body, "## Merged from src-001") || !slices.Equal(got.Children, []string{"kid-001"}) {
+		t.Errorf("dry run result = %+v, want the merge it would make", got)
+	}
+	if after := diskETags(t, c); !maps.Equal(before, after) {
+		t.Errorf("dry run wrote to disk: before %v, after %v", before, after)
+	}
+	if tgt, _ := c.Get("tgt-001"); strings.Contains(tgt.Body, "Merged from") || tgt.HasSync("github") {
+		t.Error("dry run changed the stored target")
+	}
+	if kid, _ := c.Get("kid-001"); kid.Parent != "src-001" {
+		t.Error("dry run moved the child")
+	}
+}
@@ -4,6 +4,8 @@ package model
 
 import (
 	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
 )
 
 // Structured body modifications applied atomically.
@@ -21,6 +23,13 @@ type BodyModification struct {
 	// Each substring must match exactly one checked (- [x]) item.
 	// Applied after check, before append.
 	Uncheck []string `json:"uncheck,omitempty"`
+	// Replace the content of one section, leaving the heading and the rest of
+	// the body untouched. Mutually exclusive with replace. Applied before
+	// check/uncheck.
+	SetSection *SectionEdit `json:"setSection,omitempty"`
+	// Append text to the end of one section. Mutually exclusive with replace.
+	// Applied after setSection.
+	AppendToSection *SectionEdit `json:"appendToSection,omitempty"`
 	// Text to append after all replacements.
 	// Appended with blank line separator.
 	Append *string `json:"append,omitempty"`
@@ -30,6 +39,8 @@ type BodyModification struct {
 type CreateIssueInput struct {
 	// Issue title (required)
 	Title string `json:"title"`
+	// One-line summary shown in lists (max 160 characters, no newlines)
+	Summary *string `json:"summary,omitempty"`
 	// Issue type (defaults to 'task')
 	Type *string `json:"type,omitempty"`
 	// Status (defaults to 'todo')
@@ -38,6 +49,10 @@ type CreateIssueInput struct {
 	Priority *string `json:"priority,omitempty"`
 	// Milestone ID this issue is assigned to
 	Milestone *string `json:"milestone,omitempty"`
+	// Iteration: an ISO week such as 2025-W34, a name declared in the config, or 'current'
+	Iteration *string `json:"iteration,omitempty"`
+	// Expected effort: a duration such as 4h, 2d, or 1w
+	Estimate *string `json:"estimate,omitempty"`
 	// Tags for categorization
 	Tags []string `json:"tags,omitempty"`
 	// Markdown body content
@@ -50,6 +65,18 @@ type CreateIssueInput struct {
 	Blocking []string `json:"blocking,omitempty"`
 	// Issue IDs that are blocking this issue
 	BlockedBy []string `json:"blockedBy,omitempty"`
+	// Encrypt the body at rest (requires JIG_ISSUE_KEY or issue_key_file)
+	Encrypted *bool `json:"encrypted,omitempty"`
+	// Pin the issue so it sorts ahead of the rest
+	Pinned *bool `json:"pinned,omitempty"`
+	// public (the default) or internal
+	Visibility *string `json:"visibility,omitempty"`
+	// Customer-facing title the changelog uses in place of the title
+	ReleaseTitle *string `json:"releaseTitle,omitempty"`
+	// Customer-facing note the changelog uses in place of the body
+	ReleaseNote *string `json:"releaseNote,omitempty"`
+	// Who is creating the issue, recorded as createdBy (defaults to the caller's actor, if any)
+	Actor *string `json:"actor,omitempty"`
 }
 
 // Input for creating a new milestone
@@ -66,7 +93,7 @@ type CreateMilestoneInput struct {
 
 // Filter options for querying issues
 type IssueFilter struct {
-	// Full-text search across slug, title, and body using Bleve query syntax.
+	// Full-text search across slug, title, summary, and body using Bleve query syntax.
 	//
 	// Examples:
 	// - "login" - exact term match
@@ -78,6 +105,7 @@ type IssueFilter struct {
 	// - "user OR login" - either term
 	// - "slug:auth" - search only slug field
 	// - "title:login" - search only title field
+	// - "summary:login" - search only summary field
 	// - "body:auth" - search only body field
 	Search *string `json:"search,omitempty"`
 	// Include only issues with these statuses (OR logic)
@@ -100,6 +128,14 @@ type IssueFilter struct {
 	Milestone []string `json:"milestone,omitempty"`
 	// Exclude issues assigned to any of these milestone IDs
 	ExcludeMilestone []string `json:"excludeMilestone,omitempty"`
+	// Include only issues assigned to any of these iterations (OR logic; 'current' resolves to the current iteration)
+	Iteration []string `json:"iteration,omitempty"`
+	// Exclude issues assigned to any of these iterations
+	ExcludeIteration []string `json:"excludeIteration,omitempty"`
+	// Include only issues created by any of these actors (OR logic)
+	CreatedBy []string `json:"createdBy,omitempty"`
+	// Include only issues created through any of these entry points: cli, tui, graphql, import, or sync (OR logic)
+	CreatedVia []string `json:"createdVia,omitempty"`
 	// Include only issues with a parent
 	HasParent *bool `json:"hasParent,omitempty"`
 	// Include only issues with this specific parent ID
@@ -128,11 +164,86 @@ type IssueFilter struct {
 	SyncStale *string `json:"syncStale,omitempty"`
 	// Include only issues updated at or after this timestamp
 	ChangedSince *time.Time `json:"changedSince,omitempty"`
+	// Include only issues due on or before this date (YYYY-MM-DD) or time (RFC 3339).
+	// A date-only boundary includes the whole day.
+	DueBefore *string `json:"dueBefore,omitempty"`
+	// Include only issues due on or after this date (YYYY-MM-DD) or time (RFC 3339).
+	// A date-only boundary includes the whole day.
+	DueAfter *string `json:"dueAfter,omitempty"`
+	// Include only stale issues (true) or only non-stale issues (false); see stale_after
+	IsStale *bool `json:"isStale,omitempty"`
+	// Include only pinned issues (true) or only unpinned issues (false)
+	Pinned *bool `json:"pinned,omitempty"`
+	// Include only issues with this visibility (public or internal)
+	Visibility *string `json:"visibility,omitempty"`
+}
+
+// One link for linkIssues to add
+type LinkInput struct {
+	// Issue the link is stored on
+	ID string `json:"id"`
+	// blocking, blocked_by, or parent
+	Type string `json:"type"`
+	// Issue the link points to
+	Target string `json:"target"`
+}
+
+// What mergeIssues changed, or with dryRun would change
+type MergeResult struct {
+	// The target with the source merged in
+	Target *issue.Issue `json:"target"`
+	// The source as marked merged, or null when it was deleted
+	Source *issue.Issue `json:"source,omitempty"`
+	// Children moved from the source to the target
+	Children []string `json:"children"`
+	// Issues the target now blocks in place of the source
+	Blocking []string `json:"blocking"`
+	// Issues that now block the target in place of the source
+	BlockedBy []string `json:"blockedBy"`
+	// Issues whose bodies mentioned the source and now mention the target
+	Mentions []string `json:"mentions"`
+	// Tags the target gained from the source
+	TagsAdded []string `json:"tagsAdded"`
+	// The source's sync entries and whether each moved to the target
+	Sync []*MergeSync `json:"sync"`
+	// True when the source was deleted rather than scrapped
+	DeletedSource bool `json:"deletedSource"`
+	// True when nothing was saved
+	DryRun bool `json:"dryRun"`
+}
+
+// One sync entry of a merged source
+type MergeSync struct {
+	// Integration name
+	Name string `json:"name"`
+	// True when the entry moved to the target
+	Migrated bool `json:"migrated"`
+	// Why the entry stayed on the source (null when migrated)
+	Reason *string `json:"reason,omitempty"`
 }
 
 type Mutation struct {
 }
 
+// One choice in a status, type, priority, or sort picker.
+type PickerOption struct {
+	// Value to pass back, e.g. to updateIssue
+	Name string `json:"name"`
+	// Text a picker shows for the option
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	// Glyph a picker shows beside the label, or empty for none
+	Icon string `json:"icon"`
+	// Configured color name or hex value, or empty for none
+	Color string `json:"color"`
+	// Whether the option is the issue's current value (always false without forIssue)
+	Current bool `json:"current"`
+	// Whether choosing the option would be accepted
+	Applicable bool `json:"applicable"`
+	// Why the option is not applicable, or empty
+	Reason string `json:"reason"`
+}
+
 type Query struct {
 }
 
@@ -144,18 +255,32 @@ type ReplaceOperation struct {
 	New string `json:"new"`
 }
 
+// An edit to one heading-delimited section of a body.
+type SectionEdit struct {
+	// Heading text of the section (case-insensitive, must be unique in the body)
+	Title string `json:"title"`
+	// New content (setSection) or text to append (appendToSection)
+	Content string `json:"content"`
+	// Create the section as a level-2 heading at the end of the body if missing
+	CreateIfMissing *bool `json:"createIfMissing,omitempty"`
+}
+
 // Sync metadata entry for a single integration
 type SyncEntry struct {
 	// Integration name (e.g., 'clickup', 'github')
 	Name string `json:"name"`
 	// Integration-specific data (arbitrary key-value pairs)
 	Data map[string]any `json:"data"`
+	// Web URL of the linked item, from the provider integration or a sync_url_templates entry; null when neither can build one
+	URL *string `json:"url,omitempty"`
 }
 
 // Input for updating an existing issue
 type UpdateIssueInput struct {
 	// New title
 	Title *string `json:"title,omitempty"`
+	// New one-line summary (empty string to clear)
+	Summary *string `json:"summary,omitempty"`
 	// New status
 	Status *string `json:"status,omitempty"`
 	// New type
@@ -164,6 +289,10 @@ type UpdateIssueInput struct {
 	Priority *string `json:"priority,omitempty"`
 	// Milestone ID (empty string to clear)
 	Milestone *string `json:"milestone,omitempty"`
+	// Iteration, or 'current' (empty string to clear)
+	Iteration *string `json:"iteration,omitempty"`
+	// Expected effort, such as 4h or 2d (empty string to clear)
+	Estimate *string `json:"estimate,omitempty"`
 	// Replace all tags (nil preserves existing, mutually exclusive with addTags/removeTags)
 	Tags []string `json:"tags,omitempty"`
 	// Add tags to existing list
@@ -176,6 +305,16 @@ type UpdateIssueInput struct {
 	BodyMod *BodyModification `json:"bodyMod,omitempty"`
 	// Due date in YYYY-MM-DD format (empty string to clear)
 	Due *string `json:"due,omitempty"`
+	// Encrypt (true) or decrypt (false) the body at rest
+	Encrypted *bool `json:"encrypted,omitempty"`
+	// Pin (true) or unpin (false) the issue
+	Pinned *bool `json:"pinned,omitempty"`
+	// public or internal (empty string for the default, public)
+	Visibility *string `json:"visibility,omitempty"`
+	// Customer-facing changelog title (empty string to clear)
+	ReleaseTitle *string `json:"releaseTitle,omitempty"`
+	// Customer-facing changelog note (empty string to clear)
+	ReleaseNote *string `json:"releaseNote,omitempty"`
 	// Set parent issue ID (null/empty to clear, validates type hierarchy)
 	Parent *string `json:"parent,omitempty"`
 	// Add issues to blocking list (validates cycles and existence)
@@ -186,6 +325,8 @@ type UpdateIssueInput struct {
 	AddBlockedBy []string `json:"addBlockedBy,omitempty"`
 	// Remove issues from blocked-by list
 	RemoveBlockedBy []string `json:"removeBlockedBy,omitempty"`
+	// Replace the body even if that removes unchecked tasks, which protect_unchecked_tasks: strict otherwise rejects
+	Force *bool `json:"force,omitempty"`
 	// ETag for optimistic concurrency control (optional)
 	IfMatch *string `json:"ifMatch,omitempty"`
 }
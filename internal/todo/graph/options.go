@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// sortChoices are the list sort orders, in picker order.
+var sortChoices = []model.PickerOption{
+	{Name: config.SortDefault, Label: "Default", Description: "Status, priority, type, then title"},
+	{Name: "status", Label: "Status", Description: "Status order, then newest created"},
+	{Name: "priority", Label: "Priority", Description: "Priority order, then newest created"},
+	{Name: "created", Label: "Created", Description: "Newest created first"},
+	{Name: "updated", Label: "Updated", Description: "Last updated first"},
+	{Name: "due", Label: "Due", Description: "Soonest due first"},
+}
+
+// StatusOptions returns the statusOptions choices for issues, which may be
+// empty. An option is current when every issue has it, and applicable
+// unless every issue refuses it; when only some do, reason says how many.
+func (r *Resolver) StatusOptions(issues []*issue.Issue) []*model.PickerOption {
+	cfg := r.Core.Config()
+	var opts []*model.PickerOption
+	for _, s := range config.DefaultStatuses {
+		current := isCurrent(issues, s.Name, func(b *issue.Issue) string { return b.Status })
+		if !cfg.IsStatusEnabled(s.Name) && !current {
+			continue
+		}
+		opt := &model.PickerOption{
+			Name:        s.Name,
+			Label:       s.Name,
+			Description: s.Description,
+			Icon:        s.Icon,
+			Color:       s.Color,
+			Current:     current,
+		}
+		refuse(opt, issues, func(b *issue.Issue) string { return r.StatusRefusal(b, s.Name) })
+		opts = append(opts, opt)
+	}
+	return opts
+}
+
+// TypeOptions returns the typeOptions choices for issues, with current and
+// applicable as for StatusOptions.
+func (r *Resolver) TypeOptions(issues []*issue.Issue) []*model.PickerOption {
+	types := r.Core.Config().TypeConfigs()
+	opts := make([]*model.PickerOption, 0, len(types))
+	for _, t := range types {
+		opt := &model.PickerOption{
+			Name:        t.Name,
+			Label:       t.Name,
+			Description: t.Description,
+			Icon:        t.Icon,
+			Color:       t.Color,
+			Current:     isCurrent(issues, t.Name, func(b *issue.Issue) string { return b.Type }),
+		}
+		refuse(opt, issues, func(b *issue.Issue) string { return r.TypeRefusal(b, t.Name) })
+		opts = append(opts, opt)
+	}
+	return opts
+}
+
+// PriorityOptions returns the priorityOptions choices, marking the one
+// every issue has as current. Any priority is applicable.
+func (r *Resolver) PriorityOptions(issues []*issue.Issue) []*model.PickerOption {
+	opts := make([]*model.PickerOption, 0, len(config.DefaultPriorities))
+	for _, p := range config.DefaultPriorities {
+		opts = append(opts, &model.PickerOption{
+			Name:        p.Name,
+			Label:       p.Name,
+			Description: p.Description,
+			Color:       p.Color,
+			Current:     isCurrent(issues, p.Name, func(b *issue.Issue) string { return b.Priority }),
+			Applicable:  true,
+		})
+	}
+	return opts
+}
+
+// SortOptions returns the sortOptions choices, all applicable.
+func (r *Resolver) SortOptions() []*model.PickerOption {
+	opts := make([]*model.PickerOption, len(sortChoices))
+	for i, c := range sortChoices {
+		c.Applicable = true
+		opts[i] = &c
+	}
+	return opts
+}
+
+// StatusRefusal says why b cannot move into status, or "" if it can. It
+// applies the parent-completion rule up front, so an issue only moves into
+// a complete status once all of its children are complete, and the
+// config's validation rules, reporting the missing fields.
+func (r *Resolver) StatusRefusal(b *issue.Issue, status string) string {
+	if status == b.Status {
+		return ""
+	}
+	if config.IsCompleteStatus(status) {
+		for _, child := range r.Core.ChildrenOf(b.ID) {
+			if !config.IsCompleteStatus(child.Status) {
+				return "children not complete"
+			}
+		}
+	}
+	if err := r.Core.CheckRules(b, status); err != nil {
+		return err.Reason()
+	}
+	return ""
+}
+
+// TypeRefusal says why b cannot change to issueType, or "" if it can: the
+// new type must accept the issue's current parent, and must itself be a
+// valid parent for the issue's children.
+func (r *Resolver) TypeRefusal(b *issue.Issue, issueType string) string {
+	if issueType == b.Type {
+		return ""
+	}
+	if b.Parent != "" {
+		valid := r.Core.ValidParentTypes(issueType)
+		if valid == nil {
+			return NoParentReason(issueType)
+		}
+		if p, err := r.Core.Get(b.Parent); err == nil && !slices.Contains(valid, p.Type) {
+			return fmt.Sprintf("%ss cannot have a %s parent", issueType, p.Type)
+		}
+	}
+	for _, child := range r.Core.ChildrenOf(b.ID) {
+		if !slices.Contains(r.Core.ValidParentTypes(child.Type), issueType) {
+			return fmt.Sprintf("%s children cannot have a %s parent", child.Type, issueType)
+		}
+	}
+	return ""
+}
+
+// NoParentReason explains why issues of a type cannot take a parent.
+func NoParentReason(issueType string) string {
+	return fmt.Sprintf("%ss cannot have parents", issueType)
+}
+
+// optionIssues resolves a picker query's forIssue argument: no issues
+// when it is omitted, else the one issue, which must exist.
+func (r *Resolver) optionIssues(forIssue *string) ([]*issue.Issue, error) {
+	if forIssue == nil {
+		return nil, nil
+	}
+	b, err := r.Core.Get(*forIssue)
+	if err != nil {
+		return nil, err
+	}
+	return []*issue.Issue{b}, nil
+}
+
+// isCurrent reports whether every one of issues has value, as read by
+// field; it is false for no issues.
+func isCurrent(issues []*issue.Issue, value string, field func(*issue.Issue) string) bool {
+	if len(issues) == 0 {
+		return false
+	}
+	for _, b := range issues {
+		if field(b) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// refuse sets opt's applicable and reason from check, which returns why
+// an issue refuses the option or "". A refusal by only some of issues
+// leaves the option applicable, with a reason counting them.
+func refuse(opt *model.PickerOption, issues []*issue.Issue, check func(*issue.Issue) string) {
+	var reason string
+	refused := 0
+	for _, b := range issues {
+		if why := check(b); why != "" {
+			refused++
+			reason = cmp.Or(reason, why)
+		}
+	}
+	if refused > 0 && refused < len(issues) {
+		reason = fmt.Sprintf("%d of %d: %s", refused, len(issues), reason)
+	}
+	opt.Reason = reason
+	opt.Applicable = refused == 0 || refused < len(issues)
+}
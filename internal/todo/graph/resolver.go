@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
@@ -19,14 +21,15 @@ type Resolver struct {
 }
 
 // validateETag checks if the provided ifMatch etag matches the issue's current etag.
-// Returns an error if validation fails or if require_if_match is enabled and no etag provided.
-func (r *Resolver) validateETag(b *issue.Issue, ifMatch *string) error {
-	cfg := r.Core.Config()
-	requireIfMatch := cfg != nil && cfg.RequireIfMatch
-
-	// If require_if_match is enabled and no etag provided, reject
-	if requireIfMatch && (ifMatch == nil || *ifMatch == "") {
-		return &core.ETagRequiredError{}
+// Returns an error if validation fails or if require_if_match protects any of
+// the given field classes (every class when none are given) and no etag is
+// provided.
+func (r *Resolver) validateETag(b *issue.Issue, ifMatch *string, classes ...string) error {
+	if len(classes) == 0 {
+		classes = config.IfMatchFields
+	}
+	if err := r.requireETag(ifMatch, classes); err != nil {
+		return err
 	}
 
 	// If ifMatch provided, validate it
@@ -40,6 +43,71 @@ func (r *Resolver) validateETag(b *issue.Issue, ifMatch *string) error {
 	return nil
 }
 
+// requireETag rejects a write without an etag when require_if_match
+// protects one of the field classes it touches, naming the first such class.
+func (r *Resolver) requireETag(ifMatch *string, classes []string) error {
+	cfg := r.Core.Config()
+	if cfg == nil || (ifMatch != nil && *ifMatch != "") {
+		return nil
+	}
+	if field, ok := cfg.RequireIfMatch.FirstRequired(classes); ok {
+		return &core.ETagRequiredError{Field: field}
+	}
+	return nil
+}
+
+// updateFieldClasses returns the require_if_match field classes an update
+// touches: body for body edits and encryption, status for a status change,
+// and metadata for anything else.
+func updateFieldClasses(input model.UpdateIssueInput) []string {
+	var classes []string
+	if input.Body != nil || input.BodyMod != nil || input.Encrypted != nil {
+		classes = append(classes, config.IfMatchBody)
+	}
+	if input.Status != nil {
+		classes = append(classes, config.IfMatchStatus)
+	}
+	if input.Title != nil || input.Summary != nil || input.Type != nil || input.Priority != nil ||
+		input.Milestone != nil || input.Iteration != nil || input.Estimate != nil || input.Tags != nil || input.AddTags != nil ||
+		input.RemoveTags != nil || input.Due != nil || input.Pinned != nil || input.Visibility != nil ||
+		input.ReleaseTitle != nil || input.ReleaseNote != nil ||
+		input.Parent != nil || input.AddBlocking != nil || input.RemoveBlocking != nil ||
+		input.AddBlockedBy != nil || input.RemoveBlockedBy != nil {
+		classes = append(classes, config.IfMatchMetadata)
+	}
+	return classes
+}
+
+// validateValues checks the status, type, and priority an input sets against
+// the config before anything is mutated, so a typo fails with a suggestion
+// and leaves the stored issue untouched. On update, b is the issue being
+// changed and a value equal to its current one is always accepted; b is nil
+// on create.
+func (r *Resolver) validateValues(b *issue.Issue, status, typ, priority *string) error {
+	cfg := r.Core.Config()
+	if cfg == nil {
+		return nil
+	}
+	checks := []struct {
+		value    *string
+		current  func(*issue.Issue) string
+		validate func(string) error
+	}{
+		{status, func(b *issue.Issue) string { return b.Status }, cfg.ValidateStatus},
+		{typ, func(b *issue.Issue) string { return b.Type }, cfg.ValidateType},
+		{priority, func(b *issue.Issue) string { return b.Priority }, cfg.ValidatePriority},
+	}
+	for _, c := range checks {
+		if c.value == nil || (b != nil && c.current(b) == *c.value) {
+			continue
+		}
+		if err := c.validate(*c.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateParentCompletion guards the transition of an issue into a complete
 // status (completed, scrapped, deferred). A parent may only enter such a status
 // once all of its children are themselves in a complete status. It is a no-op
@@ -53,7 +121,7 @@ func (r *Resolver) validateParentCompletion(b *issue.Issue, newStatus string) er
 	}
 
 	var incomplete []string
-	for _, child := range r.Core.Children(b.ID) {
+	for _, child := range r.Core.ChildrenOf(b.ID) {
 		if !config.IsCompleteStatus(child.Status) {
 			incomplete = append(incomplete, fmt.Sprintf("%s (%s)", child.ID, child.Status))
 		}
@@ -85,6 +153,10 @@ func (r *Resolver) validateAndSetParent(b *issue.Issue, parentID string) error {
 		return fmt.Errorf("setting parent would create cycle: %v", cycle)
 	}
 
+	if err := r.Core.ValidateHierarchyDepth(b, normalizedParent); err != nil {
+		return err
+	}
+
 	b.Parent = normalizedParent
 	return nil
 }
@@ -103,6 +175,28 @@ func (r *Resolver) inheritMilestoneFromParent(b *issue.Issue) {
 	}
 }
 
+// resolveIteration maps "current" to the current iteration and validates
+// any other name against the config. Empty clears the iteration.
+func (r *Resolver) resolveIteration(name string) (string, error) {
+	cfg := r.Core.Config()
+	if name == "" || cfg == nil {
+		return name, nil
+	}
+	return cfg.ResolveIteration(name, r.Core.Now())
+}
+
+// resolveVisibility validates a visibility and returns what is stored for
+// it: public is the default, so it is stored as empty.
+func resolveVisibility(v string) (string, error) {
+	if err := config.ValidateVisibility(v); err != nil {
+		return "", err
+	}
+	if v == config.VisibilityPublic {
+		return "", nil
+	}
+	return v, nil
+}
+
 // validateAndAddBlocking validates and adds blocking relationships.
 func (r *Resolver) validateAndAddBlocking(b *issue.Issue, targetIDs []string) error {
 	for _, targetID := range targetIDs {
@@ -116,7 +210,7 @@ func (r *Resolver) validateAndAddBlocking(b *issue.Issue, targetIDs []string) er
 
 		// Validate: target must exist
 		if _, err := r.Core.Get(normalizedTargetID); err != nil {
-			return fmt.Errorf("blocking target issue not found: %s", targetID)
+			return fmt.Errorf("blocking target %w", r.Core.NotFound(targetID))
 		}
 
 		// Check for cycles in both directions
@@ -153,7 +247,7 @@ func (r *Resolver) validateAndAddBlockedBy(b *issue.Issue, targetIDs []string) e
 
 		// Validate: blocker must exist
 		if _, err := r.Core.Get(normalizedTargetID); err != nil {
-			return fmt.Errorf("blocker issue not found: %s", targetID)
+			return fmt.Errorf("blocker %w", r.Core.NotFound(targetID))
 		}
 
 		// Check for cycles in both directions
@@ -176,3 +270,47 @@ func (r *Resolver) removeBlockedByRelationships(b *issue.Issue, targetIDs []stri
 		b.RemoveBlockedBy(normalizedTargetID)
 	}
 }
+
+// unlinkRemovedBlocking clears the removed blocking and blocked-by links
+// from the other issue too, since a link may be stored on either end.
+func (r *Resolver) unlinkRemovedBlocking(b *issue.Issue, removeBlocking, removeBlockedBy []string) error {
+	for _, targetID := range removeBlocking {
+		normalizedTargetID, _ := r.Core.NormalizeID(targetID)
+		if err := r.Core.UnlinkBlocking(b.ID, normalizedTargetID); err != nil {
+			return err
+		}
+	}
+	for _, blockerID := range removeBlockedBy {
+		normalizedBlockerID, _ := r.Core.NormalizeID(blockerID)
+		if err := r.Core.UnlinkBlocking(normalizedBlockerID, b.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MoveHistorySection is the body section moveIssue records moves in.
+const MoveHistorySection = "History"
+
+// moveNote is the History line recording a move of b to parentID, or "" when
+// the parent is unchanged (issues have no sibling order, so nothing moves),
+// move notes are off, or the body cannot be edited.
+func (r *Resolver) moveNote(b *issue.Issue, parentID string) string {
+	if parentID == b.Parent {
+		return ""
+	}
+	if cfg := r.Core.Config(); cfg != nil && cfg.SkipMoveNotes {
+		return ""
+	}
+	if b.Encrypted && b.Body == issue.EncryptedPlaceholder {
+		return ""
+	}
+	where := func(id string) string {
+		if id == "" {
+			return "top level"
+		}
+		return id
+	}
+	return fmt.Sprintf("- %s: moved from %s to %s",
+		r.Core.Now().UTC().Format(time.RFC3339), where(b.Parent), where(parentID))
+}
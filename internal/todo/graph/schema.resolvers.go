@@ -6,6 +6,7 @@ package graph
 // Code generated by github.com/99designs/gqlgen version v0.17.90
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -15,9 +16,15 @@ import (
 	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/integration"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
+// Synopsis is the resolver for the synopsis field.
+func (r *issueResolver) Synopsis(ctx context.Context, obj *issue.Issue) (string, error) {
+	return obj.Synopsis(0), nil
+}
+
 // Due is the resolver for the due field.
 func (r *issueResolver) Due(ctx context.Context, obj *issue.Issue) (*string, error) {
 	if obj.Due == nil {
@@ -27,8 +34,20 @@ func (r *issueResolver) Due(ctx context.Context, obj *issue.Issue) (*string, err
 	return &s, nil
 }
 
+// Visibility is the resolver for the visibility field.
+func (r *issueResolver) Visibility(ctx context.Context, obj *issue.Issue) (string, error) {
+	return cmp.Or(obj.Visibility, config.VisibilityPublic), nil
+}
+
+// Stale is the resolver for the stale field.
+func (r *issueResolver) Stale(ctx context.Context, obj *issue.Issue) (bool, error) {
+	c := r.reader(ctx)
+	return c.IsStale(obj), nil
+}
+
 // Sync is the resolver for the sync field.
 func (r *issueResolver) Sync(ctx context.Context, obj *issue.Issue) ([]*model.SyncEntry, error) {
+	c := r.reader(ctx)
 	if len(obj.Sync) == 0 {
 		return []*model.SyncEntry{}, nil
 	}
@@ -40,12 +59,17 @@ func (r *issueResolver) Sync(ctx context.Context, obj *issue.Issue) ([]*model.Sy
 	}
 	sort.Strings(names)
 
+	cfg := c.Config()
 	entries := make([]*model.SyncEntry, 0, len(obj.Sync))
 	for _, name := range names {
-		entries = append(entries, &model.SyncEntry{
+		entry := &model.SyncEntry{
 			Name: name,
 			Data: obj.Sync[name],
-		})
+		}
+		if url := integration.SyncURL(obj, name, cfg); url != "" {
+			entry.URL = &url
+		}
+		entries = append(entries, entry)
 	}
 	return entries, nil
 }
@@ -70,50 +94,65 @@ func (r *issueResolver) BlockedByIds(ctx context.Context, obj *issue.Issue) ([]s
 
 // BlockedBy is the resolver for the blockedBy field.
 func (r *issueResolver) BlockedBy(ctx context.Context, obj *issue.Issue, filter *model.IssueFilter) ([]*issue.Issue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	seen := make(map[string]bool)
 	var result []*issue.Issue
 
 	// Source 1: issues that declare obj in their blocking list
-	incoming := r.Core.FindIncomingLinks(obj.ID)
-	for _, link := range incoming {
-		if link.LinkType == issue.LinkTypeBlocking {
-			seen[link.FromIssue.ID] = true
-			result = append(result, link.FromIssue)
-		}
+	for _, blocker := range c.BlockersOf(obj.ID) {
+		seen[blocker.ID] = true
+		result = append(result, blocker)
 	}
 
 	// Source 2: issues listed in obj's blocked_by field
 	for _, blockerID := range obj.BlockedBy {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if seen[blockerID] {
 			continue
 		}
-		if blocker, err := r.Core.Get(blockerID); err == nil {
+		if blocker, err := c.Get(blockerID); err == nil {
 			result = append(result, blocker)
 		}
 	}
 
-	return ApplyFilter(result, filter, r.Core), nil
+	return ApplyFilter(result, filter, c), nil
 }
 
 // Blocking is the resolver for the blocking field.
 func (r *issueResolver) Blocking(ctx context.Context, obj *issue.Issue, filter *model.IssueFilter) ([]*issue.Issue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	var result []*issue.Issue
 	for _, targetID := range obj.Blocking {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		// Filter out broken links
-		if target, err := r.Core.Get(targetID); err == nil {
+		if target, err := c.Get(targetID); err == nil {
 			result = append(result, target)
 		}
 	}
-	return ApplyFilter(result, filter, r.Core), nil
+	return ApplyFilter(result, filter, c), nil
 }
 
 // Parent is the resolver for the parent field.
 func (r *issueResolver) Parent(ctx context.Context, obj *issue.Issue) (*issue.Issue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if obj.Parent == "" {
 		return nil, nil
 	}
 	// Filter out broken links
-	parent, err := r.Core.Get(obj.Parent)
+	parent, err := c.Get(obj.Parent)
 	if errors.Is(err, core.ErrNotFound) {
 		return nil, nil
 	}
@@ -122,14 +161,43 @@ func (r *issueResolver) Parent(ctx context.Context, obj *issue.Issue) (*issue.Is
 
 // Children is the resolver for the children field.
 func (r *issueResolver) Children(ctx context.Context, obj *issue.Issue, filter *model.IssueFilter) ([]*issue.Issue, error) {
-	incoming := r.Core.FindIncomingLinks(obj.ID)
-	var result []*issue.Issue
-	for _, link := range incoming {
-		if link.LinkType == issue.LinkTypeParent {
-			result = append(result, link.FromIssue)
-		}
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	return ApplyFilter(result, filter, r.Core), nil
+	return ApplyFilter(c.ChildrenOf(obj.ID), filter, c), nil
+}
+
+// Mentions is the resolver for the mentions field.
+func (r *issueResolver) Mentions(ctx context.Context, obj *issue.Issue, filter *model.IssueFilter) ([]*issue.Issue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ApplyFilter(c.Mentions(obj.ID), filter, c), nil
+}
+
+// MentionedBy is the resolver for the mentionedBy field.
+func (r *issueResolver) MentionedBy(ctx context.Context, obj *issue.Issue, filter *model.IssueFilter) ([]*issue.Issue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ApplyFilter(c.MentionedBy(obj.ID), filter, c), nil
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *issueResolver) Revisions(ctx context.Context, obj *issue.Issue) ([]*core.Revision, error) {
+	c := r.reader(ctx)
+	revisions, err := c.Revisions(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*core.Revision, len(revisions))
+	for i := range revisions {
+		result[i] = &revisions[i]
+	}
+	return result, nil
 }
 
 // Due is the resolver for the due field.
@@ -143,12 +211,21 @@ func (r *milestoneResolver) Due(ctx context.Context, obj *issue.Milestone) (*str
 
 // CreateIssue is the resolver for the createIssue field.
 func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIssueInput) (*issue.Issue, error) {
+	if err := r.validateValues(nil, input.Status, input.Type, input.Priority); err != nil {
+		return nil, err
+	}
+
 	b := &issue.Issue{
 		Slug:     issue.Slugify(input.Title),
 		Title:    input.Title,
 		Type:     config.TypeTask,
 		Blocking: []string{},
 	}
+	creator := creatorFrom(ctx)
+	if input.Actor != nil {
+		creator.By = *input.Actor
+	}
+	b.CreatedBy, b.CreatedVia = creator.By, creator.Via
 
 	// Optional fields with defaults documented in schema
 	if input.Type != nil {
@@ -166,6 +243,22 @@ func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIs
 		}
 		b.Milestone = *input.Milestone
 	}
+	if input.Iteration != nil {
+		iteration, err := r.resolveIteration(*input.Iteration)
+		if err != nil {
+			return nil, err
+		}
+		b.Iteration = iteration
+	}
+	if input.Estimate != nil {
+		b.Estimate = *input.Estimate
+	}
+	if input.Summary != nil {
+		if err := issue.ValidateSummary(*input.Summary); err != nil {
+			return nil, err
+		}
+		b.Summary = *input.Summary
+	}
 	if input.Body != nil {
 		b.Body = *input.Body
 	}
@@ -179,6 +272,25 @@ func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIs
 		}
 		b.Due = due
 	}
+	if input.Encrypted != nil {
+		b.Encrypted = *input.Encrypted
+	}
+	if input.Pinned != nil {
+		b.Pinned = *input.Pinned
+	}
+	if input.Visibility != nil {
+		visibility, err := resolveVisibility(*input.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		b.Visibility = visibility
+	}
+	if input.ReleaseTitle != nil {
+		b.ReleaseTitle = *input.ReleaseTitle
+	}
+	if input.ReleaseNote != nil {
+		b.ReleaseNote = *input.ReleaseNote
+	}
 
 	// Handle parent (with validation)
 	if input.Parent != nil && *input.Parent != "" {
@@ -187,6 +299,9 @@ func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIs
 		if err := r.Core.ValidateParent(b, parentID); err != nil {
 			return nil, err
 		}
+		if err := r.Core.ValidateHierarchyDepth(b, parentID); err != nil {
+			return nil, err
+		}
 		b.Parent = parentID
 		// Inherit the parent's milestone when none was given for the child.
 		r.inheritMilestoneFromParent(b)
@@ -200,7 +315,7 @@ func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIs
 			normalizedBlocking[i], _ = r.Core.NormalizeID(id)
 			// Verify target exists
 			if _, err := r.Core.Get(normalizedBlocking[i]); err != nil {
-				return nil, fmt.Errorf("target issue not found: %s", id)
+				return nil, fmt.Errorf("target %w", r.Core.NotFound(id))
 			}
 		}
 		b.Blocking = normalizedBlocking
@@ -214,7 +329,7 @@ func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIs
 			normalizedBlockedBy[i], _ = r.Core.NormalizeID(id)
 			// Verify blocker exists
 			if _, err := r.Core.Get(normalizedBlockedBy[i]); err != nil {
-				return nil, fmt.Errorf("blocker issue not found: %s", id)
+				return nil, fmt.Errorf("blocker %w", r.Core.NotFound(id))
 			}
 		}
 		// Check for cycles with blocking relationships
@@ -236,21 +351,45 @@ func (r *mutationResolver) CreateIssue(ctx context.Context, input model.CreateIs
 
 // UpdateIssue is the resolver for the updateIssue field.
 func (r *mutationResolver) UpdateIssue(ctx context.Context, id string, input model.UpdateIssueInput) (*issue.Issue, error) {
-	b, err := r.Core.Get(id)
+	b, err := r.Core.LookupWith(id, core.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 
+	// Reject a missing etag before anything is mutated, naming the
+	// protected field class the update touches.
+	if err := r.requireETag(input.IfMatch, updateFieldClasses(input)); err != nil {
+		return nil, err
+	}
+
 	// Validate body and bodyMod are mutually exclusive
 	if input.Body != nil && input.BodyMod != nil {
 		return nil, errors.New("cannot specify both body and bodyMod")
 	}
+	if m := input.BodyMod; m != nil && m.Replace != nil && (m.SetSection != nil || m.AppendToSection != nil) {
+		return nil, errors.New("cannot specify both replace and setSection/appendToSection")
+	}
 
 	// Validate tags and addTags/removeTags are mutually exclusive
 	if input.Tags != nil && (input.AddTags != nil || input.RemoveTags != nil) {
 		return nil, errors.New("cannot specify both tags and addTags/removeTags")
 	}
 
+	// Without the key the body is only a placeholder; editing it would
+	// encrypt the placeholder over the real content.
+	if b.Encrypted && b.Body == issue.EncryptedPlaceholder && (input.Body != nil || input.BodyMod != nil) {
+		return nil, core.ErrIssueKeyUnavailable
+	}
+
+	// A wholesale body rewrite must not silently drop open tasks; check
+	// before anything is mutated so a rejection leaves b untouched.
+	var removedTasks []string
+	if input.Body != nil {
+		if removedTasks, err = r.Core.CheckRemovedTasks(b, *input.Body, input.Force != nil && *input.Force); err != nil {
+			return nil, err
+		}
+	}
+
 	// Guard parent completion before mutating b so b.Status still reflects the
 	// current status. A parent cannot enter a complete status (completed,
 	// scrapped, deferred) while any child is still active.
@@ -260,10 +399,22 @@ func (r *mutationResolver) UpdateIssue(ctx context.Context, id string, input mod
 		}
 	}
 
+	if input.Summary != nil {
+		if err := issue.ValidateSummary(*input.Summary); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.validateValues(b, input.Status, input.Type, input.Priority); err != nil {
+		return nil, err
+	}
+
 	// Update fields if provided
 	if input.Title != nil {
 		b.Title = *input.Title
 	}
+	if input.Summary != nil {
+		b.Summary = *input.Summary
+	}
 	if input.Status != nil {
 		b.Status = *input.Status
 	}
@@ -283,6 +434,16 @@ func (r *mutationResolver) UpdateIssue(ctx context.Context, id string, input mod
 			b.Milestone = *input.Milestone
 		}
 	}
+	if input.Iteration != nil {
+		iteration, err := r.resolveIteration(*input.Iteration)
+		if err != nil {
+			return nil, err
+		}
+		b.Iteration = iteration
+	}
+	if input.Estimate != nil {
+		b.Estimate = *input.Estimate
+	}
 	if input.Due != nil {
 		if *input.Due == "" {
 			b.Due = nil
@@ -294,6 +455,25 @@ func (r *mutationResolver) UpdateIssue(ctx context.Context, id string, input mod
 			b.Due = due
 		}
 	}
+	if input.Encrypted != nil {
+		b.Encrypted = *input.Encrypted
+	}
+	if input.Pinned != nil {
+		b.Pinned = *input.Pinned
+	}
+	if input.Visibility != nil {
+		visibility, err := resolveVisibility(*input.Visibility)
+		if err != nil {
+			return nil, err
+		}
+		b.Visibility = visibility
+	}
+	if input.ReleaseTitle != nil {
+		b.ReleaseTitle = *input.ReleaseTitle
+	}
+	if input.ReleaseNote != nil {
+		b.ReleaseNote = *input.ReleaseNote
+	}
 	if input.Body != nil {
 		b.Body = *input.Body
 	} else if input.BodyMod != nil {
@@ -311,6 +491,22 @@ func (r *mutationResolver) UpdateIssue(ctx context.Context, id string, input mod
 			}
 		}
 
+		// Apply section edits
+		if edit := input.BodyMod.SetSection; edit != nil {
+			newBody, err := issue.SetSection(workingBody, edit.Title, edit.Content, edit.CreateIfMissing != nil && *edit.CreateIfMissing)
+			if err != nil {
+				return nil, fmt.Errorf("setSection failed: %w", err)
+			}
+			workingBody = newBody
+		}
+		if edit := input.BodyMod.AppendToSection; edit != nil {
+			newBody, err := issue.AppendToSection(workingBody, edit.Title, edit.Content, edit.CreateIfMissing != nil && *edit.CreateIfMissing)
+			if err != nil {
+				return nil, fmt.Errorf("appendToSection failed: %w", err)
+			}
+			workingBody = newBody
+		}
+
 		// Apply check items
 		for i, substr := range input.BodyMod.Check {
 			newBody, err := issue.CheckItem(workingBody, substr)
@@ -404,14 +600,113 @@ func (r *mutationResolver) UpdateIssue(ctx context.Context, id string, input mod
 	if err := r.Core.Update(b, input.IfMatch); err != nil {
 		return nil, err
 	}
+	if err := r.unlinkRemovedBlocking(b, input.RemoveBlocking, input.RemoveBlockedBy); err != nil {
+		return nil, err
+	}
+	if len(removedTasks) > 0 {
+		addWarning(ctx, core.RemovedTasksWarning(b.ID, removedTasks))
+	}
 
 	return b, nil
 }
 
+// MoveIssue is the resolver for the moveIssue field.
+func (r *mutationResolver) MoveIssue(ctx context.Context, id string, newParent *string, position *int) (*issue.Issue, error) {
+	b, err := r.Core.LookupWith(id, core.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	parentID := ""
+	if newParent != nil && *newParent != "" {
+		parentID, _ = r.Core.NormalizeID(*newParent)
+	}
+
+	// Validate the hierarchy and cycles on a copy first, so a rejected move
+	// never leaves a history note behind.
+	probe := *b
+	if err := r.validateAndSetParent(&probe, parentID); err != nil {
+		return nil, err
+	}
+
+	// Issues have no sibling order, so position is accepted but unused: a
+	// moved issue goes after its new siblings.
+	input := model.UpdateIssueInput{Parent: &parentID}
+	if note := r.moveNote(b, parentID); note != "" {
+		input.BodyMod = &model.BodyModification{AppendToSection: &model.SectionEdit{
+			Title:           MoveHistorySection,
+			Content:         note,
+			CreateIfMissing: new(true),
+		}}
+	}
+	return r.UpdateIssue(ctx, b.ID, input)
+}
+
+// LinkIssues is the resolver for the linkIssues field.
+func (r *mutationResolver) LinkIssues(ctx context.Context, links []*model.LinkInput) ([]*issue.Issue, error) {
+	// Validate every link before anything is written, adding them to copies
+	// so a rejected batch leaves the stored issues untouched.
+	batch := newIssueBatch()
+	edges := make([]core.LinkEdge, 0, len(links))
+	for _, link := range links {
+		b, err := r.Core.LookupWith(link.ID, core.GetOptions{})
+		if err != nil {
+			return nil, &LinkError{ID: link.ID, Err: err}
+		}
+		edge, err := r.linkEdge(b, link)
+		if err != nil {
+			return nil, &LinkError{ID: b.ID, Err: err}
+		}
+		edges = append(edges, edge)
+		cp, err := batch.copyOf(r.Core, b)
+		if err != nil {
+			return nil, &LinkError{ID: b.ID, Err: err}
+		}
+		if edge.Type == issue.LinkTypeParent {
+			if err := r.batchParent(batch, cp, edge.To); err != nil {
+				return nil, &LinkError{ID: b.ID, Err: err}
+			}
+		}
+	}
+
+	// Links that are each fine alone may still loop together.
+	if edge, cycle := r.Core.DetectBatchCycle(edges); cycle != nil {
+		return nil, &LinkError{ID: edge.From, Err: fmt.Errorf("adding %s link would create cycle: %v", edge.Type, cycle)}
+	}
+
+	for _, edge := range edges {
+		b := batch.copies[edge.From]
+		switch edge.Type {
+		case issue.LinkTypeBlocking:
+			b.AddBlocking(edge.To)
+		case issue.LinkTypeBlockedBy:
+			b.AddBlockedBy(edge.To)
+		case issue.LinkTypeParent:
+			b.Parent = edge.To
+			r.inheritMilestoneFromParent(b)
+		}
+	}
+
+	saved, failed, err := batch.save(r.Core)
+	if err != nil {
+		return nil, &LinkError{ID: failed, Err: err}
+	}
+	return saved, nil
+}
+
+// MergeIssues is the resolver for the mergeIssues field.
+func (r *mutationResolver) MergeIssues(ctx context.Context, source string, target string, deleteSource *bool, migrateSync *bool, dryRun *bool) (*model.MergeResult, error) {
+	return r.merge(source, target, mergeOptions{
+		deleteSource: deleteSource != nil && *deleteSource,
+		migrateSync:  migrateSync != nil && *migrateSync,
+		dryRun:       dryRun != nil && *dryRun,
+	})
+}
+
 // DeleteIssue is the resolver for the deleteIssue field.
 func (r *mutationResolver) DeleteIssue(ctx context.Context, id string) (bool, error) {
-	// Verify issue exists
-	_, err := r.Core.Get(id)
+	// Verify issue exists and is not archived
+	_, err := r.Core.LookupWith(id, core.GetOptions{})
 	if err != nil {
 		return false, err
 	}
@@ -430,12 +725,17 @@ func (r *mutationResolver) DeleteIssue(ctx context.Context, id string) (bool, er
 }
 
 // SetSyncData is the resolver for the setSyncData field.
-func (r *mutationResolver) SetSyncData(ctx context.Context, id, name string, data map[string]any, ifMatch *string) (*issue.Issue, error) {
+func (r *mutationResolver) SetSyncData(ctx context.Context, id string, name string, data map[string]any, ifMatch *string, validate *bool) (*issue.Issue, error) {
 	if name == "" {
 		return nil, errors.New("sync name cannot be empty")
 	}
+	if validate == nil || *validate {
+		if err := integration.ValidateSyncData(name, data, false); err != nil {
+			return nil, err
+		}
+	}
 
-	b, err := r.Core.Get(id)
+	b, err := r.Core.LookupWith(id, core.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -449,8 +749,8 @@ func (r *mutationResolver) SetSyncData(ctx context.Context, id, name string, dat
 }
 
 // RemoveSyncData is the resolver for the removeSyncData field.
-func (r *mutationResolver) RemoveSyncData(ctx context.Context, id, name string, ifMatch *string) (*issue.Issue, error) {
-	b, err := r.Core.Get(id)
+func (r *mutationResolver) RemoveSyncData(ctx context.Context, id string, name string, ifMatch *string) (*issue.Issue, error) {
+	b, err := r.Core.LookupWith(id, core.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -531,9 +831,18 @@ func (r *mutationResolver) DeleteMilestone(ctx context.Context, id string) (bool
 	return true, nil
 }
 
+// PriorityFrom is the resolver for the priorityFrom field.
+func (r *nextIssueResolver) PriorityFrom(ctx context.Context, obj *core.NextIssue) (*string, error) {
+	if obj.PriorityFrom == "" {
+		return nil, nil
+	}
+	return &obj.PriorityFrom, nil
+}
+
 // Issue is the resolver for the issue field.
 func (r *queryResolver) Issue(ctx context.Context, id string) (*issue.Issue, error) {
-	b, err := r.Core.Get(id)
+	c := r.reader(ctx)
+	b, err := c.Get(id)
 	if errors.Is(err, core.ErrNotFound) {
 		return nil, nil
 	}
@@ -542,25 +851,38 @@ func (r *queryResolver) Issue(ctx context.Context, id string) (*issue.Issue, err
 
 // Issues is the resolver for the issues field.
 func (r *queryResolver) Issues(ctx context.Context, filter *model.IssueFilter) ([]*issue.Issue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := ValidateFilter(filter); err != nil {
+		return nil, err
+	}
 	var issues []*issue.Issue
 
 	// If search filter is provided, start with search results
 	if filter != nil && filter.Search != nil && *filter.Search != "" {
-		searchResults, err := r.Core.Search(*filter.Search)
+		searchResults, err := c.Search(*filter.Search)
 		if err != nil {
 			return nil, err
 		}
 		issues = searchResults
 	} else {
-		issues = r.Core.All()
+		issues = c.All()
 	}
 
-	return ApplyFilter(issues, filter, r.Core), nil
+	// Search and All both walk every issue; bail out before filtering if the
+	// operation has already been cancelled or timed out.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ApplyFilter(issues, filter, c), nil
 }
 
 // Milestone is the resolver for the milestone field.
 func (r *queryResolver) Milestone(ctx context.Context, id string) (*issue.Milestone, error) {
-	m, err := r.Core.GetMilestone(id)
+	c := r.reader(ctx)
+	m, err := c.GetMilestone(id)
 	if errors.Is(err, core.ErrMilestoneNotFound) {
 		return nil, nil
 	}
@@ -569,7 +891,69 @@ func (r *queryResolver) Milestone(ctx context.Context, id string) (*issue.Milest
 
 // Milestones is the resolver for the milestones field.
 func (r *queryResolver) Milestones(ctx context.Context) ([]*issue.Milestone, error) {
-	return r.Core.MilestonesSorted(), nil
+	c := r.reader(ctx)
+	return c.MilestonesSorted(), nil
+}
+
+// BodySection is the resolver for the bodySection field.
+func (r *queryResolver) BodySection(ctx context.Context, id string, title string) (*issue.Section, error) {
+	c := r.reader(ctx)
+	b, err := c.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return issue.GetSection(b.Body, title)
+}
+
+// NextIssues is the resolver for the nextIssues field.
+func (r *queryResolver) NextIssues(ctx context.Context, count *int, types []string, tags []string) ([]*core.NextIssue, error) {
+	c := r.reader(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	opts := core.NextOptions{Types: types, Tags: tags}
+	if count != nil {
+		if *count < 0 {
+			return nil, fmt.Errorf("count must not be negative, got %d", *count)
+		}
+		opts.Count = *count
+	}
+	picked := c.Next(opts)
+	result := make([]*core.NextIssue, len(picked))
+	for i := range picked {
+		result[i] = &picked[i]
+	}
+	return result, nil
+}
+
+// StatusOptions is the resolver for the statusOptions field.
+func (r *queryResolver) StatusOptions(ctx context.Context, forIssue *string) ([]*model.PickerOption, error) {
+	v := &Resolver{Core: r.reader(ctx)}
+	issues, err := v.optionIssues(forIssue)
+	if err != nil {
+		return nil, err
+	}
+	return v.StatusOptions(issues), nil
+}
+
+// TypeOptions is the resolver for the typeOptions field.
+func (r *queryResolver) TypeOptions(ctx context.Context, forIssue *string) ([]*model.PickerOption, error) {
+	v := &Resolver{Core: r.reader(ctx)}
+	issues, err := v.optionIssues(forIssue)
+	if err != nil {
+		return nil, err
+	}
+	return v.TypeOptions(issues), nil
+}
+
+// PriorityOptions is the resolver for the priorityOptions field.
+func (r *queryResolver) PriorityOptions(ctx context.Context) ([]*model.PickerOption, error) {
+	return r.Resolver.PriorityOptions(nil), nil
+}
+
+// SortOptions is the resolver for the sortOptions field.
+func (r *queryResolver) SortOptions(ctx context.Context) ([]*model.PickerOption, error) {
+	return r.Resolver.SortOptions(), nil
 }
 
 // Issue returns IssueResolver implementation.
@@ -581,10 +965,14 @@ func (r *Resolver) Milestone() MilestoneResolver { return &milestoneResolver{r}
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// NextIssue returns NextIssueResolver implementation.
+func (r *Resolver) NextIssue() NextIssueResolver { return &nextIssueResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
 type issueResolver struct{ *Resolver }
 type milestoneResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type nextIssueResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/issue"
+	"gopkg.in/yaml.v3"
 )
 
 func setupTestResolver(t *testing.T) (*Resolver, *core.Core) {
@@ -50,7 +52,7 @@ func TestQueryIssue(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test issue
-	createTestIssue(t, c, "test-1", "Test Issue", "todo")
+	createTestIssue(t, c, "test-1", "Test Issue", "ready")
 
 	// Test exact match
 	t.Run("exact match", func(t *testing.T) {
@@ -97,7 +99,7 @@ func TestQueryIssues(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test issues
-	createTestIssue(t, c, "issue-1", "First Issue", "todo")
+	createTestIssue(t, c, "issue-1", "First Issue", "ready")
 	createTestIssue(t, c, "issue-2", "Second Issue", "in-progress")
 	createTestIssue(t, c, "issue-3", "Third Issue", "completed")
 
@@ -115,7 +117,7 @@ func TestQueryIssues(t *testing.T) {
 	t.Run("filter by status", func(t *testing.T) {
 		qr := resolver.Query()
 		filter := &model.IssueFilter{
-			Status: []string{"todo"},
+			Status: []string{"ready"},
 		}
 		got, err := qr.Issues(ctx, filter)
 		if err != nil {
@@ -132,7 +134,7 @@ func TestQueryIssues(t *testing.T) {
 	t.Run("filter by multiple statuses", func(t *testing.T) {
 		qr := resolver.Query()
 		filter := &model.IssueFilter{
-			Status: []string{"todo", "in-progress"},
+			Status: []string{"ready", "in-progress"},
 		}
 		got, err := qr.Issues(ctx, filter)
 		if err != nil {
@@ -163,9 +165,9 @@ func TestQueryIssuesWithTags(t *testing.T) {
 	ctx := context.Background()
 
 	// Create test issues with tags
-	b1 := &issue.Issue{ID: "tag-1", Title: "Tagged 1", Status: "todo", Tags: []string{"frontend", "urgent"}}
-	b2 := &issue.Issue{ID: "tag-2", Title: "Tagged 2", Status: "todo", Tags: []string{"backend"}}
-	b3 := &issue.Issue{ID: "tag-3", Title: "No Tags", Status: "todo"}
+	b1 := &issue.Issue{ID: "tag-1", Title: "Tagged 1", Status: "ready", Tags: []string{"frontend", "urgent"}}
+	b2 := &issue.Issue{ID: "tag-2", Title: "Tagged 2", Status: "ready", Tags: []string{"backend"}}
+	b3 := &issue.Issue{ID: "tag-3", Title: "No Tags", Status: "ready"}
 	c.Create(b1)
 	c.Create(b2)
 	c.Create(b3)
@@ -219,11 +221,11 @@ func TestQueryIssuesWithPriority(t *testing.T) {
 
 	// Create test issues with various priorities
 	// Empty priority should be treated as "normal"
-	b1 := &issue.Issue{ID: "pri-1", Title: "Critical", Status: "todo", Priority: "critical"}
-	b2 := &issue.Issue{ID: "pri-2", Title: "High", Status: "todo", Priority: "high"}
-	b3 := &issue.Issue{ID: "pri-3", Title: "Normal Explicit", Status: "todo", Priority: "normal"}
-	b4 := &issue.Issue{ID: "pri-4", Title: "Normal Implicit", Status: "todo", Priority: ""} // empty = normal
-	b5 := &issue.Issue{ID: "pri-5", Title: "Low", Status: "todo", Priority: "low"}
+	b1 := &issue.Issue{ID: "pri-1", Title: "Critical", Status: "ready", Priority: "critical"}
+	b2 := &issue.Issue{ID: "pri-2", Title: "High", Status: "ready", Priority: "high"}
+	b3 := &issue.Issue{ID: "pri-3", Title: "Normal Explicit", Status: "ready", Priority: "normal"}
+	b4 := &issue.Issue{ID: "pri-4", Title: "Normal Implicit", Status: "ready", Priority: ""} // empty = normal
+	b5 := &issue.Issue{ID: "pri-5", Title: "Low", Status: "ready", Priority: "low"}
 	c.Create(b1)
 	c.Create(b2)
 	c.Create(b3)
@@ -309,35 +311,35 @@ func TestIssueRelationships(t *testing.T) {
 	ctx := context.Background()
 
 	// Create issues with relationships
-	parent := &issue.Issue{ID: "parent-1", Title: "Parent", Status: "todo"}
+	parent := &issue.Issue{ID: "parent-1", Title: "Parent", Status: "ready"}
 	child1 := &issue.Issue{
 		ID:     "child-1",
 		Title:  "Child 1",
-		Status: "todo",
+		Status: "ready",
 		Parent: "parent-1",
 	}
 	child2 := &issue.Issue{
 		ID:     "child-2",
 		Title:  "Child 2",
-		Status: "todo",
+		Status: "ready",
 		Parent: "parent-1",
 	}
 	blocker := &issue.Issue{
 		ID:       "blocker-1",
 		Title:    "Blocker",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"child-1"},
 	}
 	// blocker2 is declared via blocked_by on the blockee side
 	blocker2 := &issue.Issue{
 		ID:     "blocker-2",
 		Title:  "Blocker 2",
-		Status: "todo",
+		Status: "ready",
 	}
 	blockedByChild := &issue.Issue{
 		ID:        "child-3",
 		Title:     "Child 3",
-		Status:    "todo",
+		Status:    "ready",
 		Parent:    "parent-1",
 		BlockedBy: []string{"blocker-2"},
 	}
@@ -417,6 +419,90 @@ func TestIssueRelationships(t *testing.T) {
 	})
 }
 
+func TestMentionResolvers(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+
+	createTestIssue(t, c, "aaa-111", "Target", "ready")
+	createTestIssue(t, c, "bbb-222", "Done", "completed")
+	src := &issue.Issue{ID: "ccc-333", Slug: "source", Title: "Source", Status: "ready",
+		Body: "Follows aaa-111 and [bbb-222](../b/bbb-222--done.md).\n\n`ccc-333`"}
+	c.Create(src)
+
+	br := resolver.Issue()
+	got, err := br.Mentions(ctx, src, nil)
+	if err != nil {
+		t.Fatalf("Mentions() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "aaa-111" || got[1].ID != "bbb-222" {
+		t.Errorf("Mentions() = %v, want [aaa-111 bbb-222]", ids(got))
+	}
+
+	got, err = br.Mentions(ctx, src, &model.IssueFilter{Status: []string{"ready"}})
+	if err != nil {
+		t.Fatalf("Mentions() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "aaa-111" {
+		t.Errorf("Mentions(status: todo) = %v, want [aaa-111]", ids(got))
+	}
+
+	target, _ := c.Get("aaa-111")
+	got, err = br.MentionedBy(ctx, target, nil)
+	if err != nil {
+		t.Fatalf("MentionedBy() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "ccc-333" {
+		t.Errorf("MentionedBy() = %v, want [ccc-333]", ids(got))
+	}
+}
+
+func TestSummaryResolvers(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	mr := resolver.Mutation()
+
+	created, err := mr.CreateIssue(ctx, model.CreateIssueInput{
+		Title:   "Key rotation",
+		Summary: new("Rotate the zanzibar signing keys nightly"),
+		Body:    new("# Plan\n\nDetails here."),
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	createTestIssue(t, c, "other-1", "Unrelated", "ready")
+
+	got, err := resolver.Query().Issues(ctx, &model.IssueFilter{Search: new("zanzibar")})
+	if err != nil {
+		t.Fatalf("Issues(search) error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != created.ID {
+		t.Errorf("Issues(search: zanzibar) = %v, want [%s]", ids(got), created.ID)
+	}
+
+	if synopsis, _ := resolver.Issue().Synopsis(ctx, created); synopsis != created.Summary {
+		t.Errorf("Synopsis() = %q, want the summary %q", synopsis, created.Summary)
+	}
+
+	if _, err := mr.CreateIssue(ctx, model.CreateIssueInput{Title: "Bad", Summary: new("two\nlines")}); err == nil {
+		t.Error("CreateIssue() with a multi-line summary should fail")
+	}
+	long := strings.Repeat("x", issue.MaxSummaryLength+1)
+	if _, err := mr.UpdateIssue(ctx, created.ID, model.UpdateIssueInput{Summary: &long}); err == nil {
+		t.Error("UpdateIssue() with an over-long summary should fail")
+	}
+	if b, _ := c.Get(created.ID); b.Summary != "Rotate the zanzibar signing keys nightly" {
+		t.Errorf("rejected update changed summary to %q", b.Summary)
+	}
+
+	updated, err := mr.UpdateIssue(ctx, created.ID, model.UpdateIssueInput{Summary: new("")})
+	if err != nil {
+		t.Fatalf("UpdateIssue() clearing summary error = %v", err)
+	}
+	if synopsis, _ := resolver.Issue().Synopsis(ctx, updated); synopsis != "Details here." {
+		t.Errorf("Synopsis() without summary = %q, want first body paragraph", synopsis)
+	}
+}
+
 func TestBrokenLinksFiltered(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
@@ -425,7 +511,7 @@ func TestBrokenLinksFiltered(t *testing.T) {
 	b := &issue.Issue{
 		ID:     "orphan-1",
 		Title:  "Orphan",
-		Status: "todo",
+		Status: "ready",
 		Parent: "nonexistent",
 	}
 	c.Create(b)
@@ -447,17 +533,17 @@ func TestQueryIssuesWithParentAndBlocks(t *testing.T) {
 	ctx := context.Background()
 
 	// Create issues with various relationship configurations
-	noRels := &issue.Issue{ID: "no-rels", Title: "No Relationships", Status: "todo"}
+	noRels := &issue.Issue{ID: "no-rels", Title: "No Relationships", Status: "ready"}
 	hasParent := &issue.Issue{
 		ID:     "has-parent",
 		Title:  "Has Parent",
-		Status: "todo",
+		Status: "ready",
 		Parent: "no-rels",
 	}
 	hasBlocks := &issue.Issue{
 		ID:       "has-blocks",
 		Title:    "Has Blocks",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"has-parent"},
 	}
 
@@ -583,7 +669,7 @@ func TestIsBlockedFilterWithResolvedBlockers(t *testing.T) {
 	activeBlocker := &issue.Issue{
 		ID:       "active-blocker",
 		Title:    "Active Blocker",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"blocked-by-active"},
 	}
 	completedBlocker := &issue.Issue{
@@ -601,22 +687,22 @@ func TestIsBlockedFilterWithResolvedBlockers(t *testing.T) {
 	blockedByActive := &issue.Issue{
 		ID:     "blocked-by-active",
 		Title:  "Blocked by Active",
-		Status: "todo",
+		Status: "ready",
 	}
 	blockedByCompleted := &issue.Issue{
 		ID:     "blocked-by-completed",
 		Title:  "Blocked by Completed",
-		Status: "todo",
+		Status: "ready",
 	}
 	blockedByScrapped := &issue.Issue{
 		ID:     "blocked-by-scrapped",
 		Title:  "Blocked by Scrapped",
-		Status: "todo",
+		Status: "ready",
 	}
 	notBlocked := &issue.Issue{
 		ID:     "not-blocked",
 		Title:  "Not Blocked",
-		Status: "todo",
+		Status: "ready",
 	}
 	// Issue with mixed blockers (one active, one completed)
 	mixedBlocker := &issue.Issue{
@@ -634,7 +720,7 @@ func TestIsBlockedFilterWithResolvedBlockers(t *testing.T) {
 	mixedBlocked := &issue.Issue{
 		ID:     "mixed-blocked",
 		Title:  "Mixed Blocked",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	issues := []*issue.Issue{
@@ -751,13 +837,13 @@ func TestMutationCreateIssue(t *testing.T) {
 		parentBean := &issue.Issue{
 			ID:     "some-parent",
 			Title:  "Parent Issue",
-			Status: "todo",
+			Status: "ready",
 			Type:   "epic",
 		}
 		targetBean := &issue.Issue{
 			ID:     "some-target",
 			Title:  "Target Issue",
-			Status: "todo",
+			Status: "ready",
 			Type:   "task",
 		}
 		c.Create(parentBean)
@@ -836,7 +922,7 @@ func TestMutationUpdateIssue(t *testing.T) {
 	b := &issue.Issue{
 		ID:       "update-test",
 		Title:    "Original Title",
-		Status:   "todo",
+		Status:   "ready",
 		Type:     "task",
 		Priority: "normal",
 		Body:     "Original body",
@@ -1000,7 +1086,7 @@ func TestMutationDeleteIssue(t *testing.T) {
 
 	t.Run("delete existing issue", func(t *testing.T) {
 		// Create an issue to delete
-		b := &issue.Issue{ID: "delete-me", Title: "Delete Me", Status: "todo", Type: "task"}
+		b := &issue.Issue{ID: "delete-me", Title: "Delete Me", Status: "ready", Type: "task"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
@@ -1022,14 +1108,14 @@ func TestMutationDeleteIssue(t *testing.T) {
 
 	t.Run("delete removes incoming links", func(t *testing.T) {
 		// Create target issue
-		target := &issue.Issue{ID: "target-issue", Title: "Target", Status: "todo", Type: "task"}
+		target := &issue.Issue{ID: "target-issue", Title: "Target", Status: "ready", Type: "task"}
 		c.Create(target)
 
 		// Create issue that links to target
 		linker := &issue.Issue{
 			ID:       "linker-issue",
 			Title:    "Linker",
-			Status:   "todo",
+			Status:   "ready",
 			Type:     "task",
 			Blocking: []string{"target-issue"},
 		}
@@ -1077,7 +1163,7 @@ func TestRelationshipFieldsWithFilter(t *testing.T) {
 		ID:     "child-todo",
 		Title:  "Todo Task",
 		Type:   "task",
-		Status: "todo",
+		Status: "ready",
 		Parent: "parent-filter-test",
 	}
 	child2 := &issue.Issue{
@@ -1101,7 +1187,7 @@ func TestRelationshipFieldsWithFilter(t *testing.T) {
 		ID:       "blocker-bug",
 		Title:    "Blocking Bug",
 		Type:     "bug",
-		Status:   "todo",
+		Status:   "ready",
 		Blocking: []string{"child-todo"},
 	}
 	blocker2 := &issue.Issue{
@@ -1122,7 +1208,7 @@ func TestRelationshipFieldsWithFilter(t *testing.T) {
 
 	t.Run("children with status filter", func(t *testing.T) {
 		filter := &model.IssueFilter{
-			Status: []string{"todo"},
+			Status: []string{"ready"},
 		}
 		got, err := br.Children(ctx, parent, filter)
 		if err != nil {
@@ -1209,7 +1295,7 @@ func TestRelationshipFieldsWithFilter(t *testing.T) {
 
 	t.Run("blocking with status filter", func(t *testing.T) {
 		filter := &model.IssueFilter{
-			Status: []string{"todo"},
+			Status: []string{"ready"},
 		}
 		got, err := br.Blocking(ctx, blocker1, filter)
 		if err != nil {
@@ -1244,7 +1330,7 @@ func setupTestResolverWithRequireIfMatch(t *testing.T) (*Resolver, *core.Core) {
 	}
 
 	cfg := config.Default()
-	cfg.RequireIfMatch = true
+	cfg.RequireIfMatch = config.RequireIfMatchAll()
 	c := core.New(dataDir, cfg)
 	if err := c.Load(); err != nil {
 		t.Fatalf("failed to load core: %v", err)
@@ -1258,7 +1344,7 @@ func TestETagValidation(t *testing.T) {
 		resolver, c := setupTestResolver(t)
 		ctx := context.Background()
 
-		b := &issue.Issue{ID: "etag-test-1", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "etag-test-1", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		// Get current etag
@@ -1283,7 +1369,7 @@ func TestETagValidation(t *testing.T) {
 		resolver, c := setupTestResolver(t)
 		ctx := context.Background()
 
-		b := &issue.Issue{ID: "etag-test-2", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "etag-test-2", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
@@ -1306,7 +1392,7 @@ func TestETagValidation(t *testing.T) {
 		resolver, c := setupTestResolver(t)
 		ctx := context.Background()
 
-		b := &issue.Issue{ID: "etag-test-3", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "etag-test-3", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
@@ -1329,7 +1415,7 @@ func TestRequireIfMatchConfig(t *testing.T) {
 		resolver, c := setupTestResolverWithRequireIfMatch(t)
 		ctx := context.Background()
 
-		b := &issue.Issue{ID: "require-etag-1", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "require-etag-1", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
@@ -1350,7 +1436,7 @@ func TestRequireIfMatchConfig(t *testing.T) {
 		resolver, c := setupTestResolverWithRequireIfMatch(t)
 		ctx := context.Background()
 
-		b := &issue.Issue{ID: "require-etag-2", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "require-etag-2", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		currentETag := b.ETag()
@@ -1371,6 +1457,148 @@ func TestRequireIfMatchConfig(t *testing.T) {
 	})
 }
 
+func TestMutationsRefuseArchivedIssues(t *testing.T) {
+	ctx := context.Background()
+	title := "Changed"
+	tests := []struct {
+		name   string
+		mutate func(mr MutationResolver) error
+	}{
+		{"updateIssue", func(mr MutationResolver) error {
+			_, err := mr.UpdateIssue(ctx, "arc-1", model.UpdateIssueInput{Title: &title})
+			return err
+		}},
+		{"moveIssue", func(mr MutationResolver) error {
+			parent := "epic-1"
+			_, err := mr.MoveIssue(ctx, "arc-1", &parent, nil)
+			return err
+		}},
+		{"deleteIssue", func(mr MutationResolver) error {
+			_, err := mr.DeleteIssue(ctx, "arc-1")
+			return err
+		}},
+		{"setSyncData", func(mr MutationResolver) error {
+			_, err := mr.SetSyncData(ctx, "arc-1", "github", map[string]any{"issue_number": "7"}, nil, nil)
+			return err
+		}},
+		{"removeSyncData", func(mr MutationResolver) error {
+			_, err := mr.RemoveSyncData(ctx, "arc-1", "github", nil)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, c := setupTestResolver(t)
+			createTestIssue(t, c, "arc-1", "Archived", "completed")
+			c.Create(&issue.Issue{ID: "epic-1", Title: "Epic", Status: "ready", Type: "epic"})
+			if err := c.Archive("arc-1"); err != nil {
+				t.Fatalf("Archive: %v", err)
+			}
+			before, _ := c.Get("arc-1")
+			etag := before.ETag()
+
+			err := tt.mutate(resolver.Mutation())
+			if !errors.Is(err, core.ErrArchived) {
+				t.Fatalf("error = %v, want ErrArchived", err)
+			}
+			if errors.Is(err, core.ErrNotFound) {
+				t.Error("an archived issue must not be reported as not found")
+			}
+			if got := presentError(ctx, err).Extensions["code"]; got != ErrCodeConflict {
+				t.Errorf("code = %v, want %s", got, ErrCodeConflict)
+			}
+			b, err := c.Get("arc-1")
+			if err != nil || !c.IsArchived("arc-1") || b.ETag() != etag {
+				t.Errorf("archived issue changed: %v, %v", b, err)
+			}
+		})
+	}
+
+	t.Run("query still finds it", func(t *testing.T) {
+		resolver, c := setupTestResolver(t)
+		createTestIssue(t, c, "arc-1", "Archived", "completed")
+		if err := c.Archive("arc-1"); err != nil {
+			t.Fatalf("Archive: %v", err)
+		}
+		if b, err := resolver.Query().Issue(ctx, "arc-1"); err != nil || b == nil {
+			t.Errorf("Issue() = %v, %v, want the archived issue", b, err)
+		}
+	})
+}
+
+func TestRequireIfMatchFieldClasses(t *testing.T) {
+	status, title, body := "in-progress", "Renamed", "New body"
+	updates := map[string]model.UpdateIssueInput{
+		config.IfMatchBody:     {Body: &body},
+		config.IfMatchStatus:   {Status: &status},
+		config.IfMatchMetadata: {Title: &title},
+		"tags":                 {AddTags: []string{"automation"}},
+	}
+	// Which updates each policy lets through without an etag; a rejected
+	// update must name its class.
+	policies := []struct {
+		name    string
+		yaml    string
+		allowed []string
+	}{
+		{"boolean true", "true", nil},
+		{"boolean false", "false", []string{config.IfMatchBody, config.IfMatchStatus, config.IfMatchMetadata, "tags"}},
+		{"body and status", "{body: true, status: true, metadata: false}", []string{config.IfMatchMetadata, "tags"}},
+		{"metadata only", "{metadata: true}", []string{config.IfMatchBody, config.IfMatchStatus}},
+	}
+	for _, p := range policies {
+		for name, input := range updates {
+			t.Run(p.name+"/"+name, func(t *testing.T) {
+				resolver, c := setupTestResolver(t)
+				if err := yaml.Unmarshal([]byte(p.yaml), &c.Config().RequireIfMatch); err != nil {
+					t.Fatal(err)
+				}
+				b := &issue.Issue{ID: "class-1", Title: "Test", Status: "ready"}
+				if err := c.Create(b); err != nil {
+					t.Fatal(err)
+				}
+
+				_, err := resolver.Mutation().UpdateIssue(context.Background(), b.ID, input)
+				if slices.Contains(p.allowed, name) {
+					if err != nil {
+						t.Fatalf("UpdateIssue() without etag error = %v, want allowed", err)
+					}
+					return
+				}
+				required, ok := errors.AsType[*core.ETagRequiredError](err)
+				if !ok {
+					t.Fatalf("UpdateIssue() without etag error = %v, want ETagRequiredError", err)
+				}
+				wantField := name
+				if name == "tags" {
+					wantField = config.IfMatchMetadata
+				}
+				if required.Field != wantField {
+					t.Errorf("Field = %q, want %q", required.Field, wantField)
+				}
+				if !strings.Contains(err.Error(), "to change "+wantField) {
+					t.Errorf("error %q should name %s", err, wantField)
+				}
+				if got := presentError(context.Background(), err).Extensions["field"]; got != wantField {
+					t.Errorf("extensions.field = %v, want %s", got, wantField)
+				}
+
+				// The rejected update left the issue alone, and succeeds
+				// with the etag.
+				stored, _ := c.Get(b.ID)
+				if stored.Title != "Test" || stored.Status != "ready" || len(stored.Tags) > 0 {
+					t.Errorf("rejected update changed the issue: %+v", stored)
+				}
+				etag := stored.ETag()
+				input.IfMatch = &etag
+				if _, err := resolver.Mutation().UpdateIssue(context.Background(), b.ID, input); err != nil {
+					t.Errorf("UpdateIssue() with etag error = %v", err)
+				}
+			})
+		}
+	}
+}
+
 func TestDueDateResolver(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
@@ -1425,7 +1653,7 @@ func TestDueDateResolver(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "due-update-test",
 			Title:  "Update Due",
-			Status: "todo",
+			Status: "ready",
 			Type:   "task",
 		}
 		c.Create(b)
@@ -1451,7 +1679,7 @@ func TestDueDateResolver(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "due-clear-test",
 			Title:  "Clear Due",
-			Status: "todo",
+			Status: "ready",
 			Type:   "task",
 			Due:    issue.NewDueDate(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)),
 		}
@@ -1475,7 +1703,7 @@ func TestDueDateResolver(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "due-resolver-test",
 			Title:  "Resolver Due",
-			Status: "todo",
+			Status: "ready",
 			Type:   "task",
 			Due:    issue.NewDueDate(time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)),
 		}
@@ -1498,7 +1726,7 @@ func TestDueDateResolver(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "due-nil-resolver-test",
 			Title:  "No Due Resolver",
-			Status: "todo",
+			Status: "ready",
 			Type:   "task",
 		}
 		c.Create(b)
@@ -1522,7 +1750,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-1",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "## Tasks\n- [ ] Task 1\n- [ ] Task 2",
 		}
 		c.Create(b)
@@ -1549,7 +1777,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-2",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "Existing content",
 		}
 		c.Create(b)
@@ -1575,7 +1803,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-3",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "## Tasks\n- [ ] Deploy",
 		}
 		c.Create(b)
@@ -1604,7 +1832,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-4",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "- [ ] Task 1\n- [ ] Task 2\n- [ ] Task 3",
 		}
 		c.Create(b)
@@ -1633,7 +1861,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-5",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "- [ ] Task",
 		}
 		c.Create(b)
@@ -1667,7 +1895,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-6",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "Original",
 		}
 		c.Create(b)
@@ -1694,7 +1922,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-7",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "Hello world",
 		}
 		c.Create(b)
@@ -1720,7 +1948,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-8",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "foo foo foo",
 		}
 		c.Create(b)
@@ -1746,7 +1974,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-9",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "Task 1\nTask 2",
 		}
 		c.Create(b)
@@ -1777,7 +2005,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-10",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "Original content",
 		}
 		c.Create(b)
@@ -1798,42 +2026,11 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		}
 	})
 
-	t.Run("transactional: later replacement fails, nothing saved", func(t *testing.T) {
-		b := &issue.Issue{
-			ID:     "bodymod-test-9",
-			Title:  "Test",
-			Status: "todo",
-			Body:   "Task 1\nTask 2",
-		}
-		c.Create(b)
-		originalBody := b.Body
-
-		input := model.UpdateIssueInput{
-			BodyMod: &model.BodyModification{
-				Replace: []*model.ReplaceOperation{
-					{Old: "Task 1", New: "Done 1"},    // This should succeed
-					{Old: "nonexistent", New: "fail"}, // This should fail
-				},
-			},
-		}
-
-		_, err := resolver.Mutation().UpdateIssue(ctx, "bodymod-test-9", input)
-		if err == nil {
-			t.Error("UpdateIssue() expected error")
-		}
-
-		// Verify issue wasn't modified
-		updated, _ := c.Get("bodymod-test-9")
-		if updated.Body != originalBody {
-			t.Errorf("Issue body was modified despite error. Got %q, want %q", updated.Body, originalBody)
-		}
-	})
-
 	t.Run("bodyMod check item by substring", func(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-check-1",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "- [ ] Add `debug_detach` call\n- [ ] Fix other thing",
 		}
 		c.Create(b)
@@ -1858,7 +2055,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-uncheck-1",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "- [x] Task A\n- [x] Task B",
 		}
 		c.Create(b)
@@ -1883,7 +2080,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-check-multi",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "- [ ] First task\n- [ ] Second task\n- [ ] Third task",
 		}
 		c.Create(b)
@@ -1908,7 +2105,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-check-combo",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "## Tasks\n- [ ] Do thing\n- [ ] Other thing",
 		}
 		c.Create(b)
@@ -1935,7 +2132,7 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "bodymod-test-check-ambig",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Body:   "- [ ] Fix bug in parser\n- [ ] Fix bug in lexer",
 		}
 		c.Create(b)
@@ -1956,12 +2153,73 @@ func TestUpdateIssueWithBodyMod(t *testing.T) {
 	})
 }
 
+func TestUpdateIssueWithSectionEdits(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	body := "Intro\n\n## Plan\n\nOld plan\n\n## Notes\n\nKeep me"
+	c.Create(&issue.Issue{ID: "section-test", Title: "Test", Status: "ready", Body: body})
+
+	t.Run("bodySection query", func(t *testing.T) {
+		s, err := resolver.Query().BodySection(ctx, "section-test", "plan")
+		if err != nil {
+			t.Fatalf("BodySection() error = %v", err)
+		}
+		if s == nil || s.Title != "Plan" || s.Content != "\nOld plan\n\n" {
+			t.Errorf("BodySection() = %+v", s)
+		}
+		if s, err := resolver.Query().BodySection(ctx, "section-test", "Missing"); err != nil || s != nil {
+			t.Errorf("BodySection(Missing) = %+v, %v; want nil, nil", s, err)
+		}
+	})
+
+	t.Run("setSection rewrites only that section", func(t *testing.T) {
+		got, err := resolver.Mutation().UpdateIssue(ctx, "section-test", model.UpdateIssueInput{
+			BodyMod: &model.BodyModification{SetSection: &model.SectionEdit{Title: "Plan", Content: "New plan"}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+		if want := "Intro\n\n## Plan\n\nNew plan\n\n## Notes\n\nKeep me"; got.Body != want {
+			t.Errorf("Body = %q, want %q", got.Body, want)
+		}
+	})
+
+	t.Run("appendToSection creates a missing section when asked", func(t *testing.T) {
+		input := model.UpdateIssueInput{
+			BodyMod: &model.BodyModification{AppendToSection: &model.SectionEdit{Title: "Log", Content: "- done"}},
+		}
+		if _, err := resolver.Mutation().UpdateIssue(ctx, "section-test", input); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+		input.BodyMod.AppendToSection.CreateIfMissing = new(true)
+		got, err := resolver.Mutation().UpdateIssue(ctx, "section-test", input)
+		if err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+		if !strings.HasSuffix(got.Body, "## Notes\n\nKeep me\n\n## Log\n\n- done") {
+			t.Errorf("Body = %q, want Log appended at end", got.Body)
+		}
+	})
+
+	t.Run("replace and setSection are mutually exclusive", func(t *testing.T) {
+		_, err := resolver.Mutation().UpdateIssue(ctx, "section-test", model.UpdateIssueInput{
+			BodyMod: &model.BodyModification{
+				Replace:    []*model.ReplaceOperation{{Old: "Intro", New: "Hi"}},
+				SetSection: &model.SectionEdit{Title: "Plan", Content: "x"},
+			},
+		})
+		if err == nil || !strings.Contains(err.Error(), "cannot specify both replace and setSection") {
+			t.Errorf("expected exclusivity error, got %v", err)
+		}
+	})
+}
+
 func TestSyncResolver(t *testing.T) {
 	resolver, c := setupTestResolver(t)
 	ctx := context.Background()
 
 	t.Run("empty sync returns empty list", func(t *testing.T) {
-		b := &issue.Issue{ID: "ext-empty", Title: "No Sync", Status: "todo"}
+		b := &issue.Issue{ID: "ext-empty", Title: "No Sync", Status: "ready"}
 		c.Create(b)
 
 		br := resolver.Issue()
@@ -1978,7 +2236,7 @@ func TestSyncResolver(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "ext-sorted",
 			Title:  "With Sync",
-			Status: "todo",
+			Status: "ready",
 			Sync: map[string]map[string]any{
 				"jira":    {"issue_key": "PROJ-123"},
 				"clickup": {"task_id": "abc"},
@@ -2005,6 +2263,100 @@ func TestSyncResolver(t *testing.T) {
 			t.Errorf("Sync()[0].Data = %v, want task_id=abc", got[0].Data)
 		}
 	})
+
+	t.Run("resolves urls", func(t *testing.T) {
+		c.Config().SyncURLTemplates = map[string]string{"jira": "https://acme.atlassian.net/browse/{issue_key}"}
+		defer func() { c.Config().SyncURLTemplates = nil }()
+		b := &issue.Issue{
+			ID:     "ext-url",
+			Title:  "With URLs",
+			Status: "ready",
+			Sync: map[string]map[string]any{
+				"jira":    {"issue_key": "PROJ-123"},
+				"clickup": {"task_id": "abc"},
+				"linear":  {"id": "LIN-1"},
+			},
+		}
+		c.Create(b)
+
+		got, err := resolver.Issue().Sync(ctx, b)
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		want := map[string]string{
+			"clickup": "https://app.clickup.com/t/abc",
+			"jira":    "https://acme.atlassian.net/browse/PROJ-123",
+			"linear":  "",
+		}
+		for _, e := range got {
+			var url string
+			if e.URL != nil {
+				url = *e.URL
+			}
+			if url != want[e.Name] {
+				t.Errorf("Sync() %s url = %q, want %q", e.Name, url, want[e.Name])
+			}
+		}
+	})
+}
+
+// TestSyncStaleLoop guards against sync writes bumping updated_at, which made
+// an issue look stale again right after every sync.
+func TestSyncStaleLoop(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	mr := resolver.Mutation()
+	qr := resolver.Query()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c.SetClock(func() time.Time { return now })
+	createTestIssue(t, c, "loop-1", "Loop", "ready")
+
+	name := "clickup"
+	isStale := func() bool {
+		t.Helper()
+		got, err := qr.Issues(ctx, &model.IssueFilter{SyncStale: &name})
+		if err != nil {
+			t.Fatalf("Issues() error = %v", err)
+		}
+		return len(got) == 1
+	}
+	sync := func() {
+		t.Helper()
+		data := map[string]any{"task_id": "t1", "synced_at": now.Format(time.RFC3339)}
+		if _, err := mr.SetSyncData(ctx, "loop-1", name, data, nil, nil); err != nil {
+			t.Fatalf("SetSyncData() error = %v", err)
+		}
+	}
+
+	sync()
+	now = now.Add(time.Minute)
+	if isStale() {
+		t.Fatal("issue stale right after sync")
+	}
+
+	// An update that changes nothing but sync data must not bump updated_at.
+	same := "ready"
+	if _, err := mr.UpdateIssue(ctx, "loop-1", model.UpdateIssueInput{Status: &same}); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if isStale() {
+		t.Error("no-op update made the issue stale")
+	}
+
+	title := "Loop renamed"
+	if _, err := mr.UpdateIssue(ctx, "loop-1", model.UpdateIssueInput{Title: &title}); err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if !isStale() {
+		t.Fatal("title change should make the issue stale")
+	}
+
+	sync()
+	now = now.Add(time.Minute)
+	if isStale() {
+		t.Error("issue stale after second sync")
+	}
 }
 
 func TestMutationSetSyncData(t *testing.T) {
@@ -2012,12 +2364,12 @@ func TestMutationSetSyncData(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("set sync data", func(t *testing.T) {
-		b := &issue.Issue{ID: "set-ext-1", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "set-ext-1", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
 		data := map[string]any{"task_id": "abc123", "synced_at": "2026-01-01T00:00:00Z"}
-		got, err := mr.SetSyncData(ctx, "set-ext-1", "clickup", data, nil)
+		got, err := mr.SetSyncData(ctx, "set-ext-1", "clickup", data, nil, nil)
 		if err != nil {
 			t.Fatalf("SetSyncData() error = %v", err)
 		}
@@ -2033,7 +2385,7 @@ func TestMutationSetSyncData(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "set-ext-2",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Sync: map[string]map[string]any{
 				"clickup": {"task_id": "old"},
 			},
@@ -2042,7 +2394,8 @@ func TestMutationSetSyncData(t *testing.T) {
 
 		mr := resolver.Mutation()
 		data := map[string]any{"task_id": "new", "extra": "field"}
-		got, err := mr.SetSyncData(ctx, "set-ext-2", "clickup", data, nil)
+		validate := false
+		got, err := mr.SetSyncData(ctx, "set-ext-2", "clickup", data, nil, &validate)
 		if err != nil {
 			t.Fatalf("SetSyncData() error = %v", err)
 		}
@@ -2055,11 +2408,11 @@ func TestMutationSetSyncData(t *testing.T) {
 	})
 
 	t.Run("empty name fails", func(t *testing.T) {
-		b := &issue.Issue{ID: "set-ext-3", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "set-ext-3", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
-		_, err := mr.SetSyncData(ctx, "set-ext-3", "", map[string]any{"key": "val"}, nil)
+		_, err := mr.SetSyncData(ctx, "set-ext-3", "", map[string]any{"key": "val"}, nil, nil)
 		if err == nil {
 			t.Error("SetSyncData() should fail with empty name")
 		}
@@ -2067,19 +2420,49 @@ func TestMutationSetSyncData(t *testing.T) {
 
 	t.Run("nonexistent issue fails", func(t *testing.T) {
 		mr := resolver.Mutation()
-		_, err := mr.SetSyncData(ctx, "nonexistent", "clickup", map[string]any{"key": "val"}, nil)
+		_, err := mr.SetSyncData(ctx, "nonexistent", "clickup", map[string]any{"task_id": "val"}, nil, nil)
 		if err == nil {
 			t.Error("SetSyncData() should fail for nonexistent issue")
 		}
 	})
 
+	t.Run("wrong key rejected", func(t *testing.T) {
+		createTestIssue(t, c, "set-ext-typo", "Typo", "ready")
+
+		mr := resolver.Mutation()
+		_, err := mr.SetSyncData(ctx, "set-ext-typo", "clickup", map[string]any{"task_Id": "abc"}, nil, nil)
+		if err == nil {
+			t.Fatal("SetSyncData() accepted task_Id for clickup")
+		}
+		for _, want := range []string{`unknown key "task_Id" (did you mean "task_id"?)`, "missing required key task_id"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q does not mention %q", err, want)
+			}
+		}
+		if got := presentError(ctx, err).Extensions["code"]; got != ErrCodeValidation {
+			t.Errorf("code = %v, want %s", got, ErrCodeValidation)
+		}
+		if b, _ := c.Get("set-ext-typo"); b.HasSync("clickup") {
+			t.Error("rejected sync data was saved")
+		}
+
+		_, err = mr.SetSyncData(ctx, "set-ext-typo", "github", map[string]any{"issue_number": "abc"}, nil, nil)
+		if err == nil || !strings.Contains(err.Error(), `issue_number must be a number, got "abc"`) {
+			t.Errorf("SetSyncData() error = %v, want a mistyped issue_number", err)
+		}
+
+		if _, err := mr.SetSyncData(ctx, "set-ext-typo", "myext", map[string]any{"anything": 1}, nil, nil); err != nil {
+			t.Errorf("extension sync data rejected: %v", err)
+		}
+	})
+
 	t.Run("persists to disk", func(t *testing.T) {
-		b := &issue.Issue{ID: "set-ext-disk", Title: "Disk Test", Status: "todo"}
+		b := &issue.Issue{ID: "set-ext-disk", Title: "Disk Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
 		data := map[string]any{"task_id": "persist-test"}
-		_, err := mr.SetSyncData(ctx, "set-ext-disk", "clickup", data, nil)
+		_, err := mr.SetSyncData(ctx, "set-ext-disk", "clickup", data, nil, nil)
 		if err != nil {
 			t.Fatalf("SetSyncData() error = %v", err)
 		}
@@ -2103,7 +2486,7 @@ func TestMutationRemoveSyncData(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "rm-ext-1",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 			Sync: map[string]map[string]any{
 				"clickup": {"task_id": "abc"},
 				"jira":    {"issue_key": "PROJ-123"},
@@ -2125,7 +2508,7 @@ func TestMutationRemoveSyncData(t *testing.T) {
 	})
 
 	t.Run("remove nonexistent sync is no-op", func(t *testing.T) {
-		b := &issue.Issue{ID: "rm-ext-2", Title: "Test", Status: "todo"}
+		b := &issue.Issue{ID: "rm-ext-2", Title: "Test", Status: "ready"}
 		c.Create(b)
 
 		mr := resolver.Mutation()
@@ -2152,9 +2535,9 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("atomic update with parent and blocking", func(t *testing.T) {
-		epic := &issue.Issue{ID: "epic-1", Title: "Epic", Type: "epic", Status: "todo"}
-		task := &issue.Issue{ID: "task-1", Title: "Task", Type: "task", Status: "todo"}
-		blocker := &issue.Issue{ID: "blocker-1", Title: "Blocker", Type: "task", Status: "todo"}
+		epic := &issue.Issue{ID: "epic-1", Title: "Epic", Type: "epic", Status: "ready"}
+		task := &issue.Issue{ID: "task-1", Title: "Task", Type: "task", Status: "ready"}
+		blocker := &issue.Issue{ID: "blocker-1", Title: "Blocker", Type: "task", Status: "ready"}
 		c.Create(epic)
 		c.Create(task)
 		c.Create(blocker)
@@ -2182,9 +2565,9 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("atomic update with bodyMod and relationships", func(t *testing.T) {
-		epic := &issue.Issue{ID: "epic-2", Title: "Epic", Type: "epic", Status: "todo"}
-		task := &issue.Issue{ID: "task-2", Title: "Task", Type: "task", Status: "todo", Body: "- [ ] Step 1"}
-		blocker := &issue.Issue{ID: "blocker-2", Title: "Blocker", Type: "task", Status: "todo"}
+		epic := &issue.Issue{ID: "epic-2", Title: "Epic", Type: "epic", Status: "ready"}
+		task := &issue.Issue{ID: "task-2", Title: "Task", Type: "task", Status: "ready", Body: "- [ ] Step 1"}
+		blocker := &issue.Issue{ID: "blocker-2", Title: "Blocker", Type: "task", Status: "ready"}
 		c.Create(epic)
 		c.Create(task)
 		c.Create(blocker)
@@ -2224,8 +2607,8 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("parent auto-promotes task to epic", func(t *testing.T) {
-		task1 := &issue.Issue{ID: "task-invalid-1", Title: "Task 1", Type: "task", Status: "todo"}
-		task2 := &issue.Issue{ID: "task-invalid-2", Title: "Task 2", Type: "task", Status: "todo"}
+		task1 := &issue.Issue{ID: "task-invalid-1", Title: "Task 1", Type: "task", Status: "ready"}
+		task2 := &issue.Issue{ID: "task-invalid-2", Title: "Task 2", Type: "task", Status: "ready"}
 		c.Create(task1)
 		c.Create(task2)
 
@@ -2248,7 +2631,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("blocking self-reference validation", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-self", Title: "Task", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-self", Title: "Task", Type: "task", Status: "ready"}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2265,8 +2648,8 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("blocking cycle detection", func(t *testing.T) {
-		task1 := &issue.Issue{ID: "task-block-1", Title: "Task 1", Type: "task", Status: "todo"}
-		task2 := &issue.Issue{ID: "task-block-2", Title: "Task 2", Type: "task", Status: "todo", Blocking: []string{"task-block-1"}}
+		task1 := &issue.Issue{ID: "task-block-1", Title: "Task 1", Type: "task", Status: "ready"}
+		task2 := &issue.Issue{ID: "task-block-2", Title: "Task 2", Type: "task", Status: "ready", Blocking: []string{"task-block-1"}}
 		c.Create(task1)
 		c.Create(task2)
 
@@ -2285,7 +2668,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("blocking target not found", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-notfound", Title: "Task", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-notfound", Title: "Task", Type: "task", Status: "ready"}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2302,9 +2685,9 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("remove blocking relationships", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-remove-1", Title: "Task", Type: "task", Status: "todo", Blocking: []string{"other-1", "other-2"}}
-		other1 := &issue.Issue{ID: "other-1", Title: "Other 1", Type: "task", Status: "todo"}
-		other2 := &issue.Issue{ID: "other-2", Title: "Other 2", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-remove-1", Title: "Task", Type: "task", Status: "ready", Blocking: []string{"other-1", "other-2"}}
+		other1 := &issue.Issue{ID: "other-1", Title: "Other 1", Type: "task", Status: "ready"}
+		other2 := &issue.Issue{ID: "other-2", Title: "Other 2", Type: "task", Status: "ready"}
 		c.Create(task)
 		c.Create(other1)
 		c.Create(other2)
@@ -2324,7 +2707,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("blockedBy self-reference validation", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-blockedby-self", Title: "Task", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-blockedby-self", Title: "Task", Type: "task", Status: "ready"}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2341,7 +2724,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("blockedBy target not found", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-blockedby-notfound", Title: "Task", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-blockedby-notfound", Title: "Task", Type: "task", Status: "ready"}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2358,9 +2741,9 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("combined add and remove operations", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-combined", Title: "Task", Type: "task", Status: "todo", Blocking: []string{"old-1"}}
-		old1 := &issue.Issue{ID: "old-1", Title: "Old", Type: "task", Status: "todo"}
-		new1 := &issue.Issue{ID: "new-1", Title: "New", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-combined", Title: "Task", Type: "task", Status: "ready", Blocking: []string{"old-1"}}
+		old1 := &issue.Issue{ID: "old-1", Title: "Old", Type: "task", Status: "ready"}
+		new1 := &issue.Issue{ID: "new-1", Title: "New", Type: "task", Status: "ready"}
 		c.Create(task)
 		c.Create(old1)
 		c.Create(new1)
@@ -2381,8 +2764,8 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("blockedBy cycle detection", func(t *testing.T) {
-		task1 := &issue.Issue{ID: "task-blockedby-cycle-1", Title: "Task 1", Type: "task", Status: "todo"}
-		task2 := &issue.Issue{ID: "task-blockedby-cycle-2", Title: "Task 2", Type: "task", Status: "todo", BlockedBy: []string{"task-blockedby-cycle-1"}}
+		task1 := &issue.Issue{ID: "task-blockedby-cycle-1", Title: "Task 1", Type: "task", Status: "ready"}
+		task2 := &issue.Issue{ID: "task-blockedby-cycle-2", Title: "Task 2", Type: "task", Status: "ready", BlockedBy: []string{"task-blockedby-cycle-1"}}
 		c.Create(task1)
 		c.Create(task2)
 
@@ -2401,8 +2784,8 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("remove parent", func(t *testing.T) {
-		epic := &issue.Issue{ID: "epic-parent-remove", Title: "Epic", Type: "epic", Status: "todo"}
-		task := &issue.Issue{ID: "task-parent-remove", Title: "Task", Type: "task", Status: "todo", Parent: "epic-parent-remove"}
+		epic := &issue.Issue{ID: "epic-parent-remove", Title: "Epic", Type: "epic", Status: "ready"}
+		task := &issue.Issue{ID: "task-parent-remove", Title: "Task", Type: "task", Status: "ready", Parent: "epic-parent-remove"}
 		c.Create(epic)
 		c.Create(task)
 
@@ -2423,9 +2806,9 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("remove blockedBy relationships", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-remove-blockedby", Title: "Task", Type: "task", Status: "todo", BlockedBy: []string{"blocker-1", "blocker-2"}}
-		blocker1 := &issue.Issue{ID: "blocker-1", Title: "Blocker 1", Type: "task", Status: "todo"}
-		blocker2 := &issue.Issue{ID: "blocker-2", Title: "Blocker 2", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-remove-blockedby", Title: "Task", Type: "task", Status: "ready", BlockedBy: []string{"blocker-1", "blocker-2"}}
+		blocker1 := &issue.Issue{ID: "blocker-1", Title: "Blocker 1", Type: "task", Status: "ready"}
+		blocker2 := &issue.Issue{ID: "blocker-2", Title: "Blocker 2", Type: "task", Status: "ready"}
 		c.Create(task)
 		c.Create(blocker1)
 		c.Create(blocker2)
@@ -2444,10 +2827,51 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 		}
 	})
 
+	t.Run("remove blockedBy stored on the blocker", func(t *testing.T) {
+		blocker := &issue.Issue{ID: "ghost-blocker", Title: "Blocker", Type: "task", Status: "ready", Blocking: []string{"ghost-task"}}
+		task := &issue.Issue{ID: "ghost-task", Title: "Task", Type: "task", Status: "ready"}
+		c.Create(task)
+		c.Create(blocker)
+
+		input := model.UpdateIssueInput{
+			RemoveBlockedBy: []string{"ghost-blocker"},
+		}
+
+		if _, err := resolver.Mutation().UpdateIssue(ctx, "ghost-task", input); err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+
+		if c.IsBlocked("ghost-task") {
+			t.Error("ghost-task should no longer be blocked")
+		}
+		if got, _ := c.Get("ghost-blocker"); len(got.Blocking) != 0 {
+			t.Errorf("blocker Blocking = %v, want empty", got.Blocking)
+		}
+	})
+
+	t.Run("remove blocking stored on the blocked issue", func(t *testing.T) {
+		blocker := &issue.Issue{ID: "ghost-blocker-2", Title: "Blocker", Type: "task", Status: "ready"}
+		task := &issue.Issue{ID: "ghost-task-2", Title: "Task", Type: "task", Status: "ready", BlockedBy: []string{"ghost-blocker-2"}}
+		c.Create(blocker)
+		c.Create(task)
+
+		input := model.UpdateIssueInput{
+			RemoveBlocking: []string{"ghost-task-2"},
+		}
+
+		if _, err := resolver.Mutation().UpdateIssue(ctx, "ghost-blocker-2", input); err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+
+		if c.IsBlocked("ghost-task-2") {
+			t.Error("ghost-task-2 should no longer be blocked")
+		}
+	})
+
 	t.Run("multiple blocking additions", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-multi-blocking", Title: "Task", Type: "task", Status: "todo"}
-		target1 := &issue.Issue{ID: "target-1", Title: "Target 1", Type: "task", Status: "todo"}
-		target2 := &issue.Issue{ID: "target-2", Title: "Target 2", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-multi-blocking", Title: "Task", Type: "task", Status: "ready"}
+		target1 := &issue.Issue{ID: "target-1", Title: "Target 1", Type: "task", Status: "ready"}
+		target2 := &issue.Issue{ID: "target-2", Title: "Target 2", Type: "task", Status: "ready"}
 		c.Create(task)
 		c.Create(target1)
 		c.Create(target2)
@@ -2467,11 +2891,11 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("all relationship types combined", func(t *testing.T) {
-		epic := &issue.Issue{ID: "epic-all", Title: "Epic", Type: "epic", Status: "todo"}
-		task := &issue.Issue{ID: "task-all", Title: "Task", Type: "task", Status: "todo", Blocking: []string{"old-blocking"}}
-		blocker := &issue.Issue{ID: "new-blocker", Title: "Blocker", Type: "task", Status: "todo"}
-		blocked := &issue.Issue{ID: "new-blocked", Title: "Blocked", Type: "task", Status: "todo"}
-		oldBlocking := &issue.Issue{ID: "old-blocking", Title: "Old Blocking", Type: "task", Status: "todo"}
+		epic := &issue.Issue{ID: "epic-all", Title: "Epic", Type: "epic", Status: "ready"}
+		task := &issue.Issue{ID: "task-all", Title: "Task", Type: "task", Status: "ready", Blocking: []string{"old-blocking"}}
+		blocker := &issue.Issue{ID: "new-blocker", Title: "Blocker", Type: "task", Status: "ready"}
+		blocked := &issue.Issue{ID: "new-blocked", Title: "Blocked", Type: "task", Status: "ready"}
+		oldBlocking := &issue.Issue{ID: "old-blocking", Title: "Old Blocking", Type: "task", Status: "ready"}
 		c.Create(epic)
 		c.Create(task)
 		c.Create(blocker)
@@ -2506,7 +2930,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("add tags", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-tags-1", Title: "Task", Type: "task", Status: "todo", Tags: []string{"existing"}}
+		task := &issue.Issue{ID: "task-tags-1", Title: "Task", Type: "task", Status: "ready", Tags: []string{"existing"}}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2531,7 +2955,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("remove tags", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-tags-2", Title: "Task", Type: "task", Status: "todo", Tags: []string{"tag1", "tag2", "tag3"}}
+		task := &issue.Issue{ID: "task-tags-2", Title: "Task", Type: "task", Status: "ready", Tags: []string{"tag1", "tag2", "tag3"}}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2554,7 +2978,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("add and remove tags in one operation", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-tags-3", Title: "Task", Type: "task", Status: "todo", Tags: []string{"old1", "old2", "keep"}}
+		task := &issue.Issue{ID: "task-tags-3", Title: "Task", Type: "task", Status: "ready", Tags: []string{"old1", "old2", "keep"}}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2583,7 +3007,7 @@ func TestUpdateIssueWithRelationships(t *testing.T) {
 	})
 
 	t.Run("tags and addTags are mutually exclusive", func(t *testing.T) {
-		task := &issue.Issue{ID: "task-tags-4", Title: "Task", Type: "task", Status: "todo"}
+		task := &issue.Issue{ID: "task-tags-4", Title: "Task", Type: "task", Status: "ready"}
 		c.Create(task)
 
 		input := model.UpdateIssueInput{
@@ -2613,7 +3037,7 @@ func TestQueryIssuesWithSyncFilters(t *testing.T) {
 	b1 := &issue.Issue{
 		ID:     "ext-filter-1",
 		Title:  "With ClickUp (stale)",
-		Status: "todo",
+		Status: "ready",
 		Sync: map[string]map[string]any{
 			"clickup": {"task_id": "abc", "synced_at": earlier.Format(time.RFC3339)},
 		},
@@ -2622,7 +3046,7 @@ func TestQueryIssuesWithSyncFilters(t *testing.T) {
 	b2 := &issue.Issue{
 		ID:     "ext-filter-2",
 		Title:  "With ClickUp (fresh)",
-		Status: "todo",
+		Status: "ready",
 		Sync: map[string]map[string]any{
 			"clickup": {"task_id": "def", "synced_at": later.Format(time.RFC3339)},
 		},
@@ -2631,7 +3055,7 @@ func TestQueryIssuesWithSyncFilters(t *testing.T) {
 	b3 := &issue.Issue{
 		ID:     "ext-filter-3",
 		Title:  "With Jira",
-		Status: "todo",
+		Status: "ready",
 		Sync: map[string]map[string]any{
 			"jira": {"issue_key": "PROJ-123"},
 		},
@@ -2640,7 +3064,7 @@ func TestQueryIssuesWithSyncFilters(t *testing.T) {
 	b4 := &issue.Issue{
 		ID:     "ext-filter-4",
 		Title:  "No Sync",
-		Status: "todo",
+		Status: "ready",
 	}
 
 	for _, b := range []*issue.Issue{b1, b2, b3, b4} {
@@ -2766,7 +3190,7 @@ func TestCreateIssueBlockedByValidation(t *testing.T) {
 	})
 
 	t.Run("create with same issue in both blocking and blocked_by fails", func(t *testing.T) {
-		target := &issue.Issue{ID: "target-issue", Title: "Target", Status: "todo"}
+		target := &issue.Issue{ID: "target-issue", Title: "Target", Status: "ready"}
 		c.Create(target)
 
 		mr := resolver.Mutation()
@@ -2785,7 +3209,7 @@ func TestCreateIssueBlockedByValidation(t *testing.T) {
 	})
 
 	t.Run("create with valid blocked_by succeeds", func(t *testing.T) {
-		blocker := &issue.Issue{ID: "valid-blocker", Title: "Blocker", Status: "todo"}
+		blocker := &issue.Issue{ID: "valid-blocker", Title: "Blocker", Status: "ready"}
 		c.Create(blocker)
 
 		mr := resolver.Mutation()
@@ -2814,7 +3238,7 @@ func TestUpdateIssueWithETag(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-update-1",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		c.Create(b)
 
@@ -2838,7 +3262,7 @@ func TestUpdateIssueWithETag(t *testing.T) {
 		b := &issue.Issue{
 			ID:     "etag-update-2",
 			Title:  "Test",
-			Status: "todo",
+			Status: "ready",
 		}
 		c.Create(b)
 
@@ -2944,3 +3368,703 @@ func TestMilestoneInheritedFromParent(t *testing.T) {
 		}
 	})
 }
+
+func TestStaleFieldAndFilter(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+
+	createTestIssue(t, c, "stale-1", "Started long ago", "in-progress")
+	createTestIssue(t, c, "stale-2", "Queued long ago", "ready")
+
+	// Pin "now" three weeks past the issues' update time.
+	c.SetClock(func() time.Time { return time.Now().Add(21 * 24 * time.Hour) })
+
+	ir := resolver.Issue()
+	b, _ := c.Get("stale-1")
+
+	t.Run("disabled without stale_after", func(t *testing.T) {
+		if stale, _ := ir.Stale(ctx, b); stale {
+			t.Error("Stale() = true with no stale_after configured")
+		}
+	})
+
+	c.Config().StaleAfter = "14d"
+
+	t.Run("stale field", func(t *testing.T) {
+		if stale, _ := ir.Stale(ctx, b); !stale {
+			t.Error("Stale() = false for in-progress issue idle 21d")
+		}
+		other, _ := c.Get("stale-2")
+		if stale, _ := ir.Stale(ctx, other); stale {
+			t.Error("Stale() = true for ready issue (not a stale status)")
+		}
+	})
+
+	t.Run("isStale filter", func(t *testing.T) {
+		yes, no := true, false
+		got, err := resolver.Query().Issues(ctx, &model.IssueFilter{IsStale: &yes})
+		if err != nil {
+			t.Fatalf("Issues() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != "stale-1" {
+			t.Errorf("isStale: true = %v, want [stale-1]", issueIDList(got))
+		}
+		got, _ = resolver.Query().Issues(ctx, &model.IssueFilter{IsStale: &no})
+		if len(got) != 1 || got[0].ID != "stale-2" {
+			t.Errorf("isStale: false = %v, want [stale-2]", issueIDList(got))
+		}
+	})
+
+	t.Run("pinned never stale", func(t *testing.T) {
+		pin := true
+		pinned, err := resolver.Mutation().UpdateIssue(ctx, "stale-1", model.UpdateIssueInput{Pinned: &pin})
+		if err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+		if !pinned.Pinned {
+			t.Fatal("pinned: true not applied")
+		}
+		c.SetClock(func() time.Time { return time.Now().Add(42 * 24 * time.Hour) })
+		if stale, _ := ir.Stale(ctx, pinned); stale {
+			t.Error("Stale() = true for a pinned issue")
+		}
+		got, _ := resolver.Query().Issues(ctx, &model.IssueFilter{Pinned: &pin})
+		if len(got) != 1 || got[0].ID != "stale-1" {
+			t.Errorf("pinned: true = %v, want [stale-1]", issueIDList(got))
+		}
+	})
+}
+
+func issueIDList(issues []*issue.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, b := range issues {
+		ids[i] = b.ID
+	}
+	return ids
+}
+
+func TestMoveIssue(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	c.SetClock(func() time.Time { return now })
+	c.Create(&issue.Issue{ID: "epic-a", Title: "Epic A", Status: "ready", Type: "epic"})
+	c.Create(&issue.Issue{ID: "epic-b", Title: "Epic B", Status: "ready", Type: "epic"})
+	c.Create(&issue.Issue{ID: "sib-1", Title: "Sibling 1", Status: "ready", Type: "task", Parent: "epic-b"})
+	c.Create(&issue.Issue{ID: "sib-2", Title: "Sibling 2", Status: "ready", Type: "task", Parent: "epic-b"})
+	c.Create(&issue.Issue{ID: "mover", Title: "Mover", Status: "ready", Type: "task", Parent: "epic-a", Body: "Details"})
+
+	t.Run("moves under a new parent and records history", func(t *testing.T) {
+		got, err := resolver.Mutation().MoveIssue(ctx, "mover", new("epic-b"), new(2))
+		if err != nil {
+			t.Fatalf("MoveIssue() error = %v", err)
+		}
+		if got.Parent != "epic-b" {
+			t.Errorf("Parent = %q, want epic-b", got.Parent)
+		}
+		want := "Details\n\n## History\n\n- 2026-05-01T09:00:00Z: moved from epic-a to epic-b"
+		if got.Body != want {
+			t.Errorf("Body = %q, want %q", got.Body, want)
+		}
+	})
+
+	t.Run("same parent adds no note", func(t *testing.T) {
+		before, _ := c.Get("mover")
+		for _, position := range []*int{nil, new(1), new(1)} {
+			got, err := resolver.Mutation().MoveIssue(ctx, "mover", new("epic-b"), position)
+			if err != nil {
+				t.Fatalf("MoveIssue() error = %v", err)
+			}
+			if got.Body != before.Body {
+				t.Errorf("Body = %q, want it unchanged", got.Body)
+			}
+		}
+	})
+
+	t.Run("null parent moves to the top level", func(t *testing.T) {
+		got, err := resolver.Mutation().MoveIssue(ctx, "mover", nil, nil)
+		if err != nil {
+			t.Fatalf("MoveIssue() error = %v", err)
+		}
+		if got.Parent != "" {
+			t.Errorf("Parent = %q, want empty", got.Parent)
+		}
+		if !strings.Contains(got.Body, "moved from epic-b to top level") {
+			t.Errorf("body should record the move to the top level: %q", got.Body)
+		}
+		if n := strings.Count(got.Body, "## History"); n != 1 {
+			t.Errorf("History heading appears %d times, want 1", n)
+		}
+	})
+
+	t.Run("invalid hierarchy is rejected without a note", func(t *testing.T) {
+		before, _ := c.Get("epic-a")
+		beforeBody := before.Body
+		if _, err := resolver.Mutation().MoveIssue(ctx, "epic-a", new("sib-1"), nil); err == nil {
+			t.Fatal("expected error moving an epic under a task")
+		}
+		after, _ := c.Get("epic-a")
+		if after.Parent != "" || after.Body != beforeBody {
+			t.Errorf("failed move changed the issue: parent %q, body %q", after.Parent, after.Body)
+		}
+	})
+
+	t.Run("notes can be turned off", func(t *testing.T) {
+		c.Config().SkipMoveNotes = true
+		defer func() { c.Config().SkipMoveNotes = false }()
+		c.Create(&issue.Issue{ID: "quiet", Title: "Quiet", Status: "ready", Type: "task", Body: "Body"})
+		got, err := resolver.Mutation().MoveIssue(ctx, "quiet", new("epic-a"), nil)
+		if err != nil {
+			t.Fatalf("MoveIssue() error = %v", err)
+		}
+		if got.Parent != "epic-a" || got.Body != "Body" {
+			t.Errorf("got parent %q body %q, want epic-a and an untouched body", got.Parent, got.Body)
+		}
+	})
+}
+
+func TestParentCycleAndDepth(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+
+	// Files written by hand can already form a cycle the resolver would refuse.
+	for id, parent := range map[string]string{"cyc-a": "cyc-b", "cyc-b": "cyc-a"} {
+		content := "---\ntitle: " + id + "\nstatus: ready\ntype: task\nparent: " + parent + "\n---\n"
+		if err := os.WriteFile(filepath.Join(c.Root(), issue.BuildFilename(id, "cycle")), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("nested queries terminate in a cycle", func(t *testing.T) {
+		url := startTestServer(t, resolver, ServerOptions{Timeout: 5 * time.Second})
+		_, out := postQuery(t, url, "", `{ issue(id: "cyc-a") { children { id children { id parent { parent { id } } } } } }`)
+		if len(out.Errors) > 0 {
+			t.Fatalf("errors = %+v", out.Errors)
+		}
+		children := out.Data["issue"].(map[string]any)["children"].([]any)
+		if len(children) != 1 || children[0].(map[string]any)["id"] != "cyc-b" {
+			t.Errorf("children = %v, want cyc-b", children)
+		}
+	})
+
+	t.Run("too deep a chain is rejected with its path", func(t *testing.T) {
+		c.Config().MaxHierarchyDepth = 1
+		defer func() { c.Config().MaxHierarchyDepth = 0 }()
+		createTestIssue(t, c, "deep-1", "Deep 1", "ready")
+		createTestIssue(t, c, "deep-2", "Deep 2", "ready")
+		if _, err := resolver.Mutation().UpdateIssue(ctx, "deep-2", model.UpdateIssueInput{Parent: new("deep-1")}); err != nil {
+			t.Fatalf("UpdateIssue() error = %v", err)
+		}
+
+		_, err := resolver.Mutation().CreateIssue(ctx, model.CreateIssueInput{Title: "Deep 3", Parent: new("deep-2")})
+		if _, ok := errors.AsType[*core.HierarchyDepthError](err); !ok {
+			t.Fatalf("CreateIssue() error = %v, want HierarchyDepthError", err)
+		}
+		if !strings.Contains(err.Error(), "deep-2 → deep-1") {
+			t.Errorf("error %q does not name the chain", err)
+		}
+		if got := presentError(ctx, err).Extensions["code"]; got != ErrCodeValidation {
+			t.Errorf("code = %v, want %s", got, ErrCodeValidation)
+		}
+	})
+}
+
+func TestQueryNextIssues(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	for _, b := range []*issue.Issue{
+		{ID: "next-low", Title: "Low", Status: "ready", Type: "task", Priority: "low", Blocking: []string{"next-crit"}},
+		{ID: "next-crit", Title: "Critical", Status: "ready", Type: "bug", Priority: "critical"},
+		{ID: "next-high", Title: "High", Status: "ready", Type: "task", Priority: "high"},
+		{ID: "next-draft", Title: "Draft", Status: "draft", Type: "task", Priority: "critical"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := resolver.Query().NextIssues(ctx, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NextIssues() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Issue.ID != "next-low" || got[1].Issue.ID != "next-high" {
+		t.Fatalf("NextIssues() picked %d issues, want next-low then next-high", len(got))
+	}
+	if from, _ := resolver.NextIssue().PriorityFrom(ctx, got[0]); from == nil || *from != "next-crit" {
+		t.Errorf("priorityFrom = %v, want next-crit", from)
+	}
+	if from, _ := resolver.NextIssue().PriorityFrom(ctx, got[1]); from != nil {
+		t.Errorf("priorityFrom = %q, want null for an issue's own priority", *from)
+	}
+
+	count := 1
+	if got, _ := resolver.Query().NextIssues(ctx, &count, []string{"task"}, nil); len(got) != 1 || got[0].Issue.ID != "next-low" {
+		t.Errorf("NextIssues(count: 1, types: [task]) = %d issues, want next-low", len(got))
+	}
+	count = -1
+	if _, err := resolver.Query().NextIssues(ctx, &count, nil, nil); err == nil {
+		t.Error("expected an error for a negative count")
+	}
+}
+
+func TestIssueVisibility(t *testing.T) {
+	resolver, _ := setupTestResolver(t)
+	ctx := context.Background()
+	mr := resolver.Mutation()
+
+	internal := config.VisibilityInternal
+	secret, err := mr.CreateIssue(ctx, model.CreateIssueInput{Title: "Secret", Visibility: &internal})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	open, err := mr.CreateIssue(ctx, model.CreateIssueInput{Title: "Open"})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if got, _ := resolver.Issue().Visibility(ctx, open); got != config.VisibilityPublic {
+		t.Errorf("visibility = %q, want public when unset", got)
+	}
+
+	got, _ := resolver.Query().Issues(ctx, &model.IssueFilter{Visibility: &internal})
+	if len(got) != 1 || got[0].ID != secret.ID {
+		t.Errorf("Issues(visibility: internal) = %d issues, want only %s", len(got), secret.ID)
+	}
+
+	// Making an issue public again clears the field.
+	public := config.VisibilityPublic
+	updated, err := mr.UpdateIssue(ctx, secret.ID, model.UpdateIssueInput{Visibility: &public})
+	if err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if updated.Visibility != "" {
+		t.Errorf("stored visibility = %q, want it cleared", updated.Visibility)
+	}
+
+	bad := "private"
+	_, err = mr.UpdateIssue(ctx, secret.ID, model.UpdateIssueInput{Visibility: &bad})
+	if _, ok := errors.AsType[*config.ValueError](err); !ok {
+		t.Errorf("UpdateIssue(visibility: private) error = %v, want a ValueError", err)
+	}
+}
+
+func TestMutationNotFoundSuggestsID(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	for _, b := range []*issue.Issue{
+		{ID: "k3f-9da", Title: "Login flow", Status: "ready"},
+		{ID: "a1b-2c3", Title: "Logout button", Status: "ready"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The issue query keeps returning null for an unknown ID.
+	if b, err := resolver.Query().Issue(ctx, "k3f-9db"); b != nil || err != nil {
+		t.Errorf("Issue(k3f-9db) = %v, %v; want nil, nil", b, err)
+	}
+
+	title := "Renamed"
+	_, err := resolver.Mutation().UpdateIssue(ctx, "k3f-9db", model.UpdateIssueInput{Title: &title})
+	if !errors.Is(err, core.ErrNotFound) || !strings.Contains(err.Error(), "did you mean k3f-9da?") {
+		t.Errorf("UpdateIssue(k3f-9db) error = %v, want not found suggesting k3f-9da", err)
+	}
+	_, err = resolver.Mutation().UpdateIssue(ctx, "k3f-9da", model.UpdateIssueInput{AddBlocking: []string{"a1b-2c4"}})
+	if err == nil || !strings.Contains(err.Error(), "did you mean a1b-2c3?") {
+		t.Errorf("UpdateIssue(addBlocking: a1b-2c4) error = %v, want a suggestion", err)
+	}
+}
+
+func TestIssueRevisions(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	c.Config().KeepBodyRevisions = 3
+
+	b, err := resolver.Mutation().CreateIssue(ctx, model.CreateIssueInput{Title: "Revised"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, body := range []string{"one", "two"} {
+		if _, err := resolver.Mutation().UpdateIssue(ctx, b.ID, model.UpdateIssueInput{Body: &body}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := resolver.Issue().Revisions(ctx, b)
+	if err != nil {
+		t.Fatalf("Revisions() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Revisions() = %d revisions, want 2", len(got))
+	}
+	if body, _ := c.RevisionBody(b.ID, got[0].Timestamp); strings.TrimSpace(body) != "one" || got[0].Size != len(body) {
+		t.Errorf("newest revision = %q (size %d), want %q", body, got[0].Size, "one")
+	}
+}
+
+func TestUpdateIssueRemovedTasks(t *testing.T) {
+	const original = "## Tasks\n\n- [ ] Write the parser\n- [ ] Add fuzz tests\n- [x] Sketch the grammar"
+	const rewritten = "## Tasks\n\n- [ ] Write the parser"
+	tests := []struct {
+		name        string
+		mode        string
+		body        string
+		force       bool
+		wantErr     bool
+		wantWarning bool
+	}{
+		{name: "warn by default", body: rewritten, wantWarning: true},
+		{name: "warn keeps reworded and checked items quiet", mode: config.ProtectTasksWarn, body: "- [x] Write the parser\n- [ ] Add fuzz test"},
+		{name: "strict rejects", mode: config.ProtectTasksStrict, body: rewritten, wantErr: true},
+		{name: "strict forced", mode: config.ProtectTasksStrict, body: rewritten, force: true, wantWarning: true},
+		{name: "strict allows keeping the tasks", mode: config.ProtectTasksStrict, body: original + "\n- [ ] One more"},
+		{name: "off", mode: config.ProtectTasksOff, body: rewritten},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, c := setupTestResolver(t)
+			c.Config().ProtectUncheckedTasks = tt.mode
+			c.Create(&issue.Issue{ID: "tsk-1", Title: "Tasks", Status: "ready", Body: original})
+
+			ctx, warnings := WithWarnings(context.Background())
+			input := model.UpdateIssueInput{Body: &tt.body}
+			if tt.force {
+				input.Force = &tt.force
+			}
+			_, err := resolver.Mutation().UpdateIssue(ctx, "tsk-1", input)
+			b, _ := c.Get("tsk-1")
+			if tt.wantErr {
+				removed, ok := errors.AsType[*core.RemovedTasksError](err)
+				if !ok || !slices.Equal(removed.Tasks, []string{"Add fuzz tests"}) {
+					t.Fatalf("error = %v, want a RemovedTasksError for %q", err, "Add fuzz tests")
+				}
+				if got := presentError(ctx, err).Extensions["code"]; got != ErrCodeConflict {
+					t.Errorf("code = %v, want %s", got, ErrCodeConflict)
+				}
+				if b.Body != original {
+					t.Errorf("rejected update changed the body to %q", b.Body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateIssue() error = %v", err)
+			}
+			if b.Body != tt.body {
+				t.Errorf("body = %q, want %q", b.Body, tt.body)
+			}
+			got := warnings()
+			if tt.wantWarning != (len(got) == 1) || tt.wantWarning && !strings.Contains(got[0], `"Add fuzz tests"`) {
+				t.Errorf("warnings = %q, want warning %v", got, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestUpdateIssueBodyModSkipsRemovedTasks(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	c.Config().ProtectUncheckedTasks = config.ProtectTasksStrict
+	c.Create(&issue.Issue{ID: "tsk-1", Title: "Tasks", Status: "ready", Body: "- [ ] Keep me\n- [ ] Drop me"})
+
+	input := model.UpdateIssueInput{BodyMod: &model.BodyModification{Replace: []*model.ReplaceOperation{{Old: "\n- [ ] Drop me", New: ""}}}}
+	if _, err := resolver.Mutation().UpdateIssue(context.Background(), "tsk-1", input); err != nil {
+		t.Fatalf("bodyMod edit rejected: %v", err)
+	}
+}
+
+func TestQueryTypeOptions(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	c.Config().Types = []config.TypeConfig{{Name: "spike", Color: "cyan", Icon: "⚡", AllowedParents: []string{config.TypeEpic}}}
+	for _, b := range []*issue.Issue{
+		{ID: "ms-1", Title: "Legacy milestone", Status: "ready", Type: config.TypeMilestone},
+		{ID: "ta-1", Title: "Under milestone", Status: "ready", Type: config.TypeTask, Parent: "ms-1"},
+		{ID: "fe-1", Title: "Feature", Status: "ready", Type: config.TypeFeature},
+		{ID: "ta-2", Title: "Under feature", Status: "ready", Type: config.TypeTask, Parent: "fe-1"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	options := func(id *string) map[string]*model.PickerOption {
+		t.Helper()
+		opts, err := resolver.Query().TypeOptions(ctx, id)
+		if err != nil {
+			t.Fatalf("TypeOptions() error = %v", err)
+		}
+		byName := map[string]*model.PickerOption{}
+		for _, opt := range opts {
+			byName[opt.Name] = opt
+		}
+		return byName
+	}
+
+	t.Run("no issue", func(t *testing.T) {
+		opts := options(nil)
+		if len(opts) != len(config.DefaultTypes)+1 {
+			t.Errorf("got %d options, want the default types plus spike", len(opts))
+		}
+		spike := opts["spike"]
+		if spike == nil || spike.Icon != "⚡" || spike.Color != "cyan" || !spike.Applicable || spike.Current {
+			t.Errorf("spike = %+v, want the configured icon and color, applicable", spike)
+		}
+	})
+
+	t.Run("milestone target", func(t *testing.T) {
+		// ms-1's task child only accepts a milestone, epic, or feature parent.
+		opts := options(new("ms-1"))
+		for name, want := range map[string]bool{config.TypeEpic: true, config.TypeFeature: true, config.TypeBug: false, config.TypeTask: false, "spike": false} {
+			if got := opts[name].Applicable; got != want {
+				t.Errorf("%s applicable = %v, want %v (reason %q)", name, got, want, opts[name].Reason)
+			}
+		}
+		if got := opts[config.TypeBug].Reason; got != "task children cannot have a bug parent" {
+			t.Errorf("bug reason = %q", got)
+		}
+	})
+
+	t.Run("task target", func(t *testing.T) {
+		opts := options(new("ta-2"))
+		if !opts[config.TypeTask].Current || !opts[config.TypeTask].Applicable {
+			t.Errorf("task = %+v, want current and applicable", opts[config.TypeTask])
+		}
+		if !opts[config.TypeBug].Applicable {
+			t.Errorf("bug reason = %q, want applicable", opts[config.TypeBug].Reason)
+		}
+		if opts[config.TypeEpic].Applicable || opts[config.TypeEpic].Reason != "epics cannot have a feature parent" {
+			t.Errorf("epic = %+v, want refused for the feature parent", opts[config.TypeEpic])
+		}
+		if opts["spike"].Applicable {
+			t.Error("spike should be refused under a feature")
+		}
+		if opts := options(new("ta-1")); !opts[config.TypeEpic].Applicable {
+			t.Errorf("epic under a milestone reason = %q, want applicable", opts[config.TypeEpic].Reason)
+		}
+	})
+
+	t.Run("unknown issue", func(t *testing.T) {
+		if _, err := resolver.Query().TypeOptions(ctx, new("nope")); err == nil {
+			t.Error("TypeOptions() for an unknown issue should fail")
+		}
+	})
+}
+
+func TestQueryStatusOptions(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	c.Config().ExtraStatuses = map[string]bool{config.StatusReview: true}
+	createTestIssue(t, c, "par-1", "Parent", "ready")
+	createTestIssue(t, c, "dra-1", "Draft", config.StatusDraft)
+	child := &issue.Issue{ID: "chi-1", Title: "Child", Status: "ready", Type: config.TypeTask, Parent: "par-1"}
+	if err := c.Create(child); err != nil {
+		t.Fatal(err)
+	}
+	parent, _ := c.Get("par-1")
+	parent.Type = config.TypeEpic
+	if err := c.Update(parent, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(opts []*model.PickerOption) []string {
+		var out []string
+		for _, opt := range opts {
+			out = append(out, opt.Name)
+		}
+		return out
+	}
+	opts, err := resolver.Query().StatusOptions(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(opts), c.Config().EnabledStatusNames(); !slices.Equal(got, want) {
+		t.Errorf("statusOptions = %v, want the enabled statuses %v", got, want)
+	}
+	if opts[0].Icon == "" {
+		t.Errorf("%s has no icon", opts[0].Name)
+	}
+
+	// A disabled status is still offered for an issue already in it.
+	opts, _ = resolver.Query().StatusOptions(ctx, new("dra-1"))
+	if !slices.Contains(names(opts), config.StatusDraft) {
+		t.Errorf("statusOptions(dra-1) = %v, want draft included", names(opts))
+	}
+
+	opts, _ = resolver.Query().StatusOptions(ctx, new("par-1"))
+	for _, opt := range opts {
+		if opt.Name == config.StatusCompleted && (opt.Applicable || opt.Reason != "children not complete") {
+			t.Errorf("completed = %+v, want refused until children complete", opt)
+		}
+	}
+}
+
+func TestQueryPriorityAndSortOptions(t *testing.T) {
+	resolver, _ := setupTestResolver(t)
+	ctx := context.Background()
+	priorities, err := resolver.Query().PriorityOptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(priorities) != len(config.DefaultPriorities) || priorities[0].Name != config.PriorityCritical {
+		t.Errorf("priorityOptions = %+v, want the priorities, most urgent first", priorities)
+	}
+	sorts, err := resolver.Query().SortOptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sorts[0].Name != config.SortDefault || sorts[0].Label != "Default" || !sorts[0].Applicable {
+		t.Errorf("first sort option = %+v, want default", sorts[0])
+	}
+	sorts[0].Name = "changed"
+	if again := resolver.SortOptions(); again[0].Name != config.SortDefault {
+		t.Error("SortOptions() returned shared values")
+	}
+}
+
+func TestCreateIssueRecordsCreator(t *testing.T) {
+	resolver, _ := setupTestResolver(t)
+	mr := resolver.Mutation()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		actor   *string
+		wantBy  string
+		wantVia string
+	}{
+		{name: "bare graphql", ctx: context.Background(), wantVia: issue.CreatedViaGraphQL},
+		{name: "graphql actor", ctx: context.Background(), actor: new("agent-7"), wantBy: "agent-7", wantVia: issue.CreatedViaGraphQL},
+		{name: "cli context", ctx: WithCreator(context.Background(), Creator{By: "alice", Via: issue.CreatedViaCLI}), wantBy: "alice", wantVia: issue.CreatedViaCLI},
+		{name: "actor overrides context", ctx: WithCreator(context.Background(), Creator{By: "alice", Via: issue.CreatedViaGraphQL}), actor: new("bot"), wantBy: "bot", wantVia: issue.CreatedViaGraphQL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := mr.CreateIssue(tt.ctx, model.CreateIssueInput{Title: tt.name, Actor: tt.actor})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.CreatedBy != tt.wantBy || b.CreatedVia != tt.wantVia {
+				t.Errorf("created by %q via %q, want %q via %q", b.CreatedBy, b.CreatedVia, tt.wantBy, tt.wantVia)
+			}
+		})
+	}
+
+	qr := resolver.Query()
+	got, err := qr.Issues(context.Background(), &model.IssueFilter{CreatedVia: []string{issue.CreatedViaCLI}})
+	if err != nil || len(got) != 1 || got[0].CreatedBy != "alice" {
+		t.Errorf("createdVia cli = %v, %v; want the one CLI issue", got, err)
+	}
+	got, err = qr.Issues(context.Background(), &model.IssueFilter{CreatedBy: []string{"agent-7", "bot"}})
+	if err != nil || len(got) != 2 {
+		t.Errorf("createdBy [agent-7 bot] = %d issues, %v; want 2", len(got), err)
+	}
+	if _, err := qr.Issues(context.Background(), &model.IssueFilter{CreatedVia: []string{"email"}}); err == nil {
+		t.Error("unknown createdVia should be an error")
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	ctx := context.Background()
+	c.Create(&issue.Issue{ID: "gate", Title: "Release gate", Status: "ready", Type: "task"})
+	c.Create(&issue.Issue{ID: "epic", Title: "Epic", Status: "ready", Type: "epic", Milestone: "m1"})
+	c.Create(&issue.Issue{ID: "one", Title: "One", Status: "ready", Type: "task"})
+	c.Create(&issue.Issue{ID: "two", Title: "Two", Status: "ready", Type: "task"})
+
+	t.Run("links every issue", func(t *testing.T) {
+		got, err := resolver.Mutation().LinkIssues(ctx, []*model.LinkInput{
+			{ID: "one", Type: issue.LinkTypeBlockedBy, Target: "gate"},
+			{ID: "two", Type: issue.LinkTypeBlockedBy, Target: "gate"},
+			{ID: "one", Type: issue.LinkTypeParent, Target: "epic"},
+		})
+		if err != nil {
+			t.Fatalf("LinkIssues() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID != "one" || got[1].ID != "two" {
+			t.Fatalf("LinkIssues() returned %v, want one and two", got)
+		}
+		one, _ := c.Get("one")
+		if !one.IsBlockedBy("gate") || one.Parent != "epic" || one.Milestone != "m1" {
+			t.Errorf("one = blocked_by %v, parent %q, milestone %q", one.BlockedBy, one.Parent, one.Milestone)
+		}
+		if two, _ := c.Get("two"); !two.IsBlockedBy("gate") {
+			t.Errorf("two.BlockedBy = %v, want gate", two.BlockedBy)
+		}
+	})
+
+	t.Run("batch that loops only together is rejected whole", func(t *testing.T) {
+		before := map[string]string{}
+		for _, id := range []string{"gate", "one", "two"} {
+			etag, err := c.DiskETag(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			before[id] = etag
+		}
+
+		// two blocking one is fine alone, as is gate blocked by two, but
+		// with one already blocked by gate they loop.
+		_, err := resolver.Mutation().LinkIssues(ctx, []*model.LinkInput{
+			{ID: "two", Type: issue.LinkTypeBlocking, Target: "one"},
+			{ID: "gate", Type: issue.LinkTypeBlockedBy, Target: "two"},
+		})
+		linkErr, ok := errors.AsType[*LinkError](err)
+		if !ok || !strings.Contains(err.Error(), "cycle") {
+			t.Fatalf("LinkIssues() error = %v, want a cycle LinkError", err)
+		}
+		if linkErr.ID != "gate" {
+			t.Errorf("LinkError.ID = %q, want gate", linkErr.ID)
+		}
+		for id, etag := range before {
+			if got, _ := c.DiskETag(id); got != etag {
+				t.Errorf("%s changed on disk after a rejected batch", id)
+			}
+		}
+		if two, _ := c.Get("two"); two.IsBlocking("one") {
+			t.Error("two gained a blocking link in memory after a rejected batch")
+		}
+	})
+
+	t.Run("parent promotion is saved only with the batch", func(t *testing.T) {
+		c.Create(&issue.Issue{ID: "lead", Title: "Lead", Status: "ready", Type: "task"})
+		c.Create(&issue.Issue{ID: "three", Title: "Three", Status: "ready", Type: "task"})
+
+		// Linking three under lead needs lead promoted to an epic, but the
+		// epic cannot go under a task, so nothing may be written.
+		_, err := resolver.Mutation().LinkIssues(ctx, []*model.LinkInput{
+			{ID: "three", Type: issue.LinkTypeParent, Target: "lead"},
+			{ID: "epic", Type: issue.LinkTypeParent, Target: "two"},
+		})
+		if linkErr, ok := errors.AsType[*LinkError](err); !ok || linkErr.ID != "epic" {
+			t.Fatalf("LinkIssues() error = %v, want a LinkError for epic", err)
+		}
+		if lead, _ := c.Get("lead"); lead.Type != "task" {
+			t.Errorf("lead.Type = %q after a rejected batch, want task", lead.Type)
+		}
+
+		if _, err := resolver.Mutation().LinkIssues(ctx, []*model.LinkInput{
+			{ID: "three", Type: issue.LinkTypeParent, Target: "lead"},
+		}); err != nil {
+			t.Fatalf("LinkIssues() error = %v", err)
+		}
+		if lead, _ := c.Get("lead"); lead.Type != "epic" {
+			t.Errorf("lead.Type = %q, want it promoted to epic", lead.Type)
+		}
+		if three, _ := c.Get("three"); three.Parent != "lead" {
+			t.Errorf("three.Parent = %q, want lead", three.Parent)
+		}
+	})
+
+	t.Run("rejects unknown targets and types", func(t *testing.T) {
+		for _, link := range []*model.LinkInput{
+			{ID: "two", Type: issue.LinkTypeBlocking, Target: "nope"},
+			{ID: "two", Type: "relates", Target: "gate"},
+			{ID: "two", Type: issue.LinkTypeBlocking, Target: "two"},
+			{ID: "epic", Type: issue.LinkTypeParent, Target: "two"},
+		} {
+			if _, err := resolver.Mutation().LinkIssues(ctx, []*model.LinkInput{link}); err == nil {
+				t.Errorf("LinkIssues(%+v) succeeded, want an error", *link)
+			}
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package graph
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// ErrCodeReadOnly is the extensions.code of a mutation sent to a read-only
+// server.
+const ErrCodeReadOnly = "READ_ONLY"
+
+// Paths served by NewHandler.
+const (
+	QueryPath      = "/graphql"
+	PlaygroundPath = "/"
+)
+
+// ServerOptions configure the HTTP handler behind `todo serve`.
+type ServerOptions struct {
+	// AllowMutations lets clients run mutations; the server is read-only
+	// without it.
+	AllowMutations bool
+	// Playground serves the GraphiQL playground at PlaygroundPath.
+	Playground bool
+	// Token, if set, is the bearer token every request must carry.
+	Token string
+	// CORSOrigins are the browser origins allowed to call the server. "*"
+	// allows any.
+	CORSOrigins []string
+	// Timeout is the deadline each request runs under. Zero means none.
+	Timeout time.Duration
+}
+
+// NewHandler serves the GraphQL schema over HTTP at QueryPath, with the same
+// depth and complexity limits and per-query views as NewExecutor.
+func NewHandler(r *Resolver, opts ServerOptions) http.Handler {
+	cfg := r.Core.Config()
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: r}))
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(Views{Core: r.Core})
+	if !opts.AllowMutations {
+		srv.Use(ReadOnly{})
+	}
+	applyLimits(srv, cfg)
+
+	mux := http.NewServeMux()
+	mux.Handle(QueryPath, srv)
+	if opts.Playground {
+		mux.Handle(PlaygroundPath, playground.Handler("jig todo", QueryPath))
+	}
+	return withCORS(opts.CORSOrigins, withAuth(opts.Token, withTimeout(opts.Timeout, mux)))
+}
+
+// ReadOnly rejects mutation operations before they execute.
+type ReadOnly struct{}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = ReadOnly{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (ReadOnly) ExtensionName() string { return "ReadOnly" }
+
+// Validate implements graphql.HandlerExtension.
+func (ReadOnly) Validate(graphql.ExecutableSchema) error { return nil }
+
+// MutateOperationContext implements graphql.OperationContextMutator.
+func (ReadOnly) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil || opCtx.Operation.Operation != ast.Mutation {
+		return nil
+	}
+	err := gqlerror.Errorf("mutations are disabled on this server (start it with --allow-mutations)")
+	err.Extensions = map[string]any{"code": ErrCodeReadOnly}
+	return err
+}
+
+// withAuth requires "Authorization: Bearer <token>" on every request but
+// CORS preflights when token is set.
+func withAuth(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if r.Method != http.MethodOptions && subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="jig"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"missing or invalid bearer token","extensions":{"code":"UNAUTHENTICATED"}}]}`))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withTimeout gives each request a context deadline, which resolvers honor
+// mid-traversal.
+func withTimeout(timeout time.Duration, next http.Handler) http.Handler {
+	if timeout <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withCORS answers preflights and sets Access-Control-Allow-Origin for the
+// allowed origins. Requests from other origins are served without CORS
+// headers, so browsers refuse to expose the response.
+func withCORS(origins []string, next http.Handler) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+	allowAll := slices.Contains(origins, "*")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && (allowAll || slices.Contains(origins, origin)) {
+			h := w.Header()
+			h.Set("Access-Control-Allow-Origin", origin)
+			h.Add("Vary", "Origin")
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+				h.Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// startTestServer serves the handler on a random localhost port and returns
+// its base URL.
+func startTestServer(t *testing.T, r *Resolver, opts ServerOptions) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	srv := &http.Server{Handler: NewHandler(r, opts), ReadHeaderTimeout: 5 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return "http://" + ln.Addr().String()
+}
+
+type testResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+func postQuery(t *testing.T, url, token, query string) (int, testResponse) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req, err := http.NewRequest(http.MethodPost, url+QueryPath, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	var out testResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	return resp.StatusCode, out
+}
+
+func TestServerQueryAndReadOnly(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	createTestIssue(t, c, "srv-1", "Served issue", "ready")
+	url := startTestServer(t, resolver, ServerOptions{Timeout: 5 * time.Second})
+
+	status, out := postQuery(t, url, "", `{ issue(id: "srv-1") { title } }`)
+	if status != http.StatusOK || len(out.Errors) > 0 {
+		t.Fatalf("query status = %d, errors = %+v", status, out.Errors)
+	}
+	if got := out.Data["issue"].(map[string]any)["title"]; got != "Served issue" {
+		t.Errorf("title = %v, want Served issue", got)
+	}
+
+	_, out = postQuery(t, url, "", `mutation { createIssue(input: { title: "Nope" }) { id } }`)
+	if len(out.Errors) != 1 || out.Errors[0].Extensions["code"] != ErrCodeReadOnly {
+		t.Fatalf("mutation errors = %+v, want %s", out.Errors, ErrCodeReadOnly)
+	}
+	if n := len(c.All()); n != 1 {
+		t.Errorf("read-only server created an issue: %d issues", n)
+	}
+}
+
+func TestServerAllowMutations(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	url := startTestServer(t, resolver, ServerOptions{AllowMutations: true})
+
+	_, out := postQuery(t, url, "", `mutation { createIssue(input: { title: "Made over HTTP" }) { id } }`)
+	if len(out.Errors) > 0 {
+		t.Fatalf("mutation errors = %+v", out.Errors)
+	}
+	if n := len(c.All()); n != 1 {
+		t.Errorf("issues = %d, want 1", n)
+	}
+}
+
+func TestServerReportsWarnings(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	c.Create(&issue.Issue{ID: "srv-1", Title: "Tasks", Status: "ready", Body: "- [ ] Keep\n- [ ] Drop"})
+	url := startTestServer(t, resolver, ServerOptions{AllowMutations: true})
+
+	_, out := postQuery(t, url, "", `mutation { updateIssue(id: "srv-1", input: { body: "- [ ] Keep" }) { id } }`)
+	if len(out.Errors) > 0 {
+		t.Fatalf("mutation errors = %+v", out.Errors)
+	}
+	warnings, _ := out.Extensions["warnings"].([]any)
+	if len(warnings) != 1 || !strings.Contains(warnings[0].(string), `"Drop"`) {
+		t.Errorf("extensions.warnings = %v, want the removed task", out.Extensions["warnings"])
+	}
+
+	_, out = postQuery(t, url, "", `mutation { updateIssue(id: "srv-1", input: { title: "Renamed" }) { id } }`)
+	if _, ok := out.Extensions["warnings"]; ok {
+		t.Errorf("extensions = %v, want no warnings", out.Extensions)
+	}
+}
+
+func TestServerRequiresToken(t *testing.T) {
+	resolver, _ := setupTestResolver(t)
+	url := startTestServer(t, resolver, ServerOptions{Token: "s3cret"})
+
+	for _, token := range []string{"", "wrong"} {
+		status, out := postQuery(t, url, token, `{ issues { id } }`)
+		if status != http.StatusUnauthorized {
+			t.Errorf("token %q: status = %d, want 401", token, status)
+		}
+		if len(out.Errors) != 1 || !strings.Contains(out.Errors[0].Message, "bearer token") {
+			t.Errorf("token %q: errors = %+v", token, out.Errors)
+		}
+	}
+
+	if status, out := postQuery(t, url, "s3cret", `{ issues { id } }`); status != http.StatusOK || len(out.Errors) > 0 {
+		t.Errorf("valid token: status = %d, errors = %+v", status, out.Errors)
+	}
+}
+
+func TestServerCORSPreflight(t *testing.T) {
+	resolver, _ := setupTestResolver(t)
+	url := startTestServer(t, resolver, ServerOptions{Token: "s3cret", CORSOrigins: []string{"http://localhost:3000"}})
+
+	preflight := func(origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, url+QueryPath, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("OPTIONS: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := preflight("http://localhost:3000")
+	if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != "http://localhost:3000" {
+		t.Errorf("allowed origin: status = %d, allow-origin = %q", resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+	if got := preflight("http://evil.example").Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("other origin allowed: %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/toba/jig/internal/todo/core"
+)
+
+// viewKey is the context key of the view WithView installs.
+type viewKey struct{}
+
+// WithView returns ctx with v as the state query resolvers read from.
+func WithView(ctx context.Context, v *core.Core) context.Context {
+	return context.WithValue(ctx, viewKey{}, v)
+}
+
+// reader returns the core a read resolves against: the operation's view
+// if ctx carries one, else r.Core. Mutations always use r.Core.
+func (r *Resolver) reader(ctx context.Context) *core.Core {
+	if v, ok := ctx.Value(viewKey{}).(*core.Core); ok {
+		return v
+	}
+	return r.Core
+}
+
+// Views gives each query operation its own view of Core, taken as it
+// starts, so every field of the query sees the same issues even if the
+// watcher reloads while it runs: counts and lists in one document agree.
+// Mutations run against live state.
+type Views struct {
+	Core *core.Core
+}
+
+var _ interface {
+	graphql.OperationInterceptor
+	graphql.HandlerExtension
+} = Views{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (Views) ExtensionName() string { return "Views" }
+
+// Validate implements graphql.HandlerExtension.
+func (Views) Validate(graphql.ExecutableSchema) error { return nil }
+
+// InterceptOperation implements graphql.OperationInterceptor.
+func (v Views) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if op := graphql.GetOperationContext(ctx).Operation; op != nil && op.Operation == ast.Query {
+		ctx = WithView(ctx, v.Core.View())
+	}
+	return next(ctx)
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestQueriesReadFromView(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	createTestIssue(t, c, "vw-1", "Before", "ready")
+
+	ctx := WithView(context.Background(), c.View())
+	createTestIssue(t, c, "vw-2", "After", "ready")
+	b, _ := c.Get("vw-1")
+	b.Title = "Renamed"
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := resolver.Query().Issues(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Before" {
+		t.Errorf("view issues = %v, want only vw-1 as it was", issueIDs(issues))
+	}
+	if got, _ := resolver.Query().Issue(ctx, "vw-2"); got != nil {
+		t.Errorf("issue created after the view was found: %v", got.ID)
+	}
+
+	// Without a view, reads see live state.
+	issues, err = resolver.Query().Issues(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("live issues = %v, want both", issueIDs(issues))
+	}
+}
+
+func issueIDs(issues []*issue.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, b := range issues {
+		ids[i] = b.ID
+	}
+	return ids
+}
+
+// TestQueryIsConsistentDuringReloads runs a query that reads the issue list
+// many times over while another goroutine keeps adding, reparenting, and
+// removing issue files behind the watcher. Every read in one response must
+// agree, whatever reloads happen between them.
+func TestQueryIsConsistentDuringReloads(t *testing.T) {
+	resolver, c := setupTestResolver(t)
+	c.Config().Watcher.DebounceMs = 1
+	if err := c.Create(&issue.Issue{ID: "cns-epic", Title: "Epic", Status: "ready", Type: "epic"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 20 {
+		createTestIssue(t, c, fmt.Sprintf("cns-%03d", i), "Seed", "ready")
+	}
+	if err := c.StartWatching(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Unwatch() })
+	url := startTestServer(t, resolver, ServerOptions{Timeout: 10 * time.Second})
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			path := filepath.Join(c.Root(), fmt.Sprintf("ext-%03d--churn.md", i%10))
+			if i%3 == 2 {
+				_ = os.Remove(path)
+				continue
+			}
+			content := fmt.Sprintf("---\ntitle: Churn %d\nstatus: ready\ntype: task\nparent: cns-epic\n---\n", i)
+			_ = os.WriteFile(path, []byte(content), 0o644)
+			time.Sleep(time.Millisecond)
+		}
+	})
+	t.Cleanup(func() { close(done); wg.Wait() })
+
+	var query strings.Builder
+	query.WriteString("{")
+	for i := range 15 {
+		fmt.Fprintf(&query, " a%d: issues { id } k%d: issues(filter: { parentId: \"cns-epic\" }) { id }", i, i)
+	}
+	query.WriteString(` epic: issue(id: "cns-epic") { children { id } } }`)
+
+	for range 30 {
+		_, out := postQuery(t, url, "", query.String())
+		if len(out.Errors) > 0 {
+			t.Fatalf("errors = %+v", out.Errors)
+		}
+		all, kids := responseIDs(out.Data["a0"]), responseIDs(out.Data["k0"])
+		for i := range 15 {
+			if got := responseIDs(out.Data[fmt.Sprintf("a%d", i)]); !slices.Equal(got, all) {
+				t.Fatalf("issues read %d = %v, read 0 = %v", i, got, all)
+			}
+			if got := responseIDs(out.Data[fmt.Sprintf("k%d", i)]); !slices.Equal(got, kids) {
+				t.Fatalf("children read %d = %v, read 0 = %v", i, got, kids)
+			}
+		}
+		epic, _ := out.Data["epic"].(map[string]any)
+		if got := responseIDs(epic["children"]); !slices.Equal(got, kids) {
+			t.Fatalf("epic children = %v, filtered list = %v", got, kids)
+		}
+		for _, id := range kids {
+			if !slices.Contains(all, id) {
+				t.Fatalf("child %s is missing from the issue list %v", id, all)
+			}
+		}
+	}
+}
+
+// responseIDs returns the sorted ids of a list of issues in a response.
+func responseIDs(v any) []string {
+	list, _ := v.([]any)
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			ids = append(ids, fmt.Sprint(m["id"]))
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
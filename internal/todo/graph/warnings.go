@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// warningsKey is the context key of the collector WithWarnings installs.
+type warningsKey struct{}
+
+type warningList struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+// WithWarnings returns ctx with a collector for the warnings resolvers raise
+// (such as a body update that removed unchecked tasks), and a function
+// returning those collected so far. Without one, warnings are dropped.
+func WithWarnings(ctx context.Context) (context.Context, func() []string) {
+	w := &warningList{}
+	return context.WithValue(ctx, warningsKey{}, w), func() []string {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return append([]string(nil), w.msgs...)
+	}
+}
+
+// addWarning records msg with ctx's warning collector, if it has one.
+func addWarning(ctx context.Context, msg string) {
+	if w, ok := ctx.Value(warningsKey{}).(*warningList); ok {
+		w.mu.Lock()
+		w.msgs = append(w.msgs, msg)
+		w.mu.Unlock()
+	}
+}
+
+// Warnings reports the warnings resolvers raise as an extensions.warnings
+// list of messages on the response.
+type Warnings struct{}
+
+var _ interface {
+	graphql.ResponseInterceptor
+	graphql.HandlerExtension
+} = Warnings{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (Warnings) ExtensionName() string { return "Warnings" }
+
+// Validate implements graphql.HandlerExtension.
+func (Warnings) Validate(graphql.ExecutableSchema) error { return nil }
+
+// InterceptResponse implements graphql.ResponseInterceptor.
+func (Warnings) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	ctx, collected := WithWarnings(ctx)
+	resp := next(ctx)
+	if resp == nil {
+		return nil
+	}
+	if warnings := collected(); len(warnings) > 0 {
+		if resp.Extensions == nil {
+			resp.Extensions = map[string]any{}
+		}
+		resp.Extensions["warnings"] = warnings
+	}
+	return resp
+}
@@ -0,0 +1,225 @@
+// Package ical renders issues with due dates as an RFC 5545 iCalendar file,
+// so due work shows up in calendar and reminder apps.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Component kinds an issue can be exported as.
+const (
+	ComponentTodo  = "VTODO"
+	ComponentEvent = "VEVENT"
+)
+
+// DefaultBodyLimit is how many characters of the body go into DESCRIPTION.
+const DefaultBodyLimit = 500
+
+// uidDomain keeps UIDs globally unique per RFC 5545 while staying stable for
+// an issue, so re-importing an export updates entries instead of duplicating
+// them.
+const uidDomain = "jig.todo"
+
+// Options controls how issues are rendered.
+type Options struct {
+	// Component is ComponentTodo (default) or ComponentEvent.
+	Component string
+	// Now is written as DTSTAMP on every component.
+	Now time.Time
+	// BodyLimit truncates the body in DESCRIPTION (default DefaultBodyLimit).
+	BodyLimit int
+}
+
+// UID returns the stable calendar UID for an issue ID.
+func UID(id string) string {
+	return id + "@" + uidDomain
+}
+
+// Write renders one component per issue with a due date. Issues without one
+// are skipped.
+func Write(w io.Writer, issues []*issue.Issue, opts Options) error {
+	component := opts.Component
+	if component == "" {
+		component = ComponentTodo
+	}
+	if component != ComponentTodo && component != ComponentEvent {
+		return fmt.Errorf("unknown calendar component %q (must be %s or %s)", component, ComponentTodo, ComponentEvent)
+	}
+	limit := opts.BodyLimit
+	if limit == 0 {
+		limit = DefaultBodyLimit
+	}
+	stamp := formatUTC(opts.Now)
+
+	cw := &contentWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//toba//jig todo//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	for _, b := range issues {
+		if b.Due == nil {
+			continue
+		}
+		cw.line("BEGIN:" + component)
+		cw.line("UID:" + UID(b.ID))
+		cw.line("DTSTAMP:" + stamp)
+		if b.CreatedAt != nil {
+			cw.line("CREATED:" + formatUTC(*b.CreatedAt))
+		}
+		if b.UpdatedAt != nil {
+			cw.line("LAST-MODIFIED:" + formatUTC(*b.UpdatedAt))
+		}
+		cw.line("SUMMARY:" + Escape(b.Title))
+		if component == ComponentTodo {
+			cw.line(dateProperty("DUE", b.Due))
+			cw.line("STATUS:" + todoStatus(b.Status))
+		} else {
+			cw.line(dateProperty("DTSTART", b.Due))
+			cw.line("STATUS:" + eventStatus(b.Status))
+		}
+		if p := priority(b.Priority); p != 0 {
+			cw.line(fmt.Sprintf("PRIORITY:%d", p))
+		}
+		if len(b.Tags) > 0 {
+			tags := make([]string, len(b.Tags))
+			for i, t := range b.Tags {
+				tags[i] = Escape(t)
+			}
+			cw.line("CATEGORIES:" + strings.Join(tags, ","))
+		}
+		cw.line("DESCRIPTION:" + Escape(description(b, limit)))
+		cw.line("END:" + component)
+	}
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// Escape escapes a TEXT value per RFC 5545 section 3.3.11: backslashes,
+// semicolons, and commas are backslash-escaped and line breaks become \n.
+func Escape(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', ';', ',':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n', '\r':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func description(b *issue.Issue, limit int) string {
+	body := strings.TrimSpace(b.Body)
+	if b.Encrypted {
+		body = ""
+	}
+	if utf8.RuneCountInString(body) > limit {
+		body = strings.TrimSpace(string([]rune(body)[:limit])) + "…"
+	}
+	if body == "" {
+		return "Issue " + b.ID
+	}
+	return "Issue " + b.ID + "\n\n" + body
+}
+
+// dateProperty renders a date-only due date as a VALUE=DATE and a timed one
+// in UTC.
+func dateProperty(name string, d *issue.DueDate) string {
+	if !d.HasTime {
+		return name + ";VALUE=DATE:" + d.Format("20060102")
+	}
+	return name + ":" + formatUTC(d.Time)
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func todoStatus(status string) string {
+	switch status {
+	case config.StatusInProgress, config.StatusReview:
+		return "IN-PROCESS"
+	case config.StatusCompleted:
+		return "COMPLETED"
+	case config.StatusScrapped:
+		return "CANCELLED"
+	}
+	return "NEEDS-ACTION"
+}
+
+func eventStatus(status string) string {
+	switch status {
+	case config.StatusScrapped:
+		return "CANCELLED"
+	case config.StatusDraft, config.StatusDeferred:
+		return "TENTATIVE"
+	}
+	return "CONFIRMED"
+}
+
+// priority maps issue priorities onto the RFC 5545 1 (highest) to 9 scale;
+// 0 means undefined.
+func priority(p string) int {
+	switch p {
+	case config.PriorityCritical:
+		return 1
+	case config.PriorityHigh:
+		return 3
+	case config.PriorityNormal:
+		return 5
+	case config.PriorityLow:
+		return 7
+	case config.PriorityDeferred:
+		return 9
+	}
+	return 0
+}
+
+// contentWriter writes CRLF-terminated content lines folded at 75 octets,
+// keeping the first error.
+type contentWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *contentWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = io.WriteString(cw.w, fold(s)+"\r\n")
+}
+
+// fold splits a content line into 75-octet chunks joined by CRLF and a
+// space, never splitting a UTF-8 sequence (RFC 5545 section 3.1).
+func fold(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // the leading space counts toward the limit
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,186 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func render(t *testing.T, issues []*issue.Issue, opts Options) string {
+	t.Helper()
+	opts.Now = now
+	var b strings.Builder
+	if err := Write(&b, issues, opts); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	return b.String()
+}
+
+// unfold reverses line folding so assertions can match whole properties.
+func unfold(s string) []string {
+	return strings.Split(strings.ReplaceAll(s, "\r\n ", ""), "\r\n")
+}
+
+func property(lines []string, name string) (string, bool) {
+	for _, l := range lines {
+		if v, ok := strings.CutPrefix(l, name); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"a,b", `a\,b`},
+		{"a;b", `a\;b`},
+		{`back\slash`, `back\\slash`},
+		{"line\nbreak", `line\nbreak`},
+		{"crlf\r\nbreak", `crlf\nbreak`},
+		{"Fix login, again\nfor real; today", `Fix login\, again\nfor real\; today`},
+		{"colon: stays", "colon: stays"},
+	}
+	for _, tt := range tests {
+		if got := Escape(tt.in); got != tt.want {
+			t.Errorf("Escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTodo(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	issues := []*issue.Issue{
+		{
+			ID:        "abc-def",
+			Title:     "Ship it, finally\nfor real",
+			Status:    "in-progress",
+			Priority:  "high",
+			Tags:      []string{"release", "a,b"},
+			Due:       issue.NewDueDate(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: &created,
+			Body:      "Body; with punctuation",
+		},
+		{ID: "no-due", Title: "Skipped", Status: "ready"},
+	}
+
+	out := render(t, issues, Options{})
+	if !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Errorf("output should end with CRLF-terminated END:VCALENDAR:\n%q", out)
+	}
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("output contains a bare LF")
+	}
+	lines := unfold(out)
+
+	if got := strings.Count(out, "BEGIN:VTODO"); got != 1 {
+		t.Fatalf("got %d VTODO components, want 1 (issues without due dates are skipped)", got)
+	}
+	want := map[string]string{
+		"VERSION:":        "2.0",
+		"UID:":            "abc-def@jig.todo",
+		"DTSTAMP:":        "20260301T120000Z",
+		"CREATED:":        "20260102T030405Z",
+		"SUMMARY:":        `Ship it\, finally\nfor real`,
+		"DUE;VALUE=DATE:": "20260310",
+		"STATUS:":         "IN-PROCESS",
+		"PRIORITY:":       "3",
+		"CATEGORIES:":     `release,a\,b`,
+		"DESCRIPTION:":    `Issue abc-def\n\nBody\; with punctuation`,
+	}
+	for _, name := range []string{"LAST-MODIFIED:", "DTSTART", "DUE:", "UID:no-due"} {
+		if _, ok := property(lines, name); ok {
+			t.Errorf("unexpected %s line", name)
+		}
+	}
+	for name, value := range want {
+		got, ok := property(lines, name)
+		if !ok {
+			t.Errorf("missing %s line", name)
+			continue
+		}
+		if got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestWriteEventWithTime(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+	due := issue.NewDueDateTime(time.Date(2026, 3, 10, 9, 30, 0, 0, loc))
+	out := render(t, []*issue.Issue{{ID: "evt-1", Title: "Demo", Status: "scrapped", Due: due}},
+		Options{Component: ComponentEvent})
+	lines := unfold(out)
+
+	if got, _ := property(lines, "DTSTART:"); got != "20260310T143000Z" {
+		t.Errorf("DTSTART = %q, want UTC timestamp", got)
+	}
+	if got, _ := property(lines, "STATUS:"); got != "CANCELLED" {
+		t.Errorf("STATUS = %q, want CANCELLED", got)
+	}
+	if _, ok := property(lines, "PRIORITY:"); ok {
+		t.Error("PRIORITY should be omitted when the issue has none")
+	}
+	if !strings.Contains(out, "BEGIN:VEVENT\r\n") {
+		t.Error("expected a VEVENT component")
+	}
+
+	if err := Write(&strings.Builder{}, nil, Options{Component: "VJOURNAL"}); err == nil {
+		t.Error("expected error for unknown component")
+	}
+}
+
+func TestWriteStableUID(t *testing.T) {
+	b := &issue.Issue{ID: "abc-def", Title: "One", Status: "ready", Due: issue.NewDueDate(now)}
+	first, _ := property(unfold(render(t, []*issue.Issue{b}, Options{})), "UID:")
+	b.Title = "Renamed"
+	b.Due = issue.NewDueDate(now.AddDate(0, 1, 0))
+	second, _ := property(unfold(render(t, []*issue.Issue{b}, Options{})), "UID:")
+	if first == "" || first != second {
+		t.Errorf("UID changed across exports: %q vs %q", first, second)
+	}
+}
+
+func TestWriteTruncatesBody(t *testing.T) {
+	body := strings.Repeat("é", 40)
+	out := render(t, []*issue.Issue{{ID: "long", Title: "Long", Status: "ready", Due: issue.NewDueDate(now), Body: body}},
+		Options{BodyLimit: 10})
+	got, _ := property(unfold(out), "DESCRIPTION:")
+	if want := `Issue long\n\n` + strings.Repeat("é", 10) + "…"; got != want {
+		t.Errorf("DESCRIPTION = %q, want %q", got, want)
+	}
+
+	encrypted := render(t, []*issue.Issue{{ID: "enc", Title: "Secret", Status: "ready", Due: issue.NewDueDate(now), Body: "hidden", Encrypted: true}},
+		Options{})
+	if got, _ := property(unfold(encrypted), "DESCRIPTION:"); got != "Issue enc" {
+		t.Errorf("encrypted DESCRIPTION = %q, want only the ID", got)
+	}
+}
+
+func TestFold(t *testing.T) {
+	long := "DESCRIPTION:" + strings.Repeat("ü", 100)
+	folded := fold(long)
+	for i, l := range strings.Split(folded, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line %d is %d octets, want at most 75", i, len(l))
+		}
+		if i > 0 && !strings.HasPrefix(l, " ") {
+			t.Errorf("continuation line %d should start with a space", i)
+		}
+		if !strings.HasPrefix(strings.TrimPrefix(l, " "), "DESCRIPTION") && !strings.HasPrefix(strings.TrimPrefix(l, " "), "ü") {
+			t.Errorf("line %d splits a UTF-8 sequence: %q", i, l)
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != long {
+		t.Error("unfolding did not restore the original line")
+	}
+	if fold("SHORT:x") != "SHORT:x" {
+		t.Error("short lines should not be folded")
+	}
+}
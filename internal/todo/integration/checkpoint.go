@@ -0,0 +1,228 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// CheckpointDir is the directory, inside the data directory, that holds one
+// checkpoint file per provider. The leading dot keeps it out of issue loading.
+const CheckpointDir = ".sync-state"
+
+// Checkpoint records which issues a sync run has finished, so an interrupted
+// run can be resumed without calling the provider again for them.
+type Checkpoint struct {
+	Provider  string                     `json:"provider"`
+	StartedAt time.Time                  `json:"started_at"`
+	Completed map[string]CheckpointEntry `json:"completed"` // issue ID -> result
+
+	path string
+	mu   sync.Mutex
+}
+
+// CheckpointEntry is the stored form of a successful SyncResult.
+type CheckpointEntry struct {
+	IssueTitle  string   `json:"issue_title"`
+	ExternalID  string   `json:"external_id,omitempty"`
+	ExternalURL string   `json:"external_url,omitempty"`
+	Action      string   `json:"action"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// CheckpointPath returns the checkpoint file for a provider under the data
+// directory root.
+func CheckpointPath(root, provider string) string {
+	return filepath.Join(root, CheckpointDir, provider+".json")
+}
+
+// NewCheckpoint starts an empty checkpoint for a provider. Nothing is written
+// until Save.
+func NewCheckpoint(root, provider string, now time.Time) *Checkpoint {
+	return &Checkpoint{
+		Provider:  provider,
+		StartedAt: now.UTC(),
+		Completed: make(map[string]CheckpointEntry),
+		path:      CheckpointPath(root, provider),
+	}
+}
+
+// LoadCheckpoint reads the provider's checkpoint. It returns nil, nil when
+// there is none.
+func LoadCheckpoint(root, provider string) (*Checkpoint, error) {
+	path := CheckpointPath(root, provider)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sync checkpoint: %w", err)
+	}
+	cp := &Checkpoint{path: path}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("parsing sync checkpoint %s: %w", path, err)
+	}
+	if cp.Completed == nil {
+		cp.Completed = make(map[string]CheckpointEntry)
+	}
+	return cp, nil
+}
+
+// Path returns the checkpoint's file path.
+func (cp *Checkpoint) Path() string { return cp.path }
+
+// Record marks a result's issue as done. Errors are not recorded, so a
+// resumed run retries them.
+func (cp *Checkpoint) Record(r SyncResult) {
+	if r.Error != nil || r.Action == ActionError || r.IssueID == "" {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.Completed[r.IssueID] = CheckpointEntry{
+		IssueTitle:  r.IssueTitle,
+		ExternalID:  r.ExternalID,
+		ExternalURL: r.ExternalURL,
+		Action:      r.Action,
+		Warnings:    r.Warnings,
+	}
+}
+
+// Save writes the checkpoint atomically, creating CheckpointDir if needed.
+func (cp *Checkpoint) Save() error {
+	cp.mu.Lock()
+	data, err := json.MarshalIndent(cp, "", "  ")
+	cp.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(cp.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", CheckpointDir, err)
+	}
+	// Checkpoints are local to this checkout; keep them out of commits.
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*\n"), 0644); err != nil {
+		return fmt.Errorf("creating %s: %w", CheckpointDir, err)
+	}
+	tmp := cp.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing sync checkpoint: %w", err)
+	}
+	return os.Rename(tmp, cp.path)
+}
+
+// Remove deletes the checkpoint file. A missing file is not an error.
+func (cp *Checkpoint) Remove() error {
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Pending returns the issues the checkpoint has not recorded as done.
+func (cp *Checkpoint) Pending(issues []*issue.Issue) []*issue.Issue {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	var pending []*issue.Issue
+	for _, b := range issues {
+		if _, done := cp.Completed[b.ID]; !done {
+			pending = append(pending, b)
+		}
+	}
+	return pending
+}
+
+// Results returns the recorded results as SyncResults, sorted by issue ID.
+func (cp *Checkpoint) Results() []SyncResult {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	results := make([]SyncResult, 0, len(cp.Completed))
+	for id, e := range cp.Completed {
+		results = append(results, SyncResult{
+			IssueID:     id,
+			IssueTitle:  e.IssueTitle,
+			ExternalID:  e.ExternalID,
+			ExternalURL: e.ExternalURL,
+			Action:      e.Action,
+			Warnings:    e.Warnings,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].IssueID < results[j].IssueID })
+	return results
+}
+
+// SyncWithCheckpoint runs integ.Sync on the issues cp has not recorded yet,
+// saving cp after every finished issue. The returned results combine the
+// earlier runs' recorded results with this run's. The checkpoint file is
+// removed once a run ends without errors or cancellation; otherwise it is
+// kept for a later resume.
+//
+// When ctx is cancelled, failed results are dropped from the report: they are
+// almost always requests cut off by the cancellation, and resuming retries
+// them anyway.
+func SyncWithCheckpoint(ctx context.Context, integ Integration, issues []*issue.Issue, opts SyncOptions, cp *Checkpoint) ([]SyncResult, error) {
+	prior := cp.Results()
+	pending := cp.Pending(issues)
+
+	var saveErr error
+	var saveMu sync.Mutex
+	save := func() {
+		saveMu.Lock()
+		defer saveMu.Unlock()
+		if err := cp.Save(); err != nil && saveErr == nil {
+			saveErr = err
+		}
+	}
+
+	onProgress := opts.OnProgress
+	opts.OnProgress = func(result SyncResult, completed, total int) {
+		cp.Record(result)
+		save()
+		if onProgress != nil {
+			onProgress(result, completed, total)
+		}
+	}
+
+	var results []SyncResult
+	var err error
+	if len(pending) > 0 {
+		results, err = integ.Sync(ctx, pending, opts)
+	}
+
+	// Results that never went through OnProgress (e.g. refused encrypted
+	// issues) are recorded here.
+	failed := err != nil || ctx.Err() != nil
+	kept := results[:0]
+	for _, r := range results {
+		if r.Error != nil && ctx.Err() != nil {
+			continue
+		}
+		if r.Error != nil || r.Action == ActionError {
+			failed = true
+		}
+		cp.Record(r)
+		kept = append(kept, r)
+	}
+
+	if failed {
+		save()
+	} else if rmErr := cp.Remove(); rmErr != nil && saveErr == nil {
+		saveErr = rmErr
+	}
+	if err == nil && saveErr != nil {
+		err = fmt.Errorf("saving sync checkpoint: %w", saveErr)
+	}
+
+	if len(prior) == 0 && len(kept) == 0 {
+		return nil, err
+	}
+	return sortResults(append(prior, kept...)), err
+}
@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	if cp, err := LoadCheckpoint(root, "github"); err != nil || cp != nil {
+		t.Fatalf("LoadCheckpoint with no file = %v, %v; want nil, nil", cp, err)
+	}
+
+	cp := NewCheckpoint(root, "github", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	cp.Record(SyncResult{IssueID: "aaa-111", IssueTitle: "One", ExternalID: "7", Action: ActionCreated})
+	cp.Record(SyncResult{IssueID: "bbb-222", Action: ActionError, Error: errors.New("boom")})
+	if err := cp.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, CheckpointDir, ".gitignore")); err != nil || string(data) != "*\n" {
+		t.Errorf(".gitignore = %q, %v; want \"*\\n\"", data, err)
+	}
+
+	loaded, err := LoadCheckpoint(root, "github")
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadCheckpoint = %v, %v", loaded, err)
+	}
+	if len(loaded.Completed) != 1 || loaded.Completed["aaa-111"].ExternalID != "7" {
+		t.Errorf("Completed = %+v, want only aaa-111 with external ID 7", loaded.Completed)
+	}
+	pending := loaded.Pending([]*issue.Issue{{ID: "aaa-111"}, {ID: "bbb-222"}})
+	if len(pending) != 1 || pending[0].ID != "bbb-222" {
+		t.Errorf("Pending = %v, want [bbb-222]", pending)
+	}
+
+	if err := loaded.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(CheckpointPath(root, "github")); !os.IsNotExist(err) {
+		t.Errorf("checkpoint file still exists after Remove (stat err %v)", err)
+	}
+}
+
+// gitHubCreateServer is a fake GitHub API that counts issue creates by title.
+// Once limit creates have been served, further creates hang until the client
+// gives up, which is how a cancelled run looks from the provider's side.
+type gitHubCreateServer struct {
+	mu      sync.Mutex
+	limit   int // 0 = unlimited
+	served  int
+	creates map[string]int // title -> creates served
+	writes  int            // PATCH requests to issues
+}
+
+func (s *gitHubCreateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/user":
+		_, _ = w.Write([]byte(`{"login":"tester","id":1}`))
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/labels"):
+		_, _ = w.Write([]byte(`[]`))
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/issues"):
+		var req struct {
+			Title string `json:"title"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.mu.Lock()
+		if s.limit > 0 && s.served >= s.limit {
+			s.mu.Unlock()
+			<-r.Context().Done()
+			return
+		}
+		s.served++
+		n := s.served
+		s.creates[req.Title]++
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"number":%d,"id":%d,"title":%q,"state":"open","html_url":"https://github.com/o/r/issues/%d"}`, n+100, n+1000, req.Title, n+100)
+	case r.Method == http.MethodPatch && strings.Contains(r.URL.Path, "/issues/"):
+		s.mu.Lock()
+		s.writes++
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+// redirectDefaultTransport points every request made through
+// http.DefaultTransport at server for the rest of the test.
+func redirectDefaultTransport(t *testing.T, server *httptest.Server) {
+	t.Helper()
+	orig := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme = "http"
+		req.URL.Host = strings.TrimPrefix(server.URL, "http://")
+		return orig.RoundTrip(req)
+	})
+	t.Cleanup(func() { http.DefaultTransport = orig })
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestSyncWithCheckpointResume(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	const total, cancelAfter = 8, 3
+
+	fake := &gitHubCreateServer{limit: cancelAfter, creates: make(map[string]int)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	redirectDefaultTransport(t, server)
+
+	dir := t.TempDir()
+	c := core.New(dir, config.Default())
+	c.SetWarnWriter(nil)
+	for i := range total {
+		b := &issue.Issue{ID: fmt.Sprintf("iss-%03d", i), Slug: "task", Title: fmt.Sprintf("Task %d", i), Status: "ready", Type: "task"}
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gh := mustDetectGitHub(t, "o", "r", c)
+	// One issue at a time, so no create is still on the wire when the run is
+	// cancelled and then lands after the gate is lifted.
+	gh.cfg.Concurrency = 1
+	// Force makes every issue eligible again on the second run, so only the
+	// checkpoint can keep the finished ones from being sent twice.
+	opts := SyncOptions{Force: true, NoRelationships: true}
+
+	// First run: cancel once cancelAfter issues have finished.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var finished int
+	var mu sync.Mutex
+	opts.OnProgress = func(r SyncResult, _, _ int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Error == nil {
+			finished++
+		}
+		if finished == cancelAfter {
+			cancel()
+		}
+	}
+	cp := NewCheckpoint(dir, gh.Name(), time.Now())
+	results, err := SyncWithCheckpoint(ctx, gh, c.All(), opts, cp)
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if len(results) != cancelAfter {
+		t.Fatalf("first run reported %d results, want %d (failed requests dropped)", len(results), cancelAfter)
+	}
+
+	saved, err := LoadCheckpoint(dir, gh.Name())
+	if err != nil || saved == nil {
+		t.Fatalf("checkpoint after interrupted run = %v, %v", saved, err)
+	}
+	if len(saved.Completed) != cancelAfter {
+		t.Fatalf("checkpoint has %d completed, want %d", len(saved.Completed), cancelAfter)
+	}
+
+	// Second run resumes from the saved checkpoint with the gate lifted.
+	fake.mu.Lock()
+	fake.limit = 0
+	fake.mu.Unlock()
+	opts.OnProgress = nil
+	results, err = SyncWithCheckpoint(context.Background(), gh, c.All(), opts, saved)
+	if err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if len(results) != total {
+		t.Fatalf("resumed run reported %d results, want %d", len(results), total)
+	}
+	if !slices.IsSortedFunc(results, func(a, b SyncResult) int { return strings.Compare(a.IssueID, b.IssueID) }) {
+		t.Errorf("resumed run results are not ordered by issue ID")
+	}
+	for _, r := range results {
+		if r.Action != ActionCreated {
+			t.Errorf("%s: action %q, want %q", r.IssueID, r.Action, ActionCreated)
+		}
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.creates) != total {
+		t.Errorf("provider saw creates for %d issues, want %d", len(fake.creates), total)
+	}
+	for title, n := range fake.creates {
+		if n != 1 {
+			t.Errorf("%s created %d times, want 1", title, n)
+		}
+	}
+	if fake.writes != 0 {
+		t.Errorf("provider saw %d issue updates, want 0", fake.writes)
+	}
+	if _, err := os.Stat(CheckpointPath(dir, gh.Name())); !os.IsNotExist(err) {
+		t.Errorf("checkpoint should be removed after a complete run (stat err %v)", err)
+	}
+}
+
+// TestGitHubSyncRefusesInternal checks that --include-internal alone does not
+// push an internal issue to GitHub; the repo must also set allow_internal.
+func TestGitHubSyncRefusesInternal(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	fake := &gitHubCreateServer{creates: make(map[string]int)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	redirectDefaultTransport(t, server)
+
+	c := core.New(t.TempDir(), config.Default())
+	c.SetWarnWriter(nil)
+	for _, b := range []*issue.Issue{
+		{ID: "pub-001", Slug: "public", Title: "Public", Status: "ready", Type: "task"},
+		{ID: "int-001", Slug: "internal", Title: "Internal", Status: "ready", Type: "task", Visibility: config.VisibilityInternal},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts := SyncOptions{NoRelationships: true, IncludeInternal: true}
+
+	gh, err := detectGitHub(map[string]any{"repo": "o/r", "detect_prs": false}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := gh.Sync(context.Background(), c.All(), opts)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if fake.creates["Internal"] != 0 {
+		t.Error("internal issue pushed without allow_internal")
+	}
+	var refused bool
+	for _, r := range results {
+		if r.IssueID == "int-001" {
+			refused = r.Action == ActionSkipped && r.Reason == SkipReasonInternalRefused
+		}
+	}
+	if !refused {
+		t.Errorf("results = %+v, want int-001 skipped with %q", results, SkipReasonInternalRefused)
+	}
+
+	integ, err := detectGitHub(map[string]any{"repo": "o/r", "detect_prs": false, "allow_internal": true}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := integ.Sync(context.Background(), c.All(), opts); err != nil {
+		t.Fatalf("Sync() with allow_internal error = %v", err)
+	}
+	if fake.creates["Internal"] != 1 {
+		t.Errorf("internal issue created %d times with allow_internal, want 1", fake.creates["Internal"])
+	}
+}
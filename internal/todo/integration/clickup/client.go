@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/toba/jig/internal/todo/integration/syncutil"
@@ -57,8 +59,9 @@ type Client struct {
 	listInfo *List
 	// Cached authorized user
 	authorizedUser *AuthorizedUser
-	// Cached space tags (tag name -> true)
-	spaceTags map[string]bool
+	// Cached space tags (tag name -> true), written by concurrent syncs
+	spaceTagMu sync.Mutex
+	spaceTags  map[string]bool
 }
 
 func (c *Client) getRetryConfig() RetryConfig {
@@ -163,6 +166,75 @@ func (c *Client) UpdateTask(ctx context.Context, taskID string, update *UpdateTa
 	return resp.toTaskInfo(), nil
 }
 
+// DeleteTask permanently deletes a task.
+func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
+	url := fmt.Sprintf("%s/task/%s", baseURL, taskID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	if err := c.doRequest(req, nil); err != nil {
+		return fmt.Errorf("deleting task: %w", err)
+	}
+
+	return nil
+}
+
+// commentPageSize is how many comments ClickUp returns per page.
+const commentPageSize = 25
+
+// ListComments fetches all comments on a task, oldest first. ClickUp pages
+// them newest first, continuing from the oldest comment of the last page.
+func (c *Client) ListComments(ctx context.Context, taskID string) ([]Comment, error) {
+	var allComments []Comment
+	url := fmt.Sprintf("%s/task/%s/comment", baseURL, taskID)
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		var resp commentsResponse
+		if err := c.doRequest(req, &resp); err != nil {
+			return nil, fmt.Errorf("listing comments: %w", err)
+		}
+
+		for _, cm := range resp.Comments {
+			allComments = append(allComments, cm.toComment())
+		}
+		if len(resp.Comments) < commentPageSize {
+			break
+		}
+		oldest := resp.Comments[len(resp.Comments)-1]
+		url = fmt.Sprintf("%s/task/%s/comment?start=%s&start_id=%s", baseURL, taskID, oldest.Date, oldest.ID)
+	}
+
+	slices.Reverse(allComments)
+	return allComments, nil
+}
+
+// CreateComment posts a comment on a task without notifying its watchers.
+func (c *Client) CreateComment(ctx context.Context, taskID, text string) (*Comment, error) {
+	url := fmt.Sprintf("%s/task/%s/comment", baseURL, taskID)
+
+	req, err := c.newJSONRequest(ctx, "POST", url, map[string]any{"comment_text": text, "notify_all": false})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp commentJSON
+	if err := c.doRequest(req, &resp); err != nil {
+		return nil, fmt.Errorf("creating comment: %w", err)
+	}
+
+	cm := resp.toComment()
+	cm.Text = text
+	return &cm, nil
+}
+
 // AddDependency adds a dependency to a task.
 // This sets the task with taskID as waiting on (depends on) the task with dependsOnID.
 // In other words: dependsOnID is blocking taskID.
@@ -312,10 +384,16 @@ func (c *Client) GetSpaceTags(ctx context.Context, spaceID string) ([]Tag, error
 }
 
 // CreateSpaceTag creates a tag at the space level so it appears in the tag picker.
-func (c *Client) CreateSpaceTag(ctx context.Context, spaceID, tagName string) error {
+// An empty color leaves the tag in ClickUp's default color.
+func (c *Client) CreateSpaceTag(ctx context.Context, spaceID, tagName, color string) error {
 	url := fmt.Sprintf("%s/space/%s/tag", baseURL, spaceID)
 
-	req, err := c.newJSONRequest(ctx, "POST", url, map[string]any{"tag": map[string]string{"name": tagName}})
+	tag := map[string]string{"name": tagName}
+	if color != "" {
+		tag["tag_bg"] = "#" + color
+		tag["tag_fg"] = "#ffffff"
+	}
+	req, err := c.newJSONRequest(ctx, "POST", url, map[string]any{"tag": tag})
 	if err != nil {
 		return err
 	}
@@ -334,6 +412,8 @@ func (c *Client) PopulateSpaceTagCache(ctx context.Context, spaceID string) erro
 		return err
 	}
 
+	c.spaceTagMu.Lock()
+	defer c.spaceTagMu.Unlock()
 	c.spaceTags = make(map[string]bool, len(tags))
 	for _, t := range tags {
 		c.spaceTags[t.Name] = true
@@ -342,16 +422,20 @@ func (c *Client) PopulateSpaceTagCache(ctx context.Context, spaceID string) erro
 	return nil
 }
 
-// EnsureSpaceTag creates a tag at the space level if it doesn't already exist in the cache.
-func (c *Client) EnsureSpaceTag(ctx context.Context, spaceID, tagName string) error {
-	if c.spaceTags != nil && c.spaceTags[tagName] {
+// EnsureSpaceTag creates a tag at the space level, in color (hex, or "" for
+// ClickUp's default), if it doesn't already exist in the cache. A tag that
+// another sync created first counts as existing.
+func (c *Client) EnsureSpaceTag(ctx context.Context, spaceID, tagName, color string) error {
+	if c.HasSpaceTag(tagName) {
 		return nil
 	}
 
-	if err := c.CreateSpaceTag(ctx, spaceID, tagName); err != nil {
+	if err := c.CreateSpaceTag(ctx, spaceID, tagName, color); err != nil && !syncutil.IsAlreadyExists(err) {
 		return err
 	}
 
+	c.spaceTagMu.Lock()
+	defer c.spaceTagMu.Unlock()
 	if c.spaceTags == nil {
 		c.spaceTags = make(map[string]bool)
 	}
@@ -363,7 +447,9 @@ func (c *Client) EnsureSpaceTag(ctx context.Context, spaceID, tagName string) er
 // HasSpaceTag returns true if the tag exists in the space tag cache.
 // PopulateSpaceTagCache must be called first.
 func (c *Client) HasSpaceTag(tagName string) bool {
-	return c.spaceTags != nil && c.spaceTags[tagName]
+	c.spaceTagMu.Lock()
+	defer c.spaceTagMu.Unlock()
+	return c.spaceTags[tagName]
 }
 
 // SetCustomFieldValue sets a custom field value on a task.
@@ -1,14 +1,39 @@
 package clickup
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+)
 
 // Sync metadata constants
 const (
 	SyncName        = "clickup"
 	SyncKeyTaskID   = "task_id"
 	SyncKeySyncedAt = "synced_at"
+	SyncKeyLabels   = "labels"
 )
 
+// SyncSchema describes the sync data ClickUp keeps on an issue.
+var SyncSchema = syncutil.Schema{
+	Provider: SyncName,
+	Keys: map[string]syncutil.ValueKind{
+		SyncKeyTaskID:   syncutil.KindString,
+		SyncKeySyncedAt: syncutil.KindTime,
+		SyncKeyLabels:   syncutil.KindList,
+
+		syncutil.SyncKeyComments:       syncutil.KindMap,
+		syncutil.SyncKeyPulledComments: syncutil.KindList,
+	},
+	Required: []string{SyncKeyTaskID},
+	LinkKey:  SyncKeyTaskID,
+}
+
+// TaskURL returns the web URL of the task with the given ID.
+func TaskURL(taskID string) string {
+	return "https://app.clickup.com/t/" + taskID
+}
+
 // Config holds ClickUp-specific settings parsed from cfg.SyncConfig("clickup").
 type Config struct {
 	ListID          string
@@ -17,7 +42,25 @@ type Config struct {
 	PriorityMapping map[string]int
 	TypeMapping     map[string]int
 	CustomFields    *CustomFieldsMap
+	FieldMapping    map[string]*FieldMapping // Issue field name → ClickUp custom field
 	SyncFilter      *SyncFilter
+	// Concurrency is how many issues sync pushes at once
+	// (sync.clickup.concurrency, default syncutil.DefaultConcurrency).
+	Concurrency int
+	// Labels sets the colors of created space tags and renames issue tags
+	// on the way to ClickUp (sync.clickup.labels).
+	Labels *syncutil.LabelConfig
+	// SandboxListID is the scratch list `sync smoke-test` creates and
+	// deletes a task in (sync.clickup.sandbox). Empty when unset; sync never
+	// touches it.
+	SandboxListID string
+	// Comments posts the issue's comments as remote comments and leaves
+	// the Comments section out of the pushed description
+	// (sync.clickup.comments, default false).
+	Comments bool
+	// CommentsPull also copies remote comments into the Comments section
+	// (sync.clickup.comments_pull, default false). It implies Comments.
+	CommentsPull bool
 }
 
 // CustomFieldsMap maps issue fields to ClickUp custom field UUIDs.
@@ -134,6 +177,19 @@ func ParseConfig(m map[string]any) (*Config, error) {
 		}
 	}
 
+	// Parse field_mapping
+	if v, ok := m["field_mapping"]; ok {
+		if fm, ok := v.(map[string]any); ok {
+			mapping, err := parseFieldMapping(fm)
+			if err != nil {
+				return nil, err
+			}
+			if len(mapping) > 0 {
+				cfg.FieldMapping = mapping
+			}
+		}
+	}
+
 	// Parse sync_filter
 	if v, ok := m["sync_filter"]; ok {
 		if sf, ok := v.(map[string]any); ok {
@@ -153,6 +209,21 @@ func ParseConfig(m map[string]any) (*Config, error) {
 		}
 	}
 
+	concurrency, err := syncutil.ParseConcurrency(SyncName, m)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Concurrency = concurrency
+
+	if cfg.Labels, err = syncutil.ParseLabelConfig(SyncName, m); err != nil {
+		return nil, err
+	}
+
+	cfg.SandboxListID, _ = m["sandbox"].(string)
+	cfg.CommentsPull, _ = m["comments_pull"].(bool)
+	cfg.Comments, _ = m["comments"].(bool)
+	cfg.Comments = cfg.Comments || cfg.CommentsPull
+
 	return cfg, nil
 }
 
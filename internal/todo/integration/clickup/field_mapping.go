@@ -0,0 +1,258 @@
+package clickup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Field mapping kinds, named after the ClickUp custom field types they target.
+const (
+	FieldKindDropdown = "dropdown"
+	FieldKindText     = "text"
+	FieldKindDate     = "date"
+)
+
+// MappableFields lists the issue fields that can be mapped to ClickUp custom fields.
+var MappableFields = []string{"priority", "type", "status", "tags", "due"}
+
+// clickUpFieldTypes maps each field kind to the ClickUp field types it may target.
+var clickUpFieldTypes = map[string][]string{
+	FieldKindDropdown: {"drop_down"},
+	FieldKindText:     {"short_text", "text"},
+	FieldKindDate:     {"date"},
+}
+
+// FieldMapping maps one issue field to a ClickUp custom field.
+type FieldMapping struct {
+	FieldID string            // ClickUp custom field UUID
+	Kind    string            // One of the FieldKind* constants
+	Values  map[string]string // Issue value → ClickUp value (dropdown option ID or text)
+}
+
+// DropdownOption is a single option of a ClickUp dropdown field.
+type DropdownOption struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	OrderIndex int    `json:"orderindex"`
+}
+
+// parseFieldMapping parses the field_mapping section of the ClickUp config.
+//
+//	field_mapping:
+//	  priority:
+//	    field_id: 0a1b...
+//	    kind: dropdown
+//	    values: {critical: <option-id>, high: <option-id>}
+func parseFieldMapping(m map[string]any) (map[string]*FieldMapping, error) {
+	result := make(map[string]*FieldMapping, len(m))
+	for field, v := range m {
+		if !slices.Contains(MappableFields, field) {
+			return nil, fmt.Errorf("field_mapping: unknown issue field %q (valid: %s)", field, strings.Join(MappableFields, ", "))
+		}
+		raw, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("field_mapping.%s: expected a mapping", field)
+		}
+
+		fm := &FieldMapping{}
+		fm.FieldID, _ = raw["field_id"].(string)
+		fm.Kind, _ = raw["kind"].(string)
+		if fm.FieldID == "" {
+			return nil, fmt.Errorf("field_mapping.%s: field_id is required", field)
+		}
+		if _, ok := clickUpFieldTypes[fm.Kind]; !ok {
+			return nil, fmt.Errorf("field_mapping.%s: kind must be %s, %s, or %s", field, FieldKindDropdown, FieldKindText, FieldKindDate)
+		}
+		if fm.Kind == FieldKindDate && field != "due" {
+			return nil, fmt.Errorf("field_mapping.%s: only due can map to a date field", field)
+		}
+		if fm.Kind == FieldKindDropdown && field == "due" {
+			return nil, fmt.Errorf("field_mapping.due: kind must be %s or %s", FieldKindDate, FieldKindText)
+		}
+
+		if vals, ok := raw["values"].(map[string]any); ok {
+			fm.Values = make(map[string]string, len(vals))
+			for k, val := range vals {
+				fm.Values[k] = fmt.Sprint(val)
+			}
+		}
+		if fm.Kind == FieldKindDropdown && len(fm.Values) == 0 {
+			return nil, fmt.Errorf("field_mapping.%s: dropdown fields need a values table", field)
+		}
+
+		result[field] = fm
+	}
+	return result, nil
+}
+
+// issueFieldValues returns the raw values of a mappable issue field. The due
+// date is rendered as YYYY-MM-DD for text fields.
+func issueFieldValues(b *issue.Issue, field string) []string {
+	var v string
+	switch field {
+	case "due":
+		if b.Due != nil {
+			v = b.Due.String()
+		}
+	case "priority":
+		v = b.Priority
+	case "type":
+		v = b.Type
+	case "status":
+		v = b.Status
+	case "tags":
+		return b.Tags
+	}
+	if v == "" {
+		return nil
+	}
+	return []string{v}
+}
+
+// mappedValue computes the ClickUp value for field on b. It returns ok=false
+// when there is nothing to set, and a non-empty warning when the issue has a
+// value the translation table cannot express.
+func (fm *FieldMapping) mappedValue(b *issue.Issue, field string) (value any, ok bool, warning string) {
+	if fm.Kind == FieldKindDate {
+		if b.Due == nil {
+			return nil, false, ""
+		}
+		return *issueDueToMillis(b.Due), true, ""
+	}
+
+	vals := issueFieldValues(b, field)
+	if len(vals) == 0 {
+		return nil, false, ""
+	}
+
+	if fm.Kind == FieldKindDropdown {
+		// A dropdown holds a single option; for tags use the first mapped tag.
+		for _, v := range vals {
+			if opt, ok := fm.Values[v]; ok {
+				return opt, true, ""
+			}
+		}
+		return nil, false, fmt.Sprintf("field_mapping.%s: no translation for %q", field, strings.Join(vals, ", "))
+	}
+
+	out := make([]string, len(vals))
+	for i, v := range vals {
+		out[i] = v
+		if t, ok := fm.Values[v]; ok {
+			out[i] = t
+		}
+	}
+	return strings.Join(out, ", "), true, ""
+}
+
+// buildMappedFields returns the custom field values for every configured
+// field mapping, along with per-issue warnings for untranslatable values.
+func (s *Syncer) buildMappedFields(b *issue.Issue) ([]CustomField, []string) {
+	if s.config == nil || len(s.config.FieldMapping) == 0 {
+		return nil, nil
+	}
+
+	var fields []CustomField
+	var warnings []string
+	for _, field := range sortedFieldNames(s.config.FieldMapping) {
+		fm := s.config.FieldMapping[field]
+		value, ok, warning := fm.mappedValue(b, field)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		if ok {
+			fields = append(fields, CustomField{ID: fm.FieldID, Value: value})
+		}
+	}
+	return fields, warnings
+}
+
+// updateMappedFields sets mapped custom fields whose values differ from the
+// task's current values. Returns true if any field was updated, plus
+// per-issue warnings for untranslatable values and failed writes.
+func (s *Syncer) updateMappedFields(ctx context.Context, current *TaskInfo, taskID string, b *issue.Issue) (bool, []string) {
+	fields, warnings := s.buildMappedFields(b)
+	if len(fields) == 0 {
+		return false, warnings
+	}
+
+	currentFields := make(map[string]TaskCustomField, len(current.CustomFields))
+	for _, f := range current.CustomFields {
+		currentFields[f.ID] = f
+	}
+
+	updated := false
+	for _, f := range fields {
+		if customFieldValueEqual(currentFields[f.ID], f.Value) {
+			continue
+		}
+		if err := s.client.SetCustomFieldValue(ctx, taskID, f.ID, f.Value); err != nil {
+			warnings = append(warnings, fmt.Sprintf("setting custom field %s: %v", f.ID, err))
+			continue
+		}
+		updated = true
+	}
+	return updated, warnings
+}
+
+// customFieldValueEqual compares a task's current custom field value with a
+// value produced by buildMappedFields.
+func customFieldValueEqual(current TaskCustomField, want any) bool {
+	if current.Value == nil {
+		return false
+	}
+	switch w := want.(type) {
+	case int64:
+		return customFieldDateEqual(current.Value, w)
+	case string:
+		// ClickUp reports a dropdown's value as the selected option's orderindex.
+		if current.Type == "drop_down" {
+			for _, opt := range DropdownOptions(current.TypeConfig) {
+				if opt.ID == w {
+					return fmt.Sprint(current.Value) == fmt.Sprint(opt.OrderIndex)
+				}
+			}
+			return false
+		}
+		s, _ := current.Value.(string)
+		return s == w
+	}
+	return false
+}
+
+// DropdownOptions extracts the options from a dropdown field's type_config.
+func DropdownOptions(typeConfig any) []DropdownOption {
+	if typeConfig == nil {
+		return nil
+	}
+	data, err := json.Marshal(typeConfig)
+	if err != nil {
+		return nil
+	}
+	var tc struct {
+		Options []DropdownOption `json:"options"`
+	}
+	if err := json.Unmarshal(data, &tc); err != nil {
+		return nil
+	}
+	return tc.Options
+}
+
+// FieldKindMatches reports whether a ClickUp field type is a valid target for kind.
+func FieldKindMatches(kind, clickUpType string) bool {
+	return slices.Contains(clickUpFieldTypes[kind], clickUpType)
+}
+
+func sortedFieldNames(m map[string]*FieldMapping) []string {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	return names
+}
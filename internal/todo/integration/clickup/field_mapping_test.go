@@ -0,0 +1,228 @@
+package clickup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestParseConfig_FieldMapping(t *testing.T) {
+	cfg, err := ParseConfig(map[string]any{
+		"list_id": "123",
+		"field_mapping": map[string]any{
+			"priority": map[string]any{
+				"field_id": "sev-field",
+				"kind":     "dropdown",
+				"values":   map[string]any{"high": "opt-high", "low": "opt-low"},
+			},
+			"type": map[string]any{"field_id": "team-field", "kind": "text"},
+			"due":  map[string]any{"field_id": "due-field", "kind": "date"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.FieldMapping) != 3 {
+		t.Fatalf("FieldMapping len = %d, want 3", len(cfg.FieldMapping))
+	}
+	p := cfg.FieldMapping["priority"]
+	if p.FieldID != "sev-field" || p.Kind != FieldKindDropdown || p.Values["high"] != "opt-high" {
+		t.Errorf("priority mapping = %+v", p)
+	}
+
+	bad := map[string]map[string]any{
+		"unknown field": {"estimate": map[string]any{"field_id": "x", "kind": "text"}},
+		"missing id":    {"type": map[string]any{"kind": "text"}},
+		"bad kind":      {"type": map[string]any{"field_id": "x", "kind": "number"}},
+		"date non-due":  {"type": map[string]any{"field_id": "x", "kind": "date"}},
+		"empty values":  {"priority": map[string]any{"field_id": "x", "kind": "dropdown"}},
+		"due dropdown":  {"due": map[string]any{"field_id": "x", "kind": "dropdown", "values": map[string]any{"a": "b"}}},
+	}
+	for name, fm := range bad {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseConfig(map[string]any{"list_id": "123", "field_mapping": fm}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+// newFieldMappingSyncer returns a syncer with dropdown, text, and date field
+// mappings configured against a test server.
+func newFieldMappingSyncer(t *testing.T, serverURL string) *Syncer {
+	t.Helper()
+	client := &Client{
+		token:      "test",
+		httpClient: &http.Client{Transport: &redirectTransport{target: serverURL}},
+	}
+	syncer := newTestSyncer(t, client)
+	syncer.config.Assignee = new(0)
+	syncer.config.FieldMapping = map[string]*FieldMapping{
+		"priority": {FieldID: "sev-field", Kind: FieldKindDropdown, Values: map[string]string{"high": "opt-high"}},
+		"tags":     {FieldID: "team-field", Kind: FieldKindText, Values: map[string]string{"fe": "Frontend"}},
+		"due":      {FieldID: "due-field", Kind: FieldKindDate},
+	}
+	return syncer
+}
+
+func TestSyncIssue_CreateWithFieldMapping(t *testing.T) {
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/list/") {
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			_ = json.NewEncoder(w).Encode(taskResponse{ID: "task-1", URL: "https://app.clickup.com/t/task-1"})
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	syncer := newFieldMappingSyncer(t, server.URL)
+	due, _ := issue.ParseDueDate("2025-06-15")
+	b := &issue.Issue{
+		ID:       "issue-1",
+		Title:    "Mapped",
+		Status:   "ready",
+		Priority: "high",
+		Tags:     []string{"fe", "api"},
+		Due:      due,
+	}
+
+	result := syncer.syncIssue(context.Background(), b)
+	if result.Action != "created" {
+		t.Fatalf("expected action 'created', got %q (%v)", result.Action, result.Error)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", result.Warnings)
+	}
+
+	raw, _ := payload["custom_fields"].([]any)
+	got := make(map[string]any)
+	for _, f := range raw {
+		m := f.(map[string]any)
+		got[m["id"].(string)] = m["value"]
+	}
+
+	// Dropdown: the translated option ID.
+	if got["sev-field"] != "opt-high" {
+		t.Errorf("dropdown value = %v, want opt-high", got["sev-field"])
+	}
+	// Text: translated where possible, raw otherwise, comma-joined.
+	if got["team-field"] != "Frontend, api" {
+		t.Errorf("text value = %v, want %q", got["team-field"], "Frontend, api")
+	}
+	// Date: Unix milliseconds as a JSON number.
+	if v, ok := got["due-field"].(float64); !ok || int64(v) != toLocalDateMillis(due.Time) {
+		t.Errorf("date value = %v (%T), want %d", got["due-field"], got["due-field"], toLocalDateMillis(due.Time))
+	}
+}
+
+func TestMappedValue_DueAsText(t *testing.T) {
+	fm := &FieldMapping{FieldID: "due-text", Kind: FieldKindText}
+	due, _ := issue.ParseDueDate("2025-06-15")
+
+	value, ok, warning := fm.mappedValue(&issue.Issue{Due: due}, "due")
+	if !ok || warning != "" || value != "2025-06-15" {
+		t.Errorf("mappedValue() = %v, %v, %q; want 2025-06-15", value, ok, warning)
+	}
+	if _, ok, _ := fm.mappedValue(&issue.Issue{}, "due"); ok {
+		t.Error("mappedValue() set a value for an issue with no due date")
+	}
+}
+
+func TestSyncIssue_FieldMappingUnknownValue(t *testing.T) {
+	var payload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/list/") {
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			_ = json.NewEncoder(w).Encode(taskResponse{ID: "task-2"})
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	syncer := newFieldMappingSyncer(t, server.URL)
+	b := &issue.Issue{ID: "issue-2", Title: "Unmapped", Status: "ready", Priority: "low"}
+
+	result := syncer.syncIssue(context.Background(), b)
+	if result.Action != "created" || result.Error != nil {
+		t.Fatalf("unmapped value should not fail the sync: action=%q err=%v", result.Action, result.Error)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "priority") {
+		t.Errorf("warnings = %v, want one priority warning", result.Warnings)
+	}
+	if _, ok := payload["custom_fields"]; ok {
+		t.Errorf("expected no custom_fields in payload, got %v", payload["custom_fields"])
+	}
+}
+
+func TestSyncIssue_UpdateWithFieldMapping(t *testing.T) {
+	var mu sync.Mutex
+	set := make(map[string]any)
+	due, _ := issue.ParseDueDate("2025-06-15")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/task/task-3"):
+			_ = json.NewEncoder(w).Encode(taskResponse{
+				ID:   "task-3",
+				Name: "Mapped",
+				CustomFields: []TaskCustomField{
+					// Dropdown already on the wanted option (orderindex 0 = opt-high).
+					{ID: "sev-field", Type: "drop_down", Value: float64(0), TypeConfig: map[string]any{
+						"options": []any{map[string]any{"id": "opt-high", "name": "High", "orderindex": 0}},
+					}},
+					{ID: "team-field", Type: "short_text", Value: "Backend"},
+				},
+			})
+		case r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/field/"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			parts := strings.Split(r.URL.Path, "/field/")
+			mu.Lock()
+			set[parts[len(parts)-1]] = body["value"]
+			mu.Unlock()
+			_, _ = w.Write([]byte("{}"))
+		default:
+			_ = json.NewEncoder(w).Encode(taskResponse{ID: "task-3"})
+		}
+	}))
+	defer server.Close()
+
+	syncer := newFieldMappingSyncer(t, server.URL)
+	syncer.syncStore.SetTaskID("issue-3", "task-3")
+	syncer.opts.Force = true
+
+	now := time.Now()
+	b := &issue.Issue{
+		ID:        "issue-3",
+		Title:     "Mapped",
+		Status:    "ready",
+		Priority:  "high",
+		Tags:      []string{"fe"},
+		Due:       due,
+		UpdatedAt: &now,
+	}
+
+	result := syncer.syncIssue(context.Background(), b)
+	if result.Action != "updated" {
+		t.Fatalf("expected action 'updated', got %q (%v)", result.Action, result.Error)
+	}
+	if _, ok := set["sev-field"]; ok {
+		t.Error("unchanged dropdown should not be written")
+	}
+	if set["team-field"] != "Frontend" {
+		t.Errorf("text field set to %v, want Frontend", set["team-field"])
+	}
+	if v, ok := set["due-field"].(float64); !ok || int64(v) != toLocalDateMillis(due.Time) {
+		t.Errorf("date field set to %v, want %d", set["due-field"], toLocalDateMillis(due.Time))
+	}
+}
@@ -1,8 +1,11 @@
 package clickup
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +24,12 @@ type SyncResult struct {
 	TaskURL    string
 	Action     string // Matches syncutil.Action* constants
 	Error      error
+	Warnings   []string               // Non-fatal problems, e.g. untranslatable field mappings
+	Changes    []syncutil.FieldChange // Fields a dry run would push (empty when unchanged)
+	// LabelsAdded and LabelsRemoved are the tags the sync put on or took
+	// off the task (or would, in a dry run).
+	LabelsAdded   []string
+	LabelsRemoved []string
 }
 
 // ProgressFunc is called when an issue sync completes.
@@ -33,7 +42,12 @@ type SyncOptions struct {
 	Force           bool
 	NoRelationships bool
 	ListID          string
-	OnProgress      ProgressFunc // Optional callback for progress updates
+	// Concurrency caps how many issues are pushed at once (default
+	// syncutil.DefaultConcurrency).
+	Concurrency int
+	// FailFast stops starting issues after the first error.
+	FailFast   bool
+	OnProgress ProgressFunc // Optional callback for progress updates
 }
 
 // Syncer handles syncing issues to ClickUp tasks.
@@ -65,8 +79,8 @@ func NewSyncer(client *Client, cfg *Config, opts SyncOptions, c *core.Core, sync
 
 // SyncIssues syncs a list of issues to ClickUp tasks.
 // Uses a multi-pass approach:
-// 1. Create/update parent tasks (issues without parents, or parents not in this sync)
-// 2. Create/update child tasks with parent references
+// 1. Create/update top-level tasks (issues without parents, or parents not in this sync)
+// 2. Create/update child tasks with parent references, one depth at a time
 // 3. Sync blocking relationships as dependencies
 func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncResult, error) {
 	// Prefetch only what this run actually needs, and do it concurrently so the
@@ -141,23 +155,6 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 		}
 	}
 
-	// Build a set of issue IDs being synced
-	syncingIDs := make(map[string]bool)
-	for _, b := range issues {
-		syncingIDs[b.ID] = true
-	}
-
-	// Separate issues into layers: parents first, then children
-	// An issue is a "parent" if it has no parent, or its parent isn't being synced
-	var parents, children []*issue.Issue
-	for _, b := range issues {
-		if b.Parent == "" || !syncingIDs[b.Parent] {
-			parents = append(parents, b)
-		} else {
-			children = append(children, b)
-		}
-	}
-
 	// Create index mapping for results
 	issueIndex := make(map[string]int)
 	for i, b := range issues {
@@ -165,11 +162,10 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 	}
 	results := make([]SyncResult, len(issues))
 	total := len(issues)
+	concurrency := cmp.Or(s.opts.Concurrency, syncutil.DefaultConcurrency)
 
 	var mu sync.Mutex // protects issueToTaskID and completed count
 	var completed int
-	g := new(errgroup.Group)
-	g.SetLimit(10)
 
 	// Helper to report progress
 	reportProgress := func(result SyncResult) {
@@ -194,26 +190,19 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 		reportProgress(result)
 	}
 
-	// Pass 1: Create/update parent tasks in parallel
-	for _, b := range parents {
-		g.Go(func() error {
-			syncAndTrack(b, s.syncIssue(ctx, b))
-			return nil
-		})
-	}
-	_ = g.Wait()
-
-	// Pass 2: Create/update child tasks in parallel (parents now exist)
-	for _, b := range children {
-		g.Go(func() error {
-			syncAndTrack(b, s.syncIssue(ctx, b))
-			return nil
-		})
-	}
-	_ = g.Wait()
+	// Passes 1..n: Create/update tasks in parallel, one parent depth at a
+	// time, so parent tasks exist before the children that reference them.
+	finished := syncutil.RunLayers(syncutil.Layers(issues), concurrency, s.opts.FailFast, func(b *issue.Issue) bool {
+		result := s.syncIssue(ctx, b)
+		s.syncComments(ctx, b, &result)
+		syncAndTrack(b, result)
+		return result.Error == nil
+	})
 
-	// Pass 3: Sync blocking relationships in parallel (if not disabled)
-	if !s.opts.NoRelationships && !s.opts.DryRun {
+	// Last pass: Sync blocking relationships in parallel (if not disabled)
+	if finished && !s.opts.NoRelationships && !s.opts.DryRun {
+		g := new(errgroup.Group)
+		g.SetLimit(concurrency)
 		for _, b := range issues {
 			g.Go(func() error {
 				s.syncRelationships(ctx, b)
@@ -223,9 +212,33 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 		_ = g.Wait()
 	}
 
+	// Issues a --fail-fast stop never started have no result.
+	if !finished {
+		results = slices.DeleteFunc(results, func(r SyncResult) bool { return r.IssueID == "" })
+	}
+
 	return results, nil
 }
 
+// taskDescription builds the ClickUp task description from a local issue:
+// the summary and body as separate paragraphs, then the sync footer. With
+// comments set, the body's Comments section is left out; its entries are
+// posted as task comments instead.
+func taskDescription(b *issue.Issue, comments bool) string {
+	var parts []string
+	if b.Summary != "" {
+		parts = append(parts, b.Summary)
+	}
+	body := b.Body
+	if comments {
+		body = syncutil.StripComments(body)
+	}
+	if body != "" {
+		parts = append(parts, body)
+	}
+	return strings.Join(append(parts, syncutil.SyncFooter), "\n\n")
+}
+
 // syncIssue syncs a single issue to a ClickUp task.
 func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 	result := SyncResult{
@@ -233,13 +246,7 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 		IssueTitle: b.Title,
 	}
 
-	// Build the task description with sync footer
-	description := b.Body
-	if description != "" {
-		description += "\n\n" + syncutil.SyncFooter
-	} else {
-		description = syncutil.SyncFooter
-	}
+	description := taskDescription(b, s.commentsEnabled())
 
 	// Map issue status to ClickUp status
 	clickUpStatus := s.getClickUpStatus(b.Status)
@@ -275,7 +282,15 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 			result.TaskURL = task.URL
 
 			if s.opts.DryRun {
-				result.Action = syncutil.ActionWouldUpdate
+				update := s.buildUpdateRequest(task, b, description, priority, clickUpStatus)
+				plan := s.planTags(b, task.Tags)
+				result.LabelsAdded, result.LabelsRemoved = plan.Added, plan.Removed
+				result.Changes = append(updateChanges(task, update), tagChanges(task.Tags, plan.Labels)...)
+				if len(result.Changes) > 0 {
+					result.Action = syncutil.ActionWouldUpdate
+				} else {
+					result.Action = syncutil.ActionUnchanged
+				}
 				return result
 			}
 
@@ -303,14 +318,18 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 
 			// Update custom fields only if changed (best-effort)
 			customFieldsUpdated := s.updateChangedCustomFields(ctx, task, *taskID, b)
+			mappedFieldsUpdated, warnings := s.updateMappedFields(ctx, task, *taskID, b)
+			result.Warnings = warnings
 
 			// Sync tags (best-effort)
-			tagsChanged := s.syncTags(ctx, *taskID, b, task.Tags)
+			tags := s.syncTags(ctx, *taskID, b, task.Tags)
+			result.LabelsAdded, result.LabelsRemoved = tags.Added, tags.Removed
+			s.syncStore.SetLabels(b.ID, tags.Managed)
 
 			// Update synced_at timestamp in sync store
 			s.syncStore.SetSyncedAt(b.ID, time.Now().UTC())
 
-			if update.hasChanges() || customFieldsUpdated || tagsChanged {
+			if update.hasChanges() || customFieldsUpdated || mappedFieldsUpdated || tags.Changed() {
 				result.Action = syncutil.ActionUpdated
 			} else {
 				result.Action = syncutil.ActionUnchanged
@@ -322,24 +341,27 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 	// Create new task
 	if s.opts.DryRun {
 		result.Action = syncutil.ActionWouldCreate
+		result.LabelsAdded = s.labels().Labels(b.Tags)
 		return result
 	}
 
+	mappedFields, warnings := s.buildMappedFields(b)
+	result.Warnings = warnings
+
 	createReq := &CreateTaskRequest{
 		Name:                b.Title,
 		MarkdownDescription: description,
 		Status:              clickUpStatus,
 		Priority:            priority,
 		Assignees:           s.getAssignees(ctx),
-		CustomFields:        s.buildCustomFields(b),
+		CustomFields:        append(s.buildCustomFields(b), mappedFields...),
 		CustomItemID:        s.getClickUpCustomItemID(b.Type),
 	}
 
 	// Set due date if issue has one
 	if b.Due != nil {
-		millis := toLocalDateMillis(b.Due.Time)
-		createReq.DueDate = &millis
-		createReq.DueDatetime = new(false)
+		createReq.DueDate = issueDueToMillis(b.Due)
+		createReq.DueDatetime = new(b.Due.HasTime)
 	}
 
 	// Set parent task ID if issue has a parent that's already synced
@@ -363,14 +385,16 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 	// Upload local images and replace paths with remote URLs
 	if urlMap, err := UploadImages(ctx, s.client, task.ID, b.Body); err == nil && len(urlMap) > 0 {
 		refs := syncutil.FindLocalImages(b.Body)
-		newDesc := syncutil.ReplaceImages(b.Body, refs, urlMap)
+		newDesc := syncutil.ReplaceImages(description, refs, urlMap)
 		s.client.UpdateTask(ctx, task.ID, &UpdateTaskRequest{MarkdownDescription: &newDesc}) //nolint:errcheck // best-effort description update
-		b.Body = newDesc
+		b.Body = syncutil.ReplaceImages(b.Body, refs, urlMap)
 		_ = s.core.Update(b, nil)
 	}
 
 	// Sync tags for new task (no existing tags to remove)
-	s.syncTags(ctx, task.ID, b, nil)
+	tags := s.syncTags(ctx, task.ID, b, nil)
+	result.LabelsAdded = tags.Added
+	s.syncStore.SetLabels(b.ID, tags.Managed)
 
 	// Store task ID and sync timestamp in sync store
 	s.syncStore.SetTaskID(b.ID, task.ID)
@@ -380,6 +404,78 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 	return result
 }
 
+// commentsEnabled reports whether comments sync as task comments.
+func (s *Syncer) commentsEnabled() bool {
+	return s.config != nil && s.config.Comments
+}
+
+// syncComments posts the issue's new comments to its task and, with
+// comments_pull, copies new task comments into it, folding the outcome into
+// result. An issue skipped as unchanged has no new local comments, so it is
+// only checked for remote ones.
+func (s *Syncer) syncComments(ctx context.Context, b *issue.Issue, result *SyncResult) {
+	if !s.commentsEnabled() || result.Error != nil || (result.Action == syncutil.ActionSkipped && !s.config.CommentsPull) {
+		return
+	}
+	if result.TaskID == "" && !s.opts.DryRun {
+		return
+	}
+	comments, err := syncutil.SyncComments(ctx, &taskComments{client: s.client, taskID: result.TaskID}, b.Body, s.syncStore.GetComments(b.ID), syncutil.CommentOptions{
+		Provider: SyncName,
+		Pull:     s.config.CommentsPull,
+		DryRun:   s.opts.DryRun,
+	})
+	if s.opts.DryRun {
+		result.Changes = append(result.Changes, comments.Changes()...)
+		if len(result.Changes) > 0 && (result.Action == syncutil.ActionUnchanged || result.Action == syncutil.ActionSkipped) {
+			result.Action = syncutil.ActionWouldUpdate
+		}
+		return
+	}
+	s.syncStore.SetComments(b.ID, comments.State)
+	if comments.Body != b.Body {
+		b.Body = comments.Body
+		if s.core != nil {
+			_ = s.core.Update(b, nil)
+		}
+		s.syncStore.SetSyncedAt(b.ID, time.Now().UTC())
+	}
+	if err != nil {
+		result.Action = syncutil.ActionError
+		result.Error = fmt.Errorf("syncing comments: %w", err)
+		return
+	}
+	if comments.Pushed+comments.Pulled > 0 && (result.Action == syncutil.ActionUnchanged || result.Action == syncutil.ActionSkipped) {
+		result.Action = syncutil.ActionUpdated
+	}
+}
+
+// taskComments is the syncutil.CommentAPI of one task.
+type taskComments struct {
+	client *Client
+	taskID string
+}
+
+func (c *taskComments) ListComments(ctx context.Context) ([]syncutil.RemoteComment, error) {
+	comments, err := c.client.ListComments(ctx, c.taskID)
+	if err != nil {
+		return nil, err
+	}
+	remote := make([]syncutil.RemoteComment, len(comments))
+	for i, cm := range comments {
+		remote[i] = syncutil.RemoteComment{ID: cm.ID, Author: cm.Username, Text: cm.Text, CreatedAt: cm.Date}
+	}
+	return remote, nil
+}
+
+func (c *taskComments) CreateComment(ctx context.Context, text string) (string, error) {
+	cm, err := c.client.CreateComment(ctx, c.taskID, text)
+	if err != nil {
+		return "", err
+	}
+	return cm.ID, nil
+}
+
 // needsSync checks if an issue needs to be synced based on timestamps.
 func (s *Syncer) needsSync(b *issue.Issue) bool {
 	syncedAt := s.syncStore.GetSyncedAt(b.ID)
@@ -512,7 +608,7 @@ func (s *Syncer) buildUpdateRequest(current *TaskInfo, b *issue.Issue, descripti
 	if !ptrEqual(currentDueMillis, newDueMillis) {
 		if newDueMillis != nil {
 			update.DueDate = newDueMillis
-			update.DueDatetime = new(false)
+			update.DueDatetime = new(b.Due.HasTime)
 		} else {
 			// Clear due date: ClickUp accepts null to remove it
 			zero := int64(0)
@@ -540,6 +636,71 @@ func (s *Syncer) buildUpdateRequest(current *TaskInfo, b *issue.Issue, descripti
 	return update
 }
 
+// updateChanges lists the fields an update request would change. The
+// description is compared by digest; parent is the only relationship edge
+// ClickUp stores on the task itself.
+func updateChanges(current *TaskInfo, update *UpdateTaskRequest) []syncutil.FieldChange {
+	var changes []syncutil.FieldChange
+	add := func(field, local, remote string) {
+		changes = append(changes, syncutil.FieldChange{Field: field, Local: local, Remote: remote})
+	}
+	if update.Name != nil {
+		add(syncutil.FieldTitle, *update.Name, current.Name)
+	}
+	if update.MarkdownDescription != nil {
+		add(syncutil.FieldDescription, syncutil.DescriptionDigest(*update.MarkdownDescription), syncutil.DescriptionDigest(current.Description))
+	}
+	if update.Status != nil {
+		add(syncutil.FieldStatus, *update.Status, current.Status.Status)
+	}
+	if update.Priority != nil {
+		add(syncutil.FieldPriority, ptrString(update.Priority), ptrString(priorityID(current.Priority)))
+	}
+	if update.DueDate != nil {
+		local := ""
+		if *update.DueDate != 0 {
+			local = strconv.FormatInt(*update.DueDate, 10)
+		}
+		add(syncutil.FieldDue, local, ptrString(current.DueDate))
+	}
+	if update.CustomItemID != nil {
+		add(syncutil.FieldType, ptrString(update.CustomItemID), ptrString(current.CustomItemID))
+	}
+	if update.Parent != nil {
+		add(syncutil.FieldParent, *update.Parent, ptrString(current.Parent))
+	}
+	return changes
+}
+
+// tagChanges reports a tag difference between a task and its issue.
+func tagChanges(current []Tag, want []string) []syncutil.FieldChange {
+	names := make([]string, len(current))
+	for i, t := range current {
+		names[i] = t.Name
+	}
+	local, remote := syncutil.JoinSorted(want), syncutil.JoinSorted(names)
+	if local == remote {
+		return nil
+	}
+	return []syncutil.FieldChange{{Field: syncutil.FieldLabels, Local: local, Remote: remote}}
+}
+
+// priorityID unwraps a ClickUp priority for comparison and display.
+func priorityID(p *TaskPriority) *int {
+	if p == nil {
+		return nil
+	}
+	return &p.ID
+}
+
+// ptrString formats an optional value for display, with nil as "".
+func ptrString[T any](p *T) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprint(*p)
+}
+
 // priorityEqual compares a TaskPriority (from ClickUp response) with a target priority int pointer.
 func (s *Syncer) priorityEqual(current *TaskPriority, target *int) bool {
 	if current == nil && target == nil {
@@ -669,52 +830,57 @@ func (s *Syncer) getClickUpCustomItemID(issueType string) *int {
 	return nil
 }
 
-// syncTags syncs issue tags to ClickUp task tags.
-// Returns true if any tags were added or removed.
-func (s *Syncer) syncTags(ctx context.Context, taskID string, b *issue.Issue, currentTags []Tag) bool {
-	// Build set of current ClickUp tag names
-	current := make(map[string]bool)
-	for _, t := range currentTags {
-		current[t.Name] = true
+// labels returns the label configuration, which may be absent.
+func (s *Syncer) labels() *syncutil.LabelConfig {
+	if s.config == nil {
+		return nil
 	}
+	return s.config.Labels
+}
 
-	// Build set of desired issue tag names
-	desired := make(map[string]bool)
-	for _, t := range b.Tags {
-		desired[t] = true
+// planTags works out the tags to push to a task that has currentTags:
+// the issue's tags, renamed by the label map, in place of the ones jig
+// pushed last time. Tags added in ClickUp are left alone.
+func (s *Syncer) planTags(b *issue.Issue, currentTags []Tag) syncutil.LabelPlan {
+	current := make([]string, len(currentTags))
+	for i, t := range currentTags {
+		current[i] = t.Name
 	}
+	return syncutil.PlanLabels(current, s.syncStore.GetLabels(b.ID), s.labels().Labels(b.Tags))
+}
 
-	changed := false
-
-	// Add missing tags
-	for _, t := range b.Tags {
-		if !current[t] {
-			// Ensure tag exists at space level so it's discoverable in the tag picker
-			if s.spaceID != "" {
-				if err := s.client.EnsureSpaceTag(ctx, s.spaceID, t); err != nil {
-					_ = err // Best-effort
-				}
-			}
-			if err := s.client.AddTagToTask(ctx, taskID, t); err != nil {
+// syncTags adds and removes task tags as planTags decides, creating
+// missing space tags first. The returned plan lists only the tags that
+// were actually added or removed.
+func (s *Syncer) syncTags(ctx context.Context, taskID string, b *issue.Issue, currentTags []Tag) syncutil.LabelPlan {
+	plan := s.planTags(b, currentTags)
+
+	var added, removed []string
+	for _, t := range plan.Added {
+		// Ensure tag exists at space level so it's discoverable in the tag picker
+		if s.spaceID != "" {
+			if err := s.client.EnsureSpaceTag(ctx, s.spaceID, t, s.labels().Color(t, "")); err != nil {
 				_ = err // Best-effort
-			} else {
-				changed = true
 			}
 		}
+		if err := s.client.AddTagToTask(ctx, taskID, t); err != nil {
+			_ = err // Best-effort
+		} else {
+			added = append(added, t)
+		}
 	}
 
-	// Remove extra tags
-	for _, t := range currentTags {
-		if !desired[t.Name] {
-			if err := s.client.RemoveTagFromTask(ctx, taskID, t.Name); err != nil {
-				_ = err // Best-effort
-			} else {
-				changed = true
-			}
+	for _, t := range plan.Removed {
+		if err := s.client.RemoveTagFromTask(ctx, taskID, t); err != nil {
+			// Best-effort; still jig's to remove next time
+			plan.Managed = append(plan.Managed, t)
+		} else {
+			removed = append(removed, t)
 		}
 	}
 
-	return changed
+	plan.Added, plan.Removed = added, removed
+	return plan
 }
 
 // syncRelationships syncs blocking relationships for an issue.
@@ -761,12 +927,16 @@ func FilterIssuesForSync(issues []*issue.Issue, filter *SyncFilter) []*issue.Iss
 	return filtered
 }
 
-// issueDueToMillis converts an issue due date to Unix milliseconds (local midnight).
+// issueDueToMillis converts an issue due date to Unix milliseconds (local
+// midnight for date-only values, the exact time otherwise).
 // Returns nil if the issue has no due date.
 func issueDueToMillis(due *issue.DueDate) *int64 {
 	if due == nil {
 		return nil
 	}
+	if due.HasTime {
+		return new(due.UnixMilli())
+	}
 	millis := toLocalDateMillis(due.Time)
 	return &millis
 }
@@ -15,6 +15,13 @@ type SyncStateProvider interface {
 	GetSyncedAt(issueID string) *time.Time
 	SetTaskID(issueID, taskID string)
 	SetSyncedAt(issueID string, t time.Time)
+	// GetLabels returns the tags jig last pushed to the task.
+	GetLabels(issueID string) []string
+	SetLabels(issueID string, labels []string)
+	// GetComments returns which comments have been posted to and copied
+	// from the task.
+	GetComments(issueID string) syncutil.CommentState
+	SetComments(issueID string, state syncutil.CommentState)
 	Clear(issueID string)
 	Flush() error
 }
@@ -23,6 +30,8 @@ type SyncStateProvider interface {
 type extensionCache struct {
 	taskID   string
 	syncedAt *time.Time
+	labels   []string
+	comments syncutil.CommentState
 }
 
 // pendingOp represents a pending write operation.
@@ -50,11 +59,15 @@ func NewSyncStateStore(store *core.Core, issues []*issue.Issue) *SyncStateStore
 	for _, b := range issues {
 		taskID := GetSyncString(b, SyncKeyTaskID)
 		syncedAt := GetSyncTime(b, SyncKeySyncedAt)
+		labels := syncutil.SyncStrings(b.Sync[SyncName][SyncKeyLabels])
+		comments := syncutil.ParseCommentState(b.Sync[SyncName])
 
 		if taskID != "" || syncedAt != nil {
 			p.cache[b.ID] = &extensionCache{
 				taskID:   taskID,
 				syncedAt: syncedAt,
+				labels:   labels,
+				comments: comments,
 			}
 		}
 	}
@@ -107,6 +120,50 @@ func (p *SyncStateStore) SetSyncedAt(issueID string, t time.Time) {
 	p.ops = append(p.ops, pendingOp{issueID: issueID, isSet: true})
 }
 
+func (p *SyncStateStore) GetLabels(issueID string) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c, ok := p.cache[issueID]
+	if !ok {
+		return nil
+	}
+	return c.labels
+}
+
+func (p *SyncStateStore) SetLabels(issueID string, labels []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cache[issueID] == nil {
+		p.cache[issueID] = &extensionCache{}
+	}
+	p.cache[issueID].labels = labels
+	p.ops = append(p.ops, pendingOp{issueID: issueID, isSet: true})
+}
+
+func (p *SyncStateStore) GetComments(issueID string) syncutil.CommentState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c, ok := p.cache[issueID]
+	if !ok {
+		return syncutil.CommentState{}
+	}
+	return c.comments
+}
+
+func (p *SyncStateStore) SetComments(issueID string, state syncutil.CommentState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cache[issueID] == nil {
+		p.cache[issueID] = &extensionCache{}
+	}
+	p.cache[issueID].comments = state
+	p.ops = append(p.ops, pendingOp{issueID: issueID, isSet: true})
+}
+
 func (p *SyncStateStore) Clear(issueID string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -156,6 +213,10 @@ func (p *SyncStateStore) Flush() error {
 			if c.syncedAt != nil {
 				data[SyncKeySyncedAt] = c.syncedAt.Format(time.RFC3339)
 			}
+			if len(c.labels) > 0 {
+				data[SyncKeyLabels] = c.labels
+			}
+			c.comments.Store(data)
 
 			b.SetSync(SyncName, data)
 		} else {
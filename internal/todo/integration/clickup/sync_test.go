@@ -3,6 +3,7 @@ package clickup
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
 	"github.com/toba/jig/internal/todo/issue"
 )
 
@@ -25,12 +27,16 @@ type memorySyncProvider struct {
 	mu       sync.RWMutex
 	taskIDs  map[string]string
 	syncedAt map[string]*time.Time
+	labels   map[string][]string
+	comments map[string]syncutil.CommentState
 }
 
 func newMemorySyncProvider() *memorySyncProvider {
 	return &memorySyncProvider{
 		taskIDs:  make(map[string]string),
 		syncedAt: make(map[string]*time.Time),
+		labels:   make(map[string][]string),
+		comments: make(map[string]syncutil.CommentState),
 	}
 }
 
@@ -63,11 +69,37 @@ func (m *memorySyncProvider) SetSyncedAt(issueID string, t time.Time) {
 	m.syncedAt[issueID] = &utc
 }
 
+func (m *memorySyncProvider) GetLabels(issueID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.labels[issueID]
+}
+
+func (m *memorySyncProvider) SetLabels(issueID string, labels []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.labels[issueID] = labels
+}
+
+func (m *memorySyncProvider) GetComments(issueID string) syncutil.CommentState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.comments[issueID]
+}
+
+func (m *memorySyncProvider) SetComments(issueID string, state syncutil.CommentState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.comments[issueID] = state
+}
+
 func (m *memorySyncProvider) Clear(issueID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.taskIDs, issueID)
 	delete(m.syncedAt, issueID)
+	delete(m.labels, issueID)
+	delete(m.comments, issueID)
 }
 
 func (m *memorySyncProvider) Flush() error { return nil }
@@ -108,6 +140,7 @@ func TestSyncTags_SetDiff(t *testing.T) {
 		name        string
 		issueTags   []string
 		currentTags []Tag
+		managed     []string
 		wantAdds    []string
 		wantRemoves []string
 		wantChanged bool
@@ -123,6 +156,7 @@ func TestSyncTags_SetDiff(t *testing.T) {
 			name:        "remove extra tags",
 			issueTags:   nil,
 			currentTags: []Tag{{Name: "old-tag"}},
+			managed:     []string{"old-tag"},
 			wantRemoves: []string{"old-tag"},
 			wantChanged: true,
 		},
@@ -130,6 +164,16 @@ func TestSyncTags_SetDiff(t *testing.T) {
 			name:        "add and remove tags",
 			issueTags:   []string{"keep", "new-tag"},
 			currentTags: []Tag{{Name: "keep"}, {Name: "old-tag"}},
+			managed:     []string{"keep", "old-tag"},
+			wantAdds:    []string{"new-tag"},
+			wantRemoves: []string{"old-tag"},
+			wantChanged: true,
+		},
+		{
+			name:        "keep tags added in ClickUp",
+			issueTags:   []string{"new-tag"},
+			currentTags: []Tag{{Name: "human"}, {Name: "old-tag"}},
+			managed:     []string{"old-tag"},
 			wantAdds:    []string{"new-tag"},
 			wantRemoves: []string{"old-tag"},
 			wantChanged: true,
@@ -151,13 +195,14 @@ func TestSyncTags_SetDiff(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			calls = nil
+			syncer.syncStore.SetLabels("issue-1", tt.managed)
 
 			b := &issue.Issue{
 				ID:   "issue-1",
 				Tags: tt.issueTags,
 			}
 
-			changed := syncer.syncTags(context.Background(), "task-1", b, tt.currentTags)
+			changed := syncer.syncTags(context.Background(), "task-1", b, tt.currentTags).Changed()
 
 			if changed != tt.wantChanged {
 				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
@@ -289,6 +334,7 @@ func TestSyncIssue_UpdateWithTagChanges(t *testing.T) {
 
 	store := newMemorySyncProvider()
 	store.SetTaskID("issue-1", "task-123")
+	store.SetLabels("issue-1", []string{"old-tag", "keep"})
 	syncer := &Syncer{
 		client:        client,
 		config:        &Config{},
@@ -321,6 +367,12 @@ func TestSyncIssue_UpdateWithTagChanges(t *testing.T) {
 	if !slices.Equal(tagCalls, expectedCalls) {
 		t.Errorf("tag calls = %v, want %v", tagCalls, expectedCalls)
 	}
+	if !slices.Equal(result.LabelsAdded, []string{"new-tag"}) || !slices.Equal(result.LabelsRemoved, []string{"old-tag"}) {
+		t.Errorf("labels added %v, removed %v; want [new-tag], [old-tag]", result.LabelsAdded, result.LabelsRemoved)
+	}
+	if got := store.GetLabels("issue-1"); !slices.Equal(got, []string{"keep", "new-tag"}) {
+		t.Errorf("managed labels = %v, want [keep new-tag]", got)
+	}
 }
 
 // redirectTransport redirects all requests to the test server.
@@ -439,6 +491,60 @@ func TestSyncTags_CachePreventsRedundantSpaceTagCreation(t *testing.T) {
 	}
 }
 
+func TestEnsureSpaceTag_CreateRace(t *testing.T) {
+	var body map[string]map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Another sync created the tag first.
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"err":"Tag already exists","ECODE":"TAG_001"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		token:      "test",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+	}
+	if err := client.EnsureSpaceTag(context.Background(), "space-1", "frontend", "d73a4a"); err != nil {
+		t.Fatalf("EnsureSpaceTag() error: %v", err)
+	}
+	if !client.HasSpaceTag("frontend") {
+		t.Error("an existing tag should be cached")
+	}
+	if got := body["tag"]["tag_bg"]; got != "#d73a4a" {
+		t.Errorf("tag_bg = %q, want #d73a4a", got)
+	}
+}
+
+func TestSyncTags_LabelTranslation(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/tag/") {
+			parts := strings.Split(r.URL.Path, "/tag/")
+			calls = append(calls, r.Method+" "+parts[len(parts)-1])
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		token:      "test",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+	}
+	syncer := newTestSyncer(t, client)
+	syncer.config.Labels = &syncutil.LabelConfig{Map: map[string]string{"bug": "defect"}}
+
+	b := &issue.Issue{ID: "issue-1", Tags: []string{"bug"}}
+	plan := syncer.syncTags(context.Background(), "task-1", b, []Tag{{Name: "bug"}})
+
+	if want := []string{"POST defect"}; !slices.Equal(calls, want) {
+		t.Errorf("tag calls = %v, want %v (a bug tag set by hand is not jig's)", calls, want)
+	}
+	if !slices.Equal(plan.Managed, []string{"defect"}) {
+		t.Errorf("managed = %v, want [defect]", plan.Managed)
+	}
+}
+
 func TestSyncIssue_CreateWithDueDate(t *testing.T) {
 	var capturedReq CreateTaskRequest
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -921,3 +1027,153 @@ func TestSyncIssues_ConditionalPrefetch(t *testing.T) {
 		}
 	})
 }
+
+func TestSyncIssue_DryRunChanges(t *testing.T) {
+	local := &issue.Issue{ID: "issue-1", Title: "New title", Status: "ready", Body: "body"}
+	description := "body\n\n" + syncutil.SyncFooter
+
+	tests := []struct {
+		name   string
+		remote taskResponse
+		want   []syncutil.FieldChange
+	}{
+		{
+			name:   "title only",
+			remote: taskResponse{ID: "task-1", Name: "Old title", Description: description, Status: Status{Status: "to do"}},
+			want:   []syncutil.FieldChange{{Field: syncutil.FieldTitle, Local: "New title", Remote: "Old title"}},
+		},
+		{
+			name:   "status only",
+			remote: taskResponse{ID: "task-1", Name: "New title", Description: description, Status: Status{Status: "complete"}},
+			want:   []syncutil.FieldChange{{Field: syncutil.FieldStatus, Local: "to do", Remote: "complete"}},
+		},
+		{
+			name:   "unchanged",
+			remote: taskResponse{ID: "task-1", Name: "New title", Description: description, Status: Status{Status: "to do"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if r.Method != http.MethodGet {
+					t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(tt.remote)
+			}))
+			defer server.Close()
+
+			syncer := newTestSyncer(t, &Client{
+				token:      "test",
+				httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+			})
+			syncer.opts.DryRun = true
+			syncer.opts.Force = true
+			syncer.syncStore.SetTaskID(local.ID, "task-1")
+
+			result := syncer.syncIssue(context.Background(), local)
+
+			wantAction := syncutil.ActionWouldUpdate
+			if len(tt.want) == 0 {
+				wantAction = syncutil.ActionUnchanged
+			}
+			if result.Action != wantAction {
+				t.Errorf("Action = %q, want %q", result.Action, wantAction)
+			}
+			if !slices.Equal(result.Changes, tt.want) {
+				t.Errorf("Changes = %+v, want %+v", result.Changes, tt.want)
+			}
+			if n := requests.Load(); n != 1 {
+				t.Errorf("dry run made %d requests, want 1", n)
+			}
+		})
+	}
+}
+
+func TestTaskDescription(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *issue.Issue
+		want  string
+	}{
+		{"empty", &issue.Issue{}, syncutil.SyncFooter},
+		{"body only", &issue.Issue{Body: "Details"}, "Details\n\n" + syncutil.SyncFooter},
+		{"summary and body", &issue.Issue{Summary: "One line", Body: "Details"}, "One line\n\nDetails\n\n" + syncutil.SyncFooter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskDescription(tt.issue, false); got != tt.want {
+				t.Errorf("taskDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncComments(t *testing.T) {
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v2/task/t1/comment" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var req struct {
+				CommentText string `json:"comment_text"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			posted = append(posted, req.CommentText)
+			_, _ = fmt.Fprintf(w, `{"id":%d,"date":1767261600000}`, 50+len(posted))
+			return
+		}
+		// Newest first, as ClickUp lists them.
+		_, _ = w.Write([]byte(`{"comments":[
+			{"id":"2","comment_text":"Later","user":{"username":"dave"},"date":"1767348000000"},
+			{"id":"1","comment_text":"Earlier","user":{"username":"erin"},"date":"1767261600000"}
+		]}`))
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, &Client{token: "test", httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}}})
+	syncer.config = &Config{Comments: true, CommentsPull: true}
+	b := &issue.Issue{ID: "abc-123", Body: "## Comments\n\n**alice** (jig, 2026-01-01T08:00:00Z):\n\nMine\n\n**erin** (clickup, 2026-01-01T10:00:00Z):\n\nEarlier"}
+
+	for i, wantAction := range []string{syncutil.ActionUpdated, syncutil.ActionUnchanged} {
+		result := SyncResult{IssueID: b.ID, TaskID: "t1", Action: syncutil.ActionUnchanged}
+		syncer.syncComments(context.Background(), b, &result)
+		if result.Error != nil || result.Action != wantAction {
+			t.Fatalf("sync %d: action %q, error %v; want %q", i+1, result.Action, result.Error, wantAction)
+		}
+	}
+
+	if want := []string{"**alice** (jig, 2026-01-01T08:00:00Z):\n\nMine"}; !slices.Equal(posted, want) {
+		t.Errorf("posted = %q, want %q once", posted, want)
+	}
+	comments, err := issue.ParseComments(b.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, cm := range comments {
+		got = append(got, cm.Author+": "+cm.Text)
+	}
+	if want := []string{"alice: Mine", "erin: Earlier", "dave: Later"}; !slices.Equal(got, want) {
+		t.Errorf("local comments = %q, want %q", got, want)
+	}
+}
+
+func TestSyncComments_DryRun(t *testing.T) {
+	syncer := newTestSyncer(t, nil)
+	syncer.config = &Config{Comments: true}
+	syncer.opts.DryRun = true
+	b := &issue.Issue{ID: "abc-123", Body: "## Comments\n\n**alice** (jig, 2026-01-01T08:00:00Z):\n\nMine"}
+
+	result := SyncResult{IssueID: b.ID, TaskID: "t1", Action: syncutil.ActionUnchanged}
+	syncer.syncComments(context.Background(), b, &result)
+	want := []syncutil.FieldChange{{Field: syncutil.FieldComments, Local: "1 new"}}
+	if result.Action != syncutil.ActionWouldUpdate || !slices.Equal(result.Changes, want) {
+		t.Errorf("result = %q %+v, want %q %+v", result.Action, result.Changes, syncutil.ActionWouldUpdate, want)
+	}
+}
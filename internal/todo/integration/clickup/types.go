@@ -1,6 +1,11 @@
 // Package clickup provides ClickUp API integration.
 package clickup
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // TaskInfo holds task data returned from ClickUp.
 type TaskInfo struct {
 	ID           string            `json:"id"`
@@ -23,9 +28,11 @@ type TaskPriority struct {
 
 // TaskCustomField represents a custom field value on a task.
 type TaskCustomField struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Value any    `json:"value"` // Can be string, number, etc. depending on field type
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`        // ClickUp field type (e.g. drop_down, short_text, date)
+	TypeConfig any    `json:"type_config,omitempty"` // Dropdown options etc. depending on field type
+	Value      any    `json:"value"`                 // Can be string, number, etc. depending on field type
 }
 
 // Tag represents a ClickUp task tag.
@@ -193,6 +200,35 @@ type userResponse struct {
 	User AuthorizedUser `json:"user"`
 }
 
+// Comment is a comment on a ClickUp task.
+type Comment struct {
+	ID       string
+	Text     string
+	Username string
+	Date     time.Time
+}
+
+// commentJSON is a comment as the API returns it. IDs and dates are numbers
+// in some responses and strings in others.
+type commentJSON struct {
+	ID          json.Number `json:"id"`
+	CommentText string      `json:"comment_text"`
+	User        struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Date json.Number `json:"date"`
+}
+
+func (c commentJSON) toComment() Comment {
+	ms, _ := c.Date.Int64()
+	return Comment{ID: c.ID.String(), Text: c.CommentText, Username: c.User.Username, Date: time.UnixMilli(ms).UTC()}
+}
+
+// commentsResponse is the API response for listing task comments.
+type commentsResponse struct {
+	Comments []commentJSON `json:"comments"`
+}
+
 // CustomItem represents a custom task type in ClickUp.
 type CustomItem struct {
 	ID          int    `json:"id"`
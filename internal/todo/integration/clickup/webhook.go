@@ -0,0 +1,158 @@
+package clickup
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WebhookSignatureHeader carries the hex HMAC-SHA256 of a webhook delivery's
+// body, keyed with the webhook's secret.
+const WebhookSignatureHeader = "X-Signature"
+
+// Webhook event names inbound sync handles.
+const (
+	EventTaskCreated       = "taskCreated"
+	EventTaskUpdated       = "taskUpdated"
+	EventTaskStatusUpdated = "taskStatusUpdated"
+	EventTaskCommentPosted = "taskCommentPosted"
+)
+
+// VerifyWebhookSignature reports whether signature, the X-Signature header,
+// was made over body with secret.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// WebhookUser is the user a history item is attributed to.
+type WebhookUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// WebhookComment is the comment a taskCommentPosted history item carries.
+type WebhookComment struct {
+	ID          string `json:"id"`
+	TextContent string `json:"text_content"`
+}
+
+// WebhookHistoryItem is one change recorded in a webhook delivery. Before
+// and After hold a string for name changes and an object with a status key
+// for status changes.
+type WebhookHistoryItem struct {
+	ID      string          `json:"id"`
+	Field   string          `json:"field"`
+	Before  json.RawMessage `json:"before,omitempty"`
+	After   json.RawMessage `json:"after,omitempty"`
+	User    WebhookUser     `json:"user"`
+	Comment *WebhookComment `json:"comment,omitempty"`
+}
+
+// WebhookEvent is a ClickUp webhook delivery.
+type WebhookEvent struct {
+	Event        string               `json:"event"`
+	TaskID       string               `json:"task_id"`
+	WebhookID    string               `json:"webhook_id"`
+	HistoryItems []WebhookHistoryItem `json:"history_items"`
+}
+
+// ParseWebhookEvent decodes a webhook delivery. It returns nil for events
+// inbound sync ignores.
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var e WebhookEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return nil, fmt.Errorf("parsing webhook event: %w", err)
+	}
+	switch e.Event {
+	case EventTaskCreated, EventTaskUpdated, EventTaskStatusUpdated, EventTaskCommentPosted:
+	default:
+		return nil, nil
+	}
+	if e.TaskID == "" {
+		return nil, fmt.Errorf("%s event has no task_id", e.Event)
+	}
+	return &e, nil
+}
+
+// DeliveryID identifies the delivery for replay protection. ClickUp sends
+// no delivery header, but history item IDs are unique per change.
+func (e *WebhookEvent) DeliveryID() string {
+	ids := make([]string, 0, len(e.HistoryItems))
+	for _, item := range e.HistoryItems {
+		if item.ID != "" {
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	return e.Event + ":" + strings.Join(ids, ",")
+}
+
+// Name returns the task's new name when the delivery renames it.
+func (e *WebhookEvent) Name() (string, bool) {
+	for _, item := range slices.Backward(e.HistoryItems) {
+		var name string
+		if item.Field == "name" && json.Unmarshal(item.After, &name) == nil && name != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Status returns the task's new ClickUp status when the delivery changes it.
+func (e *WebhookEvent) Status() (string, bool) {
+	for _, item := range slices.Backward(e.HistoryItems) {
+		var after struct {
+			Status string `json:"status"`
+		}
+		if item.Field == "status" && json.Unmarshal(item.After, &after) == nil && after.Status != "" {
+			return after.Status, true
+		}
+	}
+	return "", false
+}
+
+// Comments returns the text and author of each comment the delivery posts.
+func (e *WebhookEvent) Comments() (texts, authors []string) {
+	for _, item := range e.HistoryItems {
+		if item.Comment == nil || strings.TrimSpace(item.Comment.TextContent) == "" {
+			continue
+		}
+		texts = append(texts, item.Comment.TextContent)
+		authors = append(authors, cmp.Or(item.User.Username, fmt.Sprint(item.User.ID)))
+	}
+	return texts, authors
+}
+
+// LocalStatus maps a ClickUp status back to an issue status through the
+// status mapping, ignoring case. An issue whose current status already maps
+// to status keeps it; otherwise the first (by name) of the statuses mapped
+// to it is used. It returns false when no status maps to status.
+func (c *Config) LocalStatus(status, current string) (string, bool) {
+	mapping := c.GetStatusMapping()
+	if strings.EqualFold(mapping[current], status) {
+		return current, true
+	}
+	for _, local := range slices.Sorted(maps.Keys(mapping)) {
+		if strings.EqualFold(mapping[local], status) {
+			return local, true
+		}
+	}
+	return "", false
+}
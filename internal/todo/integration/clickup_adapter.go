@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,8 +22,9 @@ import (
 
 // clickUpIntegration implements Integration for ClickUp.
 type clickUpIntegration struct {
-	cfg  *clickup.Config
-	core *core.Core
+	cfg    *clickup.Config
+	core   *core.Core
+	filter *SyncFilter
 }
 
 func newClickUpIntegration(cfg *clickup.Config, c *core.Core) *clickUpIntegration {
@@ -34,10 +37,16 @@ func detectClickUp(cfgMap map[string]any, c *core.Core) (Integration, error) {
 	if err != nil {
 		return nil, err
 	}
-	if cfg != nil {
-		return newClickUpIntegration(cfg, c), nil
+	if cfg == nil {
+		return nil, nil
 	}
-	return nil, nil
+	filter, err := ParseSyncFilter(clickup.SyncName, cfgMap)
+	if err != nil {
+		return nil, err
+	}
+	integ := newClickUpIntegration(cfg, c)
+	integ.filter = filter
+	return integ, nil
 }
 
 func (cu *clickUpIntegration) Name() string { return "clickup" }
@@ -53,11 +62,17 @@ func (cu *clickUpIntegration) getToken() (string, error) {
 func (cu *clickUpIntegration) Sync(ctx context.Context, issues []*issue.Issue, opts SyncOptions) ([]SyncResult, error) {
 	token, err := cu.getToken()
 	if err != nil {
-		return nil, err
+		return nil, providerError(cu.Name(), err)
 	}
 
 	client := clickup.NewClient(token)
 
+	issues, refused := withoutEncrypted(issues, allowEncryptedSync(cu.core))
+	issues, skipped := withFilter(issues, cu.filter)
+	refused = append(refused, skipped...)
+	issues, skipped = withoutInternal(issues, opts.IncludeInternal, true)
+	refused = append(refused, skipped...)
+
 	// Create sync state provider from issue sync metadata
 	syncProvider := clickup.NewSyncStateStore(cu.core, issues)
 
@@ -67,7 +82,7 @@ func (cu *clickUpIntegration) Sync(ctx context.Context, issues []*issue.Issue, o
 	// Pre-filter to issues that actually need syncing
 	toSync := syncutil.FilterIssuesNeedingSync(filtered, syncProvider, opts.Force)
 	if len(toSync) == 0 {
-		return nil, nil
+		return sortResults(refused), nil
 	}
 
 	// Convert integration progress callback to clickup progress callback
@@ -84,6 +99,8 @@ func (cu *clickUpIntegration) Sync(ctx context.Context, issues []*issue.Issue, o
 		Force:           opts.Force,
 		NoRelationships: opts.NoRelationships,
 		ListID:          cu.cfg.ListID,
+		Concurrency:     cu.cfg.Concurrency,
+		FailFast:        opts.FailFast,
 		OnProgress:      clickupProgress,
 	}
 
@@ -92,41 +109,46 @@ func (cu *clickUpIntegration) Sync(ctx context.Context, issues []*issue.Issue, o
 	// Run sync
 	clickupResults, err := syncer.SyncIssues(ctx, toSync)
 	if err != nil {
-		return nil, fmt.Errorf("sync failed: %w", err)
+		return nil, providerError(cu.Name(), fmt.Errorf("sync failed: %w", err))
 	}
 
 	// Convert results
-	results := make([]SyncResult, len(clickupResults))
-	for i, r := range clickupResults {
-		results[i] = convertClickUpResult(r)
+	results := make([]SyncResult, 0, len(refused)+len(clickupResults))
+	results = append(results, refused...)
+	for _, r := range clickupResults {
+		results = append(results, convertClickUpResult(r))
 	}
 
 	// Flush sync state to issue sync metadata
 	if !opts.DryRun {
 		if flushErr := syncProvider.Flush(); flushErr != nil {
-			return results, fmt.Errorf("saving sync state: %w", flushErr)
+			return sortResults(results), fmt.Errorf("saving sync state: %w", flushErr)
 		}
 	}
 
-	return results, nil
+	return sortResults(results), nil
 }
 
 // convertClickUpResult converts a clickup.SyncResult to an integration.SyncResult.
 func convertClickUpResult(r clickup.SyncResult) SyncResult {
 	return SyncResult{
-		IssueID:     r.IssueID,
-		IssueTitle:  r.IssueTitle,
-		ExternalID:  r.TaskID,
-		ExternalURL: r.TaskURL,
-		Action:      r.Action,
-		Error:       r.Error,
+		IssueID:       r.IssueID,
+		IssueTitle:    r.IssueTitle,
+		ExternalID:    r.TaskID,
+		ExternalURL:   r.TaskURL,
+		Action:        r.Action,
+		Error:         r.Error,
+		Warnings:      r.Warnings,
+		Changes:       r.Changes,
+		LabelsAdded:   r.LabelsAdded,
+		LabelsRemoved: r.LabelsRemoved,
 	}
 }
 
 func (cu *clickUpIntegration) Link(ctx context.Context, issueID, taskID string) (*LinkResult, error) {
-	b, err := cu.core.Get(issueID)
+	b, err := linkTarget(cu.core, issueID)
 	if err != nil {
-		return nil, fmt.Errorf("issue not found: %s", issueID)
+		return nil, err
 	}
 
 	// Check if already linked to this task
@@ -158,9 +180,9 @@ func (cu *clickUpIntegration) Link(ctx context.Context, issueID, taskID string)
 }
 
 func (cu *clickUpIntegration) Unlink(ctx context.Context, issueID string) (*UnlinkResult, error) {
-	b, err := cu.core.Get(issueID)
+	b, err := linkTarget(cu.core, issueID)
 	if err != nil {
-		return nil, fmt.Errorf("issue not found: %s", issueID)
+		return nil, err
 	}
 
 	// Check if linked
@@ -263,6 +285,11 @@ func (cu *clickUpIntegration) checkConfiguration(ctx context.Context, opts Check
 						Message: "Not configured",
 					})
 				}
+
+				// Check field mapping targets exist on the list
+				if len(cu.cfg.FieldMapping) > 0 {
+					section.Checks = append(section.Checks, cu.checkFieldMappingTargets(ctx, client)...)
+				}
 			}
 		}
 	}
@@ -307,6 +334,8 @@ func (cu *clickUpIntegration) checkConfiguration(ctx context.Context, opts Check
 		})
 	}
 
+	section.Checks = append(section.Checks, checkSyncFilter(cu.filter, cu.core)...)
+
 	return section
 }
 
@@ -386,6 +415,9 @@ func (cu *clickUpIntegration) checkMappingKeys() []CheckResult {
 		})
 	}
 
+	// Check field mapping translations cover the project's values
+	results = append(results, cu.checkFieldMappingCoverage()...)
+
 	// Check sync filter exclude_status values are valid
 	if cu.cfg.SyncFilter != nil {
 		var unknownFilterStatuses []string
@@ -524,6 +556,126 @@ func (cu *clickUpIntegration) checkCustomFields(ctx context.Context, client *cli
 	return results
 }
 
+// checkFieldMappingCoverage verifies that dropdown translation tables cover
+// every value the project defines for the mapped field.
+func (cu *clickUpIntegration) checkFieldMappingCoverage() []CheckResult {
+	var results []CheckResult
+	projectCfg := cu.core.Config()
+
+	for _, field := range slices.Sorted(maps.Keys(cu.cfg.FieldMapping)) {
+		fm := cu.cfg.FieldMapping[field]
+		if fm.Kind != clickup.FieldKindDropdown {
+			continue
+		}
+
+		var known []string
+		switch field {
+		case "priority":
+			known = projectCfg.PriorityNames()
+		case "type":
+			known = projectCfg.TypeNames()
+		case "status":
+			known = projectCfg.StatusNames()
+		default:
+			continue // tags are open-ended
+		}
+
+		var missing []string
+		for _, v := range known {
+			if _, ok := fm.Values[v]; !ok {
+				missing = append(missing, v)
+			}
+		}
+		name := fmt.Sprintf("Field mapping %s coverage", field)
+		if len(missing) > 0 {
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckWarn,
+				Message: fmt.Sprintf("No translation for: %v (those issues will sync without this field)", missing),
+			})
+		} else {
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckPass,
+				Message: fmt.Sprintf("All %d values translated", len(known)),
+			})
+		}
+	}
+
+	return results
+}
+
+// checkFieldMappingTargets verifies that each mapped field ID exists on the
+// list with a compatible type, and that dropdown translations name real options.
+func (cu *clickUpIntegration) checkFieldMappingTargets(ctx context.Context, client *clickup.Client) []CheckResult {
+	fields, err := client.GetAccessibleCustomFields(ctx, cu.cfg.ListID)
+	if err != nil {
+		return []CheckResult{{
+			Name:    "Field mapping valid",
+			Status:  CheckWarn,
+			Message: fmt.Sprintf("Cannot fetch fields: %v", err),
+		}}
+	}
+
+	byID := make(map[string]clickup.FieldInfo, len(fields))
+	for _, f := range fields {
+		byID[f.ID] = f
+	}
+
+	var results []CheckResult
+	for _, field := range slices.Sorted(maps.Keys(cu.cfg.FieldMapping)) {
+		fm := cu.cfg.FieldMapping[field]
+		name := fmt.Sprintf("Field mapping %s", field)
+
+		info, ok := byID[fm.FieldID]
+		if !ok {
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckFail,
+				Message: fmt.Sprintf("Unknown field UUID: %s", fm.FieldID),
+			})
+			continue
+		}
+		if !clickup.FieldKindMatches(fm.Kind, info.Type) {
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckFail,
+				Message: fmt.Sprintf("%q is a %s field, not %s", info.Name, info.Type, fm.Kind),
+			})
+			continue
+		}
+
+		if fm.Kind == clickup.FieldKindDropdown {
+			options := make(map[string]bool)
+			for _, opt := range clickup.DropdownOptions(info.TypeConfig) {
+				options[opt.ID] = true
+			}
+			var unknown []string
+			for _, v := range slices.Sorted(maps.Keys(fm.Values)) {
+				if !options[fm.Values[v]] {
+					unknown = append(unknown, v)
+				}
+			}
+			if len(unknown) > 0 {
+				results = append(results, CheckResult{
+					Name:    name,
+					Status:  CheckFail,
+					Message: fmt.Sprintf("Translations name unknown %q options: %v", info.Name, unknown),
+				})
+				continue
+			}
+		}
+
+		results = append(results, CheckResult{
+			Name:    name,
+			Status:  CheckPass,
+			Message: fmt.Sprintf("%s (%s)", info.Name, info.Type),
+		})
+	}
+
+	return results
+}
+
 func (cu *clickUpIntegration) checkClickUpIntegration(ctx context.Context, opts CheckOptions) CheckSection {
 	section := CheckSection{
 		Name:   "ClickUp Integration",
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -75,6 +76,30 @@ func TestClickUpIntegration_Unlink_IssueNotFound(t *testing.T) {
 	}
 }
 
+func TestClickUpIntegration_LinkArchived(t *testing.T) {
+	t.Setenv("CLICKUP_TOKEN", "")
+	cfg := config.Default()
+	c := core.New(t.TempDir(), cfg)
+	b := &issue.Issue{ID: "arc-1", Title: "Archived", Status: "completed"}
+	if err := c.Create(b); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	if err := c.Archive(b.ID); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+
+	cu := newClickUpIntegration(&clickup.Config{ListID: "123"}, c)
+	if _, err := cu.Link(context.Background(), b.ID, "abc123"); !errors.Is(err, core.ErrArchived) {
+		t.Errorf("Link() error = %v, want ErrArchived", err)
+	}
+	if _, err := cu.Unlink(context.Background(), b.ID); !errors.Is(err, core.ErrArchived) {
+		t.Errorf("Unlink() error = %v, want ErrArchived", err)
+	}
+	if b.Sync != nil {
+		t.Errorf("archived issue sync = %v, want none", b.Sync)
+	}
+}
+
 func TestClickUpIntegration_Unlink_NotLinked(t *testing.T) {
 	t.Setenv("CLICKUP_TOKEN", "")
 	cfg := config.Default()
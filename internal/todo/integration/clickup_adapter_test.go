@@ -176,3 +176,32 @@ func TestCheckMappingKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFieldMappingCoverage(t *testing.T) {
+	cfg := config.Default()
+	c := core.New(t.TempDir(), cfg)
+
+	full := make(map[string]string)
+	for _, p := range cfg.PriorityNames() {
+		full[p] = "opt-" + p
+	}
+	cu := newClickUpIntegration(&clickup.Config{
+		ListID: "123",
+		FieldMapping: map[string]*clickup.FieldMapping{
+			"priority": {FieldID: "sev", Kind: clickup.FieldKindDropdown, Values: full},
+			"type":     {FieldID: "team", Kind: clickup.FieldKindDropdown, Values: map[string]string{"bug": "opt-bug"}},
+			"tags":     {FieldID: "labels", Kind: clickup.FieldKindDropdown, Values: map[string]string{"fe": "opt-fe"}},
+		},
+	}, c)
+
+	results := cu.checkFieldMappingCoverage()
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2 (tags are not checked): %+v", len(results), results)
+	}
+	if results[0].Name != "Field mapping priority coverage" || results[0].Status != CheckPass {
+		t.Errorf("priority coverage = %+v, want pass", results[0])
+	}
+	if results[1].Status != CheckWarn || !strings.Contains(results[1].Message, "task") {
+		t.Errorf("type coverage = %+v, want warn listing missing types", results[1])
+	}
+}
@@ -0,0 +1,178 @@
+package integration
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// SyncFilter selects the issues a provider syncs. It is read from the
+// filter key of the provider's sync section:
+//
+//	sync:
+//	  github:
+//	    repo: owner/repo
+//	    filter:
+//	      include_types: [bug, feature]
+//	      exclude_tags: [internal]
+//
+// An empty list does not restrict; an issue must pass every non-empty one.
+type SyncFilter struct {
+	IncludeTypes    []string
+	IncludeStatuses []string
+	ExcludeTags     []string
+}
+
+// Skip reasons reported on ActionSkipped results.
+const (
+	SkipReasonType   = "excluded by type filter"
+	SkipReasonStatus = "excluded by status filter"
+	SkipReasonTag    = "excluded by tag filter"
+
+	SkipReasonInternal        = "internal issue (pass --include-internal to sync it)"
+	SkipReasonInternalRefused = "internal issue (this provider needs allow_internal: true to push it)"
+)
+
+// ParseSyncFilter reads the filter key of a provider's sync section. It
+// returns nil when there is none, and an error naming the setting when a
+// key is unknown or not a list of strings.
+func ParseSyncFilter(provider string, cfgMap map[string]any) (*SyncFilter, error) {
+	v, ok := cfgMap["filter"]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("sync.%s.filter: must be a mapping", provider)
+	}
+
+	f := &SyncFilter{}
+	for key, val := range m {
+		var dst *[]string
+		switch key {
+		case "include_types":
+			dst = &f.IncludeTypes
+		case "include_statuses":
+			dst = &f.IncludeStatuses
+		case "exclude_tags":
+			dst = &f.ExcludeTags
+		default:
+			return nil, fmt.Errorf("sync.%s.filter: unknown key %q (must be include_types, include_statuses, or exclude_tags)", provider, key)
+		}
+		items, ok := val.([]any)
+		if !ok {
+			return nil, fmt.Errorf("sync.%s.filter.%s: must be a list", provider, key)
+		}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("sync.%s.filter.%s: %v is not a string", provider, key, item)
+			}
+			*dst = append(*dst, s)
+		}
+	}
+	for i, tag := range f.ExcludeTags {
+		f.ExcludeTags[i] = issue.NormalizeTag(tag)
+	}
+	return f, nil
+}
+
+// SkipReason returns why f leaves b out of a sync, or "" when b passes.
+func (f *SyncFilter) SkipReason(b *issue.Issue) string {
+	switch {
+	case f == nil:
+		return ""
+	case len(f.IncludeTypes) > 0 && !slices.Contains(f.IncludeTypes, b.Type):
+		return SkipReasonType
+	case len(f.IncludeStatuses) > 0 && !slices.Contains(f.IncludeStatuses, b.Status):
+		return SkipReasonStatus
+	case slices.ContainsFunc(f.ExcludeTags, b.HasTag):
+		return SkipReasonTag
+	}
+	return ""
+}
+
+// withFilter removes the issues f leaves out of a sync batch, returning a
+// skipped result with the reason for each one.
+func withFilter(issues []*issue.Issue, f *SyncFilter) ([]*issue.Issue, []SyncResult) {
+	if f == nil {
+		return issues, nil
+	}
+	var kept []*issue.Issue
+	var skipped []SyncResult
+	for _, b := range issues {
+		reason := f.SkipReason(b)
+		if reason == "" {
+			kept = append(kept, b)
+			continue
+		}
+		skipped = append(skipped, SyncResult{
+			IssueID:    b.ID,
+			IssueTitle: b.Title,
+			Action:     ActionSkipped,
+			Reason:     reason,
+		})
+	}
+	return kept, skipped
+}
+
+// checkSyncFilter reports filter values that name no known type, status, or
+// tag, and warns when the filter leaves out every issue.
+func checkSyncFilter(f *SyncFilter, c *core.Core) []CheckResult {
+	if f == nil {
+		return nil
+	}
+	cfg := c.Config()
+	all := c.AllUnordered()
+
+	tags := make(map[string]bool)
+	for _, b := range all {
+		for _, tag := range b.Tags {
+			tags[issue.NormalizeTag(tag)] = true
+		}
+	}
+
+	var unknown []string
+	for _, t := range f.IncludeTypes {
+		if !cfg.IsValidType(t) {
+			unknown = append(unknown, "type "+t)
+		}
+	}
+	for _, s := range f.IncludeStatuses {
+		if !cfg.IsValidStatus(s) {
+			unknown = append(unknown, "status "+s)
+		}
+	}
+	for _, tag := range f.ExcludeTags {
+		if !tags[tag] {
+			unknown = append(unknown, "tag "+tag)
+		}
+	}
+
+	var results []CheckResult
+	if len(unknown) > 0 {
+		results = append(results, CheckResult{
+			Name:    "Sync filter values known",
+			Status:  CheckWarn,
+			Message: "No such " + strings.Join(unknown, ", "),
+		})
+	} else {
+		results = append(results, CheckResult{
+			Name:    "Sync filter values known",
+			Status:  CheckPass,
+			Message: "All filter values exist",
+		})
+	}
+
+	if kept, _ := withFilter(all, f); len(kept) == 0 && len(all) > 0 {
+		results = append(results, CheckResult{
+			Name:    "Sync filter matches issues",
+			Status:  CheckWarn,
+			Message: fmt.Sprintf("Filter excludes all %d issues", len(all)),
+		})
+	}
+	return results
+}
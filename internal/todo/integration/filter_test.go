@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestSyncFilterSkipReason(t *testing.T) {
+	bug := &issue.Issue{ID: "b1", Type: "bug", Status: "ready"}
+	feature := &issue.Issue{ID: "f1", Type: "feature", Status: "in-progress", Tags: []string{"internal"}}
+	task := &issue.Issue{ID: "t1", Type: "task", Status: "completed"}
+
+	tests := []struct {
+		name   string
+		filter *SyncFilter
+		issue  *issue.Issue
+		want   string
+	}{
+		{"no filter", nil, task, ""},
+		{"empty filter", &SyncFilter{}, task, ""},
+		{"type included", &SyncFilter{IncludeTypes: []string{"bug", "feature"}}, bug, ""},
+		{"type excluded", &SyncFilter{IncludeTypes: []string{"bug", "feature"}}, task, SkipReasonType},
+		{"status included", &SyncFilter{IncludeStatuses: []string{"ready"}}, bug, ""},
+		{"status excluded", &SyncFilter{IncludeStatuses: []string{"ready"}}, task, SkipReasonStatus},
+		{"tag excluded", &SyncFilter{ExcludeTags: []string{"internal"}}, feature, SkipReasonTag},
+		{"tag not present", &SyncFilter{ExcludeTags: []string{"internal"}}, bug, ""},
+		{"type checked first", &SyncFilter{IncludeTypes: []string{"bug"}, ExcludeTags: []string{"internal"}}, feature, SkipReasonType},
+		{"all pass", &SyncFilter{IncludeTypes: []string{"feature"}, IncludeStatuses: []string{"in-progress"}, ExcludeTags: []string{"secret"}}, feature, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.SkipReason(tt.issue); got != tt.want {
+				t.Errorf("SkipReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSyncFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     map[string]any
+		want    *SyncFilter
+		wantErr string
+	}{
+		{"absent", map[string]any{"repo": "o/r"}, nil, ""},
+		{
+			"all keys",
+			map[string]any{"filter": map[string]any{
+				"include_types":    []any{"bug", "feature"},
+				"include_statuses": []any{"ready"},
+				"exclude_tags":     []any{"Internal"},
+			}},
+			&SyncFilter{IncludeTypes: []string{"bug", "feature"}, IncludeStatuses: []string{"ready"}, ExcludeTags: []string{"internal"}},
+			"",
+		},
+		{"not a mapping", map[string]any{"filter": "bug"}, nil, "sync.github.filter: must be a mapping"},
+		{"unknown key", map[string]any{"filter": map[string]any{"types": []any{"bug"}}}, nil, `unknown key "types"`},
+		{"not a list", map[string]any{"filter": map[string]any{"include_types": "bug"}}, nil, "include_types: must be a list"},
+		{"not a string", map[string]any{"filter": map[string]any{"exclude_tags": []any{1}}}, nil, "1 is not a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSyncFilter("github", tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSyncFilter() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSyncFilter() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSyncFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithFilter(t *testing.T) {
+	bug := &issue.Issue{ID: "b1", Title: "Bug", Type: "bug"}
+	task := &issue.Issue{ID: "t1", Title: "Task", Type: "task"}
+
+	kept, skipped := withFilter([]*issue.Issue{bug, task}, &SyncFilter{IncludeTypes: []string{"bug"}})
+	if len(kept) != 1 || kept[0] != bug {
+		t.Errorf("kept = %v, want only the bug", kept)
+	}
+	if len(skipped) != 1 || skipped[0].IssueID != "t1" || skipped[0].Action != ActionSkipped || skipped[0].Reason != SkipReasonType {
+		t.Errorf("skipped = %+v, want t1 skipped by type", skipped)
+	}
+}
+
+func TestDetectParsesSyncFilter(t *testing.T) {
+	c := core.New(t.TempDir(), config.Default())
+	integ, err := Detect(map[string]map[string]any{
+		"github": {"repo": "o/r", "filter": map[string]any{"include_types": []any{"bug"}}},
+	}, c)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if f := integ.(*gitHubIntegration).filter; f == nil || len(f.IncludeTypes) != 1 {
+		t.Errorf("filter = %+v, want include_types [bug]", f)
+	}
+
+	if _, err := Detect(map[string]map[string]any{
+		"clickup": {"list_id": "1", "filter": map[string]any{"bogus": []any{}}},
+	}, c); err == nil {
+		t.Error("Detect() should reject an unknown filter key")
+	}
+}
+
+func TestCheckSyncFilter(t *testing.T) {
+	c := core.New(t.TempDir(), config.Default())
+	for _, b := range []*issue.Issue{
+		{ID: "t1", Title: "Task", Type: "task", Status: "ready", Tags: []string{"internal"}},
+		{ID: "t2", Title: "Task", Type: "task", Status: "ready"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := checkSyncFilter(&SyncFilter{IncludeTypes: []string{"task"}, ExcludeTags: []string{"internal"}}, c)
+	if len(results) != 1 || results[0].Status != CheckPass {
+		t.Errorf("known values: %+v, want one pass", results)
+	}
+
+	results = checkSyncFilter(&SyncFilter{IncludeTypes: []string{"bug", "chore"}, IncludeStatuses: []string{"doing"}, ExcludeTags: []string{"nope"}}, c)
+	if len(results) != 2 || results[0].Status != CheckWarn || results[1].Status != CheckWarn {
+		t.Fatalf("unknown values: %+v, want two warnings", results)
+	}
+	for _, want := range []string{"type chore", "status doing", "tag nope"} {
+		if !strings.Contains(results[0].Message, want) {
+			t.Errorf("message %q missing %q", results[0].Message, want)
+		}
+	}
+	if strings.Contains(results[0].Message, "type bug") {
+		t.Errorf("message %q flags a configured type", results[0].Message)
+	}
+	if !strings.Contains(results[1].Message, "excludes all 2 issues") {
+		t.Errorf("message %q, want a warning that nothing matches", results[1].Message)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/toba/jig/internal/todo/integration/syncutil"
@@ -14,6 +15,9 @@ import (
 
 const baseURL = "https://api.github.com"
 
+// graphqlURL is the GraphQL endpoint, for the operations REST lacks.
+const graphqlURL = baseURL + "/graphql"
+
 // Default retry configuration for rate limit handling
 const (
 	defaultMaxRetries     = 5
@@ -59,7 +63,8 @@ type Client struct {
 
 	// Cached authenticated user
 	authenticatedUser *User
-	// Cached labels (label name -> true)
+	// Cached labels (label name -> true), written by concurrent EnsureLabel calls
+	labelMu    sync.Mutex
 	labelCache map[string]bool
 }
 
@@ -155,6 +160,78 @@ func (c *Client) UpdateIssue(ctx context.Context, number int, update *UpdateIssu
 	return &resp, nil
 }
 
+// DeleteIssue permanently deletes the issue with the given GraphQL node ID
+// (Issue.NodeID). REST has no delete, and GitHub allows it only for
+// repository admins.
+func (c *Client) DeleteIssue(ctx context.Context, nodeID string) error {
+	payload := map[string]any{
+		"query":     `mutation($id: ID!) { deleteIssue(input: {issueId: $id}) { clientMutationId } }`,
+		"variables": map[string]any{"id": nodeID},
+	}
+	req, err := c.newJSONRequest(ctx, "POST", graphqlURL, payload)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.doRequest(req, &resp); err != nil {
+		return fmt.Errorf("deleting issue: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("deleting issue: %s", resp.Errors[0].Message)
+	}
+
+	return nil
+}
+
+// ListComments fetches all comments on an issue, oldest first.
+func (c *Client) ListComments(ctx context.Context, number int) ([]Comment, error) {
+	var allComments []Comment
+	page := 1
+
+	for {
+		url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments?per_page=100&page=%d", baseURL, c.owner, c.repo, number, page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		var comments []Comment
+		if err := c.doRequest(req, &comments); err != nil {
+			return nil, fmt.Errorf("listing comments: %w", err)
+		}
+
+		allComments = append(allComments, comments...)
+		if len(comments) < 100 {
+			break
+		}
+		page++
+	}
+
+	return allComments, nil
+}
+
+// CreateComment posts a comment on an issue.
+func (c *Client) CreateComment(ctx context.Context, number int, body string) (*Comment, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", baseURL, c.owner, c.repo, number)
+
+	req, err := c.newJSONRequest(ctx, "POST", url, map[string]string{"body": body})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Comment
+	if err := c.doRequest(req, &resp); err != nil {
+		return nil, fmt.Errorf("creating comment: %w", err)
+	}
+
+	return &resp, nil
+}
+
 // GetAuthenticatedUser fetches the user associated with the API token.
 // Results are cached for the lifetime of the client.
 func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
@@ -218,10 +295,7 @@ func (c *Client) CreateLabel(ctx context.Context, name, color string) (*Label, e
 		return nil, fmt.Errorf("creating label: %w", err)
 	}
 
-	if c.labelCache != nil {
-		c.labelCache[name] = true
-	}
-
+	c.cacheLabel(name)
 	return &resp, nil
 }
 
@@ -232,6 +306,8 @@ func (c *Client) PopulateLabelCache(ctx context.Context) error {
 		return err
 	}
 
+	c.labelMu.Lock()
+	defer c.labelMu.Unlock()
 	c.labelCache = make(map[string]bool, len(labels))
 	for _, l := range labels {
 		c.labelCache[l.Name] = true
@@ -240,20 +316,31 @@ func (c *Client) PopulateLabelCache(ctx context.Context) error {
 	return nil
 }
 
+// cacheLabel records that a label exists in the repository.
+func (c *Client) cacheLabel(name string) {
+	c.labelMu.Lock()
+	defer c.labelMu.Unlock()
+	if c.labelCache == nil {
+		c.labelCache = make(map[string]bool)
+	}
+	c.labelCache[name] = true
+}
+
 // EnsureLabel creates a label if it doesn't exist in the cache.
 func (c *Client) EnsureLabel(ctx context.Context, name, color string) error {
-	if c.labelCache != nil && c.labelCache[name] {
+	c.labelMu.Lock()
+	cached := c.labelCache[name]
+	c.labelMu.Unlock()
+	if cached {
 		return nil
 	}
 
 	_, err := c.CreateLabel(ctx, name, color)
 	if err != nil {
-		// 422 means label already exists (race or cache miss)
-		if strings.Contains(err.Error(), "422") || strings.Contains(err.Error(), "already_exists") {
-			if c.labelCache == nil {
-				c.labelCache = make(map[string]bool)
-			}
-			c.labelCache[name] = true
+		// 409, or 422 with code already_exists, means the label already
+		// exists (race or cache miss)
+		if syncutil.IsAlreadyExists(err) {
+			c.cacheLabel(name)
 			return nil
 		}
 		return err
@@ -487,6 +574,15 @@ func (c *Client) doRequest(req *http.Request, result any) error {
 		HandleAPIError: func(statusCode int, body []byte) error {
 			var errResp errorResponse
 			if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+				var codes []string
+				for _, e := range errResp.Errors {
+					if e.Code != "" {
+						codes = append(codes, e.Code)
+					}
+				}
+				if len(codes) > 0 {
+					return fmt.Errorf("API error (HTTP %d): %s (%s)", statusCode, errResp.Message, strings.Join(codes, ", "))
+				}
 				return fmt.Errorf("API error (HTTP %d): %s", statusCode, errResp.Message)
 			}
 			return nil
@@ -3,6 +3,10 @@ package github
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
 )
 
 // Sync metadata constants for GitHub.
@@ -11,8 +15,37 @@ const (
 	SyncKeyIssueNumber     = "issue_number"
 	SyncKeySyncedAt        = "synced_at"
 	SyncKeyMilestoneNumber = "milestone_number"
+	SyncKeyPRs             = "prs"
+	SyncKeyPRStates        = "pr_states"
+	SyncKeyLabels          = "labels"
 )
 
+// SyncSchema describes the sync data GitHub keeps on an issue. No key is
+// required: an issue may only have linked pull requests.
+var SyncSchema = syncutil.Schema{
+	Provider: SyncName,
+	Keys: map[string]syncutil.ValueKind{
+		SyncKeyIssueNumber:     syncutil.KindNumber,
+		SyncKeySyncedAt:        syncutil.KindTime,
+		SyncKeyMilestoneNumber: syncutil.KindNumber,
+		SyncKeyPRs:             syncutil.KindList,
+		SyncKeyPRStates:        syncutil.KindMap,
+		SyncKeyLabels:          syncutil.KindList,
+
+		syncutil.SyncKeyComments:       syncutil.KindMap,
+		syncutil.SyncKeyPulledComments: syncutil.KindList,
+	},
+	LinkKey: SyncKeyIssueNumber,
+}
+
+// DefaultLabelColor is the color of labels sync creates when no palette is
+// configured.
+const DefaultLabelColor = "ededed"
+
+// DefaultPRStateTTL is how long a fetched pull request state counts as
+// current before it is shown as stale.
+const DefaultPRStateTTL = 24 * time.Hour
+
 // GitHub issue states.
 const (
 	StateOpen   = "open"
@@ -26,6 +59,39 @@ const TodoCommentFormat = "<!-- todo:%s -->"
 type Config struct {
 	Owner string // Repository owner
 	Repo  string // Repository name
+
+	// DetectPRs links pull requests whose branch or body references an
+	// issue during sync (sync.github.detect_prs, default true).
+	DetectPRs bool
+	// PRStateTTL is how long a fetched pull request state stays current
+	// (sync.github.pr_state_ttl, default 24h).
+	PRStateTTL time.Duration
+	// AllowInternal lets sync push internal issues when --include-internal
+	// is given (sync.github.allow_internal, default false).
+	AllowInternal bool
+	// Concurrency is how many issues sync pushes at once
+	// (sync.github.concurrency, default syncutil.DefaultConcurrency).
+	Concurrency int
+	// Labels sets the colors of created labels and renames tags on the
+	// way to labels (sync.github.labels).
+	Labels *syncutil.LabelConfig
+	// SandboxOwner and SandboxRepo name the scratch repository `sync
+	// smoke-test` creates and deletes an issue in (sync.github.sandbox,
+	// owner/repo). Empty when unset; sync never touches it.
+	SandboxOwner string
+	SandboxRepo  string
+	// Comments posts the issue's comments as remote comments and leaves
+	// the Comments section out of the pushed description
+	// (sync.github.comments, default false).
+	Comments bool
+	// CommentsPull also copies remote comments into the Comments section
+	// (sync.github.comments_pull, default false). It implies Comments.
+	CommentsPull bool
+}
+
+// IssueURL returns the web URL of the issue with the given number.
+func (c *Config) IssueURL(number string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%s", c.Owner, c.Repo, number)
 }
 
 // DefaultStatusMapping maps issue statuses to GitHub issue states.
@@ -69,10 +135,45 @@ func ParseConfig(cfgMap map[string]any) (*Config, error) {
 		return nil, err
 	}
 
-	return &Config{
-		Owner: owner,
-		Repo:  repo,
-	}, nil
+	cfg := &Config{
+		Owner:      owner,
+		Repo:       repo,
+		DetectPRs:  true,
+		PRStateTTL: DefaultPRStateTTL,
+	}
+	if v, ok := cfgMap["detect_prs"].(bool); ok {
+		cfg.DetectPRs = v
+	}
+	if v, ok := cfgMap["allow_internal"].(bool); ok {
+		cfg.AllowInternal = v
+	}
+	if v, ok := cfgMap["pr_state_ttl"].(string); ok {
+		ttl, err := config.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("sync.github.pr_state_ttl: %w", err)
+		}
+		cfg.PRStateTTL = ttl
+	}
+	if cfg.Concurrency, err = syncutil.ParseConcurrency(SyncName, cfgMap); err != nil {
+		return nil, err
+	}
+	if cfg.Labels, err = syncutil.ParseLabelConfig(SyncName, cfgMap); err != nil {
+		return nil, err
+	}
+	cfg.CommentsPull, _ = cfgMap["comments_pull"].(bool)
+	cfg.Comments, _ = cfgMap["comments"].(bool)
+	cfg.Comments = cfg.Comments || cfg.CommentsPull
+	if v, ok := cfgMap["sandbox"].(string); ok && v != "" {
+		if cfg.SandboxOwner, cfg.SandboxRepo, err = ParseRepo(v); err != nil {
+			return nil, fmt.Errorf("sync.github.sandbox: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// PullRequestURL returns the web URL of the pull request with the given number.
+func (c *Config) PullRequestURL(number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", c.Owner, c.Repo, number)
 }
 
 // ParseRepo splits a "owner/repo" string into owner and repo.
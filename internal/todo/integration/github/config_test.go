@@ -2,6 +2,7 @@ package github
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParseConfig(t *testing.T) {
@@ -147,3 +148,25 @@ func TestDefaultStatusMapping(t *testing.T) {
 		}
 	}
 }
+
+func TestParseConfig_PullRequests(t *testing.T) {
+	cfg, err := ParseConfig(map[string]any{"repo": "o/r"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.DetectPRs || cfg.PRStateTTL != DefaultPRStateTTL {
+		t.Errorf("defaults = detect %v, ttl %v; want true, %v", cfg.DetectPRs, cfg.PRStateTTL, DefaultPRStateTTL)
+	}
+
+	cfg, err = ParseConfig(map[string]any{"repo": "o/r", "detect_prs": false, "pr_state_ttl": "2d"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DetectPRs || cfg.PRStateTTL != 48*time.Hour {
+		t.Errorf("got detect %v, ttl %v; want false, 48h", cfg.DetectPRs, cfg.PRStateTTL)
+	}
+
+	if _, err := ParseConfig(map[string]any{"repo": "o/r", "pr_state_ttl": "soon"}); err == nil {
+		t.Error("expected error for invalid pr_state_ttl")
+	}
+}
@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// maxPRPages caps how many pages of pull requests (100 per page, most
+// recently updated first) detection scans in one sync run.
+const maxPRPages = 5
+
+// PullRequest represents a GitHub pull request.
+type PullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	Body     string     `json:"body"`
+	State    string     `json:"state"` // "open" or "closed"
+	HTMLURL  string     `json:"html_url"`
+	MergedAt *time.Time `json:"merged_at"`
+	Head     struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+// LinkState returns the state recorded for the pull request: open, merged,
+// or closed without merging.
+func (pr *PullRequest) LinkState() string {
+	switch {
+	case pr.MergedAt != nil:
+		return issue.PRStateMerged
+	case pr.State == StateClosed:
+		return issue.PRStateClosed
+	}
+	return issue.PRStateOpen
+}
+
+// GetPullRequest fetches a pull request by number.
+func (c *Client) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", baseURL, c.owner, c.repo, number)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	var resp PullRequest
+	if err := c.doRequest(req, &resp); err != nil {
+		return nil, fmt.Errorf("getting pull request: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// ListPullRequests fetches open and closed pull requests, most recently
+// updated first, up to maxPages pages of 100.
+func (c *Client) ListPullRequests(ctx context.Context, maxPages int) ([]PullRequest, error) {
+	var all []PullRequest
+	for page := 1; page <= maxPages; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/pulls?state=all&sort=updated&direction=desc&per_page=100&page=%d", baseURL, c.owner, c.repo, page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+
+		var prs []PullRequest
+		if err := c.doRequest(req, &prs); err != nil {
+			return nil, fmt.Errorf("listing pull requests: %w", err)
+		}
+
+		all = append(all, prs...)
+		if len(prs) < 100 {
+			break
+		}
+	}
+	return all, nil
+}
+
+// References reports whether the pull request's branch name or body names
+// the issue: its ID anywhere in either, or its GitHub issue number as a
+// "#123" reference in the body or a separate "123" segment of the branch.
+// issueNumber is 0 for an issue not synced to GitHub.
+func (pr *PullRequest) References(issueID string, issueNumber int) bool {
+	idPattern := regexp.MustCompile(`(?i)(^|[^a-z0-9])` + regexp.QuoteMeta(issueID) + `($|[^a-z0-9])`)
+	if idPattern.MatchString(pr.Head.Ref) || idPattern.MatchString(pr.Body) {
+		return true
+	}
+	if issueNumber == 0 {
+		return false
+	}
+	number := strconv.Itoa(issueNumber)
+	if regexp.MustCompile(`(^|[^\w/])#` + number + `\b`).MatchString(pr.Body) {
+		return true
+	}
+	segments := strings.FieldsFunc(pr.Head.Ref, func(r rune) bool {
+		return !('0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+	})
+	return slices.Contains(segments, number)
+}
+
+// RefreshOptions configures RefreshPullRequests.
+type RefreshOptions struct {
+	// Detect links pull requests that reference an issue, scanning the
+	// most recently updated ones. Otherwise only linked pull requests are
+	// fetched.
+	Detect bool
+	Now    time.Time
+}
+
+// RefreshPullRequests records the current state of the pull requests
+// linked to each issue and, with opts.Detect, links those that reference
+// it. Issues whose pull requests changed are saved without touching
+// updated_at. It returns how many issues were saved.
+func RefreshPullRequests(ctx context.Context, client *Client, c *core.Core, issues []*issue.Issue, opts RefreshOptions) (int, error) {
+	fetched := make(map[int]*PullRequest)
+	var recent []PullRequest
+	if opts.Detect {
+		prs, err := client.ListPullRequests(ctx, maxPRPages)
+		if err != nil {
+			return 0, err
+		}
+		recent = prs
+		for i := range recent {
+			fetched[recent[i].Number] = &recent[i]
+		}
+	}
+	fetch := func(number int) *PullRequest {
+		if pr, ok := fetched[number]; ok {
+			return pr
+		}
+		pr, err := client.GetPullRequest(ctx, number)
+		if err != nil {
+			pr = nil // keep the last recorded state
+		}
+		fetched[number] = pr
+		return pr
+	}
+
+	saved := 0
+	for _, stale := range issues {
+		b, err := c.Get(stale.ID)
+		if err != nil {
+			continue // deleted since the batch was read
+		}
+		links := b.GithubPullRequests()
+		changed := false
+
+		if opts.Detect {
+			number, _ := GetSyncInt(b, SyncKeyIssueNumber)
+			for _, pr := range recent {
+				linked := slices.ContainsFunc(links, func(l issue.PullRequest) bool { return l.Number == pr.Number })
+				if !linked && pr.References(b.ID, number) {
+					links = append(links, issue.PullRequest{Number: pr.Number})
+					changed = true
+				}
+			}
+		}
+
+		for i := range links {
+			pr := fetch(links[i].Number)
+			if pr == nil {
+				continue
+			}
+			state := pr.LinkState()
+			if state != links[i].State || !equalTime(pr.MergedAt, links[i].MergedAt) || links[i].IsStale(opts.Now, 0) {
+				changed = true
+			}
+			links[i].State, links[i].MergedAt, links[i].CheckedAt = state, pr.MergedAt, &opts.Now
+		}
+
+		if !changed {
+			continue
+		}
+		b.SetGithubPullRequests(links)
+		if err := c.SaveSyncOnly(b, nil); err != nil {
+			return saved, err
+		}
+		saved++
+	}
+	return saved, nil
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
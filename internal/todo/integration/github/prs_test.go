@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// newPullRequestServer serves prs from the pull request list and by number,
+// counting requests per path.
+func newPullRequestServer(t *testing.T, prs []PullRequest) (*Client, map[string]int) {
+	t.Helper()
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/pulls") {
+			_ = json.NewEncoder(w).Encode(prs)
+			return
+		}
+		for _, pr := range prs {
+			if strings.HasSuffix(r.URL.Path, "/pulls/"+strconv.Itoa(pr.Number)) {
+				_ = json.NewEncoder(w).Encode(pr)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	t.Cleanup(server.Close)
+	client := &Client{token: "test", owner: "o", repo: "r", httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}}}
+	return client, calls
+}
+
+func newPullRequestCore(t *testing.T, issues ...*issue.Issue) *core.Core {
+	t.Helper()
+	c := core.New(t.TempDir(), config.Default())
+	for _, b := range issues {
+		if err := c.Create(b); err != nil {
+			t.Fatalf("Create(%s): %v", b.ID, err)
+		}
+	}
+	return c
+}
+
+func pullRequest(number int, ref, body, state string, mergedAt *time.Time) PullRequest {
+	pr := PullRequest{Number: number, Body: body, State: state, MergedAt: mergedAt}
+	pr.Head.Ref = ref
+	return pr
+}
+
+func TestPullRequestReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		ref    string
+		body   string
+		number int
+		want   bool
+	}{
+		{"id in branch", "feature/abc-123-login", "", 0, true},
+		{"id in body", "main-fix", "Implements abc-123.", 0, true},
+		{"id as prefix of longer id", "fix/abc-1234", "", 0, false},
+		{"issue number in body", "fix", "Fixes #42", 42, true},
+		{"longer issue number in body", "fix", "Fixes #420", 42, false},
+		{"issue number in branch", "fix/42-login", "", 42, true},
+		{"number inside branch word", "fix/v42", "", 42, false},
+		{"number without github link", "fix", "Fixes #42", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := pullRequest(1, tt.ref, tt.body, StateOpen, nil)
+			if got := pr.References("abc-123", tt.number); got != tt.want {
+				t.Errorf("References() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshPullRequests_DetectsBranchReference(t *testing.T) {
+	merged := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	client, _ := newPullRequestServer(t, []PullRequest{
+		pullRequest(7, "feature/abc-123-login", "Adds login", StateClosed, &merged),
+		pullRequest(8, "feature/other", "Unrelated", StateOpen, nil),
+	})
+	c := newPullRequestCore(t, &issue.Issue{ID: "abc-123", Title: "Login", Status: "ready"})
+
+	now := time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC)
+	saved, err := RefreshPullRequests(context.Background(), client, c, c.All(), RefreshOptions{Detect: true, Now: now})
+	if err != nil {
+		t.Fatalf("RefreshPullRequests: %v", err)
+	}
+	if saved != 1 {
+		t.Errorf("saved = %d, want 1", saved)
+	}
+
+	b, _ := c.Get("abc-123")
+	prs := b.GithubPullRequests()
+	if len(prs) != 1 || prs[0].Number != 7 {
+		t.Fatalf("linked pull requests = %+v, want only #7", prs)
+	}
+	if prs[0].State != issue.PRStateMerged || prs[0].MergedAt == nil || !prs[0].MergedAt.Equal(merged) {
+		t.Errorf("pull request state = %+v, want merged at %v", prs[0], merged)
+	}
+	if prs[0].CheckedAt == nil || !prs[0].CheckedAt.Equal(now) {
+		t.Errorf("checked_at = %v, want %v", prs[0].CheckedAt, now)
+	}
+}
+
+func TestRefreshPullRequests_DetectsBodyReference(t *testing.T) {
+	client, _ := newPullRequestServer(t, []PullRequest{
+		pullRequest(11, "fix-login", "Fixes #42", StateOpen, nil),
+		pullRequest(12, "fix-other", "Fixes #420", StateOpen, nil),
+		pullRequest(13, "fix-abandoned", "Closes #42", StateClosed, nil),
+	})
+	b := &issue.Issue{ID: "abc-123", Title: "Login", Status: "ready"}
+	b.SetSync(SyncName, map[string]any{SyncKeyIssueNumber: "42"})
+	c := newPullRequestCore(t, b)
+
+	if _, err := RefreshPullRequests(context.Background(), client, c, c.All(), RefreshOptions{Detect: true, Now: time.Now()}); err != nil {
+		t.Fatalf("RefreshPullRequests: %v", err)
+	}
+
+	got, _ := c.Get("abc-123")
+	prs := got.GithubPullRequests()
+	if len(prs) != 2 || prs[0].Number != 11 || prs[1].Number != 13 {
+		t.Fatalf("linked pull requests = %+v, want #11 and #13", prs)
+	}
+	if prs[0].State != issue.PRStateOpen || prs[1].State != issue.PRStateClosed {
+		t.Errorf("states = %q, %q, want open, closed", prs[0].State, prs[1].State)
+	}
+	if n, _ := GetSyncInt(got, SyncKeyIssueNumber); n != 42 {
+		t.Errorf("issue_number = %d, want 42 kept", n)
+	}
+}
+
+func TestRefreshPullRequests_FetchesLinkedOnly(t *testing.T) {
+	client, calls := newPullRequestServer(t, []PullRequest{
+		pullRequest(5, "abc-123", "", StateOpen, nil),
+		pullRequest(6, "abc-123-more", "", StateOpen, nil),
+	})
+	b := &issue.Issue{ID: "abc-123", Title: "Login", Status: "ready"}
+	b.SetGithubPullRequests([]issue.PullRequest{{Number: 5}})
+	c := newPullRequestCore(t, b)
+
+	if _, err := RefreshPullRequests(context.Background(), client, c, c.All(), RefreshOptions{Now: time.Now()}); err != nil {
+		t.Fatalf("RefreshPullRequests: %v", err)
+	}
+	if calls["/repos/o/r/pulls"] != 0 {
+		t.Error("listed pull requests without detection")
+	}
+	got, _ := c.Get("abc-123")
+	prs := got.GithubPullRequests()
+	if len(prs) != 1 || prs[0].State != issue.PRStateOpen {
+		t.Errorf("linked pull requests = %+v, want #5 open", prs)
+	}
+}
+
+func TestSyncStateStoreFlushKeepsPullRequests(t *testing.T) {
+	b := &issue.Issue{ID: "abc-123", Title: "Login", Status: "ready"}
+	b.SetGithubPullRequests([]issue.PullRequest{{Number: 5}})
+	c := newPullRequestCore(t, b)
+
+	store := NewSyncStateStore(c, c.All())
+	store.SetIssueNumber("abc-123", 42)
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	got, _ := c.Get("abc-123")
+	if prs := got.GithubPullRequests(); len(prs) != 1 || prs[0].Number != 5 {
+		t.Errorf("pull requests after flush = %+v, want #5", prs)
+	}
+	if n, _ := GetSyncInt(got, SyncKeyIssueNumber); n != 42 {
+		t.Errorf("issue_number = %d, want 42", n)
+	}
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
@@ -34,6 +35,10 @@ type Syncer struct {
 	// Milestone tracking
 	milestoneToGHNumber map[string]int    // local milestone entity ID -> GitHub milestone number
 	issueTypes          map[string]string // local issue ID -> issue type
+
+	// Dry-run relationship diffs
+	blockersOf     map[string][]string          // local issue ID -> batch issues listing it in Blocking
+	blockedByCache map[int][]BlockingDependency // GitHub issue number -> remote blocked-by, fetched once per run
 }
 
 // NewSyncer creates a new syncer with the given client and options.
@@ -55,8 +60,8 @@ func NewSyncer(client *Client, cfg *Config, opts SyncOptions, c *core.Core, sync
 // SyncIssues syncs a list of issues to GitHub issues.
 // Uses a multi-pass approach:
 // 0. Create/update GitHub milestones from local milestone entities
-// 1. Create/update parent issues (issues without parents, or parents not in this sync)
-// 2. Create/update child issues with sub-issue relationships
+// 1. Create/update top-level issues (issues without parents, or parents not in this sync)
+// 2. Create/update child issues with sub-issue relationships, one depth at a time
 // 3. Sync native blocking/blocked-by relationships via GitHub dependencies API
 func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncResult, error) {
 	// Pre-fetch authenticated user to avoid per-issue API calls
@@ -70,7 +75,9 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 	}
 
 	// Ensure all labels that will be needed exist
-	s.ensureAllLabels(ctx, issues)
+	if !s.opts.DryRun {
+		s.ensureAllLabels(ctx, issues)
+	}
 
 	// Build type index for parent lookups
 	for _, b := range issues {
@@ -102,21 +109,19 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 			s.childrenOf[b.Parent] = append(s.childrenOf[b.Parent], b.ID)
 		}
 	}
+	if s.opts.DryRun {
+		s.blockersOf = make(map[string][]string)
+		for _, b := range issues {
+			for _, blockedID := range b.Blocking {
+				s.blockersOf[blockedID] = append(s.blockersOf[blockedID], b.ID)
+			}
+		}
+	}
 
 	// Milestones are now first-class entities (not issues). All issues sync as
 	// regular issues; milestone assignment is resolved via issue.Milestone.
 	regularIssues := issues
 
-	// Separate regular issues into layers: parents first, then children
-	var parents, children []*issue.Issue
-	for _, b := range regularIssues {
-		if b.Parent == "" || !syncingIDs[b.Parent] {
-			parents = append(parents, b)
-		} else {
-			children = append(children, b)
-		}
-	}
-
 	// Create index mapping for results
 	issueIndex := make(map[string]int)
 	for i, b := range issues {
@@ -124,11 +129,9 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 	}
 	results := make([]SyncResult, len(issues))
 	total := len(issues)
+	concurrency := cmp.Or(s.opts.Concurrency, syncutil.DefaultConcurrency)
 
 	var completed int
-	g := new(errgroup.Group)
-	g.SetLimit(10)
-
 	reportProgress := func(result SyncResult) {
 		if s.opts.OnProgress != nil {
 			s.mu.Lock()
@@ -160,26 +163,19 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 		s.syncMilestoneEntity(ctx, m)
 	}
 
-	// Pass 1: Create/update parent issues in parallel
-	for _, b := range parents {
-		g.Go(func() error {
-			syncAndTrack(b, s.syncIssue(ctx, b))
-			return nil
-		})
-	}
-	_ = g.Wait()
-
-	// Pass 2: Create/update child issues in parallel (parents now exist)
-	for _, b := range children {
-		g.Go(func() error {
-			syncAndTrack(b, s.syncIssue(ctx, b))
-			return nil
-		})
-	}
-	_ = g.Wait()
+	// Passes 1..n: Create/update issues in parallel, one parent depth at a
+	// time, so parents exist before the children that link to them.
+	finished := syncutil.RunLayers(syncutil.Layers(regularIssues), concurrency, s.opts.FailFast, func(b *issue.Issue) bool {
+		result := s.syncIssue(ctx, b)
+		s.syncComments(ctx, b, &result)
+		syncAndTrack(b, result)
+		return result.Error == nil
+	})
 
-	// Pass 3: Sync native blocking relationships (if not disabled)
-	if !s.opts.NoRelationships && !s.opts.DryRun {
+	// Last pass: Sync native blocking relationships (if not disabled)
+	if finished && !s.opts.NoRelationships && !s.opts.DryRun {
+		g := new(errgroup.Group)
+		g.SetLimit(concurrency)
 		for _, b := range regularIssues {
 			if len(b.Blocking) == 0 && len(b.BlockedBy) == 0 {
 				continue
@@ -192,6 +188,10 @@ func (s *Syncer) SyncIssues(ctx context.Context, issues []*issue.Issue) ([]SyncR
 		_ = g.Wait()
 	}
 
+	// Issues a --fail-fast stop never started have no result.
+	if !finished {
+		results = slices.DeleteFunc(results, func(r SyncResult) bool { return r.IssueID == "" })
+	}
 	return results, nil
 }
 
@@ -360,18 +360,31 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 			s.issueToGHID[b.ID] = ghIssue.ID
 			s.mu.Unlock()
 
+			plan := syncutil.PlanLabels(labelNames(ghIssue.Labels), s.syncStore.GetLabels(b.ID), labels)
+			update := s.buildUpdateRequest(ghIssue, b, body, state, ghType, plan.Labels, milestoneNumber)
+			if update.Labels != nil {
+				result.LabelsAdded, result.LabelsRemoved = plan.Added, plan.Removed
+			}
+
 			if s.opts.DryRun {
-				result.Action = syncutil.ActionWouldUpdate
+				result.Changes = updateChanges(ghIssue, update)
+				if !s.opts.NoRelationships {
+					result.Changes = append(result.Changes, s.relationshipChanges(ctx, b, *issueNumber)...)
+				}
+				if len(result.Changes) > 0 {
+					result.Action = syncutil.ActionWouldUpdate
+				} else {
+					result.Action = syncutil.ActionUnchanged
+				}
 				return result
 			}
 
-			update := s.buildUpdateRequest(ghIssue, b, body, state, ghType, labels, milestoneNumber)
-
 			if update.hasChanges() {
 				updatedIssue, err := s.client.UpdateIssue(ctx, *issueNumber, update)
 				if err != nil {
 					result.Action = syncutil.ActionError
 					result.Error = fmt.Errorf("updating issue: %w", err)
+					result.LabelsAdded, result.LabelsRemoved = nil, nil
 					return result
 				}
 				result.ExternalURL = updatedIssue.HTMLURL
@@ -383,13 +396,15 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 			// Sync sub-issue link (handles add, remove, and re-parent)
 			s.syncSubIssueLink(ctx, b, *issueNumber)
 
-			// Update synced_at timestamp
+			// Update managed labels and synced_at timestamp
+			s.syncStore.SetLabels(b.ID, plan.Managed)
 			s.syncStore.SetSyncedAt(b.ID, time.Now().UTC())
 			return result
 		}
 	}
 
 	// Create new issue
+	result.LabelsAdded = labels
 	if s.opts.DryRun {
 		result.Action = syncutil.ActionWouldCreate
 		return result
@@ -408,6 +423,7 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 	if err != nil {
 		result.Action = syncutil.ActionError
 		result.Error = fmt.Errorf("creating issue: %w", err)
+		result.LabelsAdded = nil
 		return result
 	}
 
@@ -430,14 +446,83 @@ func (s *Syncer) syncIssue(ctx context.Context, b *issue.Issue) SyncResult {
 	// Link as sub-issue if parent is synced
 	s.syncSubIssueLink(ctx, b, ghIssue.Number)
 
-	// Store issue number and sync timestamp
+	// Store issue number, managed labels, and sync timestamp
 	s.syncStore.SetIssueNumber(b.ID, ghIssue.Number)
+	s.syncStore.SetLabels(b.ID, labels)
 	s.syncStore.SetSyncedAt(b.ID, time.Now().UTC())
 
 	result.Action = syncutil.ActionCreated
 	return result
 }
 
+// syncComments posts the issue's new comments to its GitHub issue and, with
+// comments_pull, copies new GitHub comments into it, folding the outcome
+// into result. An issue skipped as unchanged has no new local comments, so
+// it is only checked for remote ones.
+func (s *Syncer) syncComments(ctx context.Context, b *issue.Issue, result *SyncResult) {
+	if !s.config.Comments || result.Error != nil || (result.Action == syncutil.ActionSkipped && !s.config.CommentsPull) {
+		return
+	}
+	number, _ := strconv.Atoi(result.ExternalID)
+	if number == 0 && !s.opts.DryRun {
+		return
+	}
+	comments, err := syncutil.SyncComments(ctx, &issueComments{client: s.client, number: number}, b.Body, s.syncStore.GetComments(b.ID), syncutil.CommentOptions{
+		Provider: SyncName,
+		Pull:     s.config.CommentsPull,
+		DryRun:   s.opts.DryRun,
+	})
+	if s.opts.DryRun {
+		result.Changes = append(result.Changes, comments.Changes()...)
+		if len(result.Changes) > 0 && (result.Action == syncutil.ActionUnchanged || result.Action == syncutil.ActionSkipped) {
+			result.Action = syncutil.ActionWouldUpdate
+		}
+		return
+	}
+	s.syncStore.SetComments(b.ID, comments.State)
+	if comments.Body != b.Body {
+		b.Body = comments.Body
+		if s.core != nil {
+			_ = s.core.Update(b, nil)
+		}
+		s.syncStore.SetSyncedAt(b.ID, time.Now().UTC())
+	}
+	if err != nil {
+		result.Action = syncutil.ActionError
+		result.Error = fmt.Errorf("syncing comments: %w", err)
+		return
+	}
+	if comments.Pushed+comments.Pulled > 0 && (result.Action == syncutil.ActionUnchanged || result.Action == syncutil.ActionSkipped) {
+		result.Action = syncutil.ActionUpdated
+	}
+}
+
+// issueComments is the syncutil.CommentAPI of one GitHub issue.
+type issueComments struct {
+	client *Client
+	number int
+}
+
+func (c *issueComments) ListComments(ctx context.Context) ([]syncutil.RemoteComment, error) {
+	comments, err := c.client.ListComments(ctx, c.number)
+	if err != nil {
+		return nil, err
+	}
+	remote := make([]syncutil.RemoteComment, len(comments))
+	for i, cm := range comments {
+		remote[i] = syncutil.RemoteComment{ID: strconv.FormatInt(cm.ID, 10), Author: cm.User.Login, Text: cm.Body, CreatedAt: cm.CreatedAt}
+	}
+	return remote, nil
+}
+
+func (c *issueComments) CreateComment(ctx context.Context, text string) (string, error) {
+	cm, err := c.client.CreateComment(ctx, c.number, text)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(cm.ID, 10), nil
+}
+
 // needsSync checks if an issue needs to be synced based on timestamps.
 func (s *Syncer) needsSync(b *issue.Issue) bool {
 	syncedAt := s.syncStore.GetSyncedAt(b.ID)
@@ -451,11 +536,20 @@ func (s *Syncer) needsSync(b *issue.Issue) bool {
 }
 
 // buildIssueBody builds the GitHub issue body from a local issue.
-// Includes the issue body and a hidden HTML comment with the issue ID.
+// Includes the summary and body and a hidden HTML comment with the issue ID.
+// With comment sync on, the body's Comments section is left out; its
+// entries are posted as comments instead.
 func (s *Syncer) buildIssueBody(b *issue.Issue) string {
 	var parts []string
-	if b.Body != "" {
-		parts = append(parts, b.Body)
+	if b.Summary != "" {
+		parts = append(parts, b.Summary)
+	}
+	body := b.Body
+	if s.config.Comments {
+		body = syncutil.StripComments(body)
+	}
+	if body != "" {
+		parts = append(parts, body)
 	}
 	parts = append(parts, syncutil.SyncFooter, fmt.Sprintf(TodoCommentFormat, b.ID))
 	return strings.Join(parts, "\n\n")
@@ -477,9 +571,19 @@ func (s *Syncer) getGitHubType(issueType string) string {
 	return ""
 }
 
-// computeLabels returns only tag-based labels for an issue.
+// computeLabels returns only tag-based labels for an issue, renamed by the
+// configured label map.
 func (s *Syncer) computeLabels(b *issue.Issue) []string {
-	return b.Tags
+	return s.config.Labels.Labels(b.Tags)
+}
+
+// labelNames returns the names of GitHub labels.
+func labelNames(labels []Label) []string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return names
 }
 
 // ensureAllLabels pre-creates all labels that will be needed.
@@ -496,7 +600,7 @@ func (s *Syncer) ensureAllLabels(ctx context.Context, issues []*issue.Issue) {
 
 	for label := range needed {
 		g.Go(func() error {
-			_ = s.client.EnsureLabel(gctx, label, "ededed") // Best-effort
+			_ = s.client.EnsureLabel(gctx, label, s.config.Labels.Color(label, DefaultLabelColor)) // Best-effort
 			return nil
 		})
 	}
@@ -554,10 +658,7 @@ func (s *Syncer) buildUpdateRequest(current *Issue, b *issue.Issue, body, state,
 	}
 
 	// Only include labels if changed
-	currentLabels := make([]string, len(current.Labels))
-	for i, l := range current.Labels {
-		currentLabels[i] = l.Name
-	}
+	currentLabels := labelNames(current.Labels)
 	sort.Strings(currentLabels)
 	sortedNew := make([]string, len(labels))
 	copy(sortedNew, labels)
@@ -591,6 +692,129 @@ func (s *Syncer) buildUpdateRequest(current *Issue, b *issue.Issue, body, state,
 	return update
 }
 
+// updateChanges lists the fields an update request would change, in the
+// order GitHub shows them. Bodies are compared by digest.
+func updateChanges(current *Issue, update *UpdateIssueRequest) []syncutil.FieldChange {
+	var changes []syncutil.FieldChange
+	if update.Title != nil {
+		changes = append(changes, syncutil.FieldChange{Field: syncutil.FieldTitle, Local: *update.Title, Remote: current.Title})
+	}
+	if update.Body != nil {
+		changes = append(changes, syncutil.FieldChange{
+			Field:  syncutil.FieldDescription,
+			Local:  syncutil.DescriptionDigest(*update.Body),
+			Remote: syncutil.DescriptionDigest(stripRelationshipLines(current.Body)),
+		})
+	}
+	if update.State != nil {
+		changes = append(changes, syncutil.FieldChange{Field: syncutil.FieldStatus, Local: *update.State, Remote: current.State})
+	}
+	if update.Labels != nil {
+		currentLabels := labelNames(current.Labels)
+		changes = append(changes, syncutil.FieldChange{
+			Field:  syncutil.FieldLabels,
+			Local:  syncutil.JoinSorted(update.Labels),
+			Remote: syncutil.JoinSorted(currentLabels),
+		})
+	}
+	if update.Type != nil {
+		currentType := ""
+		if current.Type != nil {
+			currentType = current.Type.Name
+		}
+		changes = append(changes, syncutil.FieldChange{Field: syncutil.FieldType, Local: *update.Type, Remote: currentType})
+	}
+	if update.Milestone.Set {
+		currentMilestone := 0
+		if current.Milestone != nil {
+			currentMilestone = current.Milestone.Number
+		}
+		changes = append(changes, syncutil.FieldChange{
+			Field:  syncutil.FieldMilestone,
+			Local:  issueRef(update.Milestone.Value),
+			Remote: issueRef(currentMilestone),
+		})
+	}
+	return changes
+}
+
+// relationshipChanges reports the sub-issue parent and blocked-by edges a
+// sync would change for an existing GitHub issue. It only reads remote state.
+func (s *Syncer) relationshipChanges(ctx context.Context, b *issue.Issue, ghNumber int) []syncutil.FieldChange {
+	var changes []syncutil.FieldChange
+
+	if parentType := s.issueTypes[b.Parent]; b.Parent == "" || parentType != config.TypeMilestone {
+		s.mu.RLock()
+		wantParent := s.issueToGHNumber[b.Parent]
+		s.mu.RUnlock()
+		if currentParent, err := s.client.GetParentIssue(ctx, ghNumber); err == nil {
+			currentNumber := 0
+			if currentParent != nil {
+				currentNumber = currentParent.Number
+			}
+			if currentNumber != wantParent {
+				changes = append(changes, syncutil.FieldChange{Field: syncutil.FieldParent, Local: issueRef(wantParent), Remote: issueRef(currentNumber)})
+			}
+		}
+	}
+
+	blockers := slices.Concat(b.BlockedBy, s.blockersOf[b.ID])
+	if len(blockers) == 0 && len(b.Blocking) == 0 {
+		return changes
+	}
+	var want []string
+	s.mu.RLock()
+	for _, id := range blockers {
+		if n, ok := s.issueToGHNumber[id]; ok && !slices.Contains(want, issueRef(n)) {
+			want = append(want, issueRef(n))
+		}
+	}
+	s.mu.RUnlock()
+	current, err := s.cachedBlockedBy(ctx, ghNumber)
+	if err != nil {
+		return changes
+	}
+	remote := make([]string, len(current))
+	for i, dep := range current {
+		remote[i] = issueRef(dep.Number)
+	}
+	if local, remote := syncutil.JoinSorted(want), syncutil.JoinSorted(remote); local != remote {
+		changes = append(changes, syncutil.FieldChange{Field: syncutil.FieldBlockedBy, Local: local, Remote: remote})
+	}
+	return changes
+}
+
+// cachedBlockedBy lists an issue's remote blocked-by dependencies, fetching
+// each issue at most once per dry run.
+func (s *Syncer) cachedBlockedBy(ctx context.Context, ghNumber int) ([]BlockingDependency, error) {
+	s.mu.RLock()
+	deps, ok := s.blockedByCache[ghNumber]
+	s.mu.RUnlock()
+	if ok {
+		return deps, nil
+	}
+	deps, err := s.client.ListBlockedBy(ctx, ghNumber)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.blockedByCache == nil {
+		s.blockedByCache = make(map[int][]BlockingDependency)
+	}
+	s.blockedByCache[ghNumber] = deps
+	s.mu.Unlock()
+	return deps, nil
+}
+
+// issueRef formats a GitHub issue or milestone number for display, with 0
+// meaning none.
+func issueRef(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return "#" + strconv.Itoa(n)
+}
+
 // syncSubIssueLink ensures the GitHub sub-issue relationship matches the local parent field.
 // It handles adding, removing, and re-parenting sub-issues.
 // Skips if parent is a milestone-type issue (those use milestone assignment instead).
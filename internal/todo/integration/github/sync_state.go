@@ -2,6 +2,7 @@ package github
 
 import (
 	"fmt"
+	"maps"
 	"strconv"
 	"sync"
 	"time"
@@ -19,6 +20,13 @@ type SyncStateProvider interface {
 	SetIssueNumber(issueID string, number int)
 	SetSyncedAt(issueID string, t time.Time)
 	SetMilestoneNumber(issueID string, number int)
+	// GetLabels returns the labels jig last pushed to the issue.
+	GetLabels(issueID string) []string
+	SetLabels(issueID string, labels []string)
+	// GetComments returns which comments have been posted to and copied
+	// from the GitHub issue.
+	GetComments(issueID string) syncutil.CommentState
+	SetComments(issueID string, state syncutil.CommentState)
 	Clear(issueID string)
 	Flush() error
 }
@@ -28,6 +36,8 @@ type extensionCache struct {
 	issueNumber     int
 	milestoneNumber int
 	syncedAt        *time.Time
+	labels          []string
+	comments        syncutil.CommentState
 }
 
 // pendingOp represents a pending write operation.
@@ -56,12 +66,16 @@ func NewSyncStateStore(store *core.Core, issues []*issue.Issue) *SyncStateStore
 		issueNumber, hasNumber := GetSyncInt(b, SyncKeyIssueNumber)
 		milestoneNumber, hasMilestone := GetSyncInt(b, SyncKeyMilestoneNumber)
 		syncedAt := GetSyncTime(b, SyncKeySyncedAt)
+		labels := syncutil.SyncStrings(b.Sync[SyncName][SyncKeyLabels])
+		comments := syncutil.ParseCommentState(b.Sync[SyncName])
 
-		if hasNumber || hasMilestone || syncedAt != nil {
+		if hasNumber || hasMilestone || syncedAt != nil || len(labels) > 0 {
 			p.cache[b.ID] = &extensionCache{
 				issueNumber:     issueNumber,
 				milestoneNumber: milestoneNumber,
 				syncedAt:        syncedAt,
+				labels:          labels,
+				comments:        comments,
 			}
 		}
 	}
@@ -138,6 +152,50 @@ func (p *SyncStateStore) SetMilestoneNumber(issueID string, number int) {
 	p.ops = append(p.ops, pendingOp{issueID: issueID, isSet: true})
 }
 
+func (p *SyncStateStore) GetLabels(issueID string) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c, ok := p.cache[issueID]
+	if !ok {
+		return nil
+	}
+	return c.labels
+}
+
+func (p *SyncStateStore) SetLabels(issueID string, labels []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cache[issueID] == nil {
+		p.cache[issueID] = &extensionCache{}
+	}
+	p.cache[issueID].labels = labels
+	p.ops = append(p.ops, pendingOp{issueID: issueID, isSet: true})
+}
+
+func (p *SyncStateStore) GetComments(issueID string) syncutil.CommentState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	c, ok := p.cache[issueID]
+	if !ok {
+		return syncutil.CommentState{}
+	}
+	return c.comments
+}
+
+func (p *SyncStateStore) SetComments(issueID string, state syncutil.CommentState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cache[issueID] == nil {
+		p.cache[issueID] = &extensionCache{}
+	}
+	p.cache[issueID].comments = state
+	p.ops = append(p.ops, pendingOp{issueID: issueID, isSet: true})
+}
+
 func (p *SyncStateStore) Clear(issueID string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -171,6 +229,18 @@ func (p *SyncStateStore) Flush() error {
 			continue // Issue may have been deleted
 		}
 
+		// Start from the existing data so keys the syncer does not own,
+		// such as linked pull requests, survive.
+		data := maps.Clone(b.Sync[SyncName])
+		if data == nil {
+			data = map[string]any{}
+		}
+		delete(data, SyncKeyIssueNumber)
+		delete(data, SyncKeyMilestoneNumber)
+		delete(data, SyncKeySyncedAt)
+		delete(data, SyncKeyLabels)
+		syncutil.CommentState{}.Store(data)
+
 		if op.isSet {
 			// Build extension data from cache
 			p.mu.RLock()
@@ -181,7 +251,6 @@ func (p *SyncStateStore) Flush() error {
 				continue
 			}
 
-			data := map[string]any{}
 			if c.issueNumber != 0 {
 				data[SyncKeyIssueNumber] = strconv.Itoa(c.issueNumber)
 			}
@@ -191,7 +260,13 @@ func (p *SyncStateStore) Flush() error {
 			if c.syncedAt != nil {
 				data[SyncKeySyncedAt] = c.syncedAt.Format(time.RFC3339)
 			}
+			if len(c.labels) > 0 {
+				data[SyncKeyLabels] = c.labels
+			}
+			c.comments.Store(data)
+		}
 
+		if len(data) > 0 {
 			b.SetSync(SyncName, data)
 		} else {
 			b.RemoveSync(SyncName)
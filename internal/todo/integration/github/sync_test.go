@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"slices"
@@ -23,6 +24,8 @@ type memorySyncProvider struct {
 	issueNumbers     map[string]int
 	milestoneNumbers map[string]int
 	syncedAt         map[string]*time.Time
+	labels           map[string][]string
+	comments         map[string]syncutil.CommentState
 }
 
 func newMemorySyncProvider() *memorySyncProvider {
@@ -30,6 +33,8 @@ func newMemorySyncProvider() *memorySyncProvider {
 		issueNumbers:     make(map[string]int),
 		milestoneNumbers: make(map[string]int),
 		syncedAt:         make(map[string]*time.Time),
+		labels:           make(map[string][]string),
+		comments:         make(map[string]syncutil.CommentState),
 	}
 }
 
@@ -78,12 +83,38 @@ func (m *memorySyncProvider) SetMilestoneNumber(issueID string, number int) {
 	m.milestoneNumbers[issueID] = number
 }
 
+func (m *memorySyncProvider) GetLabels(issueID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.labels[issueID]
+}
+
+func (m *memorySyncProvider) SetLabels(issueID string, labels []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.labels[issueID] = labels
+}
+
+func (m *memorySyncProvider) GetComments(issueID string) syncutil.CommentState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.comments[issueID]
+}
+
+func (m *memorySyncProvider) SetComments(issueID string, state syncutil.CommentState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.comments[issueID] = state
+}
+
 func (m *memorySyncProvider) Clear(issueID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.issueNumbers, issueID)
 	delete(m.milestoneNumbers, issueID)
 	delete(m.syncedAt, issueID)
+	delete(m.labels, issueID)
+	delete(m.comments, issueID)
 }
 
 func (m *memorySyncProvider) Flush() error { return nil }
@@ -176,6 +207,11 @@ func TestBuildIssueBody(t *testing.T) {
 			issue:    &issue.Issue{ID: "test-2"},
 			wantBody: syncutil.SyncFooter + "\n\n<!-- todo:test-2 -->",
 		},
+		{
+			name:     "with summary",
+			issue:    &issue.Issue{ID: "test-3", Summary: "One line", Body: "Details"},
+			wantBody: "One line\n\nDetails\n\n" + syncutil.SyncFooter + "\n\n<!-- todo:test-3 -->",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1281,3 +1317,355 @@ func (rt *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error
 	req.URL.Host = strings.TrimPrefix(rt.target, "http://")
 	return http.DefaultTransport.RoundTrip(req)
 }
+
+// newDryRunServer serves issue #42 as remote and fails the test on any write.
+func newDryRunServer(t *testing.T, remote Issue) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/issues/42"):
+			_ = json.NewEncoder(w).Encode(remote)
+		case strings.HasSuffix(r.URL.Path, "/dependencies/blocked_by"):
+			_, _ = w.Write([]byte("[]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &Client{
+		token:      "test",
+		owner:      "test-owner",
+		repo:       "test-repo",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+	}
+}
+
+func TestSyncIssue_DryRunChanges(t *testing.T) {
+	local := &issue.Issue{ID: "test-1", Title: "New title", Status: "ready", Body: "body"}
+
+	tests := []struct {
+		name   string
+		remote Issue
+		want   []syncutil.FieldChange
+	}{
+		{
+			name:   "title only",
+			remote: Issue{Number: 42, Title: "Old title", State: StateOpen},
+			want:   []syncutil.FieldChange{{Field: syncutil.FieldTitle, Local: "New title", Remote: "Old title"}},
+		},
+		{
+			name:   "status only",
+			remote: Issue{Number: 42, Title: "New title", State: StateClosed},
+			want:   []syncutil.FieldChange{{Field: syncutil.FieldStatus, Local: StateOpen, Remote: StateClosed}},
+		},
+		{
+			name:   "unchanged",
+			remote: Issue{Number: 42, Title: "New title", State: StateOpen},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncer := newTestSyncer(t, nil)
+			syncer.opts = SyncOptions{DryRun: true, Force: true}
+			syncer.syncStore.SetIssueNumber(local.ID, 42)
+			tt.remote.Body = syncer.buildIssueBody(local)
+			syncer.client = newDryRunServer(t, tt.remote)
+
+			result := syncer.syncIssue(context.Background(), local)
+
+			wantAction := syncutil.ActionWouldUpdate
+			if len(tt.want) == 0 {
+				wantAction = syncutil.ActionUnchanged
+			}
+			if result.Action != wantAction {
+				t.Errorf("Action = %q, want %q", result.Action, wantAction)
+			}
+			if !slices.Equal(result.Changes, tt.want) {
+				t.Errorf("Changes = %+v, want %+v", result.Changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateChanges_Description(t *testing.T) {
+	body := "new body"
+	changes := updateChanges(&Issue{Body: "old body"}, &UpdateIssueRequest{Body: &body})
+	want := []syncutil.FieldChange{{
+		Field:  syncutil.FieldDescription,
+		Local:  syncutil.DescriptionDigest("new body"),
+		Remote: syncutil.DescriptionDigest("old body"),
+	}}
+	if !slices.Equal(changes, want) {
+		t.Errorf("updateChanges() = %+v, want %+v", changes, want)
+	}
+}
+
+func TestSyncIssue_DryRunBlockedByChange(t *testing.T) {
+	local := &issue.Issue{ID: "test-1", Title: "Title", Status: "ready", BlockedBy: []string{"test-2", "unsynced"}}
+
+	syncer := newTestSyncer(t, nil)
+	syncer.opts = SyncOptions{DryRun: true, Force: true}
+	syncer.syncStore.SetIssueNumber(local.ID, 42)
+	syncer.issueToGHNumber["test-2"] = 7
+	syncer.client = newDryRunServer(t, Issue{Number: 42, Title: "Title", State: StateOpen, Body: syncer.buildIssueBody(local)})
+
+	result := syncer.syncIssue(context.Background(), local)
+
+	want := []syncutil.FieldChange{{Field: syncutil.FieldBlockedBy, Local: "#7", Remote: ""}}
+	if !slices.Equal(result.Changes, want) {
+		t.Errorf("Changes = %+v, want %+v", result.Changes, want)
+	}
+}
+
+func TestEnsureLabel_CreateRace(t *testing.T) {
+	var creates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/labels") {
+			// Another sync created the label between our list and create.
+			creates++
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"label already exists"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		token:      "test",
+		owner:      "test-owner",
+		repo:       "test-repo",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+	}
+
+	for range 2 {
+		if err := client.EnsureLabel(context.Background(), "frontend", DefaultLabelColor); err != nil {
+			t.Fatalf("EnsureLabel() error: %v", err)
+		}
+	}
+	if creates != 1 {
+		t.Errorf("creates = %d, want 1 (the existing label is cached)", creates)
+	}
+}
+
+func TestEnsureLabel_ValidationFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// A bad color is a 422 too, but not an existing label.
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"Label","field":"color","code":"invalid"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		token:      "test",
+		owner:      "test-owner",
+		repo:       "test-repo",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+	}
+	if err := client.EnsureLabel(context.Background(), "frontend", "nope"); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("EnsureLabel() error = %v, want the validation failure", err)
+	}
+}
+
+func TestSyncIssue_UpdateKeepsUnmanagedLabels(t *testing.T) {
+	var patched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(Issue{
+				Number: 42, Title: "Labelled", State: StateOpen,
+				Labels: []Label{{Name: "needs-triage"}, {Name: "old-tag"}},
+			})
+		case http.MethodPatch:
+			var req UpdateIssueRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			patched = req.Labels
+			_ = json.NewEncoder(w).Encode(Issue{Number: 42})
+		default:
+			_, _ = w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		token:      "test",
+		owner:      "test-owner",
+		repo:       "test-repo",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+	}
+	syncer := newTestSyncer(t, client)
+	syncer.opts = SyncOptions{Force: true, NoRelationships: true}
+	syncer.syncStore.SetIssueNumber("test-1", 42)
+	syncer.syncStore.SetLabels("test-1", []string{"old-tag"})
+
+	b := &issue.Issue{ID: "test-1", Title: "Labelled", Status: "ready", Tags: []string{"new-tag"}}
+	result := syncer.syncIssue(context.Background(), b)
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+
+	if want := []string{"needs-triage", "new-tag"}; !slices.Equal(patched, want) {
+		t.Errorf("labels pushed = %v, want %v", patched, want)
+	}
+	if !slices.Equal(result.LabelsAdded, []string{"new-tag"}) || !slices.Equal(result.LabelsRemoved, []string{"old-tag"}) {
+		t.Errorf("labels added %v, removed %v; want [new-tag], [old-tag]", result.LabelsAdded, result.LabelsRemoved)
+	}
+	if got := syncer.syncStore.GetLabels("test-1"); !slices.Equal(got, []string{"new-tag"}) {
+		t.Errorf("managed labels = %v, want [new-tag]", got)
+	}
+}
+
+func TestSyncIssue_LabelTranslation(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]string{} // label -> color
+	var issueLabels []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/labels"):
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			created[req["name"]] = req["color"]
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(Label{Name: req["name"]})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/issues"):
+			var req CreateIssueRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			issueLabels = req.Labels
+			_ = json.NewEncoder(w).Encode(Issue{Number: 1})
+		default:
+			_, _ = w.Write([]byte("{}"))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		token:      "test",
+		owner:      "test-owner",
+		repo:       "test-repo",
+		httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}},
+		labelCache: map[string]bool{"ui": true},
+	}
+	syncer := newTestSyncer(t, client)
+	syncer.config.Labels = &syncutil.LabelConfig{
+		Colors: []string{"d73a4a"},
+		Map:    map[string]string{"bug": "type: bug"},
+	}
+
+	b := &issue.Issue{ID: "test-1", Title: "Crash", Status: "ready", Tags: []string{"bug", "ui"}}
+	syncer.ensureAllLabels(context.Background(), []*issue.Issue{b})
+	result := syncer.syncIssue(context.Background(), b)
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+
+	if want := map[string]string{"type: bug": "d73a4a"}; !maps.Equal(created, want) {
+		t.Errorf("labels created = %v, want %v", created, want)
+	}
+	if want := []string{"type: bug", "ui"}; !slices.Equal(issueLabels, want) || !slices.Equal(result.LabelsAdded, want) {
+		t.Errorf("issue labels = %v, added = %v; want %v", issueLabels, result.LabelsAdded, want)
+	}
+}
+
+// commentServer is a GitHub API holding issue #42 and its comments, which it
+// adds to as comments are posted.
+type commentServer struct {
+	mu       sync.Mutex
+	comments []Comment
+	posted   []string
+}
+
+func (cs *commentServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/repos/o/r/issues/42/comments" && r.Method == http.MethodPost:
+		var req struct{ Body string }
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		cm := Comment{ID: int64(100 + len(cs.posted)), Body: req.Body, User: User{Login: "bot"}, CreatedAt: time.Now().UTC()}
+		cs.comments = append(cs.comments, cm)
+		cs.posted = append(cs.posted, req.Body)
+		_ = json.NewEncoder(w).Encode(cm)
+	case r.URL.Path == "/repos/o/r/issues/42/comments":
+		_ = json.NewEncoder(w).Encode(cs.comments)
+	case r.URL.Path == "/repos/o/r/issues/42":
+		_ = json.NewEncoder(w).Encode(Issue{Number: 42, Title: "Discussed", State: StateOpen})
+	case r.URL.Path == "/repos/o/r/labels":
+		_, _ = w.Write([]byte(`[]`))
+	default:
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestSyncIssues_Comments(t *testing.T) {
+	remote := &commentServer{comments: []Comment{
+		{ID: 7, Body: "Seen on GitHub", User: User{Login: "carol"}, CreatedAt: time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC)},
+	}}
+	server := httptest.NewServer(remote)
+	defer server.Close()
+	client := &Client{token: "test", owner: "o", repo: "r", httpClient: &http.Client{Transport: &redirectTransport{target: server.URL}}}
+
+	b := &issue.Issue{ID: "abc-123", Title: "Discussed", Status: "ready", Type: "task",
+		Body: "Intro.\n\n## Comments\n\n**alice** (jig, 2026-01-01T10:00:00Z):\n\nFirst\n\n**bob** (jig, 2026-01-02T10:00:00Z):\n\nSecond"}
+	b.SetSync(SyncName, map[string]any{SyncKeyIssueNumber: "42"})
+	c := newPullRequestCore(t, b)
+	cfg := &Config{Owner: "o", Repo: "r", Comments: true, CommentsPull: true}
+
+	// Each run starts from the sync data the last one saved.
+	run := func() SyncResult {
+		t.Helper()
+		issues := c.All()
+		store := NewSyncStateStore(c, issues)
+		results, err := NewSyncer(client, cfg, SyncOptions{Force: true, NoRelationships: true}, c, store).SyncIssues(context.Background(), issues)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		return results[0]
+	}
+
+	if r := run(); r.Error != nil {
+		t.Fatalf("first sync: %v", r.Error)
+	}
+	if r := run(); r.Error != nil {
+		t.Fatalf("second sync: %v", r.Error)
+	}
+
+	want := []string{"**alice** (jig, 2026-01-01T10:00:00Z):\n\nFirst", "**bob** (jig, 2026-01-02T10:00:00Z):\n\nSecond"}
+	if !slices.Equal(remote.posted, want) {
+		t.Errorf("posted = %q, want each local comment once, in order", remote.posted)
+	}
+	got, err := c.Get("abc-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := issue.ParseComments(got.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authors []string
+	for _, cm := range comments {
+		authors = append(authors, cm.Author+"/"+cm.Source)
+	}
+	if want := []string{"alice/jig", "bob/jig", "carol/github"}; !slices.Equal(authors, want) {
+		t.Errorf("local comments = %v, want %v", authors, want)
+	}
+	if body := (&Syncer{config: cfg}).buildIssueBody(got); strings.Contains(body, "Comments") {
+		t.Errorf("pushed body = %q, want the Comments section left out", body)
+	}
+}
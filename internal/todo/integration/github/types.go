@@ -1,7 +1,12 @@
 // Package github provides GitHub Issues API integration.
 package github
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+)
 
 // NullableInt represents an optional integer that can be explicitly null in JSON.
 // When Set is false, the field is omitted from JSON output.
@@ -33,6 +38,7 @@ type IssueType struct {
 // Issue represents a GitHub issue.
 type Issue struct {
 	ID        int        `json:"id"`
+	NodeID    string     `json:"node_id"` // GraphQL ID, which DeleteIssue takes
 	Number    int        `json:"number"`
 	Title     string     `json:"title"`
 	Body      string     `json:"body"`
@@ -100,6 +106,14 @@ type User struct {
 	ID    int    `json:"id"`
 }
 
+// Comment represents a comment on a GitHub issue.
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Repo represents a GitHub repository.
 type Repo struct {
 	FullName string `json:"full_name"`
@@ -182,6 +196,11 @@ type SyncResult struct {
 	ExternalURL string // GitHub issue HTML URL
 	Action      string // Matches integration.Action* constants
 	Error       error
+	Changes     []syncutil.FieldChange // Fields a dry run would push (empty when unchanged)
+	// LabelsAdded and LabelsRemoved are the labels the sync put on or took
+	// off the GitHub issue (or would, in a dry run).
+	LabelsAdded   []string
+	LabelsRemoved []string
 }
 
 // ProgressFunc is called when an issue sync completes.
@@ -192,11 +211,22 @@ type SyncOptions struct {
 	DryRun          bool
 	Force           bool
 	NoRelationships bool
-	OnProgress      ProgressFunc
+	// Concurrency caps how many issues are pushed at once (default
+	// syncutil.DefaultConcurrency).
+	Concurrency int
+	// FailFast stops starting issues after the first error.
+	FailFast   bool
+	OnProgress ProgressFunc
 }
 
 // errorResponse represents a GitHub API error.
 type errorResponse struct {
 	Message          string `json:"message"`
 	DocumentationURL string `json:"documentation_url,omitempty"`
+	// Errors details a 422 Validation Failed, e.g. code "already_exists".
+	Errors []struct {
+		Resource string `json:"resource"`
+		Field    string `json:"field"`
+		Code     string `json:"code"`
+	} `json:"errors,omitempty"`
 }
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// Headers GitHub sends with every webhook delivery.
+const (
+	WebhookEventHeader     = "X-GitHub-Event"
+	WebhookDeliveryHeader  = "X-GitHub-Delivery"
+	WebhookSignatureHeader = "X-Hub-Signature-256"
+)
+
+// Webhook event names inbound sync handles.
+const (
+	EventIssues       = "issues"
+	EventIssueComment = "issue_comment"
+)
+
+// VerifyWebhookSignature reports whether signature, the X-Hub-Signature-256
+// header ("sha256=" and a hex HMAC-SHA256 of the body), was made with secret.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	sum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok || secret == "" {
+		return false
+	}
+	got, err := hex.DecodeString(sum)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// WebhookComment is an issue comment in an issue_comment delivery.
+type WebhookComment struct {
+	Body string `json:"body"`
+	User User   `json:"user"`
+}
+
+// WebhookEvent is the part of an issues or issue_comment delivery inbound
+// sync reads.
+type WebhookEvent struct {
+	Action  string          `json:"action"`
+	Issue   Issue           `json:"issue"`
+	Comment *WebhookComment `json:"comment,omitempty"`
+}
+
+// ParseWebhookEvent decodes a delivery of the named event. It returns nil
+// for events and actions inbound sync ignores: anything but issues events
+// and newly created issue comments.
+func ParseWebhookEvent(event string, body []byte) (*WebhookEvent, error) {
+	if event != EventIssues && event != EventIssueComment {
+		return nil, nil
+	}
+	var e WebhookEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return nil, fmt.Errorf("parsing %s event: %w", event, err)
+	}
+	if e.Issue.Number == 0 {
+		return nil, fmt.Errorf("%s event has no issue number", event)
+	}
+	if event == EventIssueComment && (e.Action != "created" || e.Comment == nil) {
+		return nil, nil
+	}
+	if event == EventIssues {
+		e.Comment = nil
+	}
+	return &e, nil
+}
+
+// LocalStatus maps a GitHub issue state back to an issue status. An issue
+// whose current status already maps to state keeps it, since the mapping
+// loses detail (ready and in-progress are both open); otherwise a closed
+// issue becomes completed and an open one ready.
+func LocalStatus(state, current string) string {
+	if mapped, ok := DefaultStatusMapping[current]; (ok && mapped == state) || (!ok && current != "" && state == StateOpen) {
+		return current
+	}
+	if state == StateClosed {
+		return config.StatusCompleted
+	}
+	return config.StatusReady
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/integration/github"
 	"github.com/toba/jig/internal/todo/integration/syncutil"
@@ -18,8 +21,9 @@ import (
 
 // gitHubIntegration implements Integration for GitHub Issues.
 type gitHubIntegration struct {
-	cfg  *github.Config
-	core *core.Core
+	cfg    *github.Config
+	core   *core.Core
+	filter *SyncFilter
 }
 
 func newGitHubIntegration(cfg *github.Config, c *core.Core) *gitHubIntegration {
@@ -32,10 +36,16 @@ func detectGitHub(cfgMap map[string]any, c *core.Core) (Integration, error) {
 	if err != nil {
 		return nil, err
 	}
-	if cfg != nil {
-		return newGitHubIntegration(cfg, c), nil
+	if cfg == nil {
+		return nil, nil
 	}
-	return nil, nil
+	filter, err := ParseSyncFilter(github.SyncName, cfgMap)
+	if err != nil {
+		return nil, err
+	}
+	integ := newGitHubIntegration(cfg, c)
+	integ.filter = filter
+	return integ, nil
 }
 
 func (gh *gitHubIntegration) Name() string { return "github" }
@@ -51,18 +61,29 @@ func (gh *gitHubIntegration) getToken() (string, error) {
 func (gh *gitHubIntegration) Sync(ctx context.Context, issues []*issue.Issue, opts SyncOptions) ([]SyncResult, error) {
 	token, err := gh.getToken()
 	if err != nil {
-		return nil, err
+		return nil, providerError(gh.Name(), err)
 	}
 
 	client := github.NewClient(token, gh.cfg.Owner, gh.cfg.Repo)
 
+	issues, refused := withoutEncrypted(issues, allowEncryptedSync(gh.core))
+	issues, skipped := withFilter(issues, gh.filter)
+	refused = append(refused, skipped...)
+	// GitHub issues are usually public, so internal ones need the repo's
+	// opt-in as well as --include-internal.
+	issues, skipped = withoutInternal(issues, opts.IncludeInternal, gh.cfg.AllowInternal)
+	refused = append(refused, skipped...)
+
 	// Create sync state provider from issue sync metadata
 	syncProvider := github.NewSyncStateStore(gh.core, issues)
 
 	// Pre-filter to issues that actually need syncing
 	toSync := syncutil.FilterIssuesNeedingSync(issues, syncProvider, opts.Force)
 	if len(toSync) == 0 {
-		return nil, nil
+		if !opts.DryRun {
+			gh.refreshPullRequests(ctx, client, issues)
+		}
+		return sortResults(refused), nil
 	}
 
 	// Convert integration progress callback to github progress callback
@@ -78,6 +99,8 @@ func (gh *gitHubIntegration) Sync(ctx context.Context, issues []*issue.Issue, op
 		DryRun:          opts.DryRun,
 		Force:           opts.Force,
 		NoRelationships: opts.NoRelationships,
+		Concurrency:     gh.cfg.Concurrency,
+		FailFast:        opts.FailFast,
 		OnProgress:      ghProgress,
 	}
 
@@ -86,41 +109,59 @@ func (gh *gitHubIntegration) Sync(ctx context.Context, issues []*issue.Issue, op
 	// Run sync
 	ghResults, err := syncer.SyncIssues(ctx, toSync)
 	if err != nil {
-		return nil, fmt.Errorf("sync failed: %w", err)
+		return nil, providerError(gh.Name(), fmt.Errorf("sync failed: %w", err))
 	}
 
 	// Convert results
-	results := make([]SyncResult, len(ghResults))
-	for i, r := range ghResults {
-		results[i] = convertGitHubResult(r)
+	results := make([]SyncResult, 0, len(refused)+len(ghResults))
+	results = append(results, refused...)
+	for _, r := range ghResults {
+		results = append(results, convertGitHubResult(r))
 	}
 
 	// Flush sync state to issue sync metadata
 	if !opts.DryRun {
 		if flushErr := syncProvider.Flush(); flushErr != nil {
-			return results, fmt.Errorf("saving sync state: %w", flushErr)
+			return sortResults(results), fmt.Errorf("saving sync state: %w", flushErr)
 		}
+		gh.refreshPullRequests(ctx, client, issues)
 	}
 
-	return results, nil
+	return sortResults(results), nil
+}
+
+// refreshPullRequests updates the state of linked pull requests and, when
+// detect_prs is on, links pull requests that reference an issue. A failure
+// is reported as a warning rather than failing the sync.
+func (gh *gitHubIntegration) refreshPullRequests(ctx context.Context, client *github.Client, issues []*issue.Issue) {
+	if !gh.cfg.DetectPRs && !slices.ContainsFunc(issues, func(b *issue.Issue) bool { return len(b.GithubPullRequests()) > 0 }) {
+		return
+	}
+	refreshOpts := github.RefreshOptions{Detect: gh.cfg.DetectPRs, Now: time.Now().UTC()}
+	if _, err := github.RefreshPullRequests(ctx, client, gh.core, issues, refreshOpts); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not refresh pull requests: %v\n", err)
+	}
 }
 
 // convertGitHubResult converts a github.SyncResult to an integration.SyncResult.
 func convertGitHubResult(r github.SyncResult) SyncResult {
 	return SyncResult{
-		IssueID:     r.IssueID,
-		IssueTitle:  r.IssueTitle,
-		ExternalID:  r.ExternalID,
-		ExternalURL: r.ExternalURL,
-		Action:      r.Action,
-		Error:       r.Error,
+		IssueID:       r.IssueID,
+		IssueTitle:    r.IssueTitle,
+		ExternalID:    r.ExternalID,
+		ExternalURL:   r.ExternalURL,
+		Action:        r.Action,
+		Error:         r.Error,
+		Changes:       r.Changes,
+		LabelsAdded:   r.LabelsAdded,
+		LabelsRemoved: r.LabelsRemoved,
 	}
 }
 
 func (gh *gitHubIntegration) Link(ctx context.Context, issueID, externalID string) (*LinkResult, error) {
-	b, err := gh.core.Get(issueID)
+	b, err := linkTarget(gh.core, issueID)
 	if err != nil {
-		return nil, fmt.Errorf("issue not found: %s", issueID)
+		return nil, err
 	}
 
 	// Check if already linked to this issue number
@@ -141,11 +182,14 @@ func (gh *gitHubIntegration) Link(ctx context.Context, issueID, externalID strin
 		}
 	}
 
-	// Set extension data on the issue
-	data := map[string]any{
-		github.SyncKeyIssueNumber: externalID,
-		github.SyncKeySyncedAt:    time.Now().UTC().Format(time.RFC3339),
+	// Set extension data on the issue, keeping any linked pull requests
+	data := maps.Clone(b.Sync[github.SyncName])
+	if data == nil {
+		data = map[string]any{}
 	}
+	delete(data, github.SyncKeyMilestoneNumber)
+	data[github.SyncKeyIssueNumber] = externalID
+	data[github.SyncKeySyncedAt] = time.Now().UTC().Format(time.RFC3339)
 	b.SetSync(github.SyncName, data)
 	if err := gh.core.SaveSyncOnly(b, nil); err != nil {
 		return nil, err
@@ -154,9 +198,9 @@ func (gh *gitHubIntegration) Link(ctx context.Context, issueID, externalID strin
 }
 
 func (gh *gitHubIntegration) Unlink(ctx context.Context, issueID string) (*UnlinkResult, error) {
-	b, err := gh.core.Get(issueID)
+	b, err := linkTarget(gh.core, issueID)
 	if err != nil {
-		return nil, fmt.Errorf("issue not found: %s", issueID)
+		return nil, err
 	}
 
 	// Check if linked
@@ -165,7 +209,10 @@ func (gh *gitHubIntegration) Unlink(ctx context.Context, issueID string) (*Unlin
 		return &UnlinkResult{Action: ActionNotLinked}, nil
 	}
 
+	// Linked pull requests stay recorded; everything else goes.
+	prs := b.GithubPullRequests()
 	b.RemoveSync(github.SyncName)
+	b.SetGithubPullRequests(prs)
 	if err := gh.core.SaveSyncOnly(b, nil); err != nil {
 		return nil, err
 	}
@@ -249,6 +296,8 @@ func (gh *gitHubIntegration) checkConfiguration(ctx context.Context, opts CheckO
 		}
 	}
 
+	section.Checks = append(section.Checks, checkSyncFilter(gh.filter, gh.core)...)
+
 	return section
 }
 
@@ -330,6 +379,8 @@ func (gh *gitHubIntegration) checkSyncState(ctx context.Context, opts CheckOptio
 		Message: fmt.Sprintf("%d issues", linkedCount),
 	})
 
+	section.Checks = append(section.Checks, gh.checkPullRequests(ctx, opts, allIssues)...)
+
 	if linkedCount == 0 {
 		return section
 	}
@@ -406,3 +457,57 @@ func (gh *gitHubIntegration) checkSyncState(ctx context.Context, opts CheckOptio
 
 	return section
 }
+
+// checkPullRequests refreshes the state of linked pull requests, or with
+// --skip-api reports how many recorded states are older than pr_state_ttl.
+func (gh *gitHubIntegration) checkPullRequests(ctx context.Context, opts CheckOptions, allIssues []*issue.Issue) []CheckResult {
+	var withPRs []*issue.Issue
+	var prs []issue.PullRequest
+	for _, b := range allIssues {
+		if linked := b.GithubPullRequests(); len(linked) > 0 {
+			withPRs = append(withPRs, b)
+			prs = append(prs, linked...)
+		}
+	}
+	if len(prs) == 0 {
+		return nil
+	}
+
+	token, _ := gh.getToken()
+	if opts.SkipAPI || token == "" {
+		now := time.Now()
+		stale := 0
+		for _, pr := range prs {
+			if pr.IsStale(now, gh.cfg.PRStateTTL) {
+				stale++
+			}
+		}
+		if stale == 0 {
+			return []CheckResult{{
+				Name:    "Pull request states",
+				Status:  CheckPass,
+				Message: fmt.Sprintf("%d pull requests", len(prs)),
+			}}
+		}
+		return []CheckResult{{
+			Name:    "Pull request states",
+			Status:  CheckWarn,
+			Message: fmt.Sprintf("%d of %d pull requests have stale state (>%s)", stale, len(prs), config.FormatAge(gh.cfg.PRStateTTL)),
+		}}
+	}
+
+	client := github.NewClient(token, gh.cfg.Owner, gh.cfg.Repo)
+	refreshOpts := github.RefreshOptions{Now: time.Now().UTC()}
+	if _, err := github.RefreshPullRequests(ctx, client, gh.core, withPRs, refreshOpts); err != nil {
+		return []CheckResult{{
+			Name:    "Pull request states",
+			Status:  CheckFail,
+			Message: fmt.Sprintf("Cannot refresh pull requests: %v", err),
+		}}
+	}
+	return []CheckResult{{
+		Name:    "Pull request states",
+		Status:  CheckPass,
+		Message: fmt.Sprintf("Refreshed %d pull requests", len(prs)),
+	}}
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/toba/jig/internal/todo/config"
@@ -103,6 +104,30 @@ func TestGitHubIntegration_Unlink_IssueNotFound(t *testing.T) {
 	}
 }
 
+func TestGitHubIntegration_LinkArchived(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	cfg := config.Default()
+	c := core.New(t.TempDir(), cfg)
+	b := &issue.Issue{ID: "arc-1", Title: "Archived", Status: "completed"}
+	if err := c.Create(b); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	if err := c.Archive(b.ID); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+
+	gh := mustDetectGitHub(t, "o", "r", c)
+	if _, err := gh.Link(context.Background(), b.ID, "42"); !errors.Is(err, core.ErrArchived) {
+		t.Errorf("Link() error = %v, want ErrArchived", err)
+	}
+	if _, err := gh.Unlink(context.Background(), b.ID); !errors.Is(err, core.ErrArchived) {
+		t.Errorf("Unlink() error = %v, want ErrArchived", err)
+	}
+	if b.Sync != nil {
+		t.Errorf("archived issue sync = %v, want none", b.Sync)
+	}
+}
+
 func TestGitHubIntegration_Unlink_NotLinked(t *testing.T) {
 	t.Setenv("GITHUB_TOKEN", "")
 	cfg := config.Default()
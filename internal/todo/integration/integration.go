@@ -3,8 +3,15 @@ package integration
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/github"
 	"github.com/toba/jig/internal/todo/integration/syncutil"
 	"github.com/toba/jig/internal/todo/issue"
 )
@@ -17,8 +24,18 @@ type SyncResult struct {
 	ExternalURL string // URL to the external resource
 	Action      string // One of the Action* constants
 	Error       error
+	Warnings    []string      // Non-fatal, per-issue problems (e.g. unmapped field values)
+	Reason      string        // Why an ActionSkipped issue was left out (e.g. SkipReasonType)
+	Changes     []FieldChange // Fields a dry run would push; empty when the remote already matches
+	// LabelsAdded and LabelsRemoved are the labels (ClickUp tags) the sync
+	// put on or took off the remote issue, or would in a dry run.
+	LabelsAdded   []string
+	LabelsRemoved []string
 }
 
+// FieldChange is re-exported from syncutil to avoid import cycles.
+type FieldChange = syncutil.FieldChange
+
 // ProgressFunc is called when an issue sync completes.
 type ProgressFunc func(result SyncResult, completed, total int)
 
@@ -27,7 +44,13 @@ type SyncOptions struct {
 	DryRun          bool
 	Force           bool
 	NoRelationships bool
-	OnProgress      ProgressFunc
+	// IncludeInternal syncs internal issues too; a provider may still
+	// refuse them (GitHub without allow_internal).
+	IncludeInternal bool
+	// FailFast stops starting issues after the first error; the ones never
+	// started get no result and stay pending in the checkpoint.
+	FailFast   bool
+	OnProgress ProgressFunc
 }
 
 // LinkResult holds the result of a link operation.
@@ -78,6 +101,8 @@ type CheckResult struct {
 	Name    string      `json:"name"`
 	Status  CheckStatus `json:"status"`
 	Message string      `json:"message"`
+	// DurationMS is how long a timed check (a smoke test step) took.
+	DurationMS int64 `json:"duration_ms,omitempty"`
 }
 
 // CheckSection groups related checks.
@@ -111,6 +136,10 @@ type Integration interface {
 	Link(ctx context.Context, issueID, externalID string) (*LinkResult, error)
 	Unlink(ctx context.Context, issueID string) (*UnlinkResult, error)
 	Check(ctx context.Context, opts CheckOptions) (*CheckReport, error)
+	// Sandbox returns the operations SmokeTest runs against the provider's
+	// configured sandbox, or an error wrapping ErrNoSandbox when there is
+	// none.
+	Sandbox() (Sandbox, error)
 }
 
 // Detect checks cfg.Sync for known integration keys and returns the appropriate integration.
@@ -124,7 +153,7 @@ func Detect(syncCfg map[string]map[string]any, c *core.Core) (Integration, error
 	if clickupCfg, ok := syncCfg["clickup"]; ok {
 		integ, err := detectClickUp(clickupCfg, c)
 		if err != nil {
-			return nil, err
+			return nil, providerError(clickup.SyncName, err)
 		}
 		if integ != nil {
 			return integ, nil
@@ -135,7 +164,7 @@ func Detect(syncCfg map[string]map[string]any, c *core.Core) (Integration, error
 	if githubCfg, ok := syncCfg["github"]; ok {
 		integ, err := detectGitHub(githubCfg, c)
 		if err != nil {
-			return nil, err
+			return nil, providerError(github.SyncName, err)
 		}
 		if integ != nil {
 			return integ, nil
@@ -144,3 +173,171 @@ func Detect(syncCfg map[string]map[string]any, c *core.Core) (Integration, error
 
 	return nil, nil
 }
+
+// DetectProvider is Detect for the named provider, for commands that can
+// target one when several are configured. It returns an error wrapping
+// ErrNotConfigured when the config has no usable section for it.
+func DetectProvider(syncCfg map[string]map[string]any, name string, c *core.Core) (Integration, error) {
+	var integ Integration
+	var err error
+	switch name {
+	case clickup.SyncName:
+		integ, err = detectClickUp(syncCfg[name], c)
+	case github.SyncName:
+		integ, err = detectGitHub(syncCfg[name], c)
+	default:
+		return nil, fmt.Errorf("unknown provider %q (must be %s)", name, strings.Join(Providers, " or "))
+	}
+	if err != nil {
+		return nil, providerError(name, err)
+	}
+	if integ == nil {
+		return nil, fmt.Errorf("%w for %s", ErrNotConfigured, name)
+	}
+	return integ, nil
+}
+
+// withoutEncrypted removes encrypted issues from a sync batch unless allow is
+// set, returning a skipped result for each one. Issues whose body could not
+// be decrypted are always held back, since only the placeholder would be
+// pushed.
+func withoutEncrypted(issues []*issue.Issue, allow bool) ([]*issue.Issue, []SyncResult) {
+	var kept []*issue.Issue
+	var refused []SyncResult
+	for _, b := range issues {
+		var reason string
+		switch {
+		case !b.Encrypted:
+			kept = append(kept, b)
+			continue
+		case b.Body == issue.EncryptedPlaceholder:
+			reason = "encrypted issue not synced: body could not be decrypted"
+		case !allow:
+			reason = "encrypted issue not synced (set allow_encrypted_sync: true to push it)"
+		default:
+			kept = append(kept, b)
+			continue
+		}
+		refused = append(refused, SyncResult{
+			IssueID:    b.ID,
+			IssueTitle: b.Title,
+			Action:     ActionSkipped,
+			Warnings:   []string{reason},
+		})
+	}
+	return kept, refused
+}
+
+// withoutInternal removes internal issues from a sync batch, returning a
+// skipped result for each one. They are kept only when include is set and
+// the provider allows them.
+func withoutInternal(issues []*issue.Issue, include, allowed bool) ([]*issue.Issue, []SyncResult) {
+	var kept []*issue.Issue
+	var skipped []SyncResult
+	for _, b := range issues {
+		var reason string
+		switch {
+		case b.Visibility != config.VisibilityInternal:
+			kept = append(kept, b)
+			continue
+		case !include:
+			reason = SkipReasonInternal
+		case !allowed:
+			reason = SkipReasonInternalRefused
+		default:
+			kept = append(kept, b)
+			continue
+		}
+		skipped = append(skipped, SyncResult{
+			IssueID:    b.ID,
+			IssueTitle: b.Title,
+			Action:     ActionSkipped,
+			Reason:     reason,
+		})
+	}
+	return kept, skipped
+}
+
+// sortResults orders results by issue ID, so the report does not depend on
+// which issue finished first.
+func sortResults(results []SyncResult) []SyncResult {
+	slices.SortStableFunc(results, func(a, b SyncResult) int { return strings.Compare(a.IssueID, b.IssueID) })
+	return results
+}
+
+// allowEncryptedSync reports whether the project opted into syncing encrypted issues.
+func allowEncryptedSync(c *core.Core) bool {
+	cfg := c.Config()
+	return cfg != nil && cfg.AllowEncryptedSync
+}
+
+// ProviderError is a failure talking to a sync provider or setting it up:
+// a bad sync section, a missing token, or a failed API call.
+type ProviderError struct {
+	Provider string
+	Err      error
+}
+
+func (e *ProviderError) Error() string { return e.Provider + ": " + e.Err.Error() }
+
+func (e *ProviderError) Unwrap() error { return e.Err }
+
+// providerError wraps a non-nil err as a ProviderError for provider.
+func providerError(provider string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ProviderError{Provider: provider, Err: err}
+}
+
+// ErrNotConfigured is returned by commands that need a sync provider when
+// the config names none.
+var ErrNotConfigured = errors.New("no integration configured")
+
+// ErrNotLinked is returned by ExternalURL for an issue with no sync link to
+// the provider.
+var ErrNotLinked = errors.New("not linked")
+
+// Providers are the sync providers, in the order ExternalURL tries them
+// when none is named.
+var Providers = []string{github.SyncName, clickup.SyncName}
+
+// ExternalURL returns the web URL of the item b is linked to in provider
+// ("github" or "clickup"), or in the first provider it is linked to when
+// provider is empty. It returns an error wrapping ErrNotLinked when there
+// is no link, and one naming the missing setting when a GitHub link has no
+// sync.github.repo to resolve against.
+func ExternalURL(b *issue.Issue, provider string, syncCfg map[string]map[string]any) (string, error) {
+	if provider == "" {
+		for _, p := range Providers {
+			if url, err := ExternalURL(b, p, syncCfg); !errors.Is(err, ErrNotLinked) {
+				return url, err
+			}
+		}
+		return "", fmt.Errorf("%s is %w to %s", b.ID, ErrNotLinked, strings.Join(Providers, " or "))
+	}
+
+	switch provider {
+	case github.SyncName:
+		number := github.GetSyncString(b, github.SyncKeyIssueNumber)
+		if number == "" {
+			return "", fmt.Errorf("%s is %w to GitHub", b.ID, ErrNotLinked)
+		}
+		cfg, err := github.ParseConfig(syncCfg[github.SyncName])
+		if err != nil {
+			return "", err
+		}
+		if cfg == nil {
+			return "", fmt.Errorf("%s is linked to GitHub issue #%s, but sync.github.repo is not set", b.ID, number)
+		}
+		return cfg.IssueURL(number), nil
+	case clickup.SyncName:
+		taskID := clickup.GetSyncString(b, clickup.SyncKeyTaskID)
+		if taskID == "" {
+			return "", fmt.Errorf("%s is %w to ClickUp", b.ID, ErrNotLinked)
+		}
+		return clickup.TaskURL(taskID), nil
+	default:
+		return "", fmt.Errorf("unknown provider %q (must be %s)", provider, strings.Join(Providers, " or "))
+	}
+}
@@ -1,10 +1,13 @@
 package integration
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
 )
 
 func TestDetect_NilConfig(t *testing.T) {
@@ -139,3 +142,164 @@ func TestDetect_GitHubNoRepo(t *testing.T) {
 		t.Fatal("expected nil integration when github has no repo")
 	}
 }
+
+func TestWithoutEncrypted(t *testing.T) {
+	plain := &issue.Issue{ID: "plain"}
+	secret := &issue.Issue{ID: "secret", Body: "decrypted", Encrypted: true}
+	locked := &issue.Issue{ID: "locked", Body: issue.EncryptedPlaceholder, Encrypted: true}
+	all := []*issue.Issue{plain, secret, locked}
+
+	kept, refused := withoutEncrypted(all, false)
+	if len(kept) != 1 || kept[0] != plain {
+		t.Errorf("kept = %v, want only the plaintext issue", kept)
+	}
+	if len(refused) != 2 || refused[0].Action != ActionSkipped || len(refused[0].Warnings) != 1 {
+		t.Errorf("refused = %+v, want two skipped results with warnings", refused)
+	}
+
+	// Opting in pushes decrypted bodies but never the placeholder.
+	kept, refused = withoutEncrypted(all, true)
+	if len(kept) != 2 || len(refused) != 1 || refused[0].IssueID != "locked" {
+		t.Errorf("allow: kept=%d refused=%+v, want locked issue refused", len(kept), refused)
+	}
+}
+
+func TestWithoutInternal(t *testing.T) {
+	public := &issue.Issue{ID: "public"}
+	internal := &issue.Issue{ID: "internal", Visibility: config.VisibilityInternal}
+	all := []*issue.Issue{public, internal}
+
+	tests := []struct {
+		name             string
+		include, allowed bool
+		kept             int
+		reason           string
+	}{
+		{"default", false, true, 1, SkipReasonInternal},
+		{"included", true, true, 2, ""},
+		{"provider refuses", true, false, 1, SkipReasonInternalRefused},
+		{"provider refuses without flag", false, false, 1, SkipReasonInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, skipped := withoutInternal(all, tt.include, tt.allowed)
+			if len(kept) != tt.kept || kept[0] != public {
+				t.Errorf("kept = %v, want %d issues starting with the public one", kept, tt.kept)
+			}
+			if tt.reason == "" {
+				if len(skipped) != 0 {
+					t.Errorf("skipped = %+v, want none", skipped)
+				}
+				return
+			}
+			if len(skipped) != 1 || skipped[0].IssueID != "internal" || skipped[0].Action != ActionSkipped || skipped[0].Reason != tt.reason {
+				t.Errorf("skipped = %+v, want internal skipped with %q", skipped, tt.reason)
+			}
+		})
+	}
+}
+
+func TestExternalURL(t *testing.T) {
+	syncCfg := map[string]map[string]any{"github": {"repo": "toba/jig"}}
+	both := &issue.Issue{ID: "both", Sync: map[string]map[string]any{
+		"github":  {"issue_number": "42"},
+		"clickup": {"task_id": "86abc"},
+	}}
+	clickupOnly := &issue.Issue{ID: "cu", Sync: map[string]map[string]any{"clickup": {"task_id": "86abc"}}}
+	unlinked := &issue.Issue{ID: "none"}
+
+	tests := []struct {
+		name      string
+		b         *issue.Issue
+		provider  string
+		cfg       map[string]map[string]any
+		want      string
+		notLinked bool
+		wantErr   bool
+	}{
+		{"github", both, "github", syncCfg, "https://github.com/toba/jig/issues/42", false, false},
+		{"clickup", both, "clickup", syncCfg, "https://app.clickup.com/t/86abc", false, false},
+		{"first linked provider", clickupOnly, "", syncCfg, "https://app.clickup.com/t/86abc", false, false},
+		{"github preferred", both, "", syncCfg, "https://github.com/toba/jig/issues/42", false, false},
+		{"not linked to github", clickupOnly, "github", syncCfg, "", true, true},
+		{"not linked anywhere", unlinked, "", syncCfg, "", true, true},
+		{"github link without repo", both, "github", nil, "", false, true},
+		{"unknown provider", both, "jira", syncCfg, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExternalURL(tt.b, tt.provider, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExternalURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNotLinked) != tt.notLinked {
+				t.Errorf("ExternalURL() error = %v, want ErrNotLinked: %v", err, tt.notLinked)
+			}
+			if got != tt.want {
+				t.Errorf("ExternalURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncURL(t *testing.T) {
+	cfg := config.Default()
+	cfg.Sync = map[string]map[string]any{"github": {"repo": "toba/jig"}}
+	cfg.SyncURLTemplates = map[string]string{
+		"jira":    "https://acme.atlassian.net/browse/{issue_key}",
+		"clickup": "https://clickup.example/{task_id}",
+		"github":  "https://ghe.example/{issue_number}",
+	}
+	b := &issue.Issue{ID: "abc", Sync: map[string]map[string]any{
+		"github":  {"issue_number": "42"},
+		"clickup": {"task_id": "86abc"},
+		"jira":    {"issue_key": "ENG 12"},
+		"linear":  {"id": "LIN-1"},
+	}}
+
+	tests := []struct {
+		name  string
+		entry string
+		cfg   *config.Config
+		want  string
+	}{
+		{"template", "jira", cfg, "https://acme.atlassian.net/browse/ENG%2012"},
+		{"provider wins over template", "clickup", cfg, "https://app.clickup.com/t/86abc"},
+		{"github with repo wins", "github", cfg, "https://github.com/toba/jig/issues/42"},
+		{"no template", "linear", cfg, ""},
+		{"no entry", "asana", cfg, ""},
+		{"nil config", "jira", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SyncURL(b, tt.entry, tt.cfg); got != tt.want {
+				t.Errorf("SyncURL(%q) = %q, want %q", tt.entry, got, tt.want)
+			}
+		})
+	}
+
+	// Without sync.github.repo the provider cannot build the URL, so the
+	// template is used instead.
+	noRepo := config.Default()
+	noRepo.SyncURLTemplates = cfg.SyncURLTemplates
+	if got := SyncURL(b, "github", noRepo); got != "https://ghe.example/42" {
+		t.Errorf("SyncURL(github) without repo = %q, want the template URL", got)
+	}
+
+	links := SyncLinks(b, cfg)
+	var names []string
+	for _, l := range links {
+		names = append(names, l.Name)
+	}
+	if strings.Join(names, ",") != "clickup,github,jira" {
+		t.Errorf("SyncLinks() names = %v, want clickup,github,jira", names)
+	}
+
+	jiraOnly := &issue.Issue{ID: "j", Sync: map[string]map[string]any{"jira": {"issue_key": "ENG-1"}}}
+	if got, err := OpenURL(jiraOnly, cfg); err != nil || got != "https://acme.atlassian.net/browse/ENG-1" {
+		t.Errorf("OpenURL() = %q, %v, want the jira template URL", got, err)
+	}
+	if _, err := OpenURL(&issue.Issue{ID: "none"}, cfg); !errors.Is(err, ErrNotLinked) {
+		t.Errorf("OpenURL() on an unlinked issue: error = %v, want ErrNotLinked", err)
+	}
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/github"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// LinkPullRequest records GitHub pull request number against an issue. When
+// sync.github is configured and GITHUB_TOKEN is set, the pull request's
+// current state is fetched too; failing that, the link is still recorded
+// and its state is filled in by the next sync.
+func LinkPullRequest(ctx context.Context, syncCfg map[string]map[string]any, c *core.Core, issueID string, number int) (*LinkResult, error) {
+	externalID := fmt.Sprintf("#%d", number)
+	if number <= 0 {
+		return nil, fmt.Errorf("invalid pull request number: %d", number)
+	}
+
+	b, err := c.Get(issueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", core.ErrNotFound, issueID)
+	}
+
+	prs := b.GithubPullRequests()
+	if slices.ContainsFunc(prs, func(pr issue.PullRequest) bool { return pr.Number == number }) {
+		return &LinkResult{Action: ActionAlreadyLinked, ExternalID: externalID}, nil
+	}
+	link := issue.PullRequest{Number: number}
+
+	cfg, err := github.ParseConfig(syncCfg[github.SyncName])
+	if err != nil {
+		return nil, providerError(github.SyncName, err)
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); cfg != nil && token != "" {
+		client := github.NewClient(token, cfg.Owner, cfg.Repo)
+		if pr, err := client.GetPullRequest(ctx, number); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Could not fetch pull request %s: %v\n", externalID, err)
+		} else {
+			now := time.Now().UTC()
+			link.State, link.MergedAt, link.CheckedAt = pr.LinkState(), pr.MergedAt, &now
+		}
+	}
+
+	b.SetGithubPullRequests(append(prs, link))
+	if err := c.SaveSyncOnly(b, nil); err != nil {
+		return nil, err
+	}
+	return &LinkResult{Action: ActionLinked, ExternalID: externalID}, nil
+}
+
+// FormatPullRequests describes the pull requests linked to b, one per
+// entry, e.g. "#123 merged" or "#456 open (stale)". A state fetched longer
+// than sync.github.pr_state_ttl before now is marked stale rather than
+// re-fetched; one never fetched is shown as "not synced".
+func FormatPullRequests(b *issue.Issue, syncCfg map[string]map[string]any, now time.Time) []string {
+	prs := b.GithubPullRequests()
+	if len(prs) == 0 {
+		return nil
+	}
+	ttl := github.DefaultPRStateTTL
+	if cfg, err := github.ParseConfig(syncCfg[github.SyncName]); err == nil && cfg != nil {
+		ttl = cfg.PRStateTTL
+	}
+
+	lines := make([]string, len(prs))
+	for i, pr := range prs {
+		switch {
+		case pr.State == "":
+			lines[i] = fmt.Sprintf("#%d not synced", pr.Number)
+		case pr.IsStale(now, ttl):
+			lines[i] = fmt.Sprintf("#%d %s (stale)", pr.Number, pr.State)
+		default:
+			lines[i] = fmt.Sprintf("#%d %s", pr.Number, pr.State)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/github"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+)
+
+// ErrNoSandbox is returned by Sandbox when the provider's sync section names
+// no sandbox, so a smoke test has nowhere it may create items.
+var ErrNoSandbox = errors.New("no sandbox configured")
+
+// Sandbox is the item operations `sync smoke-test` runs against a
+// provider's sandbox location: a scratch ClickUp list or GitHub repository
+// that sync itself never touches.
+type Sandbox interface {
+	// Authenticate checks the token, returning who it belongs to.
+	Authenticate(ctx context.Context) (string, error)
+	// Inspect reads the sandbox location, returning its name.
+	Inspect(ctx context.Context) (string, error)
+	// CreateItem creates a temporary item, returning its ID.
+	CreateItem(ctx context.Context, title, body string) (string, error)
+	// UpdateItem retitles the item.
+	UpdateItem(ctx context.Context, id, title string) error
+	// DeleteItem removes the item. A provider that cannot delete it but
+	// closes it instead returns a *ClosedInsteadError.
+	DeleteItem(ctx context.Context, id string) error
+	// Secrets are the values Redact hides in reported errors.
+	Secrets() []string
+}
+
+// ClosedInsteadError is a sandbox item that could not be deleted but was
+// closed, so it stays behind without counting as open work.
+type ClosedInsteadError struct {
+	ID  string
+	Err error
+}
+
+func (e *ClosedInsteadError) Error() string {
+	return fmt.Sprintf("could not delete %s, closed it instead: %v", e.ID, e.Err)
+}
+
+func (e *ClosedInsteadError) Unwrap() error { return e.Err }
+
+// Smoke test step names, in the order they run.
+const (
+	SmokeStepAuthenticate = "Authenticate"
+	SmokeStepInspect      = "Read sandbox"
+	SmokeStepCreate       = "Create temporary item"
+	SmokeStepUpdate       = "Update temporary item"
+	SmokeStepDelete       = "Delete temporary item"
+)
+
+// SmokeTest runs a non-destructive end-to-end check through sb: it
+// authenticates, reads the sandbox, then creates, updates, and deletes a
+// temporary item, timing each step. A failed step skips the ones after it,
+// except that an item once created is always deleted. Failures carry the
+// provider's error body, with sb's secrets redacted.
+func SmokeTest(ctx context.Context, provider string, sb Sandbox) *CheckReport {
+	section := CheckSection{Name: provider + " smoke test", Checks: make([]CheckResult, 0, 5)}
+	run := func(name string, step func() (string, error)) bool {
+		start := time.Now()
+		msg, err := step()
+		check := CheckResult{Name: name, Status: CheckPass, Message: msg, DurationMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			check.Status = CheckFail
+			if _, ok := errors.AsType[*ClosedInsteadError](err); ok {
+				check.Status = CheckWarn
+			}
+			check.Message = Redact(errorDetail(err), sb.Secrets()...)
+		}
+		section.Checks = append(section.Checks, check)
+		return err == nil
+	}
+
+	stamp := time.Now().UTC().Format(time.RFC3339)
+	title := "jig smoke test " + stamp
+	var id string
+	if run(SmokeStepAuthenticate, func() (string, error) { return sb.Authenticate(ctx) }) &&
+		run(SmokeStepInspect, func() (string, error) { return sb.Inspect(ctx) }) &&
+		run(SmokeStepCreate, func() (string, error) {
+			var err error
+			id, err = sb.CreateItem(ctx, title, "Temporary item created by `jig sync smoke-test` at "+stamp+". It is deleted when the test finishes.")
+			return id, err
+		}) {
+		run(SmokeStepUpdate, func() (string, error) { return id, sb.UpdateItem(ctx, id, title+" (updated)") })
+	}
+	if id != "" {
+		run(SmokeStepDelete, func() (string, error) { return id, sb.DeleteItem(ctx, id) })
+	}
+
+	report := &CheckReport{Sections: []CheckSection{section}}
+	for _, check := range section.Checks {
+		switch check.Status {
+		case CheckPass:
+			report.Summary.Passed++
+		case CheckWarn:
+			report.Summary.Warnings++
+		case CheckFail:
+			report.Summary.Failed++
+		}
+	}
+	return report
+}
+
+// errorDetail is err's message, followed by the provider's response body
+// when the message does not already include it.
+func errorDetail(err error) string {
+	msg := err.Error()
+	if apiErr, ok := errors.AsType[*syncutil.APIError](err); ok && apiErr.Body != "" && !strings.Contains(msg, apiErr.Body) {
+		msg += ": " + strings.TrimSpace(apiErr.Body)
+	}
+	return msg
+}
+
+// Redact replaces each non-empty secret in s.
+func Redact(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "[redacted]")
+		}
+	}
+	return s
+}
+
+// gitHubSandbox runs smoke test steps against sync.github.sandbox.
+type gitHubSandbox struct {
+	token  string
+	client *github.Client
+	nodes  map[string]string // issue number -> GraphQL node ID, for DeleteItem
+}
+
+func (gh *gitHubIntegration) Sandbox() (Sandbox, error) {
+	if gh.cfg.SandboxRepo == "" {
+		return nil, fmt.Errorf("%w: set sync.github.sandbox to a scratch owner/repo", ErrNoSandbox)
+	}
+	token, err := gh.getToken()
+	if err != nil {
+		return nil, providerError(gh.Name(), err)
+	}
+	return &gitHubSandbox{
+		token:  token,
+		client: github.NewClient(token, gh.cfg.SandboxOwner, gh.cfg.SandboxRepo),
+		nodes:  make(map[string]string),
+	}, nil
+}
+
+func (s *gitHubSandbox) Authenticate(ctx context.Context) (string, error) {
+	user, err := s.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+func (s *gitHubSandbox) Inspect(ctx context.Context) (string, error) {
+	repo, err := s.client.GetRepo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return repo.FullName, nil
+}
+
+func (s *gitHubSandbox) CreateItem(ctx context.Context, title, body string) (string, error) {
+	created, err := s.client.CreateIssue(ctx, &github.CreateIssueRequest{Title: title, Body: body})
+	if err != nil {
+		return "", err
+	}
+	id := fmt.Sprintf("#%d", created.Number)
+	s.nodes[id] = created.NodeID
+	return id, nil
+}
+
+func (s *gitHubSandbox) UpdateItem(ctx context.Context, id, title string) error {
+	number, err := issueNumber(id)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.UpdateIssue(ctx, number, &github.UpdateIssueRequest{Title: &title})
+	return err
+}
+
+// DeleteItem deletes the issue, which GitHub allows only repository
+// admins; otherwise it closes the issue.
+func (s *gitHubSandbox) DeleteItem(ctx context.Context, id string) error {
+	deleteErr := s.client.DeleteIssue(ctx, s.nodes[id])
+	if deleteErr == nil {
+		return nil
+	}
+	number, err := issueNumber(id)
+	if err != nil {
+		return err
+	}
+	closed := github.StateClosed
+	if _, err := s.client.UpdateIssue(ctx, number, &github.UpdateIssueRequest{State: &closed}); err != nil {
+		return fmt.Errorf("%w; closing it: %w", deleteErr, err)
+	}
+	return &ClosedInsteadError{ID: id, Err: deleteErr}
+}
+
+func (s *gitHubSandbox) Secrets() []string { return []string{s.token} }
+
+// issueNumber parses a gitHubSandbox item ID ("#12").
+func issueNumber(id string) (int, error) {
+	var number int
+	if _, err := fmt.Sscanf(id, "#%d", &number); err != nil {
+		return 0, fmt.Errorf("invalid issue %q", id)
+	}
+	return number, nil
+}
+
+// clickUpSandbox runs smoke test steps against sync.clickup.sandbox.
+type clickUpSandbox struct {
+	token  string
+	listID string
+	client *clickup.Client
+}
+
+func (cu *clickUpIntegration) Sandbox() (Sandbox, error) {
+	if cu.cfg.SandboxListID == "" {
+		return nil, fmt.Errorf("%w: set sync.clickup.sandbox to a scratch list ID", ErrNoSandbox)
+	}
+	token, err := cu.getToken()
+	if err != nil {
+		return nil, providerError(cu.Name(), err)
+	}
+	return &clickUpSandbox{token: token, listID: cu.cfg.SandboxListID, client: clickup.NewClient(token)}, nil
+}
+
+func (s *clickUpSandbox) Authenticate(ctx context.Context) (string, error) {
+	user, err := s.client.GetAuthorizedUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+func (s *clickUpSandbox) Inspect(ctx context.Context) (string, error) {
+	list, err := s.client.GetList(ctx, s.listID)
+	if err != nil {
+		return "", err
+	}
+	return list.Name, nil
+}
+
+func (s *clickUpSandbox) CreateItem(ctx context.Context, title, body string) (string, error) {
+	task, err := s.client.CreateTask(ctx, s.listID, &clickup.CreateTaskRequest{Name: title, MarkdownDescription: body})
+	if err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+func (s *clickUpSandbox) UpdateItem(ctx context.Context, id, title string) error {
+	_, err := s.client.UpdateTask(ctx, id, &clickup.UpdateTaskRequest{Name: &title})
+	return err
+}
+
+func (s *clickUpSandbox) DeleteItem(ctx context.Context, id string) error {
+	return s.client.DeleteTask(ctx, id)
+}
+
+func (s *clickUpSandbox) Secrets() []string { return []string{s.token} }
@@ -0,0 +1,234 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+)
+
+// sandboxServer is a fake provider API for smoke tests. It records the
+// requests it serves as "METHOD path" and answers from routes, keyed the
+// same way; unrouted requests get {}.
+type sandboxServer struct {
+	mu       sync.Mutex
+	requests []string
+	routes   map[string]func(w http.ResponseWriter, r *http.Request)
+}
+
+func (s *sandboxServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + r.URL.Path
+	s.mu.Lock()
+	s.requests = append(s.requests, key)
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if route, ok := s.routes[key]; ok {
+		route(w, r)
+		return
+	}
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (s *sandboxServer) served(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.requests, key)
+}
+
+func reply(status int, body string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func startSandboxServer(t *testing.T, routes map[string]func(http.ResponseWriter, *http.Request)) *sandboxServer {
+	t.Helper()
+	fake := &sandboxServer{routes: routes}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	redirectDefaultTransport(t, server)
+	return fake
+}
+
+func gitHubSandboxFor(t *testing.T, sandbox string) Sandbox {
+	t.Helper()
+	integ, err := detectGitHub(map[string]any{"repo": "o/r", "sandbox": sandbox}, core.New(t.TempDir(), config.Default()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := integ.Sandbox()
+	if err != nil {
+		t.Fatalf("Sandbox() error = %v", err)
+	}
+	return sb
+}
+
+func checkStatuses(report *CheckReport) string {
+	var parts []string
+	for _, c := range report.Sections[0].Checks {
+		parts = append(parts, c.Name+"="+string(c.Status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestSmokeTestGitHub(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	fake := startSandboxServer(t, map[string]func(http.ResponseWriter, *http.Request){
+		"GET /user":                       reply(200, `{"login":"tester"}`),
+		"GET /repos/o/scratch":            reply(200, `{"full_name":"o/scratch"}`),
+		"POST /repos/o/scratch/issues":    reply(201, `{"number":7,"node_id":"I_7"}`),
+		"PATCH /repos/o/scratch/issues/7": reply(200, `{"number":7}`),
+		"POST /graphql":                   reply(200, `{"data":{"deleteIssue":{"clientMutationId":null}}}`),
+	})
+
+	report := SmokeTest(context.Background(), "github", gitHubSandboxFor(t, "o/scratch"))
+	if report.Summary.Passed != 5 || report.Summary.Failed != 0 {
+		t.Fatalf("report = %s, want all five steps passed", checkStatuses(report))
+	}
+	if got := report.Sections[0].Checks[2].Message; got != "#7" {
+		t.Errorf("create message = %q, want #7", got)
+	}
+	if fake.served("GET /repos/o/r") {
+		t.Error("smoke test read the synced repo, want only the sandbox")
+	}
+}
+
+func TestSmokeTestGitHubClosesWhenDeleteRefused(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	var closed bool
+	startSandboxServer(t, map[string]func(http.ResponseWriter, *http.Request){
+		"GET /user":                    reply(200, `{"login":"tester"}`),
+		"GET /repos/o/scratch":         reply(200, `{"full_name":"o/scratch"}`),
+		"POST /repos/o/scratch/issues": reply(201, `{"number":7,"node_id":"I_7"}`),
+		"PATCH /repos/o/scratch/issues/7": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			closed = closed || strings.Contains(string(body), `"state":"closed"`)
+			_, _ = w.Write([]byte(`{"number":7}`))
+		},
+		"POST /graphql": reply(200, `{"errors":[{"message":"test-token must have administrator access"}]}`),
+	})
+
+	report := SmokeTest(context.Background(), "github", gitHubSandboxFor(t, "o/scratch"))
+	del := report.Sections[0].Checks[4]
+	if del.Status != CheckWarn || !closed {
+		t.Fatalf("delete step = %+v (closed %v), want a warning after closing the issue", del, closed)
+	}
+	if strings.Contains(del.Message, "test-token") || !strings.Contains(del.Message, "[redacted] must have administrator access") {
+		t.Errorf("delete message = %q, want the error with the token redacted", del.Message)
+	}
+}
+
+func TestSmokeTestReportsRedactedErrorBody(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	fake := startSandboxServer(t, map[string]func(http.ResponseWriter, *http.Request){
+		"GET /user":                    reply(200, `{"login":"tester"}`),
+		"GET /repos/o/scratch":         reply(200, `{"full_name":"o/scratch"}`),
+		"POST /repos/o/scratch/issues": reply(403, `{"message":"Resource not accessible by integration","echo":"Bearer test-token"}`),
+	})
+
+	report := SmokeTest(context.Background(), "github", gitHubSandboxFor(t, "o/scratch"))
+	if got := checkStatuses(report); got != "Authenticate=pass, Read sandbox=pass, Create temporary item=fail" {
+		t.Fatalf("report = %s, want the steps after a failed create skipped", got)
+	}
+	msg := report.Sections[0].Checks[2].Message
+	if !strings.Contains(msg, "Resource not accessible by integration") || !strings.Contains(msg, `"echo":"Bearer [redacted]"`) {
+		t.Errorf("create message = %q, want the redacted error body", msg)
+	}
+	if fake.served("POST /graphql") {
+		t.Error("smoke test tried to delete an item it never created")
+	}
+}
+
+func TestSmokeTestClickUpDeletesAfterFailedUpdate(t *testing.T) {
+	t.Setenv("CLICKUP_TOKEN", "pk_secret")
+	fake := startSandboxServer(t, map[string]func(http.ResponseWriter, *http.Request){
+		"GET /api/v2/user":          reply(200, `{"user":{"id":1,"username":"tester"}}`),
+		"GET /api/v2/list/L1":       reply(200, `{"id":"L1","name":"Scratch","space":{"id":"S1"}}`),
+		"POST /api/v2/list/L1/task": reply(200, `{"id":"t9","name":"jig smoke test"}`),
+		"PUT /api/v2/task/t9":       reply(401, `{"err":"Token pk_secret lacks access","ECODE":"OAUTH_027"}`),
+		"DELETE /api/v2/task/t9":    reply(200, `{}`),
+	})
+	integ, err := detectClickUp(map[string]any{"list_id": "L0", "sandbox": "L1"}, core.New(t.TempDir(), config.Default()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := integ.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := SmokeTest(context.Background(), "clickup", sb)
+	if got := checkStatuses(report); got != "Authenticate=pass, Read sandbox=pass, Create temporary item=pass, Update temporary item=fail, Delete temporary item=pass" {
+		t.Fatalf("report = %s", got)
+	}
+	if msg := report.Sections[0].Checks[3].Message; strings.Contains(msg, "pk_secret") || !strings.Contains(msg, "OAUTH_027") {
+		t.Errorf("update message = %q, want the redacted error", msg)
+	}
+	if !fake.served("DELETE /api/v2/task/t9") {
+		t.Error("temporary task was not deleted")
+	}
+}
+
+func TestSandboxRequiresConfig(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("CLICKUP_TOKEN", "pk_secret")
+	c := core.New(t.TempDir(), config.Default())
+	for name, cfgMap := range map[string]map[string]any{
+		"github":  {"repo": "o/r"},
+		"clickup": {"list_id": "L0"},
+	} {
+		integ, err := DetectProvider(map[string]map[string]any{name: cfgMap}, name, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := integ.Sandbox(); !errors.Is(err, ErrNoSandbox) {
+			t.Errorf("%s Sandbox() error = %v, want ErrNoSandbox", name, err)
+		}
+	}
+	if _, err := DetectProvider(map[string]map[string]any{"github": {"repo": "o/r"}}, "clickup", c); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("DetectProvider(clickup) error = %v, want ErrNotConfigured", err)
+	}
+}
+
+// TestSmokeTestLive runs the smoke test against a real provider. It is
+// skipped unless JIG_SMOKE_TEST_PROVIDER names one, with its token in the
+// usual variable and the sandbox in JIG_SMOKE_TEST_SANDBOX, e.g. in a CI job
+// with access to a scratch repository.
+func TestSmokeTestLive(t *testing.T) {
+	provider := os.Getenv("JIG_SMOKE_TEST_PROVIDER")
+	if provider == "" {
+		t.Skip("JIG_SMOKE_TEST_PROVIDER not set")
+	}
+	cfgMap := map[string]any{"sandbox": os.Getenv("JIG_SMOKE_TEST_SANDBOX")}
+	switch provider {
+	case "github":
+		cfgMap["repo"] = cfgMap["sandbox"]
+	case "clickup":
+		cfgMap["list_id"] = cfgMap["sandbox"]
+	}
+	integ, err := DetectProvider(map[string]map[string]any{provider: cfgMap}, provider, core.New(t.TempDir(), config.Default()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := integ.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := SmokeTest(context.Background(), provider, sb)
+	for _, c := range report.Sections[0].Checks {
+		t.Logf("%s: %s (%s) %dms", c.Name, c.Status, c.Message, c.DurationMS)
+	}
+	if report.Summary.Failed > 0 {
+		t.Fatalf("%d step(s) failed", report.Summary.Failed)
+	}
+}
@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/github"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// syncSchemas holds the sync data schema of each built-in provider. Sync data
+// under any other name belongs to an extension and is not validated.
+var syncSchemas = map[string]*syncutil.Schema{
+	clickup.SyncName: &clickup.SyncSchema,
+	github.SyncName:  &github.SyncSchema,
+}
+
+// SyncSchema returns the schema for the named provider's sync data, or false
+// when name is not a built-in provider.
+func SyncSchema(name string) (*syncutil.Schema, bool) {
+	s, ok := syncSchemas[name]
+	return s, ok
+}
+
+// ValidateSyncData checks data against the named provider's schema, returning
+// a *syncutil.SchemaError describing any problems. Data for providers without
+// a schema is always accepted. With allowExtra, unknown keys are accepted but
+// known keys must still hold the right kind of value.
+func ValidateSyncData(name string, data map[string]any, allowExtra bool) error {
+	s, ok := SyncSchema(name)
+	if !ok {
+		return nil
+	}
+	return s.Validate(data, allowExtra)
+}
+
+// ValidateLink checks the sync data b would have after `sync link` records
+// externalID for the named provider: what b already has, with the provider's
+// link key set.
+func ValidateLink(name string, b *issue.Issue, externalID string, allowExtra bool) error {
+	s, ok := SyncSchema(name)
+	if !ok {
+		return nil
+	}
+	data := maps.Clone(b.Sync[name])
+	if data == nil {
+		data = map[string]any{}
+	}
+	data[s.LinkKey] = externalID
+	return s.Validate(data, allowExtra)
+}
+
+// linkTarget returns the issue `sync link` or `sync unlink` changes. An
+// archived issue is refused with core's *ArchivedError; anything else
+// missing is core.ErrNotFound.
+func linkTarget(c *core.Core, issueID string) (*issue.Issue, error) {
+	b, err := c.GetWith(issueID, core.GetOptions{})
+	if errors.Is(err, core.ErrArchived) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", core.ErrNotFound, issueID)
+	}
+	return b, nil
+}
+
+// SyncDataProblem is a problem with one built-in provider's sync data on an
+// issue.
+type SyncDataProblem struct {
+	IssueID  string `json:"issue_id"`
+	Provider string `json:"provider"`
+	syncutil.SchemaProblem
+}
+
+// CheckSyncData returns every problem in the built-in providers' sync data on
+// issues, sorted by issue ID, provider, then key.
+func CheckSyncData(issues []*issue.Issue) []SyncDataProblem {
+	var result []SyncDataProblem
+	for _, b := range issues {
+		for name, data := range b.Sync {
+			s, ok := SyncSchema(name)
+			if !ok {
+				continue
+			}
+			for _, p := range s.Check(data, false) {
+				result = append(result, SyncDataProblem{IssueID: b.ID, Provider: name, SchemaProblem: p})
+			}
+		}
+	}
+	slices.SortFunc(result, func(a, b SyncDataProblem) int {
+		return cmp.Or(cmp.Compare(a.IssueID, b.IssueID), cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.Key, b.Key))
+	})
+	return result
+}
+
+// FixSyncData renames the misspelled keys in the built-in providers' sync
+// data (see syncutil.Schema.Normalize) and saves each changed issue without
+// bumping updated_at. Returns the number of keys renamed.
+func FixSyncData(c *core.Core) (int, error) {
+	fixed := 0
+	for _, b := range c.AllUnordered() {
+		changed := false
+		for name, data := range b.Sync {
+			s, ok := SyncSchema(name)
+			if !ok {
+				continue
+			}
+			normalized, renames := s.Normalize(data)
+			if len(renames) == 0 {
+				continue
+			}
+			b.SetSync(name, normalized)
+			fixed += len(renames)
+			changed = true
+		}
+		if changed {
+			if err := c.SaveSyncOnly(b, nil); err != nil {
+				return fixed, err
+			}
+		}
+	}
+	return fixed, nil
+}
@@ -0,0 +1,116 @@
+package integration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestValidateLink(t *testing.T) {
+	b := &issue.Issue{ID: "sd-1", Sync: map[string]map[string]any{
+		"clickup": {"task_id": "old", "list": "123"},
+		"github":  {"prs": []any{7}},
+	}}
+
+	err := ValidateLink("clickup", b, "86a1b2c3", false)
+	if _, ok := errors.AsType[*syncutil.SchemaError](err); !ok {
+		t.Fatalf("ValidateLink() error = %v, want a *SchemaError for the unknown key", err)
+	}
+	if !strings.Contains(err.Error(), `unknown key "list"`) {
+		t.Errorf("error %q does not name the unknown key", err)
+	}
+	if err := ValidateLink("clickup", b, "86a1b2c3", true); err != nil {
+		t.Errorf("ValidateLink() with allowExtra = %v", err)
+	}
+
+	if err := ValidateLink("github", b, "42", false); err != nil {
+		t.Errorf("ValidateLink(github, 42) = %v", err)
+	}
+	if err := ValidateLink("github", b, "#42", true); err == nil {
+		t.Error("ValidateLink(github, #42) accepted a non-numeric issue number")
+	}
+	if err := ValidateLink("myext", b, "anything", false); err != nil {
+		t.Errorf("ValidateLink() for a provider without a schema = %v", err)
+	}
+}
+
+func TestCheckAndFixSyncData(t *testing.T) {
+	dir := t.TempDir()
+	c := core.New(dir, config.Default())
+	for _, b := range []*issue.Issue{
+		{ID: "sdok", Title: "Fine", Status: "ready", Sync: map[string]map[string]any{
+			"clickup": {"task_id": "abc", "synced_at": "2026-01-01T00:00:00Z"},
+			"myext":   {"Whatever": true},
+		}},
+		{ID: "sdcase", Title: "Case", Status: "ready", Sync: map[string]map[string]any{
+			"clickup": {"task_Id": "abc", "Synced-At": "2026-01-01T00:00:00Z"},
+		}},
+		{ID: "sdtyped", Title: "Typed", Status: "ready", Sync: map[string]map[string]any{
+			"github": {"issue_number": "abc", "issueNumber": 4, "color": "red"},
+		}},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, p := range CheckSyncData(c.All()) {
+		got = append(got, p.IssueID+" "+p.Key+" "+p.Rename)
+	}
+	want := []string{
+		"sdcase Synced-At synced_at",
+		"sdcase task_Id task_id",
+		"sdcase task_id ",
+		"sdtyped color ",
+		"sdtyped issueNumber issue_number",
+		"sdtyped issue_number ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("CheckSyncData() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	fixed, err := FixSyncData(c)
+	if err != nil {
+		t.Fatalf("FixSyncData() error = %v", err)
+	}
+	if fixed != 2 {
+		t.Errorf("FixSyncData() fixed %d keys, want 2", fixed)
+	}
+
+	reloaded := core.New(dir, config.Default())
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := reloaded.Get("sdcase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Sync["clickup"]["task_id"] != "abc" || b.Sync["clickup"]["synced_at"] != "2026-01-01T00:00:00Z" || len(b.Sync["clickup"]) != 2 {
+		t.Errorf("normalized sync data = %v", b.Sync["clickup"])
+	}
+	data, err := os.ReadFile(filepath.Join(dir, b.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "task_Id") {
+		t.Errorf("file still has the misspelled key:\n%s", data)
+	}
+
+	// The mistyped issue_number blocks the issueNumber rename and needs a
+	// person to sort out.
+	var left []string
+	for _, p := range CheckSyncData(reloaded.All()) {
+		left = append(left, p.IssueID+" "+p.Key)
+	}
+	if strings.Join(left, ",") != "sdtyped color,sdtyped issueNumber,sdtyped issue_number" {
+		t.Errorf("after fix, CheckSyncData() = %v", left)
+	}
+}
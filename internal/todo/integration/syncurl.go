@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// SyncLink is the web URL of one of an issue's sync entries.
+type SyncLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// SyncURL returns the web URL of b's sync entry name, or "" when it has
+// none. A provider integration that can build the URL itself (see
+// ExternalURL) wins; otherwise the sync_url_templates entry for name is
+// expanded against the entry's data.
+func SyncURL(b *issue.Issue, name string, cfg *config.Config) string {
+	data, ok := b.Sync[name]
+	if !ok {
+		return ""
+	}
+	if slices.Contains(Providers, name) {
+		var syncCfg map[string]map[string]any
+		if cfg != nil {
+			syncCfg = cfg.Sync
+		}
+		if url, err := ExternalURL(b, name, syncCfg); err == nil {
+			return url
+		}
+	}
+	if tmpl := cfg.SyncURLTemplate(name); tmpl != "" {
+		return config.ExpandSyncURL(tmpl, data)
+	}
+	return ""
+}
+
+// SyncLinks returns the URLs of b's sync entries that have one, sorted by
+// entry name.
+func SyncLinks(b *issue.Issue, cfg *config.Config) []SyncLink {
+	names := make([]string, 0, len(b.Sync))
+	for name := range b.Sync {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var links []SyncLink
+	for _, name := range names {
+		if url := SyncURL(b, name, cfg); url != "" {
+			links = append(links, SyncLink{Name: name, URL: url})
+		}
+	}
+	return links
+}
+
+// OpenURL returns the URL `todo open` and the TUI open key use for b: its
+// first provider link (see ExternalURL), falling back to the first entry a
+// sync_url_templates entry resolves. The error is ExternalURL's when
+// neither gives a URL.
+func OpenURL(b *issue.Issue, cfg *config.Config) (string, error) {
+	var syncCfg map[string]map[string]any
+	if cfg != nil {
+		syncCfg = cfg.Sync
+	}
+	url, err := ExternalURL(b, "", syncCfg)
+	if err == nil {
+		return url, nil
+	}
+	if links := SyncLinks(b, cfg); len(links) > 0 {
+		return links[0].URL, nil
+	}
+	return "", err
+}
+
+// SyncEntryURL is SyncURL for `todo open --sync`, with an error saying why
+// there is no URL.
+func SyncEntryURL(b *issue.Issue, name string, cfg *config.Config) (string, error) {
+	if _, ok := b.Sync[name]; !ok {
+		return "", fmt.Errorf("%s has no %q sync entry", b.ID, name)
+	}
+	if url := SyncURL(b, name, cfg); url != "" {
+		return url, nil
+	}
+	if cfg.SyncURLTemplate(name) == "" && !slices.Contains(Providers, name) {
+		return "", fmt.Errorf("no URL for %s's %q sync entry: add a sync_url_templates.%s entry", b.ID, name, name)
+	}
+	return "", fmt.Errorf("no URL for %s's %q sync entry: its data is missing a key the URL needs", b.ID, name)
+}
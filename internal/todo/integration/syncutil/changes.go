@@ -0,0 +1,47 @@
+package syncutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+// FieldChange describes one field a sync would push, with the local value
+// that would be written and the remote value it replaces.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Local  string `json:"local"`
+	Remote string `json:"remote"`
+}
+
+// Field names reported in FieldChange.Field.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldStatus      = "status"
+	FieldPriority    = "priority"
+	FieldType        = "type"
+	FieldLabels      = "labels"
+	FieldMilestone   = "milestone"
+	FieldDue         = "due"
+	FieldParent      = "parent"
+	FieldBlockedBy   = "blocked_by"
+)
+
+// DescriptionDigest returns a short, stable hash of a description so dry-run
+// diffs can show that a body changed without printing it.
+func DescriptionDigest(s string) string {
+	if s == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// JoinSorted renders a set of values (labels, edge targets) in a stable order.
+func JoinSorted(values []string) string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ", ")
+}
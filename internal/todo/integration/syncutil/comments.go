@@ -0,0 +1,190 @@
+package syncutil
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Sync data keys for comment state, shared by the providers.
+const (
+	SyncKeyComments       = "comments"
+	SyncKeyPulledComments = "pulled_comments"
+)
+
+// FieldComments is the FieldChange.Field a dry run reports pending comment
+// pushes under.
+const FieldComments = "comments"
+
+// RemoteComment is a comment on a provider's remote item.
+type RemoteComment struct {
+	ID        string
+	Author    string
+	Text      string
+	CreatedAt time.Time
+}
+
+// CommentAPI reads and posts the comments of one remote item.
+type CommentAPI interface {
+	// ListComments returns the item's comments, oldest first.
+	ListComments(ctx context.Context) ([]RemoteComment, error)
+	// CreateComment posts text as a new comment, returning its ID.
+	CreateComment(ctx context.Context, text string) (string, error)
+}
+
+// CommentState records which comments have crossed between an issue and its
+// remote item, so a re-sync neither posts nor copies one twice.
+type CommentState struct {
+	// Pushed maps the Key of each local comment posted to the remote
+	// comment it became.
+	Pushed map[string]string
+	// Pulled lists the remote comments copied into the issue.
+	Pulled []string
+}
+
+// ParseCommentState reads the comment state from a provider's sync data.
+func ParseCommentState(data map[string]any) CommentState {
+	var state CommentState
+	if pushed, ok := data[SyncKeyComments].(map[string]any); ok {
+		state.Pushed = make(map[string]string, len(pushed))
+		for key, id := range pushed {
+			state.Pushed[key] = fmt.Sprint(id)
+		}
+	}
+	state.Pulled = SyncStrings(data[SyncKeyPulledComments])
+	return state
+}
+
+// Store writes the state into a provider's sync data, removing the keys
+// when there is nothing to record.
+func (s CommentState) Store(data map[string]any) {
+	delete(data, SyncKeyComments)
+	delete(data, SyncKeyPulledComments)
+	if len(s.Pushed) > 0 {
+		pushed := make(map[string]any, len(s.Pushed))
+		for key, id := range s.Pushed {
+			pushed[key] = id
+		}
+		data[SyncKeyComments] = pushed
+	}
+	if len(s.Pulled) > 0 {
+		data[SyncKeyPulledComments] = s.Pulled
+	}
+}
+
+// CommentOptions configures SyncComments.
+type CommentOptions struct {
+	// Provider is the sync provider's name. Comments whose source it is
+	// were copied from it, so they are never posted back.
+	Provider string
+	// Pull copies remote comments the issue lacks into its Comments
+	// section.
+	Pull   bool
+	DryRun bool
+}
+
+// CommentResult is what SyncComments did, or in a dry run would do.
+type CommentResult struct {
+	Pushed int
+	Pulled int
+	// Body is the issue body with pulled comments appended.
+	Body  string
+	State CommentState
+}
+
+// Changes reports a dry run's pending comment pushes as a FieldChange.
+func (r CommentResult) Changes() []FieldChange {
+	if r.Pushed == 0 {
+		return nil
+	}
+	return []FieldChange{{Field: FieldComments, Local: fmt.Sprintf("%d new", r.Pushed)}}
+}
+
+// SyncComments posts the comments in body that have not been posted through
+// api yet, in the order they appear, then with opts.Pull copies the remote
+// comments the issue has not seen into body, oldest first. A remote comment
+// is matched by ID, or, for one a webhook already copied, by author and
+// text. Deleted comments stay deleted only on their own side. A dry run
+// counts the comments it would post without calling api. On error the
+// result still records the comments posted so far.
+func SyncComments(ctx context.Context, api CommentAPI, body string, state CommentState, opts CommentOptions) (CommentResult, error) {
+	result := CommentResult{Body: body, State: CommentState{Pushed: maps.Clone(state.Pushed), Pulled: slices.Clone(state.Pulled)}}
+	if result.State.Pushed == nil {
+		result.State.Pushed = make(map[string]string)
+	}
+	local, err := issue.ParseComments(body)
+	if err != nil {
+		return result, err
+	}
+
+	for _, c := range local {
+		if c.Source == opts.Provider || result.State.Pushed[c.Key()] != "" {
+			continue
+		}
+		if opts.DryRun {
+			result.Pushed++
+			continue
+		}
+		id, err := api.CreateComment(ctx, c.String())
+		if err != nil {
+			return result, fmt.Errorf("posting comment by %s: %w", c.Author, err)
+		}
+		result.State.Pushed[c.Key()] = id
+		result.Pushed++
+	}
+	if !opts.Pull || opts.DryRun {
+		return result, nil
+	}
+
+	remote, err := api.ListComments(ctx)
+	if err != nil {
+		return result, err
+	}
+	known := make(map[string]bool)
+	for _, id := range result.State.Pushed {
+		known[id] = true
+	}
+	for _, id := range result.State.Pulled {
+		known[id] = true
+	}
+	copied := make(map[string]bool)
+	for _, c := range local {
+		if c.Source == opts.Provider {
+			copied[c.Author+"\n"+c.Text] = true
+		}
+	}
+	slices.SortStableFunc(remote, func(a, b RemoteComment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	for _, rc := range remote {
+		if known[rc.ID] {
+			continue
+		}
+		known[rc.ID] = true
+		result.State.Pulled = append(result.State.Pulled, rc.ID)
+		if copied[rc.Author+"\n"+strings.TrimSpace(rc.Text)] {
+			continue
+		}
+		c := issue.Comment{Author: rc.Author, Source: opts.Provider, At: rc.CreatedAt.Truncate(time.Second), Text: rc.Text}
+		if result.Body, err = issue.AppendComment(result.Body, c); err != nil {
+			return result, err
+		}
+		result.Pulled++
+	}
+	return result, nil
+}
+
+// StripComments returns body without its Comments section, for providers
+// that sync comments as remote comments rather than as part of the
+// description. A body with more than one Comments section is returned
+// whole.
+func StripComments(body string) string {
+	stripped, err := issue.RemoveSection(body, issue.CommentsSection)
+	if err != nil {
+		return body
+	}
+	return stripped
+}
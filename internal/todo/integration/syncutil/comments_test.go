@@ -0,0 +1,71 @@
+package syncutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fakeComments is a CommentAPI that fails the post at index failAt.
+type fakeComments struct {
+	posted []string
+	failAt int
+}
+
+func (f *fakeComments) ListComments(context.Context) ([]RemoteComment, error) { return nil, nil }
+
+func (f *fakeComments) CreateComment(_ context.Context, text string) (string, error) {
+	if len(f.posted) == f.failAt {
+		return "", errors.New("rate limited")
+	}
+	f.posted = append(f.posted, text)
+	return fmt.Sprintf("c%d", len(f.posted)), nil
+}
+
+const commentBody = "Intro.\n\n## Comments\n\n" +
+	"**alice** (jig, 2026-01-01T10:00:00Z):\n\nOne\n\n" +
+	"**bob** (github, 2026-01-01T11:00:00Z):\n\nFrom GitHub\n\n" +
+	"**carol** (jig, 2026-01-01T12:00:00Z):\n\nTwo\n\n" +
+	"**dave** (jig, 2026-01-01T13:00:00Z):\n\nThree\n"
+
+func TestSyncCommentsPartialFailure(t *testing.T) {
+	api := &fakeComments{failAt: 1}
+	opts := CommentOptions{Provider: "github"}
+	result, err := SyncComments(context.Background(), api, commentBody, CommentState{}, opts)
+	if err == nil {
+		t.Fatal("SyncComments() error = nil, want the failed post")
+	}
+	if len(result.State.Pushed) != 1 || result.State.Pushed["2026-01-01T10:00:00Z alice"] != "c1" {
+		t.Fatalf("State.Pushed = %v, want alice's comment kept", result.State.Pushed)
+	}
+
+	api.failAt = -1
+	result, err = SyncComments(context.Background(), api, commentBody, result.State, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pushed != 2 || len(api.posted) != 3 {
+		t.Errorf("retry pushed %d, posted %q; want carol and dave once each", result.Pushed, api.posted)
+	}
+}
+
+func TestSyncCommentsDryRun(t *testing.T) {
+	api := &fakeComments{failAt: 0}
+	result, err := SyncComments(context.Background(), api, commentBody, CommentState{}, CommentOptions{Provider: "github", DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Pushed != 3 || len(result.State.Pushed) != 0 {
+		t.Errorf("dry run = %+v, want 3 pending and nothing recorded", result)
+	}
+	if got := result.Changes(); len(got) != 1 || got[0].Local != "3 new" {
+		t.Errorf("Changes() = %+v", got)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	if got := StripComments(commentBody); got != "Intro.\n" {
+		t.Errorf("StripComments() = %q, want the intro only", got)
+	}
+}
@@ -0,0 +1,216 @@
+package syncutil
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+// LabelConfig controls how tags become provider labels. It is read from the
+// labels key of a provider's sync section:
+//
+//	sync:
+//	  github:
+//	    repo: owner/repo
+//	    labels:
+//	      colors: [d73a4a, 0075ca, a2eeef]
+//	      map:
+//	        bug: "type: bug"
+//
+// Colors is the palette new labels are created with; each label always gets
+// the same color from it. Without one the provider's default is used. Map
+// renames a tag on the way out and back in; unmapped tags keep their name.
+type LabelConfig struct {
+	Colors []string
+	Map    map[string]string
+}
+
+// ParseLabelConfig reads the labels key of a provider's sync section. It
+// returns nil when there is none, and an error naming the setting when it is
+// malformed.
+func ParseLabelConfig(provider string, cfgMap map[string]any) (*LabelConfig, error) {
+	v, ok := cfgMap["labels"]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("sync.%s.labels: must be a mapping", provider)
+	}
+
+	c := &LabelConfig{}
+	for key, val := range m {
+		switch key {
+		case "colors":
+			items, ok := val.([]any)
+			if !ok {
+				return nil, fmt.Errorf("sync.%s.labels.colors: must be a list", provider)
+			}
+			for _, item := range items {
+				s, ok := item.(string)
+				if !ok || !isHexColor(strings.TrimPrefix(s, "#")) {
+					return nil, fmt.Errorf("sync.%s.labels.colors: %v is not a hex color like d73a4a", provider, item)
+				}
+				c.Colors = append(c.Colors, strings.ToLower(strings.TrimPrefix(s, "#")))
+			}
+		case "map":
+			mm, ok := val.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("sync.%s.labels.map: must be a mapping of tag to label", provider)
+			}
+			c.Map = make(map[string]string, len(mm))
+			labels := make(map[string]string, len(mm))
+			for tag, l := range mm {
+				label, ok := l.(string)
+				if !ok || label == "" {
+					return nil, fmt.Errorf("sync.%s.labels.map.%s: must be a label name", provider, tag)
+				}
+				if other, dup := labels[label]; dup {
+					return nil, fmt.Errorf("sync.%s.labels.map: tags %q and %q both map to label %q", provider, min(tag, other), max(tag, other), label)
+				}
+				labels[label] = tag
+				c.Map[tag] = label
+			}
+		default:
+			return nil, fmt.Errorf("sync.%s.labels: unknown key %q (must be colors or map)", provider, key)
+		}
+	}
+	return c, nil
+}
+
+// isHexColor reports whether s is a six-digit hex color.
+func isHexColor(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Label returns the provider label for a tag.
+func (c *LabelConfig) Label(tag string) string {
+	if c != nil {
+		if label, ok := c.Map[tag]; ok {
+			return label
+		}
+	}
+	return tag
+}
+
+// Labels returns the provider labels for tags, in order.
+func (c *LabelConfig) Labels(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	labels := make([]string, len(tags))
+	for i, tag := range tags {
+		labels[i] = c.Label(tag)
+	}
+	return labels
+}
+
+// Tag returns the tag a provider label stands for, the inverse of Label.
+func (c *LabelConfig) Tag(label string) string {
+	if c != nil {
+		for tag, l := range c.Map {
+			if l == label {
+				return tag
+			}
+		}
+	}
+	return label
+}
+
+// Color returns the palette color for a new label, or fallback when there
+// is no palette.
+func (c *LabelConfig) Color(label, fallback string) string {
+	if c == nil || len(c.Colors) == 0 {
+		return fallback
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(label))
+	return c.Colors[h.Sum32()%uint32(len(c.Colors))]
+}
+
+// LabelPlan is the label set a sync writes to a remote issue and how it
+// differs from what is there.
+type LabelPlan struct {
+	// Labels is the full set to write: the remote labels jig does not
+	// manage, followed by the wanted ones.
+	Labels  []string
+	Added   []string
+	Removed []string
+	// Managed is the set to record as jig's for the next sync.
+	Managed []string
+}
+
+// PlanLabels works out the labels to push when the remote issue has
+// current, jig last pushed managed, and the tags now ask for want. Only
+// labels jig pushed before are removed, so labels added on the provider
+// side survive. A wanted label already on the remote becomes jig's.
+func PlanLabels(current, managed, want []string) LabelPlan {
+	var p LabelPlan
+	for _, l := range current {
+		if slices.Contains(managed, l) && !slices.Contains(want, l) {
+			p.Removed = append(p.Removed, l)
+			continue
+		}
+		p.Labels = append(p.Labels, l)
+	}
+	for _, l := range want {
+		if slices.Contains(p.Managed, l) {
+			continue
+		}
+		p.Managed = append(p.Managed, l)
+		if !slices.Contains(current, l) {
+			p.Added = append(p.Added, l)
+			p.Labels = append(p.Labels, l)
+		}
+	}
+	return p
+}
+
+// Changed reports whether the plan adds or removes any label.
+func (p LabelPlan) Changed() bool {
+	return len(p.Added) > 0 || len(p.Removed) > 0
+}
+
+// SyncStrings reads a list of strings stored in sync data, which holds
+// []string until it is written and []any once it is read back from disk.
+func SyncStrings(v any) []string {
+	switch v := v.(type) {
+	case []string:
+		return slices.Clone(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// IsAlreadyExists reports whether a create request failed because the
+// resource already exists, as when another sync created it first. GitHub
+// answers 422 for every validation failure, so a 422 only counts with an
+// already_exists code or message.
+func IsAlreadyExists(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"http 409", "already exists", "already_exists"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
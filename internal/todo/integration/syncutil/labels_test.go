@@ -0,0 +1,128 @@
+package syncutil
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPlanLabels(t *testing.T) {
+	tests := []struct {
+		name                   string
+		current, managed, want []string
+		labels, added, removed []string
+	}{
+		{
+			name:   "new issue",
+			want:   []string{"a", "b"},
+			labels: []string{"a", "b"},
+			added:  []string{"a", "b"},
+		},
+		{
+			name:    "keeps labels jig did not push",
+			current: []string{"human", "old"},
+			managed: []string{"old"},
+			want:    []string{"new"},
+			labels:  []string{"human", "new"},
+			added:   []string{"new"},
+			removed: []string{"old"},
+		},
+		{
+			name:    "nothing recorded removes nothing",
+			current: []string{"old"},
+			want:    []string{"new"},
+			labels:  []string{"old", "new"},
+			added:   []string{"new"},
+		},
+		{
+			name:    "wanted label already on the remote",
+			current: []string{"a"},
+			want:    []string{"a"},
+			labels:  []string{"a"},
+		},
+		{
+			name:    "managed label removed on the provider stays off",
+			current: []string{"human"},
+			managed: []string{"gone", "human"},
+			want:    []string{"human"},
+			labels:  []string{"human"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := PlanLabels(tt.current, tt.managed, tt.want)
+			if !slices.Equal(p.Labels, tt.labels) {
+				t.Errorf("Labels = %v, want %v", p.Labels, tt.labels)
+			}
+			if !slices.Equal(p.Added, tt.added) || !slices.Equal(p.Removed, tt.removed) {
+				t.Errorf("Added %v Removed %v, want %v %v", p.Added, p.Removed, tt.added, tt.removed)
+			}
+			if !slices.Equal(p.Managed, tt.want) {
+				t.Errorf("Managed = %v, want %v", p.Managed, tt.want)
+			}
+			if p.Changed() != (len(tt.added)+len(tt.removed) > 0) {
+				t.Errorf("Changed() = %v", p.Changed())
+			}
+		})
+	}
+}
+
+func TestParseLabelConfig(t *testing.T) {
+	c, err := ParseLabelConfig("github", map[string]any{"labels": map[string]any{
+		"colors": []any{"#D73A4A", "0075ca"},
+		"map":    map[string]any{"bug": "type: bug"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Labels([]string{"bug", "ui"}); !slices.Equal(got, []string{"type: bug", "ui"}) {
+		t.Errorf("Labels = %v", got)
+	}
+	if got := c.Tag("type: bug"); got != "bug" {
+		t.Errorf("Tag(type: bug) = %q, want bug", got)
+	}
+	if got := c.Tag("ui"); got != "ui" {
+		t.Errorf("Tag(ui) = %q, want ui", got)
+	}
+	color := c.Color("ui", "ededed")
+	if !slices.Contains(c.Colors, color) || c.Color("ui", "ededed") != color {
+		t.Errorf("Color(ui) = %q, want a stable pick from %v", color, c.Colors)
+	}
+
+	var none *LabelConfig
+	if none.Label("bug") != "bug" || none.Color("bug", "ededed") != "ededed" {
+		t.Error("a missing config should pass tags through with the default color")
+	}
+	if c, err := ParseLabelConfig("github", map[string]any{}); c != nil || err != nil {
+		t.Errorf("no labels key = %v, %v; want nil, nil", c, err)
+	}
+
+	for name, labels := range map[string]any{
+		"not a mapping":   "red",
+		"unknown key":     map[string]any{"palette": []any{}},
+		"bad color":       map[string]any{"colors": []any{"red"}},
+		"bad label":       map[string]any{"map": map[string]any{"bug": 3}},
+		"duplicate label": map[string]any{"map": map[string]any{"bug": "defect", "defect": "defect"}},
+	} {
+		if _, err := ParseLabelConfig("github", map[string]any{"labels": labels}); err == nil || !strings.Contains(err.Error(), "sync.github.labels") {
+			t.Errorf("%s: err = %v, want one naming sync.github.labels", name, err)
+		}
+	}
+}
+
+func TestIsAlreadyExists(t *testing.T) {
+	for _, msg := range []string{
+		"creating label: API error (HTTP 422): Validation Failed (already_exists)",
+		"creating space tag: HTTP 409: conflict",
+		"creating space tag: API error: Tag already exists (code: TAG_001)",
+	} {
+		if !IsAlreadyExists(errors.New(msg)) {
+			t.Errorf("IsAlreadyExists(%q) = false", msg)
+		}
+	}
+	if IsAlreadyExists(errors.New("HTTP 500: boom")) || IsAlreadyExists(nil) ||
+		IsAlreadyExists(errors.New("creating label: API error (HTTP 422): Validation Failed (invalid)")) {
+		t.Error("IsAlreadyExists should only match conflicts")
+	}
+}
@@ -0,0 +1,105 @@
+package syncutil
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/toba/jig/internal/todo/issue"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency is how many issues a sync pushes at once when the
+// provider section sets no concurrency.
+const DefaultConcurrency = 4
+
+// ParseConcurrency reads the concurrency key of a provider's sync section,
+// returning DefaultConcurrency when it is unset.
+func ParseConcurrency(provider string, m map[string]any) (int, error) {
+	v, ok := m["concurrency"]
+	if !ok {
+		return DefaultConcurrency, nil
+	}
+	var n int
+	switch v := v.(type) {
+	case int:
+		n = v
+	case float64:
+		n = int(v)
+		if float64(n) != v {
+			n = 0
+		}
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("sync.%s.concurrency: must be a whole number of at least 1, got %v", provider, v)
+	}
+	return n, nil
+}
+
+// Layers groups issues so that every issue's parent, when the parent is in
+// the batch too, is in an earlier layer. Syncing one layer after another
+// means a parent exists remotely before any child that links to it. Issues
+// keep their relative order within a layer; a parent cycle is cut where the
+// walk up the chain first revisits an issue.
+func Layers(issues []*issue.Issue) [][]*issue.Issue {
+	byID := make(map[string]*issue.Issue, len(issues))
+	for _, b := range issues {
+		byID[b.ID] = b
+	}
+	depth := make(map[string]int, len(issues))
+	var depthOf func(b *issue.Issue, seen map[string]bool) int
+	depthOf = func(b *issue.Issue, seen map[string]bool) int {
+		if d, ok := depth[b.ID]; ok {
+			return d
+		}
+		d := 0
+		if parent, ok := byID[b.Parent]; ok && !seen[parent.ID] {
+			seen[b.ID] = true
+			d = depthOf(parent, seen) + 1
+		}
+		depth[b.ID] = d
+		return d
+	}
+
+	var layers [][]*issue.Issue
+	for _, b := range issues {
+		d := depthOf(b, map[string]bool{})
+		for len(layers) <= d {
+			layers = append(layers, nil)
+		}
+		layers[d] = append(layers[d], b)
+	}
+	return layers
+}
+
+// RunLayers calls fn for every issue in layers, with at most concurrency
+// calls running at once. A layer starts only after the previous one has
+// finished. When failFast is set and fn returns false, no further calls are
+// started, though those already running finish. It reports whether every
+// issue was run.
+func RunLayers(layers [][]*issue.Issue, concurrency int, failFast bool, fn func(*issue.Issue) bool) bool {
+	var stopped atomic.Bool
+	g := new(errgroup.Group)
+	g.SetLimit(max(1, concurrency))
+	for _, layer := range layers {
+		for _, b := range layer {
+			if stopped.Load() {
+				break
+			}
+			g.Go(func() error {
+				// Go may have waited for a slot while the stop was set.
+				if stopped.Load() {
+					return nil
+				}
+				if !fn(b) && failFast {
+					stopped.Store(true)
+				}
+				return nil
+			})
+		}
+		_ = g.Wait()
+		if stopped.Load() {
+			return false
+		}
+	}
+	return true
+}
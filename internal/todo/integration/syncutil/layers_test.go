@@ -0,0 +1,168 @@
+package syncutil
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// recordingProvider stands in for a provider's per-issue push: it records
+// when each call starts and finishes and how many overlap.
+type recordingProvider struct {
+	latency time.Duration
+	fail    map[string]bool
+
+	mu       sync.Mutex
+	started  map[string]int
+	finished map[string]int
+	seq      int
+	inFlight int
+	peak     int
+}
+
+func newRecordingProvider(latency time.Duration) *recordingProvider {
+	return &recordingProvider{latency: latency, started: map[string]int{}, finished: map[string]int{}}
+}
+
+func (p *recordingProvider) push(b *issue.Issue) bool {
+	p.mu.Lock()
+	p.seq++
+	p.started[b.ID] = p.seq
+	p.inFlight++
+	p.peak = max(p.peak, p.inFlight)
+	p.mu.Unlock()
+
+	time.Sleep(p.latency)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seq++
+	p.finished[b.ID] = p.seq
+	p.inFlight--
+	return !p.fail[b.ID]
+}
+
+func layerIDs(layers [][]*issue.Issue) [][]string {
+	ids := make([][]string, len(layers))
+	for i, layer := range layers {
+		for _, b := range layer {
+			ids[i] = append(ids[i], b.ID)
+		}
+	}
+	return ids
+}
+
+func TestLayers(t *testing.T) {
+	issues := []*issue.Issue{
+		{ID: "grandchild", Parent: "child"},
+		{ID: "child", Parent: "root"},
+		{ID: "root"},
+		{ID: "orphan", Parent: "not-in-batch"},
+		{ID: "loop-a", Parent: "loop-b"},
+		{ID: "loop-b", Parent: "loop-a"},
+	}
+	got := layerIDs(Layers(issues))
+	want := [][]string{
+		{"root", "orphan", "loop-b"},
+		{"child", "loop-a"},
+		{"grandchild"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Layers() = %v, want %v", got, want)
+	}
+}
+
+func TestRunLayersParentsFirst(t *testing.T) {
+	var issues []*issue.Issue
+	for _, root := range []string{"a", "b", "c"} {
+		issues = append(issues, &issue.Issue{ID: root})
+		for _, child := range []string{"1", "2"} {
+			issues = append(issues, &issue.Issue{ID: root + child, Parent: root})
+			issues = append(issues, &issue.Issue{ID: root + child + "x", Parent: root + child})
+		}
+	}
+
+	p := newRecordingProvider(5 * time.Millisecond)
+	if !RunLayers(Layers(issues), 4, false, p.push) {
+		t.Fatal("RunLayers() = false, want every issue run")
+	}
+	if len(p.finished) != len(issues) {
+		t.Fatalf("pushed %d issues, want %d", len(p.finished), len(issues))
+	}
+	for _, b := range issues {
+		if b.Parent != "" && p.started[b.ID] < p.finished[b.Parent] {
+			t.Errorf("%s started before its parent %s finished", b.ID, b.Parent)
+		}
+	}
+	if p.peak < 2 {
+		t.Errorf("peak concurrent pushes = %d, want calls to overlap", p.peak)
+	}
+	if p.peak > 4 {
+		t.Errorf("peak concurrent pushes = %d, want at most 4", p.peak)
+	}
+}
+
+func TestRunLayersSerial(t *testing.T) {
+	issues := []*issue.Issue{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	p := newRecordingProvider(time.Millisecond)
+	RunLayers(Layers(issues), 1, false, p.push)
+	if p.peak != 1 {
+		t.Errorf("peak concurrent pushes = %d, want 1", p.peak)
+	}
+}
+
+func TestRunLayersFailFast(t *testing.T) {
+	issues := []*issue.Issue{{ID: "bad"}, {ID: "b"}, {ID: "c"}, {ID: "child", Parent: "bad"}}
+
+	p := newRecordingProvider(0)
+	p.fail = map[string]bool{"bad": true}
+	if RunLayers(Layers(issues), 1, true, p.push) {
+		t.Error("RunLayers(failFast) = true, want stopped")
+	}
+	if len(p.started) != 1 {
+		t.Errorf("started %d pushes after the first error, want none", len(p.started)-1)
+	}
+
+	p = newRecordingProvider(0)
+	p.fail = map[string]bool{"bad": true}
+	if !RunLayers(Layers(issues), 1, false, p.push) {
+		t.Error("RunLayers() = false, want every issue run without failFast")
+	}
+	if len(p.started) != len(issues) {
+		t.Errorf("started %d pushes, want %d", len(p.started), len(issues))
+	}
+}
+
+func TestParseConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    int
+		wantErr bool
+	}{
+		{name: "unset", want: DefaultConcurrency},
+		{name: "int", value: 8, want: 8},
+		{name: "float from JSON", value: 2.0, want: 2},
+		{name: "zero", value: 0, wantErr: true},
+		{name: "fraction", value: 1.5, wantErr: true},
+		{name: "string", value: "4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := map[string]any{}
+			if tt.value != nil {
+				m["concurrency"] = tt.value
+			}
+			got, err := ParseConcurrency("github", m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConcurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseConcurrency() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -41,6 +41,24 @@ type RequestHooks struct {
 	HandleAPIError func(statusCode int, body []byte) error
 }
 
+// APIError is an error response from a provider's API. Its message is the
+// client's summary of the response (Err) when there is one, else the status
+// and body; Body keeps the full response for reports that show it.
+type APIError struct {
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *APIError) Unwrap() error { return e.Err }
+
 // DoWithRetry executes an HTTP request with retry logic for transient and rate-limit errors.
 // It buffers the request body so it can be replayed on retries, applies exponential backoff
 // with jitter, and delegates client-specific auth/rate-limit/error handling to hooks.
@@ -121,11 +139,11 @@ func DoWithRetry(httpClient *http.Client, req *http.Request, cfg RetryConfig, ho
 			// Try client-specific error parsing
 			if hooks.HandleAPIError != nil {
 				if apiErr := hooks.HandleAPIError(resp.StatusCode, body); apiErr != nil {
-					return apiErr
+					return &APIError{StatusCode: resp.StatusCode, Body: string(body), Err: apiErr}
 				}
 			}
 
-			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+			return &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 		}
 
 		if result != nil && len(body) > 0 {
@@ -0,0 +1,223 @@
+package syncutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// ValueKind is the kind of value a sync data key holds.
+type ValueKind int
+
+const (
+	KindString ValueKind = iota // a non-empty string
+	KindNumber                  // a positive integer, as a number or a string of digits
+	KindTime                    // an RFC 3339 timestamp
+	KindList                    // a list
+	KindMap                     // a mapping
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case KindNumber:
+		return "a number"
+	case KindTime:
+		return "an RFC 3339 timestamp"
+	case KindList:
+		return "a list"
+	case KindMap:
+		return "a mapping"
+	default:
+		return "a string"
+	}
+}
+
+// Schema describes the sync data a provider keeps on an issue.
+type Schema struct {
+	Provider string
+	// Keys maps each key the provider reads or writes to its value kind.
+	Keys map[string]ValueKind
+	// Required keys must be present whenever the provider has sync data.
+	Required []string
+	// LinkKey is the key holding the external ID `sync link` records.
+	LinkKey string
+}
+
+// SchemaProblem is one thing wrong with a provider's sync data.
+type SchemaProblem struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+	// Rename is the known key an unknown Key is a spelling variant of, which
+	// Normalize renames it to.
+	Rename string `json:"rename,omitempty"`
+}
+
+// SchemaError is sync data that does not match its provider's schema.
+type SchemaError struct {
+	Provider string
+	Problems []SchemaProblem
+}
+
+func (e *SchemaError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.Message
+	}
+	return fmt.Sprintf("invalid %s sync data: %s", e.Provider, strings.Join(msgs, "; "))
+}
+
+// Check returns what is wrong with data, sorted by key: required keys that
+// are missing, known keys holding the wrong kind of value, and, unless
+// allowExtra, keys the provider does not know.
+func (s *Schema) Check(data map[string]any, allowExtra bool) []SchemaProblem {
+	var problems []SchemaProblem
+	for _, key := range s.Required {
+		if _, ok := data[key]; !ok {
+			problems = append(problems, SchemaProblem{Key: key, Message: fmt.Sprintf("missing required key %s", key)})
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		kind, known := s.Keys[key]
+		switch {
+		case known && !kind.matches(data[key]):
+			problems = append(problems, SchemaProblem{
+				Key:     key,
+				Message: fmt.Sprintf("%s must be %s, got %s", key, kind, describeValue(data[key])),
+			})
+		case !known && !allowExtra:
+			p := SchemaProblem{Key: key, Message: fmt.Sprintf("unknown key %q", key)}
+			if rename := s.normalTable()[normalKey(key)]; rename != "" {
+				p.Rename = rename
+				p.Message += fmt.Sprintf(" (did you mean %q?)", rename)
+			} else if suggestion := config.Suggest(key, slices.Sorted(maps.Keys(s.Keys))); suggestion != "" {
+				p.Message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			problems = append(problems, p)
+		}
+	}
+	return problems
+}
+
+// Validate returns a *SchemaError listing the problems Check finds in data,
+// or nil when there are none.
+func (s *Schema) Validate(data map[string]any, allowExtra bool) error {
+	if problems := s.Check(data, allowExtra); len(problems) > 0 {
+		return &SchemaError{Provider: s.Provider, Problems: problems}
+	}
+	return nil
+}
+
+// Normalize returns a copy of data with each unknown key that differs from a
+// known one only in case or separators (task_Id, taskId, task-id) renamed to
+// the known key, and the renames made (old key to new). A variant is left
+// alone when the known key is already set.
+func (s *Schema) Normalize(data map[string]any) (map[string]any, map[string]string) {
+	table := s.normalTable()
+	out := maps.Clone(data)
+	var renames map[string]string
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if _, known := s.Keys[key]; known {
+			continue
+		}
+		target := table[normalKey(key)]
+		if _, taken := out[target]; target == "" || taken {
+			continue
+		}
+		out[target] = out[key]
+		delete(out, key)
+		if renames == nil {
+			renames = map[string]string{}
+		}
+		renames[key] = target
+	}
+	return out, renames
+}
+
+// normalTable maps the normalized spelling of each known key to the key.
+func (s *Schema) normalTable() map[string]string {
+	table := make(map[string]string, len(s.Keys))
+	for key := range s.Keys {
+		table[normalKey(key)] = key
+	}
+	return table
+}
+
+// normalKey folds case and drops the separators people use in place of '_'.
+func normalKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+}
+
+func (k ValueKind) matches(v any) bool {
+	switch k {
+	case KindNumber:
+		switch v := v.(type) {
+		case int:
+			return v > 0
+		case int64:
+			return v > 0
+		case uint64:
+			return v > 0
+		case float64:
+			return v > 0 && v == math.Trunc(v)
+		case json.Number:
+			n, err := v.Int64()
+			return err == nil && n > 0
+		case string:
+			n, err := strconv.Atoi(v)
+			return err == nil && n > 0
+		}
+		return false
+	case KindTime:
+		switch v := v.(type) {
+		case time.Time:
+			return true
+		case string:
+			_, err := time.Parse(time.RFC3339, v)
+			return err == nil
+		}
+		return false
+	case KindList:
+		switch v.(type) {
+		case []any, []string:
+			return true
+		}
+		return false
+	case KindMap:
+		switch v.(type) {
+		case map[string]any, map[any]any:
+			return true
+		}
+		return false
+	default:
+		s, ok := v.(string)
+		return ok && s != ""
+	}
+}
+
+// describeValue renders a rejected value for an error message.
+func describeValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		return "a list"
+	case map[string]any, map[any]any:
+		return "a mapping"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,84 @@
+package syncutil
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	Provider: "test",
+	Keys: map[string]ValueKind{
+		"task_id":   KindString,
+		"number":    KindNumber,
+		"synced_at": KindTime,
+		"prs":       KindList,
+		"states":    KindMap,
+	},
+	Required: []string{"task_id"},
+	LinkKey:  "task_id",
+}
+
+func TestSchemaCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]any
+		allowExtra bool
+		want       []string // messages
+	}{
+		{"valid", map[string]any{
+			"task_id": "a", "number": 3, "synced_at": "2026-01-01T00:00:00Z",
+			"prs": []any{1}, "states": map[string]any{},
+		}, false, nil},
+		{"numeric forms", map[string]any{"task_id": "a", "number": "12"}, false, nil},
+		{"json number", map[string]any{"task_id": "a", "number": json.Number("12")}, false, nil},
+		{"time value", map[string]any{"task_id": "a", "synced_at": time.Now()}, false, nil},
+		{"missing required", map[string]any{}, false, []string{"missing required key task_id"}},
+		{"wrong kinds", map[string]any{"task_id": "", "number": 1.5, "synced_at": "yesterday"}, false, []string{
+			`number must be a number, got 1.5`,
+			`synced_at must be an RFC 3339 timestamp, got "yesterday"`,
+			`task_id must be a string, got ""`,
+		}},
+		{"variant", map[string]any{"task_id": "a", "Synced_At": "x"}, false, []string{`unknown key "Synced_At" (did you mean "synced_at"?)`}},
+		{"near miss", map[string]any{"task_id": "a", "numbr": 1}, false, []string{`unknown key "numbr" (did you mean "number"?)`}},
+		{"unrelated", map[string]any{"task_id": "a", "zzzzzz": 1}, false, []string{`unknown key "zzzzzz"`}},
+		{"allow extra", map[string]any{"task_id": "a", "zzzzzz": 1}, true, nil},
+		{"allow extra still checks kinds", map[string]any{"task_id": "a", "prs": "1"}, true, []string{`prs must be a list, got "1"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := testSchema.Check(tt.data, tt.allowExtra)
+			if len(problems) != len(tt.want) {
+				t.Fatalf("Check() = %v, want %v", problems, tt.want)
+			}
+			for i, p := range problems {
+				if p.Message != tt.want[i] {
+					t.Errorf("problem %d = %q, want %q", i, p.Message, tt.want[i])
+				}
+			}
+			if err := testSchema.Validate(tt.data, tt.allowExtra); (err != nil) != (len(tt.want) > 0) {
+				t.Errorf("Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestSchemaNormalize(t *testing.T) {
+	data := map[string]any{"Task-ID": "a", "syncedAt": "t", "number": 1, "NUMBER": 2, "other": true}
+	got, renames := testSchema.Normalize(data)
+
+	want := map[string]any{"task_id": "a", "synced_at": "t", "number": 1, "NUMBER": 2, "other": true}
+	if !maps.Equal(got, want) {
+		t.Errorf("Normalize() = %v, want %v", got, want)
+	}
+	if !maps.Equal(renames, map[string]string{"Task-ID": "task_id", "syncedAt": "synced_at"}) {
+		t.Errorf("renames = %v", renames)
+	}
+	if _, ok := data["task_id"]; ok {
+		t.Error("Normalize() modified its input")
+	}
+	if _, renames := testSchema.Normalize(want); len(renames) != 0 {
+		t.Errorf("second Normalize() renamed %v", renames)
+	}
+}
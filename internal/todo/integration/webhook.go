@@ -0,0 +1,523 @@
+package integration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/github"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Paths served by NewWebhookHandler.
+const (
+	WebhookPathGitHub  = "/webhooks/github"
+	WebhookPathClickUp = "/webhooks/clickup"
+)
+
+// Body sections inbound webhook edits append to. The history section is the
+// one moveIssue records moves in.
+const (
+	WebhookCommentsSection = issue.CommentsSection
+	WebhookHistorySection  = "History"
+)
+
+// Actions reported in a WebhookResult.
+const (
+	WebhookUpdated   = "updated"
+	WebhookImported  = "imported"
+	WebhookUnchanged = "unchanged"
+	WebhookIgnored   = "ignored"
+	WebhookDuplicate = "duplicate"
+)
+
+// maxWebhookBody caps the size of a delivery. Issue and comment events are
+// far smaller.
+const maxWebhookBody = 5 << 20
+
+// WebhookResult is the JSON a webhook endpoint answers a delivery with.
+type WebhookResult struct {
+	Action  string   `json:"action"`
+	IssueID string   `json:"issue_id,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// InboundComment is a comment posted on the remote item.
+type InboundComment struct {
+	Author string
+	Text   string
+}
+
+// InboundChange is a webhook delivery in provider-neutral form: the remote
+// edits to apply to the local issue linked to ExternalID.
+type InboundChange struct {
+	DeliveryID string
+	ExternalID string   // GitHub issue number or ClickUp task ID
+	LocalID    string   // issue ID the remote item names itself, if any
+	Title      string   // new title; "" leaves it alone
+	Status     string   // new provider status; "" leaves it alone
+	Body       string   // remote description, used only when importing
+	Labels     []string // remote labels, used only when importing
+	Comments   []InboundComment
+	Created    bool // the remote item was just created; only these are imported
+}
+
+// webhookProvider adapts one provider's deliveries to InboundChanges.
+type webhookProvider struct {
+	name       string
+	path       string
+	secret     string
+	autoImport bool
+	// verify checks the delivery's signature.
+	verify func(h http.Header, body []byte, secret string) bool
+	// parse decodes a delivery, returning nil for events inbound sync
+	// ignores.
+	parse func(h http.Header, body []byte) (*InboundChange, error)
+	// localStatus maps a provider status to an issue status, given the
+	// issue's current one.
+	localStatus func(status, current string) (string, bool)
+	// linkedTo returns the external ID b is linked to, or "".
+	linkedTo func(b *issue.Issue) string
+	// linkKey and syncedAtKey are the sync data keys holding the external
+	// ID and the last sync time.
+	linkKey     string
+	syncedAtKey string
+	// labels maps remote labels back to tags on import; labelsKey is the
+	// sync data key recording them as jig's.
+	labels    *syncutil.LabelConfig
+	labelsKey string
+}
+
+// WebhookOptions configure NewWebhookHandler.
+type WebhookOptions struct {
+	// Log receives a line for each delivery that changed nothing because
+	// its remote item is not linked to an issue, and for each remote status
+	// that could not be applied. Nil discards them.
+	Log io.Writer
+}
+
+// NewWebhookHandler serves inbound sync webhooks for every provider with a
+// secret configured (see config.WebhookSecret): GitHub at WebhookPathGitHub
+// and ClickUp at WebhookPathClickUp. Each delivery's signature is checked,
+// deliveries already seen are dropped, and the remote edits are applied to
+// the linked issue and noted in its History section. With
+// sync.<provider>.webhook_auto_import set, a newly created remote item that
+// is not linked yet is imported as a new issue.
+func NewWebhookHandler(c *core.Core, opts WebhookOptions) (http.Handler, error) {
+	cfg := c.Config()
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	providers, err := webhookProviders(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no webhook secret configured: set $%sGITHUB or $%sCLICKUP, or webhook_secrets in %s",
+			config.WebhookSecretEnvPrefix, config.WebhookSecretEnvPrefix, config.LocalConfigFileName)
+	}
+
+	log := opts.Log
+	if log == nil {
+		log = io.Discard
+	}
+	deliveries, err := loadDeliveryLog(c.Root())
+	if err != nil {
+		return nil, err
+	}
+	var mu sync.Mutex
+	mux := http.NewServeMux()
+	for _, p := range providers {
+		mux.Handle(p.path, &webhookHandler{core: c, provider: p, deliveries: deliveries, log: log, mu: &mu})
+	}
+	return mux, nil
+}
+
+// WebhookPaths returns the paths NewWebhookHandler serves under cfg, for
+// startup messages.
+func WebhookPaths(cfg *config.Config) []string {
+	providers, _ := webhookProviders(cfg)
+	paths := make([]string, len(providers))
+	for i, p := range providers {
+		paths[i] = p.path
+	}
+	return paths
+}
+
+// webhookProviders returns the inbound webhook adapters for the providers
+// with a secret configured.
+func webhookProviders(cfg *config.Config) ([]*webhookProvider, error) {
+	var providers []*webhookProvider
+	if secret := cfg.WebhookSecret(github.SyncName); secret != "" {
+		labels, err := syncutil.ParseLabelConfig(github.SyncName, cfg.SyncConfig(github.SyncName))
+		if err != nil {
+			return nil, providerError(github.SyncName, err)
+		}
+		providers = append(providers, &webhookProvider{
+			name:       github.SyncName,
+			path:       WebhookPathGitHub,
+			secret:     secret,
+			autoImport: webhookAutoImport(cfg, github.SyncName),
+			verify: func(h http.Header, body []byte, secret string) bool {
+				return github.VerifyWebhookSignature(secret, body, h.Get(github.WebhookSignatureHeader))
+			},
+			parse: parseGitHubDelivery,
+			localStatus: func(status, current string) (string, bool) {
+				return github.LocalStatus(status, current), true
+			},
+			linkedTo: func(b *issue.Issue) string {
+				if n, ok := github.GetSyncInt(b, github.SyncKeyIssueNumber); ok {
+					return strconv.Itoa(n)
+				}
+				return ""
+			},
+			linkKey:     github.SyncKeyIssueNumber,
+			syncedAtKey: github.SyncKeySyncedAt,
+			labels:      labels,
+			labelsKey:   github.SyncKeyLabels,
+		})
+	}
+	if secret := cfg.WebhookSecret(clickup.SyncName); secret != "" {
+		cuCfg, err := clickup.ParseConfig(cfg.SyncConfig(clickup.SyncName))
+		if err != nil {
+			return nil, providerError(clickup.SyncName, err)
+		}
+		if cuCfg == nil {
+			cuCfg = &clickup.Config{}
+		}
+		providers = append(providers, &webhookProvider{
+			name:       clickup.SyncName,
+			path:       WebhookPathClickUp,
+			secret:     secret,
+			autoImport: webhookAutoImport(cfg, clickup.SyncName),
+			verify: func(h http.Header, body []byte, secret string) bool {
+				return clickup.VerifyWebhookSignature(secret, body, h.Get(clickup.WebhookSignatureHeader))
+			},
+			parse:       parseClickUpDelivery,
+			localStatus: cuCfg.LocalStatus,
+			linkedTo: func(b *issue.Issue) string {
+				return clickup.GetSyncString(b, clickup.SyncKeyTaskID)
+			},
+			linkKey:     clickup.SyncKeyTaskID,
+			syncedAtKey: clickup.SyncKeySyncedAt,
+			labels:      cuCfg.Labels,
+			labelsKey:   clickup.SyncKeyLabels,
+		})
+	}
+	return providers, nil
+}
+
+// webhookAutoImport reports whether sync.<provider>.webhook_auto_import is set.
+func webhookAutoImport(cfg *config.Config, provider string) bool {
+	on, _ := cfg.SyncConfig(provider)["webhook_auto_import"].(bool)
+	return on
+}
+
+// todoMarker matches the hidden comment sync puts in GitHub issue bodies.
+var todoMarker = regexp.MustCompile(`<!-- todo:([^ ]+) -->`)
+
+// parseGitHubDelivery turns an issues or issue_comment delivery into an
+// InboundChange.
+func parseGitHubDelivery(h http.Header, body []byte) (*InboundChange, error) {
+	e, err := github.ParseWebhookEvent(h.Get(github.WebhookEventHeader), body)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	ch := &InboundChange{
+		DeliveryID: h.Get(github.WebhookDeliveryHeader),
+		ExternalID: strconv.Itoa(e.Issue.Number),
+	}
+	if m := todoMarker.FindStringSubmatch(e.Issue.Body); m != nil {
+		ch.LocalID = m[1]
+	}
+	if e.Comment != nil {
+		ch.Comments = []InboundComment{{Author: e.Comment.User.Login, Text: e.Comment.Body}}
+		return ch, nil
+	}
+	ch.Title = e.Issue.Title
+	ch.Status = e.Issue.State
+	ch.Body = e.Issue.Body
+	for _, l := range e.Issue.Labels {
+		ch.Labels = append(ch.Labels, l.Name)
+	}
+	ch.Created = e.Action == "opened"
+	return ch, nil
+}
+
+// parseClickUpDelivery turns a task delivery into an InboundChange.
+func parseClickUpDelivery(_ http.Header, body []byte) (*InboundChange, error) {
+	e, err := clickup.ParseWebhookEvent(body)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	ch := &InboundChange{
+		DeliveryID: e.DeliveryID(),
+		ExternalID: e.TaskID,
+		Created:    e.Event == clickup.EventTaskCreated,
+	}
+	ch.Title, _ = e.Name()
+	ch.Status, _ = e.Status()
+	texts, authors := e.Comments()
+	for i, text := range texts {
+		ch.Comments = append(ch.Comments, InboundComment{Author: authors[i], Text: text})
+	}
+	return ch, nil
+}
+
+// webhookHandler receives one provider's deliveries.
+type webhookHandler struct {
+	core       *core.Core
+	provider   *webhookProvider
+	deliveries *deliveryLog
+	log        io.Writer
+	mu         *sync.Mutex // shared by all providers: one delivery is applied at a time
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "reading body: "+err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !h.provider.verify(r.Header, body, h.provider.secret) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	ch, err := h.provider.parse(r.Header, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ch == nil {
+		writeWebhookResult(w, http.StatusAccepted, WebhookResult{Action: WebhookIgnored, Reason: "event not handled"})
+		return
+	}
+	if ch.DeliveryID == "" {
+		sum := sha256.Sum256(body)
+		ch.DeliveryID = hex.EncodeToString(sum[:])
+	}
+	deliveryKey := h.provider.name + ":" + ch.DeliveryID
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.deliveries.Seen(deliveryKey) {
+		writeWebhookResult(w, http.StatusOK, WebhookResult{Action: WebhookDuplicate})
+		return
+	}
+	result, err := h.apply(ch)
+	if err != nil {
+		status := http.StatusInternalServerError
+		_, badValue := errors.AsType[*config.ValueError](err)
+		_, brokeRule := errors.AsType[*core.RuleError](err)
+		if badValue || brokeRule {
+			status = http.StatusUnprocessableEntity
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if err := h.deliveries.Add(deliveryKey); err != nil {
+		fmt.Fprintf(h.log, "webhook: recording %s delivery %s: %v\n", h.provider.name, ch.DeliveryID, err)
+	}
+	status := http.StatusOK
+	if result.Action == WebhookIgnored {
+		status = http.StatusAccepted
+	}
+	writeWebhookResult(w, status, result)
+}
+
+func writeWebhookResult(w http.ResponseWriter, status int, result WebhookResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// find returns the issue linked to the remote item, falling back to the
+// issue the item names in its body.
+func (h *webhookHandler) find(ch *InboundChange) *issue.Issue {
+	for _, b := range h.core.All() {
+		if h.provider.linkedTo(b) == ch.ExternalID {
+			return b
+		}
+	}
+	if ch.LocalID != "" {
+		if b, err := h.core.Get(ch.LocalID); err == nil && h.provider.linkedTo(b) == "" {
+			return b
+		}
+	}
+	return nil
+}
+
+// apply makes the change to the linked issue, or imports the remote item.
+func (h *webhookHandler) apply(ch *InboundChange) (WebhookResult, error) {
+	b := h.find(ch)
+	if b == nil {
+		return h.importIssue(ch)
+	}
+
+	now := h.core.Now().UTC()
+	var changes []string
+	if ch.Title != "" && ch.Title != b.Title {
+		b.Title = ch.Title
+		changes = append(changes, "title")
+	}
+	newStatus := ""
+	if ch.Status != "" {
+		status, ok := h.provider.localStatus(ch.Status, b.Status)
+		switch {
+		case !ok:
+			fmt.Fprintf(h.log, "webhook: %s status %q maps to no issue status; left %s at %s\n", h.provider.name, ch.Status, b.ID, b.Status)
+		case status != b.Status:
+			newStatus = status
+		}
+	}
+
+	editable := !b.Encrypted || b.Body != issue.EncryptedPlaceholder
+	var comments string
+	if len(ch.Comments) > 0 && editable {
+		for _, cm := range ch.Comments {
+			body, err := issue.AppendComment(b.Body, issue.Comment{Author: cm.Author, Source: h.provider.name, At: now, Text: cm.Text})
+			if err != nil {
+				return WebhookResult{}, err
+			}
+			b.Body = body
+		}
+		comments = pluralComments(len(ch.Comments))
+	}
+
+	// A status a validation rule rejects is left alone; the rest still applies.
+	var reason string
+	if newStatus != "" {
+		if err := h.core.CheckRules(b, newStatus); err != nil {
+			fmt.Fprintf(h.log, "webhook: %s status %q not applied: %v; left %s at %s\n", h.provider.name, ch.Status, err, b.ID, b.Status)
+			reason = err.Error()
+		} else {
+			changes = append(changes, fmt.Sprintf("status %s → %s", b.Status, newStatus))
+			b.Status = newStatus
+		}
+	}
+	if comments != "" {
+		changes = append(changes, comments)
+	}
+	if len(changes) == 0 {
+		return WebhookResult{Action: WebhookUnchanged, IssueID: b.ID, Reason: reason}, nil
+	}
+
+	if editable {
+		note := fmt.Sprintf("- %s: %s (delivery %s): %s", now.Format(time.RFC3339), webhookActor(h.provider.name), ch.DeliveryID, strings.Join(changes, "; "))
+		body, err := issue.AppendToSection(b.Body, WebhookHistorySection, note, true)
+		if err != nil {
+			return WebhookResult{}, err
+		}
+		b.Body = body
+	}
+	h.link(b, ch.ExternalID, now)
+	if err := h.core.Update(b, nil); err != nil {
+		return WebhookResult{}, err
+	}
+	return WebhookResult{Action: WebhookUpdated, IssueID: b.ID, Changes: changes, Reason: reason}, nil
+}
+
+// importIssue creates an issue for a newly created remote item when the
+// provider's auto-import is on, and logs the delivery otherwise.
+func (h *webhookHandler) importIssue(ch *InboundChange) (WebhookResult, error) {
+	reason := ""
+	switch {
+	case !h.provider.autoImport:
+		reason = "not linked to an issue"
+	case !ch.Created:
+		reason = "not linked to an issue (only new items are imported)"
+	case ch.Title == "":
+		reason = "not linked to an issue (delivery has no title to import)"
+	}
+	if reason != "" {
+		fmt.Fprintf(h.log, "webhook: %s item %s %s; ignored\n", h.provider.name, ch.ExternalID, reason)
+		return WebhookResult{Action: WebhookIgnored, Reason: reason}, nil
+	}
+
+	cfg := h.core.Config()
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	b := &issue.Issue{
+		Title:      ch.Title,
+		Status:     cfg.GetDefaultStatus(),
+		Type:       cfg.GetDefaultType(),
+		Body:       strings.TrimSpace(todoMarker.ReplaceAllString(ch.Body, "")),
+		CreatedBy:  webhookActor(h.provider.name),
+		CreatedVia: issue.CreatedViaSync,
+	}
+	now := h.core.Now().UTC()
+	note := fmt.Sprintf("- %s: %s (delivery %s): imported from %s %s", now.Format(time.RFC3339), webhookActor(h.provider.name), ch.DeliveryID, h.provider.name, ch.ExternalID)
+	body, err := issue.AppendToSection(b.Body, WebhookHistorySection, note, true)
+	if err != nil {
+		return WebhookResult{}, err
+	}
+	b.Body = body
+	if ch.Status != "" {
+		if status, ok := h.provider.localStatus(ch.Status, ""); ok {
+			if err := h.core.CheckRules(b, status); err != nil {
+				fmt.Fprintf(h.log, "webhook: %s status %q not applied to import: %v\n", h.provider.name, ch.Status, err)
+				reason = err.Error()
+			} else {
+				b.Status = status
+			}
+		}
+	}
+	for _, l := range ch.Labels {
+		_ = b.AddTag(issue.NormalizeTag(h.provider.labels.Tag(l)))
+	}
+	h.link(b, ch.ExternalID, now)
+	if len(ch.Labels) > 0 {
+		// The labels are the issue's tags now, so the next push manages them.
+		b.Sync[h.provider.name][h.provider.labelsKey] = ch.Labels
+	}
+	if err := h.core.Create(b); err != nil {
+		return WebhookResult{}, err
+	}
+	return WebhookResult{Action: WebhookImported, IssueID: b.ID, Reason: reason}, nil
+}
+
+// link records the remote item in b's sync data and marks b synced at now,
+// so the next push does not send the change straight back.
+func (h *webhookHandler) link(b *issue.Issue, externalID string, now time.Time) {
+	data := maps.Clone(b.Sync[h.provider.name])
+	if data == nil {
+		data = map[string]any{}
+	}
+	data[h.provider.linkKey] = externalID
+	data[h.provider.syncedAtKey] = now.Format(time.RFC3339)
+	b.SetSync(h.provider.name, data)
+}
+
+// webhookActor is who inbound webhook edits are attributed to in an
+// issue's History section.
+func webhookActor(provider string) string {
+	return provider + "-webhook"
+}
+
+func pluralComments(n int) string {
+	if n == 1 {
+		return "1 comment"
+	}
+	return fmt.Sprintf("%d comments", n)
+}
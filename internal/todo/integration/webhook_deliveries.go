@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// deliveryLogFile is the file, in CheckpointDir, that holds the IDs of the
+// most recent webhook deliveries.
+const deliveryLogFile = "webhook-deliveries.json"
+
+// deliveryLogSize is how many delivery IDs are kept. Providers redeliver
+// within minutes, so a few hundred cover any retry burst.
+const deliveryLogSize = 500
+
+// deliveryLog is a ring of recently applied webhook delivery IDs, kept on
+// disk so a redelivery is recognized across server restarts. Callers
+// serialize access.
+type deliveryLog struct {
+	path string
+	ids  []string // oldest first
+}
+
+// loadDeliveryLog reads the delivery log under the data directory root. A
+// missing file is an empty log.
+func loadDeliveryLog(root string) (*deliveryLog, error) {
+	l := &deliveryLog{path: filepath.Join(root, CheckpointDir, deliveryLogFile)}
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading webhook delivery log: %w", err)
+	}
+	if err := json.Unmarshal(data, &l.ids); err != nil {
+		return nil, fmt.Errorf("parsing webhook delivery log %s: %w", l.path, err)
+	}
+	return l, nil
+}
+
+// Seen reports whether the delivery was applied already.
+func (l *deliveryLog) Seen(id string) bool {
+	return slices.Contains(l.ids, id)
+}
+
+// Add records a delivery, dropping the oldest past deliveryLogSize, and
+// writes the log atomically.
+func (l *deliveryLog) Add(id string) error {
+	l.ids = append(l.ids, id)
+	if over := len(l.ids) - deliveryLogSize; over > 0 {
+		l.ids = slices.Delete(l.ids, 0, over)
+	}
+	data, err := json.MarshalIndent(l.ids, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", CheckpointDir, err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing webhook delivery log: %w", err)
+	}
+	return os.Rename(tmp, l.path)
+}
@@ -0,0 +1,339 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/integration/clickup"
+	"github.com/toba/jig/internal/todo/integration/syncutil"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+const (
+	testGitHubSecret  = "gh-hook-secret"
+	testClickUpSecret = "cu-hook-secret"
+)
+
+// newWebhookCore returns a core holding one issue linked to GitHub issue 42
+// and ClickUp task 86a1b2c3, with webhook secrets set for both providers.
+func newWebhookCore(t *testing.T, syncCfg map[string]map[string]any) *core.Core {
+	t.Helper()
+	t.Setenv(config.WebhookSecretEnvPrefix+"GITHUB", testGitHubSecret)
+	t.Setenv(config.WebhookSecretEnvPrefix+"CLICKUP", testClickUpSecret)
+	cfg := config.Default()
+	cfg.Sync = syncCfg
+	c := core.New(t.TempDir(), cfg)
+	c.SetClock(func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) })
+
+	b := &issue.Issue{
+		ID:     "hook-1",
+		Title:  "Fix login redirect",
+		Status: config.StatusReady,
+		Type:   "bug",
+		Body:   "Users land on the wrong page.",
+	}
+	b.SetSync(ghSyncName, map[string]any{ghSyncKeyIssueNumber: 42})
+	b.SetSync(clickup.SyncName, map[string]any{clickup.SyncKeyTaskID: "86a1b2c3"})
+	if err := c.Create(b); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return c
+}
+
+func readWebhookFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "webhooks", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// postGitHub delivers a GitHub fixture signed with secret.
+func postGitHub(t *testing.T, h http.Handler, event, delivery, fixture, secret string) (*httptest.ResponseRecorder, WebhookResult) {
+	t.Helper()
+	body := readWebhookFixture(t, fixture)
+	req := httptest.NewRequest(http.MethodPost, WebhookPathGitHub, bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", delivery)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+signWebhook(secret, body))
+	return serveWebhook(t, h, req)
+}
+
+// postClickUp delivers a ClickUp fixture signed with secret.
+func postClickUp(t *testing.T, h http.Handler, fixture, secret string) (*httptest.ResponseRecorder, WebhookResult) {
+	t.Helper()
+	body := readWebhookFixture(t, fixture)
+	req := httptest.NewRequest(http.MethodPost, WebhookPathClickUp, bytes.NewReader(body))
+	req.Header.Set(clickup.WebhookSignatureHeader, signWebhook(secret, body))
+	return serveWebhook(t, h, req)
+}
+
+func serveWebhook(t *testing.T, h http.Handler, req *http.Request) (*httptest.ResponseRecorder, WebhookResult) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var result WebhookResult
+	if rec.Header().Get("Content-Type") == "application/json" {
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec, result
+}
+
+func mustWebhookHandler(t *testing.T, c *core.Core) http.Handler {
+	t.Helper()
+	h, err := NewWebhookHandler(c, WebhookOptions{})
+	if err != nil {
+		t.Fatalf("NewWebhookHandler: %v", err)
+	}
+	return h
+}
+
+func TestWebhookGitHubIssueClosed(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	h := mustWebhookHandler(t, c)
+
+	rec, result := postGitHub(t, h, "issues", "d-closed", "github_issues_closed.json", testGitHubSecret)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if result.Action != WebhookUpdated || result.IssueID != "hook-1" {
+		t.Errorf("result = %+v, want updated hook-1", result)
+	}
+
+	b, err := c.Get("hook-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Status != config.StatusCompleted {
+		t.Errorf("status = %q, want %q", b.Status, config.StatusCompleted)
+	}
+	if !strings.Contains(b.Body, "## History") || !strings.Contains(b.Body, "github-webhook (delivery d-closed): status ready → completed") {
+		t.Errorf("body has no History note for the delivery:\n%s", b.Body)
+	}
+	if got := b.Sync[ghSyncName][ghSyncKeySyncedAt]; got != "2026-03-01T12:00:00Z" {
+		t.Errorf("synced_at = %v, want the delivery time", got)
+	}
+}
+
+func TestWebhookStatusBreaksValidationRule(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	c.Config().ValidationRules = []config.ValidationRule{{WhenStatus: config.StatusCompleted, Require: []string{"due"}}}
+	var log bytes.Buffer
+	h, err := NewWebhookHandler(c, WebhookOptions{Log: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, result := postGitHub(t, h, "issues", "d-closed", "github_issues_closed.json", testGitHubSecret)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if result.IssueID != "hook-1" || !strings.Contains(result.Reason, "hook-1 is missing due") {
+		t.Errorf("result = %+v, want the rule violation reported", result)
+	}
+	if b, _ := c.Get("hook-1"); b.Status != config.StatusReady {
+		t.Errorf("status = %q, want it left at ready", b.Status)
+	}
+	if !strings.Contains(log.String(), "not applied") {
+		t.Errorf("log = %q, want the skipped status", log.String())
+	}
+}
+
+func TestWebhookGitHubInvalidSignature(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	h := mustWebhookHandler(t, c)
+
+	rec, _ := postGitHub(t, h, "issues", "d-forged", "github_issues_closed.json", "wrong-secret")
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+	b, _ := c.Get("hook-1")
+	if b.Status != config.StatusReady || strings.Contains(b.Body, "History") {
+		t.Errorf("forged delivery changed the issue: status %q, body:\n%s", b.Status, b.Body)
+	}
+
+	// A forged delivery is not recorded, so the genuine one still applies.
+	_, result := postGitHub(t, h, "issues", "d-forged", "github_issues_closed.json", testGitHubSecret)
+	if result.Action != WebhookUpdated {
+		t.Errorf("genuine delivery after forged one: action = %q, want updated", result.Action)
+	}
+}
+
+func TestWebhookGitHubReplay(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	h := mustWebhookHandler(t, c)
+
+	if _, result := postGitHub(t, h, "issues", "d-edit", "github_issues_edited.json", testGitHubSecret); result.Action != WebhookUpdated {
+		t.Fatalf("first delivery: action = %q, want updated", result.Action)
+	}
+	b, _ := c.Get("hook-1")
+	if b.Title != "Fix login redirect loop" {
+		t.Errorf("title = %q, want the edited title", b.Title)
+	}
+	body := b.Body
+
+	// A restarted server reloads the delivery log from disk.
+	h = mustWebhookHandler(t, c)
+	rec, result := postGitHub(t, h, "issues", "d-edit", "github_issues_edited.json", testGitHubSecret)
+	if rec.Code != http.StatusOK || result.Action != WebhookDuplicate {
+		t.Fatalf("replay: status %d, action %q; want 200 duplicate", rec.Code, result.Action)
+	}
+	b, _ = c.Get("hook-1")
+	if b.Body != body {
+		t.Errorf("replay changed the body:\n%s", b.Body)
+	}
+}
+
+func TestWebhookGitHubComment(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	h := mustWebhookHandler(t, c)
+
+	_, result := postGitHub(t, h, "issue_comment", "d-comment", "github_issue_comment_created.json", testGitHubSecret)
+	if result.Action != WebhookUpdated {
+		t.Fatalf("action = %q, want updated", result.Action)
+	}
+	b, _ := c.Get("hook-1")
+	want := "**octocat** (github, 2026-03-01T12:00:00Z):\n\nReproduced on Safari too."
+	if !strings.Contains(b.Body, "## Comments") || !strings.Contains(b.Body, want) {
+		t.Errorf("body has no comment entry:\n%s", b.Body)
+	}
+	if b.Status != config.StatusReady {
+		t.Errorf("comment changed status to %q", b.Status)
+	}
+}
+
+func TestWebhookGitHubIgnoredEvent(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	h := mustWebhookHandler(t, c)
+
+	rec, result := postGitHub(t, h, "push", "d-push", "github_issues_closed.json", testGitHubSecret)
+	if rec.Code != http.StatusAccepted || result.Action != WebhookIgnored {
+		t.Errorf("status %d, action %q; want 202 ignored", rec.Code, result.Action)
+	}
+}
+
+func TestWebhookGitHubUnknownIssue(t *testing.T) {
+	t.Run("logged without auto-import", func(t *testing.T) {
+		c := newWebhookCore(t, nil)
+		var log bytes.Buffer
+		h, err := NewWebhookHandler(c, WebhookOptions{Log: &log})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, result := postGitHub(t, h, "issues", "d-open", "github_issues_opened.json", testGitHubSecret)
+		if rec.Code != http.StatusAccepted || result.Action != WebhookIgnored {
+			t.Errorf("status %d, action %q; want 202 ignored", rec.Code, result.Action)
+		}
+		if n := len(c.All()); n != 1 {
+			t.Errorf("issues = %d, want 1", n)
+		}
+		if !strings.Contains(log.String(), "github item 77 not linked") {
+			t.Errorf("log = %q, want a line for item 77", log.String())
+		}
+	})
+
+	t.Run("imported with auto-import", func(t *testing.T) {
+		c := newWebhookCore(t, map[string]map[string]any{
+			ghSyncName: {"repo": "acme/app", "webhook_auto_import": true, "labels": map[string]any{
+				"map": map[string]any{"bug": "type: bug"},
+			}},
+		})
+		h := mustWebhookHandler(t, c)
+
+		_, result := postGitHub(t, h, "issues", "d-open", "github_issues_opened.json", testGitHubSecret)
+		if result.Action != WebhookImported || result.IssueID == "" {
+			t.Fatalf("result = %+v, want imported", result)
+		}
+		b, err := c.Get(result.IssueID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Title != "Dark mode flickers on load" || b.Status != config.StatusReady {
+			t.Errorf("imported %q at %q", b.Title, b.Status)
+		}
+		if b.CreatedBy != "github-webhook" || b.CreatedVia != issue.CreatedViaSync {
+			t.Errorf("created by %q via %q, want github-webhook via sync", b.CreatedBy, b.CreatedVia)
+		}
+		if n := b.Sync[ghSyncName][ghSyncKeyIssueNumber]; n != "77" {
+			t.Errorf("issue_number = %v, want 77", b.Sync[ghSyncName][ghSyncKeyIssueNumber])
+		}
+		if !strings.Contains(b.Body, "Seen on the settings page.") || !strings.Contains(b.Body, "imported from github 77") {
+			t.Errorf("body:\n%s", b.Body)
+		}
+		if !slices.Equal(b.Tags, []string{"bug", "ui"}) {
+			t.Errorf("tags = %v, want [bug ui] (labels through the translation table)", b.Tags)
+		}
+		if got := syncutil.SyncStrings(b.Sync[ghSyncName]["labels"]); !slices.Equal(got, []string{"type: bug", "UI"}) {
+			t.Errorf("managed labels = %v, want the imported labels", got)
+		}
+	})
+}
+
+func TestWebhookClickUp(t *testing.T) {
+	c := newWebhookCore(t, nil)
+	h := mustWebhookHandler(t, c)
+
+	rec, result := postClickUp(t, h, "clickup_task_status_updated.json", testClickUpSecret)
+	if rec.Code != http.StatusOK || result.Action != WebhookUpdated {
+		t.Fatalf("status delivery: %d %+v", rec.Code, result)
+	}
+	b, _ := c.Get("hook-1")
+	if b.Status != config.StatusInProgress {
+		t.Errorf("status = %q, want %q", b.Status, config.StatusInProgress)
+	}
+	if !strings.Contains(b.Body, "clickup-webhook (delivery taskStatusUpdated:2800797048554170804)") {
+		t.Errorf("body has no History note:\n%s", b.Body)
+	}
+
+	if _, result = postClickUp(t, h, "clickup_task_updated_name.json", testClickUpSecret); result.Action != WebhookUpdated {
+		t.Fatalf("name delivery: %+v", result)
+	}
+	if _, result = postClickUp(t, h, "clickup_task_comment_posted.json", testClickUpSecret); result.Action != WebhookUpdated {
+		t.Fatalf("comment delivery: %+v", result)
+	}
+	b, _ = c.Get("hook-1")
+	if b.Title != "Write release notes for 2.0" {
+		t.Errorf("title = %q", b.Title)
+	}
+	if !strings.Contains(b.Body, "**John** (clickup, 2026-03-01T12:00:00Z):\n\nDraft is in the shared folder.") {
+		t.Errorf("body has no comment entry:\n%s", b.Body)
+	}
+
+	if rec, _ = postClickUp(t, h, "clickup_task_status_updated.json", "wrong-secret"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("forged delivery: status = %d, want 401", rec.Code)
+	}
+	if _, result = postClickUp(t, h, "clickup_task_status_updated.json", testClickUpSecret); result.Action != WebhookDuplicate {
+		t.Errorf("replayed delivery: action = %q, want duplicate", result.Action)
+	}
+}
+
+func TestNewWebhookHandlerNoSecrets(t *testing.T) {
+	t.Setenv(config.WebhookSecretEnvPrefix+"GITHUB", "")
+	t.Setenv(config.WebhookSecretEnvPrefix+"CLICKUP", "")
+	c := core.New(t.TempDir(), config.Default())
+	if _, err := NewWebhookHandler(c, WebhookOptions{}); err == nil {
+		t.Error("expected an error with no webhook secrets configured")
+	}
+}
@@ -0,0 +1,87 @@
+package issue
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Names ChangedFields uses for what is not a front matter key, and for the
+// status, which callers look for most.
+const (
+	FieldStatus = "status"
+	FieldBody   = "body"
+	FieldPath   = "path"
+)
+
+// statusDetailPrefix starts the detail ChangedFields adds for a status
+// change, as in "status:ready→completed".
+const statusDetailPrefix = FieldStatus + ":"
+
+// ChangedFields returns the front matter keys whose values differ between
+// before and after, in file order, followed by "body" and "path" if those
+// changed. updated_at is left out, since every write changes it. A status
+// change adds a "status:<old>→<new>" detail right after "status".
+func ChangedFields(before, after *Issue) []string {
+	var fields []string
+	add := func(name string, changed bool) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	add("title", before.Title != after.Title)
+	add("summary", before.Summary != after.Summary)
+	if before.Status != after.Status {
+		fields = append(fields, FieldStatus, statusDetailPrefix+before.Status+"→"+after.Status)
+	}
+	add("type", before.Type != after.Type)
+	add("priority", before.Priority != after.Priority)
+	add("milestone", before.Milestone != after.Milestone)
+	add("iteration", before.Iteration != after.Iteration)
+	add("estimate", before.Estimate != after.Estimate)
+	add("tags", !slices.Equal(before.Tags, after.Tags))
+	add(KeyCreatedAt, !sameTime(before.CreatedAt, after.CreatedAt))
+	add("due", !sameDue(before.Due, after.Due))
+	add("created_by", before.CreatedBy != after.CreatedBy)
+	add("created_via", before.CreatedVia != after.CreatedVia)
+	add("pinned", before.Pinned != after.Pinned)
+	add("visibility", before.Visibility != after.Visibility)
+	add("release_title", before.ReleaseTitle != after.ReleaseTitle)
+	add("release_note", before.ReleaseNote != after.ReleaseNote)
+	add("merged_into", before.MergedInto != after.MergedInto)
+	add("parent", before.Parent != after.Parent)
+	add("blocking", !slices.Equal(before.Blocking, after.Blocking))
+	add("blocked_by", !slices.Equal(before.BlockedBy, after.BlockedBy))
+	add("encrypted", before.Encrypted != after.Encrypted)
+	add("sync", len(before.Sync)+len(after.Sync) > 0 && !reflect.DeepEqual(before.Sync, after.Sync))
+	add(FieldBody, before.Body != after.Body)
+	add(FieldPath, before.Path != after.Path)
+	return fields
+}
+
+// StatusChange returns the old and new status from the detail
+// ChangedFields adds for a status change, if fields has one.
+func StatusChange(fields []string) (from, to string, ok bool) {
+	for _, f := range fields {
+		if detail, found := strings.CutPrefix(f, statusDetailPrefix); found {
+			from, to, ok = strings.Cut(detail, "→")
+			return from, to, ok
+		}
+	}
+	return "", "", false
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func sameDue(a, b *DueDate) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.HasTime == b.HasTime && a.Equal(b.Time)
+}
@@ -0,0 +1,45 @@
+package issue
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestChangedFields(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	base := func() *Issue {
+		return &Issue{
+			ID: "abc-001", Title: "Login", Status: "ready", Type: "task", Priority: "normal",
+			Tags: []string{"auth"}, CreatedAt: &created, Due: &DueDate{Time: created},
+			Sync: map[string]map[string]any{"github": {"issue_number": 12}}, Body: "Fix teh login.", Path: "abc-001--login.md",
+		}
+	}
+	tests := []struct {
+		name string
+		edit func(*Issue)
+		want []string
+	}{
+		{"nothing", func(*Issue) {}, nil},
+		{"updated_at only", func(b *Issue) { now := time.Now(); b.UpdatedAt = &now }, nil},
+		{"same instant in another zone", func(b *Issue) { local := created.In(time.FixedZone("X", 3600)); b.CreatedAt = &local }, nil},
+		{"metadata", func(b *Issue) { b.Priority = "high"; b.Tags = append(b.Tags, "perf"); b.Due.HasTime = true }, []string{"priority", "tags", "due"}},
+		{"body", func(b *Issue) { b.Body = "Fix the login." }, []string{FieldBody}},
+		{"status", func(b *Issue) { b.Status = "completed"; b.Parent = "abc-002" }, []string{FieldStatus, "status:ready→completed", "parent"}},
+		{"sync and path", func(b *Issue) { b.Sync["github"]["issue_number"] = 13; b.Path = "abc-001--sign-in.md" }, []string{"sync", FieldPath}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := base()
+			tt.edit(after)
+			got := ChangedFields(base(), after)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ChangedFields() = %v, want %v", got, tt.want)
+			}
+			from, to, ok := StatusChange(got)
+			if ok != (tt.name == "status") || (ok && (from != "ready" || to != "completed")) {
+				t.Errorf("StatusChange() = %q, %q, %v", from, to, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package issue
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CommentsSection is the body section that holds an issue's comments.
+const CommentsSection = "Comments"
+
+// CommentSourceLocal is the source of comments written in jig itself rather
+// than copied from a sync provider.
+const CommentSourceLocal = "jig"
+
+// commentHeader matches the line that opens a comment: "**author** (source,
+// time):".
+var commentHeader = regexp.MustCompile(`^\*\*(.+?)\*\* \(([^(),]+), ([^(),]+)\):$`)
+
+// Comment is one entry of an issue's Comments section: a header line naming
+// who wrote it, where, and when, then the text.
+type Comment struct {
+	Author string
+	// Source is where the comment was written: CommentSourceLocal, or the
+	// sync provider it was copied from.
+	Source string
+	At     time.Time
+	Text   string
+}
+
+// String renders the comment as it is stored in the Comments section.
+func (c Comment) String() string {
+	return fmt.Sprintf("**%s** (%s, %s):\n\n%s", c.Author, c.Source, c.At.UTC().Format(time.RFC3339), strings.TrimSpace(c.Text))
+}
+
+// Key identifies the comment across edits to its text: its time and author.
+func (c Comment) Key() string {
+	return c.At.UTC().Format(time.RFC3339) + " " + c.Author
+}
+
+// ParseComments returns the comments in body's Comments section, in the
+// order they appear. Text before the first header is not a comment, and
+// headers inside fenced code blocks are part of the comment around them.
+func ParseComments(body string) ([]Comment, error) {
+	section, err := GetSection(body, CommentsSection)
+	if err != nil || section == nil {
+		return nil, err
+	}
+	var comments []Comment
+	var text []string
+	flush := func() {
+		if len(comments) > 0 {
+			comments[len(comments)-1].Text = strings.TrimSpace(strings.Join(text, "\n"))
+		}
+		text = nil
+	}
+	var fence string
+	for line := range strings.SplitSeq(section.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+			}
+		case fenceMarker(trimmed) != "":
+			fence = fenceMarker(trimmed)
+		default:
+			if m := commentHeader.FindStringSubmatch(trimmed); m != nil {
+				if at, err := time.Parse(time.RFC3339, m[3]); err == nil {
+					flush()
+					comments = append(comments, Comment{Author: m[1], Source: m[2], At: at.UTC()})
+					continue
+				}
+			}
+		}
+		text = append(text, line)
+	}
+	flush()
+	return comments, nil
+}
+
+// AppendComment adds c to the end of body's Comments section, creating the
+// section if it is missing. Headings in the text that would end the section
+// are nested under it.
+func AppendComment(body string, c Comment) (string, error) {
+	c.Text = nestHeadings(c.Text)
+	return AppendToSection(body, CommentsSection, c.String(), true)
+}
+
+// nestHeadings turns level 1 and 2 headings outside fenced code into level
+// 3 ones, below the Comments section's own level.
+func nestHeadings(text string) string {
+	lines := strings.Split(text, "\n")
+	var fence string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+			}
+		case fenceMarker(trimmed) != "":
+			fence = fenceMarker(trimmed)
+		default:
+			if level, _, ok := parseHeading(line); ok && level < 3 {
+				lines[i] = strings.Repeat("#", 3-level) + line
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,63 @@
+package issue
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseComments(t *testing.T) {
+	body := "Intro.\n\n## Comments\n\nNot a comment.\n\n" +
+		"**alice** (jig, 2026-01-01T10:00:00Z):\n\nFirst line.\n\n```\n**mallory** (jig, 2026-01-01T11:00:00Z):\n```\n\n" +
+		"**bob** (github, 2026-01-02T10:00:00Z):\n\nSecond\n\n## After\n\nNot either."
+	comments, err := ParseComments(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Comment{
+		{Author: "alice", Source: CommentSourceLocal, At: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Text: "First line.\n\n```\n**mallory** (jig, 2026-01-01T11:00:00Z):\n```"},
+		{Author: "bob", Source: "github", At: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), Text: "Second"},
+	}
+	if !slices.Equal(comments, want) {
+		t.Errorf("ParseComments() =\n%+v\nwant\n%+v", comments, want)
+	}
+
+	if comments, err := ParseComments("No section."); err != nil || comments != nil {
+		t.Errorf("ParseComments(no section) = %v, %v; want none", comments, err)
+	}
+}
+
+func TestAppendCommentRoundtrip(t *testing.T) {
+	c := Comment{Author: "alice", Source: CommentSourceLocal, At: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Text: "Hello\n\nWorld"}
+	body, err := AppendComment("Intro.", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := ParseComments(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0] != c {
+		t.Errorf("ParseComments(AppendComment()) = %+v, want %+v", comments, c)
+	}
+	if got, want := c.Key(), "2026-01-01T10:00:00Z alice"; got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+}
+
+func TestAppendCommentNestsHeadings(t *testing.T) {
+	c := Comment{Author: "alice", Source: CommentSourceLocal, At: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		Text: "# Done\n\n## Summary\n\nFixed.\n\n```\n## in code\n```\n\n### Details"}
+	body, err := AppendComment("Intro.", c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments, err := ParseComments(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "### Done\n\n### Summary\n\nFixed.\n\n```\n## in code\n```\n\n### Details"
+	if len(comments) != 1 || comments[0].Text != want {
+		t.Errorf("ParseComments() = %+v, want one comment with text %q", comments, want)
+	}
+}
@@ -5,6 +5,7 @@ const (
 	LinkTypeParent    = "parent"
 	LinkTypeBlocking  = "blocking"
 	LinkTypeBlockedBy = "blocked_by"
+	LinkTypeMention   = "mention" // body reference; derived, never stored
 )
 
 // Field name constants for ComputeEffectiveDates.
@@ -12,3 +13,15 @@ const (
 	FieldCreatedAt = "created_at"
 	FieldUpdatedAt = "updated_at"
 )
+
+// Entry points recorded in Issue.CreatedVia.
+const (
+	CreatedViaCLI     = "cli"
+	CreatedViaTUI     = "tui"
+	CreatedViaGraphQL = "graphql"
+	CreatedViaImport  = "import"
+	CreatedViaSync    = "sync"
+)
+
+// CreatedVias lists the CreatedVia constants.
+var CreatedVias = []string{CreatedViaCLI, CreatedViaTUI, CreatedViaGraphQL, CreatedViaImport, CreatedViaSync}
@@ -4,23 +4,73 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // ReplaceOnce replaces exactly one occurrence of old with new in text.
 // Returns an error if old is empty, not found, or found multiple times.
 // The new string can be empty to delete the matched text.
+//
+// Matching is line-ending insensitive: a body stored with CRLF line endings
+// matches an old string written with LF, and the result keeps the body's
+// original line endings. Occurrences are counted without overlap, the same
+// way the replacement scans the text.
 func ReplaceOnce(text, old, new string) (string, error) {
 	if old == "" {
 		return "", errors.New("old text cannot be empty")
 	}
+	crlf := strings.Contains(text, "\r\n")
+	text, old, new = normalizeEOL(text), normalizeEOL(old), normalizeEOL(new)
+
 	count := strings.Count(text, old)
 	if count == 0 {
+		if hint := nearMiss(text, old); hint != "" {
+			return "", fmt.Errorf("text not found in body (closest match: %q)", hint)
+		}
 		return "", errors.New("text not found in body")
 	}
 	if count > 1 {
 		return "", fmt.Errorf("text found %d times in body (must be unique)", count)
 	}
-	return strings.Replace(text, old, new, 1), nil
+	return restoreEOL(strings.Replace(text, old, new, 1), crlf), nil
+}
+
+// normalizeEOL converts CRLF line endings to LF.
+func normalizeEOL(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+// restoreEOL converts LF line endings back to CRLF when crlf is set.
+func restoreEOL(s string, crlf bool) string {
+	if !crlf {
+		return s
+	}
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+// nearMissMinPrefix is the shortest prefix of a failed replace target that
+// is worth reporting as a near miss.
+const nearMissMinPrefix = 4
+
+// nearMiss locates the longest prefix of old that occurs in text and returns
+// the body text at that spot, about as long as old, so callers can see where
+// their target diverges. Returns "" when no useful prefix matches.
+func nearMiss(text, old string) string {
+	for n := len(old) - 1; n >= nearMissMinPrefix; n-- {
+		if !utf8.RuneStart(old[n]) {
+			continue
+		}
+		i := strings.Index(text, old[:n])
+		if i < 0 {
+			continue
+		}
+		end := min(i+len(old)+10, len(text))
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+		return text[i:end]
+	}
+	return ""
 }
 
 // CheckItem finds an unchecked checkbox line (- [ ]) matching substr
@@ -100,7 +150,8 @@ func AppendWithSeparator(text, addition string) string {
 	if text == "" {
 		return addition
 	}
-	// Ensure single newline separator
-	text = strings.TrimRight(text, "\n")
-	return text + "\n\n" + addition
+	// Ensure single newline separator, matching the body's line endings
+	crlf := strings.Contains(text, "\r\n")
+	text = strings.TrimRight(normalizeEOL(text), "\n")
+	return restoreEOL(text+"\n\n"+normalizeEOL(addition), crlf)
 }
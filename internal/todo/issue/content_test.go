@@ -105,6 +105,41 @@ func TestReplaceOnce(t *testing.T) {
 			new:     "world",
 			wantErr: "text not found in body",
 		},
+		{
+			name: "CRLF body matches LF old and keeps CRLF",
+			text: "## Tasks\r\n- [ ] One\r\n- [ ] Two\r\n",
+			old:  "- [ ] One\n- [ ] Two",
+			new:  "- [x] One\n- [x] Two",
+			want: "## Tasks\r\n- [x] One\r\n- [x] Two\r\n",
+		},
+		{
+			name: "LF body matches CRLF old",
+			text: "a\nb\nc",
+			old:  "a\r\nb",
+			new:  "x",
+			want: "x\nc",
+		},
+		{
+			name: "overlapping candidates count once without overlap",
+			text: "aaa",
+			old:  "aa",
+			new:  "X",
+			want: "Xa",
+		},
+		{
+			name:    "overlapping pattern counted without overlap",
+			text:    "abababa",
+			old:     "aba",
+			new:     "X",
+			wantErr: "text found 2 times in body (must be unique)",
+		},
+		{
+			name:    "near miss shows context",
+			text:    "- [ ] Write the docs\n- [ ] Ship",
+			old:     "- [ ] Write docs",
+			new:     "done",
+			wantErr: `text not found in body (closest match: "- [ ] Write the docs\n- [ ]")`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -396,3 +431,11 @@ func TestAppendWithSeparator(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendWithSeparatorCRLF(t *testing.T) {
+	got := AppendWithSeparator("line one\r\n", "## Notes\nmore")
+	want := "line one\r\n\r\n## Notes\r\nmore"
+	if got != want {
+		t.Errorf("AppendWithSeparator() = %q, want %q", got, want)
+	}
+}
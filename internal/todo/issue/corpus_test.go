@@ -0,0 +1,105 @@
+package issue
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// corpusFiles returns the real-world-ish issue files under testdata/corpus,
+// each paired with a <file>.json golden of its parsed fields.
+func corpusFiles(t testing.TB) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "corpus", "*.md"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no corpus files: %v", err)
+	}
+	return paths
+}
+
+// roundTrip renders b and parses the result, as a save and reload would.
+func roundTrip(b *Issue) (*Issue, error) {
+	if b.Encrypted {
+		b.Ciphertext = b.Body
+	}
+	rendered, err := b.Render()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(rendered))
+}
+
+// fields is b as JSON, a comparable snapshot of every field (the etag
+// covers the rendered form too).
+func fields(t testing.TB, b *Issue) []byte {
+	t.Helper()
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		t.Fatalf("marshaling %+v: %v", b, err)
+	}
+	return append(data, '\n')
+}
+
+func TestParseCorpus(t *testing.T) {
+	for _, path := range corpusFiles(t) {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := Parse(bytes.NewReader(content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			b.ID, _ = ParseFilename(filepath.Base(path))
+			got := fields(t, b)
+
+			want, err := os.ReadFile(path + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("parsed fields drifted from golden file:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+
+			again, err := roundTrip(b)
+			if err != nil {
+				t.Fatalf("Parse(Render()) error = %v", err)
+			}
+			again.ID = b.ID
+			if after := fields(t, again); !bytes.Equal(after, got) {
+				t.Errorf("Parse→Render→Parse changed fields:\ngot:\n%s\nwant:\n%s", after, got)
+			}
+		})
+	}
+}
+
+// FuzzParse checks that Parse never panics, and that whatever it accepts
+// renders and re-parses to the same fields. The corpus and canonical files
+// seed it, so `go test` runs those cases in short mode.
+func FuzzParse(f *testing.F) {
+	canonical, _ := filepath.Glob(filepath.Join("testdata", "canonical", "*.md"))
+	for _, path := range append(corpusFiles(f), canonical...) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(content)
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		b, err := Parse(bytes.NewReader(content))
+		if err != nil {
+			return
+		}
+		want := fields(t, b)
+		again, err := roundTrip(b)
+		if err != nil {
+			t.Fatalf("Parse(Render()) error = %v\ninput: %q", err, content)
+		}
+		if got := fields(t, again); !bytes.Equal(got, want) {
+			t.Errorf("Parse→Render→Parse changed fields of %q:\ngot:\n%s\nwant:\n%s", content, got, want)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package issue
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EncryptedPlaceholder stands in for the body of an encrypted issue when no
+// key (or the wrong key) is available to decrypt it.
+const EncryptedPlaceholder = "[encrypted — key unavailable]"
+
+// ciphertextPrefix tags encrypted bodies with the scheme and version so the
+// format can evolve without guessing.
+const ciphertextPrefix = "jig:aes-256-gcm:v1:"
+
+// ErrDecrypt is returned when an encrypted body cannot be decrypted, either
+// because the key is wrong or the ciphertext was altered.
+var ErrDecrypt = errors.New("cannot decrypt issue body (wrong key or corrupted ciphertext)")
+
+// DeriveKey turns a key string (from $JIG_ISSUE_KEY or a keyfile) into a
+// 256-bit AES key. Any non-empty string is accepted.
+func DeriveKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// EncryptBody seals plaintext with AES-256-GCM under key. The result is a
+// single line: the scheme prefix followed by base64(nonce || ciphertext).
+func EncryptBody(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return ciphertextPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptBody reverses EncryptBody.
+func DecryptBody(key []byte, ciphertext string) (string, error) {
+	encoded, ok := strings.CutPrefix(strings.TrimSpace(ciphertext), ciphertextPrefix)
+	if !ok {
+		return "", fmt.Errorf("%w: missing %q prefix", ErrDecrypt, ciphertextPrefix)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", ErrDecrypt
+	}
+	nonce, data := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
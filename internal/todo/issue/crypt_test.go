@@ -0,0 +1,49 @@
+package issue
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncryptBodyRoundTrip(t *testing.T) {
+	key := DeriveKey("secret")
+	sealed, err := EncryptBody(key, "line one\nline `two`")
+	if err != nil {
+		t.Fatalf("EncryptBody() error = %v", err)
+	}
+	if !strings.HasPrefix(sealed, ciphertextPrefix) || strings.Contains(sealed, "\n") {
+		t.Errorf("EncryptBody() = %q, want single prefixed line", sealed)
+	}
+
+	got, err := DecryptBody(key, sealed)
+	if err != nil || got != "line one\nline `two`" {
+		t.Errorf("DecryptBody() = %q, %v", got, err)
+	}
+
+	if _, err := DecryptBody(DeriveKey("other"), sealed); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("wrong key: err = %v, want ErrDecrypt", err)
+	}
+	if _, err := DecryptBody(key, "plain text"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("unprefixed: err = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestRenderEncryptedUsesCiphertext(t *testing.T) {
+	b := &Issue{Title: "T", Status: "ready", Body: "plain", Encrypted: true, Ciphertext: "jig:aes-256-gcm:v1:AAAA"}
+	out, err := b.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "plain") || !strings.Contains(string(out), b.Ciphertext) {
+		t.Errorf("Render() = %s", out)
+	}
+
+	parsed, err := Parse(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Encrypted || strings.TrimSpace(parsed.Body) != b.Ciphertext {
+		t.Errorf("Parse() encrypted=%v body=%q", parsed.Encrypted, parsed.Body)
+	}
+}
@@ -7,17 +7,45 @@ import (
 	"unicode"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/toba/jig/internal/todo/config"
 )
 
-const idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+// defaultIDGenerator generates IDs in the default xxx-xxx format.
+var defaultIDGenerator = NewIDGenerator(config.DefaultIDFormat())
 
-// NewID generates a new issue ID in the fixed xxx-xxx format (3 random chars, hyphen, 3 random chars).
+// NewID generates a new issue ID in the default xxx-xxx format (3 random chars, hyphen, 3 random chars).
 func NewID() string {
-	raw, err := gonanoid.Generate(idAlphabet, 6)
+	return defaultIDGenerator.NewID()
+}
+
+// IDGenerator generates issue IDs in an id_format.
+type IDGenerator struct {
+	format config.IDFormat
+}
+
+// NewIDGenerator returns a generator of IDs in format, which must be valid
+// (as Load checks) and have every field set (as GetIDFormat returns).
+func NewIDGenerator(format config.IDFormat) *IDGenerator {
+	return &IDGenerator{format: format}
+}
+
+// NewID generates a new ID: the prefix, then the random groups joined by
+// hyphens.
+func (g *IDGenerator) NewID() string {
+	f := g.format
+	raw, err := gonanoid.Generate(f.Alphabet, f.Groups*f.GroupLength)
 	if err != nil {
-		panic(err) // should never happen with valid alphabet
+		panic(err) // should never happen with a validated alphabet
 	}
-	return raw[:3] + "-" + raw[3:]
+	var id strings.Builder
+	id.WriteString(f.Prefix)
+	for i := range f.Groups {
+		if i > 0 {
+			id.WriteByte('-')
+		}
+		id.WriteString(raw[i*f.GroupLength : (i+1)*f.GroupLength])
+	}
+	return id.String()
 }
 
 // BuildPath returns the hash-prefixed relative path for an issue file.
@@ -56,6 +84,17 @@ func ParseFilename(name string) (id, slug string) {
 	return id, slug
 }
 
+// ParseFilename is ParseFilename for a filename that may hold an ID alone
+// in one of the matcher's formats: "abc-123.md" and "PLAT-k7mq.md" are
+// those IDs with no slug, where the legacy single-dash form would split
+// them.
+func (m *IDMatcher) ParseFilename(name string) (id, slug string) {
+	if bare := strings.TrimSuffix(name, ".md"); m.Match(bare) {
+		return bare, ""
+	}
+	return ParseFilename(name)
+}
+
 // BuildFilename constructs a filename from ID and optional slug.
 // Uses double-dash separator: id--slug.md
 func BuildFilename(id, slug string) string {
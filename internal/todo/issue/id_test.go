@@ -3,6 +3,8 @@ package issue
 import (
 	"strings"
 	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
 )
 
 func TestSlugify(t *testing.T) {
@@ -47,6 +49,46 @@ func TestSlugify(t *testing.T) {
 	}
 }
 
+func TestIDGeneratorCustomFormat(t *testing.T) {
+	format := config.IDFormat{Prefix: "PLAT-", Groups: 1, GroupLength: 4, Alphabet: "abcdefghjkmnpqrstuvwxyz23456789"}
+	gen := NewIDGenerator(format)
+	matcher := NewIDMatcher(format)
+	for range 100 {
+		id := gen.NewID()
+		if len(id) != 9 || !strings.HasPrefix(id, "PLAT-") {
+			t.Fatalf("NewID() = %q, want PLAT- and 4 characters", id)
+		}
+		if strings.ContainsAny(id[5:], "01ilo") {
+			t.Errorf("NewID() = %q uses a character outside the alphabet", id)
+		}
+		if !matcher.Match(id) {
+			t.Errorf("Match(%q) = false for a generated ID", id)
+		}
+	}
+
+	groups := NewIDGenerator(config.IDFormat{Groups: 3, GroupLength: 2, Alphabet: "ab"}).NewID()
+	if len(groups) != 8 || groups[2] != '-' || groups[5] != '-' {
+		t.Errorf("NewID() = %q, want three hyphenated pairs", groups)
+	}
+}
+
+func TestIDMatcherParseFilename(t *testing.T) {
+	m := NewIDMatcher(config.DefaultIDFormat(), config.IDFormat{Prefix: "PLAT-", Groups: 1, GroupLength: 4, Alphabet: "abcdefghjkmnpqrstuvwxyz23456789"})
+	tests := []struct {
+		filename, id, slug string
+	}{
+		{"abc-123.md", "abc-123", ""},
+		{"PLAT-k7mq.md", "PLAT-k7mq", ""},
+		{"PLAT-k7mq--spec.md", "PLAT-k7mq", "spec"},
+		{"f7g-user-registration.md", "f7g", "user-registration"},
+	}
+	for _, tt := range tests {
+		if id, slug := m.ParseFilename(tt.filename); id != tt.id || slug != tt.slug {
+			t.Errorf("ParseFilename(%q) = (%q, %q), want (%q, %q)", tt.filename, id, slug, tt.id, tt.slug)
+		}
+	}
+}
+
 func TestParseFilename(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -130,8 +172,8 @@ func TestNewID(t *testing.T) {
 				if i == 3 {
 					continue // skip the hyphen
 				}
-				if !strings.ContainsRune(idAlphabet, r) {
-					t.Errorf("NewID contains invalid character %q at position %d, should only use %q", r, i, idAlphabet)
+				if !strings.ContainsRune(config.DefaultIDAlphabet, r) {
+					t.Errorf("NewID contains invalid character %q at position %d, should only use %q", r, i, config.DefaultIDAlphabet)
 				}
 			}
 		}
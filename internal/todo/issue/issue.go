@@ -123,69 +123,163 @@ func (b *Issue) RemoveSync(name string) {
 	}
 }
 
-// DueDate is a date-only wrapper around time.Time that serializes as "YYYY-MM-DD".
+// DueDate wraps time.Time for due dates. It serializes as "YYYY-MM-DD" unless
+// a time of day was provided, in which case it serializes as RFC 3339.
 type DueDate struct {
 	time.Time
+	// HasTime reports whether the due date carries a time of day.
+	HasTime bool
 }
 
-// DueDateFormat is the format used for due dates.
+// DueDateFormat is the format used for date-only due dates.
 const DueDateFormat = "2006-01-02"
 
+// DueDateTimeFormat is the format used for due dates with a time of day.
+const DueDateTimeFormat = time.RFC3339
+
 // NewDueDate creates a DueDate from a time.Time, zeroing the time component.
 func NewDueDate(t time.Time) *DueDate {
 	d := DueDate{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
 	return &d
 }
 
-// ParseDueDate parses a "YYYY-MM-DD" string into a DueDate.
+// NewDueDateTime creates a DueDate that keeps t's time of day and location.
+func NewDueDateTime(t time.Time) *DueDate {
+	return &DueDate{Time: t.Truncate(time.Second), HasTime: true}
+}
+
+// ParseDueDate parses a "YYYY-MM-DD" or RFC 3339 string into a DueDate.
 func ParseDueDate(s string) (*DueDate, error) {
-	t, err := time.Parse(DueDateFormat, s)
+	if t, err := time.Parse(DueDateFormat, s); err == nil {
+		return NewDueDate(t), nil
+	}
+	if t, err := time.Parse(DueDateTimeFormat, s); err == nil {
+		return NewDueDateTime(t), nil
+	}
+	return nil, fmt.Errorf("invalid due date %q: expected YYYY-MM-DD format (or RFC 3339 with a time)", s)
+}
+
+// ParseRelativeDue parses a due date relative to now: "today", "tomorrow", a
+// weekday name or its three-letter abbreviation (the next one after today),
+// an offset in days or weeks ("3d", "2w"), or anything ParseDueDate accepts.
+// Relative forms give a date-only due date on now's calendar.
+func ParseRelativeDue(s string, now time.Time) (*DueDate, error) {
+	word := strings.ToLower(strings.TrimSpace(s))
+	day := func(offset int) *DueDate {
+		return NewDueDate(time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, time.UTC))
+	}
+	switch word {
+	case "today":
+		return day(0), nil
+	case "tomorrow":
+		return day(1), nil
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if word == name || word == name[:3] {
+			return day((int(wd)-int(now.Weekday())+6)%7 + 1), nil
+		}
+	}
+	for suffix, days := range map[string]int{"d": 1, "w": 7} {
+		if n, ok := strings.CutSuffix(word, suffix); ok {
+			if v, err := strconv.Atoi(n); err == nil && v >= 0 {
+				return day(v * days), nil
+			}
+		}
+	}
+	if d, err := ParseDueDate(strings.TrimSpace(s)); err == nil {
+		return d, nil
+	}
+	return nil, fmt.Errorf("invalid due date %q: expected YYYY-MM-DD, today, tomorrow, a weekday, or an offset like 3d or 2w", s)
+}
+
+// ParseDueDateTime combines a "YYYY-MM-DD" date with a "15:04" time of day
+// in loc, as given by `--due` and `--due-time` on the command line.
+func ParseDueDateTime(date, clock string, loc *time.Location) (*DueDate, error) {
+	t, err := time.ParseInLocation(DueDateFormat+" 15:04", date+" "+clock, loc)
 	if err != nil {
-		return nil, fmt.Errorf("invalid due date %q: expected YYYY-MM-DD format", s)
+		if _, dateErr := time.Parse(DueDateFormat, date); dateErr != nil {
+			return nil, fmt.Errorf("invalid due date %q: expected YYYY-MM-DD format", date)
+		}
+		return nil, fmt.Errorf("invalid due time %q: expected HH:MM format", clock)
 	}
-	return NewDueDate(t), nil
+	return NewDueDateTime(t), nil
+}
+
+// Deadline returns the instant by which the issue is due. A date-only value
+// is due by the end of its day, so it sorts after any time on the same day.
+func (d DueDate) Deadline() time.Time {
+	if d.HasTime {
+		return d.Time
+	}
+	return d.AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// DateKey returns the calendar date as "YYYY-MM-DD", in the due date's own
+// location for values with a time, for grouping and comparing by day.
+func (d DueDate) DateKey() string {
+	return d.Format(DueDateFormat)
 }
 
-// MarshalYAML implements yaml.Marshaler to serialize as "YYYY-MM-DD".
+// MarshalYAML implements yaml.Marshaler, using the precision that was provided.
 func (d DueDate) MarshalYAML() (any, error) {
-	return d.Format(DueDateFormat), nil
+	return d.String(), nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler to parse "YYYY-MM-DD".
+// UnmarshalYAML implements yaml.Unmarshaler to parse "YYYY-MM-DD" or RFC 3339.
 func (d *DueDate) UnmarshalYAML(unmarshal func(any) error) error {
 	var s string
 	if err := unmarshal(&s); err != nil {
 		return err
 	}
-	t, err := time.Parse(DueDateFormat, s)
+	parsed, err := ParseDueDate(s)
 	if err != nil {
-		return fmt.Errorf("invalid due date %q: expected YYYY-MM-DD format", s)
+		return err
 	}
-	d.Time = t
+	*d = *parsed
 	return nil
 }
 
-// MarshalJSON implements json.Marshaler to serialize as "YYYY-MM-DD".
+// MarshalJSON implements json.Marshaler, using the precision that was provided.
 func (d DueDate) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Format(DueDateFormat))
+	return json.Marshal(d.String())
 }
 
-// UnmarshalJSON implements json.Unmarshaler to parse "YYYY-MM-DD".
+// UnmarshalJSON implements json.Unmarshaler to parse "YYYY-MM-DD" or RFC 3339.
 func (d *DueDate) UnmarshalJSON(data []byte) error {
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	t, err := time.Parse(DueDateFormat, s)
+	parsed, err := ParseDueDate(s)
 	if err != nil {
-		return fmt.Errorf("invalid due date %q: expected YYYY-MM-DD format", s)
+		return err
+	}
+	*d = *parsed
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder with the same text as MarshalYAML, so
+// HasTime survives (the promoted time.Time method would drop it).
+func (d DueDate) GobEncode() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// GobDecode implements gob.GobDecoder for the text written by GobEncode.
+func (d *DueDate) GobDecode(data []byte) error {
+	parsed, err := ParseDueDate(string(data))
+	if err != nil {
+		return err
 	}
-	d.Time = t
+	*d = *parsed
 	return nil
 }
 
-// String returns the date as "YYYY-MM-DD".
+// String returns the date as "YYYY-MM-DD", or as RFC 3339 when it has a time.
 func (d DueDate) String() string {
+	if d.HasTime {
+		return d.Format(DueDateTimeFormat)
+	}
 	return d.Format(DueDateFormat)
 }
 
@@ -200,18 +294,49 @@ type Issue struct {
 
 	// Front matter fields
 	Title     string     `yaml:"title" json:"title"`
+	Summary   string     `yaml:"summary,omitempty" json:"summary,omitempty"` // one-line description, see Synopsis
 	Status    string     `yaml:"status" json:"status"`
 	Type      string     `yaml:"type,omitempty" json:"type,omitempty"`
 	Priority  string     `yaml:"priority,omitempty" json:"priority,omitempty"`
 	Milestone string     `yaml:"milestone,omitempty" json:"milestone,omitempty"` // milestone id
+	Iteration string     `yaml:"iteration,omitempty" json:"iteration,omitempty"` // ISO week or declared iteration
+	Estimate  string     `yaml:"estimate,omitempty" json:"estimate,omitempty"`   // expected effort, e.g. "4h" or "2d"
 	Tags      []string   `yaml:"tags,omitempty" json:"tags,omitempty"`
 	CreatedAt *time.Time `yaml:"created_at,omitempty" json:"created_at,omitempty"`
 	UpdatedAt *time.Time `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
 	Due       *DueDate   `yaml:"due,omitempty" json:"due,omitempty"`
-
-	// Body is the markdown content after the front matter.
+	// NaiveTimes lists the timestamp keys (KeyCreatedAt, KeyUpdatedAt) that
+	// had no zone in the file and were read as UTC.
+	NaiveTimes []string `yaml:"-" json:"-"`
+	// CreatedBy is who created the issue ($JIG_ACTOR or the OS user for
+	// the CLI and TUI), and CreatedVia the entry point, one of the
+	// CreatedVia constants. Both are empty on issues that predate them.
+	CreatedBy  string `yaml:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedVia string `yaml:"created_via,omitempty" json:"created_via,omitempty"`
+	// Pinned issues sort ahead of the rest in every sort order.
+	Pinned bool `yaml:"pinned,omitempty" json:"pinned,omitempty"`
+	// Visibility is "internal" for issues kept out of public egress
+	// (exports, changelogs, roadmaps, sync); empty means public.
+	Visibility string `yaml:"visibility,omitempty" json:"visibility,omitempty"`
+	// ReleaseTitle and ReleaseNote are the customer-facing title and note
+	// the changelog uses in place of Title and Body when set.
+	ReleaseTitle string `yaml:"release_title,omitempty" json:"release_title,omitempty"`
+	ReleaseNote  string `yaml:"release_note,omitempty" json:"release_note,omitempty"`
+	// MergedInto is the issue this one was merged into by jig todo merge.
+	MergedInto string `yaml:"merged_into,omitempty" json:"merged_into,omitempty"`
+
+	// Body is the markdown content after the front matter. For encrypted
+	// issues it holds the decrypted text, or EncryptedPlaceholder when the
+	// key is unavailable.
 	Body string `yaml:"-" json:"body,omitempty"`
 
+	// Encrypted marks the body as encrypted at rest. Metadata stays plaintext.
+	Encrypted bool `yaml:"encrypted,omitempty" json:"encrypted,omitempty"`
+
+	// Ciphertext is the encrypted body as stored on disk. Render writes it in
+	// place of Body when Encrypted is set.
+	Ciphertext string `yaml:"-" json:"-"`
+
 	// Parent is the optional parent issue ID (milestone, epic, or feature).
 	Parent string `yaml:"parent,omitempty" json:"parent,omitempty"`
 
@@ -227,83 +352,255 @@ type Issue struct {
 
 // frontMatter is the subset of Issue that gets serialized to YAML front matter.
 type frontMatter struct {
-	Title     string                    `yaml:"title"`
-	Status    string                    `yaml:"status"`
-	Type      string                    `yaml:"type,omitempty"`
-	Priority  string                    `yaml:"priority,omitempty"`
-	Milestone string                    `yaml:"milestone,omitempty"`
-	Tags      []string                  `yaml:"tags,omitempty"`
-	CreatedAt *time.Time                `yaml:"created_at,omitempty"`
-	UpdatedAt *time.Time                `yaml:"updated_at,omitempty"`
-	Due       *DueDate                  `yaml:"due,omitempty"`
-	Parent    string                    `yaml:"parent,omitempty"`
-	Blocking  []string                  `yaml:"blocking,omitempty"`
-	BlockedBy []string                  `yaml:"blocked_by,omitempty"`
-	Sync      map[string]map[string]any `yaml:"sync,omitempty"`
+	Title        string                    `yaml:"title"`
+	Summary      string                    `yaml:"summary,omitempty"`
+	Status       string                    `yaml:"status"`
+	Type         string                    `yaml:"type,omitempty"`
+	Priority     string                    `yaml:"priority,omitempty"`
+	Milestone    string                    `yaml:"milestone,omitempty"`
+	Iteration    string                    `yaml:"iteration,omitempty"`
+	Estimate     string                    `yaml:"estimate,omitempty"`
+	Tags         []string                  `yaml:"tags,omitempty"`
+	CreatedAt    *timestamp                `yaml:"created_at,omitempty"`
+	UpdatedAt    *timestamp                `yaml:"updated_at,omitempty"`
+	Due          *DueDate                  `yaml:"due,omitempty"`
+	CreatedBy    string                    `yaml:"created_by,omitempty"`
+	CreatedVia   string                    `yaml:"created_via,omitempty"`
+	Pinned       bool                      `yaml:"pinned,omitempty"`
+	Visibility   string                    `yaml:"visibility,omitempty"`
+	ReleaseTitle string                    `yaml:"release_title,omitempty"`
+	ReleaseNote  string                    `yaml:"release_note,omitempty"`
+	MergedInto   string                    `yaml:"merged_into,omitempty"`
+	Parent       string                    `yaml:"parent,omitempty"`
+	Blocking     []string                  `yaml:"blocking,omitempty"`
+	BlockedBy    []string                  `yaml:"blocked_by,omitempty"`
+	Encrypted    bool                      `yaml:"encrypted,omitempty"`
+	Sync         map[string]map[string]any `yaml:"sync,omitempty"`
 }
 
 // Parse reads an issue from a reader (markdown with YAML front matter).
+// A leading byte order mark is ignored, Windows and classic Mac line
+// endings are read as "\n", and tabs indenting front matter lines (which
+// YAML rejects) count as two spaces each, so files saved by other editors
+// parse like jig's own.
 func Parse(r io.Reader) (*Issue, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading issue: %w", err)
+	}
+	content = expandFrontMatterTabs(normalizeNewlines(bytes.TrimPrefix(content, utf8BOM)))
+
 	var fm frontMatter
-	body, err := frontmatter.Parse(r, &fm)
+	body, err := frontmatter.Parse(bytes.NewReader(content), &fm)
 	if err != nil {
 		return nil, fmt.Errorf("parsing front matter: %w", err)
 	}
 
-	// Trim trailing newline from body (POSIX files end with newline, but it's not part of content)
-	bodyStr := strings.TrimSuffix(string(body), "\n")
+	// Blank lines between the front matter and the body, and the newlines
+	// the file ends with, are not part of the body. Render puts back one of
+	// each, so a parsed issue renders and re-parses to the same body.
+	bodyStr := strings.Trim(string(body), "\n")
 
 	return &Issue{
-		Title:     fm.Title,
-		Status:    fm.Status,
-		Type:      fm.Type,
-		Priority:  fm.Priority,
-		Milestone: fm.Milestone,
-		Tags:      fm.Tags,
-		CreatedAt: fm.CreatedAt,
-		UpdatedAt: fm.UpdatedAt,
-		Due:       fm.Due,
-		Body:      bodyStr,
-		Parent:    fm.Parent,
-		Blocking:  fm.Blocking,
-		BlockedBy: fm.BlockedBy,
-		Sync:      fm.Sync,
+		Title:        fm.Title,
+		Summary:      fm.Summary,
+		Status:       fm.Status,
+		Type:         fm.Type,
+		Priority:     fm.Priority,
+		Milestone:    fm.Milestone,
+		Iteration:    fm.Iteration,
+		Estimate:     fm.Estimate,
+		Tags:         fm.Tags,
+		CreatedAt:    nonZeroTime(fm.CreatedAt),
+		UpdatedAt:    nonZeroTime(fm.UpdatedAt),
+		NaiveTimes:   naiveKeys(fm.CreatedAt, fm.UpdatedAt),
+		Due:          fm.Due,
+		CreatedBy:    fm.CreatedBy,
+		CreatedVia:   fm.CreatedVia,
+		Pinned:       fm.Pinned,
+		Visibility:   fm.Visibility,
+		ReleaseTitle: fm.ReleaseTitle,
+		ReleaseNote:  fm.ReleaseNote,
+		MergedInto:   fm.MergedInto,
+		Body:         bodyStr,
+		Parent:       fm.Parent,
+		Blocking:     fm.Blocking,
+		BlockedBy:    fm.BlockedBy,
+		Encrypted:    fm.Encrypted,
+		Sync:         nonNilSync(fm.Sync),
 	}, nil
 }
 
+// nonNilSync replaces null sync entries with empty ones, which is how
+// Render writes them.
+func nonNilSync(sync map[string]map[string]any) map[string]map[string]any {
+	for name, data := range sync {
+		if data == nil {
+			sync[name] = map[string]any{}
+		}
+	}
+	return sync
+}
+
+// utf8BOM is the byte order mark some Windows editors write at the start
+// of UTF-8 files.
+var utf8BOM = []byte("\ufeff")
+
+// normalizeNewlines rewrites "\r\n" and lone "\r" line endings as "\n".
+func normalizeNewlines(content []byte) []byte {
+	if !bytes.ContainsRune(content, '\r') {
+		return content
+	}
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+}
+
+// expandFrontMatterTabs replaces the tabs that start front matter lines
+// with two spaces each. YAML does not allow tabs as indentation, so this only
+// changes front matter that would otherwise fail to parse.
+func expandFrontMatterTabs(content []byte) []byte {
+	rest, ok := bytes.CutPrefix(content, []byte("---\n"))
+	if !ok {
+		return content
+	}
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 || !bytes.Contains(rest[:end], []byte("\n\t")) && !bytes.HasPrefix(rest, []byte("\t")) {
+		return content
+	}
+	lines := bytes.SplitAfter(rest[:end+1], []byte("\n"))
+	out := make([]byte, 0, len(content)+len(lines))
+	out = append(out, "---\n"...)
+	for _, line := range lines {
+		trimmed := bytes.TrimLeft(line, "\t")
+		out = append(out, bytes.Repeat([]byte("  "), len(line)-len(trimmed))...)
+		out = append(out, trimmed...)
+	}
+	return append(out, rest[end+1:]...)
+}
+
 // renderFrontMatter is used for YAML output with yaml.v3 (supports custom marshalers).
+// Its field order is the canonical key order of an issue file; yaml.v3
+// sorts map keys, so sync data renders in a stable order too.
 type renderFrontMatter struct {
-	Title     string                    `yaml:"title"`
-	Status    string                    `yaml:"status"`
-	Type      string                    `yaml:"type,omitempty"`
-	Priority  string                    `yaml:"priority,omitempty"`
-	Milestone string                    `yaml:"milestone,omitempty"`
-	Tags      []string                  `yaml:"tags,omitempty"`
-	CreatedAt *time.Time                `yaml:"created_at,omitempty"`
-	UpdatedAt *time.Time                `yaml:"updated_at,omitempty"`
-	Due       *DueDate                  `yaml:"due,omitempty"`
-	Parent    string                    `yaml:"parent,omitempty"`
-	Blocking  []string                  `yaml:"blocking,omitempty"`
-	BlockedBy []string                  `yaml:"blocked_by,omitempty"`
-	Sync      map[string]map[string]any `yaml:"sync,omitempty"`
+	Title        yamlText                  `yaml:"title"`
+	Summary      yamlText                  `yaml:"summary,omitempty"`
+	Status       yamlText                  `yaml:"status"`
+	Type         yamlText                  `yaml:"type,omitempty"`
+	Priority     yamlText                  `yaml:"priority,omitempty"`
+	Milestone    yamlText                  `yaml:"milestone,omitempty"`
+	Iteration    yamlText                  `yaml:"iteration,omitempty"`
+	Estimate     yamlText                  `yaml:"estimate,omitempty"`
+	Tags         []yamlText                `yaml:"tags,omitempty"`
+	CreatedAt    *time.Time                `yaml:"created_at,omitempty"`
+	UpdatedAt    *time.Time                `yaml:"updated_at,omitempty"`
+	Due          *DueDate                  `yaml:"due,omitempty"`
+	CreatedBy    yamlText                  `yaml:"created_by,omitempty"`
+	CreatedVia   yamlText                  `yaml:"created_via,omitempty"`
+	Pinned       bool                      `yaml:"pinned,omitempty"`
+	Visibility   yamlText                  `yaml:"visibility,omitempty"`
+	ReleaseTitle yamlText                  `yaml:"release_title,omitempty"`
+	ReleaseNote  yamlText                  `yaml:"release_note,omitempty"`
+	MergedInto   yamlText                  `yaml:"merged_into,omitempty"`
+	Parent       yamlText                  `yaml:"parent,omitempty"`
+	Blocking     []yamlText                `yaml:"blocking,omitempty"`
+	BlockedBy    []yamlText                `yaml:"blocked_by,omitempty"`
+	Encrypted    bool                      `yaml:"encrypted,omitempty"`
+	Sync         map[string]map[string]any `yaml:"sync,omitempty"`
+}
+
+// yamlText is a front matter string. yaml.v3 writes a string that starts
+// with a newline as a literal block that loses it, so those are written
+// double-quoted instead; everything else marshals as a plain string would.
+type yamlText string
+
+// MarshalYAML implements yaml.Marshaler.
+func (s yamlText) MarshalYAML() (any, error) {
+	if strings.HasPrefix(string(s), "\n") {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: string(s)}, nil
+	}
+	return string(s), nil
+}
+
+func yamlTexts(ss []string) []yamlText {
+	if ss == nil {
+		return nil
+	}
+	out := make([]yamlText, len(ss))
+	for i, s := range ss {
+		out[i] = yamlText(s)
+	}
+	return out
+}
+
+// yamlSync copies sync data with its strings as yamlText.
+func yamlSync(sync map[string]map[string]any) map[string]map[string]any {
+	if sync == nil {
+		return nil
+	}
+	out := make(map[string]map[string]any, len(sync))
+	for name, data := range sync {
+		out[name] = yamlValue(data).(map[string]any)
+	}
+	return out
+}
+
+func yamlValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return yamlText(v)
+	case map[string]any:
+		if v == nil {
+			return map[string]any(nil)
+		}
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = yamlValue(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = yamlValue(e)
+		}
+		return out
+	default:
+		return v
+	}
 }
 
 // Render serializes the issue back to markdown with YAML front matter.
+// Encrypted issues render their Ciphertext in place of the body.
 func (b *Issue) Render() ([]byte, error) {
 	fm := renderFrontMatter{
-		Title:     b.Title,
-		Status:    b.Status,
-		Type:      b.Type,
-		Priority:  b.Priority,
-		Milestone: b.Milestone,
-		Tags:      b.Tags,
-		CreatedAt: b.CreatedAt,
-		UpdatedAt: b.UpdatedAt,
-		Due:       b.Due,
-		Parent:    b.Parent,
-		Blocking:  b.Blocking,
-		BlockedBy: b.BlockedBy,
-		Sync:      b.Sync,
+		Title:        yamlText(b.Title),
+		Summary:      yamlText(b.Summary),
+		Status:       yamlText(b.Status),
+		Type:         yamlText(b.Type),
+		Priority:     yamlText(b.Priority),
+		Milestone:    yamlText(b.Milestone),
+		Iteration:    yamlText(b.Iteration),
+		Estimate:     yamlText(b.Estimate),
+		Tags:         yamlTexts(b.Tags),
+		CreatedAt:    utcTime(b.CreatedAt),
+		UpdatedAt:    utcTime(b.UpdatedAt),
+		Due:          b.Due,
+		CreatedBy:    yamlText(b.CreatedBy),
+		CreatedVia:   yamlText(b.CreatedVia),
+		Pinned:       b.Pinned,
+		Visibility:   yamlText(b.Visibility),
+		ReleaseTitle: yamlText(b.ReleaseTitle),
+		ReleaseNote:  yamlText(b.ReleaseNote),
+		MergedInto:   yamlText(b.MergedInto),
+		Parent:       yamlText(b.Parent),
+		Blocking:     yamlTexts(b.Blocking),
+		BlockedBy:    yamlTexts(b.BlockedBy),
+		Encrypted:    b.Encrypted,
+		Sync:         yamlSync(b.Sync),
+	}
+
+	body := b.Body
+	if b.Encrypted {
+		body = b.Ciphertext
 	}
 
 	fmBytes, err := yaml.Marshal(&fm)
@@ -320,14 +617,14 @@ func (b *Issue) Render() ([]byte, error) {
 	}
 	buf.Write(fmBytes)
 	buf.WriteString("---\n")
-	if b.Body != "" {
+	if body != "" {
 		// Only add newline separator if body doesn't already start with one
-		if !strings.HasPrefix(b.Body, "\n") {
+		if !strings.HasPrefix(body, "\n") {
 			buf.WriteString("\n")
 		}
-		buf.WriteString(b.Body)
+		buf.WriteString(body)
 		// Ensure trailing newline if body doesn't end with one
-		if !strings.HasSuffix(b.Body, "\n") {
+		if !strings.HasSuffix(body, "\n") {
 			buf.WriteString("\n")
 		}
 	} else {
@@ -338,6 +635,22 @@ func (b *Issue) Render() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Format rewrites the content of the issue file for id in canonical form:
+// front matter keys in Render's order, lists in block style, and one blank
+// line between the front matter and the body. Keys jig does not know are
+// dropped. Formatting canonical content returns it unchanged.
+func Format(id string, content []byte) ([]byte, error) {
+	b, err := Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	b.ID = id
+	if b.Encrypted {
+		b.Ciphertext = strings.TrimSpace(b.Body)
+	}
+	return b.Render()
+}
+
 // ETag returns a hash of the issue's rendered content for optimistic concurrency control.
 // Uses FNV-1a 64-bit hash, producing a 16-character hex string.
 // Returns "0000000000000000" if rendering fails (should never happen for valid issues).
@@ -378,29 +691,40 @@ func (b *Issue) GithubIssueNumber() int {
 	return n
 }
 
-// MarshalJSON implements json.Marshaler to include computed etag and github_issue fields.
+// JSONView is the JSON form of an issue: its stored fields followed by the
+// computed due_ts, github_issue, prs, and etag. Commands that add fields of
+// their own embed it in a struct alongside them.
+type JSONView struct {
+	*jsonFields
+	// DueTS gives scripts an epoch to compare when the due date has a time.
+	DueTS       *int64        `json:"due_ts,omitempty"`
+	GithubIssue *int          `json:"github_issue"`
+	PRs         []PullRequest `json:"prs,omitempty"`
+	ETag        string        `json:"etag"`
+}
+
+// jsonFields is Issue without its methods, so embedding it does not promote
+// MarshalJSON.
+type jsonFields Issue
+
+// JSONView returns the JSON form of b.
+func (b *Issue) JSONView() JSONView {
+	v := JSONView{
+		jsonFields: (*jsonFields)(b),
+		PRs:        b.GithubPullRequests(),
+		ETag:       b.ETag(),
+	}
+	if b.Due != nil && b.Due.HasTime {
+		v.DueTS = new(b.Due.Unix())
+	}
+	if n := b.GithubIssueNumber(); n != 0 {
+		v.GithubIssue = &n
+	}
+	return v
+}
+
+// MarshalJSON implements json.Marshaler to include computed etag, github_issue,
+// and prs fields.
 func (b *Issue) MarshalJSON() ([]byte, error) {
-	type IssueAlias Issue // Avoid infinite recursion
-
-	ghIssue := b.GithubIssueNumber()
-	if ghIssue == 0 {
-		return json.Marshal(&struct {
-			*IssueAlias
-			GithubIssue *int   `json:"github_issue"`
-			ETag        string `json:"etag"`
-		}{
-			IssueAlias:  (*IssueAlias)(b),
-			GithubIssue: nil,
-			ETag:        b.ETag(),
-		})
-	}
-	return json.Marshal(&struct {
-		*IssueAlias
-		GithubIssue int    `json:"github_issue"`
-		ETag        string `json:"etag"`
-	}{
-		IssueAlias:  (*IssueAlias)(b),
-		GithubIssue: ghIssue,
-		ETag:        b.ETag(),
-	})
+	return json.Marshal(b.JSONView())
 }
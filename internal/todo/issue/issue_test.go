@@ -1,8 +1,11 @@
 package issue
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -27,7 +30,7 @@ status: todo
 This is the body.`,
 			expectedTitle:  "Test Issue",
 			expectedStatus: "todo",
-			expectedBody:   "\nThis is the body.",
+			expectedBody:   "This is the body.",
 		},
 		{
 			name: "with timestamps",
@@ -41,7 +44,7 @@ updated_at: 2024-01-16T14:45:00Z
 Body content here.`,
 			expectedTitle:  "With Times",
 			expectedStatus: "in-progress",
-			expectedBody:   "\nBody content here.",
+			expectedBody:   "Body content here.",
 		},
 		{
 			name: "empty body",
@@ -68,7 +71,7 @@ status: todo
 Paragraph text.`,
 			expectedTitle:  "Multi Line",
 			expectedStatus: "todo",
-			expectedBody:   "\n# Header\n\n- Item 1\n- Item 2\n\nParagraph text.",
+			expectedBody:   "# Header\n\n- Item 1\n- Item 2\n\nParagraph text.",
 		},
 		{
 			name:           "plain text without frontmatter",
@@ -424,6 +427,31 @@ func TestParseRenderRoundtrip(t *testing.T) {
 				Body:   "Bug description.",
 			},
 		},
+		{
+			name: "pinned",
+			issue: &Issue{
+				Title:  "Pinned Issue",
+				Status: "todo",
+				Pinned: true,
+			},
+		},
+		{
+			name: "internal",
+			issue: &Issue{
+				Title:      "Internal Issue",
+				Status:     "todo",
+				Visibility: "internal",
+			},
+		},
+		{
+			name: "release wording",
+			issue: &Issue{
+				Title:        "Fix the horrible auth hack",
+				Status:       "completed",
+				ReleaseTitle: "Sign-in is more reliable",
+				ReleaseNote:  "Sessions no longer expire early.\n\nNo action needed.",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -450,14 +478,18 @@ func TestParseRenderRoundtrip(t *testing.T) {
 			if parsed.Type != tt.issue.Type {
 				t.Errorf("Type roundtrip: got %q, want %q", parsed.Type, tt.issue.Type)
 			}
-
-			// Body comparison (parse adds newline prefix for non-empty body)
-			wantBody := tt.issue.Body
-			if wantBody != "" {
-				wantBody = "\n" + wantBody
+			if parsed.Pinned != tt.issue.Pinned {
+				t.Errorf("Pinned roundtrip: got %v, want %v", parsed.Pinned, tt.issue.Pinned)
+			}
+			if parsed.Visibility != tt.issue.Visibility {
+				t.Errorf("Visibility roundtrip: got %q, want %q", parsed.Visibility, tt.issue.Visibility)
 			}
-			if parsed.Body != wantBody {
-				t.Errorf("Body roundtrip: got %q, want %q", parsed.Body, wantBody)
+			if parsed.ReleaseTitle != tt.issue.ReleaseTitle || parsed.ReleaseNote != tt.issue.ReleaseNote {
+				t.Errorf("release roundtrip: got %q/%q, want %q/%q", parsed.ReleaseTitle, parsed.ReleaseNote, tt.issue.ReleaseTitle, tt.issue.ReleaseNote)
+			}
+
+			if parsed.Body != tt.issue.Body {
+				t.Errorf("Body roundtrip: got %q, want %q", parsed.Body, tt.issue.Body)
 			}
 
 			// Timestamp comparison
@@ -2160,6 +2192,47 @@ func TestParseDueDate(t *testing.T) {
 	}
 }
 
+func TestParseRelativeDue(t *testing.T) {
+	// A Wednesday evening, local to a zone west of UTC.
+	now := time.Date(2025, 6, 11, 22, 0, 0, 0, time.FixedZone("test", -7*60*60))
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"today", "2025-06-11", false},
+		{"Tomorrow", "2025-06-12", false},
+		{"friday", "2025-06-13", false},
+		{"fri", "2025-06-13", false},
+		{"wednesday", "2025-06-18", false},
+		{"mon", "2025-06-16", false},
+		{"3d", "2025-06-14", false},
+		{"2w", "2025-06-25", false},
+		{"2025-06-01", "2025-06-01", false},
+		{"2025-06-01T09:00:00Z", "2025-06-01T09:00:00Z", false},
+		{"someday", "", true},
+		{"-3d", "", true},
+		{"fr", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseRelativeDue(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.String() != tt.want {
+				t.Errorf("got %q, want %q", d.String(), tt.want)
+			}
+		})
+	}
+}
+
 func TestDueDateJSON(t *testing.T) {
 	due := NewDueDate(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
 	b := &Issue{
@@ -2180,6 +2253,61 @@ func TestDueDateJSON(t *testing.T) {
 	}
 }
 
+func TestDueDateWithTime(t *testing.T) {
+	d, err := ParseDueDate("2025-06-15T17:00:00Z")
+	if err != nil {
+		t.Fatalf("ParseDueDate error: %v", err)
+	}
+	if !d.HasTime || d.String() != "2025-06-15T17:00:00Z" {
+		t.Errorf("got %q (HasTime=%v), want 2025-06-15T17:00:00Z with time", d.String(), d.HasTime)
+	}
+
+	b := &Issue{Title: "Timed", Status: "todo", Due: d}
+	rendered, err := b.Render()
+	if err != nil {
+		t.Fatalf("Render error: %v", err)
+	}
+	parsed, err := Parse(strings.NewReader(string(rendered)))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if parsed.Due == nil || !parsed.Due.HasTime || !parsed.Due.Equal(d.Time) {
+		t.Errorf("round trip: got %v, want %v", parsed.Due, d)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"due":"2025-06-15T17:00:00Z"`) ||
+		!strings.Contains(string(data), fmt.Sprintf(`"due_ts":%d`, d.Unix())) {
+		t.Errorf("JSON should contain due time and due_ts, got: %s", data)
+	}
+
+	b.Due = NewDueDate(d.Time)
+	data, _ = json.Marshal(b)
+	if strings.Contains(string(data), "due_ts") {
+		t.Errorf("date-only due should omit due_ts, got: %s", data)
+	}
+}
+
+func TestParseDueDateTime(t *testing.T) {
+	loc := time.FixedZone("test", -5*60*60)
+	d, err := ParseDueDateTime("2025-06-15", "17:30", loc)
+	if err != nil {
+		t.Fatalf("ParseDueDateTime error: %v", err)
+	}
+	if d.String() != "2025-06-15T17:30:00-05:00" {
+		t.Errorf("got %q, want 2025-06-15T17:30:00-05:00", d.String())
+	}
+	if _, err := ParseDueDateTime("2025-06-15", "5pm", loc); err == nil || !strings.Contains(err.Error(), "due time") {
+		t.Errorf("expected due time error, got %v", err)
+	}
+	if _, err := ParseDueDateTime("June 15", "17:30", loc); err == nil || !strings.Contains(err.Error(), "due date") {
+		t.Errorf("expected due date error, got %v", err)
+	}
+}
+
 func TestETagChangesAfterModification(t *testing.T) {
 	// Verify that ETag changes reflect actual content changes
 	// (this is important for optimistic concurrency control)
@@ -2213,3 +2341,69 @@ func TestETagChangesAfterModification(t *testing.T) {
 		t.Error("JSON etag should differ after modification")
 	}
 }
+
+func TestFormatCanonicalFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "canonical", "*.md"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no fixtures: %v", err)
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id, _ := ParseFilename(filepath.Base(path))
+			got, err := Format(id, want)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Format() of a canonical file changed it:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+
+			// Parse then Render is byte-identical too.
+			b, err := Parse(bytes.NewReader(want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.ID = id
+			if b.Encrypted {
+				return // Render writes Ciphertext, which only Format fills in
+			}
+			if got, _ := b.Render(); !bytes.Equal(got, want) {
+				t.Errorf("Render() after Parse():\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestFormatReordersKeys(t *testing.T) {
+	messy := "---\n" +
+		"sync:\n  github: {synced_at: \"2026-01-01T00:00:00Z\", issue_number: \"12\"}\n" +
+		"tags: [b, a]\n" +
+		"status: ready\n" +
+		"title: Messy\n" +
+		"---\n" +
+		"Body without a blank line.\n\n"
+	want := "---\n" +
+		"# msy-001\n" +
+		"title: Messy\n" +
+		"status: ready\n" +
+		"tags:\n    - b\n    - a\n" +
+		"sync:\n    github:\n        issue_number: \"12\"\n        synced_at: \"2026-01-01T00:00:00Z\"\n" +
+		"---\n" +
+		"\nBody without a blank line.\n"
+
+	got, err := Format("msy-001", []byte(messy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("Format() =\n%s\nwant:\n%s", got, want)
+	}
+	again, _ := Format("msy-001", got)
+	if !bytes.Equal(again, got) {
+		t.Errorf("Format() is not idempotent:\n%s", again)
+	}
+}
@@ -0,0 +1,227 @@
+package issue
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// IDMatcher finds issue IDs in one or more id_formats, so IDs generated
+// before a format change keep matching alongside new ones.
+type IDMatcher struct {
+	// mention matches an ID standing on its own, optionally followed by
+	// the rest of an issue filename (e.g. "../a/abc-123--fix-login.md"),
+	// so that both bare IDs and relative links into the data directory are
+	// found.
+	mention *regexp.Regexp
+	// exact matches a whole string that is an ID.
+	exact *regexp.Regexp
+	// idChars holds every byte an ID can contain.
+	idChars [256]bool
+}
+
+// defaultIDMatcher matches IDs in the default xxx-xxx format.
+var defaultIDMatcher = NewIDMatcher(config.DefaultIDFormat())
+
+// NewIDMatcher returns a matcher of IDs in any of formats, each of which
+// must have every field set (as GetIDFormat returns).
+func NewIDMatcher(formats ...config.IDFormat) *IDMatcher {
+	m := &IDMatcher{}
+	m.idChars['-'] = true
+	var alts []string
+	for _, f := range formats {
+		for i := range len(f.Prefix) {
+			m.idChars[f.Prefix[i]] = true
+		}
+		for i := range len(f.Alphabet) {
+			m.idChars[f.Alphabet[i]] = true
+		}
+		group := fmt.Sprintf("[%s]{%d}", regexp.QuoteMeta(f.Alphabet), f.GroupLength)
+		alt := regexp.QuoteMeta(f.Prefix) + group + strings.Repeat("-"+group, f.Groups-1)
+		if !slices.Contains(alts, alt) {
+			alts = append(alts, alt)
+		}
+	}
+	// Leftmost-first alternation takes the first alternative that matches,
+	// so try longer IDs first: a prefixed ID wins over an unprefixed one
+	// inside it.
+	slices.SortStableFunc(alts, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	ids := strings.Join(alts, "|")
+
+	var boundary strings.Builder
+	for c := range 256 {
+		if m.idChars[c] {
+			boundary.WriteString(regexp.QuoteMeta(string(rune(c))))
+		}
+	}
+	m.mention = regexp.MustCompile(`(^|[^` + strings.ReplaceAll(boundary.String(), "-", `\-`) + `])(` + ids + `)((?:--[\w.-]*|\.[\w-]+)?\.md)?`)
+	m.exact = regexp.MustCompile(`^(?:` + ids + `)$`)
+	return m
+}
+
+// Match reports whether id is in one of the matcher's formats.
+func (m *IDMatcher) Match(id string) bool {
+	return m.exact.MatchString(id)
+}
+
+// mentionEnd reports whether the byte after a bare ID keeps it from being an
+// ID, as in "abc-1234" or "abc-123-def".
+func (m *IDMatcher) mentionEnd(s string, i int) bool {
+	return i >= len(s) || !m.idChars[s[i]]
+}
+
+// ExtractMentions returns the issue IDs referenced in a markdown body, either
+// bare ("see abc-123") or as relative paths to issue files, in order of first
+// appearance. Text inside fenced code blocks and inline code spans is ignored
+// so that code samples never count as links. Hyphenated words like "one-off"
+// match too; callers keep only IDs of issues that exist.
+func (m *IDMatcher) ExtractMentions(body string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, text := range proseSegments(body) {
+		for _, loc := range m.mention.FindAllStringSubmatchIndex(text, -1) {
+			id := text[loc[4]:loc[5]]
+			if loc[6] < 0 && !m.mentionEnd(text, loc[5]) {
+				continue
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// ExtractMentions is IDMatcher.ExtractMentions for IDs in the default
+// format.
+func ExtractMentions(body string) []string {
+	return defaultIDMatcher.ExtractMentions(body)
+}
+
+// ReplaceMention returns body with each bare mention of the ID from
+// rewritten as to. Mentions ExtractMentions would skip (in code, or inside
+// a longer ID) are left alone, as are relative links to from's file, which
+// would break if only the ID changed.
+func (m *IDMatcher) ReplaceMention(body, from, to string) string {
+	return mapProse(body, func(text string) string {
+		var out strings.Builder
+		last := 0
+		for _, loc := range m.mention.FindAllStringSubmatchIndex(text, -1) {
+			if loc[6] >= 0 || text[loc[4]:loc[5]] != from || !m.mentionEnd(text, loc[5]) {
+				continue
+			}
+			out.WriteString(text[last:loc[4]])
+			out.WriteString(to)
+			last = loc[5]
+		}
+		if last == 0 {
+			return text
+		}
+		out.WriteString(text[last:])
+		return out.String()
+	})
+}
+
+// LinkMentions returns body with each bare mention ExtractMentions would find
+// rewritten as a markdown link to href(id). Mentions href returns "" for are
+// left alone, as are relative links to issue files and IDs already inside a
+// link's text or a URL.
+func (m *IDMatcher) LinkMentions(body string, href func(id string) string) string {
+	return mapProse(body, func(text string) string {
+		var out strings.Builder
+		last := 0
+		for _, loc := range m.mention.FindAllStringSubmatchIndex(text, -1) {
+			if loc[6] >= 0 || !m.mentionEnd(text, loc[5]) || inLink(text, loc[4]) {
+				continue
+			}
+			id := text[loc[4]:loc[5]]
+			url := href(id)
+			if url == "" {
+				continue
+			}
+			out.WriteString(text[last:loc[4]])
+			fmt.Fprintf(&out, "[%s](%s)", id, url)
+			last = loc[5]
+		}
+		if last == 0 {
+			return text
+		}
+		out.WriteString(text[last:])
+		return out.String()
+	})
+}
+
+// inLink reports whether offset i of text falls inside the text of a
+// markdown link or inside a path or URL.
+func inLink(text string, i int) bool {
+	before := text[:i]
+	if strings.Count(before, "[") > strings.Count(before, "]") {
+		return true
+	}
+	word := before[strings.LastIndexAny(before, " \t(<")+1:]
+	return strings.Contains(word, "/")
+}
+
+// proseSegments splits body into the runs of text outside fenced code blocks
+// and inline code spans.
+func proseSegments(body string) []string {
+	var segs []string
+	mapProse(body, func(text string) string {
+		segs = append(segs, text)
+		return text
+	})
+	return segs
+}
+
+// mapProse returns body with fn applied to each run of text outside fenced
+// code blocks and inline code spans.
+func mapProse(body string, fn func(string) string) string {
+	lines := strings.Split(body, "\n")
+	var fence string
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if f := fenceMarker(trimmed); f != "" {
+			fence = f
+			continue
+		}
+		lines[i] = mapCodeSpans(line, fn)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mapCodeSpans returns line with fn applied to the parts outside `code`
+// spans. An unmatched backtick run is kept as text.
+func mapCodeSpans(line string, fn func(string) string) string {
+	var out strings.Builder
+	for {
+		start := strings.IndexByte(line, '`')
+		if start < 0 {
+			break
+		}
+		n := start
+		for n < len(line) && line[n] == '`' {
+			n++
+		}
+		ticks := line[start:n]
+		end := strings.Index(line[n:], ticks)
+		if end < 0 {
+			break
+		}
+		out.WriteString(fn(line[:start]))
+		out.WriteString(line[start : n+end+len(ticks)])
+		line = line[n+end+len(ticks):]
+	}
+	out.WriteString(fn(line))
+	return out.String()
+}
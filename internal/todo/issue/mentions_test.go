@@ -0,0 +1,111 @@
+package issue
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+func TestIDMatcherMixedFormats(t *testing.T) {
+	m := NewIDMatcher(config.DefaultIDFormat(), config.IDFormat{Prefix: "PLAT-", Groups: 1, GroupLength: 4, Alphabet: "abcdefghjkmnpqrstuvwxyz23456789"})
+	for id, want := range map[string]bool{"abc-123": true, "PLAT-k7mq": true, "PLAT-k7m": false, "PLAT-k1mq": false, "abc-1234": false} {
+		if got := m.Match(id); got != want {
+			t.Errorf("Match(%q) = %v, want %v", id, got, want)
+		}
+	}
+
+	body := "Follows abc-123 and PLAT-k7mq; see [spec](../P/PLAT-x2yz--spec.md), not PLAT-k7mqq."
+	want := []string{"abc-123", "PLAT-k7mq", "PLAT-x2yz"}
+	if got := m.ExtractMentions(body); !slices.Equal(got, want) {
+		t.Errorf("ExtractMentions(%q) = %v, want %v", body, got, want)
+	}
+	if got := ExtractMentions(body); !slices.Equal(got, []string{"abc-123"}) {
+		t.Errorf("default ExtractMentions(%q) = %v, want only the default-format ID", body, got)
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"bare ID", "Depends on abc-123 landing first.", []string{"abc-123"}},
+		{"order and dedupe", "def-456, then abc-123, then def-456 again", []string{"def-456", "abc-123"}},
+		{"markdown link to file", "See [login](../a/abc-123--fix-login.md).", []string{"abc-123"}},
+		{"dot filename", "See a/abc-123.fix-login.md", []string{"abc-123"}},
+		{"id-only filename", "See a/abc-123.md", []string{"abc-123"}},
+		{"longer token", "abc-1234 and xabc-123 and abc-123-def", nil},
+		{"line start and punctuation", "abc-123: done (def-456)", []string{"abc-123", "def-456"}},
+		{"fenced code", "before\n```\nabc-123\n```\nafter def-456", []string{"def-456"}},
+		{"tilde fence", "~~~go\nabc-123\n~~~\n", nil},
+		{"longer fence", "````\n```\nabc-123\n````\nghi-789", []string{"ghi-789"}},
+		{"inline code", "run `jig todo show abc-123` or see def-456", []string{"def-456"}},
+		{"double backtick span", "``abc-123`` def-456", []string{"def-456"}},
+		{"unmatched backtick", "a ` abc-123", []string{"abc-123"}},
+		{"unterminated fence", "```\nabc-123", nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractMentions(tt.body); !slices.Equal(got, tt.want) {
+				t.Errorf("ExtractMentions(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceMention(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"bare ID", "Depends on abc-123 landing first.", "Depends on xyz-789 landing first."},
+		{"every mention", "abc-123: see abc-123 (abc-123)", "xyz-789: see xyz-789 (xyz-789)"},
+		{"other IDs", "abc-123 and def-456", "xyz-789 and def-456"},
+		{"longer token", "abc-1234 and xabc-123 and abc-123-def", "abc-1234 and xabc-123 and abc-123-def"},
+		{"file link", "See [login](../a/abc-123--fix-login.md).", "See [login](../a/abc-123--fix-login.md)."},
+		{"fenced code", "```\nabc-123\n```\nafter abc-123", "```\nabc-123\n```\nafter xyz-789"},
+		{"inline code", "run `jig todo show abc-123` after abc-123", "run `jig todo show abc-123` after xyz-789"},
+		{"unmatched backtick", "a ` abc-123", "a ` xyz-789"},
+		{"no mention", "nothing here\n", "nothing here\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultIDMatcher.ReplaceMention(tt.body, "abc-123", "xyz-789"); got != tt.want {
+				t.Errorf("ReplaceMention(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkMentions(t *testing.T) {
+	href := func(id string) string {
+		if id == "def-456" {
+			return "" // an issue that does not exist
+		}
+		return "file:///issues/" + id + ".md"
+	}
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"bare ID", "Depends on abc-123.", "Depends on [abc-123](file:///issues/abc-123.md)."},
+		{"unknown ID", "abc-123 and def-456", "[abc-123](file:///issues/abc-123.md) and def-456"},
+		{"file link", "See [login](../a/abc-123--fix-login.md).", "See [login](../a/abc-123--fix-login.md)."},
+		{"link text", "See [abc-123](https://example.com).", "See [abc-123](https://example.com)."},
+		{"URL", "https://example.com/abc-123 here", "https://example.com/abc-123 here"},
+		{"fenced code", "```\nabc-123\n```", "```\nabc-123\n```"},
+		{"inline code", "run `show abc-123`", "run `show abc-123`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultIDMatcher.LinkMentions(tt.body, href); got != tt.want {
+				t.Errorf("LinkMentions(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
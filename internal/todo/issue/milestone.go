@@ -152,3 +152,14 @@ func ValidateShort(short string) error {
 	}
 	return nil
 }
+
+// FormatMilestone rewrites the content of the milestone file for id in
+// canonical form, as Format does for issues.
+func FormatMilestone(id string, content []byte) ([]byte, error) {
+	m, err := ParseMilestone(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	m.ID = id
+	return m.Render()
+}
@@ -0,0 +1,148 @@
+package issue
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Pull request states, as recorded by sync.
+const (
+	PRStateOpen   = "open"
+	PRStateMerged = "merged"
+	PRStateClosed = "closed"
+)
+
+// PullRequest is a GitHub pull request linked to an issue. The numbers are
+// kept in the github sync data under "prs"; the state last fetched for each
+// is kept under "pr_states", keyed by number.
+type PullRequest struct {
+	Number    int        `json:"number"`
+	State     string     `json:"state,omitempty"` // "" until sync fetches it
+	MergedAt  *time.Time `json:"merged_at,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+}
+
+// IsStale reports whether the recorded state is missing or was fetched
+// longer than ttl before now.
+func (p PullRequest) IsStale(now time.Time, ttl time.Duration) bool {
+	return p.CheckedAt == nil || now.Sub(*p.CheckedAt) > ttl
+}
+
+// GithubPullRequests returns the pull requests linked in the github sync
+// data, sorted by number.
+func (b *Issue) GithubPullRequests() []PullRequest {
+	gh := b.Sync["github"]
+	if gh == nil {
+		return nil
+	}
+	numbers, _ := gh["prs"].([]any)
+	states := syncMap(gh["pr_states"])
+
+	var prs []PullRequest
+	for _, v := range numbers {
+		n, ok := syncNumber(v)
+		if !ok || slices.ContainsFunc(prs, func(p PullRequest) bool { return p.Number == n }) {
+			continue
+		}
+		pr := PullRequest{Number: n}
+		if state := syncMap(states[strconv.Itoa(n)]); state != nil {
+			pr.State, _ = state["state"].(string)
+			pr.MergedAt = syncTime(state["merged_at"])
+			pr.CheckedAt = syncTime(state["checked_at"])
+		}
+		prs = append(prs, pr)
+	}
+	slices.SortFunc(prs, func(a, b PullRequest) int { return cmp.Compare(a.Number, b.Number) })
+	return prs
+}
+
+// SetGithubPullRequests replaces the pull requests linked in the github sync
+// data, keeping the other github sync keys. An empty prs removes them.
+func (b *Issue) SetGithubPullRequests(prs []PullRequest) {
+	data := maps.Clone(b.Sync["github"])
+	if data == nil {
+		data = map[string]any{}
+	}
+	delete(data, "prs")
+	delete(data, "pr_states")
+	if len(prs) > 0 {
+		numbers := make([]any, len(prs))
+		states := map[string]any{}
+		for i, pr := range prs {
+			numbers[i] = pr.Number
+			if pr.State == "" {
+				continue
+			}
+			state := map[string]any{"state": pr.State}
+			if pr.MergedAt != nil {
+				state["merged_at"] = pr.MergedAt.UTC().Format(time.RFC3339)
+			}
+			if pr.CheckedAt != nil {
+				state["checked_at"] = pr.CheckedAt.UTC().Format(time.RFC3339)
+			}
+			states[strconv.Itoa(pr.Number)] = state
+		}
+		data["prs"] = numbers
+		if len(states) > 0 {
+			data["pr_states"] = states
+		}
+	}
+	if len(data) == 0 {
+		b.RemoveSync("github")
+		return
+	}
+	b.SetSync("github", data)
+}
+
+// syncNumber reads a positive number stored in sync data as an int, a
+// float (from JSON), or a string such as "123" or "#123".
+func syncNumber(v any) (int, bool) {
+	var n int
+	switch v := v.(type) {
+	case int:
+		n = v
+	case float64:
+		n = int(v)
+	case string:
+		if _, err := fmt.Sscanf(v, "#%d", &n); err != nil {
+			if n, err = strconv.Atoi(v); err != nil {
+				return 0, false
+			}
+		}
+	}
+	return n, n > 0
+}
+
+// syncMap reads a nested map from sync data, which YAML decodes with
+// untyped keys.
+func syncMap(v any) map[string]any {
+	switch v := v.(type) {
+	case map[string]any:
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = val
+		}
+		return m
+	}
+	return nil
+}
+
+// syncTime reads a time stored in sync data as RFC 3339 text or as a
+// timestamp YAML already decoded.
+func syncTime(v any) *time.Time {
+	switch v := v.(type) {
+	case time.Time:
+		return &v
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package issue
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGithubPullRequestsRoundTrip(t *testing.T) {
+	merged := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	checked := time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC)
+	b := &Issue{ID: "abc-123", Title: "Login", Status: "todo"}
+	b.SetSync("github", map[string]any{"issue_number": "42"})
+	b.SetGithubPullRequests([]PullRequest{
+		{Number: 456},
+		{Number: 123, State: PRStateMerged, MergedAt: &merged, CheckedAt: &checked},
+	})
+
+	content, err := b.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prs := parsed.GithubPullRequests()
+	if len(prs) != 2 || prs[0].Number != 123 || prs[1].Number != 456 {
+		t.Fatalf("GithubPullRequests() = %+v, want #123 and #456", prs)
+	}
+	if prs[0].State != PRStateMerged || prs[0].MergedAt == nil || !prs[0].MergedAt.Equal(merged) {
+		t.Errorf("#123 = %+v, want merged at %v", prs[0], merged)
+	}
+	if prs[1].State != "" || prs[1].CheckedAt != nil {
+		t.Errorf("#456 = %+v, want no state", prs[1])
+	}
+	if got := parsed.GithubIssueNumber(); got != 42 {
+		t.Errorf("GithubIssueNumber() = %d, want 42 kept", got)
+	}
+
+	if !prs[0].IsStale(checked.Add(25*time.Hour), 24*time.Hour) || prs[0].IsStale(checked.Add(time.Hour), 24*time.Hour) {
+		t.Error("IsStale() does not honor the TTL")
+	}
+}
+
+func TestGithubPullRequestsLenientNumbers(t *testing.T) {
+	b := &Issue{Sync: map[string]map[string]any{
+		"github": {"prs": []any{float64(7), "#9", "9", "x", 0}},
+	}}
+	prs := b.GithubPullRequests()
+	if len(prs) != 2 || prs[0].Number != 7 || prs[1].Number != 9 {
+		t.Errorf("GithubPullRequests() = %+v, want #7 and #9", prs)
+	}
+
+	b.SetGithubPullRequests(nil)
+	if b.HasSync("github") {
+		t.Error("expected empty github sync data to be removed")
+	}
+}
+
+func TestMarshalJSONPullRequests(t *testing.T) {
+	b := &Issue{ID: "abc-123", Title: "Login", Status: "todo"}
+	b.SetGithubPullRequests([]PullRequest{{Number: 5, State: PRStateOpen}})
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"prs":[{"number":5,"state":"open"}]`) {
+		t.Errorf("JSON missing prs: %s", data)
+	}
+}
@@ -0,0 +1,287 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Section is a heading-delimited part of a markdown body. Content is the text
+// between the heading line and the next heading of the same or a higher
+// level, so it includes any nested subsections, which also appear in
+// Children.
+type Section struct {
+	Level    int       `json:"level"`
+	Title    string    `json:"title"`
+	Content  string    `json:"content"`
+	Children []Section `json:"children,omitempty"`
+}
+
+// NewSectionLevel is the heading level used when a missing section is created.
+const NewSectionLevel = 2
+
+// heading is an ATX heading located in a body, with byte offsets.
+type heading struct {
+	level int
+	title string
+	line  int // 1-based line number
+	start int // start of the heading line
+	body  int // start of the section content (after the heading line)
+	end   int // end of the section (next heading at the same or higher level)
+}
+
+// Sections parses the body into its heading tree.
+func (b *Issue) Sections() []Section {
+	return ParseSections(b.Body)
+}
+
+// ParseSections parses a markdown body into a tree of sections. Text before
+// the first heading is not part of any section. Headings inside fenced code
+// blocks are ignored.
+func ParseSections(body string) []Section {
+	body = normalizeEOL(body)
+	hs := findHeadings(body)
+	sections, _ := buildSections(body, hs, 0, 0)
+	return sections
+}
+
+// buildSections turns the headings from index i onward that sit deeper than
+// parentLevel into sibling sections, returning them and the index of the
+// first heading it did not consume.
+func buildSections(body string, hs []heading, i, parentLevel int) ([]Section, int) {
+	var out []Section
+	for i < len(hs) && hs[i].level > parentLevel {
+		h := hs[i]
+		children, next := buildSections(body, hs, i+1, h.level)
+		out = append(out, Section{
+			Level:    h.level,
+			Title:    h.title,
+			Content:  body[h.body:h.end],
+			Children: children,
+		})
+		i = next
+	}
+	return out, i
+}
+
+// findHeadings locates every ATX heading outside fenced code blocks and
+// computes where each section ends.
+func findHeadings(body string) []heading {
+	var hs []heading
+	var fence string
+	offset := 0
+	for n, line := range strings.SplitAfter(body, "\n") {
+		start := offset
+		offset += len(line)
+		text := strings.TrimRight(line, "\n")
+		trimmed := strings.TrimLeft(text, " ")
+		if len(text)-len(trimmed) > 3 {
+			continue
+		}
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if f := fenceMarker(trimmed); f != "" {
+			fence = f
+			continue
+		}
+		if level, title, ok := parseHeading(trimmed); ok {
+			hs = append(hs, heading{level: level, title: title, line: n + 1, start: start, body: offset})
+		}
+	}
+	for i := range hs {
+		hs[i].end = len(body)
+		for _, next := range hs[i+1:] {
+			if next.level <= hs[i].level {
+				hs[i].end = next.start
+				break
+			}
+		}
+	}
+	return hs
+}
+
+// fenceMarker returns the opening fence (``` or ~~~, possibly longer) of a
+// code block line, or "".
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, c))
+		if n >= 3 {
+			return strings.Repeat(c, n)
+		}
+	}
+	return ""
+}
+
+// parseHeading parses an ATX heading line ("## Title ##").
+func parseHeading(line string) (int, string, bool) {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level < 1 || level > 6 {
+		return 0, "", false
+	}
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+	title := strings.TrimSpace(rest)
+	if trimmed := strings.TrimRight(title, "#"); trimmed == "" || strings.HasSuffix(trimmed, " ") {
+		title = strings.TrimSpace(trimmed)
+	}
+	return level, title, true
+}
+
+// findSection returns the one heading titled title (case-insensitive), or
+// ok=false when there is none. Duplicate titles are an error.
+func findSection(body, title string) (heading, bool, error) {
+	var matches []heading
+	for _, h := range findHeadings(body) {
+		if strings.EqualFold(h.title, strings.TrimSpace(title)) {
+			matches = append(matches, h)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return heading{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+	lines := make([]string, len(matches))
+	for i, h := range matches {
+		lines[i] = fmt.Sprint(h.line)
+	}
+	return heading{}, false, fmt.Errorf("section %q appears %d times (lines %s); rename one to make it unique",
+		title, len(matches), strings.Join(lines, ", "))
+}
+
+// GetSection returns the section titled title, or nil if the body has none.
+func GetSection(body, title string) (*Section, error) {
+	body = normalizeEOL(body)
+	h, ok, err := findSection(body, title)
+	if err != nil || !ok {
+		return nil, err
+	}
+	children, _ := buildSections(body, findHeadingsWithin(body, h), 0, h.level)
+	return &Section{Level: h.level, Title: h.title, Content: body[h.body:h.end], Children: children}, nil
+}
+
+// SectionLines returns the 1-based lines the section titled title spans in
+// body, from its heading to its last line, or ok=false when the body has no
+// such section (or more than one).
+func SectionLines(body, title string) (first, last int, ok bool) {
+	body = normalizeEOL(body)
+	h, ok, err := findSection(body, title)
+	if err != nil || !ok {
+		return 0, 0, false
+	}
+	return h.line, h.line + strings.Count(strings.TrimSuffix(body[h.start:h.end], "\n"), "\n"), true
+}
+
+// findHeadingsWithin returns the headings nested inside h.
+func findHeadingsWithin(body string, h heading) []heading {
+	var inner []heading
+	for _, c := range findHeadings(body) {
+		if c.start > h.start && c.start < h.end {
+			inner = append(inner, c)
+		}
+	}
+	return inner
+}
+
+// SetSection replaces the content of the section titled title, leaving the
+// heading and the rest of the body untouched. A missing section is an error
+// unless createIfMissing is set, in which case it is appended as a level-2
+// section.
+func SetSection(body, title, content string, createIfMissing bool) (string, error) {
+	return editSection(body, title, createIfMissing, func(string) string { return content })
+}
+
+// AppendToSection appends text to the end of the section titled title, with
+// a blank line separator. Missing sections are handled as in SetSection.
+func AppendToSection(body, title, addition string, createIfMissing bool) (string, error) {
+	return editSection(body, title, createIfMissing, func(current string) string {
+		return AppendWithSeparator(strings.TrimRight(current, "\n"), addition)
+	})
+}
+
+func editSection(body, title string, createIfMissing bool, edit func(string) string) (string, error) {
+	if strings.TrimSpace(title) == "" {
+		return "", errors.New("section title cannot be empty")
+	}
+	crlf := strings.Contains(body, "\r\n")
+	body = normalizeEOL(body)
+	// Bodies are usually stored without a trailing newline; keep whichever
+	// form the body had.
+	finish := func(s string) string {
+		if !strings.HasSuffix(body, "\n") {
+			s = strings.TrimRight(s, "\n")
+		}
+		return restoreEOL(s, crlf)
+	}
+
+	h, ok, err := findSection(body, title)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		if !createIfMissing {
+			return "", fmt.Errorf("section %q not found", title)
+		}
+		section := strings.Repeat("#", NewSectionLevel) + " " + strings.TrimSpace(title)
+		if content := strings.Trim(normalizeEOL(edit("")), "\n"); content != "" {
+			section += "\n\n" + content
+		}
+		return finish(AppendWithSeparator(body, section) + "\n"), nil
+	}
+
+	current := body[h.body:h.end]
+	content := strings.Trim(normalizeEOL(edit(current)), "\n")
+
+	var b strings.Builder
+	b.WriteString(body[:h.body])
+	if !strings.HasSuffix(body[:h.body], "\n") {
+		b.WriteString("\n")
+	}
+	if content != "" {
+		// Keep the original spacing style under the heading.
+		if strings.HasPrefix(current, "\n") || current == "" {
+			b.WriteString("\n")
+		}
+		b.WriteString(content)
+		b.WriteString("\n")
+	}
+	if h.end < len(body) {
+		if content != "" {
+			b.WriteString("\n")
+		}
+		b.WriteString(body[h.end:])
+	}
+	return finish(b.String()), nil
+}
+
+// RemoveSection removes the section titled title, heading and content, from
+// body. A body without the section is returned as it is.
+func RemoveSection(body, title string) (string, error) {
+	crlf := strings.Contains(body, "\r\n")
+	normalized := normalizeEOL(body)
+	h, ok, err := findSection(normalized, title)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return body, nil
+	}
+	before := strings.TrimRight(normalized[:h.start], "\n")
+	after := normalized[h.end:]
+	switch {
+	case before == "":
+		return restoreEOL(after, crlf), nil
+	case after == "" && strings.HasSuffix(normalized, "\n"):
+		return restoreEOL(before+"\n", crlf), nil
+	case after == "":
+		return restoreEOL(before, crlf), nil
+	}
+	return restoreEOL(before+"\n\n"+after, crlf), nil
+}
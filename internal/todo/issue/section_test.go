@@ -0,0 +1,265 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+)
+
+const sectionBody = `Intro text.
+
+## Plan
+
+Step one.
+
+### Details
+
+Fine print.
+
+` + "```" + `
+## Not a heading
+` + "```" + `
+
+## Notes ##
+
+Some notes.`
+
+func TestParseSections(t *testing.T) {
+	sections := ParseSections(sectionBody)
+	if len(sections) != 2 {
+		t.Fatalf("got %d top-level sections, want 2: %+v", len(sections), sections)
+	}
+
+	plan := sections[0]
+	if plan.Level != 2 || plan.Title != "Plan" {
+		t.Errorf("sections[0] = %d %q, want 2 \"Plan\"", plan.Level, plan.Title)
+	}
+	if len(plan.Children) != 1 || plan.Children[0].Title != "Details" || plan.Children[0].Level != 3 {
+		t.Fatalf("Plan children = %+v, want one level-3 \"Details\"", plan.Children)
+	}
+	if !strings.Contains(plan.Content, "### Details") {
+		t.Errorf("Plan content should include nested subsection, got %q", plan.Content)
+	}
+	if got := plan.Children[0].Content; !strings.Contains(got, "## Not a heading") {
+		t.Errorf("fenced heading should stay in Details content, got %q", got)
+	}
+
+	if sections[1].Title != "Notes" {
+		t.Errorf("closing #s should be stripped, got title %q", sections[1].Title)
+	}
+	if sections[1].Content != "\nSome notes." {
+		t.Errorf("Notes content = %q", sections[1].Content)
+	}
+}
+
+func TestParseSectionsIgnoresNonHeadings(t *testing.T) {
+	for _, body := range []string{
+		"#hashtag at start",
+		"    ## indented code",
+		"####### seven hashes",
+		"",
+	} {
+		if got := ParseSections(body); len(got) != 0 {
+			t.Errorf("ParseSections(%q) = %+v, want none", body, got)
+		}
+	}
+}
+
+func TestGetSection(t *testing.T) {
+	s, err := GetSection(sectionBody, "details")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || s.Title != "Details" || s.Level != 3 {
+		t.Fatalf("GetSection(details) = %+v", s)
+	}
+
+	s, err = GetSection(sectionBody, "plan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Children) != 1 || s.Children[0].Title != "Details" {
+		t.Errorf("Plan children = %+v", s.Children)
+	}
+
+	s, err = GetSection(sectionBody, "Missing")
+	if err != nil || s != nil {
+		t.Errorf("GetSection(Missing) = %+v, %v; want nil, nil", s, err)
+	}
+}
+
+func TestSectionDuplicateTitles(t *testing.T) {
+	body := "## Plan\n\na\n\n## Other\n\n## Plan\n\nb"
+	_, err := GetSection(body, "Plan")
+	if err == nil {
+		t.Fatal("expected duplicate title error")
+	}
+	if !strings.Contains(err.Error(), "appears 2 times (lines 1, 7)") {
+		t.Errorf("error should list positions, got %q", err)
+	}
+	if _, err := SetSection(body, "Plan", "x", true); err == nil {
+		t.Error("SetSection should refuse an ambiguous title")
+	}
+}
+
+func TestSetSection(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		title   string
+		content string
+		create  bool
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "replaces middle section and keeps the rest byte-identical",
+			body:    sectionBody,
+			title:   "Plan",
+			content: "New plan.",
+			want:    "Intro text.\n\n## Plan\n\nNew plan.\n\n## Notes ##\n\nSome notes.",
+		},
+		{
+			name:    "replaces nested section only",
+			body:    "## A\n\n### B\n\nold\n\n### C\n\nkeep",
+			title:   "B",
+			content: "new",
+			want:    "## A\n\n### B\n\nnew\n\n### C\n\nkeep",
+		},
+		{
+			name:    "replaces last section",
+			body:    "## A\n\nfirst\n\n## B\n\nold",
+			title:   "b",
+			content: "new",
+			want:    "## A\n\nfirst\n\n## B\n\nnew",
+		},
+		{
+			name:    "keeps tight heading style",
+			body:    "## Tasks\n- [ ] one\n## Next\nx",
+			title:   "Tasks",
+			content: "- [x] one",
+			want:    "## Tasks\n- [x] one\n\n## Next\nx",
+		},
+		{
+			name:    "empty content clears the section",
+			body:    "## A\n\nold\n\n## B\n\nkeep",
+			title:   "A",
+			content: "",
+			want:    "## A\n## B\n\nkeep",
+		},
+		{
+			name:    "missing section errors",
+			body:    "## A\n\nx",
+			title:   "Plan",
+			content: "y",
+			wantErr: `section "Plan" not found`,
+		},
+		{
+			name:    "missing section created at end",
+			body:    "Intro\n\n## A\n\nx",
+			title:   "Plan",
+			content: "y",
+			create:  true,
+			want:    "Intro\n\n## A\n\nx\n\n## Plan\n\ny",
+		},
+		{
+			name:    "created in empty body",
+			body:    "",
+			title:   "Plan",
+			content: "y",
+			create:  true,
+			want:    "## Plan\n\ny",
+		},
+		{
+			name:    "keeps trailing newline",
+			body:    "## A\n\nold\n",
+			title:   "A",
+			content: "new",
+			want:    "## A\n\nnew\n",
+		},
+		{
+			name:    "keeps CRLF line endings",
+			body:    "## A\r\n\r\nold\r\n\r\n## B\r\n\r\nkeep",
+			title:   "A",
+			content: "new",
+			want:    "## A\r\n\r\nnew\r\n\r\n## B\r\n\r\nkeep",
+		},
+		{
+			name:    "empty title errors",
+			body:    "## A",
+			title:   " ",
+			wantErr: "cannot be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetSection(tt.body, tt.title, tt.content, tt.create)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetSection() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetSection() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SetSection() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetSectionRoundtrip(t *testing.T) {
+	// Writing a section's own content back must leave the body unchanged.
+	for _, s := range ParseSections(sectionBody) {
+		got, err := SetSection(sectionBody, s.Title, s.Content, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != sectionBody {
+			t.Errorf("roundtrip of %q changed body:\n%q", s.Title, got)
+		}
+	}
+}
+
+func TestAppendToSection(t *testing.T) {
+	body := "## Log\n\n- first\n\n## Other\n\nkeep"
+	got, err := AppendToSection(body, "Log", "- second", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "## Log\n\n- first\n\n- second\n\n## Other\n\nkeep"
+	if got != want {
+		t.Errorf("AppendToSection() =\n%q\nwant\n%q", got, want)
+	}
+
+	got, err = AppendToSection("Intro", "Log", "- first", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Intro\n\n## Log\n\n- first"; got != want {
+		t.Errorf("AppendToSection(create) = %q, want %q", got, want)
+	}
+}
+
+func TestRemoveSection(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"Intro\n\n## Comments\n\n- one\n\n## Other\n\nkeep", "Intro\n\n## Other\n\nkeep"},
+		{"Intro\n\n## Comments\n\n### Nested\n\n- one\n", "Intro\n"},
+		{"## Comments\n\n- one\n\n## Other\n\nkeep", "## Other\n\nkeep"},
+		{"Intro\r\n\r\n## Comments\r\n\r\n- one\r\n\r\n## Other", "Intro\r\n\r\n## Other"},
+		{"No comments here", "No comments here"},
+	}
+	for _, tt := range tests {
+		got, err := RemoveSection(tt.body, "Comments")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("RemoveSection(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
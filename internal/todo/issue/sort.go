@@ -9,6 +9,27 @@ import (
 	"github.com/toba/jig/internal/todo/config"
 )
 
+// PinnedFirst moves pinned issues ahead of the rest, keeping the existing
+// order within each group. Call it after sorting.
+func PinnedFirst(issues []*Issue) {
+	slices.SortStableFunc(issues, func(a, b *Issue) int {
+		return ComparePinned(a, b)
+	})
+}
+
+// ComparePinned orders pinned issues before unpinned ones and treats issues
+// in the same group as equal.
+func ComparePinned(a, b *Issue) int {
+	switch {
+	case a.Pinned == b.Pinned:
+		return 0
+	case a.Pinned:
+		return -1
+	default:
+		return 1
+	}
+}
+
 // CompareByCreatedDesc compares two issues by creation date, newest first.
 // Issues without dates sort last. Ties are broken by ID for stability.
 func CompareByCreatedDesc(a, b *Issue) int {
@@ -262,8 +283,8 @@ func SortByDueDate(issues []*Issue) {
 		if db == nil {
 			return -1
 		}
-		if !da.Equal(db.Time) {
-			return da.Compare(db.Time) // soonest first
+		if c := da.Deadline().Compare(db.Deadline()); c != 0 {
+			return c // soonest first; a date-only due sorts after times that day
 		}
 		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
 	})
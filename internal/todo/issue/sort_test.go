@@ -142,6 +142,23 @@ func TestSortByStatusPriorityAndType(t *testing.T) {
 	})
 }
 
+func TestPinnedFirst(t *testing.T) {
+	issues := []*Issue{
+		{ID: "a"},
+		{ID: "b", Pinned: true},
+		{ID: "c"},
+		{ID: "d", Pinned: true},
+	}
+	PinnedFirst(issues)
+
+	want := []string{"b", "d", "a", "c"}
+	for i, id := range want {
+		if issues[i].ID != id {
+			t.Errorf("issues[%d].ID = %q, want %q", i, issues[i].ID, id)
+		}
+	}
+}
+
 func TestSortByStatus(t *testing.T) {
 	now := time.Now()
 	earlier := now.Add(-1 * time.Hour)
@@ -474,6 +491,24 @@ func TestSortByDueDate(t *testing.T) {
 		}
 	})
 
+	t.Run("mixed precision", func(t *testing.T) {
+		issues := []*Issue{
+			{ID: "1", Title: "Day", Due: NewDueDate(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))},
+			{ID: "2", Title: "Evening", Due: NewDueDateTime(time.Date(2025, 6, 1, 17, 0, 0, 0, time.UTC))},
+			{ID: "3", Title: "Day before", Due: NewDueDate(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC))},
+			{ID: "4", Title: "Morning", Due: NewDueDateTime(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))},
+		}
+		SortByDueDate(issues)
+
+		// A date-only due runs to the end of its day.
+		expected := []string{"Day before", "Morning", "Evening", "Day"}
+		for i, title := range expected {
+			if issues[i].Title != title {
+				t.Errorf("issues[%d].Title = %q, want %q", i, issues[i].Title, title)
+			}
+		}
+	})
+
 	t.Run("all nil due dates", func(t *testing.T) {
 		issues := []*Issue{
 			{ID: "1", Title: "Zebra"},
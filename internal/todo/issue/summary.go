@@ -0,0 +1,67 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSummaryLength is the longest summary accepted, in characters.
+const MaxSummaryLength = 160
+
+// ValidateSummary checks that a summary is a single line of at most
+// MaxSummaryLength characters. An empty summary is valid (it clears the field).
+func ValidateSummary(s string) error {
+	if strings.ContainsAny(s, "\r\n") {
+		return errors.New("summary must be a single line")
+	}
+	if n := utf8.RuneCountInString(s); n > MaxSummaryLength {
+		return fmt.Errorf("summary is %d characters, the limit is %d", n, MaxSummaryLength)
+	}
+	return nil
+}
+
+// Synopsis returns a one-line description of the issue, at most maxLen
+// characters (0 means no limit): the summary when set, otherwise the first
+// paragraph of the body, skipping headings. Bodies that could not be
+// decrypted have no synopsis.
+func (b *Issue) Synopsis(maxLen int) string {
+	s := b.Summary
+	if s == "" && b.Body != EncryptedPlaceholder {
+		s = FirstParagraph(b.Body)
+	}
+	return truncate(s, maxLen)
+}
+
+// FirstParagraph joins the lines of the body's first paragraph into one,
+// ignoring heading lines and any blank lines before the paragraph starts.
+func FirstParagraph(body string) string {
+	var para []string
+	for line := range strings.SplitSeq(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		para = append(para, line)
+	}
+	return strings.Join(para, " ")
+}
+
+// truncate shortens s to at most maxLen characters, ending in "..." when
+// cut. A maxLen of 0 or less leaves s unchanged.
+func truncate(s string, maxLen int) string {
+	if maxLen <= 0 || utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	if maxLen <= 3 {
+		return string([]rune(s)[:maxLen])
+	}
+	return string([]rune(s)[:maxLen-3]) + "..."
+}
@@ -0,0 +1,77 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummaryRoundTrip(t *testing.T) {
+	b := &Issue{ID: "abc-123", Title: "Login", Summary: "Users can sign in with SSO: Okta, Google", Status: "todo", Body: "# Plan\n\nDetails."}
+	content, err := b.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "\nsummary: 'Users can sign in with SSO: Okta, Google'\n") {
+		t.Errorf("rendered front matter missing summary:\n%s", content)
+	}
+	parsed, err := Parse(strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Summary != b.Summary {
+		t.Errorf("Summary = %q, want %q", parsed.Summary, b.Summary)
+	}
+
+	b.Summary = ""
+	content, _ = b.Render()
+	if strings.Contains(string(content), "summary:") {
+		t.Errorf("empty summary should be omitted:\n%s", content)
+	}
+}
+
+func TestValidateSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"one line", "Short and sweet", false},
+		{"at limit", strings.Repeat("é", MaxSummaryLength), false},
+		{"over limit", strings.Repeat("a", MaxSummaryLength+1), true},
+		{"newline", "two\nlines", true},
+		{"carriage return", "two\rlines", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSummary(tt.summary); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSummary(%q) error = %v, wantErr %v", tt.summary, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSynopsis(t *testing.T) {
+	tests := []struct {
+		name   string
+		issue  Issue
+		maxLen int
+		want   string
+	}{
+		{"summary wins", Issue{Summary: "The summary", Body: "Body text"}, 0, "The summary"},
+		{"first paragraph fallback", Issue{Body: "Line one\nline two\n\nSecond."}, 0, "Line one line two"},
+		{"skips heading and blank", Issue{Body: "## Context\n\nWhy this matters.\n\nMore."}, 0, "Why this matters."},
+		{"heading only", Issue{Body: "# Title"}, 0, ""},
+		{"encrypted placeholder", Issue{Body: EncryptedPlaceholder}, 0, ""},
+		{"truncates", Issue{Summary: "abcdefghij"}, 8, "abcde..."},
+		{"truncates runes", Issue{Body: "ééééé"}, 4, "é..."},
+		{"fits exactly", Issue{Summary: "abcd"}, 4, "abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.Synopsis(tt.maxLen); got != tt.want {
+				t.Errorf("Synopsis(%d) = %q, want %q", tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
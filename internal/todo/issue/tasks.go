@@ -0,0 +1,176 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskSection is the section whose checklist jig todo expand turns into
+// child issues when no other is named.
+const TaskSection = "Tasks"
+
+// TaskItem is a checkbox list item ("- [ ] Write the parser") in a body
+// section.
+type TaskItem struct {
+	// Line is the item's 0-based line number in the body.
+	Line    int
+	Title   string
+	Checked bool
+}
+
+// TaskItems returns the checkbox items in the section titled section, in
+// order. Items inside fenced code blocks are ignored. A missing section is
+// an error.
+func TaskItems(body, section string) ([]TaskItem, error) {
+	body = normalizeEOL(body)
+	h, ok, err := findSection(body, section)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("section %q not found", section)
+	}
+
+	return scanTaskItems(body[h.body:h.end], strings.Count(body[:h.body], "\n")), nil
+}
+
+// scanTaskItems returns the checkbox items in text, skipping fenced code
+// blocks. first is the body line text starts on.
+func scanTaskItems(text string, first int) []TaskItem {
+	var items []TaskItem
+	var fence string
+	for n, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if f := fenceMarker(trimmed); f != "" {
+			fence = f
+			continue
+		}
+		checked, title, ok := parseTaskItem(trimmed)
+		if !ok || title == "" {
+			continue
+		}
+		items = append(items, TaskItem{Line: first + n, Title: title, Checked: checked})
+	}
+	return items
+}
+
+// taskSimilarity is how alike (see similarity) an unchecked item's title
+// and a new item's must be for RemovedTasks to count the item as reworded
+// rather than removed.
+const taskSimilarity = 0.75
+
+// RemovedTasks returns the titles of the unchecked checkbox items in
+// oldBody that newBody no longer has, in order, so a wholesale body rewrite
+// cannot silently drop open work. An item survives when newBody has an item
+// (checked or not, in any section or order) with the same normalized title,
+// or failing that one whose title is similar enough to be a rewording. Each
+// new item accounts for one old item at most.
+func RemovedTasks(oldBody, newBody string) []string {
+	var open []TaskItem
+	for _, item := range scanTaskItems(normalizeEOL(oldBody), 0) {
+		if !item.Checked {
+			open = append(open, item)
+		}
+	}
+	if len(open) == 0 {
+		return nil
+	}
+	var titles []string
+	for _, item := range scanTaskItems(normalizeEOL(newBody), 0) {
+		titles = append(titles, NormalizeTitle(item.Title))
+	}
+	used := make([]bool, len(titles))
+	kept := make([]bool, len(open))
+	for i, item := range open {
+		title := NormalizeTitle(item.Title)
+		for j, t := range titles {
+			if !used[j] && t == title {
+				used[j], kept[i] = true, true
+				break
+			}
+		}
+	}
+	var removed []string
+	for i, item := range open {
+		if kept[i] {
+			continue
+		}
+		title := NormalizeTitle(item.Title)
+		best, bestScore := -1, taskSimilarity
+		for j, t := range titles {
+			if score := similarity(title, t); !used[j] && score >= bestScore {
+				best, bestScore = j, score
+			}
+		}
+		if best < 0 {
+			removed = append(removed, item.Title)
+			continue
+		}
+		used[best] = true
+	}
+	return removed
+}
+
+// similarity is the Sørensen–Dice coefficient of a's and b's character
+// bigrams: 1 for identical strings, 0 for ones sharing no bigram.
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 2 || len(rb) < 2 {
+		return 0
+	}
+	bigrams := make(map[[2]rune]int, len(ra)-1)
+	for i := range len(ra) - 1 {
+		bigrams[[2]rune{ra[i], ra[i+1]}]++
+	}
+	shared := 0
+	for i := range len(rb) - 1 {
+		if bg := [2]rune{rb[i], rb[i+1]}; bigrams[bg] > 0 {
+			bigrams[bg]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ra)+len(rb)-2)
+}
+
+// parseTaskItem parses a checkbox list item with its indent removed.
+func parseTaskItem(line string) (checked bool, title string, ok bool) {
+	if len(line) < 6 || (line[0] != '-' && line[0] != '*') || line[1] != ' ' || line[2] != '[' || line[4] != ']' || line[5] != ' ' {
+		return false, "", false
+	}
+	switch line[3] {
+	case ' ':
+	case 'x', 'X':
+		checked = true
+	default:
+		return false, "", false
+	}
+	return checked, strings.TrimSpace(line[6:]), true
+}
+
+// LinkTaskItems appends " (<id>)" to the body lines given by links, which
+// maps TaskItem.Line to the ID of the issue the item became.
+func LinkTaskItems(body string, links map[int]string) string {
+	crlf := strings.Contains(body, "\r\n")
+	lines := strings.Split(normalizeEOL(body), "\n")
+	for n, id := range links {
+		if n >= 0 && n < len(lines) {
+			lines[n] = strings.TrimRight(lines[n], " \t") + " (" + id + ")"
+		}
+	}
+	return restoreEOL(strings.Join(lines, "\n"), crlf)
+}
+
+// NormalizeTitle folds a title for matching a task item to an issue:
+// lower-cased, with runs of whitespace collapsed to one space.
+func NormalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
@@ -0,0 +1,70 @@
+package issue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTaskItems(t *testing.T) {
+	body := "Intro\n\n## Tasks\n\n- [ ] First\n  * [X] Nested done\n- [ ]  \n- plain bullet\n```\n- [ ] in code\n```\n- [ ] Second (abc-123)\n\n## Other\n\n- [ ] Elsewhere\n"
+	items, err := TaskItems(body, "tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TaskItem{
+		{Line: 4, Title: "First"},
+		{Line: 5, Title: "Nested done", Checked: true},
+		{Line: 11, Title: "Second (abc-123)"},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("TaskItems() = %+v, want %+v", items, want)
+	}
+
+	if _, err := TaskItems(body, "Missing"); err == nil {
+		t.Error("TaskItems() of a missing section succeeded")
+	}
+}
+
+func TestLinkTaskItems(t *testing.T) {
+	got := LinkTaskItems("## Tasks\r\n\r\n- [ ] First \r\n- [ ] Second\r\n", map[int]string{2: "abc-123"})
+	if want := "## Tasks\r\n\r\n- [ ] First (abc-123)\r\n- [ ] Second\r\n"; got != want {
+		t.Errorf("LinkTaskItems() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	if got := NormalizeTitle("  Write   the\tPARSER "); got != "write the parser" {
+		t.Errorf("NormalizeTitle() = %q", got)
+	}
+}
+
+func TestRemovedTasks(t *testing.T) {
+	old := "## Tasks\n\n- [ ] Write the parser\n- [ ] Add fuzz tests\n- [x] Sketch the grammar\n"
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{name: "unchanged", old: old, new: old},
+		{name: "no unchecked items", old: "- [x] Done\n", new: ""},
+		{name: "reordered", old: old, new: "- [ ] Add fuzz tests\n- [ ] Write the parser\n"},
+		{name: "moved to another section", old: old, new: "Intro\n\n## Later\n\n* [ ] write the  PARSER\n- [ ] Add fuzz tests\n"},
+		{name: "checked off", old: old, new: "- [x] Write the parser\n- [X] Add fuzz tests\n"},
+		{name: "reworded", old: old, new: "- [ ] Write the YAML parser\n- [ ] Add fuzz test\n"},
+		{name: "checked item dropped", old: old, new: "- [ ] Write the parser\n- [ ] Add fuzz tests\n"},
+		{name: "one deleted", old: old, new: "- [ ] Write the parser\n", want: []string{"Add fuzz tests"}},
+		{name: "all deleted", old: old, new: "Rewritten from scratch.\n", want: []string{"Write the parser", "Add fuzz tests"}},
+		{name: "replaced by an unrelated item", old: old, new: "- [ ] Write the parser\n- [ ] Ship the release\n", want: []string{"Add fuzz tests"}},
+		{name: "only in a code block now", old: old, new: "- [ ] Write the parser\n```\n- [ ] Add fuzz tests\n```\n", want: []string{"Add fuzz tests"}},
+		{name: "duplicates each need an item", old: "- [ ] Review\n- [ ] Review\n", new: "- [ ] Review\n", want: []string{"Review"}},
+		{name: "crlf", old: "- [ ] First\r\n- [ ] Second\r\n", new: "- [ ] Second\r\n", want: []string{"First"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemovedTasks(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RemovedTasks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Front matter keys of the timestamps Parse reads with timestamp.
+const (
+	KeyCreatedAt = "created_at"
+	KeyUpdatedAt = "updated_at"
+)
+
+// timestamp is a created_at or updated_at as read from front matter. It
+// accepts a timestamp without a zone, reading it as UTC, and remembers that
+// it did, so the loader can say so.
+type timestamp struct {
+	time.Time
+	naive bool
+}
+
+// zonedLayouts are the YAML timestamp forms that carry a zone.
+var zonedLayouts = []string{
+	"2006-1-2T15:4:5.999999999Z07:00",
+	"2006-1-2t15:4:5.999999999Z07:00",
+	"2006-1-2 15:4:5.999999999Z07:00",
+	"2006-1-2 15:4:5.999999999 Z07:00",
+}
+
+// naiveLayouts are the forms without a zone that hand-edited and older
+// files use. time.Parse reads them as UTC.
+var naiveLayouts = []string{
+	"2006-1-2T15:4:5.999999999",
+	"2006-1-2t15:4:5.999999999",
+	"2006-1-2 15:4:5.999999999",
+	"2006-1-2T15:4",
+	"2006-1-2 15:4",
+	"2006-1-2",
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. A zoned timestamp is
+// converted to UTC; an empty one is left zero.
+func (t *timestamp) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	parsed, naive, err := parseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = timestamp{Time: parsed, naive: naive}
+	return nil
+}
+
+// ParseTimestamp parses a timestamp the way created_at is read from front
+// matter: RFC 3339 or one of the YAML forms, converted to UTC, or a form
+// without a zone (down to a bare date), read as UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	t, _, err := parseTimestamp(strings.TrimSpace(s))
+	return t, err
+}
+
+// parseTimestamp parses s, reporting whether it had no zone.
+func parseTimestamp(s string) (t time.Time, naive bool, err error) {
+	for _, layout := range zonedLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed.UTC(), false, nil
+		}
+	}
+	for _, layout := range naiveLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, true, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid timestamp %q (want RFC 3339, such as 2006-01-02T15:04:05Z)", s)
+}
+
+// nonZeroTime returns t's time, or nil for a missing or zero time, which
+// Render leaves out just as it does a missing timestamp.
+func nonZeroTime(t *timestamp) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return &t.Time
+}
+
+// naiveKeys returns the keys of the timestamps that had no zone.
+func naiveKeys(createdAt, updatedAt *timestamp) []string {
+	var keys []string
+	if createdAt != nil && createdAt.naive && !createdAt.IsZero() {
+		keys = append(keys, KeyCreatedAt)
+	}
+	if updatedAt != nil && updatedAt.naive && !updatedAt.IsZero() {
+		keys = append(keys, KeyUpdatedAt)
+	}
+	return keys
+}
+
+// utcTime returns t in UTC, so every rendered timestamp is RFC 3339 UTC
+// whatever zone it was created or parsed in.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
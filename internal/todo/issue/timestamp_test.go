@@ -0,0 +1,67 @@
+package issue
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamps(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt string
+		want      time.Time
+		naive     bool
+	}{
+		{"utc", "2025-03-04T09:15:00Z", time.Date(2025, 3, 4, 9, 15, 0, 0, time.UTC), false},
+		{"offset is converted to utc", "2025-03-04T11:15:00+02:00", time.Date(2025, 3, 4, 9, 15, 0, 0, time.UTC), false},
+		{"space before offset", "2025-03-04 09:15:00 -05:00", time.Date(2025, 3, 4, 14, 15, 0, 0, time.UTC), false},
+		{"naive with T", "2025-03-04T09:15:00", time.Date(2025, 3, 4, 9, 15, 0, 0, time.UTC), true},
+		{"naive with space", "2025-03-04 09:15:00.25", time.Date(2025, 3, 4, 9, 15, 0, 250_000_000, time.UTC), true},
+		{"naive without seconds", `"2025-03-04T09:15"`, time.Date(2025, 3, 4, 9, 15, 0, 0, time.UTC), true},
+		{"date only", "2025-03-04", time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Parse(strings.NewReader("---\ntitle: T\nstatus: ready\ncreated_at: " + tt.createdAt + "\n---\n"))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if b.CreatedAt == nil || !b.CreatedAt.Equal(tt.want) || b.CreatedAt.Location() != time.UTC {
+				t.Errorf("CreatedAt = %v, want %v", b.CreatedAt, tt.want)
+			}
+			if got := slices.Contains(b.NaiveTimes, KeyCreatedAt); got != tt.naive {
+				t.Errorf("NaiveTimes = %v, want naive %v", b.NaiveTimes, tt.naive)
+			}
+
+			rendered, err := b.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "created_at: " + tt.want.Format(time.RFC3339Nano) + "\n"; !strings.Contains(string(rendered), want) {
+				t.Errorf("Render() = %s, want a line %q", rendered, want)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("---\ntitle: T\nstatus: ready\ncreated_at: last tuesday\n---\n"))
+		if err == nil || !strings.Contains(err.Error(), "invalid timestamp") {
+			t.Errorf("Parse() error = %v, want an invalid timestamp error", err)
+		}
+	})
+}
+
+func TestRenderConvertsTimestampsToUTC(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, tokyo)
+	b := &Issue{Title: "T", Status: "ready", CreatedAt: &created}
+	rendered, err := b.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(rendered), "created_at: 2026-01-01T18:04:05Z\n") {
+		t.Errorf("Render() = %s, want created_at in UTC", rendered)
+	}
+}
@@ -0,0 +1,137 @@
+// Package launch opens issue files in the user's editor, and folders and
+// URLs with the operating system's default handlers.
+package launch
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// SystemEditor returns the OS default application opener command and flags.
+// On macOS, it returns "open" with flags to wait for the app to close (-W),
+// open a new instance (-n), and keep the GUI app focused (-g).
+// On other platforms, it returns empty values (e.g. xdg-open doesn't support blocking).
+func SystemEditor() (cmd string, args []string, ok bool) {
+	if runtime.GOOS == "darwin" {
+		return "open", []string{"-W", "-n", "-g"}, true
+	}
+	return "", nil, false
+}
+
+// Editor returns the user's preferred editor command and any extra arguments.
+// Fallback chain: config editor -> $VISUAL -> $EDITOR -> OS default -> vi -> nano.
+// The special value "system" resolves to the OS default application opener.
+// Multi-word editor values (e.g. "code --wait") are split on whitespace.
+// Relative paths from config are resolved relative to the config directory.
+func Editor(cfg *config.Config) (string, []string) {
+	editor := cfg.GetEditor()
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	// Handle the "system" keyword — resolve to OS default app opener
+	if strings.EqualFold(editor, "system") {
+		if cmd, args, ok := SystemEditor(); ok {
+			return cmd, args
+		}
+		// Unsupported platform — fall through to vi/nano
+		editor = ""
+	}
+
+	if editor == "" {
+		// Try OS default app opener before falling back to terminal editors
+		if cmd, args, ok := SystemEditor(); ok {
+			return cmd, args
+		}
+		if _, err := exec.LookPath("vi"); err == nil {
+			editor = "vi"
+		} else {
+			editor = "nano"
+		}
+	}
+
+	parts := strings.Fields(editor)
+	cmd := parts[0]
+	var args []string
+	if len(parts) > 1 {
+		args = parts[1:]
+	}
+
+	// Resolve relative paths from config against config directory
+	if (strings.HasPrefix(cmd, "./") || strings.HasPrefix(cmd, "../")) && cfg.ConfigDir() != "" {
+		cmd = filepath.Join(cfg.ConfigDir(), cmd)
+	}
+
+	return cmd, args
+}
+
+// EditorCommand returns the command that opens path in the user's editor.
+// The caller attaches the terminal and runs it.
+func EditorCommand(cfg *config.Config, path string) *exec.Cmd {
+	name, args := Editor(cfg)
+	return exec.Command(name, append(slices.Clone(args), path)...) //nolint:gosec // user-configured editor
+}
+
+// Runner runs a command to completion.
+type Runner func(name string, args ...string) error
+
+// Opener hands URLs and folders to the platform's default handlers:
+// open on macOS, explorer and the URL protocol handler on Windows, and
+// xdg-open elsewhere.
+type Opener struct {
+	// GOOS selects the commands, as runtime.GOOS names platforms.
+	GOOS string
+	// Run runs them; tests substitute one that records the commands.
+	Run Runner
+}
+
+// New returns an Opener for the running platform.
+func New() *Opener {
+	return &Opener{GOOS: runtime.GOOS, Run: run}
+}
+
+func run(name string, args ...string) error {
+	return exec.Command(name, args...).Run() //nolint:gosec // platform opener
+}
+
+// URL opens url in the default browser.
+func (o *Opener) URL(url string) error {
+	switch o.GOOS {
+	case "darwin":
+		return o.Run("open", url)
+	case "windows":
+		// start would need cmd quoting for the & in query strings.
+		return o.Run("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return o.Run("xdg-open", url)
+	}
+}
+
+// Reveal shows path in the file manager. Where the file manager can select
+// a file (macOS and Windows) the file is selected; elsewhere its folder is
+// opened.
+func (o *Opener) Reveal(path string) error {
+	switch o.GOOS {
+	case "darwin":
+		return o.Run("open", "-R", path)
+	case "windows":
+		// explorer exits 1 even when the window opens.
+		err := o.Run("explorer", "/select,"+path)
+		if _, ok := errors.AsType[*exec.ExitError](err); ok {
+			return nil
+		}
+		return err
+	default:
+		return o.Run("xdg-open", filepath.Dir(path))
+	}
+}
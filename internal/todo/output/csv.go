@@ -0,0 +1,51 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// utf8BOM marks a file as UTF-8 for spreadsheet apps (Excel) that otherwise
+// guess a legacy code page.
+const utf8BOM = "\ufeff"
+
+// CSVColumn is one exported field: its header and how to read it from an issue.
+type CSVColumn struct {
+	Name  string
+	Value func(*issue.Issue) string
+}
+
+// WriteCSV writes issues as RFC 4180 CSV: a header row of column names, then
+// one CRLF-terminated row per issue. Fields holding commas, quotes, or line
+// breaks are quoted. With bom set, the output starts with a UTF-8 byte order
+// mark.
+func WriteCSV(w io.Writer, issues []*issue.Issue, columns []CSVColumn, bom bool) error {
+	if bom {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return err
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		record[i] = col.Name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, b := range issues {
+		for i, col := range columns {
+			record[i] = col.Value(b)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	issues := []*issue.Issue{
+		{ID: "aaa-111", Title: `Say "hello", then wave`, Status: "ready"},
+		{ID: "bbb-222", Title: "Two\nlines, one issue", Status: "draft"},
+		{ID: "ccc-333", Title: "Plain", Status: "completed"},
+	}
+	columns := []CSVColumn{
+		{Name: "id", Value: func(b *issue.Issue) string { return b.ID }},
+		{Name: "title", Value: func(b *issue.Issue) string { return b.Title }},
+		{Name: "status", Value: func(b *issue.Issue) string { return b.Status }},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, issues, columns, false); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Say ""hello"", then wave"`) {
+		t.Errorf("embedded quotes not escaped:\n%s", buf.String())
+	}
+	if !strings.HasSuffix(buf.String(), "\r\n") {
+		t.Error("records should end in CRLF")
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing output: %v", err)
+	}
+	if len(records) != len(issues)+1 {
+		t.Fatalf("got %d records, want %d", len(records), len(issues)+1)
+	}
+	if !slices.Equal(records[0], []string{"id", "title", "status"}) {
+		t.Errorf("header = %v", records[0])
+	}
+	for i, b := range issues {
+		want := []string{b.ID, b.Title, b.Status}
+		if !slices.Equal(records[i+1], want) {
+			t.Errorf("record %d = %q, want %q", i+1, records[i+1], want)
+		}
+	}
+}
+
+func TestWriteCSVBOM(t *testing.T) {
+	columns := []CSVColumn{{Name: "id", Value: func(b *issue.Issue) string { return b.ID }}}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, nil, columns, true); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if got := buf.String(); got != "\ufeffid\r\n" {
+		t.Errorf("WriteCSV(bom) = %q, want BOM then header", got)
+	}
+
+	buf.Reset()
+	if err := WriteCSV(&buf, nil, columns, false); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if strings.HasPrefix(buf.String(), "\ufeff") {
+		t.Error("BOM written without bom set")
+	}
+}
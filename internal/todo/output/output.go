@@ -2,7 +2,7 @@ package output
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"os"
 
 	"github.com/toba/jig/internal/todo/issue"
@@ -16,8 +16,56 @@ const (
 	ErrFileError     = "FILE_ERROR"
 	ErrValidation    = "VALIDATION_ERROR"
 	ErrConflict      = "CONFLICT"
+	ErrAmbiguous     = "AMBIGUOUS"
+	ErrIntegration   = "INTEGRATION_ERROR"
 )
 
+// Exit codes for failed todo commands. Scripts can tell the failure classes
+// apart without parsing the message; every other failure exits ExitError.
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitValidation  = 2
+	ExitNotFound    = 3
+	ExitConflict    = 4
+	ExitIntegration = 5
+)
+
+// ExitCode returns the process exit code for a JSON error code.
+func ExitCode(code string) int {
+	switch code {
+	case ErrValidation, ErrInvalidStatus, ErrAmbiguous:
+		return ExitValidation
+	case ErrNotFound:
+		return ExitNotFound
+	case ErrConflict:
+		return ExitConflict
+	case ErrIntegration:
+		return ExitIntegration
+	}
+	return ExitError
+}
+
+// CodedError is a command error with its JSON error code. Err keeps the
+// underlying error so errors.Is and errors.As still see through it.
+type CodedError struct {
+	Code string
+	Err  error
+	// reported is set once the error has been written as a JSON response.
+	reported bool
+}
+
+func (e *CodedError) Error() string { return e.Err.Error() }
+
+func (e *CodedError) Unwrap() error { return e.Err }
+
+// ExitCode returns the process exit code for e.
+func (e *CodedError) ExitCode() int { return ExitCode(e.Code) }
+
+// Reported reports whether the error has already been written to stdout as
+// a JSON response.
+func (e *CodedError) Reported() bool { return e.reported }
+
 // Response is the standard JSON response envelope.
 type Response struct {
 	Success  bool           `json:"success"`
@@ -28,7 +76,19 @@ type Response struct {
 	Warnings []string       `json:"warnings,omitempty"`
 	Error    string         `json:"error,omitempty"`
 	Code     string         `json:"code,omitempty"`
+	ExitCode int            `json:"exit_code,omitempty"`
 	Path     string         `json:"path,omitempty"`
+	// Conflicts is set on CONFLICT errors whose changes could not be merged.
+	Conflicts []FieldConflict `json:"conflicts,omitempty"`
+}
+
+// FieldConflict is a field changed both by an update and, concurrently, by
+// someone else. Base is the value both started from, when known.
+type FieldConflict struct {
+	Field  string  `json:"field"`
+	Base   *string `json:"base,omitempty"`
+	Yours  string  `json:"yours"`
+	Theirs string  `json:"theirs"`
 }
 
 // JSON outputs a response as JSON to stdout.
@@ -92,15 +152,35 @@ func SuccessInit(path string) error {
 
 // Error outputs an error response and returns an error for command handling.
 func Error(code, message string) error {
+	return ErrorFrom(code, errors.New(message))
+}
+
+// AlreadyReported returns an error for a failure whose JSON response the
+// command has written itself, so it is not reported a second time.
+func AlreadyReported(code string, err error) error {
+	return &CodedError{Code: code, Err: err, reported: true}
+}
+
+// ConflictError outputs a CONFLICT error response listing the conflicting
+// fields.
+func ConflictError(message string, conflicts []FieldConflict) error {
 	_ = JSON(Response{
-		Success: false,
-		Error:   message,
-		Code:    code,
+		Success:   false,
+		Error:     message,
+		Code:      ErrConflict,
+		ExitCode:  ExitConflict,
+		Conflicts: conflicts,
 	})
-	return fmt.Errorf("%s", message)
+	return &CodedError{Code: ErrConflict, Err: errors.New(message), reported: true}
 }
 
 // ErrorFrom outputs an error response from an existing error.
 func ErrorFrom(code string, err error) error {
-	return Error(code, err.Error())
+	_ = JSON(Response{
+		Success:  false,
+		Error:    err.Error(),
+		Code:     code,
+		ExitCode: ExitCode(code),
+	})
+	return &CodedError{Code: code, Err: err, reported: true}
 }
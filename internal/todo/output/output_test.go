@@ -234,6 +234,33 @@ func TestError(t *testing.T) {
 	if resp.Error != "issue not found" {
 		t.Errorf("error = %q, want %q", resp.Error, "issue not found")
 	}
+	if resp.ExitCode != ExitNotFound {
+		t.Errorf("exit_code = %d, want %d", resp.ExitCode, ExitNotFound)
+	}
+
+	var coded *CodedError
+	if !errors.As(returnedErr, &coded) || coded.Code != ErrNotFound || !coded.Reported() {
+		t.Errorf("returned error = %#v, want a reported *CodedError with code %s", returnedErr, ErrNotFound)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := map[string]int{
+		ErrValidation:    ExitValidation,
+		ErrInvalidStatus: ExitValidation,
+		ErrAmbiguous:     ExitValidation,
+		ErrNotFound:      ExitNotFound,
+		ErrConflict:      ExitConflict,
+		ErrIntegration:   ExitIntegration,
+		ErrFileError:     ExitError,
+		ErrNoDataDir:     ExitError,
+		"":               ExitError,
+	}
+	for code, want := range tests {
+		if got := ExitCode(code); got != want {
+			t.Errorf("ExitCode(%q) = %d, want %d", code, got, want)
+		}
+	}
 }
 
 func TestErrorFrom(t *testing.T) {
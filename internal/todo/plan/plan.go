@@ -0,0 +1,111 @@
+// Package plan proposes which ready issues fit in an iteration, given its
+// capacity and the issues' estimates.
+package plan
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Item is an issue with the effort it counts for against capacity.
+type Item struct {
+	Issue    *issue.Issue
+	Estimate time.Duration
+	// Defaulted is true when the issue has no estimate of its own and
+	// Estimate is the configured default.
+	Defaulted bool
+}
+
+// Plan is what Pack proposes for an iteration.
+type Plan struct {
+	Capacity time.Duration
+	// Committed are the issues already assigned to the iteration.
+	Committed []Item
+	// Proposed are the issues to add, must-includes first, then in rank
+	// order.
+	Proposed []Item
+	// Skipped are the ranked issues that did not fit.
+	Skipped []Item
+	// Load is the effort of Committed and Proposed together.
+	Load time.Duration
+	// Warnings explain where the plan breaks capacity, such as
+	// must-includes that do not fit.
+	Warnings []string
+}
+
+// Pack fills an iteration up to capacity. Committed issues count first,
+// then every must-include, even past capacity (with a warning), then
+// ranked issues greedily: each one that still fits is proposed and the rest
+// are skipped, so a large issue does not keep smaller ones behind it out.
+// An issue in more than one list counts once, at its first appearance.
+func Pack(capacity time.Duration, committed, mustInclude, ranked []Item) Plan {
+	p := Plan{Capacity: capacity}
+	seen := make(map[string]bool)
+	for _, it := range committed {
+		if !seen[it.Issue.ID] {
+			seen[it.Issue.ID] = true
+			p.Committed = append(p.Committed, it)
+			p.Load += it.Estimate
+		}
+	}
+	if p.Load > capacity {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("already over capacity: %s committed of %s", FormatEffort(p.Load), FormatEffort(capacity)))
+	}
+	for _, it := range mustInclude {
+		if seen[it.Issue.ID] {
+			continue
+		}
+		seen[it.Issue.ID] = true
+		p.Proposed = append(p.Proposed, it)
+		p.Load += it.Estimate
+		if p.Load > capacity {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("must-include %s (%s) exceeds capacity: %s of %s", it.Issue.ID, FormatEffort(it.Estimate), FormatEffort(p.Load), FormatEffort(capacity)))
+		}
+	}
+	for _, it := range ranked {
+		if seen[it.Issue.ID] {
+			continue
+		}
+		seen[it.Issue.ID] = true
+		if p.Load+it.Estimate > capacity {
+			p.Skipped = append(p.Skipped, it)
+			continue
+		}
+		p.Proposed = append(p.Proposed, it)
+		p.Load += it.Estimate
+	}
+	return p
+}
+
+// Remaining is the capacity left after the plan, or a negative amount when
+// it is over.
+func (p Plan) Remaining() time.Duration {
+	return p.Capacity - p.Load
+}
+
+// ProposedIssues returns the issues of p.Proposed.
+func (p Plan) ProposedIssues() []*issue.Issue {
+	issues := make([]*issue.Issue, len(p.Proposed))
+	for i, it := range p.Proposed {
+		issues[i] = it.Issue
+	}
+	return issues
+}
+
+// FormatEffort renders an effort in hours under a day and in days (to one
+// decimal place) beyond that, e.g. "4h", "2d", or "2.5d".
+func FormatEffort(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	const day = 24 * time.Hour
+	if d < day {
+		return sign + strconv.FormatFloat(d.Hours(), 'f', -1, 64) + "h"
+	}
+	days := float64(d) / float64(day)
+	return sign + strconv.FormatFloat(float64(int64(days*10+0.5))/10, 'f', -1, 64) + "d"
+}
@@ -0,0 +1,149 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+const day = 24 * time.Hour
+
+func item(id string, estimate time.Duration) Item {
+	return Item{Issue: &issue.Issue{ID: id}, Estimate: estimate}
+}
+
+func ids(items []Item) []string {
+	var out []string
+	for _, it := range items {
+		out = append(out, it.Issue.ID)
+	}
+	return out
+}
+
+func TestPack(t *testing.T) {
+	tests := []struct {
+		name         string
+		capacity     time.Duration
+		committed    []Item
+		mustInclude  []Item
+		ranked       []Item
+		wantProposed []string
+		wantSkipped  []string
+		wantLoad     time.Duration
+		wantWarnings int
+	}{
+		{
+			name:         "everything fits",
+			capacity:     10 * day,
+			ranked:       []Item{item("a", 2*day), item("b", 3*day)},
+			wantProposed: []string{"a", "b"},
+			wantLoad:     5 * day,
+		},
+		{
+			name:         "exact fit",
+			capacity:     5 * day,
+			ranked:       []Item{item("a", 2*day), item("b", 3*day)},
+			wantProposed: []string{"a", "b"},
+			wantLoad:     5 * day,
+		},
+		{
+			name:         "large issue skipped, smaller ones after it still fit",
+			capacity:     4 * day,
+			ranked:       []Item{item("a", 2*day), item("b", 3*day), item("c", day), item("d", 4*time.Hour)},
+			wantProposed: []string{"a", "c", "d"},
+			wantSkipped:  []string{"b"},
+			wantLoad:     3*day + 4*time.Hour,
+		},
+		{
+			name:         "committed issues count first",
+			capacity:     5 * day,
+			committed:    []Item{item("x", 4*day)},
+			ranked:       []Item{item("a", 2*day), item("b", day)},
+			wantProposed: []string{"b"},
+			wantSkipped:  []string{"a"},
+			wantLoad:     5 * day,
+		},
+		{
+			name:         "committed issue in the ranked list is not proposed again",
+			capacity:     5 * day,
+			committed:    []Item{item("a", 2*day)},
+			ranked:       []Item{item("a", 2*day), item("b", day)},
+			wantProposed: []string{"b"},
+			wantLoad:     3 * day,
+		},
+		{
+			name:         "must-include goes first",
+			capacity:     3 * day,
+			mustInclude:  []Item{item("m", 2*day)},
+			ranked:       []Item{item("a", 2*day), item("m", 2*day), item("b", day)},
+			wantProposed: []string{"m", "b"},
+			wantSkipped:  []string{"a"},
+			wantLoad:     3 * day,
+		},
+		{
+			name:         "must-include over capacity is kept with a warning",
+			capacity:     2 * day,
+			mustInclude:  []Item{item("m", 3*day)},
+			ranked:       []Item{item("a", day)},
+			wantProposed: []string{"m"},
+			wantSkipped:  []string{"a"},
+			wantLoad:     3 * day,
+			wantWarnings: 1,
+		},
+		{
+			name:         "already over capacity",
+			capacity:     day,
+			committed:    []Item{item("x", 2*day)},
+			ranked:       []Item{item("a", time.Hour)},
+			wantSkipped:  []string{"a"},
+			wantLoad:     2 * day,
+			wantWarnings: 1,
+		},
+		{
+			name:        "zero capacity proposes nothing",
+			ranked:      []Item{item("a", time.Hour)},
+			wantSkipped: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Pack(tt.capacity, tt.committed, tt.mustInclude, tt.ranked)
+			if got := ids(p.Proposed); !reflect.DeepEqual(got, tt.wantProposed) {
+				t.Errorf("Proposed = %v, want %v", got, tt.wantProposed)
+			}
+			if got := ids(p.Skipped); !reflect.DeepEqual(got, tt.wantSkipped) {
+				t.Errorf("Skipped = %v, want %v", got, tt.wantSkipped)
+			}
+			if p.Load != tt.wantLoad {
+				t.Errorf("Load = %v, want %v", p.Load, tt.wantLoad)
+			}
+			if p.Remaining() != tt.capacity-tt.wantLoad {
+				t.Errorf("Remaining() = %v, want %v", p.Remaining(), tt.capacity-tt.wantLoad)
+			}
+			if len(p.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %q, want %d", p.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestFormatEffort(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{4 * time.Hour, "4h"},
+		{90 * time.Minute, "1.5h"},
+		{day, "1d"},
+		{60 * time.Hour, "2.5d"},
+		{10 * day, "10d"},
+		{-12 * time.Hour, "-12h"},
+	}
+	for _, tt := range tests {
+		if got := FormatEffort(tt.in); got != tt.want {
+			t.Errorf("FormatEffort(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
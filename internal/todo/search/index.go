@@ -2,6 +2,8 @@
 package search
 
 import (
+	"strings"
+
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/mapping"
 	"github.com/toba/jig/internal/todo/issue"
@@ -14,10 +16,12 @@ type Index struct {
 
 // issueDocument is the structure stored in the Bleve index.
 type issueDocument struct {
-	ID    string `json:"id"`
-	Slug  string `json:"slug"`
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	ID      string   `json:"id"`
+	Slug    string   `json:"slug"`
+	Title   string   `json:"title"`
+	Summary string   `json:"summary"`
+	Body    string   `json:"body"`
+	Tags    []string `json:"tags"`
 }
 
 // NewIndex creates a new in-memory Bleve index.
@@ -45,8 +49,15 @@ func buildIndexMapping() mapping.IndexMapping {
 	issueMapping.AddFieldMappingsAt("id", keywordFieldMapping)
 	issueMapping.AddFieldMappingsAt("slug", textFieldMapping)
 	issueMapping.AddFieldMappingsAt("title", textFieldMapping)
+	issueMapping.AddFieldMappingsAt("summary", textFieldMapping)
 	issueMapping.AddFieldMappingsAt("body", textFieldMapping)
 
+	// Tags are looked up only by Candidates, so query-string searches keep
+	// matching the text fields alone.
+	tagFieldMapping := bleve.NewKeywordFieldMapping()
+	tagFieldMapping.IncludeInAll = false
+	issueMapping.AddFieldMappingsAt("tags", tagFieldMapping)
+
 	// Create the index mapping with BM25 scoring for better relevance ranking
 	indexMapping := bleve.NewIndexMapping()
 	indexMapping.DefaultMapping = issueMapping
@@ -70,13 +81,23 @@ func (idx *Index) Close() error {
 
 // IndexIssue adds or updates an issue in the search index.
 func (idx *Index) IndexIssue(b *issue.Issue) error {
+	return idx.index.Index(b.ID, newIssueDocument(b))
+}
+
+// newIssueDocument builds the indexed form of an issue. Encrypted bodies are
+// never indexed, so their plaintext cannot leak through search results.
+func newIssueDocument(b *issue.Issue) issueDocument {
 	doc := issueDocument{
-		ID:    b.ID,
-		Slug:  b.Slug,
-		Title: b.Title,
-		Body:  b.Body,
+		ID:      b.ID,
+		Slug:    b.Slug,
+		Title:   b.Title,
+		Summary: b.Summary,
+		Tags:    b.Tags,
+	}
+	if !b.Encrypted {
+		doc.Body = b.Body
 	}
-	return idx.index.Index(b.ID, doc)
+	return doc
 }
 
 // DeleteIssue removes an issue from the search index.
@@ -119,17 +140,45 @@ func (idx *Index) Search(queryStr string, limit int) ([]string, error) {
 	return ids, nil
 }
 
+// Candidates returns the IDs of issues whose slug, title, summary, body, or
+// tags contain word anywhere, ignoring case. ok is false when word is not a
+// single term the index holds (several words, punctuation, or a stop word);
+// callers then scan every issue instead.
+func (idx *Index) Candidates(word string) (ids []string, ok bool, err error) {
+	analyzer := idx.index.Mapping().AnalyzerNamed("standard")
+	if analyzer == nil {
+		return nil, false, nil
+	}
+	tokens := analyzer.Analyze([]byte(word))
+	if len(tokens) != 1 || string(tokens[0].Term) != strings.ToLower(word) {
+		return nil, false, nil
+	}
+
+	pattern := "*" + string(tokens[0].Term) + "*"
+	text := bleve.NewWildcardQuery(pattern)
+	tags := bleve.NewWildcardQuery(pattern)
+	tags.SetField("tags")
+	count, err := idx.index.DocCount()
+	if err != nil {
+		return nil, false, err
+	}
+	searchRequest := bleve.NewSearchRequest(bleve.NewDisjunctionQuery(text, tags))
+	searchRequest.Size = int(count) // every match, as a scan would find
+	result, err := idx.index.Search(searchRequest)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids, true, nil
+}
+
 // IndexIssues indexes multiple issues in a batch for efficiency.
 func (idx *Index) IndexIssues(issues []*issue.Issue) error {
 	batch := idx.index.NewBatch()
 	for _, b := range issues {
-		doc := issueDocument{
-			ID:    b.ID,
-			Slug:  b.Slug,
-			Title: b.Title,
-			Body:  b.Body,
-		}
-		if err := batch.Index(b.ID, doc); err != nil {
+		if err := batch.Index(b.ID, newIssueDocument(b)); err != nil {
 			return err
 		}
 	}
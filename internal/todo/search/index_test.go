@@ -101,6 +101,30 @@ func TestSearch_MatchBody(t *testing.T) {
 	}
 }
 
+func TestSearch_MatchSummary(t *testing.T) {
+	idx := setupTestIndex(t)
+
+	issues := []*issue.Issue{
+		{ID: "aaa1", Title: "Feature A", Summary: "Rotate signing keys nightly", Body: "Some content"},
+		{ID: "bbb2", Title: "Feature B", Summary: "Speed up the importer", Body: "Other content"},
+	}
+
+	for _, b := range issues {
+		if err := idx.IndexIssue(b); err != nil {
+			t.Fatalf("IndexIssue() error = %v", err)
+		}
+	}
+
+	ids, err := idx.Search("signing", 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if len(ids) != 1 || ids[0] != "aaa1" {
+		t.Errorf("Search(signing) = %v, want [aaa1]", ids)
+	}
+}
+
 func TestSearch_MatchSlug(t *testing.T) {
 	idx := setupTestIndex(t)
 
@@ -385,3 +409,25 @@ func TestSearch_DefaultLimit(t *testing.T) {
 		t.Errorf("Search with limit 0 (default) returned %d results, want 1", len(ids))
 	}
 }
+
+func TestCandidates(t *testing.T) {
+	idx := setupTestIndex(t)
+	if err := idx.IndexIssues([]*issue.Issue{
+		{ID: "aaa1", Title: "Parser rewrite", Body: "Tokenizer too"},
+		{ID: "bbb2", Title: "Other", Tags: []string{"reparse"}},
+		{ID: "ccc3", Title: "Unrelated"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, ok, err := idx.Candidates("PARS")
+	slices.Sort(ids)
+	if err != nil || !ok || !slices.Equal(ids, []string{"aaa1", "bbb2"}) {
+		t.Errorf("Candidates(PARS) = %v, %v, %v; want aaa1 and bbb2", ids, ok, err)
+	}
+	for _, word := range []string{"two words", "the", "c-d"} {
+		if _, ok, _ := idx.Candidates(word); ok {
+			t.Errorf("Candidates(%q) ok = true, want a scan", word)
+		}
+	}
+}
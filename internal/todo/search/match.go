@@ -0,0 +1,196 @@
+package search
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Fields a query can match, in ranking order.
+const (
+	FieldTitle   = "title"
+	FieldTag     = "tag"
+	FieldSummary = "summary"
+	FieldBody    = "body"
+	FieldComment = "comment"
+)
+
+// Fields lists every searchable field. Comments live in the body's Comments
+// section; matches there are reported as comment rather than body.
+var Fields = []string{FieldTitle, FieldTag, FieldSummary, FieldBody, FieldComment}
+
+// fieldWeight ranks a match by the field it is in.
+var fieldWeight = map[string]int{FieldTitle: 5, FieldTag: 4, FieldSummary: 3, FieldBody: 2, FieldComment: 1}
+
+// Options controls how Find matches a query.
+type Options struct {
+	// Regex treats the query as a Go regular expression instead of literal
+	// text.
+	Regex bool
+	// CaseSensitive matches letter case exactly.
+	CaseSensitive bool
+	// Fields restricts matching to these fields; empty means all of Fields.
+	Fields []string
+}
+
+// Match is one place a query matched. For body matches the snippet holds
+// the matching line with up to one line of context on either side.
+type Match struct {
+	Field string `json:"field"`
+	// Line is the 1-based line of the match within the field; comment
+	// matches count lines from the start of the body.
+	Line int `json:"line"`
+	// Snippet is the text around the match; Start and End are the byte
+	// offsets of the match within it.
+	Snippet string `json:"snippet"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+}
+
+// Hit is an issue with every place the query matched in it.
+type Hit struct {
+	Issue   *issue.Issue `json:"-"`
+	Matches []Match      `json:"matches"`
+}
+
+// Matcher finds a compiled query in issues.
+type Matcher struct {
+	re     *regexp.Regexp
+	fields []string
+}
+
+// NewMatcher compiles query under opts. Literal queries match as is;
+// unknown fields and invalid expressions are errors.
+func NewMatcher(query string, opts Options) (*Matcher, error) {
+	if query == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	for _, f := range opts.Fields {
+		if !slices.Contains(Fields, f) {
+			return nil, fmt.Errorf("unknown search field %q (want %s)", f, strings.Join(Fields, ", "))
+		}
+	}
+	expr := query
+	if !opts.Regex {
+		expr = regexp.QuoteMeta(query)
+	}
+	if !opts.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %w", err)
+	}
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = Fields
+	}
+	return &Matcher{re: re, fields: fields}, nil
+}
+
+// Match returns every place the query matches in b, in field order. The
+// bodies of encrypted issues are not searched.
+func (m *Matcher) Match(b *issue.Issue) []Match {
+	var matches []Match
+	for _, field := range m.fields {
+		switch field {
+		case FieldTitle:
+			matches = append(matches, m.matchText(field, b.Title)...)
+		case FieldSummary:
+			matches = append(matches, m.matchText(field, b.Summary)...)
+		case FieldTag:
+			for _, tag := range b.Tags {
+				matches = append(matches, m.matchText(field, tag)...)
+			}
+		case FieldBody, FieldComment:
+			if !b.Encrypted {
+				matches = append(matches, m.matchBody(field, strings.TrimPrefix(b.Body, "\n"))...)
+			}
+		}
+	}
+	return matches
+}
+
+// matchBody returns the matches in body for field: those inside the
+// Comments section for comment, and the rest for body.
+func (m *Matcher) matchBody(field, body string) []Match {
+	first, last, hasComments := issue.SectionLines(body, issue.CommentsSection)
+	var matches []Match
+	for _, match := range m.matchText(FieldBody, body) {
+		inComments := hasComments && match.Line >= first && match.Line <= last
+		if inComments == (field == FieldComment) {
+			match.Field = field
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// matchText returns a match for the first hit on each line of text.
+func (m *Matcher) matchText(field, text string) []Match {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var matches []Match
+	for n, line := range lines {
+		loc := m.re.FindStringIndex(line)
+		if loc == nil || loc[0] == loc[1] {
+			continue
+		}
+		snippet, offset := Snippet(lines, n, 1)
+		matches = append(matches, Match{Field: field, Line: n + 1, Snippet: snippet, Start: offset + loc[0], End: offset + loc[1]})
+	}
+	return matches
+}
+
+// Snippet joins lines[n] with up to context lines on either side, and
+// returns it with the byte offset at which lines[n] starts.
+func Snippet(lines []string, n, context int) (snippet string, offset int) {
+	from, to := max(0, n-context), min(len(lines), n+context+1)
+	for _, l := range lines[from:n] {
+		offset += len(l) + 1
+	}
+	return strings.Join(lines[from:to], "\n"), offset
+}
+
+// Find returns the issues the query matches, best first: issues with a
+// higher-ranked field match (title, then tag, summary, body, comment) come
+// first, then the more recently updated.
+func (m *Matcher) Find(issues []*issue.Issue) []Hit {
+	var hits []Hit
+	for _, b := range issues {
+		if matches := m.Match(b); len(matches) > 0 {
+			hits = append(hits, Hit{Issue: b, Matches: matches})
+		}
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if c := cmp.Compare(b.rank(), a.rank()); c != 0 {
+			return c
+		}
+		if ta, tb := a.Issue.UpdatedAt, b.Issue.UpdatedAt; ta != nil && tb != nil && !ta.Equal(*tb) {
+			return tb.Compare(*ta)
+		}
+		return cmp.Compare(a.Issue.ID, b.Issue.ID)
+	})
+	return hits
+}
+
+// rank is the weight of the best field the hit matched in.
+func (h Hit) rank() int {
+	best := 0
+	for _, m := range h.Matches {
+		best = max(best, fieldWeight[m.Field])
+	}
+	return best
+}
+
+// Literal reports whether the query is matched as plain, case-insensitive
+// text, which the index can narrow down (see Index.Candidates).
+func (o Options) Literal() bool {
+	return !o.Regex && !o.CaseSensitive
+}
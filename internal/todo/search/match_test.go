@@ -0,0 +1,107 @@
+package search
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestMatchSnippet(t *testing.T) {
+	m, err := NewMatcher("größe", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &issue.Issue{Title: "Über", Body: "\nerste Zeile\nDie GRÖSSE ist falsch, die Größe auch 🚀\nletzte Zeile\nnoch eine"}
+	matches := m.Match(b)
+	if len(matches) != 1 {
+		t.Fatalf("Match() = %+v, want one body match", matches)
+	}
+	got := matches[0]
+	if got.Field != FieldBody || got.Line != 2 {
+		t.Errorf("match at %s:%d, want body:2", got.Field, got.Line)
+	}
+	if want := "erste Zeile\nDie GRÖSSE ist falsch, die Größe auch 🚀\nletzte Zeile"; got.Snippet != want {
+		t.Errorf("Snippet = %q, want %q", got.Snippet, want)
+	}
+	if s := got.Snippet[got.Start:got.End]; s != "Größe" {
+		t.Errorf("matched text = %q, want Größe", s)
+	}
+}
+
+func TestMatchComment(t *testing.T) {
+	body := "Flaky upload.\n\n## Comments\n\n**sam** (jig, 2026-05-01T10:00:00Z):\nupload retried fine\n"
+	b := &issue.Issue{Body: body}
+
+	m, err := NewMatcher("upload", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := m.Match(b)
+	if len(matches) != 2 {
+		t.Fatalf("Match() = %+v, want a body and a comment match", matches)
+	}
+	if got := matches[0]; got.Field != FieldBody || got.Line != 1 {
+		t.Errorf("first match at %s:%d, want body:1", got.Field, got.Line)
+	}
+	if got := matches[1]; got.Field != FieldComment || got.Line != 6 {
+		t.Errorf("second match at %s:%d, want comment:6", got.Field, got.Line)
+	}
+
+	m, _ = NewMatcher("retried", Options{Fields: []string{FieldBody}})
+	if got := m.Match(b); len(got) != 0 {
+		t.Errorf("body-only search matched a comment: %+v", got)
+	}
+	m, _ = NewMatcher("flaky", Options{Fields: []string{FieldComment}})
+	if got := m.Match(b); len(got) != 0 {
+		t.Errorf("comment-only search matched the body: %+v", got)
+	}
+}
+
+func TestMatchRegexAndFields(t *testing.T) {
+	b := &issue.Issue{Title: "Retry the upload", Summary: "retries", Tags: []string{"network"}, Body: "No retrying here"}
+
+	m, err := NewMatcher(`^retr(y|ies)\b`, Options{Regex: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields []string
+	for _, match := range m.Match(b) {
+		fields = append(fields, match.Field)
+	}
+	if !slices.Equal(fields, []string{FieldTitle, FieldSummary}) {
+		t.Errorf("regex matched in %v, want title and summary", fields)
+	}
+
+	m, _ = NewMatcher("Retry", Options{CaseSensitive: true, Fields: []string{FieldSummary, FieldBody}})
+	if got := m.Match(b); len(got) != 0 {
+		t.Errorf("case-sensitive summary/body search = %+v, want none", got)
+	}
+
+	if _, err := NewMatcher("(", Options{Regex: true}); err == nil {
+		t.Error("NewMatcher() accepted an invalid expression")
+	}
+	if _, err := NewMatcher("x", Options{Fields: []string{"comments"}}); err == nil {
+		t.Error("NewMatcher() accepted an unknown field")
+	}
+}
+
+func TestFindRanking(t *testing.T) {
+	old, recent := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*issue.Issue{
+		{ID: "bod-old", Title: "Other", Body: "cache", UpdatedAt: &old},
+		{ID: "bod-new", Title: "Other", Body: "cache", UpdatedAt: &recent},
+		{ID: "tag-old", Title: "Other", Tags: []string{"cache"}, UpdatedAt: &old},
+		{ID: "ttl-old", Title: "Cache eviction", UpdatedAt: &old},
+		{ID: "non-new", Title: "Nothing", UpdatedAt: &recent},
+	}
+	m, _ := NewMatcher("cache", Options{})
+	var ids []string
+	for _, h := range m.Find(issues) {
+		ids = append(ids, h.Issue.ID)
+	}
+	if want := []string{"ttl-old", "tag-old", "bod-new", "bod-old"}; !slices.Equal(ids, want) {
+		t.Errorf("Find() order = %v, want %v", ids, want)
+	}
+}
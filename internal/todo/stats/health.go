@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// DueSoonWindow is how far ahead a due date counts as due soon.
+const DueSoonWindow = 7 * 24 * time.Hour
+
+// Summary is a workspace-wide health overview: issues per status, and how
+// many open issues are blocked, stale, due soon, or overdue. Open issues are
+// those not in an archive status.
+type Summary struct {
+	Statuses []Count `json:"statuses"`
+	Total    int     `json:"total"`
+	Blocked  int     `json:"blocked"`
+	Stale    int     `json:"stale"`
+	DueSoon  int     `json:"due_soon"`
+	Overdue  int     `json:"overdue"`
+}
+
+// Summarize computes the Summary of issues at now. isBlocked reports whether
+// an issue has an active blocker, and isStale whether it has gone too long
+// without an update (see core.Core.IsStale); nil counts nothing. DueSoon
+// counts open issues due within DueSoonWindow, not yet past their deadline.
+func Summarize(issues []*issue.Issue, cfg *config.Config, now time.Time, isBlocked func(id string) bool, isStale func(b *issue.Issue) bool) Summary {
+	s := Summary{
+		Statuses: Tally(issues, cfg.StatusNames(), func(b *issue.Issue) string { return b.Status }),
+		Total:    len(issues),
+	}
+	for _, b := range issues {
+		if cfg.IsArchiveStatus(b.Status) {
+			continue
+		}
+		if isBlocked != nil && isBlocked(b.ID) {
+			s.Blocked++
+		}
+		if isStale != nil && isStale(b) {
+			s.Stale++
+		}
+		if b.Due == nil {
+			continue
+		}
+		switch deadline := b.Due.Deadline(); {
+		case deadline.Before(now):
+			s.Overdue++
+		case deadline.Before(now.Add(DueSoonWindow)):
+			s.DueSoon++
+		}
+	}
+	return s
+}
+
+// StatusCount returns the number of issues with the given status.
+func (s Summary) StatusCount(status string) int {
+	for _, c := range s.Statuses {
+		if c.Value == status {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+// LastTouched is when b was last updated, falling back to its creation.
+func LastTouched(b *issue.Issue) time.Time {
+	switch {
+	case b.UpdatedAt != nil:
+		return *b.UpdatedAt
+	case b.CreatedAt != nil:
+		return *b.CreatedAt
+	}
+	return time.Time{}
+}
+
+// OldestInStatus returns up to n issues with the given status, least
+// recently updated first.
+func OldestInStatus(issues []*issue.Issue, status string, n int) []*issue.Issue {
+	var result []*issue.Issue
+	for _, b := range issues {
+		if b.Status == status {
+			result = append(result, b)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *issue.Issue) int {
+		return cmp.Or(LastTouched(a).Compare(LastTouched(b)), cmp.Compare(a.ID, b.ID))
+	})
+	return result[:min(n, len(result))]
+}
+
+// UpcomingDue returns up to n open issues with a due date, earliest
+// deadline first, so overdue issues lead.
+func UpcomingDue(issues []*issue.Issue, cfg *config.Config, n int) []*issue.Issue {
+	var result []*issue.Issue
+	for _, b := range issues {
+		if b.Due != nil && !cfg.IsArchiveStatus(b.Status) {
+			result = append(result, b)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *issue.Issue) int {
+		return cmp.Or(a.Due.Deadline().Compare(b.Due.Deadline()), cmp.Compare(a.ID, b.ID))
+	})
+	return result[:min(n, len(result))]
+}
+
+// RecentlyCompleted returns up to n completed issues, most recently updated
+// first.
+func RecentlyCompleted(issues []*issue.Issue, n int) []*issue.Issue {
+	var result []*issue.Issue
+	for _, b := range issues {
+		if b.Status == config.StatusCompleted {
+			result = append(result, b)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *issue.Issue) int {
+		return cmp.Or(LastTouched(b).Compare(LastTouched(a)), cmp.Compare(a.ID, b.ID))
+	})
+	return result[:min(n, len(result))]
+}
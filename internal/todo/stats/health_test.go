@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func ids(issues []*issue.Issue) []string {
+	result := make([]string, len(issues))
+	for i, b := range issues {
+		result[i] = b.ID
+	}
+	return result
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	day := func(offset int) *issue.DueDate { return issue.NewDueDate(now.AddDate(0, 0, offset)) }
+	issues := []*issue.Issue{
+		{ID: "a", Status: "ready", Due: day(2)},
+		{ID: "b", Status: "ready", Due: day(30)},
+		{ID: "c", Status: "in-progress", Due: day(-1)},
+		{ID: "d", Status: "completed", Due: day(-3)},
+		{ID: "e", Status: "ready", Due: day(0)},
+		{ID: "f", Status: "scrapped"},
+	}
+	blocked := map[string]bool{"b": true, "d": true}
+
+	stale := map[string]bool{"c": true, "f": true}
+
+	got := Summarize(issues, config.Default(), now, func(id string) bool { return blocked[id] }, func(b *issue.Issue) bool { return stale[b.ID] })
+	if got.Total != 6 || got.Blocked != 1 || got.Stale != 1 || got.DueSoon != 2 || got.Overdue != 1 {
+		t.Errorf("Summarize() = %+v, want total 6, blocked 1, stale 1, due soon 2, overdue 1", got)
+	}
+	if n := got.StatusCount("ready"); n != 3 {
+		t.Errorf("StatusCount(ready) = %d, want 3", n)
+	}
+	if n := got.StatusCount("review"); n != 0 {
+		t.Errorf("StatusCount(review) = %d, want 0", n)
+	}
+}
+
+func TestDashboardLists(t *testing.T) {
+	at := func(day int) *time.Time { return new(time.Date(2025, 8, day, 0, 0, 0, 0, time.UTC)) }
+	issues := []*issue.Issue{
+		{ID: "a", Status: "in-progress", UpdatedAt: at(5)},
+		{ID: "b", Status: "in-progress", CreatedAt: at(2)},
+		{ID: "c", Status: "in-progress", UpdatedAt: at(9)},
+		{ID: "d", Status: "completed", UpdatedAt: at(3)},
+		{ID: "e", Status: "completed", UpdatedAt: at(8)},
+		{ID: "f", Status: "ready", Due: issue.NewDueDate(*at(20))},
+		{ID: "g", Status: "ready", Due: issue.NewDueDate(*at(12))},
+		{ID: "h", Status: "completed", Due: issue.NewDueDate(*at(1))},
+	}
+
+	if got := ids(OldestInStatus(issues, "in-progress", 2)); !slices.Equal(got, []string{"b", "a"}) {
+		t.Errorf("OldestInStatus() = %v, want [b a]", got)
+	}
+	if got := ids(UpcomingDue(issues, config.Default(), 5)); !slices.Equal(got, []string{"g", "f"}) {
+		t.Errorf("UpcomingDue() = %v, want [g f]", got)
+	}
+	if got := ids(RecentlyCompleted(issues, 5)); !slices.Equal(got, []string{"e", "d", "h"}) {
+		t.Errorf("RecentlyCompleted() = %v, want [e d h]", got)
+	}
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Rollup totals a group of issues, such as everything under a roadmap
+// milestone or epic, so dashboards need not re-derive the counts.
+type Rollup struct {
+	Totals       Totals         `json:"totals"`
+	BlockedCount int            `json:"blockedCount"`
+	OverdueCount int            `json:"overdueCount"`
+	Blockers     []BlockedIssue `json:"blockers"`
+}
+
+// Totals counts a group's issues by status and by type.
+type Totals struct {
+	ByStatus map[string]int `json:"byStatus"`
+	ByType   map[string]int `json:"byType"`
+}
+
+// BlockedIssue is an open issue with the IDs of its active blockers.
+type BlockedIssue struct {
+	IssueID   string   `json:"issueId"`
+	BlockedBy []string `json:"blockedBy"`
+}
+
+// RollUp computes the Rollup of issues at now. Totals count every issue;
+// blocked and overdue only count open ones, as Summarize does.
+// activeBlockers returns an issue's unresolved blockers, the same ones the
+// isBlocked filter checks; nil counts nothing as blocked. Blockers is sorted
+// by issue ID.
+func RollUp(issues []*issue.Issue, cfg *config.Config, now time.Time, activeBlockers func(id string) []*issue.Issue) Rollup {
+	r := Rollup{
+		Totals:   Totals{ByStatus: map[string]int{}, ByType: map[string]int{}},
+		Blockers: []BlockedIssue{},
+	}
+	for _, b := range issues {
+		r.Totals.ByStatus[b.Status]++
+		r.Totals.ByType[b.Type]++
+		if cfg.IsArchiveStatus(b.Status) {
+			continue
+		}
+		if b.Due != nil && b.Due.Deadline().Before(now) {
+			r.OverdueCount++
+		}
+		if activeBlockers == nil {
+			continue
+		}
+		if blockers := activeBlockers(b.ID); len(blockers) > 0 {
+			ids := make([]string, len(blockers))
+			for i, blocker := range blockers {
+				ids[i] = blocker.ID
+			}
+			slices.Sort(ids)
+			r.Blockers = append(r.Blockers, BlockedIssue{IssueID: b.ID, BlockedBy: ids})
+		}
+	}
+	slices.SortFunc(r.Blockers, func(a, b BlockedIssue) int { return cmp.Compare(a.IssueID, b.IssueID) })
+	r.BlockedCount = len(r.Blockers)
+	return r
+}
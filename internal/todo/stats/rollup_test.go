@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestRollUp(t *testing.T) {
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := issue.NewDueDate(now.AddDate(0, 0, -1))
+	issues := []*issue.Issue{
+		{ID: "a", Type: "task", Status: "ready", Due: yesterday},
+		{ID: "b", Type: "bug", Status: "in-progress"},
+		{ID: "c", Type: "task", Status: "completed", Due: yesterday},
+	}
+	blockers := map[string][]*issue.Issue{
+		"b": {{ID: "z"}, {ID: "x"}},
+		"c": {{ID: "x"}},
+	}
+	r := RollUp(issues, config.Default(), now, func(id string) []*issue.Issue { return blockers[id] })
+
+	if want := map[string]int{"ready": 1, "in-progress": 1, "completed": 1}; !reflect.DeepEqual(r.Totals.ByStatus, want) {
+		t.Errorf("ByStatus = %v, want %v", r.Totals.ByStatus, want)
+	}
+	if want := map[string]int{"task": 2, "bug": 1}; !reflect.DeepEqual(r.Totals.ByType, want) {
+		t.Errorf("ByType = %v, want %v", r.Totals.ByType, want)
+	}
+	if r.OverdueCount != 1 {
+		t.Errorf("OverdueCount = %d, want 1 (closed issues are not overdue)", r.OverdueCount)
+	}
+	want := []BlockedIssue{{IssueID: "b", BlockedBy: []string{"x", "z"}}}
+	if r.BlockedCount != 1 || !reflect.DeepEqual(r.Blockers, want) {
+		t.Errorf("blocked = %d %v, want 1 %v", r.BlockedCount, r.Blockers, want)
+	}
+}
+
+func TestRollUpEmptyEncodesLists(t *testing.T) {
+	got, err := json.Marshal(RollUp(nil, config.Default(), time.Now(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"totals":{"byStatus":{},"byType":{}},"blockedCount":0,"overdueCount":0,"blockers":[]}`
+	if string(got) != want {
+		t.Errorf("RollUp(nil) = %s, want %s", got, want)
+	}
+}
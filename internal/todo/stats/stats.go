@@ -0,0 +1,96 @@
+// Package stats computes issue counts shared by the stats command, the
+// roadmap, and the TUI.
+package stats
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Count is the number of issues sharing one value of a field.
+type Count struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Tally counts issues by key. Values appear in order first, then any others
+// sorted by name; values with no issues are left out.
+func Tally(issues []*issue.Issue, order []string, key func(*issue.Issue) string) []Count {
+	counts := make(map[string]int)
+	for _, b := range issues {
+		counts[key(b)]++
+	}
+	var result []Count
+	for _, v := range order {
+		if n := counts[v]; n > 0 {
+			result = append(result, Count{Value: v, Count: n})
+			delete(counts, v)
+		}
+	}
+	rest := make([]Count, 0, len(counts))
+	for v, n := range counts {
+		rest = append(rest, Count{Value: v, Count: n})
+	}
+	slices.SortFunc(rest, func(a, b Count) int { return cmp.Compare(a.Value, b.Value) })
+	return append(result, rest...)
+}
+
+// IterationCount rolls up the issues assigned to one iteration. Committed
+// counts every assigned issue except scrapped ones; Completed counts those
+// of them that are completed.
+type IterationCount struct {
+	Iteration string `json:"iteration"`
+	Start     string `json:"start,omitempty"`
+	End       string `json:"end,omitempty"`
+	Current   bool   `json:"current,omitempty"`
+	Committed int    `json:"committed"`
+	Completed int    `json:"completed"`
+}
+
+// ByIteration rolls up issues per iteration: the declared iterations in
+// config order, including empty ones, then any other assigned iterations
+// (ISO weeks sort chronologically). Unassigned issues are not counted.
+// current is the current iteration's name, or "" for none.
+func ByIteration(issues []*issue.Issue, cfg *config.Config, current string) []IterationCount {
+	byName := make(map[string]*IterationCount)
+	var result []*IterationCount
+	add := func(name string) *IterationCount {
+		if r, ok := byName[name]; ok {
+			return r
+		}
+		r := &IterationCount{Iteration: name, Current: name == current}
+		if it := cfg.GetIteration(name); it != nil {
+			r.Start, r.End = it.Start, it.End
+		}
+		byName[name] = r
+		result = append(result, r)
+		return r
+	}
+	for _, name := range cfg.IterationNames() {
+		add(name)
+	}
+	declared := len(result)
+
+	for _, b := range issues {
+		if b.Iteration == "" || b.Status == config.StatusScrapped {
+			continue
+		}
+		r := add(b.Iteration)
+		r.Committed++
+		if b.Status == config.StatusCompleted {
+			r.Completed++
+		}
+	}
+
+	slices.SortFunc(result[declared:], func(a, b *IterationCount) int {
+		return cmp.Compare(a.Iteration, b.Iteration)
+	})
+	counts := make([]IterationCount, len(result))
+	for i, r := range result {
+		counts[i] = *r
+	}
+	return counts
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+func TestTally(t *testing.T) {
+	issues := []*issue.Issue{
+		{Status: "ready"}, {Status: "in-progress"}, {Status: "ready"}, {Status: "legacy"},
+	}
+	got := Tally(issues, []string{"in-progress", "review", "ready"}, func(b *issue.Issue) string { return b.Status })
+	want := []Count{{"in-progress", 1}, {"ready", 2}, {"legacy", 1}}
+	if len(got) != len(want) {
+		t.Fatalf("Tally() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Tally()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestByIteration(t *testing.T) {
+	cfg := config.Default()
+	cfg.Iterations = []config.IterationConfig{
+		{Name: "sprint-1", Start: "2025-08-04", End: "2025-08-17"},
+		{Name: "sprint-2"},
+		{Name: "sprint-3"},
+	}
+	issues := []*issue.Issue{
+		{ID: "a", Iteration: "sprint-1", Status: "completed"},
+		{ID: "b", Iteration: "sprint-1", Status: "completed"},
+		{ID: "c", Iteration: "sprint-1", Status: "in-progress"},
+		{ID: "d", Iteration: "sprint-1", Status: "scrapped"},
+		{ID: "e", Iteration: "sprint-2", Status: "ready"},
+		{ID: "f", Iteration: "2025-W40", Status: "completed"},
+		{ID: "g", Iteration: "2025-W36", Status: "ready"},
+		{ID: "h", Status: "completed"},
+	}
+
+	got := ByIteration(issues, cfg, "sprint-2")
+	want := []IterationCount{
+		{Iteration: "sprint-1", Start: "2025-08-04", End: "2025-08-17", Committed: 3, Completed: 2},
+		{Iteration: "sprint-2", Current: true, Committed: 1},
+		{Iteration: "sprint-3"},
+		{Iteration: "2025-W36", Committed: 1},
+		{Iteration: "2025-W40", Committed: 1, Completed: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ByIteration() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ByIteration()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,365 @@
+// Package testfixtures generates deterministic, realistic issue graphs for
+// tests, golden files, and demos. Everything is written through the normal
+// core.Create and core.CreateMilestone paths, so the files on disk are the
+// same as ones made by the CLI.
+package testfixtures
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// Weight pairs a value with its relative frequency.
+type Weight struct {
+	Value  string
+	Weight int
+}
+
+// Options controls the generated graph. Zero fields fall back to the values
+// in DefaultOptions.
+type Options struct {
+	// Count is the number of issues to create (milestones not included).
+	Count int
+	// Seed makes the output reproducible: the same seed and options always
+	// produce the same IDs, fields, and timestamps.
+	Seed uint64
+	// Milestones to create; issues are spread across them.
+	Milestones int
+
+	Types      []Weight
+	Statuses   []Weight // statuses not enabled in the project config are skipped
+	Priorities []Weight // "" leaves the priority unset
+	Tags       []string
+
+	// ParentRatio, BlockedRatio, and DueRatio are the chances (0-1) that an
+	// issue gets a parent, a blocker, or a due date. Use a negative value to
+	// turn one off.
+	ParentRatio  float64
+	BlockedRatio float64
+	DueRatio     float64
+
+	// Start is the creation time of the first item; each later one is a few
+	// minutes after the previous.
+	Start time.Time
+}
+
+// DefaultOptions returns a mix that resembles a mid-sized project.
+func DefaultOptions() Options {
+	return Options{
+		Count: 50,
+		Seed:  1,
+		Types: []Weight{
+			{config.TypeEpic, 8},
+			{config.TypeFeature, 22},
+			{config.TypeTask, 45},
+			{config.TypeBug, 25},
+		},
+		Statuses: []Weight{
+			{config.StatusReady, 35},
+			{config.StatusInProgress, 10},
+			{config.StatusReview, 5},
+			{config.StatusDraft, 15},
+			{config.StatusDeferred, 5},
+			{config.StatusCompleted, 25},
+			{config.StatusScrapped, 5},
+		},
+		Priorities: []Weight{
+			{"", 50},
+			{config.PriorityCritical, 4},
+			{config.PriorityHigh, 16},
+			{config.PriorityNormal, 12},
+			{config.PriorityLow, 12},
+			{config.PriorityDeferred, 6},
+		},
+		Tags:         []string{"backend", "frontend", "docs", "perf", "security", "ux"},
+		ParentRatio:  0.75,
+		BlockedRatio: 0.15,
+		DueRatio:     0.2,
+		Start:        time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+// Result lists what Generate created, in creation order.
+type Result struct {
+	Milestones []*issue.Milestone
+	Issues     []*issue.Issue
+}
+
+// Generate populates c with a milestone → epic → feature → task/bug graph.
+// Parents are always of a type the project config allows, and blockers are
+// always created earlier than the issues they block, so the graph has no
+// cycles. While it runs, Generate drives c's clock and ID generator from the
+// seed; both are reset to their defaults when it returns.
+func Generate(c *core.Core, opts Options) (*Result, error) {
+	opts = withDefaults(opts)
+	if opts.Count < 0 {
+		return nil, errors.New("count cannot be negative")
+	}
+	cfg := c.Config()
+	statuses := slices.DeleteFunc(slices.Clone(opts.Statuses), func(w Weight) bool {
+		return !cfg.IsStatusEnabled(w.Value)
+	})
+	if len(statuses) == 0 {
+		return nil, errors.New("none of the requested statuses are enabled")
+	}
+
+	g := &generator{
+		rng:      rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15)),
+		opts:     opts,
+		cfg:      cfg,
+		statuses: statuses,
+		now:      opts.Start,
+	}
+	c.SetClock(func() time.Time { return g.now })
+	c.SetIDGenerator(g.id)
+	defer func() {
+		c.SetClock(nil)
+		c.SetIDGenerator(nil)
+	}()
+
+	res := &Result{}
+	for i := range opts.Milestones {
+		m := &issue.Milestone{
+			ID:          g.id(),
+			Short:       fmt.Sprintf("M%d", i+1),
+			Name:        fmt.Sprintf("Release %d", i+1),
+			Due:         issue.NewDueDate(opts.Start.AddDate(0, 0, 30*(i+1))),
+			Description: g.paragraph(),
+		}
+		if err := c.CreateMilestone(m); err != nil {
+			return res, fmt.Errorf("creating milestone %d: %w", i+1, err)
+		}
+		res.Milestones = append(res.Milestones, m)
+		g.tick()
+	}
+
+	for range opts.Count {
+		b := g.issue(res)
+		if err := c.Create(b); err != nil {
+			return res, fmt.Errorf("creating issue %q: %w", b.Title, err)
+		}
+		res.Issues = append(res.Issues, b)
+		g.tick()
+	}
+	return res, nil
+}
+
+func withDefaults(opts Options) Options {
+	def := DefaultOptions()
+	if opts.Count == 0 {
+		opts.Count = def.Count
+	}
+	if opts.Milestones == 0 {
+		opts.Milestones = max(1, opts.Count/25)
+	}
+	if opts.Types == nil {
+		opts.Types = def.Types
+	}
+	if opts.Statuses == nil {
+		opts.Statuses = def.Statuses
+	}
+	if opts.Priorities == nil {
+		opts.Priorities = def.Priorities
+	}
+	if opts.Tags == nil {
+		opts.Tags = def.Tags
+	}
+	if opts.ParentRatio == 0 {
+		opts.ParentRatio = def.ParentRatio
+	}
+	if opts.BlockedRatio == 0 {
+		opts.BlockedRatio = def.BlockedRatio
+	}
+	if opts.DueRatio == 0 {
+		opts.DueRatio = def.DueRatio
+	}
+	if opts.Start.IsZero() {
+		opts.Start = def.Start
+	}
+	return opts
+}
+
+type generator struct {
+	rng      *rand.Rand
+	opts     Options
+	cfg      *config.Config
+	statuses []Weight // opts.Statuses limited to enabled ones
+	now      time.Time
+}
+
+// id returns a seeded ID in the config's id_format, the same shape as the
+// IDs the core generates.
+func (g *generator) id() string {
+	f := g.cfg.GetIDFormat()
+	var b strings.Builder
+	b.WriteString(f.Prefix)
+	for i := range f.Groups * f.GroupLength {
+		if i > 0 && i%f.GroupLength == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(f.Alphabet[g.rng.IntN(len(f.Alphabet))])
+	}
+	return b.String()
+}
+
+// tick advances the clock so created_at values are distinct and ordered.
+func (g *generator) tick() {
+	g.now = g.now.Add(time.Duration(5+g.rng.IntN(240)) * time.Minute)
+}
+
+func (g *generator) pick(weights []Weight) string {
+	total := 0
+	for _, w := range weights {
+		total += w.Weight
+	}
+	if total <= 0 {
+		return ""
+	}
+	n := g.rng.IntN(total)
+	for _, w := range weights {
+		if n < w.Weight {
+			return w.Value
+		}
+		n -= w.Weight
+	}
+	return weights[len(weights)-1].Value
+}
+
+func (g *generator) chance(p float64) bool {
+	return g.rng.Float64() < p
+}
+
+func (g *generator) issue(res *Result) *issue.Issue {
+	typ := g.pick(g.opts.Types)
+	title := g.title(typ)
+	b := &issue.Issue{
+		Title:    title,
+		Slug:     issue.Slugify(title),
+		Type:     typ,
+		Status:   g.pick(g.statuses),
+		Priority: g.pick(g.opts.Priorities),
+		Body:     g.body(typ),
+	}
+
+	for _, tag := range g.opts.Tags {
+		if g.chance(0.2) {
+			b.Tags = append(b.Tags, tag)
+		}
+	}
+
+	if parent := g.parent(typ, res.Issues); parent != nil {
+		b.Parent = parent.ID
+		b.Milestone = parent.Milestone
+	} else if len(res.Milestones) > 0 && g.chance(g.opts.ParentRatio) {
+		b.Milestone = res.Milestones[g.rng.IntN(len(res.Milestones))].ID
+	}
+
+	// Only earlier, non-container issues can block, which keeps chains acyclic.
+	if g.chance(g.opts.BlockedRatio) {
+		var blockers []*issue.Issue
+		for _, other := range res.Issues {
+			if other.Type != config.TypeEpic && other.ID != b.Parent {
+				blockers = append(blockers, other)
+			}
+		}
+		if len(blockers) > 0 {
+			b.BlockedBy = []string{blockers[g.rng.IntN(len(blockers))].ID}
+		}
+	}
+
+	if g.chance(g.opts.DueRatio) {
+		b.Due = issue.NewDueDate(g.now.AddDate(0, 0, 3+g.rng.IntN(60)))
+	}
+	return b
+}
+
+// parent picks an earlier issue whose type the config allows as a parent of
+// typ, or nil.
+func (g *generator) parent(typ string, earlier []*issue.Issue) *issue.Issue {
+	if !g.chance(g.opts.ParentRatio) {
+		return nil
+	}
+	allowed := g.cfg.ValidParentTypes(typ)
+	var candidates []*issue.Issue
+	for _, b := range earlier {
+		if slices.Contains(allowed, b.Type) {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[g.rng.IntN(len(candidates))]
+}
+
+var (
+	verbs    = []string{"Add", "Support", "Refactor", "Document", "Speed up", "Simplify", "Remove", "Migrate"}
+	bugVerbs = []string{"Fix", "Handle", "Prevent", "Guard against"}
+	subjects = []string{
+		"login flow", "search results", "settings page", "export to CSV", "sync retries",
+		"webhook delivery", "config loading", "error messages", "onboarding", "audit log",
+		"rate limiting", "keyboard shortcuts", "dark mode", "billing report", "API pagination",
+	}
+	bugSubjects = []string{
+		"crash on empty input", "stale cache after update", "timezone drift in reports",
+		"duplicate notifications", "race in file watcher", "broken link in footer",
+		"slow startup on large repos", "lost edits on reload",
+	}
+	lorem = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod
+		tempor incididunt ut labore et dolore magna aliqua ut enim ad minim veniam quis nostrud
+		exercitation ullamco laboris nisi aliquip ex ea commodo consequat duis aute irure in
+		reprehenderit voluptate velit esse cillum fugiat nulla pariatur excepteur sint occaecat`)
+)
+
+func (g *generator) title(typ string) string {
+	switch typ {
+	case config.TypeBug:
+		return bugVerbs[g.rng.IntN(len(bugVerbs))] + " " + bugSubjects[g.rng.IntN(len(bugSubjects))]
+	case config.TypeEpic:
+		return capitalize(subjects[g.rng.IntN(len(subjects))]) + " overhaul"
+	}
+	return verbs[g.rng.IntN(len(verbs))] + " " + subjects[g.rng.IntN(len(subjects))]
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func (g *generator) paragraph() string {
+	words := make([]string, 12+g.rng.IntN(24))
+	for i := range words {
+		words[i] = lorem[g.rng.IntN(len(lorem))]
+	}
+	return capitalize(strings.Join(words, " ")) + "."
+}
+
+func (g *generator) body(typ string) string {
+	parts := []string{g.paragraph()}
+	if g.chance(0.5) {
+		parts = append(parts, g.paragraph())
+	}
+	if typ != config.TypeEpic && g.chance(0.6) {
+		var list strings.Builder
+		list.WriteString("## Tasks\n")
+		for i := range 2 + g.rng.IntN(3) {
+			mark := " "
+			if g.chance(0.4) {
+				mark = "x"
+			}
+			fmt.Fprintf(&list, "\n- [%s] Step %d: %s", mark, i+1, lorem[g.rng.IntN(len(lorem))])
+		}
+		parts = append(parts, list.String())
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,159 @@
+package testfixtures
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+)
+
+func newCore(t *testing.T) (*core.Core, string) {
+	t.Helper()
+	dataDir := filepath.Join(t.TempDir(), core.DataDir)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	cfg.ExtraStatuses = map[string]bool{config.StatusInProgress: true, config.StatusDraft: true, config.StatusScrapped: true}
+	c := core.New(dataDir, cfg)
+	c.SetWarnWriter(nil)
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return c, dataDir
+}
+
+func generate(t *testing.T, seed uint64) (*Result, string) {
+	t.Helper()
+	c, dataDir := newCore(t)
+	opts := DefaultOptions()
+	opts.Count = 80
+	opts.Seed = seed
+	res, err := Generate(c, opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return res, dataDir
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	a, dirA := generate(t, 42)
+	b, dirB := generate(t, 42)
+
+	if len(a.Issues) != 80 || len(b.Issues) != 80 {
+		t.Fatalf("got %d and %d issues, want 80", len(a.Issues), len(b.Issues))
+	}
+	for i := range a.Issues {
+		// Rendered files cover every persisted field, timestamps included.
+		fileA, err := os.ReadFile(filepath.Join(dirA, a.Issues[i].Path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileB, err := os.ReadFile(filepath.Join(dirB, b.Issues[i].Path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Issues[i].Path != b.Issues[i].Path || string(fileA) != string(fileB) {
+			t.Fatalf("issue %d differs between runs:\n%s\n%s", i, fileA, fileB)
+		}
+	}
+	for i := range a.Milestones {
+		if a.Milestones[i].ID != b.Milestones[i].ID {
+			t.Errorf("milestone %d ID %q != %q", i, a.Milestones[i].ID, b.Milestones[i].ID)
+		}
+	}
+
+	c, _ := generate(t, 43)
+	if c.Issues[0].ID == a.Issues[0].ID {
+		t.Error("different seeds should give different IDs")
+	}
+}
+
+func TestGenerateValidGraph(t *testing.T) {
+	c, dataDir := newCore(t)
+	opts := DefaultOptions()
+	opts.Count = 120
+	opts.Seed = 7
+	res, err := Generate(c, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reload from disk so the checks see exactly what was written.
+	reloaded := core.New(dataDir, c.Config())
+	reloaded.SetWarnWriter(nil)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reloaded.All()); got != len(res.Issues) {
+		t.Fatalf("reloaded %d issues, want %d", got, len(res.Issues))
+	}
+
+	links := reloaded.CheckAllLinks()
+	if links.HasIssues() {
+		t.Errorf("link problems: broken=%v self=%v cycles=%v", links.BrokenLinks, links.SelfLinks, links.Cycles)
+	}
+
+	milestones := map[string]bool{}
+	for _, m := range res.Milestones {
+		milestones[m.ID] = true
+	}
+	var parents, blocked, due int
+	cfg := reloaded.Config()
+	for _, b := range reloaded.All() {
+		if !cfg.IsValidType(b.Type) {
+			t.Errorf("%s has invalid type %q", b.ID, b.Type)
+		}
+		if !cfg.IsStatusEnabled(b.Status) {
+			t.Errorf("%s has disabled status %q", b.ID, b.Status)
+		}
+		if b.Milestone != "" && !milestones[b.Milestone] {
+			t.Errorf("%s has unknown milestone %q", b.ID, b.Milestone)
+		}
+		if b.Parent != "" {
+			parents++
+			p, err := reloaded.Get(b.Parent)
+			if err != nil {
+				t.Fatalf("%s parent: %v", b.ID, err)
+			}
+			if !slices.Contains(cfg.ValidParentTypes(b.Type), p.Type) {
+				t.Errorf("%s (%s) has %s parent %s", b.ID, b.Type, p.Type, p.ID)
+			}
+		}
+		if len(b.BlockedBy) > 0 {
+			blocked++
+		}
+		if b.Due != nil {
+			due++
+		}
+	}
+	if parents == 0 || blocked == 0 || due == 0 {
+		t.Errorf("expected some parents, blockers, and due dates; got %d, %d, %d", parents, blocked, due)
+	}
+}
+
+func TestGenerateSkipsDisabledStatuses(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), core.DataDir)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	c := core.New(dataDir, config.Default())
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	res, err := Generate(c, Options{Count: 30, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range res.Issues {
+		if b.Status != config.StatusReady && b.Status != config.StatusCompleted {
+			t.Errorf("%s has status %q, which is not enabled by default", b.ID, b.Status)
+		}
+	}
+	if _, err := Generate(c, Options{Count: 1, Statuses: []Weight{{config.StatusDraft, 1}}}); err == nil {
+		t.Error("expected error when no requested status is enabled")
+	}
+}
@@ -3,9 +3,13 @@ package tui
 import (
 	"context"
 	"errors"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/toba/jig/internal/todo/config"
@@ -13,6 +17,8 @@ import (
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/launch"
+	"github.com/toba/jig/internal/todo/ui"
 
 	"github.com/toba/jig/internal/todo/core"
 )
@@ -60,7 +66,7 @@ func newTestAppWithIssues(t *testing.T) (*App, *core.Core) {
 
 	// Create some issues
 	issues := []*issue.Issue{
-		{ID: "abc-123", Title: "First issue", Status: "todo", Type: "task", Tags: []string{"frontend"}},
+		{ID: "abc-123", Title: "First issue", Status: "ready", Type: "task", Tags: []string{"frontend"}},
 		{ID: "def-456", Title: "Second issue", Status: "in-progress", Type: "bug"},
 		{ID: "ghi-789", Title: "Third issue", Status: "completed", Type: "feature"},
 	}
@@ -276,6 +282,47 @@ func TestAppKeyChordGT(t *testing.T) {
 	}
 }
 
+func TestAppKeyChordGIFiltersByIteration(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.state = viewList
+	b, _ := c.Get("abc-123")
+	b.Iteration = "2025-W34"
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	updatedModel, _ := app.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	updatedModel, cmd := updatedModel.(*App).Update(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	if cmd == nil {
+		t.Fatal("g i chord should produce a command")
+	}
+	open, ok := cmd().(openIterationPickerMsg)
+	if !ok {
+		t.Fatal("g i chord should open the iteration picker")
+	}
+	updatedModel, _ = updatedModel.(*App).Update(open)
+	updated := updatedModel.(*App)
+	if updated.state != viewIterationPicker {
+		t.Fatalf("state = %d, want viewIterationPicker (%d)", updated.state, viewIterationPicker)
+	}
+	if n := len(updated.iterationPicker.list.Items()); n != 2 {
+		t.Errorf("picker has %d items, want (none) and 2025-W34", n)
+	}
+
+	updatedModel, cmd = updated.Update(iterationSelectedMsg{iteration: "2025-W34"})
+	updated = updatedModel.(*App)
+	if updated.state != viewList || updated.list.iterationFilter != "2025-W34" {
+		t.Errorf("state = %d, iterationFilter = %q; want the list filtered by 2025-W34", updated.state, updated.list.iterationFilter)
+	}
+	loaded, ok := cmd().(issuesLoadedMsg)
+	if !ok {
+		t.Fatal("iterationSelectedMsg should reload the list")
+	}
+	if len(loaded.items) != 1 || loaded.items[0].Issue.ID != "abc-123" {
+		t.Errorf("filtered list = %d items, want only abc-123", len(loaded.items))
+	}
+}
+
 func TestAppKeyChordInvalidSecond(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewList
@@ -344,7 +391,7 @@ func TestAppSelectIssueMsg(t *testing.T) {
 	testIssue := &issue.Issue{
 		ID:     "test-1",
 		Title:  "Test Issue",
-		Status: "todo",
+		Status: "ready",
 		Type:   "task",
 	}
 
@@ -364,11 +411,11 @@ func TestAppSelectIssueMsgPushesHistory(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "first", Title: "First", Status: "todo", Type: "task",
+		ID: "first", Title: "First", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	secondIssue := &issue.Issue{
-		ID: "second", Title: "Second", Status: "todo", Type: "task",
+		ID: "second", Title: "Second", Status: "ready", Type: "task",
 	}
 	msg := selectIssueMsg{issue: secondIssue}
 	updatedModel, _ := app.Update(msg)
@@ -387,14 +434,17 @@ func TestAppSelectIssueMsgPushesHistory(t *testing.T) {
 
 func TestAppBackToListMsgPopsHistory(t *testing.T) {
 	app := newTestApp(t)
+	prev := &issue.Issue{ID: "prev", Title: "Previous", Status: "ready", Type: "task"}
+	// The popped issue is looked up again, so it has to exist
+	if err := app.core.Create(prev); err != nil {
+		t.Fatal(err)
+	}
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "current", Title: "Current", Status: "todo", Type: "task",
+		ID: "current", Title: "Current", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 	app.history = []detailModel{
-		newDetailModel(&issue.Issue{
-			ID: "prev", Title: "Previous", Status: "todo", Type: "task",
-		}, app.resolver, app.config, 80, 24),
+		newDetailModel(prev, app.resolver, app.config, 80, 24),
 	}
 
 	msg := backToListMsg{}
@@ -417,7 +467,7 @@ func TestAppBackToListMsgGoesToList(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "current", Title: "Current", Status: "todo", Type: "task",
+		ID: "current", Title: "Current", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 	// No history
 
@@ -615,6 +665,7 @@ func TestAppCloseCreateModalMsg(t *testing.T) {
 }
 
 func TestAppIssueCreatedMsg(t *testing.T) {
+	t.Setenv(graph.ActorEnv, "tui-tester")
 	app := newTestApp(t)
 	app.previousState = viewList
 	app.state = viewCreateModal
@@ -629,6 +680,10 @@ func TestAppIssueCreatedMsg(t *testing.T) {
 	if cmd == nil {
 		t.Error("issueCreatedMsg should produce commands")
 	}
+	issues := app.core.All()
+	if len(issues) != 1 || issues[0].CreatedVia != issue.CreatedViaTUI || issues[0].CreatedBy != "tui-tester" {
+		t.Errorf("created %+v, want one issue created by tui-tester via tui", issues)
+	}
 }
 
 func TestAppParentSelectedMsg(t *testing.T) {
@@ -636,7 +691,7 @@ func TestAppParentSelectedMsg(t *testing.T) {
 	app.previousState = viewList
 
 	// Create an epic for parent
-	epic := &issue.Issue{ID: "epic-1", Title: "Epic", Status: "todo", Type: "epic"}
+	epic := &issue.Issue{ID: "epic-1", Title: "Epic", Status: "ready", Type: "epic"}
 	c.Create(epic)
 
 	msg := parentSelectedMsg{
@@ -652,6 +707,303 @@ func TestAppParentSelectedMsg(t *testing.T) {
 	if cmd == nil {
 		t.Error("parentSelectedMsg should produce a loadIssues command")
 	}
+
+	// The picker goes through moveIssue, which records the move.
+	b, _ := c.Get("abc-123")
+	if b.Parent != "epic-1" || !strings.Contains(b.Body, "moved from top level to epic-1") {
+		t.Errorf("parent = %q, body = %q; want epic-1 with a history note", b.Parent, b.Body)
+	}
+}
+
+func TestAppParentPickerCreatesParent(t *testing.T) {
+	press := func(t *testing.T, app *App, msg tea.KeyPressMsg) *App {
+		t.Helper()
+		m, cmd := app.Update(msg)
+		app = m.(*App)
+		// Feed the picker's reply to enter back through the app. Other keys
+		// only schedule cursor blinks, which would stall the test.
+		if cmd != nil && msg.Code == tea.KeyEnter {
+			if out := cmd(); out != nil {
+				switch out.(type) {
+				case parentCreateRequestedMsg, parentSelectedMsg, closeParentPickerMsg:
+					m, _ = app.Update(out)
+					app = m.(*App)
+				}
+			}
+		}
+		return app
+	}
+	enter := tea.KeyPressMsg{Code: tea.KeyEnter}
+	open := func(t *testing.T) (*App, *core.Core) {
+		t.Helper()
+		app, c := newTestAppWithIssues(t)
+		app.state = viewList
+		m, _ := app.Update(openParentPickerMsg{
+			issueIDs:   []string{"abc-123"},
+			issueTitle: "First issue",
+			issueTypes: []string{"task"},
+		})
+		app = m.(*App)
+		// The create entry sits above "No Parent", which is selected.
+		app.parentPicker.list.Select(0)
+		return app, c
+	}
+
+	t.Run("create entry offers the allowed parent type", func(t *testing.T) {
+		app, _ := open(t)
+		item, ok := app.parentPicker.list.SelectedItem().(createParentItem)
+		if !ok || item.typeName != config.TypeEpic {
+			t.Fatalf("first item = %#v, want create entry for epic", app.parentPicker.list.SelectedItem())
+		}
+	})
+
+	t.Run("new epic becomes the parent", func(t *testing.T) {
+		app, c := open(t)
+		app = press(t, app, enter)
+		if !app.parentPicker.creating {
+			t.Fatal("enter on the create entry should open the title input")
+		}
+		// q is typed into the title, not treated as quit.
+		for _, r := range "Fix quirks" {
+			app = press(t, app, tea.KeyPressMsg{Code: r, Text: string(r)})
+		}
+		app = press(t, app, enter)
+
+		if app.state != viewList {
+			t.Fatalf("state = %d, want viewList (%d)", app.state, viewList)
+		}
+		child, _ := c.Get("abc-123")
+		if child.Parent == "" {
+			t.Fatal("child has no parent after inline create")
+		}
+		epic, err := c.Get(child.Parent)
+		if err != nil {
+			t.Fatalf("parent %q not in core: %v", child.Parent, err)
+		}
+		if epic.Title != "Fix quirks" || epic.Type != config.TypeEpic {
+			t.Errorf("parent = %q (%s), want epic titled %q", epic.Title, epic.Type, "Fix quirks")
+		}
+		if !strings.Contains(app.list.statusMessage, epic.ID) {
+			t.Errorf("status message = %q, want it to name %s", app.list.statusMessage, epic.ID)
+		}
+	})
+
+	t.Run("empty title stays inline", func(t *testing.T) {
+		app, c := open(t)
+		before := len(c.All())
+		app = press(t, app, enter)
+		app = press(t, app, enter)
+
+		if app.state != viewParentPicker || !app.parentPicker.creating {
+			t.Fatalf("state = %d creating = %v, want the title input still open", app.state, app.parentPicker.creating)
+		}
+		if app.parentPicker.errText == "" {
+			t.Error("expected an inline error for the empty title")
+		}
+		if len(c.All()) != before {
+			t.Error("no issue should be created for an empty title")
+		}
+
+		app = press(t, app, tea.KeyPressMsg{Code: tea.KeyEscape})
+		if app.parentPicker.creating || app.state != viewParentPicker {
+			t.Error("esc should return to the parent list")
+		}
+	})
+
+	t.Run("no create entry for types without parents", func(t *testing.T) {
+		app, _ := newTestAppWithIssues(t)
+		m, _ := app.Update(openParentPickerMsg{
+			issueIDs:   []string{"epic-x"},
+			issueTitle: "An epic",
+			issueTypes: []string{config.TypeEpic},
+		})
+		app = m.(*App)
+		for _, item := range app.parentPicker.list.Items() {
+			if _, ok := item.(createParentItem); ok {
+				t.Fatal("epics cannot have parents, so no create entry should be offered")
+			}
+		}
+	})
+}
+
+func TestAppBatchMixedSelection(t *testing.T) {
+	selectAll := func(app *App, ids ...string) {
+		for _, id := range ids {
+			app.list.selectedIssues[id] = true
+		}
+	}
+	assertSelected := func(t *testing.T, app *App, want ...string) {
+		t.Helper()
+		if len(app.list.selectedIssues) != len(want) {
+			t.Errorf("selectedIssues = %v, want %v", app.list.selectedIssues, want)
+		}
+		for _, id := range want {
+			if !app.list.selectedIssues[id] {
+				t.Errorf("%s should stay selected", id)
+			}
+		}
+	}
+
+	t.Run("parent skips milestones", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.previousState = viewList
+		c.Create(&issue.Issue{ID: "epic-1", Title: "Epic", Status: "ready", Type: "epic"})
+		c.Create(&issue.Issue{ID: "ms-1", Title: "Milestone", Status: "ready", Type: "milestone"})
+		selectAll(app, "abc-123", "ghi-789", "ms-1")
+
+		updatedModel, _ := app.Update(parentSelectedMsg{issueIDs: []string{"abc-123", "ghi-789", "ms-1"}, parentID: "epic-1"})
+		updated := updatedModel.(*App)
+
+		want := "Set parent on 2 issues, skipped 1 (milestones cannot have parents)"
+		if updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+		for _, id := range []string{"abc-123", "ghi-789"} {
+			if b, _ := c.Get(id); b.Parent != "epic-1" {
+				t.Errorf("%s parent = %q, want epic-1", id, b.Parent)
+			}
+		}
+		if b, _ := c.Get("ms-1"); b.Parent != "" {
+			t.Errorf("milestone parent = %q, want empty", b.Parent)
+		}
+		assertSelected(t, updated, "ms-1")
+	})
+
+	t.Run("status skips issues with incomplete children", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.previousState = viewList
+		c.Create(&issue.Issue{ID: "epic-1", Title: "Epic", Status: "ready", Type: "epic"})
+		c.Create(&issue.Issue{ID: "child-1", Title: "Child", Status: "ready", Type: "task", Parent: "epic-1"})
+		selectAll(app, "epic-1", "abc-123")
+
+		updatedModel, _ := app.Update(statusSelectedMsg{issueIDs: []string{"epic-1", "abc-123"}, status: "completed"})
+		updated := updatedModel.(*App)
+
+		want := "Set status on 1 issue, skipped 1 (children not complete)"
+		if updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+		if b, _ := c.Get("abc-123"); b.Status != "completed" {
+			t.Errorf("abc-123 status = %q, want completed", b.Status)
+		}
+		if b, _ := c.Get("epic-1"); b.Status != "ready" {
+			t.Errorf("epic-1 status = %q, want ready", b.Status)
+		}
+		assertSelected(t, updated, "epic-1")
+	})
+
+	t.Run("type skips changes that break the hierarchy", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.previousState = viewList
+		// ghi-789 is a feature; a task child cannot have a task parent.
+		c.Create(&issue.Issue{ID: "child-1", Title: "Child", Status: "ready", Type: "task", Parent: "ghi-789"})
+		selectAll(app, "ghi-789", "def-456")
+
+		updatedModel, _ := app.Update(typeSelectedMsg{issueIDs: []string{"ghi-789", "def-456"}, issueType: "task"})
+		updated := updatedModel.(*App)
+
+		want := "Set type on 1 issue, skipped 1 (task children cannot have a task parent)"
+		if updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+		if b, _ := c.Get("def-456"); b.Type != "task" {
+			t.Errorf("def-456 type = %q, want task", b.Type)
+		}
+		if b, _ := c.Get("ghi-789"); b.Type != "feature" {
+			t.Errorf("ghi-789 type = %q, want feature", b.Type)
+		}
+		assertSelected(t, updated, "ghi-789")
+	})
+
+	t.Run("type to milestone skips issues with a parent", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.previousState = viewList
+		c.Create(&issue.Issue{ID: "child-1", Title: "Child", Status: "ready", Type: "task", Parent: "ghi-789"})
+
+		updatedModel, _ := app.Update(typeSelectedMsg{issueIDs: []string{"child-1"}, issueType: "milestone"})
+		updated := updatedModel.(*App)
+
+		want := "Could not set type: milestones cannot have parents"
+		if updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+	})
+
+	t.Run("all valid clears selection", func(t *testing.T) {
+		app, _ := newTestAppWithIssues(t)
+		app.previousState = viewList
+		selectAll(app, "abc-123", "def-456")
+
+		updatedModel, _ := app.Update(statusSelectedMsg{issueIDs: []string{"abc-123", "def-456"}, status: "ready"})
+		updated := updatedModel.(*App)
+
+		if want := "Set status on 2 issues"; updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+		assertSelected(t, updated)
+	})
+
+	t.Run("archived issues are skipped", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.previousState = viewList
+		if err := c.Archive("ghi-789"); err != nil {
+			t.Fatalf("Archive: %v", err)
+		}
+		selectAll(app, "abc-123", "ghi-789")
+
+		updatedModel, _ := app.Update(statusSelectedMsg{issueIDs: []string{"abc-123", "ghi-789"}, status: "draft"})
+		updated := updatedModel.(*App)
+
+		want := "Set status on 1 issue, skipped 1 (archived)"
+		if updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+		if b, _ := c.Get("ghi-789"); b.Status != "completed" {
+			t.Errorf("archived status = %q, want completed", b.Status)
+		}
+		assertSelected(t, updated, "ghi-789")
+	})
+
+	t.Run("editor refuses archived issues", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.state = viewList
+		if err := c.Archive("ghi-789"); err != nil {
+			t.Fatalf("Archive: %v", err)
+		}
+		b, _ := c.Get("ghi-789")
+
+		updatedModel, cmd := app.Update(openEditorMsg{issueID: b.ID, issuePath: b.Path})
+		updated := updatedModel.(*App)
+
+		if cmd != nil {
+			t.Error("editor should not launch for an archived issue")
+		}
+		if want := "Cannot edit ghi-789: archived"; updated.list.statusMessage != want {
+			t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+		}
+	})
+
+	t.Run("parent picker opens for mixed selection", func(t *testing.T) {
+		app, _ := newTestAppWithIssues(t)
+		app.state = viewList
+
+		updatedModel, _ := app.Update(openParentPickerMsg{
+			issueIDs:   []string{"abc-123", "ms-1"},
+			issueTitle: "2 selected issues",
+			issueTypes: []string{"task", "milestone"},
+		})
+		updated := updatedModel.(*App)
+
+		if updated.state != viewParentPicker {
+			t.Fatalf("state = %d, want viewParentPicker (%d)", updated.state, viewParentPicker)
+		}
+		if got := updated.parentPicker.issueTypes; len(got) != 1 || got[0] != "task" {
+			t.Errorf("picker issueTypes = %v, want [task] (milestones should not narrow eligible parents)", got)
+		}
+		if len(updated.parentPicker.issueIDs) != 2 {
+			t.Errorf("picker issueIDs = %v, want both selected issues", updated.parentPicker.issueIDs)
+		}
+	})
 }
 
 func TestAppCloseParentPickerMsg(t *testing.T) {
@@ -842,7 +1194,7 @@ func TestAppFinishBatchEditDetail(t *testing.T) {
 	app.previousState = viewDetail
 	app.state = viewStatusPicker
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "abc-123", Title: "First", Status: "todo", Type: "task",
+		ID: "abc-123", Title: "First", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 	app.list.selectedIssues["abc-123"] = true
 
@@ -885,7 +1237,7 @@ func TestAppForwardsToCurrentView(t *testing.T) {
 		app.state = state
 		if state == viewDetail {
 			app.detail = newDetailModel(&issue.Issue{
-				ID: "test", Title: "Test", Status: "todo", Type: "task",
+				ID: "test", Title: "Test", Status: "ready", Type: "task",
 			}, app.resolver, app.config, 80, 24)
 		}
 		updatedModel, _ := app.Update(sizeMsg)
@@ -1073,7 +1425,7 @@ func TestDetailModel(t *testing.T) {
 	resolver := &graph.Resolver{Core: c}
 
 	testIssue := &issue.Issue{
-		ID: "test-1", Title: "Test Issue", Status: "todo", Type: "task",
+		ID: "test-1", Title: "Test Issue", Status: "ready", Type: "task",
 		Body: "Some description here",
 	}
 
@@ -1228,7 +1580,7 @@ func TestDetailModel(t *testing.T) {
 
 	t.Run("renderBody with empty body", func(t *testing.T) {
 		emptyIssue := &issue.Issue{
-			ID: "empty-1", Title: "Empty", Status: "todo", Type: "task",
+			ID: "empty-1", Title: "Empty", Status: "ready", Type: "task",
 		}
 		em := newDetailModel(emptyIssue, resolver, cfg, 80, 24)
 		body := em.renderBody(76)
@@ -1330,6 +1682,9 @@ func TestAppOpenParentPickerMsgMilestone(t *testing.T) {
 	if cmd != nil {
 		t.Error("should not produce a command for milestones")
 	}
+	if want := "milestones cannot have parents"; updated.list.statusMessage != want {
+		t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+	}
 }
 
 // Test CopyIssueIDMsg handling
@@ -1374,7 +1729,7 @@ func TestAppIssuesChangedMsgInDetailNotRelevant(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "current-1", Title: "Current", Status: "todo", Type: "task",
+		ID: "current-1", Title: "Current", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	// Change is for a different issue
@@ -1394,7 +1749,7 @@ func TestAppOpenStatusPickerMsg(t *testing.T) {
 	msg := openStatusPickerMsg{
 		issueIDs:      []string{"test-1"},
 		issueTitle:    "Test",
-		currentStatus: "todo",
+		currentStatus: "ready",
 	}
 	updatedModel, _ := app.Update(msg)
 	updated := updatedModel.(*App)
@@ -1404,6 +1759,112 @@ func TestAppOpenStatusPickerMsg(t *testing.T) {
 	}
 }
 
+func TestStatusPickerValidationRules(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.state = viewList
+	c.Config().ValidationRules = []config.ValidationRule{{WhenStatus: "completed", Require: []string{"body"}}}
+	b, _ := c.Get("def-456")
+	b.Body = "Notes."
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	completedItem := func() (statusItem, int) {
+		for i, item := range app.statusPicker.list.Items() {
+			if si := item.(statusItem); si.name == "completed" {
+				return si, i
+			}
+		}
+		t.Fatal("picker offers no completed status")
+		return statusItem{}, 0
+	}
+
+	app.Update(openStatusPickerMsg{issueIDs: []string{"abc-123"}, issueTitle: "First issue", currentStatus: "ready"})
+	item, i := completedItem()
+	if item.reason != "missing body" || !item.blocked {
+		t.Fatalf("completed item = %+v, want blocked by missing body", item)
+	}
+	app.statusPicker.list.Select(i)
+	if !strings.Contains(app.statusPicker.View(), "missing body") {
+		t.Error("picker view does not show the reason")
+	}
+	if _, cmd := app.statusPicker.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Error("enter on a refused status should keep the picker open")
+	}
+
+	// A status only some issues refuse stays selectable.
+	app.state = viewList
+	app.Update(openStatusPickerMsg{issueIDs: []string{"abc-123", "def-456"}, issueTitle: "2 issues"})
+	item, _ = completedItem()
+	if item.reason != "1 of 2: missing body" || item.blocked {
+		t.Errorf("completed item = %+v, want a selectable partial refusal", item)
+	}
+	if item, _ := app.statusPicker.list.Items()[0].(statusItem); item.reason != "" {
+		t.Errorf("%s item reason = %q, want none", item.name, item.reason)
+	}
+}
+
+func TestStatusPickerRendersExtraStatus(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.state = viewList
+	c.Config().ExtraStatuses = map[string]bool{config.StatusReview: true}
+
+	app.Update(openStatusPickerMsg{issueIDs: []string{"abc-123"}, issueTitle: "First issue", currentStatus: "ready"})
+	view := app.statusPicker.View()
+	if !strings.Contains(view, config.StatusReview) {
+		t.Errorf("picker does not render the enabled review status:\n%s", view)
+	}
+	if strings.Contains(view, config.StatusDeferred) {
+		t.Errorf("picker renders deferred, which is not enabled:\n%s", view)
+	}
+	want := resolverOptionNames(app.resolver.StatusOptions(app.pickerIssues([]string{"abc-123"})))
+	var got []string
+	for _, item := range app.statusPicker.list.Items() {
+		got = append(got, item.(statusItem).name)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("picker statuses = %v, want the resolver's %v", got, want)
+	}
+}
+
+// resolverOptionNames lists the names of picker options, in order.
+func resolverOptionNames(opts []*model.PickerOption) []string {
+	names := make([]string, len(opts))
+	for i, opt := range opts {
+		names[i] = opt.Name
+	}
+	return names
+}
+
+func TestTypePickerShowsHierarchyRefusal(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.state = viewList
+	parent := &issue.Issue{ID: "fea-1", Title: "Feature", Status: "ready", Type: config.TypeFeature}
+	child := &issue.Issue{ID: "tas-1", Title: "Task", Status: "ready", Type: config.TypeTask, Parent: "fea-1"}
+	for _, b := range []*issue.Issue{parent, child} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app.Update(openTypePickerMsg{issueIDs: []string{"tas-1"}, issueTitle: "Task", currentType: config.TypeTask})
+	for i, item := range app.typePicker.list.Items() {
+		ti := item.(typeItem)
+		if ti.name != config.TypeEpic {
+			continue
+		}
+		if !ti.blocked || ti.reason != "epics cannot have a feature parent" {
+			t.Fatalf("epic item = %+v, want blocked by the feature parent", ti)
+		}
+		app.typePicker.list.Select(i)
+		if _, cmd := app.typePicker.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+			t.Error("enter on a refused type should keep the picker open")
+		}
+		return
+	}
+	t.Fatal("picker offers no epic type")
+}
+
 func TestAppOpenTypePickerMsg(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewList
@@ -1506,7 +1967,7 @@ func TestListModelViewWithTagFilter(t *testing.T) {
 // Test issueItem interface methods
 func TestIssueItemMethods(t *testing.T) {
 	item := issueItem{
-		issue: &issue.Issue{ID: "test-1", Title: "Test Title", Status: "todo"},
+		issue: &issue.Issue{ID: "test-1", Title: "Test Title", Status: "ready"},
 	}
 
 	if item.Title() != "Test Title" {
@@ -1543,7 +2004,7 @@ func TestAppBlockingConfirmedMsgInDetail(t *testing.T) {
 	app.previousState = viewDetail
 	app.state = viewBlockingPicker
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "abc-123", Title: "First", Status: "todo", Type: "task",
+		ID: "abc-123", Title: "First", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	msg := blockingConfirmedMsg{
@@ -1588,7 +2049,7 @@ func TestAppIssuesChangedMsgDetailRelevant(t *testing.T) {
 	app, _ := newTestAppWithIssues(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "abc-123", Title: "First", Status: "todo", Type: "task",
+		ID: "abc-123", Title: "First", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	msg := issuesChangedMsg{changedIDs: map[string]bool{"abc-123": true}}
@@ -1604,7 +2065,7 @@ func TestAppIssuesChangedMsgDetailDeletedIssue(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "deleted-id", Title: "Deleted", Status: "todo", Type: "task",
+		ID: "deleted-id", Title: "Deleted", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	msg := issuesChangedMsg{changedIDs: map[string]bool{"deleted-id": true}}
@@ -1617,6 +2078,139 @@ func TestAppIssuesChangedMsgDetailDeletedIssue(t *testing.T) {
 	}
 }
 
+// editExternally changes an issue through a second core on the same data
+// directory, as another process would, then reloads c the way the watcher
+// does before it sends issuesChangedMsg.
+func editExternally(t *testing.T, c *core.Core, id string, edit func(*issue.Issue)) {
+	t.Helper()
+	other := core.New(c.Root(), config.Default())
+	if err := other.Load(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := other.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit(b)
+	if err := other.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func blockedByLinks(m detailModel) map[string]string {
+	links := make(map[string]string)
+	for _, l := range m.links {
+		if l.linkType == issue.LinkTypeBlocking && l.incoming {
+			links[l.issue.ID] = l.issue.Status
+		}
+	}
+	return links
+}
+
+func TestAppDetailRefreshesBlockedBy(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	editExternally(t, c, "def-456", func(b *issue.Issue) { b.Blocking = []string{"abc-123"} })
+	current, _ := c.Get("abc-123")
+	app.state = viewDetail
+	app.detail = newDetailModel(current, app.resolver, app.config, 80, 24)
+	if got := blockedByLinks(app.detail); got["def-456"] != "in-progress" {
+		t.Fatalf("blocked by = %v, want def-456 in-progress", got)
+	}
+
+	// The blocker's status changes
+	editExternally(t, c, "def-456", func(b *issue.Issue) { b.Status = "completed" })
+	app.Update(issuesChangedMsg{changedIDs: map[string]bool{"def-456": true}})
+	if got := blockedByLinks(app.detail); got["def-456"] != "completed" {
+		t.Errorf("blocked by after status change = %v, want def-456 completed", got)
+	}
+
+	// An issue not shown yet starts blocking the viewed one
+	editExternally(t, c, "ghi-789", func(b *issue.Issue) { b.Blocking = []string{"abc-123"} })
+	app.Update(issuesChangedMsg{changedIDs: map[string]bool{"ghi-789": true}})
+	if got := blockedByLinks(app.detail); got["ghi-789"] == "" {
+		t.Errorf("blocked by after new blocker = %v, want ghi-789 listed", got)
+	}
+}
+
+func TestDetailAffectedByBodyOnly(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	editExternally(t, c, "def-456", func(b *issue.Issue) { b.Blocking = []string{"abc-123"} })
+	current, _ := c.Get("abc-123")
+	m := newDetailModel(current, app.resolver, app.config, 80, 24)
+	bodyOnly := func(id string) (map[string]bool, map[string]bool) {
+		return map[string]bool{id: true}, map[string]bool{id: true}
+	}
+
+	editExternally(t, c, "def-456", func(b *issue.Issue) { b.Body = "Reworded." })
+	if m.affectedBy(bodyOnly("def-456")) {
+		t.Error("a body edit to a linked issue refreshed the detail view")
+	}
+	if !m.affectedBy(map[string]bool{"def-456": true}, nil) {
+		t.Error("a metadata edit to a linked issue did not refresh the detail view")
+	}
+	editExternally(t, c, "abc-123", func(b *issue.Issue) { b.Body = "Reworded." })
+	if !m.affectedBy(bodyOnly("abc-123")) {
+		t.Error("a body edit to the viewed issue did not refresh the detail view")
+	}
+	editExternally(t, c, "ghi-789", func(b *issue.Issue) { b.Body = "Depends on abc-123." })
+	if !m.affectedBy(bodyOnly("ghi-789")) {
+		t.Error("a body edit that mentions the viewed issue did not refresh the detail view")
+	}
+}
+
+func TestAppDetailRefreshesOnBack(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	first, _ := c.Get("abc-123")
+	second, _ := c.Get("def-456")
+	app.Update(selectIssueMsg{issue: first})
+	app.Update(selectIssueMsg{issue: second})
+
+	editExternally(t, c, "ghi-789", func(b *issue.Issue) { b.Blocking = []string{"abc-123"} })
+	app.Update(backToListMsg{})
+	if app.state != viewDetail || app.detail.issue.ID != "abc-123" {
+		t.Fatalf("back went to %d %q, want the first detail view", app.state, app.detail.issue.ID)
+	}
+	if got := blockedByLinks(app.detail); got["ghi-789"] == "" {
+		t.Errorf("blocked by after back = %v, want the blocker added meanwhile", got)
+	}
+}
+
+func TestAppForceRefresh(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.state = viewList
+
+	// A new issue the watcher never reported
+	other := core.New(c.Root(), config.Default())
+	if err := other.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Create(&issue.Issue{ID: "jkl-012", Title: "Missed", Status: "ready", Type: "task"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cmd := app.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	if cmd == nil {
+		t.Fatal("R should produce a reload command")
+	}
+	msg := cmd()
+	if got, ok := msg.(refreshedMsg); !ok || got.count != 4 || got.err != nil {
+		t.Fatalf("reload message = %#v, want 4 issues", msg)
+	}
+	if _, err := c.Get("jkl-012"); err != nil {
+		t.Errorf("core after reload: %v", err)
+	}
+	_, cmd = app.Update(msg)
+	if cmd == nil {
+		t.Error("refreshedMsg should reload the list")
+	}
+	if app.list.statusMessage != "Refreshed 4 issues" {
+		t.Errorf("status = %q, want Refreshed 4 issues", app.list.statusMessage)
+	}
+}
+
 // Test formatLinkLabel
 func TestFormatLinkLabel(t *testing.T) {
 	cfg := config.Default()
@@ -1631,6 +2225,8 @@ func TestFormatLinkLabel(t *testing.T) {
 		{issue.LinkTypeBlocking, true, "Blocked by"},
 		{issue.LinkTypeParent, false, "Parent"},
 		{issue.LinkTypeParent, true, "Child"},
+		{issue.LinkTypeMention, false, "Mentions"},
+		{issue.LinkTypeMention, true, "Mentioned by"},
 		{"custom", false, "custom"},
 		{"custom", true, "custom (incoming)"},
 	}
@@ -1643,6 +2239,38 @@ func TestFormatLinkLabel(t *testing.T) {
 	}
 }
 
+func TestDetailMentionLinks(t *testing.T) {
+	tmpDir := t.TempDir()
+	dataDir := filepath.Join(tmpDir, ".issues")
+	os.MkdirAll(dataDir, 0755)
+	cfg := config.Default()
+	c := core.New(dataDir, cfg)
+	c.Load()
+
+	c.Create(&issue.Issue{ID: "aaa-111", Slug: "target", Title: "Target", Status: "ready", Type: "task"})
+	c.Create(&issue.Issue{ID: "bbb-222", Slug: "blocker", Title: "Blocker", Status: "ready", Type: "task"})
+	c.Create(&issue.Issue{ID: "ccc-333", Slug: "source", Title: "Source", Status: "ready", Type: "task",
+		BlockedBy: []string{"bbb-222"}, Body: "After bbb-222, see aaa-111.\n\n```\nddd-444\n```"})
+	c.Create(&issue.Issue{ID: "ddd-444", Slug: "other", Title: "Other", Status: "ready", Type: "task", Body: "Relates to ccc-333"})
+
+	resolver := &graph.Resolver{Core: c}
+	src, _ := c.Get("ccc-333")
+	m := newDetailModel(src, resolver, cfg, 80, 24)
+
+	got := map[string]string{}
+	for _, l := range m.links {
+		got[l.issue.ID] = m.formatLinkLabel(l.linkType, l.incoming)
+	}
+	want := map[string]string{
+		"aaa-111": "Mentions",
+		"bbb-222": "Blocked by", // the real link wins over the mention
+		"ddd-444": "Mentioned by",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("links = %v, want %v", got, want)
+	}
+}
+
 // Test refreshIssue
 func TestDetailRefreshIssue(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -1655,7 +2283,7 @@ func TestDetailRefreshIssue(t *testing.T) {
 	resolver := &graph.Resolver{Core: c}
 
 	testIssue := &issue.Issue{
-		ID: "test-1", Title: "Original", Status: "todo", Type: "task",
+		ID: "test-1", Title: "Original", Status: "ready", Type: "task",
 	}
 	m := newDetailModel(testIssue, resolver, cfg, 80, 24)
 
@@ -1741,7 +2369,7 @@ func TestDetailCalculateHeaderHeight(t *testing.T) {
 
 	t.Run("no links", func(t *testing.T) {
 		m := newDetailModel(&issue.Issue{
-			ID: "test-1", Title: "Test", Status: "todo", Type: "task",
+			ID: "test-1", Title: "Test", Status: "ready", Type: "task",
 		}, resolver, cfg, 80, 24)
 		h := m.calculateHeaderHeight()
 		if h < 6 {
@@ -1826,7 +2454,7 @@ func TestAppTickMsgInDetail(t *testing.T) {
 	app, _ := newTestAppWithIssues(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "abc-123", Title: "First", Status: "todo", Type: "task",
+		ID: "abc-123", Title: "First", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	msg := tickMsg{}
@@ -1841,7 +2469,7 @@ func TestAppTickMsgDetailDeletedIssue(t *testing.T) {
 	app := newTestApp(t)
 	app.state = viewDetail
 	app.detail = newDetailModel(&issue.Issue{
-		ID: "nonexistent", Title: "Gone", Status: "todo", Type: "task",
+		ID: "nonexistent", Title: "Gone", Status: "ready", Type: "task",
 	}, app.resolver, app.config, 80, 24)
 
 	msg := tickMsg{}
@@ -1864,15 +2492,6 @@ func TestNewAppResolver(t *testing.T) {
 	}
 }
 
-// Test systemEditor
-func TestSystemEditor(t *testing.T) {
-	cmd, args, ok := systemEditor()
-	// Just verify it doesn't panic; result depends on OS
-	_ = cmd
-	_ = args
-	_ = ok
-}
-
 // Test OpenStatusPickerMsg from detail
 func TestAppOpenStatusPickerMsgFromDetail(t *testing.T) {
 	app := newTestApp(t)
@@ -1881,7 +2500,7 @@ func TestAppOpenStatusPickerMsgFromDetail(t *testing.T) {
 	msg := openStatusPickerMsg{
 		issueIDs:      []string{"test-1"},
 		issueTitle:    "Test",
-		currentStatus: "todo",
+		currentStatus: "ready",
 	}
 	updatedModel, _ := app.Update(msg)
 	updated := updatedModel.(*App)
@@ -1918,3 +2537,803 @@ func TestAppResolverMutationViaUpdate(t *testing.T) {
 		t.Errorf("core Title = %q, want \"Via Resolver\"", b.Title)
 	}
 }
+
+func TestProjectDefinedTypeParents(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, config.ConfigFileName)
+	content := "todo:\n    types:\n        - name: spike\n          color: cyan\n          allowed_parents: [epic]\n"
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	dataDir := filepath.Join(tmpDir, ".issues")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	c := core.New(dataDir, cfg)
+	if err := c.Load(); err != nil {
+		t.Fatalf("failed to load core: %v", err)
+	}
+	for _, b := range []*issue.Issue{
+		{ID: "epc-1", Title: "Research", Status: "ready", Type: "epic"},
+		{ID: "ftr-1", Title: "Widgets", Status: "ready", Type: "feature"},
+		{ID: "mst-1", Title: "v1", Status: "ready", Type: "milestone"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+
+	resolver := &graph.Resolver{Core: c}
+	ctx := context.Background()
+	spike, err := resolver.Mutation().CreateIssue(ctx, model.CreateIssueInput{
+		Title:  "Try the new parser",
+		Type:   new("spike"),
+		Parent: new("epc-1"),
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue(spike under epic) error = %v", err)
+	}
+	if spike.Parent != "epc-1" {
+		t.Errorf("spike.Parent = %q, want epc-1", spike.Parent)
+	}
+	if _, err := resolver.Mutation().CreateIssue(ctx, model.CreateIssueInput{
+		Title:  "Misplaced spike",
+		Type:   new("spike"),
+		Parent: new("mst-1"),
+	}); err == nil {
+		t.Error("spike under a milestone should be rejected")
+	}
+
+	all, _ := resolver.Query().Issues(ctx, nil)
+	got := eligibleParents([]string{spike.ID}, []string{"spike"}, all, cfg)
+	if len(got) != 1 || got[0].ID != "epc-1" {
+		ids := make([]string, len(got))
+		for i, b := range got {
+			ids[i] = b.ID
+		}
+		t.Errorf("eligibleParents(spike) = %v, want [epc-1]", ids)
+	}
+}
+
+func TestSkippedFilesIndicator(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	if err := os.WriteFile(filepath.Join(c.Root(), "broken.md"), []byte("---\ntitle: [oops\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	app.list, _ = app.list.Update(app.list.loadIssues())
+	if view := app.list.View(); !strings.Contains(view, "⚠ 1 file skipped") {
+		t.Errorf("list footer should show skipped-file indicator, got:\n%s", view)
+	}
+
+	_, cmd := app.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	if cmd == nil {
+		t.Fatal("w should request the skipped-files modal")
+	}
+	model, _ := app.Update(cmd())
+	updated := model.(*App)
+	if updated.state != viewWarnings {
+		t.Fatalf("state = %d, want viewWarnings (%d)", updated.state, viewWarnings)
+	}
+	if view := updated.warningsModal.View(); !strings.Contains(view, "broken.md") || !strings.Contains(view, "[parse]") {
+		t.Errorf("modal should list the skipped file, got:\n%s", view)
+	}
+
+	_, cmd = updated.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	model, _ = updated.Update(cmd())
+	if model.(*App).state != viewList {
+		t.Errorf("esc should return to the list, state = %d", model.(*App).state)
+	}
+}
+
+func TestConfigReloadUpdatesStatusPicker(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.ConfigFileName)
+	writeConfig := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig("todo:\n  path: .issues\n")
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := core.New(cfg.ResolveDataPath(), cfg)
+	if err := os.MkdirAll(c.Root(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	app := New(c, cfg)
+	app.width, app.height = 80, 24
+
+	pickerStatuses := func() []string {
+		app.Update(openStatusPickerMsg{issueIDs: []string{"x"}, issueTitle: "X", currentStatus: config.StatusReady})
+		var names []string
+		for _, item := range app.statusPicker.list.Items() {
+			names = append(names, item.(statusItem).name)
+		}
+		app.state = viewList
+		return names
+	}
+	if slices.Contains(pickerStatuses(), config.StatusReview) {
+		t.Fatal("review offered before it is enabled")
+	}
+
+	msgs := make(chan tea.Msg, 4)
+	stop, err := watchConfig(path, func(msg tea.Msg) { msgs <- msg })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	reload := func(body string) configReloadedMsg {
+		t.Helper()
+		writeConfig(body)
+		select {
+		case msg := <-msgs:
+			reloaded := msg.(configReloadedMsg)
+			app.Update(reloaded)
+			return reloaded
+		case <-time.After(5 * time.Second):
+			t.Fatal("no config reload after rewriting the file")
+		}
+		return configReloadedMsg{}
+	}
+
+	if msg := reload("todo:\n  path: .issues\n  extra_statuses:\n    review: true\n"); msg.err != nil {
+		t.Fatalf("reload error: %v", msg.err)
+	}
+	if !slices.Contains(pickerStatuses(), config.StatusReview) {
+		t.Error("status picker missing review after it was enabled")
+	}
+	if c.Config() != app.config {
+		t.Error("core still holds the old config")
+	}
+	if app.list.statusMessage != "Config reloaded" {
+		t.Errorf("status message = %q, want %q", app.list.statusMessage, "Config reloaded")
+	}
+
+	// An invalid config is reported and the previous one stays active.
+	if msg := reload("todo:\n  stale_after: soon\n"); msg.err == nil {
+		t.Fatal("expected a reload error for an invalid stale_after")
+	}
+	if !slices.Contains(pickerStatuses(), config.StatusReview) {
+		t.Error("failed reload dropped the previous config")
+	}
+	if !strings.HasPrefix(app.list.statusMessage, "Config not reloaded") {
+		t.Errorf("status message = %q, want a reload warning", app.list.statusMessage)
+	}
+}
+
+func TestAppDetailOpensExternalLink(t *testing.T) {
+	pressO := func(t *testing.T, app *App, b *issue.Issue) *App {
+		t.Helper()
+		m, _ := app.Update(selectIssueMsg{issue: b})
+		app = m.(*App)
+		m, cmd := app.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+		app = m.(*App)
+		// o asks the app to open the link; the app replies with a command
+		// that runs the opener.
+		for cmd != nil {
+			out := cmd()
+			switch out.(type) {
+			case openExternalMsg, externalOpenedMsg:
+				m, cmd = app.Update(out)
+				app = m.(*App)
+			default:
+				cmd = nil
+			}
+		}
+		return app
+	}
+
+	t.Run("linked issue opens in the browser", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.config.Sync = map[string]map[string]any{"github": {"repo": "toba/jig"}}
+		var opened []string
+		app.opener = &launch.Opener{GOOS: "linux", Run: func(name string, args ...string) error {
+			opened = append(opened, name+" "+strings.Join(args, " "))
+			return nil
+		}}
+		b, _ := c.Get("abc-123")
+		b.SetSync("github", map[string]any{"issue_number": "42"})
+
+		app = pressO(t, app, b)
+		want := "xdg-open https://github.com/toba/jig/issues/42"
+		if len(opened) != 1 || opened[0] != want {
+			t.Errorf("opened = %q, want [%q]", opened, want)
+		}
+		if !strings.Contains(app.detail.statusMessage, "Opened https://github.com/toba/jig/issues/42") {
+			t.Errorf("status = %q, want the opened URL", app.detail.statusMessage)
+		}
+	})
+
+	t.Run("unlinked issue explains in the footer", func(t *testing.T) {
+		app, c := newTestAppWithIssues(t)
+		app.opener = &launch.Opener{GOOS: "linux", Run: func(string, ...string) error {
+			t.Error("opener ran for an unlinked issue")
+			return nil
+		}}
+		b, _ := c.Get("def-456")
+
+		app = pressO(t, app, b)
+		if !strings.Contains(app.detail.statusMessage, "not linked") {
+			t.Errorf("status = %q, want a not-linked message", app.detail.statusMessage)
+		}
+	})
+}
+
+func TestListFooterStatsStrip(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.list, _ = app.list.Update(app.list.loadIssues())
+	if view := app.list.View(); !strings.Contains(view, "1 in-progress · 1 ready · 1 completed") {
+		t.Errorf("footer should show counts by status, got:\n%s", view)
+	}
+
+	b, _ := c.Get("abc-123")
+	b.Due = issue.NewDueDate(c.Now().AddDate(0, 0, 2))
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	_, cmd := app.Update(issuesChangedMsg{changedIDs: map[string]bool{"abc-123": true}})
+	app.list, _ = app.list.Update(cmd())
+	if view := app.list.View(); !strings.Contains(view, "1 due soon") {
+		t.Errorf("footer should refresh on issuesChangedMsg, got:\n%s", view)
+	}
+}
+
+func TestAppKeyChordGDOpensDashboard(t *testing.T) {
+	app, _ := newTestAppWithIssues(t)
+
+	updatedModel, _ := app.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	updatedModel, cmd := updatedModel.(*App).Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	if cmd == nil {
+		t.Fatal("g d chord should produce a command")
+	}
+	open, ok := cmd().(openDashboardMsg)
+	if !ok {
+		t.Fatal("g d chord should open the dashboard")
+	}
+	updatedModel, _ = updatedModel.(*App).Update(open)
+	updated := updatedModel.(*App)
+	if updated.state != viewDashboard {
+		t.Fatalf("state = %d, want viewDashboard (%d)", updated.state, viewDashboard)
+	}
+	view := updated.dashboard.View()
+	for _, want := range []string{"Status", "Oldest in progress", "def-456", "Upcoming due", "Recently completed", "ghi-789"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("dashboard should show %q, got:\n%s", want, view)
+		}
+	}
+
+	updatedModel, cmd = updated.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	updatedModel, _ = updatedModel.(*App).Update(cmd())
+	if state := updatedModel.(*App).state; state != viewList {
+		t.Errorf("esc should return to the list, state = %d", state)
+	}
+}
+
+func TestDashboardEnterFiltersByStatus(t *testing.T) {
+	app, _ := newTestAppWithIssues(t)
+	updatedModel, _ := app.Update(openDashboardMsg{})
+
+	// The status panel opens focused, listing statuses in config order.
+	updatedModel, _ = updatedModel.(*App).Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	updatedModel, cmd := updatedModel.(*App).Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a status should produce a command")
+	}
+	updatedModel, cmd = updatedModel.(*App).Update(cmd())
+	updated := updatedModel.(*App)
+	if updated.state != viewList || updated.list.statusFilter != "ready" {
+		t.Fatalf("state = %d, statusFilter = %q; want the list filtered by ready", updated.state, updated.list.statusFilter)
+	}
+	loaded, ok := cmd().(issuesLoadedMsg)
+	if !ok {
+		t.Fatal("choosing a status should reload the list")
+	}
+	if len(loaded.items) != 1 || loaded.items[0].Issue.ID != "abc-123" {
+		t.Errorf("filtered list = %d items, want only abc-123", len(loaded.items))
+	}
+	if loaded.summary.Total != 3 {
+		t.Errorf("summary total = %d, want all 3 issues despite the filter", loaded.summary.Total)
+	}
+}
+
+func TestDashboardEnterOpensIssue(t *testing.T) {
+	app, _ := newTestAppWithIssues(t)
+	updatedModel, _ := app.Update(openDashboardMsg{})
+
+	updatedModel, _ = updatedModel.(*App).Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	updatedModel, cmd := updatedModel.(*App).Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on an issue should produce a command")
+	}
+	updatedModel, _ = updatedModel.(*App).Update(cmd())
+	updated := updatedModel.(*App)
+	if updated.state != viewDetail || updated.detail.issue.ID != "def-456" {
+		t.Errorf("state = %d; want the detail of def-456", updated.state)
+	}
+}
+
+func TestDashboardDegradesOnSmallTerminals(t *testing.T) {
+	app, _ := newTestAppWithIssues(t)
+	updatedModel, _ := app.Update(openDashboardMsg{})
+	updated := updatedModel.(*App)
+
+	tests := []struct {
+		name          string
+		width, height int
+		panels        int
+	}{
+		{"grid", 120, 40, 4},
+		{"stacked", 60, 40, 4},
+		{"focused only", 60, 12, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := updated.dashboard.Update(tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+			view := m.View()
+			shown := 0
+			for _, title := range []string{"Status", "Oldest in progress", "Upcoming due", "Recently completed"} {
+				if strings.Contains(view, title) {
+					shown++
+				}
+			}
+			if shown != tt.panels {
+				t.Errorf("%d panels shown, want %d:\n%s", shown, tt.panels, view)
+			}
+			if lines := strings.Count(view, "\n") + 1; lines > tt.height {
+				t.Errorf("view is %d lines, taller than the %d-line terminal", lines, tt.height)
+			}
+		})
+	}
+}
+
+// newSplitTestApp returns an app with issues loaded into the list and its
+// config anchored in a temp dir, so the split view preference can be saved.
+func newSplitTestApp(t *testing.T, width, height int) *App {
+	t.Helper()
+	app, _ := newTestAppWithIssues(t)
+	app.config.SetConfigDir(t.TempDir())
+	app.state = viewList
+	app.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	app.list, _ = app.list.Update(app.list.loadIssues())
+	return app
+}
+
+// pressChord sends "g" followed by key.
+func pressChord(app *App, key rune) tea.Cmd {
+	app.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	_, cmd := app.Update(tea.KeyPressMsg{Code: key, Text: string(key)})
+	return cmd
+}
+
+func TestAppSplitViewToggle(t *testing.T) {
+	app := newSplitTestApp(t, 160, 40)
+	first := app.list.highlighted()
+	if first == nil {
+		t.Fatal("no highlighted issue after load")
+	}
+
+	pressChord(app, 's')
+	if !app.config.SplitView() || !app.splitActive() {
+		t.Fatal("g s should turn the split view on")
+	}
+	if _, err := os.Stat(filepath.Join(app.config.ConfigDir(), config.LocalConfigFileName)); err != nil {
+		t.Errorf("split view not saved to the local config: %v", err)
+	}
+	if app.list.width != 88 {
+		t.Errorf("list width = %d, want 88 (55%% of 160)", app.list.width)
+	}
+	if app.previewID != first.ID || app.preview.width != 72 {
+		t.Errorf("preview = %q at width %d, want %s at 72 right away", app.previewID, app.preview.width, first.ID)
+	}
+	if view := app.View().Content; !strings.Contains(view, "No description") {
+		t.Errorf("split view does not render the preview body:\n%s", view)
+	}
+
+	pressChord(app, 's')
+	if app.config.SplitView() || app.list.width != 160 {
+		t.Errorf("second g s: SplitView() = %v, list width = %d, want off at 160", app.config.SplitView(), app.list.width)
+	}
+}
+
+func TestAppSplitViewTooNarrow(t *testing.T) {
+	app := newSplitTestApp(t, 100, 30)
+
+	pressChord(app, 's')
+	if app.config.SplitView() {
+		t.Error("g s should be ignored below split_view_min_width")
+	}
+	if !strings.Contains(app.list.statusMessage, "at least 120 columns") {
+		t.Errorf("statusMessage = %q, want the width needed", app.list.statusMessage)
+	}
+
+	app.config.SplitViewMinWidth = 90
+	pressChord(app, 's')
+	if !app.splitActive() {
+		t.Error("g s should work once split_view_min_width allows it")
+	}
+}
+
+func TestAppSplitViewPreviewFollowsCursor(t *testing.T) {
+	app := newSplitTestApp(t, 160, 40)
+	pressChord(app, 's')
+	first := app.previewID
+
+	_, cmd := app.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	next := app.list.highlighted()
+	if next == nil || next.ID == first {
+		t.Fatal("j should move the cursor to another issue")
+	}
+	if cmd == nil {
+		t.Fatal("moving the cursor should schedule a preview")
+	}
+	if app.previewID != first {
+		t.Errorf("preview = %q before the debounce, want %q kept", app.previewID, first)
+	}
+
+	// A second move before the first previewMsg fires supersedes it.
+	stale := app.previewSeq
+	app.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	app.Update(previewMsg{seq: stale})
+	if app.previewID != first {
+		t.Errorf("stale previewMsg rendered %q", app.previewID)
+	}
+
+	app.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	app.Update(previewMsg{seq: app.previewSeq})
+	if app.previewID != next.ID {
+		t.Errorf("preview = %q after the debounce, want %q", app.previewID, next.ID)
+	}
+
+	// Resting on the same issue schedules nothing more.
+	if _, cmd := app.Update(app.list.loadIssues()); app.previewWant != next.ID {
+		t.Errorf("reload changed the wanted preview to %q (cmd %v)", app.previewWant, cmd != nil)
+	}
+}
+
+func TestAppSplitViewWindowResize(t *testing.T) {
+	app := newSplitTestApp(t, 160, 40)
+	pressChord(app, 's')
+
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	if app.list.width != 110 || app.preview.width != 90 || app.preview.height != 50 {
+		t.Errorf("after widening: list %d, preview %dx%d, want 110 and 90x50", app.list.width, app.preview.width, app.preview.height)
+	}
+
+	// Below the threshold the list takes the full width, but the preference stays.
+	app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if app.splitActive() || app.list.width != 100 {
+		t.Errorf("after narrowing: splitActive = %v, list width = %d, want off at 100", app.splitActive(), app.list.width)
+	}
+	if !app.config.SplitView() {
+		t.Error("narrowing the terminal should not forget the split view preference")
+	}
+
+	app.Update(tea.WindowSizeMsg{Width: 150, Height: 30})
+	if !app.splitActive() || app.list.width != 82 || app.previewID == "" {
+		t.Errorf("after widening again: splitActive = %v, list width = %d, preview %q", app.splitActive(), app.list.width, app.previewID)
+	}
+}
+
+// listIDs returns the IDs of every row in the list, in order.
+func listIDs(app *App) []string {
+	var ids []string
+	for _, item := range app.list.allItems {
+		ids = append(ids, item.(issueItem).issue.ID)
+	}
+	return ids
+}
+
+func TestAppPinToggleRoundTrip(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	app.list, _ = app.list.Update(app.list.loadIssues())
+
+	app.list.selectedIssues["abc-123"] = true
+	app.list.selectedIssues["ghi-789"] = true
+	cmd := pressChord(app, 'p')
+	for _, id := range []string{"abc-123", "ghi-789"} {
+		if b, _ := c.Get(id); !b.Pinned {
+			t.Errorf("%s not pinned after g p", id)
+		}
+	}
+	if b, _ := c.Get("def-456"); b.Pinned {
+		t.Error("def-456 pinned, but it was not marked")
+	}
+	if len(app.list.selectedIssues) != 0 {
+		t.Errorf("selection = %v, want cleared after the edit", app.list.selectedIssues)
+	}
+	app.list, _ = app.list.Update(cmd())
+
+	ids := listIDs(app)
+	if !slices.Equal(ids[:2], []string{"ghi-789", "abc-123"}) && !slices.Equal(ids[:2], []string{"abc-123", "ghi-789"}) {
+		t.Errorf("rows = %v, want the pinned issues first", ids)
+	}
+	for i, item := range app.list.allItems {
+		if got := item.(issueItem).pinEnd; got != (i == 1) {
+			t.Errorf("row %d pinEnd = %v, want the rule under row 1 only", i, got)
+		}
+	}
+
+	// Marking a pinned and an unpinned issue pins both.
+	app.list.selectedIssues["abc-123"] = true
+	app.list.selectedIssues["def-456"] = true
+	app.list, _ = app.list.Update(pressChord(app, 'p')())
+	for _, id := range []string{"abc-123", "def-456", "ghi-789"} {
+		if b, _ := c.Get(id); !b.Pinned {
+			t.Errorf("%s not pinned", id)
+		}
+	}
+	for _, item := range app.list.allItems {
+		if item.(issueItem).pinEnd {
+			t.Error("rule shown with every issue pinned")
+		}
+	}
+
+	// With nothing marked, g p unpins the highlighted issue, and again pins it.
+	highlighted := app.list.highlighted().ID
+	app.list, _ = app.list.Update(pressChord(app, 'p')())
+	if b, _ := c.Get(highlighted); b.Pinned {
+		t.Errorf("%s still pinned after g p", highlighted)
+	}
+	if ids := listIDs(app); ids[len(ids)-1] != highlighted {
+		t.Errorf("rows = %v, want unpinned %s last", ids, highlighted)
+	}
+	app.list.list.Select(len(app.list.allItems) - 1)
+	pressChord(app, 'p')
+	if b, _ := c.Get(highlighted); !b.Pinned {
+		t.Errorf("%s not pinned again after g p", highlighted)
+	}
+}
+
+func TestListPinnedFirstEachSort(t *testing.T) {
+	app, c := newTestAppWithIssues(t)
+	b, _ := c.Get("ghi-789")
+	b.Pinned = true
+	if err := c.Update(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := c.Get("abc-123")
+	a.Pinned = true
+	a.Priority = "critical"
+	if err := c.Update(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, order := range []sortOrder{sortDefault, sortStatus, sortPriority, sortCreated, sortUpdated, sortDue} {
+		t.Run(string(order), func(t *testing.T) {
+			app.list.sortOrder = order
+			app.list, _ = app.list.Update(app.list.loadIssues())
+			ids := listIDs(app)
+			if len(ids) != 3 || ids[2] != "def-456" {
+				t.Errorf("rows = %v, want the pinned issues ahead of def-456", ids)
+			}
+		})
+	}
+}
+
+func TestAppDetailIssueDeleted(t *testing.T) {
+	for name, msg := range map[string]tea.Msg{
+		"issuesChangedMsg": issuesChangedMsg{changedIDs: map[string]bool{"k3f-9da": true}},
+		"tickMsg":          tickMsg(time.Now()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			app := newTestApp(t)
+			app.state = viewDetail
+			app.detail = newDetailModel(&issue.Issue{
+				ID: "k3f-9da", Title: "Gone", Status: "ready", Type: "task",
+			}, app.resolver, app.config, 80, 24)
+
+			updatedModel, _ := app.Update(msg)
+			updated := updatedModel.(*App)
+			if updated.state != viewList {
+				t.Errorf("state = %d, want viewList (%d)", updated.state, viewList)
+			}
+			if want := "issue not found: k3f-9da"; updated.list.statusMessage != want {
+				t.Errorf("statusMessage = %q, want %q", updated.list.statusMessage, want)
+			}
+		})
+	}
+}
+
+func TestAppAccessibleViewHasNoLoneGlyphs(t *testing.T) {
+	ui.SetAccessible(true)
+	t.Cleanup(func() { ui.SetAccessible(false) })
+	app, c := newTestAppWithIssues(t)
+	due, _ := issue.ParseDueDate("2026-03-05")
+	flagged := &issue.Issue{ID: "jkl-000", Title: "Flagged issue", Status: "ready", Type: "feature", Priority: "critical",
+		Pinned: true, Due: due, BlockedBy: []string{"abc-123"}, Body: "- [ ] one\n- [x] two\n"}
+	if err := c.Create(flagged); err != nil {
+		t.Fatal(err)
+	}
+	app = New(c, app.config)
+	app.width, app.height = 120, 30
+	m, _ := app.Update(app.list.loadIssues())
+	app = m.(*App)
+	m, _ = app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	app = m.(*App)
+
+	// Every glyph the list and detail views draw has a text label instead.
+	glyphs := []string{"△", "○", "◔", "◈", "⏸", "✔", "✖", "‼", "↓", "→",
+		ui.PinnedSymbol, ui.InternalSymbol, ui.StaleSymbol, ui.BlockedSymbol, ui.BlockingSymbol,
+		ui.DueSymbol, ui.CursorSymbol, ui.PointerSymbol, ui.CheckedSymbol}
+	check := func(name, view string) {
+		t.Helper()
+		for _, g := range glyphs {
+			if strings.Contains(view, g) {
+				t.Errorf("%s view contains %q:\n%s", name, g, view)
+			}
+		}
+	}
+	list := app.View().Content
+	for _, want := range []string{"[feature]", "[ready]", "[critical]", "[pinned]", "[due]", "[blocked 1]"} {
+		if !strings.Contains(list, want) {
+			t.Errorf("list view missing %q:\n%s", want, list)
+		}
+	}
+	check("list", list)
+
+	m, _ = app.Update(selectIssueMsg{issue: flagged})
+	app = m.(*App)
+	check("detail", app.View().Content)
+}
+
+// newTriageTestApp returns an app with three drafts, queued for triage in
+// priority order, besides the issues of newTestAppWithIssues.
+func newTriageTestApp(t *testing.T) (*App, *core.Core) {
+	t.Helper()
+	app, c := newTestAppWithIssues(t)
+	for _, b := range []*issue.Issue{
+		{ID: "drf-003", Title: "Third draft", Status: "draft", Priority: "low"},
+		{ID: "drf-001", Title: "First draft", Status: "draft", Priority: "high", Body: "Needs a decision."},
+		{ID: "drf-002", Title: "Second draft", Status: "draft", Priority: "normal"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cmd := pressChord(app, 'r'); cmd == nil {
+		t.Fatal("g r should start a triage session")
+	} else {
+		app.Update(cmd())
+	}
+	if app.state != viewTriage {
+		t.Fatalf("state = %d, want viewTriage (%d)", app.state, viewTriage)
+	}
+	return app, c
+}
+
+// triageKeys sends keys to the app, each a key name or text to type, and
+// returns the command the last one produced.
+func triageKeys(app *App, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var msg tea.KeyPressMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyPressMsg{Code: tea.KeyEscape}
+		case "space":
+			msg = tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+		default:
+			for _, r := range k {
+				_, cmd = app.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+			}
+			continue
+		}
+		_, cmd = app.Update(msg)
+	}
+	return cmd
+}
+
+func TestTriageActions(t *testing.T) {
+	app, c := newTriageTestApp(t)
+	if got := app.triage.queue; !slices.Equal(got, []string{"drf-001", "drf-002", "drf-003"}) {
+		t.Fatalf("queue = %v, want the drafts most urgent first", got)
+	}
+	if view := app.View().Content; !strings.Contains(view, "1/3") || !strings.Contains(view, "First draft") {
+		t.Errorf("view should show progress and the first draft, got:\n%s", view)
+	}
+
+	triageKeys(app, "4")
+	if b, _ := c.Get("drf-001"); b.Priority != "low" {
+		t.Errorf("drf-001 priority = %q, want low", b.Priority)
+	}
+	if app.triage.progress() != "2/3" {
+		t.Errorf("progress = %s after a decision, want 2/3", app.triage.progress())
+	}
+
+	triageKeys(app, "s")
+	if b, _ := c.Get("drf-002"); b.Status != "ready" {
+		t.Errorf("drf-002 status = %q, want s to move a draft to ready", b.Status)
+	}
+
+	triageKeys(app, "t", "auth", "enter")
+	if b, _ := c.Get("drf-003"); !b.HasTag("auth") {
+		t.Errorf("drf-003 tags = %v, want auth added", b.Tags)
+	}
+	if !app.triage.done() {
+		t.Fatal("session not done after deciding on every issue")
+	}
+	view := app.View().Content
+	for _, want := range []string{"Triaged 3 issues of 3", "priority low", "status ready", "tag +auth"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("summary should list %q, got:\n%s", want, view)
+		}
+	}
+
+	cmd := triageKeys(app, "enter")
+	if cmd == nil {
+		t.Fatal("enter on the summary should close the session")
+	}
+	app.Update(cmd())
+	if app.state != viewList {
+		t.Errorf("state = %d after closing, want the list", app.state)
+	}
+}
+
+func TestTriageDueAndScrap(t *testing.T) {
+	app, c := newTriageTestApp(t)
+
+	triageKeys(app, "d", "3d", "enter")
+	want := c.Now().AddDate(0, 0, 3).Format(issue.DueDateFormat)
+	if b, _ := c.Get("drf-001"); b.Due == nil || b.Due.DateKey() != want {
+		t.Errorf("drf-001 due = %v, want %s", b.Due, want)
+	}
+
+	triageKeys(app, "d", "someday", "enter")
+	if app.triage.errText == "" || app.triage.pos != 1 {
+		t.Errorf("a bad due date should be reported without advancing, pos = %d", app.triage.pos)
+	}
+
+	triageKeys(app, "x")
+	if b, _ := c.Get("drf-002"); b.Status != "scrapped" {
+		t.Errorf("drf-002 status = %q, want scrapped", b.Status)
+	}
+}
+
+func TestTriageUndo(t *testing.T) {
+	app, c := newTriageTestApp(t)
+
+	triageKeys(app, "x", "space", "t", "ui", "enter")
+	if !app.triage.done() {
+		t.Fatal("session not done")
+	}
+
+	triageKeys(app, "u")
+	if b, _ := c.Get("drf-003"); b.HasTag("ui") || app.triage.pos != 2 {
+		t.Errorf("undo left tags %v at pos %d, want the tag removed and drf-003 shown again", b.Tags, app.triage.pos)
+	}
+	triageKeys(app, "u")
+	if app.triage.pos != 1 {
+		t.Errorf("undoing a skip should return to drf-002, pos = %d", app.triage.pos)
+	}
+	triageKeys(app, "u")
+	if b, _ := c.Get("drf-001"); b.Status != "draft" || app.triage.pos != 0 {
+		t.Errorf("undo left drf-001 %s at pos %d, want draft and shown again", b.Status, app.triage.pos)
+	}
+	triageKeys(app, "u")
+	if app.triage.errText != "Nothing to undo" {
+		t.Errorf("errText = %q with nothing left to undo", app.triage.errText)
+	}
+
+	// Ending early shows what is left decided
+	triageKeys(app, "1", "esc")
+	if view := app.View().Content; !app.triage.done() || !strings.Contains(view, "Triaged 1 issue of 3") {
+		t.Errorf("esc should end the session with a summary, got:\n%s", view)
+	}
+}
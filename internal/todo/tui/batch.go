@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+)
+
+// batchOutcome tallies a batch edit: the issues that were updated and the
+// reasons the rest were skipped.
+type batchOutcome struct {
+	action  string // e.g. "set parent", used in the status message
+	total   int
+	updated []string
+	skipped int
+	reasons []string // distinct skip reasons, in first-seen order
+}
+
+func (o *batchOutcome) skip(reason string) {
+	o.skipped++
+	if !slices.Contains(o.reasons, reason) {
+		o.reasons = append(o.reasons, reason)
+	}
+}
+
+// message summarizes the outcome for the footer, e.g. "Set parent on 4
+// issues, skipped 1 (milestones cannot have parents)". A single issue
+// updated without problems needs no message.
+func (o batchOutcome) message() string {
+	if o.skipped == 0 {
+		if o.total <= 1 {
+			return ""
+		}
+		return fmt.Sprintf("%s on %s", capitalize(o.action), pluralIssues(len(o.updated)))
+	}
+	reasons := strings.Join(o.reasons, "; ")
+	if len(o.updated) == 0 {
+		return fmt.Sprintf("Could not %s: %s", o.action, reasons)
+	}
+	return fmt.Sprintf("%s on %s, skipped %d (%s)", capitalize(o.action), pluralIssues(len(o.updated)), o.skipped, reasons)
+}
+
+func pluralIssues(n int) string {
+	if n == 1 {
+		return "1 issue"
+	}
+	return fmt.Sprintf("%d issues", n)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// applyBatch validates each target with check, which returns a skip reason
+// or "", and applies input to the rest. Mutation errors count as skips.
+func (a *App) applyBatch(action string, issueIDs []string, input model.UpdateIssueInput, check func(b *issue.Issue) string) batchOutcome {
+	return a.applyBatchFunc(action, issueIDs, check, func(ctx context.Context, id string) error {
+		_, err := a.resolver.Mutation().UpdateIssue(ctx, id, input)
+		return err
+	})
+}
+
+// applyBatchFunc is applyBatch for edits that need a mutation other than
+// updateIssue. Archived issues are read-only and always skipped.
+func (a *App) applyBatchFunc(action string, issueIDs []string, check func(b *issue.Issue) string, apply func(ctx context.Context, id string) error) batchOutcome {
+	out := batchOutcome{action: action, total: len(issueIDs)}
+	ctx := context.Background()
+	for _, id := range issueIDs {
+		b, err := a.resolver.Query().Issue(ctx, id)
+		if err != nil || b == nil {
+			out.skip("issue not found")
+			continue
+		}
+		if a.core.IsArchived(id) {
+			out.skip("archived")
+			continue
+		}
+		if check != nil {
+			if reason := check(b); reason != "" {
+				out.skip(reason)
+				continue
+			}
+		}
+		if err := apply(ctx, id); err != nil {
+			out.skip(err.Error())
+			continue
+		}
+		out.updated = append(out.updated, id)
+	}
+	return out
+}
+
+// checkParent rejects issues whose type cannot take a parent at all. The
+// parent picker only offers parents valid for the remaining types, so finer
+// hierarchy errors are left to the mutation.
+func (a *App) checkParent(parentID string) func(b *issue.Issue) string {
+	return func(b *issue.Issue) string {
+		if parentID == "" {
+			return ""
+		}
+		if a.config.ValidParentTypes(b.Type) == nil {
+			return graph.NoParentReason(b.Type)
+		}
+		if b.ID == parentID {
+			return "an issue cannot be its own parent"
+		}
+		return ""
+	}
+}
+
+// checkStatus pre-validates a status change with the same rules as the
+// status picker's options: see graph.Resolver.StatusRefusal.
+func (a *App) checkStatus(status string) func(b *issue.Issue) string {
+	return func(b *issue.Issue) string {
+		return a.resolver.StatusRefusal(b, status)
+	}
+}
+
+// checkType rejects type changes that would break the hierarchy, as the
+// type picker's options do: see graph.Resolver.TypeRefusal.
+func (a *App) checkType(issueType string) func(b *issue.Issue) string {
+	return func(b *issue.Issue) string {
+		return a.resolver.TypeRefusal(b, issueType)
+	}
+}
+
+// pickerIssues loads the issues a picker edits, for the resolver to mark
+// which of its options apply. Issues that no longer exist are left out.
+func (a *App) pickerIssues(issueIDs []string) []*issue.Issue {
+	issues := make([]*issue.Issue, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		if b, err := a.core.Get(id); err == nil {
+			issues = append(issues, b)
+		}
+	}
+	return issues
+}
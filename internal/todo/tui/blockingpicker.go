@@ -66,16 +66,16 @@ func (d blockingItemDelegate) Render(w io.Writer, m list.Model, index int, listI
 	isBlocking := (*d.pendingBlocking)[item.issue.ID]
 	var blockingIndicator string
 	if isBlocking {
-		blockingIndicator = lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true).Render("● ") // Red dot for blocking
+		blockingIndicator = lipgloss.NewStyle().Foreground(ui.ColorDanger).Bold(true).Render(ui.Glyph(ui.CheckedSymbol) + " ") // Red dot for blocking
 	} else {
-		blockingIndicator = lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("○ ") // Empty circle for not blocking
+		blockingIndicator = lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(ui.Glyph(ui.UncheckedSymbol) + " ") // Empty circle for not blocking
 	}
 
 	// Get colors from config
 	colors := d.cfg.GetIssueColors(item.issue.Status, item.issue.Type, item.issue.Priority)
 
 	// Format: [indicator] [type] title (id)
-	typeBadge := ui.RenderTypeText(item.issue.Type, colors.TypeColor)
+	typeBadge := ui.RenderTypeLabel(item.issue.Type, colors.TypeIcon, colors.TypeColor)
 	title := item.issue.Title
 	if colors.IsArchive {
 		title = ui.Muted.Render(title)
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/fsnotify/fsnotify"
+	"github.com/toba/jig/internal/todo/config"
+)
+
+// configDebounce coalesces the burst of events an editor save produces
+// (write, chmod, or rename-over) into a single reload.
+const configDebounce = 150 * time.Millisecond
+
+// configReloadedMsg carries the result of re-reading the config file. When
+// err is set, cfg is nil and the current config stays active.
+type configReloadedMsg struct {
+	cfg *config.Config
+	err error
+}
+
+// watchConfig watches the config file at path and calls send with a
+// configReloadedMsg after each change. The file's directory is watched rather
+// than the file itself so saves that replace the file are still seen.
+// The returned function stops the watcher.
+func watchConfig(path string, send func(tea.Msg)) (func(), error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close() //nolint:errcheck // already failing
+		return nil, err
+	}
+
+	names := map[string]bool{
+		filepath.Base(path):        true,
+		config.LocalConfigFileName: true,
+	}
+	done := make(chan struct{})
+	go func() {
+		var timer *time.Timer
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !names[filepath.Base(event.Name)] || event.Op == fsnotify.Chmod {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(configDebounce, func() {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					cfg, err := config.Load(path)
+					if err != nil {
+						cfg = nil
+					}
+					send(configReloadedMsg{cfg: cfg, err: err})
+				})
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		watcher.Close() //nolint:errcheck // cleanup
+	}, nil
+}
+
+// applyConfig swaps in a reloaded config: the core, the list (styles and
+// default sort), and the open detail view all pick it up, and pickers opened
+// from here on list the new options. A failed reload leaves the old config
+// in place and reports why.
+func (a *App) applyConfig(msg configReloadedMsg) tea.Cmd {
+	if msg.err != nil {
+		a.setStatusMessage("Config not reloaded: " + msg.err.Error())
+		return nil
+	}
+	a.config = msg.cfg
+	a.core.SetConfig(msg.cfg)
+	a.list.setConfig(msg.cfg)
+	a.detail.config = msg.cfg
+	if a.state == viewDetail {
+		a.detail.refreshIssue(a.detail.issue)
+	}
+	if a.state == viewList {
+		// split_view_min_width may have changed
+		a.list, _ = a.list.Update(a.listSize())
+		if a.splitActive() {
+			a.renderPreview()
+		}
+	}
+	a.setStatusMessage("Config reloaded")
+	return a.list.loadIssues
+}
@@ -26,10 +26,20 @@ type createModalModel struct {
 }
 
 func newCreateModalModel(width, height int) createModalModel {
+	return createModalModel{
+		textInput: newTitleInput("Enter issue title...", 50),
+		width:     width,
+		height:    height,
+	}
+}
+
+// newTitleInput returns a focused single-line input styled for the create
+// modals.
+func newTitleInput(placeholder string, width int) textinput.Model {
 	ti := textinput.New()
-	ti.Placeholder = "Enter issue title..."
+	ti.Placeholder = placeholder
 	ti.CharLimit = 200
-	ti.SetWidth(50)
+	ti.SetWidth(width)
 	ti.Focus()
 	styles := ti.Styles()
 	styles.Focused.Prompt = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
@@ -40,12 +50,7 @@ func newCreateModalModel(width, height int) createModalModel {
 	styles.Blurred.Placeholder = lipgloss.NewStyle().Foreground(ui.ColorMuted)
 	ti.SetStyles(styles)
 	ti.Prompt = ""
-
-	return createModalModel{
-		textInput: ti,
-		width:     width,
-		height:    height,
-	}
+	return ti
 }
 
 func (m createModalModel) Init() tea.Cmd {
@@ -0,0 +1,309 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/stats"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// dashboardPanelRows is how many issues the dashboard lists per panel.
+const dashboardPanelRows = 5
+
+// dashboardGridWidth is the narrowest terminal that shows the panels in two
+// columns; narrower terminals stack them.
+const dashboardGridWidth = 80
+
+// openDashboardMsg requests opening the dashboard
+type openDashboardMsg struct{}
+
+// closeDashboardMsg is sent when the dashboard is closed
+type closeDashboardMsg struct{}
+
+// dashboardStatusMsg is sent when a status is chosen on the dashboard, to
+// filter the list by it
+type dashboardStatusMsg struct {
+	status string
+}
+
+// dashboardRow is one navigable line of a dashboard panel: a status count or
+// an issue.
+type dashboardRow struct {
+	status string // set for status count rows
+	issue  *issue.Issue
+	label  string
+	detail string // right-hand annotation (count, age, or due date)
+	alert  bool   // detail is shown in the danger color
+}
+
+// dashboardPanel is a titled list of rows.
+type dashboardPanel struct {
+	title  string
+	empty  string // shown when there are no rows
+	rows   []dashboardRow
+	cursor int
+}
+
+// dashboardData is what the dashboard is built from.
+type dashboardData struct {
+	issues    []*issue.Issue
+	config    *config.Config
+	now       time.Time
+	isBlocked func(id string) bool
+	isStale   func(b *issue.Issue) bool
+}
+
+// dashboardModel shows workspace health: counts by status, the oldest
+// in-progress issues, upcoming due dates, and recently completed issues.
+type dashboardModel struct {
+	summary stats.Summary
+	panels  []dashboardPanel
+	focus   int
+	width   int
+	height  int
+}
+
+func newDashboardModel(data dashboardData, width, height int) dashboardModel {
+	m := dashboardModel{width: width, height: height}
+	m.refresh(data)
+	return m
+}
+
+// refresh rebuilds the panels from data, keeping the focused panel and each
+// panel's cursor where they still fit.
+func (m *dashboardModel) refresh(data dashboardData) {
+	m.summary = stats.Summarize(data.issues, data.config, data.now, data.isBlocked, data.isStale)
+
+	age := func(b *issue.Issue) string {
+		ts := stats.LastTouched(b)
+		if ts.IsZero() {
+			return ""
+		}
+		return fmt.Sprintf("%dd", int(data.now.Sub(ts).Hours()/24))
+	}
+	issueRows := func(issues []*issue.Issue, detail func(*issue.Issue) (string, bool)) []dashboardRow {
+		rows := make([]dashboardRow, len(issues))
+		for i, b := range issues {
+			text, alert := detail(b)
+			rows[i] = dashboardRow{issue: b, label: b.ID + " " + b.Title, detail: text, alert: alert}
+		}
+		return rows
+	}
+
+	statusRows := make([]dashboardRow, len(m.summary.Statuses))
+	for i, c := range m.summary.Statuses {
+		statusRows[i] = dashboardRow{status: c.Value, label: c.Value, detail: fmt.Sprint(c.Count)}
+	}
+
+	panels := []dashboardPanel{
+		{
+			title: "Status",
+			empty: "No issues",
+			rows:  statusRows,
+		},
+		{
+			title: "Oldest in progress",
+			empty: "Nothing in progress",
+			rows: issueRows(stats.OldestInStatus(data.issues, config.StatusInProgress, dashboardPanelRows), func(b *issue.Issue) (string, bool) {
+				return age(b), data.isStale != nil && data.isStale(b)
+			}),
+		},
+		{
+			title: "Upcoming due",
+			empty: "No due dates",
+			rows: issueRows(stats.UpcomingDue(data.issues, data.config, dashboardPanelRows), func(b *issue.Issue) (string, bool) {
+				return b.Due.DateKey(), b.Due.Deadline().Before(data.now)
+			}),
+		},
+		{
+			title: "Recently completed",
+			empty: "Nothing completed",
+			rows: issueRows(stats.RecentlyCompleted(data.issues, dashboardPanelRows), func(b *issue.Issue) (string, bool) {
+				return age(b), false
+			}),
+		},
+	}
+	for i := range panels {
+		if i < len(m.panels) {
+			panels[i].cursor = min(m.panels[i].cursor, max(0, len(panels[i].rows)-1))
+		}
+	}
+	m.panels = panels
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyPressMsg:
+		panel := &m.panels[m.focus]
+		switch msg.String() {
+		case "tab", "l", "right":
+			m.focus = (m.focus + 1) % len(m.panels)
+		case "shift+tab", "h", "left":
+			m.focus = (m.focus + len(m.panels) - 1) % len(m.panels)
+		case "j", "down":
+			if panel.cursor < len(panel.rows)-1 {
+				panel.cursor++
+			}
+		case "k", "up":
+			if panel.cursor > 0 {
+				panel.cursor--
+			}
+		case "enter":
+			if len(panel.rows) == 0 {
+				return m, nil
+			}
+			row := panel.rows[panel.cursor]
+			if row.issue != nil {
+				return m, func() tea.Msg { return selectIssueMsg{issue: row.issue} }
+			}
+			return m, func() tea.Msg { return dashboardStatusMsg{status: row.status} }
+		case "esc", "backspace":
+			return m, func() tea.Msg { return closeDashboardMsg{} }
+		}
+	}
+	return m, nil
+}
+
+// panelRows returns how many rows each panel can show at the current size,
+// with the number of panel columns. Zero rows means only the focused panel
+// fits.
+func (m dashboardModel) panelRows() (rows, columns int) {
+	columns = 1
+	if m.width >= dashboardGridWidth {
+		columns = 2
+	}
+	stacked := (len(m.panels) + columns - 1) / columns
+	// Each panel spends two lines on its border and one on its title; the
+	// view spends one on the summary line and one on the footer.
+	rows = min(dashboardPanelRows, (m.height-2)/stacked-3)
+	return max(rows, 0), columns
+}
+
+func (m dashboardModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	rows, columns := m.panelRows()
+	panelWidth := m.width / columns
+	var body string
+	if rows == 0 {
+		// Too short for every panel: show the focused one alone.
+		columns = 1
+		panelWidth = m.width
+		rows = max(1, min(dashboardPanelRows, m.height-5))
+		body = m.renderPanel(m.focus, panelWidth, rows)
+	} else {
+		var lines []string
+		for start := 0; start < len(m.panels); start += columns {
+			var row []string
+			for i := start; i < min(start+columns, len(m.panels)); i++ {
+				row = append(row, m.renderPanel(i, panelWidth, rows))
+			}
+			lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+		}
+		body = lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	header := listTitleStyle.Render("Dashboard") + "  " + renderStatsStrip(m.summary)
+	footer := helpKeyStyle.Render("tab") + " " + helpStyle.Render("next panel") + "  " +
+		helpKeyStyle.Render("j/k") + " " + helpStyle.Render("move") + "  " +
+		helpKeyStyle.Render("enter") + " " + helpStyle.Render("open") + "  " +
+		helpKeyStyle.Render("esc") + " " + helpStyle.Render("back") + "  " +
+		helpKeyStyle.Render("q") + " " + helpStyle.Render("quit")
+	fit := lipgloss.NewStyle().MaxWidth(m.width)
+	return fit.Render(header) + "\n" + body + "\n" + fit.Render(footer)
+}
+
+// renderPanel draws panel i in a box width wide showing up to rows rows,
+// scrolled to keep its cursor visible.
+func (m dashboardModel) renderPanel(i, width, rows int) string {
+	p := m.panels[i]
+	focused := i == m.focus
+	inner := max(1, width-4)
+
+	var sb strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	if focused {
+		titleStyle = titleStyle.Foreground(ui.ColorPrimary)
+	}
+	sb.WriteString(titleStyle.Render(p.title))
+
+	if len(p.rows) == 0 {
+		sb.WriteString("\n  " + ui.Muted.Render(p.empty))
+	}
+	offset := max(0, p.cursor-rows+1)
+	for j := offset; j < min(offset+rows, len(p.rows)); j++ {
+		row := p.rows[j]
+		cursor := "  "
+		if focused && j == p.cursor {
+			cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(ui.Glyph(ui.PointerSymbol) + " ")
+		}
+		detailStyle := helpStyle
+		if row.alert {
+			detailStyle = lipgloss.NewStyle().Foreground(ui.ColorDanger)
+		}
+		label := row.label
+		if row.status != "" {
+			label = ui.RenderStatusText(row.status)
+		}
+		labelWidth := max(1, inner-2-lipgloss.Width(row.detail)-1)
+		label = lipgloss.NewStyle().MaxWidth(labelWidth).Render(label)
+		gap := max(1, inner-2-lipgloss.Width(label)-lipgloss.Width(row.detail))
+		sb.WriteString("\n" + cursor + label + strings.Repeat(" ", gap) + detailStyle.Render(row.detail))
+	}
+
+	borderColor := ui.ColorMuted
+	if focused {
+		borderColor = ui.ColorPrimary
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(width).
+		Height(rows + 3).
+		Render(sb.String())
+}
+
+// renderStatsStrip renders the one-line health summary shown in the list
+// footer and the dashboard header, e.g. "12 todo · 4 in-progress · 2 blocked".
+func renderStatsStrip(s stats.Summary) string {
+	counts := make([]string, len(s.Statuses))
+	for i, c := range s.Statuses {
+		counts[i] = fmt.Sprintf("%d %s", c.Count, c.Value)
+	}
+	var parts []string
+	if len(counts) > 0 {
+		parts = append(parts, helpStyle.Render(strings.Join(counts, " · ")))
+	}
+	warn := lipgloss.NewStyle().Foreground(ui.ColorWarning)
+	danger := lipgloss.NewStyle().Foreground(ui.ColorDanger)
+	if s.Blocked > 0 {
+		parts = append(parts, warn.Render(fmt.Sprintf("%d blocked", s.Blocked)))
+	}
+	if s.Stale > 0 {
+		parts = append(parts, warn.Render(fmt.Sprintf("%d stale", s.Stale)))
+	}
+	if s.DueSoon > 0 {
+		parts = append(parts, warn.Render(fmt.Sprintf("%d due soon", s.DueSoon)))
+	}
+	if s.Overdue > 0 {
+		parts = append(parts, danger.Render(fmt.Sprintf("%d overdue", s.Overdue)))
+	}
+	return strings.Join(parts, helpStyle.Render(" · "))
+}
@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"charm.land/bubbles/v2/list"
 	"charm.land/bubbles/v2/viewport"
@@ -16,6 +17,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/toba/jig/internal/todo/config"
 	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/integration"
 	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/ui"
 )
@@ -79,7 +81,7 @@ func (d linkDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	// Cursor indicator
 	cursor := "  "
 	if index == m.Index() {
-		cursor = ui.Primary.Render("▸ ")
+		cursor = ui.Primary.Render(ui.Glyph(ui.PointerSymbol) + " ")
 	}
 
 	// Format the link type label
@@ -104,6 +106,7 @@ func (d linkDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		ui.IssueRowConfig{
 			StatusColor:    colors.StatusColor,
 			TypeColor:      colors.TypeColor,
+			TypeIcon:       colors.TypeIcon,
 			PriorityColor:  colors.PriorityColor,
 			Priority:       link.issue.Priority,
 			IsArchive:      colors.IsArchive,
@@ -136,6 +139,7 @@ type detailModel struct {
 	cols            ui.ResponsiveColumns // responsive column widths for links
 	statusMessage   string               // Status message to display in footer
 	milestoneShorts map[string]string    // milestone ID -> short name, for the "<short>:" ID prefix
+	preview         bool                 // read-only split view preview: header and body only
 }
 
 // loadMilestoneShorts builds the milestone ID -> short name lookup from core.
@@ -191,6 +195,27 @@ func newDetailModel(b *issue.Issue, resolver *graph.Resolver, cfg *config.Config
 	return m
 }
 
+// newPreviewModel returns a read-only view of b for the split view's preview
+// pane: the detail header and body, without links or key help.
+func newPreviewModel(b *issue.Issue, resolver *graph.Resolver, cfg *config.Config, width, height int) detailModel {
+	m := detailModel{
+		issue:    b,
+		resolver: resolver,
+		config:   cfg,
+		width:    width,
+		height:   height,
+		ready:    true,
+		preview:  true,
+	}
+	m.milestoneShorts = m.loadMilestoneShorts()
+
+	vpWidth := width - 6
+	vpHeight := max(height-m.calculateHeaderHeight(), 1)
+	m.viewport = viewport.New(viewport.WithWidth(vpWidth), viewport.WithHeight(vpHeight))
+	m.viewport.SetContent(m.renderBody(vpWidth))
+	return m
+}
+
 // createLinkList creates a new list.Model for the links
 func (m detailModel) createLinkList() list.Model {
 	delegate := linkDelegate{
@@ -226,11 +251,7 @@ func (m detailModel) createLinkList() list.Model {
 	l.Filter = substringFilter
 
 	// Style the title bar similar to the detail header title (badge style) but with different color
-	l.Styles.Title = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#fff")).
-		Background(ui.ColorBlue).
-		Padding(0, 1)
+	l.Styles.Title = ui.Badge(ui.ColorBlue).Bold(true)
 	l.Styles.TitleBar = lipgloss.NewStyle().Padding(0, 0, 0, 1) // Left padding to align with header title
 	l.Styles.Filter.Focused.Prompt = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
 	l.Styles.Filter.Blurred.Prompt = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
@@ -390,6 +411,13 @@ func (m detailModel) Update(msg tea.Msg) (detailModel, tea.Cmd) {
 			return m, func() tea.Msg {
 				return copyIssueIDMsg{ids: []string{m.issue.ID}}
 			}
+
+		case "o":
+			// Open the linked GitHub issue or ClickUp task, or the first
+			// sync entry with a URL template
+			return m, func() tea.Msg {
+				return openExternalMsg{issue: m.issue}
+			}
 		}
 	}
 
@@ -424,6 +452,15 @@ func (m detailModel) View() string {
 	// Header (issue info only, no links)
 	header := m.renderHeader()
 
+	if m.preview {
+		body := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ui.ColorMuted).
+			Width(m.width - 4).
+			Render(m.viewport.View())
+		return header + "\n" + body
+	}
+
 	// Links section (if any)
 	var linksSection string
 	if len(m.links) > 0 {
@@ -465,8 +502,11 @@ func (m detailModel) View() string {
 		helpKeyStyle.Render("P") + " " + helpStyle.Render("priority") + "  " +
 		helpKeyStyle.Render("s") + " " + helpStyle.Render("status") + "  " +
 		helpKeyStyle.Render("t") + " " + helpStyle.Render("type") + "  " +
-		helpKeyStyle.Render("c") + " " + helpStyle.Render("copy id") + "  " +
-		helpKeyStyle.Render("j/k") + " " + helpStyle.Render("scroll") + "  " +
+		helpKeyStyle.Render("c") + " " + helpStyle.Render("copy id") + "  "
+	if len(m.issue.Sync) > 0 {
+		footer += helpKeyStyle.Render("o") + " " + helpStyle.Render("open link") + "  "
+	}
+	footer += helpKeyStyle.Render("j/k") + " " + helpStyle.Render("scroll") + "  " +
 		helpKeyStyle.Render("?") + " " + helpStyle.Render("help") + "  " +
 		helpKeyStyle.Render("esc") + " " + helpStyle.Render("back") + "  " +
 		helpKeyStyle.Render("q") + " " + helpStyle.Render("quit")
@@ -484,6 +524,11 @@ func (m detailModel) calculateHeaderHeight() int {
 	// Base: title line + ID/status line + borders/padding = ~6
 	baseHeight := 6
 
+	// Linked pull requests take one more line
+	if len(m.issue.GithubPullRequests()) > 0 {
+		baseHeight++
+	}
+
 	// Add height for links section (separate bordered box)
 	if len(m.links) > 0 {
 		// Links list height + borders (matches createLinkList calculation)
@@ -526,12 +571,34 @@ func (m detailModel) renderHeader() string {
 		}
 	}
 
+	// Add iteration if assigned
+	if m.issue.Iteration != "" {
+		headerContent.WriteString("  ")
+		headerContent.WriteString(ui.Muted.Render("iteration:" + m.issue.Iteration))
+	}
+
 	// Add tags if present
 	if len(m.issue.Tags) > 0 {
 		headerContent.WriteString("  ")
 		headerContent.WriteString(ui.RenderTags(m.issue.Tags))
 	}
 
+	// Add linked pull requests with their last synced state
+	if prs := integration.FormatPullRequests(m.issue, m.config.Sync, time.Now()); len(prs) > 0 {
+		headerContent.WriteString("\n")
+		headerContent.WriteString(ui.Muted.Render("pr: ") + strings.Join(prs, ui.Muted.Render(" · ")))
+	}
+
+	// Add the URLs of sync entries that have one
+	if links := integration.SyncLinks(m.issue, m.config); len(links) > 0 {
+		parts := make([]string, len(links))
+		for i, l := range links {
+			parts[i] = l.Name + " " + l.URL
+		}
+		headerContent.WriteString("\n")
+		headerContent.WriteString(ui.Muted.Render("links: ") + strings.Join(parts, ui.Muted.Render(" · ")))
+	}
+
 	// Header box style - always muted border (not focused, links section is separate)
 	headerBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -550,6 +617,8 @@ func (m detailModel) formatLinkLabel(linkType string, incoming bool) string {
 			return "Blocked by"
 		case issue.LinkTypeParent:
 			return "Child"
+		case issue.LinkTypeMention:
+			return "Mentioned by"
 		default:
 			return linkType + " (incoming)"
 		}
@@ -561,6 +630,8 @@ func (m detailModel) formatLinkLabel(linkType string, incoming bool) string {
 		return "Blocking"
 	case issue.LinkTypeParent:
 		return "Parent"
+	case issue.LinkTypeMention:
+		return "Mentions"
 	default:
 		return linkType
 	}
@@ -576,6 +647,39 @@ func (m detailModel) visibleIssueIDs() map[string]bool {
 	return ids
 }
 
+// affectedBy reports whether any of the changed issues is shown in the
+// detail view, or now links to or from the viewed issue, so that links added
+// by an edit to another issue appear too. A linked issue whose body alone
+// changed only counts if that added or removed a mention of the viewed one,
+// since linked issues are shown without their bodies.
+func (m detailModel) affectedBy(changed, bodyOnly map[string]bool) bool {
+	visible := m.visibleIssueIDs()
+	for id := range changed {
+		if visible[id] && (id == m.issue.ID || !bodyOnly[id]) {
+			return true
+		}
+	}
+	current, err := m.resolver.Core.Lookup(m.issue.ID)
+	if err != nil {
+		return false // a deletion names the issue itself
+	}
+	fresh := m
+	fresh.issue = current
+	linked := map[string]bool{}
+	for _, link := range fresh.resolveAllLinks() {
+		if changed[link.issue.ID] && !visible[link.issue.ID] {
+			return true
+		}
+		linked[link.issue.ID] = true
+	}
+	for id := range changed {
+		if visible[id] && !linked[id] {
+			return true
+		}
+	}
+	return false
+}
+
 // refreshIssue updates the detail view with fresh issue data without resetting
 // the cursor position or focus state.
 func (m *detailModel) refreshIssue(b *issue.Issue) {
@@ -629,6 +733,27 @@ func (m detailModel) resolveAllLinks() []resolvedLink {
 		}
 	}
 
+	// Body mentions, skipping issues already listed through a real link
+	linked := make(map[string]bool, len(links))
+	for _, l := range links {
+		linked[l.issue.ID] = true
+	}
+	if mentions, _ := issueResolver.Mentions(ctx, m.issue, nil); mentions != nil {
+		for _, b := range mentions {
+			if !linked[b.ID] {
+				linked[b.ID] = true
+				links = append(links, resolvedLink{linkType: issue.LinkTypeMention, issue: b, incoming: false})
+			}
+		}
+	}
+	if mentionedBy, _ := issueResolver.MentionedBy(ctx, m.issue, nil); mentionedBy != nil {
+		for _, b := range mentionedBy {
+			if !linked[b.ID] {
+				links = append(links, resolvedLink{linkType: issue.LinkTypeMention, issue: b, incoming: true})
+			}
+		}
+	}
+
 	// Sort all links by link type label first, then by issue status/type/title
 	// This keeps link categories together while ordering issues consistently with the main list
 	statusNames := m.config.StatusNames()
@@ -657,7 +782,12 @@ func linksHaveTags(links []resolvedLink) bool {
 }
 
 func (m detailModel) renderBody(_ int) string {
-	if m.issue.Body == "" {
+	return renderIssueBody(m.issue)
+}
+
+// renderIssueBody renders b's body as markdown for the terminal.
+func renderIssueBody(b *issue.Issue) string {
+	if b.Body == "" {
 		return lipgloss.NewStyle().
 			Foreground(ui.ColorMuted).
 			Padding(0, 1).
@@ -666,12 +796,12 @@ func (m detailModel) renderBody(_ int) string {
 
 	renderer := getGlamourRenderer()
 	if renderer == nil {
-		return m.issue.Body
+		return b.Body
 	}
 
-	rendered, err := renderer.Render(m.issue.Body)
+	rendered, err := renderer.Render(b.Body)
 	if err != nil {
-		return m.issue.Body
+		return b.Body
 	}
 
 	return strings.TrimSpace(rendered)
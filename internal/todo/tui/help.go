@@ -87,16 +87,23 @@ func (m helpOverlayModel) View() string {
 	content.WriteString(shortcut("C", "Create issue or milestone") + "\n")
 	content.WriteString(shortcut("e", "Edit in editor") + "\n")
 	content.WriteString(shortcut("m", "Change milestone") + "\n")
-	content.WriteString(shortcut("o", "Sort order") + "\n")
+	content.WriteString(shortcut("o", "Sort order (list), open link (detail)") + "\n")
 	content.WriteString(shortcut("p", "Set parent") + "\n")
 	content.WriteString(shortcut("P", "Change priority") + "\n")
+	content.WriteString(shortcut("R", "Reload all issues from disk") + "\n")
 	content.WriteString(shortcut("s", "Change status") + "\n")
 	content.WriteString(shortcut("t", "Change type") + "\n")
+	content.WriteString(shortcut("w", "Show skipped files") + "\n")
 	content.WriteString(shortcut("z", "Collapse/expand") + "\n")
 	content.WriteString(shortcut("Z", "Collapse/expand all") + "\n")
 	content.WriteString(shortcut("/", "Filter by title") + "\n")
 	content.WriteString(shortcut("//", "Search title + body") + "\n")
 	content.WriteString(shortcut("g t", "Filter by tag") + "\n")
+	content.WriteString(shortcut("g i", "Filter by iteration") + "\n")
+	content.WriteString(shortcut("g d", "Dashboard") + "\n")
+	content.WriteString(shortcut("g r", "Triage, one issue at a time") + "\n")
+	content.WriteString(shortcut("g s", "Toggle split view") + "\n")
+	content.WriteString(shortcut("g p", "Pin/unpin issue(s)") + "\n")
 	content.WriteString(shortcut("q", "Quit") + "\n")
 	content.WriteString("\n")
 
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/toba/jig/internal/todo/stats"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// iterationSelectedMsg is sent when an iteration is chosen to filter the
+// list. An empty iteration clears the filter.
+type iterationSelectedMsg struct {
+	iteration string
+}
+
+// closeIterationPickerMsg is sent when the iteration picker is cancelled.
+type closeIterationPickerMsg struct{}
+
+// openIterationPickerMsg requests opening the iteration filter picker.
+type openIterationPickerMsg struct {
+	currentIteration string // the active filter, if any
+}
+
+// iterationItem wraps an iteration to implement list.Item.
+type iterationItem struct {
+	name        string // "" for the clear entry
+	description string
+	current     bool // the project's current iteration
+	isCurrent   bool // the active filter
+}
+
+func (i iterationItem) Title() string       { return i.name }
+func (i iterationItem) Description() string { return i.description }
+func (i iterationItem) FilterValue() string { return i.name }
+
+// iterationItemDelegate handles rendering of iteration picker items.
+type iterationItemDelegate struct{}
+
+func (d iterationItemDelegate) Height() int                             { return 1 }
+func (d iterationItemDelegate) Spacing() int                            { return 0 }
+func (d iterationItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d iterationItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(iterationItem)
+	if !ok {
+		return
+	}
+
+	cursor := renderPickerCursor(index, &m)
+	label := item.name
+	switch {
+	case item.name == "":
+		label = ui.Muted.Render("(none)")
+	case item.current:
+		label += " " + ui.Secondary.Render("(current)")
+	}
+	renderPickerItem(w, cursor, label, item.isCurrent)
+}
+
+// iterationPickerModel is the model for the iteration filter picker.
+type iterationPickerModel struct {
+	list   list.Model
+	width  int
+	height int
+}
+
+// newIterationPickerModel lists the iterations in rollup, described by
+// their progress and dates, after an entry that clears the filter.
+func newIterationPickerModel(currentIteration string, rollup []stats.IterationCount, width, height int) iterationPickerModel {
+	items := make([]list.Item, 0, len(rollup)+1)
+	selectedIndex := 0
+
+	items = append(items, iterationItem{isCurrent: currentIteration == ""})
+	for _, r := range rollup {
+		description := fmt.Sprintf("%d of %d completed", r.Completed, r.Committed)
+		if r.Start != "" && r.End != "" {
+			description += fmt.Sprintf(" · %s – %s", r.Start, r.End)
+		}
+		isCurrent := r.Iteration == currentIteration
+		if isCurrent {
+			selectedIndex = len(items)
+		}
+		items = append(items, iterationItem{
+			name:        r.Iteration,
+			description: description,
+			current:     r.Current,
+			isCurrent:   isCurrent,
+		})
+	}
+
+	dims := calculatePickerDimensions(width, height, defaultPickerDimensionConfig())
+
+	l := list.New(items, iterationItemDelegate{}, dims.ListWidth, dims.ListHeight)
+	l.Title = "Filter by Iteration"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(false)
+	l.SetShowPagination(false)
+	l.Filter = substringFilter
+	l.Styles.Title = listTitleStyle
+	l.Styles.TitleBar = lipgloss.NewStyle().Padding(0, 0, 0, 0)
+	l.Styles.Filter.Focused.Prompt = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
+	l.Styles.Filter.Blurred.Prompt = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
+	l.Styles.Filter.Cursor.Color = ui.ColorPrimary
+	l.Select(selectedIndex)
+
+	return iterationPickerModel{list: l, width: width, height: height}
+}
+
+func (m iterationPickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m iterationPickerModel) Update(msg tea.Msg) (iterationPickerModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		dims := calculatePickerDimensions(msg.Width, msg.Height, defaultPickerDimensionConfig())
+		m.list.SetSize(dims.ListWidth, dims.ListHeight)
+
+	case tea.KeyPressMsg:
+		if m.list.FilterState() != list.Filtering {
+			switch msg.String() {
+			case "enter":
+				if item, ok := m.list.SelectedItem().(iterationItem); ok {
+					return m, func() tea.Msg {
+						return iterationSelectedMsg{iteration: item.name}
+					}
+				}
+			case "esc", "backspace":
+				return m, func() tea.Msg {
+					return closeIterationPickerMsg{}
+				}
+			}
+		}
+	}
+
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m iterationPickerModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	var description string
+	if item, ok := m.list.SelectedItem().(iterationItem); ok {
+		description = item.description
+	}
+
+	return renderPickerModal(pickerModalConfig{
+		Title:       "Filter by Iteration",
+		ListContent: m.list.View(),
+		Description: description,
+		Width:       m.width,
+	})
+}
+
+// ModalView returns the picker rendered as a centered modal overlay on top of the background.
+func (m iterationPickerModel) ModalView(bgView string, fullWidth, fullHeight int) string {
+	modal := m.View()
+	return overlayModal(bgView, modal, fullWidth, fullHeight)
+}
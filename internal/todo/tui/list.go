@@ -12,9 +12,11 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/stats"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
@@ -53,9 +55,11 @@ func treeAwareFilter(flatItems *[]ui.FlatItem, _ *bool) func(string, []string) [
 
 		items := *flatItems
 
-		// Build set of already-matched indices
+		// Index direct matches so ancestors can be added without rescanning
+		matched := make(map[int][]int, len(ranks))
 		included := make(map[int]bool, len(ranks))
 		for _, r := range ranks {
+			matched[r.Index] = r.MatchedIndexes
 			included[r.Index] = true
 		}
 
@@ -84,17 +88,9 @@ func treeAwareFilter(flatItems *[]ui.FlatItem, _ *bool) func(string, []string) [
 			if !included[i] {
 				continue
 			}
-			// Find original rank if it was a direct match
-			var matchedIndexes []int
-			for _, r := range ranks {
-				if r.Index == i {
-					matchedIndexes = r.MatchedIndexes
-					break
-				}
-			}
 			result = append(result, list.Rank{
 				Index:          i,
-				MatchedIndexes: matchedIndexes,
+				MatchedIndexes: matched[i],
 			})
 		}
 
@@ -102,6 +98,16 @@ func treeAwareFilter(flatItems *[]ui.FlatItem, _ *bool) func(string, []string) [
 	}
 }
 
+// listWindowSize is how many rows the list hands to the list component at
+// first; listWindowBuffer is how close the cursor may come to the last of
+// them before more are added. Keeping the component's item count small keeps
+// each keypress cheap in large workspaces. Filtering materializes every row
+// so it searches the full set.
+const (
+	listWindowSize   = 300
+	listWindowBuffer = 100
+)
+
 // issueItem wraps an issue to implement list.Item, with tree context
 type issueItem struct {
 	issue      *issue.Issue
@@ -110,15 +116,66 @@ type issueItem struct {
 	matched    bool   // true if issue matched filter (vs. ancestor shown for context)
 	deepSearch *bool  // pointer to listModel.deepSearch
 	leafCount  int    // leaf descendant count (shown as badge when collapsed)
+	stale      bool   // not updated within stale_after
+	blocks     core.BlockCounts
+	pinEnd     bool // last row of the pinned group; a rule is drawn below it
+	resolved   bool // old closed issue, greyed out under collapse_resolved
 }
 
-func (i issueItem) Title() string       { return i.issue.Title }
-func (i issueItem) Description() string { return i.issue.ID + " · " + i.issue.Status }
+func (i issueItem) Title() string { return i.issue.Title }
+func (i issueItem) Description() string {
+	desc := i.issue.ID + " · " + i.issue.Status
+	if i.stale {
+		desc += " · stale"
+	}
+	if i.blocks.BlockedBy > 0 {
+		desc += " · " + ui.CountMark(ui.BlockedSymbol, i.blocks.BlockedBy)
+	}
+	if i.blocks.Blocking > 0 {
+		desc += " · " + ui.CountMark(ui.BlockingSymbol, i.blocks.Blocking)
+	}
+	if synopsis := i.issue.Synopsis(80); synopsis != "" {
+		desc += " · " + synopsis
+	}
+	return desc
+}
 func (i issueItem) FilterValue() string {
+	v := i.issue.Title + " " + i.issue.ID
+	if i.issue.Summary != "" {
+		v += " " + i.issue.Summary
+	}
 	if i.deepSearch != nil && *i.deepSearch {
-		return i.issue.Title + " " + i.issue.ID + " " + i.issue.Body
+		v += " " + i.issue.Body
+	}
+	return v
+}
+
+// resolvedGroupItem is the summary row for the old closed issues of one
+// status, which collapse_resolved gathers at the bottom of the list. It is
+// not an issue: enter expands or collapses it, and it cannot be marked.
+type resolvedGroupItem struct {
+	status   string
+	count    int // issues in the group, nested ones included
+	expanded bool
+}
+
+func (g resolvedGroupItem) FilterValue() string { return "" }
+
+// label is the row's text, e.g. "▸ 214 completed issues — press enter to
+// expand".
+func (g resolvedGroupItem) label() string {
+	symbol, action := "▸", "expand"
+	if g.expanded {
+		symbol, action = "▾", "collapse"
+	}
+	if ui.Accessible {
+		symbol = "[" + action + "]"
+	}
+	noun := "issues"
+	if g.count == 1 {
+		noun = "issue"
 	}
-	return i.issue.Title + " " + i.issue.ID
+	return fmt.Sprintf("%s %d %s %s — press enter to %s", symbol, g.count, g.status, noun, action)
 }
 
 // issueDueTime converts an *issue.DueDate to *time.Time for UI rendering.
@@ -147,6 +204,15 @@ func (d itemDelegate) Spacing() int                            { return 0 }
 func (d itemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 
 func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	if group, ok := listItem.(resolvedGroupItem); ok {
+		cursor, style := " ", ui.Muted
+		if index == m.Index() {
+			cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(ui.Glyph(ui.CursorSymbol))
+			style = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorPrimary)
+		}
+		fmt.Fprint(w, cursor+" "+style.Render(group.label())) //nolint:errcheck // terminal output
+		return
+	}
 	item, ok := listItem.(issueItem)
 	if !ok {
 		return
@@ -156,7 +222,7 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	// - BuildTree ancestors (item.matched == false)
 	// - Filter ancestors: when filter is active, items with no MatchedIndexes
 	//   were included only to preserve tree hierarchy
-	dimmed := !item.matched
+	dimmed := !item.matched || item.resolved
 	if !dimmed && m.FilterState() == list.FilterApplied {
 		dimmed = len(m.MatchesForItem(index)) == 0
 	}
@@ -193,6 +259,7 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		ui.IssueRowConfig{
 			StatusColor:    colors.StatusColor,
 			TypeColor:      colors.TypeColor,
+			TypeIcon:       colors.TypeIcon,
 			PriorityColor:  colors.PriorityColor,
 			Priority:       item.issue.Priority,
 			IsArchive:      colors.IsArchive,
@@ -211,9 +278,19 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 			LeafCount:      item.leafCount,
 			LeafColWidth:   d.leafColWidth,
 			MilestoneShort: d.milestoneShorts[item.issue.Milestone],
+			Stale:          item.stale,
+			Pinned:         item.issue.Pinned,
+			Internal:       item.issue.Visibility == config.VisibilityInternal,
+			BlockedCount:   item.blocks.BlockedBy,
+			BlockingCount:  item.blocks.Blocking,
+			Summary:        item.issue.Synopsis(0),
 		},
 	)
 
+	if item.pinEnd {
+		str += "\n" + ui.Muted.Render(strings.Repeat("─", max(0, m.Width()-2)))
+	}
+
 	fmt.Fprint(w, str) //nolint:errcheck // terminal output
 }
 
@@ -237,6 +314,8 @@ type listModel struct {
 	// Active filters
 	tagFilter       string // if set, only show issues with this tag
 	milestoneFilter string // if set, only show issues assigned to this milestone ID
+	iterationFilter string // if set, only show issues assigned to this iteration
+	statusFilter    string // if set, only show issues with this status
 
 	// Sort order
 	sortOrder sortOrder // current sort mode
@@ -252,11 +331,30 @@ type listModel struct {
 	leafCounts map[string]int  // root ID → leaf descendant count
 	firstLoad  bool            // true until first issuesLoadedMsg is processed
 
-	// Multi-select state
+	// Old closed issues under collapse_resolved, and the statuses whose
+	// group of them is expanded (kept for the session)
+	resolved         map[string]bool
+	expandedResolved map[string]bool
+
+	// Windowed rows: every visible row, of which the first window are
+	// materialized in the list component
+	allItems []list.Item
+	window   int
+
+	// Whether a rule separates pinned rows from the rest; it takes a line
+	hasPinRule bool
+
+	// Multi-select state (by ID, so marks survive paging and reloads)
 	selectedIssues map[string]bool // IDs of issues marked for multi-edit
 
 	// Status message to display in footer
 	statusMessage string
+
+	// Issue files the core skipped, shown as a footer indicator
+	loadWarnings []core.LoadWarning
+
+	// Workspace-wide counts, shown as a strip below the footer
+	summary stats.Summary
 }
 
 func newListModel(resolver *graph.Resolver, cfg *config.Config) listModel {
@@ -279,15 +377,16 @@ func newListModel(resolver *graph.Resolver, cfg *config.Config) listModel {
 	l.Styles.Filter.Cursor.Color = ui.ColorPrimary
 
 	return listModel{
-		list:           l,
-		resolver:       resolver,
-		config:         cfg,
-		sortOrder:      sortOrder(cfg.GetDefaultSort()),
-		deepSearch:     &deepSearch,
-		flatItems:      flatItems,
-		collapsed:      make(map[string]bool),
-		firstLoad:      true,
-		selectedIssues: selectedIssues,
+		list:             l,
+		resolver:         resolver,
+		config:           cfg,
+		sortOrder:        sortOrder(cfg.GetDefaultSort()),
+		deepSearch:       &deepSearch,
+		flatItems:        flatItems,
+		collapsed:        make(map[string]bool),
+		firstLoad:        true,
+		expandedResolved: make(map[string]bool),
+		selectedIssues:   selectedIssues,
 	}
 }
 
@@ -296,6 +395,15 @@ type issuesLoadedMsg struct {
 	items      []ui.FlatItem  // flattened tree items
 	idColWidth int            // calculated ID column width for tree
 	leafCounts map[string]int // root ID → leaf descendant count
+	// blockCounts holds active blocked/blocking counts by issue ID (nil when
+	// hide_block_indicators is set).
+	blockCounts map[string]core.BlockCounts
+	// warnings lists issue files the core skipped while loading.
+	warnings []core.LoadWarning
+	// summary counts every issue, regardless of the active filter.
+	summary stats.Summary
+	// resolved holds the IDs of old closed issues under collapse_resolved.
+	resolved map[string]bool
 }
 
 // errMsg is sent when an error occurs
@@ -313,9 +421,9 @@ func (m listModel) Init() tea.Cmd {
 }
 
 func (m listModel) loadIssues() tea.Msg {
-	// Build filter if a tag or milestone filter is set
+	// Build filter if a tag, milestone, iteration, or status filter is set
 	var filter *model.IssueFilter
-	if m.tagFilter != "" || m.milestoneFilter != "" {
+	if m.hasActiveFilter() {
 		filter = &model.IssueFilter{}
 		if m.tagFilter != "" {
 			filter.Tags = []string{m.tagFilter}
@@ -323,6 +431,12 @@ func (m listModel) loadIssues() tea.Msg {
 		if m.milestoneFilter != "" {
 			filter.Milestone = []string{m.milestoneFilter}
 		}
+		if m.iterationFilter != "" {
+			filter.Iteration = []string{m.iterationFilter}
+		}
+		if m.statusFilter != "" {
+			filter.Status = []string{m.statusFilter}
+		}
 	}
 
 	// Query filtered issues
@@ -368,11 +482,35 @@ func (m listModel) loadIssues() tea.Msg {
 		}
 	}
 
+	// Pinned issues float to the top whatever the sort order
+	sortGroup := sortFn
+	sortFn = func(issues []*issue.Issue) {
+		sortGroup(issues)
+		issue.PinnedFirst(issues)
+	}
+
+	var warnings []core.LoadWarning
+	now := time.Now()
+	var isBlocked func(string) bool
+	var isStale func(*issue.Issue) bool
+	if m.resolver.Core != nil {
+		warnings = m.resolver.Core.Warnings()
+		now, isBlocked, isStale = m.resolver.Core.Now(), m.resolver.Core.IsBlocked, m.resolver.Core.IsStale
+	}
+
 	// Build tree and flatten it
 	tree := ui.BuildTree(filteredIssues, allIssues, sortFn)
 	leafCounts := ui.LeafCounts(tree)
 	items := ui.FlattenTree(tree)
 
+	// Old closed issues are greyed out. Without a filter, the top-level ones
+	// also sink below everything else, whatever the sort order, to be
+	// gathered into a summary row per status.
+	resolved := m.resolvedIssues(allIssues, now)
+	if !m.hasActiveFilter() {
+		items = sinkResolved(items, resolved, m.config.StatusNames())
+	}
+
 	// Calculate ID column width based on max ID length and tree depth
 	maxIDLen := 0
 	for _, b := range allIssues {
@@ -387,30 +525,113 @@ func (m listModel) loadIssues() tea.Msg {
 		idColWidth += maxDepth * 3 // 3 chars per depth level (├─ + space)
 	}
 
-	return issuesLoadedMsg{items: items, idColWidth: idColWidth, leafCounts: leafCounts}
+	// Precompute link counts in one pass rather than resolving per item.
+	var blockCounts map[string]core.BlockCounts
+	if !m.config.HideBlockIndicators && m.resolver.Core != nil {
+		blockCounts = m.resolver.Core.AllBlockCounts()
+	}
+	summary := stats.Summarize(allIssues, m.config, now, isBlocked, isStale)
+
+	return issuesLoadedMsg{items: items, idColWidth: idColWidth, leafCounts: leafCounts, blockCounts: blockCounts, warnings: warnings, summary: summary, resolved: resolved}
+}
+
+// resolvedIssues returns the IDs of the issues collapse_resolved greys out,
+// or nil when it is off. Pinned issues stay as they are.
+func (m listModel) resolvedIssues(issues []*issue.Issue, now time.Time) map[string]bool {
+	if _, on := m.config.GetCollapseResolved(); !on {
+		return nil
+	}
+	resolved := make(map[string]bool)
+	for _, b := range issues {
+		ts := b.UpdatedAt
+		if ts == nil {
+			ts = b.CreatedAt
+		}
+		var updated time.Time
+		if ts != nil {
+			updated = *ts
+		}
+		if !b.Pinned && m.config.IsCollapsedResolved(b.Status, updated, now) {
+			resolved[b.ID] = true
+		}
+	}
+	return resolved
+}
+
+// sinkResolved moves the resolved roots, with everything under them, to the
+// end of items, grouped by status in the order of statuses. Each group keeps
+// the sort order it had.
+func sinkResolved(items []ui.FlatItem, resolved map[string]bool, statuses []string) []ui.FlatItem {
+	if len(resolved) == 0 {
+		return items
+	}
+	status := make(map[string]string) // resolved root ID → status
+	for _, fi := range items {
+		if fi.Depth == 0 && resolved[fi.Issue.ID] {
+			status[fi.RootID] = fi.Issue.Status
+		}
+	}
+	if len(status) == 0 {
+		return items
+	}
+	result := make([]ui.FlatItem, 0, len(items))
+	groups := make(map[string][]ui.FlatItem)
+	for _, fi := range items {
+		if s, ok := status[fi.RootID]; ok {
+			groups[s] = append(groups[s], fi)
+		} else {
+			result = append(result, fi)
+		}
+	}
+	for _, s := range statuses {
+		result = append(result, groups[s]...)
+	}
+	return result
 }
 
-// setTagFilter sets the tag filter (and clears any milestone filter)
+// setTagFilter sets the tag filter (and clears any other filter)
 func (m *listModel) setTagFilter(tag string) {
+	m.clearFilter()
 	m.tagFilter = tag
-	m.milestoneFilter = ""
 }
 
-// setMilestoneFilter sets the milestone filter (and clears any tag filter)
+// setMilestoneFilter sets the milestone filter (and clears any other filter)
 func (m *listModel) setMilestoneFilter(milestoneID string) {
+	m.clearFilter()
 	m.milestoneFilter = milestoneID
-	m.tagFilter = ""
+}
+
+// setIterationFilter sets the iteration filter (and clears any other filter)
+func (m *listModel) setIterationFilter(iteration string) {
+	m.clearFilter()
+	m.iterationFilter = iteration
+}
+
+// setStatusFilter sets the status filter (and clears any other filter)
+func (m *listModel) setStatusFilter(status string) {
+	m.clearFilter()
+	m.statusFilter = status
 }
 
 // clearFilter clears all active filters
 func (m *listModel) clearFilter() {
 	m.tagFilter = ""
 	m.milestoneFilter = ""
+	m.iterationFilter = ""
+	m.statusFilter = ""
 }
 
 // hasActiveFilter returns true if any filter is active
 func (m *listModel) hasActiveFilter() bool {
-	return m.tagFilter != "" || m.milestoneFilter != ""
+	return m.tagFilter != "" || m.milestoneFilter != "" || m.iterationFilter != "" || m.statusFilter != ""
+}
+
+// highlighted returns the issue under the cursor, or nil when the list is empty.
+func (m listModel) highlighted() *issue.Issue {
+	if item, ok := m.list.SelectedItem().(issueItem); ok {
+		return item.issue
+	}
+	return nil
 }
 
 func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
@@ -420,9 +641,7 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		// Reserve space for border (2 chars each side in lipgloss v2 where
-		// Width/Height include border) and footer lines.
-		m.list.SetSize(msg.Width-4, msg.Height-6)
+		m.resizeList()
 		// Recalculate responsive columns using content width inside border
 		m.cols = ui.CalculateResponsiveColumns(msg.Width-4, m.hasTags)
 		m.updateDelegate()
@@ -433,6 +652,9 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 			*m.flatItems = msg.items
 		}
 		m.leafCounts = msg.leafCounts
+		m.loadWarnings = msg.warnings
+		m.summary = msg.summary
+		m.resolved = msg.resolved
 
 		// On first load, collapse all roots that have children
 		if m.firstLoad {
@@ -444,11 +666,12 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 			m.firstLoad = false
 		}
 
-		// Apply collapse filtering
-		visible := m.applyCollapse(msg.items)
+		// Apply collapse filtering, then gather old closed issues into
+		// their summary rows
+		visible, summaries := m.groupResolved(m.applyCollapse(msg.items), msg.items)
 
 		// Skip SetItems if nothing changed — avoids resetting filter UI state
-		if m.itemsUnchanged(visible) {
+		if m.itemsUnchanged(visible, summaries, msg.blockCounts) {
 			return m, nil
 		}
 
@@ -460,13 +683,18 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 			}
 		}
 
-		items := make([]list.Item, len(visible))
+		items := make([]list.Item, 0, len(visible)+len(summaries))
+		pinEnd := pinnedGroupEnd(visible)
 		// Check if any issues have tags, compute the leaf column width, and the
 		// widest "<short>:" milestone prefix so it can be folded into the ID column.
 		m.hasTags = false
 		m.leafColWidth = 0
 		maxMsPrefix := 0
+		next := 0 // next summary row to place
 		for i, flatItem := range visible {
+			for ; next < len(summaries) && summaries[next].at == i; next++ {
+				items = append(items, summaries[next].row)
+			}
 			if short := m.milestoneShorts[flatItem.Issue.Milestone]; short != "" {
 				if w := len(short) + 1; w > maxMsPrefix { // +1 for the ":" separator
 					maxMsPrefix = w
@@ -479,19 +707,30 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 					m.leafColWidth = w
 				}
 			}
-			items[i] = issueItem{
+			items = append(items, issueItem{
 				issue:      flatItem.Issue,
 				cfg:        m.config,
 				treePrefix: flatItem.TreePrefix,
 				matched:    flatItem.Matched,
 				deepSearch: m.deepSearch,
 				leafCount:  lc,
-			}
+				stale:      m.resolver != nil && m.resolver.Core != nil && m.resolver.Core.IsStale(flatItem.Issue),
+				blocks:     msg.blockCounts[flatItem.Issue.ID],
+				pinEnd:     i == pinEnd,
+				resolved:   m.resolved[flatItem.Issue.ID],
+			})
 			if len(flatItem.Issue.Tags) > 0 {
 				m.hasTags = true
 			}
 		}
-		cmd = m.list.SetItems(items)
+		for _, sr := range summaries[next:] {
+			items = append(items, sr.row)
+		}
+		m.allItems = items
+		m.hasPinRule = pinEnd >= 0
+		m.resizeList()
+		m.window = max(m.window, listWindowSize)
+		cmd = m.list.SetItems(m.windowItems())
 		// ID column must fit the tree prefix, the optional "<short>:" milestone
 		// prefix, and the ID itself.
 		m.fullIDColWidth = msg.idColWidth + maxMsPrefix
@@ -523,6 +762,14 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyPressMsg:
+		// Filtering and jumping to the end need every row
+		if m.list.FilterState() == list.Unfiltered {
+			switch msg.String() {
+			case "/", "G", "end":
+				m.materialize(len(m.allItems))
+			}
+		}
+
 		// Toggle deep search: "/" while filtering with empty input
 		if m.list.FilterState() == list.Filtering && msg.String() == "/" {
 			if m.list.FilterInput.Value() == "" {
@@ -539,6 +786,11 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 		if m.list.FilterState() != list.Filtering {
 			switch msg.String() {
 			case "space":
+				// A summary row cannot be marked; move past it
+				if _, ok := m.list.SelectedItem().(resolvedGroupItem); ok {
+					m.list.CursorDown()
+					return m, nil
+				}
 				// Toggle selection for multi-select, then move to next item
 				if item, ok := m.list.SelectedItem().(issueItem); ok {
 					if m.selectedIssues[item.issue.ID] {
@@ -550,6 +802,11 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 				}
 				return m, nil
 			case "enter":
+				// Expand or collapse a group of old closed issues
+				if group, ok := m.list.SelectedItem().(resolvedGroupItem); ok {
+					m.expandedResolved[group.status] = !group.expanded
+					return m, m.rebuildVisibleItems
+				}
 				if item, ok := m.list.SelectedItem().(issueItem); ok {
 					return m, func() tea.Msg {
 						return selectIssueMsg{issue: item.issue}
@@ -564,7 +821,7 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 					for id := range m.selectedIssues {
 						ids = append(ids, id)
 						// Find the issue to get its type
-						for _, item := range m.list.Items() {
+						for _, item := range m.allItems {
 							if bi, ok := item.(issueItem); ok && bi.issue.ID == id {
 								types = append(types, bi.issue.Type)
 								break
@@ -690,6 +947,11 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 						}
 					}
 				}
+			case "w":
+				// Show the files skipped while loading
+				if len(m.loadWarnings) > 0 {
+					return m, func() tea.Msg { return openWarningsMsg{} }
+				}
 			case "o":
 				// Open sort order picker
 				return m, func() tea.Msg {
@@ -783,6 +1045,11 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 	// Always forward to the list component
 	m.list, cmd = m.list.Update(msg)
 
+	// Add rows as the cursor nears the end of those materialized
+	if m.list.FilterState() == list.Unfiltered && m.list.Index() >= m.window-listWindowBuffer {
+		m.materialize(m.window + listWindowSize)
+	}
+
 	// Reset deep search if filtering ended
 	if m.list.FilterState() == list.Unfiltered && *m.deepSearch {
 		*m.deepSearch = false
@@ -792,6 +1059,16 @@ func (m listModel) Update(msg tea.Msg) (listModel, tea.Cmd) {
 	return m, cmd
 }
 
+// setConfig switches the list to cfg. A list still on the old default sort
+// follows the new one; a sort the user picked is kept.
+func (m *listModel) setConfig(cfg *config.Config) {
+	if m.sortOrder == sortOrder(m.config.GetDefaultSort()) {
+		m.sortOrder = sortOrder(cfg.GetDefaultSort())
+	}
+	m.config = cfg
+	m.updateDelegate()
+}
+
 // updateDelegate updates the list delegate with current responsive columns
 func (m *listModel) updateDelegate() {
 	delegate := itemDelegate{
@@ -807,29 +1084,93 @@ func (m *listModel) updateDelegate() {
 	m.list.SetDelegate(delegate)
 }
 
-// itemsUnchanged returns true if the new flat items match what's currently displayed.
+// windowItems returns the rows to hand to the list component: all of them
+// while a filter is in use, otherwise the first m.window.
+func (m listModel) windowItems() []list.Item {
+	if m.list.FilterState() != list.Unfiltered {
+		return m.allItems
+	}
+	return m.allItems[:min(m.window, len(m.allItems))]
+}
+
+// materialize grows the window to n rows, keeping the cursor in place.
+func (m *listModel) materialize(n int) {
+	if m.window >= len(m.allItems) || n <= m.window {
+		return
+	}
+	m.window = n
+	index := m.list.Index()
+	m.list.SetItems(m.windowItems())
+	m.list.Select(index)
+}
+
+// itemsUnchanged returns true if the new flat items and summary rows match
+// what's currently displayed.
+// Block counts are compared too, since resolving a blocker changes the counts
+// of issues whose own etags stay the same.
 // This avoids calling SetItems which resets the Bubble Tea filter UI state.
-func (m listModel) itemsUnchanged(newItems []ui.FlatItem) bool {
-	current := m.list.Items()
-	if len(current) != len(newItems) {
+func (m listModel) itemsUnchanged(newItems []ui.FlatItem, summaries []resolvedSummary, blockCounts map[string]core.BlockCounts) bool {
+	current := m.allItems
+	if len(current) != len(newItems)+len(summaries) {
 		return false
 	}
-	for i, item := range current {
+	i, next := 0, 0
+	for _, item := range current {
+		if next < len(summaries) && summaries[next].at == i {
+			if item != list.Item(summaries[next].row) {
+				return false
+			}
+			next++
+			continue
+		}
 		ii, ok := item.(issueItem)
 		if !ok {
 			return false
 		}
 		ni := newItems[i]
+		i++
 		if ii.issue.ID != ni.Issue.ID ||
 			ii.issue.ETag() != ni.Issue.ETag() ||
 			ii.treePrefix != ni.TreePrefix ||
-			ii.matched != ni.Matched {
+			ii.matched != ni.Matched ||
+			ii.blocks != blockCounts[ni.Issue.ID] ||
+			ii.resolved != m.resolved[ni.Issue.ID] {
 			return false
 		}
 	}
 	return true
 }
 
+// resizeList sizes the list component to the window, reserving space for the
+// border (2 chars each side in lipgloss v2 where Width/Height include border),
+// the footer lines, and the pinned-group rule when one is shown.
+func (m *listModel) resizeList() {
+	height := m.height - 6
+	if m.hasPinRule {
+		height--
+	}
+	m.list.SetSize(m.width-4, max(0, height))
+}
+
+// pinnedGroupEnd returns the index of the last row under a pinned root when
+// unpinned roots follow it, or -1 when there is no boundary to draw.
+func pinnedGroupEnd(items []ui.FlatItem) int {
+	end := -1
+	rootPinned := false
+	for i, fi := range items {
+		if fi.Depth == 0 {
+			if !fi.Issue.Pinned && rootPinned {
+				return end
+			}
+			rootPinned = fi.Issue.Pinned
+		}
+		if rootPinned {
+			end = i
+		}
+	}
+	return -1
+}
+
 // applyCollapse filters out descendants of collapsed root items.
 // When filtering is active, collapse is bypassed so that matched children
 // inside collapsed parents remain visible.
@@ -851,6 +1192,52 @@ func (m listModel) applyCollapse(items []ui.FlatItem) []ui.FlatItem {
 	return result
 }
 
+// resolvedSummary places a summary row before visible row at (or after the
+// last row when at is past it).
+type resolvedSummary struct {
+	at  int
+	row resolvedGroupItem
+}
+
+// groupResolved drops the rows of collapsed groups of old closed issues from
+// visible, and returns where each group's summary row goes. all holds every
+// row, so the counts do not change as trees are collapsed with z. Groups are
+// contiguous because loadIssues sank them to the bottom; while a filter is in
+// use nothing is grouped.
+func (m listModel) groupResolved(visible, all []ui.FlatItem) ([]ui.FlatItem, []resolvedSummary) {
+	if len(m.resolved) == 0 || m.hasActiveFilter() || m.list.FilterState() != list.Unfiltered {
+		return visible, nil
+	}
+	status := make(map[string]string) // resolved root ID → status
+	counts := make(map[string]int)
+	for _, fi := range all {
+		if fi.Depth == 0 && m.resolved[fi.Issue.ID] {
+			status[fi.RootID] = fi.Issue.Status
+		}
+		if s, ok := status[fi.RootID]; ok {
+			counts[s]++
+		}
+	}
+	if len(status) == 0 {
+		return visible, nil
+	}
+	result := make([]ui.FlatItem, 0, len(visible))
+	var summaries []resolvedSummary
+	for _, fi := range visible {
+		s, grouped := status[fi.RootID]
+		if grouped && (len(summaries) == 0 || summaries[len(summaries)-1].row.status != s) {
+			summaries = append(summaries, resolvedSummary{
+				at:  len(result),
+				row: resolvedGroupItem{status: s, count: counts[s], expanded: m.expandedResolved[s]},
+			})
+		}
+		if !grouped || m.expandedResolved[s] {
+			result = append(result, fi)
+		}
+	}
+	return result, summaries
+}
+
 // rebuildVisibleItems returns a tea.Cmd that rebuilds the visible item list
 // from the stored flat items without re-querying issues.
 func (m *listModel) rebuildVisibleItems() tea.Msg {
@@ -861,6 +1248,9 @@ func (m *listModel) rebuildVisibleItems() tea.Msg {
 		items:      *m.flatItems,
 		idColWidth: m.fullIDColWidth,
 		leafCounts: m.leafCounts,
+		warnings:   m.loadWarnings,
+		summary:    m.summary,
+		resolved:   m.resolved,
 	}
 }
 
@@ -883,6 +1273,10 @@ func (m listModel) View() string {
 			label = short
 		}
 		m.list.Title = fmt.Sprintf("Issues [milestone: %s]", label)
+	case m.iterationFilter != "":
+		m.list.Title = fmt.Sprintf("Issues [iteration: %s]", m.iterationFilter)
+	case m.statusFilter != "":
+		m.list.Title = fmt.Sprintf("Issues [status: %s]", m.statusFilter)
 	default:
 		m.list.Title = "Issues"
 	}
@@ -949,10 +1343,21 @@ func (m listModel) View() string {
 			helpKeyStyle.Render("z") + " " + helpStyle.Render("collapse") + "  " +
 			helpKeyStyle.Render("/") + " " + helpStyle.Render("filter") + "  " +
 			helpKeyStyle.Render("g m") + " " + helpStyle.Render("filter milestone") + "  " +
+			helpKeyStyle.Render("g i") + " " + helpStyle.Render("filter iteration") + "  " +
+			helpKeyStyle.Render("g d") + " " + helpStyle.Render("dashboard") + "  " +
+			helpKeyStyle.Render("g s") + " " + helpStyle.Render("split") + "  " +
+			helpKeyStyle.Render("g p") + " " + helpStyle.Render("pin") + "  " +
 			helpKeyStyle.Render("?") + " " + helpStyle.Render("help") + "  " +
 			helpKeyStyle.Render("q") + " " + helpStyle.Render("quit")
 	}
 
+	// Flag skipped files ahead of the help so they are not missed
+	if len(m.loadWarnings) > 0 {
+		warnStyle := lipgloss.NewStyle().Foreground(ui.ColorWarning).Bold(true)
+		selectionPrefix = warnStyle.Render(skippedFilesLabel(len(m.loadWarnings))) + " " +
+			helpKeyStyle.Render("w") + " " + helpStyle.Render("show") + "  " + selectionPrefix
+	}
+
 	// Show status message if present, otherwise show help
 	footer := selectionPrefix
 	if m.statusMessage != "" {
@@ -962,5 +1367,6 @@ func (m listModel) View() string {
 		footer += help
 	}
 
-	return content + "\n" + footer
+	strip := lipgloss.NewStyle().MaxWidth(m.width).Render(renderStatsStrip(m.summary))
+	return content + "\n" + footer + "\n" + strip
 }
@@ -1,11 +1,19 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/ui"
 )
@@ -422,6 +430,7 @@ func TestIssuesLoadedMsgSkipsUnchangedItems(t *testing.T) {
 		issueItem{issue: &issue.Issue{ID: "3", Title: "Update docs"}, cfg: cfg, matched: true, deepSearch: &deepSearch},
 	}
 	m.list.SetItems(initialItems)
+	m.allItems = initialItems
 
 	t.Run("skips SetItems when items unchanged and filter active", func(t *testing.T) {
 		// Simulate an active filter
@@ -504,6 +513,13 @@ func TestIssueItemFilterValue(t *testing.T) {
 		}
 	})
 
+	t.Run("summary is always searchable", func(t *testing.T) {
+		item2 := issueItem{issue: &issue.Issue{ID: "x", Title: "T", Summary: "one liner"}}
+		if got := item2.FilterValue(); got != "T x one liner" {
+			t.Errorf("got %q, want %q", got, "T x one liner")
+		}
+	})
+
 	t.Run("deep search pointer invalidated by value copy (reproduces bug)", func(t *testing.T) {
 		// This test reproduces the actual bug: listModel has `deepSearch bool`
 		// as a plain field, and items store `&m.deepSearch`. When the model is
@@ -715,3 +731,229 @@ func TestTreeAwareFilterDeepHierarchy(t *testing.T) {
 		t.Errorf("t1: expected index 2 with non-nil matches, got index %d matches %v", ranks[2].Index, ranks[2].MatchedIndexes)
 	}
 }
+
+// newLargeListModel returns a list showing n generated issues, "iss-0000"
+// onward.
+func newLargeListModel(t *testing.T, n int) listModel {
+	t.Helper()
+	m := newListModel(&graph.Resolver{}, config.Default())
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	items := make([]ui.FlatItem, n)
+	for i := range items {
+		id := fmt.Sprintf("iss-%04d", i)
+		items[i] = ui.FlatItem{
+			Issue:   &issue.Issue{ID: id, Title: fmt.Sprintf("Generated issue %d", i), Status: "ready", Type: "task"},
+			RootID:  id,
+			Matched: true,
+		}
+	}
+	m, _ = m.Update(issuesLoadedMsg{items: items, idColWidth: 10})
+	return m
+}
+
+func TestListWindowsLargeWorkspaces(t *testing.T) {
+	m := newLargeListModel(t, 5000)
+	if got := len(m.list.Items()); got != listWindowSize {
+		t.Fatalf("materialized %d rows, want %d", got, listWindowSize)
+	}
+
+	// Mark a row, then page past the window: more rows are added and the
+	// mark survives.
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	for range listWindowSize {
+		m, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	}
+	if got := len(m.list.Items()); got <= listWindowSize {
+		t.Errorf("materialized %d rows after moving past the window, want more than %d", got, listWindowSize)
+	}
+	if got := m.list.Index(); got != listWindowSize+1 {
+		t.Errorf("cursor at %d, want %d", got, listWindowSize+1)
+	}
+	if !m.selectedIssues["iss-0000"] {
+		t.Error("mark on iss-0000 lost while paging")
+	}
+
+	// Jumping to the end materializes everything.
+	m, _ = m.Update(tea.KeyPressMsg{Code: 'G', Text: "G"})
+	if got := len(m.list.Items()); got != 5000 {
+		t.Errorf("materialized %d rows after G, want 5000", got)
+	}
+	if item, ok := m.list.SelectedItem().(issueItem); !ok || item.issue.ID != "iss-4999" {
+		t.Errorf("G selected %v, want iss-4999", m.list.SelectedItem())
+	}
+}
+
+func TestListSearchFindsRowsBeyondWindow(t *testing.T) {
+	m := newLargeListModel(t, 5000)
+
+	m, _ = m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	m.list.SetFilterText("iss-2500")
+	m.list.SetFilterState(list.FilterApplied)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on the filtered list returned no command")
+	}
+	msg, ok := cmd().(selectIssueMsg)
+	if !ok || msg.issue.ID != "iss-2500" {
+		t.Errorf("enter selected %v, want iss-2500", msg.issue)
+	}
+}
+
+func TestListUpdateLargeWorkspaceIsFast(t *testing.T) {
+	items := make([]ui.FlatItem, 5000)
+	for i := range items {
+		id := fmt.Sprintf("iss-%04d", i)
+		items[i] = ui.FlatItem{Issue: &issue.Issue{ID: id, Title: "Generated issue", Status: "ready"}, RootID: id, Matched: true}
+	}
+	m := newListModel(&graph.Resolver{}, config.Default())
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	start := time.Now()
+	m, _ = m.Update(issuesLoadedMsg{items: items, idColWidth: 10})
+	loaded := time.Since(start)
+
+	const keys = 200
+	start = time.Now()
+	for range keys {
+		m, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+		_ = m.View()
+	}
+	perKey := time.Since(start) / keys
+	t.Logf("5000 issues: load %v, %v per keypress", loaded, perKey)
+
+	// Generous bounds: this guards against regressions to per-keypress
+	// work proportional to the workspace, not against slow CI machines.
+	if loaded > time.Second {
+		t.Errorf("loading 5000 issues took %v, want under 1s", loaded)
+	}
+	if perKey > 50*time.Millisecond {
+		t.Errorf("keypress with 5000 issues took %v, want under 50ms", perKey)
+	}
+}
+
+// listRows describes the list's rows: issue IDs, with a "~" suffix on
+// greyed-out ones, and "[status count]" for summary rows.
+func listRows(m listModel) []string {
+	var rows []string
+	for _, item := range m.allItems {
+		switch item := item.(type) {
+		case issueItem:
+			id := item.issue.ID
+			if item.resolved {
+				id += "~"
+			}
+			rows = append(rows, id)
+		case resolvedGroupItem:
+			rows = append(rows, fmt.Sprintf("[%s %d]", item.status, item.count))
+		}
+	}
+	return rows
+}
+
+func TestListCollapsesResolvedIssues(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), ".issues")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	cfg.TUI.CollapseResolved = "true"
+	c := core.New(dataDir, cfg)
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*issue.Issue{
+		{ID: "rdy-1", Title: "Open work", Status: "ready", Type: "task", Priority: "low"},
+		{ID: "cmp-1", Title: "Done one", Status: "completed", Type: "task", Priority: "critical"},
+		{ID: "cmp-2", Title: "Done two", Status: "completed", Type: "task", Priority: "critical"},
+	} {
+		if err := c.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := New(c, cfg)
+	app.width, app.height = 100, 30
+	app.list.width, app.list.height = 100, 30
+	app.list.list.SetSize(96, 24)
+	// Sorted by priority the completed issues would come first; the group
+	// stays at the bottom anyway.
+	app.list.sortOrder = sortPriority
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		model, cmd := app.Update(msg)
+		app = model.(*App)
+		return cmd
+	}
+	update(app.list.loadIssues())
+
+	if got, want := listRows(app.list), []string{"rdy-1", "[completed 2]"}; !slices.Equal(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	if view := app.list.View(); !strings.Contains(view, "▸ 2 completed issues — press enter to expand") {
+		t.Errorf("view has no collapsed summary row:\n%s", view)
+	}
+
+	// space on the summary row marks nothing and moves on
+	app.list.list.Select(1)
+	update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if len(app.list.selectedIssues) != 0 {
+		t.Errorf("space on the summary row marked %v", app.list.selectedIssues)
+	}
+
+	// enter expands the group in place; its issues are greyed out
+	app.list.list.Select(1)
+	cmd := update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on the summary row returned no command")
+	}
+	update(cmd())
+	if got, want := listRows(app.list), []string{"rdy-1", "[completed 2]", "cmp-1~", "cmp-2~"}; !slices.Equal(got, want) {
+		t.Fatalf("expanded rows = %v, want %v", got, want)
+	}
+	if view := app.list.View(); !strings.Contains(view, "▾ 2 completed issues — press enter to collapse") {
+		t.Errorf("view has no expanded summary row:\n%s", view)
+	}
+	if app.state != viewList {
+		t.Errorf("enter on the summary row left the list for state %v", app.state)
+	}
+
+	// The count follows changes on disk, and the group stays expanded.
+	if err := c.Create(&issue.Issue{ID: "cmp-3", Title: "Done three", Status: "completed", Type: "task"}); err != nil {
+		t.Fatal(err)
+	}
+	update(update(issuesChangedMsg{changedIDs: map[string]bool{"cmp-3": true}})())
+	if got, want := listRows(app.list), []string{"rdy-1", "[completed 3]", "cmp-1~", "cmp-2~", "cmp-3~"}; !slices.Equal(got, want) {
+		t.Errorf("rows after a change = %v, want %v", got, want)
+	}
+
+	// enter again collapses it
+	app.list.list.Select(1)
+	update(update(tea.KeyPressMsg{Code: tea.KeyEnter})())
+	if got, want := listRows(app.list), []string{"rdy-1", "[completed 3]"}; !slices.Equal(got, want) {
+		t.Errorf("collapsed rows = %v, want %v", got, want)
+	}
+}
+
+func TestSinkResolved(t *testing.T) {
+	item := func(id, status string, depth int, root string) ui.FlatItem {
+		return ui.FlatItem{Issue: &issue.Issue{ID: id, Status: status}, Depth: depth, RootID: root, Matched: true}
+	}
+	items := []ui.FlatItem{
+		item("scr-1", "scrapped", 0, "scr-1"),
+		item("cmp-1", "completed", 0, "cmp-1"),
+		item("cmp-1a", "ready", 1, "cmp-1"),
+		item("rdy-1", "ready", 0, "rdy-1"),
+		item("rdy-1a", "completed", 1, "rdy-1"), // greyed out, but stays under its open parent
+		item("cmp-2", "completed", 0, "cmp-2"),
+	}
+	resolved := map[string]bool{"scr-1": true, "cmp-1": true, "rdy-1a": true, "cmp-2": true}
+
+	var got []string
+	for _, fi := range sinkResolved(items, resolved, []string{"ready", "completed", "scrapped"}) {
+		got = append(got, fi.Issue.ID)
+	}
+	if want := []string{"rdy-1", "rdy-1a", "cmp-1", "cmp-1a", "cmp-2", "scr-1"}; !slices.Equal(got, want) {
+		t.Errorf("sinkResolved() = %v, want %v", got, want)
+	}
+}
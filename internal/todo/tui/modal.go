@@ -23,6 +23,7 @@ type pickerModalConfig struct {
 	IssueID     string // the issue's ID
 	ListContent string // the rendered list
 	Description string // optional description shown below list
+	Help        string // replaces the default select/filter/cancel help footer
 	Width       int    // screen width
 	WidthPct    int    // modal width percentage (default 50)
 	MaxWidth    int    // max modal width (default 60)
@@ -54,9 +55,12 @@ func renderPickerModal(cfg pickerModalConfig) string {
 	subtitle := ui.Muted.Render(cfg.IssueID)
 
 	// Help footer
-	help := helpKeyStyle.Render("enter") + " " + helpStyle.Render("select") + "  " +
-		helpKeyStyle.Render("/") + " " + helpStyle.Render("filter") + "  " +
-		helpKeyStyle.Render("esc") + " " + helpStyle.Render("cancel")
+	help := cfg.Help
+	if help == "" {
+		help = helpKeyStyle.Render("enter") + " " + helpStyle.Render("select") + "  " +
+			helpKeyStyle.Render("/") + " " + helpStyle.Render("filter") + "  " +
+			helpKeyStyle.Render("esc") + " " + helpStyle.Render("cancel")
+	}
 
 	// Border style
 	border := lipgloss.NewStyle().
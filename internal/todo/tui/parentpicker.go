@@ -9,10 +9,10 @@ import (
 	"strings"
 
 	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/toba/jig/internal/todo/config"
-	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/issue"
 	"github.com/toba/jig/internal/todo/ui"
@@ -27,6 +27,14 @@ type parentSelectedMsg struct {
 // closeParentPickerMsg is sent when the parent picker is cancelled
 type closeParentPickerMsg struct{}
 
+// parentCreateRequestedMsg is sent when a new parent is named in the parent
+// picker; the app creates it and assigns it to the issues.
+type parentCreateRequestedMsg struct {
+	issueIDs  []string // the issues being modified
+	issueType string   // type of the parent to create
+	title     string
+}
+
 // parentItem wraps an issue to implement list.Item for the parent picker
 type parentItem struct {
 	issue *issue.Issue
@@ -44,6 +52,17 @@ func (i clearParentItem) Title() string       { return "(No Parent)" }
 func (i clearParentItem) Description() string { return "Clear the parent assignment" }
 func (i clearParentItem) FilterValue() string { return "no parent clear none" }
 
+// createParentItem is a special item that creates a new parent inline
+type createParentItem struct {
+	typeName string
+}
+
+func (i createParentItem) Title() string { return "+ Create new " + i.typeName + "…" }
+func (i createParentItem) Description() string {
+	return "Create a new " + i.typeName + " and make it the parent"
+}
+func (i createParentItem) FilterValue() string { return "create new " + i.typeName }
+
 // parentItemDelegate handles rendering of parent picker items
 type parentItemDelegate struct {
 	cfg *config.Config
@@ -56,12 +75,16 @@ func (d parentItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return ni
 func (d parentItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	var cursor string
 	if index == m.Index() {
-		cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render("▌") + " "
+		cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(ui.Glyph(ui.CursorSymbol)) + " "
 	} else {
 		cursor = "  "
 	}
 
 	switch item := listItem.(type) {
+	case createParentItem:
+		text := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Render(item.Title())
+		fmt.Fprint(w, cursor+text) //nolint:errcheck // terminal output
+
 	case clearParentItem:
 		text := ui.Muted.Render(item.Title())
 		fmt.Fprint(w, cursor+text) //nolint:errcheck // terminal output
@@ -71,7 +94,7 @@ func (d parentItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 		colors := d.cfg.GetIssueColors(item.issue.Status, item.issue.Type, item.issue.Priority)
 
 		// Format: [type] title (id)
-		typeBadge := ui.RenderTypeText(item.issue.Type, colors.TypeColor)
+		typeBadge := ui.RenderTypeLabel(item.issue.Type, colors.TypeIcon, colors.TypeColor)
 		title := item.issue.Title
 		if colors.IsArchive {
 			title = ui.Muted.Render(title)
@@ -89,88 +112,38 @@ type parentPickerModel struct {
 	issueTitle    string   // display title (single title or "N selected issues")
 	issueTypes    []string // types of the issues (to filter eligible parents)
 	currentParent string   // current parent ID (to highlight, only for single issue)
+	createType    string   // type offered by the "create new" item ("" = not offered)
 	width         int
 	height        int
+
+	// Inline creation state, entered from the "create new" item
+	creating   bool
+	titleInput textinput.Model
+	errText    string
 }
 
 func newParentPickerModel(issueIDs []string, issueTitle string, issueTypes []string, currentParent string, resolver *graph.Resolver, cfg *config.Config, width, height int) parentPickerModel {
-	// Get valid parent types - for multi-select, find types valid for all issues
-	var validParentTypes []string
-	for i, issueType := range issueTypes {
-		typeParents := core.ValidParentTypes(issueType)
-		if i == 0 {
-			validParentTypes = typeParents
-		} else {
-			// Intersect with existing valid types
-			validParentTypes = intersectStrings(validParentTypes, typeParents)
-		}
-	}
-
 	// Fetch all issues and filter to eligible parents
 	allIssues, _ := resolver.Query().Issues(context.Background(), nil)
+	eligibleIssues := eligibleParents(issueIDs, issueTypes, allIssues, cfg)
 
-	// Collect all descendants of all selected issues (to prevent cycles)
-	allDescendants := make(map[string]bool)
-	for _, issueID := range issueIDs {
-		for descID := range collectDescendants(issueID, allIssues) {
-			allDescendants[descID] = true
-		}
-	}
-
-	// Create set of selected issue IDs for quick lookup
-	selectedSet := make(map[string]bool)
-	for _, id := range issueIDs {
-		selectedSet[id] = true
-	}
-
-	// Filter to eligible parents:
-	// 1. Must be of a valid parent type for ALL selected issues
-	// 2. Must not be any of the selected issues
-	// 3. Must not be a descendant of any selected issue (to prevent cycles)
-	var eligibleIssues []*issue.Issue
-	for _, b := range allIssues {
-		// Skip selected issues
-		if selectedSet[b.ID] {
-			continue
-		}
-		// Skip descendants (would create cycle)
-		if allDescendants[b.ID] {
-			continue
-		}
-		// Check if type is valid
-		isValidType := slices.Contains(validParentTypes, b.Type)
-		if !isValidType {
-			continue
-		}
-		eligibleIssues = append(eligibleIssues, b)
-	}
-
-	// Sort by type order (milestone > epic > feature), then by title
-	typeNames := cfg.TypeNames()
-	typeOrder := make(map[string]int)
-	for i, t := range typeNames {
-		typeOrder[t] = i
-	}
-	slices.SortFunc(eligibleIssues, func(a, b *issue.Issue) int {
-		if c := cmp.Compare(typeOrder[a.Type], typeOrder[b.Type]); c != 0 {
-			return c
-		}
-		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
-	})
-
+	createType := creatableParentType(issueTypes, cfg)
 	delegate := parentItemDelegate{cfg: cfg}
 
-	// Build items list - start with "clear parent" option
-	items := make([]list.Item, 0, len(eligibleIssues)+1)
+	// Build items list - start with the "create new" and "clear parent" options
+	items := make([]list.Item, 0, len(eligibleIssues)+2)
+	if createType != "" {
+		items = append(items, createParentItem{typeName: createType})
+	}
 	items = append(items, clearParentItem{})
 
-	selectedIndex := 0 // default to "No Parent"
-	for i, b := range eligibleIssues {
-		items = append(items, parentItem{issue: b, cfg: cfg})
-		// If this is the current parent, remember its index (+1 for the clear option)
+	selectedIndex := len(items) - 1 // default to "No Parent"
+	for _, b := range eligibleIssues {
+		// If this is the current parent, remember its index
 		if b.ID == currentParent {
-			selectedIndex = i + 1
+			selectedIndex = len(items)
 		}
+		items = append(items, parentItem{issue: b, cfg: cfg})
 	}
 
 	// Calculate modal dimensions (matching View() function)
@@ -193,10 +166,8 @@ func newParentPickerModel(issueIDs []string, issueTitle string, issueTypes []str
 	l.Styles.Filter.Blurred.Prompt = lipgloss.NewStyle().Foreground(ui.ColorPrimary)
 	l.Styles.Filter.Cursor.Color = ui.ColorPrimary
 
-	// Select the current parent if set
-	if selectedIndex > 0 && selectedIndex < len(items) {
-		l.Select(selectedIndex)
-	}
+	// Select the current parent if set, otherwise "No Parent"
+	l.Select(selectedIndex)
 
 	return parentPickerModel{
 		list:          l,
@@ -204,11 +175,91 @@ func newParentPickerModel(issueIDs []string, issueTitle string, issueTypes []str
 		issueTitle:    issueTitle,
 		issueTypes:    issueTypes,
 		currentParent: currentParent,
+		createType:    createType,
 		width:         width,
 		height:        height,
 	}
 }
 
+// creatableParentType returns the type to offer for a new parent of the
+// given issue types: the first parent type allowed for all of them that is
+// an issue type (milestones are separate entities, not created here).
+// Returns "" when there is none, e.g. for epics whose only parent is a
+// milestone.
+func creatableParentType(issueTypes []string, cfg *config.Config) string {
+	var allowed []string
+	for i, issueType := range issueTypes {
+		if i == 0 {
+			allowed = cfg.ValidParentTypes(issueType)
+		} else {
+			allowed = intersectStrings(allowed, cfg.ValidParentTypes(issueType))
+		}
+	}
+	for _, t := range allowed {
+		if t != config.TypeMilestone && cfg.IsValidType(t) {
+			return t
+		}
+	}
+	return ""
+}
+
+// eligibleParents returns the issues that can be the parent of every selected
+// issue, sorted by type order then title:
+//  1. Its type must be an allowed parent type for ALL selected issue types
+//  2. It must not be any of the selected issues
+//  3. It must not be a descendant of any selected issue (to prevent cycles)
+func eligibleParents(issueIDs, issueTypes []string, allIssues []*issue.Issue, cfg *config.Config) []*issue.Issue {
+	// Get valid parent types - for multi-select, find types valid for all issues
+	var validParentTypes []string
+	for i, issueType := range issueTypes {
+		typeParents := cfg.ValidParentTypes(issueType)
+		if i == 0 {
+			validParentTypes = typeParents
+		} else {
+			// Intersect with existing valid types
+			validParentTypes = intersectStrings(validParentTypes, typeParents)
+		}
+	}
+
+	// Collect all descendants of all selected issues (to prevent cycles)
+	allDescendants := make(map[string]bool)
+	for _, issueID := range issueIDs {
+		for descID := range collectDescendants(issueID, allIssues) {
+			allDescendants[descID] = true
+		}
+	}
+
+	// Create set of selected issue IDs for quick lookup
+	selectedSet := make(map[string]bool)
+	for _, id := range issueIDs {
+		selectedSet[id] = true
+	}
+
+	var eligible []*issue.Issue
+	for _, b := range allIssues {
+		if selectedSet[b.ID] || allDescendants[b.ID] {
+			continue
+		}
+		if !slices.Contains(validParentTypes, b.Type) {
+			continue
+		}
+		eligible = append(eligible, b)
+	}
+
+	// Sort by configured type order, then by title
+	typeOrder := make(map[string]int)
+	for i, t := range cfg.TypeNames() {
+		typeOrder[t] = i
+	}
+	slices.SortFunc(eligible, func(a, b *issue.Issue) int {
+		if c := cmp.Compare(typeOrder[a.Type], typeOrder[b.Type]); c != 0 {
+			return c
+		}
+		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+	return eligible
+}
+
 // intersectStrings returns the intersection of two string slices
 func intersectStrings(a, b []string) []string {
 	set := make(map[string]bool)
@@ -257,6 +308,12 @@ func (m parentPickerModel) Init() tea.Cmd {
 func (m parentPickerModel) Update(msg tea.Msg) (parentPickerModel, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if m.creating {
+		if key, ok := msg.(tea.KeyPressMsg); ok {
+			return m.updateCreate(key)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -273,6 +330,11 @@ func (m parentPickerModel) Update(msg tea.Msg) (parentPickerModel, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				switch item := m.list.SelectedItem().(type) {
+				case createParentItem:
+					m.creating = true
+					m.errText = ""
+					m.titleInput = newTitleInput("New "+item.typeName+" title...", 40)
+					return m, textinput.Blink
 				case clearParentItem:
 					return m, func() tea.Msg {
 						return parentSelectedMsg{issueIDs: m.issueIDs, parentID: ""}
@@ -295,6 +357,30 @@ func (m parentPickerModel) Update(msg tea.Msg) (parentPickerModel, tea.Cmd) {
 	return m, cmd
 }
 
+// updateCreate handles keys while the new parent's title is being typed.
+// An empty title is rejected inline; esc returns to the list.
+func (m parentPickerModel) updateCreate(msg tea.KeyPressMsg) (parentPickerModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		title := strings.TrimSpace(m.titleInput.Value())
+		if title == "" {
+			m.errText = "title is required"
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			return parentCreateRequestedMsg{issueIDs: m.issueIDs, issueType: m.createType, title: title}
+		}
+	case "esc":
+		m.creating = false
+		m.errText = ""
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.titleInput, cmd = m.titleInput.Update(msg)
+	m.errText = ""
+	return m, cmd
+}
+
 func (m parentPickerModel) View() string {
 	if m.width == 0 {
 		return "Loading..."
@@ -306,7 +392,7 @@ func (m parentPickerModel) View() string {
 		issueID = m.issueIDs[0]
 	}
 
-	return renderPickerModal(pickerModalConfig{
+	cfg := pickerModalConfig{
 		Title:       "Select Parent",
 		IssueTitle:  m.issueTitle,
 		IssueID:     issueID,
@@ -314,7 +400,21 @@ func (m parentPickerModel) View() string {
 		Width:       m.width,
 		WidthPct:    60,
 		MaxWidth:    80,
-	})
+	}
+	if m.creating {
+		cfg.ListContent = listTitleStyle.Render("New "+m.createType) + "\n\n" +
+			lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(ui.ColorMuted).
+				Padding(0, 1).
+				Render(m.titleInput.View())
+		if m.errText != "" {
+			cfg.ListContent += "\n" + ui.Danger.Render(m.errText)
+		}
+		cfg.Help = helpKeyStyle.Render("enter") + " " + helpStyle.Render("create and assign") + "  " +
+			helpKeyStyle.Render("esc") + " " + helpStyle.Render("back")
+	}
+	return renderPickerModal(cfg)
 }
 
 // ModalView returns the picker rendered as a centered modal overlay on top of the background
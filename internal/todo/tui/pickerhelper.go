@@ -67,7 +67,7 @@ func calculatePickerDimensions(screenWidth, screenHeight int, cfg pickerDimensio
 // Returns the cursor string (with trailing space) for the given index.
 func renderPickerCursor(index int, m interface{ Index() int }) string {
 	if index == m.Index() {
-		return lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render("▌") + " "
+		return lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(ui.Glyph(ui.CursorSymbol)) + " "
 	}
 	return "  "
 }
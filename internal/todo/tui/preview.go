@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// previewDebounce is how long the cursor must rest on an issue before the
+// split view's preview renders it, so scrolling through the list does not
+// render every issue passed over.
+const previewDebounce = 100 * time.Millisecond
+
+// splitListPercent is the share of the terminal width the list keeps in the
+// split view; the preview gets the rest.
+const splitListPercent = 55
+
+// previewMsg asks the split view to render the issue the cursor rested on.
+// Messages from before a later cursor move carry an old seq and are dropped.
+type previewMsg struct {
+	seq int
+}
+
+// splitActive reports whether the list is shown beside a preview: the split
+// view is on and the terminal is wide enough for it.
+func (a *App) splitActive() bool {
+	return a.config.SplitView() && a.width >= a.config.GetSplitViewMinWidth()
+}
+
+// listSize is the size the list renders at, leaving room for the preview in
+// the split view.
+func (a *App) listSize() tea.WindowSizeMsg {
+	if !a.splitActive() {
+		return tea.WindowSizeMsg{Width: a.width, Height: a.height}
+	}
+	return tea.WindowSizeMsg{Width: a.width * splitListPercent / 100, Height: a.height}
+}
+
+// toggleSplit turns the split view on or off and records the choice in the
+// local config. Turning it on in a terminal narrower than
+// split_view_min_width is refused with a status message.
+func (a *App) toggleSplit() tea.Cmd {
+	on := !a.config.SplitView()
+	if minWidth := a.config.GetSplitViewMinWidth(); on && a.width < minWidth {
+		a.setStatusMessage(fmt.Sprintf("Split view needs at least %d columns (terminal has %d)", minWidth, a.width))
+		return nil
+	}
+	if err := a.config.SetSplitView(on); err != nil {
+		a.setStatusMessage("Split view not saved: " + err.Error())
+		return nil
+	}
+
+	var cmd tea.Cmd
+	a.list, cmd = a.list.Update(a.listSize())
+	a.previewWant = ""
+	if b := a.list.highlighted(); on && b != nil {
+		a.previewWant = b.ID
+	}
+	a.renderPreview()
+	return cmd
+}
+
+// syncPreview schedules the preview to follow the cursor once it has rested
+// for previewDebounce. It does nothing when the preview already shows, or is
+// about to show, the highlighted issue.
+func (a *App) syncPreview() tea.Cmd {
+	if !a.splitActive() {
+		return nil
+	}
+	want := ""
+	if b := a.list.highlighted(); b != nil {
+		want = b.ID
+	}
+	if want == a.previewWant {
+		return nil
+	}
+	a.previewWant = want
+	a.previewSeq++
+	seq := a.previewSeq
+	return tea.Tick(previewDebounce, func(time.Time) tea.Msg {
+		return previewMsg{seq: seq}
+	})
+}
+
+// renderPreview rebuilds the preview for previewWant at the current size,
+// reading the issue afresh so edits on disk show up.
+func (a *App) renderPreview() {
+	a.previewID = ""
+	if a.previewWant == "" {
+		return
+	}
+	b, err := a.core.Get(a.previewWant)
+	if err != nil {
+		return
+	}
+	size := a.listSize()
+	a.preview = newPreviewModel(b, a.resolver, a.config, a.width-size.Width, a.height)
+	a.previewID = b.ID
+}
+
+// listView renders the list, with the preview beside it in the split view.
+func (a *App) listView() string {
+	if !a.splitActive() {
+		return a.list.View()
+	}
+	var right string
+	if a.previewID != "" {
+		right = a.preview.View()
+	} else {
+		right = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ui.ColorMuted).
+			Padding(0, 1).
+			Width(a.width - a.listSize().Width - 4).
+			Render(ui.Muted.Render("No issue selected"))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, a.list.View(), right)
+}
@@ -6,7 +6,7 @@ import (
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
@@ -67,17 +67,14 @@ type priorityPickerModel struct {
 	height          int
 }
 
-func newPriorityPickerModel(issueIDs []string, issueTitle, currentPriority string, _ *config.Config, width, height int) priorityPickerModel {
-	// Get all priorities (hardcoded in config package)
-	priorities := config.DefaultPriorities
-
+func newPriorityPickerModel(issueIDs []string, issueTitle, currentPriority string, options []*model.PickerOption, width, height int) priorityPickerModel {
 	delegate := priorityItemDelegate{}
 
 	// Build items list
-	items := make([]list.Item, 0, len(priorities))
+	items := make([]list.Item, 0, len(options))
 	selectedIndex := 0
 
-	for i, p := range priorities {
+	for i, p := range options {
 		isCurrent := p.Name == currentPriority
 		if isCurrent {
 			selectedIndex = i
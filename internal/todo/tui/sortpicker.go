@@ -8,6 +8,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
@@ -63,7 +64,7 @@ func (d sortItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 
 	var cursor string
 	if index == m.Index() {
-		cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render("▌") + " "
+		cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(ui.Glyph(ui.CursorSymbol)) + " "
 	} else {
 		cursor = "  "
 	}
@@ -90,34 +91,22 @@ type sortPickerModel struct {
 	height       int
 }
 
-func newSortPickerModel(currentOrder sortOrder, width, height int) sortPickerModel {
+func newSortPickerModel(currentOrder sortOrder, options []*model.PickerOption, width, height int) sortPickerModel {
 	delegate := sortItemDelegate{}
 
-	options := []struct {
-		name        string
-		value       sortOrder
-		description string
-	}{
-		{"Default", sortDefault, "Status, priority, type, then title"},
-		{"Status", sortStatus, "Status order, then newest created"},
-		{"Priority", sortPriority, "Priority order, then newest created"},
-		{"Created", sortCreated, "Newest created first"},
-		{"Updated", sortUpdated, "Last updated first"},
-		{"Due", sortDue, "Soonest due first"},
-	}
-
 	items := make([]list.Item, 0, len(options))
 	selectedIndex := 0
 
 	for i, opt := range options {
-		isCurrent := opt.value == currentOrder
+		value := sortOrder(opt.Name)
+		isCurrent := value == currentOrder
 		if isCurrent {
 			selectedIndex = i
 		}
 		items = append(items, sortItem{
-			name:        opt.name,
-			value:       opt.value,
-			description: opt.description,
+			name:        opt.Label,
+			value:       value,
+			description: opt.Description,
 			isCurrent:   isCurrent,
 		})
 	}
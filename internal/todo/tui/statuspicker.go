@@ -7,6 +7,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
@@ -33,6 +34,10 @@ type statusItem struct {
 	color       string
 	isArchive   bool
 	isCurrent   bool
+	// reason says why moving into this status would be refused, if it
+	// would; blocked means it would be refused for every issue.
+	reason  string
+	blocked bool
 }
 
 func (i statusItem) Title() string       { return i.name }
@@ -54,6 +59,9 @@ func (d statusItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 
 	cursor := renderPickerCursor(index, &m)
 	statusText := ui.RenderStatusIconAndLabel(item.name, item.color, item.isArchive)
+	if item.reason != "" {
+		statusText += ui.Warning.Render(" (" + item.reason + ")")
+	}
 	renderPickerItem(w, cursor, statusText, item.isCurrent)
 }
 
@@ -67,28 +75,32 @@ type statusPickerModel struct {
 	height        int
 }
 
-func newStatusPickerModel(issueIDs []string, issueTitle, currentStatus string, _ *config.Config, width, height int) statusPickerModel {
-	// Get all statuses (hardcoded in config package)
-	statuses := config.DefaultStatuses
-
+// newStatusPickerModel builds the picker from the resolver's status
+// options, which already leave out disabled statuses and say why a change
+// would be refused, so the reason shows inline rather than after selection.
+func newStatusPickerModel(issueIDs []string, issueTitle, currentStatus string, cfg *config.Config, options []*model.PickerOption, width, height int) statusPickerModel {
 	delegate := statusItemDelegate{}
 
 	// Build items list
-	items := make([]list.Item, 0, len(statuses))
+	items := make([]list.Item, 0, len(options))
 	selectedIndex := 0
 
-	for i, s := range statuses {
-		isCurrent := s.Name == currentStatus
+	for i, opt := range options {
+		isCurrent := opt.Name == currentStatus
 		if isCurrent {
 			selectedIndex = i
 		}
-		items = append(items, statusItem{
-			name:        s.Name,
-			description: s.Description,
-			color:       s.Color,
-			isArchive:   s.Archive,
+		item := statusItem{
+			name:        opt.Name,
+			description: opt.Description,
+			color:       opt.Color,
+			isArchive:   cfg.IsArchiveStatus(opt.Name),
 			isCurrent:   isCurrent,
-		})
+		}
+		if !isCurrent {
+			item.reason, item.blocked = opt.Reason, !opt.Applicable
+		}
+		items = append(items, item)
 	}
 
 	// Calculate modal dimensions
@@ -141,6 +153,10 @@ func (m statusPickerModel) Update(msg tea.Msg) (statusPickerModel, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				if item, ok := m.list.SelectedItem().(statusItem); ok {
+					if item.blocked {
+						// The reason is already shown; stay open for another choice
+						return m, nil
+					}
 					return m, func() tea.Msg {
 						return statusSelectedMsg{issueIDs: m.issueIDs, status: item.name}
 					}
@@ -164,8 +180,11 @@ func (m statusPickerModel) View() string {
 
 	// Get description of currently selected status
 	var description string
-	if item, ok := m.list.SelectedItem().(statusItem); ok && item.description != "" {
+	if item, ok := m.list.SelectedItem().(statusItem); ok {
 		description = item.description
+		if item.blocked {
+			description = "Can't move to " + item.name + ": " + item.reason
+		}
 	}
 
 	// For multi-select, don't show individual issue ID
@@ -11,19 +11,25 @@ var (
 			Foreground(ui.ColorPrimary).
 			Bold(true)
 
-	// Detail title style
-	detailTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("#fff")).
-				Background(ui.ColorPrimary).
-				Padding(0, 1)
+	// Detail title style (set by setStyles)
+	detailTitleStyle lipgloss.Style
 
-	// Help text style
-	helpStyle = lipgloss.NewStyle().
-			Foreground(ui.ColorMuted)
+	// Help text style (set by setStyles)
+	helpStyle lipgloss.Style
 
 	// Help key style
 	helpKeyStyle = lipgloss.NewStyle().
 			Foreground(ui.ColorPrimary).
 			Bold(true)
 )
+
+func init() {
+	setStyles()
+}
+
+// setStyles sets the styles that follow ui.Accessible; New calls it again
+// in case that was switched on after startup.
+func setStyles() {
+	detailTitleStyle = ui.Badge(ui.ColorPrimary).Bold(true)
+	helpStyle = lipgloss.NewStyle().Foreground(ui.ColorMuted)
+}
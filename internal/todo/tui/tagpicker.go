@@ -43,7 +43,7 @@ func (d tagItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 
 	var cursor string
 	if index == m.Index() {
-		cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render("▌") + " "
+		cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(ui.Glyph(ui.CursorSymbol)) + " "
 	} else {
 		cursor = "  "
 	}
@@ -0,0 +1,411 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph"
+	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// openTriageMsg requests starting a triage session
+type openTriageMsg struct{}
+
+// closeTriageMsg is sent when a triage session is closed
+type closeTriageMsg struct{}
+
+// triageDecision is one step of a triage session, kept so it can be undone.
+type triageDecision struct {
+	pos    int    // queue position of the issue
+	id     string // the issue decided on
+	change string // what was done, as the summary lists it; empty for a skip
+	// undo puts back what the change replaced; nil for a skip
+	undo *model.UpdateIssueInput
+}
+
+// triageModel walks the issues in the triage statuses one at a time, with
+// the body in full, applying single-key decisions through the resolver's
+// mutations. The queue is fixed when the session starts, so an issue a
+// decision moves out of the triage statuses keeps its place.
+type triageModel struct {
+	resolver  *graph.Resolver
+	config    *config.Config
+	statuses  []string
+	queue     []string // issue IDs, in the order they are presented
+	pos       int      // position in queue; len(queue) once the session is over
+	decisions []triageDecision
+	prompt    string // "tag" or "due" while the quick input is open
+	input     textinput.Model
+	errText   string
+	viewport  viewport.Model
+	width     int
+	height    int
+}
+
+// newTriageModel queues the issues in statuses, most urgent first.
+func newTriageModel(resolver *graph.Resolver, cfg *config.Config, statuses []string, width, height int) triageModel {
+	var issues []*issue.Issue
+	for _, b := range resolver.Core.All() {
+		if slices.Contains(statuses, b.Status) {
+			issues = append(issues, b)
+		}
+	}
+	issue.SortByPriority(issues, cfg.PriorityNames())
+	m := triageModel{
+		resolver: resolver,
+		config:   cfg,
+		statuses: statuses,
+		queue:    make([]string, len(issues)),
+		width:    width,
+		height:   height,
+	}
+	for i, b := range issues {
+		m.queue[i] = b.ID
+	}
+	m.viewport = viewport.New(viewport.WithWidth(m.bodyWidth()), viewport.WithHeight(m.bodyHeight()))
+	m.skipMissing()
+	m.renderBody()
+	return m
+}
+
+func (m triageModel) Init() tea.Cmd {
+	return nil
+}
+
+// done reports whether every queued issue has been decided on or skipped.
+func (m triageModel) done() bool {
+	return m.pos >= len(m.queue)
+}
+
+// current returns the issue being triaged, or nil once the session is over.
+func (m triageModel) current() *issue.Issue {
+	if m.done() {
+		return nil
+	}
+	b, err := m.resolver.Core.Get(m.queue[m.pos])
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func (m triageModel) Update(msg tea.Msg) (triageModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.SetWidth(m.bodyWidth())
+		m.viewport.SetHeight(m.bodyHeight())
+		m.renderBody()
+		return m, nil
+
+	case tea.KeyPressMsg:
+		if m.prompt != "" {
+			return m.updatePrompt(msg)
+		}
+		m.errText = ""
+		key := msg.String()
+		if key == "u" {
+			m.undo()
+			return m, nil
+		}
+		if key == "esc" || key == "q" {
+			if m.done() {
+				return m, func() tea.Msg { return closeTriageMsg{} }
+			}
+			// End early: show what was decided so far
+			m.pos = len(m.queue)
+			return m, nil
+		}
+		b := m.current()
+		if b == nil {
+			if key == "enter" {
+				return m, func() tea.Msg { return closeTriageMsg{} }
+			}
+			return m, nil
+		}
+		switch key {
+		case "1", "2", "3", "4":
+			// The number keys set the four most urgent priorities
+			names := m.config.PriorityNames()
+			i := int(key[0] - '1')
+			if i < len(names) {
+				priority, before := names[i], b.Priority
+				m.decide(b, "priority "+priority,
+					model.UpdateIssueInput{Priority: &priority},
+					model.UpdateIssueInput{Priority: &before})
+			}
+		case "s":
+			status := m.nextStatus(b.Status)
+			if reason := m.resolver.StatusRefusal(b, status); reason != "" {
+				m.errText = reason
+				return m, nil
+			}
+			before := b.Status
+			m.decide(b, "status "+status,
+				model.UpdateIssueInput{Status: &status},
+				model.UpdateIssueInput{Status: &before})
+		case "x":
+			status := config.StatusScrapped
+			if reason := m.resolver.StatusRefusal(b, status); reason != "" {
+				m.errText = reason
+				return m, nil
+			}
+			before := b.Status
+			m.decide(b, "scrapped",
+				model.UpdateIssueInput{Status: &status},
+				model.UpdateIssueInput{Status: &before})
+		case "t", "d":
+			m.prompt = map[string]string{"t": "tag", "d": "due"}[key]
+			placeholder := "tag name"
+			if m.prompt == "due" {
+				placeholder = "3d, 2w, fri, tomorrow, or YYYY-MM-DD"
+			}
+			m.input = newTitleInput(placeholder, max(20, m.bodyWidth()-10))
+			return m, textinput.Blink
+		case "space":
+			m.decisions = append(m.decisions, triageDecision{pos: m.pos, id: b.ID})
+			m.advance()
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+// updatePrompt handles keys while the tag or due date input is open.
+func (m triageModel) updatePrompt(msg tea.KeyPressMsg) (triageModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompt = ""
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		prompt := m.prompt
+		m.prompt = ""
+		b := m.current()
+		if value == "" || b == nil {
+			return m, nil
+		}
+		if prompt == "tag" {
+			if b.HasTag(value) {
+				m.errText = b.ID + " is already tagged " + value
+				return m, nil
+			}
+			m.decide(b, "tag +"+value,
+				model.UpdateIssueInput{AddTags: []string{value}},
+				model.UpdateIssueInput{RemoveTags: []string{value}})
+			return m, nil
+		}
+		due, err := issue.ParseRelativeDue(value, m.resolver.Core.Now())
+		if err != nil {
+			m.errText = err.Error()
+			return m, nil
+		}
+		day, before := due.String(), ""
+		if b.Due != nil {
+			before = b.Due.String()
+		}
+		m.decide(b, "due "+day,
+			model.UpdateIssueInput{Due: &day},
+			model.UpdateIssueInput{Due: &before})
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// decide applies input to b, records how to undo it, and moves on to the
+// next issue. A rejected change is shown and the issue stays.
+func (m *triageModel) decide(b *issue.Issue, change string, input, undo model.UpdateIssueInput) {
+	if _, err := m.resolver.Mutation().UpdateIssue(context.Background(), b.ID, input); err != nil {
+		m.errText = err.Error()
+		return
+	}
+	m.decisions = append(m.decisions, triageDecision{pos: m.pos, id: b.ID, change: change, undo: &undo})
+	m.advance()
+}
+
+// undo reverts the last decision and returns to its issue.
+func (m *triageModel) undo() {
+	if len(m.decisions) == 0 {
+		m.errText = "Nothing to undo"
+		return
+	}
+	last := m.decisions[len(m.decisions)-1]
+	if last.undo != nil {
+		if _, err := m.resolver.Mutation().UpdateIssue(context.Background(), last.id, *last.undo); err != nil {
+			m.errText = "Could not undo " + last.change + ": " + err.Error()
+			return
+		}
+	}
+	m.decisions = m.decisions[:len(m.decisions)-1]
+	m.pos = last.pos
+	m.renderBody()
+}
+
+// advance moves to the next queued issue that still exists.
+func (m *triageModel) advance() {
+	m.pos++
+	m.skipMissing()
+	m.renderBody()
+}
+
+// skipMissing steps past queued issues deleted since the session started.
+func (m *triageModel) skipMissing() {
+	for !m.done() && m.current() == nil {
+		m.pos++
+	}
+}
+
+// nextStatus returns the status after status in the cycle s steps through:
+// the enabled statuses that are not archive statuses, from the least to the
+// most advanced.
+func (m triageModel) nextStatus(status string) string {
+	var cycle []string
+	for _, name := range slices.Backward(m.config.EnabledStatusNames()) {
+		if !m.config.IsArchiveStatus(name) {
+			cycle = append(cycle, name)
+		}
+	}
+	i := slices.Index(cycle, status)
+	return cycle[(i+1)%len(cycle)]
+}
+
+// refresh re-renders the current issue, which may have changed on disk.
+func (m *triageModel) refresh() {
+	m.skipMissing()
+	m.renderBody()
+}
+
+func (m triageModel) bodyWidth() int {
+	return max(20, m.width-4)
+}
+
+// bodyHeight leaves room for the header, the issue's title and metadata, the
+// input or error line, and the footer.
+func (m triageModel) bodyHeight() int {
+	return max(3, m.height-8)
+}
+
+func (m *triageModel) renderBody() {
+	b := m.current()
+	if b == nil {
+		m.viewport.SetContent("")
+		return
+	}
+	m.viewport.SetContent(renderIssueBody(b))
+	m.viewport.GotoTop()
+}
+
+// progress returns the session's progress, e.g. "7/23".
+func (m triageModel) progress() string {
+	return fmt.Sprintf("%d/%d", min(m.pos+1, len(m.queue)), len(m.queue))
+}
+
+func (m triageModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+	fit := lipgloss.NewStyle().MaxWidth(m.width)
+	header := listTitleStyle.Render("Triage") + "  " + helpStyle.Render(strings.Join(m.statuses, ", "))
+	if m.done() {
+		return fit.Render(header) + "\n\n" + m.summaryView()
+	}
+	header += "  " + lipgloss.NewStyle().Bold(true).Render(m.progress())
+
+	b := m.current()
+	meta := []string{ui.ID.Render(b.ID), ui.RenderStatusText(b.Status)}
+	if b.Priority != "" {
+		meta = append(meta, "priority "+b.Priority)
+	}
+	if len(b.Tags) > 0 {
+		meta = append(meta, "#"+strings.Join(b.Tags, " #"))
+	}
+	if b.Due != nil {
+		meta = append(meta, "due "+b.Due.String())
+	}
+	title := lipgloss.NewStyle().Bold(true).Render(b.Title) + "\n" + strings.Join(meta, helpStyle.Render(" · "))
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorMuted).
+		Width(m.width).
+		Render(m.viewport.View())
+
+	var line string
+	switch {
+	case m.prompt != "":
+		line = helpKeyStyle.Render(m.prompt+":") + " " + m.input.View()
+	case m.errText != "":
+		line = lipgloss.NewStyle().Foreground(ui.ColorDanger).Render(m.errText)
+	}
+
+	footer := helpKeyStyle.Render("1-4") + " " + helpStyle.Render("priority") + "  " +
+		helpKeyStyle.Render("s") + " " + helpStyle.Render("status") + "  " +
+		helpKeyStyle.Render("t") + " " + helpStyle.Render("tag") + "  " +
+		helpKeyStyle.Render("d") + " " + helpStyle.Render("due") + "  " +
+		helpKeyStyle.Render("x") + " " + helpStyle.Render("scrap") + "  " +
+		helpKeyStyle.Render("space") + " " + helpStyle.Render("skip") + "  " +
+		helpKeyStyle.Render("u") + " " + helpStyle.Render("undo") + "  " +
+		helpKeyStyle.Render("esc") + " " + helpStyle.Render("finish")
+	if m.prompt != "" {
+		footer = helpKeyStyle.Render("enter") + " " + helpStyle.Render("apply") + "  " +
+			helpKeyStyle.Render("esc") + " " + helpStyle.Render("cancel")
+	}
+	return fit.Render(header) + "\n" + fit.Render(title) + "\n" + body + "\n" + fit.Render(line) + "\n" + fit.Render(footer)
+}
+
+// summaryView lists what the session changed, one line per issue.
+func (m triageModel) summaryView() string {
+	var changed []string
+	changes := map[string][]string{}
+	skipped := 0
+	for _, d := range m.decisions {
+		if d.change == "" {
+			skipped++
+			continue
+		}
+		if _, ok := changes[d.id]; !ok {
+			changed = append(changed, d.id)
+		}
+		changes[d.id] = append(changes[d.id], d.change)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Triaged %s of %d", pluralIssues(len(changed)), len(m.queue))))
+	if skipped > 0 {
+		sb.WriteString(helpStyle.Render(fmt.Sprintf(" · %d skipped", skipped)))
+	}
+	sb.WriteString("\n")
+	if len(m.queue) == 0 {
+		sb.WriteString("\n" + ui.Muted.Render("No issues in "+strings.Join(m.statuses, ", ")) + "\n")
+	}
+	for _, id := range changed {
+		title := id
+		if b, err := m.resolver.Core.Get(id); err == nil {
+			title = b.Title
+		}
+		sb.WriteString("\n" + ui.ID.Render(id) + " " + title + helpStyle.Render(": "+strings.Join(changes[id], ", ")))
+	}
+	sb.WriteString("\n\n")
+	if m.errText != "" {
+		sb.WriteString(lipgloss.NewStyle().Foreground(ui.ColorDanger).Render(m.errText) + "\n")
+	}
+	sb.WriteString(helpKeyStyle.Render("u") + " " + helpStyle.Render("undo last") + "  " +
+		helpKeyStyle.Render("enter/esc") + " " + helpStyle.Render("close"))
+	return sb.String()
+}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"slices"
 	"strings"
 	"time"
@@ -17,6 +15,10 @@ import (
 	"github.com/toba/jig/internal/todo/core"
 	"github.com/toba/jig/internal/todo/graph"
 	"github.com/toba/jig/internal/todo/graph/model"
+	"github.com/toba/jig/internal/todo/integration"
+	"github.com/toba/jig/internal/todo/issue"
+	"github.com/toba/jig/internal/todo/launch"
+	"github.com/toba/jig/internal/todo/stats"
 )
 
 // viewState represents which view is currently active
@@ -32,16 +34,44 @@ const (
 	viewBlockingPicker
 	viewPriorityPicker
 	viewMilestonePicker
+	viewIterationPicker
 	viewSortPicker
 	viewCreateModal
 	viewCreateChooser
 	viewMilestoneCreateModal
 	viewHelpOverlay
+	viewWarnings
+	viewDashboard
+	viewTriage
 )
 
 // issuesChangedMsg is sent when issues change on disk (via file watcher)
 type issuesChangedMsg struct {
 	changedIDs map[string]bool
+	bodyOnly   map[string]bool // the changed issues whose only change was the body
+}
+
+// metadataChanged reports whether any change was more than a body edit.
+func (msg issuesChangedMsg) metadataChanged() bool {
+	for id := range msg.changedIDs {
+		if !msg.bodyOnly[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// reconciledMsg is sent when a warm start's background load has replaced the
+// snapshot (see core.Reconcile); changedIDs are the issues that differ.
+type reconciledMsg struct {
+	changedIDs map[string]bool
+	err        error
+}
+
+// refreshedMsg is sent when a forced full reload (R) has finished.
+type refreshedMsg struct {
+	count int
+	err   error
 }
 
 // tickMsg is sent periodically to refresh the TUI as a safety net
@@ -63,6 +93,17 @@ type copyIssueIDMsg struct {
 	ids []string
 }
 
+// openExternalMsg requests opening the URL an issue is synced to
+type openExternalMsg struct {
+	issue *issue.Issue
+}
+
+// externalOpenedMsg reports the result of opening an external URL
+type externalOpenedMsg struct {
+	url string
+	err error
+}
+
 // openEditorMsg requests opening the editor for an issue
 type openEditorMsg struct {
 	issueID   string
@@ -94,12 +135,17 @@ type App struct {
 	blockingPicker  blockingPickerModel
 	priorityPicker  priorityPickerModel
 	milestonePicker milestonePickerModel
+	iterationPicker iterationPickerModel
 	sortPicker      sortPickerModel
 	createModal     createModalModel
 	createChooser   createChooserModel
 	milestoneCreate milestoneCreateModalModel
 	helpOverlay     helpOverlayModel
+	warningsModal   warningsModalModel
+	dashboard       dashboardModel
+	triage          triageModel
 	history         []detailModel // stack of previous detail views for back navigation
+	preview         detailModel   // split view preview of the highlighted issue
 	core            *core.Core
 	resolver        *graph.Resolver
 	config          *config.Config
@@ -107,19 +153,32 @@ type App struct {
 	height          int
 	program         *tea.Program // reference to program for sending messages from watcher
 
+	// Statuses a triage session walks; nil means the configured ones
+	triageStatuses []string
+
 	// Key chord state - tracks partial key sequences like "g" waiting for "t"
 	pendingKey string
 
 	// Modal state - tracks view behind modal pickers
 	previousState viewState
 
+	// Split view state - the issue the preview shows, the one it is waiting
+	// to show once the cursor rests, and the latest scheduled previewMsg
+	previewID   string
+	previewWant string
+	previewSeq  int
+
 	// Editor state - tracks issue being edited to update updated_at on save
 	editingIssueID      string
 	editingIssueModTime time.Time
+
+	// opener opens external URLs; tests replace its runner
+	opener *launch.Opener
 }
 
 // New creates a new TUI application
 func New(core *core.Core, cfg *config.Config) *App {
+	setStyles()
 	resolver := &graph.Resolver{Core: core}
 	return &App{
 		state:    viewList,
@@ -127,9 +186,16 @@ func New(core *core.Core, cfg *config.Config) *App {
 		resolver: resolver,
 		config:   cfg,
 		list:     newListModel(resolver, cfg),
+		opener:   launch.New(),
 	}
 }
 
+// createContext is the context the TUI creates issues under, recording
+// them as created via the TUI by the local actor.
+func createContext() context.Context {
+	return graph.WithCreator(context.Background(), graph.Creator{By: graph.DefaultActor(), Via: issue.CreatedViaTUI})
+}
+
 const tickInterval = 2 * time.Second
 
 // tickCmd returns a command that sends a tickMsg after the tick interval.
@@ -152,6 +218,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
+		if a.state == viewList {
+			// The list only gets its share of the width in the split view
+			a.list, cmd = a.list.Update(a.listSize())
+			if a.splitActive() {
+				a.renderPreview()
+			}
+			return a, tea.Batch(cmd, a.syncPreview())
+		}
 
 	case tea.KeyPressMsg:
 		// Clear status messages on any keypress
@@ -177,6 +251,23 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							filterMode:       true,
 						}
 					}
+				case "i":
+					// "g i" - filter by iteration
+					return a, func() tea.Msg {
+						return openIterationPickerMsg{currentIteration: a.list.iterationFilter}
+					}
+				case "d":
+					// "g d" - dashboard
+					return a, func() tea.Msg { return openDashboardMsg{} }
+				case "r":
+					// "g r" - triage session
+					return a, func() tea.Msg { return openTriageMsg{} }
+				case "s":
+					// "g s" - toggle the split view
+					return a, a.toggleSplit()
+				case "p":
+					// "g p" - pin or unpin the marked or highlighted issues
+					return a.togglePin()
 				default:
 					// Invalid second key, ignore the chord
 				}
@@ -205,8 +296,17 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.state = viewHelpOverlay
 				return a, a.helpOverlay.Init()
 			}
+		case "R":
+			// Full reload, for when the watcher missed changes (network filesystems)
+			if (a.state == viewList && a.list.list.FilterState() != 1) ||
+				(a.state == viewDetail && a.detail.linkList.FilterState() != 1) {
+				return a, a.reloadAll
+			}
 		case "q":
-			if a.state == viewDetail || a.state == viewTagPicker || a.state == viewParentPicker || a.state == viewStatusPicker || a.state == viewTypePicker || a.state == viewBlockingPicker || a.state == viewPriorityPicker || a.state == viewMilestonePicker || a.state == viewSortPicker || a.state == viewHelpOverlay {
+			if a.state == viewParentPicker && a.parentPicker.creating {
+				break // typing a new parent's title
+			}
+			if a.state == viewDetail || a.state == viewTagPicker || a.state == viewParentPicker || a.state == viewStatusPicker || a.state == viewTypePicker || a.state == viewBlockingPicker || a.state == viewPriorityPicker || a.state == viewMilestonePicker || a.state == viewIterationPicker || a.state == viewSortPicker || a.state == viewHelpOverlay || a.state == viewWarnings || a.state == viewDashboard {
 				return a, tea.Quit
 			}
 			// For list, only quit if not filtering
@@ -216,38 +316,67 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case issuesChangedMsg:
-		// Issues changed on disk - only refresh detail if a visible issue changed
+		// Issues changed on disk - only refresh detail if a shown or newly
+		// linked issue changed
+		if a.state == viewDetail && a.detail.affectedBy(msg.changedIDs, msg.bodyOnly) {
+			a.refreshDetail()
+		}
+		// The dashboard shows no bodies
+		if a.state == viewDashboard && msg.metadataChanged() {
+			a.dashboard.refresh(a.dashboardData())
+		}
+		if a.state == viewTriage {
+			a.triage.refresh()
+		}
+		if a.previewID != "" && msg.changedIDs[a.previewID] {
+			a.renderPreview()
+		}
+		return a, a.list.loadIssues
+
+	case refreshedMsg:
+		if msg.err != nil {
+			a.setStatusMessage(fmt.Sprintf("Failed to reload issues: %v", msg.err))
+			return a, nil
+		}
 		if a.state == viewDetail {
-			visible := a.detail.visibleIssueIDs()
-			relevant := false
-			for id := range msg.changedIDs {
-				if visible[id] {
-					relevant = true
-					break
-				}
-			}
-			if relevant {
-				updatedIssue, err := a.resolver.Query().Issue(context.Background(), a.detail.issue.ID)
-				if err != nil || updatedIssue == nil {
-					a.state = viewList
-					a.history = nil
-				} else {
-					a.detail.refreshIssue(updatedIssue)
-				}
-			}
+			a.refreshDetail()
 		}
+		if a.previewID != "" {
+			a.renderPreview()
+		}
+		a.setStatusMessage(fmt.Sprintf("Refreshed %d issues", msg.count))
 		return a, a.list.loadIssues
 
+	case reconciledMsg:
+		// The snapshot had no bodies, so open ones render again even if unchanged
+		if msg.err != nil {
+			a.setStatusMessage(fmt.Sprintf("Failed to load issues: %v", msg.err))
+		}
+		if a.state == viewDetail {
+			a.refreshDetail()
+		}
+		if a.previewID != "" {
+			a.renderPreview()
+		}
+		return a.Update(issuesChangedMsg{changedIDs: msg.changedIDs})
+
+	case previewMsg:
+		// Only the latest cursor move renders
+		if msg.seq == a.previewSeq && a.splitActive() {
+			a.renderPreview()
+		}
+		return a, nil
+
+	case configReloadedMsg:
+		return a, a.applyConfig(msg)
+
 	case tickMsg:
 		// Periodic refresh as safety net for dropped fsnotify events
 		if a.state == viewDetail {
-			updatedIssue, err := a.resolver.Query().Issue(context.Background(), a.detail.issue.ID)
-			if err != nil || updatedIssue == nil {
-				a.state = viewList
-				a.history = nil
-			} else {
-				a.detail.refreshIssue(updatedIssue)
-			}
+			a.refreshDetail()
+		}
+		if a.state == viewDashboard {
+			a.dashboard.refresh(a.dashboardData())
 		}
 		return a, tea.Batch(tickCmd(), a.list.loadIssues)
 
@@ -268,15 +397,24 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.list.loadIssues
 
 	case openParentPickerMsg:
-		// Check if all issue types can have parents
+		// Only types that can have parents (not milestones) narrow the
+		// eligible parents; the rest are skipped when the parent is applied.
+		var parentableTypes, skippedTypes []string
 		for _, issueType := range msg.issueTypes {
-			if core.ValidParentTypes(issueType) == nil {
-				// At least one issue type (e.g., milestone) cannot have parents - don't open the picker
-				return a, nil
+			if a.config.ValidParentTypes(issueType) == nil {
+				skippedTypes = append(skippedTypes, issueType)
+			} else {
+				parentableTypes = append(parentableTypes, issueType)
 			}
 		}
+		if len(parentableTypes) == 0 {
+			if len(skippedTypes) > 0 {
+				a.setStatusMessage(graph.NoParentReason(skippedTypes[0]))
+			}
+			return a, nil
+		}
 		a.previousState = a.state // Remember where we came from for the modal background
-		a.parentPicker = newParentPickerModel(msg.issueIDs, msg.issueTitle, msg.issueTypes, msg.currentParent, a.resolver, a.config, a.width, a.height)
+		a.parentPicker = newParentPickerModel(msg.issueIDs, msg.issueTitle, parentableTypes, msg.currentParent, a.resolver, a.config, a.width, a.height)
 		a.state = viewParentPicker
 		return a, a.parentPicker.Init()
 
@@ -287,7 +425,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case openStatusPickerMsg:
 		a.previousState = a.state
-		a.statusPicker = newStatusPickerModel(msg.issueIDs, msg.issueTitle, msg.currentStatus, a.config, a.width, a.height)
+		a.statusPicker = newStatusPickerModel(msg.issueIDs, msg.issueTitle, msg.currentStatus, a.config, a.resolver.StatusOptions(a.pickerIssues(msg.issueIDs)), a.width, a.height)
 		a.state = viewStatusPicker
 		return a, a.statusPicker.Init()
 
@@ -297,20 +435,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.list.loadIssues
 
 	case statusSelectedMsg:
-		// Update all issues' status via GraphQL mutations
-		for _, issueID := range msg.issueIDs {
-			_, err := a.resolver.Mutation().UpdateIssue(context.Background(), issueID, model.UpdateIssueInput{
-				Status: &msg.status,
-			})
-			if err != nil {
-				continue
-			}
-		}
-		return a.finishBatchEdit(msg.issueIDs)
+		// Update all issues' status via GraphQL mutations, skipping any the
+		// parent-completion rule would reject
+		out := a.applyBatch("set status", msg.issueIDs, model.UpdateIssueInput{
+			Status: &msg.status,
+		}, a.checkStatus(msg.status))
+		return a.finishBatchEdit(out)
 
 	case openTypePickerMsg:
 		a.previousState = a.state
-		a.typePicker = newTypePickerModel(msg.issueIDs, msg.issueTitle, msg.currentType, a.config, a.width, a.height)
+		a.typePicker = newTypePickerModel(msg.issueIDs, msg.issueTitle, msg.currentType, a.resolver.TypeOptions(a.pickerIssues(msg.issueIDs)), a.width, a.height)
 		a.state = viewTypePicker
 		return a, a.typePicker.Init()
 
@@ -320,20 +454,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.list.loadIssues
 
 	case typeSelectedMsg:
-		// Update all issues' type via GraphQL mutations
-		for _, issueID := range msg.issueIDs {
-			_, err := a.resolver.Mutation().UpdateIssue(context.Background(), issueID, model.UpdateIssueInput{
-				Type: &msg.issueType,
-			})
-			if err != nil {
-				continue
-			}
-		}
-		return a.finishBatchEdit(msg.issueIDs)
+		// Update all issues' type via GraphQL mutations, skipping changes that
+		// would break the parent hierarchy
+		out := a.applyBatch("set type", msg.issueIDs, model.UpdateIssueInput{
+			Type: &msg.issueType,
+		}, a.checkType(msg.issueType))
+		return a.finishBatchEdit(out)
 
 	case openPriorityPickerMsg:
 		a.previousState = a.state
-		a.priorityPicker = newPriorityPickerModel(msg.issueIDs, msg.issueTitle, msg.currentPriority, a.config, a.width, a.height)
+		a.priorityPicker = newPriorityPickerModel(msg.issueIDs, msg.issueTitle, msg.currentPriority, a.resolver.PriorityOptions(nil), a.width, a.height)
 		a.state = viewPriorityPicker
 		return a, a.priorityPicker.Init()
 
@@ -344,15 +474,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case prioritySelectedMsg:
 		// Update all issues' priority via GraphQL mutations
-		for _, issueID := range msg.issueIDs {
-			_, err := a.resolver.Mutation().UpdateIssue(context.Background(), issueID, model.UpdateIssueInput{
-				Priority: &msg.priority,
-			})
-			if err != nil {
-				continue
-			}
-		}
-		return a.finishBatchEdit(msg.issueIDs)
+		out := a.applyBatch("set priority", msg.issueIDs, model.UpdateIssueInput{
+			Priority: &msg.priority,
+		}, nil)
+		return a.finishBatchEdit(out)
 
 	case openMilestonePickerMsg:
 		a.previousState = a.state
@@ -373,19 +498,64 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Assign (or clear) milestone on all selected issues via GraphQL mutations.
 		ms := msg.milestoneID
-		for _, issueID := range msg.issueIDs {
-			_, err := a.resolver.Mutation().UpdateIssue(context.Background(), issueID, model.UpdateIssueInput{
-				Milestone: &ms,
-			})
-			if err != nil {
-				continue
-			}
+		out := a.applyBatch("set milestone", msg.issueIDs, model.UpdateIssueInput{
+			Milestone: &ms,
+		}, nil)
+		return a.finishBatchEdit(out)
+
+	case openIterationPickerMsg:
+		current, _ := a.config.CurrentIteration(a.core.Now())
+		rollup := stats.ByIteration(a.core.AllUnordered(), a.config, current)
+		if len(rollup) == 0 {
+			a.list.statusMessage = "No iterations"
+			return a, nil
 		}
-		return a.finishBatchEdit(msg.issueIDs)
+		a.previousState = a.state
+		a.iterationPicker = newIterationPickerModel(msg.currentIteration, rollup, a.width, a.height)
+		a.state = viewIterationPicker
+		return a, a.iterationPicker.Init()
+
+	case closeIterationPickerMsg:
+		a.state = a.previousState
+		return a, nil
+
+	case iterationSelectedMsg:
+		a.state = viewList
+		a.list.setIterationFilter(msg.iteration)
+		return a, a.list.loadIssues
+
+	case openDashboardMsg:
+		a.dashboard = newDashboardModel(a.dashboardData(), a.width, a.height)
+		a.state = viewDashboard
+		return a, a.dashboard.Init()
+
+	case closeDashboardMsg:
+		a.state = viewList
+		return a, a.list.loadIssues
+
+	case openTriageMsg:
+		statuses := a.triageStatuses
+		if statuses == nil {
+			statuses = a.config.GetTriageStatuses()
+		}
+		a.triage = newTriageModel(a.resolver, a.config, statuses, a.width, a.height)
+		a.state = viewTriage
+		return a, a.triage.Init()
+
+	case closeTriageMsg:
+		a.state = viewList
+		// The list was not resized while triage covered it
+		a.list, cmd = a.list.Update(a.listSize())
+		return a, tea.Batch(cmd, a.list.loadIssues)
+
+	case dashboardStatusMsg:
+		a.state = viewList
+		a.list.setStatusFilter(msg.status)
+		return a, a.list.loadIssues
 
 	case openSortPickerMsg:
 		a.previousState = a.state
-		a.sortPicker = newSortPickerModel(msg.currentOrder, a.width, a.height)
+		a.sortPicker = newSortPickerModel(msg.currentOrder, a.resolver.SortOptions(), a.width, a.height)
 		a.state = viewSortPicker
 		return a, a.sortPicker.Init()
 
@@ -408,6 +578,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.state = a.previousState
 		return a, nil
 
+	case openWarningsMsg:
+		a.previousState = a.state
+		a.warningsModal = newWarningsModalModel(a.list.loadWarnings, a.width, a.height)
+		a.state = viewWarnings
+		return a, a.warningsModal.Init()
+
+	case closeWarningsMsg:
+		a.state = a.previousState
+		return a, nil
+
 	case openBlockingPickerMsg:
 		a.previousState = a.state
 		a.blockingPicker = newBlockingPickerModel(msg.issueID, msg.issueTitle, msg.currentBlocking, a.resolver, a.config, a.width, a.height)
@@ -451,7 +631,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case issueCreatedMsg:
 		// Create the issue via GraphQL mutation with draft status
 		draftStatus := "draft"
-		createdIssue, err := a.resolver.Mutation().CreateIssue(context.Background(), model.CreateIssueInput{
+		createdIssue, err := a.resolver.Mutation().CreateIssue(createContext(), model.CreateIssueInput{
 			Title:  msg.title,
 			Status: &draftStatus,
 		})
@@ -495,8 +675,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.list.loadIssues
 
 	case openEditorMsg:
+		// Archived issues are read-only: editing the file in the archive
+		// would slip past the guard every other mutation has.
+		if a.core.IsArchived(msg.issueID) {
+			a.setStatusMessage("Cannot edit " + msg.issueID + ": archived")
+			return a, nil
+		}
+
 		// Launch editor for the issue file
-		editorCmd, editorArgs := getEditor(a.config)
 		fullPath := filepath.Join(a.core.Root(), msg.issuePath)
 
 		// Record the issue ID and file mod time before editing
@@ -505,9 +691,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.editingIssueModTime = info.ModTime()
 		}
 
-		args := append(slices.Clone(editorArgs), fullPath)
-		c := exec.Command(editorCmd, args...) //nolint:gosec // user-configured editor
-		return a, tea.ExecProcess(c, func(err error) tea.Msg {
+		return a, tea.ExecProcess(launch.EditorCommand(a.config, fullPath), func(err error) tea.Msg {
 			return editorFinishedMsg{err: err}
 		})
 
@@ -534,18 +718,29 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case parentSelectedMsg:
-		// Set the new parent via updateIssue mutation for all issues
-		parentValue := msg.parentID
-		input := model.UpdateIssueInput{
-			Parent: &parentValue,
-		}
-		for _, issueID := range msg.issueIDs {
-			_, err := a.resolver.Mutation().UpdateIssue(context.Background(), issueID, input)
-			if err != nil {
-				continue
-			}
+		return a.finishBatchEdit(a.setParent(msg.issueIDs, msg.parentID))
+
+	case parentCreateRequestedMsg:
+		// Create the new parent, then assign it as if it had been picked.
+		// Creation errors stay in the picker so the title can be fixed.
+		status := a.config.GetDefaultStatus()
+		created, err := a.resolver.Mutation().CreateIssue(createContext(), model.CreateIssueInput{
+			Title:  msg.title,
+			Type:   &msg.issueType,
+			Status: &status,
+		})
+		if err != nil {
+			a.parentPicker.errText = err.Error()
+			return a, nil
+		}
+		out := a.setParent(msg.issueIDs, created.ID)
+		m, cmd := a.finishBatchEdit(out)
+		status = fmt.Sprintf("Created %s %s", msg.issueType, created.ID)
+		if detail := out.message(); detail != "" {
+			status += "; " + detail
 		}
-		return a.finishBatchEdit(msg.issueIDs)
+		a.setStatusMessage(status)
+		return m, cmd
 
 	case clearFilterMsg:
 		a.list.clearFilter()
@@ -562,14 +757,26 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			statusMsg = fmt.Sprintf("Copied %d issue IDs to clipboard", len(msg.ids))
 		}
 
-		// Set status on current view
-		switch a.state {
-		case viewList:
-			a.list.statusMessage = statusMsg
-		case viewDetail:
-			a.detail.statusMessage = statusMsg
+		a.setStatusMessage(statusMsg)
+		return a, nil
+
+	case openExternalMsg:
+		url, err := integration.OpenURL(msg.issue, a.config)
+		if err != nil {
+			a.setStatusMessage(err.Error())
+			return a, nil
+		}
+		opener := a.opener
+		return a, func() tea.Msg {
+			return externalOpenedMsg{url: url, err: opener.URL(url)}
 		}
 
+	case externalOpenedMsg:
+		if msg.err != nil {
+			a.setStatusMessage(fmt.Sprintf("Failed to open %s: %v", msg.url, msg.err))
+		} else {
+			a.setStatusMessage("Opened " + msg.url)
+		}
 		return a, nil
 
 	case selectIssueMsg:
@@ -586,12 +793,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(a.history) > 0 {
 			a.detail = a.history[len(a.history)-1]
 			a.history = a.history[:len(a.history)-1]
-			// Stay in viewDetail state
+			// Stay in viewDetail state, with whatever changed while it was covered
+			a.refreshDetail()
 		} else {
 			a.state = viewList
 			// Force list to pick up any size changes that happened while in detail view
-			a.list, cmd = a.list.Update(tea.WindowSizeMsg{Width: a.width, Height: a.height})
-			return a, cmd
+			a.list, cmd = a.list.Update(a.listSize())
+			if a.splitActive() {
+				a.renderPreview()
+			}
+			return a, tea.Batch(cmd, a.syncPreview())
 		}
 		return a, nil
 	}
@@ -600,6 +811,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch a.state {
 	case viewList:
 		a.list, cmd = a.list.Update(msg)
+		cmd = tea.Batch(cmd, a.syncPreview())
 	case viewDetail:
 		a.detail, cmd = a.detail.Update(msg)
 	case viewTagPicker:
@@ -614,6 +826,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.priorityPicker, cmd = a.priorityPicker.Update(msg)
 	case viewMilestonePicker:
 		a.milestonePicker, cmd = a.milestonePicker.Update(msg)
+	case viewIterationPicker:
+		a.iterationPicker, cmd = a.iterationPicker.Update(msg)
 	case viewSortPicker:
 		a.sortPicker, cmd = a.sortPicker.Update(msg)
 	case viewBlockingPicker:
@@ -626,25 +840,125 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.milestoneCreate, cmd = a.milestoneCreate.Update(msg)
 	case viewHelpOverlay:
 		a.helpOverlay, cmd = a.helpOverlay.Update(msg)
+	case viewWarnings:
+		a.warningsModal, cmd = a.warningsModal.Update(msg)
+	case viewDashboard:
+		a.dashboard, cmd = a.dashboard.Update(msg)
+	case viewTriage:
+		a.triage, cmd = a.triage.Update(msg)
 	}
 
 	return a, cmd
 }
 
 // finishBatchEdit completes a batch edit operation by returning to the previous view,
-// clearing selection, refreshing the detail view if applicable, and reloading the list.
-func (a *App) finishBatchEdit(issueIDs []string) (tea.Model, tea.Cmd) {
+// deselecting the issues that were updated (skipped ones stay selected so they can
+// be retried or deselected), reporting the outcome, refreshing the detail view if
+// applicable, and reloading the list.
+func (a *App) finishBatchEdit(out batchOutcome) (tea.Model, tea.Cmd) {
 	a.state = a.previousState
-	clear(a.list.selectedIssues)
-	if a.state == viewDetail && len(issueIDs) == 1 {
-		updatedIssue, _ := a.resolver.Query().Issue(context.Background(), issueIDs[0])
+	for _, id := range out.updated {
+		delete(a.list.selectedIssues, id)
+	}
+	if a.state == viewDetail && len(out.updated) == 1 {
+		updatedIssue, _ := a.resolver.Query().Issue(context.Background(), out.updated[0])
 		if updatedIssue != nil {
 			a.detail.refreshIssue(updatedIssue)
 		}
 	}
+	a.setStatusMessage(out.message())
 	return a, a.list.loadIssues
 }
 
+// togglePin pins the marked issues, or the highlighted one when none are
+// marked. If they are all pinned already it unpins them instead.
+func (a *App) togglePin() (tea.Model, tea.Cmd) {
+	var ids []string
+	for id := range a.list.selectedIssues {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		b := a.list.highlighted()
+		if b == nil {
+			return a, nil
+		}
+		ids = []string{b.ID}
+	}
+	slices.Sort(ids)
+
+	pin := false
+	for _, id := range ids {
+		if b, err := a.core.Get(id); err == nil && !b.Pinned {
+			pin = true
+			break
+		}
+	}
+	action := "unpin"
+	if pin {
+		action = "pin"
+	}
+	a.previousState = a.state
+	return a.finishBatchEdit(a.applyBatch(action, ids, model.UpdateIssueInput{Pinned: &pin}, nil))
+}
+
+// setParent moves every issue whose type can take a parent under parentID
+// ("" clears it) via the moveIssue mutation, which also records the move in
+// the issue's history.
+func (a *App) setParent(issueIDs []string, parentID string) batchOutcome {
+	var newParent *string
+	if parentID != "" {
+		newParent = &parentID
+	}
+	return a.applyBatchFunc("set parent", issueIDs, a.checkParent(parentID), func(ctx context.Context, id string) error {
+		_, err := a.resolver.Mutation().MoveIssue(ctx, id, newParent, nil)
+		return err
+	})
+}
+
+// setStatusMessage shows msg in the footer of the current view.
+func (a *App) setStatusMessage(msg string) {
+	switch a.state {
+	case viewList:
+		a.list.statusMessage = msg
+	case viewDetail:
+		a.detail.statusMessage = msg
+	}
+}
+
+// refreshDetail reloads the issue shown in the detail view. When it no
+// longer exists, the list is shown instead with the not-found message in its
+// footer.
+func (a *App) refreshDetail() {
+	updatedIssue, err := a.resolver.Core.Lookup(a.detail.issue.ID)
+	if err != nil {
+		a.state = viewList
+		a.history = nil
+		a.setStatusMessage(err.Error())
+		return
+	}
+	a.detail.refreshIssue(updatedIssue)
+}
+
+// reloadAll reads every issue from disk again, replacing what the watcher
+// has kept up to date.
+func (a *App) reloadAll() tea.Msg {
+	if err := a.core.Load(); err != nil {
+		return refreshedMsg{err: err}
+	}
+	return refreshedMsg{count: len(a.core.AllUnordered())}
+}
+
+// dashboardData gathers every issue for the dashboard.
+func (a *App) dashboardData() dashboardData {
+	return dashboardData{
+		issues:    a.core.All(),
+		config:    a.config,
+		now:       a.core.Now(),
+		isBlocked: a.core.IsBlocked,
+		isStale:   a.core.IsStale,
+	}
+}
+
 // collectTagsWithCounts returns all tags with their usage counts
 func (a *App) collectTagsWithCounts() []tagWithCount {
 	issues, _ := a.resolver.Query().Issues(context.Background(), nil)
@@ -668,7 +982,7 @@ func (a *App) View() tea.View {
 	var content string
 	switch a.state {
 	case viewList:
-		content = a.list.View()
+		content = a.listView()
 	case viewDetail:
 		content = a.detail.View()
 	case viewTagPicker:
@@ -683,6 +997,8 @@ func (a *App) View() tea.View {
 		content = a.priorityPicker.ModalView(a.getBackgroundView(), a.width, a.height)
 	case viewMilestonePicker:
 		content = a.milestonePicker.ModalView(a.getBackgroundView(), a.width, a.height)
+	case viewIterationPicker:
+		content = a.iterationPicker.ModalView(a.getBackgroundView(), a.width, a.height)
 	case viewSortPicker:
 		content = a.sortPicker.ModalView(a.getBackgroundView(), a.width, a.height)
 	case viewBlockingPicker:
@@ -695,6 +1011,12 @@ func (a *App) View() tea.View {
 		content = a.milestoneCreate.ModalView(a.getBackgroundView(), a.width, a.height)
 	case viewHelpOverlay:
 		content = a.helpOverlay.ModalView(a.getBackgroundView(), a.width, a.height)
+	case viewWarnings:
+		content = a.warningsModal.ModalView(a.getBackgroundView(), a.width, a.height)
+	case viewDashboard:
+		content = a.dashboard.View()
+	case viewTriage:
+		content = a.triage.View()
 	}
 	v := tea.NewView(content)
 	v.AltScreen = true
@@ -705,89 +1027,57 @@ func (a *App) View() tea.View {
 func (a *App) getBackgroundView() string {
 	switch a.previousState {
 	case viewList:
-		return a.list.View()
+		return a.listView()
 	case viewDetail:
 		return a.detail.View()
 	default:
-		return a.list.View()
+		return a.listView()
 	}
 }
 
-// systemEditor returns the OS default application opener command and flags.
-// On macOS, it returns "open" with flags to wait for the app to close (-W),
-// open a new instance (-n), and keep the GUI app focused (-g).
-// On other platforms, it returns empty values (e.g. xdg-open doesn't support blocking).
-func systemEditor() (cmd string, args []string, ok bool) {
-	if runtime.GOOS == "darwin" {
-		return "open", []string{"-W", "-n", "-g"}, true
-	}
-	return "", nil, false
+// Run starts the TUI application with file watching. A core filled by
+// LoadSnapshot is reconciled in the background, and the snapshot is saved
+// again on a clean exit.
+func Run(core *core.Core, cfg *config.Config) error {
+	return run(New(core, cfg))
 }
 
-// getEditor returns the user's preferred editor command and any extra arguments.
-// Fallback chain: config editor -> $VISUAL -> $EDITOR -> OS default -> vi -> nano.
-// The special value "system" resolves to the OS default application opener.
-// Multi-word editor values (e.g. "code --wait") are split on whitespace.
-// Relative paths from config are resolved relative to the config directory.
-func getEditor(cfg *config.Config) (string, []string) {
-	editor := cfg.GetEditor()
-	if editor == "" {
-		editor = os.Getenv("VISUAL")
-	}
-	if editor == "" {
-		editor = os.Getenv("EDITOR")
-	}
-
-	// Handle the "system" keyword — resolve to OS default app opener
-	if strings.EqualFold(editor, "system") {
-		if cmd, args, ok := systemEditor(); ok {
-			return cmd, args
-		}
-		// Unsupported platform — fall through to vi/nano
-		editor = ""
-	}
-
-	if editor == "" {
-		// Try OS default app opener before falling back to terminal editors
-		if cmd, args, ok := systemEditor(); ok {
-			return cmd, args
-		}
-		if _, err := exec.LookPath("vi"); err == nil {
-			editor = "vi"
-		} else {
-			editor = "nano"
-		}
-	}
-
-	parts := strings.Fields(editor)
-	cmd := parts[0]
-	var args []string
-	if len(parts) > 1 {
-		args = parts[1:]
-	}
-
-	// Resolve relative paths from config against config directory
-	if (strings.HasPrefix(cmd, "./") || strings.HasPrefix(cmd, "../")) && cfg.ConfigDir() != "" {
-		cmd = filepath.Join(cfg.ConfigDir(), cmd)
+// RunTriage starts the TUI in a triage session over the issues in
+// statuses, or in the configured triage statuses when statuses is empty.
+// Closing the session leaves the list open.
+func RunTriage(core *core.Core, cfg *config.Config, statuses []string) error {
+	app := New(core, cfg)
+	if len(statuses) > 0 {
+		app.triageStatuses = statuses
 	}
-
-	return cmd, args
+	app.Update(openTriageMsg{})
+	return run(app)
 }
 
-// Run starts the TUI application with file watching
-func Run(core *core.Core, cfg *config.Config) error {
-	app := New(core, cfg)
+func run(app *App) error {
+	core, cfg := app.core, app.config
 	p := tea.NewProgram(app)
 
 	// Store reference to program for sending messages from watcher
 	app.program = p
 
-	// Start file watching
-	if err := core.StartWatching(); err != nil {
-		return err
+	// Start file watching, unless a warm start is showing the snapshot: then
+	// watching starts once the real state has replaced it below
+	warm := core.Warm()
+	if !warm {
+		if err := core.StartWatching(); err != nil {
+			return err
+		}
 	}
 	defer core.Unwatch() //nolint:errcheck // cleanup
 
+	// Watch the config file so edits apply without a restart
+	if path, err := config.FindConfig(cfg.ConfigDir()); err == nil && path != "" {
+		if stop, err := watchConfig(path, p.Send); err == nil {
+			defer stop()
+		}
+	}
+
 	// Subscribe to issue events
 	eventCh, unsubscribe := core.Subscribe()
 	defer unsubscribe()
@@ -796,14 +1086,35 @@ func Run(core *core.Core, cfg *config.Config) error {
 	prog := app.program
 	go func() {
 		for events := range eventCh {
-			ids := make(map[string]bool, len(events))
+			msg := issuesChangedMsg{changedIDs: make(map[string]bool, len(events)), bodyOnly: map[string]bool{}}
 			for _, e := range events {
-				ids[e.IssueID] = true
+				// Of several events for one issue, all must be body-only
+				bodyOnly := slices.Equal(e.ChangedFields, []string{issue.FieldBody})
+				msg.bodyOnly[e.IssueID] = bodyOnly && (!msg.changedIDs[e.IssueID] || msg.bodyOnly[e.IssueID])
+				msg.changedIDs[e.IssueID] = true
 			}
-			prog.Send(issuesChangedMsg{changedIDs: ids})
+			prog.Send(msg)
 		}
 	}()
 
-	_, err := p.Run()
-	return err
+	if warm {
+		go func() {
+			changed, err := core.Reconcile()
+			if err == nil {
+				err = core.StartWatching()
+			}
+			ids := make(map[string]bool, len(changed))
+			for _, id := range changed {
+				ids[id] = true
+			}
+			prog.Send(reconciledMsg{changedIDs: ids, err: err})
+		}()
+	}
+
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+	// Best-effort: without a snapshot the next start just loads normally
+	_ = core.SaveSnapshot()
+	return nil
 }
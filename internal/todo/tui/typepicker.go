@@ -6,7 +6,7 @@ import (
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"github.com/toba/jig/internal/todo/config"
+	"github.com/toba/jig/internal/todo/graph/model"
 	"github.com/toba/jig/internal/todo/ui"
 )
 
@@ -31,7 +31,12 @@ type typeItem struct {
 	name        string
 	description string
 	color       string
+	icon        string
 	isCurrent   bool
+	// reason says why changing to this type would break the hierarchy, if
+	// it would; blocked means it would for every issue.
+	reason  string
+	blocked bool
 }
 
 func (i typeItem) Title() string       { return i.name }
@@ -52,7 +57,10 @@ func (d typeItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	}
 
 	cursor := renderPickerCursor(index, &m)
-	typeText := ui.RenderTypeText(item.name, item.color)
+	typeText := ui.RenderTypeLabel(item.name, item.icon, item.color)
+	if item.reason != "" {
+		typeText += ui.Warning.Render(" (" + item.reason + ")")
+	}
 	renderPickerItem(w, cursor, typeText, item.isCurrent)
 }
 
@@ -66,27 +74,29 @@ type typePickerModel struct {
 	height      int
 }
 
-func newTypePickerModel(issueIDs []string, issueTitle, currentType string, _ *config.Config, width, height int) typePickerModel {
-	// Get all types (hardcoded in config package)
-	types := config.DefaultTypes
-
+func newTypePickerModel(issueIDs []string, issueTitle, currentType string, options []*model.PickerOption, width, height int) typePickerModel {
 	delegate := typeItemDelegate{}
 
 	// Build items list
-	items := make([]list.Item, 0, len(types))
+	items := make([]list.Item, 0, len(options))
 	selectedIndex := 0
 
-	for i, t := range types {
-		isCurrent := t.Name == currentType
+	for i, opt := range options {
+		isCurrent := opt.Name == currentType
 		if isCurrent {
 			selectedIndex = i
 		}
-		items = append(items, typeItem{
-			name:        t.Name,
-			description: t.Description,
-			color:       t.Color,
+		item := typeItem{
+			name:        opt.Name,
+			description: opt.Description,
+			color:       opt.Color,
+			icon:        opt.Icon,
 			isCurrent:   isCurrent,
-		})
+		}
+		if !isCurrent {
+			item.reason, item.blocked = opt.Reason, !opt.Applicable
+		}
+		items = append(items, item)
 	}
 
 	// Calculate modal dimensions
@@ -139,6 +149,10 @@ func (m typePickerModel) Update(msg tea.Msg) (typePickerModel, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				if item, ok := m.list.SelectedItem().(typeItem); ok {
+					if item.blocked {
+						// The reason is already shown; stay open for another choice
+						return m, nil
+					}
 					return m, func() tea.Msg {
 						return typeSelectedMsg{issueIDs: m.issueIDs, issueType: item.name}
 					}
@@ -162,8 +176,11 @@ func (m typePickerModel) View() string {
 
 	// Get description of currently selected type
 	var description string
-	if item, ok := m.list.SelectedItem().(typeItem); ok && item.description != "" {
+	if item, ok := m.list.SelectedItem().(typeItem); ok {
 		description = item.description
+		if item.blocked {
+			description = "Can't change to " + item.name + ": " + item.reason
+		}
 	}
 
 	// For multi-select, don't show individual issue ID
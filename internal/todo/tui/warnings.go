@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/toba/jig/internal/todo/core"
+	"github.com/toba/jig/internal/todo/ui"
+)
+
+// openWarningsMsg requests opening the skipped-files modal
+type openWarningsMsg struct{}
+
+// closeWarningsMsg is sent when the skipped-files modal is closed
+type closeWarningsMsg struct{}
+
+// warningsModalModel lists the issue files the core skipped while loading
+type warningsModalModel struct {
+	warnings []core.LoadWarning
+	width    int
+	height   int
+}
+
+func newWarningsModalModel(warnings []core.LoadWarning, width, height int) warningsModalModel {
+	return warningsModalModel{warnings: warnings, width: width, height: height}
+}
+
+func (m warningsModalModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m warningsModalModel) Update(msg tea.Msg) (warningsModalModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "w", "esc", "enter":
+			return m, func() tea.Msg {
+				return closeWarningsMsg{}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m warningsModalModel) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	modalWidth := max(50, min(100, m.width*80/100))
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ui.ColorWarning).
+		Render(skippedFilesLabel(len(m.warnings)))
+
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#fff")).Bold(true)
+	kindStyle := lipgloss.NewStyle().Foreground(ui.ColorWarning)
+	errStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted).Width(modalWidth - 8)
+
+	// Leave room for the border, padding, title, and footer.
+	limit := max(1, (m.height-10)/2)
+
+	var content strings.Builder
+	content.WriteString(title + "\n\n")
+	for i, w := range m.warnings {
+		if i == limit {
+			content.WriteString(helpStyle.Render(fmt.Sprintf("… and %d more (run 'jig todo doctor' for the full list)", len(m.warnings)-limit)) + "\n")
+			break
+		}
+		content.WriteString(pathStyle.Render(w.Path) + " " + kindStyle.Render("["+w.Kind+"]") + "\n")
+		content.WriteString(errStyle.Render("  "+w.Message()) + "\n")
+	}
+	content.WriteString("\n")
+
+	footer := helpKeyStyle.Render("w/esc") + " " + helpStyle.Render("close")
+
+	border := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorWarning).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	return border.Render(content.String() + footer)
+}
+
+// ModalView returns the skipped-files list as a centered modal on top of the background
+func (m warningsModalModel) ModalView(bgView string, fullWidth, fullHeight int) string {
+	return overlayModal(bgView, m.View(), fullWidth, fullHeight)
+}
+
+// skippedFilesLabel is the footer indicator and modal title text.
+func skippedFilesLabel(n int) string {
+	if n == 1 {
+		return ui.Glyph(ui.WarnSymbol) + " 1 file skipped"
+	}
+	return fmt.Sprintf("%s %d files skipped", ui.Glyph(ui.WarnSymbol), n)
+}
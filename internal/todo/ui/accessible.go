@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"image/color"
+	"os"
+
+	"charm.land/lipgloss/v2"
+)
+
+// AccessibleEnv names the environment variable that turns on accessible
+// output, like --accessible, when set to 1.
+const AccessibleEnv = "JIG_ACCESSIBLE"
+
+// Accessible reports whether output is rendered for screen readers and logs:
+// glyphs become bracketed text labels ("[in-progress]", "[bug]",
+// "[blocked]") and badges lose their background fills. Change it with
+// SetAccessible.
+var Accessible bool
+
+// Glyphs shown next to issues and check results; see Glyph.
+const (
+	DueSymbol       = "⏳"
+	PassSymbol      = "✓"
+	WarnSymbol      = "⚠"
+	FailSymbol      = "✗"
+	ArrowSymbol     = "→"
+	CursorSymbol    = "▌"
+	PointerSymbol   = "▸"
+	CheckedSymbol   = "●"
+	UncheckedSymbol = "○"
+)
+
+// glyphLabels are the text labels the glyphs render as under Accessible.
+// Status, type, and priority glyphs are labelled with their names instead
+// (see Label).
+var glyphLabels = map[string]string{
+	PinnedSymbol:    "[pinned]",
+	InternalSymbol:  "[internal]",
+	StaleSymbol:     "[stale]",
+	BlockedSymbol:   "[blocked]",
+	BlockingSymbol:  "[blocking]",
+	DueSymbol:       "[due]",
+	PassSymbol:      "[ok]",
+	WarnSymbol:      "[warning]",
+	FailSymbol:      "[failed]",
+	ArrowSymbol:     "->",
+	CursorSymbol:    "[selected]",
+	PointerSymbol:   "[selected]",
+	CheckedSymbol:   "[x]",
+	UncheckedSymbol: "[ ]",
+}
+
+// Glyph returns symbol, or its text label under Accessible.
+func Glyph(symbol string) string {
+	if label, ok := glyphLabels[symbol]; ok && Accessible {
+		return label
+	}
+	return symbol
+}
+
+// Label returns the bracketed text label for a status, type, or priority
+// name, which replaces its glyph under Accessible.
+func Label(name string) string {
+	return "[" + name + "]"
+}
+
+// AccessibleRequested reports whether JIG_ACCESSIBLE=1 is set.
+func AccessibleRequested() bool {
+	return os.Getenv(AccessibleEnv) == "1"
+}
+
+// SetAccessible switches accessible output on or off, rebuilding the shared
+// styles to match. Under Accessible, the muted colors become the terminal's
+// own text color for contrast.
+func SetAccessible(on bool) {
+	Accessible = on
+	ColorSecondary, ColorMuted, ColorSubtle = mutedColors[0], mutedColors[1], mutedColors[2]
+	if on {
+		ColorSecondary, ColorMuted, ColorSubtle = lipgloss.NoColor{}, lipgloss.NoColor{}, lipgloss.NoColor{}
+	}
+	buildStyles()
+}
+
+// mutedColors are ColorSecondary, ColorMuted, and ColorSubtle as defined,
+// restored when Accessible is switched off.
+var mutedColors = [...]color.Color{ColorSecondary, ColorMuted, ColorSubtle}
+
+// Badge returns a style that fills bg behind white text, or under
+// Accessible draws the text bold in bg instead.
+func Badge(bg color.Color) lipgloss.Style {
+	if Accessible {
+		return lipgloss.NewStyle().Foreground(bg).Bold(true)
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#fff")).
+		Background(bg).
+		Padding(0, 1)
+}
@@ -52,6 +52,13 @@ func ResolveColor(s string) color.Color {
 	return ColorMuted
 }
 
+// HexColor resolves a color name or hex code like ResolveColor and returns
+// it as #rrggbb, for output drawn outside the terminal such as Graphviz.
+func HexColor(s string) string {
+	r, g, b, _ := ResolveColor(s).RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
 // IsValidColor returns true if the color is a valid named color or hex code.
 func IsValidColor(color string) bool {
 	if strings.HasPrefix(color, "#") {
@@ -62,24 +69,12 @@ func IsValidColor(color string) bool {
 	return ok
 }
 
-// Status badge styles (for inline use, like in show command)
+// Status badge styles (for inline use, like in show command), set by
+// buildStyles
 var (
-	StatusOpen = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#fff")).
-			Background(ColorSuccess).
-			Padding(0, 1).
-			Bold(true)
-
-	StatusDone = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#fff")).
-			Background(ColorSecondary).
-			Padding(0, 1)
-
-	StatusInProgress = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#fff")).
-				Background(ColorWarning).
-				Padding(0, 1).
-				Bold(true)
+	StatusOpen       lipgloss.Style
+	StatusDone       lipgloss.Style
+	StatusInProgress lipgloss.Style
 )
 
 // Status text styles (for table use, no background/padding)
@@ -90,10 +85,8 @@ var (
 )
 
 // TagBadge is the style for tag badges - black text on gray background.
-var TagBadge = lipgloss.NewStyle().
-	Foreground(lipgloss.Color("#000")).
-	Background(ColorMuted).
-	Padding(0, 1)
+// Set by buildStyles.
+var TagBadge lipgloss.Style
 
 // RenderTag renders a single tag as a badge
 func RenderTag(tag string) string {
@@ -147,15 +140,15 @@ func RenderTagsCompact(tags []string, maxTags int) string {
 	return result
 }
 
-// Text styles
+// Text styles (Muted and Secondary are set by buildStyles)
 var (
 	Bold      = lipgloss.NewStyle().Bold(true)
-	Muted     = lipgloss.NewStyle().Foreground(ColorMuted)
+	Muted     lipgloss.Style
 	Primary   = lipgloss.NewStyle().Foreground(ColorPrimary)
 	Success   = lipgloss.NewStyle().Foreground(ColorSuccess)
 	Warning   = lipgloss.NewStyle().Foreground(ColorWarning)
 	Danger    = lipgloss.NewStyle().Foreground(ColorDanger)
-	Secondary = lipgloss.NewStyle().Foreground(ColorSecondary)
+	Secondary lipgloss.Style
 )
 
 // ID style - distinctive for issue IDs
@@ -163,14 +156,33 @@ var ID = lipgloss.NewStyle().
 	Foreground(ColorPrimary).
 	Bold(true)
 
-// TreeLine style - subtle for tree connectors
-var TreeLine = lipgloss.NewStyle().Foreground(ColorSubtle)
+// TreeLine style - subtle for tree connectors (set by buildStyles)
+var TreeLine lipgloss.Style
 
 // Title style
 var Title = lipgloss.NewStyle().Bold(true)
 
-// Path style - subdued
-var Path = lipgloss.NewStyle().Foreground(ColorMuted)
+// Path style - subdued (set by buildStyles)
+var Path lipgloss.Style
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles sets the shared styles that differ under Accessible.
+func buildStyles() {
+	StatusOpen = Badge(ColorSuccess).Bold(true)
+	StatusDone = Badge(ColorSecondary)
+	StatusInProgress = Badge(ColorWarning).Bold(true)
+	TagBadge = Badge(ColorMuted)
+	if !Accessible {
+		TagBadge = TagBadge.Foreground(lipgloss.Color("#000"))
+	}
+	Muted = lipgloss.NewStyle().Foreground(ColorMuted)
+	Secondary = lipgloss.NewStyle().Foreground(ColorSecondary)
+	TreeLine = lipgloss.NewStyle().Foreground(ColorSubtle)
+	Path = lipgloss.NewStyle().Foreground(ColorMuted)
+}
 
 // Header style for section headers
 var Header = lipgloss.NewStyle().
@@ -206,41 +218,27 @@ func RenderStatusText(status string) string {
 	}
 }
 
-// StatusIcon returns a Unicode icon for the given status.
+// StatusIcon returns a Unicode icon for the given status, or its label
+// under Accessible.
 func StatusIcon(status string) string {
-	switch status {
-	case "draft":
-		return "△" // "⬜︎"∷∴▲
-	case "ready":
-		return "○"
-	case "in-progress":
-		return "◔"
-	case "review":
-		return "◈"
-	case "deferred":
-		return "⏸"
-	case "completed":
-		return "✔"
-	case "scrapped":
-		return "✖"
-	default:
-		return "○"
+	if Accessible {
+		return Label(status)
 	}
+	for _, s := range config.DefaultStatuses {
+		if s.Name == status {
+			return s.Icon
+		}
+	}
+	return "○"
 }
 
 // RenderStatusWithColor returns a styled status badge using the specified color.
 func RenderStatusWithColor(status, color string, isArchiveStatus bool) string {
-	c := ResolveColor(color)
-	style := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#fff")).
-		Background(c).
-		Padding(0, 1)
-
+	style := Badge(ResolveColor(color))
 	if !isArchiveStatus {
 		style = style.Bold(true)
 	}
-
-	return style.Render(StatusIcon(status) + " " + status)
+	return style.Render(statusIconAndName(status))
 }
 
 // RenderStatusIconWithColor returns styled status text (for tables) using the specified color.
@@ -265,7 +263,16 @@ func RenderStatusIconAndLabel(status, color string, isArchiveStatus bool) string
 		style = style.Bold(true)
 	}
 
-	return style.Render(StatusIcon(status) + " " + status)
+	return style.Render(statusIconAndName(status))
+}
+
+// statusIconAndName is the status icon and name, or just the label under
+// Accessible, where the label already names it.
+func statusIconAndName(status string) string {
+	if Accessible {
+		return Label(status)
+	}
+	return StatusIcon(status) + " " + status
 }
 
 // TypeAbbrev returns a two-letter abbreviation for a type name.
@@ -281,10 +288,30 @@ func TypeAbbrev(typeName string) string {
 	return strings.ToUpper(string(r[0])) + strings.ToLower(string(r[1]))
 }
 
+// TypeMark is the unstyled type column text: the configured icon, else the
+// two-letter abbreviation, or the label under Accessible.
+func TypeMark(typeName, icon string) string {
+	switch {
+	case typeName == "":
+		return ""
+	case Accessible:
+		return Label(typeName)
+	case icon != "":
+		return icon
+	}
+	return TypeAbbrev(typeName)
+}
+
 // RenderTypeText returns styled type text using the specified color.
 // If color is empty, uses muted styling.
 func RenderTypeText(typeName, color string) string {
-	abbrev := TypeAbbrev(typeName)
+	return RenderTypeLabel(typeName, "", color)
+}
+
+// RenderTypeLabel is RenderTypeText with a configured icon, which replaces the
+// two-letter abbreviation when set.
+func RenderTypeLabel(typeName, icon, color string) string {
+	abbrev := TypeMark(typeName, icon)
 	if abbrev == "" {
 		return ""
 	}
@@ -297,17 +324,11 @@ func RenderTypeText(typeName, color string) string {
 
 // RenderTypeWithColor returns a styled type badge with colored background.
 func RenderTypeWithColor(typeName, color string) string {
-	abbrev := TypeAbbrev(typeName)
+	abbrev := TypeMark(typeName, "")
 	if abbrev == "" {
 		return ""
 	}
-	c := ResolveColor(color)
-	style := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#fff")).
-		Background(c).
-		Bold(true).
-		Padding(0, 1)
-	return style.Render(abbrev)
+	return Badge(ResolveColor(color)).Bold(true).Render(abbrev)
 }
 
 // RenderPriorityWithColor returns a styled priority badge using the specified color.
@@ -335,9 +356,16 @@ func RenderPriorityText(priority, color string) string {
 	return style.Render(priority)
 }
 
-// GetPrioritySymbol returns the raw symbol for a priority without styling.
+// GetPrioritySymbol returns the raw symbol for a priority without styling,
+// or its label under Accessible.
 // Returns empty string for normal/empty priority.
 func GetPrioritySymbol(priority string) string {
+	switch priority {
+	case config.PriorityCritical, config.PriorityHigh, config.PriorityLow, config.PriorityDeferred:
+		if Accessible {
+			return Label(priority)
+		}
+	}
 	switch priority {
 	case config.PriorityCritical:
 		return "‼"
@@ -368,6 +396,23 @@ func RenderPrioritySymbol(priority, color string) string {
 	return style.Render(symbol)
 }
 
+// PinnedSymbol marks pinned issues, which sort ahead of the rest.
+const PinnedSymbol = "📌"
+
+// InternalSymbol marks internal issues, which stay out of public exports
+// and sync.
+const InternalSymbol = "🔒"
+
+// StaleSymbol marks issues that have gone longer than stale_after without an update.
+const StaleSymbol = "◌"
+
+// BlockedSymbol and BlockingSymbol prefix the active blocker and blocked-issue
+// counts shown before the title (e.g. "⛔2 ⛓3").
+const (
+	BlockedSymbol  = "⛔"
+	BlockingSymbol = "⛓"
+)
+
 // IssueRowConfig holds configuration for rendering an issue row
 type IssueRowConfig struct {
 	StatusColor    string
@@ -390,8 +435,19 @@ type IssueRowConfig struct {
 	LeafCount      int        // Number of leaf descendants (shown as badge when collapsed)
 	LeafColWidth   int        // Width of leaf count column (0 = hidden)
 	MilestoneShort string     // Milestone short name (2-3 chars), glued to the front of the ID as a "<short>:" prefix
+	Stale          bool       // Not updated within stale_after; shows a muted marker before the title
+	Pinned         bool       // Pinned to the top; shows a pin before the priority symbol
+	Internal       bool       // Internal visibility; shows a lock after the pin
+	TypeIcon       string     // Configured type icon, replacing the two-letter abbreviation
+	BlockedCount   int        // Active blockers of this issue (0 = no indicator)
+	BlockingCount  int        // Unresolved issues this one blocks (0 = no indicator)
+	Summary        string     // One-line description, shown muted after the title when it fits
 }
 
+// minSummaryWidth is the fewest cells a summary gets before it is dropped
+// from a row rather than cut down to a stub.
+const minSummaryWidth = 12
+
 // Base column widths for issue lists (minimum sizes)
 const (
 	ColWidthID     = 12
@@ -488,6 +544,10 @@ func RenderIssueRow(id, status, typeName, title string, cfg IssueRowConfig) stri
 	}
 	typeStyle := lipgloss.NewStyle().Width(ColWidthType)
 	statusStyle := lipgloss.NewStyle().Width(ColWidthStatus)
+	if Accessible {
+		// Labels are wider than the glyph columns; let them run on
+		typeStyle, statusStyle = lipgloss.NewStyle(), lipgloss.NewStyle()
+	}
 
 	tagsColWidth := ColWidthTags
 	if cfg.TagsColWidth > 0 {
@@ -544,9 +604,9 @@ func RenderIssueRow(id, status, typeName, title string, cfg IssueRowConfig) stri
 	var typeCol string
 	if typeName != "" {
 		if cfg.Dimmed {
-			typeCol = typeStyle.Render(Muted.Render(TypeAbbrev(typeName)))
+			typeCol = typeStyle.Render(Muted.Render(TypeMark(typeName, cfg.TypeIcon)))
 		} else {
-			typeCol = typeStyle.Render(RenderTypeText(typeName, cfg.TypeColor))
+			typeCol = typeStyle.Render(RenderTypeLabel(typeName, cfg.TypeIcon, cfg.TypeColor))
 		}
 	} else {
 		typeCol = typeStyle.Render("")
@@ -573,6 +633,18 @@ func RenderIssueRow(id, status, typeName, title string, cfg IssueRowConfig) stri
 		}
 	}
 
+	// Pin marker (first, so pinned rows line up)
+	var pinnedSymbol string
+	if !cfg.Dimmed && cfg.Pinned {
+		pinnedSymbol = Glyph(PinnedSymbol) + " "
+	}
+
+	// Lock marker for internal issues
+	var internalSymbol string
+	if !cfg.Dimmed && cfg.Internal {
+		internalSymbol = Glyph(InternalSymbol) + " "
+	}
+
 	// Priority symbol (prepended to title)
 	var prioritySymbol string
 	if !cfg.Dimmed {
@@ -585,18 +657,44 @@ func RenderIssueRow(id, status, typeName, title string, cfg IssueRowConfig) stri
 	// Due date hourglass indicator (after priority symbol, before title)
 	var dueDateSymbol string
 	if !cfg.Dimmed && cfg.DueDate != nil {
-		dueDateSymbol = lipgloss.NewStyle().Foreground(dueDateColor(*cfg.DueDate)).Render("⏳") + " "
+		dueDateSymbol = lipgloss.NewStyle().Foreground(dueDateColor(*cfg.DueDate)).Render(Glyph(DueSymbol)) + " "
+	}
+
+	// Stale marker (muted, so it reads as a hint rather than an alarm)
+	var staleSymbol string
+	if !cfg.Dimmed && cfg.Stale {
+		staleSymbol = Muted.Render(Glyph(StaleSymbol)) + " "
+	}
+
+	// Blocked/blocking counts
+	var linkSymbol string
+	if !cfg.Dimmed {
+		if linkSymbol = RenderBlockIndicators(cfg.BlockedCount, cfg.BlockingCount); linkSymbol != "" {
+			linkSymbol += " "
+		}
 	}
 
 	// Title (truncate if needed, accounting for priority symbol and due date width)
 	displayTitle := title
 	titleColWidth := cfg.MaxTitleWidth // Save original for padding
 	maxWidth := cfg.MaxTitleWidth
+	if maxWidth > 0 && pinnedSymbol != "" {
+		maxWidth -= markWidth(pinnedSymbol, 3) // Account for pin (2 cells wide) + space
+	}
+	if maxWidth > 0 && internalSymbol != "" {
+		maxWidth -= markWidth(internalSymbol, 3) // Account for lock (2 cells wide) + space
+	}
 	if maxWidth > 0 && prioritySymbol != "" {
-		maxWidth -= 2 // Account for symbol + space
+		maxWidth -= markWidth(prioritySymbol, 2) // Account for symbol + space
 	}
 	if maxWidth > 0 && dueDateSymbol != "" {
-		maxWidth -= 3 // Account for hourglass (2 cells wide) + space
+		maxWidth -= markWidth(dueDateSymbol, 3) // Account for hourglass (2 cells wide) + space
+	}
+	if maxWidth > 0 && staleSymbol != "" {
+		maxWidth -= markWidth(staleSymbol, 2) // Account for stale marker + space
+	}
+	if maxWidth > 0 && linkSymbol != "" {
+		maxWidth -= lipgloss.Width(linkSymbol)
 	}
 	if maxWidth > 3 && len(title) > maxWidth {
 		displayTitle = title[:maxWidth-3] + "..."
@@ -604,12 +702,24 @@ func RenderIssueRow(id, status, typeName, title string, cfg IssueRowConfig) stri
 		displayTitle = title[:maxWidth]
 	}
 
+	// Summary (muted, in whatever title space is left over)
+	var summaryStyled string
+	if cfg.Summary != "" && !cfg.Dimmed {
+		room := maxWidth - lipgloss.Width(displayTitle) - 3 // " — "
+		if maxWidth <= 0 || lipgloss.Width(cfg.Summary) <= room {
+			summaryStyled = Muted.Render(" — " + cfg.Summary)
+		} else if room >= minSummaryWidth {
+			summary := lipgloss.NewStyle().MaxWidth(room - 3).Render(cfg.Summary)
+			summaryStyled = Muted.Render(" — " + summary + "...")
+		}
+	}
+
 	// Cursor and title styling
 	var cursor string
 	var titleStyled string
 	if cfg.ShowCursor {
 		if cfg.IsSelected {
-			cursor = lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true).Render("▌")
+			cursor = lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true).Render(Glyph(CursorSymbol))
 			titleStyled = lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary).Render(displayTitle)
 		} else {
 			cursor = " "
@@ -632,19 +742,62 @@ func RenderIssueRow(id, status, typeName, title string, cfg IssueRowConfig) stri
 		// Pad title column to fixed width so tags align in a column
 		// Calculate padding needed: titleColWidth - (priority symbol width + title length)
 		titleLen := len(displayTitle)
+		if pinnedSymbol != "" {
+			titleLen += markWidth(pinnedSymbol, 3) // pin (2 cells wide) + space
+		}
+		if internalSymbol != "" {
+			titleLen += markWidth(internalSymbol, 3) // lock (2 cells wide) + space
+		}
 		if prioritySymbol != "" {
-			titleLen += 2 // symbol + space
+			titleLen += markWidth(prioritySymbol, 2) // symbol + space
 		}
 		if dueDateSymbol != "" {
-			titleLen += 3 // hourglass (2 cells wide) + space
+			titleLen += markWidth(dueDateSymbol, 3) // hourglass (2 cells wide) + space
+		}
+		if staleSymbol != "" {
+			titleLen += markWidth(staleSymbol, 2) // stale marker + space
 		}
+		titleLen += lipgloss.Width(linkSymbol)
+		titleLen += lipgloss.Width(summaryStyled)
 		padding := ""
 		if titleColWidth > titleLen {
 			padding = strings.Repeat(" ", titleColWidth-titleLen)
 		}
-		return cursor + idCol + leafCol + " " + typeCol + " " + statusCol + " " + prioritySymbol + dueDateSymbol + titleStyled + padding + " " + tagsCol
+		return cursor + idCol + leafCol + " " + typeCol + " " + statusCol + " " + pinnedSymbol + internalSymbol + prioritySymbol + dueDateSymbol + staleSymbol + linkSymbol + titleStyled + summaryStyled + padding + " " + tagsCol
+	}
+	return cursor + idCol + leafCol + " " + typeCol + " " + statusCol + " " + pinnedSymbol + internalSymbol + prioritySymbol + dueDateSymbol + staleSymbol + linkSymbol + titleStyled + summaryStyled
+}
+
+// markWidth is the cells a title marker and its space take: the glyph's
+// known width, or the label's under Accessible.
+func markWidth(mark string, cells int) int {
+	if Accessible {
+		return lipgloss.Width(mark)
+	}
+	return cells
+}
+
+// RenderBlockIndicators renders compact blocked/blocking counts such as
+// "⛔2 ⛓3", or "[blocked 2] [blocking 3]" under Accessible. Zero counts are
+// omitted; both zero yields "".
+func RenderBlockIndicators(blocked, blocking int) string {
+	var parts []string
+	if blocked > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ColorDanger).Render(CountMark(BlockedSymbol, blocked)))
+	}
+	if blocking > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(ColorOrange).Render(CountMark(BlockingSymbol, blocking)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// CountMark is symbol followed by n, with n inside the label's brackets
+// under Accessible.
+func CountMark(symbol string, n int) string {
+	if Accessible {
+		return strings.TrimSuffix(Glyph(symbol), "]") + " " + strconv.Itoa(n) + "]"
 	}
-	return cursor + idCol + leafCol + " " + typeCol + " " + statusCol + " " + prioritySymbol + dueDateSymbol + titleStyled
+	return symbol + strconv.Itoa(n)
 }
 
 // dueDateColor returns a color based on how soon the due date is.
@@ -186,6 +186,66 @@ func TestRenderIssueRow_DueDateIndicator(t *testing.T) {
 	})
 }
 
+func TestRenderIssueRow_BlockIndicators(t *testing.T) {
+	cfg := IssueRowConfig{
+		MaxTitleWidth: 40,
+		StatusColor:   "green",
+		TypeColor:     "blue",
+		BlockedCount:  2,
+		BlockingCount: 3,
+	}
+	result := RenderIssueRow("abc123", "todo", "task", "Test Title", cfg)
+	if !strings.Contains(result, BlockedSymbol+"2") || !strings.Contains(result, BlockingSymbol+"3") {
+		t.Errorf("expected blocked and blocking counts, got %q", result)
+	}
+
+	cfg.BlockingCount = 0
+	result = RenderIssueRow("abc123", "todo", "task", "Test Title", cfg)
+	if strings.Contains(result, BlockingSymbol) {
+		t.Error("zero blocking count should not render an indicator")
+	}
+
+	cfg.Dimmed = true
+	result = RenderIssueRow("abc123", "todo", "task", "Test Title", cfg)
+	if strings.Contains(result, BlockedSymbol) {
+		t.Error("expected no block indicators when dimmed")
+	}
+}
+
+func TestRenderIssueRow_Summary(t *testing.T) {
+	cfg := IssueRowConfig{
+		MaxTitleWidth: 40,
+		StatusColor:   "green",
+		TypeColor:     "blue",
+		Summary:       "Short and sweet",
+	}
+	result := RenderIssueRow("abc123", "todo", "task", "Test Title", cfg)
+	if !strings.Contains(result, "Short and sweet") {
+		t.Errorf("expected summary after the title, got %q", result)
+	}
+
+	cfg.Summary = "A much longer summary that cannot possibly fit in the space"
+	result = RenderIssueRow("abc123", "todo", "task", "Test Title", cfg)
+	if !strings.Contains(result, "...") || strings.Contains(result, "the space") {
+		t.Errorf("expected truncated summary, got %q", result)
+	}
+
+	cfg.MaxTitleWidth = 20
+	result = RenderIssueRow("abc123", "todo", "task", "Test Title", cfg)
+	if strings.Contains(result, " — ") {
+		t.Errorf("expected summary dropped when too little room, got %q", result)
+	}
+
+	// Wide characters take two cells each: a 6-cell title leaves 31 cells,
+	// of which the summary gets 28 before the "...".
+	cfg.MaxTitleWidth = 40
+	cfg.Summary = strings.Repeat("日本", 10)
+	result = RenderIssueRow("abc123", "todo", "task", "日本語", cfg)
+	if !strings.Contains(result, " — "+strings.Repeat("日本", 7)+"...") || strings.Contains(result, strings.Repeat("日本", 8)) {
+		t.Errorf("expected the wide summary cut to 28 cells, got %q", result)
+	}
+}
+
 func TestIsValidColor(t *testing.T) {
 	tests := []struct {
 		name  string
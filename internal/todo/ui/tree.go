@@ -102,22 +102,39 @@ func BuildTree(matchedIssues, allIssues []*issue.Issue, sortFn func([]*issue.Iss
 		sortFn(children[parentID])
 	}
 
-	// Find root issues (no parent or parent not in needed set)
+	// Find root issues (no parent or parent not in needed set). An issue in
+	// a parent cycle has no such root above it, so the cycle member with the
+	// smallest ID stands in as one.
 	var roots []*issue.Issue
 	for _, b := range neededIssues {
 		if b.Parent == "" {
 			roots = append(roots, b)
-		} else {
-			// Check if parent is in the tree
-			if _, ok := neededIssues[b.Parent]; !ok {
-				roots = append(roots, b)
-			}
+		} else if _, ok := neededIssues[b.Parent]; !ok {
+			roots = append(roots, b)
+		} else if cycleRoot(b, neededIssues) {
+			roots = append(roots, b)
 		}
 	}
 	sortFn(roots)
 
 	// Build tree nodes recursively
-	return buildNodes(roots, children, matchedSet)
+	return buildNodes(roots, children, matchedSet, make(map[string]bool))
+}
+
+// cycleRoot reports whether b is in a parent cycle within needed and has the
+// smallest ID in it.
+func cycleRoot(b *issue.Issue, needed map[string]*issue.Issue) bool {
+	seen := map[string]bool{b.ID: true}
+	for cur := needed[b.Parent]; cur != nil; cur = needed[cur.Parent] {
+		if cur.ID == b.ID {
+			return true
+		}
+		if seen[cur.ID] || cur.ID < b.ID {
+			return false // a cycle above b, or one with a smaller member
+		}
+		seen[cur.ID] = true
+	}
+	return false
 }
 
 // addAncestors recursively adds all ancestors of an issue to the needed set.
@@ -136,15 +153,20 @@ func addAncestors(b *issue.Issue, issueByID, needed map[string]*issue.Issue) {
 	addAncestors(parent, issueByID, needed)
 }
 
-// buildNodes recursively builds TreeNodes from issues.
-func buildNodes(issues []*issue.Issue, children map[string][]*issue.Issue, matchedSet map[string]bool) []*TreeNode {
-	nodes := make([]*TreeNode, len(issues))
-	for i, b := range issues {
-		nodes[i] = &TreeNode{
+// buildNodes recursively builds TreeNodes from issues. placed records the
+// issues already in the tree, so a parent cycle is not followed around.
+func buildNodes(issues []*issue.Issue, children map[string][]*issue.Issue, matchedSet, placed map[string]bool) []*TreeNode {
+	nodes := make([]*TreeNode, 0, len(issues))
+	for _, b := range issues {
+		if placed[b.ID] {
+			continue
+		}
+		placed[b.ID] = true
+		nodes = append(nodes, &TreeNode{
 			Issue:    b,
 			Matched:  matchedSet[b.ID],
-			Children: buildNodes(children[b.ID], children, matchedSet),
-		}
+			Children: buildNodes(children[b.ID], children, matchedSet, placed),
+		})
 	}
 	return nodes
 }
@@ -209,7 +231,14 @@ func RenderTree(nodes []*TreeNode, cfg *config.Config, maxIDWidth int, hasTags b
 	statusHeader := headerCol.Render("ST") + strings.Repeat(" ", max(0, ColWidthStatus-2))
 
 	header := idHeader + typeHeader + statusHeader + headerCol.Render("TITLE")
-	if cols.ShowTags && titleWidth > 5 {
+	if Accessible {
+		// Labels have no fixed width, so the columns are named in full rather
+		// than aligned.
+		header = idHeader + headerCol.Render("TYPE STATUS TITLE")
+		if cols.ShowTags {
+			header += headerCol.Render(" TAGS")
+		}
+	} else if cols.ShowTags && titleWidth > 5 {
 		header += strings.Repeat(" ", max(0, titleWidth-5+3)) + headerCol.Render("TAGS") // +3 for priority/spacing
 	}
 	dividerWidth := max(1, termWidth-1) // -1 to avoid wrapping on exact terminal width
@@ -291,6 +320,7 @@ func renderNode(sb *strings.Builder, node *TreeNode, depth int, isLast bool, anc
 	row := RenderIssueRow(b.ID, b.Status, b.Type, b.Title, IssueRowConfig{
 		StatusColor:   colors.StatusColor,
 		TypeColor:     colors.TypeColor,
+		TypeIcon:      colors.TypeIcon,
 		PriorityColor: colors.PriorityColor,
 		Priority:      b.Priority,
 		IsArchive:     colors.IsArchive,
@@ -304,6 +334,9 @@ func renderNode(sb *strings.Builder, node *TreeNode, depth int, isLast bool, anc
 		Dimmed:        !node.Matched,
 		IDColWidth:    renderCfg.treeColWidth,
 		DueDate:       dueTime,
+		Pinned:        b.Pinned,
+		Internal:      b.Visibility == config.VisibilityInternal,
+		Summary:       b.Synopsis(0),
 	})
 
 	sb.WriteString(row)
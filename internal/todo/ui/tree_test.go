@@ -160,6 +160,24 @@ func TestBuildTree(t *testing.T) {
 			t.Errorf("expected broken issue as root, got %s", tree[0].Issue.ID)
 		}
 	})
+
+	t.Run("parent cycle", func(t *testing.T) {
+		// Hand-edited files can leave c1 → c2 → c3 → c1.
+		c1 := &issue.Issue{ID: "c1", Title: "Cycle 1", Parent: "c3"}
+		c2 := &issue.Issue{ID: "c2", Title: "Cycle 2", Parent: "c1"}
+		c3 := &issue.Issue{ID: "c3", Title: "Cycle 3", Parent: "c2"}
+		cyclic := []*issue.Issue{c2, c3, c1}
+
+		tree := BuildTree([]*issue.Issue{c3}, cyclic, noSort)
+
+		// The smallest ID becomes the root and each member appears once.
+		if len(tree) != 1 || tree[0].Issue.ID != "c1" {
+			t.Fatalf("expected c1 as the only root, got %v", tree)
+		}
+		if got := len(FlattenTree(tree)); got != 3 {
+			t.Errorf("expected 3 nodes, got %d", got)
+		}
+	})
 }
 
 func TestLeafCounts(t *testing.T) {